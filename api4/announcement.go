@@ -0,0 +1,180 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitAnnouncement() {
+	api.BaseRoutes.Announcements.Handle("", api.ApiSessionRequired(createAnnouncement)).Methods("POST")
+	api.BaseRoutes.Announcements.Handle("", api.ApiSessionRequired(getAnnouncements)).Methods("GET")
+	api.BaseRoutes.Announcement.Handle("", api.ApiSessionRequired(getAnnouncement)).Methods("GET")
+	api.BaseRoutes.Announcement.Handle("", api.ApiSessionRequired(deleteAnnouncement)).Methods("DELETE")
+	api.BaseRoutes.Announcement.Handle("/report", api.ApiSessionRequired(getAnnouncementReport)).Methods("GET")
+	api.BaseRoutes.Announcement.Handle("/recipients", api.ApiSessionRequired(getAnnouncementRecipients)).Methods("GET")
+	api.BaseRoutes.Announcement.Handle("/acknowledge", api.ApiSessionRequired(acknowledgeAnnouncement)).Methods("POST")
+
+	api.BaseRoutes.User.Handle("/announcements/pending", api.ApiSessionRequired(getPendingAnnouncementsForUser)).Methods("GET")
+}
+
+func createAnnouncement(c *Context, w http.ResponseWriter, r *http.Request) {
+	announcement := model.AnnouncementFromJson(r.Body)
+	if announcement == nil {
+		c.SetInvalidParam("announcement")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	announcement.CreatorId = c.App.Session.UserId
+
+	announcement, err := c.App.CreateAnnouncement(announcement)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - announcement_id=" + announcement.Id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(announcement.ToJson()))
+}
+
+func getAnnouncements(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	announcements, err := c.App.GetAnnouncements(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.AnnouncementListToJson(announcements)))
+}
+
+func getAnnouncement(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireAnnouncementId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	announcement, err := c.App.GetAnnouncement(c.Params.AnnouncementId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(announcement.ToJson()))
+}
+
+func deleteAnnouncement(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireAnnouncementId()
+	if c.Err != nil {
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.DeleteAnnouncement(c.Params.AnnouncementId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - announcement_id=" + c.Params.AnnouncementId)
+	ReturnStatusOK(w)
+}
+
+func getAnnouncementReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireAnnouncementId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	report, err := c.App.GetAnnouncementReport(c.Params.AnnouncementId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(report.ToJson()))
+}
+
+func getAnnouncementRecipients(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireAnnouncementId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	recipients, err := c.App.GetAnnouncementRecipients(c.Params.AnnouncementId, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.AnnouncementRecipientListToJson(recipients)))
+}
+
+func acknowledgeAnnouncement(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireAnnouncementId()
+	if c.Err != nil {
+		return
+	}
+
+	if err := c.App.AcknowledgeAnnouncement(c.Params.AnnouncementId, c.App.Session.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func getPendingAnnouncementsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	announcements, err := c.App.GetPendingAnnouncementsForUser(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.AnnouncementListToJson(announcements)))
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestAnnouncements(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	announcement := &model.Announcement{
+		Title:                 "Maintenance",
+		Message:               "The server will be down tonight.",
+		ShowBanner:            true,
+		RequireAcknowledgment: true,
+		TeamIds:               model.StringArray{th.BasicTeam.Id},
+	}
+
+	_, resp := Client.CreateAnnouncement(announcement)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.CreateAnnouncement(&model.Announcement{Title: "Title", Message: "Message"})
+	CheckBadRequestStatus(t, resp)
+
+	announcement, resp = th.SystemAdminClient.CreateAnnouncement(announcement)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.SystemAdminUser.Id, announcement.CreatorId)
+
+	t.Run("get", func(t *testing.T) {
+		_, resp := Client.GetAnnouncement(announcement.Id)
+		CheckForbiddenStatus(t, resp)
+
+		got, resp := th.SystemAdminClient.GetAnnouncement(announcement.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, announcement.Title, got.Title)
+
+		_, resp = th.SystemAdminClient.GetAnnouncement(model.NewId())
+		CheckNotFoundStatus(t, resp)
+
+		_, resp = Client.GetAnnouncements(0, 10)
+		CheckForbiddenStatus(t, resp)
+
+		announcements, resp := th.SystemAdminClient.GetAnnouncements(0, 10)
+		CheckNoError(t, resp)
+		require.NotEmpty(t, announcements)
+		assert.Equal(t, announcement.Id, announcements[0].Id)
+	})
+
+	t.Run("pending and acknowledge", func(t *testing.T) {
+		pending, resp := Client.GetPendingAnnouncementsForUser(th.BasicUser.Id)
+		CheckNoError(t, resp)
+		require.Len(t, pending, 1)
+		assert.Equal(t, announcement.Id, pending[0].Id)
+
+		_, resp = Client.GetPendingAnnouncementsForUser(th.BasicUser2.Id)
+		CheckForbiddenStatus(t, resp)
+
+		ok, resp := Client.AcknowledgeAnnouncement(announcement.Id)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		pending, resp = Client.GetPendingAnnouncementsForUser(th.BasicUser.Id)
+		CheckNoError(t, resp)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("report and recipients", func(t *testing.T) {
+		_, resp := Client.GetAnnouncementReport(announcement.Id)
+		CheckForbiddenStatus(t, resp)
+
+		report, resp := th.SystemAdminClient.GetAnnouncementReport(announcement.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, announcement.Id, report.AnnouncementId)
+		assert.Equal(t, report.Total, report.Delivered)
+		assert.Equal(t, int64(1), report.Acknowledged)
+
+		_, resp = Client.GetAnnouncementRecipients(announcement.Id, 0, 100)
+		CheckForbiddenStatus(t, resp)
+
+		recipients, resp := th.SystemAdminClient.GetAnnouncementRecipients(announcement.Id, 0, 100)
+		CheckNoError(t, resp)
+		assert.Len(t, recipients, int(report.Total))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, resp := Client.DeleteAnnouncement(announcement.Id)
+		CheckForbiddenStatus(t, resp)
+
+		ok, resp := th.SystemAdminClient.DeleteAnnouncement(announcement.Id)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		pending, resp := th.SystemAdminClient.GetPendingAnnouncementsForUser(th.BasicUser2.Id)
+		CheckNoError(t, resp)
+		assert.Empty(t, pending)
+	})
+}
@@ -59,6 +59,7 @@ type Routes struct {
 	Plugin  *mux.Router // 'api/v4/plugins/{plugin_id:[A-Za-z0-9_-]+}'
 
 	PublicFile *mux.Router // 'files/{file_id:[A-Za-z0-9]+}/public'
+	PublicPost *mux.Router // 'posts/{link_id:[A-Za-z0-9]+}/public'
 
 	Commands *mux.Router // 'api/v4/commands'
 	Command  *mux.Router // 'api/v4/commands/{command_id:[A-Za-z0-9]+}'
@@ -108,10 +109,55 @@ type Routes struct {
 	Emoji       *mux.Router // 'api/v4/emoji/{emoji_id:[A-Za-z0-9]+}'
 	EmojiByName *mux.Router // 'api/v4/emoji/name/{emoji_name:[A-Za-z0-9_-\.]+}'
 
+	PendingEmojis *mux.Router // 'api/v4/emoji/pending'
+	PendingEmoji  *mux.Router // 'api/v4/emoji/pending/{pending_emoji_id:[A-Za-z0-9]+}'
+	EmojiAliases  *mux.Router // 'api/v4/emoji/aliases'
+	EmojiAlias    *mux.Router // 'api/v4/emoji/aliases/{emoji_alias:[A-Za-z0-9_-]+}'
+
 	ReactionByNameForPostForUser *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/posts/{post_id:[A-Za-z0-9]+}/reactions/{emoji_name:[A-Za-z0-9_-+]+}'
 
 	TermsOfService *mux.Router // 'api/v4/terms_of_service
 	Groups         *mux.Router // 'api/v4/groups'
+
+	Integrations *mux.Router // 'api/v4/integrations'
+
+	MentionAliases *mux.Router // 'api/v4/mention_aliases'
+	MentionAlias   *mux.Router // 'api/v4/mention_aliases/{alias_id:[A-Za-z0-9]+}'
+
+	UserAttributeFields *mux.Router // 'api/v4/user_attribute_fields'
+	UserAttributeField  *mux.Router // 'api/v4/user_attribute_fields/{field_id:[A-Za-z0-9]+}'
+
+	PendingPosts *mux.Router // 'api/v4/pending_posts'
+	PendingPost  *mux.Router // 'api/v4/pending_posts/{pending_post_id:[A-Za-z0-9]+}'
+
+	ContentFilters *mux.Router // 'api/v4/content_filters'
+	ContentFilter  *mux.Router // 'api/v4/content_filters/{content_filter_id:[A-Za-z0-9]+}'
+
+	KeywordRules *mux.Router // 'api/v4/keyword_rules'
+	KeywordRule  *mux.Router // 'api/v4/keyword_rules/{keyword_rule_id:[A-Za-z0-9]+}'
+
+	PostReports *mux.Router // 'api/v4/reports'
+	PostReport  *mux.Router // 'api/v4/reports/{report_id:[A-Za-z0-9]+}'
+
+	RecurringPosts *mux.Router // 'api/v4/recurring_posts'
+	RecurringPost  *mux.Router // 'api/v4/recurring_posts/{recurring_post_id:[A-Za-z0-9]+}'
+
+	UserAvailability *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/availability/{availability_id:[A-Za-z0-9]+}'
+
+	Calls *mux.Router // 'api/v4/calls'
+	Call  *mux.Router // 'api/v4/calls/{call_id:[A-Za-z0-9]+}'
+
+	ExportConsumers *mux.Router // 'api/v4/compliance/export/consumers'
+	ExportConsumer  *mux.Router // 'api/v4/compliance/export/consumers/{consumer_id:[A-Za-z0-9]+}'
+
+	PostPurges *mux.Router // 'api/v4/post_purges'
+	PostPurge  *mux.Router // 'api/v4/post_purges/{purge_id:[A-Za-z0-9]+}'
+
+	Announcements *mux.Router // 'api/v4/announcements'
+	Announcement  *mux.Router // 'api/v4/announcements/{announcement_id:[A-Za-z0-9]+}'
+
+	OnboardingSteps *mux.Router // 'api/v4/onboarding/steps'
+	OnboardingStep  *mux.Router // 'api/v4/onboarding/steps/{onboarding_step_id:[A-Za-z0-9]+}'
 }
 
 type API struct {
@@ -166,6 +212,7 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 	api.BaseRoutes.Files = api.BaseRoutes.ApiRoot.PathPrefix("/files").Subrouter()
 	api.BaseRoutes.File = api.BaseRoutes.Files.PathPrefix("/{file_id:[A-Za-z0-9]+}").Subrouter()
 	api.BaseRoutes.PublicFile = api.BaseRoutes.Root.PathPrefix("/files/{file_id:[A-Za-z0-9]+}/public").Subrouter()
+	api.BaseRoutes.PublicPost = api.BaseRoutes.Root.PathPrefix("/posts/{link_id:[A-Za-z0-9]+}/public").Subrouter()
 
 	api.BaseRoutes.Plugins = api.BaseRoutes.ApiRoot.PathPrefix("/plugins").Subrouter()
 	api.BaseRoutes.Plugin = api.BaseRoutes.Plugins.PathPrefix("/{plugin_id:[A-Za-z0-9\\_\\-\\.]+}").Subrouter()
@@ -201,6 +248,10 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 	api.BaseRoutes.Emojis = api.BaseRoutes.ApiRoot.PathPrefix("/emoji").Subrouter()
 	api.BaseRoutes.Emoji = api.BaseRoutes.ApiRoot.PathPrefix("/emoji/{emoji_id:[A-Za-z0-9]+}").Subrouter()
 	api.BaseRoutes.EmojiByName = api.BaseRoutes.Emojis.PathPrefix("/name/{emoji_name:[A-Za-z0-9\\_\\-\\+]+}").Subrouter()
+	api.BaseRoutes.PendingEmojis = api.BaseRoutes.Emojis.PathPrefix("/pending").Subrouter()
+	api.BaseRoutes.PendingEmoji = api.BaseRoutes.PendingEmojis.PathPrefix("/{pending_emoji_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.EmojiAliases = api.BaseRoutes.Emojis.PathPrefix("/aliases").Subrouter()
+	api.BaseRoutes.EmojiAlias = api.BaseRoutes.EmojiAliases.PathPrefix("/{emoji_alias:[A-Za-z0-9\\_\\-]+}").Subrouter()
 
 	api.BaseRoutes.ReactionByNameForPostForUser = api.BaseRoutes.PostForUser.PathPrefix("/reactions/{emoji_name:[A-Za-z0-9\\_\\-\\+]+}").Subrouter()
 
@@ -214,6 +265,46 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 	api.BaseRoutes.TermsOfService = api.BaseRoutes.ApiRoot.PathPrefix("/terms_of_service").Subrouter()
 	api.BaseRoutes.Groups = api.BaseRoutes.ApiRoot.PathPrefix("/groups").Subrouter()
 
+	api.BaseRoutes.Integrations = api.BaseRoutes.ApiRoot.PathPrefix("/integrations").Subrouter()
+
+	api.BaseRoutes.MentionAliases = api.BaseRoutes.ApiRoot.PathPrefix("/mention_aliases").Subrouter()
+	api.BaseRoutes.MentionAlias = api.BaseRoutes.MentionAliases.PathPrefix("/{alias_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.UserAttributeFields = api.BaseRoutes.ApiRoot.PathPrefix("/user_attribute_fields").Subrouter()
+	api.BaseRoutes.UserAttributeField = api.BaseRoutes.UserAttributeFields.PathPrefix("/{field_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.PendingPosts = api.BaseRoutes.ApiRoot.PathPrefix("/pending_posts").Subrouter()
+	api.BaseRoutes.PendingPost = api.BaseRoutes.PendingPosts.PathPrefix("/{pending_post_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.ContentFilters = api.BaseRoutes.ApiRoot.PathPrefix("/content_filters").Subrouter()
+	api.BaseRoutes.ContentFilter = api.BaseRoutes.ContentFilters.PathPrefix("/{content_filter_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.KeywordRules = api.BaseRoutes.ApiRoot.PathPrefix("/keyword_rules").Subrouter()
+	api.BaseRoutes.KeywordRule = api.BaseRoutes.KeywordRules.PathPrefix("/{keyword_rule_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.PostReports = api.BaseRoutes.ApiRoot.PathPrefix("/reports").Subrouter()
+	api.BaseRoutes.PostReport = api.BaseRoutes.PostReports.PathPrefix("/{report_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.RecurringPosts = api.BaseRoutes.ApiRoot.PathPrefix("/recurring_posts").Subrouter()
+	api.BaseRoutes.RecurringPost = api.BaseRoutes.RecurringPosts.PathPrefix("/{recurring_post_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.UserAvailability = api.BaseRoutes.User.PathPrefix("/availability/{availability_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.Calls = api.BaseRoutes.ApiRoot.PathPrefix("/calls").Subrouter()
+	api.BaseRoutes.Call = api.BaseRoutes.Calls.PathPrefix("/{call_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.ExportConsumers = api.BaseRoutes.Compliance.PathPrefix("/export/consumers").Subrouter()
+	api.BaseRoutes.ExportConsumer = api.BaseRoutes.ExportConsumers.PathPrefix("/{consumer_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.PostPurges = api.BaseRoutes.ApiRoot.PathPrefix("/post_purges").Subrouter()
+	api.BaseRoutes.PostPurge = api.BaseRoutes.PostPurges.PathPrefix("/{purge_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.Announcements = api.BaseRoutes.ApiRoot.PathPrefix("/announcements").Subrouter()
+	api.BaseRoutes.Announcement = api.BaseRoutes.Announcements.PathPrefix("/{announcement_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.OnboardingSteps = api.BaseRoutes.ApiRoot.PathPrefix("/onboarding/steps").Subrouter()
+	api.BaseRoutes.OnboardingStep = api.BaseRoutes.OnboardingSteps.PathPrefix("/{onboarding_step_id:[A-Za-z0-9]+}").Subrouter()
+
 	api.InitUser()
 	api.InitBot()
 	api.InitTeam()
@@ -247,6 +338,45 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 	api.InitTermsOfService()
 	api.InitGroup()
 	api.InitAction()
+	api.InitIntegrations()
+	api.InitHashtag()
+	api.InitMentionAlias()
+	api.InitMentionResolution()
+	api.InitPostStar()
+	api.InitPendingPost()
+	api.InitContentFilter()
+	api.InitKeywordRule()
+	api.InitPostReport()
+	api.InitRecurringPost()
+	api.InitUserAvailability()
+	api.InitCall()
+	api.InitMessageExportStream()
+	api.InitPostPurge()
+	api.InitPublicPostLink()
+	api.InitUserAttribute()
+	api.InitAnnouncement()
+	api.InitOnboarding()
+
+	root.Handle("/api/v4/{anything:.*}", http.HandlerFunc(api.Handle404))
+
+	return api
+}
+
+// InitWebSocketGateway registers only the routes needed by a websocket gateway node: the websocket endpoint itself
+// and a ping endpoint for load balancer health checks. All other API requests are expected to go to the API nodes.
+func InitWebSocketGateway(configservice configservice.ConfigService, globalOptionsFunc app.AppOptionCreator, root *mux.Router) *API {
+	api := &API{
+		ConfigService:       configservice,
+		GetGlobalAppOptions: globalOptionsFunc,
+		BaseRoutes:          &Routes{},
+	}
+
+	api.BaseRoutes.Root = root
+	api.BaseRoutes.ApiRoot = root.PathPrefix(model.API_URL_SUFFIX).Subrouter()
+	api.BaseRoutes.System = api.BaseRoutes.ApiRoot.PathPrefix("/system").Subrouter()
+
+	api.BaseRoutes.System.Handle("/ping", api.ApiHandler(getSystemPing)).Methods("GET")
+	api.InitWebSocket()
 
 	root.Handle("/api/v4/{anything:.*}", http.HandlerFunc(api.Handle404))
 
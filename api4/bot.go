@@ -21,6 +21,8 @@ func (api *API) InitBot() {
 	api.BaseRoutes.Bot.Handle("/disable", api.ApiSessionRequired(disableBot)).Methods("POST")
 	api.BaseRoutes.Bot.Handle("/enable", api.ApiSessionRequired(enableBot)).Methods("POST")
 	api.BaseRoutes.Bot.Handle("/assign/{user_id:[A-Za-z0-9]+}", api.ApiSessionRequired(assignBot)).Methods("POST")
+	api.BaseRoutes.Bot.Handle("/broadcasts", api.ApiSessionRequired(broadcastDirectMessage)).Methods("POST")
+	api.BaseRoutes.Bot.Handle("/broadcasts/{broadcast_id:[A-Za-z0-9]+}", api.ApiSessionRequired(getDirectMessageBroadcast)).Methods("GET")
 
 	api.BaseRoutes.Bot.Handle("/icon", api.ApiSessionRequiredTrustRequester(getBotIconImage)).Methods("GET")
 	api.BaseRoutes.Bot.Handle("/icon", api.ApiSessionRequired(setBotIconImage)).Methods("POST")
@@ -226,6 +228,76 @@ func assignBot(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write(bot.ToJson())
 }
 
+// sessionHasPermissionToBroadcast checks that the session is the bot's own, or that of a user who manages it.
+func sessionHasPermissionToBroadcast(c *Context, botUserId string) bool {
+	if c.App.Session.UserId == botUserId {
+		return true
+	}
+
+	if err := c.App.SessionHasPermissionToManageBot(c.App.Session, botUserId); err != nil {
+		c.Err = err
+		return false
+	}
+
+	return true
+}
+
+func broadcastDirectMessage(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireBotUserId()
+	if c.Err != nil {
+		return
+	}
+
+	request := model.DirectMessageBroadcastRequestFromJson(r.Body)
+	if request == nil {
+		c.SetInvalidParam("broadcast")
+		return
+	}
+
+	if !sessionHasPermissionToBroadcast(c, c.Params.BotUserId) {
+		return
+	}
+
+	// Messaging every member of a team is left to those who manage it
+	if request.TeamId != "" && !c.App.SessionHasPermissionToTeam(c.App.Session, request.TeamId, model.PERMISSION_MANAGE_TEAM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_TEAM)
+		return
+	}
+
+	broadcast, err := c.App.BroadcastDirectMessage(c.Params.BotUserId, request.UserIds, request.TeamId, request.Post)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	w.Write([]byte(broadcast.ToJson()))
+}
+
+func getDirectMessageBroadcast(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireBotUserId().RequireBroadcastId()
+	if c.Err != nil {
+		return
+	}
+
+	if !sessionHasPermissionToBroadcast(c, c.Params.BotUserId) {
+		return
+	}
+
+	broadcast, err := c.App.GetDirectMessageBroadcast(c.Params.BroadcastId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if broadcast.BotUserId != c.Params.BotUserId {
+		c.Err = model.NewAppError("getDirectMessageBroadcast", "app.direct_message_broadcast.get.not_found.app_error", nil, "id="+c.Params.BroadcastId, http.StatusNotFound)
+		return
+	}
+
+	w.Write([]byte(broadcast.ToJson()))
+}
+
 func getBotIconImage(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireBotUserId()
 	if c.Err != nil {
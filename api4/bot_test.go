@@ -1295,3 +1295,69 @@ func TestDeleteBotIconImage(t *testing.T) {
 func sToP(s string) *string {
 	return &s
 }
+
+func TestBroadcastDirectMessage(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	defer th.RestoreDefaultRolePermissions(th.SaveDefaultRolePermissions())
+	th.AddPermissionToRole(model.PERMISSION_CREATE_BOT.Id, model.SYSTEM_USER_ROLE_ID)
+	th.AddPermissionToRole(model.PERMISSION_MANAGE_BOTS.Id, model.SYSTEM_USER_ROLE_ID)
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableBotAccountCreation = true
+	})
+
+	bot, resp := th.Client.CreateBot(&model.Bot{
+		Username:    GenerateTestUsername(),
+		Description: "bot",
+	})
+	CheckCreatedStatus(t, resp)
+	defer th.App.PermanentDeleteBot(bot.UserId)
+
+	request := &model.DirectMessageBroadcastRequest{
+		UserIds: []string{th.BasicUser2.Id},
+		Post:    &model.Post{Message: "announcement"},
+	}
+
+	t.Run("owner broadcasts to users", func(t *testing.T) {
+		broadcast, resp := th.Client.BroadcastDirectMessage(bot.UserId, request)
+		CheckNoError(t, resp)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		require.Equal(t, bot.UserId, broadcast.BotUserId)
+		require.Equal(t, 1, broadcast.Total)
+
+		fetched, resp := th.Client.GetDirectMessageBroadcast(bot.UserId, broadcast.Id)
+		CheckOKStatus(t, resp)
+		require.Equal(t, broadcast.Id, fetched.Id)
+
+		_, resp = th.Client.GetDirectMessageBroadcast(bot.UserId, model.NewId())
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, resp := th.Client.BroadcastDirectMessage(bot.UserId, &model.DirectMessageBroadcastRequest{Post: &model.Post{Message: "announcement"}})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("team broadcasts need to manage the team", func(t *testing.T) {
+		_, resp := th.Client.BroadcastDirectMessage(bot.UserId, &model.DirectMessageBroadcastRequest{
+			TeamId: th.BasicTeam.Id,
+			Post:   &model.Post{Message: "announcement"},
+		})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.BroadcastDirectMessage(bot.UserId, &model.DirectMessageBroadcastRequest{
+			TeamId: th.BasicTeam.Id,
+			Post:   &model.Post{Message: "announcement"},
+		})
+		CheckNoError(t, resp)
+	})
+
+	t.Run("other users can't broadcast", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := th.Client.BroadcastDirectMessage(bot.UserId, request)
+		CheckNotFoundStatus(t, resp)
+	})
+}
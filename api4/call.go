@@ -0,0 +1,146 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitCall() {
+	api.BaseRoutes.Calls.Handle("", api.ApiSessionRequired(startCall)).Methods("POST")
+	api.BaseRoutes.Channel.Handle("/calls", api.ApiSessionRequired(getActiveCallsForChannel)).Methods("GET")
+	api.BaseRoutes.Call.Handle("", api.ApiSessionRequired(getCall)).Methods("GET")
+	api.BaseRoutes.Call.Handle("/join", api.ApiSessionRequired(joinCall)).Methods("POST")
+	api.BaseRoutes.Call.Handle("/leave", api.ApiSessionRequired(leaveCall)).Methods("POST")
+	api.BaseRoutes.Call.Handle("/end", api.ApiSessionRequired(endCall)).Methods("POST")
+}
+
+func startCall(c *Context, w http.ResponseWriter, r *http.Request) {
+	call := model.CallFromJson(r.Body)
+	if call == nil {
+		c.SetInvalidParam("call")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, call.ChannelId, model.PERMISSION_CREATE_POST) {
+		c.SetPermissionError(model.PERMISSION_CREATE_POST)
+		return
+	}
+
+	call.Id = ""
+	call.CreatorId = c.App.Session.UserId
+
+	call, err := c.App.StartCall(call)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(call.ToJson()))
+}
+
+func getActiveCallsForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	calls, err := c.App.GetActiveCallsForChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.CallListToJson(calls)))
+}
+
+func getCall(c *Context, w http.ResponseWriter, r *http.Request) {
+	call := getCallWithPermission(c, model.PERMISSION_READ_CHANNEL)
+	if c.Err != nil {
+		return
+	}
+
+	w.Write([]byte(call.ToJson()))
+}
+
+func joinCall(c *Context, w http.ResponseWriter, r *http.Request) {
+	call := getCallWithPermission(c, model.PERMISSION_READ_CHANNEL)
+	if c.Err != nil {
+		return
+	}
+
+	call, err := c.App.JoinCall(call, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(call.ToJson()))
+}
+
+func leaveCall(c *Context, w http.ResponseWriter, r *http.Request) {
+	call := getCallWithPermission(c, model.PERMISSION_READ_CHANNEL)
+	if c.Err != nil {
+		return
+	}
+
+	call, err := c.App.LeaveCall(call, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(call.ToJson()))
+}
+
+func endCall(c *Context, w http.ResponseWriter, r *http.Request) {
+	call := getCallWithPermission(c, model.PERMISSION_READ_CHANNEL)
+	if c.Err != nil {
+		return
+	}
+
+	// Calls are ended by whoever started them, or by an admin of their channel
+	if call.CreatorId != c.App.Session.UserId && !c.App.SessionHasPermissionToChannel(c.App.Session, call.ChannelId, model.PERMISSION_MANAGE_CHANNEL_ROLES) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return
+	}
+
+	call, err := c.App.EndCall(call)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(call.ToJson()))
+}
+
+// getCallWithPermission returns the call of the request if the session has the given permission in its channel, or
+// sets the error of the context otherwise.
+func getCallWithPermission(c *Context, permission *model.Permission) *model.Call {
+	c.RequireCallId()
+	if c.Err != nil {
+		return nil
+	}
+
+	call, err := c.App.GetCall(c.Params.CallId)
+	if err != nil {
+		c.Err = err
+		return nil
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, call.ChannelId, permission) {
+		c.SetPermissionError(permission)
+		return nil
+	}
+
+	return call
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCalls(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	call, resp := th.Client.StartCall(&model.Call{ChannelId: th.BasicChannel.Id, Title: "Retro", JoinUrl: "https://meet.example.com/retro"})
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.BasicUser.Id, call.CreatorId)
+	assert.Equal(t, model.CALL_PROVIDER_MANUAL, call.Provider)
+
+	privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.CHANNEL_PRIVATE)
+	_, resp = th.Client.StartCall(&model.Call{ChannelId: privateChannel.Id})
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.StartCall(&model.Call{ChannelId: th.BasicChannel.Id, JoinUrl: "not a url"})
+	CheckBadRequestStatus(t, resp)
+
+	calls, resp := th.Client.GetActiveCallsForChannel(th.BasicChannel.Id)
+	CheckNoError(t, resp)
+	require.Len(t, calls, 1)
+
+	th.LoginBasic2()
+
+	call, resp = th.Client.JoinCall(call.Id)
+	CheckNoError(t, resp)
+	assert.ElementsMatch(t, []string{th.BasicUser.Id, th.BasicUser2.Id}, call.ParticipantIds())
+
+	call, resp = th.Client.LeaveCall(call.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, []string{th.BasicUser.Id}, call.ParticipantIds())
+
+	_, resp = th.Client.EndCall(call.Id)
+	CheckForbiddenStatus(t, resp)
+
+	th.LoginBasic()
+
+	call, resp = th.Client.EndCall(call.Id)
+	CheckNoError(t, resp)
+	assert.True(t, call.IsEnded())
+
+	got, resp := th.Client.GetCall(call.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, call.EndAt, got.EndAt)
+
+	_, resp = th.Client.GetCall(model.NewId())
+	CheckNotFoundStatus(t, resp)
+}
@@ -6,6 +6,8 @@ package api4
 import (
 	"encoding/json"
 	"net/http"
+	"path"
+	"strconv"
 	"strings"
 
 	"github.com/mattermost/mattermost-server/mlog"
@@ -40,8 +42,13 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.Channel.Handle("", api.ApiSessionRequired(deleteChannel)).Methods("DELETE")
 	api.BaseRoutes.Channel.Handle("/stats", api.ApiSessionRequired(getChannelStats)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("/pinned", api.ApiSessionRequired(getPinnedPosts)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/integrations", api.ApiSessionRequired(getChannelIntegrations)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/timeline_exports", api.ApiSessionRequired(createChannelTimelineExport)).Methods("POST")
+	api.BaseRoutes.Channel.Handle("/timeline_exports/{job_id:[A-Za-z0-9]+}", api.ApiSessionRequired(getChannelTimelineExport)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/timeline_exports/{job_id:[A-Za-z0-9]+}/download", api.ApiSessionRequired(downloadChannelTimelineExport)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("/timezones", api.ApiSessionRequired(getChannelMembersTimezones)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("/members_minus_group_members", api.ApiSessionRequired(channelMembersMinusGroupMembers)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/convert_to_channel", api.ApiSessionRequired(convertGroupMessageToChannel)).Methods("POST")
 	api.BaseRoutes.ChannelForUser.Handle("/unread", api.ApiSessionRequired(getChannelUnread)).Methods("GET")
 
 	api.BaseRoutes.ChannelByName.Handle("", api.ApiSessionRequired(getChannelByName)).Methods("GET")
@@ -49,6 +56,7 @@ func (api *API) InitChannel() {
 
 	api.BaseRoutes.ChannelMembers.Handle("", api.ApiSessionRequired(getChannelMembers)).Methods("GET")
 	api.BaseRoutes.ChannelMembers.Handle("/ids", api.ApiSessionRequired(getChannelMembersByIds)).Methods("POST")
+	api.BaseRoutes.ChannelMembers.Handle("/role_counts", api.ApiSessionRequired(getChannelMemberCountsByRole)).Methods("GET")
 	api.BaseRoutes.ChannelMembers.Handle("", api.ApiSessionRequired(addChannelMember)).Methods("POST")
 	api.BaseRoutes.ChannelMembersForUser.Handle("", api.ApiSessionRequired(getChannelMembersForUser)).Methods("GET")
 	api.BaseRoutes.ChannelMember.Handle("", api.ApiSessionRequired(getChannelMember)).Methods("GET")
@@ -56,6 +64,16 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.ChannelMember.Handle("/roles", api.ApiSessionRequired(updateChannelMemberRoles)).Methods("PUT")
 	api.BaseRoutes.ChannelMember.Handle("/schemeRoles", api.ApiSessionRequired(updateChannelMemberSchemeRoles)).Methods("PUT")
 	api.BaseRoutes.ChannelMember.Handle("/notify_props", api.ApiSessionRequired(updateChannelMemberNotifyProps)).Methods("PUT")
+	api.BaseRoutes.ChannelMember.Handle("/expiry", api.ApiSessionRequired(updateChannelMemberExpiry)).Methods("PUT")
+	api.BaseRoutes.Channel.Handle("/member_expiries", api.ApiSessionRequired(getChannelMemberExpiries)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/membership_rules", api.ApiSessionRequired(getChannelMembershipRules)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/membership_rules", api.ApiSessionRequired(addChannelMembershipRule)).Methods("POST")
+	api.BaseRoutes.Channel.Handle("/membership_rules/{membership_rule_id:[A-Za-z0-9]+}", api.ApiSessionRequired(deleteChannelMembershipRule)).Methods("DELETE")
+	api.BaseRoutes.Channel.Handle("/membership_overrides", api.ApiSessionRequired(getChannelMembershipOverrides)).Methods("GET")
+	api.BaseRoutes.ChannelMember.Handle("/membership_override", api.ApiSessionRequired(deleteChannelMembershipOverride)).Methods("DELETE")
+	api.BaseRoutes.Channel.Handle("/teams", api.ApiSessionRequired(getChannelTeamBindings)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/teams/{team_id:[A-Za-z0-9]+}", api.ApiSessionRequired(bindChannelToTeam)).Methods("POST")
+	api.BaseRoutes.Channel.Handle("/teams/{team_id:[A-Za-z0-9]+}", api.ApiSessionRequired(unbindChannelFromTeam)).Methods("DELETE")
 }
 
 func createChannel(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -508,7 +526,7 @@ func getChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	if channel.Type == model.CHANNEL_OPEN {
-		if !c.App.SessionHasPermissionToTeam(c.App.Session, channel.TeamId, model.PERMISSION_READ_PUBLIC_CHANNEL) && !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		if !c.App.SessionHasPermissionToChannelTeams(c.App.Session, channel, model.PERMISSION_READ_PUBLIC_CHANNEL) && !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
 			c.SetPermissionError(model.PERMISSION_READ_PUBLIC_CHANNEL)
 			return
 		}
@@ -519,12 +537,17 @@ func getChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if c.HandleEtag(channel.Etag(), "Get Channel", w, r) {
+		return
+	}
+
 	err = c.App.FillInChannelProps(channel)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
+	w.Header().Set(model.HEADER_ETAG_SERVER, channel.Etag())
 	w.Write([]byte(channel.ToJson()))
 }
 
@@ -583,9 +606,40 @@ func getChannelStats(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	stats := model.ChannelStats{ChannelId: c.Params.ChannelId, MemberCount: memberCount, GuestCount: guestCount, PinnedPostCount: pinnedPostCount}
+	if c.HandleEtag(stats.Etag(), "Get Channel Stats", w, r) {
+		return
+	}
+
+	w.Header().Set(model.HEADER_ETAG_SERVER, stats.Etag())
 	w.Write([]byte(stats.ToJson()))
 }
 
+func getChannelIntegrations(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	integrations, err := c.App.GetChannelIntegrations(c.App.Session, channel)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelIntegrationListToJson(integrations)))
+}
+
 func getPinnedPosts(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
 	if c.Err != nil {
@@ -613,6 +667,97 @@ func getPinnedPosts(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(clientPostList.ToJson()))
 }
 
+func createChannelTimelineExport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	request := model.ChannelTimelineRequestFromJson(r.Body)
+	if request == nil {
+		c.SetInvalidParam("timeline_export")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	job, err := c.App.CreateChannelTimelineJob(channel, request, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("channel_id=" + channel.Id + " job_id=" + job.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(job.ToJson()))
+}
+
+func getChannelTimelineExport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	job, err := c.App.GetChannelTimelineJob(c.Params.ChannelId, c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(job.ToJson()))
+}
+
+func downloadChannelTimelineExport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	job, err := c.App.GetChannelTimelineJob(c.Params.ChannelId, c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	data, err := c.App.GetChannelTimelineFile(job)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("downloaded job_id=" + job.Id)
+
+	contentType := "text/markdown; charset=utf-8"
+	if job.Data[model.CHANNEL_TIMELINE_JOB_DATA_FORMAT] == model.CHANNEL_TIMELINE_FORMAT_JSON {
+		contentType = "application/json"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Content-Disposition", "attachment;filename=\""+path.Base(job.Data[model.CHANNEL_TIMELINE_JOB_DATA_FILE_PATH])+"\"")
+	w.Write(data)
+}
+
 func getAllChannels(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
 		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
@@ -942,7 +1087,7 @@ func getChannelByName(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	if channel.Type == model.CHANNEL_OPEN {
-		if !c.App.SessionHasPermissionToTeam(c.App.Session, channel.TeamId, model.PERMISSION_READ_PUBLIC_CHANNEL) && !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_READ_CHANNEL) {
+		if !c.App.SessionHasPermissionToChannelTeams(c.App.Session, channel, model.PERMISSION_READ_PUBLIC_CHANNEL) && !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_READ_CHANNEL) {
 			c.SetPermissionError(model.PERMISSION_READ_PUBLIC_CHANNEL)
 			return
 		}
@@ -953,12 +1098,17 @@ func getChannelByName(c *Context, w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if c.HandleEtag(channel.Etag(), "Get Channel By Name", w, r) {
+		return
+	}
+
 	err = c.App.FillInChannelProps(channel)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
+	w.Header().Set(model.HEADER_ETAG_SERVER, channel.Etag())
 	w.Write([]byte(channel.ToJson()))
 }
 
@@ -981,12 +1131,17 @@ func getChannelByNameForTeamName(c *Context, w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if c.HandleEtag(channel.Etag(), "Get Channel By Name For Team Name", w, r) {
+		return
+	}
+
 	err = c.App.FillInChannelProps(channel)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
+	w.Header().Set(model.HEADER_ETAG_SERVER, channel.Etag())
 	w.Write([]byte(channel.ToJson()))
 }
 
@@ -1001,7 +1156,22 @@ func getChannelMembers(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	members, err := c.App.GetChannelMembersPage(c.Params.ChannelId, c.Params.Page, c.Params.PerPage)
+	options := &model.ChannelMembersGetOptions{
+		Sort: r.URL.Query().Get("sort"),
+		Role: r.URL.Query().Get("role"),
+	}
+	if err := options.IsValid(); err != nil {
+		c.Err = err
+		return
+	}
+
+	var members *model.ChannelMembers
+	var err *model.AppError
+	if options.Sort != "" || options.Role != "" {
+		members, err = c.App.GetChannelMembersPageWithOptions(c.Params.ChannelId, c.Params.Page, c.Params.PerPage, options)
+	} else {
+		members, err = c.App.GetChannelMembersPage(c.Params.ChannelId, c.Params.Page, c.Params.PerPage)
+	}
 	if err != nil {
 		c.Err = err
 		return
@@ -1010,6 +1180,26 @@ func getChannelMembers(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(members.ToJson()))
 }
 
+func getChannelMemberCountsByRole(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	counts, err := c.App.GetChannelMemberCountsByRole(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(counts.ToJson()))
+}
+
 func getChannelMembersTimezones(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
 	if c.Err != nil {
@@ -1073,6 +1263,11 @@ func getChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if c.HandleEtag(member.Etag(), "Get Channel Member", w, r) {
+		return
+	}
+
+	w.Header().Set(model.HEADER_ETAG_SERVER, member.Etag())
 	w.Write([]byte(member.ToJson()))
 }
 
@@ -1224,6 +1419,314 @@ func updateChannelMemberNotifyProps(c *Context, w http.ResponseWriter, r *http.R
 	ReturnStatusOK(w)
 }
 
+func getChannelMemberExpiries(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	expiries, err := c.App.GetChannelMemberExpiries(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelMemberExpiryListToJson(expiries)))
+}
+
+func updateChannelMemberExpiry(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.StringInterfaceFromJson(r.Body)
+	expiresAt, ok := props["expires_at"].(float64)
+	if !ok || expiresAt < 0 {
+		c.SetInvalidParam("expires_at")
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	switch channel.Type {
+	case model.CHANNEL_OPEN:
+		if !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS)
+			return
+		}
+	case model.CHANNEL_PRIVATE:
+		if !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS)
+			return
+		}
+	default:
+		c.Err = model.NewAppError("updateChannelMemberExpiry", "app.channel_member_expiry.channel.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := c.App.SetChannelMemberExpiry(channel, c.Params.UserId, int64(expiresAt), c.App.Session.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + channel.Name + " user_id=" + c.Params.UserId + " expires_at=" + strconv.FormatInt(int64(expiresAt), 10))
+	ReturnStatusOK(w)
+}
+
+func getChannelMembershipRules(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	rules, err := c.App.GetChannelMembershipRules(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelMembershipRuleListToJson(rules)))
+}
+
+func addChannelMembershipRule(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	rule := model.ChannelMembershipRuleFromJson(r.Body)
+	if rule == nil {
+		c.SetInvalidParam("membership_rule")
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !checkManageChannelMembersPermission(c, channel) {
+		return
+	}
+
+	rule.CreatorId = c.App.Session.UserId
+
+	rule, err = c.App.AddChannelMembershipRule(channel, rule)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + channel.Name + " membership_rule_id=" + rule.Id + " attribute=" + rule.Attribute)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(rule.ToJson()))
+}
+
+func deleteChannelMembershipRule(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireMembershipRuleId()
+	if c.Err != nil {
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !checkManageChannelMembersPermission(c, channel) {
+		return
+	}
+
+	if err := c.App.DeleteChannelMembershipRule(channel, c.Params.MembershipRuleId, c.App.Session.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + channel.Name + " membership_rule_id=" + c.Params.MembershipRuleId)
+	ReturnStatusOK(w)
+}
+
+func getChannelMembershipOverrides(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	overrides, err := c.App.GetChannelMembershipOverrides(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelMembershipOverrideListToJson(overrides)))
+}
+
+func deleteChannelMembershipOverride(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !checkManageChannelMembersPermission(c, channel) {
+		return
+	}
+
+	if err := c.App.DeleteChannelMembershipOverride(channel, c.Params.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + channel.Name + " user_id=" + c.Params.UserId)
+	ReturnStatusOK(w)
+}
+
+func getChannelTeamBindings(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if channel.Type == model.CHANNEL_OPEN {
+		if !c.App.SessionHasPermissionToChannelTeams(c.App.Session, channel, model.PERMISSION_READ_PUBLIC_CHANNEL) && !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_READ_CHANNEL) {
+			c.SetPermissionError(model.PERMISSION_READ_PUBLIC_CHANNEL)
+			return
+		}
+	} else if !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	bindings, err := c.App.GetChannelTeamBindings(channel.Id)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelTeamBindingListToJson(bindings)))
+}
+
+func bindChannelToTeam(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, channel.TeamId, model.PERMISSION_MANAGE_TEAM) || !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_MANAGE_TEAM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_TEAM)
+		return
+	}
+
+	binding, err := c.App.BindChannelToTeam(channel, c.Params.TeamId, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + channel.Name + " team_id=" + c.Params.TeamId)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(binding.ToJson()))
+}
+
+func unbindChannelFromTeam(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, channel.TeamId, model.PERMISSION_MANAGE_TEAM) && !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_MANAGE_TEAM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_TEAM)
+		return
+	}
+
+	if err := c.App.UnbindChannelFromTeam(channel, c.Params.TeamId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + channel.Name + " team_id=" + c.Params.TeamId)
+	ReturnStatusOK(w)
+}
+
+// checkManageChannelMembersPermission sets a permission error on the context unless the session can manage the
+// members of the channel.
+func checkManageChannelMembersPermission(c *Context, channel *model.Channel) bool {
+	switch channel.Type {
+	case model.CHANNEL_OPEN:
+		if !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS)
+			return false
+		}
+	case model.CHANNEL_PRIVATE:
+		if !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS)
+			return false
+		}
+	default:
+		c.Err = model.NewAppError("checkManageChannelMembersPermission", "app.channel_membership_rule.channel.app_error", nil, "", http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
 func addChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
 	if c.Err != nil {
@@ -1242,6 +1745,16 @@ func addChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 		UserId:    userId,
 	}
 
+	var expiresAt int64
+	if expiresAtProp, ok := props["expires_at"]; ok && expiresAtProp != nil {
+		value, ok := expiresAtProp.(float64)
+		if !ok || value < 0 || (value > 0 && int64(value) <= model.GetMillis()) {
+			c.SetInvalidParam("expires_at")
+			return
+		}
+		expiresAt = int64(value)
+	}
+
 	postRootId, ok := props["post_root_id"].(string)
 	if ok && len(postRootId) != 0 && len(postRootId) != 26 {
 		c.SetInvalidParam("post_root_id")
@@ -1266,11 +1779,21 @@ func addChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if channel.Type == model.CHANNEL_DIRECT || channel.Type == model.CHANNEL_GROUP {
+	if channel.Type == model.CHANNEL_GROUP {
+		addGroupChannelMember(c, w, channel, member.UserId)
+		return
+	}
+
+	if channel.Type == model.CHANNEL_DIRECT {
 		c.Err = model.NewAppError("addUserToChannel", "api.channel.add_user_to_channel.type.app_error", nil, "", http.StatusBadRequest)
 		return
 	}
 
+	if expiresAt > 0 && channel.Name == model.DEFAULT_CHANNEL {
+		c.Err = model.NewAppError("addUserToChannel", "app.channel_member_expiry.channel.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
 	isNewMembership := false
 	if _, err = c.App.GetChannelMember(member.ChannelId, member.UserId); err != nil {
 		if err.Id == store.MISSING_CHANNEL_MEMBER_ERROR {
@@ -1285,7 +1808,7 @@ func addChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	if channel.Type == model.CHANNEL_OPEN {
 		if isSelfAdd && isNewMembership {
-			if !c.App.SessionHasPermissionToTeam(c.App.Session, channel.TeamId, model.PERMISSION_JOIN_PUBLIC_CHANNELS) {
+			if !c.App.SessionHasPermissionToChannelTeams(c.App.Session, channel, model.PERMISSION_JOIN_PUBLIC_CHANNELS) {
 				c.SetPermissionError(model.PERMISSION_JOIN_PUBLIC_CHANNELS)
 				return
 			}
@@ -1337,6 +1860,13 @@ func addChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if expiresAt > 0 {
+		if _, err = c.App.SetChannelMemberExpiry(channel, cm.UserId, expiresAt, c.App.Session.UserId); err != nil {
+			c.Err = err
+			return
+		}
+	}
+
 	c.LogAudit("name=" + channel.Name + " user_id=" + cm.UserId)
 	w.WriteHeader(http.StatusCreated)
 	w.Write([]byte(cm.ToJson()))
@@ -1354,6 +1884,11 @@ func removeChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if channel.Type == model.CHANNEL_GROUP {
+		removeGroupChannelMember(c, w, channel)
+		return
+	}
+
 	if !(channel.Type == model.CHANNEL_OPEN || channel.Type == model.CHANNEL_PRIVATE) {
 		c.Err = model.NewAppError("removeChannelMember", "api.channel.remove_channel_member.type.app_error", nil, "", http.StatusBadRequest)
 		return
@@ -1386,6 +1921,99 @@ func removeChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+// addGroupChannelMember adds a user to a group message, which any of its members may do.
+func addGroupChannelMember(c *Context, w http.ResponseWriter, channel *model.Channel, userId string) {
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	cm, err := c.App.AddGroupChannelMember(channel, userId, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + channel.Name + " user_id=" + cm.UserId)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(cm.ToJson()))
+}
+
+// removeGroupChannelMember removes a user from a group message, which any of its members may do.
+func removeGroupChannelMember(c *Context, w http.ResponseWriter, channel *model.Channel) {
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	if err := c.App.RemoveGroupChannelMember(channel, c.Params.UserId, c.App.Session.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + channel.Name + " user_id=" + c.Params.UserId)
+
+	ReturnStatusOK(w)
+}
+
+func convertGroupMessageToChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.MapFromJson(r.Body)
+
+	teamId := props["team_id"]
+	if len(teamId) != 26 {
+		c.SetInvalidParam("team_id")
+		return
+	}
+
+	name := props["name"]
+	if len(name) == 0 {
+		c.SetInvalidParam("name")
+		return
+	}
+
+	displayName := props["display_name"]
+	if len(displayName) == 0 {
+		c.SetInvalidParam("display_name")
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, teamId, model.PERMISSION_CREATE_PRIVATE_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_CREATE_PRIVATE_CHANNEL)
+		return
+	}
+
+	user, err := c.App.GetUser(c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	converted, err := c.App.ConvertGroupChannelToPrivate(channel, teamId, name, displayName, user)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + converted.Name + " team_id=" + teamId)
+	w.Write([]byte(converted.ToJson()))
+}
+
 func updateChannelScheme(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
 	if c.Err != nil {
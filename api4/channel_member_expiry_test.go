@@ -0,0 +1,85 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestChannelMemberExpiry(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	channel := th.CreatePublicChannel()
+	expiresAt := model.GetMillis() + 60*60*1000
+
+	_, resp := Client.AddChannelMemberWithExpiry(channel.Id, th.BasicUser2.Id, model.GetMillis()-1000)
+	CheckBadRequestStatus(t, resp)
+
+	member, resp := Client.AddChannelMemberWithExpiry(channel.Id, th.BasicUser2.Id, expiresAt)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.BasicUser2.Id, member.UserId)
+
+	expiries, resp := Client.GetChannelMemberExpiries(channel.Id)
+	CheckNoError(t, resp)
+	require.Len(t, expiries, 1)
+	assert.Equal(t, th.BasicUser2.Id, expiries[0].UserId)
+	assert.Equal(t, th.BasicUser.Id, expiries[0].CreatorId)
+	assert.Equal(t, expiresAt, expiries[0].ExpiresAt)
+
+	t.Run("extend", func(t *testing.T) {
+		ok, resp := Client.UpdateChannelMemberExpiry(channel.Id, th.BasicUser2.Id, expiresAt+60*60*1000)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+
+		expiries, resp := Client.GetChannelMemberExpiries(channel.Id)
+		CheckNoError(t, resp)
+		require.Len(t, expiries, 1)
+		assert.Equal(t, expiresAt+60*60*1000, expiries[0].ExpiresAt)
+	})
+
+	t.Run("expiry in the past", func(t *testing.T) {
+		_, resp := Client.UpdateChannelMemberExpiry(channel.Id, th.BasicUser2.Id, model.GetMillis()-1000)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		_, resp := Client.UpdateChannelMemberExpiry(channel.Id, th.CreateUser().Id, expiresAt)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("without permission", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS.Id, model.CHANNEL_USER_ROLE_ID)
+		th.RemovePermissionFromRole(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS.Id, model.TEAM_USER_ROLE_ID)
+		defer th.AddPermissionToRole(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS.Id, model.CHANNEL_USER_ROLE_ID)
+		defer th.AddPermissionToRole(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS.Id, model.TEAM_USER_ROLE_ID)
+
+		_, resp := Client.UpdateChannelMemberExpiry(channel.Id, th.BasicUser2.Id, expiresAt)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("not in the channel", func(t *testing.T) {
+		private := th.CreateChannelWithClientAndTeam(th.SystemAdminClient, model.CHANNEL_PRIVATE, th.BasicTeam.Id)
+
+		_, resp := Client.GetChannelMemberExpiries(private.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("make permanent", func(t *testing.T) {
+		ok, resp := Client.UpdateChannelMemberExpiry(channel.Id, th.BasicUser2.Id, 0)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+
+		expiries, resp := Client.GetChannelMemberExpiries(channel.Id)
+		CheckNoError(t, resp)
+		assert.Empty(t, expiries)
+	})
+}
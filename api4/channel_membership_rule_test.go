@@ -0,0 +1,85 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestChannelMembershipRules(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	channel := th.CreatePublicChannel()
+
+	rule, resp := Client.AddChannelMembershipRule(channel.Id, &model.ChannelMembershipRule{Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION, Value: "Engineer"})
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, channel.Id, rule.ChannelId)
+	assert.Equal(t, th.BasicUser.Id, rule.CreatorId)
+
+	rules, resp := Client.GetChannelMembershipRules(channel.Id)
+	CheckNoError(t, resp)
+	require.Len(t, rules, 1)
+	assert.Equal(t, rule.Id, rules[0].Id)
+
+	t.Run("invalid attribute", func(t *testing.T) {
+		_, resp := Client.AddChannelMembershipRule(channel.Id, &model.ChannelMembershipRule{Attribute: "password", Value: "secret"})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		_, resp := Client.AddChannelMember(channel.Id, th.BasicUser2.Id)
+		CheckNoError(t, resp)
+
+		overrides, resp := Client.GetChannelMembershipOverrides(channel.Id)
+		CheckNoError(t, resp)
+		userIds := []string{}
+		for _, override := range overrides {
+			assert.Equal(t, model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE, override.Type)
+			userIds = append(userIds, override.UserId)
+		}
+		assert.ElementsMatch(t, []string{th.BasicUser.Id, th.BasicUser2.Id}, userIds)
+
+		ok, resp := Client.DeleteChannelMembershipOverride(channel.Id, th.BasicUser2.Id)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+	})
+
+	t.Run("without permission", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS.Id, model.CHANNEL_USER_ROLE_ID)
+		th.RemovePermissionFromRole(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS.Id, model.TEAM_USER_ROLE_ID)
+		defer th.AddPermissionToRole(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS.Id, model.CHANNEL_USER_ROLE_ID)
+		defer th.AddPermissionToRole(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS.Id, model.TEAM_USER_ROLE_ID)
+
+		_, resp := Client.AddChannelMembershipRule(channel.Id, &model.ChannelMembershipRule{Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE, Value: "fr"})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.DeleteChannelMembershipRule(channel.Id, rule.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("not in the channel", func(t *testing.T) {
+		private := th.CreateChannelWithClientAndTeam(th.SystemAdminClient, model.CHANNEL_PRIVATE, th.BasicTeam.Id)
+
+		_, resp := Client.GetChannelMembershipRules(private.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		ok, resp := Client.DeleteChannelMembershipRule(channel.Id, rule.Id)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+
+		rules, resp := Client.GetChannelMembershipRules(channel.Id)
+		CheckNoError(t, resp)
+		assert.Empty(t, rules)
+	})
+}
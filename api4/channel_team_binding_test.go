@@ -0,0 +1,62 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelTeamBindings(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	channel := th.CreatePublicChannel()
+	otherTeam := th.CreateTeamWithClient(th.SystemAdminClient)
+
+	t.Run("without permission on the other team", func(t *testing.T) {
+		_, resp := Client.BindChannelToTeam(channel.Id, otherTeam.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	binding, resp := th.SystemAdminClient.BindChannelToTeam(channel.Id, otherTeam.Id)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, channel.Id, binding.ChannelId)
+	assert.Equal(t, th.SystemAdminUser.Id, binding.CreatorId)
+
+	bindings, resp := Client.GetChannelTeamBindings(channel.Id)
+	CheckNoError(t, resp)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, otherTeam.Id, bindings[0].TeamId)
+
+	t.Run("members of the bound team can join", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, otherTeam)
+
+		client := th.CreateClient()
+		_, resp := client.Login(user.Email, user.Password)
+		CheckNoError(t, resp)
+
+		found, resp := client.GetChannelByName(channel.Name, otherTeam.Id, "")
+		CheckNoError(t, resp)
+		assert.Equal(t, channel.Id, found.Id)
+
+		_, resp = client.AddChannelMember(channel.Id, user.Id)
+		CheckNoError(t, resp)
+	})
+
+	t.Run("unbind", func(t *testing.T) {
+		ok, resp := Client.UnbindChannelFromTeam(channel.Id, otherTeam.Id)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+
+		bindings, resp := Client.GetChannelTeamBindings(channel.Id)
+		CheckNoError(t, resp)
+		assert.Empty(t, bindings)
+	})
+}
@@ -634,6 +634,9 @@ func TestGetChannel(t *testing.T) {
 		t.Fatal("ids did not match")
 	}
 
+	channel, resp = Client.GetChannel(th.BasicChannel.Id, resp.Etag)
+	CheckEtag(t, channel, resp)
+
 	Client.RemoveUserFromChannel(th.BasicChannel.Id, th.BasicUser.Id)
 	_, resp = Client.GetChannel(th.BasicChannel.Id, "")
 	CheckNoError(t, resp)
@@ -1630,6 +1633,62 @@ func TestGetChannelMembers(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetChannelMembersWithOptions(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	_, err := th.App.UpdateChannelMemberSchemeRoles(th.BasicChannel.Id, th.BasicUser2.Id, false, true, true)
+	require.Nil(t, err)
+
+	members, resp := Client.GetChannelMembersWithOptions(th.BasicChannel.Id, 0, 60, &model.ChannelMembersGetOptions{Role: model.CHANNEL_MEMBER_ROLE_FILTER_ADMIN}, "")
+	CheckNoError(t, resp)
+	admins := map[string]bool{}
+	for _, member := range *members {
+		assert.True(t, member.SchemeAdmin)
+		admins[member.UserId] = true
+	}
+	assert.True(t, admins[th.BasicUser2.Id])
+	assert.False(t, admins[th.BasicUser.Id])
+
+	members, resp = Client.GetChannelMembersWithOptions(th.BasicChannel.Id, 0, 60, &model.ChannelMembersGetOptions{Sort: model.CHANNEL_MEMBERS_SORT_ROLE}, "")
+	CheckNoError(t, resp)
+	require.NotEmpty(t, *members)
+	assert.True(t, (*members)[0].SchemeAdmin, "admins should come first")
+	assert.False(t, (*members)[len(*members)-1].SchemeAdmin)
+
+	th.CreateMessagePostNoClient(th.BasicChannel, "latest", model.GetMillis()+1000)
+
+	members, resp = Client.GetChannelMembersWithOptions(th.BasicChannel.Id, 0, 1, &model.ChannelMembersGetOptions{Sort: model.CHANNEL_MEMBERS_SORT_LAST_POST}, "")
+	CheckNoError(t, resp)
+	require.Len(t, *members, 1)
+	assert.Equal(t, th.BasicUser.Id, (*members)[0].UserId)
+
+	_, resp = Client.GetChannelMembersWithOptions(th.BasicChannel.Id, 0, 60, &model.ChannelMembersGetOptions{Sort: "email"}, "")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetChannelMembersWithOptions(th.BasicChannel.Id, 0, 60, &model.ChannelMembersGetOptions{Role: "owner"}, "")
+	CheckBadRequestStatus(t, resp)
+
+	counts, resp := Client.GetChannelMemberCountsByRole(th.BasicChannel.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, th.BasicChannel.Id, counts.ChannelId)
+	assert.Equal(t, int64(len(admins)), counts.Admins)
+	assert.Equal(t, counts.Total, counts.Admins+counts.Users+counts.Guests)
+
+	count, err := th.App.GetChannelMemberCount(th.BasicChannel.Id)
+	require.Nil(t, err)
+	assert.Equal(t, count, counts.Total)
+
+	privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.CHANNEL_PRIVATE)
+
+	_, resp = Client.GetChannelMembersWithOptions(privateChannel.Id, 0, 60, &model.ChannelMembersGetOptions{Sort: model.CHANNEL_MEMBERS_SORT_ROLE}, "")
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = Client.GetChannelMemberCountsByRole(privateChannel.Id)
+	CheckForbiddenStatus(t, resp)
+}
+
 func TestGetChannelMembersByIds(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -1693,6 +1752,9 @@ func TestGetChannelMember(t *testing.T) {
 		t.Fatal("wrong user id")
 	}
 
+	member, resp = Client.GetChannelMember(th.BasicChannel.Id, th.BasicUser.Id, resp.Etag)
+	CheckEtag(t, member, resp)
+
 	_, resp = Client.GetChannelMember("", th.BasicUser.Id, "")
 	CheckNotFoundStatus(t, resp)
 
@@ -1923,6 +1985,9 @@ func TestGetChannelStats(t *testing.T) {
 		t.Fatal("should have returned 1 pinned post count")
 	}
 
+	stats, resp = Client.GetChannelStats(channel.Id, resp.Etag)
+	CheckEtag(t, stats, resp)
+
 	_, resp = Client.GetChannelStats("junk", "")
 	CheckBadRequestStatus(t, resp)
 
@@ -1942,6 +2007,124 @@ func TestGetChannelStats(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetChannelIntegrations(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableIncomingWebhooks = true
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.EnableCommands = true
+	})
+
+	bot, err := th.App.CreateBot(&model.Bot{
+		Username:    "channelbot",
+		Description: "Posts build results",
+		OwnerId:     th.SystemAdminUser.Id,
+	})
+	require.Nil(t, err)
+	botUser, err := th.App.GetUser(bot.UserId)
+	require.Nil(t, err)
+	th.LinkUserToTeam(botUser, th.BasicTeam)
+	th.AddUserToChannel(botUser, th.BasicChannel)
+
+	incomingHook, err := th.App.CreateIncomingWebhookForChannel(th.SystemAdminUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id, DisplayName: "alerts"})
+	require.Nil(t, err)
+
+	outgoingHook, err := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		CreatorId:    th.BasicUser.Id,
+		TeamId:       th.BasicTeam.Id,
+		TriggerWords: []string{"deploy"},
+		CallbackURLs: []string{"http://example.com/deploy"},
+		DisplayName:  "deploy",
+	})
+	require.Nil(t, err)
+
+	command, err := th.App.CreateCommand(&model.Command{
+		CreatorId: th.BasicUser.Id,
+		TeamId:    th.BasicTeam.Id,
+		Trigger:   "trigger" + model.NewId()[:8],
+		Method:    model.COMMAND_METHOD_POST,
+		URL:       "http://example.com/command",
+	})
+	require.Nil(t, err)
+
+	findIntegration := func(integrations []*model.ChannelIntegration, integrationType string) *model.ChannelIntegration {
+		for _, integration := range integrations {
+			if integration.Type == integrationType {
+				return integration
+			}
+		}
+		return nil
+	}
+
+	t.Run("member", func(t *testing.T) {
+		integrations, resp := Client.GetChannelIntegrations(th.BasicChannel.Id)
+		CheckNoError(t, resp)
+
+		botIntegration := findIntegration(integrations, model.INTEGRATION_TYPE_BOT)
+		require.NotNil(t, botIntegration)
+		assert.Equal(t, bot.UserId, botIntegration.Id)
+		assert.Equal(t, "Posts build results", botIntegration.Description)
+		assert.Equal(t, th.SystemAdminUser.Id, botIntegration.OwnerId)
+		assert.False(t, botIntegration.CanManage)
+
+		incomingIntegration := findIntegration(integrations, model.INTEGRATION_TYPE_INCOMING_WEBHOOK)
+		require.NotNil(t, incomingIntegration)
+		assert.Equal(t, "alerts", incomingIntegration.DisplayName)
+		assert.Empty(t, incomingIntegration.Id, "incoming webhook ids should only be shown to the users who can manage them")
+
+		outgoingIntegration := findIntegration(integrations, model.INTEGRATION_TYPE_OUTGOING_WEBHOOK)
+		require.NotNil(t, outgoingIntegration)
+		assert.Equal(t, outgoingHook.Id, outgoingIntegration.Id)
+		assert.Equal(t, []string{"deploy"}, outgoingIntegration.TriggerWords)
+
+		commandIntegration := findIntegration(integrations, model.INTEGRATION_TYPE_COMMAND)
+		require.NotNil(t, commandIntegration)
+		assert.Equal(t, command.Trigger, commandIntegration.Trigger)
+		assert.Equal(t, th.BasicUser.Id, commandIntegration.OwnerId)
+	})
+
+	t.Run("system admin", func(t *testing.T) {
+		integrations, resp := th.SystemAdminClient.GetChannelIntegrations(th.BasicChannel.Id)
+		CheckNoError(t, resp)
+
+		incomingIntegration := findIntegration(integrations, model.INTEGRATION_TYPE_INCOMING_WEBHOOK)
+		require.NotNil(t, incomingIntegration)
+		assert.Equal(t, incomingHook.Id, incomingIntegration.Id)
+		assert.True(t, incomingIntegration.CanManage)
+
+		botIntegration := findIntegration(integrations, model.INTEGRATION_TYPE_BOT)
+		require.NotNil(t, botIntegration)
+		assert.True(t, botIntegration.CanManage)
+	})
+
+	t.Run("team-wide outgoing webhooks don't apply to private channels", func(t *testing.T) {
+		integrations, resp := Client.GetChannelIntegrations(th.CreatePrivateChannel().Id)
+		CheckNoError(t, resp)
+		assert.Nil(t, findIntegration(integrations, model.INTEGRATION_TYPE_OUTGOING_WEBHOOK))
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel()
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.GetChannelIntegrations(privateChannel.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("not logged in", func(t *testing.T) {
+		Client.Logout()
+		defer th.LoginBasic()
+
+		_, resp := Client.GetChannelIntegrations(th.BasicChannel.Id)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
 func TestGetPinnedPosts(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -2606,7 +2789,7 @@ func TestRemoveChannelMember(t *testing.T) {
 	_, resp = th.SystemAdminClient.RemoveUserFromChannel(directChannel.Id, user1.Id)
 	CheckBadRequestStatus(t, resp)
 
-	// Test on preventing removal of user from a group channel
+	// Test on preventing removal of user from a group channel with the fewest members allowed
 	user3 := th.CreateUser()
 	groupChannel, resp := Client.CreateGroupChannel([]string{user1.Id, user2.Id, user3.Id})
 	CheckNoError(t, resp)
@@ -2618,6 +2801,51 @@ func TestRemoveChannelMember(t *testing.T) {
 	CheckBadRequestStatus(t, resp)
 }
 
+func TestGroupMessageMembers(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	user3 := th.CreateUser()
+	user4 := th.CreateUser()
+	groupChannel, resp := Client.CreateGroupChannel([]string{th.BasicUser.Id, th.BasicUser2.Id, user3.Id})
+	CheckNoError(t, resp)
+
+	cm, resp := Client.AddChannelMember(groupChannel.Id, user4.Id)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	require.Equal(t, user4.Id, cm.UserId)
+
+	channel, resp := Client.GetChannel(groupChannel.Id, "")
+	CheckNoError(t, resp)
+	require.Equal(t, model.GetGroupNameFromUserIds([]string{th.BasicUser.Id, th.BasicUser2.Id, user3.Id, user4.Id}), channel.Name)
+
+	outsider := th.CreateUser()
+	th.LinkUserToTeam(outsider, th.BasicTeam)
+	th.Client.Logout()
+	th.Client.Login(outsider.Email, outsider.Password)
+	_, resp = Client.AddChannelMember(groupChannel.Id, outsider.Id)
+	CheckForbiddenStatus(t, resp)
+
+	th.LoginBasic()
+	_, resp = Client.RemoveUserFromChannel(groupChannel.Id, user4.Id)
+	CheckNoError(t, resp)
+
+	_, resp = Client.ConvertGroupMessageToChannel(groupChannel.Id, th.BasicTeam.Id, "converted", "Converted")
+	CheckBadRequestStatus(t, resp)
+
+	th.LinkUserToTeam(user3, th.BasicTeam)
+
+	_, resp = Client.ConvertGroupMessageToChannel(groupChannel.Id, th.BasicTeam.Id, "", "Converted")
+	CheckBadRequestStatus(t, resp)
+
+	converted, resp := Client.ConvertGroupMessageToChannel(groupChannel.Id, th.BasicTeam.Id, "converted", "Converted")
+	CheckNoError(t, resp)
+	require.Equal(t, groupChannel.Id, converted.Id)
+	require.Equal(t, model.CHANNEL_PRIVATE, converted.Type)
+	require.Equal(t, th.BasicTeam.Id, converted.TeamId)
+}
+
 func TestAutocompleteChannels(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -3033,3 +3261,42 @@ func TestChannelMembersMinusGroupMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateChannelTimelineExport(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	request := &model.ChannelTimelineRequest{
+		StartTime: model.GetMillis() - 60*60*1000,
+		EndTime:   model.GetMillis(),
+		Format:    model.CHANNEL_TIMELINE_FORMAT_MARKDOWN,
+	}
+
+	job, resp := Client.CreateChannelTimelineExport(th.BasicChannel.Id, request)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	require.Equal(t, model.JOB_TYPE_CHANNEL_TIMELINE, job.Type)
+	require.Equal(t, th.BasicChannel.Id, job.Data[model.CHANNEL_TIMELINE_JOB_DATA_CHANNEL_ID])
+
+	rjob, resp := Client.GetChannelTimelineExport(th.BasicChannel.Id, job.Id)
+	CheckNoError(t, resp)
+	require.Equal(t, job.Id, rjob.Id)
+
+	_, resp = Client.GetChannelTimelineExport(th.BasicChannel2.Id, job.Id)
+	CheckNotFoundStatus(t, resp)
+
+	_, resp = Client.DownloadChannelTimelineExport(th.BasicChannel.Id, job.Id)
+	CheckBadRequestStatus(t, resp)
+
+	request.Format = "pdf"
+	_, resp = Client.CreateChannelTimelineExport(th.BasicChannel.Id, request)
+	CheckBadRequestStatus(t, resp)
+
+	privateChannel := th.CreatePrivateChannel()
+	Client.Logout()
+	th.LoginBasic2()
+	request.Format = model.CHANNEL_TIMELINE_FORMAT_JSON
+	_, resp = Client.CreateChannelTimelineExport(privateChannel.Id, request)
+	CheckForbiddenStatus(t, resp)
+}
@@ -6,7 +6,6 @@ package api4
 import (
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/mattermost/mattermost-server/model"
 )
@@ -15,6 +14,7 @@ func (api *API) InitCommand() {
 	api.BaseRoutes.Commands.Handle("", api.ApiSessionRequired(createCommand)).Methods("POST")
 	api.BaseRoutes.Commands.Handle("", api.ApiSessionRequired(listCommands)).Methods("GET")
 	api.BaseRoutes.Commands.Handle("/execute", api.ApiSessionRequired(executeCommand)).Methods("POST")
+	api.BaseRoutes.Commands.Handle("/explain", api.ApiSessionRequired(explainCommand)).Methods("POST")
 
 	api.BaseRoutes.Command.Handle("", api.ApiSessionRequired(updateCommand)).Methods("PUT")
 	api.BaseRoutes.Command.Handle("", api.ApiSessionRequired(deleteCommand)).Methods("DELETE")
@@ -185,57 +185,55 @@ func listCommands(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.CommandListToJson(commands)))
 }
 
-func executeCommand(c *Context, w http.ResponseWriter, r *http.Request) {
+// commandArgsFromRequest reads the arguments of a command the session's user may run from the request.
+func commandArgsFromRequest(c *Context, r *http.Request) *model.CommandArgs {
 	commandArgs := model.CommandArgsFromJson(r.Body)
 	if commandArgs == nil {
 		c.SetInvalidParam("command_args")
-		return
+		return nil
 	}
 
-	if len(commandArgs.Command) <= 1 || strings.Index(commandArgs.Command, "/") != 0 || len(commandArgs.ChannelId) != 26 {
-		c.Err = model.NewAppError("executeCommand", "api.command.execute_command.start.app_error", nil, "", http.StatusBadRequest)
-		return
+	if err := c.App.CheckCommandArgs(c.App.Session, commandArgs); err != nil {
+		c.Err = err
+		return nil
 	}
 
-	// checks that user is a member of the specified channel, and that they have permission to use slash commands in it
-	if !c.App.SessionHasPermissionToChannel(c.App.Session, commandArgs.ChannelId, model.PERMISSION_USE_SLASH_COMMANDS) {
-		c.SetPermissionError(model.PERMISSION_USE_SLASH_COMMANDS)
+	commandArgs.UserId = c.App.Session.UserId
+	commandArgs.T = c.App.T
+	commandArgs.Session = c.App.Session
+	commandArgs.SiteURL = c.GetSiteURLHeader()
+
+	return commandArgs
+}
+
+func executeCommand(c *Context, w http.ResponseWriter, r *http.Request) {
+	commandArgs := commandArgsFromRequest(c, r)
+	if c.Err != nil {
 		return
 	}
 
-	channel, err := c.App.GetChannel(commandArgs.ChannelId)
+	response, err := c.App.ExecuteCommand(commandArgs)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	if channel.Type != model.CHANNEL_DIRECT && channel.Type != model.CHANNEL_GROUP {
-		// if this isn't a DM or GM, the team id is implicitly taken from the channel so that slash commands created on
-		// some other team can't be run against this one
-		commandArgs.TeamId = channel.TeamId
-	} else {
-		// if the slash command was used in a DM or GM, ensure that the user is a member of the specified team, so that
-		// they can't just execute slash commands against arbitrary teams
-		if c.App.Session.GetTeamByTeamId(commandArgs.TeamId) == nil {
-			if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_USE_SLASH_COMMANDS) {
-				c.SetPermissionError(model.PERMISSION_USE_SLASH_COMMANDS)
-				return
-			}
-		}
-	}
+	w.Write([]byte(response.ToJson()))
+}
 
-	commandArgs.UserId = c.App.Session.UserId
-	commandArgs.T = c.App.T
-	commandArgs.Session = c.App.Session
-	commandArgs.SiteURL = c.GetSiteURLHeader()
+func explainCommand(c *Context, w http.ResponseWriter, r *http.Request) {
+	commandArgs := commandArgsFromRequest(c, r)
+	if c.Err != nil {
+		return
+	}
 
-	response, err := c.App.ExecuteCommand(commandArgs)
+	explanation, err := c.App.ExplainCommand(commandArgs)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	w.Write([]byte(response.ToJson()))
+	w.Write([]byte(explanation.ToJson()))
 }
 
 func listAutocompleteCommands(c *Context, w http.ResponseWriter, r *http.Request) {
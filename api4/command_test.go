@@ -847,3 +847,50 @@ func TestExecuteCommandInTeamUserIsNotOn(t *testing.T) {
 	_, resp = client.ExecuteCommand(dmChannel.Id, "/postcommand")
 	CheckForbiddenStatus(t, resp)
 }
+
+func TestExplainCommand(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCommands = true })
+
+	customCmd := &model.Command{
+		CreatorId: th.BasicUser.Id,
+		TeamId:    th.BasicTeam.Id,
+		URL:       "http://localhost/deploy",
+		Method:    model.COMMAND_METHOD_POST,
+		Trigger:   "deploy",
+	}
+	customCmd, err := th.App.CreateCommand(customCmd)
+	require.Nil(t, err)
+
+	explanation, resp := th.Client.ExplainCommand(th.BasicChannel.Id, "/echo hello")
+	CheckNoError(t, resp)
+	assert.Equal(t, "echo", explanation.Trigger)
+	assert.Equal(t, model.COMMAND_SOURCE_BUILT_IN, explanation.Source)
+	assert.True(t, explanation.Allowed)
+
+	explanation, resp = th.SystemAdminClient.ExplainCommand(th.BasicChannel.Id, "/deploy production")
+	CheckNoError(t, resp)
+	assert.Equal(t, model.COMMAND_SOURCE_CUSTOM, explanation.Source)
+	assert.Equal(t, customCmd.Id, explanation.CommandId)
+	assert.Equal(t, customCmd.URL, explanation.URL)
+
+	_, resp = th.Client.ExplainCommand(th.BasicChannel.Id, "/doesnotexist")
+	CheckNotFoundStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.ServiceSettings.CommandRestrictions = map[string]*model.CommandRestriction{
+			"deploy": {Roles: []string{model.SYSTEM_ADMIN_ROLE_ID}},
+		}
+	})
+
+	explanation, resp = th.Client.ExplainCommand(th.BasicChannel.Id, "/deploy production")
+	CheckNoError(t, resp)
+	assert.False(t, explanation.Allowed)
+	assert.NotEmpty(t, explanation.Reason)
+	require.NotNil(t, explanation.Restriction)
+
+	_, resp = th.Client.ExecuteCommand(th.BasicChannel.Id, "/deploy production")
+	CheckForbiddenStatus(t, resp)
+}
@@ -15,6 +15,10 @@ import (
 func (api *API) InitConfig() {
 	api.BaseRoutes.ApiRoot.Handle("/config", api.ApiSessionRequired(getConfig)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/config", api.ApiSessionRequired(updateConfig)).Methods("PUT")
+	api.BaseRoutes.ApiRoot.Handle("/config/patch", api.ApiSessionRequired(patchConfig)).Methods("PUT")
+	api.BaseRoutes.ApiRoot.Handle("/config/audits", api.ApiSessionRequired(getConfigAudits)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/config/audits/{audit_id:[A-Za-z0-9]+}", api.ApiSessionRequired(getConfigAudit)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/config/audits/{audit_id:[A-Za-z0-9]+}/rollback", api.ApiSessionRequired(rollbackConfig)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/config/reload", api.ApiSessionRequired(configReload)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/config/client", api.ApiHandler(getClientConfig)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/config/environment", api.ApiSessionRequired(getEnvironmentConfig)).Methods("GET")
@@ -66,18 +70,58 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		// Start with the current configuration, and only merge values not marked as being
 		// restricted.
 		var err error
-		cfg, err = config.Merge(appCfg, cfg, &utils.MergeConfig{
-			StructFieldFilter: func(structField reflect.StructField, base, patch reflect.Value) bool {
-				restricted := structField.Tag.Get("restricted") == "true"
-
-				return !restricted
-			},
-		})
+		cfg, err = config.Merge(appCfg, cfg, unrestrictedConfigMerge)
 		if err != nil {
 			c.Err = model.NewAppError("updateConfig", "api.config.update_config.restricted_merge.app_error", nil, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	}
 
+	saveConfig(c, w, appCfg, cfg, "updateConfig")
+}
+
+// patchConfig merges the settings given in the request on top of the current configuration, so that a single setting
+// can be changed without sending back the whole configuration.
+func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
+	patch := model.ConfigFromJson(r.Body)
+	if patch == nil {
+		c.SetInvalidParam("config")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	appCfg := c.App.Config()
+
+	var mergeConfig *utils.MergeConfig
+	if *appCfg.ExperimentalSettings.RestrictSystemAdmin {
+		mergeConfig = unrestrictedConfigMerge
+	}
+
+	cfg, err := config.Merge(appCfg, patch, mergeConfig)
+	if err != nil {
+		c.Err = model.NewAppError("patchConfig", "api.config.patch_config.merge.app_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	saveConfig(c, w, appCfg, cfg, "patchConfig")
+}
+
+// unrestrictedConfigMerge only merges the values not marked as being restricted.
+var unrestrictedConfigMerge = &utils.MergeConfig{
+	StructFieldFilter: func(structField reflect.StructField, base, patch reflect.Value) bool {
+		restricted := structField.Tag.Get("restricted") == "true"
+
+		return !restricted
+	},
+}
+
+// saveConfig validates and saves a configuration updated through the API, notifying the other cluster nodes, and
+// writes the sanitized result to the response.
+func saveConfig(c *Context, w http.ResponseWriter, appCfg, cfg *model.Config, where string) {
 	// Do not allow plugin uploads to be toggled through the API
 	cfg.PluginSettings.EnableUploads = appCfg.PluginSettings.EnableUploads
 
@@ -107,7 +151,7 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c.LogAudit("updateConfig")
+	c.LogAudit(where)
 
 	cfg = c.App.GetSanitizedConfig()
 
@@ -115,6 +159,71 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(cfg.ToJson()))
 }
 
+func getConfigAudits(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	audits, err := c.App.GetConfigAuditsPage(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ConfigAuditListToJson(audits)))
+}
+
+func getConfigAudit(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireConfigAuditId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	audit, err := c.App.GetConfigAudit(c.Params.ConfigAuditId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(audit.ToJson()))
+}
+
+func rollbackConfig(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireConfigAuditId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	// Rolling back replaces the whole configuration, including the restricted settings.
+	if *c.App.Config().ExperimentalSettings.RestrictSystemAdmin {
+		c.Err = model.NewAppError("rollbackConfig", "api.restricted_system_admin", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.App.RollbackConfig(c.Params.ConfigAuditId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("audit_id=" + c.Params.ConfigAuditId)
+
+	cfg := c.App.GetSanitizedConfig()
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte(cfg.ToJson()))
+}
+
 func getClientConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	format := r.URL.Query().Get("format")
 
@@ -230,6 +230,124 @@ func TestUpdateConfigRestrictSystemAdmin(t *testing.T) {
 	require.Equal(t, returnedCfg, actualCfg)
 }
 
+func TestPatchConfig(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	originalCfg, resp := th.SystemAdminClient.GetConfig()
+	CheckNoError(t, resp)
+
+	patch := &model.Config{}
+	patch.TeamSettings.SiteName = model.NewString("MyPatchedName")
+
+	_, resp = th.Client.PatchConfig(patch)
+	CheckForbiddenStatus(t, resp)
+
+	cfg, resp := th.SystemAdminClient.PatchConfig(patch)
+	CheckNoError(t, resp)
+	assert.Equal(t, "MyPatchedName", *cfg.TeamSettings.SiteName)
+	assert.Equal(t, *originalCfg.ServiceSettings.SiteURL, *cfg.ServiceSettings.SiteURL, "settings left out of the patch should be kept")
+	assert.Equal(t, *originalCfg.TeamSettings.MaxUsersPerTeam, *cfg.TeamSettings.MaxUsersPerTeam, "settings left out of the patch should be kept")
+	assert.Equal(t, "MyPatchedName", *th.App.Config().TeamSettings.SiteName)
+
+	t.Run("Should fail with validation error if invalid config setting is passed", func(t *testing.T) {
+		badPatch := &model.Config{}
+		badPatch.PasswordSettings.MinimumLength = model.NewInt(4)
+		_, resp = th.SystemAdminClient.PatchConfig(badPatch)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "model.config.is_valid.password_length.app_error")
+	})
+
+	t.Run("Should not be able to modify PluginSettings.EnableUploads", func(t *testing.T) {
+		oldEnableUploads := *th.App.Config().PluginSettings.EnableUploads
+
+		uploadsPatch := &model.Config{}
+		uploadsPatch.PluginSettings.EnableUploads = model.NewBool(!oldEnableUploads)
+		cfg, resp = th.SystemAdminClient.PatchConfig(uploadsPatch)
+		CheckNoError(t, resp)
+		assert.Equal(t, oldEnableUploads, *cfg.PluginSettings.EnableUploads)
+	})
+
+	t.Run("Should ignore restricted settings", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = false })
+
+		restrictedPatch := &model.Config{}
+		restrictedPatch.ServiceSettings.SiteURL = model.NewString("http://example.com")
+		cfg, resp = th.SystemAdminClient.PatchConfig(restrictedPatch)
+		CheckNoError(t, resp)
+		assert.Equal(t, *originalCfg.ServiceSettings.SiteURL, *cfg.ServiceSettings.SiteURL)
+	})
+}
+
+func TestConfigAudits(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	originalCfg, resp := th.SystemAdminClient.GetConfig()
+	CheckNoError(t, resp)
+
+	patch := &model.Config{}
+	patch.TeamSettings.SiteName = model.NewString("MyAuditedName")
+	patch.EmailSettings.SMTPPassword = model.NewString("supersecret")
+	_, resp = th.SystemAdminClient.PatchConfig(patch)
+	CheckNoError(t, resp)
+
+	_, resp = th.Client.GetConfigAudits(0, 10)
+	CheckForbiddenStatus(t, resp)
+
+	audits, resp := th.SystemAdminClient.GetConfigAudits(0, 1)
+	CheckNoError(t, resp)
+	require.Len(t, audits, 1)
+	audit := audits[0]
+	assert.Equal(t, th.SystemAdminUser.Id, audit.UserId)
+	assert.NotContains(t, audit.ToJson(), "supersecret")
+
+	paths := map[string]bool{}
+	for _, change := range audit.Changes {
+		paths[change.Path] = true
+	}
+	assert.True(t, paths["TeamSettings.SiteName"])
+	assert.True(t, paths["EmailSettings.SMTPPassword"])
+
+	fetched, resp := th.SystemAdminClient.GetConfigAudit(audit.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, audit.Id, fetched.Id)
+
+	_, resp = th.SystemAdminClient.GetConfigAudit(model.NewId())
+	CheckNotFoundStatus(t, resp)
+
+	t.Run("rollback", func(t *testing.T) {
+		patch := &model.Config{}
+		patch.TeamSettings.SiteName = model.NewString("MyLaterName")
+		_, resp := th.SystemAdminClient.PatchConfig(patch)
+		CheckNoError(t, resp)
+
+		_, resp = th.Client.RollbackConfig(audit.Id)
+		CheckForbiddenStatus(t, resp)
+
+		cfg, resp := th.SystemAdminClient.RollbackConfig(audit.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, "MyAuditedName", *cfg.TeamSettings.SiteName)
+		assert.Equal(t, "supersecret", *th.App.Config().EmailSettings.SMTPPassword)
+
+		audits, resp := th.SystemAdminClient.GetConfigAudits(0, 1)
+		CheckNoError(t, resp)
+		require.Len(t, audits, 1)
+		assert.Equal(t, audit.Id, audits[0].RollbackOf)
+	})
+
+	t.Run("rollback restricted", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = false })
+
+		_, resp := th.SystemAdminClient.RollbackConfig(audit.Id)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.SiteName = *originalCfg.TeamSettings.SiteName })
+}
+
 func TestGetEnvironmentConfig(t *testing.T) {
 	os.Setenv("MM_SERVICESETTINGS_SITEURL", "http://example.mattermost.com")
 	os.Setenv("MM_SERVICESETTINGS_ENABLECUSTOMEMOJI", "true")
@@ -0,0 +1,143 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitContentFilter() {
+	api.BaseRoutes.ContentFilters.Handle("", api.ApiSessionRequired(createContentFilter)).Methods("POST")
+	api.BaseRoutes.ContentFilters.Handle("", api.ApiSessionRequired(getContentFilters)).Methods("GET")
+	api.BaseRoutes.ContentFilter.Handle("", api.ApiSessionRequired(getContentFilter)).Methods("GET")
+	api.BaseRoutes.ContentFilter.Handle("", api.ApiSessionRequired(updateContentFilter)).Methods("PUT")
+	api.BaseRoutes.ContentFilter.Handle("", api.ApiSessionRequired(deleteContentFilter)).Methods("DELETE")
+}
+
+func createContentFilter(c *Context, w http.ResponseWriter, r *http.Request) {
+	filter := model.ContentFilterFromJson(r.Body)
+	if filter == nil {
+		c.SetInvalidParam("content_filter")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	filter.CreatorId = c.App.Session.UserId
+
+	filter, err := c.App.CreateContentFilter(filter)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - content_filter_id=" + filter.Id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(filter.ToJson()))
+}
+
+func getContentFilters(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	filters, err := c.App.GetContentFilters(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ContentFilterListToJson(filters)))
+}
+
+func getContentFilter(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireContentFilterId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	filter, err := c.App.GetContentFilter(c.Params.ContentFilterId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(filter.ToJson()))
+}
+
+func updateContentFilter(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireContentFilterId()
+	if c.Err != nil {
+		return
+	}
+
+	updatedFilter := model.ContentFilterFromJson(r.Body)
+	if updatedFilter == nil {
+		c.SetInvalidParam("content_filter")
+		return
+	}
+
+	// The filter being updated in the payload must be the same one as indicated in the URL.
+	if updatedFilter.Id != c.Params.ContentFilterId {
+		c.SetInvalidParam("content_filter_id")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	oldFilter, err := c.App.GetContentFilter(c.Params.ContentFilterId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	filter, err := c.App.UpdateContentFilter(oldFilter, updatedFilter)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success")
+	w.Write([]byte(filter.ToJson()))
+}
+
+func deleteContentFilter(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireContentFilterId()
+	if c.Err != nil {
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.DeleteContentFilter(c.Params.ContentFilterId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success")
+	ReturnStatusOK(w)
+}
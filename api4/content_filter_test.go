@@ -0,0 +1,76 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestContentFilters(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	filter := &model.ContentFilter{
+		TeamId: th.BasicTeam.Id,
+		Name:   "profanity",
+		Action: model.CONTENT_FILTER_ACTION_BLOCK,
+		Words:  model.StringArray{"darn"},
+	}
+
+	_, resp := th.Client.CreateContentFilter(filter)
+	CheckForbiddenStatus(t, resp)
+
+	created, resp := th.SystemAdminClient.CreateContentFilter(filter)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.SystemAdminUser.Id, created.CreatorId)
+
+	_, resp = th.SystemAdminClient.CreateContentFilter(&model.ContentFilter{Name: "invalid", Action: model.CONTENT_FILTER_ACTION_BLOCK, Patterns: model.StringArray{"(unclosed"}})
+	CheckBadRequestStatus(t, resp)
+
+	got, resp := th.SystemAdminClient.GetContentFilter(created.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, created.Words, got.Words)
+
+	_, resp = th.Client.GetContentFilter(created.Id)
+	CheckForbiddenStatus(t, resp)
+
+	filters, resp := th.SystemAdminClient.GetContentFilters(0, 100)
+	CheckNoError(t, resp)
+	assert.Len(t, filters, 1)
+
+	_, resp = th.Client.GetContentFilters(0, 100)
+	CheckForbiddenStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ContentFilterSettings.Enable = true })
+
+	_, resp = th.Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "darn it"})
+	CheckBadRequestStatus(t, resp)
+
+	created.Action = model.CONTENT_FILTER_ACTION_MASK
+	updated, resp := th.SystemAdminClient.UpdateContentFilter(created)
+	CheckNoError(t, resp)
+	assert.Equal(t, model.CONTENT_FILTER_ACTION_MASK, updated.Action)
+
+	_, resp = th.Client.UpdateContentFilter(created)
+	CheckForbiddenStatus(t, resp)
+
+	post, resp := th.Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "darn it"})
+	CheckNoError(t, resp)
+	assert.Equal(t, "**** it", post.Message)
+
+	_, resp = th.Client.DeleteContentFilter(created.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.DeleteContentFilter(created.Id)
+	CheckNoError(t, resp)
+	assert.True(t, ok)
+
+	_, resp = th.SystemAdminClient.GetContentFilter(created.Id)
+	CheckNotFoundStatus(t, resp)
+}
@@ -4,9 +4,11 @@
 package api4
 
 import (
+	"bytes"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/mattermost/mattermost-server/app"
@@ -27,6 +29,16 @@ func (api *API) InitEmoji() {
 	api.BaseRoutes.Emoji.Handle("", api.ApiSessionRequired(getEmoji)).Methods("GET")
 	api.BaseRoutes.EmojiByName.Handle("", api.ApiSessionRequired(getEmojiByName)).Methods("GET")
 	api.BaseRoutes.Emoji.Handle("/image", api.ApiSessionRequiredTrustRequester(getEmojiImage)).Methods("GET")
+	api.BaseRoutes.Emojis.Handle("/usage", api.ApiSessionRequired(getEmojiUsage)).Methods("GET")
+	api.BaseRoutes.Emojis.Handle("/export", api.ApiSessionRequired(exportEmojiPack)).Methods("GET")
+	api.BaseRoutes.Emojis.Handle("/import", api.ApiSessionRequired(importEmojiPack)).Methods("POST")
+	api.BaseRoutes.PendingEmojis.Handle("", api.ApiSessionRequired(getPendingEmojiList)).Methods("GET")
+	api.BaseRoutes.PendingEmoji.Handle("/image", api.ApiSessionRequiredTrustRequester(getPendingEmojiImage)).Methods("GET")
+	api.BaseRoutes.PendingEmoji.Handle("/approve", api.ApiSessionRequired(approvePendingEmoji)).Methods("POST")
+	api.BaseRoutes.PendingEmoji.Handle("/reject", api.ApiSessionRequired(rejectPendingEmoji)).Methods("POST")
+	api.BaseRoutes.EmojiAliases.Handle("", api.ApiSessionRequired(getEmojiAliases)).Methods("GET")
+	api.BaseRoutes.EmojiAliases.Handle("", api.ApiSessionRequired(createEmojiAlias)).Methods("POST")
+	api.BaseRoutes.EmojiAlias.Handle("", api.ApiSessionRequired(deleteEmojiAlias)).Methods("DELETE")
 }
 
 func createEmoji(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -89,6 +101,9 @@ func createEmoji(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if newEmoji.PendingApproval {
+		w.WriteHeader(http.StatusAccepted)
+	}
 	w.Write([]byte(newEmoji.ToJson()))
 }
 
@@ -267,3 +282,265 @@ func autocompleteEmojis(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	w.Write([]byte(model.EmojiListToJson(emojis)))
 }
+
+func getEmojiUsage(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	usage, err := c.App.GetEmojiUsage(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.EmojiUsageListToJson(usage)))
+}
+
+func exportEmojiPack(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := c.App.ExportEmojiPack(buf); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("exported an emoji pack")
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", "attachment;filename=\"emoji.zip\"")
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.Write(buf.Bytes())
+}
+
+func importEmojiPack(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer io.Copy(ioutil.Discard, r.Body)
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if r.ContentLength > app.MaxEmojiPackFileSize {
+		c.Err = model.NewAppError("importEmojiPack", "api.emoji.import_pack.too_large.app_error", nil, "", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := r.ParseMultipartForm(app.MaxEmojiPackFileSize); err != nil {
+		c.Err = model.NewAppError("importEmojiPack", "api.emoji.create.parse.app_error", nil, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	files := r.MultipartForm.File["pack"]
+	if len(files) == 0 {
+		c.SetInvalidParam("pack")
+		return
+	}
+
+	fileData, err := files[0].Open()
+	if err != nil {
+		c.Err = model.NewAppError("importEmojiPack", "api.emoji.upload.open.app_error", nil, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer fileData.Close()
+
+	result, appErr := c.App.ImportEmojiPack(c.App.Session.UserId, fileData, files[0].Size)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	c.LogAudit("imported=" + strconv.Itoa(len(result.Imported)) + ", skipped=" + strconv.Itoa(len(result.Errors)))
+	w.Write([]byte(result.ToJson()))
+}
+
+func getPendingEmojiList(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	list, err := c.App.GetPendingEmojiList(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PendingEmojiListToJson(list)))
+}
+
+func getPendingEmojiImage(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePendingEmojiId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	image, imageType, err := c.App.GetPendingEmojiImage(c.Params.PendingEmojiId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/"+imageType)
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write(image)
+}
+
+func approvePendingEmoji(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePendingEmojiId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	pendingEmoji, err := c.App.GetPendingEmoji(c.Params.PendingEmojiId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	emoji, err := c.App.ApprovePendingEmoji(pendingEmoji)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + emoji.Name)
+	w.Write([]byte(emoji.ToJson()))
+}
+
+func rejectPendingEmoji(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePendingEmojiId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	pendingEmoji, err := c.App.GetPendingEmoji(c.Params.PendingEmojiId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := c.App.RejectPendingEmoji(pendingEmoji); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + pendingEmoji.Name)
+	ReturnStatusOK(w)
+}
+
+// sessionHasEmojiPermission checks an emoji permission at system level or on any team of the user, since emoji are
+// shared by every team.
+func sessionHasEmojiPermission(c *Context, permission *model.Permission) (bool, *model.AppError) {
+	if c.App.SessionHasPermissionTo(c.App.Session, permission) {
+		return true, nil
+	}
+
+	memberships, err := c.App.GetTeamMembersForUser(c.App.Session.UserId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, membership := range memberships {
+		if c.App.SessionHasPermissionToTeam(c.App.Session, membership.TeamId, permission) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func getEmojiAliases(c *Context, w http.ResponseWriter, r *http.Request) {
+	aliases, err := c.App.GetEmojiAliases()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.EmojiAliasListToJson(aliases)))
+}
+
+func createEmojiAlias(c *Context, w http.ResponseWriter, r *http.Request) {
+	alias := model.EmojiAliasFromJson(r.Body)
+	if alias == nil {
+		c.SetInvalidParam("alias")
+		return
+	}
+
+	if ok, err := sessionHasEmojiPermission(c, model.PERMISSION_CREATE_EMOJIS); err != nil {
+		c.Err = err
+		return
+	} else if !ok {
+		c.SetPermissionError(model.PERMISSION_CREATE_EMOJIS)
+		return
+	}
+
+	alias.CreatorId = c.App.Session.UserId
+
+	alias, err := c.App.CreateEmojiAlias(alias)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("alias=" + alias.Alias + ", emoji_name=" + alias.EmojiName)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(alias.ToJson()))
+}
+
+func deleteEmojiAlias(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireEmojiAlias()
+	if c.Err != nil {
+		return
+	}
+
+	alias, err := c.App.GetEmojiAlias(c.Params.EmojiAlias)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	permissions := []*model.Permission{model.PERMISSION_DELETE_EMOJIS}
+	if alias.CreatorId != c.App.Session.UserId {
+		permissions = append(permissions, model.PERMISSION_DELETE_OTHERS_EMOJIS)
+	}
+
+	for _, permission := range permissions {
+		if ok, err := sessionHasEmojiPermission(c, permission); err != nil {
+			c.Err = err
+			return
+		} else if !ok {
+			c.SetPermissionError(permission)
+			return
+		}
+	}
+
+	if err := c.App.DeleteEmojiAlias(alias); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("alias=" + alias.Alias)
+	ReturnStatusOK(w)
+}
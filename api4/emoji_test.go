@@ -4,9 +4,11 @@
 package api4
 
 import (
+	"archive/zip"
 	"bytes"
 	"image"
 	_ "image/gif"
+	"net/http"
 	"testing"
 
 	"github.com/mattermost/mattermost-server/app"
@@ -14,6 +16,7 @@ import (
 	"github.com/mattermost/mattermost-server/utils"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateEmoji(t *testing.T) {
@@ -719,3 +722,334 @@ func TestAutocompleteEmoji(t *testing.T) {
 	_, resp = Client.AutocompleteEmoji(searchTerm1, "")
 	CheckUnauthorizedStatus(t, resp)
 }
+
+func TestCreateEmojiQuota(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableCustomEmoji = true
+		*cfg.ServiceSettings.CustomEmojiQuotaPerUser = 1
+	})
+
+	emoji := &model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}
+	_, resp := Client.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckNoError(t, resp)
+
+	emoji = &model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}
+	_, resp = Client.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckForbiddenStatus(t, resp)
+	CheckErrorMessage(t, resp, "api.emoji.create.quota_exceeded.app_error")
+
+	// system admins aren't held to the quota
+	emoji = &model.Emoji{CreatorId: th.SystemAdminUser.Id, Name: model.NewId()}
+	_, resp = th.SystemAdminClient.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckNoError(t, resp)
+	emoji = &model.Emoji{CreatorId: th.SystemAdminUser.Id, Name: model.NewId()}
+	_, resp = th.SystemAdminClient.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckNoError(t, resp)
+}
+
+func TestPendingEmoji(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableCustomEmoji = true
+		*cfg.ServiceSettings.RequireCustomEmojiApproval = true
+	})
+
+	emoji := &model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}
+	pending, resp := Client.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckNoError(t, resp)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.True(t, pending.PendingApproval)
+
+	_, resp = Client.GetEmojiByName(emoji.Name)
+	CheckNotFoundStatus(t, resp)
+
+	// the name is taken while the emoji waits for approval
+	_, resp = Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: emoji.Name}, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetPendingEmojiList(0, 100)
+	CheckForbiddenStatus(t, resp)
+
+	list, resp := th.SystemAdminClient.GetPendingEmojiList(0, 100)
+	CheckNoError(t, resp)
+	require.NotEmpty(t, list)
+	assert.Equal(t, pending.Id, list[len(list)-1].Id)
+	assert.Equal(t, emoji.Name, list[len(list)-1].Name)
+
+	_, resp = Client.GetPendingEmojiImage(pending.Id)
+	CheckForbiddenStatus(t, resp)
+
+	img, resp := th.SystemAdminClient.GetPendingEmojiImage(pending.Id)
+	CheckNoError(t, resp)
+	assert.NotEmpty(t, img)
+
+	_, resp = Client.ApprovePendingEmoji(pending.Id)
+	CheckForbiddenStatus(t, resp)
+
+	approved, resp := th.SystemAdminClient.ApprovePendingEmoji(pending.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, pending.Id, approved.Id)
+
+	received, resp := Client.GetEmojiByName(emoji.Name)
+	CheckNoError(t, resp)
+	assert.Equal(t, approved.Id, received.Id)
+
+	_, resp = Client.GetEmojiImage(approved.Id)
+	CheckNoError(t, resp)
+
+	_, resp = th.SystemAdminClient.ApprovePendingEmoji(pending.Id)
+	CheckNotFoundStatus(t, resp)
+
+	t.Run("reject", func(t *testing.T) {
+		emoji := &model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}
+		pending, resp := Client.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+		CheckNoError(t, resp)
+
+		_, resp = Client.RejectPendingEmoji(pending.Id)
+		CheckForbiddenStatus(t, resp)
+
+		ok, resp := th.SystemAdminClient.RejectPendingEmoji(pending.Id)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		list, resp := th.SystemAdminClient.GetPendingEmojiList(0, 100)
+		CheckNoError(t, resp)
+		for _, emoji := range list {
+			assert.NotEqual(t, pending.Id, emoji.Id)
+		}
+
+		_, resp = Client.GetEmojiByName(emoji.Name)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("system admins don't need approval", func(t *testing.T) {
+		emoji := &model.Emoji{CreatorId: th.SystemAdminUser.Id, Name: model.NewId()}
+		newEmoji, resp := th.SystemAdminClient.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+		CheckNoError(t, resp)
+		assert.False(t, newEmoji.PendingApproval)
+
+		_, resp = Client.GetEmojiByName(emoji.Name)
+		CheckNoError(t, resp)
+	})
+}
+
+func TestGetEmojiUsage(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	unused, resp := Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckNoError(t, resp)
+	used, resp := Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckNoError(t, resp)
+
+	_, resp = Client.SaveReaction(&model.Reaction{UserId: th.BasicUser.Id, PostId: th.BasicPost.Id, EmojiName: used.Name})
+	CheckNoError(t, resp)
+
+	_, resp = Client.GetEmojiUsage(0, 100)
+	CheckForbiddenStatus(t, resp)
+
+	usage, resp := th.SystemAdminClient.GetEmojiUsage(0, 200)
+	CheckNoError(t, resp)
+
+	counts := map[string]int64{}
+	for _, u := range usage {
+		counts[u.EmojiId] = u.ReactionCount
+	}
+	require.Contains(t, counts, unused.Id)
+	require.Contains(t, counts, used.Id)
+	assert.Equal(t, int64(0), counts[unused.Id])
+	assert.Equal(t, int64(1), counts[used.Id])
+}
+
+func TestEmojiPack(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	emoji, resp := Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckNoError(t, resp)
+
+	_, resp = Client.ExportEmojiPack()
+	CheckForbiddenStatus(t, resp)
+
+	pack, resp := th.SystemAdminClient.ExportEmojiPack()
+	CheckNoError(t, resp)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(pack), int64(len(pack)))
+	require.Nil(t, err)
+	names := []string{}
+	for _, file := range zipReader.File {
+		names = append(names, file.Name)
+	}
+	assert.Contains(t, names, model.EMOJI_PACK_MANIFEST_NAME)
+	assert.Contains(t, names, emoji.Name+".gif")
+
+	_, resp = Client.ImportEmojiPack(pack, "emoji.zip")
+	CheckForbiddenStatus(t, resp)
+
+	// the exported emoji already exists
+	result, resp := th.SystemAdminClient.ImportEmojiPack(pack, "emoji.zip")
+	CheckNoError(t, resp)
+	assert.Empty(t, result.Imported)
+	assert.Contains(t, result.Errors, emoji.Name)
+
+	// packs without a manifest name emoji after their images
+	buf := &bytes.Buffer{}
+	writer := zip.NewWriter(buf)
+	name := model.NewId()
+	f, err := writer.Create(name + ".png")
+	require.Nil(t, err)
+	_, err = f.Write(utils.CreateTestPng(t, 10, 10))
+	require.Nil(t, err)
+	f, err = writer.Create("not-an-image.png")
+	require.Nil(t, err)
+	_, err = f.Write([]byte("text"))
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+
+	result, resp = th.SystemAdminClient.ImportEmojiPack(buf.Bytes(), "emoji.zip")
+	CheckNoError(t, resp)
+	require.Len(t, result.Imported, 1)
+	assert.Equal(t, name, result.Imported[0].Name)
+	assert.Contains(t, result.Errors, "not-an-image")
+
+	_, resp = Client.GetEmojiByName(name)
+	CheckNoError(t, resp)
+
+	_, resp = th.SystemAdminClient.ImportEmojiPack([]byte("not a zip"), "emoji.zip")
+	CheckBadRequestStatus(t, resp)
+}
+
+func TestCreateAnimatedEmoji(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	emoji, resp := Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckNoError(t, resp)
+	assert.False(t, emoji.Animated)
+
+	emoji, resp = Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}, utils.CreateTestAnimatedGif(t, 10, 10, 10), "image.gif")
+	CheckNoError(t, resp)
+	assert.True(t, emoji.Animated)
+
+	received, resp := Client.GetEmoji(emoji.Id)
+	CheckNoError(t, resp)
+	assert.True(t, received.Animated)
+
+	emoji, resp = Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}, utils.CreateTestAnimatedPng(t, 10, 10, 10), "image.png")
+	CheckNoError(t, resp)
+	assert.True(t, emoji.Animated)
+
+	_, resp = Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}, utils.CreateTestAnimatedPng(t, 200, 10, 10), "image.png")
+	CheckBadRequestStatus(t, resp)
+	CheckErrorMessage(t, resp, "api.emoji.upload.large_image.animated_png.app_error")
+
+	// large images are stored at the size emoji are displayed at
+	emoji, resp = Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}, utils.CreateTestAnimatedGif(t, 500, 500, 3), "image.gif")
+	CheckNoError(t, resp)
+	assert.True(t, emoji.Animated)
+
+	emojiImage, resp := Client.GetEmojiImage(emoji.Id)
+	CheckNoError(t, resp)
+	config, imageType, err := image.DecodeConfig(bytes.NewReader(emojiImage))
+	require.Nil(t, err)
+	assert.Equal(t, "gif", imageType)
+	assert.Equal(t, app.MaxEmojiWidth, config.Width)
+	assert.Equal(t, app.MaxEmojiHeight, config.Height)
+}
+
+func TestEmojiAliases(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	emoji, resp := Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()}, utils.CreateTestGif(t, 10, 10), "image.gif")
+	CheckNoError(t, resp)
+
+	alias, resp := Client.CreateEmojiAlias(&model.EmojiAlias{Alias: model.NewId(), EmojiName: emoji.Name})
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, emoji.Name, alias.EmojiName)
+	assert.Equal(t, th.BasicUser.Id, alias.CreatorId)
+
+	systemAlias, resp := Client.CreateEmojiAlias(&model.EmojiAlias{Alias: model.NewId(), EmojiName: "+1"})
+	CheckNoError(t, resp)
+
+	aliases, resp := th.Client.GetEmojiAliases()
+	CheckNoError(t, resp)
+	assert.Contains(t, aliases, alias)
+	assert.Contains(t, aliases, systemAlias)
+
+	t.Run("invalid aliases", func(t *testing.T) {
+		// taken by a custom emoji
+		_, resp := Client.CreateEmojiAlias(&model.EmojiAlias{Alias: emoji.Name, EmojiName: "+1"})
+		CheckBadRequestStatus(t, resp)
+
+		// taken by another alias
+		_, resp = Client.CreateEmojiAlias(&model.EmojiAlias{Alias: alias.Alias, EmojiName: "+1"})
+		CheckBadRequestStatus(t, resp)
+
+		// taken by a system emoji
+		_, resp = Client.CreateEmojiAlias(&model.EmojiAlias{Alias: "smile", EmojiName: "+1"})
+		CheckBadRequestStatus(t, resp)
+
+		// alias of an alias
+		_, resp = Client.CreateEmojiAlias(&model.EmojiAlias{Alias: model.NewId(), EmojiName: alias.Alias})
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "app.emoji_alias.alias_of_alias.app_error")
+
+		// alias of a missing emoji
+		_, resp = Client.CreateEmojiAlias(&model.EmojiAlias{Alias: model.NewId(), EmojiName: model.NewId()})
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "app.emoji_alias.emoji_not_found.app_error")
+	})
+
+	t.Run("emoji can't be named after an alias", func(t *testing.T) {
+		_, resp := Client.CreateEmoji(&model.Emoji{CreatorId: th.BasicUser.Id, Name: alias.Alias}, utils.CreateTestGif(t, 10, 10), "image.gif")
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.emoji.create.duplicate.app_error")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, resp := th.CreateClient().DeleteEmojiAlias(systemAlias.Alias)
+		CheckUnauthorizedStatus(t, resp)
+
+		Client2 := th.CreateClient()
+		th.LoginBasic2WithClient(Client2)
+		_, resp = Client2.DeleteEmojiAlias(systemAlias.Alias)
+		CheckForbiddenStatus(t, resp)
+
+		ok, resp := Client.DeleteEmojiAlias(systemAlias.Alias)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		_, resp = Client.DeleteEmojiAlias(systemAlias.Alias)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("deleting an emoji deletes its aliases", func(t *testing.T) {
+		_, resp := Client.DeleteEmoji(emoji.Id)
+		CheckNoError(t, resp)
+
+		aliases, resp := Client.GetEmojiAliases()
+		CheckNoError(t, resp)
+		assert.NotContains(t, aliases, alias)
+	})
+}
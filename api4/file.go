@@ -60,6 +60,7 @@ func (api *API) InitFile() {
 	api.BaseRoutes.File.Handle("/link", api.ApiSessionRequired(getFileLink)).Methods("GET")
 	api.BaseRoutes.File.Handle("/preview", api.ApiSessionRequiredTrustRequester(getFilePreview)).Methods("GET")
 	api.BaseRoutes.File.Handle("/info", api.ApiSessionRequired(getFileInfo)).Methods("GET")
+	api.BaseRoutes.File.Handle("/verdict", api.ApiSessionRequired(setFileInfoVerdict)).Methods("PUT")
 
 	api.BaseRoutes.PublicFile.Handle("", api.ApiHandler(getPublicFile)).Methods("GET")
 
@@ -538,6 +539,11 @@ func getFile(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if info.IsBlocked() {
+		c.Err = model.NewAppError("getFile", "api.file.blocked.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		return
+	}
+
 	fileReader, err := c.App.FileReader(info.Path)
 	if err != nil {
 		c.Err = err
@@ -575,6 +581,11 @@ func getFileThumbnail(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if info.IsBlocked() {
+		c.Err = model.NewAppError("getFileThumbnail", "api.file.blocked.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		return
+	}
+
 	if info.ThumbnailPath == "" {
 		c.Err = model.NewAppError("getFileThumbnail", "api.file.get_file_thumbnail.no_thumbnail.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
 		return
@@ -617,6 +628,11 @@ func getFileLink(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if info.IsBlocked() {
+		c.Err = model.NewAppError("getFileLink", "api.file.blocked.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		return
+	}
+
 	if len(info.PostId) == 0 {
 		c.Err = model.NewAppError("getPublicLink", "api.file.get_public_link.no_post.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
 		return
@@ -650,6 +666,11 @@ func getFilePreview(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if info.IsBlocked() {
+		c.Err = model.NewAppError("getFilePreview", "api.file.blocked.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		return
+	}
+
 	if info.PreviewPath == "" {
 		c.Err = model.NewAppError("getFilePreview", "api.file.get_file_preview.no_preview.app_error", nil, "file_id="+info.Id, http.StatusBadRequest)
 		return
@@ -691,6 +712,36 @@ func getFileInfo(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(info.ToJson()))
 }
 
+func setFileInfoVerdict(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireFileId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.MapFromJson(r.Body)
+
+	verdict := props["verdict"]
+	if !model.IsValidFileVerdict(verdict) {
+		c.SetInvalidParam("verdict")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	info, err := c.App.SetFileInfoVerdict(c.Params.FileId, verdict, props["reason"])
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("file_id=" + info.Id + " verdict=" + verdict)
+
+	w.Write([]byte(info.ToJson()))
+}
+
 func getPublicFile(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireFileId()
 	if c.Err != nil {
@@ -722,6 +773,12 @@ func getPublicFile(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if info.IsBlocked() {
+		c.Err = model.NewAppError("getPublicFile", "api.file.blocked.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		utils.RenderWebAppError(c.App.Config(), w, r, c.Err, c.App.AsymmetricSigningKey())
+		return
+	}
+
 	fileReader, err := c.App.FileReader(info.Path)
 	if err != nil {
 		c.Err = err
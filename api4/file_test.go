@@ -998,6 +998,87 @@ func TestGetFileInfo(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestSetFileInfoVerdict(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	channel := th.BasicChannel
+
+	if *th.App.Config().FileSettings.DriverName == "" {
+		t.Skip("skipping because no file driver is enabled")
+	}
+
+	sent, err := testutils.ReadTestFile("test.png")
+	require.Nil(t, err)
+
+	fileResp, resp := Client.UploadFile(sent, channel.Id, "test.png")
+	CheckNoError(t, resp)
+	fileId := fileResp.FileInfos[0].Id
+
+	post, resp := Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "attachment", FileIds: []string{fileId}})
+	CheckNoError(t, resp)
+
+	// Wait a bit for files to ready
+	time.Sleep(2 * time.Second)
+
+	WebSocketClient, err := th.CreateWebSocketClient()
+	require.Nil(t, err)
+	WebSocketClient.Listen()
+	defer WebSocketClient.Close()
+
+	_, resp = Client.SetFileInfoVerdict(fileId, model.FILE_VERDICT_BLOCKED, "malware")
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.SetFileInfoVerdict(fileId, "junk", "")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.SetFileInfoVerdict(model.NewId(), model.FILE_VERDICT_CLEAN, "")
+	CheckNotFoundStatus(t, resp)
+
+	info, resp := th.SystemAdminClient.SetFileInfoVerdict(fileId, model.FILE_VERDICT_FLAGGED, "contains a credit card number")
+	CheckNoError(t, resp)
+	assert.Equal(t, model.FILE_VERDICT_FLAGGED, info.Verdict)
+	assert.Equal(t, "contains a credit card number", info.VerdictReason)
+	assert.NotZero(t, info.VerdictAt)
+
+	_, resp = Client.GetFile(fileId)
+	CheckNoError(t, resp)
+
+	timeout := time.After(2 * time.Second)
+	waiting := true
+	for waiting {
+		select {
+		case event := <-WebSocketClient.EventChannel:
+			if event.Event == model.WEBSOCKET_EVENT_POST_METADATA_UPDATED {
+				updated := model.PostFromJson(strings.NewReader(event.Data["post"].(string)))
+				require.Equal(t, post.Id, updated.Id)
+				require.NotNil(t, updated.Metadata)
+				require.Len(t, updated.Metadata.Files, 1)
+				assert.Equal(t, model.FILE_VERDICT_FLAGGED, updated.Metadata.Files[0].Verdict)
+				waiting = false
+			}
+		case <-timeout:
+			t.Fatal("should have received a post metadata updated event")
+		}
+	}
+
+	_, resp = th.SystemAdminClient.SetFileInfoVerdict(fileId, model.FILE_VERDICT_BLOCKED, "malware")
+	CheckNoError(t, resp)
+
+	_, resp = Client.GetFile(fileId)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = Client.GetFileThumbnail(fileId)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = Client.GetFilePreview(fileId)
+	CheckForbiddenStatus(t, resp)
+
+	info, resp = Client.GetFileInfo(fileId)
+	CheckNoError(t, resp)
+	assert.True(t, info.IsBlocked())
+}
+
 func TestGetPublicFile(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
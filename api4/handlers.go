@@ -18,6 +18,7 @@ func (api *API) ApiHandler(h func(*Context, http.ResponseWriter, *http.Request))
 	handler := &web.Handler{
 		GetGlobalAppOptions: api.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         web.GetHandlerName(h),
 		RequireSession:      false,
 		TrustRequester:      false,
 		RequireMfa:          false,
@@ -35,6 +36,7 @@ func (api *API) ApiSessionRequired(h func(*Context, http.ResponseWriter, *http.R
 	handler := &web.Handler{
 		GetGlobalAppOptions: api.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         web.GetHandlerName(h),
 		RequireSession:      true,
 		TrustRequester:      false,
 		RequireMfa:          true,
@@ -47,6 +49,26 @@ func (api *API) ApiSessionRequired(h func(*Context, http.ResponseWriter, *http.R
 
 }
 
+// ApiSessionRequiredWithOAuthScope provides a handler for API endpoints which require the user to be logged in, and
+// which OAuth apps granted only granular scopes may use when they were granted the given scope.
+func (api *API) ApiSessionRequiredWithOAuthScope(h func(*Context, http.ResponseWriter, *http.Request), scope string) http.Handler {
+	handler := &web.Handler{
+		GetGlobalAppOptions: api.GetGlobalAppOptions,
+		HandleFunc:          h,
+		HandlerName:         web.GetHandlerName(h),
+		RequireSession:      true,
+		TrustRequester:      false,
+		RequireMfa:          true,
+		IsStatic:            false,
+		OAuthScope:          scope,
+	}
+	if *api.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
+		return gziphandler.GzipHandler(handler)
+	}
+	return handler
+
+}
+
 // ApiSessionRequiredMfa provides a handler for API endpoints which require a logged-in user session  but when accessed,
 // if MFA is enabled, the MFA process is not yet complete, and therefore the requirement to have completed the MFA
 // authentication must be waived.
@@ -54,6 +76,7 @@ func (api *API) ApiSessionRequiredMfa(h func(*Context, http.ResponseWriter, *htt
 	handler := &web.Handler{
 		GetGlobalAppOptions: api.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         web.GetHandlerName(h),
 		RequireSession:      true,
 		TrustRequester:      false,
 		RequireMfa:          false,
@@ -73,6 +96,7 @@ func (api *API) ApiHandlerTrustRequester(h func(*Context, http.ResponseWriter, *
 	handler := &web.Handler{
 		GetGlobalAppOptions: api.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         web.GetHandlerName(h),
 		RequireSession:      false,
 		TrustRequester:      true,
 		RequireMfa:          false,
@@ -91,6 +115,7 @@ func (api *API) ApiSessionRequiredTrustRequester(h func(*Context, http.ResponseW
 	handler := &web.Handler{
 		GetGlobalAppOptions: api.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         web.GetHandlerName(h),
 		RequireSession:      true,
 		TrustRequester:      true,
 		RequireMfa:          true,
@@ -0,0 +1,79 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitHashtag() {
+	api.BaseRoutes.Team.Handle("/hashtags/trending", api.ApiSessionRequired(getTrendingHashtags)).Methods("GET")
+	api.BaseRoutes.Team.Handle("/hashtags/{hashtag:[^/]+}/posts", api.ApiSessionRequiredWithOAuthScope(getHashtagPosts, model.OAUTH_SCOPE_READ_POSTS)).Methods("GET")
+}
+
+func getTrendingHashtags(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	since := model.GetMillis() - model.HASHTAG_TRENDING_DEFAULT_PERIOD
+	if sinceString := r.URL.Query().Get("since"); len(sinceString) > 0 {
+		var parseError error
+		since, parseError = strconv.ParseInt(sinceString, 10, 64)
+		if parseError != nil || since < 0 {
+			c.SetInvalidParam("since")
+			return
+		}
+	}
+
+	limit := model.HASHTAG_TRENDING_DEFAULT_LIMIT
+	if limitString := r.URL.Query().Get("limit"); len(limitString) > 0 {
+		var parseError error
+		limit, parseError = strconv.Atoi(limitString)
+		if parseError != nil || limit <= 0 {
+			c.SetInvalidParam("limit")
+			return
+		}
+		if limit > model.HASHTAG_TRENDING_MAX_LIMIT {
+			limit = model.HASHTAG_TRENDING_MAX_LIMIT
+		}
+	}
+
+	trending, err := c.App.GetTrendingHashtags(c.Params.TeamId, since, limit)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.HashtagCountListToJson(trending)))
+}
+
+func getHashtagPosts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId().RequireHashtag()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	postList, err := c.App.GetHashtagPostsForUser(c.App.Session.UserId, c.Params.TeamId, c.Params.Hashtag, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(c.App.PreparePostListForClient(postList).ToJson()))
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestGetTrendingHashtags(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	for _, message := range []string{"#deploy #incident", "#incident", "#release"} {
+		_, resp := Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: message})
+		CheckNoError(t, resp)
+	}
+
+	trending, resp := Client.GetTrendingHashtags(th.BasicTeam.Id, 0, 0)
+	CheckNoError(t, resp)
+	require.Len(t, trending, 3)
+	assert.Equal(t, &model.HashtagCount{Hashtag: "#incident", Count: 2}, trending[0])
+
+	trending, resp = Client.GetTrendingHashtags(th.BasicTeam.Id, 0, 1)
+	CheckNoError(t, resp)
+	assert.Len(t, trending, 1)
+
+	trending, resp = Client.GetTrendingHashtags(th.BasicTeam.Id, model.GetMillis()+1000, 0)
+	CheckNoError(t, resp)
+	assert.Empty(t, trending)
+
+	_, err := Client.DoApiGet(Client.GetTeamHashtagsRoute(th.BasicTeam.Id)+"/trending?limit=abc", "")
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+
+	_, resp = Client.GetTrendingHashtags(model.NewId(), 0, 0)
+	CheckForbiddenStatus(t, resp)
+
+	Client.Logout()
+	_, resp = Client.GetTrendingHashtags(th.BasicTeam.Id, 0, 0)
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestGetHashtagPosts(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	post, resp := Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "#Feed one"})
+	CheckNoError(t, resp)
+	privateChannel := th.CreatePrivateChannel()
+	private, resp := Client.CreatePost(&model.Post{ChannelId: privateChannel.Id, Message: "#feed two"})
+	CheckNoError(t, resp)
+
+	list, resp := Client.GetHashtagPosts(th.BasicTeam.Id, "#feed", 0, 60)
+	CheckNoError(t, resp)
+	assert.Equal(t, []string{private.Id, post.Id}, list.Order)
+
+	list, resp = Client.GetHashtagPosts(th.BasicTeam.Id, "FEED", 1, 1)
+	CheckNoError(t, resp)
+	assert.Equal(t, []string{post.Id}, list.Order)
+
+	// Posts in channels the user is not a member of are not returned
+	th.LoginBasic2()
+	list, resp = Client.GetHashtagPosts(th.BasicTeam.Id, "feed", 0, 60)
+	CheckNoError(t, resp)
+	assert.Equal(t, []string{post.Id}, list.Order)
+
+	_, resp = Client.GetHashtagPosts(th.BasicTeam.Id, "not-a-hashtag!", 0, 60)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetHashtagPosts(model.NewId(), "feed", 0, 60)
+	CheckForbiddenStatus(t, resp)
+
+	Client.Logout()
+	_, resp = Client.GetHashtagPosts(th.BasicTeam.Id, "feed", 0, 60)
+	CheckUnauthorizedStatus(t, resp)
+}
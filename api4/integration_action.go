@@ -96,7 +96,8 @@ func submitDialog(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if submit.URL == "" {
+	// Dialogs opened by the server are submitted to the URL they were opened with
+	if submit.URL == "" && submit.DialogId == "" {
 		c.SetInvalidParam("url")
 		return
 	}
@@ -213,6 +213,14 @@ func TestSubmitDialog(t *testing.T) {
 	CheckBadRequestStatus(t, resp)
 	assert.Nil(t, submitResp)
 
+	// Dialogs opened by the server don't need a URL, but their id must be known
+	submit.DialogId = model.NewId()
+	submitResp, resp = Client.SubmitInteractiveDialog(submit)
+	CheckBadRequestStatus(t, resp)
+	CheckErrorMessage(t, resp, "app.submit_interactive_dialog.expired.app_error")
+	assert.Nil(t, submitResp)
+	submit.DialogId = ""
+
 	submit.URL = ts.URL
 	submit.ChannelId = model.NewId()
 	submitResp, resp = Client.SubmitInteractiveDialog(submit)
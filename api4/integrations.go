@@ -0,0 +1,55 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitIntegrations() {
+	api.BaseRoutes.Integrations.Handle("/export", api.ApiSessionRequired(exportIntegrations)).Methods("GET")
+	api.BaseRoutes.Integrations.Handle("/import", api.ApiSessionRequired(importIntegrations)).Methods("POST")
+}
+
+func exportIntegrations(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	export, err := c.App.ExportIntegrations()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("")
+	w.Write([]byte(export.ToJson()))
+}
+
+func importIntegrations(c *Context, w http.ResponseWriter, r *http.Request) {
+	export := model.IntegrationsExportFromJson(r.Body)
+	if export == nil {
+		c.SetInvalidParam("integrations")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	result, err := c.App.ImportIntegrations(export, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit(fmt.Sprintf("incoming_webhooks=%v outgoing_webhooks=%v commands=%v oauth_apps=%v bots=%v errors=%v",
+		result.IncomingWebhooks, result.OutgoingWebhooks, result.Commands, result.OAuthApps, result.Bots, len(result.Errors)))
+	w.Write([]byte(result.ToJson()))
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestExportImportIntegrations(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableIncomingWebhooks = true
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.EnableCommands = true
+	})
+
+	_, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id, DisplayName: "alerts"})
+	require.Nil(t, err)
+
+	_, err = th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		CreatorId:    th.BasicUser.Id,
+		TeamId:       th.BasicTeam.Id,
+		ChannelId:    th.BasicChannel.Id,
+		TriggerWords: []string{"deploy"},
+		CallbackURLs: []string{"http://example.com/deploy"},
+		DisplayName:  "deploy",
+	})
+	require.Nil(t, err)
+
+	trigger := "trigger" + model.NewId()[:8]
+	_, err = th.App.CreateCommand(&model.Command{
+		CreatorId: th.BasicUser.Id,
+		TeamId:    th.BasicTeam.Id,
+		Trigger:   trigger,
+		Method:    model.COMMAND_METHOD_POST,
+		URL:       "http://example.com/command",
+	})
+	require.Nil(t, err)
+
+	_, resp := th.Client.ExportIntegrations()
+	CheckForbiddenStatus(t, resp)
+
+	export, resp := th.SystemAdminClient.ExportIntegrations()
+	CheckNoError(t, resp)
+	require.NotNil(t, export)
+	assert.Equal(t, model.INTEGRATIONS_EXPORT_VERSION, export.Version)
+
+	var incoming *model.IncomingWebhookExport
+	for _, hook := range export.IncomingWebhooks {
+		if hook.DisplayName == "alerts" {
+			incoming = hook
+		}
+	}
+	require.NotNil(t, incoming)
+	assert.Equal(t, th.BasicTeam.Name, incoming.Team)
+	assert.Equal(t, th.BasicChannel.Name, incoming.Channel)
+	assert.Equal(t, th.BasicUser.Username, incoming.Creator)
+
+	var command *model.CommandExport
+	for _, cmd := range export.Commands {
+		if cmd.Trigger == trigger {
+			command = cmd
+		}
+	}
+	require.NotNil(t, command)
+	assert.Equal(t, model.INTEGRATIONS_EXPORT_SECRET_PLACEHOLDER, command.Token)
+
+	for _, hook := range export.OutgoingWebhooks {
+		assert.Equal(t, model.INTEGRATIONS_EXPORT_SECRET_PLACEHOLDER, hook.Token)
+	}
+
+	_, resp = th.Client.ImportIntegrations(export)
+	CheckForbiddenStatus(t, resp)
+
+	imported := &model.IntegrationsExport{
+		Version:          model.INTEGRATIONS_EXPORT_VERSION,
+		IncomingWebhooks: []*model.IncomingWebhookExport{incoming},
+		Commands:         []*model.CommandExport{command, {Team: th.BasicTeam.Name, Creator: "unknown", Trigger: "other" + model.NewId()[:8], Method: model.COMMAND_METHOD_GET, URL: "http://example.com/other"}},
+		Bots: []*model.BotExport{
+			{Username: "bot" + model.NewId()[:8], Owner: th.BasicUser.Username},
+			{Username: "bot" + model.NewId()[:8], Owner: "com.example.plugin"},
+		},
+	}
+
+	result, resp := th.SystemAdminClient.ImportIntegrations(imported)
+	CheckNoError(t, resp)
+	assert.Equal(t, 1, result.IncomingWebhooks)
+	assert.Equal(t, 1, result.Commands)
+	assert.Equal(t, 1, result.Bots)
+	// The original command still exists so its trigger is a duplicate, and the owner of the second bot isn't a user.
+	assert.Len(t, result.Errors, 2)
+
+	botUser, err := th.App.GetUserByUsername(imported.Bots[0].Username)
+	require.Nil(t, err)
+	bot, err := th.App.GetBot(botUser.Id, false)
+	require.Nil(t, err)
+	assert.Equal(t, th.BasicUser.Id, bot.OwnerId)
+
+	_, err = th.App.GetUserByUsername(imported.Bots[1].Username)
+	require.NotNil(t, err)
+
+	hooks, err := th.App.GetIncomingWebhooksForTeamPage(th.BasicTeam.Id, 0, 100)
+	require.Nil(t, err)
+	count := 0
+	for _, hook := range hooks {
+		if hook.DisplayName == "alerts" {
+			count++
+		}
+	}
+	assert.Equal(t, 2, count)
+
+	_, resp = th.SystemAdminClient.ImportIntegrations(&model.IntegrationsExport{Version: 0})
+	CheckBadRequestStatus(t, resp)
+}
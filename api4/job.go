@@ -12,6 +12,7 @@ import (
 func (api *API) InitJob() {
 	api.BaseRoutes.Jobs.Handle("", api.ApiSessionRequired(getJobs)).Methods("GET")
 	api.BaseRoutes.Jobs.Handle("", api.ApiSessionRequired(createJob)).Methods("POST")
+	api.BaseRoutes.Jobs.Handle("/leader", api.ApiSessionRequired(getJobsLeader)).Methods("GET")
 	api.BaseRoutes.Jobs.Handle("/{job_id:[A-Za-z0-9]+}", api.ApiSessionRequired(getJob)).Methods("GET")
 	api.BaseRoutes.Jobs.Handle("/{job_id:[A-Za-z0-9]+}/cancel", api.ApiSessionRequired(cancelJob)).Methods("POST")
 	api.BaseRoutes.Jobs.Handle("/type/{job_type:[A-Za-z0-9_-]+}", api.ApiSessionRequired(getJobsByType)).Methods("GET")
@@ -98,6 +99,25 @@ func getJobsByType(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.JobsToJson(jobs)))
 }
 
+func getJobsLeader(c *Context, w http.ResponseWriter, r *http.Request) {
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_JOBS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_JOBS)
+		return
+	}
+
+	status, err := c.App.GetJobsLeaderStatus()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(status.ToJson()))
+}
+
 func cancelJob(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireJobId()
 	if c.Err != nil {
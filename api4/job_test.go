@@ -229,3 +229,28 @@ func TestCancelJob(t *testing.T) {
 	_, resp = th.SystemAdminClient.CancelJob(model.NewId())
 	CheckInternalErrorStatus(t, resp)
 }
+
+func TestGetJobsLeader(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	_, resp := th.Client.GetJobsLeader()
+	CheckForbiddenStatus(t, resp)
+
+	lease := &model.LeaderLease{
+		Name:     model.SYSTEM_JOBS_LEADER_LEASE,
+		HolderId: th.App.Srv.Jobs.ServerId(),
+		Hostname: "leader",
+		ExpireAt: model.GetMillis() + 60000,
+	}
+	_, err := th.App.Srv.Store.System().AcquireLease(lease)
+	require.Nil(t, err)
+	defer th.App.Srv.Store.System().ReleaseLease(lease.Name, lease.HolderId)
+
+	status, resp := th.SystemAdminClient.GetJobsLeader()
+	CheckNoError(t, resp)
+	require.Equal(t, lease.HolderId, status.LeaderId)
+	require.Equal(t, "leader", status.LeaderHostname)
+	require.Equal(t, th.App.Srv.Jobs.ServerId(), status.ServerId)
+	require.True(t, status.IsLeader)
+}
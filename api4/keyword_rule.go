@@ -0,0 +1,143 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitKeywordRule() {
+	api.BaseRoutes.KeywordRules.Handle("", api.ApiSessionRequired(createKeywordRule)).Methods("POST")
+	api.BaseRoutes.KeywordRules.Handle("", api.ApiSessionRequired(getKeywordRules)).Methods("GET")
+	api.BaseRoutes.KeywordRule.Handle("", api.ApiSessionRequired(getKeywordRule)).Methods("GET")
+	api.BaseRoutes.KeywordRule.Handle("", api.ApiSessionRequired(updateKeywordRule)).Methods("PUT")
+	api.BaseRoutes.KeywordRule.Handle("", api.ApiSessionRequired(deleteKeywordRule)).Methods("DELETE")
+}
+
+func createKeywordRule(c *Context, w http.ResponseWriter, r *http.Request) {
+	rule := model.KeywordRuleFromJson(r.Body)
+	if rule == nil {
+		c.SetInvalidParam("keyword_rule")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	rule.CreatorId = c.App.Session.UserId
+
+	rule, err := c.App.CreateKeywordRule(rule)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - keyword_rule_id=" + rule.Id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(rule.ToJson()))
+}
+
+func getKeywordRules(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	rules, err := c.App.GetKeywordRules(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.KeywordRuleListToJson(rules)))
+}
+
+func getKeywordRule(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireKeywordRuleId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	rule, err := c.App.GetKeywordRule(c.Params.KeywordRuleId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(rule.ToJson()))
+}
+
+func updateKeywordRule(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireKeywordRuleId()
+	if c.Err != nil {
+		return
+	}
+
+	updatedRule := model.KeywordRuleFromJson(r.Body)
+	if updatedRule == nil {
+		c.SetInvalidParam("keyword_rule")
+		return
+	}
+
+	// The rule being updated in the payload must be the same one as indicated in the URL.
+	if updatedRule.Id != c.Params.KeywordRuleId {
+		c.SetInvalidParam("keyword_rule_id")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	oldRule, err := c.App.GetKeywordRule(c.Params.KeywordRuleId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	rule, err := c.App.UpdateKeywordRule(oldRule, updatedRule)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success")
+	w.Write([]byte(rule.ToJson()))
+}
+
+func deleteKeywordRule(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireKeywordRuleId()
+	if c.Err != nil {
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.DeleteKeywordRule(c.Params.KeywordRuleId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success")
+	ReturnStatusOK(w)
+}
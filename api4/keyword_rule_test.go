@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestKeywordRules(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	rule := &model.KeywordRule{
+		TeamId:       th.BasicTeam.Id,
+		Name:         "outage",
+		ChannelIds:   model.StringArray{th.BasicChannel.Id},
+		Keywords:     model.StringArray{"outage"},
+		ResponderId:  th.BasicUser2.Id,
+		ReplyMessage: "Check the status page.",
+	}
+
+	_, resp := th.Client.CreateKeywordRule(rule)
+	CheckForbiddenStatus(t, resp)
+
+	created, resp := th.SystemAdminClient.CreateKeywordRule(rule)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.SystemAdminUser.Id, created.CreatorId)
+
+	_, resp = th.SystemAdminClient.CreateKeywordRule(&model.KeywordRule{Name: "no actions", Keywords: model.StringArray{"outage"}, ResponderId: th.BasicUser2.Id})
+	CheckBadRequestStatus(t, resp)
+
+	got, resp := th.SystemAdminClient.GetKeywordRule(created.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, created.Keywords, got.Keywords)
+
+	_, resp = th.Client.GetKeywordRule(created.Id)
+	CheckForbiddenStatus(t, resp)
+
+	rules, resp := th.SystemAdminClient.GetKeywordRules(0, 100)
+	CheckNoError(t, resp)
+	assert.Len(t, rules, 1)
+
+	_, resp = th.Client.GetKeywordRules(0, 100)
+	CheckForbiddenStatus(t, resp)
+
+	created.ReactionEmojiName = "eyes"
+	updated, resp := th.SystemAdminClient.UpdateKeywordRule(created)
+	CheckNoError(t, resp)
+	assert.Equal(t, "eyes", updated.ReactionEmojiName)
+
+	_, resp = th.Client.UpdateKeywordRule(created)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.DeleteKeywordRule(created.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.DeleteKeywordRule(created.Id)
+	CheckNoError(t, resp)
+	assert.True(t, ok)
+
+	_, resp = th.SystemAdminClient.GetKeywordRule(created.Id)
+	CheckNotFoundStatus(t, resp)
+}
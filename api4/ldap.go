@@ -43,6 +43,18 @@ func syncLdap(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.URL.Query().Get("dry_run") == "true" {
+		job, err := c.App.StartLdapSyncDryRun()
+		if err != nil {
+			c.Err = err
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(job.ToJson()))
+		return
+	}
+
 	c.App.SyncLdap()
 
 	ReturnStatusOK(w)
@@ -49,6 +49,16 @@ func TestSyncLdap(t *testing.T) {
 
 	_, resp = th.Client.SyncLdap()
 	CheckForbiddenStatus(t, resp)
+
+	t.Run("dry run", func(t *testing.T) {
+		_, resp := th.Client.SyncLdapDryRun()
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.SyncLdapDryRun()
+		CheckNotImplementedStatus(t, resp)
+		require.NotNil(t, resp.Error)
+		require.Equal(t, "ent.ldap.disabled.app_error", resp.Error.Id)
+	})
 }
 
 func TestGetLdapGroups(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/mattermost/mattermost-server/model"
 )
@@ -15,6 +16,7 @@ func (api *API) InitLicense() {
 	api.BaseRoutes.ApiRoot.Handle("/license", api.ApiSessionRequired(addLicense)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/license", api.ApiSessionRequired(removeLicense)).Methods("DELETE")
 	api.BaseRoutes.ApiRoot.Handle("/license/client", api.ApiHandler(getClientLicense)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/license/usage", api.ApiSessionRequired(getLicenseUsage)).Methods("GET")
 }
 
 func getClientLicense(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -129,3 +131,28 @@ func removeLicense(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.LogAudit("success")
 	ReturnStatusOK(w)
 }
+
+func getLicenseUsage(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	days := model.LICENSE_USAGE_FORECAST_DAYS
+	if daysString := r.URL.Query().Get("days"); len(daysString) > 0 {
+		var parseError error
+		days, parseError = strconv.Atoi(daysString)
+		if parseError != nil || days <= 0 || days > model.DAILY_STATS_MAX_RANGE_DAYS {
+			c.SetInvalidParam("days")
+			return
+		}
+	}
+
+	summary, err := c.App.GetLicenseUsageSummary(days)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(summary.ToJson()))
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetOldClientLicense(t *testing.T) {
@@ -103,3 +105,32 @@ func TestRemoveLicenseFile(t *testing.T) {
 		}
 	})
 }
+
+func TestGetLicenseUsage(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	_, resp := th.Client.GetLicenseUsage(30)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetLicenseUsage(30)
+	CheckNotImplementedStatus(t, resp)
+
+	license := model.NewTestLicense()
+	license.Features.Users = model.NewInt(10000)
+	th.App.SetLicense(license)
+	defer th.App.SetLicense(nil)
+
+	th.App.RecordLicenseUsage()
+
+	summary, resp := th.SystemAdminClient.GetLicenseUsage(30)
+	CheckNoError(t, resp)
+	assert.Equal(t, int64(10000), summary.Seats)
+	assert.NotZero(t, summary.RegisteredUsers)
+	assert.False(t, summary.Warning)
+	require.NotEmpty(t, summary.History)
+	assert.Equal(t, summary.RegisteredUsers, summary.History[len(summary.History)-1].RegisteredUsers)
+
+	_, resp = th.SystemAdminClient.GetLicenseUsage(0)
+	CheckBadRequestStatus(t, resp)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitMentionAlias() {
+	api.BaseRoutes.MentionAliases.Handle("", api.ApiSessionRequired(createMentionAlias)).Methods("POST")
+	api.BaseRoutes.MentionAliases.Handle("", api.ApiSessionRequired(getMentionAliases)).Methods("GET")
+	api.BaseRoutes.MentionAlias.Handle("", api.ApiSessionRequired(getMentionAlias)).Methods("GET")
+	api.BaseRoutes.MentionAlias.Handle("", api.ApiSessionRequired(updateMentionAlias)).Methods("PUT")
+	api.BaseRoutes.MentionAlias.Handle("", api.ApiSessionRequired(deleteMentionAlias)).Methods("DELETE")
+}
+
+func createMentionAlias(c *Context, w http.ResponseWriter, r *http.Request) {
+	alias := model.MentionAliasFromJson(r.Body)
+	if alias == nil {
+		c.SetInvalidParam("mention_alias")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	alias.CreatorId = c.App.Session.UserId
+
+	alias, err := c.App.CreateMentionAlias(alias)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - alias_id=" + alias.Id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(alias.ToJson()))
+}
+
+func getMentionAliases(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	aliases, err := c.App.GetMentionAliases(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.MentionAliasListToJson(aliases)))
+}
+
+func getMentionAlias(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireMentionAliasId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	alias, err := c.App.GetMentionAlias(c.Params.MentionAliasId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(alias.ToJson()))
+}
+
+func updateMentionAlias(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireMentionAliasId()
+	if c.Err != nil {
+		return
+	}
+
+	updatedAlias := model.MentionAliasFromJson(r.Body)
+	if updatedAlias == nil {
+		c.SetInvalidParam("mention_alias")
+		return
+	}
+
+	// The alias being updated in the payload must be the same one as indicated in the URL.
+	if updatedAlias.Id != c.Params.MentionAliasId {
+		c.SetInvalidParam("alias_id")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	oldAlias, err := c.App.GetMentionAlias(c.Params.MentionAliasId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	alias, err := c.App.UpdateMentionAlias(oldAlias, updatedAlias)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success")
+	w.Write([]byte(alias.ToJson()))
+}
+
+func deleteMentionAlias(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireMentionAliasId()
+	if c.Err != nil {
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.DeleteMentionAlias(c.Params.MentionAliasId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success")
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestMentionAliases(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	alias := &model.MentionAlias{
+		TeamId:      th.BasicTeam.Id,
+		Name:        "frontend-team",
+		Description: "Frontend engineers",
+		Type:        model.MENTION_ALIAS_TYPE_USERS,
+		TargetIds:   model.StringArray{th.BasicUser.Id, th.BasicUser2.Id},
+	}
+
+	_, resp := th.Client.CreateMentionAlias(alias)
+	CheckForbiddenStatus(t, resp)
+
+	created, resp := th.SystemAdminClient.CreateMentionAlias(alias)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.SystemAdminUser.Id, created.CreatorId)
+	assert.Equal(t, alias.TargetIds, created.TargetIds)
+
+	_, resp = th.SystemAdminClient.CreateMentionAlias(&model.MentionAlias{Name: th.BasicUser.Username, Type: model.MENTION_ALIAS_TYPE_USERS, TargetIds: model.StringArray{th.BasicUser.Id}})
+	CheckBadRequestStatus(t, resp)
+
+	got, resp := th.SystemAdminClient.GetMentionAlias(created.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, created.Name, got.Name)
+
+	_, resp = th.Client.GetMentionAlias(created.Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetMentionAlias(model.NewId())
+	CheckNotFoundStatus(t, resp)
+
+	aliases, resp := th.SystemAdminClient.GetMentionAliases(0, 100)
+	CheckNoError(t, resp)
+	found := false
+	for _, a := range aliases {
+		found = found || a.Id == created.Id
+	}
+	assert.True(t, found)
+
+	_, resp = th.Client.GetMentionAliases(0, 100)
+	CheckForbiddenStatus(t, resp)
+
+	created.Type = model.MENTION_ALIAS_TYPE_CHANNEL
+	created.TargetIds = model.StringArray{th.BasicChannel2.Id}
+	updated, resp := th.SystemAdminClient.UpdateMentionAlias(created)
+	CheckNoError(t, resp)
+	assert.Equal(t, model.MENTION_ALIAS_TYPE_CHANNEL, updated.Type)
+
+	_, resp = th.Client.UpdateMentionAlias(created)
+	CheckForbiddenStatus(t, resp)
+
+	r, err := th.SystemAdminClient.DoApiPut(th.SystemAdminClient.GetMentionAliasRoute(model.NewId()), created.ToJson())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	closeBody(r)
+
+	_, resp = th.Client.DeleteMentionAlias(created.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.DeleteMentionAlias(created.Id)
+	CheckNoError(t, resp)
+	assert.True(t, ok)
+
+	_, resp = th.SystemAdminClient.GetMentionAlias(created.Id)
+	CheckNotFoundStatus(t, resp)
+}
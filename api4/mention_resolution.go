@@ -0,0 +1,54 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitMentionResolution() {
+	api.BaseRoutes.Channel.Handle("/mentions/resolve", api.ApiSessionRequired(resolveMentions)).Methods("POST")
+}
+
+func resolveMentions(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.MapFromJson(r.Body)
+	message, ok := props["message"]
+	if !ok || utf8.RuneCountInString(message) > c.App.MaxPostSize() {
+		c.SetInvalidParam("message")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_CREATE_POST) {
+		c.SetPermissionError(model.PERMISSION_CREATE_POST)
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	sender, err := c.App.GetUser(c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	resolution, err := c.App.ResolveMentions(sender, channel, message)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(resolution.ToJson()))
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestResolveMentions(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	outOfChannelUser := th.CreateUser()
+	th.LinkUserToTeam(outOfChannelUser, th.BasicTeam)
+
+	message := "@" + th.BasicUser2.Username + " @" + outOfChannelUser.Username + " see ~" + th.BasicChannel2.Name + " and ~" + th.BasicPrivateChannel.Name
+
+	resolution, resp := th.Client.ResolveMentions(th.BasicChannel.Id, message)
+	CheckNoError(t, resp)
+	assert.Equal(t, 1, resolution.NotificationCount)
+	assert.True(t, resolution.HasWarnings())
+
+	mention := resolution.GetMention("@" + th.BasicUser2.Username)
+	require.NotNil(t, mention)
+	assert.Equal(t, model.RESOLVED_MENTION_TYPE_USER, mention.Type)
+	assert.Equal(t, th.BasicUser2.Id, mention.Id)
+	assert.Empty(t, mention.Warning)
+
+	mention = resolution.GetMention("@" + outOfChannelUser.Username)
+	require.NotNil(t, mention)
+	assert.Equal(t, outOfChannelUser.Id, mention.Id)
+	assert.Equal(t, model.RESOLVED_MENTION_WARNING_NOT_IN_CHANNEL, mention.Warning)
+
+	mention = resolution.GetMention("~" + th.BasicChannel2.Name)
+	require.NotNil(t, mention)
+	assert.Equal(t, model.RESOLVED_MENTION_TYPE_CHANNEL, mention.Type)
+	assert.Equal(t, th.BasicChannel2.Id, mention.Id)
+
+	assert.Nil(t, resolution.GetMention("~"+th.BasicPrivateChannel.Name), "private channels aren't linked to")
+
+	t.Run("special mentions", func(t *testing.T) {
+		resolution, resp := th.Client.ResolveMentions(th.BasicChannel.Id, "hello @channel")
+		CheckNoError(t, resp)
+
+		mention := resolution.GetMention("@channel")
+		require.NotNil(t, mention)
+		assert.Equal(t, model.RESOLVED_MENTION_TYPE_SPECIAL, mention.Type)
+		assert.Equal(t, resolution.NotificationCount, mention.UserCount)
+		assert.Empty(t, mention.Warning)
+
+		maxNotifications := *th.App.Config().TeamSettings.MaxNotificationsPerChannel
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.MaxNotificationsPerChannel = maxNotifications })
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.MaxNotificationsPerChannel = 1 })
+
+		resolution, resp = th.Client.ResolveMentions(th.BasicChannel.Id, "hello @channel")
+		CheckNoError(t, resp)
+		assert.Equal(t, 0, resolution.NotificationCount)
+		assert.Equal(t, model.RESOLVED_MENTION_WARNING_DISABLED, resolution.GetMention("@channel").Warning)
+	})
+
+	t.Run("mention aliases", func(t *testing.T) {
+		alias, err := th.App.CreateMentionAlias(&model.MentionAlias{
+			TeamId:    th.BasicTeam.Id,
+			Name:      "outsiders",
+			Type:      model.MENTION_ALIAS_TYPE_USERS,
+			TargetIds: model.StringArray{outOfChannelUser.Id},
+			CreatorId: th.SystemAdminUser.Id,
+		})
+		require.Nil(t, err)
+
+		resolution, resp := th.Client.ResolveMentions(th.BasicChannel.Id, "ping @outsiders")
+		CheckNoError(t, resp)
+
+		mention := resolution.GetMention("@outsiders")
+		require.NotNil(t, mention)
+		assert.Equal(t, alias.Id, mention.Id)
+		assert.Equal(t, model.RESOLVED_MENTION_WARNING_NO_MEMBERS, mention.Warning)
+	})
+
+	t.Run("direct channel", func(t *testing.T) {
+		dm, resp := th.Client.CreateDirectChannel(th.BasicUser.Id, th.BasicUser2.Id)
+		CheckNoError(t, resp)
+
+		resolution, resp := th.Client.ResolveMentions(dm.Id, "hello")
+		CheckNoError(t, resp)
+		assert.Equal(t, 1, resolution.NotificationCount)
+	})
+
+	t.Run("channels of other teams", func(t *testing.T) {
+		otherTeam := th.CreateTeamWithClient(th.SystemAdminClient)
+		th.LinkUserToTeam(th.BasicUser, otherTeam)
+		otherChannel := th.CreateChannelWithClientAndTeam(th.SystemAdminClient, model.CHANNEL_OPEN, otherTeam.Id)
+
+		resolution, resp := th.Client.ResolveMentions(th.BasicChannel.Id, "see ~"+otherChannel.Name)
+		CheckNoError(t, resp)
+		assert.Nil(t, resolution.GetMention("~"+otherChannel.Name))
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.EnableCrossTeamChannelMentions = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.EnableCrossTeamChannelMentions = false })
+
+		resolution, resp = th.Client.ResolveMentions(th.BasicChannel.Id, "see ~"+otherChannel.Name)
+		CheckNoError(t, resp)
+
+		mention := resolution.GetMention("~" + otherChannel.Name)
+		require.NotNil(t, mention)
+		assert.Equal(t, otherChannel.Id, mention.Id)
+		assert.Equal(t, otherTeam.Id, mention.TeamId)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		_, resp := th.Client.ResolveMentions(th.BasicPrivateChannel.Id, "hello")
+		CheckNoError(t, resp)
+
+		private := th.CreateChannelWithClient(th.SystemAdminClient, model.CHANNEL_PRIVATE)
+		_, resp = th.Client.ResolveMentions(private.Id, "hello")
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		r, err := th.Client.DoApiPost(th.Client.GetChannelRoute(th.BasicChannel.Id)+"/mentions/resolve", "{}")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		closeBody(r)
+	})
+}
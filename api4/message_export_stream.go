@@ -0,0 +1,175 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitMessageExportStream() {
+	api.BaseRoutes.ExportConsumers.Handle("", api.ApiSessionRequired(createMessageExportConsumer)).Methods("POST")
+	api.BaseRoutes.ExportConsumers.Handle("", api.ApiSessionRequired(getMessageExportConsumers)).Methods("GET")
+	api.BaseRoutes.ExportConsumer.Handle("", api.ApiSessionRequired(getMessageExportConsumer)).Methods("GET")
+	api.BaseRoutes.ExportConsumer.Handle("", api.ApiSessionRequired(deleteMessageExportConsumer)).Methods("DELETE")
+	api.BaseRoutes.ExportConsumer.Handle("/changes", api.ApiSessionRequired(getMessageExportChanges)).Methods("GET")
+	api.BaseRoutes.ExportConsumer.Handle("/ack", api.ApiSessionRequired(ackMessageExportChanges)).Methods("POST")
+}
+
+func createMessageExportConsumer(c *Context, w http.ResponseWriter, r *http.Request) {
+	consumer := model.MessageExportConsumerFromJson(r.Body)
+	if consumer == nil {
+		c.SetInvalidParam("consumer")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	consumer.Id = ""
+	consumer.CreatorId = c.App.Session.UserId
+
+	consumer, err := c.App.CreateMessageExportConsumer(consumer)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + consumer.Name)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(consumer.ToJson()))
+}
+
+func getMessageExportConsumers(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	consumers, err := c.App.GetMessageExportConsumers()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.MessageExportConsumerListToJson(consumers)))
+}
+
+func getMessageExportConsumer(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireExportConsumerId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	consumer, err := c.App.GetMessageExportConsumer(c.Params.ExportConsumerId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(consumer.ToJson()))
+}
+
+func deleteMessageExportConsumer(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireExportConsumerId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.DeleteMessageExportConsumer(c.Params.ExportConsumerId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("id=" + c.Params.ExportConsumerId)
+	ReturnStatusOK(w)
+}
+
+// getMessageExportChanges returns a page of the stream after the given cursor, or after the cursor the consumer last
+// acknowledged when none is given.
+func getMessageExportChanges(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireExportConsumerId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	consumer, err := c.App.GetMessageExportConsumer(c.Params.ExportConsumerId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	query := r.URL.Query()
+
+	cursor := consumer.GetCursor()
+	if token, ok := query["cursor"]; ok {
+		if cursor, ok = model.MessageExportCursorFromToken(token[0]); !ok {
+			c.SetInvalidUrlParam("cursor")
+			return
+		}
+	}
+
+	limit := 0
+	if val := query.Get("limit"); val != "" {
+		var convErr error
+		if limit, convErr = strconv.Atoi(val); convErr != nil || limit <= 0 {
+			c.SetInvalidUrlParam("limit")
+			return
+		}
+	}
+
+	list, err := c.App.GetMessageExportChanges(cursor, limit)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(list.ToJson()))
+}
+
+func ackMessageExportChanges(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireExportConsumerId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.MapFromJson(r.Body)
+	cursor, ok := model.MessageExportCursorFromToken(props["cursor"])
+	if !ok {
+		c.SetInvalidParam("cursor")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	consumer, err := c.App.AckMessageExportChanges(c.Params.ExportConsumerId, cursor)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(consumer.ToJson()))
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestMessageExportStream(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	_, resp := th.SystemAdminClient.CreateMessageExportConsumer(&model.MessageExportConsumer{Name: "Archive"})
+	CheckNotImplementedStatus(t, resp)
+
+	th.App.SetLicense(model.NewTestLicense("message_export"))
+
+	_, resp = th.Client.CreateMessageExportConsumer(&model.MessageExportConsumer{Name: "Archive"})
+	CheckForbiddenStatus(t, resp)
+
+	consumer, resp := th.SystemAdminClient.CreateMessageExportConsumer(&model.MessageExportConsumer{Name: "Archive"})
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.SystemAdminUser.Id, consumer.CreatorId)
+	assert.Equal(t, "", consumer.Cursor)
+
+	consumers, resp := th.SystemAdminClient.GetMessageExportConsumers()
+	CheckNoError(t, resp)
+	require.Len(t, consumers, 1)
+
+	_, resp = th.Client.GetMessageExportChanges(consumer.Id, 0)
+	CheckForbiddenStatus(t, resp)
+
+	// Read the whole stream, resuming from the cursor of each page
+	list, resp := th.SystemAdminClient.GetMessageExportChanges(consumer.Id, 0)
+	CheckNoError(t, resp)
+	for list.HasMore {
+		list, resp = th.SystemAdminClient.GetMessageExportChangesAfter(consumer.Id, list.Cursor, model.MESSAGE_EXPORT_CHANGES_MAX_LIMIT)
+		CheckNoError(t, resp)
+	}
+	require.NotEmpty(t, list.Cursor)
+
+	consumer, resp = th.SystemAdminClient.AckMessageExportChanges(consumer.Id, list.Cursor)
+	CheckNoError(t, resp)
+	assert.Equal(t, list.Cursor, consumer.Cursor)
+	assert.NotZero(t, consumer.LastAckAt)
+
+	post := th.CreatePost()
+	_, resp = th.Client.DeletePost(post.Id)
+	CheckNoError(t, resp)
+
+	// The consumer resumes from where it acknowledged
+	list, resp = th.SystemAdminClient.GetMessageExportChanges(consumer.Id, 0)
+	CheckNoError(t, resp)
+	require.Len(t, list.Changes, 1)
+	assert.Equal(t, post.Id, list.Changes[0].PostId)
+	assert.Equal(t, model.MESSAGE_EXPORT_CHANGE_DELETED, list.Changes[0].ChangeType)
+	assert.False(t, list.HasMore)
+
+	_, resp = th.SystemAdminClient.GetMessageExportChangesAfter(consumer.Id, "not a cursor", 0)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.AckMessageExportChanges(consumer.Id, "not a cursor")
+	CheckBadRequestStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.DeleteMessageExportConsumer(consumer.Id)
+	CheckNoError(t, resp)
+	assert.True(t, ok)
+
+	_, resp = th.SystemAdminClient.GetMessageExportConsumer(consumer.Id)
+	CheckNotFoundStatus(t, resp)
+}
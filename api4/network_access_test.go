@@ -0,0 +1,59 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestNetworkAccess(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.NetworkAccessSettings.Enable = false })
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.NetworkAccessSettings.Enable = true
+		*cfg.NetworkAccessSettings.AllowedIPRanges = "10.0.0.0/8"
+		cfg.NetworkAccessSettings.BypassUserIds = []string{th.SystemAdminUser.Id}
+	})
+
+	t.Run("existing sessions are denied", func(t *testing.T) {
+		_, resp := th.Client.GetMe("")
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("login is denied", func(t *testing.T) {
+		client := th.CreateClient()
+		_, resp := client.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("bypass users are allowed", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.GetMe("")
+		CheckNoError(t, resp)
+	})
+
+	t.Run("allowed ranges", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.NetworkAccessSettings.AllowedIPRanges = "127.0.0.1 ::1" })
+
+		_, resp := th.Client.GetMe("")
+		CheckNoError(t, resp)
+	})
+
+	t.Run("blocked country", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.NetworkAccessSettings.AllowedIPRanges = ""
+			*cfg.NetworkAccessSettings.CountryHeader = "X-Country"
+			*cfg.NetworkAccessSettings.BlockedCountries = "KP"
+		})
+
+		th.Client.HttpHeader = map[string]string{"X-Country": "KP"}
+		defer func() { th.Client.HttpHeader = nil }()
+
+		_, resp := th.Client.GetMe("")
+		CheckForbiddenStatus(t, resp)
+	})
+}
@@ -17,6 +17,7 @@ func (api *API) InitOAuth() {
 	api.BaseRoutes.OAuthApp.Handle("/info", api.ApiSessionRequired(getOAuthAppInfo)).Methods("GET")
 	api.BaseRoutes.OAuthApp.Handle("", api.ApiSessionRequired(deleteOAuthApp)).Methods("DELETE")
 	api.BaseRoutes.OAuthApp.Handle("/regen_secret", api.ApiSessionRequired(regenerateOAuthAppSecret)).Methods("POST")
+	api.BaseRoutes.OAuth.Handle("/scopes", api.ApiSessionRequired(getOAuthScopes)).Methods("GET")
 
 	api.BaseRoutes.User.Handle("/oauth/apps/authorized", api.ApiSessionRequired(getAuthorizedOAuthApps)).Methods("GET")
 }
@@ -164,6 +165,10 @@ func getOAuthAppInfo(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(oauthApp.ToJson()))
 }
 
+func getOAuthScopes(c *Context, w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(model.OAuthScopeListToJson(c.App.GetOAuthScopes(c.App.T))))
+}
+
 func deleteOAuthApp(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireAppId()
 	if c.Err != nil {
@@ -6,10 +6,12 @@ package api4
 import (
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strconv"
 	"testing"
 
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateOAuthApp(t *testing.T) {
@@ -632,6 +634,107 @@ func TestGetAuthorizedOAuthAppsForUser(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetOAuthScopes(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	scopes, resp := Client.GetOAuthScopes()
+	CheckNoError(t, resp)
+
+	names := []string{}
+	for _, scope := range scopes {
+		names = append(names, scope.Name)
+		if scope.Description == "" {
+			t.Fatal("missing description for " + scope.Name)
+		}
+	}
+	require.Equal(t, []string{model.DEFAULT_SCOPE, model.OAUTH_SCOPE_READ_POSTS, model.OAUTH_SCOPE_WRITE_POSTS, model.OAUTH_SCOPE_READ_USERS}, names)
+
+	Client.Logout()
+	_, resp = Client.GetOAuthScopes()
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestOAuthScopedAccessToken(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	AdminClient := th.SystemAdminClient
+
+	enableOAuth := th.App.Config().ServiceSettings.EnableOAuthServiceProvider
+	defer func() {
+		th.App.UpdateConfig(func(cfg *model.Config) { cfg.ServiceSettings.EnableOAuthServiceProvider = enableOAuth })
+	}()
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oapp := &model.OAuthApp{Name: GenerateTestAppName(), Homepage: "https://nowhere.com", Description: "test", CallbackUrls: []string{"https://nowhere.com"}}
+	rapp, resp := AdminClient.CreateOAuthApp(oapp)
+	CheckNoError(t, resp)
+
+	getToken := func(scope string) string {
+		redirect, resp := Client.AuthorizeOAuthApp(&model.AuthorizeRequest{
+			ResponseType: model.AUTHCODE_RESPONSE_TYPE,
+			ClientId:     rapp.Id,
+			RedirectUri:  rapp.CallbackUrls[0],
+			Scope:        scope,
+			State:        "123",
+		})
+		CheckNoError(t, resp)
+
+		rurl, err := url.Parse(redirect)
+		require.Nil(t, err)
+
+		access, appErr := th.App.GetOAuthAccessTokenForCodeFlow(rapp.Id, model.ACCESS_TOKEN_GRANT_TYPE, rapp.CallbackUrls[0], rurl.Query().Get("code"), rapp.ClientSecret, "")
+		require.Nil(t, appErr)
+		return access.AccessToken
+	}
+
+	oauthClient := th.CreateClient()
+
+	t.Run("read posts", func(t *testing.T) {
+		oauthClient.SetOAuthToken(getToken(model.OAUTH_SCOPE_READ_POSTS))
+
+		_, resp := oauthClient.GetPost(th.BasicPost.Id, "")
+		CheckNoError(t, resp)
+
+		_, resp = oauthClient.GetPostsForChannel(th.BasicChannel.Id, 0, 10, "")
+		CheckNoError(t, resp)
+
+		_, resp = oauthClient.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "scoped"})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = oauthClient.GetUser(th.BasicUser2.Id, "")
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = oauthClient.GetTeam(th.BasicTeam.Id, "")
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("write posts and read users", func(t *testing.T) {
+		oauthClient.SetOAuthToken(getToken(model.OAUTH_SCOPE_WRITE_POSTS + " " + model.OAUTH_SCOPE_READ_USERS))
+
+		_, resp := oauthClient.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "scoped"})
+		CheckCreatedStatus(t, resp)
+
+		_, resp = oauthClient.GetUser(th.BasicUser2.Id, "")
+		CheckNoError(t, resp)
+
+		_, resp = oauthClient.GetPost(th.BasicPost.Id, "")
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("default scope grants full access", func(t *testing.T) {
+		oauthClient.SetOAuthToken(getToken(""))
+
+		_, resp := oauthClient.GetPost(th.BasicPost.Id, "")
+		CheckNoError(t, resp)
+
+		_, resp = oauthClient.GetTeam(th.BasicTeam.Id, "")
+		CheckNoError(t, resp)
+	})
+}
+
 func closeBody(r *http.Response) {
 	if r != nil && r.Body != nil {
 		ioutil.ReadAll(r.Body)
@@ -0,0 +1,172 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitOnboarding() {
+	api.BaseRoutes.OnboardingSteps.Handle("", api.ApiSessionRequired(getOnboardingSteps)).Methods("GET")
+	api.BaseRoutes.OnboardingSteps.Handle("", api.ApiSessionRequired(createOnboardingStep)).Methods("POST")
+	api.BaseRoutes.OnboardingStep.Handle("", api.ApiSessionRequired(updateOnboardingStep)).Methods("PUT")
+	api.BaseRoutes.OnboardingStep.Handle("", api.ApiSessionRequired(deleteOnboardingStep)).Methods("DELETE")
+
+	api.BaseRoutes.User.Handle("/onboarding", api.ApiSessionRequired(getOnboardingProgress)).Methods("GET")
+	api.BaseRoutes.User.Handle("/onboarding", api.ApiSessionRequired(resetOnboardingProgress)).Methods("DELETE")
+	api.BaseRoutes.User.Handle("/onboarding/steps/{onboarding_step_id:[A-Za-z0-9]+}/complete", api.ApiSessionRequired(completeOnboardingStep)).Methods("POST")
+}
+
+func getOnboardingSteps(c *Context, w http.ResponseWriter, r *http.Request) {
+	steps, err := c.App.GetOnboardingSteps()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.OnboardingStepListToJson(steps)))
+}
+
+func createOnboardingStep(c *Context, w http.ResponseWriter, r *http.Request) {
+	step := model.OnboardingStepFromJson(r.Body)
+	if step == nil {
+		c.SetInvalidParam("onboarding_step")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	step, err := c.App.CreateOnboardingStep(step)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - onboarding_step_id=" + step.Id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(step.ToJson()))
+}
+
+func updateOnboardingStep(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOnboardingStepId()
+	if c.Err != nil {
+		return
+	}
+
+	updatedStep := model.OnboardingStepFromJson(r.Body)
+	if updatedStep == nil || updatedStep.Id != c.Params.OnboardingStepId {
+		c.SetInvalidParam("onboarding_step")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	oldStep, err := c.App.GetOnboardingStep(c.Params.OnboardingStepId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	step, err := c.App.UpdateOnboardingStep(oldStep, updatedStep)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success")
+	w.Write([]byte(step.ToJson()))
+}
+
+func deleteOnboardingStep(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOnboardingStepId()
+	if c.Err != nil {
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.DeleteOnboardingStep(c.Params.OnboardingStepId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - onboarding_step_id=" + c.Params.OnboardingStepId)
+	ReturnStatusOK(w)
+}
+
+func getOnboardingProgress(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	progress, err := c.App.GetOnboardingProgress(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(progress.ToJson()))
+}
+
+func resetOnboardingProgress(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if err := c.App.ResetOnboardingProgress(c.Params.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func completeOnboardingStep(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireOnboardingStepId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	progress, err := c.App.CompleteOnboardingStep(c.Params.UserId, c.Params.OnboardingStepId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(progress.ToJson()))
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestOnboarding(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	step := &model.OnboardingStep{Type: model.ONBOARDING_STEP_TYPE_MANUAL, DisplayName: "Say hello", SortOrder: -1}
+
+	_, resp := Client.CreateOnboardingStep(step)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.CreateOnboardingStep(&model.OnboardingStep{Type: "unknown", DisplayName: "Unknown"})
+	CheckBadRequestStatus(t, resp)
+
+	step, resp = th.SystemAdminClient.CreateOnboardingStep(step)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	defer th.App.DeleteOnboardingStep(step.Id)
+
+	steps, resp := Client.GetOnboardingSteps()
+	CheckNoError(t, resp)
+	require.NotEmpty(t, steps)
+	assert.Equal(t, step.Id, steps[0].Id)
+
+	t.Run("update", func(t *testing.T) {
+		step.DisplayName = "Say hi"
+
+		_, resp := Client.UpdateOnboardingStep(step)
+		CheckForbiddenStatus(t, resp)
+
+		updated, resp := th.SystemAdminClient.UpdateOnboardingStep(step)
+		CheckNoError(t, resp)
+		assert.Equal(t, "Say hi", updated.DisplayName)
+	})
+
+	t.Run("progress", func(t *testing.T) {
+		progress, resp := Client.GetOnboardingProgress(th.BasicUser.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, len(steps), progress.Total)
+
+		_, resp = Client.GetOnboardingProgress(th.BasicUser2.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.GetOnboardingProgress(th.BasicUser2.Id)
+		CheckNoError(t, resp)
+
+		progress, resp = Client.CompleteOnboardingStep(th.BasicUser.Id, step.Id)
+		CheckNoError(t, resp)
+		require.NotEmpty(t, progress.Steps)
+		assert.Equal(t, step.Id, progress.Steps[0].Step.Id)
+		assert.NotZero(t, progress.Steps[0].CompleteAt)
+
+		_, resp = Client.CompleteOnboardingStep(th.BasicUser2.Id, step.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.CompleteOnboardingStep(th.BasicUser.Id, model.NewId())
+		CheckNotFoundStatus(t, resp)
+
+		ok, resp := Client.ResetOnboardingProgress(th.BasicUser.Id)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		progress, resp = Client.GetOnboardingProgress(th.BasicUser.Id)
+		CheckNoError(t, resp)
+		assert.Zero(t, progress.Completed)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, resp := Client.DeleteOnboardingStep(step.Id)
+		CheckForbiddenStatus(t, resp)
+
+		ok, resp := th.SystemAdminClient.DeleteOnboardingStep(step.Id)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		_, resp = th.SystemAdminClient.DeleteOnboardingStep(step.Id)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOnboarding = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOnboarding = true })
+
+		_, resp := Client.GetOnboardingSteps()
+		CheckNotImplementedStatus(t, resp)
+	})
+}
@@ -45,9 +45,15 @@ func getOpenGraphMetadata(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	ogJSONGeneric, ok := openGraphDataCache.Get(url)
 	if ok {
+		if c.App.Metrics != nil {
+			c.App.Metrics.IncrementMemCacheHitCounter("Open Graph")
+		}
 		w.Write(ogJSONGeneric.([]byte))
 		return
 	}
+	if c.App.Metrics != nil {
+		c.App.Metrics.IncrementMemCacheMissCounter("Open Graph")
+	}
 
 	og := c.App.GetOpenGraphMetadata(url)
 	ogJSON, err := og.ToJSON()
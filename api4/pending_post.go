@@ -0,0 +1,91 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitPendingPost() {
+	api.BaseRoutes.Channel.Handle("/pending_posts", api.ApiSessionRequired(getPendingPostsForChannel)).Methods("GET")
+	api.BaseRoutes.PendingPost.Handle("/approve", api.ApiSessionRequired(approvePendingPost)).Methods("POST")
+	api.BaseRoutes.PendingPost.Handle("/reject", api.ApiSessionRequired(rejectPendingPost)).Methods("POST")
+}
+
+func getPendingPostsForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_MANAGE_CHANNEL_ROLES) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return
+	}
+
+	pendingPosts, err := c.App.GetPendingPostsForChannel(c.Params.ChannelId, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PendingPostListToJson(pendingPosts)))
+}
+
+// requirePendingPostModerator checks that the session may review the pending post of the request.
+func requirePendingPostModerator(c *Context) *model.PendingPost {
+	c.RequirePendingPostId()
+	if c.Err != nil {
+		return nil
+	}
+
+	pendingPost, err := c.App.GetPendingPost(c.Params.PendingPostId)
+	if err != nil {
+		c.Err = err
+		return nil
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, pendingPost.ChannelId, model.PERMISSION_MANAGE_CHANNEL_ROLES) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return nil
+	}
+
+	return pendingPost
+}
+
+func approvePendingPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	pendingPost := requirePendingPostModerator(c)
+	if c.Err != nil {
+		return
+	}
+
+	post, err := c.App.ApprovePendingPost(pendingPost.Id)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("pending_post_id=" + pendingPost.Id + " post_id=" + post.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(post.ToJson()))
+}
+
+func rejectPendingPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	pendingPost := requirePendingPostModerator(c)
+	if c.Err != nil {
+		return
+	}
+
+	if err := c.App.RejectPendingPost(pendingPost.Id); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("pending_post_id=" + pendingPost.Id)
+
+	ReturnStatusOK(w)
+}
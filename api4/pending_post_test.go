@@ -0,0 +1,88 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestModeratedChannel(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	channel := th.BasicChannel
+	th.MakeUserChannelAdmin(th.BasicUser, channel)
+
+	patched, resp := Client.PatchChannel(channel.Id, &model.ChannelPatch{ModerationEnabled: model.NewBool(true)})
+	CheckNoError(t, resp)
+	require.True(t, patched.ModerationEnabled)
+
+	createHeldPost := func() *model.Post {
+		t.Helper()
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		post, resp := Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "awaiting approval"})
+		CheckNoError(t, resp)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		require.True(t, post.IsPendingApproval())
+
+		_, resp = Client.GetPendingPostsForChannel(channel.Id, 0, 10)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.ApprovePendingPost(post.Id)
+		CheckForbiddenStatus(t, resp)
+
+		return post
+	}
+
+	t.Run("approve", func(t *testing.T) {
+		held := createHeldPost()
+
+		pendingPosts, resp := Client.GetPendingPostsForChannel(channel.Id, 0, 10)
+		CheckNoError(t, resp)
+		require.Len(t, pendingPosts, 1)
+		assert.Equal(t, held.Id, pendingPosts[0].Id)
+		assert.Equal(t, th.BasicUser2.Id, pendingPosts[0].UserId)
+
+		post, resp := Client.ApprovePendingPost(held.Id)
+		CheckNoError(t, resp)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser2.Id, post.UserId)
+		assert.Equal(t, "awaiting approval", post.Message)
+
+		_, resp = Client.ApprovePendingPost(held.Id)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		held := createHeldPost()
+
+		ok, resp := Client.RejectPendingPost(held.Id)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		pendingPosts, resp := Client.GetPendingPostsForChannel(channel.Id, 0, 10)
+		CheckNoError(t, resp)
+		assert.Empty(t, pendingPosts)
+	})
+
+	t.Run("moderators post directly", func(t *testing.T) {
+		_, resp := Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "from a moderator"})
+		CheckNoError(t, resp)
+		CheckCreatedStatus(t, resp)
+	})
+
+	Client.Logout()
+	_, resp = Client.GetPendingPostsForChannel(channel.Id, 0, 10)
+	CheckUnauthorizedStatus(t, resp)
+}
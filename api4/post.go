@@ -10,26 +10,32 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost-server/app"
+	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
 )
 
 func (api *API) InitPost() {
-	api.BaseRoutes.Posts.Handle("", api.ApiSessionRequired(createPost)).Methods("POST")
-	api.BaseRoutes.Post.Handle("", api.ApiSessionRequired(getPost)).Methods("GET")
-	api.BaseRoutes.Post.Handle("", api.ApiSessionRequired(deletePost)).Methods("DELETE")
+	api.BaseRoutes.Posts.Handle("", api.ApiSessionRequiredWithOAuthScope(createPost, model.OAUTH_SCOPE_WRITE_POSTS)).Methods("POST")
+	api.BaseRoutes.Post.Handle("", api.ApiSessionRequiredWithOAuthScope(getPost, model.OAUTH_SCOPE_READ_POSTS)).Methods("GET")
+	api.BaseRoutes.Posts.Handle("/ids", api.ApiSessionRequiredWithOAuthScope(getPostsByIds, model.OAUTH_SCOPE_READ_POSTS)).Methods("POST")
+	api.BaseRoutes.Post.Handle("", api.ApiSessionRequiredWithOAuthScope(deletePost, model.OAUTH_SCOPE_WRITE_POSTS)).Methods("DELETE")
 	api.BaseRoutes.Posts.Handle("/ephemeral", api.ApiSessionRequired(createEphemeralPost)).Methods("POST")
-	api.BaseRoutes.Post.Handle("/thread", api.ApiSessionRequired(getPostThread)).Methods("GET")
-	api.BaseRoutes.Post.Handle("/files/info", api.ApiSessionRequired(getFileInfosForPost)).Methods("GET")
-	api.BaseRoutes.PostsForChannel.Handle("", api.ApiSessionRequired(getPostsForChannel)).Methods("GET")
-	api.BaseRoutes.PostsForUser.Handle("/flagged", api.ApiSessionRequired(getFlaggedPostsForUser)).Methods("GET")
-
-	api.BaseRoutes.ChannelForUser.Handle("/posts/unread", api.ApiSessionRequired(getPostsForChannelAroundLastUnread)).Methods("GET")
-
-	api.BaseRoutes.Team.Handle("/posts/search", api.ApiSessionRequired(searchPosts)).Methods("POST")
-	api.BaseRoutes.Post.Handle("", api.ApiSessionRequired(updatePost)).Methods("PUT")
-	api.BaseRoutes.Post.Handle("/patch", api.ApiSessionRequired(patchPost)).Methods("PUT")
-	api.BaseRoutes.Post.Handle("/pin", api.ApiSessionRequired(pinPost)).Methods("POST")
-	api.BaseRoutes.Post.Handle("/unpin", api.ApiSessionRequired(unpinPost)).Methods("POST")
+	api.BaseRoutes.Post.Handle("/thread", api.ApiSessionRequiredWithOAuthScope(getPostThread, model.OAUTH_SCOPE_READ_POSTS)).Methods("GET")
+	api.BaseRoutes.Post.Handle("/permalink", api.ApiSessionRequiredWithOAuthScope(getPermalinkPreview, model.OAUTH_SCOPE_READ_POSTS)).Methods("GET")
+	api.BaseRoutes.Post.Handle("/redact", api.ApiSessionRequired(redactPost)).Methods("POST")
+	api.BaseRoutes.Post.Handle("/files/info", api.ApiSessionRequiredWithOAuthScope(getFileInfosForPost, model.OAUTH_SCOPE_READ_POSTS)).Methods("GET")
+	api.BaseRoutes.PostsForChannel.Handle("", api.ApiSessionRequiredWithOAuthScope(getPostsForChannel, model.OAUTH_SCOPE_READ_POSTS)).Methods("GET")
+	api.BaseRoutes.PostsForChannel.Handle("/export", api.ApiSessionRequired(exportPostsForChannel)).Methods("GET")
+	api.BaseRoutes.PostsForUser.Handle("/flagged", api.ApiSessionRequiredWithOAuthScope(getFlaggedPostsForUser, model.OAUTH_SCOPE_READ_POSTS)).Methods("GET")
+	api.BaseRoutes.PostsForUser.Handle("/ephemeral", api.ApiSessionRequired(getEphemeralPostsForUser)).Methods("GET")
+
+	api.BaseRoutes.ChannelForUser.Handle("/posts/unread", api.ApiSessionRequiredWithOAuthScope(getPostsForChannelAroundLastUnread, model.OAUTH_SCOPE_READ_POSTS)).Methods("GET")
+
+	api.BaseRoutes.Team.Handle("/posts/search", api.ApiSessionRequiredWithOAuthScope(searchPosts, model.OAUTH_SCOPE_READ_POSTS)).Methods("POST")
+	api.BaseRoutes.Post.Handle("", api.ApiSessionRequiredWithOAuthScope(updatePost, model.OAUTH_SCOPE_WRITE_POSTS)).Methods("PUT")
+	api.BaseRoutes.Post.Handle("/patch", api.ApiSessionRequiredWithOAuthScope(patchPost, model.OAUTH_SCOPE_WRITE_POSTS)).Methods("PUT")
+	api.BaseRoutes.Post.Handle("/pin", api.ApiSessionRequiredWithOAuthScope(pinPost, model.OAUTH_SCOPE_WRITE_POSTS)).Methods("POST")
+	api.BaseRoutes.Post.Handle("/unpin", api.ApiSessionRequiredWithOAuthScope(unpinPost, model.OAUTH_SCOPE_WRITE_POSTS)).Methods("POST")
 }
 
 func createPost(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -69,7 +75,12 @@ func createPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.App.SetStatusOnline(c.App.Session.UserId, false)
 	c.App.UpdateLastActivityAtIfNeeded(c.App.Session)
 
-	w.WriteHeader(http.StatusCreated)
+	// A post held for the moderators of the channel to review isn't created yet.
+	if rp.IsPendingApproval() {
+		w.WriteHeader(http.StatusAccepted)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
 
 	// Note that rp has already had PreparePostForClient called on it by App.CreatePost
 	w.Write([]byte(rp.ToJson()))
@@ -190,6 +201,64 @@ func getPostsForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(clientPostList.ToJson()))
 }
 
+func exportPostsForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	query := r.URL.Query()
+	options := &model.ChannelPostExportOptions{
+		Format: query.Get("format"),
+		Gzip:   query.Get("gzip") == "true",
+	}
+	if options.Format == "" {
+		options.Format = model.CHANNEL_POST_EXPORT_FORMAT_CSV
+	}
+
+	for name, value := range map[string]*int64{"since": &options.Since, "until": &options.Until} {
+		if query.Get(name) == "" {
+			continue
+		}
+
+		var err error
+		if *value, err = strconv.ParseInt(query.Get(name), 10, 64); err != nil {
+			c.SetInvalidParam(name)
+			return
+		}
+	}
+
+	if err := options.IsValid(); err != nil {
+		c.Err = err
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("format=" + options.Format)
+
+	contentType := options.ContentType()
+	if options.Gzip {
+		contentType = "application/gzip"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment;filename=\""+options.FileName(channel.Name)+"\"")
+
+	// The response has started by the time the posts are being written, so a failure can only be logged.
+	if err := c.App.ExportChannelPosts(channel, options, w); err != nil {
+		c.Log.Error("Failed to export the posts of the channel", mlog.String("channel_id", channel.Id), mlog.Err(err))
+	}
+}
+
 func getPostsForChannelAroundLastUnread(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId().RequireChannelId()
 	if c.Err != nil {
@@ -261,6 +330,11 @@ func getFlaggedPostsForUser(c *Context, w http.ResponseWriter, r *http.Request)
 		posts, err = c.App.GetFlaggedPosts(c.Params.UserId, c.Params.Page, c.Params.PerPage)
 	}
 
+	if err != nil {
+		c.Err = err
+		return
+	}
+
 	pl := model.NewPostList()
 	channelReadPermission := make(map[string]bool)
 
@@ -287,12 +361,42 @@ func getFlaggedPostsForUser(c *Context, w http.ResponseWriter, r *http.Request)
 
 	pl.SortByCreateAt()
 
+	if c.HandleEtag(pl.Etag(), "Get Flagged Posts", w, r) {
+		return
+	}
+
+	w.Header().Set(model.HEADER_ETAG_SERVER, pl.Etag())
+	w.Write([]byte(c.App.PreparePostListForClient(pl).ToJson()))
+}
+
+func getEphemeralPostsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.App.Session.UserId != c.Params.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	var since int64
+	if sinceString := r.URL.Query().Get("since"); len(sinceString) > 0 {
+		var parseError error
+		since, parseError = strconv.ParseInt(sinceString, 10, 64)
+		if parseError != nil {
+			c.SetInvalidParam("since")
+			return
+		}
+	}
+
+	list, err := c.App.GetEphemeralPostsForUser(c.Params.UserId, since)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	w.Write([]byte(c.App.PreparePostListForClient(pl).ToJson()))
+	w.Write([]byte(list.ToJson()))
 }
 
 func getPost(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -335,6 +439,79 @@ func getPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(post.ToJson()))
 }
 
+func getPostsByIds(c *Context, w http.ResponseWriter, r *http.Request) {
+	postIds := model.ArrayFromJson(r.Body)
+	if len(postIds) == 0 || len(postIds) > model.POSTS_BY_IDS_MAX_POST_IDS {
+		c.SetInvalidParam("post_ids")
+		return
+	}
+
+	for _, postId := range postIds {
+		if !model.IsValidId(postId) {
+			c.SetInvalidParam("post_ids")
+			return
+		}
+	}
+
+	posts, err := c.App.GetPostsByIds(postIds)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	result := &model.PostsByIds{
+		Posts:  []*model.Post{},
+		Errors: map[string]*model.AppError{},
+	}
+
+	// The permissions are checked once per channel since the posts referenced together often share one
+	canRead := map[string]bool{}
+	found := map[string]bool{}
+	for _, post := range posts {
+		found[post.Id] = true
+
+		allowed, checked := canRead[post.ChannelId]
+		if !checked {
+			allowed = sessionCanReadChannel(c, post.ChannelId)
+			canRead[post.ChannelId] = allowed
+		}
+
+		if !allowed {
+			result.Errors[post.Id] = model.NewAppError("getPostsByIds", "api.post.get_posts_by_ids.permissions.app_error", nil, "", http.StatusForbidden)
+			continue
+		}
+
+		result.Posts = append(result.Posts, c.App.PreparePostForClient(post, false, false))
+	}
+
+	for _, postId := range postIds {
+		if !found[postId] {
+			result.Errors[postId] = model.NewAppError("getPostsByIds", "api.post.get_posts_by_ids.not_found.app_error", nil, "", http.StatusNotFound)
+		}
+	}
+
+	for _, postErr := range result.Errors {
+		postErr.Translate(c.App.T)
+	}
+
+	w.Write([]byte(result.ToJson()))
+}
+
+// sessionCanReadChannel returns true if the session can read the posts of the channel, either as a member or, for a
+// public channel, as a member of its team.
+func sessionCanReadChannel(c *Context, channelId string) bool {
+	if c.App.SessionHasPermissionToChannel(c.App.Session, channelId, model.PERMISSION_READ_CHANNEL) {
+		return true
+	}
+
+	channel, err := c.App.GetChannel(channelId)
+	if err != nil {
+		return false
+	}
+
+	return channel.Type == model.CHANNEL_OPEN && c.App.SessionHasPermissionToTeam(c.App.Session, channel.TeamId, model.PERMISSION_READ_PUBLIC_CHANNEL)
+}
+
 func deletePost(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
@@ -474,11 +651,17 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+
+	if c.HandleEtag(results.Etag(), "Search Posts", w, r) {
+		return
+	}
+
 	clientPostList := c.App.PreparePostListForClient(results.PostList)
 
 	results = model.MakePostSearchResults(clientPostList, results.Matches)
 
-	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set(model.HEADER_ETAG_SERVER, results.Etag())
 	w.Write([]byte(results.ToJson()))
 }
 
@@ -623,6 +806,12 @@ func saveIsPinnedPost(c *Context, w http.ResponseWriter, r *http.Request, isPinn
 		return
 	}
 
+	if post.IsPinned != isPinned {
+		if err := c.App.RecordPostPinEvent(post, c.App.Session.UserId, isPinned); err != nil {
+			mlog.Error("Failed to record a post pin event", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	}
+
 	ReturnStatusOK(w)
 }
 
@@ -634,6 +823,53 @@ func unpinPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	saveIsPinnedPost(c, w, r, false)
 }
 
+// getPermalinkPreview resolves a permalink to a post for the user without joining them to its channel. The post is
+// redacted if they can't read the channel.
+func getPermalinkPreview(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	withThread, _ := strconv.ParseBool(r.URL.Query().Get("thread"))
+
+	preview, err := c.App.GetPermalinkPreview(c.Params.PostId, c.App.Session.UserId, withThread)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(preview.ToJson()))
+}
+
+// redactPost replaces the message and/or removes the attachments of a post, keeping the post itself in its thread.
+func redactPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	redaction := model.PostRedactionFromJson(r.Body)
+	if redaction == nil {
+		c.SetInvalidParam("redaction")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	post, err := c.App.RedactPost(c.Params.PostId, redaction, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("post_id=" + post.Id)
+	w.Write([]byte(post.ToJson()))
+}
+
 func getFileInfosForPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
@@ -0,0 +1,105 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitPostPurge() {
+	api.BaseRoutes.PostPurges.Handle("", api.ApiSessionRequired(createPostPurge)).Methods("POST")
+	api.BaseRoutes.PostPurges.Handle("", api.ApiSessionRequired(getPostPurges)).Methods("GET")
+	api.BaseRoutes.PostPurge.Handle("", api.ApiSessionRequired(getPostPurge)).Methods("GET")
+	api.BaseRoutes.PostPurge.Handle("/cancel", api.ApiSessionRequired(cancelPostPurge)).Methods("POST")
+}
+
+func createPostPurge(c *Context, w http.ResponseWriter, r *http.Request) {
+	purge := model.PostPurgeFromJson(r.Body)
+	if purge == nil {
+		c.SetInvalidParam("post_purge")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	purge.Id = ""
+	purge.CreatorId = c.App.Session.UserId
+
+	purge, err := c.App.CreatePostPurge(purge)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("id=" + purge.Id + " scope=" + purge.Scope + " target_id=" + purge.TargetId)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(purge.ToJson()))
+}
+
+func getPostPurges(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	purges, err := c.App.GetPostPurges(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PostPurgeListToJson(purges)))
+}
+
+func getPostPurge(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePurgeId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	purge, err := c.App.GetPostPurge(c.Params.PurgeId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(purge.ToJson()))
+}
+
+func cancelPostPurge(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePurgeId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	purge, err := c.App.GetPostPurge(c.Params.PurgeId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	purge, err = c.App.CancelPostPurge(purge)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("id=" + purge.Id)
+	w.Write([]byte(purge.ToJson()))
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestPostPurge(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	post := th.CreatePost()
+	delayed := &model.PostPurge{Scope: model.POST_PURGE_SCOPE_POST, TargetId: post.Id, PurgeAt: model.GetMillis() + 60*60*1000}
+
+	_, resp := th.Client.CreatePostPurge(delayed)
+	CheckForbiddenStatus(t, resp)
+
+	purge, resp := th.SystemAdminClient.CreatePostPurge(delayed)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.SystemAdminUser.Id, purge.CreatorId)
+	assert.Equal(t, model.POST_PURGE_STATUS_PENDING, purge.Status)
+
+	_, resp = th.Client.GetPostPurge(purge.Id)
+	CheckForbiddenStatus(t, resp)
+
+	received, resp := th.SystemAdminClient.GetPostPurge(purge.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, purge.Id, received.Id)
+
+	purges, resp := th.SystemAdminClient.GetPostPurges(0, 60)
+	CheckNoError(t, resp)
+	require.NotEmpty(t, purges)
+	assert.Equal(t, purge.Id, purges[0].Id)
+
+	_, resp = th.Client.CancelPostPurge(purge.Id)
+	CheckForbiddenStatus(t, resp)
+
+	purge, resp = th.SystemAdminClient.CancelPostPurge(purge.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, model.POST_PURGE_STATUS_CANCELED, purge.Status)
+
+	_, resp = th.SystemAdminClient.CancelPostPurge(purge.Id)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetPostPurge(model.NewId())
+	CheckNotFoundStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetPostPurge("junk")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.CreatePostPurge(&model.PostPurge{Scope: model.POST_PURGE_SCOPE_POST, TargetId: model.NewId()})
+	CheckNotFoundStatus(t, resp)
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitPostReport() {
+	api.BaseRoutes.Post.Handle("/report", api.ApiSessionRequired(reportPost)).Methods("POST")
+	api.BaseRoutes.PostReports.Handle("", api.ApiSessionRequired(getPostReports)).Methods("GET")
+	api.BaseRoutes.PostReport.Handle("", api.ApiSessionRequired(getPostReport)).Methods("GET")
+	api.BaseRoutes.PostReport.Handle("/state", api.ApiSessionRequired(updatePostReportState)).Methods("PUT")
+	api.BaseRoutes.PostReport.Handle("/history", api.ApiSessionRequired(getPostReportHistory)).Methods("GET")
+	api.BaseRoutes.PostReport.Handle("/actions", api.ApiSessionRequired(doPostReportAction)).Methods("POST")
+}
+
+func reportPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.MapFromJson(r.Body)
+	reason := props["reason"]
+	if !model.IsValidPostReportReason(reason) {
+		c.SetInvalidParam("reason")
+		return
+	}
+
+	post, err := c.App.GetSinglePost(c.Params.PostId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, post.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	report, err := c.App.ReportPost(post, c.App.Session.UserId, reason, props["comment"])
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("report_id=" + report.Id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(report.ToJson()))
+}
+
+func getPostReports(c *Context, w http.ResponseWriter, r *http.Request) {
+	state := r.URL.Query().Get("state")
+	if state != "" && !model.IsValidPostReportState(state) {
+		c.SetInvalidUrlParam("state")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	reports, err := c.App.GetPostReports(state, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PostReportListToJson(reports)))
+}
+
+func getPostReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReportId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	report, err := c.App.GetPostReport(c.Params.ReportId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(report.ToJson()))
+}
+
+func updatePostReportState(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReportId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.MapFromJson(r.Body)
+	state := props["state"]
+	if !model.IsValidPostReportState(state) {
+		c.SetInvalidParam("state")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	report, err := c.App.GetPostReport(c.Params.ReportId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	moderator, err := c.App.GetUser(c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	report, err = c.App.UpdatePostReportState(report, state, props["comment"], moderator)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - state=" + report.State)
+	w.Write([]byte(report.ToJson()))
+}
+
+func getPostReportHistory(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReportId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	events, err := c.App.GetPostReportHistory(c.Params.ReportId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PostReportEventListToJson(events)))
+}
+
+// doPostReportAction resolves a report from the actions of the post notifying the moderators channel of it, which
+// are sent with the session of the moderator clicking them.
+func doPostReportAction(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReportId()
+	if c.Err != nil {
+		return
+	}
+
+	request := model.PostActionIntegrationRequestFromJson(r.Body)
+	if request == nil {
+		c.SetInvalidParam("request")
+		return
+	}
+
+	action, _ := request.Context["action"].(string)
+	state := model.PostReportStateForAction(action)
+	if state == "" {
+		c.SetInvalidParam("action")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) && !c.App.IsPostReportModerator(c.App.Session.UserId) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	report, err := c.App.GetPostReport(c.Params.ReportId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	moderator, err := c.App.GetUser(c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if _, err = c.App.UpdatePostReportState(report, state, "", moderator); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - state=" + state)
+
+	response := &model.PostActionIntegrationResponse{}
+	w.Write(response.ToJson())
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestPostReports(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	post := th.CreatePostWithClient(th.Client, th.BasicChannel)
+
+	_, resp := th.Client.ReportPost(post.Id, model.POST_REPORT_REASON_SPAM, "")
+	CheckNotImplementedStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PostReportSettings.Enable = true })
+
+	_, resp = th.Client.ReportPost(post.Id, model.POST_REPORT_REASON_SPAM, "")
+	CheckBadRequestStatus(t, resp)
+
+	th.LoginBasic2()
+
+	_, resp = th.Client.ReportPost(post.Id, "junk", "")
+	CheckBadRequestStatus(t, resp)
+
+	report, resp := th.Client.ReportPost(post.Id, model.POST_REPORT_REASON_SPAM, "buy now")
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, model.POST_REPORT_STATE_OPEN, report.State)
+	assert.Equal(t, post.Message, report.PostMessage)
+
+	again, resp := th.Client.ReportPost(post.Id, model.POST_REPORT_REASON_SPAM, "")
+	CheckNoError(t, resp)
+	assert.Equal(t, report.Id, again.Id)
+
+	_, resp = th.Client.GetPostReports("", 0, 100)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.UpdatePostReportState(report.Id, model.POST_REPORT_STATE_DISMISSED, "")
+	CheckForbiddenStatus(t, resp)
+
+	reports, resp := th.SystemAdminClient.GetPostReports(model.POST_REPORT_STATE_OPEN, 0, 100)
+	CheckNoError(t, resp)
+	require.Len(t, reports, 1)
+	assert.Equal(t, report.Id, reports[0].Id)
+
+	got, resp := th.SystemAdminClient.GetPostReport(report.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, th.BasicUser2.Id, got.ReporterId)
+
+	updated, resp := th.SystemAdminClient.UpdatePostReportState(report.Id, model.POST_REPORT_STATE_DELETED, "spam")
+	CheckNoError(t, resp)
+	assert.Equal(t, model.POST_REPORT_STATE_DELETED, updated.State)
+	assert.Equal(t, th.SystemAdminUser.Id, updated.ResolverId)
+
+	_, resp = th.SystemAdminClient.GetPost(post.Id, "")
+	CheckNotFoundStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.UpdatePostReportState(report.Id, model.POST_REPORT_STATE_WARNED, "")
+	CheckBadRequestStatus(t, resp)
+
+	events, resp := th.SystemAdminClient.GetPostReportHistory(report.Id)
+	CheckNoError(t, resp)
+	require.Len(t, events, 2)
+	assert.Equal(t, model.POST_REPORT_STATE_OPEN, events[0].ToState)
+	assert.Equal(t, model.POST_REPORT_STATE_DELETED, events[1].ToState)
+	assert.Equal(t, "spam", events[1].Comment)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitPostStar() {
+	api.BaseRoutes.PostForUser.Handle("/star", api.ApiSessionRequired(starPost)).Methods("POST")
+	api.BaseRoutes.PostForUser.Handle("/star", api.ApiSessionRequired(getPostStar)).Methods("GET")
+	api.BaseRoutes.PostForUser.Handle("/star", api.ApiSessionRequired(unstarPost)).Methods("DELETE")
+	api.BaseRoutes.Post.Handle("/stars/count", api.ApiSessionRequired(getPostStarCount)).Methods("GET")
+	api.BaseRoutes.Posts.Handle("/ids/stars/count", api.ApiSessionRequired(getPostStarCounts)).Methods("POST")
+}
+
+func starPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.Params.UserId != c.App.Session.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	star, err := c.App.StarPost(c.Params.PostId, c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(star.ToJson()))
+}
+
+func getPostStar(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	star, err := c.App.GetPostStar(c.Params.PostId, c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(star.ToJson()))
+}
+
+func unstarPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.Params.UserId != c.App.Session.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	if err := c.App.UnstarPost(c.Params.PostId, c.Params.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func getPostStarCount(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	post, err := c.App.GetSinglePost(c.Params.PostId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	// Star counts are shown to the author of the post only
+	if post.UserId != c.App.Session.UserId {
+		c.Err = model.NewAppError("getPostStarCount", "api.post_star.get_count.author.app_error", nil, "post_id="+post.Id, http.StatusForbidden)
+		return
+	}
+
+	count, err := c.App.GetPostStarCount(post.Id)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(count.ToJson()))
+}
+
+func getPostStarCounts(c *Context, w http.ResponseWriter, r *http.Request) {
+	postIds := model.ArrayFromJson(r.Body)
+	if len(postIds) > model.POST_STAR_COUNTS_MAX_POST_IDS {
+		c.SetInvalidParam("post_ids")
+		return
+	}
+
+	for _, postId := range postIds {
+		if !model.IsValidId(postId) {
+			c.SetInvalidParam("post_ids")
+			return
+		}
+	}
+
+	counts, err := c.App.GetPostStarCountsForAuthor(c.App.Session.UserId, postIds)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PostStarCountListToJson(counts)))
+}
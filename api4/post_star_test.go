@@ -0,0 +1,105 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestStarPost(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	post := th.BasicPost
+
+	star, resp := Client.StarPost(th.BasicUser.Id, post.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, post.Id, star.PostId)
+	assert.Equal(t, th.BasicUser.Id, star.UserId)
+
+	// Starring twice keeps the original star
+	again, resp := Client.StarPost(th.BasicUser.Id, post.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, star.CreateAt, again.CreateAt)
+
+	got, resp := Client.GetPostStar(th.BasicUser.Id, post.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, star.CreateAt, got.CreateAt)
+
+	_, resp = Client.StarPost(th.BasicUser2.Id, post.Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = Client.StarPost(th.BasicUser.Id, model.NewId())
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = Client.StarPost(th.BasicUser.Id, "junk")
+	CheckBadRequestStatus(t, resp)
+
+	private := th.CreateChannelWithClientAndTeam(th.SystemAdminClient, model.CHANNEL_PRIVATE, th.BasicTeam.Id)
+	privatePost, appErr := th.App.CreatePost(&model.Post{UserId: th.SystemAdminUser.Id, ChannelId: private.Id, Message: "private"}, private, false)
+	require.Nil(t, appErr)
+
+	_, resp = Client.StarPost(th.BasicUser.Id, privatePost.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := Client.UnstarPost(th.BasicUser.Id, post.Id)
+	CheckNoError(t, resp)
+	assert.True(t, ok)
+
+	_, resp = Client.GetPostStar(th.BasicUser.Id, post.Id)
+	CheckNotFoundStatus(t, resp)
+
+	_, resp = Client.UnstarPost(th.BasicUser2.Id, post.Id)
+	CheckForbiddenStatus(t, resp)
+
+	Client.Logout()
+	_, resp = Client.StarPost(th.BasicUser.Id, post.Id)
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestGetPostStarCount(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	post := th.CreatePost()
+	otherPost := th.CreatePostWithClient(th.SystemAdminClient, th.BasicChannel)
+
+	_, resp := th.SystemAdminClient.StarPost(th.SystemAdminUser.Id, post.Id)
+	CheckNoError(t, resp)
+
+	th.LoginBasic2()
+	_, resp = Client.StarPost(th.BasicUser2.Id, post.Id)
+	CheckNoError(t, resp)
+	_, resp = Client.StarPost(th.BasicUser2.Id, otherPost.Id)
+	CheckNoError(t, resp)
+
+	// Only the author of the post sees how many users starred it
+	_, resp = Client.GetPostStarCount(post.Id)
+	CheckForbiddenStatus(t, resp)
+
+	th.LoginBasic()
+	count, resp := Client.GetPostStarCount(post.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, post.Id, count.PostId)
+	assert.Equal(t, int64(2), count.Count)
+
+	counts, resp := Client.GetPostStarCounts([]string{post.Id, otherPost.Id})
+	CheckNoError(t, resp)
+	require.Len(t, counts, 1)
+	assert.Equal(t, post.Id, counts[0].PostId)
+	assert.Equal(t, int64(2), counts[0].Count)
+
+	_, resp = Client.GetPostStarCounts([]string{"junk"})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetPostStarCount(model.NewId())
+	CheckNotFoundStatus(t, resp)
+}
@@ -4,6 +4,9 @@
 package api4
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -957,6 +960,69 @@ func TestUnpinPost(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestExportChannelPosts(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	post := th.CreatePost()
+	_, resp := Client.SaveReaction(&model.Reaction{UserId: th.BasicUser.Id, PostId: post.Id, EmojiName: "smile"})
+	CheckNoError(t, resp)
+
+	options := &model.ChannelPostExportOptions{Format: model.CHANNEL_POST_EXPORT_FORMAT_CSV}
+
+	_, resp = Client.ExportChannelPosts(th.BasicChannel.Id, options)
+	CheckForbiddenStatus(t, resp)
+
+	t.Run("csv", func(t *testing.T) {
+		data, resp := th.SystemAdminClient.ExportChannelPosts(th.BasicChannel.Id, options)
+		CheckNoError(t, resp)
+
+		rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		require.Nil(t, err)
+		require.True(t, len(rows) > 1)
+		assert.Equal(t, model.ChannelPostExportCSVHeader, rows[0])
+
+		found := false
+		for _, row := range rows[1:] {
+			if row[0] == post.Id {
+				found = true
+				assert.Equal(t, th.BasicUser.Username, row[4])
+				assert.Equal(t, post.Message, row[7])
+				assert.Equal(t, "smile:1", row[8])
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("json with gzip", func(t *testing.T) {
+		data, resp := th.SystemAdminClient.ExportChannelPosts(th.BasicChannel.Id, &model.ChannelPostExportOptions{
+			Format: model.CHANNEL_POST_EXPORT_FORMAT_JSON,
+			Since:  post.CreateAt,
+			Gzip:   true,
+		})
+		CheckNoError(t, resp)
+
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		require.Nil(t, err)
+
+		var records []*model.ChannelPostExportRecord
+		require.Nil(t, json.NewDecoder(reader).Decode(&records))
+		require.Len(t, records, 1)
+		assert.Equal(t, post.Id, records[0].PostId)
+		require.Len(t, records[0].Reactions, 1)
+		assert.Equal(t, th.BasicUser.Username, records[0].Reactions[0].Username)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.ExportChannelPosts(th.BasicChannel.Id, &model.ChannelPostExportOptions{Format: "xml"})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.ExportChannelPosts(model.NewId(), options)
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
 func TestGetPostsForChannel(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -1410,6 +1476,43 @@ func TestGetFlaggedPostsForUser(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetFlaggedPostsForUserEtag(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	user := th.BasicUser
+	post1 := th.CreatePost()
+	post2 := th.CreatePost()
+
+	preferences := model.Preferences{
+		{UserId: user.Id, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: post1.Id, Value: "true"},
+		{UserId: user.Id, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: post2.Id, Value: "true"},
+	}
+	_, resp := Client.UpdatePreferences(user.Id, &preferences)
+	CheckNoError(t, resp)
+
+	route := Client.GetUserRoute(user.Id) + "/posts/flagged"
+
+	r, err := Client.DoApiGet(route, "")
+	require.Nil(t, err)
+	etag := r.Header.Get(model.HEADER_ETAG_SERVER)
+	r.Body.Close()
+	require.NotEmpty(t, etag)
+
+	r, err = Client.DoApiGet(route, etag)
+	require.Nil(t, err)
+	r.Body.Close()
+	assert.Equal(t, http.StatusNotModified, r.StatusCode)
+
+	_, resp = Client.DeletePreferences(user.Id, &model.Preferences{preferences[0]})
+	CheckNoError(t, resp)
+
+	r, err = Client.DoApiGet(route, etag)
+	require.Nil(t, err)
+	r.Body.Close()
+	assert.Equal(t, http.StatusOK, r.StatusCode, "unflagging a post should change the etag")
+}
+
 func TestGetPostsBefore(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -2121,6 +2224,67 @@ func TestGetPost(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetPostsByIds(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	privatePost := th.CreatePostWithClient(Client, th.BasicPrivateChannel)
+	deletedPost := th.CreatePost()
+	_, resp := Client.DeletePost(deletedPost.Id)
+	require.Nil(t, resp.Error)
+	missingId := model.NewId()
+
+	result, resp := Client.GetPostsByIds([]string{th.BasicPost.Id, privatePost.Id, deletedPost.Id, missingId})
+	CheckNoError(t, resp)
+	require.Len(t, result.Posts, 2)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, http.StatusNotFound, result.Errors[deletedPost.Id].StatusCode)
+	assert.Equal(t, http.StatusNotFound, result.Errors[missingId].StatusCode)
+
+	t.Run("public channel the user left", func(t *testing.T) {
+		Client.RemoveUserFromChannel(th.BasicChannel.Id, th.BasicUser.Id)
+		defer th.AddUserToChannel(th.BasicUser, th.BasicChannel)
+
+		result, resp := Client.GetPostsByIds([]string{th.BasicPost.Id})
+		CheckNoError(t, resp)
+		require.Len(t, result.Posts, 1)
+		assert.Equal(t, th.BasicPost.Id, result.Posts[0].Id)
+	})
+
+	t.Run("private channel the user left", func(t *testing.T) {
+		Client.RemoveUserFromChannel(th.BasicPrivateChannel.Id, th.BasicUser.Id)
+		defer th.AddUserToChannel(th.BasicUser, th.BasicPrivateChannel)
+
+		result, resp := Client.GetPostsByIds([]string{th.BasicPost.Id, privatePost.Id})
+		CheckNoError(t, resp)
+		require.Len(t, result.Posts, 1)
+		assert.Equal(t, th.BasicPost.Id, result.Posts[0].Id)
+		require.Contains(t, result.Errors, privatePost.Id)
+		assert.Equal(t, http.StatusForbidden, result.Errors[privatePost.Id].StatusCode)
+		assert.NotEqual(t, "api.post.get_posts_by_ids.permissions.app_error", result.Errors[privatePost.Id].Message)
+	})
+
+	t.Run("invalid ids", func(t *testing.T) {
+		_, resp := Client.GetPostsByIds([]string{})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.GetPostsByIds([]string{"junk"})
+		CheckBadRequestStatus(t, resp)
+
+		tooMany := make([]string, model.POSTS_BY_IDS_MAX_POST_IDS+1)
+		for i := range tooMany {
+			tooMany[i] = model.NewId()
+		}
+		_, resp = Client.GetPostsByIds(tooMany)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	Client.Logout()
+	_, resp = Client.GetPostsByIds([]string{th.BasicPost.Id})
+	CheckUnauthorizedStatus(t, resp)
+}
+
 func TestDeletePost(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -2647,3 +2811,106 @@ func TestGetFileInfosForPost(t *testing.T) {
 	_, resp = th.SystemAdminClient.GetFileInfosForPost(th.BasicPost.Id, "")
 	CheckNoError(t, resp)
 }
+
+func TestGetPermalinkPreview(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	root := th.CreatePost()
+	reply, resp := Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, RootId: root.Id, Message: "reply"})
+	CheckNoError(t, resp)
+
+	preview, resp := Client.GetPermalinkPreview(reply.Id, true)
+	CheckNoError(t, resp)
+	assert.Equal(t, model.PERMALINK_ACCESS_CHANNEL, preview.Access)
+	assert.Equal(t, reply.Id, preview.Post.Id)
+	require.NotNil(t, preview.Thread)
+	assert.Len(t, preview.Thread.Order, 2)
+
+	preview, resp = Client.GetPermalinkPreview(root.Id, false)
+	CheckNoError(t, resp)
+	assert.Nil(t, preview.Thread)
+
+	privatePost := th.CreatePostWithClient(Client, th.BasicPrivateChannel)
+
+	th.LoginBasic2()
+	preview, resp = Client.GetPermalinkPreview(privatePost.Id, true)
+	CheckNoError(t, resp)
+	assert.True(t, preview.IsRedacted())
+	assert.Nil(t, preview.Post)
+
+	_, resp = Client.GetPermalinkPreview(model.NewId(), false)
+	CheckNotFoundStatus(t, resp)
+
+	Client.Logout()
+	_, resp = Client.GetPermalinkPreview(root.Id, false)
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestRedactPost(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	post := th.CreatePost()
+	redaction := &model.PostRedaction{Message: true, Attachments: true}
+
+	_, resp := Client.RedactPost(post.Id, redaction)
+	CheckForbiddenStatus(t, resp)
+
+	redacted, resp := th.SystemAdminClient.RedactPost(post.Id, redaction)
+	CheckNoError(t, resp)
+	assert.True(t, redacted.IsRedacted())
+	assert.NotEqual(t, post.Message, redacted.Message)
+	assert.Empty(t, redacted.FileIds)
+	assert.NotZero(t, redacted.EditAt)
+
+	received, resp := Client.GetPost(post.Id, "")
+	CheckNoError(t, resp)
+	assert.Equal(t, redacted.Message, received.Message)
+
+	_, resp = th.SystemAdminClient.RedactPost(post.Id, &model.PostRedaction{})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.RedactPost(model.NewId(), redaction)
+	CheckNotFoundStatus(t, resp)
+}
+
+func TestGetEphemeralPostsForUser(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	_, resp := Client.UpdatePreferences(th.BasicUser.Id, &model.Preferences{{
+		UserId:   th.BasicUser.Id,
+		Category: model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS,
+		Name:     model.PREFERENCE_NAME_PERSIST_EPHEMERAL,
+		Value:    "true",
+	}})
+	CheckNoError(t, resp)
+
+	post := th.App.SendEphemeralPost(th.BasicUser.Id, &model.Post{
+		ChannelId: th.BasicChannel.Id,
+		Message:   "ephemeral",
+	})
+
+	list, resp := Client.GetEphemeralPostsForUser(th.BasicUser.Id, 0)
+	CheckNoError(t, resp)
+	require.Equal(t, []string{post.Id}, list.Order)
+	assert.Equal(t, "ephemeral", list.Posts[post.Id].Message)
+
+	list, resp = Client.GetEphemeralPostsForUser(th.BasicUser.Id, model.GetMillis())
+	CheckNoError(t, resp)
+	assert.Empty(t, list.Order)
+
+	_, resp = Client.GetEphemeralPostsForUser(th.BasicUser2.Id, 0)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetEphemeralPostsForUser(th.BasicUser.Id, 0)
+	CheckForbiddenStatus(t, resp)
+
+	Client.Logout()
+	_, resp = Client.GetEphemeralPostsForUser(th.BasicUser.Id, 0)
+	CheckUnauthorizedStatus(t, resp)
+}
@@ -5,6 +5,7 @@ package api4
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/mattermost/mattermost-server/model"
 )
@@ -13,8 +14,15 @@ func (api *API) InitPreference() {
 	api.BaseRoutes.Preferences.Handle("", api.ApiSessionRequired(getPreferences)).Methods("GET")
 	api.BaseRoutes.Preferences.Handle("", api.ApiSessionRequired(updatePreferences)).Methods("PUT")
 	api.BaseRoutes.Preferences.Handle("/delete", api.ApiSessionRequired(deletePreferences)).Methods("POST")
+	api.BaseRoutes.Preferences.Handle("/namespaces", api.ApiSessionRequired(getPreferenceNamespaces)).Methods("GET")
+	api.BaseRoutes.Preferences.Handle("/namespaces/patch", api.ApiSessionRequired(patchPreferenceNamespaces)).Methods("PUT")
+	api.BaseRoutes.Preferences.Handle("/namespaces/versions", api.ApiSessionRequired(getPreferenceVersions)).Methods("GET")
 	api.BaseRoutes.Preferences.Handle("/{category:[A-Za-z0-9_]+}", api.ApiSessionRequired(getPreferencesByCategory)).Methods("GET")
 	api.BaseRoutes.Preferences.Handle("/{category:[A-Za-z0-9_]+}/name/{preference_name:[A-Za-z0-9_]+}", api.ApiSessionRequired(getPreferenceByCategoryAndName)).Methods("GET")
+
+	api.BaseRoutes.Users.Handle("/preferences/bulk", api.ApiSessionRequired(createBulkPreferencesJob)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/preferences/bulk/{job_id:[A-Za-z0-9]+}/manifest", api.ApiSessionRequired(getBulkPreferencesManifest)).Methods("GET")
+	api.BaseRoutes.Users.Handle("/preferences/bulk/{job_id:[A-Za-z0-9]+}/rollback", api.ApiSessionRequired(rollbackBulkPreferencesJob)).Methods("POST")
 }
 
 func getPreferences(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -145,3 +153,155 @@ func deletePreferences(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	ReturnStatusOK(w)
 }
+
+func getPreferenceVersions(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	versions, err := c.App.GetPreferenceVersionsForUser(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PreferenceVersionsToJson(versions)))
+}
+
+func getPreferenceNamespaces(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	var names []string
+	if query := r.URL.Query().Get("names"); query != "" {
+		names = strings.Split(query, ",")
+	}
+
+	namespaces, err := c.App.GetPreferenceNamespacesForUser(c.Params.UserId, names)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PreferenceNamespacesToJson(namespaces)))
+}
+
+func patchPreferenceNamespaces(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	patch := model.PreferencesPatchFromJson(r.Body)
+	if patch == nil {
+		c.SetInvalidParam("patch")
+		return
+	}
+
+	if flagged := patch[model.PREFERENCE_CATEGORY_FLAGGED_POST]; flagged != nil {
+		for postId := range flagged.Set {
+			post, err := c.App.GetSinglePost(postId)
+			if err != nil {
+				c.SetInvalidParam("preference.name")
+				return
+			}
+
+			if !c.App.SessionHasPermissionToChannel(c.App.Session, post.ChannelId, model.PERMISSION_READ_CHANNEL) {
+				c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+				return
+			}
+		}
+	}
+
+	diffs, err := c.App.PatchPreferences(c.Params.UserId, patch)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PreferenceNamespaceDiffListToJson(diffs)))
+}
+
+func createBulkPreferencesJob(c *Context, w http.ResponseWriter, r *http.Request) {
+	operation := model.BulkPreferencesOperationFromJson(r.Body)
+	if operation == nil || operation.Action == model.BULK_PREFERENCES_ACTION_ROLLBACK {
+		c.SetInvalidParam("operation")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	job, err := c.App.CreateBulkPreferencesJob(operation)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(job.ToJson()))
+}
+
+func getBulkPreferencesManifest(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	manifest, err := c.App.GetBulkPreferencesManifest(c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(manifest.ToJson()))
+}
+
+func rollbackBulkPreferencesJob(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	job, err := c.App.CreateBulkPreferencesJob(&model.BulkPreferencesOperation{
+		Action:        model.BULK_PREFERENCES_ACTION_ROLLBACK,
+		RollbackJobId: c.Params.JobId,
+	})
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(job.ToJson()))
+}
@@ -4,10 +4,14 @@
 package api4
 
 import (
+	"net/http"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/mattermost/mattermost-server/model"
 )
 
@@ -411,3 +415,103 @@ func TestDeletePreferencesWebsocket(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateBulkPreferencesJob(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	operation := &model.BulkPreferencesOperation{
+		Action:      model.BULK_PREFERENCES_ACTION_RESET,
+		TeamId:      th.BasicTeam.Id,
+		Preferences: model.Preferences{{Category: model.PREFERENCE_CATEGORY_NOTIFICATIONS, Name: model.PREFERENCE_NAME_EMAIL_INTERVAL}},
+	}
+
+	_, resp := Client.CreateBulkPreferencesJob(operation)
+	CheckForbiddenStatus(t, resp)
+
+	job, resp := th.SystemAdminClient.CreateBulkPreferencesJob(operation)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	if job.Type != model.JOB_TYPE_BULK_PREFERENCES {
+		t.Fatal("wrong job type")
+	}
+	if job.Data[model.BULK_PREFERENCES_JOB_DATA_ACTION] != model.BULK_PREFERENCES_ACTION_RESET {
+		t.Fatal("wrong job action")
+	}
+
+	_, resp = th.SystemAdminClient.CreateBulkPreferencesJob(&model.BulkPreferencesOperation{Action: model.BULK_PREFERENCES_ACTION_SET, AllUsers: true})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.CreateBulkPreferencesJob(&model.BulkPreferencesOperation{Action: model.BULK_PREFERENCES_ACTION_ROLLBACK, RollbackJobId: job.Id})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.RollbackBulkPreferencesJob(job.Id)
+	CheckForbiddenStatus(t, resp)
+
+	// The job has not run yet so there is nothing to roll back.
+	_, resp = th.SystemAdminClient.RollbackBulkPreferencesJob(job.Id)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetBulkPreferencesManifest(job.Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetBulkPreferencesManifest(job.Id)
+	CheckNotFoundStatus(t, resp)
+}
+
+func TestPreferenceNamespaces(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	user := th.BasicUser
+
+	diffs, resp := Client.PatchPreferenceNamespaces(user.Id, model.PreferencesPatch{
+		model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS: {
+			Set: map[string]string{model.PREFERENCE_NAME_USE_MILITARY_TIME: "true"},
+		},
+	})
+	CheckNoError(t, resp)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, map[string]string{model.PREFERENCE_NAME_USE_MILITARY_TIME: "true"}, diffs[0].Set)
+	version := diffs[0].Version
+
+	versions, resp := Client.GetPreferenceVersions(user.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, version, versions[model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS])
+
+	namespaces, resp := Client.GetPreferenceNamespaces(user.Id, []string{model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS})
+	CheckNoError(t, resp)
+	require.Len(t, namespaces, 1)
+	assert.Equal(t, "true", namespaces[model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS].Preferences[model.PREFERENCE_NAME_USE_MILITARY_TIME])
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, resp := Client.PatchPreferenceNamespaces(user.Id, model.PreferencesPatch{
+			model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS: {
+				Set: map[string]string{model.PREFERENCE_NAME_USE_MILITARY_TIME: "often"},
+			},
+		})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("stale base version", func(t *testing.T) {
+		baseVersion := version - 1
+		_, resp := Client.PatchPreferenceNamespaces(user.Id, model.PreferencesPatch{
+			model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS: {
+				Set:         map[string]string{model.PREFERENCE_NAME_USE_MILITARY_TIME: "false"},
+				BaseVersion: &baseVersion,
+			},
+		})
+		CheckErrorMessage(t, resp, "store.sql_preference.patch.conflict.app_error")
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		_, resp := Client.GetPreferenceVersions(th.BasicUser2.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.GetPreferenceNamespaces(th.BasicUser2.Id, nil)
+		CheckForbiddenStatus(t, resp)
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+func (api *API) InitPublicPostLink() {
+	api.BaseRoutes.Post.Handle("/public_links", api.ApiSessionRequired(createPublicPostLink)).Methods("POST")
+	api.BaseRoutes.Post.Handle("/public_links", api.ApiSessionRequired(getPublicPostLinks)).Methods("GET")
+	api.BaseRoutes.Post.Handle("/public_links/{link_id:[A-Za-z0-9]+}", api.ApiSessionRequired(revokePublicPostLink)).Methods("DELETE")
+	api.BaseRoutes.PublicPost.Handle("", api.ApiHandler(getPublicPost)).Methods("GET")
+}
+
+func createPublicPostLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	link := model.PublicPostLinkFromJson(r.Body)
+	if link == nil {
+		link = &model.PublicPostLink{}
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	link.Id = ""
+	link.PostId = c.Params.PostId
+	link.CreatorId = c.App.Session.UserId
+
+	link, err := c.App.CreatePublicPostLink(link)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("post_id=" + link.PostId + " link_id=" + link.Id)
+	link.Link = c.App.GeneratePublicPostLink(c.GetSiteURLHeader(), link)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(link.ToJson()))
+}
+
+func getPublicPostLinks(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	links, err := c.App.GetPublicPostLinks(c.Params.PostId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	for _, link := range links {
+		link.Link = c.App.GeneratePublicPostLink(c.GetSiteURLHeader(), link)
+	}
+
+	w.Write([]byte(model.PublicPostLinkListToJson(links)))
+}
+
+// revokePublicPostLink revokes a link. Links can be revoked by whoever created them or by a system admin.
+func revokePublicPostLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId().RequirePublicPostLinkId()
+	if c.Err != nil {
+		return
+	}
+
+	link, err := c.App.GetPublicPostLink(c.Params.PublicPostLinkId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if link.PostId != c.Params.PostId {
+		c.SetInvalidUrlParam("link_id")
+		return
+	}
+
+	if link.CreatorId != c.App.Session.UserId && !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.RevokePublicPostLink(link); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("post_id=" + link.PostId + " link_id=" + link.Id)
+	ReturnStatusOK(w)
+}
+
+type publicPostView struct {
+	Author  string
+	Time    string
+	Message string
+	IsReply bool
+	IsShown bool
+}
+
+// getPublicPost renders a read-only page with the post of a public link, and its thread if the link includes it, for
+// people who may not have an account.
+func getPublicPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePublicPostLinkId()
+	if c.Err != nil {
+		return
+	}
+
+	link, list, err := c.App.GetPublicPostLinkPosts(c.Params.PublicPostLinkId, r.URL.Query().Get("h"))
+	if err != nil {
+		c.Err = err
+		utils.RenderWebAppError(c.App.Config(), w, r, c.Err, c.App.AsymmetricSigningKey())
+		return
+	}
+
+	posts := make([]*model.Post, 0, len(list.Posts))
+	for _, post := range list.Posts {
+		posts = append(posts, post)
+	}
+	sort.Slice(posts, func(i, j int) bool { return posts[i].CreateAt < posts[j].CreateAt })
+
+	nameFormat := *c.App.Config().TeamSettings.TeammateNameDisplay
+	views := make([]publicPostView, 0, len(posts))
+	for _, post := range posts {
+		if post.IsSystemMessage() {
+			continue
+		}
+
+		author := ""
+		if user, err := c.App.GetUser(post.UserId); err == nil {
+			author = user.GetDisplayName(nameFormat)
+		}
+		if overrideUsername, ok := post.Props["override_username"].(string); ok && overrideUsername != "" {
+			author = overrideUsername
+		}
+
+		views = append(views, publicPostView{
+			Author:  author,
+			Time:    time.Unix(0, post.CreateAt*int64(time.Millisecond)).UTC().Format(time.RFC1123),
+			Message: post.Message,
+			IsReply: post.RootId != "",
+			IsShown: post.Id == link.PostId,
+		})
+	}
+
+	page := utils.NewHTMLTemplate(c.App.HTMLTemplates(), "public_post")
+	page.Props["Title"] = c.App.T("web.public_post.title", map[string]interface{}{"SiteName": *c.App.Config().TeamSettings.SiteName})
+	page.Props["ReadOnly"] = c.App.T("web.public_post.read_only")
+	page.Props["Posts"] = views
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	page.RenderToWriter(w)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestPublicPostLinks(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	post := th.CreatePost()
+
+	_, resp := Client.CreatePublicPostLink(post.Id, false)
+	CheckNotImplementedStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnablePublicPostLinks = true
+		*cfg.FileSettings.PublicLinkSalt = model.NewRandomString(32)
+	})
+
+	link, resp := Client.CreatePublicPostLink(post.Id, true)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.BasicUser.Id, link.CreatorId)
+	assert.True(t, link.IncludeThread)
+	require.NotEmpty(t, link.Link)
+
+	links, resp := Client.GetPublicPostLinks(post.Id)
+	CheckNoError(t, resp)
+	require.Len(t, links, 1)
+	assert.Equal(t, link.Link, links[0].Link)
+
+	// The link can be viewed without logging in
+	httpResp, err := http.Get(link.Link)
+	require.Nil(t, err)
+	body, _ := ioutil.ReadAll(httpResp.Body)
+	httpResp.Body.Close()
+	assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+	assert.Contains(t, string(body), post.Message)
+
+	httpResp, err = http.Get(link.Link[:strings.LastIndex(link.Link, "?")])
+	require.Nil(t, err)
+	httpResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, httpResp.StatusCode)
+
+	privatePost := th.CreatePostWithClient(th.SystemAdminClient, th.CreateChannelWithClient(th.SystemAdminClient, model.CHANNEL_PRIVATE))
+	_, resp = Client.CreatePublicPostLink(privatePost.Id, false)
+	CheckForbiddenStatus(t, resp)
+
+	th.LoginBasic2()
+	_, resp = Client.RevokePublicPostLink(post.Id, link.Id)
+	CheckForbiddenStatus(t, resp)
+
+	th.LoginBasic()
+	ok, resp := Client.RevokePublicPostLink(post.Id, link.Id)
+	CheckNoError(t, resp)
+	assert.True(t, ok)
+
+	links, resp = Client.GetPublicPostLinks(post.Id)
+	CheckNoError(t, resp)
+	assert.Empty(t, links)
+
+	httpResp, err = http.Get(link.Link)
+	require.Nil(t, err)
+	httpResp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, httpResp.StatusCode)
+}
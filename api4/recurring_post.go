@@ -0,0 +1,183 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitRecurringPost() {
+	api.BaseRoutes.Channel.Handle("/recurring_posts", api.ApiSessionRequired(createRecurringPost)).Methods("POST")
+	api.BaseRoutes.Channel.Handle("/recurring_posts", api.ApiSessionRequired(getRecurringPostsForChannel)).Methods("GET")
+	api.BaseRoutes.RecurringPost.Handle("", api.ApiSessionRequired(getRecurringPost)).Methods("GET")
+	api.BaseRoutes.RecurringPost.Handle("", api.ApiSessionRequired(updateRecurringPost)).Methods("PUT")
+	api.BaseRoutes.RecurringPost.Handle("", api.ApiSessionRequired(deleteRecurringPost)).Methods("DELETE")
+	api.BaseRoutes.RecurringPost.Handle("/pause", api.ApiSessionRequired(pauseRecurringPost)).Methods("POST")
+	api.BaseRoutes.RecurringPost.Handle("/resume", api.ApiSessionRequired(resumeRecurringPost)).Methods("POST")
+}
+
+func createRecurringPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	recurringPost := model.RecurringPostFromJson(r.Body)
+	if recurringPost == nil {
+		c.SetInvalidParam("recurring_post")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_MANAGE_CHANNEL_ROLES) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return
+	}
+
+	// Posting as a bot requires being able to manage it
+	if err := c.App.SessionHasPermissionToManageBot(c.App.Session, recurringPost.BotUserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	recurringPost.ChannelId = c.Params.ChannelId
+	recurringPost.CreatorId = c.App.Session.UserId
+
+	recurringPost, err := c.App.CreateRecurringPost(recurringPost)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - recurring_post_id=" + recurringPost.Id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(recurringPost.ToJson()))
+}
+
+func getRecurringPostsForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_MANAGE_CHANNEL_ROLES) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return
+	}
+
+	recurringPosts, err := c.App.GetRecurringPostsForChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.RecurringPostListToJson(recurringPosts)))
+}
+
+// requireRecurringPostManager checks that the session may manage the recurring post of the request.
+func requireRecurringPostManager(c *Context) *model.RecurringPost {
+	c.RequireRecurringPostId()
+	if c.Err != nil {
+		return nil
+	}
+
+	recurringPost, err := c.App.GetRecurringPost(c.Params.RecurringPostId)
+	if err != nil {
+		c.Err = err
+		return nil
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, recurringPost.ChannelId, model.PERMISSION_MANAGE_CHANNEL_ROLES) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return nil
+	}
+
+	return recurringPost
+}
+
+func getRecurringPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	recurringPost := requireRecurringPostManager(c)
+	if c.Err != nil {
+		return
+	}
+
+	w.Write([]byte(recurringPost.ToJson()))
+}
+
+func updateRecurringPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireRecurringPostId()
+	if c.Err != nil {
+		return
+	}
+
+	recurringPost := model.RecurringPostFromJson(r.Body)
+	if recurringPost == nil || recurringPost.Id != c.Params.RecurringPostId {
+		c.SetInvalidParam("recurring_post")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	oldRecurringPost := requireRecurringPostManager(c)
+	if c.Err != nil {
+		return
+	}
+
+	if recurringPost.BotUserId != oldRecurringPost.BotUserId {
+		if err := c.App.SessionHasPermissionToManageBot(c.App.Session, recurringPost.BotUserId); err != nil {
+			c.Err = err
+			return
+		}
+	}
+
+	recurringPost, err := c.App.UpdateRecurringPost(recurringPost)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - recurring_post_id=" + recurringPost.Id)
+	w.Write([]byte(recurringPost.ToJson()))
+}
+
+func deleteRecurringPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	recurringPost := requireRecurringPostManager(c)
+	if c.Err != nil {
+		return
+	}
+
+	if err := c.App.DeleteRecurringPost(recurringPost.Id); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("recurring_post_id=" + recurringPost.Id)
+	ReturnStatusOK(w)
+}
+
+func pauseRecurringPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	setRecurringPostPaused(c, w, true)
+}
+
+func resumeRecurringPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	setRecurringPostPaused(c, w, false)
+}
+
+func setRecurringPostPaused(c *Context, w http.ResponseWriter, paused bool) {
+	recurringPost := requireRecurringPostManager(c)
+	if c.Err != nil {
+		return
+	}
+
+	recurringPost, err := c.App.SetRecurringPostPaused(recurringPost, paused)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(recurringPost.ToJson()))
+}
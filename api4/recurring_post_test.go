@@ -0,0 +1,89 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestRecurringPosts(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.RecurringPostSettings.Enable = true })
+
+	bot, err := th.App.CreateBot(&model.Bot{
+		Username: "reminderbot",
+		OwnerId:  th.SystemAdminUser.Id,
+	})
+	require.Nil(t, err)
+	botUser, err := th.App.GetUser(bot.UserId)
+	require.Nil(t, err)
+	th.LinkUserToTeam(botUser, th.BasicTeam)
+	th.AddUserToChannel(botUser, th.BasicChannel)
+
+	recurringPost := &model.RecurringPost{
+		ChannelId: th.BasicChannel.Id,
+		BotUserId: bot.UserId,
+		Message:   "Weekly retro in 10 minutes",
+		Schedule:  "50 15 * * 5",
+		Timezone:  "America/New_York",
+	}
+
+	_, resp := th.Client.CreateRecurringPost(recurringPost)
+	CheckForbiddenStatus(t, resp)
+
+	created, resp := th.SystemAdminClient.CreateRecurringPost(recurringPost)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.SystemAdminUser.Id, created.CreatorId)
+	assert.Equal(t, th.BasicChannel.Id, created.ChannelId)
+	assert.NotZero(t, created.NextRunAt)
+
+	_, resp = th.SystemAdminClient.CreateRecurringPost(&model.RecurringPost{ChannelId: th.BasicChannel.Id, BotUserId: bot.UserId, Message: "bad", Schedule: "not a schedule"})
+	CheckBadRequestStatus(t, resp)
+
+	recurringPosts, resp := th.SystemAdminClient.GetRecurringPostsForChannel(th.BasicChannel.Id)
+	CheckNoError(t, resp)
+	assert.Len(t, recurringPosts, 1)
+
+	_, resp = th.Client.GetRecurringPostsForChannel(th.BasicChannel.Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.GetRecurringPost(created.Id)
+	CheckForbiddenStatus(t, resp)
+
+	created.Message = "Weekly retro in 5 minutes"
+	updated, resp := th.SystemAdminClient.UpdateRecurringPost(created)
+	CheckNoError(t, resp)
+	assert.Equal(t, "Weekly retro in 5 minutes", updated.Message)
+
+	_, resp = th.Client.UpdateRecurringPost(created)
+	CheckForbiddenStatus(t, resp)
+
+	paused, resp := th.SystemAdminClient.PauseRecurringPost(created.Id)
+	CheckNoError(t, resp)
+	assert.True(t, paused.Paused)
+	assert.Zero(t, paused.NextRunAt)
+
+	resumed, resp := th.SystemAdminClient.ResumeRecurringPost(created.Id)
+	CheckNoError(t, resp)
+	assert.False(t, resumed.Paused)
+	assert.NotZero(t, resumed.NextRunAt)
+
+	_, resp = th.Client.DeleteRecurringPost(created.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.DeleteRecurringPost(created.Id)
+	CheckNoError(t, resp)
+	assert.True(t, ok)
+
+	_, resp = th.SystemAdminClient.GetRecurringPost(created.Id)
+	CheckNotFoundStatus(t, resp)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/mattermost/mattermost-server/mlog"
@@ -16,26 +17,42 @@ import (
 	"github.com/mattermost/mattermost-server/utils"
 )
 
-const REDIRECT_LOCATION_CACHE_SIZE = 10000
+const (
+	REDIRECT_LOCATION_CACHE_SIZE = 10000
+
+	CHANNEL_READ_STATS_DEFAULT_RANGE = 30 * 24 * 60 * 60 * 1000
+
+	DAILY_STATS_DEFAULT_RANGE_DAYS = 30
+)
 
 var redirectLocationDataCache = utils.NewLru(REDIRECT_LOCATION_CACHE_SIZE)
 
 func (api *API) InitSystem() {
 	api.BaseRoutes.System.Handle("/ping", api.ApiHandler(getSystemPing)).Methods("GET")
+	api.BaseRoutes.System.Handle("/health", api.ApiHandler(getSystemHealth)).Methods("GET")
 
 	api.BaseRoutes.System.Handle("/timezones", api.ApiSessionRequired(getSupportedTimezones)).Methods("GET")
+	api.BaseRoutes.System.Handle("/support_packet", api.ApiSessionRequired(generateSupportPacket)).Methods("GET")
 
 	api.BaseRoutes.ApiRoot.Handle("/audits", api.ApiSessionRequired(getAudits)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/email/test", api.ApiSessionRequired(testEmail)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/site_url/test", api.ApiSessionRequired(testSiteURL)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/file/s3_test", api.ApiSessionRequired(testS3)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/database/recycle", api.ApiSessionRequired(databaseRecycle)).Methods("POST")
+	api.BaseRoutes.ApiRoot.Handle("/database/migrations", api.ApiSessionRequired(getSchemaMigrations)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/database/slow_queries", api.ApiSessionRequired(getSlowQueries)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/caches/invalidate", api.ApiSessionRequired(invalidateCaches)).Methods("POST")
 
 	api.BaseRoutes.ApiRoot.Handle("/logs", api.ApiSessionRequired(getLogs)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/logs", api.ApiHandler(postLog)).Methods("POST")
+	api.BaseRoutes.ApiRoot.Handle("/access_logs", api.ApiSessionRequired(getApiAccessLog)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/access_logs/summary", api.ApiSessionRequired(getApiAccessLogSummary)).Methods("GET")
 
 	api.BaseRoutes.ApiRoot.Handle("/analytics/old", api.ApiSessionRequired(getAnalytics)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/analytics/channel_read_stats", api.ApiSessionRequired(getChannelReadStats)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/analytics/daily_stats", api.ApiSessionRequired(getDailyStats)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/analytics/integrations", api.ApiSessionRequired(getIntegrationStats)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/analytics/integrations/daily", api.ApiSessionRequired(getIntegrationDailyStats)).Methods("GET")
 
 	api.BaseRoutes.ApiRoot.Handle("/redirect_location", api.ApiSessionRequiredTrustRequester(getRedirectLocation)).Methods("GET")
 
@@ -121,6 +138,54 @@ func getSystemPing(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.MapToJson(s)))
 }
 
+// getSystemHealth reports the health of the server. The liveness probe only tells the server is running, while the
+// readiness probe, the default, also probes the dependencies of the server.
+func getSystemHealth(c *Context, w http.ResponseWriter, r *http.Request) {
+	probe := r.URL.Query().Get("probe")
+	if probe == "" {
+		probe = model.HEALTH_CHECK_PROBE_READINESS
+	}
+
+	var health *model.SystemHealth
+	switch probe {
+	case model.HEALTH_CHECK_PROBE_LIVENESS:
+		health = &model.SystemHealth{Status: model.STATUS_OK, Dependencies: []*model.DependencyHealth{}}
+	case model.HEALTH_CHECK_PROBE_READINESS:
+		health = c.App.CheckSystemHealth()
+	default:
+		c.SetInvalidParam("probe")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if health.Status != model.STATUS_OK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write([]byte(health.ToJson()))
+}
+
+func generateSupportPacket(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	packet, err := c.App.GenerateSupportPacket()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("generated a support packet")
+
+	filename := "mattermost_support_packet_" + time.Now().UTC().Format("2006-01-02_15-04") + ".zip"
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", "attachment;filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", strconv.Itoa(len(packet)))
+	w.Write(packet)
+}
+
 func testEmail(c *Context, w http.ResponseWriter, r *http.Request) {
 	cfg := model.ConfigFromJson(r.Body)
 	if cfg == nil {
@@ -204,6 +269,30 @@ func databaseRecycle(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func getSchemaMigrations(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	migrations, err := c.App.GetSchemaMigrations()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.SchemaMigrationListToJson(migrations)))
+}
+
+func getSlowQueries(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	w.Write([]byte(model.SlowQueryListToJson(c.App.GetRecentSlowQueries())))
+}
+
 func invalidateCaches(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
 		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
@@ -240,6 +329,84 @@ func getLogs(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.ArrayToJson(lines)))
 }
 
+// apiAccessLogQueryFromRequest reads the filters of the API access log from the query string, setting an error on
+// the context when one is invalid.
+func apiAccessLogQueryFromRequest(c *Context, r *http.Request) *model.ApiAccessLogQuery {
+	query := &model.ApiAccessLogQuery{
+		UserId:    r.URL.Query().Get("user_id"),
+		TokenId:   r.URL.Query().Get("token_id"),
+		WebhookId: r.URL.Query().Get("webhook_id"),
+		Route:     r.URL.Query().Get("route"),
+		Page:      c.Params.Page,
+		PerPage:   c.Params.PerPage,
+	}
+
+	for _, param := range []struct {
+		name  string
+		value *int64
+	}{{"since", &query.Since}, {"until", &query.Until}} {
+		if valueString := r.URL.Query().Get(param.name); valueString != "" {
+			parsed, err := strconv.ParseInt(valueString, 10, 64)
+			if err != nil {
+				c.SetInvalidUrlParam(param.name)
+				return nil
+			}
+			*param.value = parsed
+		}
+	}
+
+	if minStatusCode := r.URL.Query().Get("min_status_code"); minStatusCode != "" {
+		parsed, err := strconv.Atoi(minStatusCode)
+		if err != nil {
+			c.SetInvalidUrlParam("min_status_code")
+			return nil
+		}
+		query.MinStatusCode = parsed
+	}
+
+	return query
+}
+
+func getApiAccessLog(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	query := apiAccessLogQueryFromRequest(c, r)
+	if c.Err != nil {
+		return
+	}
+
+	entries, err := c.App.GetApiAccessLog(query)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ApiAccessLogEntryListToJson(entries)))
+}
+
+func getApiAccessLogSummary(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	query := apiAccessLogQueryFromRequest(c, r)
+	if c.Err != nil {
+		return
+	}
+
+	summaries, err := c.App.GetApiAccessLogSummary(query.Since, query.Until)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ApiAccessLogSummaryListToJson(summaries)))
+}
+
 func postLog(c *Context, w http.ResponseWriter, r *http.Request) {
 	forceToDebug := false
 
@@ -303,6 +470,197 @@ func getAnalytics(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(rows.ToJson()))
 }
 
+func getChannelReadStats(c *Context, w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	teamId := query.Get("team_id")
+	if teamId != "" && !model.IsValidId(teamId) {
+		c.SetInvalidParam("team_id")
+		return
+	}
+
+	channelId := query.Get("channel_id")
+	if channelId != "" && !model.IsValidId(channelId) {
+		c.SetInvalidParam("channel_id")
+		return
+	}
+
+	until := model.GetMillis()
+	if val := query.Get("until"); val != "" {
+		parsed, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || parsed <= 0 {
+			c.SetInvalidParam("until")
+			return
+		}
+		until = parsed
+	}
+
+	since := until - CHANNEL_READ_STATS_DEFAULT_RANGE
+	if val := query.Get("since"); val != "" {
+		parsed, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || parsed < 0 || parsed > until {
+			c.SetInvalidParam("since")
+			return
+		}
+		since = parsed
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	stats, err := c.App.GetChannelReadStats(teamId, channelId, since, until, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelReadStatListToJson(stats)))
+}
+
+func getDailyStats(c *Context, w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	teamId := query.Get("team_id")
+	if teamId != "" && !model.IsValidId(teamId) {
+		c.SetInvalidParam("team_id")
+		return
+	}
+
+	channelId := query.Get("channel_id")
+	if channelId != "" && !model.IsValidId(channelId) {
+		c.SetInvalidParam("channel_id")
+		return
+	}
+
+	since, until := statDateRangeFromRequest(c, r, time.Now().UTC().AddDate(0, 0, -1))
+	if c.Err != nil {
+		return
+	}
+
+	// The stats of a channel are shown to the admins of its team, like the stats of the team itself.
+	if channelId != "" {
+		channel, err := c.App.GetChannel(channelId)
+		if err != nil {
+			c.Err = err
+			return
+		}
+
+		if channel.TeamId == "" || (teamId != "" && teamId != channel.TeamId) {
+			c.SetInvalidParam("channel_id")
+			return
+		}
+		teamId = channel.TeamId
+	}
+
+	if teamId == "" {
+		if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+			return
+		}
+	} else if !c.App.SessionHasPermissionToTeam(c.App.Session, teamId, model.PERMISSION_MANAGE_TEAM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_TEAM)
+		return
+	}
+
+	stats, err := c.App.GetDailyStats(teamId, channelId, since, until)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.DailyStatListToJson(stats)))
+}
+
+// statDateRangeFromRequest reads the dates of the stats to return from the query string, defaulting to the days up to
+// the given one, and sets an error on the context when they are invalid.
+func statDateRangeFromRequest(c *Context, r *http.Request, defaultUntil time.Time) (string, string) {
+	query := r.URL.Query()
+
+	until := defaultUntil
+	if val := query.Get("until"); val != "" {
+		parsed, err := time.Parse(model.DAILY_STAT_DATE_FORMAT, val)
+		if err != nil {
+			c.SetInvalidParam("until")
+			return "", ""
+		}
+		until = parsed
+	}
+
+	since := until.AddDate(0, 0, 1-DAILY_STATS_DEFAULT_RANGE_DAYS)
+	if val := query.Get("since"); val != "" {
+		parsed, err := time.Parse(model.DAILY_STAT_DATE_FORMAT, val)
+		if err != nil || parsed.After(until) || parsed.Before(until.AddDate(0, 0, 1-model.DAILY_STATS_MAX_RANGE_DAYS)) {
+			c.SetInvalidParam("since")
+			return "", ""
+		}
+		since = parsed
+	}
+
+	return since.Format(model.DAILY_STAT_DATE_FORMAT), until.Format(model.DAILY_STAT_DATE_FORMAT)
+}
+
+// getIntegrationStats returns the activity of each integration, with the busiest ones first. The stats of the
+// current day are included since it's usually the one where an integration is misbehaving.
+func getIntegrationStats(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	integrationType := r.URL.Query().Get("type")
+	if integrationType != "" && !model.IsValidIntegrationType(integrationType) {
+		c.SetInvalidParam("type")
+		return
+	}
+
+	since, until := statDateRangeFromRequest(c, r, time.Now().UTC())
+	if c.Err != nil {
+		return
+	}
+
+	stats, err := c.App.GetIntegrationStats(integrationType, since, until)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.IntegrationStatListToJson(stats)))
+}
+
+func getIntegrationDailyStats(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	integrationType := r.URL.Query().Get("type")
+	if !model.IsValidIntegrationType(integrationType) {
+		c.SetInvalidParam("type")
+		return
+	}
+
+	integrationId := r.URL.Query().Get("integration_id")
+	if !model.IsValidId(integrationId) {
+		c.SetInvalidParam("integration_id")
+		return
+	}
+
+	since, until := statDateRangeFromRequest(c, r, time.Now().UTC())
+	if c.Err != nil {
+		return
+	}
+
+	stats, err := c.App.GetIntegrationDailyStats(integrationType, integrationId, since, until)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.IntegrationStatListToJson(stats)))
+}
+
 func getSupportedTimezones(c *Context, w http.ResponseWriter, r *http.Request) {
 	supportedTimezones := c.App.Timezones.GetSupported()
 	if supportedTimezones == nil {
@@ -373,10 +731,16 @@ func getRedirectLocation(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	if location, ok := redirectLocationDataCache.Get(url); ok {
+		if c.App.Metrics != nil {
+			c.App.Metrics.IncrementMemCacheHitCounter("Redirect Location")
+		}
 		m["location"] = location.(string)
 		w.Write([]byte(model.MapToJson(m)))
 		return
 	}
+	if c.App.Metrics != nil {
+		c.App.Metrics.IncrementMemCacheMissCounter("Redirect Location")
+	}
 
 	client := c.App.HTTPService.MakeClient(false)
 	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
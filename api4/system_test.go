@@ -1,16 +1,21 @@
 package api4
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetPing(t *testing.T) {
@@ -59,6 +64,42 @@ func TestGetPing(t *testing.T) {
 	})
 }
 
+func TestGetSystemHealth(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	t.Run("liveness", func(t *testing.T) {
+		health, resp := th.Client.GetSystemHealth(model.HEALTH_CHECK_PROBE_LIVENESS)
+		CheckNoError(t, resp)
+		assert.Equal(t, model.STATUS_OK, health.Status)
+		assert.Empty(t, health.Dependencies)
+	})
+
+	t.Run("readiness", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.SendEmailNotifications = false
+			*cfg.EmailSettings.SendPushNotifications = false
+		})
+
+		health, resp := th.Client.GetSystemHealth("")
+		CheckNoError(t, resp)
+		assert.Equal(t, model.STATUS_OK, health.Status)
+
+		database := health.GetDependency(model.HEALTH_CHECK_DATABASE)
+		require.NotNil(t, database)
+		assert.Equal(t, model.STATUS_OK, database.Status)
+
+		elasticsearch := health.GetDependency(model.HEALTH_CHECK_ELASTICSEARCH)
+		require.NotNil(t, elasticsearch)
+		assert.Equal(t, model.STATUS_DISABLED, elasticsearch.Status)
+	})
+
+	t.Run("invalid probe", func(t *testing.T) {
+		_, resp := th.Client.GetSystemHealth("junk")
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestGetAudits(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -214,6 +255,20 @@ func TestDatabaseRecycle(t *testing.T) {
 	})
 }
 
+func TestGetSchemaMigrations(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	_, resp := th.Client.GetSchemaMigrations()
+	CheckForbiddenStatus(t, resp)
+
+	migrations, resp := th.SystemAdminClient.GetSchemaMigrations()
+	CheckNoError(t, resp)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.NotEmpty(t, migrations[0].Status)
+}
+
 func TestInvalidateCaches(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -289,6 +344,64 @@ func TestGetLogs(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestGenerateSupportPacket(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	dataSource := *th.App.Config().SqlSettings.DataSource
+	mlog.Info("Connecting to " + dataSource)
+
+	packet, resp := th.SystemAdminClient.GetSupportPacket()
+	CheckNoError(t, resp)
+
+	reader, err := zip.NewReader(bytes.NewReader(packet), int64(len(packet)))
+	require.Nil(t, err)
+
+	files := map[string]string{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.Nil(t, err)
+		data, err := ioutil.ReadAll(rc)
+		rc.Close()
+		require.Nil(t, err)
+		files[file.Name] = string(data)
+	}
+
+	for _, name := range []string{"metadata.json", "config.json", "goroutines.txt", "heap.pprof", "jobs.json", "cluster.json", "health.json"} {
+		assert.Contains(t, files, name)
+	}
+
+	for name, data := range files {
+		assert.NotContains(t, data, dataSource, "%v should have the data source redacted", name)
+	}
+
+	_, resp = Client.GetSupportPacket()
+	CheckForbiddenStatus(t, resp)
+
+	Client.Logout()
+	_, resp = Client.GetSupportPacket()
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestGetSlowQueries(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	queries, resp := th.SystemAdminClient.GetSlowQueries()
+	CheckNoError(t, resp)
+	assert.NotNil(t, queries)
+	assert.Empty(t, queries, "the slow query log is disabled by default")
+
+	_, resp = Client.GetSlowQueries()
+	CheckForbiddenStatus(t, resp)
+
+	Client.Logout()
+	_, resp = Client.GetSlowQueries()
+	CheckUnauthorizedStatus(t, resp)
+}
+
 func TestPostLog(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -397,6 +510,160 @@ func TestGetAnalyticsOld(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestGetChannelReadStats(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	_, resp := th.SystemAdminClient.GetChannelReadStats("", th.BasicChannel.Id, 0, 0, 0, 60)
+	CheckNotImplementedStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.AnalyticsSettings.EnableChannelReadStats = true
+		*cfg.AnalyticsSettings.ChannelReadStatsMinimumMembers = 2
+	})
+
+	postCreateAt := model.GetMillis() - 2*model.CHANNEL_READ_STATS_READ_WINDOW
+	post, err := th.App.CreatePost(&model.Post{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		Message:   "announcement",
+		CreateAt:  postCreateAt,
+	}, th.BasicChannel, false)
+	require.Nil(t, err)
+
+	count, err := th.App.ComputeChannelReadStats(postCreateAt, postCreateAt+1)
+	require.Nil(t, err)
+	require.NotZero(t, count)
+
+	_, resp = th.Client.GetChannelReadStats("", th.BasicChannel.Id, 0, 0, 0, 60)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetChannelReadStats("", "junk", 0, 0, 0, 60)
+	CheckBadRequestStatus(t, resp)
+
+	stats, resp := th.SystemAdminClient.GetChannelReadStats(th.BasicTeam.Id, th.BasicChannel.Id, 0, 0, 0, 60)
+	CheckNoError(t, resp)
+	require.Len(t, stats, 1)
+	assert.Equal(t, post.Id, stats[0].PostId)
+	assert.Equal(t, th.BasicTeam.Id, stats[0].TeamId)
+	assert.True(t, stats[0].MemberCount >= 2)
+
+	stats, resp = th.SystemAdminClient.GetChannelReadStats("", th.BasicChannel.Id, postCreateAt+1, 0, 0, 60)
+	CheckNoError(t, resp)
+	assert.Empty(t, stats)
+}
+
+func TestGetDailyStats(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(model.DAILY_STAT_DATE_FORMAT)
+	since, _, parseErr := model.GetDailyStatDateRange(yesterday)
+	require.Nil(t, parseErr)
+
+	_, err := th.App.CreatePost(&model.Post{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		Message:   "yesterday",
+		CreateAt:  since + 1000,
+	}, th.BasicChannel, false)
+	require.Nil(t, err)
+
+	count, err := th.App.ComputeDailyStats(yesterday)
+	require.Nil(t, err)
+	require.NotZero(t, count)
+
+	stats, resp := th.SystemAdminClient.GetDailyStats("", "", "", "")
+	CheckNoError(t, resp)
+	require.Len(t, stats, 1)
+	assert.Equal(t, yesterday, stats[0].Date)
+	assert.Empty(t, stats[0].TeamId)
+	assert.True(t, stats[0].PostCount >= 1)
+
+	stats, resp = th.SystemAdminClient.GetDailyStats("", th.BasicChannel.Id, yesterday, yesterday)
+	CheckNoError(t, resp)
+	require.Len(t, stats, 1)
+	assert.Equal(t, th.BasicTeam.Id, stats[0].TeamId)
+	assert.Equal(t, th.BasicChannel.Id, stats[0].ChannelId)
+	assert.Equal(t, int64(1), stats[0].PostCount)
+	assert.Equal(t, int64(1), stats[0].ActiveUserCount)
+
+	_, resp = th.Client.GetDailyStats("", "", "", "")
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.GetDailyStats(th.BasicTeam.Id, "", "", "")
+	CheckForbiddenStatus(t, resp)
+
+	th.LoginTeamAdmin()
+	stats, resp = th.Client.GetDailyStats(th.BasicTeam.Id, "", "", "")
+	CheckNoError(t, resp)
+	require.Len(t, stats, 1)
+	assert.Equal(t, th.BasicTeam.Id, stats[0].TeamId)
+	assert.Empty(t, stats[0].ChannelId)
+
+	_, resp = th.SystemAdminClient.GetDailyStats("", "", "yesterday", "")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetDailyStats("", "", "2019-10-02", "2019-10-01")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetDailyStats(model.NewId(), th.BasicChannel.Id, "", "")
+	CheckBadRequestStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.AnalyticsSettings.EnableDailyStats = false })
+	_, resp = th.SystemAdminClient.GetDailyStats("", "", "", "")
+	CheckNotImplementedStatus(t, resp)
+}
+
+func TestGetIntegrationStats(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableIncomingWebhooks = true
+		*cfg.AnalyticsSettings.EnableIntegrationStats = true
+	})
+
+	hook, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	require.Nil(t, err)
+	require.Nil(t, th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{Text: "hello"}))
+	require.Nil(t, th.App.RollupIntegrationStats())
+
+	stats, resp := th.SystemAdminClient.GetIntegrationStats(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, "", "")
+	CheckNoError(t, resp)
+	var found *model.IntegrationStat
+	for _, stat := range stats {
+		if stat.IntegrationId == hook.Id {
+			found = stat
+		}
+	}
+	require.NotNil(t, found)
+	assert.Equal(t, int64(1), found.Requests)
+	assert.Equal(t, int64(1), found.Posts)
+
+	today := time.Now().UTC().Format(model.DAILY_STAT_DATE_FORMAT)
+	stats, resp = th.SystemAdminClient.GetIntegrationDailyStats(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, hook.Id, today, today)
+	CheckNoError(t, resp)
+	require.Len(t, stats, 1)
+	assert.Equal(t, today, stats[0].Date)
+
+	_, resp = th.Client.GetIntegrationStats("", "", "")
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.GetIntegrationDailyStats(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, hook.Id, "", "")
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetIntegrationStats("plugin", "", "")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetIntegrationDailyStats(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, "abc", "", "")
+	CheckBadRequestStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.AnalyticsSettings.EnableIntegrationStats = false })
+	_, resp = th.SystemAdminClient.GetIntegrationStats("", "", "")
+	CheckNotImplementedStatus(t, resp)
+}
+
 func TestS3TestConnection(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -534,3 +801,55 @@ func TestRedirectLocation(t *testing.T) {
 	_, resp = Client.GetRedirectLocation("", "")
 	CheckUnauthorizedStatus(t, resp)
 }
+
+func TestGetApiAccessLog(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	dir, err := ioutil.TempDir("", "accesslog")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ApiAccessLogSettings.Enable = true
+		*cfg.ApiAccessLogSettings.FileLocation = dir
+	})
+
+	_, resp := th.Client.GetUser(th.BasicUser2.Id, "")
+	CheckNoError(t, resp)
+	_, resp = th.Client.GetUser(model.NewId(), "")
+	CheckNotFoundStatus(t, resp)
+
+	_, resp = th.Client.GetApiAccessLog(&model.ApiAccessLogQuery{PerPage: 10})
+	CheckForbiddenStatus(t, resp)
+
+	entries, resp := th.SystemAdminClient.GetApiAccessLog(&model.ApiAccessLogQuery{UserId: th.BasicUser.Id, Route: "getUser", PerPage: 10})
+	CheckNoError(t, resp)
+	require.Len(t, entries, 2)
+	assert.Equal(t, http.StatusNotFound, entries[0].StatusCode)
+	assert.Equal(t, http.StatusOK, entries[1].StatusCode)
+	assert.Equal(t, "/api/v4/users/"+th.BasicUser2.Id, entries[1].Path)
+
+	entries, resp = th.SystemAdminClient.GetApiAccessLog(&model.ApiAccessLogQuery{UserId: th.BasicUser.Id, MinStatusCode: 400, PerPage: 10})
+	CheckNoError(t, resp)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "getApiAccessLog", entries[0].Route)
+	assert.Equal(t, http.StatusForbidden, entries[0].StatusCode)
+
+	summaries, resp := th.SystemAdminClient.GetApiAccessLogSummary(0, model.GetMillis())
+	CheckNoError(t, resp)
+	require.NotEmpty(t, summaries)
+
+	var userSummary *model.ApiAccessLogSummary
+	for _, summary := range summaries {
+		if summary.IdentityType == model.API_ACCESS_LOG_IDENTITY_USER && summary.IdentityId == th.BasicUser.Id {
+			userSummary = summary
+		}
+	}
+	require.NotNil(t, userSummary)
+	assert.Equal(t, int64(2), userSummary.Errors)
+
+	_, appErr := th.SystemAdminClient.DoApiGet("/access_logs?since=yesterday", "")
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+}
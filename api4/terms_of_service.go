@@ -5,6 +5,7 @@ package api4
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/mattermost/mattermost-server/app"
 	"github.com/mattermost/mattermost-server/model"
@@ -13,6 +14,9 @@ import (
 func (api *API) InitTermsOfService() {
 	api.BaseRoutes.TermsOfService.Handle("", api.ApiSessionRequired(getLatestTermsOfService)).Methods("GET")
 	api.BaseRoutes.TermsOfService.Handle("", api.ApiSessionRequired(createTermsOfService)).Methods("POST")
+	api.BaseRoutes.TermsOfService.Handle("/versions", api.ApiSessionRequired(getTermsOfServiceVersions)).Methods("GET")
+	api.BaseRoutes.TermsOfService.Handle("/acceptances", api.ApiSessionRequired(getTermsOfServiceAcceptances)).Methods("GET")
+	api.BaseRoutes.TermsOfService.Handle("/{terms_of_service_id:[A-Za-z0-9]+}/reacceptance", api.ApiSessionRequired(scheduleTermsOfServiceReacceptance)).Methods("PUT")
 }
 
 func getLatestTermsOfService(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -63,3 +67,86 @@ func createTermsOfService(c *Context, w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(oldTermsOfService.ToJson()))
 	}
 }
+
+func getTermsOfServiceVersions(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	termsOfServices, err := c.App.GetTermsOfServiceVersions()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.TermsOfServiceListToJson(termsOfServices)))
+}
+
+func getTermsOfServiceAcceptances(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	termsOfServiceId := r.URL.Query().Get("terms_of_service_id")
+	if termsOfServiceId != "" && !model.IsValidId(termsOfServiceId) {
+		c.SetInvalidParam("terms_of_service_id")
+		return
+	}
+
+	var since int64
+	if sinceString := r.URL.Query().Get("since"); sinceString != "" {
+		var parseError error
+		if since, parseError = strconv.ParseInt(sinceString, 10, 64); parseError != nil {
+			c.SetInvalidParam("since")
+			return
+		}
+	}
+
+	perPage := c.Params.PerPage
+	if perPage > model.TERMS_OF_SERVICE_ACCEPTANCES_PER_PAGE_MAX {
+		perPage = model.TERMS_OF_SERVICE_ACCEPTANCES_PER_PAGE_MAX
+	}
+
+	acceptances, err := c.App.GetTermsOfServiceAcceptances(termsOfServiceId, since, c.Params.Page, perPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.TermsOfServiceAcceptanceListToJson(acceptances)))
+}
+
+func scheduleTermsOfServiceReacceptance(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTermsOfServiceId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if license := c.App.License(); license == nil || !*license.Features.CustomTermsOfService {
+		c.Err = model.NewAppError("scheduleTermsOfServiceReacceptance", "api.create_terms_of_service.custom_terms_of_service_disabled.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	props := model.StringInterfaceFromJson(r.Body)
+	reacceptAt, ok := props["reaccept_at"].(float64)
+	if !ok {
+		c.SetInvalidParam("reaccept_at")
+		return
+	}
+
+	termsOfService, err := c.App.ScheduleTermsOfServiceReacceptance(c.Params.TermsOfServiceId, int64(reacceptAt))
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("TermsOfServiceId=" + termsOfService.Id + ", reaccept_at=" + strconv.FormatInt(termsOfService.ReacceptAt, 10))
+	w.Write([]byte(termsOfService.ToJson()))
+}
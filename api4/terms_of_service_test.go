@@ -2,6 +2,7 @@ package api4
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/stretchr/testify/assert"
@@ -52,3 +53,65 @@ func TestCreateTermsOfServiceAdminUser(t *testing.T) {
 	assert.Equal(t, "terms of service new_2", termsOfService.Text)
 	assert.Equal(t, th.SystemAdminUser.Id, termsOfService.UserId)
 }
+
+func TestTermsOfServiceReacceptance(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	termsOfService, err := th.App.CreateTermsOfService("terms of service", th.BasicUser.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, resp := th.Client.RegisterTermsOfServiceAction(th.BasicUser.Id, termsOfService.Id, true)
+	CheckNoError(t, resp)
+
+	t.Run("versions", func(t *testing.T) {
+		_, resp := th.Client.GetTermsOfServiceVersions()
+		CheckForbiddenStatus(t, resp)
+
+		versions, resp := th.SystemAdminClient.GetTermsOfServiceVersions()
+		CheckNoError(t, resp)
+		if assert.NotEmpty(t, versions) {
+			assert.Equal(t, termsOfService.Id, versions[0].Id)
+			assert.Equal(t, termsOfService.Version, versions[0].Version)
+		}
+	})
+
+	t.Run("acceptances", func(t *testing.T) {
+		_, resp := th.Client.GetTermsOfServiceAcceptances(termsOfService.Id, 0, 0, 60)
+		CheckForbiddenStatus(t, resp)
+
+		acceptances, resp := th.SystemAdminClient.GetTermsOfServiceAcceptances(termsOfService.Id, 0, 0, 60)
+		CheckNoError(t, resp)
+		if assert.Len(t, acceptances, 1) {
+			assert.Equal(t, th.BasicUser.Id, acceptances[0].UserId)
+		}
+
+		_, resp = th.SystemAdminClient.GetTermsOfServiceAcceptances("junk", 0, 0, 60)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("schedule re-acceptance", func(t *testing.T) {
+		_, resp := th.Client.ScheduleTermsOfServiceReacceptance(termsOfService.Id, model.GetMillis())
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.ScheduleTermsOfServiceReacceptance(termsOfService.Id, model.GetMillis())
+		CheckErrorMessage(t, resp, "api.create_terms_of_service.custom_terms_of_service_disabled.app_error")
+
+		th.App.SetLicense(model.NewTestLicense("EnableCustomTermsOfService"))
+
+		// The acceptance has to predate the campaign
+		time.Sleep(2 * time.Millisecond)
+
+		updated, resp := th.SystemAdminClient.ScheduleTermsOfServiceReacceptance(termsOfService.Id, model.GetMillis())
+		CheckNoError(t, resp)
+		assert.NotZero(t, updated.ReacceptAt)
+
+		_, resp = th.Client.GetUserTermsOfService(th.BasicUser.Id, "")
+		CheckNotFoundStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.ScheduleTermsOfServiceReacceptance(model.NewId(), model.GetMillis())
+		CheckNotFoundStatus(t, resp)
+	})
+}
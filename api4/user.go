@@ -21,21 +21,24 @@ import (
 
 func (api *API) InitUser() {
 	api.BaseRoutes.Users.Handle("", api.ApiHandler(createUser)).Methods("POST")
-	api.BaseRoutes.Users.Handle("", api.ApiSessionRequired(getUsers)).Methods("GET")
-	api.BaseRoutes.Users.Handle("/ids", api.ApiSessionRequired(getUsersByIds)).Methods("POST")
-	api.BaseRoutes.Users.Handle("/usernames", api.ApiSessionRequired(getUsersByNames)).Methods("POST")
-	api.BaseRoutes.Users.Handle("/search", api.ApiSessionRequired(searchUsers)).Methods("POST")
-	api.BaseRoutes.Users.Handle("/autocomplete", api.ApiSessionRequired(autocompleteUsers)).Methods("GET")
+	api.BaseRoutes.Users.Handle("", api.ApiSessionRequiredWithOAuthScope(getUsers, model.OAUTH_SCOPE_READ_USERS)).Methods("GET")
+	api.BaseRoutes.Users.Handle("/ids", api.ApiSessionRequiredWithOAuthScope(getUsersByIds, model.OAUTH_SCOPE_READ_USERS)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/usernames", api.ApiSessionRequiredWithOAuthScope(getUsersByNames, model.OAUTH_SCOPE_READ_USERS)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/search", api.ApiSessionRequiredWithOAuthScope(searchUsers, model.OAUTH_SCOPE_READ_USERS)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/autocomplete", api.ApiSessionRequiredWithOAuthScope(autocompleteUsers, model.OAUTH_SCOPE_READ_USERS)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/stats", api.ApiSessionRequired(getTotalUsersStats)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/group_channels", api.ApiSessionRequired(getUsersByGroupChannelIds)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/bulk", api.ApiSessionRequired(createBulkUsersJob)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/bulk/{job_id:[A-Za-z0-9]+}/results", api.ApiSessionRequired(getBulkUsersResults)).Methods("GET")
 
-	api.BaseRoutes.User.Handle("", api.ApiSessionRequired(getUser)).Methods("GET")
+	api.BaseRoutes.User.Handle("", api.ApiSessionRequiredWithOAuthScope(getUser, model.OAUTH_SCOPE_READ_USERS)).Methods("GET")
 	api.BaseRoutes.User.Handle("/image/default", api.ApiSessionRequiredTrustRequester(getDefaultProfileImage)).Methods("GET")
 	api.BaseRoutes.User.Handle("/image", api.ApiSessionRequiredTrustRequester(getProfileImage)).Methods("GET")
 	api.BaseRoutes.User.Handle("/image", api.ApiSessionRequired(setProfileImage)).Methods("POST")
 	api.BaseRoutes.User.Handle("/image", api.ApiSessionRequired(setDefaultProfileImage)).Methods("DELETE")
 	api.BaseRoutes.User.Handle("", api.ApiSessionRequired(updateUser)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/patch", api.ApiSessionRequired(patchUser)).Methods("PUT")
+	api.BaseRoutes.User.Handle("/anonymize", api.ApiSessionRequired(anonymizeUser)).Methods("POST")
 	api.BaseRoutes.User.Handle("", api.ApiSessionRequired(deleteUser)).Methods("DELETE")
 	api.BaseRoutes.User.Handle("/roles", api.ApiSessionRequired(updateUserRoles)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/active", api.ApiSessionRequired(updateUserActive)).Methods("PUT")
@@ -54,25 +57,37 @@ func (api *API) InitUser() {
 	api.BaseRoutes.Users.Handle("/mfa", api.ApiHandler(checkUserMfa)).Methods("POST")
 	api.BaseRoutes.User.Handle("/mfa", api.ApiSessionRequiredMfa(updateUserMfa)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/mfa/generate", api.ApiSessionRequiredMfa(generateMfaSecret)).Methods("POST")
+	api.BaseRoutes.User.Handle("/mfa/recovery_codes", api.ApiSessionRequiredMfa(generateMfaRecoveryCodes)).Methods("POST")
+	api.BaseRoutes.User.Handle("/mfa/reset", api.ApiSessionRequired(resetUserMfa)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/mfa/adoption", api.ApiSessionRequired(getMfaAdoption)).Methods("GET")
 
 	api.BaseRoutes.Users.Handle("/login", api.ApiHandler(login)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/login/switch", api.ApiHandler(switchAccountType)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/logout", api.ApiHandler(logout)).Methods("POST")
 
-	api.BaseRoutes.UserByUsername.Handle("", api.ApiSessionRequired(getUserByUsername)).Methods("GET")
-	api.BaseRoutes.UserByEmail.Handle("", api.ApiSessionRequired(getUserByEmail)).Methods("GET")
+	api.BaseRoutes.UserByUsername.Handle("", api.ApiSessionRequiredWithOAuthScope(getUserByUsername, model.OAUTH_SCOPE_READ_USERS)).Methods("GET")
+	api.BaseRoutes.UserByEmail.Handle("", api.ApiSessionRequiredWithOAuthScope(getUserByEmail, model.OAUTH_SCOPE_READ_USERS)).Methods("GET")
 
 	api.BaseRoutes.User.Handle("/sessions", api.ApiSessionRequired(getSessions)).Methods("GET")
 	api.BaseRoutes.User.Handle("/sessions/revoke", api.ApiSessionRequired(revokeSession)).Methods("POST")
 	api.BaseRoutes.User.Handle("/sessions/revoke/all", api.ApiSessionRequired(revokeAllSessionsForUser)).Methods("POST")
+	api.BaseRoutes.User.Handle("/login_attempts", api.ApiSessionRequired(getLoginAttempts)).Methods("GET")
+	api.BaseRoutes.User.Handle("/unlock", api.ApiSessionRequired(unlockUser)).Methods("POST")
+	api.BaseRoutes.User.Handle("/impersonate", api.ApiSessionRequired(impersonateUser)).Methods("POST")
+	api.BaseRoutes.User.Handle("/deactivation_schedule", api.ApiSessionRequired(getUserDeactivationSchedule)).Methods("GET")
+	api.BaseRoutes.User.Handle("/deactivation_schedule", api.ApiSessionRequired(scheduleUserDeactivation)).Methods("PUT")
+	api.BaseRoutes.User.Handle("/deactivation_schedule", api.ApiSessionRequired(cancelUserDeactivation)).Methods("DELETE")
+	api.BaseRoutes.Users.Handle("/deactivation_schedules", api.ApiSessionRequired(getUserDeactivationSchedules)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/sessions/revoke/all", api.ApiSessionRequired(revokeAllSessionsAllUsers)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/sessions/device", api.ApiSessionRequired(attachDeviceId)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/audits", api.ApiSessionRequired(getUserAudits)).Methods("GET")
+	api.BaseRoutes.User.Handle("/push_notifications/diagnostics", api.ApiSessionRequired(getUserPushNotificationDiagnostics)).Methods("GET")
 
 	api.BaseRoutes.User.Handle("/tokens", api.ApiSessionRequired(createUserAccessToken)).Methods("POST")
 	api.BaseRoutes.User.Handle("/tokens", api.ApiSessionRequired(getUserAccessTokensForUser)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/tokens", api.ApiSessionRequired(getUserAccessTokens)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/tokens/search", api.ApiSessionRequired(searchUserAccessTokens)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/tokens/expiring", api.ApiSessionRequired(getExpiringUserAccessTokens)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/tokens/{token_id:[A-Za-z0-9]+}", api.ApiSessionRequired(getUserAccessToken)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/tokens/revoke", api.ApiSessionRequired(revokeUserAccessToken)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/tokens/disable", api.ApiSessionRequired(disableUserAccessToken)).Methods("POST")
@@ -169,10 +184,21 @@ func getUser(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	etag := user.Etag(*c.App.Config().PrivacySettings.ShowFullName, *c.App.Config().PrivacySettings.ShowEmailAddress)
 
+	// Users are told the features enabled for them, so that the clients can enable them too.
+	if c.App.Session.UserId == user.Id {
+		user.FeatureFlags = c.App.GetFeatureFlagsForUser(user.Id)
+		etag = model.Etag(etag, model.FeatureFlagsEtag(user.FeatureFlags))
+	}
+
 	if c.HandleEtag(etag, "Get User", w, r) {
 		return
 	}
 
+	if err := c.App.FillInUserAttributes([]*model.User{user}); err != nil {
+		c.Err = err
+		return
+	}
+
 	if c.App.Session.UserId == user.Id {
 		user.Sanitize(map[string]bool{})
 	} else {
@@ -234,6 +260,11 @@ func getUserByUsername(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := c.App.FillInUserAttributes([]*model.User{user}); err != nil {
+		c.Err = err
+		return
+	}
+
 	if c.App.Session.UserId == user.Id {
 		user.Sanitize(map[string]bool{})
 	} else {
@@ -287,6 +318,11 @@ func getUserByEmail(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := c.App.FillInUserAttributes([]*model.User{user}); err != nil {
+		c.Err = err
+		return
+	}
+
 	c.App.SanitizeProfile(user, c.IsSystemAdmin())
 	w.Header().Set(model.HEADER_ETAG_SERVER, etag)
 	w.Write([]byte(user.ToJson()))
@@ -740,6 +776,7 @@ func searchUsers(c *Context, w http.ResponseWriter, r *http.Request) {
 		GroupConstrained: props.GroupConstrained,
 		Limit:            props.Limit,
 		Role:             props.Role,
+		Fuzzy:            *c.App.Config().ServiceSettings.EnableFuzzyUserSearch,
 	}
 
 	if c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
@@ -762,6 +799,11 @@ func searchUsers(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := c.App.FillInUserAttributes(profiles); err != nil {
+		c.Err = err
+		return
+	}
+
 	w.Write([]byte(model.UserListToJson(profiles)))
 }
 
@@ -782,6 +824,7 @@ func autocompleteUsers(c *Context, w http.ResponseWriter, r *http.Request) {
 		// Never autocomplete on emails.
 		AllowEmails: false,
 		Limit:       limit,
+		Fuzzy:       *c.App.Config().ServiceSettings.EnableFuzzyUserSearch,
 	}
 
 	if c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
@@ -842,6 +885,14 @@ func autocompleteUsers(c *Context, w http.ResponseWriter, r *http.Request) {
 		autocomplete.Users = result
 	}
 
+	users := make([]*model.User, 0, len(autocomplete.Users)+len(autocomplete.OutOfChannel))
+	users = append(users, autocomplete.Users...)
+	users = append(users, autocomplete.OutOfChannel...)
+	if err := c.App.FillInUserAttributes(users); err != nil {
+		c.Err = err
+		return
+	}
+
 	w.Write([]byte((autocomplete.ToJson())))
 }
 
@@ -1022,6 +1073,76 @@ func updateUserRoles(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func createBulkUsersJob(c *Context, w http.ResponseWriter, r *http.Request) {
+	operation := model.BulkUsersOperationFromJson(r.Body)
+	if operation == nil {
+		c.SetInvalidParam("operation")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	job, err := c.App.CreateBulkUsersJob(operation, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit(fmt.Sprintf("job_id=%s action=%s users=%d", job.Id, operation.Action, len(operation.UserIds)))
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(job.ToJson()))
+}
+
+func getBulkUsersResults(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	results, err := c.App.GetBulkUsersResults(c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(results.ToJson()))
+}
+
+func anonymizeUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if c.Params.UserId == c.App.Session.UserId {
+		c.Err = model.NewAppError("anonymizeUser", "api.user.anonymize_user.self.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	job, err := c.App.CreateUserAnonymizationJob(c.Params.UserId, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit(fmt.Sprintf("job_id=%s user_id=%s", job.Id, c.Params.UserId))
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(job.ToJson()))
+}
+
 func updateUserActive(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -1134,6 +1255,7 @@ func checkUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 
 func updateUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
+	c.ImpersonationForbidden()
 	if c.Err != nil {
 		return
 	}
@@ -1167,17 +1289,29 @@ func updateUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	c.LogAudit("attempt")
 
-	if err := c.App.UpdateMfa(activate, c.Params.UserId, code); err != nil {
+	recoveryCodes, err := c.App.UpdateMfa(activate, c.Params.UserId, code)
+	if err != nil {
 		c.Err = err
 		return
 	}
 
 	c.LogAudit("success - mfa updated")
-	ReturnStatusOK(w)
+
+	if !activate {
+		ReturnStatusOK(w)
+		return
+	}
+
+	// The recovery codes generated on activation are only ever shown in this response
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	w.Write([]byte((&model.MfaRecoveryCodes{Codes: recoveryCodes}).ToJson()))
 }
 
 func generateMfaSecret(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
+	c.ImpersonationForbidden()
 	if c.Err != nil {
 		return
 	}
@@ -1205,8 +1339,79 @@ func generateMfaSecret(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(secret.ToJson()))
 }
 
+func generateMfaRecoveryCodes(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	c.ImpersonationForbidden()
+	if c.Err != nil {
+		return
+	}
+
+	if c.App.Session.IsOAuth {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	// Recovery codes are only ever shown to the user they belong to
+	if c.App.Session.UserId != c.Params.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	codes, err := c.App.GenerateMfaRecoveryCodes(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - mfa recovery codes generated")
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	w.Write([]byte((&model.MfaRecoveryCodes{Codes: codes}).ToJson()))
+}
+
+func resetUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if err := c.App.ResetUserMfa(c.Params.UserId, c.App.Session.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - user_id=" + c.Params.UserId)
+	ReturnStatusOK(w)
+}
+
+func getMfaAdoption(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	adoption, err := c.App.GetMfaAdoption()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(adoption.ToJson()))
+}
+
 func updatePassword(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
+	c.ImpersonationForbidden()
 	if c.Err != nil {
 		return
 	}
@@ -1470,8 +1675,49 @@ func getSessions(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.SessionsToJson(sessions)))
 }
 
+func getLoginAttempts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	attempts, err := c.App.GetLoginAttempts(c.Params.UserId, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.LoginAttemptListToJson(attempts)))
+}
+
+func unlockUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.UnlockUser(c.Params.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("user_id=" + c.Params.UserId)
+	ReturnStatusOK(w)
+}
+
 func revokeSession(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
+	c.ImpersonationForbidden()
 	if c.Err != nil {
 		return
 	}
@@ -1509,6 +1755,7 @@ func revokeSession(c *Context, w http.ResponseWriter, r *http.Request) {
 
 func revokeAllSessionsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
+	c.ImpersonationForbidden()
 	if c.Err != nil {
 		return
 	}
@@ -1540,6 +1787,118 @@ func revokeAllSessionsAllUsers(c *Context, w http.ResponseWriter, r *http.Reques
 	ReturnStatusOK(w)
 }
 
+func impersonateUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.App.Session.IsOAuth {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	c.LogAudit("attempt - user_id=" + c.Params.UserId)
+
+	session, err := c.App.ImpersonateUser(&c.App.Session, c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - user_id=" + c.Params.UserId + " session_id=" + session.Id)
+	w.Write([]byte(session.ToJson()))
+}
+
+func getUserDeactivationSchedule(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	schedule, err := c.App.GetUserDeactivationSchedule(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(schedule.ToJson()))
+}
+
+func getUserDeactivationSchedules(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	schedules, err := c.App.GetUserDeactivationSchedules(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.UserDeactivationScheduleListToJson(schedules)))
+}
+
+func scheduleUserDeactivation(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.StringInterfaceFromJson(r.Body)
+	deactivateAt, ok := props["deactivate_at"].(float64)
+	if !ok || deactivateAt <= 0 {
+		c.SetInvalidParam("deactivate_at")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	schedule, err := c.App.ScheduleUserDeactivation(c.Params.UserId, int64(deactivateAt), c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("user_id=" + schedule.UserId + " deactivate_at=" + strconv.FormatInt(schedule.DeactivateAt, 10))
+	w.Write([]byte(schedule.ToJson()))
+}
+
+func cancelUserDeactivation(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.CancelUserDeactivation(c.Params.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("user_id=" + c.Params.UserId)
+	ReturnStatusOK(w)
+}
+
 func attachDeviceId(c *Context, w http.ResponseWriter, r *http.Request) {
 	props := model.MapFromJson(r.Body)
 
@@ -1549,6 +1908,12 @@ func attachDeviceId(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Impersonated sessions are time-boxed and mustn't take over the mobile sessions of their user
+	if c.App.Session.IsImpersonated() {
+		c.Err = model.NewAppError("attachDeviceId", "api.user.attach_device_id.impersonated.app_error", nil, "", http.StatusForbidden)
+		return
+	}
+
 	// A special case where we logout of all other sessions with the same device id
 	if err := c.App.RevokeSessionsForDeviceId(c.App.Session.UserId, deviceId, c.App.Session.Id); err != nil {
 		c.Err = err
@@ -1610,6 +1975,26 @@ func getUserAudits(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(audits.ToJson()))
 }
 
+func getUserPushNotificationDiagnostics(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	diagnostics, err := c.App.GetPushNotificationDiagnostics(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(diagnostics.ToJson()))
+}
+
 func verifyUserEmail(c *Context, w http.ResponseWriter, r *http.Request) {
 	props := model.MapFromJson(r.Body)
 
@@ -1693,6 +2078,7 @@ func switchAccountType(c *Context, w http.ResponseWriter, r *http.Request) {
 
 func createUserAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
+	c.ImpersonationForbidden()
 	if c.Err != nil {
 		return
 	}
@@ -1779,6 +2165,31 @@ func getUserAccessTokens(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.UserAccessTokenListToJson(accessTokens)))
 }
 
+func getExpiringUserAccessTokens(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	days := model.USER_ACCESS_TOKEN_EXPIRING_DEFAULT_DAYS
+	if daysString := r.URL.Query().Get("days"); len(daysString) > 0 {
+		var parseError error
+		days, parseError = strconv.Atoi(daysString)
+		if parseError != nil || days <= 0 {
+			c.SetInvalidParam("days")
+			return
+		}
+	}
+
+	accessTokens, err := c.App.GetExpiringUserAccessTokens(int64(days)*24*60*60*1000, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.UserAccessTokenListToJson(accessTokens)))
+}
+
 func getUserAccessTokensForUser(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
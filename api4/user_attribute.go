@@ -0,0 +1,169 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitUserAttribute() {
+	api.BaseRoutes.UserAttributeFields.Handle("", api.ApiSessionRequired(createUserAttributeField)).Methods("POST")
+	api.BaseRoutes.UserAttributeFields.Handle("", api.ApiSessionRequired(getUserAttributeFields)).Methods("GET")
+	api.BaseRoutes.UserAttributeField.Handle("", api.ApiSessionRequired(updateUserAttributeField)).Methods("PUT")
+	api.BaseRoutes.UserAttributeField.Handle("", api.ApiSessionRequired(deleteUserAttributeField)).Methods("DELETE")
+
+	api.BaseRoutes.User.Handle("/attributes", api.ApiSessionRequired(getUserAttributes)).Methods("GET")
+	api.BaseRoutes.User.Handle("/attributes", api.ApiSessionRequired(updateUserAttributes)).Methods("PUT")
+}
+
+func createUserAttributeField(c *Context, w http.ResponseWriter, r *http.Request) {
+	field := model.UserAttributeFieldFromJson(r.Body)
+	if field == nil {
+		c.SetInvalidParam("user_attribute_field")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	field, err := c.App.CreateUserAttributeField(field)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success - field_id=" + field.Id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(field.ToJson()))
+}
+
+func getUserAttributeFields(c *Context, w http.ResponseWriter, r *http.Request) {
+	fields, err := c.App.GetUserAttributeFields()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.UserAttributeFieldListToJson(fields)))
+}
+
+func updateUserAttributeField(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserAttributeFieldId()
+	if c.Err != nil {
+		return
+	}
+
+	updatedField := model.UserAttributeFieldFromJson(r.Body)
+	if updatedField == nil {
+		c.SetInvalidParam("user_attribute_field")
+		return
+	}
+
+	// The field being updated in the payload must be the same one as indicated in the URL.
+	if updatedField.Id != c.Params.UserAttributeFieldId {
+		c.SetInvalidParam("field_id")
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	oldField, err := c.App.GetUserAttributeField(c.Params.UserAttributeFieldId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	field, err := c.App.UpdateUserAttributeField(oldField, updatedField)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success")
+	w.Write([]byte(field.ToJson()))
+}
+
+func deleteUserAttributeField(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserAttributeFieldId()
+	if c.Err != nil {
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.DeleteUserAttributeField(c.Params.UserAttributeFieldId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success")
+	ReturnStatusOK(w)
+}
+
+func getUserAttributes(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	canSee, err := c.App.UserCanSeeOtherUser(c.App.Session.UserId, c.Params.UserId)
+	if err != nil || !canSee {
+		c.SetPermissionError(model.PERMISSION_VIEW_MEMBERS)
+		return
+	}
+
+	attributes, err := c.App.GetUserAttributes(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.MapToJson(attributes)))
+}
+
+func updateUserAttributes(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	attributes := model.MapFromJson(r.Body)
+	if len(attributes) == 0 {
+		c.SetInvalidParam("attributes")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	// Only system admins can override the attributes synced from LDAP or SAML, until they are synced again.
+	overrideSynced := c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM)
+
+	updated, err := c.App.UpdateUserAttributes(c.Params.UserId, attributes, overrideSynced)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("user_id=" + c.Params.UserId)
+	w.Write([]byte(model.MapToJson(updated)))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestUserAttributeFields(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	field := &model.UserAttributeField{Name: "location", DisplayName: "Location", SamlAttribute: "city"}
+
+	_, resp := th.Client.CreateUserAttributeField(field)
+	CheckForbiddenStatus(t, resp)
+
+	created, resp := th.SystemAdminClient.CreateUserAttributeField(field)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	require.Len(t, created.Id, 26)
+
+	fields, resp := th.Client.GetUserAttributeFields()
+	CheckNoError(t, resp)
+	require.Len(t, fields, 1)
+	assert.Equal(t, "city", fields[0].SamlAttribute)
+
+	created.Searchable = true
+
+	_, resp = th.Client.UpdateUserAttributeField(created)
+	CheckForbiddenStatus(t, resp)
+
+	updated, resp := th.SystemAdminClient.UpdateUserAttributeField(created)
+	CheckNoError(t, resp)
+	require.True(t, updated.Searchable)
+
+	_, resp = th.Client.DeleteUserAttributeField(created.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.DeleteUserAttributeField(created.Id)
+	CheckNoError(t, resp)
+	require.True(t, ok)
+
+	_, resp = th.SystemAdminClient.DeleteUserAttributeField(created.Id)
+	CheckNotFoundStatus(t, resp)
+}
+
+func TestUserAttributes(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	_, resp := th.SystemAdminClient.CreateUserAttributeField(&model.UserAttributeField{Name: "department", DisplayName: "Department", Searchable: true})
+	CheckNoError(t, resp)
+
+	_, resp = th.Client.UpdateUserAttributes(th.BasicUser2.Id, map[string]string{"department": "Sales"})
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.UpdateUserAttributes(th.BasicUser.Id, map[string]string{"location": "Lisbon"})
+	CheckBadRequestStatus(t, resp)
+
+	department := "Ornithology" + model.NewId()[:8]
+	attributes, resp := th.Client.UpdateUserAttributes(th.BasicUser.Id, map[string]string{"department": department})
+	CheckNoError(t, resp)
+	require.Equal(t, department, attributes["department"])
+
+	attributes, resp = th.Client.GetUserAttributes(th.BasicUser.Id)
+	CheckNoError(t, resp)
+	require.Equal(t, department, attributes["department"])
+
+	t.Run("profile", func(t *testing.T) {
+		user, resp := th.Client.GetUser(th.BasicUser.Id, "")
+		CheckNoError(t, resp)
+		require.Equal(t, department, user.Attributes["department"])
+	})
+
+	t.Run("search", func(t *testing.T) {
+		users, resp := th.Client.SearchUsers(&model.UserSearch{Term: department, Limit: model.USER_SEARCH_DEFAULT_LIMIT})
+		CheckNoError(t, resp)
+		require.Len(t, users, 1)
+		require.Equal(t, th.BasicUser.Id, users[0].Id)
+		require.Equal(t, department, users[0].Attributes["department"])
+	})
+
+	t.Run("autocomplete", func(t *testing.T) {
+		autocomplete, resp := th.Client.AutocompleteUsersInTeam(th.BasicTeam.Id, th.BasicUser.Username, model.USER_SEARCH_DEFAULT_LIMIT, "")
+		CheckNoError(t, resp)
+		require.Len(t, autocomplete.Users, 1)
+		require.Equal(t, department, autocomplete.Users[0].Attributes["department"])
+	})
+}
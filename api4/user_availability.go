@@ -0,0 +1,190 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	USER_AVAILABILITY_DEFAULT_RANGE = 7 * 24 * 60 * 60 * 1000
+)
+
+func (api *API) InitUserAvailability() {
+	api.BaseRoutes.User.Handle("/availability", api.ApiSessionRequired(createUserAvailability)).Methods("POST")
+	api.BaseRoutes.User.Handle("/availability", api.ApiSessionRequired(getUserAvailability)).Methods("GET")
+	api.BaseRoutes.Users.Handle("/availability/ids", api.ApiSessionRequired(getUserAvailabilityStatusesByIds)).Methods("POST")
+	api.BaseRoutes.UserAvailability.Handle("", api.ApiSessionRequired(updateUserAvailability)).Methods("PUT")
+	api.BaseRoutes.UserAvailability.Handle("", api.ApiSessionRequired(deleteUserAvailability)).Methods("DELETE")
+}
+
+func createUserAvailability(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	availability := model.UserAvailabilityFromJson(r.Body)
+	if availability == nil {
+		c.SetInvalidParam("availability")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	availability.Id = ""
+	availability.UserId = c.Params.UserId
+	availability.CreatorId = c.App.Session.UserId
+
+	availability, err := c.App.CreateUserAvailability(availability)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(availability.ToJson()))
+}
+
+func getUserAvailability(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	startAt := model.GetMillis()
+	if value := r.URL.Query().Get("start_at"); value != "" {
+		var err error
+		if startAt, err = strconv.ParseInt(value, 10, 64); err != nil {
+			c.SetInvalidUrlParam("start_at")
+			return
+		}
+	}
+
+	endAt := startAt + USER_AVAILABILITY_DEFAULT_RANGE
+	if value := r.URL.Query().Get("end_at"); value != "" {
+		var err error
+		if endAt, err = strconv.ParseInt(value, 10, 64); err != nil {
+			c.SetInvalidUrlParam("end_at")
+			return
+		}
+	}
+
+	// No permission check required to know when a user is busy, like their status, but only those who can edit the
+	// user see what they're busy with.
+
+	blocks, err := c.App.GetUserAvailabilityForUser(c.Params.UserId, startAt, endAt)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		for _, block := range blocks {
+			block.Title = ""
+		}
+	}
+
+	w.Write([]byte(model.UserAvailabilityListToJson(blocks)))
+}
+
+func getUserAvailabilityStatusesByIds(c *Context, w http.ResponseWriter, r *http.Request) {
+	userIds := model.ArrayFromJson(r.Body)
+	if len(userIds) == 0 {
+		c.SetInvalidParam("user_ids")
+		return
+	}
+
+	at := model.GetMillis()
+	if value := r.URL.Query().Get("at"); value != "" {
+		var err error
+		if at, err = strconv.ParseInt(value, 10, 64); err != nil {
+			c.SetInvalidUrlParam("at")
+			return
+		}
+	}
+
+	// No permission check required
+
+	statuses, err := c.App.GetUserAvailabilityStatuses(userIds, at)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.MapToJson(statuses)))
+}
+
+func updateUserAvailability(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireAvailabilityId()
+	if c.Err != nil {
+		return
+	}
+
+	availability := model.UserAvailabilityFromJson(r.Body)
+	if availability == nil || availability.Id != c.Params.AvailabilityId {
+		c.SetInvalidParam("availability")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	oldAvailability, err := c.App.GetUserAvailability(c.Params.AvailabilityId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if oldAvailability.UserId != c.Params.UserId {
+		c.Err = model.NewAppError("updateUserAvailability", "api.user_availability.user_mismatch.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	availability, err = c.App.UpdateUserAvailability(availability)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(availability.ToJson()))
+}
+
+func deleteUserAvailability(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireAvailabilityId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	availability, err := c.App.GetUserAvailability(c.Params.AvailabilityId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if availability.UserId != c.Params.UserId {
+		c.Err = model.NewAppError("deleteUserAvailability", "api.user_availability.user_mismatch.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.App.DeleteUserAvailability(availability.Id); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestUserAvailability(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	now := model.GetMillis()
+
+	availability := &model.UserAvailability{
+		UserId:  th.BasicUser.Id,
+		Status:  model.USER_AVAILABILITY_BUSY,
+		Title:   "Dentist",
+		StartAt: now - 60*1000,
+		EndAt:   now + 60*60*1000,
+	}
+
+	created, resp := th.Client.CreateUserAvailability(availability)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.BasicUser.Id, created.CreatorId)
+	assert.Equal(t, model.USER_AVAILABILITY_SOURCE_MANUAL, created.Source)
+
+	_, resp = th.Client.CreateUserAvailability(&model.UserAvailability{UserId: th.BasicUser2.Id, Status: model.USER_AVAILABILITY_BUSY, StartAt: now, EndAt: now + 1000})
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.CreateUserAvailability(&model.UserAvailability{UserId: th.BasicUser.Id, Status: "away", StartAt: now, EndAt: now + 1000})
+	CheckBadRequestStatus(t, resp)
+
+	blocks, resp := th.Client.GetUserAvailability(th.BasicUser.Id, now, now+24*60*60*1000)
+	CheckNoError(t, resp)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "Dentist", blocks[0].Title)
+
+	// Others see when the user is busy, but not with what
+	th.LoginBasic2()
+	blocks, resp = th.Client.GetUserAvailability(th.BasicUser.Id, now, now+24*60*60*1000)
+	CheckNoError(t, resp)
+	require.Len(t, blocks, 1)
+	assert.Empty(t, blocks[0].Title)
+
+	statuses, resp := th.Client.GetUserAvailabilityStatusesByIds([]string{th.BasicUser.Id, th.BasicUser2.Id}, now)
+	CheckNoError(t, resp)
+	assert.Equal(t, model.USER_AVAILABILITY_BUSY, statuses[th.BasicUser.Id])
+	assert.Equal(t, model.USER_AVAILABILITY_FREE, statuses[th.BasicUser2.Id])
+
+	_, resp = th.Client.DeleteUserAvailability(th.BasicUser.Id, created.Id)
+	CheckForbiddenStatus(t, resp)
+
+	th.LoginBasic()
+	created.Status = model.USER_AVAILABILITY_FREE
+	updated, resp := th.Client.UpdateUserAvailability(created)
+	CheckNoError(t, resp)
+	assert.Equal(t, model.USER_AVAILABILITY_FREE, updated.Status)
+
+	ok, resp := th.SystemAdminClient.DeleteUserAvailability(th.BasicUser.Id, created.Id)
+	CheckNoError(t, resp)
+	assert.True(t, ok)
+
+	blocks, resp = th.Client.GetUserAvailability(th.BasicUser.Id, now, now+24*60*60*1000)
+	CheckNoError(t, resp)
+	assert.Empty(t, blocks)
+}
@@ -416,6 +416,39 @@ func TestGetMe(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestGetMeFeatureFlags(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.FeatureFlagSettings.Flags = map[string]*model.FeatureFlag{
+			"Everyone": {Enabled: true},
+			"Team":     {TeamIds: []string{th.BasicTeam.Id}},
+			"User":     {UserIds: []string{th.BasicUser2.Id}},
+		}
+	})
+
+	ruser, resp := th.Client.GetMe("")
+	CheckNoError(t, resp)
+	assert.Equal(t, map[string]bool{"Everyone": true, "Team": true, "User": false}, ruser.FeatureFlags)
+
+	ruser, resp = th.Client.GetMe(resp.Etag)
+	CheckEtag(t, ruser, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.FeatureFlagSettings.Flags["User"].UserIds = []string{th.BasicUser.Id}
+	})
+
+	// Enabling a feature changes the etag.
+	ruser, resp = th.Client.GetMe(resp.Etag)
+	CheckNoError(t, resp)
+	assert.True(t, ruser.FeatureFlags["User"])
+
+	ruser, resp = th.Client.GetUser(th.BasicUser2.Id, "")
+	CheckNoError(t, resp)
+	assert.Nil(t, ruser.FeatureFlags)
+}
+
 func TestGetUser(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -988,6 +1021,54 @@ func TestSearchUsers(t *testing.T) {
 	}
 }
 
+func TestSearchUsersRanking(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	prefix := "rank" + model.NewId()[:8]
+	createUser := func(username, nickname string) *model.User {
+		user, err := th.App.CreateUser(&model.User{Email: th.GenerateTestEmail(), Username: username, Nickname: nickname, Password: "Pa$$word11"})
+		require.Nil(t, err)
+		th.LinkUserToTeam(user, th.BasicTeam)
+		return user
+	}
+
+	exact := createUser(prefix, "")
+	other := createUser(prefix+"a", "")
+	partner := createUser(prefix+"b", "")
+	participant := createUser(prefix+"c", "")
+	fuzzy := createUser("z"+model.NewId(), "r-a-n-k"+prefix[4:])
+
+	th.CreateMessagePostNoClient(th.CreateDmChannel(partner), "hello", model.GetMillis())
+
+	// Replying to a thread of the user ranks the participant after direct message partners
+	_, err := th.App.CreatePost(&model.Post{UserId: participant.Id, ChannelId: th.BasicChannel.Id, RootId: th.BasicPost.Id, ParentId: th.BasicPost.Id, Message: "reply"}, th.BasicChannel, false)
+	require.Nil(t, err)
+
+	t.Run("search", func(t *testing.T) {
+		users, resp := th.Client.SearchUsers(&model.UserSearch{Term: prefix, TeamId: th.BasicTeam.Id, Limit: 10})
+		CheckNoError(t, resp)
+		require.Len(t, users, 5)
+		assert.Equal(t, []string{exact.Id, partner.Id, participant.Id, other.Id, fuzzy.Id}, model.UserSlice(users).IDs())
+	})
+
+	t.Run("autocomplete", func(t *testing.T) {
+		autocomplete, resp := th.Client.AutocompleteUsersInTeam(th.BasicTeam.Id, prefix, 10, "")
+		CheckNoError(t, resp)
+		require.Len(t, autocomplete.Users, 5)
+		assert.Equal(t, []string{exact.Id, partner.Id, participant.Id, other.Id, fuzzy.Id}, model.UserSlice(autocomplete.Users).IDs())
+	})
+
+	t.Run("fuzzy matching disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableFuzzyUserSearch = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableFuzzyUserSearch = true })
+
+		users, resp := th.Client.SearchUsers(&model.UserSearch{Term: prefix, TeamId: th.BasicTeam.Id, Limit: 10})
+		CheckNoError(t, resp)
+		assert.Equal(t, []string{exact.Id, partner.Id, participant.Id, other.Id}, model.UserSlice(users).IDs())
+	})
+}
+
 func findUserInList(id string, users []*model.User) bool {
 	for _, user := range users {
 		if user.Id == id {
@@ -2229,6 +2310,22 @@ func TestUpdateUserMfa(t *testing.T) {
 
 	_, resp := th.Client.UpdateUserMfa(th.BasicUser.Id, "12345", false)
 	CheckForbiddenStatus(t, resp)
+
+	session.IsOAuth = false
+	th.App.AddSessionToCache(session)
+
+	secret, err := th.App.GenerateMfaSecret(th.BasicUser.Id)
+	require.Nil(t, err)
+
+	code := dgoogauth.ComputeCode(secret.Secret, time.Now().UTC().Unix()/30)
+
+	codes, resp := th.Client.ActivateUserMfa(th.BasicUser.Id, fmt.Sprintf("%06d", code))
+	CheckNoError(t, resp)
+	require.Len(t, codes, model.MFA_RECOVERY_CODES_COUNT)
+
+	ok, resp := th.Client.UpdateUserMfa(th.BasicUser.Id, "", false)
+	CheckNoError(t, resp)
+	require.True(t, ok)
 }
 
 // CheckUserMfa is deprecated and should not be used anymore, it will be disabled by default in version 6.0
@@ -2723,6 +2820,124 @@ func TestRevokeSessionsFromAllUsers(t *testing.T) {
 
 }
 
+func TestImpersonateUser(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserImpersonation = false })
+
+	_, resp := th.SystemAdminClient.ImpersonateUser(th.BasicUser.Id)
+	CheckNotImplementedStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserImpersonation = true })
+
+	_, resp = th.Client.ImpersonateUser(th.BasicUser2.Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.ImpersonateUser("junk")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.ImpersonateUser(th.SystemAdminUser.Id)
+	CheckBadRequestStatus(t, resp)
+
+	session, resp := th.SystemAdminClient.ImpersonateUser(th.BasicUser.Id)
+	CheckNoError(t, resp)
+	require.Equal(t, th.BasicUser.Id, session.UserId)
+	require.True(t, session.IsImpersonated())
+	require.Equal(t, th.SystemAdminUser.Id, session.GetImpersonatorId())
+	require.True(t, session.ExpiresAt <= model.GetMillis()+int64(*th.App.Config().ServiceSettings.UserImpersonationLengthInMinutes)*60*1000)
+
+	client := th.CreateClient()
+	client.SetToken(session.Token)
+
+	user, resp := client.GetMe("")
+	CheckNoError(t, resp)
+	require.Equal(t, th.BasicUser.Id, user.Id)
+	require.Equal(t, th.SystemAdminUser.Id, resp.Header.Get(model.HEADER_IMPERSONATOR_ID))
+
+	t.Run("attach device id", func(t *testing.T) {
+		_, resp := client.AttachDeviceId("android:" + model.NewId())
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("credentials and authentication changes", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+		_, resp := client.CreateUserAccessToken(th.BasicUser.Id, "impersonated")
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = client.UpdateUserPassword(th.BasicUser.Id, th.BasicUser.Password, "newpassword1")
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = client.UpdateUserMfa(th.BasicUser.Id, "", false)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = client.RevokeAllSessions(th.BasicUser.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = client.AuthorizeOAuthApp(&model.AuthorizeRequest{
+			ResponseType: model.AUTHCODE_RESPONSE_TYPE,
+			ClientId:     model.NewId(),
+			RedirectUri:  "http://example.com",
+			State:        "123",
+		})
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("expired session", func(t *testing.T) {
+		session.ExpiresAt = model.GetMillis() - 1
+		th.App.AddSessionToCache(session)
+
+		_, resp := client.GetMe("")
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
+func TestUserDeactivationSchedule(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.OffboardingSettings.EnableScheduledDeactivation = true })
+
+	deactivateAt := model.GetMillis() + 24*60*60*1000
+
+	_, resp := th.Client.ScheduleUserDeactivation(th.BasicUser2.Id, deactivateAt)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.ScheduleUserDeactivation(th.BasicUser2.Id, 0)
+	CheckBadRequestStatus(t, resp)
+
+	schedule, resp := th.SystemAdminClient.ScheduleUserDeactivation(th.BasicUser2.Id, deactivateAt)
+	CheckNoError(t, resp)
+	require.Equal(t, th.BasicUser2.Id, schedule.UserId)
+	require.Equal(t, th.SystemAdminUser.Id, schedule.CreatorId)
+	require.Equal(t, deactivateAt, schedule.DeactivateAt)
+
+	_, resp = th.Client.GetUserDeactivationSchedule(th.BasicUser2.Id)
+	CheckForbiddenStatus(t, resp)
+
+	schedule, resp = th.SystemAdminClient.GetUserDeactivationSchedule(th.BasicUser2.Id)
+	CheckNoError(t, resp)
+	require.Equal(t, deactivateAt, schedule.DeactivateAt)
+
+	_, resp = th.Client.GetUserDeactivationSchedules(0, 60)
+	CheckForbiddenStatus(t, resp)
+
+	schedules, resp := th.SystemAdminClient.GetUserDeactivationSchedules(0, 60)
+	CheckNoError(t, resp)
+	require.Len(t, schedules, 1)
+
+	_, resp = th.Client.CancelUserDeactivation(th.BasicUser2.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.CancelUserDeactivation(th.BasicUser2.Id)
+	CheckNoError(t, resp)
+	require.True(t, ok)
+
+	_, resp = th.SystemAdminClient.GetUserDeactivationSchedule(th.BasicUser2.Id)
+	CheckNotFoundStatus(t, resp)
+}
+
 func TestAttachDeviceId(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -2797,6 +3012,37 @@ func TestGetUserAudits(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetUserPushNotificationDiagnostics(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	user := th.BasicUser
+
+	diagnostics, resp := th.Client.GetUserPushNotificationDiagnostics(user.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, user.Id, diagnostics.UserId)
+	assert.Contains(t, diagnostics.Problems, model.PUSH_DIAGNOSTIC_NO_DEVICES)
+
+	_, resp = th.Client.AttachDeviceId(model.PUSH_NOTIFY_ANDROID + ":" + model.NewId())
+	CheckNoError(t, resp)
+
+	diagnostics, resp = th.Client.GetUserPushNotificationDiagnostics(user.Id)
+	CheckNoError(t, resp)
+	require.Len(t, diagnostics.Sessions, 1)
+	assert.Equal(t, model.PUSH_NOTIFY_ANDROID, diagnostics.Sessions[0].Platform)
+	assert.True(t, diagnostics.Sessions[0].HasDeviceId)
+	assert.NotContains(t, diagnostics.Problems, model.PUSH_DIAGNOSTIC_NO_DEVICES)
+
+	_, resp = th.Client.GetUserPushNotificationDiagnostics(th.BasicUser2.Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetUserPushNotificationDiagnostics(user.Id)
+	CheckNoError(t, resp)
+
+	th.Client.Logout()
+	_, resp = th.Client.GetUserPushNotificationDiagnostics(user.Id)
+	CheckUnauthorizedStatus(t, resp)
+}
+
 func TestVerifyUserEmail(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -4225,6 +4471,111 @@ func TestUserAccessTokenInactiveUser(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestUserAccessTokenExpiry(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	_, err := th.App.CreateUserAccessToken(&model.UserAccessToken{UserId: th.BasicUser.Id, Description: "expired", ExpiresAt: model.GetMillis() - 1000})
+	require.NotNil(t, err)
+	assert.Equal(t, "app.user_access_token.expires_at.app_error", err.Id)
+
+	token, err := th.App.CreateUserAccessToken(&model.UserAccessToken{UserId: th.BasicUser.Id, Description: "expiring", ExpiresAt: model.GetMillis() + 60000})
+	require.Nil(t, err)
+
+	th.Client.AuthToken = token.Token
+	_, resp := th.Client.GetMe("")
+	CheckNoError(t, resp)
+
+	token, err = th.App.GetUserAccessToken(token.Id, false)
+	require.Nil(t, err)
+	assert.NotZero(t, token.LastUsedAt)
+
+	session, err := th.App.GetSession(token.Token)
+	require.Nil(t, err)
+	assert.Equal(t, token.ExpiresAt, session.ExpiresAt)
+
+	// A token that has expired can't be used to create a session
+	expired, err := th.App.Srv.Store.UserAccessToken().Save(&model.UserAccessToken{Token: model.NewId(), UserId: th.BasicUser.Id, Description: "expired", ExpiresAt: model.GetMillis() - 1000})
+	require.Nil(t, err)
+
+	th.Client.AuthToken = expired.Token
+	_, resp = th.Client.GetMe("")
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestUserAccessTokenAllowedIPs(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	token, err := th.App.CreateUserAccessToken(&model.UserAccessToken{UserId: th.BasicUser.Id, Description: "remote", AllowedIPs: "10.0.0.0/8"})
+	require.Nil(t, err)
+
+	th.Client.AuthToken = token.Token
+	_, resp := th.Client.GetMe("")
+	CheckUnauthorizedStatus(t, resp)
+
+	token, err = th.App.CreateUserAccessToken(&model.UserAccessToken{UserId: th.BasicUser.Id, Description: "local", AllowedIPs: "127.0.0.0/8 ::1"})
+	require.Nil(t, err)
+
+	th.Client.AuthToken = token.Token
+	_, resp = th.Client.GetMe("")
+	CheckNoError(t, resp)
+}
+
+func TestUserAccessTokenScope(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	token, err := th.App.CreateUserAccessToken(&model.UserAccessToken{UserId: th.BasicUser.Id, Description: "read only", Scope: model.OAUTH_SCOPE_READ_USERS + " " + model.OAUTH_SCOPE_READ_POSTS})
+	require.Nil(t, err)
+
+	th.Client.AuthToken = token.Token
+	_, resp := th.Client.GetMe("")
+	CheckNoError(t, resp)
+
+	_, resp = th.Client.GetPost(th.BasicPost.Id, "")
+	CheckNoError(t, resp)
+
+	_, resp = th.Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "not allowed"})
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestGetExpiringUserAccessTokens(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	now := model.GetMillis()
+	soon, err := th.App.CreateUserAccessToken(&model.UserAccessToken{UserId: th.BasicUser.Id, Description: "soon", ExpiresAt: now + 24*60*60*1000})
+	require.Nil(t, err)
+	later, err := th.App.CreateUserAccessToken(&model.UserAccessToken{UserId: th.BasicUser.Id, Description: "later", ExpiresAt: now + 30*24*60*60*1000})
+	require.Nil(t, err)
+	_, err = th.App.CreateUserAccessToken(&model.UserAccessToken{UserId: th.BasicUser.Id, Description: "never"})
+	require.Nil(t, err)
+
+	tokens, resp := th.SystemAdminClient.GetExpiringUserAccessTokens(0, 0, 100)
+	CheckNoError(t, resp)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, soon.Id, tokens[0].Id)
+	assert.Empty(t, tokens[0].Token)
+
+	tokens, resp = th.SystemAdminClient.GetExpiringUserAccessTokens(60, 0, 100)
+	CheckNoError(t, resp)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, soon.Id, tokens[0].Id)
+	assert.Equal(t, later.Id, tokens[1].Id)
+
+	_, resp = th.Client.GetExpiringUserAccessTokens(0, 0, 100)
+	CheckForbiddenStatus(t, resp)
+}
+
 func TestUserAccessTokenDisableConfig(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -4568,3 +4919,170 @@ func TestLoginLockout(t *testing.T) {
 	_, resp = th.Client.Login(th.BasicUser2.Email, th.BasicUser2.Password)
 	CheckErrorMessage(t, resp, "api.user.check_user_login_attempts.too_many.app_error")
 }
+
+func TestUnlockUser(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaximumLoginAttempts = 1 })
+
+	client := th.CreateClient()
+	_, resp := client.Login(th.BasicUser2.Email, "wrong")
+	CheckErrorMessage(t, resp, "api.user.login.invalid_credentials_email_username")
+	_, resp = client.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+	CheckErrorMessage(t, resp, "api.user.check_user_login_attempts.too_many.app_error")
+
+	_, resp = th.Client.UnlockUser(th.BasicUser2.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.UnlockUser(th.BasicUser2.Id)
+	CheckNoError(t, resp)
+	require.True(t, ok)
+
+	_, resp = client.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+	CheckNoError(t, resp)
+
+	_, resp = th.SystemAdminClient.UnlockUser(model.NewId())
+	CheckNotFoundStatus(t, resp)
+}
+
+func TestGetLoginAttempts(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	client := th.CreateClient()
+	_, resp := client.Login(th.BasicUser2.Email, "wrong")
+	CheckErrorMessage(t, resp, "api.user.login.invalid_credentials_email_username")
+
+	attempts, resp := th.SystemAdminClient.GetLoginAttempts(th.BasicUser2.Id, 0, 10)
+	CheckNoError(t, resp)
+	require.Len(t, attempts, 1)
+	require.False(t, attempts[0].Success)
+
+	_, resp = client.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+	CheckNoError(t, resp)
+
+	// Successful logins are recorded in the background.
+	for i := 0; i < 50 && len(attempts) < 2; i++ {
+		time.Sleep(100 * time.Millisecond)
+		attempts, resp = client.GetLoginAttempts(th.BasicUser2.Id, 0, 10)
+		CheckNoError(t, resp)
+	}
+	require.Len(t, attempts, 2)
+
+	_, resp = th.Client.GetLoginAttempts(th.BasicUser2.Id, 0, 10)
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestCreateBulkUsersJob(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	operation := &model.BulkUsersOperation{
+		Action:  model.BULK_USERS_ACTION_DEACTIVATE,
+		UserIds: []string{th.BasicUser2.Id},
+	}
+
+	_, resp := Client.CreateBulkUsersJob(operation)
+	CheckForbiddenStatus(t, resp)
+
+	job, resp := th.SystemAdminClient.CreateBulkUsersJob(operation)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	require.Equal(t, model.JOB_TYPE_BULK_USERS, job.Type)
+	require.Equal(t, model.BULK_USERS_ACTION_DEACTIVATE, job.Data[model.BULK_USERS_JOB_DATA_ACTION])
+	require.Equal(t, th.SystemAdminUser.Id, job.Data[model.BULK_USERS_JOB_DATA_REQUESTER_ID])
+
+	_, resp = th.SystemAdminClient.CreateBulkUsersJob(&model.BulkUsersOperation{Action: model.BULK_USERS_ACTION_DEACTIVATE})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.CreateBulkUsersJob(&model.BulkUsersOperation{Action: model.BULK_USERS_ACTION_MOVE_TEAM, UserIds: operation.UserIds, ToTeamId: model.NewId()})
+	CheckNotFoundStatus(t, resp)
+
+	_, resp = Client.GetBulkUsersResults(job.Id)
+	CheckForbiddenStatus(t, resp)
+
+	// The job has not run yet so there are no results.
+	_, resp = th.SystemAdminClient.GetBulkUsersResults(job.Id)
+	CheckNotFoundStatus(t, resp)
+}
+
+func TestAnonymizeUser(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	_, resp := Client.AnonymizeUser(th.BasicUser2.Id)
+	CheckForbiddenStatus(t, resp)
+
+	job, resp := th.SystemAdminClient.AnonymizeUser(th.BasicUser2.Id)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	require.Equal(t, model.JOB_TYPE_USER_ANONYMIZATION, job.Type)
+	require.Equal(t, th.BasicUser2.Id, job.Data[model.USER_ANONYMIZATION_JOB_DATA_USER_ID])
+	require.Equal(t, th.SystemAdminUser.Id, job.Data[model.USER_ANONYMIZATION_JOB_DATA_REQUESTER_ID])
+
+	_, resp = th.SystemAdminClient.AnonymizeUser(th.SystemAdminUser.Id)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.AnonymizeUser(model.NewId())
+	CheckNotFoundStatus(t, resp)
+}
+
+func TestGenerateMfaRecoveryCodes(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	_, resp := th.Client.GenerateMfaRecoveryCodes(th.BasicUser.Id)
+	CheckBadRequestStatus(t, resp)
+
+	require.Nil(t, th.Server.Store.User().UpdateMfaActive(th.BasicUser.Id, true))
+	th.App.InvalidateCacheForUser(th.BasicUser.Id)
+
+	codes, resp := th.Client.GenerateMfaRecoveryCodes(th.BasicUser.Id)
+	CheckNoError(t, resp)
+	require.Len(t, codes, model.MFA_RECOVERY_CODES_COUNT)
+
+	_, resp = th.SystemAdminClient.GenerateMfaRecoveryCodes(th.BasicUser.Id)
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestResetUserMfa(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	require.Nil(t, th.Server.Store.User().UpdateMfaActive(th.BasicUser2.Id, true))
+	th.App.InvalidateCacheForUser(th.BasicUser2.Id)
+
+	_, resp := th.Client.ResetUserMfa(th.BasicUser2.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.ResetUserMfa(th.BasicUser2.Id)
+	CheckNoError(t, resp)
+	require.True(t, ok)
+
+	user, err := th.App.GetUser(th.BasicUser2.Id)
+	require.Nil(t, err)
+	require.False(t, user.MfaActive)
+
+	_, resp = th.SystemAdminClient.ResetUserMfa(model.NewId())
+	CheckNotFoundStatus(t, resp)
+}
+
+func TestGetMfaAdoption(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	_, resp := th.Client.GetMfaAdoption()
+	CheckForbiddenStatus(t, resp)
+
+	adoption, resp := th.SystemAdminClient.GetMfaAdoption()
+	CheckNoError(t, resp)
+	require.NotZero(t, adoption.TotalUsers)
+	require.Zero(t, adoption.MfaActiveUsers)
+}
@@ -22,6 +22,10 @@ func (api *API) InitWebhook() {
 	api.BaseRoutes.OutgoingHook.Handle("", api.ApiSessionRequired(updateOutgoingHook)).Methods("PUT")
 	api.BaseRoutes.OutgoingHook.Handle("", api.ApiSessionRequired(deleteOutgoingHook)).Methods("DELETE")
 	api.BaseRoutes.OutgoingHook.Handle("/regen_token", api.ApiSessionRequired(regenOutgoingHookToken)).Methods("POST")
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries", api.ApiSessionRequired(getOutgoingHookDeliveries)).Methods("GET")
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries/{delivery_id:[A-Za-z0-9]+}/replay", api.ApiSessionRequired(replayOutgoingHookDelivery)).Methods("POST")
+
+	api.BaseRoutes.Hooks.Handle("/usage/incoming", api.ApiSessionRequired(getIncomingHooksUsage)).Methods("GET")
 }
 
 func createIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -464,6 +468,66 @@ func regenOutgoingHookToken(c *Context, w http.ResponseWriter, r *http.Request)
 	w.Write([]byte(rhook.ToJson()))
 }
 
+func getOutgoingHookDeliveries(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	hook, err := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, hook.TeamId, model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS)
+		return
+	}
+
+	if c.App.Session.UserId != hook.CreatorId && !c.App.SessionHasPermissionToTeam(c.App.Session, hook.TeamId, model.PERMISSION_MANAGE_OTHERS_OUTGOING_WEBHOOKS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_OTHERS_OUTGOING_WEBHOOKS)
+		return
+	}
+
+	w.Write([]byte(model.OutgoingWebhookDeliveryListToJson(c.App.GetOutgoingWebhookDeliveries(hook))))
+}
+
+func replayOutgoingHookDelivery(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId().RequireDeliveryId()
+	if c.Err != nil {
+		return
+	}
+
+	hook, err := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, hook.TeamId, model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS)
+		return
+	}
+
+	if c.App.Session.UserId != hook.CreatorId && !c.App.SessionHasPermissionToTeam(c.App.Session, hook.TeamId, model.PERMISSION_MANAGE_OTHERS_OUTGOING_WEBHOOKS) {
+		c.LogAudit("fail - inappropriate permissions")
+		c.SetPermissionError(model.PERMISSION_MANAGE_OTHERS_OUTGOING_WEBHOOKS)
+		return
+	}
+
+	delivery, err := c.App.ReplayOutgoingWebhookDelivery(hook, c.Params.DeliveryId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("success delivery_id=" + delivery.Id)
+	w.Write([]byte(delivery.ToJson()))
+}
+
 func deleteOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireHookId()
 	if c.Err != nil {
@@ -498,3 +562,18 @@ func deleteOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.LogAudit("success")
 	ReturnStatusOK(w)
 }
+
+func getIncomingHooksUsage(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	usage, err := c.App.GetTopIncomingWebhookUsage(c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.IncomingWebhookUsageListToJson(usage)))
+}
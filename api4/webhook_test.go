@@ -4,7 +4,10 @@
 package api4
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -944,6 +947,67 @@ func TestRegenOutgoingHookToken(t *testing.T) {
 	CheckNotImplementedStatus(t, resp)
 }
 
+func TestOutgoingHookTestMode(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	received := make(chan bool, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- true
+		w.Write([]byte(`{"text": "echo"}`))
+	}))
+	defer ts.Close()
+
+	hook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{ts.URL}, TestMode: true, ContentType: "application/json"}
+	rhook, resp := th.SystemAdminClient.CreateOutgoingWebhook(hook)
+	CheckNoError(t, resp)
+	require.True(t, rhook.TestMode)
+
+	_, resp = Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "captured"})
+	CheckNoError(t, resp)
+
+	var deliveries []*model.OutgoingWebhookDelivery
+	for i := 0; i < 50 && len(deliveries) == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		deliveries, resp = th.SystemAdminClient.GetOutgoingWebhookDeliveries(rhook.Id)
+		CheckNoError(t, resp)
+	}
+	require.Len(t, deliveries, 1)
+	assert.False(t, deliveries[0].Delivered)
+	assert.Equal(t, ts.URL, deliveries[0].URL)
+	assert.Equal(t, model.OUTGOING_WEBHOOK_EVENT_POST_CREATED, deliveries[0].EventType)
+	assert.Contains(t, deliveries[0].RequestBody, "captured")
+	assert.Empty(t, received, "test mode deliveries should not be sent")
+
+	_, resp = Client.GetOutgoingWebhookDeliveries(rhook.Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = Client.ReplayOutgoingWebhookDelivery(rhook.Id, deliveries[0].Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.ReplayOutgoingWebhookDelivery(rhook.Id, model.NewId())
+	CheckNotFoundStatus(t, resp)
+
+	replay, resp := th.SystemAdminClient.ReplayOutgoingWebhookDelivery(rhook.Id, deliveries[0].Id)
+	CheckNoError(t, resp)
+	assert.True(t, replay.Delivered)
+	assert.Equal(t, deliveries[0].Id, replay.ReplayOf)
+	assert.Equal(t, http.StatusOK, replay.StatusCode)
+	assert.Equal(t, `{"text": "echo"}`, replay.ResponseBody)
+	assert.Len(t, received, 1)
+
+	deliveries, resp = th.SystemAdminClient.GetOutgoingWebhookDeliveries(rhook.Id)
+	CheckNoError(t, resp)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, replay.Id, deliveries[0].Id)
+}
+
 func TestUpdateOutgoingHook(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -1191,3 +1255,30 @@ func TestDeleteOutgoingHook(t *testing.T) {
 		CheckForbiddenStatus(t, resp)
 	})
 }
+
+func TestGetTopIncomingWebhookUsage(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = true })
+
+	hook, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	require.Nil(t, err)
+	require.Nil(t, th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{Text: "text"}))
+
+	_, resp := th.Client.GetTopIncomingWebhookUsage(10)
+	CheckForbiddenStatus(t, resp)
+
+	usage, resp := th.SystemAdminClient.GetTopIncomingWebhookUsage(10)
+	CheckNoError(t, resp)
+
+	found := false
+	for _, item := range usage {
+		if item.HookId == hook.Id {
+			found = true
+			assert.Equal(t, 1, item.PostsToday)
+			assert.Equal(t, th.BasicChannel.Id, item.ChannelId)
+		}
+	}
+	assert.True(t, found)
+}
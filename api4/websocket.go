@@ -16,6 +16,15 @@ func (api *API) InitWebSocket() {
 }
 
 func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.AcceptsWebSocketConnections() {
+		if c.App.IsDrainingWebSocketConnections() {
+			c.Err = model.NewAppError("connect", "api.web_socket.connect.draining.app_error", nil, "", http.StatusServiceUnavailable)
+		} else {
+			c.Err = model.NewAppError("connect", "api.web_socket.connect.not_accepted.app_error", nil, "", http.StatusMisdirectedRequest)
+		}
+		return
+	}
+
 	upgrader := websocket.Upgrader{
 		ReadBufferSize:  model.SOCKET_MAX_MESSAGE_SIZE_KB,
 		WriteBufferSize: model.SOCKET_MAX_MESSAGE_SIZE_KB,
@@ -31,7 +40,7 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	wc := c.App.NewWebConn(ws, c.App.Session, c.App.T, "")
 
-	if len(c.App.Session.UserId) > 0 {
+	if len(c.App.Session.UserId) > 0 && c.App.Session.HasOAuthScope(model.OAUTH_SCOPE_READ_POSTS) {
 		c.App.HubRegister(wc)
 	}
 
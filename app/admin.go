@@ -175,6 +175,16 @@ func (a *App) RecycleDatabaseConnection() {
 	mlog.Warn("Finished recycling the database connection.")
 }
 
+// GetSchemaMigrations returns the online schema migrations known to the server, pending ones included.
+func (a *App) GetSchemaMigrations() ([]*model.SchemaMigration, *model.AppError) {
+	return a.Srv.Store.SchemaMigration().GetAll()
+}
+
+// GetRecentSlowQueries returns the most recent slow queries run by this server, when the slow query log is enabled.
+func (a *App) GetRecentSlowQueries() []*model.SlowQuery {
+	return a.Srv.Store.RecentSlowQueries()
+}
+
 func (a *App) TestSiteURL(siteURL string) *model.AppError {
 	url := fmt.Sprintf("%s/api/v4/system/ping", siteURL)
 	res, err := http.Get(url)
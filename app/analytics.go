@@ -202,6 +202,9 @@ func (a *App) GetAnalytics(name string, teamId string) (model.AnalyticsRows, *mo
 			YesterdayOnly: false,
 		})
 	} else if name == "post_counts_day" {
+		if a.dailyStatsAreCurrent() {
+			return a.getDailyStatsAnalyticsRows(teamId, func(stat *model.DailyStat) int64 { return stat.PostCount })
+		}
 		if skipIntensiveQueries {
 			rows := model.AnalyticsRows{&model.AnalyticsRow{Name: "", Value: -1}}
 			return rows, nil
@@ -212,6 +215,9 @@ func (a *App) GetAnalytics(name string, teamId string) (model.AnalyticsRows, *mo
 			YesterdayOnly: false,
 		})
 	} else if name == "user_counts_with_posts_day" {
+		if a.dailyStatsAreCurrent() {
+			return a.getDailyStatsAnalyticsRows(teamId, func(stat *model.DailyStat) int64 { return stat.ActiveUserCount })
+		}
 		if skipIntensiveQueries {
 			rows := model.AnalyticsRows{&model.AnalyticsRow{Name: "", Value: -1}}
 			return rows, nil
@@ -0,0 +1,257 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	announcementAudiencePerPage = 200
+)
+
+func (a *App) GetAnnouncements(page, perPage int) ([]*model.Announcement, *model.AppError) {
+	return a.Srv.Store.Announcement().GetAll(page*perPage, perPage)
+}
+
+func (a *App) GetAnnouncement(announcementId string) (*model.Announcement, *model.AppError) {
+	return a.Srv.Store.Announcement().Get(announcementId)
+}
+
+func (a *App) GetAnnouncementReport(announcementId string) (*model.AnnouncementReport, *model.AppError) {
+	if _, err := a.Srv.Store.Announcement().Get(announcementId); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.Announcement().GetReport(announcementId)
+}
+
+func (a *App) GetAnnouncementRecipients(announcementId string, page, perPage int) ([]*model.AnnouncementRecipient, *model.AppError) {
+	return a.Srv.Store.Announcement().GetRecipients(announcementId, page*perPage, perPage)
+}
+
+// GetPendingAnnouncementsForUser returns the active announcements shown to the user as banners or waiting for their
+// acknowledgment.
+func (a *App) GetPendingAnnouncementsForUser(userId string) ([]*model.Announcement, *model.AppError) {
+	return a.Srv.Store.Announcement().GetPendingForUser(userId, model.GetMillis())
+}
+
+// CreateAnnouncement saves the announcement along with its audience as it is now, shows it to them as a banner and
+// sends it to them as direct messages from the system bot in the background, as asked.
+func (a *App) CreateAnnouncement(announcement *model.Announcement) (*model.Announcement, *model.AppError) {
+	announcement.Id = ""
+	announcement.CreateAt = 0
+	announcement.DeleteAt = 0
+
+	for _, teamId := range announcement.TeamIds {
+		if _, err := a.GetTeam(teamId); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, groupId := range announcement.GroupIds {
+		if _, err := a.GetGroup(groupId); err != nil {
+			return nil, err
+		}
+	}
+
+	var bot *model.Bot
+	if announcement.SendDirectMessage {
+		var err *model.AppError
+		if bot, err = a.GetSystemBot(); err != nil {
+			return nil, err
+		}
+	}
+
+	announcement, err := a.Srv.Store.Announcement().Save(announcement)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds, err := a.getAnnouncementAudience(announcement)
+	if err != nil {
+		return nil, err
+	}
+
+	status := model.ANNOUNCEMENT_RECIPIENT_STATUS_DELIVERED
+	deliverAt := announcement.CreateAt
+	if announcement.SendDirectMessage {
+		status = model.ANNOUNCEMENT_RECIPIENT_STATUS_PENDING
+		deliverAt = 0
+	}
+
+	recipients := make([]*model.AnnouncementRecipient, 0, len(userIds))
+	for _, userId := range userIds {
+		recipients = append(recipients, &model.AnnouncementRecipient{
+			AnnouncementId: announcement.Id,
+			UserId:         userId,
+			Status:         status,
+			DeliverAt:      deliverAt,
+		})
+	}
+
+	if err := a.Srv.Store.Announcement().SaveRecipients(recipients); err != nil {
+		return nil, err
+	}
+
+	if announcement.ShowBanner || announcement.RequireAcknowledgment {
+		a.publishAnnouncementEvent(model.WEBSOCKET_EVENT_ANNOUNCEMENT_ADDED, announcement.Id, "")
+	}
+
+	if announcement.SendDirectMessage && len(userIds) > 0 {
+		a.Srv.Go(func() {
+			a.deliverAnnouncement(announcement, bot.UserId, userIds)
+		})
+	}
+
+	return announcement, nil
+}
+
+// DeleteAnnouncement stops showing the announcement. The direct messages already sent are left as they are.
+func (a *App) DeleteAnnouncement(announcementId string) *model.AppError {
+	if _, err := a.Srv.Store.Announcement().Get(announcementId); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Announcement().Delete(announcementId, model.GetMillis()); err != nil {
+		return err
+	}
+
+	a.publishAnnouncementEvent(model.WEBSOCKET_EVENT_ANNOUNCEMENT_REMOVED, announcementId, "")
+
+	return nil
+}
+
+// AcknowledgeAnnouncement records that the user read the announcement, which is no longer shown to them.
+func (a *App) AcknowledgeAnnouncement(announcementId, userId string) *model.AppError {
+	if _, err := a.Srv.Store.Announcement().GetRecipient(announcementId, userId); err != nil {
+		if err.StatusCode == http.StatusNotFound {
+			return model.NewAppError("AcknowledgeAnnouncement", "app.announcement.acknowledge.not_recipient.app_error", nil, "announcement_id="+announcementId+", user_id="+userId, http.StatusNotFound)
+		}
+		return err
+	}
+
+	if err := a.Srv.Store.Announcement().Acknowledge(announcementId, userId, model.GetMillis()); err != nil {
+		return err
+	}
+
+	a.publishAnnouncementEvent(model.WEBSOCKET_EVENT_ANNOUNCEMENT_ACKNOWLEDGED, announcementId, userId)
+
+	return nil
+}
+
+// getAnnouncementAudience returns the ids of the active users in the audience of the announcement.
+func (a *App) getAnnouncementAudience(announcement *model.Announcement) ([]string, *model.AppError) {
+	teamIdsByUser := map[string]map[string]bool{}
+	for _, teamId := range announcement.TeamIds {
+		for offset := 0; ; offset += announcementAudiencePerPage {
+			members, err := a.Srv.Store.Team().GetMembers(teamId, offset, announcementAudiencePerPage, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, member := range members {
+				if member.DeleteAt != 0 {
+					continue
+				}
+				if teamIdsByUser[member.UserId] == nil {
+					teamIdsByUser[member.UserId] = map[string]bool{}
+				}
+				teamIdsByUser[member.UserId][teamId] = true
+			}
+
+			if len(members) < announcementAudiencePerPage {
+				break
+			}
+		}
+	}
+
+	groupIdsByUser := map[string]map[string]bool{}
+	for _, groupId := range announcement.GroupIds {
+		users, err := a.Srv.Store.Group().GetMemberUsers(groupId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			if groupIdsByUser[user.Id] == nil {
+				groupIdsByUser[user.Id] = map[string]bool{}
+			}
+			groupIdsByUser[user.Id][groupId] = true
+		}
+	}
+
+	var userIds []string
+	for page := 0; ; page++ {
+		users, err := a.Srv.Store.User().GetAllProfiles(&model.UserGetOptions{Page: page, PerPage: announcementAudiencePerPage})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			if announcement.IncludesUser(user, teamIdsByUser[user.Id], groupIdsByUser[user.Id]) {
+				userIds = append(userIds, user.Id)
+			}
+		}
+
+		if len(users) < announcementAudiencePerPage {
+			return userIds, nil
+		}
+	}
+}
+
+// deliverAnnouncement sends the announcement as a direct message from the bot to each of the users, no faster than
+// DIRECT_MESSAGE_BROADCAST_POSTS_PER_SECOND, recording whether each message was sent.
+func (a *App) deliverAnnouncement(announcement *model.Announcement, botUserId string, userIds []string) {
+	ticker := time.NewTicker(time.Second / DIRECT_MESSAGE_BROADCAST_POSTS_PER_SECOND)
+	defer ticker.Stop()
+
+	for _, userId := range userIds {
+		<-ticker.C
+
+		status := model.ANNOUNCEMENT_RECIPIENT_STATUS_FAILED
+		postId := ""
+		if post, err := a.sendAnnouncementDirectMessage(announcement, botUserId, userId); err != nil {
+			mlog.Warn("Failed to send an announcement direct message", mlog.String("announcement_id", announcement.Id), mlog.String("user_id", userId), mlog.Err(err))
+		} else {
+			status = model.ANNOUNCEMENT_RECIPIENT_STATUS_DELIVERED
+			postId = post.Id
+		}
+
+		if err := a.Srv.Store.Announcement().UpdateRecipientDelivery(announcement.Id, userId, status, postId, model.GetMillis()); err != nil {
+			mlog.Error("Failed to record the delivery of an announcement", mlog.String("announcement_id", announcement.Id), mlog.String("user_id", userId), mlog.Err(err))
+		}
+	}
+}
+
+func (a *App) sendAnnouncementDirectMessage(announcement *model.Announcement, botUserId, userId string) (*model.Post, *model.AppError) {
+	channel, err := a.GetOrCreateDirectChannel(botUserId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    botUserId,
+		Message:   "#### " + announcement.Title + "\n\n" + announcement.Message,
+		Props: model.StringInterface{
+			model.ANNOUNCEMENT_POST_PROP_ID: announcement.Id,
+			"require_acknowledgment":        announcement.RequireAcknowledgment,
+		},
+	}
+
+	return a.CreatePost(post, channel, false)
+}
+
+// publishAnnouncementEvent tells the clients of the user, or of everyone when userId is empty, to refresh the
+// announcements they show.
+func (a *App) publishAnnouncementEvent(event, announcementId, userId string) {
+	message := model.NewWebSocketEvent(event, "", "", userId, nil)
+	message.Add("announcement_id", announcementId)
+	a.Publish(message)
+}
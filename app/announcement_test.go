@@ -0,0 +1,128 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCreateAnnouncement(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	waitForDelivery := func(t *testing.T, announcementId string) *model.AnnouncementReport {
+		t.Helper()
+
+		for i := 0; i < 50; i++ {
+			report, err := th.App.GetAnnouncementReport(announcementId)
+			require.Nil(t, err)
+			if report.Pending == 0 {
+				return report
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		require.Fail(t, "announcement wasn't delivered")
+		return nil
+	}
+
+	t.Run("direct messages to a team", func(t *testing.T) {
+		outsider := th.CreateUser()
+
+		announcement, err := th.App.CreateAnnouncement(&model.Announcement{
+			CreatorId:             th.SystemAdminUser.Id,
+			Title:                 "Maintenance",
+			Message:               "The server will be down tonight.",
+			SendDirectMessage:     true,
+			RequireAcknowledgment: true,
+			TeamIds:               model.StringArray{th.BasicTeam.Id},
+		})
+		require.Nil(t, err)
+
+		report := waitForDelivery(t, announcement.Id)
+		assert.Equal(t, report.Total, report.Delivered)
+		assert.Zero(t, report.Failed)
+
+		recipient, err := th.App.Srv.Store.Announcement().GetRecipient(announcement.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+		require.NotEmpty(t, recipient.PostId)
+
+		post, err := th.App.GetSinglePost(recipient.PostId)
+		require.Nil(t, err)
+		assert.Contains(t, post.Message, "The server will be down tonight.")
+		assert.Equal(t, announcement.Id, post.Props[model.ANNOUNCEMENT_POST_PROP_ID])
+
+		_, err = th.App.Srv.Store.Announcement().GetRecipient(announcement.Id, outsider.Id)
+		require.NotNil(t, err, "users outside the team shouldn't receive the announcement")
+
+		pending, err := th.App.GetPendingAnnouncementsForUser(th.BasicUser.Id)
+		require.Nil(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, announcement.Id, pending[0].Id)
+
+		require.Nil(t, th.App.AcknowledgeAnnouncement(announcement.Id, th.BasicUser.Id))
+
+		pending, err = th.App.GetPendingAnnouncementsForUser(th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.Empty(t, pending)
+
+		report, err = th.App.GetAnnouncementReport(announcement.Id)
+		require.Nil(t, err)
+		assert.Equal(t, int64(1), report.Acknowledged)
+
+		err = th.App.AcknowledgeAnnouncement(announcement.Id, outsider.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("banner to a group", func(t *testing.T) {
+		group := th.CreateGroup()
+		_, err := th.App.UpsertGroupMember(group.Id, th.BasicUser2.Id)
+		require.Nil(t, err)
+
+		announcement, err := th.App.CreateAnnouncement(&model.Announcement{
+			CreatorId:  th.SystemAdminUser.Id,
+			Title:      "Welcome",
+			Message:    "Welcome to the group.",
+			ShowBanner: true,
+			GroupIds:   model.StringArray{group.Id},
+		})
+		require.Nil(t, err)
+
+		recipients, err := th.App.GetAnnouncementRecipients(announcement.Id, 0, 100)
+		require.Nil(t, err)
+		require.Len(t, recipients, 1)
+		assert.Equal(t, th.BasicUser2.Id, recipients[0].UserId)
+		assert.Equal(t, model.ANNOUNCEMENT_RECIPIENT_STATUS_DELIVERED, recipients[0].Status)
+		assert.Empty(t, recipients[0].PostId)
+
+		pending, err := th.App.GetPendingAnnouncementsForUser(th.BasicUser2.Id)
+		require.Nil(t, err)
+		require.Len(t, pending, 1)
+
+		require.Nil(t, th.App.DeleteAnnouncement(announcement.Id))
+
+		pending, err = th.App.GetPendingAnnouncementsForUser(th.BasicUser2.Id)
+		require.Nil(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		_, err := th.App.CreateAnnouncement(&model.Announcement{
+			CreatorId:  th.SystemAdminUser.Id,
+			Title:      "Title",
+			Message:    "Message",
+			ShowBanner: true,
+			TeamIds:    model.StringArray{model.NewId()},
+		})
+		require.NotNil(t, err)
+	})
+}
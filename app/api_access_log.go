@@ -0,0 +1,39 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/accesslog"
+)
+
+func (a *App) LogApiAccess(entry *model.ApiAccessLogEntry) {
+	if a.Srv.AccessLog == nil {
+		return
+	}
+
+	a.Srv.AccessLog.Log(entry)
+}
+
+func (a *App) GetApiAccessLog(query *model.ApiAccessLogQuery) ([]*model.ApiAccessLogEntry, *model.AppError) {
+	entries, err := accesslog.Query(*a.Config().ApiAccessLogSettings.FileLocation, query)
+	if err != nil {
+		return nil, model.NewAppError("GetApiAccessLog", "app.api_access_log.read.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return entries, nil
+}
+
+// GetApiAccessLogSummary sums up the requests logged between the given times by each user, user access token
+// and webhook, so that admins can tell which of them are behind a load spike.
+func (a *App) GetApiAccessLogSummary(since, until int64) ([]*model.ApiAccessLogSummary, *model.AppError) {
+	entries, err := a.GetApiAccessLog(&model.ApiAccessLogQuery{Since: since, Until: until})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.SummarizeApiAccessLog(entries), nil
+}
@@ -4,6 +4,7 @@
 package app
 
 import (
+	"context"
 	"html/template"
 	"net/http"
 	"strconv"
@@ -33,6 +34,8 @@ type App struct {
 	UserAgent      string
 	AcceptLanguage string
 
+	context context.Context
+
 	AccountMigration einterfaces.AccountMigrationInterface
 	Cluster          einterfaces.ClusterInterface
 	Compliance       einterfaces.ComplianceInterface
@@ -93,10 +96,56 @@ func (s *Server) initJobs() {
 	if jobsPluginsInterface != nil {
 		s.Jobs.Plugins = jobsPluginsInterface(s.FakeApp())
 	}
+	if jobsBulkPreferencesInterface != nil {
+		s.Jobs.BulkPreferences = jobsBulkPreferencesInterface(s.FakeApp())
+	}
+	if jobsChannelTimelineInterface != nil {
+		s.Jobs.ChannelTimeline = jobsChannelTimelineInterface(s.FakeApp())
+	}
+	if jobsBulkUsersInterface != nil {
+		s.Jobs.BulkUsers = jobsBulkUsersInterface(s.FakeApp())
+	}
+	if jobsChannelReadStatsInterface != nil {
+		s.Jobs.ChannelReadStats = jobsChannelReadStatsInterface(s.FakeApp())
+	}
+	if jobsUserDeactivationInterface != nil {
+		s.Jobs.UserDeactivation = jobsUserDeactivationInterface(s.FakeApp())
+	}
+	if jobsRecurringPostsInterface != nil {
+		s.Jobs.RecurringPosts = jobsRecurringPostsInterface(s.FakeApp())
+	}
+	if jobsDailyStatsInterface != nil {
+		s.Jobs.DailyStats = jobsDailyStatsInterface(s.FakeApp())
+	}
+	if jobsPostArchiveInterface != nil {
+		s.Jobs.PostArchive = jobsPostArchiveInterface(s.FakeApp())
+	}
+	if jobsSearchReindexInterface != nil {
+		s.Jobs.SearchReindex = jobsSearchReindexInterface(s.FakeApp())
+	}
+	if jobsUserAnonymizationInterface != nil {
+		s.Jobs.UserAnonymization = jobsUserAnonymizationInterface(s.FakeApp())
+	}
+	s.Jobs.OnJobFailed = func(job *model.Job) {
+		s.FakeApp().notifyJobFailed(job)
+	}
 	s.Jobs.Workers = s.Jobs.InitWorkers()
 	s.Jobs.Schedulers = s.Jobs.InitSchedulers()
 }
 
+// Context returns the context of the request the app is handling, which aborts the store calls made for it once the
+// request is done, or the background context when it isn't handling any.
+func (a *App) Context() context.Context {
+	if a.context == nil {
+		return context.Background()
+	}
+	return a.context
+}
+
+func (a *App) SetContext(ctx context.Context) {
+	a.context = ctx
+}
+
 func (a *App) DiagnosticId() string {
 	return a.Srv.diagnosticId
 }
@@ -7,6 +7,28 @@ import (
 	"github.com/mattermost/mattermost-server/model"
 )
 
+// databaseAuditSink saves audit events to the Audits table, where they are shown in the System Console and
+// returned by the audits API.
+type databaseAuditSink struct {
+	server *Server
+}
+
+func (s *databaseAuditSink) Log(event *model.AuditEvent) error {
+	if s.server.Store == nil {
+		return nil
+	}
+
+	if err := s.server.Store.Audit().Save(event.ToAudit()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *databaseAuditSink) Close() error {
+	return nil
+}
+
 func (a *App) GetAudits(userId string, limit int) (model.Audits, *model.AppError) {
 	return a.Srv.Store.Audit().Get(userId, 0, limit)
 }
@@ -14,3 +36,33 @@ func (a *App) GetAudits(userId string, limit int) (model.Audits, *model.AppError
 func (a *App) GetAuditsPage(userId string, page int, perPage int) (model.Audits, *model.AppError) {
 	return a.Srv.Store.Audit().Get(userId, page*perPage, perPage)
 }
+
+// LogAuditEvent records an action taken in the context of the app. The actor, session, IP address and action
+// default to the ones of the current request when not set on the event.
+func (a *App) LogAuditEvent(event *model.AuditEvent) {
+	if event.ActorId == "" {
+		event.ActorId = a.Session.UserId
+	}
+
+	if event.SessionId == "" {
+		event.SessionId = a.Session.Id
+	}
+
+	if event.IpAddress == "" {
+		event.IpAddress = a.IpAddress
+	}
+
+	if event.Action == "" {
+		event.Action = a.Path
+	}
+
+	if event.Result == "" {
+		event.Result = model.AUDIT_RESULT_SUCCESS
+	}
+
+	if a.Srv.Audit == nil {
+		return
+	}
+
+	a.Srv.Audit.Log(event)
+}
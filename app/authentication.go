@@ -51,7 +51,7 @@ func (a *App) CheckPasswordAndAllCriteria(user *model.User, password string, mfa
 	}
 
 	if err := a.checkUserPassword(user, password); err != nil {
-		if passErr := a.Srv.Store.User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); passErr != nil {
+		if passErr := a.recordFailedLoginAttempt(user); passErr != nil {
 			return passErr
 		}
 		return err
@@ -61,7 +61,7 @@ func (a *App) CheckPasswordAndAllCriteria(user *model.User, password string, mfa
 		// If the mfaToken is not set, we assume the client used this as a pre-flight request to query the server
 		// about the MFA state of the user in question
 		if mfaToken != "" {
-			if passErr := a.Srv.Store.User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); passErr != nil {
+			if passErr := a.recordFailedLoginAttempt(user); passErr != nil {
 				return passErr
 			}
 		}
@@ -81,12 +81,12 @@ func (a *App) CheckPasswordAndAllCriteria(user *model.User, password string, mfa
 
 // This to be used for places we check the users password when they are already logged in
 func (a *App) DoubleCheckPassword(user *model.User, password string) *model.AppError {
-	if err := checkUserLoginAttempts(user, *a.Config().ServiceSettings.MaximumLoginAttempts); err != nil {
+	if err := a.checkUserLoginAttempts(user); err != nil {
 		return err
 	}
 
 	if err := a.checkUserPassword(user, password); err != nil {
-		if passErr := a.Srv.Store.User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); passErr != nil {
+		if passErr := a.recordFailedLoginAttempt(user); passErr != nil {
 			return passErr
 		}
 		return err
@@ -152,7 +152,7 @@ func (a *App) CheckUserPreflightAuthenticationCriteria(user *model.User, mfaToke
 		return err
 	}
 
-	if err := checkUserLoginAttempts(user, *a.Config().ServiceSettings.MaximumLoginAttempts); err != nil {
+	if err := a.checkUserLoginAttempts(user); err != nil {
 		return err
 	}
 
@@ -172,6 +172,13 @@ func (a *App) CheckUserMfa(user *model.User, token string) *model.AppError {
 		return nil
 	}
 
+	// Users who lost their authenticator can use one of their recovery codes instead
+	if used, err := a.useMfaRecoveryCode(user, token); err != nil {
+		return err
+	} else if used {
+		return nil
+	}
+
 	mfaService := mfa.New(a, a.Srv.Store)
 	ok, err := mfaService.ValidateToken(user.MfaSecret, token)
 	if err != nil {
@@ -185,14 +192,6 @@ func (a *App) CheckUserMfa(user *model.User, token string) *model.AppError {
 	return nil
 }
 
-func checkUserLoginAttempts(user *model.User, max int) *model.AppError {
-	if user.FailedAttempts >= max {
-		return model.NewAppError("checkUserLoginAttempts", "api.user.check_user_login_attempts.too_many.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
-	}
-
-	return nil
-}
-
 func checkUserNotDisabled(user *model.User) *model.AppError {
 	if user.DeleteAt > 0 {
 		return model.NewAppError("Login", "api.user.login.inactive.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
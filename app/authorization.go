@@ -54,7 +54,7 @@ func (a *App) SessionHasPermissionToChannel(session model.Session, channelId str
 
 	channel, err := a.GetChannel(channelId)
 	if err == nil && channel.TeamId != "" {
-		return a.SessionHasPermissionToTeam(session, channel.TeamId, permission)
+		return a.SessionHasPermissionToChannelTeams(session, channel, permission)
 	}
 
 	if err != nil && err.StatusCode == http.StatusNotFound {
@@ -64,6 +64,27 @@ func (a *App) SessionHasPermissionToChannel(session model.Session, channelId str
 	return a.SessionHasPermissionTo(session, permission)
 }
 
+// SessionHasPermissionToChannelTeams returns whether the session has the permission on the team of the channel, or on
+// any of the teams it is bound to.
+func (a *App) SessionHasPermissionToChannelTeams(session model.Session, channel *model.Channel, permission *model.Permission) bool {
+	if a.SessionHasPermissionToTeam(session, channel.TeamId, permission) {
+		return true
+	}
+
+	bindings, err := a.Srv.Store.ChannelTeamBinding().GetForChannel(channel.Id)
+	if err != nil {
+		return false
+	}
+
+	for _, binding := range bindings {
+		if a.SessionHasPermissionToTeam(session, binding.TeamId, permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (a *App) SessionHasPermissionToChannelByPost(session model.Session, postId string, permission *model.Permission) bool {
 	if channelMember, err := a.Srv.Store.Channel().GetMemberForPost(postId, session.UserId); err == nil {
 
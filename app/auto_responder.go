@@ -8,19 +8,43 @@ import (
 	"github.com/mattermost/mattermost-server/model"
 )
 
-func (a *App) SendAutoResponseIfNecessary(channel *model.Channel, sender *model.User) (bool, *model.AppError) {
-	if channel.Type != model.CHANNEL_DIRECT {
+const (
+	AUTO_RESPONDER_CACHE_SIZE = 10000
+)
+
+// SendAutoResponseIfNecessary responds to a post on behalf of the users it's sent to who are out of office, which is
+// the other user of a direct message channel, or the users mentioned in any other channel, given their ids.
+func (a *App) SendAutoResponseIfNecessary(channel *model.Channel, sender *model.User, post *model.Post, mentionedUserIds []string) (bool, *model.AppError) {
+	// Auto-responses and other system messages are never responded to, so that two users who are both out of office
+	// don't respond to each other forever.
+	if post.IsSystemMessage() {
 		return false, nil
 	}
 
+	if channel.Type != model.CHANNEL_DIRECT {
+		return a.sendMentionAutoResponses(channel, sender, post, mentionedUserIds)
+	}
+
 	receiverId := channel.GetOtherUserIdForDM(sender.Id)
+	if receiverId == sender.Id {
+		return false, nil
+	}
 
 	receiver, err := a.GetUser(receiverId)
 	if err != nil {
 		return false, err
 	}
 
-	return a.SendAutoResponse(channel, receiver)
+	if !a.startAutoResponderCooldown(receiver, sender) {
+		return false, nil
+	}
+
+	sent, err := a.SendAutoResponse(channel, receiver)
+	if !sent {
+		a.Srv.autoResponderCache.Remove(receiver.Id + sender.Id)
+	}
+
+	return sent, err
 }
 
 func (a *App) SendAutoResponse(channel *model.Channel, receiver *model.User) (bool, *model.AppError) {
@@ -28,10 +52,9 @@ func (a *App) SendAutoResponse(channel *model.Channel, receiver *model.User) (bo
 		return false, nil
 	}
 
-	active := receiver.NotifyProps[model.AUTO_RESPONDER_ACTIVE_NOTIFY_PROP] == "true"
 	message := receiver.NotifyProps[model.AUTO_RESPONDER_MESSAGE_NOTIFY_PROP]
 
-	if !active || message == "" {
+	if !receiver.IsAutoResponderActiveAt(model.GetMillis()) || message == "" {
 		return false, nil
 	}
 
@@ -49,9 +72,68 @@ func (a *App) SendAutoResponse(channel *model.Channel, receiver *model.User) (bo
 		return false, err
 	}
 
+	// The response is posted as the receiver, so it's counted as read by them. The channel isn't marked as viewed
+	// since they haven't seen the post being responded to.
+	if err := a.Srv.Store.Channel().IncrementMsgCount(channel.Id, receiver.Id); err != nil {
+		mlog.Warn("Failed to count an auto-response as read by its author", mlog.String("user_id", receiver.Id), mlog.String("channel_id", channel.Id), mlog.Err(err))
+	}
+
 	return true, nil
 }
 
+// sendMentionAutoResponses responds to a post mentioning users who are out of office with an ephemeral post only
+// seen by its sender, so that the channel isn't flooded with responses.
+func (a *App) sendMentionAutoResponses(channel *model.Channel, sender *model.User, post *model.Post, mentionedUserIds []string) (bool, *model.AppError) {
+	now := model.GetMillis()
+	sent := false
+
+	rootId := post.RootId
+	if rootId == "" {
+		rootId = post.Id
+	}
+
+	for _, receiverId := range mentionedUserIds {
+		if receiverId == sender.Id {
+			continue
+		}
+
+		receiver, err := a.GetUser(receiverId)
+		if err != nil {
+			return sent, err
+		}
+
+		message := receiver.NotifyProps[model.AUTO_RESPONDER_MENTION_MESSAGE_NOTIFY_PROP]
+		if message == "" || !receiver.IsAutoResponderActiveAt(now) || !a.startAutoResponderCooldown(receiver, sender) {
+			continue
+		}
+
+		a.SendEphemeralPost(sender.Id, &model.Post{
+			ChannelId: channel.Id,
+			RootId:    rootId,
+			ParentId:  rootId,
+			Message:   message,
+			Type:      model.POST_AUTO_RESPONDER,
+			UserId:    receiver.Id,
+			CreateAt:  post.CreateAt + 1,
+		})
+		sent = true
+	}
+
+	return sent, nil
+}
+
+// startAutoResponderCooldown returns whether the receiver's auto-responder may respond to the sender, in which case it
+// won't respond to them again until its cooldown has passed.
+func (a *App) startAutoResponderCooldown(receiver *model.User, sender *model.User) bool {
+	cooldown := receiver.AutoResponderCooldown()
+	if cooldown == 0 {
+		return true
+	}
+
+	_, loaded := a.Srv.autoResponderCache.GetOrAdd(receiver.Id+sender.Id, true, cooldown)
+	return !loaded
+}
+
 func (a *App) SetAutoResponderStatus(user *model.User, oldNotifyProps model.StringMap) {
 	active := user.NotifyProps[model.AUTO_RESPONDER_ACTIVE_NOTIFY_PROP] == "true"
 	oldActive := oldNotifyProps[model.AUTO_RESPONDER_ACTIVE_NOTIFY_PROP] == "true"
@@ -4,6 +4,7 @@
 package app
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/mattermost/mattermost-server/model"
@@ -97,7 +98,7 @@ func TestSendAutoResponseIfNecessary(t *testing.T) {
 
 		channel := th.CreateDmChannel(receiver)
 
-		sent, err := th.App.SendAutoResponseIfNecessary(channel, th.BasicUser)
+		sent, err := th.App.SendAutoResponseIfNecessary(channel, th.BasicUser, &model.Post{ChannelId: channel.Id, UserId: th.BasicUser.Id, Message: "Hello"}, nil)
 
 		assert.Nil(t, err)
 		assert.True(t, sent)
@@ -120,7 +121,7 @@ func TestSendAutoResponseIfNecessary(t *testing.T) {
 
 		channel := th.CreateDmChannel(receiver)
 
-		sent, err := th.App.SendAutoResponseIfNecessary(channel, th.BasicUser)
+		sent, err := th.App.SendAutoResponseIfNecessary(channel, th.BasicUser, &model.Post{ChannelId: channel.Id, UserId: th.BasicUser.Id, Message: "Hello"}, nil)
 
 		assert.Nil(t, err)
 		assert.False(t, sent)
@@ -130,11 +131,122 @@ func TestSendAutoResponseIfNecessary(t *testing.T) {
 		th := Setup(t).InitBasic()
 		defer th.TearDown()
 
-		sent, err := th.App.SendAutoResponseIfNecessary(th.BasicChannel, th.BasicUser)
+		sent, err := th.App.SendAutoResponseIfNecessary(th.BasicChannel, th.BasicUser, &model.Post{ChannelId: th.BasicChannel.Id, UserId: th.BasicUser.Id, Message: "Hello"}, nil)
 
 		assert.Nil(t, err)
 		assert.False(t, sent)
 	})
+
+	t.Run("should not send auto response outside of its dates", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		receiver := th.CreateUser()
+
+		patch := &model.UserPatch{
+			NotifyProps: map[string]string{
+				"auto_responder_active":   "true",
+				"auto_responder_message":  "Hello, I'm on vacation next week.",
+				"auto_responder_start_at": strconv.FormatInt(model.GetMillis()+60*60*1000, 10),
+			},
+		}
+		receiver, err := th.App.PatchUser(receiver.Id, patch, true)
+		require.Nil(t, err)
+
+		channel := th.CreateDmChannel(receiver)
+
+		sent, err := th.App.SendAutoResponseIfNecessary(channel, th.BasicUser, &model.Post{ChannelId: channel.Id, UserId: th.BasicUser.Id, Message: "Hello"}, nil)
+
+		assert.Nil(t, err)
+		assert.False(t, sent)
+	})
+
+	t.Run("should not send auto response to the same sender during its cooldown", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		receiver := th.CreateUser()
+
+		patch := &model.UserPatch{
+			NotifyProps: map[string]string{
+				"auto_responder_active":   "true",
+				"auto_responder_message":  "Hello, I'm unavailable today.",
+				"auto_responder_cooldown": "60",
+			},
+		}
+		receiver, err := th.App.PatchUser(receiver.Id, patch, true)
+		require.Nil(t, err)
+
+		channel := th.CreateDmChannel(receiver)
+		post := &model.Post{ChannelId: channel.Id, UserId: th.BasicUser.Id, Message: "Hello"}
+
+		sent, err := th.App.SendAutoResponseIfNecessary(channel, th.BasicUser, post, nil)
+		assert.Nil(t, err)
+		assert.True(t, sent)
+
+		sent, err = th.App.SendAutoResponseIfNecessary(channel, th.BasicUser, post, nil)
+		assert.Nil(t, err)
+		assert.False(t, sent)
+
+		// The auto-response is counted as read by its author
+		member, err := th.App.GetChannelMember(channel.Id, receiver.Id)
+		require.Nil(t, err)
+		updatedChannel, err := th.App.GetChannel(channel.Id)
+		require.Nil(t, err)
+		assert.Equal(t, updatedChannel.TotalMsgCount, member.MsgCount)
+	})
+
+	t.Run("should not respond to auto responses", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		receiver := th.CreateUser()
+
+		patch := &model.UserPatch{
+			NotifyProps: map[string]string{
+				"auto_responder_active":  "true",
+				"auto_responder_message": "Hello, I'm unavailable today.",
+			},
+		}
+		receiver, err := th.App.PatchUser(receiver.Id, patch, true)
+		require.Nil(t, err)
+
+		channel := th.CreateDmChannel(receiver)
+
+		sent, err := th.App.SendAutoResponseIfNecessary(channel, th.BasicUser, &model.Post{ChannelId: channel.Id, UserId: th.BasicUser.Id, Message: "Hello", Type: model.POST_AUTO_RESPONDER}, nil)
+
+		assert.Nil(t, err)
+		assert.False(t, sent)
+	})
+
+	t.Run("should send the mention message to mentions in channels", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		patch := &model.UserPatch{
+			NotifyProps: map[string]string{
+				"auto_responder_active":          "true",
+				"auto_responder_message":         "Hello, I'm unavailable today.",
+				"auto_responder_mention_message": "I'm out today, ask in ~town-square instead.",
+			},
+		}
+		_, err := th.App.PatchUser(th.BasicUser2.Id, patch, true)
+		require.Nil(t, err)
+
+		post := &model.Post{Id: model.NewId(), ChannelId: th.BasicChannel.Id, UserId: th.BasicUser.Id, Message: "@" + th.BasicUser2.Username + " can you look?"}
+
+		sent, err := th.App.SendAutoResponseIfNecessary(th.BasicChannel, th.BasicUser, post, []string{th.BasicUser2.Id})
+		assert.Nil(t, err)
+		assert.True(t, sent)
+
+		delete(patch.NotifyProps, "auto_responder_mention_message")
+		_, err = th.App.PatchUser(th.BasicUser2.Id, patch, true)
+		require.Nil(t, err)
+
+		sent, err = th.App.SendAutoResponseIfNecessary(th.BasicChannel, th.BasicUser, post, []string{th.BasicUser2.Id})
+		assert.Nil(t, err)
+		assert.False(t, sent)
+	})
 }
 
 func TestSendAutoResponseSuccess(t *testing.T) {
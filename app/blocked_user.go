@@ -0,0 +1,95 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	BLOCKED_USERS_CACHE_SIZE = 10000
+	BLOCKED_USERS_CACHE_SEC  = 60
+)
+
+// getUsersBlocking returns the users who've blocked a user, mapped to whether they reject or hide the user's direct
+// messages. They're cached for a short while, so blocks made on other servers of a cluster apply within
+// BLOCKED_USERS_CACHE_SEC.
+func (a *App) getUsersBlocking(userId string) (map[string]string, *model.AppError) {
+	if cached, ok := a.Srv.blockedUsersCache.Get(userId); ok {
+		return cached.(map[string]string), nil
+	}
+
+	preferences, err := a.Srv.Store.Preference().GetCategoryAndName(model.PREFERENCE_CATEGORY_BLOCKED_USER, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers := make(map[string]string, len(preferences))
+	for _, preference := range preferences {
+		blockers[preference.UserId] = preference.Value
+	}
+
+	a.Srv.blockedUsersCache.AddWithExpiresInSecs(userId, blockers, BLOCKED_USERS_CACHE_SEC)
+
+	return blockers, nil
+}
+
+// invalidateBlockedUsers drops the cached blockers of the users blocked or unblocked by the given preferences.
+func (a *App) invalidateBlockedUsers(preferences model.Preferences) {
+	for _, preference := range preferences {
+		if preference.Category == model.PREFERENCE_CATEGORY_BLOCKED_USER {
+			a.Srv.blockedUsersCache.Remove(preference.Name)
+		}
+	}
+}
+
+// checkBlockedDirectMessage fails if a post is a direct message to a user who rejects the messages of its author.
+func (a *App) checkBlockedDirectMessage(post *model.Post, channel *model.Channel) *model.AppError {
+	if channel.Type != model.CHANNEL_DIRECT || post.IsSystemMessage() {
+		return nil
+	}
+
+	blockers, err := a.getUsersBlocking(post.UserId)
+	if err != nil {
+		return err
+	}
+
+	if blockers[channel.GetOtherUserIdForDM(post.UserId)] == model.PREFERENCE_BLOCKED_USER_REJECT {
+		return model.NewAppError("createPost", "api.post.create_post.blocked.app_error", nil, "", http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// addBlockedUsersProp lists the users who've blocked the author of a post in its props, so that their clients
+// collapse it. The post is expected to be a copy made for the client.
+func (a *App) addBlockedUsersProp(post *model.Post) {
+	blockers, err := a.getUsersBlocking(post.UserId)
+	if err != nil {
+		mlog.Warn("Failed to get the users blocking the author of a post", mlog.String("post_id", post.Id), mlog.Err(err))
+		return
+	}
+
+	if len(blockers) == 0 {
+		return
+	}
+
+	blockerIds := make([]string, 0, len(blockers))
+	for blockerId := range blockers {
+		blockerIds = append(blockerIds, blockerId)
+	}
+	sort.Strings(blockerIds)
+
+	// The props are copied since the copy of the post shares them with the original.
+	props := make(model.StringInterface, len(post.Props)+1)
+	for key, value := range post.Props {
+		props[key] = value
+	}
+	props[model.POST_PROPS_BLOCKED_USERS] = blockerIds
+	post.Props = props
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockedUsers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	blockUser := func(blocker, blocked *model.User, value string) {
+		err := th.App.UpdatePreferences(blocker.Id, model.Preferences{{
+			UserId:   blocker.Id,
+			Category: model.PREFERENCE_CATEGORY_BLOCKED_USER,
+			Name:     blocked.Id,
+			Value:    value,
+		}})
+		require.Nil(t, err)
+	}
+
+	dm, err := th.App.GetOrCreateDirectChannel(th.BasicUser.Id, th.BasicUser2.Id)
+	require.Nil(t, err)
+
+	t.Run("rejects direct messages", func(t *testing.T) {
+		blockUser(th.BasicUser2, th.BasicUser, model.PREFERENCE_BLOCKED_USER_REJECT)
+
+		_, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: dm.Id,
+			Message:   "hello",
+		}, dm, false)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.post.create_post.blocked.app_error", err.Id)
+
+		// The blocker can still message the blocked user
+		_, err = th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser2.Id,
+			ChannelId: dm.Id,
+			Message:   "hello",
+		}, dm, false)
+		require.Nil(t, err)
+	})
+
+	t.Run("hides direct messages", func(t *testing.T) {
+		blockUser(th.BasicUser2, th.BasicUser, model.PREFERENCE_BLOCKED_USER_HIDE)
+
+		post, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: dm.Id,
+			Message:   "hello",
+		}, dm, false)
+		require.Nil(t, err)
+		assert.Equal(t, []string{th.BasicUser2.Id}, post.Props[model.POST_PROPS_BLOCKED_USERS])
+	})
+
+	t.Run("collapses posts in shared channels", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+
+		clientPost := th.App.PreparePostForClient(post, false, false)
+		assert.Equal(t, []string{th.BasicUser2.Id}, clientPost.Props[model.POST_PROPS_BLOCKED_USERS])
+		assert.Nil(t, post.Props[model.POST_PROPS_BLOCKED_USERS], "shouldn't have changed the original post")
+
+		err := th.App.DeletePreferences(th.BasicUser2.Id, model.Preferences{{
+			UserId:   th.BasicUser2.Id,
+			Category: model.PREFERENCE_CATEGORY_BLOCKED_USER,
+			Name:     th.BasicUser.Id,
+		}})
+		require.Nil(t, err)
+
+		clientPost = th.App.PreparePostForClient(post, false, false)
+		assert.Nil(t, clientPost.Props[model.POST_PROPS_BLOCKED_USERS])
+	})
+
+	t.Run("suppresses notifications", func(t *testing.T) {
+		blockUser(th.BasicUser2, th.BasicUser, model.PREFERENCE_BLOCKED_USER_HIDE)
+
+		post := &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   "@" + th.BasicUser2.Username,
+		}
+		post, err := th.App.CreatePost(post, th.BasicChannel, false)
+		require.Nil(t, err)
+
+		mentions, err2 := th.App.SendNotifications(post, th.BasicTeam, th.BasicChannel, th.BasicUser, nil)
+		require.Nil(t, err2)
+		assert.NotContains(t, mentions, th.BasicUser2.Id)
+	})
+}
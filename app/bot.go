@@ -89,6 +89,20 @@ func (a *App) GetBot(botUserId string, includeDeleted bool) (*model.Bot, *model.
 	return a.Srv.Store.Bot().Get(botUserId, includeDeleted)
 }
 
+// GetSystemBot returns the bot the server sends its own notifications as, creating it the first time.
+func (a *App) GetSystemBot() (*model.Bot, *model.AppError) {
+	if user, err := a.Srv.Store.User().GetByUsername(model.BOT_SYSTEM_BOT_USERNAME); err == nil {
+		return a.GetBot(user.Id, true)
+	}
+
+	return a.CreateBot(&model.Bot{
+		Username:    model.BOT_SYSTEM_BOT_USERNAME,
+		DisplayName: "System",
+		Description: "Sends the notifications of the server.",
+		OwnerId:     model.BOT_SYSTEM_BOT_OWNER_ID,
+	})
+}
+
 // GetBots returns the requested page of bots.
 func (a *App) GetBots(options *model.BotGetOptions) (model.BotList, *model.AppError) {
 	return a.Srv.Store.Bot().GetAll(options)
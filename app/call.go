@@ -0,0 +1,197 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+// GetCall returns a call along with its participants.
+func (a *App) GetCall(callId string) (*model.Call, *model.AppError) {
+	call, err := a.Srv.Store.Call().Get(callId)
+	if err != nil {
+		return nil, err
+	}
+
+	if call.Participants, err = a.Srv.Store.Call().GetParticipants(call.Id); err != nil {
+		return nil, err
+	}
+
+	return call, nil
+}
+
+// GetActiveCallsForChannel returns the calls of a channel that haven't ended, along with their participants.
+func (a *App) GetActiveCallsForChannel(channelId string) ([]*model.Call, *model.AppError) {
+	calls, err := a.Srv.Store.Call().GetActiveForChannel(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, call := range calls {
+		if call.Participants, err = a.Srv.Store.Call().GetParticipants(call.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	return calls, nil
+}
+
+// StartCall starts a call in a channel, with its creator as its first participant, and posts it to the channel.
+func (a *App) StartCall(call *model.Call) (*model.Call, *model.AppError) {
+	channel, err := a.GetChannel(call.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if channel.DeleteAt != 0 {
+		return nil, model.NewAppError("StartCall", "api.post.create_post.can_not_post_to_deleted.error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	creator, err := a.GetUser(call.CreatorId)
+	if err != nil {
+		return nil, err
+	}
+
+	call.EndAt = 0
+	call, err = a.Srv.Store.Call().Save(call)
+	if err != nil {
+		return nil, err
+	}
+
+	participant := &model.CallParticipant{CallId: call.Id, UserId: creator.Id, JoinAt: call.StartAt}
+	if err = a.Srv.Store.Call().SaveParticipant(participant); err != nil {
+		return nil, err
+	}
+	call.Participants = []*model.CallParticipant{participant}
+
+	T := utils.GetUserTranslations(creator.Locale)
+	message := T("app.call.post.message")
+	if call.Title != "" {
+		message = T("app.call.post.message_with_title", map[string]interface{}{"Title": call.Title})
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    creator.Id,
+		RootId:    call.RootId,
+		ParentId:  call.RootId,
+		Message:   message,
+	}
+	call.SetPostProps(post)
+
+	post, err = a.CreatePost(post, channel, false)
+	if err != nil {
+		return nil, err
+	}
+
+	call.PostId = post.Id
+	return a.Srv.Store.Call().Update(call)
+}
+
+// JoinCall adds a user to the participants of a call, or brings them back to it if they'd left.
+func (a *App) JoinCall(call *model.Call, userId string) (*model.Call, *model.AppError) {
+	if call.IsEnded() {
+		return nil, model.NewAppError("JoinCall", "app.call.ended.app_error", nil, "id="+call.Id, http.StatusBadRequest)
+	}
+
+	participant := &model.CallParticipant{CallId: call.Id, UserId: userId, JoinAt: model.GetMillis()}
+	if err := a.Srv.Store.Call().SaveParticipant(participant); err != nil {
+		return nil, err
+	}
+
+	return a.refreshCall(call)
+}
+
+func (a *App) LeaveCall(call *model.Call, userId string) (*model.Call, *model.AppError) {
+	if call.IsEnded() {
+		return nil, model.NewAppError("LeaveCall", "app.call.ended.app_error", nil, "id="+call.Id, http.StatusBadRequest)
+	}
+
+	participants, err := a.Srv.Store.Call().GetParticipants(call.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, participant := range participants {
+		if participant.UserId == userId && participant.LeaveAt == 0 {
+			participant.LeaveAt = model.GetMillis()
+			if err = a.Srv.Store.Call().SaveParticipant(participant); err != nil {
+				return nil, err
+			}
+
+			return a.refreshCall(call)
+		}
+	}
+
+	return nil, model.NewAppError("LeaveCall", "app.call.not_participant.app_error", nil, "id="+call.Id+", user_id="+userId, http.StatusBadRequest)
+}
+
+// EndCall ends a call, along with the participation of everyone still in it.
+func (a *App) EndCall(call *model.Call) (*model.Call, *model.AppError) {
+	if call.IsEnded() {
+		return nil, model.NewAppError("EndCall", "app.call.ended.app_error", nil, "id="+call.Id, http.StatusBadRequest)
+	}
+
+	participants, err := a.Srv.Store.Call().GetParticipants(call.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	call.EndAt = model.GetMillis()
+	for _, participant := range participants {
+		if participant.LeaveAt == 0 {
+			participant.LeaveAt = call.EndAt
+			if err = a.Srv.Store.Call().SaveParticipant(participant); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if call, err = a.Srv.Store.Call().Update(call); err != nil {
+		return nil, err
+	}
+
+	return a.refreshCall(call)
+}
+
+// refreshCall reloads the participants of a call and updates the post showing it to match.
+func (a *App) refreshCall(call *model.Call) (*model.Call, *model.AppError) {
+	var err *model.AppError
+	if call.Participants, err = a.Srv.Store.Call().GetParticipants(call.Id); err != nil {
+		return nil, err
+	}
+
+	a.updateCallPost(call)
+
+	return call, nil
+}
+
+func (a *App) updateCallPost(call *model.Call) {
+	if call.PostId == "" {
+		return
+	}
+
+	post, err := a.GetSinglePost(call.PostId)
+	if err != nil {
+		mlog.Warn("Unable to get the post of a call", mlog.String("call_id", call.Id), mlog.Err(err))
+		return
+	}
+
+	// Clone shares the props, which are replaced below
+	post = post.Clone()
+	props := model.StringInterface{}
+	for key, value := range post.Props {
+		props[key] = value
+	}
+	post.Props = props
+	call.SetPostProps(post)
+
+	if _, err = a.UpdatePost(post, false); err != nil {
+		mlog.Warn("Unable to update the post of a call", mlog.String("call_id", call.Id), mlog.Err(err))
+	}
+}
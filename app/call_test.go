@@ -0,0 +1,73 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCalls(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	call, err := th.App.StartCall(&model.Call{
+		ChannelId: th.BasicChannel.Id,
+		CreatorId: th.BasicUser.Id,
+		Provider:  "com.example.video",
+		Title:     "Standup",
+		JoinUrl:   "https://video.example.com/standup",
+	})
+	require.Nil(t, err)
+	require.NotEmpty(t, call.PostId)
+	assert.Equal(t, []string{th.BasicUser.Id}, call.ParticipantIds())
+
+	post, err := th.App.GetSinglePost(call.PostId)
+	require.Nil(t, err)
+	assert.Equal(t, model.POST_CALL, post.Type)
+	assert.Equal(t, "Started a call: Standup", post.Message)
+	assert.Equal(t, call.Id, post.Props[model.POST_PROPS_CALL_ID])
+
+	calls, err := th.App.GetActiveCallsForChannel(th.BasicChannel.Id)
+	require.Nil(t, err)
+	require.Len(t, calls, 1)
+	assert.Equal(t, call.Id, calls[0].Id)
+
+	t.Run("tracks participants on the post", func(t *testing.T) {
+		call, err = th.App.JoinCall(call, th.BasicUser2.Id)
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{th.BasicUser.Id, th.BasicUser2.Id}, call.ParticipantIds())
+
+		call, err = th.App.LeaveCall(call, th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.Equal(t, []string{th.BasicUser2.Id}, call.ParticipantIds())
+
+		_, err = th.App.LeaveCall(call, th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.call.not_participant.app_error", err.Id)
+
+		post, err = th.App.GetSinglePost(call.PostId)
+		require.Nil(t, err)
+		assert.Equal(t, []interface{}{th.BasicUser2.Id}, post.Props[model.POST_PROPS_CALL_PARTICIPANT_IDS])
+	})
+
+	t.Run("ends the call", func(t *testing.T) {
+		call, err = th.App.EndCall(call)
+		require.Nil(t, err)
+		assert.True(t, call.IsEnded())
+		assert.Empty(t, call.ParticipantIds())
+
+		_, err = th.App.JoinCall(call, th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.call.ended.app_error", err.Id)
+
+		calls, err = th.App.GetActiveCallsForChannel(th.BasicChannel.Id)
+		require.Nil(t, err)
+		assert.Empty(t, calls)
+	})
+}
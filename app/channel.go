@@ -223,6 +223,10 @@ func (a *App) RenameChannel(channel *model.Channel, newChannelName string, newDi
 }
 
 func (a *App) CreateChannel(channel *model.Channel, addMember bool) (*model.Channel, *model.AppError) {
+	if err := a.checkChannelNameAvailableInChannelTeams(channel); err != nil {
+		return nil, err
+	}
+
 	sc, err := a.Srv.Store.Channel().Save(channel, *a.Config().TeamSettings.MaxChannelsPerTeam)
 	if err != nil {
 		return nil, err
@@ -507,6 +511,10 @@ func (a *App) GetGroupChannel(userIds []string) (*model.Channel, *model.AppError
 }
 
 func (a *App) UpdateChannel(channel *model.Channel) (*model.Channel, *model.AppError) {
+	if err := a.checkChannelNameAvailableInChannelTeams(channel); err != nil {
+		return nil, err
+	}
+
 	_, err := a.Srv.Store.Channel().Update(channel)
 	if err != nil {
 		return nil, err
@@ -785,6 +793,10 @@ func (a *App) UpdateChannelMemberNotifyProps(data map[string]string, channelId s
 		member.NotifyProps[model.IGNORE_CHANNEL_MENTIONS_NOTIFY_PROP] = ignoreChannelMentions
 	}
 
+	if reactions, exists := data[model.REACTIONS_NOTIFY_PROP]; exists {
+		member.NotifyProps[model.REACTIONS_NOTIFY_PROP] = reactions
+	}
+
 	member, err = a.Srv.Store.Channel().UpdateMember(member)
 	if err != nil {
 		return nil, err
@@ -938,11 +950,57 @@ func (a *App) addUserToChannel(user *model.User, channel *model.Channel, teamMem
 	a.InvalidateCacheForUser(user.Id)
 	a.InvalidateCacheForChannelMembers(channel.Id)
 
+	a.Srv.Go(func() {
+		if err := a.handleChannelMemberWebhookEvents(user, channel); err != nil {
+			mlog.Error("Failed to handle outgoing webhook events for channel member", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		}
+	})
+
+	if channel.WelcomeMessage != "" && !user.IsBot {
+		a.Srv.Go(func() {
+			if err := a.sendChannelWelcomeMessage(user, channel); err != nil {
+				mlog.Error("Failed to send the channel welcome message", mlog.String("channel_id", channel.Id), mlog.String("user_id", user.Id), mlog.Err(err))
+			}
+		})
+	}
+
 	return newMember, nil
 }
 
+// sendChannelWelcomeMessage sends the channel's welcome message to a user added to it from the system bot, either
+// as a direct message or as an ephemeral post in the channel.
+func (a *App) sendChannelWelcomeMessage(user *model.User, channel *model.Channel) *model.AppError {
+	bot, err := a.GetSystemBot()
+	if err != nil {
+		return err
+	}
+
+	message := channel.RenderWelcomeMessage(user)
+
+	if channel.WelcomeMessageType != model.CHANNEL_WELCOME_MESSAGE_TYPE_DIRECT {
+		a.SendEphemeralPost(user.Id, &model.Post{
+			ChannelId: channel.Id,
+			UserId:    bot.UserId,
+			Message:   message,
+		})
+		return nil
+	}
+
+	dm, err := a.GetOrCreateDirectChannel(bot.UserId, user.Id)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.CreatePost(&model.Post{
+		ChannelId: dm.Id,
+		UserId:    bot.UserId,
+		Message:   message,
+	}, dm, false)
+	return err
+}
+
 func (a *App) AddUserToChannel(user *model.User, channel *model.Channel) (*model.ChannelMember, *model.AppError) {
-	teamMember, err := a.Srv.Store.Team().GetMember(channel.TeamId, user.Id)
+	teamMember, err := a.getChannelTeamMember(channel, user.Id)
 
 	if err != nil {
 		return nil, err
@@ -961,6 +1019,8 @@ func (a *App) AddUserToChannel(user *model.User, channel *model.Channel) (*model
 	message.Add("team_id", channel.TeamId)
 	a.Publish(message)
 
+	a.completeOnboardingSteps(user.Id, model.ONBOARDING_STEP_TYPE_JOIN_CHANNEL, channel.Id)
+
 	return newMember, nil
 }
 
@@ -992,6 +1052,10 @@ func (a *App) AddChannelMember(userId string, channel *model.Channel, userReques
 		return nil, err
 	}
 
+	if userRequestorId != "" {
+		a.recordChannelMembershipOverride(channel, userId, userRequestorId, true)
+	}
+
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		a.Srv.Go(func() {
 			pluginContext := a.PluginContext()
@@ -1174,6 +1238,10 @@ func (a *App) GetChannelByName(channelName, teamId string, includeDeleted bool)
 		channel, err = a.Srv.Store.Channel().GetByName(teamId, channelName, false)
 	}
 
+	if err != nil && err.Id == "store.sql_channel.get_by_name.missing.app_error" {
+		channel, err = a.Srv.Store.ChannelTeamBinding().GetChannelByName(teamId, channelName, includeDeleted)
+	}
+
 	if err != nil && err.Id == "store.sql_channel.get_by_name.missing.app_error" {
 		err.StatusCode = http.StatusNotFound
 		return nil, err
@@ -1282,6 +1350,14 @@ func (a *App) GetChannelMembersPage(channelId string, page, perPage int) (*model
 	return a.Srv.Store.Channel().GetMembers(channelId, page*perPage, perPage)
 }
 
+func (a *App) GetChannelMembersPageWithOptions(channelId string, page, perPage int, options *model.ChannelMembersGetOptions) (*model.ChannelMembers, *model.AppError) {
+	return a.Srv.Store.Channel().GetMembersWithOptions(channelId, page*perPage, perPage, options)
+}
+
+func (a *App) GetChannelMemberCountsByRole(channelId string) (*model.ChannelMemberCounts, *model.AppError) {
+	return a.Srv.Store.Channel().GetMemberCountsByRole(channelId)
+}
+
 func (a *App) GetChannelMembersTimezones(channelId string) ([]string, *model.AppError) {
 	membersTimezones, err := a.Srv.Store.Channel().GetChannelMembersTimezones(channelId)
 	if err != nil {
@@ -1387,6 +1463,8 @@ func (a *App) JoinChannel(channel *model.Channel, userId string) *model.AppError
 		return err
 	}
 
+	a.recordChannelMembershipOverride(channel, userId, userId, true)
+
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		a.Srv.Go(func() {
 			pluginContext := a.PluginContext()
@@ -1509,6 +1587,8 @@ func (a *App) LeaveChannel(channelId string, userId string) *model.AppError {
 		return err
 	}
 
+	a.recordChannelMembershipOverride(channel, userId, userId, false)
+
 	if channel.Name == model.DEFAULT_CHANNEL && !*a.Config().ServiceSettings.ExperimentalEnableDefaultChannelLeaveJoinMessages {
 		return nil
 	}
@@ -1643,6 +1723,9 @@ func (a *App) removeUserFromChannel(userIdToRemove string, removerUserId string,
 	if err := a.Srv.Store.ChannelMemberHistory().LogLeaveEvent(userIdToRemove, channel.Id, model.GetMillis()); err != nil {
 		return err
 	}
+	if err := a.Srv.Store.ChannelMemberExpiry().Delete(channel.Id, userIdToRemove); err != nil {
+		return err
+	}
 
 	if isGuest {
 		currentMembers, err := a.GetChannelMembersForUser(channel.TeamId, userIdToRemove)
@@ -1708,6 +1791,10 @@ func (a *App) RemoveUserFromChannel(userIdToRemove string, removerUserId string,
 		return err
 	}
 
+	if removerUserId != "" {
+		a.recordChannelMembershipOverride(channel, userIdToRemove, removerUserId, false)
+	}
+
 	var user *model.User
 	if user, err = a.GetUser(userIdToRemove); err != nil {
 		return err
@@ -1976,6 +2063,42 @@ func (a *App) PermanentDeleteChannel(channel *model.Channel) *model.AppError {
 		return err
 	}
 
+	if err := a.Srv.Store.ChannelReadStat().PermanentDeleteByChannel(channel.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.DailyStat().PermanentDeleteByChannel(channel.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.PendingPost().PermanentDeleteByChannel(channel.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.RecurringPost().PermanentDeleteByChannel(channel.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Poll().PermanentDeleteByChannel(channel.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Call().PermanentDeleteByChannel(channel.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.ChannelMembershipRule().PermanentDeleteByChannel(channel.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.ChannelTeamBinding().PermanentDeleteByChannel(channel.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.PostPinEvent().PermanentDeleteByChannel(channel.Id); err != nil {
+		return err
+	}
+
 	if err := a.Srv.Store.Channel().PermanentDelete(channel.Id); err != nil {
 		return err
 	}
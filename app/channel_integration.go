@@ -0,0 +1,146 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// GetChannelIntegrations lists the bots, webhooks and slash commands active in the channel, for its members to know
+// what automation is at work there and who owns it. Incoming webhook ids are only listed for the users who can manage
+// them, since anyone knowing one can post with it.
+func (a *App) GetChannelIntegrations(session model.Session, channel *model.Channel) ([]*model.ChannelIntegration, *model.AppError) {
+	integrations := []*model.ChannelIntegration{}
+
+	bots, err := a.Srv.Store.Bot().GetAllForChannel(channel.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bot := range bots {
+		displayName := bot.DisplayName
+		if displayName == "" {
+			displayName = bot.Username
+		}
+
+		integrations = append(integrations, &model.ChannelIntegration{
+			Type:        model.INTEGRATION_TYPE_BOT,
+			Id:          bot.UserId,
+			DisplayName: displayName,
+			Description: bot.Description,
+			Username:    bot.Username,
+			OwnerId:     bot.OwnerId,
+			CreateAt:    bot.CreateAt,
+			CanManage:   a.sessionCanManageChannelBot(session, bot),
+		})
+	}
+
+	if *a.Config().ServiceSettings.EnableIncomingWebhooks {
+		hooks, err := a.Srv.Store.Webhook().GetIncomingByChannel(channel.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hook := range hooks {
+			integration := &model.ChannelIntegration{
+				Type:        model.INTEGRATION_TYPE_INCOMING_WEBHOOK,
+				DisplayName: hook.DisplayName,
+				Description: hook.Description,
+				Username:    hook.Username,
+				OwnerId:     hook.UserId,
+				CreateAt:    hook.CreateAt,
+				CanManage:   a.sessionCanManageTeamIntegration(session, hook.TeamId, hook.UserId, model.PERMISSION_MANAGE_INCOMING_WEBHOOKS, model.PERMISSION_MANAGE_OTHERS_INCOMING_WEBHOOKS),
+			}
+			if integration.CanManage {
+				integration.Id = hook.Id
+			}
+
+			integrations = append(integrations, integration)
+		}
+	}
+
+	hooks, err := a.getOutgoingWebhooksForChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hook := range hooks {
+		integrations = append(integrations, &model.ChannelIntegration{
+			Type:         model.INTEGRATION_TYPE_OUTGOING_WEBHOOK,
+			Id:           hook.Id,
+			DisplayName:  hook.DisplayName,
+			Description:  hook.Description,
+			Username:     hook.Username,
+			TriggerWords: hook.TriggerWords,
+			OwnerId:      hook.CreatorId,
+			CreateAt:     hook.CreateAt,
+			CanManage:    a.sessionCanManageTeamIntegration(session, hook.TeamId, hook.CreatorId, model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS, model.PERMISSION_MANAGE_OTHERS_OUTGOING_WEBHOOKS),
+		})
+	}
+
+	if *a.Config().ServiceSettings.EnableCommands && channel.TeamId != "" {
+		commands, err := a.Srv.Store.Command().GetByTeam(channel.TeamId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, command := range commands {
+			if !a.commandAllowedInChannel(command.Trigger, channel.Id) {
+				continue
+			}
+
+			integrations = append(integrations, &model.ChannelIntegration{
+				Type:        model.INTEGRATION_TYPE_COMMAND,
+				Id:          command.Id,
+				DisplayName: command.DisplayName,
+				Description: command.Description,
+				Trigger:     command.Trigger,
+				OwnerId:     command.CreatorId,
+				CreateAt:    command.CreateAt,
+				CanManage:   a.sessionCanManageTeamIntegration(session, command.TeamId, command.CreatorId, model.PERMISSION_MANAGE_SLASH_COMMANDS, model.PERMISSION_MANAGE_OTHERS_SLASH_COMMANDS),
+			})
+		}
+	}
+
+	a.Srv.pluginCommandsLock.RLock()
+	defer a.Srv.pluginCommandsLock.RUnlock()
+
+	for _, pc := range a.Srv.pluginCommands {
+		if pc.Command.TeamId != "" && pc.Command.TeamId != channel.TeamId {
+			continue
+		}
+		if !a.commandAllowedInChannel(pc.Command.Trigger, channel.Id) {
+			continue
+		}
+
+		integrations = append(integrations, &model.ChannelIntegration{
+			Type:        model.INTEGRATION_TYPE_COMMAND,
+			DisplayName: pc.Command.DisplayName,
+			Description: pc.Command.AutoCompleteDesc,
+			Trigger:     pc.Command.Trigger,
+			PluginId:    pc.PluginId,
+		})
+	}
+
+	return integrations, nil
+}
+
+// sessionCanManageChannelBot returns whether the session may update or disable the bot.
+func (a *App) sessionCanManageChannelBot(session model.Session, bot *model.Bot) bool {
+	if bot.OwnerId == session.UserId {
+		return a.SessionHasPermissionTo(session, model.PERMISSION_MANAGE_BOTS)
+	}
+
+	return a.SessionHasPermissionTo(session, model.PERMISSION_MANAGE_OTHERS_BOTS)
+}
+
+// sessionCanManageTeamIntegration returns whether the session may update or delete a webhook or command of the team
+// created by the given user.
+func (a *App) sessionCanManageTeamIntegration(session model.Session, teamId, creatorId string, permission, othersPermission *model.Permission) bool {
+	if !a.SessionHasPermissionToTeam(session, teamId, permission) {
+		return false
+	}
+
+	return creatorId == session.UserId || a.SessionHasPermissionToTeam(session, teamId, othersPermission)
+}
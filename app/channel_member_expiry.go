@@ -0,0 +1,135 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const (
+	CHANNEL_MEMBER_EXPIRY_BATCH_SIZE = 100
+)
+
+func (a *App) GetChannelMemberExpiry(channelId, userId string) (*model.ChannelMemberExpiry, *model.AppError) {
+	return a.Srv.Store.ChannelMemberExpiry().Get(channelId, userId)
+}
+
+func (a *App) GetChannelMemberExpiries(channelId string) ([]*model.ChannelMemberExpiry, *model.AppError) {
+	return a.Srv.Store.ChannelMemberExpiry().GetForChannel(channelId)
+}
+
+// SetChannelMemberExpiry sets when a member of the channel is removed from it, or extends the time they were
+// already due to be removed at. An expiry of 0 makes the membership permanent.
+func (a *App) SetChannelMemberExpiry(channel *model.Channel, userId string, expiresAt int64, creatorId string) (*model.ChannelMemberExpiry, *model.AppError) {
+	if channel.IsGroupOrDirect() || channel.Name == model.DEFAULT_CHANNEL {
+		return nil, model.NewAppError("SetChannelMemberExpiry", "app.channel_member_expiry.channel.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	if _, err := a.GetChannelMember(channel.Id, userId); err != nil {
+		return nil, err
+	}
+
+	if expiresAt == 0 {
+		if err := a.Srv.Store.ChannelMemberExpiry().Delete(channel.Id, userId); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	if expiresAt <= model.GetMillis() {
+		return nil, model.NewAppError("SetChannelMemberExpiry", "app.channel_member_expiry.expires_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	expiry := &model.ChannelMemberExpiry{
+		ChannelId: channel.Id,
+		UserId:    userId,
+		CreatorId: creatorId,
+		ExpiresAt: expiresAt,
+	}
+
+	if existing, err := a.Srv.Store.ChannelMemberExpiry().Get(channel.Id, userId); err == nil {
+		expiry.CreateAt = existing.CreateAt
+	}
+
+	return a.Srv.Store.ChannelMemberExpiry().Save(expiry)
+}
+
+// RemoveExpiredChannelMembers removes the temporary members whose membership has expired from their channels.
+func (a *App) RemoveExpiredChannelMembers() {
+	for {
+		expiries, err := a.Srv.Store.ChannelMemberExpiry().GetExpired(model.GetMillis(), CHANNEL_MEMBER_EXPIRY_BATCH_SIZE)
+		if err != nil {
+			mlog.Error("Failed to get expired channel memberships", mlog.Err(err))
+			return
+		}
+
+		for _, expiry := range expiries {
+			a.removeExpiredChannelMember(expiry)
+		}
+
+		if len(expiries) < CHANNEL_MEMBER_EXPIRY_BATCH_SIZE {
+			return
+		}
+	}
+}
+
+func (a *App) removeExpiredChannelMember(expiry *model.ChannelMemberExpiry) {
+	// The expiry is deleted even when the member can't be removed, so that it isn't retried forever.
+	defer func() {
+		if err := a.Srv.Store.ChannelMemberExpiry().Delete(expiry.ChannelId, expiry.UserId); err != nil {
+			mlog.Error("Failed to delete an expired channel membership", mlog.String("channel_id", expiry.ChannelId), mlog.String("user_id", expiry.UserId), mlog.Err(err))
+		}
+	}()
+
+	channel, err := a.GetChannel(expiry.ChannelId)
+	if err != nil {
+		return
+	}
+
+	if _, err = a.GetChannelMember(expiry.ChannelId, expiry.UserId); err != nil {
+		if err.Id != store.MISSING_CHANNEL_MEMBER_ERROR {
+			mlog.Error("Failed to get an expired channel member", mlog.String("channel_id", expiry.ChannelId), mlog.String("user_id", expiry.UserId), mlog.Err(err))
+		}
+		return
+	}
+
+	user, err := a.GetUser(expiry.UserId)
+	if err != nil {
+		mlog.Error("Failed to get an expired channel member", mlog.String("user_id", expiry.UserId), mlog.Err(err))
+		return
+	}
+
+	if err = a.removeUserFromChannel(user.Id, "", channel); err != nil {
+		mlog.Error("Failed to remove an expired channel member", mlog.String("channel_id", channel.Id), mlog.String("user_id", user.Id), mlog.Err(err))
+		return
+	}
+
+	if err = a.postMembershipExpiredMessage(user, channel); err != nil {
+		mlog.Error("Failed to post the expired channel membership message", mlog.String("channel_id", channel.Id), mlog.Err(err))
+	}
+}
+
+func (a *App) postMembershipExpiredMessage(removedUser *model.User, channel *model.Channel) *model.AppError {
+	post := &model.Post{
+		ChannelId: channel.Id,
+		Message:   utils.T("app.channel_member_expiry.expired.message", map[string]interface{}{"Username": removedUser.Username}),
+		Type:      model.POST_MEMBERSHIP_EXPIRED,
+		UserId:    removedUser.Id,
+		Props: model.StringInterface{
+			"removedUserId":   removedUser.Id,
+			"removedUsername": removedUser.Username,
+		},
+	}
+
+	if _, err := a.CreatePost(post, channel, false); err != nil {
+		return model.NewAppError("postMembershipExpiredMessage", "api.channel.post_user_add_remove_message_and_forget.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
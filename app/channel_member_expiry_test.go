@@ -0,0 +1,111 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestSetChannelMemberExpiry(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	th.AddUserToChannel(th.BasicUser2, channel)
+
+	_, err := th.App.SetChannelMemberExpiry(channel, th.BasicUser2.Id, model.GetMillis()-1000, th.BasicUser.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.channel_member_expiry.expires_at.app_error", err.Id)
+
+	expiresAt := model.GetMillis() + 60*60*1000
+	expiry, err := th.App.SetChannelMemberExpiry(channel, th.BasicUser2.Id, expiresAt, th.BasicUser.Id)
+	require.Nil(t, err)
+	assert.Equal(t, expiresAt, expiry.ExpiresAt)
+
+	expiry, err = th.App.SetChannelMemberExpiry(channel, th.BasicUser2.Id, expiresAt+60*60*1000, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	expiries, err := th.App.GetChannelMemberExpiries(channel.Id)
+	require.Nil(t, err)
+	require.Len(t, expiries, 1)
+	assert.Equal(t, expiry.ExpiresAt, expiries[0].ExpiresAt)
+
+	t.Run("make permanent", func(t *testing.T) {
+		_, err := th.App.SetChannelMemberExpiry(channel, th.BasicUser2.Id, 0, th.BasicUser.Id)
+		require.Nil(t, err)
+
+		_, err = th.App.GetChannelMemberExpiry(channel.Id, th.BasicUser2.Id)
+		require.NotNil(t, err)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		_, err := th.App.SetChannelMemberExpiry(channel, th.CreateUser().Id, expiresAt, th.BasicUser.Id)
+		require.NotNil(t, err)
+	})
+
+	t.Run("default channel", func(t *testing.T) {
+		townSquare, err := th.App.GetChannelByName(model.DEFAULT_CHANNEL, th.BasicTeam.Id, false)
+		require.Nil(t, err)
+
+		_, err = th.App.SetChannelMemberExpiry(townSquare, th.BasicUser2.Id, expiresAt, th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.channel_member_expiry.channel.app_error", err.Id)
+	})
+
+	t.Run("removed when leaving", func(t *testing.T) {
+		_, err := th.App.SetChannelMemberExpiry(channel, th.BasicUser2.Id, expiresAt, th.BasicUser.Id)
+		require.Nil(t, err)
+
+		require.Nil(t, th.App.RemoveUserFromChannel(th.BasicUser2.Id, th.BasicUser.Id, channel))
+
+		_, err = th.App.GetChannelMemberExpiry(channel.Id, th.BasicUser2.Id)
+		require.NotNil(t, err)
+	})
+}
+
+func TestRemoveExpiredChannelMembers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	th.AddUserToChannel(th.BasicUser2, channel)
+
+	permanent := th.CreateUser()
+	th.LinkUserToTeam(permanent, th.BasicTeam)
+	th.AddUserToChannel(permanent, channel)
+
+	// Expiries can't be set in the past through the app, so the expired one is saved directly.
+	_, err := th.App.Srv.Store.ChannelMemberExpiry().Save(&model.ChannelMemberExpiry{ChannelId: channel.Id, UserId: th.BasicUser2.Id, ExpiresAt: model.GetMillis() - 1000})
+	require.Nil(t, err)
+	_, err = th.App.SetChannelMemberExpiry(channel, permanent.Id, model.GetMillis()+60*60*1000, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	th.App.RemoveExpiredChannelMembers()
+
+	_, err = th.App.GetChannelMember(channel.Id, th.BasicUser2.Id)
+	require.NotNil(t, err)
+	_, err = th.App.GetChannelMemberExpiry(channel.Id, th.BasicUser2.Id)
+	require.NotNil(t, err)
+
+	_, err = th.App.GetChannelMember(channel.Id, permanent.Id)
+	require.Nil(t, err)
+	_, err = th.App.GetChannelMemberExpiry(channel.Id, permanent.Id)
+	require.Nil(t, err)
+
+	posts, err := th.App.GetPosts(channel.Id, 0, 10)
+	require.Nil(t, err)
+
+	found := false
+	for _, post := range posts.Posts {
+		if post.Type == model.POST_MEMBERSHIP_EXPIRED && post.Props["removedUserId"] == th.BasicUser2.Id {
+			found = true
+		}
+	}
+	assert.True(t, found, "expected a system message announcing the removal")
+}
@@ -0,0 +1,491 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const (
+	CHANNEL_MEMBERSHIP_RULE_SYNC_BATCH_SIZE = 200
+)
+
+func (a *App) GetChannelMembershipRules(channelId string) ([]*model.ChannelMembershipRule, *model.AppError) {
+	return a.Srv.Store.ChannelMembershipRule().GetForChannel(channelId)
+}
+
+func (a *App) GetChannelMembershipOverrides(channelId string) ([]*model.ChannelMembershipOverride, *model.AppError) {
+	return a.Srv.Store.ChannelMembershipRule().GetOverridesForChannel(channelId)
+}
+
+// AddChannelMembershipRule adds a rule to the channel, whose membership is then synced with its rules in the
+// background.
+func (a *App) AddChannelMembershipRule(channel *model.Channel, rule *model.ChannelMembershipRule) (*model.ChannelMembershipRule, *model.AppError) {
+	if err := a.checkChannelAllowsMembershipRules(channel); err != nil {
+		return nil, err
+	}
+
+	rules, err := a.Srv.Store.ChannelMembershipRule().GetForChannel(channel.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) >= model.CHANNEL_MEMBERSHIP_RULES_MAX_PER_CHANNEL {
+		return nil, model.NewAppError("AddChannelMembershipRule", "app.channel_membership_rule.too_many.app_error", map[string]interface{}{"Max": model.CHANNEL_MEMBERSHIP_RULES_MAX_PER_CHANNEL}, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	rule.Id = ""
+	rule.CreateAt = 0
+	rule.ChannelId = channel.Id
+
+	if err := a.checkChannelMembershipRuleTarget(rule); err != nil {
+		return nil, err
+	}
+
+	rule, err = a.Srv.Store.ChannelMembershipRule().Save(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	a.keepChannelMembershipOfActor(channel, rule.CreatorId)
+
+	a.Srv.Go(func() {
+		if err := a.SyncChannelMembershipRules(channel); err != nil {
+			mlog.Error("Failed to sync the channel membership with its rules", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		}
+	})
+
+	return rule, nil
+}
+
+// DeleteChannelMembershipRule removes a rule from the channel. Once its last rule is removed, the membership of the
+// channel is managed by hand again and its overrides are dropped.
+func (a *App) DeleteChannelMembershipRule(channel *model.Channel, ruleId string, actorId string) *model.AppError {
+	rule, err := a.Srv.Store.ChannelMembershipRule().Get(ruleId)
+	if err != nil {
+		return err
+	}
+
+	if rule.ChannelId != channel.Id {
+		return model.NewAppError("DeleteChannelMembershipRule", "app.channel_membership_rule.not_found.app_error", nil, "id="+ruleId, http.StatusNotFound)
+	}
+
+	if err = a.Srv.Store.ChannelMembershipRule().Delete(ruleId); err != nil {
+		return err
+	}
+
+	rules, err := a.Srv.Store.ChannelMembershipRule().GetForChannel(channel.Id)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		return a.Srv.Store.ChannelMembershipRule().PermanentDeleteByChannel(channel.Id)
+	}
+
+	a.keepChannelMembershipOfActor(channel, actorId)
+
+	a.Srv.Go(func() {
+		if err := a.SyncChannelMembershipRules(channel); err != nil {
+			mlog.Error("Failed to sync the channel membership with its rules", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		}
+	})
+
+	return nil
+}
+
+// DeleteChannelMembershipOverride hands the membership of the user in the channel back to its rules.
+func (a *App) DeleteChannelMembershipOverride(channel *model.Channel, userId string) *model.AppError {
+	if err := a.Srv.Store.ChannelMembershipRule().DeleteOverride(channel.Id, userId); err != nil {
+		return err
+	}
+
+	a.Srv.Go(func() {
+		if err := a.SyncChannelMembershipRules(channel); err != nil {
+			mlog.Error("Failed to sync the channel membership with its rules", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		}
+	})
+
+	return nil
+}
+
+func (a *App) checkChannelAllowsMembershipRules(channel *model.Channel) *model.AppError {
+	if channel.IsGroupOrDirect() || channel.Name == model.DEFAULT_CHANNEL || channel.IsGroupConstrained() || channel.DeleteAt != 0 {
+		return model.NewAppError("checkChannelAllowsMembershipRules", "app.channel_membership_rule.channel.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// checkChannelMembershipRuleTarget makes sure that the group or the user attribute a rule is on exists.
+func (a *App) checkChannelMembershipRuleTarget(rule *model.ChannelMembershipRule) *model.AppError {
+	if rule.Attribute == model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP {
+		if _, err := a.GetGroup(strings.TrimSpace(rule.Value)); err != nil {
+			return model.NewAppError("checkChannelMembershipRuleTarget", "app.channel_membership_rule.group.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+		return nil
+	}
+
+	name := model.NormalizeUserAttributeName(rule.UserAttributeName())
+	if name == "" {
+		return nil
+	}
+
+	fields, err := a.GetUserAttributeFields()
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if field.Name == name {
+			return nil
+		}
+	}
+
+	return model.NewAppError("checkChannelMembershipRuleTarget", "app.channel_membership_rule.attribute.app_error", map[string]interface{}{"Name": name}, "", http.StatusBadRequest)
+}
+
+// SyncAllChannelMembershipRules syncs the membership of every channel having rules.
+func (a *App) SyncAllChannelMembershipRules() {
+	channelIds, err := a.Srv.Store.ChannelMembershipRule().GetChannelIds()
+	if err != nil {
+		mlog.Error("Failed to get the channels with membership rules", mlog.Err(err))
+		return
+	}
+
+	for _, channelId := range channelIds {
+		channel, err := a.GetChannel(channelId)
+		if err != nil {
+			mlog.Error("Failed to get a channel with membership rules", mlog.String("channel_id", channelId), mlog.Err(err))
+			continue
+		}
+
+		if err := a.SyncChannelMembershipRules(channel); err != nil {
+			mlog.Error("Failed to sync the channel membership with its rules", mlog.String("channel_id", channelId), mlog.Err(err))
+		}
+	}
+}
+
+// SyncChannelMembershipRules adds the users of the team matching the rules of the channel to it, and removes the
+// members matching none of them, except for the users whose membership was overridden by hand. Bots and guests are
+// left alone.
+func (a *App) SyncChannelMembershipRules(channel *model.Channel) *model.AppError {
+	if channel.DeleteAt != 0 {
+		return nil
+	}
+
+	rules, err := a.Srv.Store.ChannelMembershipRule().GetForChannel(channel.Id)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	overrides, err := a.Srv.Store.ChannelMembershipRule().GetOverridesForChannel(channel.Id)
+	if err != nil {
+		return err
+	}
+
+	overrideTypes := make(map[string]string, len(overrides))
+	for _, override := range overrides {
+		overrideTypes[override.UserId] = override.Type
+	}
+
+	groupIds, err := a.getChannelMembershipRuleGroupIds(rules)
+	if err != nil {
+		return err
+	}
+
+	members := map[string]bool{}
+	for offset := 0; ; offset += CHANNEL_MEMBERSHIP_RULE_SYNC_BATCH_SIZE {
+		page, err := a.Srv.Store.Channel().GetMembers(channel.Id, offset, CHANNEL_MEMBERSHIP_RULE_SYNC_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		for _, member := range *page {
+			members[member.UserId] = true
+		}
+
+		if len(*page) < CHANNEL_MEMBERSHIP_RULE_SYNC_BATCH_SIZE {
+			break
+		}
+	}
+
+	for page := 0; ; page++ {
+		users, err := a.Srv.Store.User().GetProfiles(&model.UserGetOptions{InTeamId: channel.TeamId, Page: page, PerPage: CHANNEL_MEMBERSHIP_RULE_SYNC_BATCH_SIZE})
+		if err != nil {
+			return err
+		}
+
+		if err := a.FillInUserAttributes(users); err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			a.syncChannelMembershipRulesMember(channel, user, rules, overrideTypes[user.Id], groupIds[user.Id], members[user.Id])
+		}
+
+		if len(users) < CHANNEL_MEMBERSHIP_RULE_SYNC_BATCH_SIZE {
+			return nil
+		}
+	}
+}
+
+// SyncChannelMembershipRulesForUser syncs the membership of the user in the channels with rules of their teams, for
+// when their attributes or groups changed, or they joined a team.
+func (a *App) SyncChannelMembershipRulesForUser(userId string) *model.AppError {
+	channelIds, err := a.Srv.Store.ChannelMembershipRule().GetChannelIds()
+	if err != nil {
+		return err
+	}
+
+	if len(channelIds) == 0 {
+		return nil
+	}
+
+	user, err := a.Srv.Store.User().Get(userId)
+	if err != nil {
+		return err
+	}
+
+	if err = a.FillInUserAttributes([]*model.User{user}); err != nil {
+		return err
+	}
+
+	for _, channelId := range channelIds {
+		channel, err := a.GetChannel(channelId)
+		if err != nil {
+			mlog.Error("Failed to get a channel with membership rules", mlog.String("channel_id", channelId), mlog.Err(err))
+			continue
+		}
+
+		if channel.DeleteAt != 0 {
+			continue
+		}
+
+		if teamMember, err := a.Srv.Store.Team().GetMember(channel.TeamId, user.Id); err != nil || teamMember.DeleteAt != 0 {
+			continue
+		}
+
+		rules, err := a.Srv.Store.ChannelMembershipRule().GetForChannel(channel.Id)
+		if err != nil {
+			return err
+		}
+
+		var overrideType string
+		if override, err := a.Srv.Store.ChannelMembershipRule().GetOverride(channel.Id, user.Id); err == nil {
+			overrideType = override.Type
+		} else if err.StatusCode != http.StatusNotFound {
+			return err
+		}
+
+		groupIds, err := a.getChannelMembershipRuleGroupIdsForUser(user.Id, rules)
+		if err != nil {
+			return err
+		}
+
+		_, err = a.Srv.Store.Channel().GetMember(channel.Id, user.Id)
+		if err != nil && err.Id != store.MISSING_CHANNEL_MEMBER_ERROR {
+			return err
+		}
+
+		a.syncChannelMembershipRulesMember(channel, user, rules, overrideType, groupIds, err == nil)
+	}
+
+	return nil
+}
+
+func (a *App) syncChannelMembershipRulesMember(channel *model.Channel, user *model.User, rules []*model.ChannelMembershipRule, overrideType string, groupIds map[string]bool, isMember bool) {
+	if user.DeleteAt != 0 || user.IsBot || user.IsGuest() {
+		return
+	}
+
+	shouldBeMember := model.ChannelMembershipRulesMatch(rules, user, groupIds)
+	switch overrideType {
+	case model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE:
+		shouldBeMember = true
+	case model.CHANNEL_MEMBERSHIP_OVERRIDE_EXCLUDE:
+		shouldBeMember = false
+	}
+
+	if shouldBeMember && !isMember {
+		if _, err := a.AddChannelMember(user.Id, channel, "", ""); err != nil {
+			mlog.Error("Failed to add a user matching the channel membership rules", mlog.String("channel_id", channel.Id), mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+	} else if !shouldBeMember && isMember {
+		if err := a.removeUserFromChannel(user.Id, "", channel); err != nil {
+			mlog.Error("Failed to remove a member no longer matching the channel membership rules", mlog.String("channel_id", channel.Id), mlog.String("user_id", user.Id), mlog.Err(err))
+			return
+		}
+
+		if err := a.postMembershipRulesRemovedMessage(user, channel); err != nil {
+			mlog.Error("Failed to post the channel membership rules removal message", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		}
+	}
+}
+
+// recordChannelMembershipOverride remembers that a user was added to or removed from a channel with rules by hand,
+// so that syncing the channel doesn't undo it. Changes agreeing with the rules hand the membership back to them.
+func (a *App) recordChannelMembershipOverride(channel *model.Channel, userId, actorId string, isMember bool) {
+	if channel.IsGroupOrDirect() {
+		return
+	}
+
+	rules, err := a.Srv.Store.ChannelMembershipRule().GetForChannel(channel.Id)
+	if err != nil {
+		mlog.Error("Failed to get the channel membership rules", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		return
+	}
+
+	if len(rules) == 0 {
+		return
+	}
+
+	user, err := a.Srv.Store.User().Get(userId)
+	if err != nil {
+		mlog.Error("Failed to get a user to override their channel membership", mlog.String("user_id", userId), mlog.Err(err))
+		return
+	}
+
+	if err = a.FillInUserAttributes([]*model.User{user}); err != nil {
+		mlog.Error("Failed to get the attributes of a user to override their channel membership", mlog.String("user_id", userId), mlog.Err(err))
+		return
+	}
+
+	groupIds, err := a.getChannelMembershipRuleGroupIdsForUser(userId, rules)
+	if err != nil {
+		mlog.Error("Failed to get the groups of a user to override their channel membership", mlog.String("user_id", userId), mlog.Err(err))
+		return
+	}
+
+	if model.ChannelMembershipRulesMatch(rules, user, groupIds) == isMember {
+		err = a.Srv.Store.ChannelMembershipRule().DeleteOverride(channel.Id, userId)
+	} else {
+		override := &model.ChannelMembershipOverride{
+			ChannelId: channel.Id,
+			UserId:    userId,
+			Type:      model.CHANNEL_MEMBERSHIP_OVERRIDE_EXCLUDE,
+			CreatorId: actorId,
+		}
+		if isMember {
+			override.Type = model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE
+		}
+		_, err = a.Srv.Store.ChannelMembershipRule().SaveOverride(override)
+	}
+
+	if err != nil {
+		mlog.Error("Failed to override a channel membership", mlog.String("channel_id", channel.Id), mlog.String("user_id", userId), mlog.Err(err))
+	}
+}
+
+// keepChannelMembershipOfActor keeps the member changing the rules of a channel in it, so that they don't lock
+// themselves out of it when they match none of the rules.
+func (a *App) keepChannelMembershipOfActor(channel *model.Channel, actorId string) {
+	if actorId == "" {
+		return
+	}
+
+	if _, err := a.Srv.Store.Channel().GetMember(channel.Id, actorId); err != nil {
+		return
+	}
+
+	a.recordChannelMembershipOverride(channel, actorId, actorId, true)
+}
+
+// getChannelMembershipRuleGroupIds returns the ids of the groups the rules are on that each user belongs to, keyed by
+// user id.
+func (a *App) getChannelMembershipRuleGroupIds(rules []*model.ChannelMembershipRule) (map[string]map[string]bool, *model.AppError) {
+	groupIds := map[string]map[string]bool{}
+
+	for _, rule := range rules {
+		if rule.Attribute != model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP {
+			continue
+		}
+
+		users, err := a.Srv.Store.Group().GetMemberUsers(rule.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			if groupIds[user.Id] == nil {
+				groupIds[user.Id] = map[string]bool{}
+			}
+			groupIds[user.Id][rule.Value] = true
+		}
+	}
+
+	return groupIds, nil
+}
+
+func (a *App) getChannelMembershipRuleGroupIdsForUser(userId string, rules []*model.ChannelMembershipRule) (map[string]bool, *model.AppError) {
+	hasGroupRule := false
+	for _, rule := range rules {
+		if rule.Attribute == model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP {
+			hasGroupRule = true
+			break
+		}
+	}
+
+	if !hasGroupRule {
+		return nil, nil
+	}
+
+	groups, err := a.Srv.Store.Group().GetByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIds := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		groupIds[group.Id] = true
+	}
+
+	return groupIds, nil
+}
+
+// channelMembershipRuleAttributesChanged returns whether the update of a user changed any of the attributes channel
+// membership rules can be on, besides the admin-defined ones.
+func channelMembershipRuleAttributesChanged(oldUser, newUser *model.User) bool {
+	return oldUser.Position != newUser.Position ||
+		oldUser.Email != newUser.Email ||
+		oldUser.Locale != newUser.Locale ||
+		oldUser.AuthService != newUser.AuthService
+}
+
+func (a *App) syncChannelMembershipRulesForUserInBackground(userId string) {
+	a.Srv.Go(func() {
+		if err := a.SyncChannelMembershipRulesForUser(userId); err != nil {
+			mlog.Error("Failed to sync the channel memberships of a user with the channel rules", mlog.String("user_id", userId), mlog.Err(err))
+		}
+	})
+}
+
+func (a *App) postMembershipRulesRemovedMessage(removedUser *model.User, channel *model.Channel) *model.AppError {
+	post := &model.Post{
+		ChannelId: channel.Id,
+		Message:   utils.T("app.channel_membership_rule.removed.message", map[string]interface{}{"Username": removedUser.Username}),
+		Type:      model.POST_MEMBERSHIP_RULES,
+		UserId:    removedUser.Id,
+		Props: model.StringInterface{
+			"removedUserId":   removedUser.Id,
+			"removedUsername": removedUser.Username,
+		},
+	}
+
+	if _, err := a.CreatePost(post, channel, false); err != nil {
+		return model.NewAppError("postMembershipRulesRemovedMessage", "api.channel.post_user_add_remove_message_and_forget.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestAddChannelMembershipRule(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+
+	rule, err := th.App.AddChannelMembershipRule(channel, &model.ChannelMembershipRule{Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION, Value: "Engineer", CreatorId: th.BasicUser.Id})
+	require.Nil(t, err)
+	assert.Equal(t, channel.Id, rule.ChannelId)
+
+	rules, err := th.App.GetChannelMembershipRules(channel.Id)
+	require.Nil(t, err)
+	require.Len(t, rules, 1)
+
+	override, err := th.App.Srv.Store.ChannelMembershipRule().GetOverride(channel.Id, th.BasicUser.Id)
+	require.Nil(t, err, "the creator of the rule should keep their membership")
+	assert.Equal(t, model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE, override.Type)
+
+	t.Run("default channel", func(t *testing.T) {
+		townSquare, err := th.App.GetChannelByName(model.DEFAULT_CHANNEL, th.BasicTeam.Id, false)
+		require.Nil(t, err)
+
+		_, err = th.App.AddChannelMembershipRule(townSquare, &model.ChannelMembershipRule{Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE, Value: "fr"})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.channel_membership_rule.channel.app_error", err.Id)
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		_, err := th.App.AddChannelMembershipRule(channel, &model.ChannelMembershipRule{Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP, Value: model.NewId()})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.channel_membership_rule.group.app_error", err.Id)
+	})
+
+	t.Run("unknown user attribute", func(t *testing.T) {
+		_, err := th.App.AddChannelMembershipRule(channel, &model.ChannelMembershipRule{Attribute: "attributes.department", Value: "Sales"})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.channel_membership_rule.attribute.app_error", err.Id)
+	})
+
+	t.Run("delete from another channel", func(t *testing.T) {
+		err := th.App.DeleteChannelMembershipRule(th.BasicChannel, rule.Id, th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.channel_membership_rule.not_found.app_error", err.Id)
+	})
+
+	t.Run("delete the last rule", func(t *testing.T) {
+		_, err := th.App.Srv.Store.ChannelMembershipRule().SaveOverride(&model.ChannelMembershipOverride{ChannelId: channel.Id, UserId: th.BasicUser2.Id, Type: model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE})
+		require.Nil(t, err)
+
+		require.Nil(t, th.App.DeleteChannelMembershipRule(channel, rule.Id, th.BasicUser.Id))
+
+		overrides, err := th.App.GetChannelMembershipOverrides(channel.Id)
+		require.Nil(t, err)
+		assert.Empty(t, overrides)
+	})
+}
+
+func TestSyncChannelMembershipRules(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+
+	engineer := th.CreateUser()
+	th.LinkUserToTeam(engineer, th.BasicTeam)
+	engineer.Position = "Engineer"
+	_, err := th.App.Srv.Store.User().Update(engineer, true)
+	require.Nil(t, err)
+
+	// BasicUser2 is a member matching no rule.
+	th.AddUserToChannel(th.BasicUser2, channel)
+
+	_, err = th.App.Srv.Store.ChannelMembershipRule().Save(&model.ChannelMembershipRule{ChannelId: channel.Id, Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION, Value: "engineer"})
+	require.Nil(t, err)
+	_, err = th.App.Srv.Store.ChannelMembershipRule().SaveOverride(&model.ChannelMembershipOverride{ChannelId: channel.Id, UserId: th.BasicUser.Id, Type: model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE})
+	require.Nil(t, err)
+
+	require.Nil(t, th.App.SyncChannelMembershipRules(channel))
+
+	_, err = th.App.GetChannelMember(channel.Id, engineer.Id)
+	assert.Nil(t, err, "the user matching the rules should have been added")
+
+	_, err = th.App.GetChannelMember(channel.Id, th.BasicUser.Id)
+	assert.Nil(t, err, "the included user should have been kept")
+
+	_, err = th.App.GetChannelMember(channel.Id, th.BasicUser2.Id)
+	assert.NotNil(t, err, "the member matching no rule should have been removed")
+
+	t.Run("manual changes are overrides", func(t *testing.T) {
+		_, err := th.App.AddChannelMember(th.BasicUser2.Id, channel, th.BasicUser.Id, "")
+		require.Nil(t, err)
+
+		override, err := th.App.Srv.Store.ChannelMembershipRule().GetOverride(channel.Id, th.BasicUser2.Id)
+		require.Nil(t, err)
+		assert.Equal(t, model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE, override.Type)
+
+		require.Nil(t, th.App.RemoveUserFromChannel(engineer.Id, th.BasicUser.Id, channel))
+
+		override, err = th.App.Srv.Store.ChannelMembershipRule().GetOverride(channel.Id, engineer.Id)
+		require.Nil(t, err)
+		assert.Equal(t, model.CHANNEL_MEMBERSHIP_OVERRIDE_EXCLUDE, override.Type)
+
+		require.Nil(t, th.App.SyncChannelMembershipRules(channel))
+
+		_, err = th.App.GetChannelMember(channel.Id, th.BasicUser2.Id)
+		assert.Nil(t, err)
+		_, err = th.App.GetChannelMember(channel.Id, engineer.Id)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("changes agreeing with the rules drop the override", func(t *testing.T) {
+		require.Nil(t, th.App.RemoveUserFromChannel(th.BasicUser2.Id, th.BasicUser.Id, channel))
+
+		_, err := th.App.Srv.Store.ChannelMembershipRule().GetOverride(channel.Id, th.BasicUser2.Id)
+		require.NotNil(t, err)
+	})
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// ExportChannelPosts writes the posts of the channel in the range of the options to the writer as CSV or as a JSON
+// array, oldest first, with their authors, reactions and file links. The posts are read and written a page at a
+// time, so the export of a large channel can be streamed as it is built.
+func (a *App) ExportChannelPosts(channel *model.Channel, options *model.ChannelPostExportOptions, w io.Writer) *model.AppError {
+	if err := options.IsValid(); err != nil {
+		return err
+	}
+
+	until := options.Until
+	if until == 0 {
+		until = model.GetMillis()
+	}
+
+	if options.Gzip {
+		gzipWriter := gzip.NewWriter(w)
+		defer gzipWriter.Close()
+		w = gzipWriter
+	}
+
+	var writeRecord func(record *model.ChannelPostExportRecord) error
+	var finish func() error
+
+	if options.Format == model.CHANNEL_POST_EXPORT_FORMAT_JSON {
+		encoder := json.NewEncoder(w)
+		separator := "["
+		writeRecord = func(record *model.ChannelPostExportRecord) error {
+			if _, err := io.WriteString(w, separator); err != nil {
+				return err
+			}
+			separator = ","
+			return encoder.Encode(record)
+		}
+		finish = func() error {
+			if separator == "[" {
+				_, err := io.WriteString(w, "[]\n")
+				return err
+			}
+			_, err := io.WriteString(w, "]\n")
+			return err
+		}
+	} else {
+		csvWriter := csv.NewWriter(w)
+		if err := csvWriter.Write(model.ChannelPostExportCSVHeader); err != nil {
+			return model.NewAppError("ExportChannelPosts", "app.channel_post_export.write.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		writeRecord = func(record *model.ChannelPostExportRecord) error {
+			return csvWriter.Write(record.ToCSVRow())
+		}
+		finish = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+	}
+
+	usernames := map[string]string{}
+	err := a.forEachChannelPostPageBetween(channel.Id, options.Since, until, func(page []*model.Post) *model.AppError {
+		records, err := a.buildChannelPostExportRecords(page, usernames)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			if err := writeRecord(record); err != nil {
+				return model.NewAppError("ExportChannelPosts", "app.channel_post_export.write.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := finish(); err != nil {
+		return model.NewAppError("ExportChannelPosts", "app.channel_post_export.write.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// buildChannelPostExportRecords loads the reactions and files of a page of posts in bulk and resolves the usernames of
+// their authors and reactors, caching them across pages.
+func (a *App) buildChannelPostExportRecords(posts []*model.Post, usernames map[string]string) ([]*model.ChannelPostExportRecord, *model.AppError) {
+	postIds := make([]string, len(posts))
+	for i, post := range posts {
+		postIds[i] = post.Id
+	}
+
+	reactions, err := a.Srv.Store.Reaction().BulkGetForPosts(postIds)
+	if err != nil {
+		return nil, err
+	}
+
+	reactionsByPost := map[string][]*model.ChannelPostExportReaction{}
+	for _, reaction := range reactions {
+		reactionsByPost[reaction.PostId] = append(reactionsByPost[reaction.PostId], &model.ChannelPostExportReaction{
+			EmojiName: reaction.EmojiName,
+			UserId:    reaction.UserId,
+			Username:  a.getChannelPostExportUsername(reaction.UserId, usernames),
+			CreateAt:  reaction.CreateAt,
+		})
+	}
+
+	fileInfos, err := a.Srv.Store.FileInfo().GetForPosts(postIds, false)
+	if err != nil {
+		return nil, err
+	}
+
+	fileLinkPrefix := a.GetSiteURL() + model.API_URL_SUFFIX + "/files/"
+
+	records := make([]*model.ChannelPostExportRecord, len(posts))
+	for i, post := range posts {
+		record := &model.ChannelPostExportRecord{
+			PostId:    post.Id,
+			CreateAt:  post.CreateAt,
+			EditAt:    post.EditAt,
+			UserId:    post.UserId,
+			Username:  a.getChannelPostExportUsername(post.UserId, usernames),
+			RootId:    post.RootId,
+			Type:      post.Type,
+			Message:   post.Message,
+			Reactions: reactionsByPost[post.Id],
+			Files:     []*model.ChannelPostExportFile{},
+		}
+
+		if record.Reactions == nil {
+			record.Reactions = []*model.ChannelPostExportReaction{}
+		}
+
+		for _, info := range fileInfos[post.Id] {
+			record.Files = append(record.Files, &model.ChannelPostExportFile{
+				Id:   info.Id,
+				Name: info.Name,
+				Size: info.Size,
+				Link: fileLinkPrefix + info.Id,
+			})
+		}
+
+		records[i] = record
+	}
+
+	return records, nil
+}
+
+func (a *App) getChannelPostExportUsername(userId string, usernames map[string]string) string {
+	username, ok := usernames[userId]
+	if !ok {
+		if user, err := a.Srv.Store.User().Get(userId); err == nil {
+			username = user.Username
+		}
+		usernames[userId] = username
+	}
+	return username
+}
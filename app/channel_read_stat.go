@@ -0,0 +1,35 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// ComputeChannelReadStats measures the reach of the root posts created in [since, until) and stores the aggregates,
+// returning the number of posts measured.
+func (a *App) ComputeChannelReadStats(since, until int64) (int, *model.AppError) {
+	stats, err := a.Srv.Store.ChannelReadStat().Compute(since, until, *a.Config().AnalyticsSettings.ChannelReadStatsMinimumMembers)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, stat := range stats {
+		if _, err := a.Srv.Store.ChannelReadStat().Save(stat); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(stats), nil
+}
+
+func (a *App) GetChannelReadStats(teamId, channelId string, since, until int64, page, perPage int) ([]*model.ChannelReadStat, *model.AppError) {
+	if !*a.Config().AnalyticsSettings.EnableChannelReadStats {
+		return nil, model.NewAppError("GetChannelReadStats", "app.channel_read_stats.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return a.Srv.Store.ChannelReadStat().Get(teamId, channelId, since, until, page*perPage, perPage)
+}
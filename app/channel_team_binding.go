@@ -0,0 +1,212 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (a *App) GetChannelTeamBindings(channelId string) ([]*model.ChannelTeamBinding, *model.AppError) {
+	return a.Srv.Store.ChannelTeamBinding().GetForChannel(channelId)
+}
+
+// BindChannelToTeam surfaces the channel in another team, whose members can then find and join it from there.
+func (a *App) BindChannelToTeam(channel *model.Channel, teamId string, creatorId string) (*model.ChannelTeamBinding, *model.AppError) {
+	if channel.IsGroupOrDirect() || channel.Name == model.DEFAULT_CHANNEL || channel.DeleteAt != 0 {
+		return nil, model.NewAppError("BindChannelToTeam", "app.channel_team_binding.channel.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	if teamId == channel.TeamId {
+		return nil, model.NewAppError("BindChannelToTeam", "app.channel_team_binding.own_team.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	team, err := a.GetTeam(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.DeleteAt != 0 {
+		return nil, model.NewAppError("BindChannelToTeam", "app.channel_team_binding.team_deleted.app_error", nil, "team_id="+teamId, http.StatusBadRequest)
+	}
+
+	if err := a.checkChannelNameAvailableInTeam(channel.Name, channel.Id, teamId); err != nil {
+		return nil, err
+	}
+
+	binding, err := a.Srv.Store.ChannelTeamBinding().Save(&model.ChannelTeamBinding{
+		ChannelId: channel.Id,
+		TeamId:    teamId,
+		CreatorId: creatorId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.publishChannelTeamBindingEvent(model.WEBSOCKET_EVENT_CHANNEL_TEAM_BOUND, channel, teamId)
+
+	return binding, nil
+}
+
+// UnbindChannelFromTeam stops surfacing the channel in the team. Its members who aren't on any of its remaining teams
+// are removed from it.
+func (a *App) UnbindChannelFromTeam(channel *model.Channel, teamId string) *model.AppError {
+	if _, err := a.Srv.Store.ChannelTeamBinding().Get(channel.Id, teamId); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.ChannelTeamBinding().Delete(channel.Id, teamId); err != nil {
+		return err
+	}
+
+	a.publishChannelTeamBindingEvent(model.WEBSOCKET_EVENT_CHANNEL_TEAM_UNBOUND, channel, teamId)
+
+	a.Srv.Go(func() {
+		if err := a.removeChannelMembersOutsideChannelTeams(channel); err != nil {
+			mlog.Error("Failed to remove the channel members who are no longer on its teams", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		}
+	})
+
+	return nil
+}
+
+// checkChannelNameAvailableInTeam makes sure that no other channel of the team, or bound to it, has the name, so that
+// channel names stay unique within each team the channel is surfaced in.
+func (a *App) checkChannelNameAvailableInTeam(name, channelId, teamId string) *model.AppError {
+	if existing, err := a.Srv.Store.Channel().GetByNameIncludeDeleted(teamId, name, false); err == nil && existing.Id != channelId {
+		return model.NewAppError("checkChannelNameAvailableInTeam", "app.channel_team_binding.name_taken.app_error", map[string]interface{}{"Name": name}, "team_id="+teamId, http.StatusBadRequest)
+	}
+
+	if existing, err := a.Srv.Store.ChannelTeamBinding().GetChannelByName(teamId, name, true); err == nil && existing.Id != channelId {
+		return model.NewAppError("checkChannelNameAvailableInTeam", "app.channel_team_binding.name_taken.app_error", map[string]interface{}{"Name": name}, "team_id="+teamId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// checkChannelNameAvailableInChannelTeams makes sure that the name of the channel is unique within each team it is
+// surfaced in, before it is created or renamed.
+func (a *App) checkChannelNameAvailableInChannelTeams(channel *model.Channel) *model.AppError {
+	if channel.TeamId == "" {
+		return nil
+	}
+
+	if existing, err := a.Srv.Store.ChannelTeamBinding().GetChannelByName(channel.TeamId, channel.Name, true); err == nil && existing.Id != channel.Id {
+		return model.NewAppError("checkChannelNameAvailableInChannelTeams", "app.channel_team_binding.name_taken.app_error", map[string]interface{}{"Name": channel.Name}, "team_id="+channel.TeamId, http.StatusBadRequest)
+	}
+
+	if channel.Id == "" {
+		return nil
+	}
+
+	bindings, err := a.Srv.Store.ChannelTeamBinding().GetForChannel(channel.Id)
+	if err != nil {
+		return err
+	}
+
+	for _, binding := range bindings {
+		if err := a.checkChannelNameAvailableInTeam(channel.Name, channel.Id, binding.TeamId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getChannelTeamIds returns the id of the team of the channel followed by those of the teams it is bound to.
+func (a *App) getChannelTeamIds(channel *model.Channel) ([]string, *model.AppError) {
+	teamIds := []string{channel.TeamId}
+
+	bindings, err := a.Srv.Store.ChannelTeamBinding().GetForChannel(channel.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, binding := range bindings {
+		teamIds = append(teamIds, binding.TeamId)
+	}
+
+	return teamIds, nil
+}
+
+// getChannelTeamMember returns the membership of the user in the team of the channel, or else in a team it is bound
+// to.
+func (a *App) getChannelTeamMember(channel *model.Channel, userId string) (*model.TeamMember, *model.AppError) {
+	teamMember, err := a.Srv.Store.Team().GetMember(channel.TeamId, userId)
+	if err == nil && teamMember.DeleteAt == 0 {
+		return teamMember, nil
+	}
+
+	bindings, bindingsErr := a.Srv.Store.ChannelTeamBinding().GetForChannel(channel.Id)
+	if bindingsErr != nil {
+		return nil, bindingsErr
+	}
+
+	for _, binding := range bindings {
+		if boundMember, boundErr := a.Srv.Store.Team().GetMember(binding.TeamId, userId); boundErr == nil && boundMember.DeleteAt == 0 {
+			return boundMember, nil
+		}
+	}
+
+	return teamMember, err
+}
+
+// isUserOnOtherChannelTeam returns whether the user is on a team the channel is surfaced in, other than the given one.
+func (a *App) isUserOnOtherChannelTeam(channel *model.Channel, userId, teamId string) (bool, *model.AppError) {
+	teamIds, err := a.getChannelTeamIds(channel)
+	if err != nil {
+		return false, err
+	}
+
+	for _, channelTeamId := range teamIds {
+		if channelTeamId == teamId {
+			continue
+		}
+
+		if teamMember, err := a.Srv.Store.Team().GetMember(channelTeamId, userId); err == nil && teamMember.DeleteAt == 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (a *App) removeChannelMembersOutsideChannelTeams(channel *model.Channel) *model.AppError {
+	var userIds []string
+	for offset := 0; ; offset += CHANNEL_MEMBERSHIP_RULE_SYNC_BATCH_SIZE {
+		page, err := a.Srv.Store.Channel().GetMembers(channel.Id, offset, CHANNEL_MEMBERSHIP_RULE_SYNC_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		for _, member := range *page {
+			userIds = append(userIds, member.UserId)
+		}
+
+		if len(*page) < CHANNEL_MEMBERSHIP_RULE_SYNC_BATCH_SIZE {
+			break
+		}
+	}
+
+	for _, userId := range userIds {
+		if teamMember, err := a.getChannelTeamMember(channel, userId); err == nil && teamMember.DeleteAt == 0 {
+			continue
+		}
+
+		if err := a.RemoveUserFromChannel(userId, "", channel); err != nil {
+			mlog.Error("Failed to remove a channel member who is no longer on its teams", mlog.String("channel_id", channel.Id), mlog.String("user_id", userId), mlog.Err(err))
+		}
+	}
+
+	return nil
+}
+
+func (a *App) publishChannelTeamBindingEvent(event string, channel *model.Channel, teamId string) {
+	message := model.NewWebSocketEvent(event, teamId, "", "", nil)
+	message.Add("channel_id", channel.Id)
+	message.Add("team_id", teamId)
+	a.Publish(message)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestBindChannelToTeam(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	otherTeam := th.CreateTeam()
+
+	binding, err := th.App.BindChannelToTeam(channel, otherTeam.Id, th.BasicUser.Id)
+	require.Nil(t, err)
+	assert.Equal(t, otherTeam.Id, binding.TeamId)
+
+	_, err = th.App.BindChannelToTeam(channel, otherTeam.Id, th.BasicUser.Id)
+	require.NotNil(t, err)
+
+	t.Run("found by name in the bound team", func(t *testing.T) {
+		found, err := th.App.GetChannelByName(channel.Name, otherTeam.Id, false)
+		require.Nil(t, err)
+		assert.Equal(t, channel.Id, found.Id)
+	})
+
+	t.Run("own team", func(t *testing.T) {
+		_, err := th.App.BindChannelToTeam(channel, th.BasicTeam.Id, th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.channel_team_binding.own_team.app_error", err.Id)
+	})
+
+	t.Run("name taken in the team", func(t *testing.T) {
+		thirdTeam := th.CreateTeam()
+		_, err := th.App.CreateChannel(&model.Channel{TeamId: thirdTeam.Id, Name: channel.Name, DisplayName: "Other", Type: model.CHANNEL_OPEN}, false)
+		require.Nil(t, err)
+
+		_, err = th.App.BindChannelToTeam(channel, thirdTeam.Id, th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.channel_team_binding.name_taken.app_error", err.Id)
+
+		_, err = th.App.CreateChannel(&model.Channel{TeamId: otherTeam.Id, Name: channel.Name, DisplayName: "Other", Type: model.CHANNEL_OPEN}, false)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.channel_team_binding.name_taken.app_error", err.Id)
+	})
+
+	t.Run("members of the bound team can join", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, otherTeam)
+
+		_, err := th.App.AddUserToChannel(user, channel)
+		require.Nil(t, err)
+
+		channels, err := th.App.GetChannelsForUser(otherTeam.Id, user.Id, false)
+		require.Nil(t, err)
+		found := false
+		for _, c := range *channels {
+			if c.Id == channel.Id {
+				found = true
+			}
+		}
+		assert.True(t, found, "the shared channel should be listed in the bound team")
+	})
+
+	t.Run("leaving a team keeps the channels shared with another of their teams", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+		th.LinkUserToTeam(user, otherTeam)
+		th.AddUserToChannel(user, channel)
+
+		require.Nil(t, th.App.LeaveTeam(otherTeam, user, user.Id))
+
+		_, err := th.App.GetChannelMember(channel.Id, user.Id)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unbind", func(t *testing.T) {
+		require.Nil(t, th.App.UnbindChannelFromTeam(channel, otherTeam.Id))
+
+		bindings, err := th.App.GetChannelTeamBindings(channel.Id)
+		require.Nil(t, err)
+		assert.Empty(t, bindings)
+
+		err = th.App.UnbindChannelFromTeam(channel, otherTeam.Id)
+		require.NotNil(t, err)
+	})
+}
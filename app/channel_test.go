@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -538,6 +539,43 @@ func TestAddChannelMemberNoUserRequestor(t *testing.T) {
 	}
 }
 
+func TestChannelWelcomeMessage(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+
+	channel := th.createChannel(th.BasicTeam, model.CHANNEL_OPEN)
+	channel.WelcomeMessage = "Welcome to {{channel}}, {{user}}!"
+	channel.WelcomeMessageType = model.CHANNEL_WELCOME_MESSAGE_TYPE_DIRECT
+	channel, err := th.App.UpdateChannel(channel)
+	require.Nil(t, err)
+
+	_, err = th.App.AddChannelMember(user.Id, channel, th.BasicUser.Id, "")
+	require.Nil(t, err)
+
+	bot, err := th.App.GetSystemBot()
+	require.Nil(t, err)
+
+	dm, err := th.App.GetOrCreateDirectChannel(bot.UserId, user.Id)
+	require.Nil(t, err)
+
+	// The welcome message is sent in the background
+	var posts *model.PostList
+	for i := 0; i < 50; i++ {
+		posts, err = th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, err)
+		if len(posts.Order) > 0 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	require.Len(t, posts.Order, 1)
+	assert.Equal(t, "Welcome to "+channel.DisplayName+", @"+user.Username+"!", posts.Posts[posts.Order[0]].Message)
+}
+
 func TestAppUpdateChannelScheme(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
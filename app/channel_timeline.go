@@ -0,0 +1,236 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	CHANNEL_TIMELINE_DIRECTORY      = "channel_timelines/"
+	CHANNEL_TIMELINE_POSTS_PER_PAGE = 200
+)
+
+// CreateChannelTimelineJob schedules a job exporting the timeline of the channel for the given period.
+func (a *App) CreateChannelTimelineJob(channel *model.Channel, request *model.ChannelTimelineRequest, userId string) (*model.Job, *model.AppError) {
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Jobs.CreateJob(model.JOB_TYPE_CHANNEL_TIMELINE, map[string]string{
+		model.CHANNEL_TIMELINE_JOB_DATA_CHANNEL_ID:   channel.Id,
+		model.CHANNEL_TIMELINE_JOB_DATA_START_TIME:   strconv.FormatInt(request.StartTime, 10),
+		model.CHANNEL_TIMELINE_JOB_DATA_END_TIME:     strconv.FormatInt(request.EndTime, 10),
+		model.CHANNEL_TIMELINE_JOB_DATA_FORMAT:       request.Format,
+		model.CHANNEL_TIMELINE_JOB_DATA_REQUESTED_BY: userId,
+	})
+}
+
+// GetChannelTimelineJob returns the timeline export job, making sure it belongs to the channel.
+func (a *App) GetChannelTimelineJob(channelId, jobId string) (*model.Job, *model.AppError) {
+	job, err := a.GetJob(jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Type != model.JOB_TYPE_CHANNEL_TIMELINE || job.Data[model.CHANNEL_TIMELINE_JOB_DATA_CHANNEL_ID] != channelId {
+		return nil, model.NewAppError("GetChannelTimelineJob", "app.channel_timeline.job.not_found.app_error", nil, "job_id="+jobId, http.StatusNotFound)
+	}
+
+	return job, nil
+}
+
+// GetChannelTimelineFile returns the export written by a finished timeline export job.
+func (a *App) GetChannelTimelineFile(job *model.Job) ([]byte, *model.AppError) {
+	if job.Status != model.JOB_STATUS_SUCCESS || job.Data[model.CHANNEL_TIMELINE_JOB_DATA_FILE_PATH] == "" {
+		return nil, model.NewAppError("GetChannelTimelineFile", "app.channel_timeline.file.not_ready.app_error", nil, "job_id="+job.Id+", status="+job.Status, http.StatusBadRequest)
+	}
+
+	return a.ReadFile(job.Data[model.CHANNEL_TIMELINE_JOB_DATA_FILE_PATH])
+}
+
+// ExportChannelTimeline builds the timeline requested by the job and writes it to the file store in the
+// requested format, recording where it was written in the job data.
+func (a *App) ExportChannelTimeline(job *model.Job) *model.AppError {
+	startTime, _ := strconv.ParseInt(job.Data[model.CHANNEL_TIMELINE_JOB_DATA_START_TIME], 10, 64)
+	endTime, _ := strconv.ParseInt(job.Data[model.CHANNEL_TIMELINE_JOB_DATA_END_TIME], 10, 64)
+
+	channel, err := a.Srv.Store.Channel().Get(job.Data[model.CHANNEL_TIMELINE_JOB_DATA_CHANNEL_ID], true)
+	if err != nil {
+		return err
+	}
+
+	timeline, err := a.BuildChannelTimeline(channel, startTime, endTime)
+	if err != nil {
+		return err
+	}
+
+	var path, content string
+	if job.Data[model.CHANNEL_TIMELINE_JOB_DATA_FORMAT] == model.CHANNEL_TIMELINE_FORMAT_JSON {
+		path = CHANNEL_TIMELINE_DIRECTORY + job.Id + ".json"
+		content = timeline.ToJson()
+	} else {
+		path = CHANNEL_TIMELINE_DIRECTORY + job.Id + ".md"
+		content = timeline.ToMarkdown()
+	}
+
+	if _, err := a.WriteFile(bytes.NewReader([]byte(content)), path); err != nil {
+		return err
+	}
+
+	job.Data[model.CHANNEL_TIMELINE_JOB_DATA_FILE_PATH] = path
+	job.Data[model.CHANNEL_TIMELINE_JOB_DATA_ENTRY_COUNT] = strconv.Itoa(len(timeline.Entries))
+
+	return nil
+}
+
+// BuildChannelTimeline collects the posts and channel events between the two timestamps, oldest first.
+// Joins, leaves and changes to the channel are taken from the system messages posted for them, while pins and unpins
+// are taken from the pin events recorded for the channel.
+func (a *App) BuildChannelTimeline(channel *model.Channel, startTime, endTime int64) (*model.ChannelTimeline, *model.AppError) {
+	posts, err := a.getChannelPostsBetween(channel.Id, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	pinEvents, err := a.Srv.Store.PostPinEvent().GetForChannel(channel.Id, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &model.ChannelTimeline{
+		ChannelId:   channel.Id,
+		Name:        channel.Name,
+		DisplayName: channel.DisplayName,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		GeneratedAt: model.GetMillis(),
+		Entries:     make([]*model.ChannelTimelineEntry, 0, len(posts)+len(pinEvents)),
+	}
+
+	usernames := map[string]string{}
+	getUsername := func(userId string) string {
+		username, ok := usernames[userId]
+		if !ok {
+			if user, err := a.Srv.Store.User().Get(userId); err == nil {
+				username = user.Username
+			}
+			usernames[userId] = username
+		}
+		return username
+	}
+
+	for _, post := range posts {
+		username := getUsername(post.UserId)
+
+		entry := &model.ChannelTimelineEntry{
+			Timestamp: post.CreateAt,
+			Type:      channelTimelineEventType(post),
+			UserId:    post.UserId,
+			Username:  username,
+			PostId:    post.Id,
+		}
+
+		if entry.Type == model.CHANNEL_TIMELINE_EVENT_POST {
+			entry.RootId = post.RootId
+			entry.Message = post.Message
+			entry.Pinned = post.IsPinned
+			entry.Edited = post.EditAt > 0
+		} else {
+			entry.Annotation = post.Message
+		}
+
+		timeline.Entries = append(timeline.Entries, entry)
+	}
+
+	for _, event := range pinEvents {
+		entry := &model.ChannelTimelineEntry{
+			Timestamp: event.CreateAt,
+			Type:      model.CHANNEL_TIMELINE_EVENT_PIN,
+			UserId:    event.UserId,
+			Username:  getUsername(event.UserId),
+			PostId:    event.PostId,
+		}
+
+		if event.Pinned {
+			entry.Annotation = "@" + entry.Username + " pinned a message."
+		} else {
+			entry.Type = model.CHANNEL_TIMELINE_EVENT_UNPIN
+			entry.Annotation = "@" + entry.Username + " unpinned a message."
+		}
+
+		timeline.Entries = append(timeline.Entries, entry)
+	}
+
+	// Both the posts and the pin events are oldest first, so a stable sort keeps the order of entries at the same time.
+	sort.SliceStable(timeline.Entries, func(i, j int) bool {
+		return timeline.Entries[i].Timestamp < timeline.Entries[j].Timestamp
+	})
+
+	return timeline, nil
+}
+
+func channelTimelineEventType(post *model.Post) string {
+	switch post.Type {
+	case model.POST_JOIN_CHANNEL, model.POST_GUEST_JOIN_CHANNEL, model.POST_ADD_TO_CHANNEL, model.POST_ADD_GUEST_TO_CHANNEL:
+		return model.CHANNEL_TIMELINE_EVENT_JOIN
+	case model.POST_LEAVE_CHANNEL, model.POST_REMOVE_FROM_CHANNEL:
+		return model.CHANNEL_TIMELINE_EVENT_LEAVE
+	case model.POST_HEADER_CHANGE:
+		return model.CHANNEL_TIMELINE_EVENT_HEADER_CHANGE
+	case model.POST_PURPOSE_CHANGE:
+		return model.CHANNEL_TIMELINE_EVENT_PURPOSE_CHANGE
+	case model.POST_DISPLAYNAME_CHANGE:
+		return model.CHANNEL_TIMELINE_EVENT_DISPLAYNAME_CHANGE
+	}
+
+	if post.IsSystemMessage() {
+		return model.CHANNEL_TIMELINE_EVENT_SYSTEM
+	}
+
+	return model.CHANNEL_TIMELINE_EVENT_POST
+}
+
+// getChannelPostsBetween collects the posts of the channel created between the two timestamps, oldest first.
+func (a *App) getChannelPostsBetween(channelId string, startTime, endTime int64) ([]*model.Post, *model.AppError) {
+	posts := []*model.Post{}
+	err := a.forEachChannelPostPageBetween(channelId, startTime, endTime, func(page []*model.Post) *model.AppError {
+		posts = append(posts, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// forEachChannelPostPageBetween pages through the posts of the channel created between the two timestamps inclusive,
+// oldest first, handing each page to the given function so that large ranges need not be held in memory at once.
+func (a *App) forEachChannelPostPageBetween(channelId string, startTime, endTime int64, f func(page []*model.Post) *model.AppError) *model.AppError {
+	afterCreateAt, afterId := startTime, ""
+	for {
+		page, err := a.Srv.Store.Post().GetForChannelAfter(channelId, afterCreateAt, afterId, endTime, CHANNEL_TIMELINE_POSTS_PER_PAGE)
+		if err != nil {
+			return err
+		}
+
+		if len(page) > 0 {
+			if err := f(page); err != nil {
+				return err
+			}
+
+			last := page[len(page)-1]
+			afterCreateAt, afterId = last.CreateAt, last.Id
+		}
+
+		if len(page) < CHANNEL_TIMELINE_POSTS_PER_PAGE {
+			return nil
+		}
+	}
+}
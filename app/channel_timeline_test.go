@@ -0,0 +1,139 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestBuildChannelTimeline(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	start := model.GetMillis() - 60000
+
+	createPost := func(post *model.Post) *model.Post {
+		post.UserId = th.BasicUser.Id
+		post.ChannelId = channel.Id
+		created, err := th.App.CreatePost(post, channel, false)
+		require.Nil(t, err)
+		return created
+	}
+
+	before := createPost(&model.Post{Message: "before", CreateAt: start - 1000})
+	atStart := createPost(&model.Post{Message: "at start", CreateAt: start})
+	first := createPost(&model.Post{Message: "first", CreateAt: start + 1000})
+	header := createPost(&model.Post{Message: "header updated", Type: model.POST_HEADER_CHANGE, CreateAt: start + 2000})
+	reply := createPost(&model.Post{Message: "reply", RootId: first.Id, CreateAt: start + 3000})
+	after := createPost(&model.Post{Message: "after", CreateAt: start + 10000})
+
+	_, err := th.App.Srv.Store.PostPinEvent().Save(&model.PostPinEvent{PostId: first.Id, ChannelId: channel.Id, UserId: th.BasicUser2.Id, Pinned: true, CreateAt: start + 2500})
+	require.Nil(t, err)
+	_, err = th.App.Srv.Store.PostPinEvent().Save(&model.PostPinEvent{PostId: first.Id, ChannelId: channel.Id, UserId: th.BasicUser2.Id, CreateAt: start + 20000})
+	require.Nil(t, err)
+
+	timeline, err := th.App.BuildChannelTimeline(channel, start, start+5000)
+	require.Nil(t, err)
+
+	ids := []string{}
+	for _, entry := range timeline.Entries {
+		ids = append(ids, entry.PostId)
+	}
+	assert.NotContains(t, ids, before.Id)
+	assert.NotContains(t, ids, after.Id)
+	require.Len(t, timeline.Entries, 5)
+
+	assert.Equal(t, atStart.Id, timeline.Entries[0].PostId)
+
+	assert.Equal(t, first.Id, timeline.Entries[1].PostId)
+	assert.Equal(t, model.CHANNEL_TIMELINE_EVENT_POST, timeline.Entries[1].Type)
+	assert.Equal(t, th.BasicUser.Username, timeline.Entries[1].Username)
+
+	assert.Equal(t, header.Id, timeline.Entries[2].PostId)
+	assert.Equal(t, model.CHANNEL_TIMELINE_EVENT_HEADER_CHANGE, timeline.Entries[2].Type)
+	assert.Equal(t, "header updated", timeline.Entries[2].Annotation)
+
+	assert.Equal(t, first.Id, timeline.Entries[3].PostId)
+	assert.Equal(t, model.CHANNEL_TIMELINE_EVENT_PIN, timeline.Entries[3].Type)
+	assert.Equal(t, th.BasicUser2.Username, timeline.Entries[3].Username)
+
+	assert.Equal(t, reply.Id, timeline.Entries[4].PostId)
+	assert.Equal(t, first.Id, timeline.Entries[4].RootId)
+}
+
+func TestForEachChannelPostPageBetween(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	start := model.GetMillis() - 60000
+
+	// More posts than fit on a page, all created in the same millisecond.
+	expected := map[string]bool{}
+	for i := 0; i < CHANNEL_TIMELINE_POSTS_PER_PAGE+5; i++ {
+		post, err := th.App.Srv.Store.Post().Save(&model.Post{UserId: th.BasicUser.Id, ChannelId: channel.Id, Message: "same time", CreateAt: start})
+		require.Nil(t, err)
+		expected[post.Id] = true
+	}
+
+	seen := map[string]bool{}
+	err := th.App.forEachChannelPostPageBetween(channel.Id, start, start, func(page []*model.Post) *model.AppError {
+		for _, post := range page {
+			assert.False(t, seen[post.Id], "post returned twice")
+			seen[post.Id] = true
+		}
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, expected, seen)
+}
+
+func TestExportChannelTimeline(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	start := th.BasicPost.CreateAt - 1000
+	job := &model.Job{
+		Id:     model.NewId(),
+		Type:   model.JOB_TYPE_CHANNEL_TIMELINE,
+		Status: model.JOB_STATUS_IN_PROGRESS,
+		Data: map[string]string{
+			model.CHANNEL_TIMELINE_JOB_DATA_CHANNEL_ID: th.BasicChannel.Id,
+			model.CHANNEL_TIMELINE_JOB_DATA_START_TIME: strconv.FormatInt(start, 10),
+			model.CHANNEL_TIMELINE_JOB_DATA_END_TIME:   strconv.FormatInt(start+60000, 10),
+			model.CHANNEL_TIMELINE_JOB_DATA_FORMAT:     model.CHANNEL_TIMELINE_FORMAT_JSON,
+		},
+	}
+
+	require.Nil(t, th.App.ExportChannelTimeline(job))
+	require.Equal(t, CHANNEL_TIMELINE_DIRECTORY+job.Id+".json", job.Data[model.CHANNEL_TIMELINE_JOB_DATA_FILE_PATH])
+	defer th.App.RemoveFile(job.Data[model.CHANNEL_TIMELINE_JOB_DATA_FILE_PATH])
+
+	_, err := th.App.GetChannelTimelineFile(job)
+	require.NotNil(t, err, "the export should not be available before the job succeeds")
+
+	job.Status = model.JOB_STATUS_SUCCESS
+	data, err := th.App.GetChannelTimelineFile(job)
+	require.Nil(t, err)
+
+	timeline := model.ChannelTimelineFromJson(bytes.NewReader(data))
+	require.NotNil(t, timeline)
+	assert.Equal(t, th.BasicChannel.Id, timeline.ChannelId)
+
+	found := false
+	for _, entry := range timeline.Entries {
+		if entry.PostId == th.BasicPost.Id {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	goi18n "github.com/mattermost/go-i18n/i18n"
 	"github.com/mattermost/mattermost-server/mlog"
@@ -150,12 +151,61 @@ func (a *App) ListAllCommands(teamId string, T goi18n.TranslateFunc) ([]*model.C
 	return commands, nil
 }
 
-func (a *App) ExecuteCommand(args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
+// CheckCommandArgs checks that the user of a session may run the given command in its channel, and sets the team the
+// command is looked up in.
+func (a *App) CheckCommandArgs(session model.Session, args *model.CommandArgs) *model.AppError {
+	if len(args.Command) <= 1 || strings.Index(args.Command, "/") != 0 || len(args.ChannelId) != 26 {
+		return model.NewAppError("CheckCommandArgs", "api.command.execute_command.start.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	permissionErr := model.NewAppError("Permissions", "api.context.permissions.app_error", nil, "userId="+session.UserId+", "+"permission="+model.PERMISSION_USE_SLASH_COMMANDS.Id, http.StatusForbidden)
+
+	// checks that user is a member of the specified channel, and that they have permission to use slash commands in it
+	if !a.SessionHasPermissionToChannel(session, args.ChannelId, model.PERMISSION_USE_SLASH_COMMANDS) {
+		return permissionErr
+	}
+
+	channel, err := a.GetChannel(args.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	if channel.Type != model.CHANNEL_DIRECT && channel.Type != model.CHANNEL_GROUP {
+		// if this isn't a DM or GM, the team id is implicitly taken from the channel so that slash commands created on
+		// some other team can't be run against this one
+		args.TeamId = channel.TeamId
+	} else {
+		// if the slash command was used in a DM or GM, ensure that the user is a member of the specified team, so that
+		// they can't just execute slash commands against arbitrary teams
+		if session.GetTeamByTeamId(args.TeamId) == nil {
+			if !a.SessionHasPermissionTo(session, model.PERMISSION_USE_SLASH_COMMANDS) {
+				return permissionErr
+			}
+		}
+	}
+
+	return nil
+}
+
+// getCommandTrigger returns the trigger of the command in the given text, which starts with a slash.
+func getCommandTrigger(command string) string {
+	parts := strings.Split(command, " ")
+	return strings.ToLower(parts[0][1:])
+}
+
+func (a *App) ExecuteCommand(args *model.CommandArgs) (response *model.CommandResponse, appErr *model.AppError) {
 	parts := strings.Split(args.Command, " ")
-	trigger := parts[0][1:]
-	trigger = strings.ToLower(trigger)
+	trigger := getCommandTrigger(args.Command)
 	message := strings.Join(parts[1:], " ")
 
+	defer func() {
+		a.logCommandExecution(args, trigger, appErr)
+	}()
+
+	if appErr = a.checkCommandRestriction(args, trigger); appErr != nil {
+		return nil, appErr
+	}
+
 	clientTriggerId, triggerId, appErr := model.GenerateTriggerId(args.UserId, a.AsymmetricSigningKey())
 	if appErr != nil {
 		mlog.Error(appErr.Error())
@@ -176,12 +226,20 @@ func (a *App) ExecuteCommand(args *model.CommandArgs) (*model.CommandResponse, *
 		return a.HandleCommandResponse(cmd, args, response, true)
 	}
 
+	start := time.Now()
 	cmd, response, appErr = a.tryExecuteCustomCommand(args, trigger, message)
+	if cmd != nil {
+		a.recordIntegrationRequest(model.INTEGRATION_TYPE_COMMAND, cmd.Id, time.Since(start), appErr != nil)
+	}
 	if appErr != nil {
 		return nil, appErr
 	} else if cmd != nil && response != nil {
 		response.TriggerId = clientTriggerId
-		return a.HandleCommandResponse(cmd, args, response, false)
+		response, appErr = a.HandleCommandResponse(cmd, args, response, false)
+		if appErr == nil {
+			a.recordIntegrationPost(model.INTEGRATION_TYPE_COMMAND, cmd.Id)
+		}
+		return response, appErr
 	}
 
 	return nil, model.NewAppError("command", "api.command.execute_command.not_found.app_error", map[string]interface{}{"Trigger": trigger}, "", http.StatusNotFound)
@@ -203,6 +261,23 @@ func (a *App) tryExecuteBuiltInCommand(args *model.CommandArgs, trigger string,
 	return cmd, provider.DoCommand(a, args, message)
 }
 
+// findCustomCommand returns the custom command of a team with the given trigger, or nil if there's none.
+func (a *App) findCustomCommand(teamId, trigger string) (*model.Command, *model.AppError) {
+	teamCmds, err := a.Srv.Store.Command().GetByTeam(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	var cmd *model.Command
+	for _, teamCmd := range teamCmds {
+		if trigger == teamCmd.Trigger {
+			cmd = teamCmd
+		}
+	}
+
+	return cmd, nil
+}
+
 // tryExecuteCustomCommand attempts to run a custom command based on the given arguments. If no such command can be
 // found, returns nil for all arguments.
 func (a *App) tryExecuteCustomCommand(args *model.CommandArgs, trigger string, message string) (*model.Command, *model.CommandResponse, *model.AppError) {
@@ -232,7 +307,7 @@ func (a *App) tryExecuteCustomCommand(args *model.CommandArgs, trigger string, m
 		close(userChan)
 	}()
 
-	teamCmds, err := a.Srv.Store.Command().GetByTeam(args.TeamId)
+	cmd, err := a.findCustomCommand(args.TeamId, trigger)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -255,14 +330,6 @@ func (a *App) tryExecuteCustomCommand(args *model.CommandArgs, trigger string, m
 	}
 	channel := cr.Data.(*model.Channel)
 
-	var cmd *model.Command
-
-	for _, teamCmd := range teamCmds {
-		if trigger == teamCmd.Trigger {
-			cmd = teamCmd
-		}
-	}
-
 	if cmd == nil {
 		return nil, nil, nil
 	}
@@ -292,10 +359,12 @@ func (a *App) tryExecuteCustomCommand(args *model.CommandArgs, trigger string, m
 	}
 	p.Set("response_url", args.SiteURL+"/hooks/commands/"+hook.Id)
 
-	return a.doCommandRequest(cmd, p)
+	return a.doCommandRequest(cmd, args, p)
 }
 
-func (a *App) doCommandRequest(cmd *model.Command, p url.Values) (*model.Command, *model.CommandResponse, *model.AppError) {
+// doCommandRequest sends the request of a custom command and reads its response. A command answering with a stream of
+// responses has them forwarded to the user who ran it as they come, and the last of them is the command's response.
+func (a *App) doCommandRequest(cmd *model.Command, args *model.CommandArgs, p url.Values) (*model.Command, *model.CommandResponse, *model.AppError) {
 	// Prepare the request
 	var req *http.Request
 	var err error
@@ -340,7 +409,12 @@ func (a *App) doCommandRequest(cmd *model.Command, p url.Values) (*model.Command
 		return cmd, nil, model.NewAppError("command", "api.command.execute_command.failed_resp.app_error", map[string]interface{}{"Trigger": cmd.Trigger, "Status": resp.Status}, string(bodyBytes), http.StatusInternalServerError)
 	}
 
-	response, err := model.CommandResponseFromHTTPBody(resp.Header.Get("Content-Type"), body)
+	var response *model.CommandResponse
+	if model.IsCommandResponseStream(resp.Header.Get("Content-Type")) {
+		response, err = a.streamCommandResponses(args, model.NewCommandResponseDecoder(body))
+	} else {
+		response, err = model.CommandResponseFromHTTPBody(resp.Header.Get("Content-Type"), body)
+	}
 	if err != nil {
 		return cmd, nil, model.NewAppError("command", "api.command.execute_command.failed.app_error", map[string]interface{}{"Trigger": cmd.Trigger}, err.Error(), http.StatusInternalServerError)
 	} else if response == nil {
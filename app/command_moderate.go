@@ -0,0 +1,127 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"strings"
+
+	goi18n "github.com/mattermost/go-i18n/i18n"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type ModerateProvider struct {
+}
+
+const (
+	CMD_MODERATE = "moderate"
+
+	CMD_MODERATE_LIST_MAX = 20
+)
+
+func init() {
+	RegisterCommandProvider(&ModerateProvider{})
+}
+
+func (me *ModerateProvider) GetTrigger() string {
+	return CMD_MODERATE
+}
+
+func (me *ModerateProvider) GetCommand(a *App, T goi18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CMD_MODERATE,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_moderate.desc"),
+		AutoCompleteHint: T("api.command_moderate.hint"),
+		DisplayName:      T("api.command_moderate.name"),
+	}
+}
+
+func (me *ModerateProvider) DoCommand(a *App, args *model.CommandArgs, message string) *model.CommandResponse {
+	fields := strings.Fields(message)
+	if len(fields) == 0 {
+		return moderateResponse(args.T("api.command_moderate.hint"))
+	}
+
+	switch fields[0] {
+	case "list":
+		return me.list(a, args)
+	case "approve", "reject":
+		if len(fields) != 2 {
+			return moderateResponse(args.T("api.command_moderate.hint"))
+		}
+		return me.review(a, args, fields[0] == "approve", fields[1])
+	}
+
+	return moderateResponse(args.T("api.command_moderate.hint"))
+}
+
+func (me *ModerateProvider) list(a *App, args *model.CommandArgs) *model.CommandResponse {
+	channel, err := a.GetChannel(args.ChannelId)
+	if err != nil {
+		return moderateResponse(args.T("api.command_moderate.no_channel.app_error"))
+	}
+
+	if !a.IsChannelModerator(args.UserId, channel) {
+		return moderateResponse(args.T("api.command_moderate.permission.app_error"))
+	}
+
+	pendingPosts, err := a.GetPendingPostsForChannel(channel.Id, 0, CMD_MODERATE_LIST_MAX)
+	if err != nil {
+		return moderateResponse(args.T("api.command_moderate.list.app_error"))
+	}
+
+	if len(pendingPosts) == 0 {
+		return moderateResponse(args.T("api.command_moderate.list.empty"))
+	}
+
+	lines := []string{args.T("api.command_moderate.list.header")}
+	for _, pendingPost := range pendingPosts {
+		username := pendingPost.UserId
+		if author, err := a.GetUser(pendingPost.UserId); err == nil {
+			username = author.Username
+		}
+
+		lines = append(lines, args.T("api.command_moderate.list.item", map[string]interface{}{
+			"Username":      username,
+			"Message":       pendingPost.Message,
+			"PendingPostId": pendingPost.Id,
+		}))
+	}
+
+	return moderateResponse(strings.Join(lines, "\n"))
+}
+
+func (me *ModerateProvider) review(a *App, args *model.CommandArgs, approve bool, pendingPostId string) *model.CommandResponse {
+	pendingPost, err := a.GetPendingPost(pendingPostId)
+	if err != nil {
+		return moderateResponse(args.T("api.command_moderate.not_found.app_error"))
+	}
+
+	channel, err := a.GetChannel(pendingPost.ChannelId)
+	if err != nil {
+		return moderateResponse(args.T("api.command_moderate.no_channel.app_error"))
+	}
+
+	if !a.IsChannelModerator(args.UserId, channel) {
+		return moderateResponse(args.T("api.command_moderate.permission.app_error"))
+	}
+
+	if approve {
+		if _, err = a.ApprovePendingPost(pendingPost.Id); err == nil {
+			return moderateResponse(args.T("api.command_moderate.approved"))
+		}
+	} else {
+		if err = a.RejectPendingPost(pendingPost.Id); err == nil {
+			return moderateResponse(args.T("api.command_moderate.rejected"))
+		}
+	}
+
+	err.Translate(args.T)
+	return moderateResponse(args.T("api.command_moderate.review.app_error", map[string]interface{}{"Error": err.Message}))
+}
+
+func moderateResponse(text string) *model.CommandResponse {
+	return &model.CommandResponse{Text: text, ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL}
+}
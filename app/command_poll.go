@@ -0,0 +1,190 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	goi18n "github.com/mattermost/go-i18n/i18n"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type PollProvider struct {
+}
+
+const (
+	CMD_POLL = "poll"
+)
+
+func init() {
+	RegisterCommandProvider(&PollProvider{})
+}
+
+func (me *PollProvider) GetTrigger() string {
+	return CMD_POLL
+}
+
+func (me *PollProvider) GetCommand(a *App, T goi18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CMD_POLL,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_poll.desc"),
+		AutoCompleteHint: T("api.command_poll.hint"),
+		DisplayName:      T("api.command_poll.name"),
+	}
+}
+
+func (me *PollProvider) DoCommand(a *App, args *model.CommandArgs, message string) *model.CommandResponse {
+	fields := strings.Fields(message)
+	if len(fields) == 0 {
+		return pollResponse(args.T("api.command_poll.hint"))
+	}
+
+	switch fields[0] {
+	case "vote":
+		if len(fields) != 3 {
+			return pollResponse(args.T("api.command_poll.hint"))
+		}
+		return me.vote(a, args, fields[1], fields[2])
+	case "results":
+		if len(fields) != 2 {
+			return pollResponse(args.T("api.command_poll.hint"))
+		}
+		return me.results(a, args, fields[1])
+	case "close":
+		if len(fields) != 2 {
+			return pollResponse(args.T("api.command_poll.hint"))
+		}
+		return me.close(a, args, fields[1])
+	}
+
+	return me.create(a, args, splitPollArgs(message))
+}
+
+func (me *PollProvider) create(a *App, args *model.CommandArgs, parts []string) *model.CommandResponse {
+	if len(parts) < 1+model.POLL_MIN_OPTIONS {
+		return pollResponse(args.T("api.command_poll.hint"))
+	}
+
+	if !a.SessionHasPermissionToChannel(args.Session, args.ChannelId, model.PERMISSION_CREATE_POST) {
+		return pollResponse(args.T("api.command_poll.permission.app_error"))
+	}
+
+	if _, err := a.CreatePoll(&model.Poll{
+		CreatorId: args.UserId,
+		ChannelId: args.ChannelId,
+		Question:  parts[0],
+		Options:   parts[1:],
+	}, args.T); err != nil {
+		return pollErrorResponse(args, err)
+	}
+
+	return &model.CommandResponse{}
+}
+
+// getPoll returns the given poll if the user can read its channel.
+func (me *PollProvider) getPoll(a *App, args *model.CommandArgs, pollId string) (*model.Poll, *model.CommandResponse) {
+	if !model.IsValidId(pollId) {
+		return nil, pollResponse(args.T("api.command_poll.not_found.app_error"))
+	}
+
+	poll, err := a.GetPoll(pollId)
+	if err != nil || !a.SessionHasPermissionToChannel(args.Session, poll.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		return nil, pollResponse(args.T("api.command_poll.not_found.app_error"))
+	}
+
+	return poll, nil
+}
+
+func (me *PollProvider) vote(a *App, args *model.CommandArgs, pollId, number string) *model.CommandResponse {
+	poll, resp := me.getPoll(a, args, pollId)
+	if resp != nil {
+		return resp
+	}
+
+	index, parseErr := strconv.Atoi(number)
+	if parseErr != nil {
+		return pollResponse(args.T("api.command_poll.hint"))
+	}
+
+	if err := a.VotePoll(poll, args.UserId, index-1); err != nil {
+		return pollErrorResponse(args, err)
+	}
+
+	return pollResponse(args.T("api.command_poll.voted", map[string]interface{}{"Option": poll.Options[index-1]}))
+}
+
+func (me *PollProvider) results(a *App, args *model.CommandArgs, pollId string) *model.CommandResponse {
+	poll, resp := me.getPoll(a, args, pollId)
+	if resp != nil {
+		return resp
+	}
+
+	results, err := a.GetPollResults(poll)
+	if err != nil {
+		return pollErrorResponse(args, err)
+	}
+
+	return pollResponse(a.pollResultsMessage(poll, results, args.T))
+}
+
+func (me *PollProvider) close(a *App, args *model.CommandArgs, pollId string) *model.CommandResponse {
+	poll, resp := me.getPoll(a, args, pollId)
+	if resp != nil {
+		return resp
+	}
+
+	if _, err := a.ClosePoll(poll, args.UserId, args.T); err != nil {
+		return pollErrorResponse(args, err)
+	}
+
+	return pollResponse(args.T("api.command_poll.closed"))
+}
+
+// splitPollArgs splits the text of a /poll command into its question and options, which are quoted when they contain
+// spaces, e.g. "Where should we eat?" Pizza "Sushi bar".
+func splitPollArgs(text string) []string {
+	var parts []string
+	var current strings.Builder
+	quoted := false
+	inPart := false
+
+	for _, r := range text {
+		switch {
+		case r == '"':
+			if quoted {
+				parts = append(parts, current.String())
+				current.Reset()
+				inPart = false
+			}
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if inPart {
+				parts = append(parts, current.String())
+				current.Reset()
+				inPart = false
+			}
+		default:
+			current.WriteRune(r)
+			inPart = true
+		}
+	}
+
+	if inPart {
+		parts = append(parts, current.String())
+	}
+
+	return parts
+}
+
+func pollErrorResponse(args *model.CommandArgs, err *model.AppError) *model.CommandResponse {
+	err.Translate(args.T)
+	return pollResponse(args.T("api.command_poll.app_error", map[string]interface{}{"Error": err.Message}))
+}
+
+func pollResponse(text string) *model.CommandResponse {
+	return &model.CommandResponse{Text: text, ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/go-i18n/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestSplitPollArgs(t *testing.T) {
+	assert.Equal(t, []string{"Where should we eat?", "Pizza", "Sushi bar"}, splitPollArgs(`"Where should we eat?" Pizza "Sushi bar"`))
+	assert.Equal(t, []string{"Lunch?", "yes", "no"}, splitPollArgs("Lunch?  yes\tno"))
+	assert.Empty(t, splitPollArgs("   "))
+}
+
+func TestPollCommand(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	cmd := &PollProvider{}
+	args := &model.CommandArgs{
+		T:         i18n.IdentityTfunc(),
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Session:   model.Session{UserId: th.BasicUser.Id, Roles: model.SYSTEM_USER_ROLE_ID},
+	}
+
+	resp := cmd.DoCommand(th.App, args, `"Where should we eat?"`)
+	assert.Equal(t, "api.command_poll.hint", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, `"Where should we eat?" Pizza "Sushi bar"`)
+	assert.Empty(t, resp.Text)
+
+	posts, err := th.App.GetPostsPage(th.BasicChannel.Id, 0, 1)
+	require.Nil(t, err)
+	post := posts.Posts[posts.Order[0]]
+	pollId, _ := post.Props[model.POST_PROPS_POLL_ID].(string)
+	require.NotEmpty(t, pollId)
+
+	poll, err := th.App.GetPoll(pollId)
+	require.Nil(t, err)
+	assert.Equal(t, post.Id, poll.PostId)
+	assert.Equal(t, model.StringArray{"Pizza", "Sushi bar"}, poll.Options)
+
+	resp = cmd.DoCommand(th.App, args, "vote "+pollId+" 2")
+	assert.Equal(t, "api.command_poll.voted", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, "vote "+pollId+" 3")
+	assert.Equal(t, "api.command_poll.app_error", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, "vote "+model.NewId()+" 1")
+	assert.Equal(t, "api.command_poll.not_found.app_error", resp.Text)
+
+	results, err := th.App.GetPollResults(poll)
+	require.Nil(t, err)
+	assert.Equal(t, []int{0, 1}, results)
+
+	otherArgs := *args
+	otherArgs.UserId = th.BasicUser2.Id
+	otherArgs.Session = model.Session{UserId: th.BasicUser2.Id, Roles: model.SYSTEM_USER_ROLE_ID}
+
+	resp = cmd.DoCommand(th.App, &otherArgs, "close "+pollId)
+	assert.Equal(t, "api.command_poll.app_error", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, "close "+pollId)
+	assert.Equal(t, "api.command_poll.closed", resp.Text)
+
+	resp = cmd.DoCommand(th.App, &otherArgs, "vote "+pollId+" 1")
+	assert.Equal(t, "api.command_poll.app_error", resp.Text)
+
+	thread, err := th.App.GetPostThread(post.Id)
+	require.Nil(t, err)
+	assert.Len(t, thread.Order, 2)
+}
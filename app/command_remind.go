@@ -0,0 +1,163 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	goi18n "github.com/mattermost/go-i18n/i18n"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type RemindProvider struct {
+}
+
+const (
+	CMD_REMIND = "remind"
+
+	// The longest a reminder can be set for.
+	REMIND_MAX_DELAY = 365 * 24 * time.Hour
+)
+
+func init() {
+	RegisterCommandProvider(&RemindProvider{})
+}
+
+func (me *RemindProvider) GetTrigger() string {
+	return CMD_REMIND
+}
+
+func (me *RemindProvider) GetCommand(a *App, T goi18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CMD_REMIND,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_remind.desc"),
+		AutoCompleteHint: T("api.command_remind.hint"),
+		DisplayName:      T("api.command_remind.name"),
+	}
+}
+
+func (me *RemindProvider) DoCommand(a *App, args *model.CommandArgs, message string) *model.CommandResponse {
+	fields := strings.Fields(message)
+	if len(fields) == 0 {
+		return remindResponse(args.T("api.command_remind.hint"))
+	}
+
+	switch fields[0] {
+	case "list":
+		return me.list(a, args)
+	case "delete":
+		if len(fields) != 2 {
+			return remindResponse(args.T("api.command_remind.hint"))
+		}
+		return me.delete(a, args, fields[1])
+	}
+
+	delay, ok := parseRemindDelay(fields[0])
+	if !ok {
+		return remindResponse(args.T("api.command_remind.hint"))
+	}
+
+	text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(message), fields[0]))
+	if text == "" {
+		return remindResponse(args.T("api.command_remind.hint"))
+	}
+
+	remindAt := time.Now().Add(delay)
+	if _, err := a.AddReminder(args.UserId, text, model.GetMillisForTime(remindAt)); err != nil {
+		return remindErrorResponse(args, err)
+	}
+
+	return remindResponse(args.T("api.command_remind.added", map[string]interface{}{
+		"Message": text,
+		"Time":    remindAt.UTC().Format(time.RFC1123),
+	}))
+}
+
+func (me *RemindProvider) list(a *App, args *model.CommandArgs) *model.CommandResponse {
+	reminders, err := a.GetPendingReminders(args.UserId)
+	if err != nil {
+		return remindErrorResponse(args, err)
+	}
+
+	if len(reminders) == 0 {
+		return remindResponse(args.T("api.command_remind.list.empty"))
+	}
+
+	lines := []string{args.T("api.command_remind.list.header")}
+	for i, reminder := range reminders {
+		lines = append(lines, args.T("api.command_remind.list.item", map[string]interface{}{
+			"Number":  i + 1,
+			"Message": reminder.Message,
+			"Time":    time.Unix(reminder.RemindAt/1000, 0).UTC().Format(time.RFC1123),
+		}))
+	}
+
+	return remindResponse(strings.Join(lines, "\n"))
+}
+
+// delete cancels a reminder of the user, given its number in the list of their pending reminders.
+func (me *RemindProvider) delete(a *App, args *model.CommandArgs, number string) *model.CommandResponse {
+	reminders, err := a.GetPendingReminders(args.UserId)
+	if err != nil {
+		return remindErrorResponse(args, err)
+	}
+
+	index, parseErr := strconv.Atoi(number)
+	if parseErr != nil || index < 1 || index > len(reminders) {
+		return remindResponse(args.T("api.command_remind.not_found.app_error", map[string]interface{}{"Number": number}))
+	}
+	reminder := reminders[index-1]
+
+	if err := a.DeleteReminder(args.UserId, reminder.Id); err != nil {
+		return remindErrorResponse(args, err)
+	}
+
+	return remindResponse(args.T("api.command_remind.deleted", map[string]interface{}{"Message": reminder.Message}))
+}
+
+// parseRemindDelay parses how long from now to send a reminder, as a positive number of minutes, hours, days or weeks,
+// e.g. 30m, 2h, 1d or 1w.
+func parseRemindDelay(text string) (time.Duration, bool) {
+	if len(text) < 2 {
+		return 0, false
+	}
+
+	count, err := strconv.Atoi(text[:len(text)-1])
+	if err != nil || count <= 0 {
+		return 0, false
+	}
+
+	var unit time.Duration
+	switch text[len(text)-1] {
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, false
+	}
+
+	if time.Duration(count) > REMIND_MAX_DELAY/unit {
+		return 0, false
+	}
+
+	return time.Duration(count) * unit, true
+}
+
+func remindErrorResponse(args *model.CommandArgs, err *model.AppError) *model.CommandResponse {
+	err.Translate(args.T)
+	return remindResponse(args.T("api.command_remind.app_error", map[string]interface{}{"Error": err.Message}))
+}
+
+func remindResponse(text string) *model.CommandResponse {
+	return &model.CommandResponse{Text: text, ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/go-i18n/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestParseRemindDelay(t *testing.T) {
+	for text, expected := range map[string]time.Duration{
+		"30m": 30 * time.Minute,
+		"2h":  2 * time.Hour,
+		"1d":  24 * time.Hour,
+		"1w":  7 * 24 * time.Hour,
+	} {
+		delay, ok := parseRemindDelay(text)
+		assert.True(t, ok, text)
+		assert.Equal(t, expected, delay, text)
+	}
+
+	for _, text := range []string{"", "m", "0m", "-1h", "2y", "abc", "60w"} {
+		_, ok := parseRemindDelay(text)
+		assert.False(t, ok, text)
+	}
+}
+
+func TestRemindCommand(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	cmd := &RemindProvider{}
+	args := &model.CommandArgs{
+		T:      i18n.IdentityTfunc(),
+		UserId: th.BasicUser.Id,
+	}
+
+	resp := cmd.DoCommand(th.App, args, "list")
+	assert.Equal(t, "api.command_remind.list.empty", resp.Text)
+	assert.Equal(t, model.COMMAND_RESPONSE_TYPE_EPHEMERAL, resp.ResponseType)
+
+	resp = cmd.DoCommand(th.App, args, "2h Submit the report")
+	assert.Equal(t, "api.command_remind.added", resp.Text)
+	resp = cmd.DoCommand(th.App, args, "30m Book the venue")
+	assert.Equal(t, "api.command_remind.added", resp.Text)
+
+	reminders, err := th.App.GetPendingReminders(th.BasicUser.Id)
+	require.Nil(t, err)
+	require.Len(t, reminders, 2)
+	assert.Equal(t, "Book the venue", reminders[0].Message)
+
+	resp = cmd.DoCommand(th.App, args, "list")
+	assert.Equal(t, "api.command_remind.list.header\napi.command_remind.list.item\napi.command_remind.list.item", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, "delete 1")
+	assert.Equal(t, "api.command_remind.deleted", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, "delete 2")
+	assert.Equal(t, "api.command_remind.not_found.app_error", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, "2h")
+	assert.Equal(t, "api.command_remind.hint", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, "tomorrow Submit the report")
+	assert.Equal(t, "api.command_remind.hint", resp.Text)
+}
+
+func TestSendDueReminders(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	reminder, err := th.App.AddReminder(th.BasicUser.Id, "Submit the report", model.GetMillis()-1000)
+	require.Nil(t, err)
+
+	th.App.SendDueReminders()
+
+	reminder, err = th.App.Srv.Store.Reminder().Get(reminder.Id)
+	require.Nil(t, err)
+	assert.True(t, reminder.IsSent())
+
+	bot, err := th.App.GetSystemBot()
+	require.Nil(t, err)
+	channel, err := th.App.GetOrCreateDirectChannel(bot.UserId, th.BasicUser.Id)
+	require.Nil(t, err)
+	posts, err := th.App.GetPostsPage(channel.Id, 0, 1)
+	require.Nil(t, err)
+	require.Len(t, posts.Order, 1)
+	assert.Contains(t, posts.Posts[posts.Order[0]].Message, "Submit the report")
+
+	err = th.App.DeleteReminder(th.BasicUser2.Id, reminder.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.reminder.not_found.app_error", err.Id)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// checkCommandRestriction returns an error when the command with the given trigger is restricted to other channels, or
+// to roles the user running it doesn't have.
+func (a *App) checkCommandRestriction(args *model.CommandArgs, trigger string) *model.AppError {
+	restriction := a.Config().ServiceSettings.CommandRestrictions[trigger]
+	if restriction == nil {
+		return nil
+	}
+
+	if !restriction.AllowsChannel(args.ChannelId) {
+		return model.NewAppError("ExecuteCommand", "api.command.execute_command.restricted_channel.app_error", map[string]interface{}{"Trigger": trigger}, "channel_id="+args.ChannelId, http.StatusForbidden)
+	}
+
+	if !restriction.AllowsRoles(a.getCommandUserRoles(args)) {
+		return model.NewAppError("ExecuteCommand", "api.command.execute_command.restricted_role.app_error", map[string]interface{}{"Trigger": trigger}, "user_id="+args.UserId, http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// commandAllowedInChannel returns whether the command with the given trigger isn't restricted to other channels.
+func (a *App) commandAllowedInChannel(trigger, channelId string) bool {
+	restriction := a.Config().ServiceSettings.CommandRestrictions[trigger]
+	return restriction == nil || restriction.AllowsChannel(channelId)
+}
+
+// getCommandUserRoles returns the system, team and channel roles of the user running a command.
+func (a *App) getCommandUserRoles(args *model.CommandArgs) []string {
+	var roles []string
+
+	if user, err := a.GetUser(args.UserId); err == nil {
+		roles = append(roles, user.GetRoles()...)
+	}
+
+	if args.TeamId != "" {
+		if member, err := a.GetTeamMember(args.TeamId, args.UserId); err == nil {
+			roles = append(roles, member.GetRoles()...)
+		}
+	}
+
+	if member, err := a.GetChannelMember(args.ChannelId, args.UserId); err == nil {
+		roles = append(roles, member.GetRoles()...)
+	}
+
+	return roles
+}
+
+// logCommandExecution records the outcome of running a command in the audit log. Only the trigger of the command is
+// recorded, since its text may hold anything the user typed.
+func (a *App) logCommandExecution(args *model.CommandArgs, trigger string, err *model.AppError) {
+	event := &model.AuditEvent{
+		ActorId:   args.UserId,
+		SessionId: args.Session.Id,
+		Action:    "command/execute",
+		Target: model.StringMap{
+			"trigger":    trigger,
+			"team_id":    args.TeamId,
+			"channel_id": args.ChannelId,
+		},
+		Result: model.AUDIT_RESULT_SUCCESS,
+	}
+
+	if err != nil {
+		event.Result = model.AUDIT_RESULT_FAIL
+		event.Details = err.Id
+	}
+
+	a.LogAuditEvent(event)
+}
+
+// ExplainCommand describes what running the given command would do, without running it. The URL of a custom command
+// is only described to the users who may manage the commands of its team.
+func (a *App) ExplainCommand(args *model.CommandArgs) (*model.CommandExplanation, *model.AppError) {
+	trigger := getCommandTrigger(args.Command)
+	explanation := &model.CommandExplanation{Trigger: trigger}
+
+	if provider := GetCommandProvider(trigger); provider != nil {
+		if cmd := provider.GetCommand(a, args.T); cmd != nil {
+			explanation.Source = model.COMMAND_SOURCE_BUILT_IN
+			explanation.DisplayName = cmd.DisplayName
+			explanation.Description = cmd.AutoCompleteDesc
+		}
+	}
+
+	if explanation.Source == "" {
+		if pc := a.findPluginCommand(args.TeamId, trigger); pc != nil {
+			explanation.Source = model.COMMAND_SOURCE_PLUGIN
+			explanation.PluginId = pc.PluginId
+			explanation.DisplayName = pc.Command.DisplayName
+			explanation.Description = pc.Command.AutoCompleteDesc
+		}
+	}
+
+	if explanation.Source == "" && *a.Config().ServiceSettings.EnableCommands {
+		cmd, err := a.findCustomCommand(args.TeamId, trigger)
+		if err != nil {
+			return nil, err
+		}
+
+		if cmd != nil {
+			explanation.Source = model.COMMAND_SOURCE_CUSTOM
+			explanation.CommandId = cmd.Id
+			explanation.DisplayName = cmd.DisplayName
+			explanation.Description = cmd.Description
+			explanation.Method = cmd.Method
+			if a.SessionHasPermissionToTeam(args.Session, cmd.TeamId, model.PERMISSION_MANAGE_SLASH_COMMANDS) {
+				explanation.URL = cmd.URL
+			}
+		}
+	}
+
+	if explanation.Source == "" {
+		return nil, model.NewAppError("ExplainCommand", "api.command.execute_command.not_found.app_error", map[string]interface{}{"Trigger": trigger}, "", http.StatusNotFound)
+	}
+
+	explanation.Restriction = a.Config().ServiceSettings.CommandRestrictions[trigger]
+	explanation.Allowed = true
+	if err := a.checkCommandRestriction(args, trigger); err != nil {
+		err.Translate(args.T)
+		explanation.Allowed = false
+		explanation.Reason = err.Message
+	}
+
+	return explanation, nil
+}
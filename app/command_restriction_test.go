@@ -0,0 +1,73 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/go-i18n/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCommandRestrictions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	args := &model.CommandArgs{
+		T:         i18n.IdentityTfunc(),
+		UserId:    th.BasicUser.Id,
+		TeamId:    th.BasicTeam.Id,
+		ChannelId: th.BasicChannel.Id,
+		Command:   "/shrug",
+	}
+
+	_, err := th.App.ExecuteCommand(args)
+	require.Nil(t, err)
+
+	t.Run("restricted to roles", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.CommandRestrictions = map[string]*model.CommandRestriction{
+				"shrug": {Roles: []string{model.TEAM_ADMIN_ROLE_ID}},
+			}
+		})
+
+		_, err := th.App.ExecuteCommand(args)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.command.execute_command.restricted_role.app_error", err.Id)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+
+		th.App.UpdateTeamMemberRoles(th.BasicTeam.Id, th.BasicUser.Id, model.TEAM_USER_ROLE_ID+" "+model.TEAM_ADMIN_ROLE_ID)
+		_, err = th.App.ExecuteCommand(args)
+		require.Nil(t, err)
+	})
+
+	t.Run("restricted to channels", func(t *testing.T) {
+		channel2 := th.CreateChannel(th.BasicTeam)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.CommandRestrictions = map[string]*model.CommandRestriction{
+				"shrug": {ChannelIds: []string{channel2.Id}},
+			}
+		})
+
+		_, err := th.App.ExecuteCommand(args)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.command.execute_command.restricted_channel.app_error", err.Id)
+
+		explanation, err := th.App.ExplainCommand(args)
+		require.Nil(t, err)
+		assert.Equal(t, model.COMMAND_SOURCE_BUILT_IN, explanation.Source)
+		assert.False(t, explanation.Allowed)
+		assert.Equal(t, "api.command.execute_command.restricted_channel.app_error", explanation.Reason)
+
+		channelArgs := *args
+		channelArgs.ChannelId = channel2.Id
+		_, err = th.App.ExecuteCommand(&channelArgs)
+		require.Nil(t, err)
+	})
+}
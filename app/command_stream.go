@@ -0,0 +1,121 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	COMMAND_STREAM_CACHE_SIZE = 10000
+	COMMAND_STREAM_CACHE_SEC  = 60 * 60
+)
+
+// CommandResponseStream forwards the responses of a long running command to the user who ran it as they come, as
+// updates of a single ephemeral post in the channel the command was run in, so that they can follow its progress.
+type CommandResponseStream struct {
+	app  *App
+	args *model.CommandArgs
+
+	mutex    sync.Mutex
+	postId   string
+	createAt int64
+}
+
+func (a *App) NewCommandResponseStream(args *model.CommandArgs) *CommandResponseStream {
+	return &CommandResponseStream{
+		app:  a,
+		args: args,
+	}
+}
+
+// Update replaces the text and attachments of the progress post with those of the response, sending the post if it
+// hasn't been sent yet.
+func (s *CommandResponseStream) Update(response *model.CommandResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	post := &model.Post{
+		ChannelId: s.args.ChannelId,
+		RootId:    s.args.RootId,
+		UserId:    s.args.UserId,
+		Message:   model.ParseSlackLinksToMarkdown(s.app.ProcessSlackText(response.Text)),
+	}
+	if response.Attachments != nil {
+		model.ParseSlackAttachment(post, s.app.ProcessSlackAttachments(response.Attachments))
+	}
+
+	if s.postId == "" {
+		post = s.app.SendEphemeralPost(s.args.UserId, post)
+		s.postId = post.Id
+		s.createAt = post.CreateAt
+		return
+	}
+
+	post.Id = s.postId
+	post.CreateAt = s.createAt
+	s.app.UpdateEphemeralPost(s.args.UserId, post)
+}
+
+// Close removes the progress post once the command is over and its final response replaces it.
+func (s *CommandResponseStream) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.postId == "" {
+		return
+	}
+
+	s.app.DeleteEphemeralPost(s.args.UserId, s.postId)
+	s.postId = ""
+}
+
+// StreamCommandResponse updates the progress post of a command run by a plugin, whose responses keep coming after its
+// ExecuteCommand hook returned. The responses of a command are recognized by the trigger id it was run with, for up to
+// an hour.
+func (a *App) StreamCommandResponse(args *model.CommandArgs, response *model.CommandResponse) *model.AppError {
+	if args == nil || args.TriggerId == "" || !model.IsValidId(args.UserId) || !model.IsValidId(args.ChannelId) {
+		return model.NewAppError("StreamCommandResponse", "app.command.stream_response.invalid_args.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if response == nil {
+		return model.NewAppError("StreamCommandResponse", "app.command.stream_response.invalid_response.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	var stream *CommandResponseStream
+	if cached, ok := a.Srv.commandStreamCache.Get(args.TriggerId); ok {
+		stream = cached.(*CommandResponseStream)
+	} else {
+		stream = a.NewCommandResponseStream(args)
+	}
+
+	stream.Update(response)
+	a.Srv.commandStreamCache.AddWithExpiresInSecs(args.TriggerId, stream, COMMAND_STREAM_CACHE_SEC)
+
+	return nil
+}
+
+// streamCommandResponses forwards the responses read from a command answering with a stream of them to the user who
+// ran it, and returns the last one as the response of the command.
+func (a *App) streamCommandResponses(args *model.CommandArgs, decoder *model.CommandResponseDecoder) (*model.CommandResponse, error) {
+	stream := a.NewCommandResponseStream(args)
+	defer stream.Close()
+
+	var last *model.CommandResponse
+	for {
+		response, err := decoder.Next()
+		if err == io.EOF {
+			return last, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		stream.Update(response)
+		last = response
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestStreamCommandResponse(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	_, triggerId, err := model.GenerateTriggerId(th.BasicUser.Id, th.App.AsymmetricSigningKey())
+	require.Nil(t, err)
+
+	args := &model.CommandArgs{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, TriggerId: triggerId}
+
+	require.Nil(t, th.App.StreamCommandResponse(args, &model.CommandResponse{Text: "Building"}))
+	cached, ok := th.App.Srv.commandStreamCache.Get(triggerId)
+	require.True(t, ok)
+	stream := cached.(*CommandResponseStream)
+	postId := stream.postId
+	require.NotEmpty(t, postId)
+
+	require.Nil(t, th.App.StreamCommandResponse(args, &model.CommandResponse{Text: "Deploying"}))
+	assert.Equal(t, postId, stream.postId, "the progress post should be updated rather than sent again")
+
+	stream.Close()
+	assert.Empty(t, stream.postId)
+
+	err = th.App.StreamCommandResponse(&model.CommandArgs{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id}, &model.CommandResponse{Text: "Building"})
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+
+	err = th.App.StreamCommandResponse(args, nil)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+}
@@ -281,7 +281,7 @@ func TestDoCommandRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, resp, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, url.Values{})
+		_, resp, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, &model.CommandArgs{}, url.Values{})
 		require.Nil(t, err)
 
 		assert.NotNil(t, resp)
@@ -296,7 +296,7 @@ func TestDoCommandRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, resp, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, url.Values{})
+		_, resp, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, &model.CommandArgs{}, url.Values{})
 		require.Nil(t, err)
 
 		assert.NotNil(t, resp)
@@ -311,7 +311,7 @@ func TestDoCommandRequest(t *testing.T) {
 
 		// Since we limit the length of the response, no error will be returned and resp.Text will be a finite string
 
-		_, resp, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, url.Values{})
+		_, resp, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, &model.CommandArgs{}, url.Values{})
 		require.Nil(t, err)
 		require.NotNil(t, resp)
 	})
@@ -324,7 +324,7 @@ func TestDoCommandRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, _, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, url.Values{})
+		_, _, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, &model.CommandArgs{}, url.Values{})
 		require.NotNil(t, err)
 		require.Equal(t, "api.command.execute_command.failed.app_error", err.Id)
 	})
@@ -337,7 +337,38 @@ func TestDoCommandRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, _, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, url.Values{})
+		_, _, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, &model.CommandArgs{}, url.Values{})
+		require.NotNil(t, err)
+		require.Equal(t, "api.command.execute_command.failed.app_error", err.Id)
+	})
+
+	t.Run("with a streamed response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", model.COMMAND_RESPONSE_CONTENT_TYPE_STREAM)
+
+			io.Copy(w, strings.NewReader(`{"text": "Building"}`+"\n"+`{"text": "Deploying"}`+"\n"+`{"response_type": "in_channel", "text": "Deployed"}`+"\n"))
+		}))
+		defer server.Close()
+
+		args := &model.CommandArgs{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id}
+		_, resp, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, args, url.Values{})
+		require.Nil(t, err)
+
+		require.NotNil(t, resp)
+		assert.Equal(t, model.COMMAND_RESPONSE_TYPE_IN_CHANNEL, resp.ResponseType)
+		assert.Equal(t, "Deployed", resp.Text)
+	})
+
+	t.Run("with an invalid streamed response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", model.COMMAND_RESPONSE_CONTENT_TYPE_STREAM)
+
+			io.Copy(w, strings.NewReader(`{"text": "Building"}`+"\n"+`{"text": `))
+		}))
+		defer server.Close()
+
+		args := &model.CommandArgs{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id}
+		_, _, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, args, url.Values{})
 		require.NotNil(t, err)
 		require.Equal(t, "api.command.execute_command.failed.app_error", err.Id)
 	})
@@ -355,7 +386,7 @@ func TestDoCommandRequest(t *testing.T) {
 			th.App.HTTPService.(*httpservice.HTTPServiceImpl).RequestTimeout = httpservice.RequestTimeout
 		}()
 
-		_, _, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, url.Values{})
+		_, _, err := th.App.doCommandRequest(&model.Command{URL: server.URL}, &model.CommandArgs{}, url.Values{})
 		require.NotNil(t, err)
 		require.Equal(t, "api.command.execute_command.failed.app_error", err.Id)
 		close(done)
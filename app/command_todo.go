@@ -0,0 +1,139 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	goi18n "github.com/mattermost/go-i18n/i18n"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type TodoProvider struct {
+}
+
+const (
+	CMD_TODO = "todo"
+)
+
+func init() {
+	RegisterCommandProvider(&TodoProvider{})
+}
+
+func (me *TodoProvider) GetTrigger() string {
+	return CMD_TODO
+}
+
+func (me *TodoProvider) GetCommand(a *App, T goi18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CMD_TODO,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_todo.desc"),
+		AutoCompleteHint: T("api.command_todo.hint"),
+		DisplayName:      T("api.command_todo.name"),
+	}
+}
+
+func (me *TodoProvider) DoCommand(a *App, args *model.CommandArgs, message string) *model.CommandResponse {
+	fields := strings.Fields(message)
+	if len(fields) == 0 {
+		return me.list(a, args)
+	}
+
+	switch fields[0] {
+	case "list":
+		return me.list(a, args)
+	case "add":
+		text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(message), "add"))
+		if text == "" {
+			return todoResponse(args.T("api.command_todo.hint"))
+		}
+		return me.add(a, args, text)
+	case "done", "remove":
+		if len(fields) != 2 {
+			return todoResponse(args.T("api.command_todo.hint"))
+		}
+		return me.update(a, args, fields[0] == "done", fields[1])
+	case "clear":
+		return me.clear(a, args)
+	}
+
+	return todoResponse(args.T("api.command_todo.hint"))
+}
+
+func (me *TodoProvider) list(a *App, args *model.CommandArgs) *model.CommandResponse {
+	todos, err := a.GetTodos(args.UserId, false)
+	if err != nil {
+		return todoErrorResponse(args, err)
+	}
+
+	if len(todos) == 0 {
+		return todoResponse(args.T("api.command_todo.list.empty"))
+	}
+
+	lines := []string{args.T("api.command_todo.list.header")}
+	for i, todo := range todos {
+		lines = append(lines, args.T("api.command_todo.list.item", map[string]interface{}{
+			"Number":  i + 1,
+			"Message": todo.Message,
+		}))
+	}
+
+	return todoResponse(strings.Join(lines, "\n"))
+}
+
+func (me *TodoProvider) add(a *App, args *model.CommandArgs, text string) *model.CommandResponse {
+	todo, err := a.AddTodo(args.UserId, text)
+	if err != nil {
+		return todoErrorResponse(args, err)
+	}
+
+	return todoResponse(args.T("api.command_todo.added", map[string]interface{}{"Message": todo.Message}))
+}
+
+// update completes or removes an item of the user's todo list, given its number in the list of the items left to do.
+func (me *TodoProvider) update(a *App, args *model.CommandArgs, complete bool, number string) *model.CommandResponse {
+	todos, err := a.GetTodos(args.UserId, false)
+	if err != nil {
+		return todoErrorResponse(args, err)
+	}
+
+	index, parseErr := strconv.Atoi(number)
+	if parseErr != nil || index < 1 || index > len(todos) {
+		return todoResponse(args.T("api.command_todo.not_found.app_error", map[string]interface{}{"Number": number}))
+	}
+	todo := todos[index-1]
+
+	if complete {
+		if _, err = a.CompleteTodo(args.UserId, todo.Id); err != nil {
+			return todoErrorResponse(args, err)
+		}
+		return todoResponse(args.T("api.command_todo.completed", map[string]interface{}{"Message": todo.Message}))
+	}
+
+	if err = a.DeleteTodo(args.UserId, todo.Id); err != nil {
+		return todoErrorResponse(args, err)
+	}
+	return todoResponse(args.T("api.command_todo.removed", map[string]interface{}{"Message": todo.Message}))
+}
+
+func (me *TodoProvider) clear(a *App, args *model.CommandArgs) *model.CommandResponse {
+	count, err := a.ClearCompletedTodos(args.UserId)
+	if err != nil {
+		return todoErrorResponse(args, err)
+	}
+
+	return todoResponse(args.T("api.command_todo.cleared", map[string]interface{}{"Count": count}))
+}
+
+func todoErrorResponse(args *model.CommandArgs, err *model.AppError) *model.CommandResponse {
+	err.Translate(args.T)
+	return todoResponse(args.T("api.command_todo.app_error", map[string]interface{}{"Error": err.Message}))
+}
+
+func todoResponse(text string) *model.CommandResponse {
+	return &model.CommandResponse{Text: text, ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL}
+}
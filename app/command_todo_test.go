@@ -0,0 +1,89 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/go-i18n/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestTodoCommand(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	cmd := &TodoProvider{}
+	args := &model.CommandArgs{
+		T:      i18n.IdentityTfunc(),
+		UserId: th.BasicUser.Id,
+	}
+
+	resp := cmd.DoCommand(th.App, args, "")
+	assert.Equal(t, "api.command_todo.list.empty", resp.Text)
+	assert.Equal(t, model.COMMAND_RESPONSE_TYPE_EPHEMERAL, resp.ResponseType)
+
+	resp = cmd.DoCommand(th.App, args, "add Review the release notes")
+	assert.Equal(t, "api.command_todo.added", resp.Text)
+	resp = cmd.DoCommand(th.App, args, "add Book the venue")
+	assert.Equal(t, "api.command_todo.added", resp.Text)
+
+	todos, err := th.App.GetTodos(th.BasicUser.Id, false)
+	require.Nil(t, err)
+	require.Len(t, todos, 2)
+	assert.Equal(t, "Review the release notes", todos[0].Message)
+
+	resp = cmd.DoCommand(th.App, args, "list")
+	assert.Equal(t, "api.command_todo.list.header\napi.command_todo.list.item\napi.command_todo.list.item", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, "done 1")
+	assert.Equal(t, "api.command_todo.completed", resp.Text)
+
+	todos, err = th.App.GetTodos(th.BasicUser.Id, false)
+	require.Nil(t, err)
+	require.Len(t, todos, 1)
+	assert.Equal(t, "Book the venue", todos[0].Message)
+
+	resp = cmd.DoCommand(th.App, args, "done 2")
+	assert.Equal(t, "api.command_todo.not_found.app_error", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, "clear")
+	assert.Equal(t, "api.command_todo.cleared", resp.Text)
+
+	todos, err = th.App.GetTodos(th.BasicUser.Id, true)
+	require.Nil(t, err)
+	require.Len(t, todos, 1)
+
+	resp = cmd.DoCommand(th.App, args, "remove 1")
+	assert.Equal(t, "api.command_todo.removed", resp.Text)
+
+	todos, err = th.App.GetTodos(th.BasicUser.Id, true)
+	require.Nil(t, err)
+	assert.Empty(t, todos)
+
+	resp = cmd.DoCommand(th.App, args, "add")
+	assert.Equal(t, "api.command_todo.hint", resp.Text)
+
+	resp = cmd.DoCommand(th.App, args, "snooze 1")
+	assert.Equal(t, "api.command_todo.hint", resp.Text)
+}
+
+func TestTodoOwnership(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	todo, err := th.App.AddTodo(th.BasicUser.Id, "Review the release notes")
+	require.Nil(t, err)
+
+	_, err = th.App.CompleteTodo(th.BasicUser2.Id, todo.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.todo.not_found.app_error", err.Id)
+
+	err = th.App.DeleteTodo(th.BasicUser2.Id, todo.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.todo.not_found.app_error", err.Id)
+}
@@ -375,6 +375,11 @@ func (a *App) GetEnvironmentConfig() map[string]interface{} {
 
 // SaveConfig replaces the active configuration, optionally notifying cluster peers.
 func (a *App) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) *model.AppError {
+	return a.saveConfig(newCfg, sendConfigChangeClusterMessage, "")
+}
+
+// saveConfig saves the configuration and records the change, as a rollback of the given audit if any.
+func (a *App) saveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool, rollbackOf string) *model.AppError {
 	oldCfg, err := a.Srv.configStore.Set(newCfg)
 	if errors.Cause(err) == config.ErrReadOnlyConfiguration {
 		return model.NewAppError("saveConfig", "ent.cluster.save_config.error", nil, err.Error(), http.StatusForbidden)
@@ -382,6 +387,8 @@ func (a *App) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bo
 		return model.NewAppError("saveConfig", "app.save_config.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	a.recordConfigAudit(oldCfg, a.Config(), rollbackOf)
+
 	if a.Metrics != nil {
 		if *a.Config().MetricsSettings.Enable {
 			a.Metrics.StartServer()
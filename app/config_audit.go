@@ -0,0 +1,62 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// recordConfigAudit records who changed the configuration and the settings that changed. Failing to record the change
+// is logged but doesn't fail it, since the configuration has already been saved.
+func (a *App) recordConfigAudit(oldCfg, newCfg *model.Config, rollbackOf string) {
+	changes := model.DiffConfigs(oldCfg, newCfg)
+	if len(changes) == 0 && rollbackOf == "" {
+		return
+	}
+
+	audit := &model.ConfigAudit{
+		UserId:     a.Session.UserId,
+		RollbackOf: rollbackOf,
+		Changes:    changes,
+		Config:     newCfg.ToJson(),
+	}
+
+	if _, err := a.Srv.Store.ConfigAudit().Save(audit); err != nil {
+		mlog.Error("Failed to record the configuration change", mlog.String("user_id", audit.UserId), mlog.Err(err))
+	}
+}
+
+func (a *App) GetConfigAuditsPage(page, perPage int) ([]*model.ConfigAudit, *model.AppError) {
+	return a.Srv.Store.ConfigAudit().GetAll(page*perPage, perPage)
+}
+
+func (a *App) GetConfigAudit(auditId string) (*model.ConfigAudit, *model.AppError) {
+	return a.Srv.Store.ConfigAudit().Get(auditId)
+}
+
+// RollbackConfig restores the configuration resulting from a recorded change, and records the rollback in turn.
+func (a *App) RollbackConfig(auditId string) *model.AppError {
+	audit, err := a.GetConfigAudit(auditId)
+	if err != nil {
+		return err
+	}
+
+	cfg := audit.GetConfig()
+	if cfg == nil {
+		return model.NewAppError("RollbackConfig", "app.config_audit.rollback.decode.app_error", nil, "audit_id="+auditId, http.StatusInternalServerError)
+	}
+	cfg.SetDefaults()
+
+	// Plugin uploads can't be toggled other than through the configuration file.
+	cfg.PluginSettings.EnableUploads = a.Config().PluginSettings.EnableUploads
+
+	if err := cfg.IsValid(); err != nil {
+		return err
+	}
+
+	return a.saveConfig(cfg, true, audit.Id)
+}
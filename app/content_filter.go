@@ -0,0 +1,216 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	CONTENT_FILTER_CACHE_SIZE = 1000
+	CONTENT_FILTER_CACHE_SEC  = 60
+
+	// CONTENT_FILTER_AUDIT_MAX_MATCHES is the number of distinct matches of a filter recorded in its audit event.
+	CONTENT_FILTER_AUDIT_MAX_MATCHES = 10
+)
+
+// compiledContentFilter is a content filter along with the expression its words and patterns are matched with.
+type compiledContentFilter struct {
+	filter *model.ContentFilter
+	regexp *regexp.Regexp
+}
+
+func (a *App) GetContentFilter(filterId string) (*model.ContentFilter, *model.AppError) {
+	return a.Srv.Store.ContentFilter().Get(filterId)
+}
+
+func (a *App) GetContentFilters(page, perPage int) ([]*model.ContentFilter, *model.AppError) {
+	return a.Srv.Store.ContentFilter().GetAll(page*perPage, perPage)
+}
+
+func (a *App) CreateContentFilter(filter *model.ContentFilter) (*model.ContentFilter, *model.AppError) {
+	filter.Id = ""
+
+	if err := a.validateContentFilterTeam(filter); err != nil {
+		return nil, err
+	}
+
+	filter, err := a.Srv.Store.ContentFilter().Save(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Srv.contentFilterCache.Purge()
+
+	return filter, nil
+}
+
+func (a *App) UpdateContentFilter(oldFilter, updatedFilter *model.ContentFilter) (*model.ContentFilter, *model.AppError) {
+	oldFilter.TeamId = updatedFilter.TeamId
+	oldFilter.Name = updatedFilter.Name
+	oldFilter.Action = updatedFilter.Action
+	oldFilter.Words = updatedFilter.Words
+	oldFilter.Patterns = updatedFilter.Patterns
+
+	if err := a.validateContentFilterTeam(oldFilter); err != nil {
+		return nil, err
+	}
+
+	filter, err := a.Srv.Store.ContentFilter().Update(oldFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Srv.contentFilterCache.Purge()
+
+	return filter, nil
+}
+
+func (a *App) DeleteContentFilter(filterId string) *model.AppError {
+	if err := a.Srv.Store.ContentFilter().Delete(filterId, model.GetMillis()); err != nil {
+		return err
+	}
+
+	a.Srv.contentFilterCache.Purge()
+
+	return nil
+}
+
+func (a *App) validateContentFilterTeam(filter *model.ContentFilter) *model.AppError {
+	if filter.TeamId == "" {
+		return nil
+	}
+
+	if _, err := a.Srv.Store.Team().Get(filter.TeamId); err != nil {
+		return model.NewAppError("validateContentFilterTeam", "app.content_filter.invalid_team.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// getContentFiltersForTeam returns the compiled filters which apply to posts in the team. They're cached for a
+// short while, so changes made on other servers of a cluster apply within CONTENT_FILTER_CACHE_SEC.
+func (a *App) getContentFiltersForTeam(teamId string) ([]*compiledContentFilter, *model.AppError) {
+	if cached, ok := a.Srv.contentFilterCache.Get(teamId); ok {
+		return cached.([]*compiledContentFilter), nil
+	}
+
+	filters, err := a.Srv.Store.ContentFilter().GetForTeam(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := make([]*compiledContentFilter, 0, len(filters))
+	for _, filter := range filters {
+		re, compileErr := filter.Regexp()
+		if compileErr != nil {
+			mlog.Warn("Skipping a content filter which doesn't compile", mlog.String("content_filter_id", filter.Id), mlog.Err(compileErr))
+			continue
+		}
+		compiled = append(compiled, &compiledContentFilter{filter: filter, regexp: re})
+	}
+
+	a.Srv.contentFilterCache.AddWithExpiresInSecs(teamId, compiled, CONTENT_FILTER_CACHE_SEC)
+
+	return compiled, nil
+}
+
+// applyContentFilters checks the message of a post against the filters of the channel's team. It fails if a
+// blocking filter matches, masks the matches of masking filters and records the flagging filters which matched in
+// the post's props. Every match is audited.
+func (a *App) applyContentFilters(post *model.Post, channel *model.Channel) *model.AppError {
+	if !*a.Config().ContentFilterSettings.Enable || post.IsSystemMessage() || post.Message == "" {
+		return nil
+	}
+
+	filters, err := a.getContentFiltersForTeam(channel.TeamId)
+	if err != nil {
+		return err
+	}
+
+	matches := make(map[*compiledContentFilter][]string)
+	for _, compiled := range filters {
+		if found := compiled.regexp.FindAllString(post.Message, -1); len(found) > 0 {
+			matches[compiled] = found
+		}
+	}
+
+	// Blocking filters are checked first, so a blocked post isn't also recorded as masked or flagged.
+	for _, compiled := range filters {
+		if found, ok := matches[compiled]; ok && compiled.filter.Action == model.CONTENT_FILTER_ACTION_BLOCK {
+			a.auditContentFilterMatch(compiled.filter, post, channel, found)
+			return model.NewAppError("applyContentFilters", "api.post.content_filter.blocked.app_error", nil, "content_filter_id="+compiled.filter.Id, http.StatusBadRequest)
+		}
+	}
+
+	message := post.Message
+	maskCharacter := *a.Config().ContentFilterSettings.MaskCharacter
+	var flaggedIds []string
+
+	for _, compiled := range filters {
+		found, ok := matches[compiled]
+		if !ok {
+			continue
+		}
+
+		a.auditContentFilterMatch(compiled.filter, post, channel, found)
+
+		switch compiled.filter.Action {
+		case model.CONTENT_FILTER_ACTION_MASK:
+			message = compiled.regexp.ReplaceAllStringFunc(message, func(match string) string {
+				return strings.Repeat(maskCharacter, utf8.RuneCountInString(match))
+			})
+		case model.CONTENT_FILTER_ACTION_FLAG:
+			flaggedIds = append(flaggedIds, compiled.filter.Id)
+		}
+	}
+
+	post.Message = message
+
+	// The props are copied since an updated post shares them with the post it replaces. The flags are cleared
+	// when an edit removes what was flagged.
+	if _, flagged := post.Props[model.POST_PROPS_CONTENT_FILTER_IDS]; flagged || len(flaggedIds) > 0 {
+		props := make(model.StringInterface, len(post.Props)+1)
+		for key, value := range post.Props {
+			props[key] = value
+		}
+		delete(props, model.POST_PROPS_CONTENT_FILTER_IDS)
+		if len(flaggedIds) > 0 {
+			props[model.POST_PROPS_CONTENT_FILTER_IDS] = flaggedIds
+		}
+		post.Props = props
+	}
+
+	return nil
+}
+
+func (a *App) auditContentFilterMatch(filter *model.ContentFilter, post *model.Post, channel *model.Channel, matches []string) {
+	result := model.AUDIT_RESULT_SUCCESS
+	if filter.Action == model.CONTENT_FILTER_ACTION_BLOCK {
+		result = model.AUDIT_RESULT_FAIL
+	}
+
+	matches = model.RemoveDuplicateStrings(matches)
+	if len(matches) > CONTENT_FILTER_AUDIT_MAX_MATCHES {
+		matches = matches[:CONTENT_FILTER_AUDIT_MAX_MATCHES]
+	}
+
+	a.LogAuditEvent(&model.AuditEvent{
+		ActorId: post.UserId,
+		Action:  "content_filter/" + filter.Action,
+		Target: model.StringMap{
+			"content_filter_id": filter.Id,
+			"channel_id":        channel.Id,
+			"post_id":           post.Id,
+		},
+		Result:  result,
+		Details: "matches=" + strings.Join(matches, ", "),
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCreateContentFilter(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	filter, err := th.App.CreateContentFilter(&model.ContentFilter{TeamId: th.BasicTeam.Id, Name: "profanity", Action: model.CONTENT_FILTER_ACTION_MASK, Words: model.StringArray{"darn"}})
+	require.Nil(t, err)
+	assert.Len(t, filter.Id, 26)
+
+	_, err = th.App.CreateContentFilter(&model.ContentFilter{TeamId: model.NewId(), Name: "unknown team", Action: model.CONTENT_FILTER_ACTION_MASK, Words: model.StringArray{"darn"}})
+	require.NotNil(t, err)
+	assert.Equal(t, "app.content_filter.invalid_team.app_error", err.Id)
+}
+
+func TestApplyContentFilters(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ContentFilterSettings.Enable = true })
+
+	_, err := th.App.CreateContentFilter(&model.ContentFilter{Name: "blocked", Action: model.CONTENT_FILTER_ACTION_BLOCK, Patterns: model.StringArray{`\b\d{3}-\d{2}-\d{4}\b`}})
+	require.Nil(t, err)
+	_, err = th.App.CreateContentFilter(&model.ContentFilter{TeamId: th.BasicTeam.Id, Name: "masked", Action: model.CONTENT_FILTER_ACTION_MASK, Words: model.StringArray{"darn"}})
+	require.Nil(t, err)
+	flag, err := th.App.CreateContentFilter(&model.ContentFilter{TeamId: th.BasicTeam.Id, Name: "flagged", Action: model.CONTENT_FILTER_ACTION_FLAG, Words: model.StringArray{"confidential"}})
+	require.Nil(t, err)
+
+	t.Run("block", func(t *testing.T) {
+		_, err := th.App.CreatePost(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: "my number is 123-45-6789, darn"}, th.BasicChannel, false)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.post.content_filter.blocked.app_error", err.Id)
+	})
+
+	t.Run("mask and flag", func(t *testing.T) {
+		post, err := th.App.CreatePost(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: "Darn, this is CONFIDENTIAL"}, th.BasicChannel, false)
+		require.Nil(t, err)
+		assert.Equal(t, "****, this is CONFIDENTIAL", post.Message)
+		assert.Equal(t, []string{flag.Id}, post.Props[model.POST_PROPS_CONTENT_FILTER_IDS])
+
+		post.Message = "darn, nothing to see"
+		updated, err := th.App.UpdatePost(post, true)
+		require.Nil(t, err)
+		assert.Equal(t, "****, nothing to see", updated.Message)
+		assert.Nil(t, updated.Props[model.POST_PROPS_CONTENT_FILTER_IDS])
+
+		updated.Message = "my number is 123-45-6789"
+		_, err = th.App.UpdatePost(updated, true)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.post.content_filter.blocked.app_error", err.Id)
+	})
+
+	t.Run("team filters don't apply to other teams", func(t *testing.T) {
+		team := th.CreateTeam()
+		th.LinkUserToTeam(th.BasicUser, team)
+		channel := th.createChannel(team, model.CHANNEL_OPEN)
+
+		post, err := th.App.CreatePost(&model.Post{UserId: th.BasicUser.Id, ChannelId: channel.Id, Message: "darn"}, channel, false)
+		require.Nil(t, err)
+		assert.Equal(t, "darn", post.Message)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ContentFilterSettings.Enable = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ContentFilterSettings.Enable = true })
+
+		post, err := th.App.CreatePost(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: "darn"}, th.BasicChannel, false)
+		require.Nil(t, err)
+		assert.Equal(t, "darn", post.Message)
+	})
+}
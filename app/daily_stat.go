@@ -0,0 +1,78 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// ComputeDailyStats rolls up the activity of the given date and stores it, returning the number of stats stored.
+func (a *App) ComputeDailyStats(date string) (int, *model.AppError) {
+	since, until, parseErr := model.GetDailyStatDateRange(date)
+	if parseErr != nil {
+		return 0, model.NewAppError("ComputeDailyStats", "app.daily_stats.invalid_date.app_error", nil, "date="+date+", "+parseErr.Error(), http.StatusBadRequest)
+	}
+
+	stats, err := a.Srv.Store.DailyStat().Compute(date, since, until)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, stat := range stats {
+		if _, err := a.Srv.Store.DailyStat().Save(stat); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(stats), nil
+}
+
+// GetDailyStats returns the stats of a channel, of a team when no channel is given, or the system wide ones when
+// neither is, for the dates in [since, until].
+func (a *App) GetDailyStats(teamId, channelId, since, until string) ([]*model.DailyStat, *model.AppError) {
+	if !*a.Config().AnalyticsSettings.EnableDailyStats {
+		return nil, model.NewAppError("GetDailyStats", "app.daily_stats.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return a.Srv.Store.DailyStat().Get(teamId, channelId, since, until)
+}
+
+// dailyStatsAreCurrent returns true if the daily stats have been rolled up until yesterday, in which case they can
+// be used instead of counting the posts of the past days.
+func (a *App) dailyStatsAreCurrent() bool {
+	if !*a.Config().AnalyticsSettings.EnableDailyStats || a.Srv.Jobs == nil {
+		return false
+	}
+
+	job, err := a.Srv.Jobs.GetLastSuccessfulJobByType(model.JOB_TYPE_DAILY_STATS)
+	if err != nil || job == nil {
+		return false
+	}
+
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(model.DAILY_STAT_DATE_FORMAT)
+	return job.Data[model.DAILY_STATS_JOB_DATA_LAST_DATE] >= yesterday
+}
+
+// getDailyStatsAnalyticsRows returns the given value of the daily stats of the last 30 days, newest first, in the
+// same form as the post analytics.
+func (a *App) getDailyStatsAnalyticsRows(teamId string, value func(stat *model.DailyStat) int64) (model.AnalyticsRows, *model.AppError) {
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
+
+	stats, err := a.Srv.Store.DailyStat().Get(teamId, "", yesterday.AddDate(0, 0, -29).Format(model.DAILY_STAT_DATE_FORMAT), yesterday.Format(model.DAILY_STAT_DATE_FORMAT))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := model.AnalyticsRows{}
+	for i := len(stats) - 1; i >= 0; i-- {
+		if v := value(stats[i]); v > 0 {
+			rows = append(rows, &model.AnalyticsRow{Name: stats[i].Date, Value: float64(v)})
+		}
+	}
+
+	return rows, nil
+}
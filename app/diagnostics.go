@@ -49,6 +49,11 @@ const (
 	TRACK_CONFIG_DISPLAY            = "config_display"
 	TRACK_CONFIG_GUEST_ACCOUNTS     = "config_guest_accounts"
 	TRACK_CONFIG_IMAGE_PROXY        = "config_image_proxy"
+	TRACK_CONFIG_AUDIT              = "config_audit"
+	TRACK_CONFIG_API_ACCESS_LOG     = "config_api_access_log"
+	TRACK_CONFIG_OFFBOARDING        = "config_offboarding"
+	TRACK_CONFIG_NETWORK_ACCESS     = "config_network_access"
+	TRACK_CONFIG_LOGIN_SECURITY     = "config_login_security"
 	TRACK_PERMISSIONS_GENERAL       = "permissions_general"
 	TRACK_PERMISSIONS_SYSTEM_SCHEME = "permissions_system_scheme"
 	TRACK_PERMISSIONS_TEAM_SCHEMES  = "permissions_team_schemes"
@@ -235,6 +240,8 @@ func (a *App) trackConfig() {
 		"enable_security_fix_alert":                               *cfg.ServiceSettings.EnableSecurityFixAlert,
 		"enable_insecure_outgoing_connections":                    *cfg.ServiceSettings.EnableInsecureOutgoingConnections,
 		"enable_incoming_webhooks":                                cfg.ServiceSettings.EnableIncomingWebhooks,
+		"incoming_webhook_rate_limit_per_minute":                  *cfg.ServiceSettings.IncomingWebhookRateLimitPerMinute,
+		"incoming_webhook_daily_quota":                            *cfg.ServiceSettings.IncomingWebhookDailyQuota,
 		"enable_outgoing_webhooks":                                cfg.ServiceSettings.EnableOutgoingWebhooks,
 		"enable_commands":                                         *cfg.ServiceSettings.EnableCommands,
 		"enable_only_admin_integrations":                          *cfg.ServiceSettings.DEPRECATED_DO_NOT_USE_EnableOnlyAdminIntegrations,
@@ -252,6 +259,7 @@ func (a *App) trackConfig() {
 		"enable_developer":                                        *cfg.ServiceSettings.EnableDeveloper,
 		"enable_multifactor_authentication":                       *cfg.ServiceSettings.EnableMultifactorAuthentication,
 		"enforce_multifactor_authentication":                      *cfg.ServiceSettings.EnforceMultifactorAuthentication,
+		"multifactor_authentication_grace_period_days":            *cfg.ServiceSettings.MultifactorAuthenticationGracePeriodDays,
 		"enable_oauth_service_provider":                           cfg.ServiceSettings.EnableOAuthServiceProvider,
 		"connection_security":                                     *cfg.ServiceSettings.ConnectionSecurity,
 		"tls_strict_transport":                                    *cfg.ServiceSettings.TLSStrictTransport,
@@ -274,6 +282,17 @@ func (a *App) trackConfig() {
 		"cors_allow_credentials":                                  *cfg.ServiceSettings.CorsAllowCredentials,
 		"cors_debug":                                              *cfg.ServiceSettings.CorsDebug,
 		"isdefault_allowed_untrusted_internal_connections":        isDefault(*cfg.ServiceSettings.AllowedUntrustedInternalConnections, ""),
+		"isdefault_outbound_proxy_url":                            isDefault(*cfg.ServiceSettings.OutboundProxyURL, ""),
+		"outbound_request_timeouts":                               len(cfg.ServiceSettings.OutboundRequestTimeouts),
+		"command_restrictions":                                    len(cfg.ServiceSettings.CommandRestrictions),
+		"enable_onboarding":                                       *cfg.ServiceSettings.EnableOnboarding,
+		"isdefault_onboarding_completion_message":                 isDefault(*cfg.ServiceSettings.OnboardingCompletionMessage, ""),
+		"onboarding_completion_channel_ids":                       len(cfg.ServiceSettings.OnboardingCompletionChannelIds),
+		"outbound_circuit_breaker_threshold":                      *cfg.ServiceSettings.OutboundCircuitBreakerThreshold,
+		"outbound_circuit_breaker_cooldown_seconds":               *cfg.ServiceSettings.OutboundCircuitBreakerCooldownSeconds,
+		"isdefault_link_preview_allowed_domains":                  isDefault(*cfg.ServiceSettings.LinkPreviewAllowedDomains, ""),
+		"isdefault_link_preview_blocked_domains":                  isDefault(*cfg.ServiceSettings.LinkPreviewBlockedDomains, ""),
+		"enable_system_bot_notifications":                         *cfg.ServiceSettings.EnableSystemBotNotifications,
 		"restrict_post_delete":                                    *cfg.ServiceSettings.DEPRECATED_DO_NOT_USE_RestrictPostDelete,
 		"allow_edit_post":                                         *cfg.ServiceSettings.DEPRECATED_DO_NOT_USE_AllowEditPost,
 		"post_edit_time_limit":                                    *cfg.ServiceSettings.PostEditTimeLimit,
@@ -301,6 +320,11 @@ func (a *App) trackConfig() {
 		"disable_bots_when_owner_is_deactivated":                  *cfg.ServiceSettings.DisableBotsWhenOwnerIsDeactivated,
 		"enable_bot_account_creation":                             *cfg.ServiceSettings.EnableBotAccountCreation,
 		"enable_svgs":                                             *cfg.ServiceSettings.EnableSVGs,
+		"enable_user_impersonation":                               *cfg.ServiceSettings.EnableUserImpersonation,
+		"user_impersonation_length_in_minutes":                    *cfg.ServiceSettings.UserImpersonationLengthInMinutes,
+		"notify_impersonated_users":                               *cfg.ServiceSettings.NotifyImpersonatedUsers,
+		"enable_fuzzy_user_search":                                *cfg.ServiceSettings.EnableFuzzyUserSearch,
+		"graceful_shutdown_timeout_seconds":                       *cfg.ServiceSettings.GracefulShutdownTimeoutSeconds,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_TEAM, map[string]interface{}{
@@ -319,6 +343,7 @@ func (a *App) trackConfig() {
 		"restrict_direct_message":                   *cfg.TeamSettings.RestrictDirectMessage,
 		"max_notifications_per_channel":             *cfg.TeamSettings.MaxNotificationsPerChannel,
 		"enable_confirm_notifications_to_channel":   *cfg.TeamSettings.EnableConfirmNotificationsToChannel,
+		"enable_cross_team_channel_mentions":        *cfg.TeamSettings.EnableCrossTeamChannelMentions,
 		"max_users_per_team":                        *cfg.TeamSettings.MaxUsersPerTeam,
 		"max_channels_per_team":                     *cfg.TeamSettings.MaxChannelsPerTeam,
 		"teammate_name_display":                     *cfg.TeamSettings.TeammateNameDisplay,
@@ -354,6 +379,10 @@ func (a *App) trackConfig() {
 		"data_source_replicas":           len(cfg.SqlSettings.DataSourceReplicas),
 		"data_source_search_replicas":    len(cfg.SqlSettings.DataSourceSearchReplicas),
 		"query_timeout":                  *cfg.SqlSettings.QueryTimeout,
+		"replica_max_lag_seconds":        *cfg.SqlSettings.ReplicaMaxLagSeconds,
+		"migration_dry_run":              *cfg.SqlSettings.MigrationDryRun,
+		"migration_batch_size":           *cfg.SqlSettings.MigrationBatchSize,
+		"partition_posts_archive":        *cfg.SqlSettings.PartitionPostsArchive,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_LOG, map[string]interface{}{
@@ -412,6 +441,10 @@ func (a *App) trackConfig() {
 		"connection_security":                  cfg.EmailSettings.ConnectionSecurity,
 		"send_push_notifications":              *cfg.EmailSettings.SendPushNotifications,
 		"push_notification_contents":           *cfg.EmailSettings.PushNotificationContents,
+		"direct_push_notification_contents":    *cfg.EmailSettings.DirectPushNotificationContents,
+		"group_push_notification_contents":     *cfg.EmailSettings.GroupPushNotificationContents,
+		"channel_push_notification_contents":   *cfg.EmailSettings.ChannelPushNotificationContents,
+		"push_notification_max_failures":       *cfg.EmailSettings.PushNotificationMaxFailures,
 		"enable_email_batching":                *cfg.EmailSettings.EnableEmailBatching,
 		"email_batching_buffer_size":           *cfg.EmailSettings.EmailBatchingBufferSize,
 		"email_batching_interval":              *cfg.EmailSettings.EmailBatchingInterval,
@@ -471,6 +504,9 @@ func (a *App) trackConfig() {
 		"connection_security":                    *cfg.LdapSettings.ConnectionSecurity,
 		"skip_certificate_verification":          *cfg.LdapSettings.SkipCertificateVerification,
 		"sync_interval_minutes":                  *cfg.LdapSettings.SyncIntervalMinutes,
+		"enable_incremental_sync":                *cfg.LdapSettings.EnableIncrementalSync,
+		"isdefault_modify_timestamp_attribute":   isDefault(*cfg.LdapSettings.ModifyTimestampAttribute, model.LDAP_SETTINGS_DEFAULT_MODIFY_TIMESTAMP_ATTRIBUTE),
+		"full_sync_interval_hours":               *cfg.LdapSettings.FullSyncIntervalHours,
 		"query_timeout":                          *cfg.LdapSettings.QueryTimeout,
 		"max_page_size":                          *cfg.LdapSettings.MaxPageSize,
 		"isdefault_first_name_attribute":         isDefault(*cfg.LdapSettings.FirstNameAttribute, model.LDAP_SETTINGS_DEFAULT_FIRST_NAME_ATTRIBUTE),
@@ -508,6 +544,8 @@ func (a *App) trackConfig() {
 		"verify":                              *cfg.SamlSettings.Verify,
 		"encrypt":                             *cfg.SamlSettings.Encrypt,
 		"sign_request":                        *cfg.SamlSettings.SignRequest,
+		"signature_algorithm":                 *cfg.SamlSettings.SignatureAlgorithm,
+		"canonical_algorithm":                 *cfg.SamlSettings.CanonicalAlgorithm,
 		"isdefault_scoping_idp_provider_id":   isDefault(*cfg.SamlSettings.ScopingIDPProviderId, ""),
 		"isdefault_scoping_idp_name":          isDefault(*cfg.SamlSettings.ScopingIDPName, ""),
 		"isdefault_id_attribute":              isDefault(*cfg.SamlSettings.IdAttribute, model.SAML_SETTINGS_DEFAULT_ID_ATTRIBUTE),
@@ -518,6 +556,7 @@ func (a *App) trackConfig() {
 		"isdefault_nickname_attribute":        isDefault(*cfg.SamlSettings.NicknameAttribute, model.SAML_SETTINGS_DEFAULT_NICKNAME_ATTRIBUTE),
 		"isdefault_locale_attribute":          isDefault(*cfg.SamlSettings.LocaleAttribute, model.SAML_SETTINGS_DEFAULT_LOCALE_ATTRIBUTE),
 		"isdefault_position_attribute":        isDefault(*cfg.SamlSettings.PositionAttribute, model.SAML_SETTINGS_DEFAULT_POSITION_ATTRIBUTE),
+		"isdefault_group_attribute":           isDefault(*cfg.SamlSettings.GroupAttribute, model.SAML_SETTINGS_DEFAULT_GROUP_ATTRIBUTE),
 		"isdefault_login_button_text":         isDefault(*cfg.SamlSettings.LoginButtonText, model.USER_AUTH_SERVICE_SAML_TEXT),
 		"isdefault_login_button_color":        isDefault(*cfg.SamlSettings.LoginButtonColor, ""),
 		"isdefault_login_button_border_color": isDefault(*cfg.SamlSettings.LoginButtonBorderColor, ""),
@@ -532,6 +571,8 @@ func (a *App) trackConfig() {
 		"use_ip_address":          *cfg.ClusterSettings.UseIpAddress,
 		"use_experimental_gossip": *cfg.ClusterSettings.UseExperimentalGossip,
 		"read_only_config":        *cfg.ClusterSettings.ReadOnlyConfig,
+		"websocket_mode":          *cfg.ClusterSettings.WebSocketMode,
+		"websocket_drain_seconds": *cfg.ClusterSettings.WebSocketDrainSeconds,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_METRICS, map[string]interface{}{
@@ -554,7 +595,12 @@ func (a *App) trackConfig() {
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_ANALYTICS, map[string]interface{}{
-		"isdefault_max_users_for_statistics": isDefault(*cfg.AnalyticsSettings.MaxUsersForStatistics, model.ANALYTICS_SETTINGS_DEFAULT_MAX_USERS_FOR_STATISTICS),
+		"isdefault_max_users_for_statistics":           isDefault(*cfg.AnalyticsSettings.MaxUsersForStatistics, model.ANALYTICS_SETTINGS_DEFAULT_MAX_USERS_FOR_STATISTICS),
+		"enable_channel_read_stats":                    *cfg.AnalyticsSettings.EnableChannelReadStats,
+		"isdefault_channel_read_stats_minimum_members": isDefault(*cfg.AnalyticsSettings.ChannelReadStatsMinimumMembers, model.ANALYTICS_SETTINGS_DEFAULT_CHANNEL_READ_STATS_MINIMUM_MEMBERS),
+		"enable_daily_stats":                           *cfg.AnalyticsSettings.EnableDailyStats,
+		"enable_integration_stats":                     *cfg.AnalyticsSettings.EnableIntegrationStats,
+		"integration_stats_retention_days":             *cfg.AnalyticsSettings.IntegrationStatsRetentionDays,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_ANNOUNCEMENT, map[string]interface{}{
@@ -613,6 +659,9 @@ func (a *App) trackConfig() {
 		"message_retention_days":  *cfg.DataRetentionSettings.MessageRetentionDays,
 		"file_retention_days":     *cfg.DataRetentionSettings.FileRetentionDays,
 		"deletion_job_start_time": *cfg.DataRetentionSettings.DeletionJobStartTime,
+		"enable_post_archive":     *cfg.DataRetentionSettings.EnablePostArchive,
+		"post_archive_months":     *cfg.DataRetentionSettings.PostArchiveMonths,
+		"archive_job_start_time":  *cfg.DataRetentionSettings.ArchiveJobStartTime,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_MESSAGE_EXPORT, map[string]interface{}{
@@ -645,6 +694,48 @@ func (a *App) trackConfig() {
 		"isdefault_remote_image_proxy_url":     isDefault(*cfg.ImageProxySettings.RemoteImageProxyURL, ""),
 		"isdefault_remote_image_proxy_options": isDefault(*cfg.ImageProxySettings.RemoteImageProxyOptions, ""),
 	})
+
+	a.SendDiagnostic(TRACK_CONFIG_AUDIT, map[string]interface{}{
+		"enable_database":       *cfg.AuditSettings.EnableDatabase,
+		"log_all_api_mutations": *cfg.AuditSettings.LogAllApiMutations,
+		"enable_file":           *cfg.AuditSettings.EnableFile,
+		"enable_syslog":         *cfg.AuditSettings.EnableSyslog,
+		"syslog_network":        *cfg.AuditSettings.SyslogNetwork,
+		"enable_http":           *cfg.AuditSettings.EnableHTTP,
+		"http_format":           *cfg.AuditSettings.HTTPFormat,
+		"http_batch_size":       *cfg.AuditSettings.HTTPBatchSize,
+	})
+
+	a.SendDiagnostic(TRACK_CONFIG_API_ACCESS_LOG, map[string]interface{}{
+		"enable":                    *cfg.ApiAccessLogSettings.Enable,
+		"isdefault_file_location":   isDefault(*cfg.ApiAccessLogSettings.FileLocation, ""),
+		"file_max_size_mb":          *cfg.ApiAccessLogSettings.FileMaxSizeMB,
+		"sample_percent":            *cfg.ApiAccessLogSettings.SamplePercent,
+		"always_log_errors":         *cfg.ApiAccessLogSettings.AlwaysLogErrors,
+		"slow_request_threshold_ms": *cfg.ApiAccessLogSettings.SlowRequestThresholdMs,
+	})
+
+	a.SendDiagnostic(TRACK_CONFIG_OFFBOARDING, map[string]interface{}{
+		"enable_scheduled_deactivation":              *cfg.OffboardingSettings.EnableScheduledDeactivation,
+		"remove_from_channels":                       *cfg.OffboardingSettings.RemoveFromChannels,
+		"isdefault_reassign_integrations_to_user_id": isDefault(*cfg.OffboardingSettings.ReassignIntegrationsToUserId, ""),
+		"isdefault_summary_channel_id":               isDefault(*cfg.OffboardingSettings.SummaryChannelId, ""),
+	})
+
+	a.SendDiagnostic(TRACK_CONFIG_NETWORK_ACCESS, map[string]interface{}{
+		"enable":                      *cfg.NetworkAccessSettings.Enable,
+		"isdefault_allowed_ip_ranges": isDefault(*cfg.NetworkAccessSettings.AllowedIPRanges, ""),
+		"role_allowed_ip_ranges":      len(cfg.NetworkAccessSettings.RoleAllowedIPRanges),
+		"isdefault_country_header":    isDefault(*cfg.NetworkAccessSettings.CountryHeader, ""),
+		"bypass_user_ids":             len(cfg.NetworkAccessSettings.BypassUserIds),
+	})
+
+	a.SendDiagnostic(TRACK_CONFIG_LOGIN_SECURITY, map[string]interface{}{
+		"lockout_duration_minutes": *cfg.LoginSecuritySettings.LockoutDurationMinutes,
+		"enable_anomaly_detection": *cfg.LoginSecuritySettings.EnableAnomalyDetection,
+		"enable_email_alerts":      *cfg.LoginSecuritySettings.EnableEmailAlerts,
+		"attempts_retention_days":  *cfg.LoginSecuritySettings.AttemptsRetentionDays,
+	})
 }
 
 func (a *App) trackLicense() {
@@ -0,0 +1,205 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	DIRECT_MESSAGE_BROADCAST_CACHE_SIZE = 1000
+	DIRECT_MESSAGE_BROADCAST_CACHE_SEC  = 24 * 60 * 60
+
+	DIRECT_MESSAGE_BROADCAST_WORKERS          = 4
+	DIRECT_MESSAGE_BROADCAST_POSTS_PER_SECOND = 20
+
+	directMessageBroadcastTeamMembersPerPage = 200
+)
+
+// directMessageBroadcast is a broadcast in progress, whose status is updated by the workers sending its messages.
+// Broadcasts are kept per server and are forgotten a day after they start.
+type directMessageBroadcast struct {
+	mutex  sync.Mutex
+	status model.DirectMessageBroadcast
+}
+
+func (b *directMessageBroadcast) getStatus() *model.DirectMessageBroadcast {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	status := b.status
+	status.FailedUserIds = append([]string{}, b.status.FailedUserIds...)
+	return &status
+}
+
+func (b *directMessageBroadcast) recordResult(userId string, sent bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if sent {
+		b.status.Sent++
+	} else {
+		b.status.Failed++
+		b.status.FailedUserIds = append(b.status.FailedUserIds, userId)
+	}
+
+	if b.status.Sent+b.status.Failed >= b.status.Total {
+		b.status.Status = model.DIRECT_MESSAGE_BROADCAST_STATUS_DONE
+		b.status.FinishAt = model.GetMillis()
+	}
+}
+
+// BroadcastDirectMessage sends a post from a bot as a direct message to each of the given users, or to each member of
+// a team when teamId is set. The messages are sent in the background by a pool of workers, limited to
+// DIRECT_MESSAGE_BROADCAST_POSTS_PER_SECOND between them, and the returned broadcast is used to follow their progress
+// with GetDirectMessageBroadcast.
+func (a *App) BroadcastDirectMessage(botUserId string, userIds []string, teamId string, post *model.Post) (*model.DirectMessageBroadcast, *model.AppError) {
+	request := &model.DirectMessageBroadcastRequest{UserIds: userIds, TeamId: teamId, Post: post}
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := a.GetBot(botUserId, false); err != nil {
+		return nil, err
+	}
+
+	if teamId != "" {
+		var err *model.AppError
+		if userIds, err = a.getDirectMessageBroadcastTeamUserIds(teamId); err != nil {
+			return nil, err
+		}
+	}
+
+	recipients := make([]string, 0, len(userIds))
+	seen := make(map[string]bool, len(userIds))
+	for _, userId := range userIds {
+		if userId != botUserId && !seen[userId] {
+			seen[userId] = true
+			recipients = append(recipients, userId)
+		}
+	}
+
+	broadcast := &directMessageBroadcast{
+		status: model.DirectMessageBroadcast{
+			Id:            model.NewId(),
+			BotUserId:     botUserId,
+			Status:        model.DIRECT_MESSAGE_BROADCAST_STATUS_IN_PROGRESS,
+			Total:         len(recipients),
+			FailedUserIds: []string{},
+			CreateAt:      model.GetMillis(),
+		},
+	}
+	if len(recipients) == 0 {
+		broadcast.status.Status = model.DIRECT_MESSAGE_BROADCAST_STATUS_DONE
+		broadcast.status.FinishAt = broadcast.status.CreateAt
+	}
+
+	a.Srv.broadcastCache.AddWithExpiresInSecs(broadcast.status.Id, broadcast, DIRECT_MESSAGE_BROADCAST_CACHE_SEC)
+
+	if len(recipients) > 0 {
+		postJson := post.ToUnsanitizedJson()
+		a.Srv.Go(func() {
+			a.runDirectMessageBroadcast(broadcast, recipients, postJson)
+		})
+	}
+
+	return broadcast.getStatus(), nil
+}
+
+// GetDirectMessageBroadcast returns the progress of a broadcast started by BroadcastDirectMessage.
+func (a *App) GetDirectMessageBroadcast(broadcastId string) (*model.DirectMessageBroadcast, *model.AppError) {
+	cached, ok := a.Srv.broadcastCache.Get(broadcastId)
+	if !ok {
+		return nil, model.NewAppError("GetDirectMessageBroadcast", "app.direct_message_broadcast.get.not_found.app_error", nil, "id="+broadcastId, http.StatusNotFound)
+	}
+
+	return cached.(*directMessageBroadcast).getStatus(), nil
+}
+
+func (a *App) getDirectMessageBroadcastTeamUserIds(teamId string) ([]string, *model.AppError) {
+	if _, err := a.GetTeam(teamId); err != nil {
+		return nil, err
+	}
+
+	var userIds []string
+	for offset := 0; ; offset += directMessageBroadcastTeamMembersPerPage {
+		members, err := a.Srv.Store.Team().GetMembers(teamId, offset, directMessageBroadcastTeamMembersPerPage, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range members {
+			if member.DeleteAt == 0 {
+				userIds = append(userIds, member.UserId)
+			}
+		}
+
+		if len(userIds) > model.DIRECT_MESSAGE_BROADCAST_MAX_USERS {
+			return nil, model.NewAppError("BroadcastDirectMessage", "model.direct_message_broadcast.is_valid.max_users.app_error", map[string]interface{}{"Max": model.DIRECT_MESSAGE_BROADCAST_MAX_USERS}, "team_id="+teamId, http.StatusBadRequest)
+		}
+
+		if len(members) < directMessageBroadcastTeamMembersPerPage {
+			return userIds, nil
+		}
+	}
+}
+
+// runDirectMessageBroadcast sends the messages of a broadcast with a pool of workers that share a ticker, so that the
+// broadcast never sends faster than DIRECT_MESSAGE_BROADCAST_POSTS_PER_SECOND however many workers it has.
+func (a *App) runDirectMessageBroadcast(broadcast *directMessageBroadcast, userIds []string, postJson string) {
+	botUserId := broadcast.status.BotUserId
+
+	ticker := time.NewTicker(time.Second / DIRECT_MESSAGE_BROADCAST_POSTS_PER_SECOND)
+	defer ticker.Stop()
+
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < DIRECT_MESSAGE_BROADCAST_WORKERS; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for userId := range queue {
+				<-ticker.C
+				broadcast.recordResult(userId, a.sendDirectMessageBroadcastPost(botUserId, userId, postJson))
+			}
+		}()
+	}
+
+	for _, userId := range userIds {
+		queue <- userId
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// sendDirectMessageBroadcastPost sends a broadcast message to a user. Each message is read from the JSON of the
+// broadcast post, since the workers mustn't share its props.
+func (a *App) sendDirectMessageBroadcastPost(botUserId, userId, postJson string) bool {
+	channel, err := a.GetOrCreateDirectChannel(botUserId, userId)
+	if err != nil {
+		mlog.Warn("Failed to get the direct channel for a broadcast", mlog.String("bot_user_id", botUserId), mlog.String("user_id", userId), mlog.Err(err))
+		return false
+	}
+
+	dm := model.PostFromJson(strings.NewReader(postJson))
+	dm.Id = ""
+	dm.CreateAt = 0
+	dm.UpdateAt = 0
+	dm.PendingPostId = ""
+	dm.UserId = botUserId
+	dm.ChannelId = channel.Id
+
+	if _, err := a.CreatePost(dm, channel, false); err != nil {
+		mlog.Warn("Failed to send a broadcast direct message", mlog.String("bot_user_id", botUserId), mlog.String("user_id", userId), mlog.Err(err))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestBroadcastDirectMessage(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	bot, err := th.App.CreateBot(&model.Bot{
+		Username:    "broadcaster",
+		Description: "a bot",
+		OwnerId:     th.BasicUser.Id,
+	})
+	require.Nil(t, err)
+	defer th.App.PermanentDeleteBot(bot.UserId)
+
+	waitForBroadcast := func(t *testing.T, broadcastId string) *model.DirectMessageBroadcast {
+		t.Helper()
+
+		for i := 0; i < 50; i++ {
+			broadcast, err := th.App.GetDirectMessageBroadcast(broadcastId)
+			require.Nil(t, err)
+			if broadcast.IsDone() {
+				return broadcast
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		require.Fail(t, "broadcast didn't finish")
+		return nil
+	}
+
+	t.Run("to users", func(t *testing.T) {
+		broadcast, err := th.App.BroadcastDirectMessage(bot.UserId, []string{th.BasicUser.Id, th.BasicUser2.Id, th.BasicUser.Id, bot.UserId}, "", &model.Post{Message: "announcement"})
+		require.Nil(t, err)
+		assert.Equal(t, 2, broadcast.Total)
+
+		broadcast = waitForBroadcast(t, broadcast.Id)
+		assert.Equal(t, 2, broadcast.Sent)
+		assert.Equal(t, 0, broadcast.Failed)
+
+		for _, userId := range []string{th.BasicUser.Id, th.BasicUser2.Id} {
+			channel, err := th.App.GetOrCreateDirectChannel(bot.UserId, userId)
+			require.Nil(t, err)
+
+			posts, err := th.App.GetPosts(channel.Id, 0, 1)
+			require.Nil(t, err)
+			postArray := posts.ToSlice()
+			require.Len(t, postArray, 1)
+			assert.Equal(t, "announcement", postArray[0].Message)
+			assert.Equal(t, bot.UserId, postArray[0].UserId)
+		}
+	})
+
+	t.Run("to a team", func(t *testing.T) {
+		broadcast, err := th.App.BroadcastDirectMessage(bot.UserId, nil, th.BasicTeam.Id, &model.Post{Message: "team announcement"})
+		require.Nil(t, err)
+
+		broadcast = waitForBroadcast(t, broadcast.Id)
+		assert.Equal(t, broadcast.Total, broadcast.Sent)
+		assert.True(t, broadcast.Sent >= 2)
+	})
+
+	t.Run("failed users are tracked", func(t *testing.T) {
+		missingUserId := model.NewId()
+
+		broadcast, err := th.App.BroadcastDirectMessage(bot.UserId, []string{th.BasicUser.Id, missingUserId}, "", &model.Post{Message: "announcement"})
+		require.Nil(t, err)
+
+		broadcast = waitForBroadcast(t, broadcast.Id)
+		assert.Equal(t, 1, broadcast.Sent)
+		assert.Equal(t, 1, broadcast.Failed)
+		assert.Equal(t, []string{missingUserId}, broadcast.FailedUserIds)
+	})
+
+	t.Run("not a bot", func(t *testing.T) {
+		_, err := th.App.BroadcastDirectMessage(th.BasicUser.Id, []string{th.BasicUser2.Id}, "", &model.Post{Message: "announcement"})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("unknown broadcast", func(t *testing.T) {
+		_, err := th.App.GetDirectMessageBroadcast(model.NewId())
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+}
@@ -273,6 +273,51 @@ func (a *App) SendPasswordResetEmail(email string, token *model.Token, locale, s
 	return true, nil
 }
 
+func (a *App) SendAccountLockedEmail(email, locale, siteURL string, lockoutMinutes int) *model.AppError {
+	T := utils.GetUserTranslations(locale)
+
+	subject := T("api.templates.account_locked_subject",
+		map[string]interface{}{"SiteName": a.ClientConfig()["SiteName"]})
+
+	bodyPage := a.NewEmailTemplate("password_change_body", locale)
+	bodyPage.Props["SiteURL"] = siteURL
+	bodyPage.Props["Title"] = T("api.templates.account_locked_body.title")
+	if lockoutMinutes > 0 {
+		bodyPage.Props["Info"] = T("api.templates.account_locked_body.info_duration",
+			map[string]interface{}{"SiteURL": siteURL, "Minutes": lockoutMinutes})
+	} else {
+		bodyPage.Props["Info"] = T("api.templates.account_locked_body.info",
+			map[string]interface{}{"SiteURL": siteURL})
+	}
+	bodyPage.Props["Warning"] = T("api.templates.email_warning")
+
+	if err := a.SendMail(email, subject, bodyPage.Render()); err != nil {
+		return model.NewAppError("SendAccountLockedEmail", "api.user.send_account_locked_email.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (a *App) SendLoginAnomalyEmail(email, locale, siteURL string, attempt *model.LoginAttempt) *model.AppError {
+	T := utils.GetUserTranslations(locale)
+
+	subject := T("api.templates.login_anomaly_subject",
+		map[string]interface{}{"SiteName": a.ClientConfig()["SiteName"]})
+
+	bodyPage := a.NewEmailTemplate("password_change_body", locale)
+	bodyPage.Props["SiteURL"] = siteURL
+	bodyPage.Props["Title"] = T("api.templates.login_anomaly_body.title")
+	bodyPage.Props["Info"] = T("api.templates.login_anomaly_body.info",
+		map[string]interface{}{"SiteURL": siteURL, "IpAddress": attempt.IpAddress, "Country": attempt.Country, "Device": attempt.Device})
+	bodyPage.Props["Warning"] = T("api.templates.email_warning")
+
+	if err := a.SendMail(email, subject, bodyPage.Render()); err != nil {
+		return model.NewAppError("SendLoginAnomalyEmail", "api.user.send_login_anomaly_email.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
 func (a *App) SendMfaChangeEmail(email string, activated bool, locale, siteURL string) *model.AppError {
 	T := utils.GetUserTranslations(locale)
 
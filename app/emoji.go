@@ -5,6 +5,7 @@ package app
 
 import (
 	"bytes"
+	"encoding/binary"
 	"image"
 	"image/draw"
 	"image/gif"
@@ -29,6 +30,7 @@ const (
 	MaxEmojiHeight         = 128
 	MaxEmojiOriginalWidth  = 1028
 	MaxEmojiOriginalHeight = 1028
+	MaxEmojiFrames         = 500
 )
 
 func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartImageData *multipart.Form) (*model.Emoji, *model.AppError) {
@@ -54,8 +56,16 @@ func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartIma
 		return nil, model.NewAppError("createEmoji", "api.emoji.create.other_user.app_error", nil, "", http.StatusForbidden)
 	}
 
-	if existingEmoji, err := a.Srv.Store.Emoji().GetByName(emoji.Name, true); err == nil && existingEmoji != nil {
-		return nil, model.NewAppError("createEmoji", "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest)
+	if err := a.checkEmojiNameAvailable("createEmoji", emoji.Name, true); err != nil {
+		return nil, err
+	}
+
+	// System admins aren't held to the quota, and their emoji don't need to be approved
+	isAdmin := a.HasPermissionTo(sessionUserId, model.PERMISSION_MANAGE_SYSTEM)
+	if !isAdmin {
+		if err := a.checkCustomEmojiQuota(sessionUserId); err != nil {
+			return nil, err
+		}
 	}
 
 	imageData := multiPartImageData.File["image"]
@@ -64,96 +74,220 @@ func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartIma
 		return nil, err
 	}
 
-	if err := a.UploadEmojiImage(emoji.Id, imageData[0]); err != nil {
+	animated, err := a.uploadEmojiImage(emoji.Id, imageData[0])
+	if err != nil {
 		return nil, err
 	}
+	emoji.Animated = animated
+
+	if !isAdmin && *a.Config().ServiceSettings.RequireCustomEmojiApproval {
+		pendingEmoji := &model.PendingEmoji{
+			Id:        emoji.Id,
+			CreatorId: emoji.CreatorId,
+			Name:      emoji.Name,
+			Animated:  emoji.Animated,
+		}
+		if _, err := a.Srv.Store.PendingEmoji().Save(pendingEmoji); err != nil {
+			a.deleteEmojiImage(emoji.Id)
+			return nil, err
+		}
 
+		emoji.PendingApproval = true
+		return emoji, nil
+	}
+
+	return a.saveEmoji(emoji)
+}
+
+// saveEmoji saves an emoji whose image has been uploaded and lets clients know about it.
+func (a *App) saveEmoji(emoji *model.Emoji) (*model.Emoji, *model.AppError) {
 	emoji, err := a.Srv.Store.Emoji().Save(emoji)
 	if err != nil {
 		return nil, err
 	}
 
+	emojiMetadataCache.Remove(emoji.Name)
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_EMOJI_ADDED, "", "", "", nil)
 	message.Add("emoji", emoji.ToJson())
 	a.Publish(message)
 	return emoji, nil
 }
 
+// checkEmojiNameAvailable checks that no custom emoji, emoji waiting for approval or emoji alias has a name already.
+func (a *App) checkEmojiNameAvailable(where string, name string, allowFromCache bool) *model.AppError {
+	if existingEmoji, err := a.Srv.Store.Emoji().GetByName(name, allowFromCache); err == nil && existingEmoji != nil {
+		return model.NewAppError(where, "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if pendingEmoji, err := a.Srv.Store.PendingEmoji().GetByName(name); err == nil && pendingEmoji != nil {
+		return model.NewAppError(where, "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if alias, err := a.Srv.Store.EmojiAlias().Get(name); err == nil && alias != nil {
+		return model.NewAppError(where, "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// checkCustomEmojiQuota checks that a user can create another emoji, counting those waiting for approval.
+func (a *App) checkCustomEmojiQuota(userId string) *model.AppError {
+	quota := *a.Config().ServiceSettings.CustomEmojiQuotaPerUser
+	if quota == 0 {
+		return nil
+	}
+
+	count, err := a.Srv.Store.Emoji().CountByCreator(userId)
+	if err != nil {
+		return err
+	}
+
+	pendingCount, err := a.Srv.Store.PendingEmoji().CountByCreator(userId)
+	if err != nil {
+		return err
+	}
+
+	if count+pendingCount >= int64(quota) {
+		return model.NewAppError("createEmoji", "api.emoji.create.quota_exceeded.app_error", map[string]interface{}{"Quota": quota}, "user_id="+userId, http.StatusForbidden)
+	}
+
+	return nil
+}
+
 func (a *App) GetEmojiList(page, perPage int, sort string) ([]*model.Emoji, *model.AppError) {
 	return a.Srv.Store.Emoji().GetList(page*perPage, perPage, sort)
 }
 
 func (a *App) UploadEmojiImage(id string, imageData *multipart.FileHeader) *model.AppError {
+	_, err := a.uploadEmojiImage(id, imageData)
+	return err
+}
+
+// uploadEmojiImage stores an uploaded image as the image of an emoji, returning whether the image is animated.
+func (a *App) uploadEmojiImage(id string, imageData *multipart.FileHeader) (bool, *model.AppError) {
 	if !*a.Config().ServiceSettings.EnableCustomEmoji {
-		return model.NewAppError("UploadEmojiImage", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return false, model.NewAppError("UploadEmojiImage", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
 	if len(*a.Config().FileSettings.DriverName) == 0 {
-		return model.NewAppError("UploadEmojiImage", "api.emoji.storage.app_error", nil, "", http.StatusNotImplemented)
+		return false, model.NewAppError("UploadEmojiImage", "api.emoji.storage.app_error", nil, "", http.StatusNotImplemented)
 	}
 
 	file, err := imageData.Open()
 	if err != nil {
-		return model.NewAppError("uploadEmojiImage", "api.emoji.upload.open.app_error", nil, "", http.StatusBadRequest)
+		return false, model.NewAppError("uploadEmojiImage", "api.emoji.upload.open.app_error", nil, "", http.StatusBadRequest)
 	}
 	defer file.Close()
 
 	buf := bytes.NewBuffer(nil)
 	io.Copy(buf, file)
 
+	return a.uploadEmojiImageData(id, buf.Bytes())
+}
+
+// uploadEmojiImageData checks that data is an image within the required limits and stores it as the image of an
+// emoji, shrinking it to the size emoji are displayed at if needed. It returns whether the image is animated.
+func (a *App) uploadEmojiImageData(id string, data []byte) (bool, *model.AppError) {
 	// make sure the file is an image and is within the required dimensions
-	config, _, err := image.DecodeConfig(bytes.NewReader(buf.Bytes()))
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
 	if err != nil {
-		return model.NewAppError("uploadEmojiImage", "api.emoji.upload.image.app_error", nil, "", http.StatusBadRequest)
+		return false, model.NewAppError("uploadEmojiImage", "api.emoji.upload.image.app_error", nil, "", http.StatusBadRequest)
 	}
 
 	if config.Width > MaxEmojiOriginalWidth || config.Height > MaxEmojiOriginalHeight {
-		return model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.too_large.app_error", map[string]interface{}{
+		return false, model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.too_large.app_error", map[string]interface{}{
 			"MaxWidth":  MaxEmojiOriginalWidth,
 			"MaxHeight": MaxEmojiOriginalHeight,
 		}, "", http.StatusBadRequest)
 	}
 
-	if config.Width > MaxEmojiWidth || config.Height > MaxEmojiHeight {
-		data := buf.Bytes()
-		newbuf := bytes.NewBuffer(nil)
-		info, err := model.GetInfoForBytes(imageData.Filename, data)
-		if err != nil {
-			return err
+	tooLarge := config.Width > MaxEmojiWidth || config.Height > MaxEmojiHeight
+	animated := false
+
+	var gifData *gif.GIF
+	switch format {
+	case "gif":
+		if gifData, err = gif.DecodeAll(bytes.NewReader(data)); err != nil {
+			return false, model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.gif_decode_error", nil, "", http.StatusBadRequest)
 		}
 
-		if info.MimeType == "image/gif" {
-			gif_data, err := gif.DecodeAll(bytes.NewReader(data))
-			if err != nil {
-				return model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.gif_decode_error", nil, "", http.StatusBadRequest)
+		if len(gifData.Image) > MaxEmojiFrames {
+			return false, model.NewAppError("uploadEmojiImage", "api.emoji.upload.too_many_frames.app_error", map[string]interface{}{"MaxFrames": MaxEmojiFrames}, "", http.StatusBadRequest)
+		}
+		animated = len(gifData.Image) > 1
+
+	case "png":
+		if frames := getApngFrameCount(data); frames > 0 {
+			if frames > MaxEmojiFrames {
+				return false, model.NewAppError("uploadEmojiImage", "api.emoji.upload.too_many_frames.app_error", map[string]interface{}{"MaxFrames": MaxEmojiFrames}, "", http.StatusBadRequest)
 			}
 
-			resized_gif := resizeEmojiGif(gif_data)
-			if err := gif.EncodeAll(newbuf, resized_gif); err != nil {
-				return model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.gif_encode_error", nil, "", http.StatusBadRequest)
+			// Animated PNGs can't be shrunk without dropping all but their first frame
+			if tooLarge {
+				return false, model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.animated_png.app_error", map[string]interface{}{
+					"MaxWidth":  MaxEmojiWidth,
+					"MaxHeight": MaxEmojiHeight,
+				}, "", http.StatusBadRequest)
 			}
+			animated = true
+		}
+	}
+
+	if tooLarge {
+		newbuf := bytes.NewBuffer(nil)
 
-			if _, err := a.WriteFile(newbuf, getEmojiImagePath(id)); err != nil {
-				return err
+		if gifData != nil {
+			resized_gif := resizeEmojiGif(gifData)
+			if err := gif.EncodeAll(newbuf, resized_gif); err != nil {
+				return false, model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.gif_encode_error", nil, "", http.StatusBadRequest)
 			}
 		} else {
 			img, _, err := image.Decode(bytes.NewReader(data))
 			if err != nil {
-				return model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.decode_error", nil, "", http.StatusBadRequest)
+				return false, model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.decode_error", nil, "", http.StatusBadRequest)
 			}
 
 			resized_image := resizeEmoji(img, config.Width, config.Height)
 			if err := png.Encode(newbuf, resized_image); err != nil {
-				return model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.encode_error", nil, "", http.StatusBadRequest)
+				return false, model.NewAppError("uploadEmojiImage", "api.emoji.upload.large_image.encode_error", nil, "", http.StatusBadRequest)
 			}
-			if _, err := a.WriteFile(newbuf, getEmojiImagePath(id)); err != nil {
-				return err
+		}
+
+		data = newbuf.Bytes()
+	}
+
+	_, appErr := a.WriteFile(bytes.NewReader(data), getEmojiImagePath(id))
+	return animated, appErr
+}
+
+// getApngFrameCount returns the number of frames of an animated PNG, or 0 if data isn't one. A PNG is animated when it
+// has an animation control chunk before its image data.
+func getApngFrameCount(data []byte) int {
+	const signatureLength = 8
+
+	for offset := signatureLength; offset+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[offset : offset+4]))
+		chunkType := string(data[offset+4 : offset+8])
+
+		switch chunkType {
+		case "acTL":
+			if length < 4 || offset+12 > len(data) {
+				return 0
 			}
+			return int(binary.BigEndian.Uint32(data[offset+8 : offset+12]))
+		case "IDAT", "IEND":
+			return 0
 		}
+
+		// Skip the chunk's length, type, data and checksum
+		if length < 0 || length > len(data) {
+			return 0
+		}
+		offset += 12 + length
 	}
 
-	_, appErr := a.WriteFile(buf, getEmojiImagePath(id))
-	return appErr
+	return 0
 }
 
 func (a *App) DeleteEmoji(emoji *model.Emoji) *model.AppError {
@@ -161,8 +295,10 @@ func (a *App) DeleteEmoji(emoji *model.Emoji) *model.AppError {
 		return err
 	}
 
+	emojiMetadataCache.Remove(emoji.Name)
 	a.deleteEmojiImage(emoji.Id)
 	a.deleteReactionsForEmoji(emoji.Name)
+	a.deleteAliasesForEmoji(emoji.Name)
 	return nil
 }
 
@@ -225,11 +361,85 @@ func (a *App) SearchEmoji(name string, prefixOnly bool, limit int) ([]*model.Emo
 	return a.Srv.Store.Emoji().Search(name, prefixOnly, limit)
 }
 
+func (a *App) GetPendingEmojiList(page, perPage int) ([]*model.PendingEmoji, *model.AppError) {
+	return a.Srv.Store.PendingEmoji().GetList(page*perPage, perPage)
+}
+
+func (a *App) GetPendingEmoji(pendingEmojiId string) (*model.PendingEmoji, *model.AppError) {
+	return a.Srv.Store.PendingEmoji().Get(pendingEmojiId)
+}
+
+func (a *App) GetPendingEmojiImage(pendingEmojiId string) ([]byte, string, *model.AppError) {
+	if _, err := a.Srv.Store.PendingEmoji().Get(pendingEmojiId); err != nil {
+		return nil, "", err
+	}
+
+	img, appErr := a.ReadFile(getEmojiImagePath(pendingEmojiId))
+	if appErr != nil {
+		return nil, "", model.NewAppError("getPendingEmojiImage", "api.emoji.get_image.read.app_error", nil, appErr.Error(), http.StatusNotFound)
+	}
+
+	_, imageType, err := image.DecodeConfig(bytes.NewReader(img))
+	if err != nil {
+		return nil, "", model.NewAppError("getPendingEmojiImage", "api.emoji.get_image.decode.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return img, imageType, nil
+}
+
+// ApprovePendingEmoji makes an emoji waiting for approval visible to everyone, keeping the image uploaded with it.
+func (a *App) ApprovePendingEmoji(pendingEmoji *model.PendingEmoji) (*model.Emoji, *model.AppError) {
+	if existingEmoji, err := a.Srv.Store.Emoji().GetByName(pendingEmoji.Name, false); err == nil && existingEmoji != nil {
+		return nil, model.NewAppError("ApprovePendingEmoji", "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if alias, err := a.Srv.Store.EmojiAlias().Get(pendingEmoji.Name); err == nil && alias != nil {
+		return nil, model.NewAppError("ApprovePendingEmoji", "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	emoji := pendingEmoji.ToEmoji()
+	emoji.PreSave()
+	emoji.CreateAt = pendingEmoji.CreateAt
+
+	emoji, err := a.saveEmoji(emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.Srv.Store.PendingEmoji().Delete(pendingEmoji.Id); err != nil {
+		mlog.Warn("Failed to delete an approved pending emoji", mlog.String("emoji_id", pendingEmoji.Id), mlog.Err(err))
+	}
+
+	return emoji, nil
+}
+
+// RejectPendingEmoji drops an emoji waiting for approval along with its image.
+func (a *App) RejectPendingEmoji(pendingEmoji *model.PendingEmoji) *model.AppError {
+	if err := a.Srv.Store.PendingEmoji().Delete(pendingEmoji.Id); err != nil {
+		return err
+	}
+
+	a.deleteEmojiImage(pendingEmoji.Id)
+	return nil
+}
+
+// GetEmojiUsage returns how much custom emoji have been used in reactions, starting with the least used ones.
+func (a *App) GetEmojiUsage(page, perPage int) ([]*model.EmojiUsage, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableCustomEmoji {
+		return nil, model.NewAppError("GetEmojiUsage", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return a.Srv.Store.Emoji().GetUsage(page*perPage, perPage)
+}
+
 // GetEmojiStaticUrl returns a relative static URL for system default emojis,
 // and the API route for custom ones. Errors if not found or if custom and deleted.
+// Aliases resolve to the URL of the emoji they stand for.
 func (a *App) GetEmojiStaticUrl(emojiName string) (string, *model.AppError) {
 	subPath, _ := utils.GetSubpathFromConfig(a.Config())
 
+	emojiName = a.resolveEmojiAliases([]string{emojiName})[0]
+
 	if id, found := model.GetSystemEmojiId(emojiName); found {
 		return path.Join(subPath, "/static/emoji", id+".png"), nil
 	}
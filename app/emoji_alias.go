@@ -0,0 +1,128 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const (
+	EMOJI_ALIAS_CACHE_KEY      = "aliases"
+	EMOJI_ALIAS_CACHE_DURATION = 300
+)
+
+// emojiAliasCache holds every emoji alias under a single key, since they are few and needed together to resolve the
+// emoji used in posts.
+var emojiAliasCache = utils.NewLru(1)
+
+func (a *App) GetEmojiAliases() ([]*model.EmojiAlias, *model.AppError) {
+	if cacheItem, ok := emojiAliasCache.Get(EMOJI_ALIAS_CACHE_KEY); ok {
+		return cacheItem.([]*model.EmojiAlias), nil
+	}
+
+	aliases, err := a.Srv.Store.EmojiAlias().GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	emojiAliasCache.AddWithExpiresInSecs(EMOJI_ALIAS_CACHE_KEY, aliases, EMOJI_ALIAS_CACHE_DURATION)
+	return aliases, nil
+}
+
+func (a *App) GetEmojiAlias(alias string) (*model.EmojiAlias, *model.AppError) {
+	return a.Srv.Store.EmojiAlias().Get(alias)
+}
+
+// CreateEmojiAlias gives an existing system or custom emoji another name. Aliases of aliases aren't allowed, so that
+// aliases resolve in a single step.
+func (a *App) CreateEmojiAlias(alias *model.EmojiAlias) (*model.EmojiAlias, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableCustomEmoji {
+		return nil, model.NewAppError("CreateEmojiAlias", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	alias.PreSave()
+	if err := alias.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkEmojiNameAvailable("CreateEmojiAlias", alias.Alias, false); err != nil {
+		return nil, err
+	}
+
+	if _, err := a.Srv.Store.EmojiAlias().Get(alias.EmojiName); err == nil {
+		return nil, model.NewAppError("CreateEmojiAlias", "app.emoji_alias.alias_of_alias.app_error", nil, "emoji_name="+alias.EmojiName, http.StatusBadRequest)
+	}
+
+	if _, isSystemEmoji := model.GetSystemEmojiId(alias.EmojiName); !isSystemEmoji {
+		if _, err := a.Srv.Store.Emoji().GetByName(alias.EmojiName, false); err != nil {
+			return nil, model.NewAppError("CreateEmojiAlias", "app.emoji_alias.emoji_not_found.app_error", nil, "emoji_name="+alias.EmojiName, http.StatusBadRequest)
+		}
+	}
+
+	alias, err := a.Srv.Store.EmojiAlias().Save(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	emojiAliasCache.Remove(EMOJI_ALIAS_CACHE_KEY)
+	emojiMetadataCache.Remove(alias.Alias)
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_EMOJI_ALIAS_ADDED, "", "", "", nil)
+	message.Add("alias", alias.ToJson())
+	a.Publish(message)
+
+	return alias, nil
+}
+
+func (a *App) DeleteEmojiAlias(alias *model.EmojiAlias) *model.AppError {
+	if err := a.Srv.Store.EmojiAlias().Delete(alias.Alias); err != nil {
+		return err
+	}
+
+	emojiAliasCache.Remove(EMOJI_ALIAS_CACHE_KEY)
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_EMOJI_ALIAS_REMOVED, "", "", "", nil)
+	message.Add("alias", alias.ToJson())
+	a.Publish(message)
+
+	return nil
+}
+
+func (a *App) deleteAliasesForEmoji(emojiName string) {
+	if err := a.Srv.Store.EmojiAlias().DeleteForEmoji(emojiName); err != nil {
+		mlog.Warn("Unable to delete aliases when deleting emoji", mlog.String("emoji_name", emojiName), mlog.Err(err))
+		return
+	}
+
+	emojiAliasCache.Remove(EMOJI_ALIAS_CACHE_KEY)
+}
+
+// resolveEmojiAliases replaces the aliases among emoji names with the names of the emoji they stand for.
+func (a *App) resolveEmojiAliases(names []string) []string {
+	aliases, err := a.GetEmojiAliases()
+	if err != nil {
+		mlog.Warn("Unable to get the emoji aliases", mlog.Err(err))
+		return names
+	}
+
+	if len(aliases) == 0 {
+		return names
+	}
+
+	aliasMap := model.EmojiAliasesToMap(aliases)
+	resolved := make([]string, len(names))
+	for i, name := range names {
+		if emojiName, ok := aliasMap[name]; ok {
+			resolved[i] = emojiName
+		} else {
+			resolved[i] = name
+		}
+	}
+
+	return model.RemoveDuplicateStrings(resolved)
+}
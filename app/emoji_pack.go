@@ -0,0 +1,201 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	MaxEmojiPackFileSize  = 100 << 20 // 100 MB
+	EMOJI_PACK_BATCH_SIZE = 200
+)
+
+// ExportEmojiPack writes every custom emoji to w as an emoji pack, naming each image after its emoji.
+func (a *App) ExportEmojiPack(w io.Writer) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableCustomEmoji {
+		return model.NewAppError("ExportEmojiPack", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	writer := zip.NewWriter(w)
+	pack := &model.EmojiPack{Emojis: []*model.EmojiPackEntry{}}
+
+	for offset := 0; ; offset += EMOJI_PACK_BATCH_SIZE {
+		emojis, err := a.Srv.Store.Emoji().GetList(offset, EMOJI_PACK_BATCH_SIZE, model.EMOJI_SORT_BY_NAME)
+		if err != nil {
+			return err
+		}
+
+		for _, emoji := range emojis {
+			img, appErr := a.ReadFile(getEmojiImagePath(emoji.Id))
+			if appErr != nil {
+				mlog.Warn("Failed to read the image of an exported emoji", mlog.String("emoji_id", emoji.Id), mlog.Err(appErr))
+				continue
+			}
+
+			_, imageType, err := image.DecodeConfig(bytes.NewReader(img))
+			if err != nil {
+				mlog.Warn("Failed to decode the image of an exported emoji", mlog.String("emoji_id", emoji.Id), mlog.Err(err))
+				continue
+			}
+
+			entry := &model.EmojiPackEntry{Name: emoji.Name, Filename: emoji.Name + "." + imageType}
+			if err := writeEmojiPackFile(writer, entry.Filename, img); err != nil {
+				return err
+			}
+			pack.Emojis = append(pack.Emojis, entry)
+		}
+
+		if len(emojis) < EMOJI_PACK_BATCH_SIZE {
+			break
+		}
+	}
+
+	if err := writeEmojiPackFile(writer, model.EMOJI_PACK_MANIFEST_NAME, []byte(pack.ToJson())); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return model.NewAppError("ExportEmojiPack", "app.emoji.export_pack.zip.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func writeEmojiPackFile(writer *zip.Writer, name string, data []byte) *model.AppError {
+	f, err := writer.Create(name)
+	if err == nil {
+		_, err = f.Write(data)
+	}
+	if err != nil {
+		return model.NewAppError("ExportEmojiPack", "app.emoji.export_pack.zip.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// ImportEmojiPack creates an emoji for each image of an emoji pack on behalf of a system admin, so none of them need
+// to be approved. Emoji that can't be imported, such as those whose name is taken, are skipped and reported in the
+// result.
+func (a *App) ImportEmojiPack(userId string, fileData multipart.File, fileSize int64) (*model.EmojiPackImportResult, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableCustomEmoji {
+		return nil, model.NewAppError("ImportEmojiPack", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if len(*a.Config().FileSettings.DriverName) == 0 {
+		return nil, model.NewAppError("ImportEmojiPack", "api.emoji.storage.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	zipReader, err := zip.NewReader(fileData, fileSize)
+	if err != nil {
+		return nil, model.NewAppError("ImportEmojiPack", "app.emoji.import_pack.zip.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	files := make(map[string]*zip.File, len(zipReader.File))
+	for _, file := range zipReader.File {
+		if !file.FileInfo().IsDir() {
+			files[file.Name] = file
+		}
+	}
+
+	pack, appErr := readEmojiPackManifest(files)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	result := &model.EmojiPackImportResult{
+		Imported: []*model.Emoji{},
+		Errors:   map[string]string{},
+	}
+
+	for _, entry := range pack.Emojis {
+		emoji, appErr := a.importEmojiPackEntry(userId, entry, files[entry.Filename])
+		if appErr != nil {
+			result.Errors[entry.Name] = appErr.Message
+			continue
+		}
+		result.Imported = append(result.Imported, emoji)
+	}
+
+	return result, nil
+}
+
+// readEmojiPackManifest reads the manifest of a pack, or makes one up from the images in it when there's none.
+func readEmojiPackManifest(files map[string]*zip.File) (*model.EmojiPack, *model.AppError) {
+	if file, ok := files[model.EMOJI_PACK_MANIFEST_NAME]; ok {
+		reader, err := file.Open()
+		if err != nil {
+			return nil, model.NewAppError("ImportEmojiPack", "app.emoji.import_pack.manifest.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+		defer reader.Close()
+
+		pack := model.EmojiPackFromJson(reader)
+		if pack == nil {
+			return nil, model.NewAppError("ImportEmojiPack", "app.emoji.import_pack.manifest.app_error", nil, "", http.StatusBadRequest)
+		}
+		return pack, nil
+	}
+
+	pack := &model.EmojiPack{}
+	for name := range files {
+		base := filepath.Base(name)
+		pack.Emojis = append(pack.Emojis, &model.EmojiPackEntry{
+			Name:     strings.ToLower(strings.TrimSuffix(base, filepath.Ext(base))),
+			Filename: name,
+		})
+	}
+	return pack, nil
+}
+
+func (a *App) importEmojiPackEntry(userId string, entry *model.EmojiPackEntry, file *zip.File) (*model.Emoji, *model.AppError) {
+	if file == nil {
+		return nil, model.NewAppError("ImportEmojiPack", "app.emoji.import_pack.missing_image.app_error", nil, "filename="+entry.Filename, http.StatusBadRequest)
+	}
+
+	if file.UncompressedSize64 > MaxEmojiFileSize {
+		return nil, model.NewAppError("ImportEmojiPack", "api.emoji.create.too_large.app_error", nil, "filename="+entry.Filename, http.StatusBadRequest)
+	}
+
+	emoji := &model.Emoji{
+		CreatorId: userId,
+		Name:      entry.Name,
+	}
+	emoji.PreSave()
+	if err := emoji.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkEmojiNameAvailable("ImportEmojiPack", emoji.Name, false); err != nil {
+		return nil, err
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, model.NewAppError("ImportEmojiPack", "api.emoji.upload.open.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+	defer reader.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(reader, MaxEmojiFileSize))
+	if err != nil {
+		return nil, model.NewAppError("ImportEmojiPack", "api.emoji.upload.open.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	animated, appErr := a.uploadEmojiImageData(emoji.Id, data)
+	if appErr != nil {
+		return nil, appErr
+	}
+	emoji.Animated = animated
+
+	return a.saveEmoji(emoji)
+}
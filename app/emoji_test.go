@@ -0,0 +1,114 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetApngFrameCount(t *testing.T) {
+	assert.Equal(t, 0, getApngFrameCount(utils.CreateTestPng(t, 10, 10)))
+	assert.Equal(t, 12, getApngFrameCount(utils.CreateTestAnimatedPng(t, 10, 10, 12)))
+	assert.Equal(t, 0, getApngFrameCount(utils.CreateTestGif(t, 10, 10)))
+	assert.Equal(t, 0, getApngFrameCount([]byte("not an image")))
+	assert.Equal(t, 0, getApngFrameCount(nil))
+}
+
+func TestUploadEmojiImageData(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	t.Run("static image", func(t *testing.T) {
+		animated, err := th.App.uploadEmojiImageData(model.NewId(), utils.CreateTestPng(t, 10, 10))
+		require.Nil(t, err)
+		assert.False(t, animated)
+	})
+
+	t.Run("animated gif", func(t *testing.T) {
+		animated, err := th.App.uploadEmojiImageData(model.NewId(), utils.CreateTestAnimatedGif(t, 10, 10, 5))
+		require.Nil(t, err)
+		assert.True(t, animated)
+	})
+
+	t.Run("animated png", func(t *testing.T) {
+		animated, err := th.App.uploadEmojiImageData(model.NewId(), utils.CreateTestAnimatedPng(t, 10, 10, 5))
+		require.Nil(t, err)
+		assert.True(t, animated)
+	})
+
+	t.Run("too many frames", func(t *testing.T) {
+		_, err := th.App.uploadEmojiImageData(model.NewId(), utils.CreateTestAnimatedGif(t, 2, 2, MaxEmojiFrames+1))
+		require.NotNil(t, err)
+		assert.Equal(t, "api.emoji.upload.too_many_frames.app_error", err.Id)
+
+		_, err = th.App.uploadEmojiImageData(model.NewId(), utils.CreateTestAnimatedPng(t, 10, 10, MaxEmojiFrames+1))
+		require.NotNil(t, err)
+		assert.Equal(t, "api.emoji.upload.too_many_frames.app_error", err.Id)
+	})
+
+	t.Run("large animated png", func(t *testing.T) {
+		_, err := th.App.uploadEmojiImageData(model.NewId(), utils.CreateTestAnimatedPng(t, MaxEmojiWidth+1, 10, 5))
+		require.NotNil(t, err)
+		assert.Equal(t, "api.emoji.upload.large_image.animated_png.app_error", err.Id)
+	})
+
+	t.Run("large images are shrunk", func(t *testing.T) {
+		for name, data := range map[string][]byte{
+			"gif":          utils.CreateTestGif(t, 500, 250),
+			"animated gif": utils.CreateTestAnimatedGif(t, 500, 250, 3),
+			"jpeg":         utils.CreateTestJpeg(t, 500, 250),
+		} {
+			id := model.NewId()
+			_, err := th.App.uploadEmojiImageData(id, data)
+			require.Nil(t, err, name)
+
+			stored, err := th.App.ReadFile(getEmojiImagePath(id))
+			require.Nil(t, err, name)
+
+			config, _, decodeErr := image.DecodeConfig(bytes.NewReader(stored))
+			require.Nil(t, decodeErr, name)
+			assert.Equal(t, MaxEmojiWidth, config.Width, name)
+			assert.Equal(t, MaxEmojiHeight/2, config.Height, name)
+		}
+	})
+}
+
+func TestResolveEmojiAliases(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	emoji, err := th.App.Srv.Store.Emoji().Save(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()})
+	require.Nil(t, err)
+
+	alias, err := th.App.CreateEmojiAlias(&model.EmojiAlias{Alias: "alias" + model.NewId()[:10], EmojiName: emoji.Name, CreatorId: th.BasicUser.Id})
+	require.Nil(t, err)
+
+	assert.ElementsMatch(t, []string{emoji.Name, "smile"}, th.App.resolveEmojiAliases([]string{alias.Alias, emoji.Name, "smile"}))
+
+	post := &model.Post{Message: "hello :" + alias.Alias + ":"}
+	emojis, err := th.App.getCustomEmojisForPost(post, nil)
+	require.Nil(t, err)
+	require.Len(t, emojis, 1)
+	assert.Equal(t, emoji.Id, emojis[0].Id)
+
+	require.Nil(t, th.App.DeleteEmoji(emoji))
+
+	aliases, err := th.App.GetEmojiAliases()
+	require.Nil(t, err)
+	for _, a := range aliases {
+		assert.NotEqual(t, alias.Alias, a.Alias, "aliases should be deleted with their emoji")
+	}
+}
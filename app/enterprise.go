@@ -83,6 +83,66 @@ func RegisterJobsPluginsJobInterface(f func(*App) tjobs.PluginsJobInterface) {
 	jobsPluginsInterface = f
 }
 
+var jobsBulkPreferencesInterface func(*App) tjobs.BulkPreferencesJobInterface
+
+func RegisterJobsBulkPreferencesJobInterface(f func(*App) tjobs.BulkPreferencesJobInterface) {
+	jobsBulkPreferencesInterface = f
+}
+
+var jobsChannelTimelineInterface func(*App) tjobs.ChannelTimelineJobInterface
+
+func RegisterJobsChannelTimelineJobInterface(f func(*App) tjobs.ChannelTimelineJobInterface) {
+	jobsChannelTimelineInterface = f
+}
+
+var jobsBulkUsersInterface func(*App) tjobs.BulkUsersJobInterface
+
+func RegisterJobsBulkUsersJobInterface(f func(*App) tjobs.BulkUsersJobInterface) {
+	jobsBulkUsersInterface = f
+}
+
+var jobsChannelReadStatsInterface func(*App) tjobs.ChannelReadStatsJobInterface
+
+func RegisterJobsChannelReadStatsJobInterface(f func(*App) tjobs.ChannelReadStatsJobInterface) {
+	jobsChannelReadStatsInterface = f
+}
+
+var jobsUserDeactivationInterface func(*App) tjobs.UserDeactivationJobInterface
+
+func RegisterJobsUserDeactivationJobInterface(f func(*App) tjobs.UserDeactivationJobInterface) {
+	jobsUserDeactivationInterface = f
+}
+
+var jobsRecurringPostsInterface func(*App) tjobs.RecurringPostsJobInterface
+
+func RegisterJobsRecurringPostsJobInterface(f func(*App) tjobs.RecurringPostsJobInterface) {
+	jobsRecurringPostsInterface = f
+}
+
+var jobsDailyStatsInterface func(*App) tjobs.DailyStatsJobInterface
+
+func RegisterJobsDailyStatsJobInterface(f func(*App) tjobs.DailyStatsJobInterface) {
+	jobsDailyStatsInterface = f
+}
+
+var jobsPostArchiveInterface func(*App) tjobs.PostArchiveJobInterface
+
+func RegisterJobsPostArchiveJobInterface(f func(*App) tjobs.PostArchiveJobInterface) {
+	jobsPostArchiveInterface = f
+}
+
+var jobsSearchReindexInterface func(*App) tjobs.SearchReindexJobInterface
+
+func RegisterJobsSearchReindexJobInterface(f func(*App) tjobs.SearchReindexJobInterface) {
+	jobsSearchReindexInterface = f
+}
+
+var jobsUserAnonymizationInterface func(*App) tjobs.UserAnonymizationJobInterface
+
+func RegisterJobsUserAnonymizationJobInterface(f func(*App) tjobs.UserAnonymizationJobInterface) {
+	jobsUserAnonymizationInterface = f
+}
+
 var ldapInterface func(*App) einterfaces.LdapInterface
 
 func RegisterLdapInterface(f func(*App) einterfaces.LdapInterface) {
@@ -129,7 +189,7 @@ func (s *Server) initEnterprise() {
 	if samlInterface != nil {
 		s.Saml = samlInterface(s.FakeApp())
 		s.AddConfigListener(func(_, cfg *model.Config) {
-			if err := s.Saml.ConfigureSP(); err != nil {
+			if err := s.Saml.ConfigureSP(*cfg.SamlSettings.SignatureAlgorithm, *cfg.SamlSettings.CanonicalAlgorithm); err != nil {
 				mlog.Error("An error occurred while configuring SAML Service Provider", mlog.Err(err))
 			}
 		})
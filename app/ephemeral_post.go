@@ -0,0 +1,73 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	EPHEMERAL_POSTS_FETCH_LIMIT = 200
+)
+
+// persistsEphemeralPosts returns whether the user chose to keep the ephemeral posts sent to them so that they survive
+// reloading the client.
+func (a *App) persistsEphemeralPosts(userId string) bool {
+	preference, err := a.GetPreferenceByCategoryAndNameForUser(userId, model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS, model.PREFERENCE_NAME_PERSIST_EPHEMERAL)
+	return err == nil && preference.Value == "true"
+}
+
+func (a *App) saveEphemeralPost(userId string, post *model.Post) {
+	if !a.persistsEphemeralPosts(userId) {
+		return
+	}
+
+	if _, err := a.Srv.Store.EphemeralPost().Save(model.NewEphemeralPost(userId, post)); err != nil {
+		mlog.Error("Failed to save ephemeral post", mlog.String("post_id", post.Id), mlog.String("user_id", userId), mlog.Err(err))
+	}
+}
+
+func (a *App) updateEphemeralPost(userId string, post *model.Post) {
+	if !a.persistsEphemeralPosts(userId) {
+		return
+	}
+
+	// The post isn't stored when it was sent before the user chose to persist their ephemeral posts
+	if _, err := a.Srv.Store.EphemeralPost().Update(model.NewEphemeralPost(userId, post)); err != nil && err.StatusCode != http.StatusNotFound {
+		mlog.Error("Failed to update ephemeral post", mlog.String("post_id", post.Id), mlog.String("user_id", userId), mlog.Err(err))
+	}
+}
+
+func (a *App) deleteEphemeralPost(userId, postId string) {
+	if err := a.Srv.Store.EphemeralPost().Delete(userId, postId); err != nil {
+		mlog.Error("Failed to delete ephemeral post", mlog.String("post_id", postId), mlog.String("user_id", userId), mlog.Err(err))
+	}
+}
+
+// GetEphemeralPostsForUser returns the persisted ephemeral posts of a user sent or updated after since, so that a
+// client can restore them after reconnecting. Ephemeral posts are never part of the posts of a channel.
+func (a *App) GetEphemeralPostsForUser(userId string, since int64) (*model.PostList, *model.AppError) {
+	ephemeralPosts, err := a.Srv.Store.EphemeralPost().GetForUser(userId, since, EPHEMERAL_POSTS_FETCH_LIMIT)
+	if err != nil {
+		return nil, err
+	}
+
+	list := model.NewPostList()
+	for _, ephemeralPost := range ephemeralPosts {
+		post := ephemeralPost.ToPost()
+		if post == nil {
+			continue
+		}
+
+		post = a.PreparePostForClient(post, true, false)
+		post = model.AddPostActionCookies(post, a.PostActionCookieSecret())
+		list.AddPost(post)
+		list.AddOrder(post.Id)
+	}
+
+	return list, nil
+}
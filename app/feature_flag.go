@@ -0,0 +1,39 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-server/mlog"
+)
+
+// IsFeatureEnabled reports whether the feature flag named name is enabled for the user, who may be empty when there is
+// no user to evaluate the flag for.
+func (a *App) IsFeatureEnabled(name, userId string) bool {
+	return a.Srv.FeatureFlags.IsEnabled(name, userId, a.featureFlagTeamIds(userId))
+}
+
+// GetFeatureFlagsForUser evaluates every feature flag for the user.
+func (a *App) GetFeatureFlagsForUser(userId string) map[string]bool {
+	return a.Srv.FeatureFlags.EnabledFor(userId, a.featureFlagTeamIds(userId))
+}
+
+func (a *App) featureFlagTeamIds(userId string) []string {
+	if userId == "" {
+		return nil
+	}
+
+	members, err := a.GetTeamMembersForUser(userId)
+	if err != nil {
+		mlog.Warn("Failed to get the teams to evaluate the feature flags for", mlog.String("user_id", userId), mlog.Err(err))
+		return nil
+	}
+
+	teamIds := make([]string, 0, len(members))
+	for _, member := range members {
+		if member.DeleteAt == 0 {
+			teamIds = append(teamIds, member.TeamId)
+		}
+	}
+	return teamIds
+}
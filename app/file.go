@@ -22,6 +22,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/disintegration/imaging"
 	"github.com/rwcarlsen/goexif/exif"
@@ -1081,6 +1082,51 @@ func (a *App) GetFileInfo(fileId string) (*model.FileInfo, *model.AppError) {
 	return a.Srv.Store.FileInfo().Get(fileId)
 }
 
+// SetFileInfoVerdict attaches the verdict of an asynchronous scanning or DLP pipeline to a file. When the file is
+// already attached to a post, the post's refreshed metadata is pushed to the channel so that clients can mark or
+// block the attachment retroactively.
+func (a *App) SetFileInfoVerdict(fileId, verdict, reason string) (*model.FileInfo, *model.AppError) {
+	if !model.IsValidFileVerdict(verdict) {
+		return nil, model.NewAppError("SetFileInfoVerdict", "app.file_info.set_verdict.verdict.app_error", nil, "verdict="+verdict, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(reason) > model.FILE_VERDICT_REASON_MAX_RUNES {
+		return nil, model.NewAppError("SetFileInfoVerdict", "app.file_info.set_verdict.reason.app_error", map[string]interface{}{"MaxLength": model.FILE_VERDICT_REASON_MAX_RUNES}, "", http.StatusBadRequest)
+	}
+
+	if err := a.Srv.Store.FileInfo().SetVerdict(fileId, verdict, reason, model.GetMillis()); err != nil {
+		return nil, err
+	}
+
+	info, err := a.GetFileInfo(fileId)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.PostId != "" {
+		a.Srv.Store.FileInfo().InvalidateFileInfosForPostCache(info.PostId)
+		a.sendPostMetadataUpdatedEvent(info.PostId)
+	}
+
+	return info, nil
+}
+
+func (a *App) sendPostMetadataUpdatedEvent(postId string) {
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		mlog.Error("Failed to get post to send its updated metadata", mlog.String("post_id", postId), mlog.Err(err))
+		return
+	}
+
+	a.InvalidateCacheForChannelPosts(post.ChannelId)
+
+	post = a.PreparePostForClient(post, false, false)
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POST_METADATA_UPDATED, "", post.ChannelId, "", nil)
+	message.Add("post", post.ToJson())
+	a.Publish(message)
+}
+
 func (a *App) GetFile(fileId string) ([]byte, *model.AppError) {
 	info, err := a.GetFileInfo(fileId)
 	if err != nil {
@@ -0,0 +1,298 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+// AddGroupChannelMember adds a user to a group message. Group messages are named after their members, so the
+// channel is renamed and adding the user fails if a group message with the resulting members already exists.
+func (a *App) AddGroupChannelMember(channel *model.Channel, userId string, userRequestorId string) (*model.ChannelMember, *model.AppError) {
+	if channel.Type != model.CHANNEL_GROUP {
+		return nil, model.NewAppError("AddGroupChannelMember", "api.channel.group_members.type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if member, err := a.Srv.Store.Channel().GetMember(channel.Id, userId); err != nil {
+		if err.Id != store.MISSING_CHANNEL_MEMBER_ERROR {
+			return nil, err
+		}
+	} else {
+		return member, nil
+	}
+
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DeleteAt > 0 {
+		return nil, model.NewAppError("AddGroupChannelMember", "api.channel.group_members.deactivated.app_error", nil, "user_id="+userId, http.StatusBadRequest)
+	}
+
+	userRequestor, err := a.GetUser(userRequestorId)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := a.getGroupChannelMemberProfiles(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members)+1 > model.CHANNEL_GROUP_MAX_USERS {
+		return nil, model.NewAppError("AddGroupChannelMember", "api.channel.create_group.bad_size.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	oldName := channel.Name
+	if channel, err = a.renameGroupChannel(channel, append(members, user)); err != nil {
+		return nil, err
+	}
+
+	cm := &model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      user.Id,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+		SchemeGuest: user.IsGuest(),
+		SchemeUser:  !user.IsGuest(),
+	}
+	if _, err = a.Srv.Store.Channel().SaveMember(cm); err != nil {
+		if _, revertErr := a.renameGroupChannel(channel, members); revertErr != nil {
+			mlog.Error("Failed to restore the name of a group message", mlog.String("channel_id", channel.Id), mlog.String("name", oldName), mlog.Err(revertErr))
+		}
+		return nil, err
+	}
+	a.WaitForChannelMembership(channel.Id, user.Id)
+
+	if err = a.Srv.Store.ChannelMemberHistory().LogJoinEvent(user.Id, channel.Id, model.GetMillis()); err != nil {
+		mlog.Warn(fmt.Sprintf("Failed to update ChannelMemberHistory table %v", err))
+	}
+
+	a.InvalidateCacheForUser(user.Id)
+	a.InvalidateCacheForChannelMembers(channel.Id)
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_USER_ADDED, "", channel.Id, "", nil)
+	message.Add("user_id", user.Id)
+	message.Add("team_id", channel.TeamId)
+	a.Publish(message)
+
+	a.Srv.Go(func() {
+		a.PostAddToChannelMessage(userRequestor, user, channel, "")
+	})
+
+	return cm, nil
+}
+
+// RemoveGroupChannelMember removes a user from a group message, renaming the channel after its remaining members.
+// A group message can't be left with fewer than CHANNEL_GROUP_MIN_USERS members.
+func (a *App) RemoveGroupChannelMember(channel *model.Channel, userIdToRemove string, removerUserId string) *model.AppError {
+	if channel.Type != model.CHANNEL_GROUP {
+		return model.NewAppError("RemoveGroupChannelMember", "api.channel.group_members.type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if _, err := a.GetChannelMember(channel.Id, userIdToRemove); err != nil {
+		return err
+	}
+
+	user, err := a.GetUser(userIdToRemove)
+	if err != nil {
+		return err
+	}
+
+	members, err := a.getGroupChannelMemberProfiles(channel)
+	if err != nil {
+		return err
+	}
+
+	if len(members)-1 < model.CHANNEL_GROUP_MIN_USERS {
+		return model.NewAppError("RemoveGroupChannelMember", "api.channel.create_group.bad_size.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	remaining := make([]*model.User, 0, len(members)-1)
+	for _, member := range members {
+		if member.Id != userIdToRemove {
+			remaining = append(remaining, member)
+		}
+	}
+
+	if channel, err = a.renameGroupChannel(channel, remaining); err != nil {
+		return err
+	}
+
+	if err = a.Srv.Store.Channel().RemoveMember(channel.Id, userIdToRemove); err != nil {
+		if _, revertErr := a.renameGroupChannel(channel, members); revertErr != nil {
+			mlog.Error("Failed to restore the name of a group message", mlog.String("channel_id", channel.Id), mlog.Err(revertErr))
+		}
+		return err
+	}
+	if err = a.Srv.Store.ChannelMemberHistory().LogLeaveEvent(userIdToRemove, channel.Id, model.GetMillis()); err != nil {
+		mlog.Warn(fmt.Sprintf("Failed to update ChannelMemberHistory table %v", err))
+	}
+
+	a.InvalidateCacheForUser(userIdToRemove)
+	a.InvalidateCacheForChannelMembers(channel.Id)
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_USER_REMOVED, "", channel.Id, "", nil)
+	message.Add("user_id", userIdToRemove)
+	message.Add("remover_id", removerUserId)
+	a.Publish(message)
+
+	// because the removed user no longer belongs to the channel we need to send a separate websocket event
+	userMsg := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_USER_REMOVED, "", "", userIdToRemove, nil)
+	userMsg.Add("channel_id", channel.Id)
+	userMsg.Add("remover_id", removerUserId)
+	a.Publish(userMsg)
+
+	if userIdToRemove == removerUserId {
+		a.postLeaveChannelMessage(user, channel)
+	} else {
+		a.Srv.Go(func() {
+			a.postRemoveFromChannelMessage(removerUserId, user, channel)
+		})
+	}
+
+	return nil
+}
+
+// ConvertGroupChannelToPrivate turns a group message into a private channel of a team, keeping its members and
+// history. Every member must belong to the team, and the user converting it becomes a channel admin.
+func (a *App) ConvertGroupChannelToPrivate(channel *model.Channel, teamId, name, displayName string, user *model.User) (*model.Channel, *model.AppError) {
+	if channel.Type != model.CHANNEL_GROUP {
+		return nil, model.NewAppError("ConvertGroupChannelToPrivate", "api.channel.group_members.type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	team, err := a.GetTeam(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.DeleteAt > 0 {
+		return nil, model.NewAppError("ConvertGroupChannelToPrivate", "api.channel.convert_group_to_channel.deleted_team.app_error", nil, "team_id="+teamId, http.StatusBadRequest)
+	}
+
+	members, err := a.getGroupChannelMemberProfiles(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := make([]string, 0, len(members))
+	for _, member := range members {
+		userIds = append(userIds, member.Id)
+	}
+
+	teamMembers, err := a.Srv.Store.Team().GetMembersByIds(teamId, userIds, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	activeTeamMembers := make(map[string]bool, len(teamMembers))
+	for _, teamMember := range teamMembers {
+		if teamMember.DeleteAt == 0 {
+			activeTeamMembers[teamMember.UserId] = true
+		}
+	}
+
+	var nonTeamMembers []string
+	for _, userId := range userIds {
+		if !activeTeamMembers[userId] {
+			nonTeamMembers = append(nonTeamMembers, userId)
+		}
+	}
+
+	if len(nonTeamMembers) > 0 {
+		return nil, model.NewAppError("ConvertGroupChannelToPrivate", "api.channel.convert_group_to_channel.not_team_members.app_error", nil, "user_ids="+model.ArrayToJson(nonTeamMembers), http.StatusBadRequest)
+	}
+
+	a.InvalidateCacheForChannel(channel)
+
+	converted := channel.DeepCopy()
+	converted.Type = model.CHANNEL_PRIVATE
+	converted.TeamId = teamId
+	converted.Name = name
+	converted.DisplayName = displayName
+
+	if converted, err = a.UpdateChannel(converted); err != nil {
+		return nil, err
+	}
+
+	if _, err = a.UpdateChannelMemberSchemeRoles(converted.Id, user.Id, user.IsGuest(), !user.IsGuest(), true); err != nil {
+		mlog.Error("Failed to make the converting user a channel admin", mlog.String("channel_id", converted.Id), mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+
+	if err = a.postGroupChannelConvertedMessage(user, converted); err != nil {
+		mlog.Error("Failed to post the conversion of a group message", mlog.String("channel_id", converted.Id), mlog.Err(err))
+	}
+
+	for _, userId := range userIds {
+		a.InvalidateCacheForUser(userId)
+	}
+
+	messageWs := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_CHANNEL_CONVERTED, converted.TeamId, "", "", nil)
+	messageWs.Add("channel_id", converted.Id)
+	a.Publish(messageWs)
+
+	return converted, nil
+}
+
+func (a *App) postGroupChannelConvertedMessage(user *model.User, channel *model.Channel) *model.AppError {
+	post := &model.Post{
+		ChannelId: channel.Id,
+		Message:   fmt.Sprintf(utils.T("api.channel.convert_group_to_channel.converted"), user.Username),
+		Type:      model.POST_GROUP_TO_CHANNEL,
+		UserId:    user.Id,
+		Props: model.StringInterface{
+			"username": user.Username,
+		},
+	}
+
+	if _, err := a.CreatePost(post, channel, false); err != nil {
+		return model.NewAppError("postGroupChannelConvertedMessage", "api.channel.post_channel_privacy_message.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (a *App) getGroupChannelMemberProfiles(channel *model.Channel) ([]*model.User, *model.AppError) {
+	members, err := a.Srv.Store.Channel().GetMembers(channel.Id, 0, model.CHANNEL_GROUP_MAX_USERS+1)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := make([]string, 0, len(*members))
+	for _, member := range *members {
+		userIds = append(userIds, member.UserId)
+	}
+
+	return a.Srv.Store.User().GetProfileByIds(userIds, nil, true)
+}
+
+// renameGroupChannel names a group message after the given members, which is how group messages are looked up.
+func (a *App) renameGroupChannel(channel *model.Channel, users []*model.User) (*model.Channel, *model.AppError) {
+	userIds := make([]string, 0, len(users))
+	for _, user := range users {
+		userIds = append(userIds, user.Id)
+	}
+
+	a.InvalidateCacheForChannel(channel)
+
+	renamed := channel.DeepCopy()
+	renamed.Name = model.GetGroupNameFromUserIds(userIds)
+	renamed.DisplayName = model.GetGroupDisplayNameFromUsers(users, true)
+
+	renamed, err := a.UpdateChannel(renamed)
+	if err != nil {
+		if err.Id == "store.sql_channel.update.exists.app_error" {
+			return nil, model.NewAppError("renameGroupChannel", "api.channel.group_members.exists.app_error", nil, err.DetailedError, http.StatusBadRequest)
+		}
+		return nil, err
+	}
+
+	return renamed, nil
+}
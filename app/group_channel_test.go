@@ -0,0 +1,113 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddGroupChannelMember(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user3 := th.CreateUser()
+	user4 := th.CreateUser()
+	channel := th.CreateGroupChannel(th.BasicUser2, user3)
+
+	t.Run("adds the user and renames the channel", func(t *testing.T) {
+		cm, err := th.App.AddGroupChannelMember(channel, user4.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.Equal(t, user4.Id, cm.UserId)
+
+		updated, err := th.App.GetChannel(channel.Id)
+		require.Nil(t, err)
+		assert.Equal(t, model.GetGroupNameFromUserIds([]string{th.BasicUser.Id, th.BasicUser2.Id, user3.Id, user4.Id}), updated.Name)
+
+		found, err := th.App.GetGroupChannel([]string{th.BasicUser.Id, th.BasicUser2.Id, user3.Id, user4.Id})
+		require.Nil(t, err)
+		assert.Equal(t, channel.Id, found.Id)
+	})
+
+	t.Run("fails for other channel types", func(t *testing.T) {
+		_, err := th.App.AddGroupChannelMember(th.BasicChannel, user4.Id, th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.channel.group_members.type.app_error", err.Id)
+	})
+
+	t.Run("fails when a group message with the members exists", func(t *testing.T) {
+		user5 := th.CreateUser()
+		other := th.CreateGroupChannel(th.BasicUser2, user5)
+		_, err := th.App.CreateGroupChannel([]string{th.BasicUser.Id, th.BasicUser2.Id, user5.Id, user3.Id}, th.BasicUser.Id)
+		require.Nil(t, err)
+
+		_, err = th.App.AddGroupChannelMember(other, user3.Id, th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.channel.group_members.exists.app_error", err.Id)
+
+		_, err = th.App.GetChannelMember(other.Id, user3.Id)
+		require.NotNil(t, err)
+	})
+}
+
+func TestRemoveGroupChannelMember(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user3 := th.CreateUser()
+	user4 := th.CreateUser()
+	channel, err := th.App.CreateGroupChannel([]string{th.BasicUser.Id, th.BasicUser2.Id, user3.Id, user4.Id}, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	err = th.App.RemoveGroupChannelMember(channel, user4.Id, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	_, err = th.App.GetChannelMember(channel.Id, user4.Id)
+	require.NotNil(t, err)
+
+	updated, err := th.App.GetChannel(channel.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.GetGroupNameFromUserIds([]string{th.BasicUser.Id, th.BasicUser2.Id, user3.Id}), updated.Name)
+
+	err = th.App.RemoveGroupChannelMember(updated, user3.Id, th.BasicUser.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "api.channel.create_group.bad_size.app_error", err.Id)
+}
+
+func TestConvertGroupChannelToPrivate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user3 := th.CreateUser()
+	channel := th.CreateGroupChannel(th.BasicUser2, user3)
+
+	t.Run("fails when a member isn't on the team", func(t *testing.T) {
+		_, err := th.App.ConvertGroupChannelToPrivate(channel, th.BasicTeam.Id, "converted", "Converted", th.BasicUser)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.channel.convert_group_to_channel.not_team_members.app_error", err.Id)
+	})
+
+	t.Run("converts the group message", func(t *testing.T) {
+		th.LinkUserToTeam(user3, th.BasicTeam)
+
+		converted, err := th.App.ConvertGroupChannelToPrivate(channel, th.BasicTeam.Id, "converted", "Converted", th.BasicUser)
+		require.Nil(t, err)
+		assert.Equal(t, channel.Id, converted.Id)
+		assert.Equal(t, model.CHANNEL_PRIVATE, converted.Type)
+		assert.Equal(t, th.BasicTeam.Id, converted.TeamId)
+		assert.Equal(t, "converted", converted.Name)
+
+		member, err := th.App.GetChannelMember(channel.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.True(t, member.SchemeAdmin)
+
+		for _, userId := range []string{th.BasicUser2.Id, user3.Id} {
+			_, err = th.App.GetChannelMember(channel.Id, userId)
+			require.Nil(t, err)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// parsePostHashtags sets the hashtags of the post from its message, unless hashtags are disabled in the channel.
+func parsePostHashtags(post *model.Post, channel *model.Channel) {
+	post.Hashtags = ""
+	if !channel.HashtagsDisabled {
+		post.Hashtags, _ = model.ParseHashtags(post.Message)
+	}
+}
+
+// indexPostHashtags replaces the entries of the hashtag index for the post with its current hashtags.
+func (a *App) indexPostHashtags(post *model.Post, channel *model.Channel) {
+	if err := a.Srv.Store.Hashtag().SaveForPost(post.Id, model.PostHashtagsForPost(post, channel.TeamId)); err != nil {
+		mlog.Error("Failed to index hashtags for post", mlog.String("post_id", post.Id), mlog.Err(err))
+	}
+}
+
+// GetTrendingHashtags returns the hashtags used in the most posts of the public channels of the team since
+// the given time.
+func (a *App) GetTrendingHashtags(teamId string, since int64, limit int) ([]*model.HashtagCount, *model.AppError) {
+	return a.Srv.Store.Hashtag().GetTrending(teamId, since, limit)
+}
+
+// GetHashtagPostsForUser returns the posts of the team using the hashtag that the user can read, newest first.
+func (a *App) GetHashtagPostsForUser(userId, teamId, hashtag string, page, perPage int) (*model.PostList, *model.AppError) {
+	return a.Srv.Store.Hashtag().GetPostsForUser(userId, teamId, model.NormalizeHashtag(hashtag), page*perPage, perPage)
+}
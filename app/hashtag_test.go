@@ -0,0 +1,68 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestPostHashtagIndex(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post, err := th.App.CreatePost(&model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "#Incident in progress",
+	}, th.BasicChannel, false)
+	require.Nil(t, err)
+	assert.Equal(t, "#Incident", post.Hashtags)
+
+	list, err := th.App.GetHashtagPostsForUser(th.BasicUser.Id, th.BasicTeam.Id, "incident", 0, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []string{post.Id}, list.Order)
+
+	trending, err := th.App.GetTrendingHashtags(th.BasicTeam.Id, 0, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.HashtagCount{{Hashtag: "#incident", Count: 1}}, trending)
+
+	t.Run("update re-indexes the post", func(t *testing.T) {
+		post.Message = "#resolved"
+		post, err = th.App.UpdatePost(post, false)
+		require.Nil(t, err)
+
+		list, err = th.App.GetHashtagPostsForUser(th.BasicUser.Id, th.BasicTeam.Id, "#incident", 0, 10)
+		require.Nil(t, err)
+		assert.Empty(t, list.Order)
+
+		list, err = th.App.GetHashtagPostsForUser(th.BasicUser.Id, th.BasicTeam.Id, "#resolved", 0, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []string{post.Id}, list.Order)
+	})
+
+	t.Run("hashtags disabled in the channel", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		disabled := true
+		channel, err = th.App.PatchChannel(channel, &model.ChannelPatch{HashtagsDisabled: &disabled}, th.BasicUser.Id)
+		require.Nil(t, err)
+		require.True(t, channel.HashtagsDisabled)
+
+		post, err = th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: channel.Id,
+			Message:   "#quiet",
+		}, channel, false)
+		require.Nil(t, err)
+		assert.Empty(t, post.Hashtags)
+
+		list, err = th.App.GetHashtagPostsForUser(th.BasicUser.Id, th.BasicTeam.Id, "#quiet", 0, 10)
+		require.Nil(t, err)
+		assert.Empty(t, list.Order)
+	})
+}
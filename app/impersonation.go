@@ -0,0 +1,100 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+// ImpersonateUser creates a session letting the system admin owning the given session act as another user until
+// the impersonation length configured in the ServiceSettings elapses.
+func (a *App) ImpersonateUser(impersonatorSession *model.Session, userId string) (*model.Session, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableUserImpersonation {
+		return nil, model.NewAppError("ImpersonateUser", "app.impersonation.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if impersonatorSession.IsImpersonated() {
+		return nil, model.NewAppError("ImpersonateUser", "app.impersonation.nested.app_error", nil, "impersonator_id="+impersonatorSession.GetImpersonatorId(), http.StatusForbidden)
+	}
+
+	if impersonatorSession.UserId == userId {
+		return nil, model.NewAppError("ImpersonateUser", "app.impersonation.self.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	impersonator, err := a.GetUser(impersonatorSession.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DeleteAt != 0 || user.IsBot {
+		return nil, model.NewAppError("ImpersonateUser", "app.impersonation.invalid_user.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	if user.IsInRole(model.SYSTEM_ADMIN_ROLE_ID) {
+		return nil, model.NewAppError("ImpersonateUser", "app.impersonation.system_admin.app_error", nil, "user_id="+user.Id, http.StatusForbidden)
+	}
+
+	session := &model.Session{
+		UserId:  user.Id,
+		Roles:   user.GetRawRoles(),
+		IsOAuth: false,
+	}
+
+	session.AddProp(model.SESSION_PROP_TYPE, model.SESSION_TYPE_IMPERSONATION)
+	session.AddProp(model.SESSION_PROP_IMPERSONATOR_ID, impersonator.Id)
+	if user.IsGuest() {
+		session.AddProp(model.SESSION_PROP_IS_GUEST, "true")
+	} else {
+		session.AddProp(model.SESSION_PROP_IS_GUEST, "false")
+	}
+	session.GenerateCSRF()
+
+	length := *a.Config().ServiceSettings.UserImpersonationLengthInMinutes
+	session.ExpiresAt = model.GetMillis() + int64(length)*60*1000
+
+	session, err = a.CreateSession(session)
+	if err != nil {
+		return nil, err
+	}
+
+	if *a.Config().ServiceSettings.NotifyImpersonatedUsers {
+		a.Srv.Go(func() {
+			if err := a.notifyImpersonatedUser(impersonator, user, length); err != nil {
+				mlog.Error("Failed to notify an impersonated user", mlog.String("user_id", user.Id), mlog.Err(err))
+			}
+		})
+	}
+
+	return session, nil
+}
+
+func (a *App) notifyImpersonatedUser(impersonator, user *model.User, length int) *model.AppError {
+	channel, err := a.GetOrCreateDirectChannel(impersonator.Id, user.Id)
+	if err != nil {
+		return err
+	}
+
+	T := utils.GetUserTranslations(user.Locale)
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		Message:   T("app.impersonation.notification.message", map[string]interface{}{"Username": impersonator.Username, "Minutes": length}),
+		UserId:    impersonator.Id,
+	}
+
+	if _, err := a.CreatePost(post, channel, false); err != nil {
+		return err
+	}
+
+	return nil
+}
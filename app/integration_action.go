@@ -14,6 +14,8 @@
 // for the relevant user, telling them to display the dialog.
 // 7. The user fills in the dialog and submits it, where SubmitInteractiveDialog will submit it back to the
 // integration for handling.
+// 8. The integration may respond with errors for the dialog's fields, keeping it open, or with the dialog of the
+// next step of a multi-step flow, which the client shows in place of the submitted one.
 
 package app
 
@@ -214,7 +216,7 @@ func (a *App) DoActionRequest(rawURL string, body []byte) (*http.Response, *mode
 
 	siteURL, _ := url.Parse(*a.Config().ServiceSettings.SiteURL)
 	rawURLPath := path.Clean(rawURL)
-	if siteURL != nil && (strings.HasPrefix(rawURLPath, "/plugins/") || strings.HasPrefix(rawURLPath, "plugins/")) {
+	if siteURL != nil && (strings.HasPrefix(rawURLPath, "/plugins/") || strings.HasPrefix(rawURLPath, "plugins/") || strings.HasPrefix(rawURLPath, POST_REPORT_ACTIONS_PATH)) {
 		inURL.Scheme = siteURL.Scheme
 		inURL.Host = siteURL.Host
 		inURL.Path = path.Join("/", siteURL.Path, rawURLPath)
@@ -228,10 +230,10 @@ func (a *App) DoActionRequest(rawURL string, body []byte) (*http.Response, *mode
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	// Allow access to plugin routes for action buttons
+	// Allow access to plugin routes and post report actions for action buttons
 	var httpClient *http.Client
 	subpath, _ := utils.GetSubpathFromConfig(a.Config())
-	if (inURL.Hostname() == "localhost" || inURL.Hostname() == "127.0.0.1" || inURL.Hostname() == siteURL.Hostname()) && strings.HasPrefix(inURL.Path, path.Join(subpath, "plugins")) {
+	if (inURL.Hostname() == "localhost" || inURL.Hostname() == "127.0.0.1" || inURL.Hostname() == siteURL.Hostname()) && (strings.HasPrefix(inURL.Path, path.Join(subpath, "plugins")) || strings.HasPrefix(inURL.Path, path.Join(subpath, POST_REPORT_ACTIONS_PATH))) {
 		req.Header.Set(model.HEADER_AUTH, "Bearer "+a.Session.Token)
 		httpClient = a.HTTPService.MakeClient(true)
 	} else {
@@ -257,6 +259,13 @@ func (a *App) OpenInteractiveDialog(request model.OpenDialogRequest) *model.AppE
 	}
 
 	request.TriggerId = clientTriggerId
+	request.DialogId = model.NewId()
+
+	a.saveInteractiveDialogSession(request.DialogId, &interactiveDialogSession{
+		UserId: userId,
+		URL:    request.URL,
+		Dialog: request.Dialog,
+	})
 
 	jsonRequest, _ := json.Marshal(request)
 
@@ -267,7 +276,32 @@ func (a *App) OpenInteractiveDialog(request model.OpenDialogRequest) *model.AppE
 	return nil
 }
 
+// SubmitInteractiveDialog submits a dialog to its integration. Dialogs opened with OpenInteractiveDialog are submitted
+// to the URL they were opened with, with their callback id and state, after their elements have been validated. The
+// integration may respond with the dialog of the next step, which then replaces the submitted one.
 func (a *App) SubmitInteractiveDialog(request model.SubmitDialogRequest) (*model.SubmitDialogResponse, *model.AppError) {
+	var session *interactiveDialogSession
+	if request.DialogId != "" {
+		session = a.getInteractiveDialogSession(request.DialogId, request.UserId)
+		if session == nil {
+			return nil, model.NewAppError("SubmitInteractiveDialog", "app.submit_interactive_dialog.expired.app_error", nil, "dialog_id="+request.DialogId, http.StatusBadRequest)
+		}
+
+		request.URL = session.URL
+		request.CallbackId = session.Dialog.CallbackId
+		request.State = session.Dialog.State
+		request.Step = session.Step
+
+		if request.Cancelled {
+			a.Srv.interactiveDialogCache.Remove(request.DialogId)
+		} else if errors := a.validateDialogSubmission(session.Dialog.Elements, &request); len(errors) > 0 {
+			return &model.SubmitDialogResponse{
+				Errors:   errors,
+				DialogId: request.DialogId,
+			}, nil
+		}
+	}
+
 	url := request.URL
 	request.URL = ""
 	request.Type = "dialog_submission"
@@ -287,8 +321,36 @@ func (a *App) SubmitInteractiveDialog(request model.SubmitDialogRequest) (*model
 	var response model.SubmitDialogResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		// Don't fail, an empty response is acceptable
+		response = model.SubmitDialogResponse{}
+	}
+
+	if request.Cancelled {
 		return &response, nil
 	}
 
+	if response.Next != nil {
+		// Dialogs submitted without an id still have the next steps of their flow kept on the server
+		if session == nil {
+			request.DialogId = model.NewId()
+			session = &interactiveDialogSession{
+				UserId: request.UserId,
+				URL:    url,
+			}
+		}
+
+		session.Dialog = *response.Next
+		session.Step++
+		a.saveInteractiveDialogSession(request.DialogId, session)
+
+		response.DialogId = request.DialogId
+	} else if session != nil {
+		// The dialog stays open when the integration rejects its submission
+		if response.Error == "" && len(response.Errors) == 0 {
+			a.Srv.interactiveDialogCache.Remove(request.DialogId)
+		} else {
+			response.DialogId = request.DialogId
+		}
+	}
+
 	return &response, nil
 }
@@ -470,6 +470,125 @@ func TestSubmitInteractiveDialog(t *testing.T) {
 	assert.Equal(t, "some error", resp.Errors["name1"])
 }
 
+func TestSubmitInteractiveDialogMultiStep(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	var requests []model.SubmitDialogRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request model.SubmitDialogRequest
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+		requests = append(requests, request)
+
+		if request.Step == 0 {
+			w.Write((&model.SubmitDialogResponse{
+				Next: &model.Dialog{
+					CallbackId: "step2",
+					State:      "state2",
+					Elements: []model.DialogElement{
+						{Name: "due", Type: model.DIALOG_ELEMENT_TYPE_DATE},
+					},
+				},
+			}).ToJson())
+		}
+	}))
+	defer ts.Close()
+
+	dialogId := model.NewId()
+	th.App.saveInteractiveDialogSession(dialogId, &interactiveDialogSession{
+		UserId: th.BasicUser.Id,
+		URL:    ts.URL,
+		Dialog: model.Dialog{
+			CallbackId: "step1",
+			State:      "state1",
+			Elements: []model.DialogElement{
+				{Name: "title", Type: model.DIALOG_ELEMENT_TYPE_TEXT, MinLength: 3},
+				{Name: "reviewer", Type: model.DIALOG_ELEMENT_TYPE_USER},
+				{Name: "channel", Type: model.DIALOG_ELEMENT_TYPE_CHANNEL, Optional: true},
+				{Name: "attachment", Type: model.DIALOG_ELEMENT_TYPE_FILE, Optional: true},
+			},
+		},
+	})
+
+	submit := func(userId string, submission map[string]interface{}) (*model.SubmitDialogResponse, *model.AppError) {
+		return th.App.SubmitInteractiveDialog(model.SubmitDialogRequest{
+			DialogId:   dialogId,
+			UserId:     userId,
+			ChannelId:  th.BasicChannel.Id,
+			TeamId:     th.BasicTeam.Id,
+			URL:        "http://example.com/ignored",
+			State:      "ignored",
+			Submission: submission,
+		})
+	}
+
+	t.Run("another user can't submit the dialog", func(t *testing.T) {
+		_, err := submit(th.BasicUser2.Id, map[string]interface{}{})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.submit_interactive_dialog.expired.app_error", err.Id)
+	})
+
+	t.Run("invalid fields aren't submitted to the integration", func(t *testing.T) {
+		resp, err := submit(th.BasicUser.Id, map[string]interface{}{
+			"title":      "ab",
+			"reviewer":   model.NewId(),
+			"channel":    model.NewId(),
+			"attachment": model.NewId(),
+		})
+		require.Nil(t, err)
+		assert.Len(t, resp.Errors, 4)
+		assert.Equal(t, dialogId, resp.DialogId)
+		assert.Empty(t, requests)
+
+		resp, err = submit(th.BasicUser.Id, map[string]interface{}{})
+		require.Nil(t, err)
+		assert.Len(t, resp.Errors, 2)
+		assert.Contains(t, resp.Errors, "title")
+		assert.Contains(t, resp.Errors, "reviewer")
+	})
+
+	t.Run("the integration responds with the next step", func(t *testing.T) {
+		resp, err := submit(th.BasicUser.Id, map[string]interface{}{
+			"title":    "Release",
+			"reviewer": th.BasicUser2.Id,
+			"channel":  th.BasicChannel.Id,
+		})
+		require.Nil(t, err)
+		assert.Empty(t, resp.Errors)
+		require.NotNil(t, resp.Next)
+		assert.Equal(t, "step2", resp.Next.CallbackId)
+		assert.Equal(t, dialogId, resp.DialogId)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, "step1", requests[0].CallbackId)
+		assert.Equal(t, "state1", requests[0].State)
+		assert.Equal(t, 0, requests[0].Step)
+	})
+
+	t.Run("the last step closes the dialog", func(t *testing.T) {
+		resp, err := submit(th.BasicUser.Id, map[string]interface{}{"due": "2019-13-40"})
+		require.Nil(t, err)
+		assert.Contains(t, resp.Errors, "due")
+
+		resp, err = submit(th.BasicUser.Id, map[string]interface{}{"due": "2019-11-05"})
+		require.Nil(t, err)
+		assert.Empty(t, resp.Errors)
+		assert.Nil(t, resp.Next)
+
+		require.Len(t, requests, 2)
+		assert.Equal(t, "step2", requests[1].CallbackId)
+		assert.Equal(t, "state2", requests[1].State)
+		assert.Equal(t, 1, requests[1].Step)
+
+		_, err = submit(th.BasicUser.Id, map[string]interface{}{"due": "2019-11-05"})
+		require.NotNil(t, err)
+	})
+}
+
 func TestPostActionRelativeURL(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -0,0 +1,139 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// integrationStatsTracker accumulates the activity of the integrations handled by this server until it is rolled up
+// into the stats stored for the current day, which are shared across a cluster.
+type integrationStatsTracker struct {
+	mutex sync.Mutex
+	stats map[string]*model.IntegrationStat
+}
+
+func newIntegrationStatsTracker() *integrationStatsTracker {
+	return &integrationStatsTracker{
+		stats: map[string]*model.IntegrationStat{},
+	}
+}
+
+func (t *integrationStatsTracker) add(integrationType, integrationId string, activity *model.IntegrationStat) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := integrationType + ":" + integrationId
+	stat, ok := t.stats[key]
+	if !ok {
+		stat = &model.IntegrationStat{IntegrationType: integrationType, IntegrationId: integrationId}
+		t.stats[key] = stat
+	}
+
+	stat.Add(activity)
+}
+
+// take returns the activity accumulated since it was last taken.
+func (t *integrationStatsTracker) take() []*model.IntegrationStat {
+	t.mutex.Lock()
+	stats := t.stats
+	t.stats = map[string]*model.IntegrationStat{}
+	t.mutex.Unlock()
+
+	list := make([]*model.IntegrationStat, 0, len(stats))
+	for _, stat := range stats {
+		list = append(list, stat)
+	}
+
+	return list
+}
+
+// recordIntegrationRequest records a request made to or by an integration, and how long it took.
+func (a *App) recordIntegrationRequest(integrationType, integrationId string, latency time.Duration, failed bool) {
+	if !*a.Config().AnalyticsSettings.EnableIntegrationStats || a.Srv.integrationStats == nil {
+		return
+	}
+
+	activity := &model.IntegrationStat{
+		Requests:       1,
+		TotalLatencyMs: int64(latency / time.Millisecond),
+		MaxLatencyMs:   int64(latency / time.Millisecond),
+	}
+	if failed {
+		activity.Errors = 1
+	}
+
+	a.Srv.integrationStats.add(integrationType, integrationId, activity)
+}
+
+// recordIntegrationPost records a post created by an integration.
+func (a *App) recordIntegrationPost(integrationType, integrationId string) {
+	if !*a.Config().AnalyticsSettings.EnableIntegrationStats || a.Srv.integrationStats == nil {
+		return
+	}
+
+	a.Srv.integrationStats.add(integrationType, integrationId, &model.IntegrationStat{Posts: 1})
+}
+
+// RecordBotRequest records an API request made by a bot.
+func (a *App) RecordBotRequest(botUserId string, latency time.Duration, failed bool) {
+	a.recordIntegrationRequest(model.INTEGRATION_TYPE_BOT, botUserId, latency, failed)
+}
+
+// RollupIntegrationStats adds the activity of the integrations handled by this server since the last roll up to the
+// stats of the current day.
+func (a *App) RollupIntegrationStats() *model.AppError {
+	if a.Srv.integrationStats == nil {
+		return nil
+	}
+
+	date := time.Now().UTC().Format(model.DAILY_STAT_DATE_FORMAT)
+
+	var lastErr *model.AppError
+	for _, stat := range a.Srv.integrationStats.take() {
+		stat.Date = date
+		if err := a.Srv.Store.IntegrationStat().Increment(stat); err != nil {
+			mlog.Error("Failed to save integration stat", mlog.String("integration_type", stat.IntegrationType), mlog.String("integration_id", stat.IntegrationId), mlog.Err(err))
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// DeleteOldIntegrationStats deletes the integration stats older than the configured retention.
+func (a *App) DeleteOldIntegrationStats() *model.AppError {
+	retentionDays := *a.Config().AnalyticsSettings.IntegrationStatsRetentionDays
+	if retentionDays == 0 {
+		return nil
+	}
+
+	before := time.Now().UTC().AddDate(0, 0, -retentionDays).Format(model.DAILY_STAT_DATE_FORMAT)
+	_, err := a.Srv.Store.IntegrationStat().PermanentDeleteBefore(before)
+	return err
+}
+
+// GetIntegrationStats returns the activity of each integration of the given type, or of every type when none is
+// given, for the dates in [since, until], with the busiest integrations first.
+func (a *App) GetIntegrationStats(integrationType, since, until string) ([]*model.IntegrationStat, *model.AppError) {
+	if !*a.Config().AnalyticsSettings.EnableIntegrationStats {
+		return nil, model.NewAppError("GetIntegrationStats", "app.integration_stats.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return a.Srv.Store.IntegrationStat().GetTotals(integrationType, since, until)
+}
+
+// GetIntegrationDailyStats returns the activity of an integration for each of the dates in [since, until].
+func (a *App) GetIntegrationDailyStats(integrationType, integrationId, since, until string) ([]*model.IntegrationStat, *model.AppError) {
+	if !*a.Config().AnalyticsSettings.EnableIntegrationStats {
+		return nil, model.NewAppError("GetIntegrationDailyStats", "app.integration_stats.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return a.Srv.Store.IntegrationStat().GetDaily(integrationType, integrationId, since, until)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestIntegrationStatsTracker(t *testing.T) {
+	tracker := newIntegrationStatsTracker()
+	hookId := model.NewId()
+
+	tracker.add(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, hookId, &model.IntegrationStat{Requests: 1, TotalLatencyMs: 10, MaxLatencyMs: 10})
+	tracker.add(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, hookId, &model.IntegrationStat{Requests: 1, Errors: 1, TotalLatencyMs: 30, MaxLatencyMs: 30})
+	tracker.add(model.INTEGRATION_TYPE_BOT, hookId, &model.IntegrationStat{Requests: 1})
+
+	stats := tracker.take()
+	require.Len(t, stats, 2)
+	for _, stat := range stats {
+		if stat.IntegrationType == model.INTEGRATION_TYPE_INCOMING_WEBHOOK {
+			assert.Equal(t, int64(2), stat.Requests)
+			assert.Equal(t, int64(1), stat.Errors)
+			assert.Equal(t, int64(40), stat.TotalLatencyMs)
+			assert.Equal(t, int64(30), stat.MaxLatencyMs)
+		}
+	}
+
+	assert.Empty(t, tracker.take())
+}
+
+func TestIncomingWebhookIntegrationStats(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableIncomingWebhooks = true
+		*cfg.AnalyticsSettings.EnableIntegrationStats = true
+	})
+
+	hook, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	require.Nil(t, err)
+
+	require.Nil(t, th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{Text: "first"}))
+	require.Nil(t, th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{Text: "second"}))
+	require.NotNil(t, th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{}))
+
+	require.Nil(t, th.App.RollupIntegrationStats())
+
+	today := time.Now().UTC().Format(model.DAILY_STAT_DATE_FORMAT)
+	stats, err := th.App.GetIntegrationDailyStats(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, hook.Id, today, today)
+	require.Nil(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(3), stats[0].Requests)
+	assert.Equal(t, int64(2), stats[0].Posts)
+	assert.Equal(t, int64(1), stats[0].Errors)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.AnalyticsSettings.EnableIntegrationStats = false })
+	_, err = th.App.GetIntegrationStats("", today, today)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotImplemented, err.StatusCode)
+}
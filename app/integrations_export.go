@@ -0,0 +1,385 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const INTEGRATIONS_EXPORT_PAGE_SIZE = 200
+
+// integrationsNameResolver caches the lookups of team, channel and user names done while exporting.
+type integrationsNameResolver struct {
+	a        *App
+	teams    map[string]string
+	channels map[string]string
+	users    map[string]string
+}
+
+func (r *integrationsNameResolver) team(teamId string) string {
+	if name, ok := r.teams[teamId]; ok {
+		return name
+	}
+	name := ""
+	if team, err := r.a.Srv.Store.Team().Get(teamId); err == nil {
+		name = team.Name
+	}
+	r.teams[teamId] = name
+	return name
+}
+
+func (r *integrationsNameResolver) channel(channelId string) string {
+	if channelId == "" {
+		return ""
+	}
+	if name, ok := r.channels[channelId]; ok {
+		return name
+	}
+	name := ""
+	if channel, err := r.a.Srv.Store.Channel().Get(channelId, true); err == nil {
+		name = channel.Name
+	}
+	r.channels[channelId] = name
+	return name
+}
+
+// user returns the username of the given user, or the id itself if it doesn't belong to a user.
+func (r *integrationsNameResolver) user(userId string) string {
+	if name, ok := r.users[userId]; ok {
+		return name
+	}
+	name := userId
+	if user, err := r.a.Srv.Store.User().Get(userId); err == nil {
+		name = user.Username
+	}
+	r.users[userId] = name
+	return name
+}
+
+// ExportIntegrations exports the webhooks, slash commands, OAuth apps and bots configured on the server.
+// Tokens and client secrets are replaced with placeholders.
+func (a *App) ExportIntegrations() (*model.IntegrationsExport, *model.AppError) {
+	resolver := &integrationsNameResolver{
+		a:        a,
+		teams:    map[string]string{},
+		channels: map[string]string{},
+		users:    map[string]string{},
+	}
+
+	export := &model.IntegrationsExport{
+		Version:          model.INTEGRATIONS_EXPORT_VERSION,
+		IncomingWebhooks: []*model.IncomingWebhookExport{},
+		OutgoingWebhooks: []*model.OutgoingWebhookExport{},
+		Commands:         []*model.CommandExport{},
+		OAuthApps:        []*model.OAuthAppExport{},
+		Bots:             []*model.BotExport{},
+	}
+
+	for offset := 0; ; offset += INTEGRATIONS_EXPORT_PAGE_SIZE {
+		hooks, err := a.Srv.Store.Webhook().GetIncomingList(offset, INTEGRATIONS_EXPORT_PAGE_SIZE)
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			export.IncomingWebhooks = append(export.IncomingWebhooks, &model.IncomingWebhookExport{
+				Team:               resolver.team(hook.TeamId),
+				Channel:            resolver.channel(hook.ChannelId),
+				Creator:            resolver.user(hook.UserId),
+				DisplayName:        hook.DisplayName,
+				Description:        hook.Description,
+				Username:           hook.Username,
+				IconURL:            hook.IconURL,
+				ChannelLocked:      hook.ChannelLocked,
+				PayloadTemplate:    hook.PayloadTemplate,
+				RateLimitPerMinute: hook.RateLimitPerMinute,
+				DailyQuota:         hook.DailyQuota,
+			})
+		}
+		if len(hooks) < INTEGRATIONS_EXPORT_PAGE_SIZE {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += INTEGRATIONS_EXPORT_PAGE_SIZE {
+		hooks, err := a.Srv.Store.Webhook().GetOutgoingList(offset, INTEGRATIONS_EXPORT_PAGE_SIZE)
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			export.OutgoingWebhooks = append(export.OutgoingWebhooks, &model.OutgoingWebhookExport{
+				Team:         resolver.team(hook.TeamId),
+				Channel:      resolver.channel(hook.ChannelId),
+				Creator:      resolver.user(hook.CreatorId),
+				Token:        model.INTEGRATIONS_EXPORT_SECRET_PLACEHOLDER,
+				TriggerWords: hook.TriggerWords,
+				TriggerWhen:  hook.TriggerWhen,
+				CallbackURLs: hook.CallbackURLs,
+				DisplayName:  hook.DisplayName,
+				Description:  hook.Description,
+				ContentType:  hook.ContentType,
+				Username:     hook.Username,
+				IconURL:      hook.IconURL,
+				EventTypes:   hook.EventTypes,
+				TestMode:     hook.TestMode,
+			})
+		}
+		if len(hooks) < INTEGRATIONS_EXPORT_PAGE_SIZE {
+			break
+		}
+	}
+
+	teams, err := a.Srv.Store.Team().GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		commands, err := a.Srv.Store.Command().GetByTeam(team.Id)
+		if err != nil {
+			return nil, err
+		}
+		for _, cmd := range commands {
+			export.Commands = append(export.Commands, &model.CommandExport{
+				Team:             team.Name,
+				Creator:          resolver.user(cmd.CreatorId),
+				Token:            model.INTEGRATIONS_EXPORT_SECRET_PLACEHOLDER,
+				Trigger:          cmd.Trigger,
+				Method:           cmd.Method,
+				Username:         cmd.Username,
+				IconURL:          cmd.IconURL,
+				AutoComplete:     cmd.AutoComplete,
+				AutoCompleteDesc: cmd.AutoCompleteDesc,
+				AutoCompleteHint: cmd.AutoCompleteHint,
+				DisplayName:      cmd.DisplayName,
+				Description:      cmd.Description,
+				URL:              cmd.URL,
+			})
+		}
+	}
+
+	for offset := 0; ; offset += INTEGRATIONS_EXPORT_PAGE_SIZE {
+		apps, err := a.Srv.Store.OAuth().GetApps(offset, INTEGRATIONS_EXPORT_PAGE_SIZE)
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			export.OAuthApps = append(export.OAuthApps, &model.OAuthAppExport{
+				Creator:      resolver.user(app.CreatorId),
+				ClientSecret: model.INTEGRATIONS_EXPORT_SECRET_PLACEHOLDER,
+				Name:         app.Name,
+				Description:  app.Description,
+				IconURL:      app.IconURL,
+				CallbackUrls: app.CallbackUrls,
+				Homepage:     app.Homepage,
+				IsTrusted:    app.IsTrusted,
+			})
+		}
+		if len(apps) < INTEGRATIONS_EXPORT_PAGE_SIZE {
+			break
+		}
+	}
+
+	for page := 0; ; page++ {
+		bots, err := a.Srv.Store.Bot().GetAll(&model.BotGetOptions{Page: page, PerPage: INTEGRATIONS_EXPORT_PAGE_SIZE})
+		if err != nil {
+			return nil, err
+		}
+		for _, bot := range bots {
+			export.Bots = append(export.Bots, &model.BotExport{
+				Username:    bot.Username,
+				DisplayName: bot.DisplayName,
+				Description: bot.Description,
+				Owner:       resolver.user(bot.OwnerId),
+			})
+		}
+		if len(bots) < INTEGRATIONS_EXPORT_PAGE_SIZE {
+			break
+		}
+	}
+
+	return export, nil
+}
+
+// ImportIntegrations creates the integrations described by an export. Creators that don't exist on this
+// server are replaced with the importing user. Bots whose owner isn't a user of this server, such as those owned by
+// plugins which create their bots themselves, are not imported. Integrations that can't be created are skipped and
+// reported in the result rather than aborting the import.
+func (a *App) ImportIntegrations(export *model.IntegrationsExport, importerId string) (*model.IntegrationsImportResult, *model.AppError) {
+	if export == nil || export.Version != model.INTEGRATIONS_EXPORT_VERSION {
+		return nil, model.NewAppError("ImportIntegrations", "app.integrations.import.version.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	result := &model.IntegrationsImportResult{Errors: []string{}}
+	fail := func(kind, name string, err *model.AppError) {
+		result.Errors = append(result.Errors, fmt.Sprintf("%s %s: %s", kind, name, err.Error()))
+	}
+
+	userId := func(username string) string {
+		if user, err := a.Srv.Store.User().GetByUsername(username); err == nil {
+			return user.Id
+		}
+		return importerId
+	}
+
+	// Bots are imported first since they can be the creators of the other integrations.
+	for _, item := range export.Bots {
+		ownerId := importerId
+		if item.Owner != "" {
+			user, err := a.Srv.Store.User().GetByUsername(item.Owner)
+			if err != nil {
+				fail("bot", item.Username, model.NewAppError("ImportIntegrations", "app.integrations.import.bot_owner.app_error", map[string]interface{}{"Owner": item.Owner}, err.Error(), http.StatusBadRequest))
+				continue
+			}
+			ownerId = user.Id
+		}
+
+		if _, err := a.CreateBot(&model.Bot{
+			Username:    item.Username,
+			DisplayName: item.DisplayName,
+			Description: item.Description,
+			OwnerId:     ownerId,
+		}); err != nil {
+			fail("bot", item.Username, err)
+			continue
+		}
+		result.Bots++
+	}
+
+	for _, item := range export.IncomingWebhooks {
+		channel, err := a.getImportedIntegrationChannel(item.Team, item.Channel)
+		if err != nil {
+			fail("incoming webhook", item.DisplayName, err)
+			continue
+		}
+
+		if _, err := a.CreateIncomingWebhookForChannel(userId(item.Creator), channel, &model.IncomingWebhook{
+			ChannelId:          channel.Id,
+			DisplayName:        item.DisplayName,
+			Description:        item.Description,
+			Username:           item.Username,
+			IconURL:            item.IconURL,
+			ChannelLocked:      item.ChannelLocked,
+			PayloadTemplate:    item.PayloadTemplate,
+			RateLimitPerMinute: item.RateLimitPerMinute,
+			DailyQuota:         item.DailyQuota,
+		}); err != nil {
+			fail("incoming webhook", item.DisplayName, err)
+			continue
+		}
+		result.IncomingWebhooks++
+	}
+
+	for _, item := range export.OutgoingWebhooks {
+		team, err := a.Srv.Store.Team().GetByName(item.Team)
+		if err != nil {
+			fail("outgoing webhook", item.DisplayName, err)
+			continue
+		}
+
+		hook := &model.OutgoingWebhook{
+			CreatorId:    userId(item.Creator),
+			TeamId:       team.Id,
+			TriggerWords: item.TriggerWords,
+			TriggerWhen:  item.TriggerWhen,
+			CallbackURLs: item.CallbackURLs,
+			DisplayName:  item.DisplayName,
+			Description:  item.Description,
+			ContentType:  item.ContentType,
+			Username:     item.Username,
+			IconURL:      item.IconURL,
+			EventTypes:   item.EventTypes,
+			TestMode:     item.TestMode,
+		}
+		if !model.IsSecretPlaceholder(item.Token) {
+			hook.Token = item.Token
+		}
+
+		if item.Channel != "" {
+			channel, err := a.getImportedIntegrationChannel(item.Team, item.Channel)
+			if err != nil {
+				fail("outgoing webhook", item.DisplayName, err)
+				continue
+			}
+			hook.ChannelId = channel.Id
+		}
+
+		if _, err := a.CreateOutgoingWebhook(hook); err != nil {
+			fail("outgoing webhook", item.DisplayName, err)
+			continue
+		}
+		result.OutgoingWebhooks++
+	}
+
+	for _, item := range export.Commands {
+		team, err := a.Srv.Store.Team().GetByName(item.Team)
+		if err != nil {
+			fail("command", item.Trigger, err)
+			continue
+		}
+
+		cmd := &model.Command{
+			CreatorId:        userId(item.Creator),
+			TeamId:           team.Id,
+			Trigger:          item.Trigger,
+			Method:           item.Method,
+			Username:         item.Username,
+			IconURL:          item.IconURL,
+			AutoComplete:     item.AutoComplete,
+			AutoCompleteDesc: item.AutoCompleteDesc,
+			AutoCompleteHint: item.AutoCompleteHint,
+			DisplayName:      item.DisplayName,
+			Description:      item.Description,
+			URL:              item.URL,
+		}
+		if !model.IsSecretPlaceholder(item.Token) {
+			cmd.Token = item.Token
+		}
+
+		if _, err := a.CreateCommand(cmd); err != nil {
+			fail("command", item.Trigger, err)
+			continue
+		}
+		result.Commands++
+	}
+
+	for _, item := range export.OAuthApps {
+		app := &model.OAuthApp{
+			CreatorId:    userId(item.Creator),
+			Name:         item.Name,
+			Description:  item.Description,
+			IconURL:      item.IconURL,
+			CallbackUrls: item.CallbackUrls,
+			Homepage:     item.Homepage,
+			IsTrusted:    item.IsTrusted,
+		}
+
+		var err *model.AppError
+		if model.IsSecretPlaceholder(item.ClientSecret) {
+			_, err = a.CreateOAuthApp(app)
+		} else if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+			err = model.NewAppError("ImportIntegrations", "api.oauth.register_oauth_app.turn_off.app_error", nil, "", http.StatusNotImplemented)
+		} else {
+			app.ClientSecret = item.ClientSecret
+			_, err = a.Srv.Store.OAuth().SaveApp(app)
+		}
+		if err != nil {
+			fail("OAuth app", item.Name, err)
+			continue
+		}
+		result.OAuthApps++
+	}
+
+	return result, nil
+}
+
+func (a *App) getImportedIntegrationChannel(teamName, channelName string) (*model.Channel, *model.AppError) {
+	team, err := a.Srv.Store.Team().GetByName(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.Channel().GetByName(team.Id, channelName, true)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"time"
+	"unicode/utf8"
+
+	goi18n "github.com/mattermost/go-i18n/i18n"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const (
+	INTERACTIVE_DIALOG_CACHE_SIZE = 10000
+	INTERACTIVE_DIALOG_CACHE_SEC  = 60 * 60
+)
+
+// interactiveDialogSession is what the server keeps of a dialog opened for a user, so that the URL it's submitted to,
+// its state and the elements its submission is validated against can't be changed by the client. It's updated with the
+// dialog of each step of a multi-step flow. Sessions are kept per server and expire after an hour.
+type interactiveDialogSession struct {
+	UserId string
+	URL    string
+	Dialog model.Dialog
+	Step   int
+}
+
+func (a *App) saveInteractiveDialogSession(dialogId string, session *interactiveDialogSession) {
+	a.Srv.interactiveDialogCache.AddWithExpiresInSecs(dialogId, session, INTERACTIVE_DIALOG_CACHE_SEC)
+}
+
+// getInteractiveDialogSession returns the session of a dialog opened for the given user, or nil if there's none.
+func (a *App) getInteractiveDialogSession(dialogId, userId string) *interactiveDialogSession {
+	cached, ok := a.Srv.interactiveDialogCache.Get(dialogId)
+	if !ok {
+		return nil
+	}
+
+	session := cached.(*interactiveDialogSession)
+	if session.UserId != userId {
+		return nil
+	}
+
+	// A copy is returned so that the cached session is only changed by saving it again
+	copied := *session
+	return &copied
+}
+
+// validateDialogSubmission checks the values submitted for the elements of a dialog, returning an error message in the
+// user's language for each invalid one, keyed by the name of its element.
+func (a *App) validateDialogSubmission(elements []model.DialogElement, request *model.SubmitDialogRequest) map[string]string {
+	locale := ""
+	if user, err := a.GetUser(request.UserId); err == nil {
+		locale = user.Locale
+	}
+	T := utils.GetUserTranslations(locale)
+
+	errors := map[string]string{}
+	for _, element := range elements {
+		if message := a.validateDialogElement(element, request.Submission[element.Name], request.UserId, T); message != "" {
+			errors[element.Name] = message
+		}
+	}
+
+	return errors
+}
+
+func (a *App) validateDialogElement(element model.DialogElement, value interface{}, userId string, T goi18n.TranslateFunc) string {
+	if isEmptyDialogValue(value) {
+		if element.Optional || element.Type == model.DIALOG_ELEMENT_TYPE_BOOL {
+			return ""
+		}
+		return T("app.submit_interactive_dialog.element.required")
+	}
+
+	switch element.Type {
+	case model.DIALOG_ELEMENT_TYPE_TEXT, model.DIALOG_ELEMENT_TYPE_TEXTAREA:
+		text, ok := value.(string)
+		if !ok {
+			return T("app.submit_interactive_dialog.element.invalid")
+		}
+
+		if length := utf8.RuneCountInString(text); length < element.MinLength {
+			return T("app.submit_interactive_dialog.element.min_length", map[string]interface{}{"MinLength": element.MinLength})
+		} else if element.MaxLength > 0 && length > element.MaxLength {
+			return T("app.submit_interactive_dialog.element.max_length", map[string]interface{}{"MaxLength": element.MaxLength})
+		}
+
+	case model.DIALOG_ELEMENT_TYPE_SELECT, model.DIALOG_ELEMENT_TYPE_RADIO:
+		selected, ok := value.(string)
+		if !ok {
+			return T("app.submit_interactive_dialog.element.invalid")
+		}
+
+		switch element.DataSource {
+		case model.DIALOG_DATA_SOURCE_USERS:
+			return a.validateDialogUser(selected, T)
+		case model.DIALOG_DATA_SOURCE_CHANNELS:
+			return a.validateDialogChannel(selected, userId, T)
+		}
+
+		for _, option := range element.Options {
+			if option != nil && option.Value == selected {
+				return ""
+			}
+		}
+		return T("app.submit_interactive_dialog.element.invalid_option")
+
+	case model.DIALOG_ELEMENT_TYPE_DATE:
+		date, ok := value.(string)
+		if !ok {
+			return T("app.submit_interactive_dialog.element.invalid_date")
+		}
+
+		if _, err := time.Parse(model.DIALOG_DATE_FORMAT, date); err != nil {
+			return T("app.submit_interactive_dialog.element.invalid_date")
+		}
+
+	case model.DIALOG_ELEMENT_TYPE_USER:
+		selected, ok := value.(string)
+		if !ok {
+			return T("app.submit_interactive_dialog.element.invalid_user")
+		}
+		return a.validateDialogUser(selected, T)
+
+	case model.DIALOG_ELEMENT_TYPE_CHANNEL:
+		selected, ok := value.(string)
+		if !ok {
+			return T("app.submit_interactive_dialog.element.invalid_channel")
+		}
+		return a.validateDialogChannel(selected, userId, T)
+
+	case model.DIALOG_ELEMENT_TYPE_FILE:
+		var fileIds []string
+		switch v := value.(type) {
+		case string:
+			fileIds = []string{v}
+		case []interface{}:
+			for _, id := range v {
+				fileId, ok := id.(string)
+				if !ok {
+					return T("app.submit_interactive_dialog.element.invalid_file")
+				}
+				fileIds = append(fileIds, fileId)
+			}
+		default:
+			return T("app.submit_interactive_dialog.element.invalid_file")
+		}
+
+		// Only files the user uploaded and hasn't attached to a post yet can be submitted
+		for _, fileId := range fileIds {
+			info, err := a.GetFileInfo(fileId)
+			if err != nil || info.CreatorId != userId || info.PostId != "" || info.DeleteAt != 0 {
+				return T("app.submit_interactive_dialog.element.invalid_file")
+			}
+		}
+	}
+
+	return ""
+}
+
+func (a *App) validateDialogUser(userId string, T goi18n.TranslateFunc) string {
+	if user, err := a.GetUser(userId); err != nil || user.DeleteAt != 0 {
+		return T("app.submit_interactive_dialog.element.invalid_user")
+	}
+	return ""
+}
+
+func (a *App) validateDialogChannel(channelId, userId string, T goi18n.TranslateFunc) string {
+	if !model.IsValidId(channelId) || !a.HasPermissionToChannel(userId, channelId, model.PERMISSION_READ_CHANNEL) {
+		return T("app.submit_interactive_dialog.element.invalid_channel")
+	}
+	return ""
+}
+
+func isEmptyDialogValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -34,3 +34,7 @@ func (a *App) CreateJob(job *model.Job) (*model.Job, *model.AppError) {
 func (a *App) CancelJob(jobId string) *model.AppError {
 	return a.Srv.Jobs.RequestCancellation(jobId)
 }
+
+func (a *App) GetJobsLeaderStatus() (*model.JobsLeaderStatus, *model.AppError) {
+	return a.Srv.Jobs.GetLeaderStatus()
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const (
+	KEYWORD_RULE_CACHE_SIZE = 1000
+	KEYWORD_RULE_CACHE_SEC  = 60
+)
+
+// compiledKeywordRule is a keyword rule along with the expression its keywords and patterns are matched with, which
+// is nil when the rule acts on every post in its channels.
+type compiledKeywordRule struct {
+	rule   *model.KeywordRule
+	regexp *regexp.Regexp
+}
+
+func (r *compiledKeywordRule) matches(post *model.Post) bool {
+	if len(r.rule.ChannelIds) > 0 && !r.rule.ChannelIds.Contains(post.ChannelId) {
+		return false
+	}
+
+	return r.regexp == nil || r.regexp.MatchString(post.Message)
+}
+
+func (a *App) GetKeywordRule(ruleId string) (*model.KeywordRule, *model.AppError) {
+	return a.Srv.Store.KeywordRule().Get(ruleId)
+}
+
+func (a *App) GetKeywordRules(page, perPage int) ([]*model.KeywordRule, *model.AppError) {
+	return a.Srv.Store.KeywordRule().GetAll(page*perPage, perPage)
+}
+
+func (a *App) CreateKeywordRule(rule *model.KeywordRule) (*model.KeywordRule, *model.AppError) {
+	rule.Id = ""
+
+	if err := a.validateKeywordRule(rule); err != nil {
+		return nil, err
+	}
+
+	rule, err := a.Srv.Store.KeywordRule().Save(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Srv.keywordRuleCache.Purge()
+
+	return rule, nil
+}
+
+func (a *App) UpdateKeywordRule(oldRule, updatedRule *model.KeywordRule) (*model.KeywordRule, *model.AppError) {
+	oldRule.TeamId = updatedRule.TeamId
+	oldRule.Name = updatedRule.Name
+	oldRule.ChannelIds = updatedRule.ChannelIds
+	oldRule.Keywords = updatedRule.Keywords
+	oldRule.Patterns = updatedRule.Patterns
+	oldRule.ResponderId = updatedRule.ResponderId
+	oldRule.ReplyMessage = updatedRule.ReplyMessage
+	oldRule.ReactionEmojiName = updatedRule.ReactionEmojiName
+	oldRule.CopyToChannelId = updatedRule.CopyToChannelId
+	oldRule.NotifyGroupId = updatedRule.NotifyGroupId
+
+	if err := a.validateKeywordRule(oldRule); err != nil {
+		return nil, err
+	}
+
+	rule, err := a.Srv.Store.KeywordRule().Update(oldRule)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Srv.keywordRuleCache.Purge()
+
+	return rule, nil
+}
+
+func (a *App) DeleteKeywordRule(ruleId string) *model.AppError {
+	if err := a.Srv.Store.KeywordRule().Delete(ruleId, model.GetMillis()); err != nil {
+		return err
+	}
+
+	a.Srv.keywordRuleCache.Purge()
+
+	return nil
+}
+
+// validateKeywordRule checks that the team, channels, responder and group a rule refers to exist, and that the
+// channels it acts in belong to its team.
+func (a *App) validateKeywordRule(rule *model.KeywordRule) *model.AppError {
+	if rule.TeamId != "" {
+		if _, err := a.Srv.Store.Team().Get(rule.TeamId); err != nil {
+			return model.NewAppError("validateKeywordRule", "app.keyword_rule.invalid_team.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	for _, channelId := range rule.ChannelIds {
+		channel, err := a.Srv.Store.Channel().Get(channelId, true)
+		if err != nil || (rule.TeamId != "" && channel.TeamId != rule.TeamId) {
+			return model.NewAppError("validateKeywordRule", "app.keyword_rule.invalid_channel.app_error", nil, "channel_id="+channelId, http.StatusBadRequest)
+		}
+	}
+
+	responder, err := a.Srv.Store.User().Get(rule.ResponderId)
+	if err != nil || responder.DeleteAt != 0 {
+		return model.NewAppError("validateKeywordRule", "app.keyword_rule.invalid_responder.app_error", nil, "responder_id="+rule.ResponderId, http.StatusBadRequest)
+	}
+
+	if rule.CopyToChannelId != "" {
+		if _, err := a.Srv.Store.Channel().Get(rule.CopyToChannelId, true); err != nil {
+			return model.NewAppError("validateKeywordRule", "app.keyword_rule.invalid_channel.app_error", nil, "channel_id="+rule.CopyToChannelId, http.StatusBadRequest)
+		}
+	}
+
+	if rule.NotifyGroupId != "" {
+		if _, err := a.Srv.Store.Group().Get(rule.NotifyGroupId); err != nil {
+			return model.NewAppError("validateKeywordRule", "app.keyword_rule.invalid_group.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// getKeywordRulesForTeam returns the compiled rules which act on posts in the team. They're cached for a short
+// while, so changes made on other servers of a cluster apply within KEYWORD_RULE_CACHE_SEC.
+func (a *App) getKeywordRulesForTeam(teamId string) ([]*compiledKeywordRule, *model.AppError) {
+	if cached, ok := a.Srv.keywordRuleCache.Get(teamId); ok {
+		return cached.([]*compiledKeywordRule), nil
+	}
+
+	rules, err := a.Srv.Store.KeywordRule().GetForTeam(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := make([]*compiledKeywordRule, 0, len(rules))
+	for _, rule := range rules {
+		re, compileErr := rule.Regexp()
+		if compileErr != nil {
+			mlog.Warn("Skipping a keyword rule which doesn't compile", mlog.String("keyword_rule_id", rule.Id), mlog.Err(compileErr))
+			continue
+		}
+		compiled = append(compiled, &compiledKeywordRule{rule: rule, regexp: re})
+	}
+
+	a.Srv.keywordRuleCache.AddWithExpiresInSecs(teamId, compiled, KEYWORD_RULE_CACHE_SEC)
+
+	return compiled, nil
+}
+
+// applyKeywordRules runs the actions of the keyword rules matching a new post. Posts made by the rules themselves
+// are ignored, so rules can't trigger each other.
+func (a *App) applyKeywordRules(post *model.Post, team *model.Team, channel *model.Channel, user *model.User) {
+	if post.IsSystemMessage() || post.Props[model.POST_PROPS_KEYWORD_RULE_ID] != nil {
+		return
+	}
+
+	rules, err := a.getKeywordRulesForTeam(channel.TeamId)
+	if err != nil {
+		mlog.Error("Failed to get the keyword rules of a team", mlog.String("team_id", channel.TeamId), mlog.Err(err))
+		return
+	}
+
+	for _, compiled := range rules {
+		if compiled.rule.ResponderId == post.UserId || !compiled.matches(post) {
+			continue
+		}
+
+		a.runKeywordRule(compiled.rule, post, team, channel, user)
+	}
+}
+
+func (a *App) runKeywordRule(rule *model.KeywordRule, post *model.Post, team *model.Team, channel *model.Channel, user *model.User) {
+	responder, err := a.Srv.Store.User().Get(rule.ResponderId)
+	if err != nil || responder.DeleteAt != 0 {
+		mlog.Warn("Skipping a keyword rule whose responder can't be found", mlog.String("keyword_rule_id", rule.Id), mlog.String("responder_id", rule.ResponderId))
+		return
+	}
+
+	postLink := a.GetSiteURL() + "/" + team.Name + "/pl/" + post.Id
+
+	if rule.ReplyMessage != "" {
+		rootId := post.RootId
+		if rootId == "" {
+			rootId = post.Id
+		}
+
+		reply := &model.Post{
+			ChannelId: channel.Id,
+			UserId:    responder.Id,
+			RootId:    rootId,
+			ParentId:  rootId,
+			Message:   rule.ReplyMessage,
+			Props:     model.StringInterface{model.POST_PROPS_KEYWORD_RULE_ID: rule.Id},
+		}
+		if _, err := a.CreatePost(reply, channel, false); err != nil {
+			mlog.Error("Failed to reply to a post matching a keyword rule", mlog.String("keyword_rule_id", rule.Id), mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	}
+
+	if rule.ReactionEmojiName != "" {
+		reaction := &model.Reaction{UserId: responder.Id, PostId: post.Id, EmojiName: rule.ReactionEmojiName}
+		if _, err := a.SaveReactionForPost(reaction); err != nil {
+			mlog.Error("Failed to react to a post matching a keyword rule", mlog.String("keyword_rule_id", rule.Id), mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	}
+
+	if rule.CopyToChannelId != "" && rule.CopyToChannelId != channel.Id {
+		a.copyKeywordRulePost(rule, post, channel, user, responder, postLink)
+	}
+
+	if rule.NotifyGroupId != "" {
+		a.notifyKeywordRuleGroup(rule, post, channel, user, responder, postLink)
+	}
+}
+
+// copyKeywordRulePost posts a copy of the message in the channel the rule copies posts to, linking to the post.
+func (a *App) copyKeywordRulePost(rule *model.KeywordRule, post *model.Post, channel *model.Channel, author, responder *model.User, postLink string) {
+	targetChannel, err := a.Srv.Store.Channel().Get(rule.CopyToChannelId, true)
+	if err != nil {
+		mlog.Error("Failed to get the channel a keyword rule copies posts to", mlog.String("keyword_rule_id", rule.Id), mlog.Err(err))
+		return
+	}
+
+	if targetChannel.DeleteAt != 0 {
+		return
+	}
+
+	T := utils.GetUserTranslations(responder.Locale)
+
+	copied := &model.Post{
+		ChannelId: targetChannel.Id,
+		UserId:    responder.Id,
+		Message: T("app.keyword_rule.copy.message", map[string]interface{}{
+			"Username":    author.Username,
+			"ChannelName": channel.Name,
+			"PostLink":    postLink,
+			"Message":     post.Message,
+		}),
+		Props: model.StringInterface{
+			model.POST_PROPS_KEYWORD_RULE_ID:    rule.Id,
+			model.POST_PROPS_REDIRECTED_POST_ID: post.Id,
+		},
+	}
+
+	if _, err := a.CreatePost(copied, targetChannel, false); err != nil {
+		mlog.Error("Failed to copy a post matching a keyword rule", mlog.String("keyword_rule_id", rule.Id), mlog.String("post_id", post.Id), mlog.Err(err))
+	}
+}
+
+// notifyKeywordRuleGroup sends the members of the rule's group a direct message from the responder linking to the
+// post, skipping the author and those who can't read the channel.
+func (a *App) notifyKeywordRuleGroup(rule *model.KeywordRule, post *model.Post, channel *model.Channel, author, responder *model.User, postLink string) {
+	members, _, err := a.GetGroupMemberUsersPage(rule.NotifyGroupId, 0, model.KEYWORD_RULE_NOTIFY_GROUP_MAX_MEMBERS)
+	if err != nil {
+		mlog.Error("Failed to get the members of the group a keyword rule notifies", mlog.String("keyword_rule_id", rule.Id), mlog.Err(err))
+		return
+	}
+
+	for _, member := range members {
+		if member.Id == author.Id || member.Id == responder.Id || member.DeleteAt != 0 {
+			continue
+		}
+
+		if !a.HasPermissionToChannel(member.Id, channel.Id, model.PERMISSION_READ_CHANNEL) {
+			continue
+		}
+
+		dm, err := a.GetOrCreateDirectChannel(responder.Id, member.Id)
+		if err != nil {
+			mlog.Warn("Failed to notify a group member of a post matching a keyword rule", mlog.String("keyword_rule_id", rule.Id), mlog.String("user_id", member.Id), mlog.Err(err))
+			continue
+		}
+
+		T := utils.GetUserTranslations(member.Locale)
+
+		notification := &model.Post{
+			ChannelId: dm.Id,
+			UserId:    responder.Id,
+			Message: T("app.keyword_rule.notify.message", map[string]interface{}{
+				"RuleName":    rule.Name,
+				"Username":    author.Username,
+				"ChannelName": channel.Name,
+				"PostLink":    postLink,
+			}),
+			Props: model.StringInterface{model.POST_PROPS_KEYWORD_RULE_ID: rule.Id},
+		}
+
+		if _, err := a.CreatePost(notification, dm, false); err != nil {
+			mlog.Warn("Failed to notify a group member of a post matching a keyword rule", mlog.String("keyword_rule_id", rule.Id), mlog.String("user_id", member.Id), mlog.Err(err))
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCreateKeywordRule(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	rule := &model.KeywordRule{TeamId: th.BasicTeam.Id, Name: "outage", Keywords: model.StringArray{"outage"}, ResponderId: th.BasicUser2.Id, ReactionEmojiName: "eyes"}
+	created, err := th.App.CreateKeywordRule(rule)
+	require.Nil(t, err)
+	assert.Len(t, created.Id, 26)
+
+	for name, test := range map[string]struct {
+		Update func(rule *model.KeywordRule)
+		ErrId  string
+	}{
+		"unknown team":      {func(o *model.KeywordRule) { o.TeamId = model.NewId() }, "app.keyword_rule.invalid_team.app_error"},
+		"unknown channel":   {func(o *model.KeywordRule) { o.ChannelIds = model.StringArray{model.NewId()} }, "app.keyword_rule.invalid_channel.app_error"},
+		"unknown responder": {func(o *model.KeywordRule) { o.ResponderId = model.NewId() }, "app.keyword_rule.invalid_responder.app_error"},
+		"unknown group":     {func(o *model.KeywordRule) { o.NotifyGroupId = model.NewId() }, "app.keyword_rule.invalid_group.app_error"},
+		"channel of another team": {func(o *model.KeywordRule) {
+			o.ChannelIds = model.StringArray{th.createChannel(th.CreateTeam(), model.CHANNEL_OPEN).Id}
+		}, "app.keyword_rule.invalid_channel.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := *rule
+			test.Update(&invalid)
+
+			_, err := th.App.CreateKeywordRule(&invalid)
+			require.NotNil(t, err)
+			assert.Equal(t, test.ErrId, err.Id)
+		})
+	}
+}
+
+func TestApplyKeywordRules(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	target := th.CreateChannel(th.BasicTeam)
+	rule, err := th.App.CreateKeywordRule(&model.KeywordRule{
+		TeamId:            th.BasicTeam.Id,
+		Name:              "outage",
+		ChannelIds:        model.StringArray{th.BasicChannel.Id},
+		Keywords:          model.StringArray{"outage"},
+		ResponderId:       th.BasicUser2.Id,
+		ReplyMessage:      "Check the status page.",
+		ReactionEmojiName: "eyes",
+		CopyToChannelId:   target.Id,
+	})
+	require.Nil(t, err)
+
+	post, err := th.App.CreatePostMissingChannel(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: "Is there an OUTAGE?"}, false)
+	require.Nil(t, err)
+
+	var reply, copied *model.Post
+	var reactions []*model.Reaction
+	for i := 0; i < 50 && (reply == nil || copied == nil || len(reactions) == 0); i++ {
+		time.Sleep(100 * time.Millisecond)
+
+		thread, err := th.App.GetPostThread(post.Id)
+		require.Nil(t, err)
+		for _, p := range thread.Posts {
+			if p.Id != post.Id {
+				reply = p
+			}
+		}
+
+		posts, err := th.App.GetPosts(target.Id, 0, 10)
+		require.Nil(t, err)
+		for _, p := range posts.Posts {
+			if p.Props[model.POST_PROPS_KEYWORD_RULE_ID] == rule.Id {
+				copied = p
+			}
+		}
+
+		reactions, err = th.App.GetReactionsForPost(post.Id)
+		require.Nil(t, err)
+	}
+
+	require.NotNil(t, reply)
+	assert.Equal(t, th.BasicUser2.Id, reply.UserId)
+	assert.Equal(t, "Check the status page.", reply.Message)
+
+	require.NotNil(t, copied)
+	assert.Equal(t, post.Id, copied.Props[model.POST_PROPS_REDIRECTED_POST_ID])
+	assert.Contains(t, copied.Message, "Is there an OUTAGE?")
+
+	require.Len(t, reactions, 1)
+	assert.Equal(t, "eyes", reactions[0].EmojiName)
+	assert.Equal(t, th.BasicUser2.Id, reactions[0].UserId)
+
+	t.Run("other channels are ignored", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		other, err := th.App.CreatePostMissingChannel(&model.Post{UserId: th.BasicUser.Id, ChannelId: channel.Id, Message: "outage"}, false)
+		require.Nil(t, err)
+
+		time.Sleep(500 * time.Millisecond)
+
+		reactions, err := th.App.GetReactionsForPost(other.Id)
+		require.Nil(t, err)
+		assert.Empty(t, reactions)
+	})
+}
@@ -12,6 +12,11 @@ import (
 	"github.com/mattermost/mattermost-server/utils"
 )
 
+const (
+	LDAP_SYNC_HISTORY_SIZE        = 100
+	LDAP_INCREMENTAL_SYNC_OVERLAP = 5 * 60 * 1000
+)
+
 func (a *App) SyncLdap() {
 	a.Srv.Go(func() {
 
@@ -40,6 +45,59 @@ func (a *App) TestLdap() *model.AppError {
 	return nil
 }
 
+// StartLdapSyncDryRun schedules an LDAP sync job that saves in its data the report of what it would change, without
+// changing anything.
+func (a *App) StartLdapSyncDryRun() (*model.Job, *model.AppError) {
+	license := a.License()
+	if a.Ldap == nil || license == nil || !*license.Features.LDAP || !(*a.Config().LdapSettings.Enable || *a.Config().LdapSettings.EnableSync) {
+		return nil, model.NewAppError("StartLdapSyncDryRun", "ent.ldap.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return a.Srv.Jobs.CreateJob(model.JOB_TYPE_LDAP_SYNC, map[string]string{model.LDAP_SYNC_JOB_DATA_DRY_RUN: "true"})
+}
+
+// GetLdapSyncSince returns the time from which an LDAP sync can only look at the entries that were modified, or 0
+// when it must scan the whole directory. LDAP sync jobs save that time in their data, so that a full sync, which is
+// the only one to see the entries removed from the directory, still runs every LdapSettings.FullSyncIntervalHours.
+// The syncs overlap by a few minutes to make up for the clock of the directory being off.
+func (a *App) GetLdapSyncSince() (int64, *model.AppError) {
+	if !*a.Config().LdapSettings.EnableIncrementalSync {
+		return 0, nil
+	}
+
+	jobs, err := a.Srv.Store.Job().GetAllByTypePage(model.JOB_TYPE_LDAP_SYNC, 0, LDAP_SYNC_HISTORY_SIZE)
+	if err != nil {
+		return 0, err
+	}
+
+	var lastSync, lastFullSync *model.Job
+	for _, job := range jobs {
+		if job.Status != model.JOB_STATUS_SUCCESS || model.IsLdapSyncDryRun(job) {
+			continue
+		}
+
+		if lastSync == nil {
+			lastSync = job
+		}
+
+		if model.LdapSyncSince(job) == 0 {
+			lastFullSync = job
+			break
+		}
+	}
+
+	if lastFullSync == nil {
+		return 0, nil
+	}
+
+	fullSyncInterval := int64(*a.Config().LdapSettings.FullSyncIntervalHours) * 60 * 60 * 1000
+	if lastFullSync.StartAt+fullSyncInterval <= model.GetMillis() {
+		return 0, nil
+	}
+
+	return lastSync.StartAt - LDAP_INCREMENTAL_SYNC_OVERLAP, nil
+}
+
 // GetLdapGroup retrieves a single LDAP group by the given LDAP group id.
 func (a *App) GetLdapGroup(ldapGroupID string) (*model.Group, *model.AppError) {
 	var group *model.Group
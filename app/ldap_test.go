@@ -0,0 +1,69 @@
+// Copyright (c) 2017-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestGetLdapSyncSince(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	saveJob := func(status string, startAt int64, data map[string]string) {
+		_, err := th.App.Srv.Store.Job().Save(&model.Job{
+			Id:       model.NewId(),
+			Type:     model.JOB_TYPE_LDAP_SYNC,
+			Status:   status,
+			CreateAt: startAt,
+			StartAt:  startAt,
+			Data:     data,
+		})
+		require.Nil(t, err)
+	}
+
+	now := model.GetMillis()
+	hour := int64(60 * 60 * 1000)
+
+	t.Run("full sync when disabled", func(t *testing.T) {
+		since, err := th.App.GetLdapSyncSince()
+		require.Nil(t, err)
+		assert.Zero(t, since)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.LdapSettings.EnableIncrementalSync = true })
+
+	t.Run("full sync without a previous one", func(t *testing.T) {
+		saveJob(model.JOB_STATUS_SUCCESS, now-30*hour, map[string]string{model.LDAP_SYNC_JOB_DATA_SINCE: strconv.FormatInt(now-31*hour, 10)})
+
+		since, err := th.App.GetLdapSyncSince()
+		require.Nil(t, err)
+		assert.Zero(t, since)
+	})
+
+	t.Run("full sync once the interval is over", func(t *testing.T) {
+		saveJob(model.JOB_STATUS_SUCCESS, now-25*hour, map[string]string{})
+
+		since, err := th.App.GetLdapSyncSince()
+		require.Nil(t, err)
+		assert.Zero(t, since)
+	})
+
+	t.Run("incremental sync from the last one", func(t *testing.T) {
+		saveJob(model.JOB_STATUS_SUCCESS, now-3*hour, map[string]string{})
+		saveJob(model.JOB_STATUS_SUCCESS, now-2*hour, map[string]string{model.LDAP_SYNC_JOB_DATA_SINCE: strconv.FormatInt(now-3*hour, 10)})
+		saveJob(model.JOB_STATUS_SUCCESS, now-hour, map[string]string{model.LDAP_SYNC_JOB_DATA_DRY_RUN: "true"})
+		saveJob(model.JOB_STATUS_ERROR, now-hour/2, map[string]string{})
+
+		since, err := th.App.GetLdapSyncSince()
+		require.Nil(t, err)
+		assert.Equal(t, now-2*hour-LDAP_INCREMENTAL_SYNC_OVERLAP, since)
+	})
+}
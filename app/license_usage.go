@@ -0,0 +1,120 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// RecordLicenseUsage records the usage of the licensed seats for the current day, keeping the peak of the day, and
+// warns the system admins when the seats are running out. Nothing is recorded without a license.
+func (a *App) RecordLicenseUsage() *model.AppError {
+	license := a.License()
+	if license == nil {
+		return nil
+	}
+
+	registeredUsers, err := a.Srv.Store.User().Count(model.UserCountOptions{})
+	if err != nil {
+		return err
+	}
+
+	activeUsers, err := a.Srv.Store.User().AnalyticsActiveCount(DAY_MILLISECONDS, model.UserCountOptions{})
+	if err != nil {
+		return err
+	}
+
+	usage := &model.LicenseUsage{
+		Date:            time.Now().UTC().Format(model.DAILY_STAT_DATE_FORMAT),
+		RegisteredUsers: registeredUsers,
+		ActiveUsers:     activeUsers,
+		Seats:           int64(*license.Features.Users),
+	}
+
+	if previous, err := a.Srv.Store.LicenseUsage().Get(usage.Date); err == nil {
+		if previous.RegisteredUsers > usage.RegisteredUsers {
+			usage.RegisteredUsers = previous.RegisteredUsers
+		}
+		if previous.ActiveUsers > usage.ActiveUsers {
+			usage.ActiveUsers = previous.ActiveUsers
+		}
+	} else if err.StatusCode != http.StatusNotFound {
+		return err
+	}
+
+	if _, err := a.Srv.Store.LicenseUsage().Save(usage); err != nil {
+		return err
+	}
+
+	if model.IsLicenseSeatWarning(registeredUsers, usage.Seats, *a.Config().ServiceSettings.LicenseSeatWarningPercentage) {
+		a.warnLicenseSeats(registeredUsers, usage.Seats)
+	}
+
+	return nil
+}
+
+// GetLicenseUsageSummary returns the current usage of the licensed seats, along with the usage recorded over the
+// given number of days.
+func (a *App) GetLicenseUsageSummary(days int) (*model.LicenseUsageSummary, *model.AppError) {
+	license := a.License()
+	if license == nil {
+		return nil, model.NewAppError("GetLicenseUsageSummary", "app.license_usage.no_license.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	registeredUsers, err := a.Srv.Store.User().Count(model.UserCountOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	activeUsers, err := a.Srv.Store.User().AnalyticsActiveCount(DAY_MILLISECONDS, model.UserCountOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	history, err := a.Srv.Store.LicenseUsage().GetRange(now.AddDate(0, 0, -days).Format(model.DAILY_STAT_DATE_FORMAT), now.Format(model.DAILY_STAT_DATE_FORMAT))
+	if err != nil {
+		return nil, err
+	}
+
+	seats := int64(*license.Features.Users)
+	percentage := *a.Config().ServiceSettings.LicenseSeatWarningPercentage
+
+	forecastHistory := history
+	if len(forecastHistory) > model.LICENSE_USAGE_FORECAST_DAYS {
+		forecastHistory = forecastHistory[len(forecastHistory)-model.LICENSE_USAGE_FORECAST_DAYS:]
+	}
+
+	return &model.LicenseUsageSummary{
+		Seats:             seats,
+		RegisteredUsers:   registeredUsers,
+		ActiveUsers:       activeUsers,
+		WarningPercentage: percentage,
+		Warning:           model.IsLicenseSeatWarning(registeredUsers, seats, percentage),
+		SeatsExhaustedAt:  model.ForecastLicenseSeatsExhaustion(forecastHistory, seats),
+		History:           history,
+	}, nil
+}
+
+// warnLicenseSeats sends a direct message from the system bot to every system admin, at most once every
+// LICENSE_SEAT_WARNING_INTERVAL.
+func (a *App) warnLicenseSeats(registeredUsers, seats int64) {
+	if system, err := a.Srv.Store.System().GetByName(model.SYSTEM_LICENSE_SEAT_WARNING_TIME); err == nil {
+		lastWarning, _ := strconv.ParseInt(system.Value, 10, 64)
+		if model.GetMillis()-lastWarning < int64(model.LICENSE_SEAT_WARNING_INTERVAL/time.Millisecond) {
+			return
+		}
+	}
+
+	a.notifySystemAdmins("app.license_usage.seat_warning.message", map[string]interface{}{"Users": registeredUsers, "Seats": seats})
+
+	if err := a.Srv.Store.System().SaveOrUpdate(&model.System{Name: model.SYSTEM_LICENSE_SEAT_WARNING_TIME, Value: strconv.FormatInt(model.GetMillis(), 10)}); err != nil {
+		mlog.Error("Failed to save the time of the license seats warning", mlog.Err(err))
+	}
+}
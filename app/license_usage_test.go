@@ -0,0 +1,73 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestRecordLicenseUsage(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("without a license", func(t *testing.T) {
+		th.App.SetLicense(nil)
+		require.Nil(t, th.App.RecordLicenseUsage())
+	})
+
+	registeredUsers, err := th.App.Srv.Store.User().Count(model.UserCountOptions{})
+	require.Nil(t, err)
+
+	license := model.NewTestLicense()
+	license.Features.Users = model.NewInt(int(registeredUsers) + 1)
+	th.App.SetLicense(license)
+	defer th.App.SetLicense(nil)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.LicenseSeatWarningPercentage = 100 })
+
+	require.Nil(t, th.App.RecordLicenseUsage())
+
+	usage, err := th.App.Srv.Store.LicenseUsage().Get(time.Now().UTC().Format(model.DAILY_STAT_DATE_FORMAT))
+	require.Nil(t, err)
+	assert.Equal(t, registeredUsers, usage.RegisteredUsers)
+	assert.Equal(t, registeredUsers+1, usage.Seats)
+
+	_, err = th.App.Srv.Store.System().GetByName(model.SYSTEM_LICENSE_SEAT_WARNING_TIME)
+	require.NotNil(t, err, "the seats aren't running out yet")
+
+	t.Run("seats running out", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.LicenseSeatWarningPercentage = 50 })
+		admin := th.CreateUser()
+		th.App.UpdateUserRoles(admin.Id, model.SYSTEM_ADMIN_ROLE_ID+" "+model.SYSTEM_USER_ROLE_ID, false)
+
+		require.Nil(t, th.App.RecordLicenseUsage())
+
+		bot, err := th.App.GetSystemBot()
+		require.Nil(t, err)
+
+		channel, err := th.App.GetOrCreateDirectChannel(bot.UserId, admin.Id)
+		require.Nil(t, err)
+
+		posts, err := th.App.GetPosts(channel.Id, 0, 10)
+		require.Nil(t, err)
+		require.Len(t, posts.Order, 1)
+
+		// The admins are only warned again after a while.
+		require.Nil(t, th.App.RecordLicenseUsage())
+		posts, err = th.App.GetPosts(channel.Id, 0, 10)
+		require.Nil(t, err)
+		assert.Len(t, posts.Order, 1)
+
+		// The peak of the day is kept.
+		usage, err := th.App.Srv.Store.LicenseUsage().Get(time.Now().UTC().Format(model.DAILY_STAT_DATE_FORMAT))
+		require.Nil(t, err)
+		assert.Equal(t, registeredUsers+1, usage.RegisteredUsers)
+	})
+}
@@ -124,6 +124,11 @@ func (a *App) DoLogin(w http.ResponseWriter, r *http.Request, user *model.User,
 		}
 	}
 
+	ipAddress := utils.GetIpAddress(r, a.Config().ServiceSettings.TrustedProxyIPHeader)
+	if err := a.CheckNetworkAccess(user.Id, user.GetRoles(), ipAddress, a.GetRequestCountry(r)); err != nil {
+		return nil, err
+	}
+
 	session := &model.Session{UserId: user.Id, Roles: user.GetRawRoles(), DeviceId: deviceId, IsOAuth: false}
 	session.GenerateCSRF()
 
@@ -163,6 +168,16 @@ func (a *App) DoLogin(w http.ResponseWriter, r *http.Request, user *model.User,
 
 	w.Header().Set(model.HEADER_TOKEN, session.Token)
 
+	if session.IsMobileApp() || bname == "Desktop App" {
+		a.completeOnboardingSteps(user.Id, model.ONBOARDING_STEP_TYPE_INSTALL_APP, "")
+	}
+
+	country, device := a.loginCountry(r), loginDevice(deviceId, r.UserAgent())
+	a.Srv.Go(func() {
+		a.notifyNewSession(user, session, ipAddress)
+		a.recordSuccessfulLogin(user, ipAddress, country, device)
+	})
+
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		a.Srv.Go(func() {
 			pluginContext := a.PluginContext()
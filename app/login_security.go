@@ -0,0 +1,199 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/avct/uasurfer"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	LOGIN_ANOMALY_HISTORY_SIZE        = 100
+	LOGIN_ATTEMPTS_CLEANUP_BATCH_SIZE = 1000
+)
+
+// loginDevice describes the device a login comes from, which is the id of the device for the mobile apps and the
+// platform, OS and browser otherwise. The version of the browser is left out so that updating it isn't taken for a
+// new device.
+func loginDevice(deviceId, userAgent string) string {
+	if deviceId != "" {
+		return deviceId
+	}
+
+	if userAgent == "" {
+		return ""
+	}
+
+	ua := uasurfer.Parse(userAgent)
+	return getPlatformName(ua) + "/" + getOSName(ua) + "/" + getBrowserName(ua, userAgent)
+}
+
+// loginCountry returns the country given by the network access settings header, when it is a valid ISO code.
+func (a *App) loginCountry(r *http.Request) string {
+	country := strings.ToUpper(strings.TrimSpace(a.GetRequestCountry(r)))
+	if len(country) != 2 {
+		return ""
+	}
+
+	return country
+}
+
+// checkUserLoginAttempts returns an error when failed logins locked the account of a user. The lock is lifted,
+// resetting the failed attempts of the user, once it lasted for LoginSecuritySettings.LockoutDurationMinutes.
+func (a *App) checkUserLoginAttempts(user *model.User) *model.AppError {
+	if user.FailedAttempts < *a.Config().ServiceSettings.MaximumLoginAttempts {
+		return nil
+	}
+
+	if a.isLockoutOver(user) {
+		if err := a.Srv.Store.User().UpdateFailedPasswordAttempts(user.Id, 0); err != nil {
+			return err
+		}
+		user.FailedAttempts = 0
+		return nil
+	}
+
+	return model.NewAppError("checkUserLoginAttempts", "api.user.check_user_login_attempts.too_many.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
+}
+
+func (a *App) isLockoutOver(user *model.User) bool {
+	duration := int64(*a.Config().LoginSecuritySettings.LockoutDurationMinutes) * 60 * 1000
+	if duration == 0 {
+		return false
+	}
+
+	failure, err := a.Srv.Store.LoginAttempt().GetLatestFailure(user.Id)
+	if err != nil {
+		// Without knowing when the account was locked, it stays locked until an admin unlocks it.
+		if err.StatusCode != http.StatusNotFound {
+			mlog.Error("Failed to get the latest failed login attempt", mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+		return false
+	}
+
+	return failure.CreateAt+duration <= model.GetMillis()
+}
+
+// recordFailedLoginAttempt counts a failed attempt to log in as a user, telling them once it locks their account.
+func (a *App) recordFailedLoginAttempt(user *model.User) *model.AppError {
+	if err := a.Srv.Store.User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); err != nil {
+		return err
+	}
+
+	attempt := &model.LoginAttempt{
+		UserId:    user.Id,
+		IpAddress: a.IpAddress,
+		Device:    loginDevice("", a.UserAgent),
+	}
+	if _, err := a.Srv.Store.LoginAttempt().Save(attempt); err != nil {
+		mlog.Error("Failed to save a failed login attempt", mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+
+	if maxAttempts := *a.Config().ServiceSettings.MaximumLoginAttempts; maxAttempts > 0 && user.FailedAttempts+1 == maxAttempts {
+		a.Srv.Go(func() {
+			a.notifyAccountLocked(user)
+			a.sendAccountLockedEmail(user)
+		})
+	}
+
+	return nil
+}
+
+// recordSuccessfulLogin saves a successful login of a user and, when anomaly detection is enabled, tells them if it
+// came from a country or a device they never logged in from before.
+func (a *App) recordSuccessfulLogin(user *model.User, ipAddress, country, device string) {
+	var previous []*model.LoginAttempt
+	if *a.Config().LoginSecuritySettings.EnableAnomalyDetection {
+		var err *model.AppError
+		if previous, err = a.Srv.Store.LoginAttempt().GetSuccessfulForUser(user.Id, LOGIN_ANOMALY_HISTORY_SIZE); err != nil {
+			mlog.Error("Failed to get the previous logins of a user", mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+	}
+
+	attempt := &model.LoginAttempt{
+		UserId:    user.Id,
+		Success:   true,
+		IpAddress: ipAddress,
+		Country:   country,
+		Device:    device,
+	}
+	if _, err := a.Srv.Store.LoginAttempt().Save(attempt); err != nil {
+		mlog.Error("Failed to save a login attempt", mlog.String("user_id", user.Id), mlog.Err(err))
+		return
+	}
+
+	if anomalies := model.DetectLoginAnomalies(previous, attempt); len(anomalies) > 0 {
+		a.LogAuditEvent(&model.AuditEvent{
+			ActorId:   user.Id,
+			IpAddress: ipAddress,
+			Action:    "login/anomaly",
+			Target:    model.StringMap{"user_id": user.Id},
+			Result:    model.AUDIT_RESULT_ATTEMPT,
+			Details:   "anomalies=" + strings.Join(anomalies, ",") + " country=" + country + " device=" + device,
+		})
+
+		a.sendLoginAnomalyEmail(user, attempt, anomalies)
+	}
+}
+
+func (a *App) sendAccountLockedEmail(user *model.User) {
+	if !*a.Config().LoginSecuritySettings.EnableEmailAlerts {
+		return
+	}
+
+	if err := a.SendAccountLockedEmail(user.Email, user.Locale, a.GetSiteURL(), *a.Config().LoginSecuritySettings.LockoutDurationMinutes); err != nil {
+		mlog.Error("Failed to send the account locked email", mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+}
+
+func (a *App) sendLoginAnomalyEmail(user *model.User, attempt *model.LoginAttempt, anomalies []string) {
+	if !*a.Config().LoginSecuritySettings.EnableEmailAlerts {
+		return
+	}
+
+	if err := a.SendLoginAnomalyEmail(user.Email, user.Locale, a.GetSiteURL(), attempt); err != nil {
+		mlog.Error("Failed to send the login anomaly email", mlog.String("user_id", user.Id), mlog.String("anomalies", strings.Join(anomalies, ",")), mlog.Err(err))
+	}
+}
+
+// UnlockUser lifts the lock failed logins put on the account of a user.
+func (a *App) UnlockUser(userId string) *model.AppError {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.User().UpdateFailedPasswordAttempts(user.Id, 0); err != nil {
+		return err
+	}
+
+	a.InvalidateCacheForUser(user.Id)
+
+	return nil
+}
+
+// GetLoginAttempts returns a page of the login attempts of a user, starting with the latest.
+func (a *App) GetLoginAttempts(userId string, page, perPage int) ([]*model.LoginAttempt, *model.AppError) {
+	return a.Srv.Store.LoginAttempt().GetForUser(userId, page*perPage, perPage)
+}
+
+// DeleteOldLoginAttempts deletes the login attempts older than LoginSecuritySettings.AttemptsRetentionDays.
+func (a *App) DeleteOldLoginAttempts() *model.AppError {
+	endTime := model.GetMillis() - int64(*a.Config().LoginSecuritySettings.AttemptsRetentionDays)*24*60*60*1000
+	for {
+		count, err := a.Srv.Store.LoginAttempt().PermanentDeleteBatch(endTime, LOGIN_ATTEMPTS_CLEANUP_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		if count < LOGIN_ATTEMPTS_CLEANUP_BATCH_SIZE {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestLoginLockoutDuration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.MaximumLoginAttempts = 1
+		*cfg.LoginSecuritySettings.LockoutDurationMinutes = 10
+	})
+
+	user := th.CreateUser()
+	require.Nil(t, th.App.recordFailedLoginAttempt(user))
+	user.FailedAttempts = 1
+
+	err := th.App.checkUserLoginAttempts(user)
+	require.NotNil(t, err)
+	assert.Equal(t, "api.user.check_user_login_attempts.too_many.app_error", err.Id)
+
+	t.Run("lock is lifted once it lasted long enough", func(t *testing.T) {
+		require.Nil(t, th.App.Srv.Store.LoginAttempt().PermanentDeleteByUser(user.Id))
+
+		_, err := th.App.Srv.Store.LoginAttempt().Save(&model.LoginAttempt{
+			UserId:   user.Id,
+			CreateAt: model.GetMillis() - 11*60*1000,
+		})
+		require.Nil(t, err)
+
+		require.Nil(t, th.App.checkUserLoginAttempts(user))
+		assert.Equal(t, 0, user.FailedAttempts)
+
+		stored, err := th.App.GetUser(user.Id)
+		require.Nil(t, err)
+		assert.Equal(t, 0, stored.FailedAttempts)
+	})
+
+	t.Run("lock without a duration lasts until unlocked", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.LoginSecuritySettings.LockoutDurationMinutes = 0 })
+
+		require.Nil(t, th.App.recordFailedLoginAttempt(user))
+		user.FailedAttempts = 1
+		require.NotNil(t, th.App.checkUserLoginAttempts(user))
+
+		require.Nil(t, th.App.UnlockUser(user.Id))
+
+		stored, err := th.App.GetUser(user.Id)
+		require.Nil(t, err)
+		assert.Equal(t, 0, stored.FailedAttempts)
+		require.Nil(t, th.App.checkUserLoginAttempts(stored))
+	})
+}
+
+func TestRecordSuccessfulLogin(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.LoginSecuritySettings.EnableAnomalyDetection = true })
+
+	user := th.CreateUser()
+	th.App.recordSuccessfulLogin(user, "10.0.0.1", "CA", "Linux/Linux/Firefox")
+	th.App.recordSuccessfulLogin(user, "10.0.0.2", "FR", "Linux/Linux/Firefox")
+
+	attempts, err := th.App.GetLoginAttempts(user.Id, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, attempts, 2)
+	for _, attempt := range attempts {
+		assert.True(t, attempt.Success)
+	}
+
+	require.Nil(t, th.App.PermanentDeleteUser(user))
+
+	attempts, err = th.App.Srv.Store.LoginAttempt().GetForUser(user.Id, 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, attempts)
+}
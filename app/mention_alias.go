@@ -0,0 +1,250 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+func (a *App) GetMentionAlias(aliasId string) (*model.MentionAlias, *model.AppError) {
+	return a.Srv.Store.MentionAlias().Get(aliasId)
+}
+
+func (a *App) GetMentionAliases(page, perPage int) ([]*model.MentionAlias, *model.AppError) {
+	return a.Srv.Store.MentionAlias().GetAll(page*perPage, perPage)
+}
+
+func (a *App) CreateMentionAlias(alias *model.MentionAlias) (*model.MentionAlias, *model.AppError) {
+	alias.Id = ""
+	alias.Name = model.NormalizeMentionAliasName(alias.Name)
+
+	if err := a.validateMentionAlias(alias); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.MentionAlias().Save(alias)
+}
+
+func (a *App) UpdateMentionAlias(oldAlias, updatedAlias *model.MentionAlias) (*model.MentionAlias, *model.AppError) {
+	oldAlias.TeamId = updatedAlias.TeamId
+	oldAlias.Name = updatedAlias.Name
+	oldAlias.Description = updatedAlias.Description
+	oldAlias.Type = updatedAlias.Type
+	oldAlias.TargetIds = updatedAlias.TargetIds
+	oldAlias.PreUpdate()
+
+	if err := a.validateMentionAlias(oldAlias); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.MentionAlias().Update(oldAlias)
+}
+
+func (a *App) DeleteMentionAlias(aliasId string) *model.AppError {
+	return a.Srv.Store.MentionAlias().Delete(aliasId, model.GetMillis())
+}
+
+// validateMentionAlias checks that the name of the alias doesn't clash with a username or another alias that
+// can be mentioned in the same teams, that its targets exist and that it doesn't expand to itself.
+func (a *App) validateMentionAlias(alias *model.MentionAlias) *model.AppError {
+	if !model.IsValidMentionAliasName(alias.Name) {
+		return model.NewAppError("validateMentionAlias", "model.mention_alias.is_valid.name.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(alias.TargetIds) == 0 {
+		return model.NewAppError("validateMentionAlias", "model.mention_alias.is_valid.target_ids.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if _, err := a.Srv.Store.User().GetByUsername(alias.Name); err == nil {
+		return model.NewAppError("validateMentionAlias", "app.mention_alias.name_exists.app_error", map[string]interface{}{"Name": alias.Name}, "username", http.StatusBadRequest)
+	}
+
+	aliases, err := a.Srv.Store.MentionAlias().GetByName(alias.Name)
+	if err != nil {
+		return err
+	}
+	for _, other := range aliases {
+		if other.Id != alias.Id && (other.TeamId == alias.TeamId || other.TeamId == "" || alias.TeamId == "") {
+			return model.NewAppError("validateMentionAlias", "app.mention_alias.name_exists.app_error", map[string]interface{}{"Name": alias.Name}, "alias_id="+other.Id, http.StatusBadRequest)
+		}
+	}
+
+	switch alias.Type {
+	case model.MENTION_ALIAS_TYPE_USERS:
+		users, err := a.Srv.Store.User().GetProfileByIds(alias.TargetIds, nil, false)
+		if err != nil {
+			return err
+		}
+		if len(users) != len(model.RemoveDuplicateStrings(alias.TargetIds)) {
+			return model.NewAppError("validateMentionAlias", "app.mention_alias.invalid_target.app_error", nil, "type=users", http.StatusBadRequest)
+		}
+	case model.MENTION_ALIAS_TYPE_GROUP:
+		if _, err := a.Srv.Store.Group().Get(alias.TargetIds[0]); err != nil {
+			return model.NewAppError("validateMentionAlias", "app.mention_alias.invalid_target.app_error", nil, "type=group, "+err.Error(), http.StatusBadRequest)
+		}
+	case model.MENTION_ALIAS_TYPE_CHANNEL:
+		channel, err := a.Srv.Store.Channel().Get(alias.TargetIds[0], true)
+		if err != nil {
+			return model.NewAppError("validateMentionAlias", "app.mention_alias.invalid_target.app_error", nil, "type=channel, "+err.Error(), http.StatusBadRequest)
+		}
+		if channel.Type != model.CHANNEL_OPEN && channel.Type != model.CHANNEL_PRIVATE {
+			return model.NewAppError("validateMentionAlias", "app.mention_alias.invalid_target.app_error", nil, "type=channel, channel_type="+channel.Type, http.StatusBadRequest)
+		}
+	case model.MENTION_ALIAS_TYPE_ALIAS:
+		for _, targetId := range alias.TargetIds {
+			target, err := a.Srv.Store.MentionAlias().Get(targetId)
+			if err != nil {
+				return model.NewAppError("validateMentionAlias", "app.mention_alias.invalid_target.app_error", nil, "type=alias, "+err.Error(), http.StatusBadRequest)
+			}
+			if err := a.checkMentionAliasLoop(alias.Id, target, 1); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkMentionAliasLoop returns an error if the alias, reached after following depth aliases, expands back to the
+// alias with the given id or expands through more aliases than allowed.
+func (a *App) checkMentionAliasLoop(aliasId string, alias *model.MentionAlias, depth int) *model.AppError {
+	if alias.Id == aliasId || depth > model.MENTION_ALIAS_MAX_DEPTH {
+		return model.NewAppError("checkMentionAliasLoop", "app.mention_alias.loop.app_error", nil, "alias_id="+alias.Id, http.StatusBadRequest)
+	}
+
+	if alias.Type != model.MENTION_ALIAS_TYPE_ALIAS {
+		return nil
+	}
+
+	for _, targetId := range alias.TargetIds {
+		if targetId == aliasId {
+			return model.NewAppError("checkMentionAliasLoop", "app.mention_alias.loop.app_error", nil, "alias_id="+alias.Id, http.StatusBadRequest)
+		}
+
+		target, err := a.Srv.Store.MentionAlias().Get(targetId)
+		if err != nil {
+			continue
+		}
+		if err := a.checkMentionAliasLoop(aliasId, target, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getMentionAliasKeywords returns the mention keywords of the aliases that can be used in the team, mapped to the
+// users in the channel they expand to, and the keywords of the aliases redirecting to a channel, mapped to the
+// id of the alias. Aliases are followed at most MENTION_ALIAS_MAX_DEPTH deep and each only once, so a loop
+// created by editing aliases after they were validated can't hang notifications.
+func (a *App) getMentionAliasKeywords(teamId string, profileMap map[string]*model.User) (map[string][]string, map[string][]string) {
+	keywords := make(map[string][]string)
+	redirectKeywords := make(map[string][]string)
+
+	aliases, err := a.Srv.Store.MentionAlias().GetForTeam(teamId)
+	if err != nil {
+		mlog.Error("Failed to get mention aliases", mlog.String("team_id", teamId), mlog.Err(err))
+		return keywords, redirectKeywords
+	}
+
+	aliasesById := make(map[string]*model.MentionAlias, len(aliases))
+	for _, alias := range aliases {
+		aliasesById[alias.Id] = alias
+	}
+
+	for _, alias := range aliases {
+		if alias.Type == model.MENTION_ALIAS_TYPE_CHANNEL {
+			redirectKeywords[alias.Mention()] = []string{alias.Id}
+			keywords[alias.Mention()] = nil
+			continue
+		}
+
+		userIds := []string{}
+		for _, userId := range a.expandMentionAlias(alias, aliasesById, map[string]bool{}, 0) {
+			if _, ok := profileMap[userId]; ok {
+				userIds = append(userIds, userId)
+			}
+		}
+
+		// The keyword is kept even when no user in the channel is mentioned, so that the alias isn't reported as
+		// a mention of users that aren't in the channel.
+		keywords[alias.Mention()] = userIds
+	}
+
+	return keywords, redirectKeywords
+}
+
+// expandMentionAlias returns the ids of the users the alias expands to.
+func (a *App) expandMentionAlias(alias *model.MentionAlias, aliasesById map[string]*model.MentionAlias, visited map[string]bool, depth int) []string {
+	if visited[alias.Id] || depth > model.MENTION_ALIAS_MAX_DEPTH {
+		return nil
+	}
+	visited[alias.Id] = true
+
+	switch alias.Type {
+	case model.MENTION_ALIAS_TYPE_USERS:
+		return alias.TargetIds
+	case model.MENTION_ALIAS_TYPE_GROUP:
+		users, err := a.Srv.Store.Group().GetMemberUsers(alias.TargetIds[0])
+		if err != nil {
+			mlog.Error("Failed to get the members of the group of a mention alias", mlog.String("alias_id", alias.Id), mlog.Err(err))
+			return nil
+		}
+		userIds := make([]string, 0, len(users))
+		for _, user := range users {
+			userIds = append(userIds, user.Id)
+		}
+		return userIds
+	case model.MENTION_ALIAS_TYPE_ALIAS:
+		userIds := []string{}
+		for _, targetId := range alias.TargetIds {
+			if target, ok := aliasesById[targetId]; ok {
+				userIds = append(userIds, a.expandMentionAlias(target, aliasesById, visited, depth+1)...)
+			}
+		}
+		return userIds
+	}
+
+	return nil
+}
+
+// sendMentionAliasRedirect posts a message in the channel an alias redirects to, linking to the post that
+// mentioned the alias.
+func (a *App) sendMentionAliasRedirect(alias *model.MentionAlias, post *model.Post, team *model.Team, channel *model.Channel, sender *model.User) {
+	targetChannel, err := a.Srv.Store.Channel().Get(alias.TargetIds[0], true)
+	if err != nil {
+		mlog.Error("Failed to get the channel of a mention alias", mlog.String("alias_id", alias.Id), mlog.Err(err))
+		return
+	}
+
+	if targetChannel.Id == channel.Id || targetChannel.DeleteAt != 0 {
+		return
+	}
+
+	T := utils.GetUserTranslations(sender.Locale)
+
+	redirect := &model.Post{
+		ChannelId: targetChannel.Id,
+		UserId:    sender.Id,
+		Type:      model.POST_MENTION_ALIAS_REDIRECT,
+		Message: T("app.mention_alias.redirect.message", map[string]interface{}{
+			"Username":    sender.Username,
+			"AliasName":   alias.Name,
+			"ChannelName": channel.Name,
+			"PostLink":    a.GetSiteURL() + "/" + team.Name + "/pl/" + post.Id,
+		}),
+		Props: model.StringInterface{
+			model.POST_PROPS_MENTION_ALIAS_ID:   alias.Id,
+			model.POST_PROPS_REDIRECTED_POST_ID: post.Id,
+		},
+	}
+
+	if _, err := a.CreatePost(redirect, targetChannel, false); err != nil {
+		mlog.Error("Failed to redirect a mention alias", mlog.String("alias_id", alias.Id), mlog.String("post_id", post.Id), mlog.Err(err))
+	}
+}
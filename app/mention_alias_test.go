@@ -0,0 +1,123 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCreateMentionAlias(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	alias, err := th.App.CreateMentionAlias(&model.MentionAlias{
+		TeamId:    th.BasicTeam.Id,
+		Name:      "@Frontend-Team",
+		Type:      model.MENTION_ALIAS_TYPE_USERS,
+		TargetIds: model.StringArray{th.BasicUser.Id, th.BasicUser2.Id},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "frontend-team", alias.Name)
+
+	t.Run("name used by a user", func(t *testing.T) {
+		_, err := th.App.CreateMentionAlias(&model.MentionAlias{Name: th.BasicUser.Username, Type: model.MENTION_ALIAS_TYPE_USERS, TargetIds: model.StringArray{th.BasicUser.Id}})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.mention_alias.name_exists.app_error", err.Id)
+	})
+
+	t.Run("name used by an alias of all teams", func(t *testing.T) {
+		_, err := th.App.CreateMentionAlias(&model.MentionAlias{Name: "frontend-team", Type: model.MENTION_ALIAS_TYPE_USERS, TargetIds: model.StringArray{th.BasicUser.Id}})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.mention_alias.name_exists.app_error", err.Id)
+	})
+
+	t.Run("name used in another team", func(t *testing.T) {
+		_, err := th.App.CreateMentionAlias(&model.MentionAlias{TeamId: model.NewId(), Name: "frontend-team", Type: model.MENTION_ALIAS_TYPE_USERS, TargetIds: model.StringArray{th.BasicUser.Id}})
+		require.Nil(t, err)
+	})
+
+	t.Run("unknown targets", func(t *testing.T) {
+		_, err := th.App.CreateMentionAlias(&model.MentionAlias{Name: "unknown-users", Type: model.MENTION_ALIAS_TYPE_USERS, TargetIds: model.StringArray{model.NewId()}})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.mention_alias.invalid_target.app_error", err.Id)
+
+		_, err = th.App.CreateMentionAlias(&model.MentionAlias{Name: "unknown-channel", Type: model.MENTION_ALIAS_TYPE_CHANNEL, TargetIds: model.StringArray{model.NewId()}})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.mention_alias.invalid_target.app_error", err.Id)
+	})
+
+	t.Run("loop", func(t *testing.T) {
+		everyone, err := th.App.CreateMentionAlias(&model.MentionAlias{TeamId: th.BasicTeam.Id, Name: "everyone", Type: model.MENTION_ALIAS_TYPE_ALIAS, TargetIds: model.StringArray{alias.Id}})
+		require.Nil(t, err)
+
+		alias.Type = model.MENTION_ALIAS_TYPE_ALIAS
+		alias.TargetIds = model.StringArray{everyone.Id}
+		oldAlias, err := th.App.GetMentionAlias(alias.Id)
+		require.Nil(t, err)
+
+		_, err = th.App.UpdateMentionAlias(oldAlias, alias)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.mention_alias.loop.app_error", err.Id)
+	})
+}
+
+func TestSendNotificationsMentionAlias(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.AddUserToChannel(th.BasicUser2, th.BasicChannel)
+
+	group := th.CreateGroup()
+	_, err := th.App.UpsertGroupMember(group.Id, th.BasicUser2.Id)
+	require.Nil(t, err)
+
+	groupAlias, err := th.App.CreateMentionAlias(&model.MentionAlias{TeamId: th.BasicTeam.Id, Name: "backend-team", Type: model.MENTION_ALIAS_TYPE_GROUP, TargetIds: model.StringArray{group.Id}})
+	require.Nil(t, err)
+	_, err = th.App.CreateMentionAlias(&model.MentionAlias{Name: "engineering", Type: model.MENTION_ALIAS_TYPE_ALIAS, TargetIds: model.StringArray{groupAlias.Id}})
+	require.Nil(t, err)
+
+	for _, message := range []string{"@backend-team please review", "@engineering please review"} {
+		post, err := th.App.CreatePostMissingChannel(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: message}, false)
+		require.Nil(t, err)
+
+		mentions, sendErr := th.App.SendNotifications(post, th.BasicTeam, th.BasicChannel, th.BasicUser, nil)
+		require.NoError(t, sendErr)
+		assert.Contains(t, mentions, th.BasicUser2.Id, message)
+	}
+
+	t.Run("redirect to a channel", func(t *testing.T) {
+		target := th.CreateChannel(th.BasicTeam)
+		_, err := th.App.CreateMentionAlias(&model.MentionAlias{TeamId: th.BasicTeam.Id, Name: "oncall", Type: model.MENTION_ALIAS_TYPE_CHANNEL, TargetIds: model.StringArray{target.Id}})
+		require.Nil(t, err)
+
+		post, err := th.App.CreatePostMissingChannel(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: "@oncall the site is down"}, false)
+		require.Nil(t, err)
+
+		_, sendErr := th.App.SendNotifications(post, th.BasicTeam, th.BasicChannel, th.BasicUser, nil)
+		require.NoError(t, sendErr)
+
+		var redirect *model.Post
+		for i := 0; i < 50 && redirect == nil; i++ {
+			time.Sleep(100 * time.Millisecond)
+
+			posts, err := th.App.GetPosts(target.Id, 0, 10)
+			require.Nil(t, err)
+			for _, p := range posts.Posts {
+				if p.Type == model.POST_MENTION_ALIAS_REDIRECT {
+					redirect = p
+				}
+			}
+		}
+
+		require.NotNil(t, redirect)
+		assert.Equal(t, post.Id, redirect.Props[model.POST_PROPS_REDIRECTED_POST_ID])
+		assert.Contains(t, redirect.Message, "/pl/"+post.Id)
+	})
+}
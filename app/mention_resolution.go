@@ -0,0 +1,234 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// ResolveMentions returns what the mentions of a message would resolve to if the sender posted it in the channel,
+// along with warnings about the mentions that won't notify the users they refer to, so that clients can warn about
+// them before the message is posted. It follows the rules SendNotifications applies to the posted message.
+func (a *App) ResolveMentions(sender *model.User, channel *model.Channel, message string) (*model.MentionResolution, *model.AppError) {
+	resolution := &model.MentionResolution{Mentions: []*model.ResolvedMention{}}
+	post := &model.Post{ChannelId: channel.Id, UserId: sender.Id, Message: message}
+
+	profileMap, err := a.Srv.Store.User().GetAllProfilesInChannel(channel.Id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if channel.Type == model.CHANNEL_DIRECT {
+		if _, ok := profileMap[channel.GetOtherUserIdForDM(sender.Id)]; ok {
+			resolution.NotificationCount = 1
+		}
+		return resolution, nil
+	}
+
+	channelMemberNotifyPropsMap, err := a.Srv.Store.Channel().GetAllChannelMembersNotifyPropsForChannel(channel.Id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Usernames of the members of the channel are looked for on their own so that the users mentioned by name can be
+	// told apart from the ones mentioned by their mention keys or through an alias.
+	usernameKeywords := make(map[string][]string, len(profileMap))
+	for id, profile := range profileMap {
+		usernameKeywords["@"+strings.ToLower(profile.Username)] = []string{id}
+	}
+
+	for userId := range getExplicitMentions(post, usernameKeywords).MentionedUserIds {
+		resolution.Mentions = append(resolution.Mentions, &model.ResolvedMention{
+			Mention: "@" + profileMap[userId].Username,
+			Type:    model.RESOLVED_MENTION_TYPE_USER,
+			Id:      userId,
+		})
+	}
+
+	keywords := a.getMentionKeywordsInChannel(profileMap, true, channelMemberNotifyPropsMap)
+
+	aliasMentions, err := a.resolveMentionAliases(post, channel, profileMap, keywords)
+	if err != nil {
+		return nil, err
+	}
+	resolution.Mentions = append(resolution.Mentions, aliasMentions...)
+
+	m := getExplicitMentions(post, keywords)
+
+	for _, special := range []struct {
+		Mentioned bool
+		Keyword   string
+	}{
+		{m.HereMentioned, "@here"},
+		{m.ChannelMentioned, "@channel"},
+		{m.AllMentioned, "@all"},
+	} {
+		if !special.Mentioned {
+			continue
+		}
+
+		mention := &model.ResolvedMention{
+			Mention:   special.Keyword,
+			Type:      model.RESOLVED_MENTION_TYPE_SPECIAL,
+			UserCount: countUsersOtherThan(keywords[special.Keyword], sender.Id),
+		}
+		if int64(len(profileMap)) > *a.Config().TeamSettings.MaxNotificationsPerChannel {
+			mention.Warning = model.RESOLVED_MENTION_WARNING_DISABLED
+		}
+		resolution.Mentions = append(resolution.Mentions, mention)
+	}
+
+	outOfChannelUsers, outOfGroupsUsers, filterErr := a.filterOutOfChannelMentions(sender, post, channel, m.OtherPotentialMentions)
+	if filterErr != nil {
+		return nil, model.NewAppError("ResolveMentions", "app.mention_resolution.out_of_channel.app_error", nil, filterErr.Error(), http.StatusInternalServerError)
+	}
+
+	for _, user := range outOfChannelUsers {
+		resolution.Mentions = append(resolution.Mentions, &model.ResolvedMention{
+			Mention: "@" + user.Username,
+			Type:    model.RESOLVED_MENTION_TYPE_USER,
+			Id:      user.Id,
+			Warning: model.RESOLVED_MENTION_WARNING_NOT_IN_CHANNEL,
+		})
+	}
+
+	for _, user := range outOfGroupsUsers {
+		resolution.Mentions = append(resolution.Mentions, &model.ResolvedMention{
+			Mention: "@" + user.Username,
+			Type:    model.RESOLVED_MENTION_TYPE_USER,
+			Id:      user.Id,
+			Warning: model.RESOLVED_MENTION_WARNING_NOT_IN_GROUPS,
+		})
+	}
+
+	channelMentions, err := a.resolveChannelMentions(post, channel)
+	if err != nil {
+		return nil, err
+	}
+	resolution.Mentions = append(resolution.Mentions, channelMentions...)
+
+	delete(m.MentionedUserIds, sender.Id)
+	resolution.NotificationCount = len(m.MentionedUserIds)
+
+	return resolution, nil
+}
+
+// resolveMentionAliases returns the mention aliases mentioned in the post, and adds the keywords of the aliases to
+// the given keywords.
+func (a *App) resolveMentionAliases(post *model.Post, channel *model.Channel, profileMap map[string]*model.User, keywords map[string][]string) ([]*model.ResolvedMention, *model.AppError) {
+	aliasKeywords, _ := a.getMentionAliasKeywords(channel.TeamId, profileMap)
+	for keyword, ids := range aliasKeywords {
+		keywords[keyword] = append(keywords[keyword], ids...)
+	}
+
+	aliases, err := a.Srv.Store.MentionAlias().GetForTeam(channel.TeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	// The ids mapped to the keywords are alias ids, so the aliases mentioned are the "users" mentioned.
+	aliasesById := make(map[string]*model.MentionAlias, len(aliases))
+	aliasIdKeywords := make(map[string][]string, len(aliases))
+	for _, alias := range aliases {
+		aliasesById[alias.Id] = alias
+		aliasIdKeywords[alias.Mention()] = []string{alias.Id}
+	}
+
+	mentions := []*model.ResolvedMention{}
+	for aliasId := range getExplicitMentions(post, aliasIdKeywords).MentionedUserIds {
+		alias := aliasesById[aliasId]
+		mention := &model.ResolvedMention{
+			Mention: alias.Mention(),
+			Type:    model.RESOLVED_MENTION_TYPE_ALIAS,
+			Id:      alias.Id,
+		}
+
+		// Aliases redirecting to a channel don't notify the members of this one.
+		if alias.Type != model.MENTION_ALIAS_TYPE_CHANNEL {
+			mention.UserCount = countUsersOtherThan(aliasKeywords[alias.Mention()], post.UserId)
+			if mention.UserCount == 0 {
+				mention.Warning = model.RESOLVED_MENTION_WARNING_NO_MEMBERS
+			}
+		}
+
+		mentions = append(mentions, mention)
+	}
+
+	return mentions, nil
+}
+
+// resolveChannelMentions returns the channels mentioned in the post that are rendered as links once it's posted,
+// which are the public channels of the team, and those of the other teams of the sender when cross-team channel
+// mentions are enabled.
+func (a *App) resolveChannelMentions(post *model.Post, channel *model.Channel) ([]*model.ResolvedMention, *model.AppError) {
+	mentions := []*model.ResolvedMention{}
+
+	crossTeam := *a.Config().TeamSettings.EnableCrossTeamChannelMentions
+
+	names := post.ChannelMentions()
+	if len(names) == 0 || (channel.TeamId == "" && !crossTeam) {
+		return mentions, nil
+	}
+
+	resolved := map[string]bool{}
+	if channel.TeamId != "" {
+		mentionedChannels, err := a.GetChannelsByNames(names, channel.TeamId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mentioned := range mentionedChannels {
+			resolved[mentioned.Name] = true
+			if mentioned.Type != model.CHANNEL_OPEN {
+				continue
+			}
+
+			mentions = append(mentions, &model.ResolvedMention{
+				Mention: "~" + mentioned.Name,
+				Type:    model.RESOLVED_MENTION_TYPE_CHANNEL,
+				Id:      mentioned.Id,
+			})
+		}
+	}
+
+	if crossTeam {
+		var unresolved []string
+		for _, name := range names {
+			if !resolved[name] {
+				unresolved = append(unresolved, name)
+			}
+		}
+
+		mentionedChannels, err := a.getCrossTeamMentionedChannels(post.UserId, channel.TeamId, unresolved)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mentioned := range mentionedChannels {
+			mentions = append(mentions, &model.ResolvedMention{
+				Mention: "~" + mentioned.Name,
+				Type:    model.RESOLVED_MENTION_TYPE_CHANNEL,
+				Id:      mentioned.Id,
+				TeamId:  mentioned.TeamId,
+			})
+		}
+	}
+
+	return mentions, nil
+}
+
+// countUsersOtherThan returns the number of distinct users in the list, not counting the excluded one.
+func countUsersOtherThan(userIds []string, excludedUserId string) int {
+	users := make(map[string]bool, len(userIds))
+	for _, userId := range userIds {
+		if userId != excludedUserId {
+			users[userId] = true
+		}
+	}
+
+	return len(users)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (a *App) checkMessageExportStreamLicense(where string) *model.AppError {
+	if license := a.License(); license == nil || !*license.Features.MessageExport {
+		return model.NewAppError(where, "ent.message_export.licence_disable.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return nil
+}
+
+func (a *App) GetMessageExportConsumers() ([]*model.MessageExportConsumer, *model.AppError) {
+	if err := a.checkMessageExportStreamLicense("GetMessageExportConsumers"); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.MessageExportConsumer().GetAll()
+}
+
+func (a *App) GetMessageExportConsumer(consumerId string) (*model.MessageExportConsumer, *model.AppError) {
+	if err := a.checkMessageExportStreamLicense("GetMessageExportConsumer"); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.MessageExportConsumer().Get(consumerId)
+}
+
+// CreateMessageExportConsumer registers a consumer of the message export stream, starting from the beginning of it.
+func (a *App) CreateMessageExportConsumer(consumer *model.MessageExportConsumer) (*model.MessageExportConsumer, *model.AppError) {
+	if err := a.checkMessageExportStreamLicense("CreateMessageExportConsumer"); err != nil {
+		return nil, err
+	}
+
+	consumer.SetCursor(model.MessageExportCursor{})
+	consumer.LastAckAt = 0
+
+	return a.Srv.Store.MessageExportConsumer().Save(consumer)
+}
+
+func (a *App) DeleteMessageExportConsumer(consumerId string) *model.AppError {
+	if err := a.checkMessageExportStreamLicense("DeleteMessageExportConsumer"); err != nil {
+		return err
+	}
+
+	return a.Srv.Store.MessageExportConsumer().Delete(consumerId)
+}
+
+// GetMessageExportChanges returns a page of the message export stream after a cursor, along with the cursor to fetch
+// the next page from. Fetching doesn't move the cursor of the consumer, which it acknowledges once it has stored the
+// changes, so that nothing is lost if it fails part way.
+func (a *App) GetMessageExportChanges(cursor model.MessageExportCursor, limit int) (*model.MessageExportChangeList, *model.AppError) {
+	if err := a.checkMessageExportStreamLicense("GetMessageExportChanges"); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = model.MESSAGE_EXPORT_CHANGES_DEFAULT_LIMIT
+	} else if limit > model.MESSAGE_EXPORT_CHANGES_MAX_LIMIT {
+		limit = model.MESSAGE_EXPORT_CHANGES_MAX_LIMIT
+	}
+
+	changes, err := a.Srv.Store.Compliance().MessageExportChanges(cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &model.MessageExportChangeList{Changes: changes}
+	if len(changes) > limit {
+		list.Changes = changes[:limit]
+		list.HasMore = true
+	}
+
+	if len(list.Changes) > 0 {
+		last := list.Changes[len(list.Changes)-1]
+		cursor = model.MessageExportCursor{UpdateAt: last.UpdateAt, PostId: last.PostId}
+	}
+	list.Cursor = cursor.Token()
+
+	return list, nil
+}
+
+// AckMessageExportChanges moves the cursor of a consumer to where it has ingested the stream up to.
+func (a *App) AckMessageExportChanges(consumerId string, cursor model.MessageExportCursor) (*model.MessageExportConsumer, *model.AppError) {
+	consumer, err := a.GetMessageExportConsumer(consumerId)
+	if err != nil {
+		return nil, err
+	}
+
+	consumer.SetCursor(cursor)
+	consumer.LastAckAt = model.GetMillis()
+
+	return a.Srv.Store.MessageExportConsumer().Update(consumer)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestGetMessageExportChanges(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	_, err := th.App.GetMessageExportChanges(model.MessageExportCursor{}, 0)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotImplemented, err.StatusCode)
+
+	th.App.SetLicense(model.NewTestLicense("message_export"))
+
+	post1 := th.CreatePost(th.BasicChannel)
+	post2 := th.CreatePost(th.BasicChannel)
+
+	// Page through the stream one change at a time from just before the posts
+	cursor := model.MessageExportCursor{UpdateAt: post1.UpdateAt - 1}
+	list, err := th.App.GetMessageExportChanges(cursor, 1)
+	require.Nil(t, err)
+	require.Len(t, list.Changes, 1)
+	assert.True(t, list.HasMore)
+
+	postIds := []string{list.Changes[0].PostId}
+	for list.HasMore {
+		var ok bool
+		cursor, ok = model.MessageExportCursorFromToken(list.Cursor)
+		require.True(t, ok)
+
+		list, err = th.App.GetMessageExportChanges(cursor, 1)
+		require.Nil(t, err)
+		require.Len(t, list.Changes, 1)
+		postIds = append(postIds, list.Changes[0].PostId)
+	}
+	assert.Contains(t, postIds, post1.Id)
+	assert.Contains(t, postIds, post2.Id)
+
+	t.Run("acknowledges the cursor of a consumer", func(t *testing.T) {
+		consumer, err := th.App.CreateMessageExportConsumer(&model.MessageExportConsumer{CreatorId: th.SystemAdminUser.Id, Name: "Archive"})
+		require.Nil(t, err)
+
+		consumer, err = th.App.AckMessageExportChanges(consumer.Id, cursor)
+		require.Nil(t, err)
+		assert.Equal(t, cursor, consumer.GetCursor())
+		assert.NotZero(t, consumer.LastAckAt)
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// GenerateMfaRecoveryCodes replaces the recovery codes of a user with new ones and returns them. They are only
+// saved hashed, so this is the only time they can be shown to the user.
+func (a *App) GenerateMfaRecoveryCodes(userId string) ([]string, *model.AppError) {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.MfaActive {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "app.mfa.recovery_codes.not_active.app_error", nil, "user_id="+userId, http.StatusBadRequest)
+	}
+
+	codes, recoveryCodes := model.NewMfaRecoveryCodes(user.Id)
+	if err := a.Srv.Store.MfaRecoveryCode().SaveForUser(user.Id, recoveryCodes); err != nil {
+		return nil, err
+	}
+
+	a.LogAuditEvent(&model.AuditEvent{
+		ActorId:   user.Id,
+		IpAddress: a.IpAddress,
+		Action:    "mfa/recovery_codes_generated",
+		Target:    model.StringMap{"user_id": user.Id},
+		Result:    model.AUDIT_RESULT_SUCCESS,
+	})
+
+	return codes, nil
+}
+
+// useMfaRecoveryCode spends one of the recovery codes of a user, returning whether the token was one of them.
+func (a *App) useMfaRecoveryCode(user *model.User, token string) (bool, *model.AppError) {
+	if !model.IsMfaRecoveryCode(token) {
+		return false, nil
+	}
+
+	used, err := a.Srv.Store.MfaRecoveryCode().Use(user.Id, model.HashMfaRecoveryCode(token))
+	if err != nil || !used {
+		return false, err
+	}
+
+	a.LogAuditEvent(&model.AuditEvent{
+		ActorId:   user.Id,
+		IpAddress: a.IpAddress,
+		Action:    "mfa/recovery_code_used",
+		Target:    model.StringMap{"user_id": user.Id},
+		Result:    model.AUDIT_RESULT_SUCCESS,
+	})
+
+	return true, nil
+}
+
+// ResetUserMfa turns MFA off for a user who lost their authenticator and their recovery codes, so that they can
+// enroll again.
+func (a *App) ResetUserMfa(userId, actorId string) *model.AppError {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return err
+	}
+
+	if err := a.DeactivateMfa(user.Id); err != nil {
+		return err
+	}
+
+	a.InvalidateCacheForUser(user.Id)
+
+	a.LogAuditEvent(&model.AuditEvent{
+		ActorId:   actorId,
+		IpAddress: a.IpAddress,
+		Action:    "mfa/reset",
+		Target:    model.StringMap{"user_id": user.Id},
+		Result:    model.AUDIT_RESULT_SUCCESS,
+	})
+
+	a.Srv.Go(func() {
+		if err := a.SendMfaChangeEmail(user.Email, false, user.Locale, a.GetSiteURL()); err != nil {
+			mlog.Error("Failed to send mfa change email", mlog.Err(err))
+		}
+	})
+
+	return nil
+}
+
+func (a *App) GetMfaAdoption() (*model.MfaAdoption, *model.AppError) {
+	return a.Srv.Store.User().AnalyticsGetMfaAdoption()
+}
+
+// IsInMfaGracePeriod returns whether a user without MFA can still use the server while MFA is enforced. The grace
+// period starts when MFA enforcement is turned on, or when the user joins after that.
+func (a *App) IsInMfaGracePeriod(user *model.User) bool {
+	days := *a.Config().ServiceSettings.MultifactorAuthenticationGracePeriodDays
+	if days == 0 {
+		return false
+	}
+
+	start := a.getMfaEnforcementStartAt()
+	if user.CreateAt > start {
+		start = user.CreateAt
+	}
+
+	return model.GetMillis() < start+int64(days)*24*60*60*1000
+}
+
+// getMfaEnforcementStartAt returns when MFA enforcement was turned on. Enforcement turned on before this was
+// recorded is taken to start the first time it is needed.
+func (a *App) getMfaEnforcementStartAt() int64 {
+	if system, err := a.Srv.Store.System().GetByName(model.SYSTEM_MFA_ENFORCEMENT_START_AT); err == nil {
+		if startAt, err := strconv.ParseInt(system.Value, 10, 64); err == nil {
+			return startAt
+		}
+	}
+
+	return a.Srv.recordMfaEnforcementStart()
+}
+
+func (s *Server) recordMfaEnforcementStart() int64 {
+	startAt := model.GetMillis()
+	if err := s.Store.System().SaveOrUpdate(&model.System{Name: model.SYSTEM_MFA_ENFORCEMENT_START_AT, Value: strconv.FormatInt(startAt, 10)}); err != nil {
+		mlog.Error("Failed to save when MFA enforcement started", mlog.Err(err))
+	}
+
+	return startAt
+}
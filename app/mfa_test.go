@@ -0,0 +1,88 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestMfaRecoveryCodes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	_, err := th.App.GenerateMfaRecoveryCodes(th.BasicUser.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.mfa.recovery_codes.not_active.app_error", err.Id)
+
+	require.Nil(t, th.App.Srv.Store.User().UpdateMfaActive(th.BasicUser.Id, true))
+	th.App.InvalidateCacheForUser(th.BasicUser.Id)
+
+	codes, err := th.App.GenerateMfaRecoveryCodes(th.BasicUser.Id)
+	require.Nil(t, err)
+	require.Len(t, codes, model.MFA_RECOVERY_CODES_COUNT)
+
+	user, err := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, err)
+
+	t.Run("a code can only be used once", func(t *testing.T) {
+		require.Nil(t, th.App.CheckUserMfa(user, codes[0]))
+		require.NotNil(t, th.App.CheckUserMfa(user, codes[0]))
+
+		count, err := th.App.Srv.Store.MfaRecoveryCode().CountUnused(user.Id)
+		require.Nil(t, err)
+		assert.Equal(t, int64(model.MFA_RECOVERY_CODES_COUNT-1), count)
+	})
+
+	t.Run("new codes replace the old ones", func(t *testing.T) {
+		newCodes, err := th.App.GenerateMfaRecoveryCodes(user.Id)
+		require.Nil(t, err)
+
+		require.NotNil(t, th.App.CheckUserMfa(user, codes[1]))
+		require.Nil(t, th.App.CheckUserMfa(user, newCodes[1]))
+	})
+
+	t.Run("resetting MFA deletes the codes", func(t *testing.T) {
+		require.Nil(t, th.App.ResetUserMfa(user.Id, th.SystemAdminUser.Id))
+
+		user, err := th.App.GetUser(user.Id)
+		require.Nil(t, err)
+		assert.False(t, user.MfaActive)
+
+		count, err := th.App.Srv.Store.MfaRecoveryCode().CountUnused(user.Id)
+		require.Nil(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func TestIsInMfaGracePeriod(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	day := int64(24 * 60 * 60 * 1000)
+	setEnforcementStart := func(startAt int64) {
+		require.Nil(t, th.App.Srv.Store.System().SaveOrUpdate(&model.System{Name: model.SYSTEM_MFA_ENFORCEMENT_START_AT, Value: strconv.FormatInt(startAt, 10)}))
+	}
+
+	user := &model.User{CreateAt: model.GetMillis() - 30*day}
+
+	setEnforcementStart(model.GetMillis() - day)
+	assert.False(t, th.App.IsInMfaGracePeriod(user))
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MultifactorAuthenticationGracePeriodDays = 7 })
+	assert.True(t, th.App.IsInMfaGracePeriod(user))
+
+	setEnforcementStart(model.GetMillis() - 8*day)
+	assert.False(t, th.App.IsInMfaGracePeriod(user))
+
+	user.CreateAt = model.GetMillis() - day
+	assert.True(t, th.App.IsInMfaGracePeriod(user))
+}
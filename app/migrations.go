@@ -15,6 +15,7 @@ import (
 const ADVANCED_PERMISSIONS_MIGRATION_KEY = "AdvancedPermissionsMigrationComplete"
 const EMOJIS_PERMISSIONS_MIGRATION_KEY = "EmojisPermissionsMigrationComplete"
 const GUEST_ROLES_CREATION_MIGRATION_KEY = "GuestRolesCreationMigrationComplete"
+const ONBOARDING_STEPS_CREATION_MIGRATION_KEY = "OnboardingStepsCreationMigrationComplete"
 
 // This function migrates the default built in roles from code/config to the database.
 func (a *App) DoAdvancedPermissionsMigration() {
@@ -238,10 +239,44 @@ func (a *App) DoGuestRolesCreationMigration() {
 	}
 }
 
+// DoOnboardingStepsCreationMigration adds the default steps to the onboarding checklist the first time the server
+// starts with it, after which they are managed by the admins.
+func (a *App) DoOnboardingStepsCreationMigration() {
+	// If the migration is already marked as completed, don't do it again.
+	if _, err := a.Srv.Store.System().GetByName(ONBOARDING_STEPS_CREATION_MIGRATION_KEY); err == nil {
+		return
+	}
+
+	steps, err := a.Srv.Store.Onboarding().GetSteps()
+	if err != nil {
+		mlog.Critical("Failed to get the onboarding steps.", mlog.Err(err))
+		return
+	}
+
+	if len(steps) == 0 {
+		for _, step := range model.MakeDefaultOnboardingSteps() {
+			if _, err := a.Srv.Store.Onboarding().SaveStep(step); err != nil {
+				mlog.Critical("Failed to create the default onboarding steps.", mlog.Err(err))
+				return
+			}
+		}
+	}
+
+	system := model.System{
+		Name:  ONBOARDING_STEPS_CREATION_MIGRATION_KEY,
+		Value: "true",
+	}
+
+	if err := a.Srv.Store.System().Save(&system); err != nil {
+		mlog.Critical("Failed to mark onboarding steps creation migration as completed.", mlog.Err(err))
+	}
+}
+
 func (a *App) DoAppMigrations() {
 	a.DoAdvancedPermissionsMigration()
 	a.DoEmojisPermissionsMigration()
 	a.DoGuestRolesCreationMigration()
+	a.DoOnboardingStepsCreationMigration()
 	// This migration always must be the last, because can be based on previous
 	// migrations. For example, it needs the guest roles migration.
 	a.DoPermissionsMigrations()
@@ -0,0 +1,41 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// GetRequestCountry returns the country a request comes from, as given by the header configured in the network
+// access settings, or an empty string when it is unknown.
+func (a *App) GetRequestCountry(r *http.Request) string {
+	header := *a.Config().NetworkAccessSettings.CountryHeader
+	if header == "" {
+		return ""
+	}
+
+	return r.Header.Get(header)
+}
+
+// CheckNetworkAccess returns an error unless the network access settings allow a user with the given roles to
+// connect from the IP address and country. Denied connections are audited, whether they log in or use a session.
+func (a *App) CheckNetworkAccess(userId string, roles []string, ipAddress, country string) *model.AppError {
+	reason := a.Config().NetworkAccessSettings.CheckAccess(userId, roles, ipAddress, country)
+	if reason == "" {
+		return nil
+	}
+
+	a.LogAuditEvent(&model.AuditEvent{
+		ActorId:   userId,
+		IpAddress: ipAddress,
+		Action:    "network_access/denied",
+		Target:    model.StringMap{"user_id": userId},
+		Result:    model.AUDIT_RESULT_FAIL,
+		Details:   "reason=" + reason + " country=" + country,
+	})
+
+	return model.NewAppError("CheckNetworkAccess", "app.network_access.denied.app_error", nil, "user_id="+userId+", ip_addr="+ipAddress+", reason="+reason, http.StatusForbidden)
+}
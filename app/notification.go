@@ -85,6 +85,30 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 	} else {
 		keywords := a.getMentionKeywordsInChannel(profileMap, post.Type != model.POST_HEADER_CHANGE && post.Type != model.POST_PURPOSE_CHANGE, channelMemberNotifyPropsMap)
 
+		// Mention aliases aren't expanded in system messages, which include the messages posted when an alias
+		// redirects to a channel, so that redirects can't loop.
+		if !post.IsSystemMessage() {
+			aliasKeywords, redirectKeywords := a.getMentionAliasKeywords(team.Id, profileMap)
+			for keyword, ids := range aliasKeywords {
+				keywords[keyword] = append(keywords[keyword], ids...)
+			}
+
+			if len(redirectKeywords) > 0 {
+				// The ids mapped to redirect keywords are alias ids, so the aliases mentioned are the "users"
+				// mentioned when looking for those keywords only.
+				for aliasId := range getExplicitMentions(post, redirectKeywords).MentionedUserIds {
+					alias, err := a.Srv.Store.MentionAlias().Get(aliasId)
+					if err != nil {
+						mlog.Error("Failed to get mention alias", mlog.String("alias_id", aliasId), mlog.Err(err))
+						continue
+					}
+					a.Srv.Go(func() {
+						a.sendMentionAliasRedirect(alias, post, team, channel, sender)
+					})
+				}
+			}
+		}
+
 		m := getExplicitMentions(post, keywords)
 
 		// Add an implicit mention when a user is added to a channel
@@ -117,6 +141,19 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 			}
 		}
 
+		// get users that only want to be notified of the threads they're in
+		if len(post.RootId) > 0 && parentPostList != nil {
+			for userId, threadType := range a.getThreadsOnlyNotifyUserIds(post, parentPostList, profileMap, channelMemberNotifyPropsMap) {
+				if _, ok := threadMentionedUserIds[userId]; !ok {
+					threadMentionedUserIds[userId] = threadType
+				}
+
+				if _, ok := mentionedUserIds[userId]; !ok {
+					mentionedUserIds[userId] = false
+				}
+			}
+		}
+
 		// prevent the user from mentioning themselves
 		if post.Props["from_webhook"] != "true" {
 			delete(mentionedUserIds, post.UserId)
@@ -140,6 +177,23 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		}
 	}
 
+	// Users who've blocked the sender aren't notified of their posts
+	if blockers, err := a.getUsersBlocking(post.UserId); err != nil {
+		mlog.Warn("Failed to get the users blocking the sender of a post", mlog.String("post_id", post.Id), mlog.Err(err))
+	} else if len(blockers) > 0 {
+		for blockerId := range blockers {
+			delete(mentionedUserIds, blockerId)
+		}
+
+		notBlocking := allActivityPushUserIds[:0]
+		for _, id := range allActivityPushUserIds {
+			if _, blocking := blockers[id]; !blocking {
+				notBlocking = append(notBlocking, id)
+			}
+		}
+		allActivityPushUserIds = notBlocking
+	}
+
 	mentionedUsersList := make([]string, 0, len(mentionedUserIds))
 	for id := range mentionedUserIds {
 		mentionedUsersList = append(mentionedUsersList, id)
@@ -270,6 +324,9 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 	}
 
 	if sendPushNotifications {
+		busyUserIds := a.getBusyUserIds(append(append([]string{}, mentionedUsersList...), allActivityPushUserIds...), post.CreateAt)
+		channelWideMention := channelNotification || hereNotification || allNotification
+
 		for _, id := range mentionedUsersList {
 			if profileMap[id] == nil {
 				continue
@@ -281,7 +338,8 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 				status = &model.Status{UserId: id, Status: model.STATUS_OFFLINE, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
 			}
 
-			if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], true, status, post) {
+			if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], true, status, post) &&
+				(!busyUserIds[id] || DoesAvailabilityAllowPushNotification(channel, mentionedUserIds[id], channelWideMention)) {
 				replyToThreadType := ""
 				if value, ok := threadMentionedUserIds[id]; ok {
 					replyToThreadType = value
@@ -291,7 +349,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 					notification,
 					profileMap[id],
 					mentionedUserIds[id],
-					(channelNotification || hereNotification || allNotification),
+					channelWideMention,
 					replyToThreadType,
 				)
 			} else {
@@ -318,7 +376,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 					status = &model.Status{UserId: id, Status: model.STATUS_OFFLINE, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
 				}
 
-				if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], false, status, post) {
+				if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], false, status, post) && !busyUserIds[id] {
 					a.sendPushNotification(
 						notification,
 						profileMap[id],
@@ -377,6 +435,43 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 	return mentionedUsersList, nil
 }
 
+// getThreadsOnlyNotifyUserIds returns the channel members set to be notified of threads only who have posted in or
+// follow the thread a reply is posted to, mapped to whether they posted its root post or only followed or replied to it.
+func (a *App) getThreadsOnlyNotifyUserIds(post *model.Post, parentPostList *model.PostList, profileMap map[string]*model.User, channelMemberNotifyPropsMap map[string]model.StringMap) map[string]string {
+	isThreadsOnly := func(userId string) bool {
+		notifyProps := channelMemberNotifyPropsMap[userId]
+		return profileMap[userId] != nil &&
+			(notifyProps[model.DESKTOP_NOTIFY_PROP] == model.CHANNEL_NOTIFY_THREADS || notifyProps[model.PUSH_NOTIFY_PROP] == model.CHANNEL_NOTIFY_THREADS)
+	}
+
+	userIds := make(map[string]string)
+	for _, threadPost := range parentPostList.Posts {
+		if !isThreadsOnly(threadPost.UserId) {
+			continue
+		}
+
+		if threadPost.Id == post.RootId {
+			userIds[threadPost.UserId] = THREAD_ROOT
+		} else if _, ok := userIds[threadPost.UserId]; !ok {
+			userIds[threadPost.UserId] = THREAD_ANY
+		}
+	}
+
+	followers, err := a.Srv.Store.Preference().GetCategoryAndName(model.PREFERENCE_CATEGORY_FOLLOWED_THREAD, post.RootId)
+	if err != nil {
+		mlog.Warn("Failed to get the followers of a thread", mlog.String("root_id", post.RootId), mlog.Err(err))
+		return userIds
+	}
+
+	for _, follower := range followers {
+		if _, ok := userIds[follower.UserId]; !ok && follower.Value == "true" && isThreadsOnly(follower.UserId) {
+			userIds[follower.UserId] = THREAD_ANY
+		}
+	}
+
+	return userIds
+}
+
 // sendOutOfChannelMentions sends an ephemeral post to the sender of a post if any of the given potential mentions
 // are outside of the post's channel. Returns whether or not an ephemeral post was sent.
 func (a *App) sendOutOfChannelMentions(sender *model.User, post *model.Post, channel *model.Channel, potentialMentions []string) (bool, error) {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -55,13 +56,18 @@ func (hub *PushNotificationsHub) GetGoChannelFromUserId(userId string) chan Push
 func (a *App) sendPushNotificationSync(post *model.Post, user *model.User, channel *model.Channel, channelName string, senderName string,
 	explicitMention bool, channelWideMention bool, replyToThreadType string) *model.AppError {
 
-	sessions, err := a.getMobileAppSessions(user.Id)
+	msg := a.BuildPushNotificationMessage(post, user, channel, channelName, senderName, explicitMention, channelWideMention, replyToThreadType)
+
+	return a.sendPushNotificationToUser(msg, user.Id)
+}
+
+// sendPushNotificationToUser sends a push notification to each device the user is logged in to the mobile app on.
+func (a *App) sendPushNotificationToUser(msg model.PushNotification, userId string) *model.AppError {
+	sessions, err := a.getMobileAppSessions(userId)
 	if err != nil {
 		return err
 	}
 
-	msg := a.BuildPushNotificationMessage(post, user, channel, channelName, senderName, explicitMention, channelWideMention, replyToThreadType)
-
 	for _, session := range sessions {
 		if session.IsExpired() {
 			continue
@@ -83,9 +89,12 @@ func (a *App) sendPushNotificationSync(post *model.Post, user *model.User, chann
 				mlog.String("status", err.Error()),
 			)
 
+			a.recordPushNotificationFailure(session, err)
 			continue
 		}
 
+		a.recordPushNotificationSuccess(session)
+
 		a.NotificationsLog.Info("Notification sent",
 			mlog.String("ackId", tmpMessage.AckId),
 			mlog.String("type", tmpMessage.Type),
@@ -139,7 +148,7 @@ func (a *App) getPushNotificationMessage(postMessage string, explicitMention, ch
 		return senderName + userLocale("api.post.send_notifications_and_forget.push_image_only")
 	}
 
-	contentsConfig := *a.Config().EmailSettings.PushNotificationContents
+	contentsConfig := a.Config().EmailSettings.GetPushNotificationContents(channelType)
 
 	if contentsConfig == model.FULL_NOTIFICATION {
 		if channelType == model.CHANNEL_DIRECT {
@@ -152,6 +161,10 @@ func (a *App) getPushNotificationMessage(postMessage string, explicitMention, ch
 		return userLocale("api.post.send_notifications_and_forget.push_message")
 	}
 
+	if contentsConfig == model.SENDER_ONLY_NOTIFICATION {
+		return senderName + userLocale("api.post.send_notifications_and_forget.push_general_message")
+	}
+
 	if channelWideMention {
 		return senderName + userLocale("api.post.send_notification_and_forget.push_channel_mention")
 	}
@@ -210,9 +223,12 @@ func (a *App) ClearPushNotificationSync(currentSessionId, userId, channelId stri
 					mlog.String("status", err.Error()),
 				)
 
+				a.recordPushNotificationFailure(session, err)
 				continue
 			}
 
+			a.recordPushNotificationSuccess(session)
+
 			a.NotificationsLog.Info("Notification sent",
 				mlog.String("ackId", tmpMessage.AckId),
 				mlog.String("type", tmpMessage.Type),
@@ -313,16 +329,164 @@ func (a *App) sendToPushProxy(msg model.PushNotification, session *model.Session
 	if pushResponse[model.PUSH_STATUS] == model.PUSH_STATUS_REMOVE {
 		a.AttachDeviceId(session.Id, "", session.ExpiresAt)
 		a.ClearSessionCacheForUser(session.UserId)
-		return errors.New("Device was reported as removed")
+		return &pushNotificationRejectedError{reason: "Device was reported as removed"}
 	}
 
 	if pushResponse[model.PUSH_STATUS] == model.PUSH_STATUS_FAIL {
-		return errors.New(pushResponse[model.PUSH_STATUS_ERROR_MSG])
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return errors.New(pushResponse[model.PUSH_STATUS_ERROR_MSG])
+		}
+		return &pushNotificationRejectedError{reason: pushResponse[model.PUSH_STATUS_ERROR_MSG]}
 	}
 
 	return nil
 }
 
+// pushNotificationRejectedError is returned by sendToPushProxy when the push proxy was reached and explicitly
+// rejected the notification for the session's device, as opposed to the proxy being unreachable or failing.
+type pushNotificationRejectedError struct {
+	reason string
+}
+
+func (e *pushNotificationRejectedError) Error() string {
+	return e.reason
+}
+
+// recordPushNotificationFailure keeps track of consecutive delivery failures reported by the push proxy for
+// the given session. Once the configured maximum is reached the device id is considered stale and detached
+// from the session so that no further notifications are sent to it. Network errors and failures of the push
+// proxy itself say nothing about the device and are not counted.
+func (a *App) recordPushNotificationFailure(session *model.Session, reason error) {
+	if a.Metrics != nil {
+		a.Metrics.IncrementPushNotificationFailure()
+	}
+
+	if _, ok := reason.(*pushNotificationRejectedError); !ok {
+		return
+	}
+
+	failures, _ := strconv.Atoi(session.Props[model.SESSION_PROP_PUSH_FAILURES])
+	failures++
+
+	session.AddProp(model.SESSION_PROP_PUSH_FAILURES, strconv.Itoa(failures))
+	session.AddProp(model.SESSION_PROP_PUSH_LAST_FAILURE, reason.Error())
+	session.AddProp(model.SESSION_PROP_PUSH_LAST_FAILURE_AT, strconv.FormatInt(model.GetMillis(), 10))
+
+	maxFailures := *a.Config().EmailSettings.PushNotificationMaxFailures
+	if maxFailures > 0 && failures >= maxFailures && session.DeviceId != "" {
+		a.NotificationsLog.Warn("Device removed after repeated notification errors",
+			mlog.String("userId", session.UserId),
+			mlog.String("sessionId", session.Id),
+			mlog.Int("failures", failures),
+		)
+
+		if err := a.AttachDeviceId(session.Id, "", session.ExpiresAt); err != nil {
+			mlog.Error("Failed to remove stale device id from session", mlog.String("session_id", session.Id), mlog.Err(err))
+		} else if a.Metrics != nil {
+			a.Metrics.IncrementPushNotificationDeviceInvalidated()
+		}
+	}
+
+	if err := a.Srv.Store.Session().UpdateProps(session); err != nil {
+		mlog.Error("Failed to record push notification failure", mlog.String("session_id", session.Id), mlog.Err(err))
+		return
+	}
+
+	a.ClearSessionCacheForUser(session.UserId)
+}
+
+// recordPushNotificationSuccess resets the consecutive failure count of the given session, if any.
+func (a *App) recordPushNotificationSuccess(session *model.Session) {
+	if _, ok := session.Props[model.SESSION_PROP_PUSH_FAILURES]; !ok {
+		return
+	}
+
+	delete(session.Props, model.SESSION_PROP_PUSH_FAILURES)
+
+	if err := a.Srv.Store.Session().UpdateProps(session); err != nil {
+		mlog.Error("Failed to reset push notification failures", mlog.String("session_id", session.Id), mlog.Err(err))
+		return
+	}
+
+	a.ClearSessionCacheForUser(session.UserId)
+}
+
+// GetPushNotificationDiagnostics explains whether, and why not, the given user is expected to receive push
+// notifications on their registered devices.
+func (a *App) GetPushNotificationDiagnostics(userId string) (*model.PushNotificationDiagnostics, *model.AppError) {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := a.GetSessions(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := a.Config()
+	diagnostics := &model.PushNotificationDiagnostics{
+		UserId:                 user.Id,
+		SendPushNotifications:  *cfg.EmailSettings.SendPushNotifications,
+		PushNotificationServer: *cfg.EmailSettings.PushNotificationServer != "",
+		PushNotifyProp:         user.NotifyProps[model.PUSH_NOTIFY_PROP],
+		PushStatusNotifyProp:   user.NotifyProps[model.PUSH_STATUS_NOTIFY_PROP],
+		Sessions:               []*model.PushNotificationSessionDiagnostics{},
+		Problems:               []string{},
+	}
+
+	if !diagnostics.SendPushNotifications {
+		diagnostics.Problems = append(diagnostics.Problems, model.PUSH_DIAGNOSTIC_SERVER_DISABLED)
+	}
+
+	if !diagnostics.PushNotificationServer {
+		diagnostics.Problems = append(diagnostics.Problems, model.PUSH_DIAGNOSTIC_NO_SERVER)
+	}
+
+	if diagnostics.PushNotifyProp == model.USER_NOTIFY_NONE {
+		diagnostics.Problems = append(diagnostics.Problems, model.PUSH_DIAGNOSTIC_USER_DISABLED)
+	}
+
+	if status, statusErr := a.GetStatus(userId); statusErr == nil {
+		diagnostics.Status = status.Status
+		if status.Status == model.STATUS_DND || status.Status == model.STATUS_OUT_OF_OFFICE {
+			diagnostics.Problems = append(diagnostics.Problems, model.PUSH_DIAGNOSTIC_STATUS_SUPPRESSED)
+		}
+	}
+
+	activeDevices := 0
+	failingDevices := 0
+	for _, session := range sessions {
+		if session.DeviceId == "" && session.Props[model.SESSION_PROP_PUSH_LAST_FAILURE_AT] == "" {
+			continue
+		}
+
+		sessionDiagnostics := model.NewPushNotificationSessionDiagnostics(session)
+		diagnostics.Sessions = append(diagnostics.Sessions, sessionDiagnostics)
+
+		if !sessionDiagnostics.HasDeviceId || session.IsExpired() {
+			continue
+		}
+
+		activeDevices++
+		if sessionDiagnostics.Failures > 0 {
+			failingDevices++
+		}
+	}
+
+	if len(diagnostics.Sessions) == 0 {
+		diagnostics.Problems = append(diagnostics.Problems, model.PUSH_DIAGNOSTIC_NO_DEVICES)
+	} else if activeDevices == 0 {
+		diagnostics.Problems = append(diagnostics.Problems, model.PUSH_DIAGNOSTIC_NO_ACTIVE_DEVICES)
+	}
+
+	if failingDevices > 0 {
+		diagnostics.Problems = append(diagnostics.Problems, model.PUSH_DIAGNOSTIC_DEVICE_FAILURES)
+	}
+
+	return diagnostics, nil
+}
+
 func (a *App) SendAckToPushProxy(ack *model.PushNotificationAck) error {
 	if ack == nil {
 		return nil
@@ -386,7 +550,7 @@ func DoesNotifyPropsAllowPushNotification(user *model.User, channelNotifyProps m
 		return false
 	}
 
-	if channelNotify == model.CHANNEL_NOTIFY_MENTION && !wasMentioned {
+	if (channelNotify == model.CHANNEL_NOTIFY_MENTION || channelNotify == model.CHANNEL_NOTIFY_THREADS) && !wasMentioned {
 		return false
 	}
 
@@ -407,6 +571,17 @@ func DoesNotifyPropsAllowPushNotification(user *model.User, channelNotifyProps m
 	return true
 }
 
+// DoesAvailabilityAllowPushNotification returns whether a user who's busy according to their availability is pushed a
+// notification of a post they were notified of, which is only the case for direct and group messages and for mentions
+// of them that aren't channel wide.
+func DoesAvailabilityAllowPushNotification(channel *model.Channel, wasMentioned bool, channelWideMention bool) bool {
+	if channel.Type == model.CHANNEL_DIRECT || channel.Type == model.CHANNEL_GROUP {
+		return true
+	}
+
+	return wasMentioned && !channelWideMention
+}
+
 func DoesStatusAllowPushNotification(userNotifyProps model.StringMap, status *model.Status, channelId string) bool {
 	// If User status is DND or OOO return false right away
 	if status.Status == model.STATUS_DND || status.Status == model.STATUS_OUT_OF_OFFICE {
@@ -443,6 +618,14 @@ func (a *App) BuildPushNotificationMessage(post *model.Post, user *model.User, c
 		SenderId:  post.UserId,
 	}
 
+	// Notifications are grouped on the device by thread for replies and by channel otherwise, while the
+	// collapse key allows a later notification for the same post to replace the one already displayed.
+	msg.ThreadId = channel.Id
+	if post.RootId != "" {
+		msg.ThreadId = post.RootId
+	}
+	msg.CollapseKey = post.Id
+
 	if user.NotifyProps["push"] == "all" {
 		if unreadCount, err := a.Srv.Store.User().GetAnyUnreadPostCountForChannel(user.Id, channel.Id); err != nil {
 			msg.Badge = 1
@@ -460,8 +643,8 @@ func (a *App) BuildPushNotificationMessage(post *model.Post, user *model.User, c
 	}
 
 	cfg := a.Config()
-	contentsConfig := *cfg.EmailSettings.PushNotificationContents
-	if contentsConfig != model.GENERIC_NO_CHANNEL_NOTIFICATION || channel.Type == model.CHANNEL_DIRECT {
+	contentsConfig := cfg.EmailSettings.GetPushNotificationContents(channel.Type)
+	if (contentsConfig != model.GENERIC_NO_CHANNEL_NOTIFICATION && contentsConfig != model.SENDER_ONLY_NOTIFICATION) || channel.Type == model.CHANNEL_DIRECT {
 		msg.ChannelName = channelName
 	}
 
@@ -4,12 +4,14 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDoesNotifyPropsAllowPushNotification(t *testing.T) {
@@ -238,6 +240,24 @@ func TestDoesNotifyPropsAllowPushNotification(t *testing.T) {
 			isMuted:              false,
 			expected:             true,
 		},
+		{
+			name:                 "When default is ALL, channel is THREADS and has no mentions",
+			userNotifySetting:    model.USER_NOTIFY_ALL,
+			channelNotifySetting: model.CHANNEL_NOTIFY_THREADS,
+			withSystemPost:       false,
+			wasMentioned:         false,
+			isMuted:              false,
+			expected:             false,
+		},
+		{
+			name:                 "When default is NONE, channel is THREADS and has mentions",
+			userNotifySetting:    model.USER_NOTIFY_NONE,
+			channelNotifySetting: model.CHANNEL_NOTIFY_THREADS,
+			withSystemPost:       false,
+			wasMentioned:         true,
+			isMuted:              false,
+			expected:             true,
+		},
 		{
 			name:                 "When default is NONE, channel is MENTION and has no mentions",
 			userNotifySetting:    model.USER_NOTIFY_NONE,
@@ -537,6 +557,27 @@ func TestDoesStatusAllowPushNotification(t *testing.T) {
 	}
 }
 
+func TestDoesAvailabilityAllowPushNotification(t *testing.T) {
+	for name, tc := range map[string]struct {
+		channelType        string
+		wasMentioned       bool
+		channelWideMention bool
+		expected           bool
+	}{
+		"direct message":                      {model.CHANNEL_DIRECT, true, false, true},
+		"group message":                       {model.CHANNEL_GROUP, false, false, true},
+		"mention":                             {model.CHANNEL_OPEN, true, false, true},
+		"channel wide mention":                {model.CHANNEL_OPEN, true, true, false},
+		"reply to a followed thread":          {model.CHANNEL_PRIVATE, false, false, false},
+		"reply to a followed thread with all": {model.CHANNEL_PRIVATE, false, true, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			channel := &model.Channel{Type: tc.channelType}
+			assert.Equal(t, tc.expected, DoesAvailabilityAllowPushNotification(channel, tc.wasMentioned, tc.channelWideMention))
+		})
+	}
+}
+
 func TestGetPushNotificationMessage(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -839,6 +880,26 @@ func TestGetPushNotificationMessage(t *testing.T) {
 			ChannelType:              model.CHANNEL_DIRECT,
 			ExpectedMessage:          "sent you a message.",
 		},
+		"sender only message, public channel, mention": {
+			Message:                  "this is a message",
+			explicitMention:          true,
+			PushNotificationContents: model.SENDER_ONLY_NOTIFICATION,
+			ChannelType:              model.CHANNEL_OPEN,
+			ExpectedMessage:          "user posted a message.",
+		},
+		"sender only message, group message channel, commented on thread": {
+			Message:                  "this is a message",
+			replyToThreadType:        THREAD_ANY,
+			PushNotificationContents: model.SENDER_ONLY_NOTIFICATION,
+			ChannelType:              model.CHANNEL_GROUP,
+			ExpectedMessage:          "user posted a message.",
+		},
+		"sender only message, direct message channel": {
+			Message:                  "this is a message",
+			PushNotificationContents: model.SENDER_ONLY_NOTIFICATION,
+			ChannelType:              model.CHANNEL_DIRECT,
+			ExpectedMessage:          "sent you a message.",
+		},
 		"only files, public channel": {
 			HasFiles:        true,
 			ChannelType:     model.CHANNEL_OPEN,
@@ -944,6 +1005,53 @@ func TestBuildPushNotificationMessage(t *testing.T) {
 			receiver.NotifyProps["push"] = tc.pushNotifyProps
 			msg := th.App.BuildPushNotificationMessage(post, receiver, channel, channel.Name, sender.Username, tc.explicitMention, tc.channelWideMention, tc.replyToThreadType)
 			assert.Equal(t, tc.expectedBadge, msg.Badge)
+			assert.Equal(t, channel.Id, msg.ThreadId)
+			assert.Equal(t, post.Id, msg.CollapseKey)
 		})
 	}
 }
+
+func TestRecordPushNotificationFailure(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.PushNotificationMaxFailures = 2
+	})
+
+	session, err := th.App.CreateSession(&model.Session{
+		UserId:   th.BasicUser.Id,
+		DeviceId: model.PUSH_NOTIFY_ANDROID + ":" + model.NewId(),
+	})
+	require.Nil(t, err)
+
+	th.App.recordPushNotificationFailure(session, errors.New("push proxy unreachable"))
+	th.App.recordPushNotificationFailure(session, errors.New("push proxy unreachable"))
+
+	session, err = th.App.Srv.Store.Session().Get(session.Id)
+	require.Nil(t, err)
+	assert.NotEmpty(t, session.DeviceId)
+	assert.Empty(t, session.Props[model.SESSION_PROP_PUSH_FAILURES])
+
+	th.App.recordPushNotificationFailure(session, &pushNotificationRejectedError{reason: "first failure"})
+
+	session, err = th.App.Srv.Store.Session().Get(session.Id)
+	require.Nil(t, err)
+	assert.NotEmpty(t, session.DeviceId)
+	assert.Equal(t, "1", session.Props[model.SESSION_PROP_PUSH_FAILURES])
+	assert.Equal(t, "first failure", session.Props[model.SESSION_PROP_PUSH_LAST_FAILURE])
+
+	th.App.recordPushNotificationSuccess(session)
+
+	session, err = th.App.Srv.Store.Session().Get(session.Id)
+	require.Nil(t, err)
+	assert.Empty(t, session.Props[model.SESSION_PROP_PUSH_FAILURES])
+
+	th.App.recordPushNotificationFailure(session, &pushNotificationRejectedError{reason: "first failure"})
+	th.App.recordPushNotificationFailure(session, &pushNotificationRejectedError{reason: "second failure"})
+
+	session, err = th.App.Srv.Store.Session().Get(session.Id)
+	require.Nil(t, err)
+	assert.Empty(t, session.DeviceId)
+	assert.Equal(t, "2", session.Props[model.SESSION_PROP_PUSH_FAILURES])
+}
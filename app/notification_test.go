@@ -70,6 +70,69 @@ func TestSendNotifications(t *testing.T) {
 	require.Len(t, mentions, 0)
 }
 
+func TestSendNotificationsThreadsOnly(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.AddUserToChannel(th.BasicUser2, th.BasicChannel)
+
+	user3 := th.CreateUser()
+	th.LinkUserToTeam(user3, th.BasicTeam)
+	th.AddUserToChannel(user3, th.BasicChannel)
+
+	for _, userId := range []string{th.BasicUser2.Id, user3.Id} {
+		_, appErr := th.App.UpdateChannelMemberNotifyProps(map[string]string{model.DESKTOP_NOTIFY_PROP: model.CHANNEL_NOTIFY_THREADS}, th.BasicChannel.Id, userId)
+		require.Nil(t, appErr)
+	}
+
+	rootPost := th.CreatePost(th.BasicChannel)
+
+	_, appErr := th.App.CreatePostMissingChannel(&model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		RootId:    rootPost.Id,
+		ParentId:  rootPost.Id,
+		Message:   "reply",
+	}, true)
+	require.Nil(t, appErr)
+
+	getMentions := func() []string {
+		post := &model.Post{
+			Id:        model.NewId(),
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			RootId:    rootPost.Id,
+			ParentId:  rootPost.Id,
+			Message:   "another reply",
+		}
+
+		parentPostList, appErr := th.App.GetPostThread(rootPost.Id)
+		require.Nil(t, appErr)
+
+		mentions, err := th.App.SendNotifications(post, th.BasicTeam, th.BasicChannel, th.BasicUser, parentPostList)
+		require.NoError(t, err)
+		return mentions
+	}
+
+	// Only the member who replied to the thread is notified
+	mentions := getMentions()
+	assert.Contains(t, mentions, th.BasicUser2.Id)
+	assert.NotContains(t, mentions, user3.Id)
+
+	// Following the thread notifies the member of replies to it too
+	appErr = th.App.UpdatePreferences(user3.Id, model.Preferences{{
+		UserId:   user3.Id,
+		Category: model.PREFERENCE_CATEGORY_FOLLOWED_THREAD,
+		Name:     rootPost.Id,
+		Value:    "true",
+	}})
+	require.Nil(t, appErr)
+
+	mentions = getMentions()
+	assert.Contains(t, mentions, th.BasicUser2.Id)
+	assert.Contains(t, mentions, user3.Id)
+}
+
 func TestSendNotificationsWithManyUsers(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
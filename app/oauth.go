@@ -15,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	goi18n "github.com/mattermost/go-i18n/i18n"
 	"github.com/mattermost/mattermost-server/einterfaces"
 	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
@@ -89,6 +90,19 @@ func (a *App) GetOAuthAppsByCreator(userId string, page, perPage int) ([]*model.
 	return a.Srv.Store.OAuth().GetAppByUser(userId, page*perPage, perPage)
 }
 
+// GetOAuthScopes returns the scopes OAuth apps can request, described in the user's language for the
+// consent screen.
+func (a *App) GetOAuthScopes(T goi18n.TranslateFunc) []*model.OAuthScope {
+	scopes := []*model.OAuthScope{{Name: model.DEFAULT_SCOPE, Description: T("app.oauth.scope.user.description")}}
+	for _, name := range model.OAuthScopes {
+		scopes = append(scopes, &model.OAuthScope{
+			Name:        name,
+			Description: T("app.oauth.scope." + strings.Replace(name, ":", "_", -1) + ".description"),
+		})
+	}
+	return scopes
+}
+
 func (a *App) GetOAuthImplicitRedirect(userId string, authRequest *model.AuthorizeRequest) (string, *model.AppError) {
 	session, err := a.GetOAuthAccessTokenForImplicitFlow(userId, authRequest)
 	if err != nil {
@@ -121,9 +135,7 @@ func (a *App) AllowOAuthAppAccessToUser(userId string, authRequest *model.Author
 		return "", model.NewAppError("AllowOAuthAppAccessToUser", "api.oauth.allow_oauth.turn_off.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	if len(authRequest.Scope) == 0 {
-		authRequest.Scope = model.DEFAULT_SCOPE
-	}
+	authRequest.Scope = model.NormalizeOAuthScope(authRequest.Scope)
 
 	oauthApp, err := a.Srv.Store.OAuth().GetApp(authRequest.ClientId)
 	if err != nil {
@@ -181,7 +193,7 @@ func (a *App) GetOAuthAccessTokenForImplicitFlow(userId string, authRequest *mod
 		return nil, err
 	}
 
-	session, err := a.newSession(oauthApp.Name, user)
+	session, err := a.newSession(oauthApp.Name, user, authRequest.Scope)
 	if err != nil {
 		return nil, err
 	}
@@ -240,7 +252,10 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(clientId, grantType, redirectUri, c
 		}
 
 		if accessData != nil {
-			if accessData.IsExpired() {
+			// A new grant for different scopes replaces the session of the previous one
+			if accessData.IsExpired() || accessData.Scope != authData.Scope {
+				accessData.Scope = authData.Scope
+
 				var access *model.AccessResponse
 				access, err = a.newSessionUpdateToken(oauthApp.Name, accessData, user)
 				if err != nil {
@@ -254,12 +269,13 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(clientId, grantType, redirectUri, c
 					TokenType:    model.ACCESS_TOKEN_TYPE,
 					RefreshToken: accessData.RefreshToken,
 					ExpiresIn:    int32((accessData.ExpiresAt - model.GetMillis()) / 1000),
+					Scope:        accessData.Scope,
 				}
 			}
 		} else {
 			var session *model.Session
 			// Create a new session and return new access token
-			session, err = a.newSession(oauthApp.Name, user)
+			session, err = a.newSession(oauthApp.Name, user, authData.Scope)
 			if err != nil {
 				return nil, err
 			}
@@ -276,6 +292,7 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(clientId, grantType, redirectUri, c
 				TokenType:    model.ACCESS_TOKEN_TYPE,
 				RefreshToken: accessData.RefreshToken,
 				ExpiresIn:    int32(*a.Config().ServiceSettings.SessionLengthSSOInDays * 60 * 60 * 24),
+				Scope:        accessData.Scope,
 			}
 		}
 
@@ -284,6 +301,19 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(clientId, grantType, redirectUri, c
 		// When grantType is refresh_token
 		accessData, err = a.Srv.Store.OAuth().GetAccessDataByRefreshToken(refreshToken)
 		if err != nil {
+			// Refresh tokens are rotated on every use, so a replayed one may have been stolen. Revoke the
+			// grant it belonged to so that neither party can keep using it.
+			if reused, reusedErr := a.Srv.Store.OAuth().GetAccessDataByPreviousRefreshToken(refreshToken); reusedErr == nil && reused.ClientId == clientId {
+				mlog.Warn("Revoking OAuth access after a refresh token was reused", mlog.String("client_id", clientId), mlog.String("user_id", reused.UserId))
+				if err = a.RevokeAccessToken(reused.Token); err != nil {
+					mlog.Error("Failed to revoke OAuth access after a refresh token was reused", mlog.Err(err))
+				}
+				return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.refresh_token_reused.app_error", nil, "", http.StatusForbidden)
+			}
+			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.refresh_token.app_error", nil, "", http.StatusNotFound)
+		}
+
+		if accessData.ClientId != clientId {
 			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.refresh_token.app_error", nil, "", http.StatusNotFound)
 		}
 
@@ -302,7 +332,7 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(clientId, grantType, redirectUri, c
 	return accessRsp, nil
 }
 
-func (a *App) newSession(appName string, user *model.User) (*model.Session, *model.AppError) {
+func (a *App) newSession(appName string, user *model.User, scope string) (*model.Session, *model.AppError) {
 	// Set new token an session
 	session := &model.Session{UserId: user.Id, Roles: user.Roles, IsOAuth: true}
 	session.GenerateCSRF()
@@ -310,6 +340,9 @@ func (a *App) newSession(appName string, user *model.User) (*model.Session, *mod
 	session.AddProp(model.SESSION_PROP_PLATFORM, appName)
 	session.AddProp(model.SESSION_PROP_OS, "OAuth2")
 	session.AddProp(model.SESSION_PROP_BROWSER, "OAuth2")
+	if model.IsRestrictedOAuthScope(scope) {
+		session.AddProp(model.SESSION_PROP_OAUTH_SCOPE, model.NormalizeOAuthScope(scope))
+	}
 
 	session, err := a.Srv.Store.Session().Save(session)
 	if err != nil {
@@ -326,13 +359,15 @@ func (a *App) newSessionUpdateToken(appName string, accessData *model.AccessData
 	if err := a.Srv.Store.Session().Remove(accessData.Token); err != nil {
 		mlog.Error(fmt.Sprint(err))
 	}
+	a.ClearSessionCacheForUser(user.Id)
 
-	session, err := a.newSession(appName, user)
+	session, err := a.newSession(appName, user, accessData.Scope)
 	if err != nil {
 		return nil, err
 	}
 
 	accessData.Token = session.Token
+	accessData.PreviousRefreshToken = accessData.RefreshToken
 	accessData.RefreshToken = model.NewId()
 	accessData.ExpiresAt = session.ExpiresAt
 
@@ -345,6 +380,7 @@ func (a *App) newSessionUpdateToken(appName string, accessData *model.AccessData
 		RefreshToken: accessData.RefreshToken,
 		TokenType:    model.ACCESS_TOKEN_TYPE,
 		ExpiresIn:    int32(*a.Config().ServiceSettings.SessionLengthSSOInDays * 60 * 60 * 24),
+		Scope:        accessData.Scope,
 	}
 
 	return accessRsp, nil
@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/mattermost/mattermost-server/model"
@@ -65,6 +66,108 @@ func TestGetOAuthAccessTokenForImplicitFlow(t *testing.T) {
 	assert.Nil(t, session)
 }
 
+func TestGetOAuthAccessTokenForCodeFlowScopesAndRotation(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oapp, err := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "fakeoauthapp" + model.NewRandomString(10),
+		CreatorId:    th.BasicUser2.Id,
+		Homepage:     "https://nowhere.com",
+		Description:  "test",
+		CallbackUrls: []string{"https://nowhere.com"},
+	})
+	require.Nil(t, err)
+
+	authorize := func(scope string) string {
+		redirect, appErr := th.App.AllowOAuthAppAccessToUser(th.BasicUser.Id, &model.AuthorizeRequest{
+			ResponseType: model.AUTHCODE_RESPONSE_TYPE,
+			ClientId:     oapp.Id,
+			RedirectUri:  oapp.CallbackUrls[0],
+			Scope:        scope,
+			State:        "123",
+		})
+		require.Nil(t, appErr)
+
+		rurl, parseErr := url.Parse(redirect)
+		require.Nil(t, parseErr)
+		return rurl.Query().Get("code")
+	}
+
+	code := authorize(model.OAUTH_SCOPE_READ_USERS + " " + model.OAUTH_SCOPE_READ_POSTS)
+	access, err := th.App.GetOAuthAccessTokenForCodeFlow(oapp.Id, model.ACCESS_TOKEN_GRANT_TYPE, oapp.CallbackUrls[0], code, oapp.ClientSecret, "")
+	require.Nil(t, err)
+	assert.Equal(t, model.OAUTH_SCOPE_READ_POSTS+" "+model.OAUTH_SCOPE_READ_USERS, access.Scope)
+
+	session, err := th.App.GetSession(access.AccessToken)
+	require.Nil(t, err)
+	assert.True(t, session.HasOAuthScope(model.OAUTH_SCOPE_READ_POSTS))
+	assert.False(t, session.HasOAuthScope(model.OAUTH_SCOPE_WRITE_POSTS))
+
+	t.Run("refresh rotates the tokens and keeps the scope", func(t *testing.T) {
+		refreshed, err := th.App.GetOAuthAccessTokenForCodeFlow(oapp.Id, model.REFRESH_TOKEN_GRANT_TYPE, oapp.CallbackUrls[0], "", oapp.ClientSecret, access.RefreshToken)
+		require.Nil(t, err)
+		assert.NotEqual(t, access.RefreshToken, refreshed.RefreshToken)
+		assert.Equal(t, access.Scope, refreshed.Scope)
+
+		_, err = th.App.GetSession(access.AccessToken)
+		assert.NotNil(t, err, "the previous access token should have been revoked")
+
+		session, err := th.App.GetSession(refreshed.AccessToken)
+		require.Nil(t, err)
+		assert.False(t, session.HasOAuthScope(model.OAUTH_SCOPE_WRITE_POSTS))
+
+		access = refreshed
+	})
+
+	t.Run("refresh with another app's credentials fails", func(t *testing.T) {
+		other, err := th.App.CreateOAuthApp(&model.OAuthApp{
+			Name:         "fakeoauthapp" + model.NewRandomString(10),
+			CreatorId:    th.BasicUser2.Id,
+			Homepage:     "https://nowhere.com",
+			Description:  "test",
+			CallbackUrls: []string{"https://nowhere.com"},
+		})
+		require.Nil(t, err)
+
+		_, err = th.App.GetOAuthAccessTokenForCodeFlow(other.Id, model.REFRESH_TOKEN_GRANT_TYPE, other.CallbackUrls[0], "", other.ClientSecret, access.RefreshToken)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("new grant for different scopes replaces the session", func(t *testing.T) {
+		code := authorize(model.OAUTH_SCOPE_WRITE_POSTS)
+		granted, err := th.App.GetOAuthAccessTokenForCodeFlow(oapp.Id, model.ACCESS_TOKEN_GRANT_TYPE, oapp.CallbackUrls[0], code, oapp.ClientSecret, "")
+		require.Nil(t, err)
+		assert.Equal(t, model.OAUTH_SCOPE_WRITE_POSTS, granted.Scope)
+		assert.NotEqual(t, access.AccessToken, granted.AccessToken)
+
+		session, err := th.App.GetSession(granted.AccessToken)
+		require.Nil(t, err)
+		assert.True(t, session.HasOAuthScope(model.OAUTH_SCOPE_WRITE_POSTS))
+		assert.False(t, session.HasOAuthScope(model.OAUTH_SCOPE_READ_POSTS))
+
+		access = granted
+	})
+
+	t.Run("reusing a rotated refresh token revokes the grant", func(t *testing.T) {
+		refreshed, err := th.App.GetOAuthAccessTokenForCodeFlow(oapp.Id, model.REFRESH_TOKEN_GRANT_TYPE, oapp.CallbackUrls[0], "", oapp.ClientSecret, access.RefreshToken)
+		require.Nil(t, err)
+
+		_, err = th.App.GetOAuthAccessTokenForCodeFlow(oapp.Id, model.REFRESH_TOKEN_GRANT_TYPE, oapp.CallbackUrls[0], "", oapp.ClientSecret, access.RefreshToken)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.oauth.get_access_token.refresh_token_reused.app_error", err.Id)
+
+		_, err = th.App.GetSession(refreshed.AccessToken)
+		assert.NotNil(t, err, "the current access token should have been revoked")
+
+		_, err = th.App.GetOAuthAccessTokenForCodeFlow(oapp.Id, model.REFRESH_TOKEN_GRANT_TYPE, oapp.CallbackUrls[0], "", oapp.ClientSecret, refreshed.RefreshToken)
+		assert.NotNil(t, err, "the current refresh token should have been revoked")
+	})
+}
+
 func TestOAuthRevokeAccessToken(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -0,0 +1,272 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (a *App) checkOnboardingEnabled(where string) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableOnboarding {
+		return model.NewAppError(where, "app.onboarding.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return nil
+}
+
+func (a *App) GetOnboardingSteps() ([]*model.OnboardingStep, *model.AppError) {
+	if err := a.checkOnboardingEnabled("GetOnboardingSteps"); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.Onboarding().GetSteps()
+}
+
+func (a *App) GetOnboardingStep(stepId string) (*model.OnboardingStep, *model.AppError) {
+	return a.Srv.Store.Onboarding().GetStep(stepId)
+}
+
+func (a *App) CreateOnboardingStep(step *model.OnboardingStep) (*model.OnboardingStep, *model.AppError) {
+	steps, err := a.Srv.Store.Onboarding().GetSteps()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(steps) >= model.ONBOARDING_MAX_STEPS {
+		return nil, model.NewAppError("CreateOnboardingStep", "app.onboarding.create_step.too_many.app_error", map[string]interface{}{"Max": model.ONBOARDING_MAX_STEPS}, "", http.StatusBadRequest)
+	}
+
+	if err := a.checkOnboardingStepChannel(step); err != nil {
+		return nil, err
+	}
+
+	step.Id = ""
+
+	return a.Srv.Store.Onboarding().SaveStep(step)
+}
+
+func (a *App) UpdateOnboardingStep(oldStep, updatedStep *model.OnboardingStep) (*model.OnboardingStep, *model.AppError) {
+	oldStep.Type = updatedStep.Type
+	oldStep.DisplayName = updatedStep.DisplayName
+	oldStep.Description = updatedStep.Description
+	oldStep.ChannelId = updatedStep.ChannelId
+	oldStep.SortOrder = updatedStep.SortOrder
+
+	if err := a.checkOnboardingStepChannel(oldStep); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.Onboarding().UpdateStep(oldStep)
+}
+
+// DeleteOnboardingStep removes the step from the checklist. Who completed it is kept, so that restoring it wouldn't
+// ask them to do it again.
+func (a *App) DeleteOnboardingStep(stepId string) *model.AppError {
+	if _, err := a.Srv.Store.Onboarding().GetStep(stepId); err != nil {
+		return err
+	}
+
+	return a.Srv.Store.Onboarding().DeleteStep(stepId, model.GetMillis())
+}
+
+func (a *App) checkOnboardingStepChannel(step *model.OnboardingStep) *model.AppError {
+	if step.Type != model.ONBOARDING_STEP_TYPE_JOIN_CHANNEL || !model.IsValidId(step.ChannelId) {
+		return nil
+	}
+
+	channel, err := a.GetChannel(step.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	if channel.Type != model.CHANNEL_OPEN || channel.DeleteAt != 0 {
+		return model.NewAppError("checkOnboardingStepChannel", "app.onboarding.step_channel.not_public.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (a *App) GetOnboardingProgress(userId string) (*model.OnboardingProgress, *model.AppError) {
+	if err := a.checkOnboardingEnabled("GetOnboardingProgress"); err != nil {
+		return nil, err
+	}
+
+	steps, err := a.Srv.Store.Onboarding().GetSteps()
+	if err != nil {
+		return nil, err
+	}
+
+	completions, err := a.Srv.Store.Onboarding().GetCompletionsForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewOnboardingProgress(userId, steps, completions), nil
+}
+
+// CompleteOnboardingStep marks a manual step as done by the user. The other steps are completed by the server.
+func (a *App) CompleteOnboardingStep(userId, stepId string) (*model.OnboardingProgress, *model.AppError) {
+	if err := a.checkOnboardingEnabled("CompleteOnboardingStep"); err != nil {
+		return nil, err
+	}
+
+	step, err := a.Srv.Store.Onboarding().GetStep(stepId)
+	if err != nil {
+		return nil, err
+	}
+
+	if step.Type != model.ONBOARDING_STEP_TYPE_MANUAL {
+		return nil, model.NewAppError("CompleteOnboardingStep", "app.onboarding.complete_step.not_manual.app_error", nil, "step_id="+stepId, http.StatusBadRequest)
+	}
+
+	if _, err := a.saveOnboardingStepCompletion(userId, step.Id); err != nil {
+		return nil, err
+	}
+
+	return a.onOnboardingProgress(userId)
+}
+
+// ResetOnboardingProgress clears the steps the user completed so that they go through the checklist again. The
+// rewards of completing it are only ever given once.
+func (a *App) ResetOnboardingProgress(userId string) *model.AppError {
+	if err := a.Srv.Store.Onboarding().PermanentDeleteCompletionsByUser(userId); err != nil {
+		return err
+	}
+
+	a.publishOnboardingProgressUpdated(userId)
+
+	return nil
+}
+
+// completeOnboardingSteps marks the steps of the given type as done by the user, in the background.
+func (a *App) completeOnboardingSteps(userId, stepType, channelId string) {
+	if !*a.Config().ServiceSettings.EnableOnboarding {
+		return
+	}
+
+	a.Srv.Go(func() {
+		steps, err := a.Srv.Store.Onboarding().GetSteps()
+		if err != nil {
+			mlog.Error("Failed to get the onboarding steps", mlog.String("user_id", userId), mlog.Err(err))
+			return
+		}
+
+		completed := false
+		for _, step := range steps {
+			if step.Type != stepType || step.ChannelId != channelId {
+				continue
+			}
+
+			saved, err := a.saveOnboardingStepCompletion(userId, step.Id)
+			if err != nil {
+				mlog.Error("Failed to complete an onboarding step", mlog.String("user_id", userId), mlog.String("step_id", step.Id), mlog.Err(err))
+				continue
+			}
+			completed = completed || saved
+		}
+
+		if completed {
+			if _, err := a.onOnboardingProgress(userId); err != nil {
+				mlog.Error("Failed to update the onboarding progress", mlog.String("user_id", userId), mlog.Err(err))
+			}
+		}
+	})
+}
+
+// saveOnboardingStepCompletion returns whether the step wasn't already completed by the user.
+func (a *App) saveOnboardingStepCompletion(userId, stepId string) (bool, *model.AppError) {
+	completion := &model.OnboardingStepCompletion{UserId: userId, StepId: stepId}
+	if _, err := a.Srv.Store.Onboarding().SaveCompletion(completion); err != nil {
+		if err.Id == "store.sql_onboarding.save_completion.exists.app_error" {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// onOnboardingProgress tells the clients of the user about their progress and rewards them when they completed the
+// checklist.
+func (a *App) onOnboardingProgress(userId string) (*model.OnboardingProgress, *model.AppError) {
+	progress, err := a.GetOnboardingProgress(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	a.publishOnboardingProgressUpdated(userId)
+
+	if progress.IsComplete() {
+		a.rewardOnboardingCompletion(userId)
+	}
+
+	return progress, nil
+}
+
+// rewardOnboardingCompletion sends the completion message and adds the user to the completion channels, unless they
+// were rewarded before.
+func (a *App) rewardOnboardingCompletion(userId string) {
+	if _, err := a.Srv.Store.Preference().Get(userId, model.PREFERENCE_CATEGORY_ONBOARDING, model.PREFERENCE_NAME_ONBOARDING_COMPLETED); err == nil {
+		return
+	}
+
+	preference := model.Preference{
+		UserId:   userId,
+		Category: model.PREFERENCE_CATEGORY_ONBOARDING,
+		Name:     model.PREFERENCE_NAME_ONBOARDING_COMPLETED,
+		Value:    strconv.FormatInt(model.GetMillis(), 10),
+	}
+	if err := a.Srv.Store.Preference().Save(&model.Preferences{preference}); err != nil {
+		mlog.Error("Failed to record the onboarding completion", mlog.String("user_id", userId), mlog.Err(err))
+		return
+	}
+
+	if message := *a.Config().ServiceSettings.OnboardingCompletionMessage; message != "" {
+		if err := a.sendOnboardingCompletionMessage(userId, message); err != nil {
+			mlog.Error("Failed to send the onboarding completion message", mlog.String("user_id", userId), mlog.Err(err))
+		}
+	}
+
+	for _, channelId := range a.Config().ServiceSettings.OnboardingCompletionChannelIds {
+		channel, err := a.GetChannel(channelId)
+		if err != nil {
+			mlog.Error("Failed to get an onboarding completion channel", mlog.String("channel_id", channelId), mlog.Err(err))
+			continue
+		}
+
+		if _, err := a.AddChannelMember(userId, channel, "", ""); err != nil {
+			mlog.Warn("Failed to add a user to an onboarding completion channel", mlog.String("user_id", userId), mlog.String("channel_id", channelId), mlog.Err(err))
+		}
+	}
+}
+
+func (a *App) sendOnboardingCompletionMessage(userId, message string) *model.AppError {
+	bot, err := a.GetSystemBot()
+	if err != nil {
+		return err
+	}
+
+	channel, err := a.GetOrCreateDirectChannel(bot.UserId, userId)
+	if err != nil {
+		return err
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    bot.UserId,
+		Message:   message,
+	}
+
+	_, err = a.CreatePost(post, channel, false)
+	return err
+}
+
+func (a *App) publishOnboardingProgressUpdated(userId string) {
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_ONBOARDING_UPDATED, "", "", userId, nil)
+	a.Publish(message)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestOnboardingProgress(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	existingSteps, err := th.App.GetOnboardingSteps()
+	require.Nil(t, err)
+	for _, step := range existingSteps {
+		require.Nil(t, th.App.DeleteOnboardingStep(step.Id))
+	}
+
+	channel := th.CreateChannel(th.BasicTeam)
+	rewardChannel := th.CreateChannel(th.BasicTeam)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.OnboardingCompletionMessage = "Welcome aboard!"
+		cfg.ServiceSettings.OnboardingCompletionChannelIds = []string{rewardChannel.Id}
+	})
+
+	manualStep, err := th.App.CreateOnboardingStep(&model.OnboardingStep{Type: model.ONBOARDING_STEP_TYPE_MANUAL, DisplayName: "Say hello", SortOrder: 0})
+	require.Nil(t, err)
+	defer th.App.DeleteOnboardingStep(manualStep.Id)
+
+	joinStep, err := th.App.CreateOnboardingStep(&model.OnboardingStep{Type: model.ONBOARDING_STEP_TYPE_JOIN_CHANNEL, DisplayName: "Join", ChannelId: channel.Id, SortOrder: 1})
+	require.Nil(t, err)
+	defer th.App.DeleteOnboardingStep(joinStep.Id)
+
+	avatarStep, err := th.App.CreateOnboardingStep(&model.OnboardingStep{Type: model.ONBOARDING_STEP_TYPE_SET_AVATAR, DisplayName: "Avatar", SortOrder: 2})
+	require.Nil(t, err)
+	defer th.App.DeleteOnboardingStep(avatarStep.Id)
+
+	_, err = th.App.CreateOnboardingStep(&model.OnboardingStep{Type: model.ONBOARDING_STEP_TYPE_JOIN_CHANNEL, DisplayName: "Private", ChannelId: th.CreatePrivateChannel(th.BasicTeam).Id})
+	require.NotNil(t, err)
+	assert.Equal(t, "app.onboarding.step_channel.not_public.app_error", err.Id)
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+
+	waitForCompleted := func(t *testing.T, completed int) *model.OnboardingProgress {
+		t.Helper()
+
+		for i := 0; i < 50; i++ {
+			progress, err := th.App.GetOnboardingProgress(user.Id)
+			require.Nil(t, err)
+			if progress.Completed == completed {
+				return progress
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		require.Fail(t, "onboarding steps weren't completed")
+		return nil
+	}
+
+	progress, err := th.App.GetOnboardingProgress(user.Id)
+	require.Nil(t, err)
+	assert.Equal(t, 3, progress.Total)
+	assert.Equal(t, 0, progress.Completed)
+	assert.Equal(t, manualStep.Id, progress.Steps[0].Step.Id)
+
+	_, err = th.App.CompleteOnboardingStep(user.Id, joinStep.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.onboarding.complete_step.not_manual.app_error", err.Id)
+
+	_, err = th.App.AddUserToChannel(user, channel)
+	require.Nil(t, err)
+	waitForCompleted(t, 1)
+
+	img := image.NewRGBA(image.Rect(0, 0, 128, 128))
+	buf := new(bytes.Buffer)
+	require.Nil(t, png.Encode(buf, img))
+	require.Nil(t, th.App.SetProfileImageFromFile(user.Id, buf))
+	waitForCompleted(t, 2)
+
+	progress, err = th.App.CompleteOnboardingStep(user.Id, manualStep.Id)
+	require.Nil(t, err)
+	assert.True(t, progress.IsComplete())
+
+	t.Run("rewarded", func(t *testing.T) {
+		_, err := th.App.GetChannelMember(rewardChannel.Id, user.Id)
+		require.Nil(t, err, "the user should have been added to the completion channel")
+
+		bot, err := th.App.GetSystemBot()
+		require.Nil(t, err)
+
+		dm, err := th.App.GetOrCreateDirectChannel(bot.UserId, user.Id)
+		require.Nil(t, err)
+
+		posts, err := th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, err)
+		postArray := posts.ToSlice()
+		require.Len(t, postArray, 1)
+		assert.Equal(t, "Welcome aboard!", postArray[0].Message)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		require.Nil(t, th.App.ResetOnboardingProgress(user.Id))
+
+		progress, err := th.App.GetOnboardingProgress(user.Id)
+		require.Nil(t, err)
+		assert.Equal(t, 0, progress.Completed)
+
+		_, err = th.App.CompleteOnboardingStep(user.Id, manualStep.Id)
+		require.Nil(t, err)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOnboarding = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOnboarding = true })
+
+		_, err := th.App.GetOnboardingProgress(user.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.onboarding.disabled.app_error", err.Id)
+	})
+}
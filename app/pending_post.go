@@ -0,0 +1,166 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+// IsChannelModerator reports whether the user moderates the channel, which the channel admins and the team and
+// system admins above them do.
+func (a *App) IsChannelModerator(userId string, channel *model.Channel) bool {
+	return a.HasPermissionToChannel(userId, channel.Id, model.PERMISSION_MANAGE_CHANNEL_ROLES)
+}
+
+func (a *App) shouldHoldPost(post *model.Post, channel *model.Channel, user *model.User) bool {
+	return channel.ModerationEnabled && !post.IsSystemMessage() && !a.IsChannelModerator(user.Id, channel)
+}
+
+// holdPost keeps a post made to a moderated channel for its moderators to review, instead of creating it, and
+// returns the held post to its author.
+func (a *App) holdPost(post *model.Post, channel *model.Channel, user *model.User) (*model.Post, *model.AppError) {
+	pendingPost, err := a.Srv.Store.PendingPost().Save(model.NewPendingPostFromPost(post))
+	if err != nil {
+		return nil, err
+	}
+
+	T := utils.GetUserTranslations(user.Locale)
+	a.SendEphemeralPost(user.Id, &model.Post{
+		ChannelId: channel.Id,
+		ParentId:  post.ParentId,
+		RootId:    post.RootId,
+		UserId:    user.Id,
+		Message:   T("app.pending_post.held.message"),
+		CreateAt:  model.GetMillis() + 1,
+	})
+
+	a.Srv.Go(func() {
+		a.notifyModeratorsOfPendingPost(pendingPost, channel, user)
+	})
+
+	post.Id = pendingPost.Id
+	post.CreateAt = pendingPost.CreateAt
+	post.UpdateAt = pendingPost.CreateAt
+	post.AddProp(model.POST_PROPS_PENDING_APPROVAL, true)
+
+	return post, nil
+}
+
+// notifyModeratorsOfPendingPost shows the channel admins an ephemeral post telling them how to review a post held
+// in their channel.
+func (a *App) notifyModeratorsOfPendingPost(pendingPost *model.PendingPost, channel *model.Channel, author *model.User) {
+	members, err := a.Srv.Store.Channel().GetMembersWithOptions(channel.Id, 0, model.PENDING_POSTS_NOTIFY_MODERATORS_MAX, &model.ChannelMembersGetOptions{Role: model.CHANNEL_MEMBER_ROLE_FILTER_ADMIN})
+	if err != nil {
+		mlog.Error("Unable to get the moderators of a channel to notify them of a pending post", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		return
+	}
+
+	for _, member := range *members {
+		if member.UserId == author.Id {
+			continue
+		}
+
+		moderator, err := a.Srv.Store.User().Get(member.UserId)
+		if err != nil {
+			mlog.Warn("Unable to notify a moderator of a pending post", mlog.String("user_id", member.UserId), mlog.Err(err))
+			continue
+		}
+
+		T := utils.GetUserTranslations(moderator.Locale)
+		a.SendEphemeralPost(moderator.Id, &model.Post{
+			ChannelId: channel.Id,
+			UserId:    moderator.Id,
+			Message: T("app.pending_post.review.message", map[string]interface{}{
+				"Username":      author.Username,
+				"Message":       pendingPost.Message,
+				"PendingPostId": pendingPost.Id,
+			}),
+			CreateAt: model.GetMillis() + 1,
+		})
+	}
+}
+
+func (a *App) GetPendingPost(pendingPostId string) (*model.PendingPost, *model.AppError) {
+	return a.Srv.Store.PendingPost().Get(pendingPostId)
+}
+
+func (a *App) GetPendingPostsForChannel(channelId string, page, perPage int) ([]*model.PendingPost, *model.AppError) {
+	return a.Srv.Store.PendingPost().GetForChannel(channelId, page*perPage, perPage)
+}
+
+// ApprovePendingPost creates a held post on behalf of its author, as if it had just been posted.
+func (a *App) ApprovePendingPost(pendingPostId string) (*model.Post, *model.AppError) {
+	pendingPost, channel, err := a.takePendingPost(pendingPostId)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := a.createPost(pendingPost.ToPost(), channel, true, false)
+	if err != nil {
+		// Keep the post for the moderators to try again, rather than losing it.
+		if _, saveErr := a.Srv.Store.PendingPost().Save(pendingPost); saveErr != nil {
+			mlog.Error("Unable to restore a pending post which couldn't be approved", mlog.String("pending_post_id", pendingPost.Id), mlog.Err(saveErr))
+		}
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// RejectPendingPost discards a held post, letting its author know and showing them what they posted.
+func (a *App) RejectPendingPost(pendingPostId string) *model.AppError {
+	pendingPost, channel, err := a.takePendingPost(pendingPostId)
+	if err != nil {
+		return err
+	}
+
+	author, err := a.Srv.Store.User().Get(pendingPost.UserId)
+	if err != nil {
+		mlog.Warn("Unable to notify the author of a rejected post", mlog.String("user_id", pendingPost.UserId), mlog.Err(err))
+		return nil
+	}
+
+	T := utils.GetUserTranslations(author.Locale)
+	a.SendEphemeralPost(author.Id, &model.Post{
+		ChannelId: channel.Id,
+		ParentId:  pendingPost.ParentId,
+		RootId:    pendingPost.RootId,
+		UserId:    author.Id,
+		Message:   T("app.pending_post.rejected.message", map[string]interface{}{"Message": pendingPost.Message}),
+		CreateAt:  model.GetMillis() + 1,
+	})
+
+	return nil
+}
+
+// takePendingPost removes a pending post which is being reviewed, failing if another moderator already reviewed it.
+func (a *App) takePendingPost(pendingPostId string) (*model.PendingPost, *model.Channel, *model.AppError) {
+	pendingPost, err := a.Srv.Store.PendingPost().Get(pendingPostId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	channel, err := a.GetChannel(pendingPost.ChannelId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if channel.DeleteAt != 0 {
+		return nil, nil, model.NewAppError("takePendingPost", "api.post.create_post.can_not_post_to_deleted.error", nil, "", http.StatusBadRequest)
+	}
+
+	deleted, err := a.Srv.Store.PendingPost().Delete(pendingPost.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !deleted {
+		return nil, nil, model.NewAppError("takePendingPost", "app.pending_post.already_reviewed.app_error", nil, "pending_post_id="+pendingPost.Id, http.StatusNotFound)
+	}
+
+	return pendingPost, channel, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCreatePostInModeratedChannel(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.BasicChannel
+	th.AddUserToChannel(th.BasicUser2, channel)
+
+	channel.ModerationEnabled = true
+	channel, err := th.App.UpdateChannel(channel)
+	require.Nil(t, err)
+
+	holdPost := func() *model.Post {
+		t.Helper()
+
+		post, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser2.Id,
+			ChannelId: channel.Id,
+			Message:   "awaiting approval",
+		}, channel, false)
+		require.Nil(t, err)
+		require.True(t, post.IsPendingApproval())
+
+		return post
+	}
+
+	t.Run("moderators post directly", func(t *testing.T) {
+		post, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: channel.Id,
+			Message:   "from a moderator",
+		}, channel, false)
+		require.Nil(t, err)
+		assert.False(t, post.IsPendingApproval())
+
+		_, err = th.App.GetSinglePost(post.Id)
+		require.Nil(t, err)
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		held := holdPost()
+
+		_, err := th.App.GetSinglePost(held.Id)
+		require.NotNil(t, err, "a held post shouldn't be created")
+
+		pendingPosts, err := th.App.GetPendingPostsForChannel(channel.Id, 0, 10)
+		require.Nil(t, err)
+		require.Len(t, pendingPosts, 1)
+		assert.Equal(t, held.Id, pendingPosts[0].Id)
+
+		post, err := th.App.ApprovePendingPost(held.Id)
+		require.Nil(t, err)
+		assert.Equal(t, th.BasicUser2.Id, post.UserId)
+		assert.Equal(t, "awaiting approval", post.Message)
+		assert.False(t, post.IsPendingApproval())
+
+		_, err = th.App.GetSinglePost(post.Id)
+		require.Nil(t, err)
+
+		_, err = th.App.ApprovePendingPost(held.Id)
+		require.NotNil(t, err, "a pending post should be reviewed only once")
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		held := holdPost()
+
+		require.Nil(t, th.App.RejectPendingPost(held.Id))
+
+		pendingPosts, err := th.App.GetPendingPostsForChannel(channel.Id, 0, 10)
+		require.Nil(t, err)
+		assert.Empty(t, pendingPosts)
+
+		_, err = th.App.GetSinglePost(held.Id)
+		require.NotNil(t, err)
+	})
+
+	t.Run("moderate command", func(t *testing.T) {
+		held := holdPost()
+
+		args := &model.CommandArgs{T: func(id string, args ...interface{}) string { return id }, UserId: th.BasicUser2.Id, ChannelId: channel.Id}
+		response := (&ModerateProvider{}).DoCommand(th.App, args, "approve "+held.Id)
+		assert.Equal(t, "api.command_moderate.permission.app_error", response.Text)
+
+		args.UserId = th.BasicUser.Id
+		response = (&ModerateProvider{}).DoCommand(th.App, args, "approve "+held.Id)
+		assert.Equal(t, "api.command_moderate.approved", response.Text)
+	})
+}
@@ -104,6 +104,10 @@ func (api *PluginAPI) SavePluginConfig(pluginConfig map[string]interface{}) *mod
 	return api.app.SaveConfig(cfg, true)
 }
 
+func (api *PluginAPI) IsFeatureEnabled(name, userId string) bool {
+	return api.app.IsFeatureEnabled(name, userId)
+}
+
 func (api *PluginAPI) GetBundlePath() (string, error) {
 	bundlePath, err := filepath.Abs(filepath.Join(*api.GetConfig().PluginSettings.Directory, api.manifest.Id))
 	if err != nil {
@@ -268,6 +272,45 @@ func (api *PluginAPI) UpdateUserStatus(userId, status string) (*model.Status, *m
 	return api.app.GetStatus(userId)
 }
 
+func (api *PluginAPI) GetUserAvailability(userId string, startAt, endAt int64) ([]*model.UserAvailability, *model.AppError) {
+	return api.app.GetUserAvailabilityForUser(userId, startAt, endAt)
+}
+
+func (api *PluginAPI) CreateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	availability.Source = api.id
+	return api.app.CreateUserAvailability(availability)
+}
+
+func (api *PluginAPI) UpdateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	if err := api.checkUserAvailabilitySource(availability.Id); err != nil {
+		return nil, err
+	}
+
+	return api.app.UpdateUserAvailability(availability)
+}
+
+func (api *PluginAPI) DeleteUserAvailability(availabilityId string) *model.AppError {
+	if err := api.checkUserAvailabilitySource(availabilityId); err != nil {
+		return err
+	}
+
+	return api.app.DeleteUserAvailability(availabilityId)
+}
+
+// checkUserAvailabilitySource makes sure plugins only change the availability they've created themselves.
+func (api *PluginAPI) checkUserAvailabilitySource(availabilityId string) *model.AppError {
+	availability, err := api.app.GetUserAvailability(availabilityId)
+	if err != nil {
+		return err
+	}
+
+	if availability.Source != api.id {
+		return model.NewAppError("checkUserAvailabilitySource", "plugin.api.user_availability.source.app_error", nil, "id="+availabilityId, http.StatusForbidden)
+	}
+
+	return nil
+}
+
 func (api *PluginAPI) GetUsersInChannel(channelId, sortBy string, page, perPage int) ([]*model.User, *model.AppError) {
 	switch sortBy {
 	case model.CHANNEL_SORT_BY_USERNAME:
@@ -460,6 +503,10 @@ func (api *PluginAPI) DeleteEphemeralPost(userId, postId string) {
 	api.app.DeleteEphemeralPost(userId, postId)
 }
 
+func (api *PluginAPI) StreamCommandResponse(args *model.CommandArgs, response *model.CommandResponse) *model.AppError {
+	return api.app.StreamCommandResponse(args, response)
+}
+
 func (api *PluginAPI) DeletePost(postId string) *model.AppError {
 	_, err := api.app.DeletePost(postId, api.id)
 	return err
@@ -493,6 +540,77 @@ func (api *PluginAPI) UpdatePost(post *model.Post) (*model.Post, *model.AppError
 	return api.app.UpdatePost(post, false)
 }
 
+func (api *PluginAPI) StartCall(call *model.Call) (*model.Call, *model.AppError) {
+	call.Id = ""
+	call.Provider = api.id
+	return api.app.StartCall(call)
+}
+
+func (api *PluginAPI) GetCall(callId string) (*model.Call, *model.AppError) {
+	return api.app.GetCall(callId)
+}
+
+func (api *PluginAPI) JoinCall(callId, userId string) (*model.Call, *model.AppError) {
+	call, err := api.getProvidedCall(callId)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.app.JoinCall(call, userId)
+}
+
+func (api *PluginAPI) LeaveCall(callId, userId string) (*model.Call, *model.AppError) {
+	call, err := api.getProvidedCall(callId)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.app.LeaveCall(call, userId)
+}
+
+func (api *PluginAPI) EndCall(callId string) (*model.Call, *model.AppError) {
+	call, err := api.getProvidedCall(callId)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.app.EndCall(call)
+}
+
+func (api *PluginAPI) SendWebrtcSignal(signal *model.WebrtcSignal) *model.AppError {
+	signal.UserId = ""
+	signal.PluginId = api.id
+	signal.ToPluginId = ""
+
+	return api.app.RelayWebrtcSignal(signal)
+}
+
+func (api *PluginAPI) GetWebrtcRoomUserIds(channelId string) []string {
+	return api.app.GetWebrtcRoomUserIds(channelId)
+}
+
+func (api *PluginAPI) BroadcastDirectMessage(botUserId string, userIds []string, teamId string, post *model.Post) (*model.DirectMessageBroadcast, *model.AppError) {
+	return api.app.BroadcastDirectMessage(botUserId, userIds, teamId, post)
+}
+
+func (api *PluginAPI) GetDirectMessageBroadcast(broadcastId string) (*model.DirectMessageBroadcast, *model.AppError) {
+	return api.app.GetDirectMessageBroadcast(broadcastId)
+}
+
+// getProvidedCall returns a call if it was started by the plugin, since plugins only change their own calls.
+func (api *PluginAPI) getProvidedCall(callId string) (*model.Call, *model.AppError) {
+	call, err := api.app.GetCall(callId)
+	if err != nil {
+		return nil, err
+	}
+
+	if call.Provider != api.id {
+		return nil, model.NewAppError("getProvidedCall", "plugin.api.call.provider.app_error", nil, "id="+callId, http.StatusForbidden)
+	}
+
+	return call, nil
+}
+
 func (api *PluginAPI) GetProfileImage(userId string) ([]byte, *model.AppError) {
 	user, err := api.app.GetUser(userId)
 	if err != nil {
@@ -532,6 +650,10 @@ func (api *PluginAPI) GetFileInfo(fileId string) (*model.FileInfo, *model.AppErr
 	return api.app.GetFileInfo(fileId)
 }
 
+func (api *PluginAPI) SetFileInfoVerdict(fileId, verdict, reason string) (*model.FileInfo, *model.AppError) {
+	return api.app.SetFileInfoVerdict(fileId, verdict, reason)
+}
+
 func (api *PluginAPI) GetFileLink(fileId string) (string, *model.AppError) {
 	if !*api.app.Config().FileSettings.EnablePublicLink {
 		return "", model.NewAppError("GetFileLink", "plugin_api.get_file_link.disabled.app_error", nil, "", http.StatusNotImplemented)
@@ -90,22 +90,25 @@ func (a *App) PluginCommandsForTeam(teamId string) []*model.Command {
 	return commands
 }
 
-// tryExecutePluginCommand attempts to run a command provided by a plugin based on the given arguments. If no such
-// command can be found, returns nil for all arguments.
-func (a *App) tryExecutePluginCommand(args *model.CommandArgs) (*model.Command, *model.CommandResponse, *model.AppError) {
-	parts := strings.Split(args.Command, " ")
-	trigger := parts[0][1:]
-	trigger = strings.ToLower(trigger)
-
-	var matched *PluginCommand
+// findPluginCommand returns the command registered by a plugin for a team with the given trigger, or nil if there's
+// none.
+func (a *App) findPluginCommand(teamId, trigger string) *PluginCommand {
 	a.Srv.pluginCommandsLock.RLock()
+	defer a.Srv.pluginCommandsLock.RUnlock()
+
 	for _, pc := range a.Srv.pluginCommands {
-		if (pc.Command.TeamId == "" || pc.Command.TeamId == args.TeamId) && pc.Command.Trigger == trigger {
-			matched = pc
-			break
+		if (pc.Command.TeamId == "" || pc.Command.TeamId == teamId) && pc.Command.Trigger == trigger {
+			return pc
 		}
 	}
-	a.Srv.pluginCommandsLock.RUnlock()
+
+	return nil
+}
+
+// tryExecutePluginCommand attempts to run a command provided by a plugin based on the given arguments. If no such
+// command can be found, returns nil for all arguments.
+func (a *App) tryExecutePluginCommand(args *model.CommandArgs) (*model.Command, *model.CommandResponse, *model.AppError) {
+	matched := a.findPluginCommand(args.TeamId, getCommandTrigger(args.Command))
 	if matched == nil {
 		return nil, nil, nil
 	}
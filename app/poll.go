@@ -0,0 +1,118 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	goi18n "github.com/mattermost/go-i18n/i18n"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// CreatePoll saves a poll and posts its question and options to its channel as its creator. The post is written in the
+// language of the given translation function.
+func (a *App) CreatePoll(poll *model.Poll, T goi18n.TranslateFunc) (*model.Poll, *model.AppError) {
+	for i, option := range poll.Options {
+		poll.Options[i] = strings.TrimSpace(option)
+	}
+	poll.Question = strings.TrimSpace(poll.Question)
+
+	poll, err := a.Srv.Store.Poll().Save(poll)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []string{T("app.poll.post.question", map[string]interface{}{"Question": poll.Question})}
+	for i, option := range poll.Options {
+		lines = append(lines, T("app.poll.post.option", map[string]interface{}{"Number": i + 1, "Option": option}))
+	}
+	lines = append(lines, T("app.poll.post.instructions", map[string]interface{}{"PollId": poll.Id}))
+
+	post, err := a.CreatePostMissingChannel(&model.Post{
+		UserId:    poll.CreatorId,
+		ChannelId: poll.ChannelId,
+		Message:   strings.Join(lines, "\n"),
+		Props:     model.StringInterface{model.POST_PROPS_POLL_ID: poll.Id},
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	poll.PostId = post.Id
+	return a.Srv.Store.Poll().Update(poll)
+}
+
+func (a *App) GetPoll(pollId string) (*model.Poll, *model.AppError) {
+	return a.Srv.Store.Poll().Get(pollId)
+}
+
+// VotePoll records the option of an open poll a user voted for, given its index, replacing their previous vote.
+func (a *App) VotePoll(poll *model.Poll, userId string, option int) *model.AppError {
+	if poll.IsClosed() {
+		return model.NewAppError("VotePoll", "app.poll.closed.app_error", nil, "id="+poll.Id, http.StatusBadRequest)
+	}
+
+	if option < 0 || option >= len(poll.Options) {
+		return model.NewAppError("VotePoll", "app.poll.invalid_option.app_error", map[string]interface{}{"Max": len(poll.Options)}, "id="+poll.Id, http.StatusBadRequest)
+	}
+
+	_, err := a.Srv.Store.Poll().SaveVote(&model.PollVote{PollId: poll.Id, UserId: userId, Option: option})
+	return err
+}
+
+// GetPollResults returns the number of votes for each option of a poll.
+func (a *App) GetPollResults(poll *model.Poll) ([]int, *model.AppError) {
+	votes, err := a.Srv.Store.Poll().GetVotes(poll.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.CountPollVotes(poll, votes), nil
+}
+
+// ClosePoll stops a poll from taking votes and replies to its post with the final results, in the language of the
+// given translation function. Only the creator of a poll can close it.
+func (a *App) ClosePoll(poll *model.Poll, userId string, T goi18n.TranslateFunc) (*model.Poll, *model.AppError) {
+	if poll.CreatorId != userId {
+		return nil, model.NewAppError("ClosePoll", "app.poll.close.not_creator.app_error", nil, "id="+poll.Id, http.StatusForbidden)
+	}
+
+	if poll.IsClosed() {
+		return poll, nil
+	}
+
+	poll.CloseAt = model.GetMillis()
+	poll, err := a.Srv.Store.Poll().Update(poll)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := a.GetPollResults(poll)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.CreatePostMissingChannel(&model.Post{
+		UserId:    poll.CreatorId,
+		ChannelId: poll.ChannelId,
+		RootId:    poll.PostId,
+		ParentId:  poll.PostId,
+		Message:   a.pollResultsMessage(poll, results, T),
+		Props:     model.StringInterface{model.POST_PROPS_POLL_ID: poll.Id},
+	}, true); err != nil {
+		return nil, err
+	}
+
+	return poll, nil
+}
+
+func (a *App) pollResultsMessage(poll *model.Poll, results []int, T goi18n.TranslateFunc) string {
+	lines := []string{T("app.poll.results.header", map[string]interface{}{"Question": poll.Question})}
+	for i, option := range poll.Options {
+		lines = append(lines, T("app.poll.results.option", map[string]interface{}{"Option": option, "Votes": results[i]}))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -127,7 +127,8 @@ func (a *App) deduplicateCreatePost(post *model.Post) (foundPost *model.Post, er
 
 	// If the other thread finished creating the post, return the created post back to the
 	// client, making the API call feel idempotent.
-	actualPost, err := a.GetSinglePost(postId)
+	// Read it from the master as the replicas may not have it yet.
+	actualPost, err := a.Srv.Store.Post().GetSingleFromMaster(postId)
 	if err != nil {
 		return nil, model.NewAppError("deduplicateCreatePost", "api.post.deduplicate_create_post.failed_to_get", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -137,7 +138,31 @@ func (a *App) deduplicateCreatePost(post *model.Post) (foundPost *model.Post, er
 	return actualPost, nil
 }
 
-func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhooks bool) (savedPost *model.Post, err *model.AppError) {
+// checkChannelIntegrationPolicy rejects posts made by an incoming webhook or a bot account which the channel doesn't
+// allow to post there. Both are checked, since a bot may post through a webhook it created.
+func (a *App) checkChannelIntegrationPolicy(post *model.Post, channel *model.Channel, user *model.User) *model.AppError {
+	if !channel.IntegrationsRestricted {
+		return nil
+	}
+
+	if hookId, ok := post.Props[model.POST_PROPS_WEBHOOK_ID].(string); ok && post.Props["from_webhook"] == "true" && !channel.IsWebhookAllowed(hookId) {
+		return model.NewAppError("createPost", "api.post.create_post.webhook_not_allowed.app_error", map[string]interface{}{"ChannelName": channel.Name}, "hook_id="+hookId+", channel_id="+channel.Id, http.StatusForbidden)
+	}
+
+	if user.IsBot && !channel.IsBotAllowed(user.Id) {
+		return model.NewAppError("createPost", "api.post.create_post.bot_not_allowed.app_error", map[string]interface{}{"ChannelName": channel.Name}, "bot_user_id="+user.Id+", channel_id="+channel.Id, http.StatusForbidden)
+	}
+
+	return nil
+}
+
+func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhooks bool) (*model.Post, *model.AppError) {
+	return a.createPost(post, channel, triggerWebhooks, true)
+}
+
+// createPost creates a post, unless moderate is set and the channel holds the post for its moderators to review,
+// in which case the held post is returned instead.
+func (a *App) createPost(post *model.Post, channel *model.Channel, triggerWebhooks, moderate bool) (savedPost *model.Post, err *model.AppError) {
 	foundPost, err := a.deduplicateCreatePost(post)
 	if err != nil {
 		return nil, err
@@ -182,6 +207,14 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 		post.AddProp("from_bot", "true")
 	}
 
+	if err := a.checkChannelIntegrationPolicy(post, channel, user); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkBlockedDirectMessage(post, channel); err != nil {
+		return nil, err
+	}
+
 	if a.License() != nil && *a.Config().TeamSettings.ExperimentalTownSquareIsReadOnly &&
 		!post.IsSystemMessage() &&
 		channel.Name == model.DEFAULT_CHANNEL &&
@@ -216,9 +249,22 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 				return nil, model.NewAppError("createPost", "api.post.create_post.parent_id.app_error", nil, "", http.StatusInternalServerError)
 			}
 		}
+
+		// Enforce the reply broadcast policy of the channel on the choice made by the client, if any.
+		if requested := post.GetAddToChannel(); requested != nil || channel.ReplyBroadcastPolicy != "" {
+			post.AddProp(model.POST_PROPS_ADD_TO_CHANNEL, channel.ShouldBroadcastReply(requested))
+		}
 	}
 
-	post.Hashtags, _ = model.ParseHashtags(post.Message)
+	if err := a.applyContentFilters(post, channel); err != nil {
+		return nil, err
+	}
+
+	if moderate && a.shouldHoldPost(post, channel, user) {
+		return a.holdPost(post, channel, user)
+	}
+
+	parsePostHashtags(post, channel)
 
 	if err = a.FillInPostProps(post, channel); err != nil {
 		return nil, err
@@ -267,6 +313,10 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 		return nil, err
 	}
 
+	if user.IsBot {
+		a.recordIntegrationPost(model.INTEGRATION_TYPE_BOT, user.Id)
+	}
+
 	// Update the mapping from pending post id to the actual post id, for any clients that
 	// might be duplicating requests.
 	a.Srv.seenPendingPostIdsCache.AddWithExpiresInSecs(post.PendingPostId, rpost.Id, int64(PENDING_POST_IDS_CACHE_TTL.Seconds()))
@@ -293,6 +343,10 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 		a.Metrics.IncrementPostCreate()
 	}
 
+	if len(rpost.Hashtags) > 0 {
+		a.indexPostHashtags(rpost, channel)
+	}
+
 	if len(post.FileIds) > 0 {
 		if err = a.attachFilesToPost(post); err != nil {
 			mlog.Error("Encountered error attaching files to post", mlog.String("post_id", post.Id), mlog.Any("file_ids", post.FileIds), mlog.Err(err))
@@ -355,15 +409,49 @@ func (a *App) FillInPostProps(post *model.Post, channel *model.Channel) *model.A
 			channel = postChannel
 		}
 
-		mentionedChannels, err := a.GetChannelsByNames(channelMentions, channel.TeamId)
-		if err != nil {
-			return err
+		crossTeam := *a.Config().TeamSettings.EnableCrossTeamChannelMentions && post.UserId != ""
+
+		// Direct and group messages don't belong to a team, so the channels they mention are all from other teams
+		resolved := map[string]bool{}
+		if channel.TeamId != "" || !crossTeam {
+			mentionedChannels, err := a.GetChannelsByNames(channelMentions, channel.TeamId)
+			if err != nil {
+				return err
+			}
+
+			for _, mentioned := range mentionedChannels {
+				resolved[mentioned.Name] = true
+				if mentioned.Type == model.CHANNEL_OPEN {
+					channelMentionsProp[mentioned.Name] = map[string]interface{}{
+						"display_name": mentioned.DisplayName,
+					}
+				}
+			}
 		}
 
-		for _, mentioned := range mentionedChannels {
-			if mentioned.Type == model.CHANNEL_OPEN {
+		if crossTeam {
+			var unresolved []string
+			for _, name := range channelMentions {
+				if !resolved[name] {
+					unresolved = append(unresolved, name)
+				}
+			}
+
+			mentionedChannels, err := a.getCrossTeamMentionedChannels(post.UserId, channel.TeamId, unresolved)
+			if err != nil {
+				return err
+			}
+
+			for _, mentioned := range mentionedChannels {
+				team, err := a.GetTeam(mentioned.TeamId)
+				if err != nil {
+					return err
+				}
+
 				channelMentionsProp[mentioned.Name] = map[string]interface{}{
 					"display_name": mentioned.DisplayName,
+					"team_id":      team.Id,
+					"team_name":    team.Name,
 				}
 			}
 		}
@@ -378,6 +466,58 @@ func (a *App) FillInPostProps(post *model.Post, channel *model.Channel) *model.A
 	return nil
 }
 
+// getCrossTeamMentionedChannels returns the public channels with the given names in the teams the user is a member of,
+// other than the excluded one. Names of channels in more than one of these teams are left out, since it can't be told
+// which of them is meant.
+func (a *App) getCrossTeamMentionedChannels(userId, excludedTeamId string, names []string) ([]*model.Channel, *model.AppError) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	members, err := a.Srv.Store.Team().GetTeamsForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	teamIds := make(map[string]bool, len(members))
+	for _, member := range members {
+		if member.DeleteAt == 0 && member.TeamId != excludedTeamId {
+			teamIds[member.TeamId] = true
+		}
+	}
+
+	if len(teamIds) == 0 {
+		return nil, nil
+	}
+
+	channels, err := a.Srv.Store.Channel().GetByNames("", names, false)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*model.Channel{}
+	ambiguous := map[string]bool{}
+	for _, channel := range channels {
+		if channel.Type != model.CHANNEL_OPEN || !teamIds[channel.TeamId] {
+			continue
+		}
+
+		if _, ok := byName[channel.Name]; ok {
+			ambiguous[channel.Name] = true
+		}
+		byName[channel.Name] = channel
+	}
+
+	mentioned := make([]*model.Channel, 0, len(byName))
+	for name, channel := range byName {
+		if !ambiguous[name] {
+			mentioned = append(mentioned, channel)
+		}
+	}
+
+	return mentioned, nil
+}
+
 func (a *App) handlePostEvents(post *model.Post, user *model.User, channel *model.Channel, triggerWebhooks bool, parentPostList *model.PostList) error {
 	var team *model.Team
 	if len(channel.TeamId) > 0 {
@@ -394,17 +534,22 @@ func (a *App) handlePostEvents(post *model.Post, user *model.User, channel *mode
 	a.InvalidateCacheForChannel(channel)
 	a.InvalidateCacheForChannelPosts(channel.Id)
 
-	if _, err := a.SendNotifications(post, team, channel, user, parentPostList); err != nil {
+	mentionedUserIds, err := a.SendNotifications(post, team, channel, user, parentPostList)
+	if err != nil {
 		return err
 	}
 
 	a.Srv.Go(func() {
-		_, err := a.SendAutoResponseIfNecessary(channel, user)
+		_, err := a.SendAutoResponseIfNecessary(channel, user, post, mentionedUserIds)
 		if err != nil {
 			mlog.Error("Failed to send auto response", mlog.String("user_id", user.Id), mlog.String("post_id", post.Id), mlog.Err(err))
 		}
 	})
 
+	a.Srv.Go(func() {
+		a.applyKeywordRules(post, team, channel, user)
+	})
+
 	if triggerWebhooks {
 		a.Srv.Go(func() {
 			if err := a.handleWebhookEvents(post, team, channel, user); err != nil {
@@ -431,6 +576,8 @@ func (a *App) SendEphemeralPost(userId string, post *model.Post) *model.Post {
 	}
 
 	post.GenerateActionIds()
+	a.saveEphemeralPost(userId, post)
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_EPHEMERAL_MESSAGE, "", post.ChannelId, userId, nil)
 	post = a.PreparePostForClient(post, true, false)
 	post = model.AddPostActionCookies(post, a.PostActionCookieSecret())
@@ -449,6 +596,8 @@ func (a *App) UpdateEphemeralPost(userId string, post *model.Post) *model.Post {
 	}
 
 	post.GenerateActionIds()
+	a.updateEphemeralPost(userId, post)
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POST_EDITED, "", post.ChannelId, userId, nil)
 	post = a.PreparePostForClient(post, true, false)
 	post = model.AddPostActionCookies(post, a.PostActionCookieSecret())
@@ -467,6 +616,8 @@ func (a *App) DeleteEphemeralPost(userId, postId string) {
 		UpdateAt: model.GetMillis(),
 	}
 
+	a.deleteEphemeralPost(userId, postId)
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POST_DELETED, "", "", userId, nil)
 	message.Add("post", post.ToJson())
 	a.Publish(message)
@@ -515,10 +666,10 @@ func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model
 	newPost := &model.Post{}
 	*newPost = *oldPost
 
-	if newPost.Message != post.Message {
+	messageChanged := newPost.Message != post.Message
+	if messageChanged {
 		newPost.Message = post.Message
 		newPost.EditAt = model.GetMillis()
-		newPost.Hashtags, _ = model.ParseHashtags(post.Message)
 	}
 
 	if !safeUpdate {
@@ -528,6 +679,13 @@ func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model
 		newPost.Props = post.Props
 	}
 
+	if messageChanged {
+		if err = a.applyContentFilters(newPost, channel); err != nil {
+			return nil, err
+		}
+		parsePostHashtags(newPost, channel)
+	}
+
 	// Avoid deep-equal checks if EditAt was already modified through message change
 	if newPost.EditAt == oldPost.EditAt && (!oldPost.FileIds.Equals(newPost.FileIds) || !oldPost.AttachmentsEqual(newPost)) {
 		newPost.EditAt = model.GetMillis()
@@ -554,6 +712,10 @@ func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model
 		return nil, err
 	}
 
+	if rpost.Hashtags != oldPost.Hashtags {
+		a.indexPostHashtags(rpost, channel)
+	}
+
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		a.Srv.Go(func() {
 			pluginContext := a.PluginContext()
@@ -614,6 +776,17 @@ func (a *App) PatchPost(postId string, patch *model.PostPatch) (*model.Post, *mo
 	return updatedPost, nil
 }
 
+// RecordPostPinEvent records that a user pinned or unpinned a post, for the timeline of its channel.
+func (a *App) RecordPostPinEvent(post *model.Post, userId string, pinned bool) *model.AppError {
+	_, err := a.Srv.Store.PostPinEvent().Save(&model.PostPinEvent{
+		PostId:    post.Id,
+		ChannelId: post.ChannelId,
+		UserId:    userId,
+		Pinned:    pinned,
+	})
+	return err
+}
+
 func (a *App) GetPostsPage(channelId string, page int, perPage int) (*model.PostList, *model.AppError) {
 	return a.Srv.Store.Post().GetPosts(channelId, page*perPage, perPage, true)
 }
@@ -634,6 +807,27 @@ func (a *App) GetSinglePost(postId string) (*model.Post, *model.AppError) {
 	return a.Srv.Store.Post().GetSingle(postId)
 }
 
+// GetPostsByIds returns the posts with the given ids that haven't been deleted, sorted from newest to oldest.
+func (a *App) GetPostsByIds(postIds []string) ([]*model.Post, *model.AppError) {
+	if len(postIds) == 0 {
+		return []*model.Post{}, nil
+	}
+
+	posts, err := a.Srv.Store.Post().GetPostsByIds(postIds)
+	if err != nil {
+		return nil, err
+	}
+
+	undeleted := []*model.Post{}
+	for _, post := range posts {
+		if post.DeleteAt == 0 {
+			undeleted = append(undeleted, post)
+		}
+	}
+
+	return undeleted, nil
+}
+
 func (a *App) GetPostThread(postId string) (*model.PostList, *model.AppError) {
 	return a.Srv.Store.Post().Get(postId)
 }
@@ -650,6 +844,8 @@ func (a *App) GetFlaggedPostsForChannel(userId, channelId string, offset int, li
 	return a.Srv.Store.Post().GetFlaggedPostsForChannel(userId, channelId, offset, limit)
 }
 
+// GetPermalinkPost returns the thread of a post for a permalink, joining the user to the channel of the post when it's a
+// public channel they aren't a member of. Use GetPermalinkPreview to resolve a permalink without joining.
 func (a *App) GetPermalinkPost(postId string, userId string) (*model.PostList, *model.AppError) {
 	list, err := a.Srv.Store.Post().Get(postId)
 	if err != nil {
@@ -666,13 +862,69 @@ func (a *App) GetPermalinkPost(postId string, userId string) (*model.PostList, *
 		return nil, err
 	}
 
-	if err = a.JoinChannel(channel, userId); err != nil {
-		return nil, err
+	switch a.permalinkAccess(channel, userId) {
+	case model.PERMALINK_ACCESS_NONE:
+		return nil, model.NewAppError("GetPermalinkPost", "app.post.permalink.permissions.app_error", nil, "post_id="+postId, http.StatusForbidden)
+	case model.PERMALINK_ACCESS_PUBLIC:
+		if err = a.JoinChannel(channel, userId); err != nil {
+			return nil, err
+		}
 	}
 
 	return list, nil
 }
 
+// GetPermalinkPreview resolves a permalink for a user without joining them to the channel of the post. The post is
+// redacted if the user can't read the channel. With withThread, the preview has the whole thread of the post, so that a
+// permalink to a reply can be shown within its thread.
+func (a *App) GetPermalinkPreview(postId string, userId string, withThread bool) (*model.PermalinkPreview, *model.AppError) {
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &model.PermalinkPreview{
+		PostId: post.Id,
+		Access: a.permalinkAccess(channel, userId),
+	}
+	if preview.IsRedacted() {
+		return preview, nil
+	}
+
+	preview.RootId = post.RootId
+	preview.ChannelId = channel.Id
+	preview.TeamId = channel.TeamId
+	preview.Post = a.PreparePostForClient(post, false, false)
+
+	if withThread {
+		thread, err := a.GetPostThread(preview.ThreadRootId())
+		if err != nil {
+			return nil, err
+		}
+		preview.Thread = a.PreparePostListForClient(thread)
+	}
+
+	return preview, nil
+}
+
+// permalinkAccess returns how a user can read a channel that a permalink points to.
+func (a *App) permalinkAccess(channel *model.Channel, userId string) string {
+	if a.HasPermissionToChannel(userId, channel.Id, model.PERMISSION_READ_CHANNEL) {
+		return model.PERMALINK_ACCESS_CHANNEL
+	}
+
+	if channel.Type == model.CHANNEL_OPEN && channel.DeleteAt == 0 && a.HasPermissionToTeam(userId, channel.TeamId, model.PERMISSION_READ_PUBLIC_CHANNEL) {
+		return model.PERMALINK_ACCESS_PUBLIC
+	}
+
+	return model.PERMALINK_ACCESS_NONE
+}
+
 func (a *App) GetPostsBeforePost(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError) {
 	return a.Srv.Store.Post().GetPostsBefore(channelId, postId, perPage, page*perPage)
 }
@@ -845,6 +1097,11 @@ func (a *App) DeletePost(postId, deleteByID string) (*model.Post, *model.AppErro
 	a.Srv.Go(func() {
 		a.DeleteFlaggedPosts(post.Id)
 	})
+	a.Srv.Go(func() {
+		if err := a.Srv.Store.Hashtag().DeleteForPost(post.Id); err != nil {
+			mlog.Warn("Encountered error when deleting hashtags for post", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	})
 
 	if a.IsESIndexingEnabled() {
 		a.Srv.Go(func() {
@@ -928,7 +1185,7 @@ func (a *App) searchPostsInTeam(teamId string, userId string, paramsList []*mode
 
 		go func(params *model.SearchParams) {
 			defer wg.Done()
-			postList, err := a.Srv.Store.Post().Search(teamId, userId, params)
+			postList, err := a.Srv.Store.Post().Search(a.Context(), teamId, userId, params)
 			pchan <- store.StoreResult{Data: postList, Err: err}
 		}(params)
 	}
@@ -24,8 +24,22 @@ const LINK_CACHE_SIZE = 10000
 const LINK_CACHE_DURATION = 3600
 const MaxMetadataImageSize = MaxOpenGraphResponseSize
 
+const EMOJI_METADATA_CACHE_SIZE = 5000
+const EMOJI_METADATA_CACHE_DURATION = 300
+
 var linkCache = utils.NewLru(LINK_CACHE_SIZE)
 
+// emojiMetadataCache maps emoji names to the custom emoji with that name, or to nil if there's none, so that the names
+// of system emojis used in posts aren't looked up again for every page of posts.
+var emojiMetadataCache = utils.NewLru(EMOJI_METADATA_CACHE_SIZE)
+
+// postListMetadata holds the reactions and file infos of a list of posts, keyed by post id, loaded for the whole
+// list at once rather than post by post. A nil map means that they couldn't be loaded and are loaded for each post.
+type postListMetadata struct {
+	reactions map[string][]*model.Reaction
+	fileInfos map[string][]*model.FileInfo
+}
+
 func (a *App) InitPostMetadata() {
 	// Dump any cached links if the proxy settings have changed so image URLs can be updated
 	a.AddConfigListener(func(before, after *model.Config) {
@@ -46,8 +60,10 @@ func (a *App) PreparePostListForClient(originalList *model.PostList) *model.Post
 		PrevPostId: originalList.PrevPostId,
 	}
 
+	metadata := a.loadPostListMetadata(originalList.Posts)
+
 	for id, originalPost := range originalList.Posts {
-		post := a.PreparePostForClient(originalPost, false, false)
+		post := a.preparePostForClient(originalPost, false, false, metadata)
 
 		list.Posts[id] = post
 	}
@@ -55,6 +71,54 @@ func (a *App) PreparePostListForClient(originalList *model.PostList) *model.Post
 	return list
 }
 
+// loadPostListMetadata loads the reactions and file infos of the posts with a query for each, and the custom emojis
+// they use with a single query, caching the emojis so that they're found when each post is prepared.
+func (a *App) loadPostListMetadata(posts map[string]*model.Post) *postListMetadata {
+	metadata := &postListMetadata{}
+
+	postIdsWithReactions := []string{}
+	postIdsWithFiles := []string{}
+	for _, post := range posts {
+		if post.HasReactions {
+			postIdsWithReactions = append(postIdsWithReactions, post.Id)
+		}
+		if len(post.FileIds) > 0 {
+			postIdsWithFiles = append(postIdsWithFiles, post.Id)
+		}
+	}
+
+	if len(postIdsWithReactions) == 0 {
+		metadata.reactions = map[string][]*model.Reaction{}
+	} else if reactions, err := a.GetBulkReactionsForPosts(postIdsWithReactions); err != nil {
+		mlog.Warn("Failed to get reactions for a list of posts", mlog.Int("post_count", len(postIdsWithReactions)), mlog.Err(err))
+	} else {
+		metadata.reactions = reactions
+	}
+
+	if len(postIdsWithFiles) == 0 {
+		metadata.fileInfos = map[string][]*model.FileInfo{}
+	} else if fileInfos, err := a.Srv.Store.FileInfo().GetForPosts(postIdsWithFiles, false); err != nil {
+		mlog.Warn("Failed to get files for a list of posts", mlog.Int("post_count", len(postIdsWithFiles)), mlog.Err(err))
+	} else {
+		metadata.fileInfos = fileInfos
+	}
+
+	if *a.Config().ServiceSettings.EnableCustomEmoji && metadata.reactions != nil {
+		names := []string{}
+		for _, post := range posts {
+			names = append(names, getEmojiNamesForPost(post, metadata.reactions[post.Id])...)
+		}
+
+		if len(names) > 0 {
+			if _, err := a.getCustomEmojisByName(a.resolveEmojiAliases(model.RemoveDuplicateStrings(names))); err != nil {
+				mlog.Warn("Failed to get emojis for a list of posts", mlog.Int("post_count", len(posts)), mlog.Err(err))
+			}
+		}
+	}
+
+	return metadata
+}
+
 // OverrideIconURLIfEmoji changes the post icon override URL prop, if it has an emoji icon,
 // so that it points to the URL (relative) of the emoji - static if emoji is default, /api if custom.
 func (a *App) OverrideIconURLIfEmoji(post *model.Post) {
@@ -78,6 +142,12 @@ func (a *App) OverrideIconURLIfEmoji(post *model.Post) {
 }
 
 func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost bool, isEditPost bool) *model.Post {
+	return a.preparePostForClient(originalPost, isNewPost, isEditPost, nil)
+}
+
+// preparePostForClient adds the metadata of a post, taking its reactions and file infos from the ones loaded for
+// the list of posts it belongs to, if any.
+func (a *App) preparePostForClient(originalPost *model.Post, isNewPost bool, isEditPost bool, listMetadata *postListMetadata) *model.Post {
 	post := originalPost.Clone()
 
 	// Proxy image links before constructing metadata so that requests go through the proxy
@@ -85,10 +155,12 @@ func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost bool, isE
 
 	a.OverrideIconURLIfEmoji(post)
 
+	a.addBlockedUsersProp(post)
+
 	post.Metadata = &model.PostMetadata{}
 
 	// Emojis and reaction counts
-	if emojis, reactions, err := a.getEmojisAndReactionsForPost(post); err != nil {
+	if emojis, reactions, err := a.getEmojisAndReactionsForPost(post, listMetadata); err != nil {
 		mlog.Warn("Failed to get emojis and reactions for a post", mlog.String("post_id", post.Id), mlog.Err(err))
 	} else {
 		post.Metadata.Emojis = emojis
@@ -96,15 +168,21 @@ func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost bool, isE
 	}
 
 	// Files
-	if fileInfos, err := a.getFileMetadataForPost(post, isNewPost || isEditPost); err != nil {
+	if fileInfos, err := a.getFileMetadataForPost(post, isNewPost || isEditPost, listMetadata); err != nil {
 		mlog.Warn("Failed to get files for a post", mlog.String("post_id", post.Id), mlog.Err(err))
 	} else {
 		post.Metadata.Files = fileInfos
 	}
 
-	// Embeds and image dimensions
+	// Embeds and image dimensions, which aren't fetched at all for the links posted in channels with link previews
+	// disabled
 	firstLink, images := getFirstLinkAndImages(post.Message)
 
+	linkPreviewsDisabled := a.areLinkPreviewsDisabledForChannel(post.ChannelId)
+	if linkPreviewsDisabled {
+		firstLink = ""
+	}
+
 	if embed, err := a.getEmbedForPost(post, firstLink, isNewPost); err != nil {
 		mlog.Debug("Failed to get embedded content for a post", mlog.String("post_id", post.Id), mlog.Err(err))
 	} else if embed == nil {
@@ -113,26 +191,64 @@ func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost bool, isE
 		post.Metadata.Embeds = []*model.PostEmbed{embed}
 	}
 
-	post.Metadata.Images = a.getImagesForPost(post, images, isNewPost)
+	if linkPreviewsDisabled {
+		post.Metadata.Images = map[string]*model.PostImage{}
+	} else {
+		post.Metadata.Images = a.getImagesForPost(post, images, isNewPost)
+	}
 
 	return post
 }
 
-func (a *App) getFileMetadataForPost(post *model.Post, fromMaster bool) ([]*model.FileInfo, *model.AppError) {
+func (a *App) areLinkPreviewsDisabledForChannel(channelId string) bool {
+	if channelId == "" {
+		return false
+	}
+
+	channel, err := a.GetChannel(channelId)
+	if err != nil {
+		mlog.Warn("Failed to get the channel of a post", mlog.String("channel_id", channelId), mlog.Err(err))
+		return false
+	}
+
+	return channel.LinkPreviewsDisabled
+}
+
+// isLinkPreviewURLAllowed returns whether a preview or the dimensions of an image may be fetched from a URL, looking
+// through the image proxy for the original URL of proxied images. URLs on the server itself are always allowed.
+func (a *App) isLinkPreviewURLAllowed(requestURL string) bool {
+	requestURL = a.ImageProxy.GetUnproxiedImageURL(requestURL)
+
+	if siteURL := a.GetSiteURL(); siteURL != "" && (requestURL == siteURL || strings.HasPrefix(requestURL, siteURL+"/")) {
+		return true
+	}
+
+	return a.Config().ServiceSettings.IsLinkPreviewURLAllowed(requestURL)
+}
+
+func (a *App) getFileMetadataForPost(post *model.Post, fromMaster bool, listMetadata *postListMetadata) ([]*model.FileInfo, *model.AppError) {
 	if len(post.FileIds) == 0 {
 		return nil, nil
 	}
 
+	if listMetadata != nil && listMetadata.fileInfos != nil {
+		return listMetadata.fileInfos[post.Id], nil
+	}
+
 	return a.GetFileInfosForPost(post.Id, fromMaster)
 }
 
-func (a *App) getEmojisAndReactionsForPost(post *model.Post) ([]*model.Emoji, []*model.Reaction, *model.AppError) {
+func (a *App) getEmojisAndReactionsForPost(post *model.Post, listMetadata *postListMetadata) ([]*model.Emoji, []*model.Reaction, *model.AppError) {
 	var reactions []*model.Reaction
 	if post.HasReactions {
-		var err *model.AppError
-		reactions, err = a.GetReactionsForPost(post.Id)
-		if err != nil {
-			return nil, nil, err
+		if listMetadata != nil && listMetadata.reactions != nil {
+			reactions = listMetadata.reactions[post.Id]
+		} else {
+			var err *model.AppError
+			reactions, err = a.GetReactionsForPost(post.Id)
+			if err != nil {
+				return nil, nil, err
+			}
 		}
 	}
 
@@ -151,7 +267,7 @@ func (a *App) getEmbedForPost(post *model.Post, firstLink string, isNewPost bool
 		}, nil
 	}
 
-	if firstLink == "" || !*a.Config().ServiceSettings.EnableLinkPreviews {
+	if firstLink == "" || !*a.Config().ServiceSettings.EnableLinkPreviews || !a.isLinkPreviewURLAllowed(firstLink) {
 		return nil, nil
 	}
 
@@ -283,7 +399,44 @@ func (a *App) getCustomEmojisForPost(post *model.Post, reactions []*model.Reacti
 		return []*model.Emoji{}, nil
 	}
 
-	return a.GetMultipleEmojiByName(names)
+	return a.getCustomEmojisByName(a.resolveEmojiAliases(names))
+}
+
+// getCustomEmojisByName returns the custom emojis with the given names, only looking up the names that aren't cached.
+func (a *App) getCustomEmojisByName(names []string) ([]*model.Emoji, *model.AppError) {
+	emojis := []*model.Emoji{}
+	uncachedNames := []string{}
+	for _, name := range names {
+		if cacheItem, ok := emojiMetadataCache.Get(name); ok {
+			if emoji := cacheItem.(*model.Emoji); emoji != nil {
+				emojis = append(emojis, emoji)
+			}
+			continue
+		}
+
+		uncachedNames = append(uncachedNames, name)
+	}
+
+	if len(uncachedNames) == 0 {
+		return emojis, nil
+	}
+
+	found, err := a.GetMultipleEmojiByName(uncachedNames)
+	if err != nil {
+		return nil, err
+	}
+
+	foundByName := make(map[string]*model.Emoji, len(found))
+	for _, emoji := range found {
+		foundByName[emoji.Name] = emoji
+		emojis = append(emojis, emoji)
+	}
+
+	for _, name := range uncachedNames {
+		emojiMetadataCache.AddWithExpiresInSecs(name, foundByName[name], EMOJI_METADATA_CACHE_DURATION)
+	}
+
+	return emojis, nil
 }
 
 // Given a string, returns the first autolinked URL in the string as well as an array of all Markdown
@@ -351,13 +504,24 @@ func getImagesInMessageAttachments(post *model.Post) []string {
 func (a *App) getLinkMetadata(requestURL string, timestamp int64, isNewPost bool) (*opengraph.OpenGraph, *model.PostImage, error) {
 	requestURL = resolveMetadataURL(requestURL, a.GetSiteURL())
 
+	// Checked before the cache so that metadata fetched before a domain was blocked isn't returned anymore
+	if !a.isLinkPreviewURLAllowed(requestURL) {
+		return nil, nil, nil
+	}
+
 	timestamp = model.FloorToNearestHour(timestamp)
 
 	// Check cache
 	og, image, ok := getLinkMetadataFromCache(requestURL, timestamp)
 	if ok {
+		if a.Metrics != nil {
+			a.Metrics.IncrementMemCacheHitCounter("Link Metadata")
+		}
 		return og, image, nil
 	}
+	if a.Metrics != nil {
+		a.Metrics.IncrementMemCacheMissCounter("Link Metadata")
+	}
 
 	// Check the database if this isn't a new post. If it is a new post and the data is cached, it should be in memory.
 	if !isNewPost {
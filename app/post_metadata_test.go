@@ -55,6 +55,50 @@ func TestPreparePostListForClient(t *testing.T) {
 			assert.NotNil(t, clientPost.Metadata, "should've populated metadata for each post")
 		}
 	})
+
+	t.Run("loads reactions, files and emojis for the whole list", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnableCustomEmoji = true
+		})
+
+		emoji := th.CreateEmoji()
+
+		fileInfo, err := th.App.DoUploadFile(time.Now(), th.BasicTeam.Id, th.BasicChannel.Id, th.BasicUser.Id, "test.txt", []byte("test"))
+		require.Nil(t, err)
+
+		postWithFile, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   ":" + emoji.Name + ":",
+			FileIds:   []string{fileInfo.Id},
+		}, th.BasicChannel, false)
+		require.Nil(t, err)
+		fileInfo.PostId = postWithFile.Id
+
+		postWithReactions, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   "reactions",
+		}, th.BasicChannel, false)
+		require.Nil(t, err)
+		th.AddReactionToPost(postWithReactions, th.BasicUser, "smile")
+		th.AddReactionToPost(postWithReactions, th.BasicUser2, emoji.Name)
+		postWithReactions.HasReactions = true
+
+		postList := model.NewPostList()
+		postList.AddPost(postWithFile)
+		postList.AddPost(postWithReactions)
+
+		clientPostList := th.App.PreparePostListForClient(postList)
+
+		assert.Equal(t, []*model.FileInfo{fileInfo}, clientPostList.Posts[postWithFile.Id].Metadata.Files)
+		assert.Equal(t, []*model.Emoji{emoji}, clientPostList.Posts[postWithFile.Id].Metadata.Emojis)
+		assert.Empty(t, clientPostList.Posts[postWithFile.Id].Metadata.Reactions)
+
+		assert.Len(t, clientPostList.Posts[postWithReactions.Id].Metadata.Reactions, 2)
+		assert.Equal(t, []*model.Emoji{emoji}, clientPostList.Posts[postWithReactions.Id].Metadata.Emojis)
+		assert.Empty(t, clientPostList.Posts[postWithReactions.Id].Metadata.Files)
+	})
 }
 
 func TestPreparePostForClient(t *testing.T) {
@@ -477,6 +521,48 @@ func TestPreparePostForClient(t *testing.T) {
 			}, imageDimensions[server.URL+"/test-image1.png"])
 		})
 	})
+
+	t.Run("link previews disabled for the channel", func(t *testing.T) {
+		th := setup()
+		defer th.TearDown()
+
+		disabled := true
+		channel, err := th.App.PatchChannel(th.BasicChannel, &model.ChannelPatch{LinkPreviewsDisabled: &disabled}, th.BasicUser.Id)
+		require.Nil(t, err)
+
+		post, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: channel.Id,
+			Message:   fmt.Sprintf("This is our web page: %s and ![our icon](%s/test-image1.png)", server.URL, server.URL),
+		}, channel, false)
+		require.Nil(t, err)
+
+		clientPost := th.App.PreparePostForClient(post, false, false)
+
+		assert.Empty(t, clientPost.Metadata.Embeds)
+		assert.Empty(t, clientPost.Metadata.Images)
+	})
+
+	t.Run("blocked link preview domain", func(t *testing.T) {
+		th := setup()
+		defer th.TearDown()
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.LinkPreviewBlockedDomains = "127.0.0.1"
+		})
+
+		post, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   fmt.Sprintf("This is our web page: %s and ![our icon](%s/test-image1.png)", server.URL, server.URL),
+		}, th.BasicChannel, false)
+		require.Nil(t, err)
+
+		clientPost := th.App.PreparePostForClient(post, false, false)
+
+		assert.Empty(t, clientPost.Metadata.Embeds)
+		assert.Empty(t, clientPost.Metadata.Images)
+	})
 }
 
 func TestPreparePostForClientWithImageProxy(t *testing.T) {
@@ -0,0 +1,252 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	POST_PURGE_BATCH_SIZE      = 500
+	POST_PURGES_DUE_BATCH_SIZE = 100
+
+	// How long a purge can run without making progress before it is considered abandoned and run again.
+	POST_PURGE_STALE_TIMEOUT = 30 * time.Minute
+)
+
+func (a *App) GetPostPurges(page, perPage int) ([]*model.PostPurge, *model.AppError) {
+	return a.Srv.Store.PostPurge().GetAll(page*perPage, perPage)
+}
+
+func (a *App) GetPostPurge(purgeId string) (*model.PostPurge, *model.AppError) {
+	return a.Srv.Store.PostPurge().Get(purgeId)
+}
+
+// CreatePostPurge requests posts to be permanently deleted at the purge time of the purge, or right away if it has
+// none. Deleted and archived posts can be purged too. Purging a root post purges its whole thread, since its replies
+// can't be kept without it.
+func (a *App) CreatePostPurge(purge *model.PostPurge) (*model.PostPurge, *model.AppError) {
+	switch purge.Scope {
+	case model.POST_PURGE_SCOPE_POST, model.POST_PURGE_SCOPE_THREAD:
+		posts, err := a.Srv.Store.Post().GetForPurge(model.POST_PURGE_SCOPE_POST, purge.TargetId, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(posts) == 0 || posts[0].Id != purge.TargetId {
+			return nil, model.NewAppError("CreatePostPurge", "app.post_purge.post_not_found.app_error", nil, "post_id="+purge.TargetId, http.StatusNotFound)
+		}
+
+		post := posts[0]
+		if post.RootId == "" {
+			purge.Scope = model.POST_PURGE_SCOPE_THREAD
+		} else if purge.Scope == model.POST_PURGE_SCOPE_THREAD {
+			purge.TargetId = post.RootId
+		}
+		purge.ChannelId = post.ChannelId
+
+	case model.POST_PURGE_SCOPE_CHANNEL:
+		channel, err := a.GetChannel(purge.TargetId)
+		if err != nil {
+			return nil, err
+		}
+		purge.ChannelId = channel.Id
+	}
+
+	purge.Status = model.POST_PURGE_STATUS_PENDING
+	purge.FinishAt = 0
+	purge.PostCount = 0
+	purge.FileCount = 0
+	if purge.PurgeAt < model.GetMillis() {
+		purge.PurgeAt = 0
+	}
+
+	purge, err := a.Srv.Store.PostPurge().Save(purge)
+	if err != nil {
+		return nil, err
+	}
+
+	if purge.PurgeAt == purge.CreateAt {
+		a.Srv.Go(func() {
+			a.runPostPurge(purge)
+		})
+	}
+
+	return purge, nil
+}
+
+// CancelPostPurge cancels a purge that hasn't started yet.
+func (a *App) CancelPostPurge(purge *model.PostPurge) (*model.PostPurge, *model.AppError) {
+	if purge.Status != model.POST_PURGE_STATUS_PENDING {
+		return nil, model.NewAppError("CancelPostPurge", "app.post_purge.not_pending.app_error", nil, "id="+purge.Id+", status="+purge.Status, http.StatusBadRequest)
+	}
+
+	purge.Status = model.POST_PURGE_STATUS_CANCELED
+	purge.FinishAt = model.GetMillis()
+
+	return a.Srv.Store.PostPurge().Update(purge)
+}
+
+// RunDuePostPurges runs the purges whose delay has passed. Each purge is claimed before it runs, so that it only runs
+// once when several servers look for due purges at the same time. Purges left running by a server that went away are
+// run again.
+func (a *App) RunDuePostPurges() {
+	staleBefore := model.GetMillisForTime(time.Now().Add(-POST_PURGE_STALE_TIMEOUT))
+	if count, err := a.Srv.Store.PostPurge().ResetStale(staleBefore); err != nil {
+		mlog.Error("Failed to reset the stale post purges", mlog.Err(err))
+	} else if count > 0 {
+		mlog.Warn("Reset stale post purges", mlog.Int64("count", count))
+	}
+
+	for {
+		purges, err := a.Srv.Store.PostPurge().GetDue(model.GetMillis(), POST_PURGES_DUE_BATCH_SIZE)
+		if err != nil {
+			mlog.Error("Failed to get the due post purges", mlog.Err(err))
+			return
+		}
+
+		for _, purge := range purges {
+			a.runPostPurge(purge)
+		}
+
+		if len(purges) < POST_PURGES_DUE_BATCH_SIZE {
+			return
+		}
+	}
+}
+
+func (a *App) runPostPurge(purge *model.PostPurge) {
+	if claimed, err := a.Srv.Store.PostPurge().Claim(purge.Id); err != nil || !claimed {
+		if err != nil {
+			mlog.Error("Failed to claim a post purge", mlog.String("post_purge_id", purge.Id), mlog.Err(err))
+		}
+		return
+	}
+
+	purge.Status = model.POST_PURGE_STATUS_RUNNING
+	err := a.purgePosts(purge)
+	a.InvalidateCacheForChannelPosts(purge.ChannelId)
+
+	if err != nil {
+		// The purge is retried the next time the due purges run
+		mlog.Error("Failed to purge posts", mlog.String("post_purge_id", purge.Id), mlog.Err(err))
+		purge.Status = model.POST_PURGE_STATUS_PENDING
+	} else {
+		purge.Status = model.POST_PURGE_STATUS_DONE
+		purge.FinishAt = model.GetMillis()
+	}
+
+	if _, err := a.Srv.Store.PostPurge().Update(purge); err != nil {
+		mlog.Error("Failed to update a post purge", mlog.String("post_purge_id", purge.Id), mlog.Err(err))
+	}
+
+	if purge.Status == model.POST_PURGE_STATUS_DONE {
+		a.LogAuditEvent(&model.AuditEvent{
+			ActorId: purge.CreatorId,
+			Action:  "post_purge/" + purge.Scope,
+			Target: model.StringMap{
+				"post_purge_id": purge.Id,
+				"channel_id":    purge.ChannelId,
+				"target_id":     purge.TargetId,
+			},
+			Details: "posts=" + strconv.FormatInt(purge.PostCount, 10) + ", files=" + strconv.FormatInt(purge.FileCount, 10),
+		})
+	}
+}
+
+// purgePosts permanently deletes the posts in the scope of a purge, batch by batch, counting what it deleted.
+func (a *App) purgePosts(purge *model.PostPurge) *model.AppError {
+	for {
+		posts, err := a.Srv.Store.Post().GetForPurge(purge.Scope, purge.TargetId, POST_PURGE_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		if len(posts) == 0 {
+			// Posts still waiting for approval in the channel would otherwise be posted into it after the purge
+			if purge.Scope == model.POST_PURGE_SCOPE_CHANNEL {
+				return a.Srv.Store.PendingPost().PermanentDeleteByChannel(purge.ChannelId)
+			}
+			return nil
+		}
+
+		postIds := make([]string, 0, len(posts))
+		for _, post := range posts {
+			purge.FileCount += a.purgePostFiles(post)
+
+			if err := a.Srv.Store.Hashtag().DeleteForPost(post.Id); err != nil {
+				mlog.Warn("Failed to delete the hashtags of a purged post", mlog.String("post_id", post.Id), mlog.Err(err))
+			}
+
+			if a.IsESIndexingEnabled() {
+				if err := a.Elasticsearch.DeletePost(post); err != nil {
+					mlog.Warn("Failed to delete a purged post from the search index", mlog.String("post_id", post.Id), mlog.Err(err))
+				}
+			}
+
+			postIds = append(postIds, post.Id)
+		}
+
+		if err := a.Srv.Store.Post().PermanentDeleteForPurge(postIds); err != nil {
+			return err
+		}
+		purge.PostCount += int64(len(posts))
+
+		// Recording the progress also shows the purge is still running, so that it isn't reset as stale
+		if _, err := a.Srv.Store.PostPurge().Update(purge); err != nil {
+			return err
+		}
+
+		// Purging a channel would send an event per post, so clients pick it up when they reload the channel instead
+		if purge.Scope != model.POST_PURGE_SCOPE_CHANNEL {
+			for _, post := range posts {
+				if post.DeleteAt == 0 {
+					message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POST_DELETED, "", post.ChannelId, "", nil)
+					message.Add("post", a.PreparePostForClient(post, false, false).ToJson())
+					a.Publish(message)
+				}
+			}
+		}
+	}
+}
+
+// purgePostFiles deletes the files attached to a post from the file store and the database, returning how many it
+// deleted.
+func (a *App) purgePostFiles(post *model.Post) int64 {
+	infos, err := a.Srv.Store.FileInfo().GetForPost(post.Id, true, true, false)
+	if err != nil {
+		mlog.Warn("Failed to get the files of a purged post", mlog.String("post_id", post.Id), mlog.Err(err))
+		return 0
+	}
+
+	var count int64
+	for _, info := range infos {
+		for _, path := range []string{info.Path, info.ThumbnailPath, info.PreviewPath} {
+			if path == "" {
+				continue
+			}
+
+			if err := a.RemoveFile(path); err != nil {
+				mlog.Warn("Failed to remove a file of a purged post", mlog.String("post_id", post.Id), mlog.String("file_id", info.Id), mlog.Err(err))
+			}
+		}
+
+		if err := a.Srv.Store.FileInfo().PermanentDelete(info.Id); err != nil {
+			mlog.Warn("Failed to delete the file info of a purged post", mlog.String("post_id", post.Id), mlog.String("file_id", info.Id), mlog.Err(err))
+			continue
+		}
+		count++
+	}
+
+	if len(infos) > 0 {
+		a.Srv.Store.FileInfo().InvalidateFileInfosForPostCache(post.Id)
+	}
+
+	return count
+}
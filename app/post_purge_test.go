@@ -0,0 +1,92 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCreatePostPurge(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	root := th.CreatePost(th.BasicChannel)
+	reply, err := th.App.CreatePostAsUser(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, RootId: root.Id, ParentId: root.Id, Message: "reply"}, "")
+	require.Nil(t, err)
+
+	t.Run("purging a root post purges its thread", func(t *testing.T) {
+		purge, err := th.App.CreatePostPurge(&model.PostPurge{CreatorId: th.SystemAdminUser.Id, Scope: model.POST_PURGE_SCOPE_POST, TargetId: root.Id, PurgeAt: model.GetMillis() + 60*60*1000})
+		require.Nil(t, err)
+		assert.Equal(t, model.POST_PURGE_SCOPE_THREAD, purge.Scope)
+		assert.Equal(t, th.BasicChannel.Id, purge.ChannelId)
+
+		purge, err = th.App.CancelPostPurge(purge)
+		require.Nil(t, err)
+		assert.Equal(t, model.POST_PURGE_STATUS_CANCELED, purge.Status)
+
+		_, err = th.App.CancelPostPurge(purge)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("purging the thread of a reply purges the whole thread", func(t *testing.T) {
+		purge, err := th.App.CreatePostPurge(&model.PostPurge{CreatorId: th.SystemAdminUser.Id, Scope: model.POST_PURGE_SCOPE_THREAD, TargetId: reply.Id, PurgeAt: model.GetMillis() + 60*60*1000})
+		require.Nil(t, err)
+		assert.Equal(t, root.Id, purge.TargetId)
+
+		_, err = th.App.CancelPostPurge(purge)
+		require.Nil(t, err)
+	})
+
+	t.Run("unknown post", func(t *testing.T) {
+		_, err := th.App.CreatePostPurge(&model.PostPurge{CreatorId: th.SystemAdminUser.Id, Scope: model.POST_PURGE_SCOPE_POST, TargetId: model.NewId()})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("too long a delay", func(t *testing.T) {
+		_, err := th.App.CreatePostPurge(&model.PostPurge{CreatorId: th.SystemAdminUser.Id, Scope: model.POST_PURGE_SCOPE_POST, TargetId: reply.Id, PurgeAt: model.GetMillis() + model.POST_PURGE_MAX_DELAY + 60*60*1000})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+}
+
+func TestRunDuePostPurges(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	root := th.CreatePost(th.BasicChannel)
+	reply, err := th.App.CreatePostAsUser(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, RootId: root.Id, ParentId: root.Id, Message: "reply"}, "")
+	require.Nil(t, err)
+	other := th.CreatePost(th.BasicChannel)
+
+	_, err = th.App.DeletePost(reply.Id, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	purge, err := th.App.CreatePostPurge(&model.PostPurge{CreatorId: th.SystemAdminUser.Id, Scope: model.POST_PURGE_SCOPE_THREAD, TargetId: root.Id, PurgeAt: model.GetMillis() + 100})
+	require.Nil(t, err)
+
+	time.Sleep(200 * time.Millisecond)
+	th.App.RunDuePostPurges()
+
+	purge, err = th.App.GetPostPurge(purge.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.POST_PURGE_STATUS_DONE, purge.Status)
+	assert.EqualValues(t, 2, purge.PostCount)
+	assert.NotZero(t, purge.FinishAt)
+
+	posts, err := th.App.Srv.Store.Post().GetForPurge(model.POST_PURGE_SCOPE_THREAD, root.Id, 10)
+	require.Nil(t, err)
+	assert.Empty(t, posts)
+
+	_, err = th.App.GetSinglePost(other.Id)
+	require.Nil(t, err)
+}
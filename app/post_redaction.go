@@ -0,0 +1,139 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+// RedactPost replaces the message and/or removes the attachments of a post on behalf of an admin, keeping the post
+// itself so that its thread stays intact. Unlike an edit, the redacted content isn't kept in the edit history, and
+// the earlier versions of the post are redacted too. Clients see the change as an edit, and the message export picks
+// it up as a redaction.
+func (a *App) RedactPost(postId string, redaction *model.PostRedaction, userId string) (*model.Post, *model.AppError) {
+	if err := redaction.IsValid(); err != nil {
+		return nil, err
+	}
+
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.IsSystemMessage() {
+		return nil, model.NewAppError("RedactPost", "app.post.redact.system_message.app_error", nil, "id="+post.Id, http.StatusBadRequest)
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	// The earlier versions of the post, which share its files
+	versions, err := a.Srv.Store.Post().GetForPurge(model.POST_PURGE_SCOPE_POST, post.Id, POST_PURGE_BATCH_SIZE)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileCount int64
+	if redaction.Attachments {
+		fileCount = a.purgePostFiles(post)
+	}
+
+	now := model.GetMillis()
+
+	redactPostContent(post, redaction, userId, now)
+	rpost, err := a.Srv.Store.Post().Overwrite(post)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		if version.OriginalId != rpost.Id {
+			continue
+		}
+
+		redactPostContent(version, redaction, userId, now)
+		if _, err := a.Srv.Store.Post().Overwrite(version); err != nil {
+			return nil, err
+		}
+	}
+
+	if redaction.Message {
+		if err := a.Srv.Store.Hashtag().DeleteForPost(rpost.Id); err != nil {
+			mlog.Warn("Failed to delete the hashtags of a redacted post", mlog.String("post_id", rpost.Id), mlog.Err(err))
+		}
+	}
+
+	if a.IsESIndexingEnabled() {
+		a.Srv.Go(func() {
+			if err := a.Elasticsearch.IndexPost(rpost, channel.TeamId); err != nil {
+				mlog.Error("Encountered error indexing post", mlog.String("post_id", rpost.Id), mlog.Err(err))
+			}
+		})
+	}
+
+	a.InvalidateCacheForChannelPosts(rpost.ChannelId)
+
+	clientPost := a.PreparePostForClient(rpost, false, true)
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POST_EDITED, "", clientPost.ChannelId, "", nil)
+	message.Add("post", clientPost.ToJson())
+	a.Publish(message)
+
+	a.LogAuditEvent(&model.AuditEvent{
+		ActorId: userId,
+		Action:  "post/redact",
+		Target: model.StringMap{
+			"post_id":    rpost.Id,
+			"channel_id": rpost.ChannelId,
+			"user_id":    rpost.UserId,
+		},
+		Details: redactionAuditDetails(redaction, fileCount),
+	})
+
+	return clientPost, nil
+}
+
+// redactPostContent replaces the redacted content of a version of a post.
+func redactPostContent(post *model.Post, redaction *model.PostRedaction, userId string, now int64) {
+	post.MakeNonNil()
+
+	if redaction.Message {
+		post.Message = utils.T("app.post.redact.notice")
+		post.Hashtags = ""
+		delete(post.Props, "attachments")
+	}
+
+	if redaction.Attachments {
+		post.FileIds = model.StringArray{}
+		post.Filenames = model.StringArray{}
+	}
+
+	post.AddProp(model.POST_PROPS_REDACTED_AT, now)
+	post.AddProp(model.POST_PROPS_REDACTED_BY, userId)
+	post.EditAt = now
+}
+
+func redactionAuditDetails(redaction *model.PostRedaction, fileCount int64) string {
+	details := ""
+	if redaction.Message {
+		details = "message"
+	}
+	if redaction.Attachments {
+		if details != "" {
+			details += ", "
+		}
+		details += "attachments (" + strconv.FormatInt(fileCount, 10) + " files)"
+	}
+	if redaction.Reason != "" {
+		details += ", reason=" + redaction.Reason
+	}
+	return details
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+func TestRedactPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post, err := th.App.CreatePostAsUser(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: "the password is #hunter2"}, "")
+	require.Nil(t, err)
+
+	edited := post.Clone()
+	edited.Message = "the password is #hunter3"
+	_, err = th.App.UpdatePost(edited, false)
+	require.Nil(t, err)
+
+	t.Run("nothing to redact", func(t *testing.T) {
+		_, err := th.App.RedactPost(post.Id, &model.PostRedaction{}, th.SystemAdminUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	redacted, err := th.App.RedactPost(post.Id, &model.PostRedaction{Message: true, Reason: "credentials"}, th.SystemAdminUser.Id)
+	require.Nil(t, err)
+	assert.Equal(t, utils.T("app.post.redact.notice"), redacted.Message)
+	assert.True(t, redacted.IsRedacted())
+	assert.Equal(t, th.SystemAdminUser.Id, redacted.Props[model.POST_PROPS_REDACTED_BY])
+	assert.Empty(t, redacted.Hashtags)
+
+	// The earlier version kept by the edit is redacted too
+	versions, err := th.App.Srv.Store.Post().GetForPurge(model.POST_PURGE_SCOPE_POST, post.Id, 10)
+	require.Nil(t, err)
+	require.Len(t, versions, 2)
+	for _, version := range versions {
+		assert.Equal(t, utils.T("app.post.redact.notice"), version.Message)
+	}
+
+	received, err := th.App.GetSinglePost(post.Id)
+	require.Nil(t, err)
+	assert.True(t, received.IsRedacted())
+
+	t.Run("system message", func(t *testing.T) {
+		systemPost, err := th.App.CreatePost(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Type: model.POST_HEADER_CHANGE, Message: "header"}, th.BasicChannel, false)
+		require.Nil(t, err)
+
+		_, err = th.App.RedactPost(systemPost.Id, &model.PostRedaction{Message: true}, th.SystemAdminUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const (
+	// POST_REPORT_ACTIONS_PATH is the path the actions of the posts notifying moderators of reports are sent to,
+	// relative to the site URL, so that they're made with the session of the moderator taking them.
+	POST_REPORT_ACTIONS_PATH = "/api/v4/reports/"
+)
+
+func (a *App) GetPostReport(reportId string) (*model.PostReport, *model.AppError) {
+	return a.Srv.Store.PostReport().Get(reportId)
+}
+
+func (a *App) GetPostReports(state string, page, perPage int) ([]*model.PostReport, *model.AppError) {
+	return a.Srv.Store.PostReport().GetAll(state, page*perPage, perPage)
+}
+
+func (a *App) GetPostReportHistory(reportId string) ([]*model.PostReportEvent, *model.AppError) {
+	return a.Srv.Store.PostReport().GetEvents(reportId)
+}
+
+// ReportPost reports a post to the moderators. Reporting a post again while the user's report of it is still open
+// returns that report.
+func (a *App) ReportPost(post *model.Post, reporterId, reason, comment string) (*model.PostReport, *model.AppError) {
+	if !*a.Config().PostReportSettings.Enable {
+		return nil, model.NewAppError("ReportPost", "app.post_report.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if post.IsSystemMessage() || post.UserId == reporterId {
+		return nil, model.NewAppError("ReportPost", "app.post_report.invalid_post.app_error", nil, "post_id="+post.Id, http.StatusBadRequest)
+	}
+
+	if existing, err := a.Srv.Store.PostReport().GetOpenForPostAndReporter(post.Id, reporterId); err == nil {
+		return existing, nil
+	} else if err.StatusCode != http.StatusNotFound {
+		return nil, err
+	}
+
+	report, err := a.Srv.Store.PostReport().Save(model.NewPostReport(post, reporterId, reason, comment))
+	if err != nil {
+		return nil, err
+	}
+
+	a.recordPostReportEvent(report, reporterId, "", comment)
+
+	a.Srv.Go(func() {
+		a.notifyModeratorsOfPostReport(report)
+	})
+
+	return report, nil
+}
+
+// UpdatePostReportState resolves or reopens a report. Resolving it as deleted deletes the reported post, and
+// resolving it as warned sends a warning to the post's author from the moderator.
+func (a *App) UpdatePostReportState(report *model.PostReport, state, comment string, moderator *model.User) (*model.PostReport, *model.AppError) {
+	if !report.CanTransitionTo(state) {
+		return nil, model.NewAppError("UpdatePostReportState", "app.post_report.invalid_transition.app_error", map[string]interface{}{"From": report.State, "To": state}, "id="+report.Id, http.StatusBadRequest)
+	}
+
+	previousState := report.State
+
+	updated := *report
+	updated.State = state
+	updated.ResolverId = moderator.Id
+	if state == model.POST_REPORT_STATE_OPEN {
+		updated.ResolverId = ""
+	}
+
+	if _, err := a.Srv.Store.PostReport().Update(&updated, previousState); err != nil {
+		return nil, err
+	}
+
+	switch state {
+	case model.POST_REPORT_STATE_DELETED:
+		if _, err := a.DeletePost(report.PostId, moderator.Id); err != nil && err.StatusCode != http.StatusNotFound {
+			mlog.Error("Failed to delete a reported post", mlog.String("report_id", report.Id), mlog.String("post_id", report.PostId), mlog.Err(err))
+		}
+	case model.POST_REPORT_STATE_WARNED:
+		if err := a.warnReportedPostAuthor(&updated, moderator); err != nil {
+			mlog.Error("Failed to warn the author of a reported post", mlog.String("report_id", report.Id), mlog.String("user_id", report.PostUserId), mlog.Err(err))
+		}
+	}
+
+	a.recordPostReportEvent(&updated, moderator.Id, previousState, comment)
+
+	a.Srv.Go(func() {
+		a.updatePostReportNotification(&updated, moderator)
+	})
+
+	return &updated, nil
+}
+
+// IsPostReportModerator reports whether a user is a member of the moderators channel, whose members act on reports.
+func (a *App) IsPostReportModerator(userId string) bool {
+	channelId := *a.Config().PostReportSettings.ModeratorsChannelId
+	if channelId == "" {
+		return false
+	}
+
+	_, err := a.Srv.Store.Channel().GetMember(channelId, userId)
+	return err == nil
+}
+
+func (a *App) recordPostReportEvent(report *model.PostReport, actorId, fromState, comment string) {
+	event := &model.PostReportEvent{
+		ReportId:  report.Id,
+		ActorId:   actorId,
+		FromState: fromState,
+		ToState:   report.State,
+		Comment:   comment,
+	}
+	if _, err := a.Srv.Store.PostReport().SaveEvent(event); err != nil {
+		mlog.Error("Failed to record the history of a post report", mlog.String("report_id", report.Id), mlog.Err(err))
+	}
+
+	a.LogAuditEvent(&model.AuditEvent{
+		ActorId: actorId,
+		Action:  "post_report/" + report.State,
+		Target: model.StringMap{
+			"report_id": report.Id,
+			"post_id":   report.PostId,
+		},
+		Details: "from_state=" + fromState,
+	})
+}
+
+func (a *App) warnReportedPostAuthor(report *model.PostReport, moderator *model.User) *model.AppError {
+	if report.PostUserId == moderator.Id {
+		return nil
+	}
+
+	author, err := a.GetUser(report.PostUserId)
+	if err != nil {
+		return err
+	}
+
+	channel, err := a.GetOrCreateDirectChannel(moderator.Id, author.Id)
+	if err != nil {
+		return err
+	}
+
+	T := utils.GetUserTranslations(author.Locale)
+	_, err = a.CreatePost(&model.Post{
+		ChannelId: channel.Id,
+		UserId:    moderator.Id,
+		Message:   T("app.post_report.warning.message", map[string]interface{}{"Message": report.PostMessage}),
+	}, channel, false)
+	return err
+}
+
+func (a *App) notifyModeratorsOfPostReport(report *model.PostReport) {
+	channelId := *a.Config().PostReportSettings.ModeratorsChannelId
+	if channelId == "" {
+		return
+	}
+
+	channel, err := a.GetChannel(channelId)
+	if err != nil {
+		mlog.Error("Unable to get the moderators channel to notify it of a post report", mlog.String("channel_id", channelId), mlog.Err(err))
+		return
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    report.ReporterId,
+	}
+	if err = a.setPostReportNotification(post, report, nil); err != nil {
+		mlog.Error("Unable to notify the moderators of a post report", mlog.String("report_id", report.Id), mlog.Err(err))
+		return
+	}
+
+	notification, err := a.CreatePost(post, channel, false)
+	if err != nil {
+		mlog.Error("Unable to notify the moderators of a post report", mlog.String("report_id", report.Id), mlog.Err(err))
+		return
+	}
+
+	// The notification is only recorded while the report is open, since it's resolved by the notification's actions.
+	updated := *report
+	updated.NotificationPostId = notification.Id
+	if _, err := a.Srv.Store.PostReport().Update(&updated, model.POST_REPORT_STATE_OPEN); err != nil {
+		mlog.Warn("Unable to record the notification of a post report", mlog.String("report_id", report.Id), mlog.Err(err))
+	}
+}
+
+// updatePostReportNotification shows the new state of a report in the post notifying the moderators of it, which
+// has actions only while the report is open.
+func (a *App) updatePostReportNotification(report *model.PostReport, moderator *model.User) {
+	if report.NotificationPostId == "" {
+		return
+	}
+
+	post, err := a.GetSinglePost(report.NotificationPostId)
+	if err != nil {
+		mlog.Warn("Unable to get the notification of a post report", mlog.String("report_id", report.Id), mlog.Err(err))
+		return
+	}
+
+	// Clone shares the props, which are replaced below
+	post = post.Clone()
+	props := model.StringInterface{}
+	for key, value := range post.Props {
+		props[key] = value
+	}
+	post.Props = props
+
+	if err = a.setPostReportNotification(post, report, moderator); err != nil {
+		mlog.Warn("Unable to update the notification of a post report", mlog.String("report_id", report.Id), mlog.Err(err))
+		return
+	}
+
+	if _, err = a.UpdatePost(post, false); err != nil {
+		mlog.Warn("Unable to update the notification of a post report", mlog.String("report_id", report.Id), mlog.Err(err))
+	}
+}
+
+// setPostReportNotification sets the message of a post notifying the moderators of a report, along with an
+// attachment showing the reported post and either the actions resolving the report or how it was resolved.
+func (a *App) setPostReportNotification(post *model.Post, report *model.PostReport, moderator *model.User) *model.AppError {
+	reporter, err := a.GetUser(report.ReporterId)
+	if err != nil {
+		return err
+	}
+
+	author, err := a.GetUser(report.PostUserId)
+	if err != nil {
+		return err
+	}
+
+	post.Message = utils.T("app.post_report.notification.message", map[string]interface{}{
+		"Reporter": reporter.Username,
+		"Author":   author.Username,
+		"Reason":   report.Reason,
+		"Comment":  report.Comment,
+	})
+
+	attachment := &model.SlackAttachment{
+		Text:      report.PostMessage,
+		TitleLink: a.getPostReportPermalink(report),
+		Title:     utils.T("app.post_report.notification.title"),
+	}
+
+	if report.State == model.POST_REPORT_STATE_OPEN {
+		for _, action := range []string{model.POST_REPORT_ACTION_DELETE, model.POST_REPORT_ACTION_WARN, model.POST_REPORT_ACTION_DISMISS} {
+			attachment.Actions = append(attachment.Actions, &model.PostAction{
+				Id:   action,
+				Type: model.POST_ACTION_TYPE_BUTTON,
+				Name: utils.T("app.post_report.action." + action),
+				Integration: &model.PostActionIntegration{
+					URL:     POST_REPORT_ACTIONS_PATH + report.Id + "/actions",
+					Context: model.StringInterface{"action": action},
+				},
+			})
+		}
+	} else if moderator != nil {
+		attachment.Footer = utils.T("app.post_report.notification.resolved", map[string]interface{}{
+			"State":     report.State,
+			"Moderator": moderator.Username,
+		})
+	}
+
+	post.AddProp("attachments", []*model.SlackAttachment{attachment})
+
+	return nil
+}
+
+func (a *App) getPostReportPermalink(report *model.PostReport) string {
+	channel, err := a.GetChannel(report.ChannelId)
+	if err != nil || channel.TeamId == "" {
+		return ""
+	}
+
+	team, err := a.GetTeam(channel.TeamId)
+	if err != nil {
+		return ""
+	}
+
+	return a.GetSiteURL() + "/" + team.Name + "/pl/" + report.PostId
+}
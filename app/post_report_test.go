@@ -0,0 +1,88 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostReports(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	moderators := th.CreateChannel(th.BasicTeam)
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PostReportSettings.Enable = true
+		*cfg.PostReportSettings.ModeratorsChannelId = moderators.Id
+	})
+
+	assert.True(t, th.App.IsPostReportModerator(th.BasicUser.Id))
+	assert.False(t, th.App.IsPostReportModerator(th.BasicUser2.Id))
+
+	post, err := th.App.CreatePost(&model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "buy now",
+	}, th.BasicChannel, false)
+	require.Nil(t, err)
+
+	_, err = th.App.ReportPost(post, th.BasicUser2.Id, model.POST_REPORT_REASON_SPAM, "")
+	require.NotNil(t, err, "shouldn't be able to report your own post")
+
+	report, err := th.App.ReportPost(post, th.BasicUser.Id, model.POST_REPORT_REASON_SPAM, "")
+	require.Nil(t, err)
+
+	// The moderators are notified in the background
+	for i := 0; i < 50 && report.NotificationPostId == ""; i++ {
+		time.Sleep(100 * time.Millisecond)
+		report, err = th.App.GetPostReport(report.Id)
+		require.Nil(t, err)
+	}
+	require.NotEmpty(t, report.NotificationPostId)
+
+	notification, err := th.App.GetSinglePost(report.NotificationPostId)
+	require.Nil(t, err)
+
+	assert.Equal(t, moderators.Id, notification.ChannelId)
+	attachments := notification.Attachments()
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "buy now", attachments[0].Text)
+	require.Len(t, attachments[0].Actions, 3)
+	assert.Equal(t, POST_REPORT_ACTIONS_PATH+report.Id+"/actions", attachments[0].Actions[0].Integration.URL)
+
+	report, err = th.App.UpdatePostReportState(report, model.POST_REPORT_STATE_WARNED, "", th.BasicUser)
+	require.Nil(t, err)
+	assert.Equal(t, th.BasicUser.Id, report.ResolverId)
+
+	dm, err := th.App.GetOrCreateDirectChannel(th.BasicUser.Id, th.BasicUser2.Id)
+	require.Nil(t, err)
+	posts, err := th.App.GetPosts(dm.Id, 0, 10)
+	require.Nil(t, err)
+	assert.Len(t, posts.Order, 1, "should have warned the author")
+
+	for i := 0; i < 50 && len(attachments[0].Actions) > 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		notification, err = th.App.GetSinglePost(report.NotificationPostId)
+		require.Nil(t, err)
+		attachments = notification.Attachments()
+		require.Len(t, attachments, 1)
+	}
+	assert.Empty(t, attachments[0].Actions, "should have removed the actions once resolved")
+
+	_, err = th.App.UpdatePostReportState(report, model.POST_REPORT_STATE_DISMISSED, "", th.BasicUser)
+	require.NotNil(t, err, "resolved reports may only be reopened")
+
+	report, err = th.App.UpdatePostReportState(report, model.POST_REPORT_STATE_OPEN, "", th.BasicUser)
+	require.Nil(t, err)
+	assert.Empty(t, report.ResolverId)
+
+	events, err := th.App.GetPostReportHistory(report.Id)
+	require.Nil(t, err)
+	assert.Len(t, events, 3)
+}
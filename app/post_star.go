@@ -0,0 +1,103 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (a *App) StarPost(postId, userId string) (*model.PostStar, *model.AppError) {
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if channel.DeleteAt > 0 {
+		return nil, model.NewAppError("StarPost", "app.post_star.archived_channel.app_error", nil, "", http.StatusForbidden)
+	}
+
+	star, err := a.Srv.Store.PostStar().Save(&model.PostStar{PostId: post.Id, UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Srv.Go(func() {
+		a.sendPostStarsUpdatedEvent(post)
+	})
+
+	return star, nil
+}
+
+func (a *App) UnstarPost(postId, userId string) *model.AppError {
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.PostStar().Delete(post.Id, userId); err != nil {
+		return err
+	}
+
+	a.Srv.Go(func() {
+		a.sendPostStarsUpdatedEvent(post)
+	})
+
+	return nil
+}
+
+func (a *App) GetPostStar(postId, userId string) (*model.PostStar, *model.AppError) {
+	return a.Srv.Store.PostStar().Get(postId, userId)
+}
+
+func (a *App) GetPostStarCount(postId string) (*model.PostStarCount, *model.AppError) {
+	count, err := a.Srv.Store.PostStar().GetCountForPost(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PostStarCount{PostId: postId, Count: count}, nil
+}
+
+// GetPostStarCountsForAuthor returns the number of stars of the given posts that were written by the author,
+// ignoring the others since star counts are only shown to the authors of posts.
+func (a *App) GetPostStarCountsForAuthor(authorId string, postIds []string) ([]*model.PostStarCount, *model.AppError) {
+	if len(postIds) == 0 {
+		return []*model.PostStarCount{}, nil
+	}
+
+	posts, err := a.Srv.Store.Post().GetPostsByIds(postIds)
+	if err != nil {
+		return nil, err
+	}
+
+	authoredIds := []string{}
+	for _, post := range posts {
+		if post.UserId == authorId {
+			authoredIds = append(authoredIds, post.Id)
+		}
+	}
+
+	return a.Srv.Store.PostStar().GetCountsForPosts(authoredIds)
+}
+
+// sendPostStarsUpdatedEvent tells the author of the post how many users starred it.
+func (a *App) sendPostStarsUpdatedEvent(post *model.Post) {
+	count, err := a.GetPostStarCount(post.Id)
+	if err != nil {
+		mlog.Error("Failed to count the stars of a post", mlog.String("post_id", post.Id), mlog.Err(err))
+		return
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POST_STARS_UPDATED, "", "", post.UserId, nil)
+	message.Add("post_star_count", count.ToJson())
+	a.Publish(message)
+}
@@ -362,6 +362,126 @@ func TestPostReplyToPostWhereRootPosterLeftChannel(t *testing.T) {
 	}
 }
 
+func TestCreatePostReplyBroadcastPolicy(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.BasicChannel
+	rootPost := th.BasicPost
+
+	createReply := func(addToChannel interface{}) *model.Post {
+		t.Helper()
+
+		reply := &model.Post{
+			Message:   "reply",
+			ChannelId: channel.Id,
+			RootId:    rootPost.Id,
+			UserId:    th.BasicUser.Id,
+		}
+		if addToChannel != nil {
+			reply.AddProp(model.POST_PROPS_ADD_TO_CHANNEL, addToChannel)
+		}
+
+		reply, err := th.App.CreatePost(reply, channel, false)
+		require.Nil(t, err)
+		return reply
+	}
+
+	reply := createReply(nil)
+	assert.Nil(t, reply.GetAddToChannel())
+
+	reply = createReply(false)
+	require.NotNil(t, reply.GetAddToChannel())
+	assert.False(t, *reply.GetAddToChannel())
+
+	channel.ReplyBroadcastPolicy = model.CHANNEL_REPLY_BROADCAST_DEFAULT_THREAD
+
+	reply = createReply(nil)
+	require.NotNil(t, reply.GetAddToChannel())
+	assert.False(t, *reply.GetAddToChannel())
+
+	reply = createReply(true)
+	assert.True(t, *reply.GetAddToChannel())
+
+	channel.ReplyBroadcastPolicy = model.CHANNEL_REPLY_BROADCAST_THREAD_ONLY
+
+	reply = createReply(true)
+	assert.False(t, *reply.GetAddToChannel())
+}
+
+func TestCreatePostChannelIntegrationPolicy(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableIncomingWebhooks = true
+	})
+
+	hook, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	require.Nil(t, err)
+
+	bot, err := th.App.CreateBot(&model.Bot{
+		Username:    "bot" + model.NewId(),
+		Description: "a bot",
+		OwnerId:     th.BasicUser.Id,
+	})
+	require.Nil(t, err)
+	defer th.App.PermanentDeleteBot(bot.UserId)
+
+	createBotPost := func() *model.AppError {
+		_, err := th.App.CreatePost(&model.Post{
+			UserId:    bot.UserId,
+			ChannelId: th.BasicChannel.Id,
+			Message:   "from a bot",
+		}, th.BasicChannel, false)
+		return err
+	}
+
+	updateChannel := func(restricted bool, hookIds, botIds model.StringArray) {
+		t.Helper()
+
+		th.BasicChannel.IntegrationsRestricted = restricted
+		th.BasicChannel.AllowedWebhookIds = hookIds
+		th.BasicChannel.AllowedBotIds = botIds
+
+		channel, err := th.App.UpdateChannel(th.BasicChannel)
+		require.Nil(t, err)
+		th.BasicChannel = channel
+	}
+
+	t.Run("unrestricted", func(t *testing.T) {
+		assert.Nil(t, th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{Text: "from a webhook"}))
+		assert.Nil(t, createBotPost())
+	})
+
+	t.Run("restricted", func(t *testing.T) {
+		updateChannel(true, nil, nil)
+
+		err := th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{Text: "from a webhook"})
+		require.NotNil(t, err)
+		assert.Equal(t, "api.post.create_post.webhook_not_allowed.app_error", err.Id)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+
+		err = createBotPost()
+		require.NotNil(t, err)
+		assert.Equal(t, "api.post.create_post.bot_not_allowed.app_error", err.Id)
+
+		_, err = th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   "from a user",
+		}, th.BasicChannel, false)
+		assert.Nil(t, err, "users should still be able to post")
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		updateChannel(true, model.StringArray{hook.Id}, model.StringArray{bot.UserId})
+
+		assert.Nil(t, th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{Text: "from a webhook"}))
+		assert.Nil(t, createBotPost())
+	})
+}
+
 func TestPostAttachPostToChildPost(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -930,3 +1050,169 @@ func TestSearchPostsInTeamForUser(t *testing.T) {
 		es.AssertExpectations(t)
 	})
 }
+
+func TestGetPermalinkPreview(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	publicChannel := th.CreateChannel(th.BasicTeam)
+	privateChannel := th.CreatePrivateChannel(th.BasicTeam)
+
+	root := th.CreatePost(publicChannel)
+	reply, err := th.App.CreatePostAsUser(&model.Post{UserId: th.BasicUser.Id, ChannelId: publicChannel.Id, RootId: root.Id, ParentId: root.Id, Message: "reply"}, "")
+	require.Nil(t, err)
+	private := th.CreatePost(privateChannel)
+
+	t.Run("member", func(t *testing.T) {
+		preview, err := th.App.GetPermalinkPreview(reply.Id, th.BasicUser.Id, true)
+		require.Nil(t, err)
+		assert.Equal(t, model.PERMALINK_ACCESS_CHANNEL, preview.Access)
+		assert.Equal(t, root.Id, preview.RootId)
+		assert.Equal(t, reply.Id, preview.Post.Id)
+		require.NotNil(t, preview.Thread)
+		assert.Contains(t, preview.Thread.Posts, root.Id)
+		assert.Contains(t, preview.Thread.Posts, reply.Id)
+	})
+
+	t.Run("public channel without joining", func(t *testing.T) {
+		preview, err := th.App.GetPermalinkPreview(root.Id, th.BasicUser2.Id, false)
+		require.Nil(t, err)
+		assert.Equal(t, model.PERMALINK_ACCESS_PUBLIC, preview.Access)
+		assert.Equal(t, root.Id, preview.Post.Id)
+		assert.Nil(t, preview.Thread)
+
+		_, err = th.App.GetChannelMember(publicChannel.Id, th.BasicUser2.Id)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("private channel is redacted", func(t *testing.T) {
+		preview, err := th.App.GetPermalinkPreview(private.Id, th.BasicUser2.Id, true)
+		require.Nil(t, err)
+		assert.True(t, preview.IsRedacted())
+		assert.Equal(t, private.Id, preview.PostId)
+		assert.Empty(t, preview.ChannelId)
+		assert.Nil(t, preview.Post)
+		assert.Nil(t, preview.Thread)
+	})
+}
+
+func TestGetPermalinkPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	publicChannel := th.CreateChannel(th.BasicTeam)
+	privateChannel := th.CreatePrivateChannel(th.BasicTeam)
+
+	_, err := th.App.GetPermalinkPost(th.CreatePost(privateChannel).Id, th.BasicUser2.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+
+	_, err = th.App.GetPermalinkPost(th.CreatePost(publicChannel).Id, th.BasicUser2.Id)
+	require.Nil(t, err)
+
+	_, err = th.App.GetChannelMember(publicChannel.Id, th.BasicUser2.Id)
+	assert.Nil(t, err)
+}
+
+func TestFillInPostPropsCrossTeamChannelMentions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	otherTeam := th.CreateTeam()
+	th.LinkUserToTeam(th.BasicUser, otherTeam)
+	otherChannel := th.CreateChannel(otherTeam)
+
+	// A team the user isn't a member of
+	th.CreateChannel(th.CreateTeam())
+
+	getChannelMentions := func(message string) map[string]interface{} {
+		post := &model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: message}
+		require.Nil(t, th.App.FillInPostProps(post, th.BasicChannel))
+
+		channelMentions, _ := post.Props["channel_mentions"].(map[string]interface{})
+		return channelMentions
+	}
+
+	assert.Empty(t, getChannelMentions("~"+otherChannel.Name))
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.EnableCrossTeamChannelMentions = true })
+
+	channelMentions := getChannelMentions("~" + otherChannel.Name + " ~" + th.BasicChannel.Name)
+	require.Len(t, channelMentions, 2)
+	assert.Equal(t, map[string]interface{}{
+		"display_name": otherChannel.DisplayName,
+		"team_id":      otherTeam.Id,
+		"team_name":    otherTeam.Name,
+	}, channelMentions[otherChannel.Name])
+	assert.Equal(t, map[string]interface{}{"display_name": th.BasicChannel.DisplayName}, channelMentions[th.BasicChannel.Name])
+
+	t.Run("channels named the same in several teams are left out", func(t *testing.T) {
+		thirdTeam := th.CreateTeam()
+		th.LinkUserToTeam(th.BasicUser, thirdTeam)
+
+		_, err := th.App.CreateChannel(&model.Channel{TeamId: thirdTeam.Id, Name: otherChannel.Name, DisplayName: "Same name", Type: model.CHANNEL_OPEN}, false)
+		require.Nil(t, err)
+
+		assert.Empty(t, getChannelMentions("~"+otherChannel.Name))
+	})
+}
+
+func TestPersistEphemeralPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	sendEphemeralPost := func(message string) *model.Post {
+		return th.App.SendEphemeralPost(th.BasicUser.Id, &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			Message:   message,
+		})
+	}
+
+	t.Run("not persisted by default", func(t *testing.T) {
+		sendEphemeralPost("not persisted")
+
+		list, err := th.App.GetEphemeralPostsForUser(th.BasicUser.Id, 0)
+		require.Nil(t, err)
+		assert.Empty(t, list.Order)
+	})
+
+	require.Nil(t, th.App.UpdatePreferences(th.BasicUser.Id, model.Preferences{{
+		UserId:   th.BasicUser.Id,
+		Category: model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS,
+		Name:     model.PREFERENCE_NAME_PERSIST_EPHEMERAL,
+		Value:    "true",
+	}}))
+
+	t.Run("persisted, updated and deleted once the user opted in", func(t *testing.T) {
+		post := sendEphemeralPost("persisted")
+
+		list, err := th.App.GetEphemeralPostsForUser(th.BasicUser.Id, 0)
+		require.Nil(t, err)
+		require.Equal(t, []string{post.Id}, list.Order)
+		assert.Equal(t, "persisted", list.Posts[post.Id].Message)
+		assert.Equal(t, model.POST_EPHEMERAL, list.Posts[post.Id].Type)
+
+		// Ephemeral posts are never part of the posts of the channel
+		channelPosts, err := th.App.GetPostsPage(th.BasicChannel.Id, 0, 60)
+		require.Nil(t, err)
+		assert.NotContains(t, channelPosts.Order, post.Id)
+
+		post.Message = "updated"
+		th.App.UpdateEphemeralPost(th.BasicUser.Id, post)
+
+		list, err = th.App.GetEphemeralPostsForUser(th.BasicUser.Id, 0)
+		require.Nil(t, err)
+		require.Len(t, list.Order, 1)
+		assert.Equal(t, "updated", list.Posts[post.Id].Message)
+
+		list, err = th.App.GetEphemeralPostsForUser(th.BasicUser2.Id, 0)
+		require.Nil(t, err)
+		assert.Empty(t, list.Order)
+
+		th.App.DeleteEphemeralPost(th.BasicUser.Id, post.Id)
+
+		list, err = th.App.GetEphemeralPostsForUser(th.BasicUser.Id, 0)
+		require.Nil(t, err)
+		assert.Empty(t, list.Order)
+	})
+}
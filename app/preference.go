@@ -53,6 +53,8 @@ func (a *App) UpdatePreferences(userId string, preferences model.Preferences) *m
 		return err
 	}
 
+	a.invalidateBlockedUsers(preferences)
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_PREFERENCES_CHANGED, "", "", userId, nil)
 	message.Add("preferences", preferences.ToJson())
 	a.Publish(message)
@@ -76,9 +78,69 @@ func (a *App) DeletePreferences(userId string, preferences model.Preferences) *m
 		}
 	}
 
+	a.invalidateBlockedUsers(preferences)
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_PREFERENCES_DELETED, "", "", userId, nil)
 	message.Add("preferences", preferences.ToJson())
 	a.Publish(message)
 
 	return nil
 }
+
+func (a *App) GetPreferenceVersionsForUser(userId string) (map[string]int64, *model.AppError) {
+	return a.Srv.Store.Preference().GetVersions(userId)
+}
+
+func (a *App) GetPreferenceNamespacesForUser(userId string, namespaces []string) (map[string]*model.PreferenceNamespace, *model.AppError) {
+	for _, namespace := range namespaces {
+		if !model.IsValidPreferenceNamespace(namespace) {
+			return nil, model.NewAppError("GetPreferenceNamespacesForUser", "api.preference.namespaces.invalid.app_error", nil, "namespace="+namespace, http.StatusBadRequest)
+		}
+	}
+
+	return a.Srv.Store.Preference().GetNamespaces(userId, namespaces)
+}
+
+// PatchPreferences applies the patch to the namespaces of the preferences of a user, and sends only what changed to
+// their clients.
+func (a *App) PatchPreferences(userId string, patch model.PreferencesPatch) ([]*model.PreferenceNamespaceDiff, *model.AppError) {
+	if err := patch.IsValid(userId); err != nil {
+		return nil, err
+	}
+
+	diffs, err := a.Srv.Store.Preference().Patch(userId, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []*model.PreferenceNamespaceDiff
+	var blocked model.Preferences
+	for _, diff := range diffs {
+		if diff.IsEmpty() {
+			continue
+		}
+		changed = append(changed, diff)
+
+		if diff.Namespace != model.PREFERENCE_CATEGORY_BLOCKED_USER {
+			continue
+		}
+		for name := range diff.Set {
+			blocked = append(blocked, model.Preference{UserId: userId, Category: diff.Namespace, Name: name})
+		}
+		for _, name := range diff.Deleted {
+			blocked = append(blocked, model.Preference{UserId: userId, Category: diff.Namespace, Name: name})
+		}
+	}
+
+	if len(changed) == 0 {
+		return diffs, nil
+	}
+
+	a.invalidateBlockedUsers(blocked)
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_PREFERENCES_PATCHED, "", "", userId, nil)
+	message.Add("diffs", model.PreferenceNamespaceDiffListToJson(changed))
+	a.Publish(message)
+
+	return diffs, nil
+}
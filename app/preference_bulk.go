@@ -0,0 +1,261 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	BULK_PREFERENCES_DIRECTORY      = "bulk_preferences/"
+	BULK_PREFERENCES_USERS_PER_PAGE = 1000
+)
+
+func bulkPreferencesOperationPath(operationId string) string {
+	return BULK_PREFERENCES_DIRECTORY + operationId + "/operation.json"
+}
+
+func bulkPreferencesManifestPath(operationId string) string {
+	return BULK_PREFERENCES_DIRECTORY + operationId + "/manifest.json"
+}
+
+// CreateBulkPreferencesJob stores the operation and schedules a job to apply it. Operations are kept
+// in the file store rather than in the job data since the list of targeted users can be large.
+func (a *App) CreateBulkPreferencesJob(operation *model.BulkPreferencesOperation) (*model.Job, *model.AppError) {
+	if err := operation.IsValid(); err != nil {
+		return nil, err
+	}
+
+	jobData := map[string]string{
+		model.BULK_PREFERENCES_JOB_DATA_ACTION: operation.Action,
+	}
+
+	if operation.Action == model.BULK_PREFERENCES_ACTION_ROLLBACK {
+		original, err := a.GetJob(operation.RollbackJobId)
+		if err != nil {
+			return nil, err
+		}
+
+		if original.Type != model.JOB_TYPE_BULK_PREFERENCES {
+			return nil, model.NewAppError("CreateBulkPreferencesJob", "app.bulk_preferences.rollback.job_type.app_error", nil, "job_id="+original.Id, http.StatusBadRequest)
+		}
+
+		if original.Status != model.JOB_STATUS_SUCCESS && original.Status != model.JOB_STATUS_CANCELED {
+			return nil, model.NewAppError("CreateBulkPreferencesJob", "app.bulk_preferences.rollback.job_status.app_error", nil, "job_id="+original.Id+", status="+original.Status, http.StatusBadRequest)
+		}
+
+		jobData[model.BULK_PREFERENCES_JOB_DATA_ROLLBACK_OF] = original.Id
+	}
+
+	operationId := model.NewId()
+	if _, err := a.WriteFile(bytes.NewReader([]byte(operation.ToJson())), bulkPreferencesOperationPath(operationId)); err != nil {
+		return nil, err
+	}
+	jobData[model.BULK_PREFERENCES_JOB_DATA_OPERATION_ID] = operationId
+
+	return a.Srv.Jobs.CreateJob(model.JOB_TYPE_BULK_PREFERENCES, jobData)
+}
+
+// GetBulkPreferencesOperation returns the operation that the given bulk preferences job applies.
+func (a *App) GetBulkPreferencesOperation(job *model.Job) (*model.BulkPreferencesOperation, *model.AppError) {
+	data, err := a.ReadFile(bulkPreferencesOperationPath(job.Data[model.BULK_PREFERENCES_JOB_DATA_OPERATION_ID]))
+	if err != nil {
+		return nil, err
+	}
+
+	operation := model.BulkPreferencesOperationFromJson(bytes.NewReader(data))
+	if operation == nil {
+		return nil, model.NewAppError("GetBulkPreferencesOperation", "app.bulk_preferences.operation.parse.app_error", nil, "job_id="+job.Id, http.StatusInternalServerError)
+	}
+
+	return operation, nil
+}
+
+// GetBulkPreferencesManifest returns the rollback manifest written by a finished bulk preferences job.
+func (a *App) GetBulkPreferencesManifest(jobId string) (*model.BulkPreferencesManifest, *model.AppError) {
+	job, err := a.GetJob(jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Type != model.JOB_TYPE_BULK_PREFERENCES {
+		return nil, model.NewAppError("GetBulkPreferencesManifest", "app.bulk_preferences.rollback.job_type.app_error", nil, "job_id="+job.Id, http.StatusBadRequest)
+	}
+
+	path := bulkPreferencesManifestPath(job.Data[model.BULK_PREFERENCES_JOB_DATA_OPERATION_ID])
+	if exists, err := a.FileExists(path); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, model.NewAppError("GetBulkPreferencesManifest", "app.bulk_preferences.manifest.not_found.app_error", nil, "job_id="+job.Id, http.StatusNotFound)
+	}
+
+	data, err := a.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := model.BulkPreferencesManifestFromJson(bytes.NewReader(data))
+	if manifest == nil {
+		return nil, model.NewAppError("GetBulkPreferencesManifest", "app.bulk_preferences.manifest.parse.app_error", nil, "job_id="+job.Id, http.StatusInternalServerError)
+	}
+
+	return manifest, nil
+}
+
+// SaveBulkPreferencesManifest writes the rollback manifest for the given bulk preferences job.
+func (a *App) SaveBulkPreferencesManifest(job *model.Job, manifest *model.BulkPreferencesManifest) *model.AppError {
+	_, err := a.WriteFile(bytes.NewReader([]byte(manifest.ToJson())), bulkPreferencesManifestPath(job.Data[model.BULK_PREFERENCES_JOB_DATA_OPERATION_ID]))
+	return err
+}
+
+// GetBulkPreferencesTargetUserIds resolves the users affected by a set or reset operation.
+func (a *App) GetBulkPreferencesTargetUserIds(operation *model.BulkPreferencesOperation) ([]string, *model.AppError) {
+	if len(operation.UserIds) > 0 {
+		return operation.UserIds, nil
+	}
+
+	userIds := []string{}
+	for page := 0; ; page++ {
+		options := &model.UserGetOptions{
+			InTeamId: operation.TeamId,
+			Page:     page,
+			PerPage:  BULK_PREFERENCES_USERS_PER_PAGE,
+		}
+
+		var users []*model.User
+		var err *model.AppError
+		if operation.AllUsers {
+			users, err = a.Srv.Store.User().GetAllProfiles(options)
+		} else {
+			users, err = a.Srv.Store.User().GetProfiles(options)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			userIds = append(userIds, user.Id)
+		}
+
+		if len(users) < BULK_PREFERENCES_USERS_PER_PAGE {
+			break
+		}
+	}
+
+	return userIds, nil
+}
+
+// ApplyBulkPreferences applies a set or reset operation to the given users and returns the manifest
+// entries needed to restore the preferences to their previous state.
+func (a *App) ApplyBulkPreferences(operation *model.BulkPreferencesOperation, userIds []string) ([]*model.BulkPreferencesManifestEntry, *model.AppError) {
+	entries := []*model.BulkPreferencesManifestEntry{}
+
+	for _, userId := range userIds {
+		changes := make([]*model.BulkPreferencesManifestEntry, 0, len(operation.Preferences))
+		for _, preference := range operation.Preferences {
+			changes = append(changes, &model.BulkPreferencesManifestEntry{
+				UserId:   userId,
+				Category: preference.Category,
+				Name:     preference.Name,
+				Existed:  operation.Action == model.BULK_PREFERENCES_ACTION_SET,
+				Value:    preference.Value,
+			})
+		}
+
+		previous, err := a.applyBulkPreferencesForUser(userId, changes)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, previous...)
+	}
+
+	return entries, nil
+}
+
+// RestoreBulkPreferences restores the preferences recorded in a rollback manifest and returns the
+// manifest entries needed to undo the restore.
+func (a *App) RestoreBulkPreferences(entries []*model.BulkPreferencesManifestEntry) ([]*model.BulkPreferencesManifestEntry, *model.AppError) {
+	byUser := map[string][]*model.BulkPreferencesManifestEntry{}
+	userIds := []string{}
+	for _, entry := range entries {
+		if _, ok := byUser[entry.UserId]; !ok {
+			userIds = append(userIds, entry.UserId)
+		}
+		byUser[entry.UserId] = append(byUser[entry.UserId], entry)
+	}
+
+	previous := []*model.BulkPreferencesManifestEntry{}
+	for _, userId := range userIds {
+		userPrevious, err := a.applyBulkPreferencesForUser(userId, byUser[userId])
+		if err != nil {
+			return nil, err
+		}
+		previous = append(previous, userPrevious...)
+	}
+
+	return previous, nil
+}
+
+// applyBulkPreferencesForUser moves the given preferences of a user to the state described by the
+// entries, saving those that should exist and deleting the others, and returns their previous state.
+func (a *App) applyBulkPreferencesForUser(userId string, entries []*model.BulkPreferencesManifestEntry) ([]*model.BulkPreferencesManifestEntry, *model.AppError) {
+	current, err := a.Srv.Store.Preference().GetAll(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	currentValues := make(map[string]string, len(current))
+	for _, preference := range current {
+		currentValues[preference.Category+":"+preference.Name] = preference.Value
+	}
+
+	previous := make([]*model.BulkPreferencesManifestEntry, 0, len(entries))
+	saved := model.Preferences{}
+	deleted := model.Preferences{}
+
+	for _, entry := range entries {
+		value, existed := currentValues[entry.Category+":"+entry.Name]
+		previous = append(previous, &model.BulkPreferencesManifestEntry{
+			UserId:   userId,
+			Category: entry.Category,
+			Name:     entry.Name,
+			Existed:  existed,
+			Value:    value,
+		})
+
+		preference := model.Preference{UserId: userId, Category: entry.Category, Name: entry.Name, Value: entry.Value}
+		if entry.Existed {
+			saved = append(saved, preference)
+		} else if existed {
+			deleted = append(deleted, preference)
+		}
+	}
+
+	if len(saved) > 0 {
+		if err := a.Srv.Store.Preference().Save(&saved); err != nil {
+			return nil, err
+		}
+
+		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_PREFERENCES_CHANGED, "", "", userId, nil)
+		message.Add("preferences", saved.ToJson())
+		a.Publish(message)
+	}
+
+	if len(deleted) > 0 {
+		for _, preference := range deleted {
+			if err := a.Srv.Store.Preference().Delete(userId, preference.Category, preference.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_PREFERENCES_DELETED, "", "", userId, nil)
+		message.Add("preferences", deleted.ToJson())
+		a.Publish(message)
+	}
+
+	return previous, nil
+}
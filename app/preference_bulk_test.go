@@ -0,0 +1,84 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestApplyAndRestoreBulkPreferences(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	category := model.NewId()[:20]
+	user1 := th.BasicUser
+	user2 := th.BasicUser2
+
+	require.Nil(t, th.App.UpdatePreferences(user1.Id, model.Preferences{{UserId: user1.Id, Category: category, Name: "setting", Value: "old"}}))
+
+	operation := &model.BulkPreferencesOperation{
+		Action:      model.BULK_PREFERENCES_ACTION_SET,
+		UserIds:     []string{user1.Id, user2.Id},
+		Preferences: model.Preferences{{Category: category, Name: "setting", Value: "new"}},
+	}
+
+	entries, err := th.App.ApplyBulkPreferences(operation, operation.UserIds)
+	require.Nil(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, &model.BulkPreferencesManifestEntry{UserId: user1.Id, Category: category, Name: "setting", Existed: true, Value: "old"}, entries[0])
+	assert.Equal(t, &model.BulkPreferencesManifestEntry{UserId: user2.Id, Category: category, Name: "setting"}, entries[1])
+
+	for _, userId := range operation.UserIds {
+		preference, err := th.App.GetPreferenceByCategoryAndNameForUser(userId, category, "setting")
+		require.Nil(t, err)
+		assert.Equal(t, "new", preference.Value)
+	}
+
+	_, err = th.App.RestoreBulkPreferences(entries)
+	require.Nil(t, err)
+
+	preference, err := th.App.GetPreferenceByCategoryAndNameForUser(user1.Id, category, "setting")
+	require.Nil(t, err)
+	assert.Equal(t, "old", preference.Value)
+
+	_, err = th.App.GetPreferenceByCategoryAndNameForUser(user2.Id, category, "setting")
+	assert.NotNil(t, err)
+
+	t.Run("reset", func(t *testing.T) {
+		reset := &model.BulkPreferencesOperation{
+			Action:      model.BULK_PREFERENCES_ACTION_RESET,
+			UserIds:     []string{user1.Id, user2.Id},
+			Preferences: model.Preferences{{Category: category, Name: "setting"}},
+		}
+
+		entries, err := th.App.ApplyBulkPreferences(reset, reset.UserIds)
+		require.Nil(t, err)
+		require.Len(t, entries, 2)
+		assert.True(t, entries[0].Existed)
+		assert.False(t, entries[1].Existed)
+
+		_, err = th.App.GetPreferenceByCategoryAndNameForUser(user1.Id, category, "setting")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetBulkPreferencesTargetUserIds(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	userIds, err := th.App.GetBulkPreferencesTargetUserIds(&model.BulkPreferencesOperation{TeamId: th.BasicTeam.Id})
+	require.Nil(t, err)
+	assert.Contains(t, userIds, th.BasicUser.Id)
+	assert.Contains(t, userIds, th.BasicUser2.Id)
+
+	explicit := []string{th.BasicUser.Id}
+	userIds, err = th.App.GetBulkPreferencesTargetUserIds(&model.BulkPreferencesOperation{UserIds: explicit})
+	require.Nil(t, err)
+	assert.Equal(t, explicit, userIds)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (a *App) checkPublicPostLinksEnabled(where string) *model.AppError {
+	if !*a.Config().ServiceSettings.EnablePublicPostLinks {
+		return model.NewAppError(where, "app.public_post_link.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return nil
+}
+
+// CreatePublicPostLink creates a link that lets anyone view a post, and its thread if the link includes it.
+func (a *App) CreatePublicPostLink(link *model.PublicPostLink) (*model.PublicPostLink, *model.AppError) {
+	if err := a.checkPublicPostLinksEnabled("CreatePublicPostLink"); err != nil {
+		return nil, err
+	}
+
+	post, err := a.GetSinglePost(link.PostId)
+	if err != nil {
+		return nil, err
+	}
+	link.ChannelId = post.ChannelId
+
+	return a.Srv.Store.PublicPostLink().Save(link)
+}
+
+func (a *App) GetPublicPostLink(linkId string) (*model.PublicPostLink, *model.AppError) {
+	if err := a.checkPublicPostLinksEnabled("GetPublicPostLink"); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.PublicPostLink().Get(linkId)
+}
+
+// GetPublicPostLinks returns the links of a post that haven't been revoked.
+func (a *App) GetPublicPostLinks(postId string) ([]*model.PublicPostLink, *model.AppError) {
+	if err := a.checkPublicPostLinksEnabled("GetPublicPostLinks"); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.PublicPostLink().GetForPost(postId)
+}
+
+func (a *App) RevokePublicPostLink(link *model.PublicPostLink) *model.AppError {
+	return a.Srv.Store.PublicPostLink().Revoke(link.Id, model.GetMillis())
+}
+
+func (a *App) GeneratePublicPostLink(siteURL string, link *model.PublicPostLink) string {
+	hash := GeneratePublicLinkHash(link.Id, *a.Config().FileSettings.PublicLinkSalt)
+	return fmt.Sprintf("%s/posts/%v/public?h=%s", siteURL, link.Id, hash)
+}
+
+// GetPublicPostLinkPosts returns the posts a public link shows after checking the hash it was signed with. Revoked
+// links and links to deleted posts are treated as if they didn't exist. Changing the public link salt invalidates
+// every link at once.
+func (a *App) GetPublicPostLinkPosts(linkId, hash string) (*model.PublicPostLink, *model.PostList, *model.AppError) {
+	if err := a.checkPublicPostLinksEnabled("GetPublicPostLinkPosts"); err != nil {
+		return nil, nil, err
+	}
+
+	link, err := a.Srv.Store.PublicPostLink().Get(linkId)
+	if err != nil {
+		if err.StatusCode == http.StatusNotFound {
+			return nil, nil, model.NewAppError("GetPublicPostLinkPosts", "app.public_post_link.invalid.app_error", nil, "link_id="+linkId, http.StatusNotFound)
+		}
+		return nil, nil, err
+	}
+
+	if hash == "" || subtle.ConstantTimeCompare([]byte(hash), []byte(GeneratePublicLinkHash(link.Id, *a.Config().FileSettings.PublicLinkSalt))) != 1 {
+		return nil, nil, model.NewAppError("GetPublicPostLinkPosts", "app.public_post_link.invalid.app_error", nil, "link_id="+linkId, http.StatusBadRequest)
+	}
+
+	if link.IsRevoked() {
+		return nil, nil, model.NewAppError("GetPublicPostLinkPosts", "app.public_post_link.invalid.app_error", nil, "link_id="+linkId, http.StatusNotFound)
+	}
+
+	post, err := a.GetSinglePost(link.PostId)
+	if err != nil {
+		return nil, nil, model.NewAppError("GetPublicPostLinkPosts", "app.public_post_link.invalid.app_error", nil, "link_id="+linkId+", "+err.Error(), http.StatusNotFound)
+	}
+
+	if !link.IncludeThread {
+		list := model.NewPostList()
+		list.AddPost(post)
+		list.AddOrder(post.Id)
+		return link, list, nil
+	}
+
+	rootId := post.RootId
+	if rootId == "" {
+		rootId = post.Id
+	}
+
+	list, err := a.GetPostThread(rootId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return link, list, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestGetPublicPostLinkPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	root := th.CreatePost(th.BasicChannel)
+	reply, err := th.App.CreatePostAsUser(&model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, RootId: root.Id, ParentId: root.Id, Message: "reply"}, "")
+	require.Nil(t, err)
+
+	_, err = th.App.CreatePublicPostLink(&model.PublicPostLink{CreatorId: th.BasicUser.Id, PostId: reply.Id})
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotImplemented, err.StatusCode)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnablePublicPostLinks = true
+		*cfg.FileSettings.PublicLinkSalt = model.NewRandomString(32)
+	})
+
+	postLink, err := th.App.CreatePublicPostLink(&model.PublicPostLink{CreatorId: th.BasicUser.Id, PostId: reply.Id})
+	require.Nil(t, err)
+	assert.Equal(t, th.BasicChannel.Id, postLink.ChannelId)
+
+	threadLink, err := th.App.CreatePublicPostLink(&model.PublicPostLink{CreatorId: th.BasicUser.Id, PostId: reply.Id, IncludeThread: true})
+	require.Nil(t, err)
+
+	hash := func(link *model.PublicPostLink) string {
+		return GeneratePublicLinkHash(link.Id, *th.App.Config().FileSettings.PublicLinkSalt)
+	}
+
+	_, list, err := th.App.GetPublicPostLinkPosts(postLink.Id, hash(postLink))
+	require.Nil(t, err)
+	assert.Len(t, list.Posts, 1)
+	assert.Contains(t, list.Posts, reply.Id)
+
+	_, list, err = th.App.GetPublicPostLinkPosts(threadLink.Id, hash(threadLink))
+	require.Nil(t, err)
+	assert.Len(t, list.Posts, 2)
+	assert.Contains(t, list.Posts, root.Id)
+
+	_, _, err = th.App.GetPublicPostLinkPosts(postLink.Id, hash(threadLink))
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+
+	require.Nil(t, th.App.RevokePublicPostLink(postLink))
+	_, _, err = th.App.GetPublicPostLinkPosts(postLink.Id, hash(postLink))
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	_, err = th.App.DeletePost(reply.Id, th.BasicUser.Id)
+	require.Nil(t, err)
+	_, _, err = th.App.GetPublicPostLinkPosts(threadLink.Id, hash(threadLink))
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
@@ -6,6 +6,7 @@ package app
 import (
 	"net/http"
 
+	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
 )
 
@@ -48,6 +49,14 @@ func (a *App) SaveReactionForPost(reaction *model.Reaction) (*model.Reaction, *m
 		a.sendReactionEvent(model.WEBSOCKET_EVENT_REACTION_ADDED, reaction, post, true)
 	})
 
+	a.Srv.Go(func() {
+		if err := a.handleReactionWebhookEvents(reaction, post, channel); err != nil {
+			mlog.Error("Failed to handle outgoing webhook events for reaction", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	})
+
+	a.queueReactionNotification(reaction, post)
+
 	return reaction, nil
 }
 
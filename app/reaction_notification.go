@@ -0,0 +1,236 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const REACTION_NOTIFICATION_BATCH_INTERVAL = 30 * time.Second
+
+type reactionNotificationBatch struct {
+	userIds    []string
+	emojiNames []string
+}
+
+// reactionNotificationBatcher gathers the reactions to each post over a short interval, so that the author of a post
+// getting many reactions at once is notified of them together instead of once per reaction. The batches are kept per
+// server and are not shared across a cluster.
+type reactionNotificationBatcher struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	batches  map[string]*reactionNotificationBatch
+}
+
+func newReactionNotificationBatcher() *reactionNotificationBatcher {
+	return &reactionNotificationBatcher{
+		interval: REACTION_NOTIFICATION_BATCH_INTERVAL,
+		batches:  map[string]*reactionNotificationBatch{},
+	}
+}
+
+// add adds a reaction to the batch of its post, returning whether it started the batch, which is then due to be sent
+// once the interval has passed.
+func (b *reactionNotificationBatcher) add(reaction *model.Reaction) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	batch, ok := b.batches[reaction.PostId]
+	if !ok {
+		batch = &reactionNotificationBatch{}
+		b.batches[reaction.PostId] = batch
+	}
+
+	if !utils.StringInSlice(reaction.UserId, batch.userIds) {
+		batch.userIds = append(batch.userIds, reaction.UserId)
+	}
+
+	if !utils.StringInSlice(reaction.EmojiName, batch.emojiNames) {
+		batch.emojiNames = append(batch.emojiNames, reaction.EmojiName)
+	}
+
+	return !ok
+}
+
+// take removes the batch of a post and returns it, or nil if there is none.
+func (b *reactionNotificationBatcher) take(postId string) *reactionNotificationBatch {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	batch := b.batches[postId]
+	delete(b.batches, postId)
+
+	return batch
+}
+
+// queueReactionNotification adds a reaction to the notifications for the author of its post, which are sent together
+// once the batch interval has passed.
+func (a *App) queueReactionNotification(reaction *model.Reaction, post *model.Post) {
+	if reaction.UserId == post.UserId || post.IsSystemMessage() || post.Props["from_webhook"] == "true" {
+		return
+	}
+
+	if !a.Srv.reactionNotifications.add(reaction) {
+		return
+	}
+
+	time.AfterFunc(a.Srv.reactionNotifications.interval, func() {
+		a.Srv.Go(func() {
+			a.sendReactionNotification(post.Id)
+		})
+	})
+}
+
+// sendReactionNotification notifies the author of a post of the batch of reactions to it, as they want to be notified
+// of reactions in its channel. Every level shows a badge through a websocket event, to which push sends a push
+// notification and dm a direct message from the system bot.
+func (a *App) sendReactionNotification(postId string) {
+	batch := a.Srv.reactionNotifications.take(postId)
+	if batch == nil {
+		return
+	}
+
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return
+	}
+
+	author, err := a.GetUser(post.UserId)
+	if err != nil || author.IsBot || author.DeleteAt != 0 {
+		return
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil || channel.DeleteAt != 0 {
+		return
+	}
+
+	// Authors who have left the channel aren't notified of reactions to their posts in it anymore
+	member, err := a.GetChannelMember(channel.Id, author.Id)
+	if err != nil {
+		return
+	}
+
+	level := author.GetReactionsNotifyLevel(member.NotifyProps)
+	if level == model.REACTIONS_NOTIFY_NONE {
+		return
+	}
+
+	reactors, err := a.Srv.Store.User().GetProfileByIds(batch.userIds, &store.UserGetByIdsOpts{}, true)
+	if err != nil || len(reactors) == 0 {
+		mlog.Warn("Failed to get the users who reacted to a post", mlog.String("post_id", post.Id), mlog.Err(err))
+		return
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_REACTION_NOTIFICATION, "", channel.Id, author.Id, nil)
+	message.Add("post_id", post.Id)
+	message.Add("user_ids", model.ArrayToJson(batch.userIds))
+	message.Add("emoji_names", model.ArrayToJson(batch.emojiNames))
+	a.Publish(message)
+
+	text := a.getReactionNotificationMessage(author, reactors, batch.emojiNames)
+
+	switch level {
+	case model.REACTIONS_NOTIFY_PUSH:
+		if !*a.Config().EmailSettings.SendPushNotifications {
+			return
+		}
+
+		if err := a.sendReactionPushNotification(post, channel, author, reactors[0], text); err != nil {
+			mlog.Error("Failed to send a push notification for reactions", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+
+	case model.REACTIONS_NOTIFY_DM:
+		if err := a.sendReactionDirectMessage(post, channel, author, text); err != nil {
+			mlog.Error("Failed to send a direct message for reactions", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	}
+}
+
+func (a *App) getReactionNotificationMessage(author *model.User, reactors []*model.User, emojiNames []string) string {
+	T := utils.GetUserTranslations(author.Locale)
+
+	emojis := make([]string, 0, len(emojiNames))
+	for _, emojiName := range emojiNames {
+		emojis = append(emojis, ":"+emojiName+":")
+	}
+
+	data := map[string]interface{}{
+		"Name":   reactors[0].GetDisplayName(a.GetNotificationNameFormat(author)),
+		"Count":  len(reactors) - 1,
+		"Emojis": strings.Join(emojis, " "),
+	}
+
+	if len(reactors) == 1 {
+		return T("app.reaction.notification.single", data)
+	}
+	return T("app.reaction.notification.multiple", data)
+}
+
+func (a *App) sendReactionPushNotification(post *model.Post, channel *model.Channel, author *model.User, sender *model.User, text string) *model.AppError {
+	msg := model.PushNotification{
+		Version:   model.PUSH_MESSAGE_V2,
+		Type:      model.PUSH_TYPE_MESSAGE,
+		TeamId:    channel.TeamId,
+		ChannelId: channel.Id,
+		PostId:    post.Id,
+		RootId:    post.RootId,
+		SenderId:  sender.Id,
+		Message:   text,
+		// Replaces the notification of earlier reactions to the post rather than the one of the post itself
+		CollapseKey: "reactions_" + post.Id,
+	}
+
+	msg.ThreadId = channel.Id
+	if post.RootId != "" {
+		msg.ThreadId = post.RootId
+	}
+
+	if unreadCount, err := a.Srv.Store.User().GetUnreadCount(author.Id); err != nil {
+		msg.Badge = 1
+	} else {
+		msg.Badge = int(unreadCount)
+	}
+
+	return a.sendPushNotificationToUser(msg, author.Id)
+}
+
+func (a *App) sendReactionDirectMessage(post *model.Post, channel *model.Channel, author *model.User, text string) *model.AppError {
+	bot, err := a.GetSystemBot()
+	if err != nil {
+		return err
+	}
+
+	dm, err := a.GetOrCreateDirectChannel(bot.UserId, author.Id)
+	if err != nil {
+		return err
+	}
+
+	// Direct and group messages don't belong to a team, so their permalinks use one of the author's teams
+	var team *model.Team
+	if channel.TeamId != "" {
+		team, err = a.GetTeam(channel.TeamId)
+	} else if teams, teamsErr := a.GetTeamsForUser(author.Id); teamsErr == nil && len(teams) > 0 {
+		team = teams[0]
+	}
+
+	if err == nil && team != nil {
+		text += "\n" + a.GetSiteURL() + "/" + team.Name + "/pl/" + post.Id
+	}
+
+	_, err = a.CreatePost(&model.Post{
+		ChannelId: dm.Id,
+		UserId:    bot.UserId,
+		Message:   text,
+	}, dm, false)
+
+	return err
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestReactionNotificationBatcher(t *testing.T) {
+	batcher := newReactionNotificationBatcher()
+	postId := model.NewId()
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+
+	assert.True(t, batcher.add(&model.Reaction{PostId: postId, UserId: userId1, EmojiName: "smile"}))
+	assert.False(t, batcher.add(&model.Reaction{PostId: postId, UserId: userId1, EmojiName: "+1"}))
+	assert.False(t, batcher.add(&model.Reaction{PostId: postId, UserId: userId2, EmojiName: "smile"}))
+	assert.True(t, batcher.add(&model.Reaction{PostId: model.NewId(), UserId: userId2, EmojiName: "smile"}))
+
+	batch := batcher.take(postId)
+	require.NotNil(t, batch)
+	assert.Equal(t, []string{userId1, userId2}, batch.userIds)
+	assert.Equal(t, []string{"smile", "+1"}, batch.emojiNames)
+
+	assert.Nil(t, batcher.take(postId))
+	assert.True(t, batcher.add(&model.Reaction{PostId: postId, UserId: userId1, EmojiName: "smile"}))
+}
+
+func TestReactionNotifications(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv.reactionNotifications.interval = 100 * time.Millisecond
+
+	bot, err := th.App.GetSystemBot()
+	require.Nil(t, err)
+
+	dm, err := th.App.GetOrCreateDirectChannel(bot.UserId, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	getNotifications := func() []*model.Post {
+		// The notifications are sent in the background once the batch interval has passed
+		time.Sleep(500 * time.Millisecond)
+
+		posts, err := th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, err)
+		return posts.ToSlice()
+	}
+
+	props := th.BasicUser.NotifyProps
+	props[model.REACTIONS_NOTIFY_PROP] = model.REACTIONS_NOTIFY_DM
+	_, err = th.App.UpdateUserNotifyProps(th.BasicUser.Id, props)
+	require.Nil(t, err)
+
+	t.Run("reactions are batched", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+
+		for _, emojiName := range []string{"smile", "+1"} {
+			_, err = th.App.SaveReactionForPost(&model.Reaction{PostId: post.Id, UserId: th.BasicUser2.Id, EmojiName: emojiName})
+			require.Nil(t, err)
+		}
+
+		// Reacting to your own post doesn't notify you
+		_, err = th.App.SaveReactionForPost(&model.Reaction{PostId: post.Id, UserId: th.BasicUser.Id, EmojiName: "smile"})
+		require.Nil(t, err)
+
+		posts := getNotifications()
+		require.Len(t, posts, 1)
+		assert.Contains(t, posts[0].Message, "reacted to your post with :smile: :+1:")
+		assert.Contains(t, posts[0].Message, "/pl/"+post.Id)
+	})
+
+	t.Run("the channel overrides the user's preference", func(t *testing.T) {
+		_, err = th.App.UpdateChannelMemberNotifyProps(map[string]string{model.REACTIONS_NOTIFY_PROP: model.REACTIONS_NOTIFY_NONE}, th.BasicChannel.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+
+		post := th.CreatePost(th.BasicChannel)
+		_, err = th.App.SaveReactionForPost(&model.Reaction{PostId: post.Id, UserId: th.BasicUser2.Id, EmojiName: "smile"})
+		require.Nil(t, err)
+
+		require.Len(t, getNotifications(), 1)
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// RECURRING_POSTS_BATCH_SIZE is how many due posts are read from the store at a time.
+const RECURRING_POSTS_BATCH_SIZE = 100
+
+func (a *App) GetRecurringPost(recurringPostId string) (*model.RecurringPost, *model.AppError) {
+	return a.Srv.Store.RecurringPost().Get(recurringPostId)
+}
+
+func (a *App) GetRecurringPostsForChannel(channelId string) ([]*model.RecurringPost, *model.AppError) {
+	return a.Srv.Store.RecurringPost().GetForChannel(channelId)
+}
+
+func (a *App) CreateRecurringPost(recurringPost *model.RecurringPost) (*model.RecurringPost, *model.AppError) {
+	if !*a.Config().RecurringPostSettings.Enable {
+		return nil, model.NewAppError("CreateRecurringPost", "app.recurring_post.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	existing, err := a.Srv.Store.RecurringPost().GetForChannel(recurringPost.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing) >= model.RECURRING_POST_MAX_PER_CHANNEL {
+		return nil, model.NewAppError("CreateRecurringPost", "app.recurring_post.too_many.app_error", map[string]interface{}{"Max": model.RECURRING_POST_MAX_PER_CHANNEL}, "channel_id="+recurringPost.ChannelId, http.StatusBadRequest)
+	}
+
+	if err := a.checkRecurringPostBot(recurringPost); err != nil {
+		return nil, err
+	}
+
+	recurringPost.Id = ""
+	recurringPost.LastRunAt = 0
+	a.scheduleRecurringPost(recurringPost, time.Now())
+
+	return a.Srv.Store.RecurringPost().Save(recurringPost)
+}
+
+// UpdateRecurringPost edits the message, bot or schedule of a recurring post, which is next posted according to its
+// new schedule.
+func (a *App) UpdateRecurringPost(recurringPost *model.RecurringPost) (*model.RecurringPost, *model.AppError) {
+	oldRecurringPost, err := a.Srv.Store.RecurringPost().Get(recurringPost.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.checkRecurringPostBot(recurringPost); err != nil {
+		return nil, err
+	}
+
+	recurringPost.CreateAt = oldRecurringPost.CreateAt
+	recurringPost.ChannelId = oldRecurringPost.ChannelId
+	recurringPost.CreatorId = oldRecurringPost.CreatorId
+	recurringPost.LastRunAt = oldRecurringPost.LastRunAt
+	a.scheduleRecurringPost(recurringPost, time.Now())
+
+	return a.Srv.Store.RecurringPost().Update(recurringPost)
+}
+
+// SetRecurringPostPaused pauses or resumes a recurring post. Resumed posts aren't caught up on the runs they missed
+// while paused.
+func (a *App) SetRecurringPostPaused(recurringPost *model.RecurringPost, paused bool) (*model.RecurringPost, *model.AppError) {
+	recurringPost.Paused = paused
+	a.scheduleRecurringPost(recurringPost, time.Now())
+
+	return a.Srv.Store.RecurringPost().Update(recurringPost)
+}
+
+func (a *App) DeleteRecurringPost(recurringPostId string) *model.AppError {
+	return a.Srv.Store.RecurringPost().Delete(recurringPostId)
+}
+
+// HasDueRecurringPosts reports whether a recurring post is due to be posted.
+func (a *App) HasDueRecurringPosts() (bool, *model.AppError) {
+	due, err := a.Srv.Store.RecurringPost().GetDue(model.GetMillis(), 1)
+	if err != nil {
+		return false, err
+	}
+
+	return len(due) > 0, nil
+}
+
+// PostDueRecurringPosts posts the recurring posts that are due and schedules their next run, returning how many
+// were posted and how many failed. Posts whose channel was archived or whose bot was disabled are paused.
+func (a *App) PostDueRecurringPosts() (int, int, *model.AppError) {
+	nowMillis := model.GetMillis()
+	now := time.Unix(0, nowMillis*int64(time.Millisecond))
+	posted := 0
+	failed := 0
+
+	for {
+		due, err := a.Srv.Store.RecurringPost().GetDue(nowMillis, RECURRING_POSTS_BATCH_SIZE)
+		if err != nil {
+			return posted, failed, err
+		}
+
+		if len(due) == 0 {
+			return posted, failed, nil
+		}
+
+		for _, recurringPost := range due {
+			if err := a.postRecurringPost(recurringPost); err != nil {
+				mlog.Warn("Failed to post a recurring post", mlog.String("recurring_post_id", recurringPost.Id), mlog.String("channel_id", recurringPost.ChannelId), mlog.Err(err))
+				failed++
+			} else {
+				posted++
+			}
+
+			recurringPost.LastRunAt = model.GetMillis()
+			a.scheduleRecurringPost(recurringPost, now)
+
+			// Every due post is either paused or rescheduled after now, so that the loop ends
+			if _, err := a.Srv.Store.RecurringPost().Update(recurringPost); err != nil {
+				return posted, failed, err
+			}
+		}
+	}
+}
+
+func (a *App) postRecurringPost(recurringPost *model.RecurringPost) *model.AppError {
+	channel, err := a.GetChannel(recurringPost.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	bot, err := a.GetUser(recurringPost.BotUserId)
+	if err != nil {
+		return err
+	}
+
+	if channel.DeleteAt != 0 || bot.DeleteAt != 0 {
+		recurringPost.Paused = true
+		return model.NewAppError("postRecurringPost", "app.recurring_post.paused.app_error", nil, "id="+recurringPost.Id, http.StatusBadRequest)
+	}
+
+	_, err = a.CreatePost(&model.Post{
+		ChannelId: channel.Id,
+		UserId:    bot.Id,
+		Message:   recurringPost.Message,
+	}, channel, false)
+	return err
+}
+
+// scheduleRecurringPost sets when a recurring post is next due after the given time, unless it's paused.
+func (a *App) scheduleRecurringPost(recurringPost *model.RecurringPost, after time.Time) {
+	if recurringPost.Paused {
+		recurringPost.NextRunAt = 0
+		return
+	}
+
+	recurringPost.SetNextRunAt(after)
+}
+
+// checkRecurringPostBot checks that a recurring post is posted by an active bot that's a member of its channel.
+func (a *App) checkRecurringPostBot(recurringPost *model.RecurringPost) *model.AppError {
+	bot, err := a.GetBot(recurringPost.BotUserId, false)
+	if err != nil {
+		return model.NewAppError("checkRecurringPostBot", "app.recurring_post.invalid_bot.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	if _, err := a.Srv.Store.Channel().GetMember(recurringPost.ChannelId, bot.UserId); err != nil {
+		return model.NewAppError("checkRecurringPostBot", "app.recurring_post.bot_not_member.app_error", nil, "bot_user_id="+bot.UserId, http.StatusBadRequest)
+	}
+
+	return nil
+}
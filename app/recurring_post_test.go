@@ -0,0 +1,120 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestRecurringPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	bot, err := th.App.CreateBot(&model.Bot{
+		Username:    "standupbot",
+		Description: "Posts standup reminders",
+		OwnerId:     th.BasicUser.Id,
+	})
+	require.Nil(t, err)
+	defer th.App.PermanentDeleteBot(bot.UserId)
+
+	newRecurringPost := func() *model.RecurringPost {
+		return &model.RecurringPost{
+			ChannelId: th.BasicChannel.Id,
+			CreatorId: th.BasicUser.Id,
+			BotUserId: bot.UserId,
+			Message:   "Time for standup!",
+			Schedule:  "0 9 * * 1-5",
+		}
+	}
+
+	_, err = th.App.CreateRecurringPost(newRecurringPost())
+	require.NotNil(t, err)
+	assert.Equal(t, "app.recurring_post.disabled.app_error", err.Id)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.RecurringPostSettings.Enable = true })
+
+	_, err = th.App.CreateRecurringPost(newRecurringPost())
+	require.NotNil(t, err)
+	assert.Equal(t, "app.recurring_post.bot_not_member.app_error", err.Id)
+
+	botUser, err := th.App.GetUser(bot.UserId)
+	require.Nil(t, err)
+	th.LinkUserToTeam(botUser, th.BasicTeam)
+	th.AddUserToChannel(botUser, th.BasicChannel)
+
+	recurringPost, err := th.App.CreateRecurringPost(newRecurringPost())
+	require.Nil(t, err)
+	assert.True(t, recurringPost.NextRunAt > model.GetMillis())
+
+	t.Run("posts due recurring posts and schedules the next run", func(t *testing.T) {
+		recurringPost.NextRunAt = model.GetMillis() - 1000
+		_, err = th.App.Srv.Store.RecurringPost().Update(recurringPost)
+		require.Nil(t, err)
+
+		posted, failed, err := th.App.PostDueRecurringPosts()
+		require.Nil(t, err)
+		assert.Equal(t, 1, posted)
+		assert.Equal(t, 0, failed)
+
+		posts, err := th.App.GetPosts(th.BasicChannel.Id, 0, 1)
+		require.Nil(t, err)
+		require.Len(t, posts.Order, 1)
+		post := posts.Posts[posts.Order[0]]
+		assert.Equal(t, bot.UserId, post.UserId)
+		assert.Equal(t, "Time for standup!", post.Message)
+
+		recurringPost, err = th.App.GetRecurringPost(recurringPost.Id)
+		require.Nil(t, err)
+		assert.NotZero(t, recurringPost.LastRunAt)
+		assert.True(t, recurringPost.NextRunAt > model.GetMillis())
+
+		due, err := th.App.HasDueRecurringPosts()
+		require.Nil(t, err)
+		assert.False(t, due)
+	})
+
+	t.Run("pauses and resumes", func(t *testing.T) {
+		recurringPost, err = th.App.SetRecurringPostPaused(recurringPost, true)
+		require.Nil(t, err)
+		assert.Zero(t, recurringPost.NextRunAt)
+
+		recurringPost, err = th.App.SetRecurringPostPaused(recurringPost, false)
+		require.Nil(t, err)
+		assert.True(t, recurringPost.NextRunAt > model.GetMillis())
+	})
+
+	t.Run("pauses posts of archived channels", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		th.AddUserToChannel(botUser, channel)
+
+		archived := newRecurringPost()
+		archived.ChannelId = channel.Id
+		archived, err = th.App.CreateRecurringPost(archived)
+		require.Nil(t, err)
+
+		require.Nil(t, th.App.DeleteChannel(channel, th.BasicUser.Id))
+
+		archived.NextRunAt = model.GetMillis() - 1000
+		_, err = th.App.Srv.Store.RecurringPost().Update(archived)
+		require.Nil(t, err)
+
+		_, failed, err := th.App.PostDueRecurringPosts()
+		require.Nil(t, err)
+		assert.Equal(t, 1, failed)
+
+		archived, err = th.App.GetRecurringPost(archived.Id)
+		require.Nil(t, err)
+		assert.True(t, archived.Paused)
+	})
+
+	require.Nil(t, th.App.DeleteRecurringPost(recurringPost.Id))
+	_, err = th.App.GetRecurringPost(recurringPost.Id)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const REMINDERS_DUE_BATCH_SIZE = 100
+
+// AddReminder schedules a message the system bot sends to a user at the given time.
+func (a *App) AddReminder(userId, message string, remindAt int64) (*model.Reminder, *model.AppError) {
+	reminders, err := a.Srv.Store.Reminder().GetPendingForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reminders) >= model.REMINDER_MAX_PER_USER {
+		return nil, model.NewAppError("AddReminder", "app.reminder.too_many.app_error", map[string]interface{}{"Max": model.REMINDER_MAX_PER_USER}, "user_id="+userId, http.StatusBadRequest)
+	}
+
+	return a.Srv.Store.Reminder().Save(&model.Reminder{UserId: userId, Message: strings.TrimSpace(message), RemindAt: remindAt})
+}
+
+// GetPendingReminders returns the reminders of a user that weren't sent yet, the soonest first.
+func (a *App) GetPendingReminders(userId string) ([]*model.Reminder, *model.AppError) {
+	return a.Srv.Store.Reminder().GetPendingForUser(userId)
+}
+
+// DeleteReminder cancels a reminder of a user.
+func (a *App) DeleteReminder(userId, reminderId string) *model.AppError {
+	reminder, err := a.Srv.Store.Reminder().Get(reminderId)
+	if err != nil {
+		return err
+	}
+
+	if reminder.UserId != userId {
+		return model.NewAppError("DeleteReminder", "app.reminder.not_found.app_error", nil, "id="+reminderId, http.StatusNotFound)
+	}
+
+	return a.Srv.Store.Reminder().Delete(reminderId)
+}
+
+// SendDueReminders sends the reminders whose time has come. Each reminder is marked as sent before it is sent, so that
+// it is only sent once when several servers look for due reminders at the same time.
+func (a *App) SendDueReminders() {
+	for {
+		reminders, err := a.Srv.Store.Reminder().GetDue(model.GetMillis(), REMINDERS_DUE_BATCH_SIZE)
+		if err != nil {
+			mlog.Error("Failed to get the due reminders", mlog.Err(err))
+			return
+		}
+
+		for _, reminder := range reminders {
+			a.sendReminder(reminder)
+		}
+
+		if len(reminders) < REMINDERS_DUE_BATCH_SIZE {
+			return
+		}
+	}
+}
+
+func (a *App) sendReminder(reminder *model.Reminder) {
+	if sent, err := a.Srv.Store.Reminder().MarkSent(reminder.Id, model.GetMillis()); err != nil || !sent {
+		if err != nil {
+			mlog.Error("Failed to mark a reminder as sent", mlog.String("reminder_id", reminder.Id), mlog.Err(err))
+		}
+		return
+	}
+
+	user, err := a.GetUser(reminder.UserId)
+	if err != nil {
+		mlog.Error("Failed to get the user of a reminder", mlog.String("reminder_id", reminder.Id), mlog.Err(err))
+		return
+	}
+
+	if user.DeleteAt != 0 {
+		return
+	}
+
+	T := utils.GetUserTranslations(user.Locale)
+	if err := a.SendSystemBotMessage(user.Id, T("app.reminder.message", map[string]interface{}{"Message": reminder.Message})); err != nil {
+		mlog.Error("Failed to send a reminder", mlog.String("reminder_id", reminder.Id), mlog.Err(err))
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"strings"
 
+	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
 )
 
@@ -172,3 +174,153 @@ func (a *App) GetSamlCertificateStatus() *model.SamlCertificateStatus {
 
 	return status
 }
+
+// SyncSamlGroups brings the SAML groups of a user in line with the values of SamlSettings.GroupAttribute in the
+// assertion they logged in with, and adds them to the teams and channels those groups are linked to. It is called on
+// SAML login, so that users get their memberships right away rather than on the next group sync. Removing users from group constrained teams and channels is still left to the sync job.
+func (a *App) SyncSamlGroups(user *model.User, remoteIds []string) *model.AppError {
+	if *a.Config().SamlSettings.GroupAttribute == "" {
+		return nil
+	}
+
+	if license := a.License(); license == nil || !*license.Features.LDAPGroups {
+		return nil
+	}
+
+	wanted := make(map[string]bool)
+	var groups []*model.Group
+	for _, remoteId := range remoteIds {
+		remoteId = strings.TrimSpace(remoteId)
+		if remoteId == "" || wanted[remoteId] {
+			continue
+		}
+		wanted[remoteId] = true
+
+		if len(remoteId) > model.GroupRemoteIDMaxLength {
+			mlog.Warn("Skipping a SAML group with a name too long", mlog.String("user_id", user.Id), mlog.String("group", remoteId))
+			continue
+		}
+
+		group, err := a.getOrCreateSamlGroup(remoteId)
+		if err != nil {
+			return err
+		}
+
+		// A group deleted by an admin stays deleted, instead of being created again by the next login.
+		if group.DeleteAt != 0 {
+			continue
+		}
+
+		if _, err := a.UpsertGroupMember(group.Id, user.Id); err != nil {
+			return err
+		}
+		groups = append(groups, group)
+	}
+
+	userGroups, err := a.Srv.Store.Group().GetByUser(user.Id)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range userGroups {
+		if group.Source == model.GroupSourceSaml && !wanted[group.RemoteId] {
+			if _, err := a.DeleteGroupMember(group.Id, user.Id); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, group := range groups {
+		if err := a.addSamlGroupMemberships(user, group); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *App) getOrCreateSamlGroup(remoteId string) (*model.Group, *model.AppError) {
+	group, err := a.GetGroupByRemoteID(remoteId, model.GroupSourceSaml)
+	if err == nil {
+		return group, nil
+	}
+
+	if err.StatusCode != http.StatusNotFound {
+		return nil, err
+	}
+
+	group, err = a.CreateGroup(&model.Group{
+		Name:        model.NewId(),
+		DisplayName: remoteId,
+		Source:      model.GroupSourceSaml,
+		RemoteId:    remoteId,
+	})
+	if err != nil {
+		// Another login may have created the group in the meantime.
+		if existing, getErr := a.GetGroupByRemoteID(remoteId, model.GroupSourceSaml); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// addSamlGroupMemberships adds a user to the teams and channels a group automatically adds its members to, unless
+// they left them already.
+func (a *App) addSamlGroupMemberships(user *model.User, group *model.Group) *model.AppError {
+	teamSyncables, err := a.GetGroupSyncables(group.Id, model.GroupSyncableTypeTeam)
+	if err != nil {
+		return err
+	}
+
+	for _, syncable := range teamSyncables {
+		if !syncable.AutoAdd || syncable.DeleteAt != 0 {
+			continue
+		}
+
+		if err := a.addSamlGroupTeamMember(user, syncable.SyncableId); err != nil {
+			return err
+		}
+	}
+
+	channelSyncables, err := a.GetGroupSyncables(group.Id, model.GroupSyncableTypeChannel)
+	if err != nil {
+		return err
+	}
+
+	for _, syncable := range channelSyncables {
+		if !syncable.AutoAdd || syncable.DeleteAt != 0 {
+			continue
+		}
+
+		channel, err := a.GetChannel(syncable.SyncableId)
+		if err != nil {
+			return err
+		}
+
+		if err := a.addSamlGroupTeamMember(user, channel.TeamId); err != nil {
+			return err
+		}
+
+		if _, err := a.AddChannelMember(user.Id, channel, "", ""); err != nil {
+			if err.Id == "api.channel.add_user.to.channel.failed.deleted.app_error" {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *App) addSamlGroupTeamMember(user *model.User, teamId string) *model.AppError {
+	if _, err := a.GetTeamMember(teamId, user.Id); err == nil {
+		return nil
+	} else if err.Id != "store.sql_team.get_member.missing.app_error" {
+		return err
+	}
+
+	_, err := a.AddTeamMember(teamId, user.Id)
+	return err
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2017-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestSyncSamlGroups(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.SetLicense(model.NewTestLicense("ldap_groups"))
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SamlSettings.GroupAttribute = "groups" })
+
+	team := th.CreateTeam()
+	channel := th.CreateChannel(team)
+
+	engineers, err := th.App.CreateGroup(&model.Group{
+		Name:        model.NewId(),
+		DisplayName: "engineers",
+		RemoteId:    "engineers",
+		Source:      model.GroupSourceSaml,
+	})
+	require.Nil(t, err)
+
+	_, err = th.App.CreateGroupSyncable(model.NewGroupTeam(engineers.Id, team.Id, true))
+	require.Nil(t, err)
+	_, err = th.App.CreateGroupSyncable(model.NewGroupChannel(engineers.Id, channel.Id, true))
+	require.Nil(t, err)
+
+	user := th.CreateUser()
+
+	t.Run("disabled without a group attribute", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SamlSettings.GroupAttribute = "" })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SamlSettings.GroupAttribute = "groups" })
+
+		require.Nil(t, th.App.SyncSamlGroups(user, []string{"engineers"}))
+
+		groups, err := th.App.Srv.Store.Group().GetByUser(user.Id)
+		require.Nil(t, err)
+		assert.Empty(t, groups)
+	})
+
+	t.Run("memberships are added on login", func(t *testing.T) {
+		require.Nil(t, th.App.SyncSamlGroups(user, []string{"engineers", "designers", "designers"}))
+
+		groups, err := th.App.Srv.Store.Group().GetByUser(user.Id)
+		require.Nil(t, err)
+		require.Len(t, groups, 2)
+
+		designers, err := th.App.GetGroupByRemoteID("designers", model.GroupSourceSaml)
+		require.Nil(t, err)
+		assert.Equal(t, "designers", designers.DisplayName)
+
+		_, err = th.App.GetTeamMember(team.Id, user.Id)
+		require.Nil(t, err)
+		_, err = th.App.GetChannelMember(channel.Id, user.Id)
+		require.Nil(t, err)
+	})
+
+	t.Run("groups missing from the assertion are left", func(t *testing.T) {
+		require.Nil(t, th.App.SyncSamlGroups(user, []string{"designers"}))
+
+		groups, err := th.App.Srv.Store.Group().GetByUser(user.Id)
+		require.Nil(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, "designers", groups[0].RemoteId)
+	})
+
+	t.Run("users who left a team aren't added back", func(t *testing.T) {
+		require.Nil(t, th.App.RemoveUserFromTeam(team.Id, user.Id, ""))
+		require.Nil(t, th.App.SyncSamlGroups(user, []string{"engineers"}))
+
+		member, err := th.App.GetTeamMember(team.Id, user.Id)
+		require.Nil(t, err)
+		assert.NotZero(t, member.DeleteAt)
+	})
+}
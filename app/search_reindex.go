@@ -0,0 +1,135 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// CheckSearchReindexAvailable returns an error if there is no search engine to rebuild the indexes of.
+func (a *App) CheckSearchReindexAvailable() *model.AppError {
+	if a.Elasticsearch == nil {
+		return model.NewAppError("CheckSearchReindexAvailable", "ent.elasticsearch.test_config.license.error", nil, "", http.StatusNotImplemented)
+	}
+
+	if !*a.Config().ElasticsearchSettings.EnableIndexing {
+		return model.NewAppError("CheckSearchReindexAvailable", "app.search_reindex.indexing_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return nil
+}
+
+// ReindexSearchBatch indexes the next batch of the current stage of a search index rebuild and advances the progress
+// past it, moving on to the next stage once the current one has nothing left to index.
+func (a *App) ReindexSearchBatch(progress *model.SearchReindexProgress) *model.AppError {
+	if err := a.CheckSearchReindexAvailable(); err != nil {
+		return err
+	}
+
+	switch progress.Stage {
+	case model.SEARCH_REINDEX_STAGE_POSTS:
+		return a.reindexPostsBatch(progress)
+	case model.SEARCH_REINDEX_STAGE_CHANNELS:
+		return a.reindexChannelsBatch(progress)
+	case model.SEARCH_REINDEX_STAGE_USERS:
+		return a.reindexUsersBatch(progress)
+	}
+
+	return nil
+}
+
+func (a *App) reindexPostsBatch(progress *model.SearchReindexProgress) *model.AppError {
+	posts, err := a.Srv.Store.Post().GetPostsBatchForIndexing(progress.LastCreateAt, progress.EndCreateAt, progress.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		if post.DeleteAt != 0 {
+			continue
+		}
+
+		if err := a.Elasticsearch.IndexPost(&post.Post, post.TeamId); err != nil {
+			return err
+		}
+		progress.PostsIndexed++
+	}
+
+	createAts := make([]int64, len(posts))
+	for i, post := range posts {
+		createAts[i] = post.CreateAt
+	}
+	advanceSearchReindexProgress(progress, createAts)
+
+	return nil
+}
+
+func (a *App) reindexChannelsBatch(progress *model.SearchReindexProgress) *model.AppError {
+	channels, err := a.Srv.Store.Channel().GetChannelsBatchForIndexing(progress.LastCreateAt, progress.EndCreateAt, progress.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	createAts := make([]int64, len(channels))
+	for i, channel := range channels {
+		if err := a.Elasticsearch.IndexChannel(channel); err != nil {
+			return err
+		}
+		progress.ChannelsIndexed++
+		createAts[i] = channel.CreateAt
+	}
+	advanceSearchReindexProgress(progress, createAts)
+
+	return nil
+}
+
+func (a *App) reindexUsersBatch(progress *model.SearchReindexProgress) *model.AppError {
+	users, err := a.Srv.Store.User().GetUsersBatchForIndexing(progress.LastCreateAt, progress.EndCreateAt, progress.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	createAts := make([]int64, len(users))
+	for i, user := range users {
+		indexedUser := &model.User{
+			Id:        user.Id,
+			Username:  user.Username,
+			Nickname:  user.Nickname,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			CreateAt:  user.CreateAt,
+			DeleteAt:  user.DeleteAt,
+		}
+
+		if err := a.Elasticsearch.IndexUser(indexedUser, user.TeamsIds, user.ChannelsIds); err != nil {
+			return err
+		}
+		progress.UsersIndexed++
+		createAts[i] = user.CreateAt
+	}
+	advanceSearchReindexProgress(progress, createAts)
+
+	return nil
+}
+
+// advanceSearchReindexProgress moves the progress past a batch given the creation times of its objects, in order. A
+// full batch resumes from the creation time of its last object, since other objects may share it, which indexes
+// those again rather than skipping them. A batch that isn't full ends the stage.
+func advanceSearchReindexProgress(progress *model.SearchReindexProgress, createAts []int64) {
+	if len(createAts) < progress.BatchSize {
+		progress.NextStage()
+		return
+	}
+
+	last := createAts[len(createAts)-1]
+	if last == progress.LastCreateAt {
+		// The whole batch was created at the same time, so move past it to avoid fetching it forever.
+		mlog.Warn("Search reindex batch is too small for the objects created at the same time, some may be skipped", mlog.String("stage", progress.Stage), mlog.Int64("create_at", last))
+		last++
+	}
+	progress.LastCreateAt = last
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestAdvanceSearchReindexProgress(t *testing.T) {
+	t.Run("full batch resumes from its last object", func(t *testing.T) {
+		progress := model.NewSearchReindexProgress(100, 1000, 3, 0)
+		advanceSearchReindexProgress(progress, []int64{100, 200, 300})
+		assert.Equal(t, model.SEARCH_REINDEX_STAGE_POSTS, progress.Stage)
+		assert.Equal(t, int64(300), progress.LastCreateAt)
+	})
+
+	t.Run("full batch created at the same time moves past it", func(t *testing.T) {
+		progress := model.NewSearchReindexProgress(100, 1000, 3, 0)
+		advanceSearchReindexProgress(progress, []int64{100, 100, 100})
+		assert.Equal(t, model.SEARCH_REINDEX_STAGE_POSTS, progress.Stage)
+		assert.Equal(t, int64(101), progress.LastCreateAt)
+	})
+
+	t.Run("partial batch ends the stage", func(t *testing.T) {
+		progress := model.NewSearchReindexProgress(100, 1000, 3, 0)
+		advanceSearchReindexProgress(progress, []int64{100, 200})
+		assert.Equal(t, model.SEARCH_REINDEX_STAGE_CHANNELS, progress.Stage)
+		assert.Equal(t, int64(0), progress.LastCreateAt)
+	})
+
+	t.Run("empty batch ends the stage", func(t *testing.T) {
+		progress := model.NewSearchReindexProgress(100, 1000, 3, 0)
+		progress.Stage = model.SEARCH_REINDEX_STAGE_USERS
+		advanceSearchReindexProgress(progress, []int64{})
+		assert.True(t, progress.IsDone())
+	})
+}
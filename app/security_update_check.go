@@ -118,6 +118,8 @@ func (s *Server) DoSecurityUpdateCheck() {
 						mailservice.SendMailUsingConfig(user.Email, utils.T("mattermost.bulletin.subject"), string(body), s.Config(), license != nil && *license.Features.Compliance)
 					}
 
+					s.FakeApp().notifySecurityBulletin(bulletin.Id)
+
 					bulletinSeen := &model.System{Name: "SecurityBulletin_" + bulletin.Id, Value: bulletin.Id}
 					s.Store.System().Save(bulletinSeen)
 				}
@@ -30,6 +30,9 @@ import (
 	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/plugin"
+	"github.com/mattermost/mattermost-server/services/accesslog"
+	"github.com/mattermost/mattermost-server/services/audit"
+	"github.com/mattermost/mattermost-server/services/featureflag"
 	"github.com/mattermost/mattermost-server/services/httpservice"
 	"github.com/mattermost/mattermost-server/services/imageproxy"
 	"github.com/mattermost/mattermost-server/services/timezones"
@@ -68,6 +71,7 @@ type Server struct {
 
 	Hubs                        []*Hub
 	HubsStopCheckingForDeadlock chan bool
+	webSocketsDraining          int32
 
 	PushNotificationsHub PushNotificationsHub
 
@@ -82,15 +86,26 @@ type Server struct {
 
 	timezones *timezones.Timezones
 
+	FeatureFlags *featureflag.FeatureFlags
+
+	systemHealthCache systemHealthCache
+
 	newStore func() store.Store
 
 	htmlTemplateWatcher     *utils.HTMLTemplateWatcher
 	sessionCache            *utils.Cache
 	seenPendingPostIdsCache *utils.Cache
+	contentFilterCache      *utils.Cache
+	keywordRuleCache        *utils.Cache
+	blockedUsersCache       *utils.Cache
+	autoResponderCache      *utils.Cache
+	userInteractionCache    *utils.Cache
+	interactiveDialogCache  *utils.Cache
+	commandStreamCache      *utils.Cache
+	broadcastCache          *utils.Cache
 	configListenerId        string
 	licenseListenerId       string
 	logListenerId           string
-	clusterLeaderListenerId string
 	configStore             config.Store
 	asymmetricSigningKey    *ecdsa.PrivateKey
 	postActionCookieSecret  []byte
@@ -111,6 +126,16 @@ type Server struct {
 
 	ImageProxy *imageproxy.ImageProxy
 
+	Audit *audit.Audit
+
+	AccessLog *accesslog.AccessLog
+
+	incomingWebhookUsage      *incomingWebhookUsageTracker
+	outgoingWebhookDeliveries *outgoingWebhookDeliveryLog
+	integrationStats          *integrationStatsTracker
+	webrtcRooms               *webrtcRooms
+	reactionNotifications     *reactionNotificationBatcher
+
 	Log              *mlog.Logger
 	NotificationsLog *mlog.Logger
 
@@ -133,12 +158,25 @@ func NewServer(options ...Option) (*Server, error) {
 	rootRouter := mux.NewRouter()
 
 	s := &Server{
-		goroutineExitSignal:     make(chan struct{}, 1),
-		RootRouter:              rootRouter,
-		licenseListeners:        map[string]func(){},
-		sessionCache:            utils.NewLru(model.SESSION_CACHE_SIZE),
-		seenPendingPostIdsCache: utils.NewLru(PENDING_POST_IDS_CACHE_SIZE),
-		clientConfig:            make(map[string]string),
+		goroutineExitSignal:       make(chan struct{}, 1),
+		RootRouter:                rootRouter,
+		licenseListeners:          map[string]func(){},
+		sessionCache:              utils.NewLru(model.SESSION_CACHE_SIZE),
+		seenPendingPostIdsCache:   utils.NewLru(PENDING_POST_IDS_CACHE_SIZE),
+		contentFilterCache:        utils.NewLru(CONTENT_FILTER_CACHE_SIZE),
+		keywordRuleCache:          utils.NewLru(KEYWORD_RULE_CACHE_SIZE),
+		blockedUsersCache:         utils.NewLru(BLOCKED_USERS_CACHE_SIZE),
+		autoResponderCache:        utils.NewLru(AUTO_RESPONDER_CACHE_SIZE),
+		userInteractionCache:      utils.NewLru(USER_INTERACTION_CACHE_SIZE),
+		interactiveDialogCache:    utils.NewLru(INTERACTIVE_DIALOG_CACHE_SIZE),
+		commandStreamCache:        utils.NewLru(COMMAND_STREAM_CACHE_SIZE),
+		broadcastCache:            utils.NewLru(DIRECT_MESSAGE_BROADCAST_CACHE_SIZE),
+		clientConfig:              make(map[string]string),
+		incomingWebhookUsage:      newIncomingWebhookUsageTracker(),
+		outgoingWebhookDeliveries: newOutgoingWebhookDeliveryLog(),
+		integrationStats:          newIntegrationStatsTracker(),
+		webrtcRooms:               newWebrtcRooms(),
+		reactionNotifications:     newReactionNotificationBatcher(),
 	}
 	for _, option := range options {
 		if err := option(s); err != nil {
@@ -172,16 +210,20 @@ func NewServer(options ...Option) (*Server, error) {
 	mlog.InitGlobalLogger(s.Log)
 
 	s.logListenerId = s.AddConfigListener(func(_, after *model.Config) {
-		s.Log.ChangeLevels(utils.MloggerConfigFromLoggerConfig(&after.LogSettings, utils.GetLogFileLocation))
+		s.Log.Reconfigure(utils.MloggerConfigFromLoggerConfig(&after.LogSettings, utils.GetLogFileLocation))
 
 		notificationLogSettings := utils.GetLogSettingsFromNotificationsLogSettings(&after.NotificationLogSettings)
-		s.NotificationsLog.ChangeLevels(utils.MloggerConfigFromLoggerConfig(notificationLogSettings, utils.GetNotificationsLogFileLocation))
+		s.NotificationsLog.Reconfigure(utils.MloggerConfigFromLoggerConfig(notificationLogSettings, utils.GetNotificationsLogFileLocation))
 	})
 
 	s.HTTPService = httpservice.MakeHTTPService(s.FakeApp())
 
 	s.ImageProxy = imageproxy.MakeImageProxy(s, s.HTTPService, s.Log)
 
+	s.Audit = audit.MakeAudit(s, &databaseAuditSink{server: s}, s.Log)
+
+	s.AccessLog = accesslog.MakeAccessLog(s, s.Log)
+
 	if err := utils.TranslationsPreInit(); err != nil {
 		return nil, errors.Wrapf(err, "unable to load Mattermost translation files")
 	}
@@ -194,6 +236,7 @@ func NewServer(options ...Option) (*Server, error) {
 	model.AppErrorInit(utils.T)
 
 	s.timezones = timezones.New()
+	s.FeatureFlags = featureflag.New(featureflag.NewConfigSource(s))
 
 	// Start email batching because it's not like the other jobs
 	s.InitEmailBatching()
@@ -201,6 +244,13 @@ func NewServer(options ...Option) (*Server, error) {
 		s.InitEmailBatching()
 	})
 
+	// The MFA grace period is counted from when enforcement gets turned on
+	s.AddConfigListener(func(oldConfig, newConfig *model.Config) {
+		if !*oldConfig.ServiceSettings.EnforceMultifactorAuthentication && *newConfig.ServiceSettings.EnforceMultifactorAuthentication {
+			s.recordMfaEnforcementStart()
+		}
+	})
+
 	// Start plugin health check job
 	pluginsEnvironment := s.PluginsEnvironment
 	if pluginsEnvironment != nil {
@@ -262,6 +312,11 @@ func NewServer(options ...Option) (*Server, error) {
 
 	s.initJobs()
 
+	// Websocket gateways only relay cluster events to their connections, so jobs are left to the API nodes.
+	if *s.Config().ClusterSettings.WebSocketMode == model.CLUSTER_WEBSOCKET_MODE_GATEWAY {
+		s.runjobs = false
+	}
+
 	if s.runjobs {
 		s.Go(func() {
 			runSecurityJob(s)
@@ -278,6 +333,33 @@ func NewServer(options ...Option) (*Server, error) {
 		s.Go(func() {
 			runCommandWebhookCleanupJob(s)
 		})
+		s.Go(func() {
+			runChannelMemberExpiryJob(s)
+		})
+		s.Go(func() {
+			runLicenseUsageJob(s)
+		})
+		s.Go(func() {
+			runPostPurgeJob(s)
+		})
+		s.Go(func() {
+			runRemindersJob(s)
+		})
+		s.Go(func() {
+			runEphemeralPostCleanupJob(s)
+		})
+		s.Go(func() {
+			runLoginAttemptsCleanupJob(s)
+		})
+		s.Go(func() {
+			runIntegrationStatsRollupJob(s)
+		})
+		s.Go(func() {
+			runIntegrationStatsCleanupJob(s)
+		})
+		s.Go(func() {
+			runChannelMembershipRuleSyncJob(s)
+		})
 
 		if complianceI := s.Compliance; complianceI != nil {
 			complianceI.StartComplianceDailyJob()
@@ -301,11 +383,15 @@ func (s *Server) AppOptions() []AppOption {
 	}
 }
 
-const TIME_TO_WAIT_FOR_CONNECTIONS_TO_CLOSE_ON_SERVER_SHUTDOWN = time.Second
+func (s *Server) gracefulShutdownTimeout() time.Duration {
+	return time.Duration(*s.Config().ServiceSettings.GracefulShutdownTimeoutSeconds) * time.Second
+}
 
+// StopHTTPServer stops accepting new connections and waits for the requests in flight, such as post creations and
+// file uploads, to complete before closing the server, for up to the graceful shutdown timeout.
 func (s *Server) StopHTTPServer() {
 	if s.Server != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), TIME_TO_WAIT_FOR_CONNECTIONS_TO_CLOSE_ON_SERVER_SHUTDOWN)
+		ctx, cancel := context.WithTimeout(context.Background(), s.gracefulShutdownTimeout())
 		defer cancel()
 		didShutdown := false
 		for s.didFinishListen != nil && !didShutdown {
@@ -328,6 +414,21 @@ func (s *Server) StopHTTPServer() {
 func (s *Server) Shutdown() error {
 	mlog.Info("Stopping Server...")
 
+	// Requests in flight may still publish websocket events, so the HTTP server is stopped before the hubs.
+	s.StopHTTPServer()
+
+	if drainSeconds := *s.Config().ClusterSettings.WebSocketDrainSeconds; drainSeconds > 0 {
+		s.FakeApp().DrainWebSocketConnections(time.Duration(drainSeconds) * time.Second)
+	}
+
+	if s.Jobs != nil && s.runjobs {
+		s.Jobs.StopSchedulers()
+		if !s.Jobs.StopWorkersWithTimeout(s.gracefulShutdownTimeout()) {
+			mlog.Warn("Timed out waiting for the running jobs to finish")
+		}
+		s.Jobs.HandOffClaimedJobs()
+	}
+
 	s.RunOldAppShutdown()
 
 	err := s.shutdownDiagnostics()
@@ -335,9 +436,23 @@ func (s *Server) Shutdown() error {
 		mlog.Error(fmt.Sprintf("Unable to cleanly shutdown diagnostic client: %s", err))
 	}
 
-	s.StopHTTPServer()
 	s.WaitForGoroutines()
 
+	// The activity of the integrations since the last roll up would be lost otherwise
+	if s.runjobs {
+		if err := s.FakeApp().RollupIntegrationStats(); err != nil {
+			mlog.Error("Failed to roll up the integration stats", mlog.Err(err))
+		}
+	}
+
+	if s.Audit != nil {
+		s.Audit.Close()
+	}
+
+	if s.AccessLog != nil {
+		s.AccessLog.Close()
+	}
+
 	if s.htmlTemplateWatcher != nil {
 		s.htmlTemplateWatcher.Close()
 	}
@@ -355,16 +470,15 @@ func (s *Server) Shutdown() error {
 		s.Metrics.StopServer()
 	}
 
-	if s.Jobs != nil && s.runjobs {
-		s.Jobs.StopWorkers()
-		s.Jobs.StopSchedulers()
-	}
-
 	if s.Store != nil {
 		s.Store.Close()
 	}
 
 	mlog.Info("Server stopped")
+
+	s.Log.Close()
+	s.NotificationsLog.Close()
+
 	return nil
 }
 
@@ -672,6 +786,69 @@ func runSessionCleanupJob(s *Server) {
 	}, time.Hour*24)
 }
 
+func runChannelMemberExpiryJob(s *Server) {
+	doChannelMemberExpiry(s)
+	model.CreateRecurringTask("Channel Member Expiry", func() {
+		doChannelMemberExpiry(s)
+	}, time.Minute*1)
+}
+
+func runChannelMembershipRuleSyncJob(s *Server) {
+	doChannelMembershipRuleSync(s)
+	model.CreateRecurringTask("Channel Membership Rule Sync", func() {
+		doChannelMembershipRuleSync(s)
+	}, time.Minute*15)
+}
+
+func runLicenseUsageJob(s *Server) {
+	doLicenseUsage(s)
+	model.CreateRecurringTask("License Usage", func() {
+		doLicenseUsage(s)
+	}, time.Hour*1)
+}
+
+func runPostPurgeJob(s *Server) {
+	doPostPurge(s)
+	model.CreateRecurringTask("Post Purge", func() {
+		doPostPurge(s)
+	}, time.Minute*1)
+}
+
+func runRemindersJob(s *Server) {
+	doReminders(s)
+	model.CreateRecurringTask("Reminders", func() {
+		doReminders(s)
+	}, time.Minute*1)
+}
+
+func runEphemeralPostCleanupJob(s *Server) {
+	doEphemeralPostCleanup(s)
+	model.CreateRecurringTask("Ephemeral Post Cleanup", func() {
+		doEphemeralPostCleanup(s)
+	}, time.Hour*1)
+}
+
+func runLoginAttemptsCleanupJob(s *Server) {
+	doLoginAttemptsCleanup(s)
+	model.CreateRecurringTask("Login Attempts Cleanup", func() {
+		doLoginAttemptsCleanup(s)
+	}, time.Hour*24)
+}
+
+// The activity of the integrations is kept by each server, so every server rolls up its own.
+func runIntegrationStatsRollupJob(s *Server) {
+	model.CreateRecurringTask("Integration Stats Rollup", func() {
+		doIntegrationStatsRollup(s)
+	}, time.Minute*1)
+}
+
+func runIntegrationStatsCleanupJob(s *Server) {
+	doIntegrationStatsCleanup(s)
+	model.CreateRecurringTask("Integration Stats Cleanup", func() {
+		doIntegrationStatsCleanup(s)
+	}, time.Hour*24)
+}
+
 func doSecurity(s *Server) {
 	s.DoSecurityUpdateCheck()
 }
@@ -682,6 +859,12 @@ func doDiagnostics(s *Server) {
 	}
 }
 
+func doLicenseUsage(s *Server) {
+	if err := s.FakeApp().RecordLicenseUsage(); err != nil {
+		mlog.Error("Failed to record the license usage", mlog.Err(err))
+	}
+}
+
 func doTokenCleanup(s *Server) {
 	s.Store.Token().Cleanup()
 }
@@ -691,13 +874,54 @@ func doCommandWebhookCleanup(s *Server) {
 }
 
 const (
-	SESSIONS_CLEANUP_BATCH_SIZE = 1000
+	SESSIONS_CLEANUP_BATCH_SIZE        = 1000
+	EPHEMERAL_POSTS_CLEANUP_BATCH_SIZE = 1000
 )
 
 func doSessionCleanup(s *Server) {
 	s.Store.Session().Cleanup(model.GetMillis(), SESSIONS_CLEANUP_BATCH_SIZE)
 }
 
+func doEphemeralPostCleanup(s *Server) {
+	now := model.GetMillis()
+	for {
+		count, err := s.Store.EphemeralPost().DeleteExpired(now, EPHEMERAL_POSTS_CLEANUP_BATCH_SIZE)
+		if err != nil {
+			mlog.Error("Failed to delete the expired ephemeral posts", mlog.Err(err))
+			return
+		}
+
+		if count < EPHEMERAL_POSTS_CLEANUP_BATCH_SIZE {
+			return
+		}
+	}
+}
+
+// doChannelMemberExpiry removes the temporary channel members whose membership expired. Only the cluster leader
+// removes them, so that each removal is announced once.
+func doChannelMemberExpiry(s *Server) {
+	if a := s.FakeApp(); a.IsLeader() {
+		a.RemoveExpiredChannelMembers()
+	}
+}
+
+// doChannelMembershipRuleSync syncs the membership of the channels with rules, catching up with the changes to user
+// attributes and groups made outside of the app, such as by LDAP sync. Only the cluster leader syncs them, so that each
+// change is announced once.
+func doChannelMembershipRuleSync(s *Server) {
+	if a := s.FakeApp(); a.IsLeader() {
+		a.SyncAllChannelMembershipRules()
+	}
+}
+
+func doPostPurge(s *Server) {
+	s.FakeApp().RunDuePostPurges()
+}
+
+func doReminders(s *Server) {
+	s.FakeApp().SendDueReminders()
+}
+
 func (s *Server) StartElasticsearch() {
 	s.Go(func() {
 		if err := s.Elasticsearch.Start(); err != nil {
@@ -776,3 +1000,21 @@ func (s *Server) shutdownDiagnostics() error {
 
 	return nil
 }
+
+func doLoginAttemptsCleanup(s *Server) {
+	if err := s.FakeApp().DeleteOldLoginAttempts(); err != nil {
+		mlog.Error("Failed to delete the old login attempts", mlog.Err(err))
+	}
+}
+
+func doIntegrationStatsRollup(s *Server) {
+	if err := s.FakeApp().RollupIntegrationStats(); err != nil {
+		mlog.Error("Failed to roll up the integration stats", mlog.Err(err))
+	}
+}
+
+func doIntegrationStatsCleanup(s *Server) {
+	if err := s.FakeApp().DeleteOldIntegrationStats(); err != nil {
+		mlog.Error("Failed to delete the old integration stats", mlog.Err(err))
+	}
+}
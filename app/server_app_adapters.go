@@ -62,7 +62,15 @@ func (s *Server) RunOldAppInitalization() error {
 
 	if s.FakeApp().Srv.newStore == nil {
 		s.FakeApp().Srv.newStore = func() store.Store {
-			return store.NewTimerLayer(localcachelayer.NewLocalCacheLayer(store.NewLayeredStore(sqlstore.NewSqlSupplier(s.FakeApp().Config().SqlSettings, s.Metrics), s.Metrics, s.Cluster), s.Metrics, s.Cluster), s.Metrics)
+			sqlSettings := s.FakeApp().Config().SqlSettings
+
+			var sqlStore store.Store = store.NewLayeredStore(sqlstore.NewSqlSupplier(sqlSettings, s.Metrics), s.Metrics, s.Cluster)
+			// CockroachDB aborts the transactions conflicting with concurrent ones, expecting them to be run again.
+			if *sqlSettings.DriverName == model.DATABASE_DRIVER_COCKROACH {
+				sqlStore = store.NewRetryLayer(sqlStore)
+			}
+
+			return store.NewTimerLayer(localcachelayer.NewLocalCacheLayer(sqlStore, s.Metrics, s.Cluster), s.Metrics)
 		}
 	}
 
@@ -89,13 +97,6 @@ func (s *Server) RunOldAppInitalization() error {
 	s.FakeApp().EnsureDiagnosticId()
 	s.FakeApp().regenerateClientConfig()
 
-	s.FakeApp().Srv.clusterLeaderListenerId = s.FakeApp().Srv.AddClusterLeaderChangedListener(func() {
-		mlog.Info("Cluster leader changed. Determining if job schedulers should be running:", mlog.Bool("isLeader", s.FakeApp().IsLeader()))
-		if s.FakeApp().Srv.Jobs != nil {
-			s.FakeApp().Srv.Jobs.Schedulers.HandleClusterLeaderChange(s.FakeApp().IsLeader())
-		}
-	})
-
 	subpath, err := utils.GetSubpathFromConfig(s.FakeApp().Config())
 	if err != nil {
 		return errors.Wrap(err, "failed to parse SiteURL subpath")
@@ -159,7 +160,6 @@ func (s *Server) RunOldAppShutdown() {
 	s.FakeApp().StopPushNotificationsHubWorkers()
 	s.FakeApp().ShutDownPlugins()
 	s.FakeApp().RemoveLicenseListener(s.licenseListenerId)
-	s.RemoveClusterLeaderChangedListener(s.clusterLeaderListenerId)
 }
 
 // A temporary bridge to deal with cases where the code is so tighly coupled that
@@ -282,6 +282,12 @@ func (a *App) UpdateLastActivityAtIfNeeded(session model.Session) {
 		mlog.Error(fmt.Sprintf("Failed to update LastActivityAt for user_id=%v and session_id=%v, err=%v", session.UserId, session.Id, err), mlog.String("user_id", session.UserId))
 	}
 
+	if session.Props[model.SESSION_PROP_TYPE] == model.SESSION_TYPE_USER_ACCESS_TOKEN {
+		if err := a.Srv.Store.UserAccessToken().UpdateLastUsedAt(session.Props[model.SESSION_PROP_USER_ACCESS_TOKEN_ID], now); err != nil {
+			mlog.Error("Failed to update LastUsedAt for user access token", mlog.String("user_id", session.UserId), mlog.String("session_id", session.Id), mlog.Err(err))
+		}
+	}
+
 	session.LastActivityAt = now
 	a.AddSessionToCache(&session)
 }
@@ -297,6 +303,10 @@ func (a *App) CreateUserAccessToken(token *model.UserAccessToken) (*model.UserAc
 		return nil, model.NewAppError("CreateUserAccessToken", "app.user_access_token.disabled", nil, "", http.StatusNotImplemented)
 	}
 
+	if token.ExpiresAt != 0 && token.ExpiresAt <= model.GetMillis() {
+		return nil, model.NewAppError("CreateUserAccessToken", "app.user_access_token.expires_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	token.Token = model.NewId()
 
 	token, err = a.Srv.Store.UserAccessToken().Save(token)
@@ -325,6 +335,10 @@ func (a *App) createSessionForUserAccessToken(tokenString string) (*model.Sessio
 		return nil, model.NewAppError("createSessionForUserAccessToken", "app.user_access_token.invalid_or_missing", nil, "inactive_token", http.StatusUnauthorized)
 	}
 
+	if token.IsExpired() {
+		return nil, model.NewAppError("createSessionForUserAccessToken", "app.user_access_token.invalid_or_missing", nil, "expired_token", http.StatusUnauthorized)
+	}
+
 	user, err := a.Srv.Store.User().Get(token.UserId)
 	if err != nil {
 		return nil, err
@@ -347,6 +361,12 @@ func (a *App) createSessionForUserAccessToken(tokenString string) (*model.Sessio
 
 	session.AddProp(model.SESSION_PROP_USER_ACCESS_TOKEN_ID, token.Id)
 	session.AddProp(model.SESSION_PROP_TYPE, model.SESSION_TYPE_USER_ACCESS_TOKEN)
+	if len(token.Scope) > 0 {
+		session.AddProp(model.SESSION_PROP_OAUTH_SCOPE, token.Scope)
+	}
+	if len(token.AllowedIPs) > 0 {
+		session.AddProp(model.SESSION_PROP_ALLOWED_IPS, token.AllowedIPs)
+	}
 	if user.IsBot {
 		session.AddProp(model.SESSION_PROP_IS_BOT, model.SESSION_PROP_IS_BOT_VALUE)
 	}
@@ -356,12 +376,19 @@ func (a *App) createSessionForUserAccessToken(tokenString string) (*model.Sessio
 		session.AddProp(model.SESSION_PROP_IS_GUEST, "false")
 	}
 	session.SetExpireInDays(model.SESSION_USER_ACCESS_TOKEN_EXPIRY)
+	if token.ExpiresAt > 0 && token.ExpiresAt < session.ExpiresAt {
+		session.ExpiresAt = token.ExpiresAt
+	}
 
 	session, err = a.Srv.Store.Session().Save(session)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := a.Srv.Store.UserAccessToken().UpdateLastUsedAt(token.Id, session.CreateAt); err != nil {
+		mlog.Error("Failed to update LastUsedAt for user access token", mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+
 	a.AddSessionToCache(session)
 
 	return session, nil
@@ -427,6 +454,22 @@ func (a *App) GetUserAccessTokens(page, perPage int) ([]*model.UserAccessToken,
 	return tokens, nil
 }
 
+// GetExpiringUserAccessTokens returns the active user access tokens expiring within the given number of
+// milliseconds, soonest first.
+func (a *App) GetExpiringUserAccessTokens(within int64, page, perPage int) ([]*model.UserAccessToken, *model.AppError) {
+	now := model.GetMillis()
+	tokens, err := a.Srv.Store.UserAccessToken().GetExpiring(now, now+within, page*perPage, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		token.Token = ""
+	}
+
+	return tokens, nil
+}
+
 func (a *App) GetUserAccessTokensForUser(userId string, page, perPage int) ([]*model.UserAccessToken, *model.AppError) {
 	tokens, err := a.Srv.Store.UserAccessToken().GetByUser(userId, page*perPage, perPage)
 	if err != nil {
@@ -0,0 +1,143 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// supportPacket gathers the files of a support packet, masking the secrets of the configuration wherever they appear.
+// Gathering a file is best effort: the reason a file is missing is listed in warnings.txt instead.
+type supportPacket struct {
+	writer   *zip.Writer
+	secrets  []string
+	warnings []string
+}
+
+func (p *supportPacket) add(name string, data []byte) {
+	file, err := p.writer.Create(name)
+	if err != nil {
+		p.warn("unable to add " + name + ": " + err.Error())
+		return
+	}
+
+	if _, err := file.Write(data); err != nil {
+		p.warn("unable to write " + name + ": " + err.Error())
+	}
+}
+
+func (p *supportPacket) addText(name, text string) {
+	p.add(name, []byte(model.RedactSecrets(text, p.secrets)))
+}
+
+func (p *supportPacket) addJson(name string, v interface{}) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		p.warn("unable to encode " + name + ": " + err.Error())
+		return
+	}
+	p.addText(name, string(b))
+}
+
+func (p *supportPacket) warn(warning string) {
+	p.warnings = append(p.warnings, model.RedactSecrets(warning, p.secrets))
+}
+
+// GenerateSupportPacket zips the sanitized configuration, the recent logs, goroutine and heap profiles, the status of
+// the jobs and of the cluster, and the health of the server, for troubleshooting by support.
+func (a *App) GenerateSupportPacket() ([]byte, *model.AppError) {
+	buf := &bytes.Buffer{}
+	packet := &supportPacket{
+		writer:  zip.NewWriter(buf),
+		secrets: model.SupportPacketSecrets(a.Config()),
+	}
+
+	packet.addText("metadata.json", a.supportPacketMetadata().ToJson())
+	packet.addJson("config.json", model.SupportPacketConfig(a.Config()))
+
+	if lines, err := a.GetLogs(0, model.SUPPORT_PACKET_LOG_LINES); err != nil {
+		packet.warn("unable to read the logs: " + err.Error())
+	} else {
+		packet.addText("mattermost.log", strings.Join(lines, "\n"))
+	}
+
+	goroutines := &bytes.Buffer{}
+	if err := pprof.Lookup("goroutine").WriteTo(goroutines, 2); err != nil {
+		packet.warn("unable to profile the goroutines: " + err.Error())
+	} else {
+		packet.addText("goroutines.txt", goroutines.String())
+	}
+
+	heap := &bytes.Buffer{}
+	if err := pprof.WriteHeapProfile(heap); err != nil {
+		packet.warn("unable to profile the heap: " + err.Error())
+	} else {
+		packet.add("heap.pprof", heap.Bytes())
+	}
+
+	if jobs, err := a.GetJobs(0, model.SUPPORT_PACKET_JOBS); err != nil {
+		packet.warn("unable to get the jobs: " + err.Error())
+	} else {
+		packet.addJson("jobs.json", jobs)
+	}
+
+	if a.Srv.Jobs != nil {
+		if status, err := a.GetJobsLeaderStatus(); err != nil {
+			packet.warn("unable to get the status of the job servers: " + err.Error())
+		} else {
+			packet.addJson("jobs_leader_status.json", status)
+		}
+	}
+
+	packet.addJson("cluster.json", a.GetClusterStatus())
+	packet.addJson("health.json", a.CheckSystemHealth())
+
+	if len(packet.warnings) > 0 {
+		packet.add("warnings.txt", []byte(strings.Join(packet.warnings, "\n")))
+	}
+
+	if err := packet.writer.Close(); err != nil {
+		return nil, model.NewAppError("GenerateSupportPacket", "app.support_packet.zip.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	mlog.Info("Generated a support packet.", mlog.Int("warnings", len(packet.warnings)))
+
+	return buf.Bytes(), nil
+}
+
+func (a *App) supportPacketMetadata() *model.SupportPacketMetadata {
+	metadata := &model.SupportPacketMetadata{
+		GeneratedAt:     model.GetMillis(),
+		ServerVersion:   model.CurrentVersion,
+		BuildNumber:     model.BuildNumber,
+		BuildHash:       model.BuildHash,
+		BuildEnterprise: model.BuildEnterpriseReady,
+		SchemaVersion:   a.Srv.Store.GetCurrentSchemaVersion(),
+		DatabaseType:    *a.Config().SqlSettings.DriverName,
+		OperatingSystem: runtime.GOOS,
+		Architecture:    runtime.GOARCH,
+		GoVersion:       runtime.Version(),
+		NumGoroutine:    runtime.NumGoroutine(),
+	}
+
+	if license := a.License(); license != nil {
+		metadata.LicenseId = license.Id
+		metadata.LicenseSku = license.SkuShortName
+	}
+
+	if a.Cluster != nil {
+		metadata.ClusterId = a.Cluster.GetClusterId()
+	}
+
+	return metadata
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+// SendSystemBotMessage sends a direct message from the system bot to a user.
+func (a *App) SendSystemBotMessage(userId, message string) *model.AppError {
+	bot, err := a.GetSystemBot()
+	if err != nil {
+		return err
+	}
+
+	channel, err := a.GetOrCreateDirectChannel(bot.UserId, userId)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.CreatePost(&model.Post{
+		ChannelId: channel.Id,
+		UserId:    bot.UserId,
+		Message:   message,
+	}, channel, false)
+
+	return err
+}
+
+// notifyUser sends a direct message from the system bot to a user in their language.
+func (a *App) notifyUser(user *model.User, translationId string, params map[string]interface{}) {
+	T := utils.GetUserTranslations(user.Locale)
+	if err := a.SendSystemBotMessage(user.Id, T(translationId, params)); err != nil {
+		mlog.Error("Failed to send a system bot message", mlog.String("user_id", user.Id), mlog.String("message_id", translationId), mlog.Err(err))
+	}
+}
+
+// notifySystemAdmins sends a direct message from the system bot to every active system admin in their language.
+func (a *App) notifySystemAdmins(translationId string, params map[string]interface{}) {
+	admins, err := a.Srv.Store.User().GetSystemAdminProfiles()
+	if err != nil {
+		mlog.Error("Failed to get the system admins to send a system bot message", mlog.String("message_id", translationId), mlog.Err(err))
+		return
+	}
+
+	for _, admin := range admins {
+		if admin.DeleteAt == 0 {
+			a.notifyUser(admin, translationId, params)
+		}
+	}
+}
+
+// areSystemBotNotificationsEnabled returns whether the server tells admins and users about the events that
+// concern them through the system bot, rather than only logging them.
+func (a *App) areSystemBotNotificationsEnabled() bool {
+	return *a.Config().ServiceSettings.EnableSystemBotNotifications
+}
+
+// notifyJobFailed tells the system admins that a job failed.
+func (a *App) notifyJobFailed(job *model.Job) {
+	if !a.areSystemBotNotificationsEnabled() {
+		return
+	}
+
+	a.notifySystemAdmins("app.system_bot.job_failed.message", map[string]interface{}{
+		"JobId":   job.Id,
+		"JobType": job.Type,
+		"Error":   job.Data["error"],
+	})
+}
+
+// notifySecurityBulletin tells the system admins that a security bulletin applies to the version of the server.
+func (a *App) notifySecurityBulletin(bulletinId string) {
+	if !a.areSystemBotNotificationsEnabled() {
+		return
+	}
+
+	a.notifySystemAdmins("app.system_bot.security_bulletin.message", map[string]interface{}{
+		"BulletinId": bulletinId,
+		"Version":    model.CurrentVersion,
+	})
+}
+
+// notifyPasswordChanged tells a user that their password was changed, in case they didn't change it themselves.
+func (a *App) notifyPasswordChanged(user *model.User) {
+	if !a.areSystemBotNotificationsEnabled() {
+		return
+	}
+
+	a.notifyUser(user, "app.system_bot.password_changed.message", nil)
+}
+
+// notifyNewSession tells a user that they logged in from a new session.
+func (a *App) notifyNewSession(user *model.User, session *model.Session, ipAddress string) {
+	if !a.areSystemBotNotificationsEnabled() {
+		return
+	}
+
+	a.notifyUser(user, "app.system_bot.new_session.message", map[string]interface{}{
+		"Platform":  session.Props[model.SESSION_PROP_PLATFORM],
+		"OS":        session.Props[model.SESSION_PROP_OS],
+		"Browser":   session.Props[model.SESSION_PROP_BROWSER],
+		"IpAddress": ipAddress,
+	})
+}
+
+// notifyAccountLocked tells a user that their account was locked after too many failed login attempts.
+func (a *App) notifyAccountLocked(user *model.User) {
+	if !a.areSystemBotNotificationsEnabled() {
+		return
+	}
+
+	a.notifyUser(user, "app.system_bot.account_locked.message", map[string]interface{}{
+		"Attempts": *a.Config().ServiceSettings.MaximumLoginAttempts,
+	})
+}
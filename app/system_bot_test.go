@@ -0,0 +1,91 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestSystemBotNotifications(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	bot, err := th.App.GetSystemBot()
+	require.Nil(t, err)
+
+	getLastMessage := func(t *testing.T, userId string) string {
+		t.Helper()
+
+		channel, err := th.App.GetOrCreateDirectChannel(bot.UserId, userId)
+		require.Nil(t, err)
+
+		posts, err := th.App.GetPosts(channel.Id, 0, 1)
+		require.Nil(t, err)
+
+		postArray := posts.ToSlice()
+		if len(postArray) == 0 {
+			return ""
+		}
+		return postArray[0].Message
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableSystemBotNotifications = false })
+
+		th.App.notifyPasswordChanged(th.BasicUser)
+		assert.Empty(t, getLastMessage(t, th.BasicUser.Id))
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableSystemBotNotifications = true })
+
+	t.Run("password changed", func(t *testing.T) {
+		th.App.notifyPasswordChanged(th.BasicUser)
+		assert.Contains(t, getLastMessage(t, th.BasicUser.Id), "Your password was changed")
+	})
+
+	t.Run("new session", func(t *testing.T) {
+		session := &model.Session{UserId: th.BasicUser2.Id}
+		session.AddProp(model.SESSION_PROP_BROWSER, "Firefox/70.0")
+
+		th.App.notifyNewSession(th.BasicUser2, session, "10.0.0.1")
+		message := getLastMessage(t, th.BasicUser2.Id)
+		assert.Contains(t, message, "Firefox/70.0")
+		assert.Contains(t, message, "10.0.0.1")
+	})
+
+	t.Run("failed job", func(t *testing.T) {
+		job := &model.Job{
+			Id:   model.NewId(),
+			Type: model.JOB_TYPE_DATA_RETENTION,
+			Data: map[string]string{"error": "something went wrong"},
+		}
+
+		th.App.notifyJobFailed(job)
+		message := getLastMessage(t, th.SystemAdminUser.Id)
+		assert.Contains(t, message, job.Id)
+		assert.Contains(t, message, "something went wrong")
+		assert.NotContains(t, getLastMessage(t, th.BasicUser.Id), job.Id)
+	})
+
+	t.Run("account locked", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaximumLoginAttempts = 1 })
+
+		user := th.CreateUser()
+		require.Nil(t, th.App.recordFailedLoginAttempt(user))
+
+		// The user is told in the background
+		var message string
+		for i := 0; i < 50 && message == ""; i++ {
+			time.Sleep(100 * time.Millisecond)
+			message = getLastMessage(t, user.Id)
+		}
+		assert.Contains(t, message, "Your account was locked")
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/mailservice"
+)
+
+const (
+	// HEALTH_CHECK_PROBE_TIMEOUT bounds how long a dependency may take to answer its probe.
+	HEALTH_CHECK_PROBE_TIMEOUT = 10 * time.Second
+
+	// HEALTH_CHECK_CACHE_DURATION is how long the result of the probes is reused, so that frequent health checks
+	// don't load the dependencies.
+	HEALTH_CHECK_CACHE_DURATION = 5 * time.Second
+
+	HEALTH_CHECK_SYSTEM_KEY = "health_check"
+)
+
+// healthProbe checks a dependency, returning an error if it's unhealthy. A nil probe is a dependency that isn't used.
+type healthProbe func() error
+
+type systemHealthCache struct {
+	sync.Mutex
+	health    *model.SystemHealth
+	checkedAt time.Time
+}
+
+// CheckSystemHealth probes the dependencies of the server concurrently, reusing the result of a check made within
+// the past HEALTH_CHECK_CACHE_DURATION.
+func (a *App) CheckSystemHealth() *model.SystemHealth {
+	cache := &a.Srv.systemHealthCache
+	cache.Lock()
+	defer cache.Unlock()
+
+	if cache.health != nil && time.Since(cache.checkedAt) < HEALTH_CHECK_CACHE_DURATION {
+		return cache.health
+	}
+
+	probes := map[string]healthProbe{
+		model.HEALTH_CHECK_DATABASE:      a.probeDatabase,
+		model.HEALTH_CHECK_FILESTORE:     a.probeFilestore,
+		model.HEALTH_CHECK_ELASTICSEARCH: a.elasticsearchProbe(),
+		model.HEALTH_CHECK_PUSH_PROXY:    a.pushProxyProbe(),
+		model.HEALTH_CHECK_SMTP:          a.smtpProbe(),
+		model.HEALTH_CHECK_CLUSTER:       a.clusterProbe(),
+	}
+
+	health := &model.SystemHealth{Status: model.STATUS_OK}
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	for _, name := range []string{
+		model.HEALTH_CHECK_DATABASE,
+		model.HEALTH_CHECK_FILESTORE,
+		model.HEALTH_CHECK_ELASTICSEARCH,
+		model.HEALTH_CHECK_PUSH_PROXY,
+		model.HEALTH_CHECK_SMTP,
+		model.HEALTH_CHECK_CLUSTER,
+	} {
+		dependency := &model.DependencyHealth{Name: name, Status: model.STATUS_DISABLED}
+		health.Dependencies = append(health.Dependencies, dependency)
+
+		probe := probes[name]
+		if probe == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			err := runHealthProbe(probe)
+			dependency.Latency = int64(time.Since(start) / time.Millisecond)
+
+			if err != nil {
+				mlog.Warn("Health check of a dependency failed", mlog.String("dependency", dependency.Name), mlog.Err(err))
+				dependency.Status = model.STATUS_UNHEALTHY
+
+				mutex.Lock()
+				health.Status = model.STATUS_UNHEALTHY
+				mutex.Unlock()
+				return
+			}
+			dependency.Status = model.STATUS_OK
+		}()
+	}
+	wg.Wait()
+
+	cache.health = health
+	cache.checkedAt = time.Now()
+
+	return health
+}
+
+// runHealthProbe gives up on a probe taking longer than HEALTH_CHECK_PROBE_TIMEOUT, leaving it to finish in the
+// background.
+func runHealthProbe(probe healthProbe) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- probe()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(HEALTH_CHECK_PROBE_TIMEOUT):
+		return errors.New("timed out")
+	}
+}
+
+// probeDatabase writes a value to the database and reads it back. The value read isn't compared, since another node
+// of the cluster may have written its own meanwhile.
+func (a *App) probeDatabase() error {
+	if err := a.Srv.Store.System().SaveOrUpdate(&model.System{Name: HEALTH_CHECK_SYSTEM_KEY, Value: model.NewId()}); err != nil {
+		return err
+	}
+
+	if _, err := a.Srv.Store.System().GetByName(HEALTH_CHECK_SYSTEM_KEY); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *App) probeFilestore() error {
+	backend, err := a.FileBackend()
+	if err != nil {
+		return err
+	}
+
+	if err := backend.TestConnection(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *App) elasticsearchProbe() healthProbe {
+	if a.Elasticsearch == nil || !*a.Config().ElasticsearchSettings.EnableIndexing {
+		return nil
+	}
+
+	return func() error {
+		if err := a.Elasticsearch.TestConfig(a.Config()); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func (a *App) pushProxyProbe() healthProbe {
+	pushServer := *a.Config().EmailSettings.PushNotificationServer
+	if !*a.Config().EmailSettings.SendPushNotifications || pushServer == "" {
+		return nil
+	}
+
+	return func() error {
+		client := a.HTTPService.MakeClient(true)
+		client.Timeout = HEALTH_CHECK_PROBE_TIMEOUT
+
+		resp, err := client.Get(pushServer)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("push proxy responded with status %v", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+func (a *App) smtpProbe() healthProbe {
+	cfg := a.Config()
+	if !*cfg.EmailSettings.SendEmailNotifications || *cfg.EmailSettings.SMTPServer == "" {
+		return nil
+	}
+
+	return func() error {
+		conn, err := mailservice.ConnectToSMTPServer(cfg)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		client, err := mailservice.NewSMTPClient(conn, cfg)
+		if err != nil {
+			return err
+		}
+		client.Quit()
+
+		return nil
+	}
+}
+
+// clusterProbe asks every other node of the cluster for its stats.
+func (a *App) clusterProbe() healthProbe {
+	if a.Cluster == nil {
+		return nil
+	}
+
+	return func() error {
+		if _, err := a.Cluster.GetClusterStats(); err != nil {
+			return err
+		}
+		return nil
+	}
+}
@@ -624,6 +624,8 @@ func (a *App) JoinUserToTeam(team *model.Team, user *model.User, userRequestorId
 				mlog.Err(err),
 			)
 		}
+
+		a.syncChannelMembershipRulesForUserInBackground(user.Id)
 	}
 
 	a.ClearSessionCacheForUser(user.Id)
@@ -952,10 +954,20 @@ func (a *App) LeaveTeam(team *model.Team, user *model.User, requestorId string)
 
 	for _, channel := range *channelList {
 		if !channel.IsGroupOrDirect() {
+			// Channels shared with other teams are kept while the user is still on one of them.
+			if onOtherTeam, err := a.isUserOnOtherChannelTeam(channel, user.Id, team.Id); err != nil {
+				return err
+			} else if onOtherTeam {
+				continue
+			}
+
 			a.InvalidateCacheForChannelMembers(channel.Id)
 			if err = a.Srv.Store.Channel().RemoveMember(channel.Id, user.Id); err != nil {
 				return err
 			}
+			if err = a.Srv.Store.ChannelMemberExpiry().Delete(channel.Id, user.Id); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -1221,10 +1233,18 @@ func (a *App) PermanentDeleteTeam(team *model.Team) *model.AppError {
 		return err
 	}
 
+	if err := a.Srv.Store.ChannelTeamBinding().PermanentDeleteByTeam(team.Id); err != nil {
+		return err
+	}
+
 	if err := a.Srv.Store.Command().PermanentDeleteByTeam(team.Id); err != nil {
 		return err
 	}
 
+	if err := a.Srv.Store.DailyStat().PermanentDeleteByTeam(team.Id); err != nil {
+		return err
+	}
+
 	if err := a.Srv.Store.Team().PermanentDelete(team.Id); err != nil {
 		return err
 	}
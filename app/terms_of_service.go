@@ -4,6 +4,8 @@
 package app
 
 import (
+	"net/http"
+
 	"github.com/mattermost/mattermost-server/model"
 )
 
@@ -27,3 +29,22 @@ func (a *App) GetLatestTermsOfService() (*model.TermsOfService, *model.AppError)
 func (a *App) GetTermsOfService(id string) (*model.TermsOfService, *model.AppError) {
 	return a.Srv.Store.TermsOfService().Get(id, true)
 }
+
+// GetTermsOfServiceVersions returns every version of the terms of service, starting with the latest.
+func (a *App) GetTermsOfServiceVersions() ([]*model.TermsOfService, *model.AppError) {
+	return a.Srv.Store.TermsOfService().GetAll()
+}
+
+// ScheduleTermsOfServiceReacceptance starts a re-acceptance campaign of a version of the terms of service at
+// reacceptAt, after which the users who accepted it before have to accept it again. A reacceptAt of 0 cancels it.
+func (a *App) ScheduleTermsOfServiceReacceptance(termsOfServiceId string, reacceptAt int64) (*model.TermsOfService, *model.AppError) {
+	if reacceptAt < 0 {
+		return nil, model.NewAppError("ScheduleTermsOfServiceReacceptance", "app.terms_of_service.reaccept_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if err := a.Srv.Store.TermsOfService().UpdateReacceptAt(termsOfServiceId, reacceptAt); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.TermsOfService().Get(termsOfServiceId, false)
+}
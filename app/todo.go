@@ -0,0 +1,89 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// AddTodo adds an item to the end of a user's todo list.
+func (a *App) AddTodo(userId, message string) (*model.Todo, *model.AppError) {
+	todos, err := a.Srv.Store.Todo().GetForUser(userId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(todos) >= model.TODO_MAX_PER_USER {
+		return nil, model.NewAppError("AddTodo", "app.todo.too_many.app_error", map[string]interface{}{"Max": model.TODO_MAX_PER_USER}, "user_id="+userId, http.StatusBadRequest)
+	}
+
+	return a.Srv.Store.Todo().Save(&model.Todo{UserId: userId, Message: strings.TrimSpace(message)})
+}
+
+// GetTodos returns the items of a user's todo list in the order they were added.
+func (a *App) GetTodos(userId string, includeCompleted bool) ([]*model.Todo, *model.AppError) {
+	return a.Srv.Store.Todo().GetForUser(userId, includeCompleted)
+}
+
+func (a *App) getTodoForUser(userId, todoId string) (*model.Todo, *model.AppError) {
+	todo, err := a.Srv.Store.Todo().Get(todoId)
+	if err != nil {
+		return nil, err
+	}
+
+	if todo.UserId != userId {
+		return nil, model.NewAppError("getTodoForUser", "app.todo.not_found.app_error", nil, "id="+todoId, http.StatusNotFound)
+	}
+
+	return todo, nil
+}
+
+// CompleteTodo marks an item of a user's todo list as done.
+func (a *App) CompleteTodo(userId, todoId string) (*model.Todo, *model.AppError) {
+	todo, err := a.getTodoForUser(userId, todoId)
+	if err != nil {
+		return nil, err
+	}
+
+	if todo.IsComplete() {
+		return todo, nil
+	}
+
+	todo.CompleteAt = model.GetMillis()
+	return a.Srv.Store.Todo().Update(todo)
+}
+
+// DeleteTodo removes an item from a user's todo list.
+func (a *App) DeleteTodo(userId, todoId string) *model.AppError {
+	if _, err := a.getTodoForUser(userId, todoId); err != nil {
+		return err
+	}
+
+	return a.Srv.Store.Todo().Delete(todoId)
+}
+
+// ClearCompletedTodos removes the items of a user's todo list that are done, returning how many were removed.
+func (a *App) ClearCompletedTodos(userId string) (int, *model.AppError) {
+	todos, err := a.Srv.Store.Todo().GetForUser(userId, true)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, todo := range todos {
+		if !todo.IsComplete() {
+			continue
+		}
+
+		if err := a.Srv.Store.Todo().Delete(todo.Id); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
@@ -691,6 +691,11 @@ func (a *App) DeactivateMfa(userId string) *model.AppError {
 		return err
 	}
 
+	// Recovery codes are only good for the MFA secret they were generated with
+	if err := a.Srv.Store.MfaRecoveryCode().PermanentDeleteByUser(userId); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -912,6 +917,8 @@ func (a *App) SetProfileImageFromFile(userId string, file io.Reader) *model.AppE
 	}
 	a.invalidateUserCacheAndPublish(userId)
 
+	a.completeOnboardingSteps(userId, model.ONBOARDING_STEP_TYPE_SET_AVATAR, "")
+
 	return nil
 }
 
@@ -1168,6 +1175,10 @@ func (a *App) UpdateUser(user *model.User, sendNotifications bool) (*model.User,
 		})
 	}
 
+	if channelMembershipRuleAttributesChanged(userUpdate.Old, userUpdate.New) {
+		a.syncChannelMembershipRulesForUserInBackground(user.Id)
+	}
+
 	return userUpdate.New, nil
 }
 
@@ -1200,14 +1211,23 @@ func (a *App) UpdateUserNotifyProps(userId string, props map[string]string) (*mo
 	return ruser, nil
 }
 
-func (a *App) UpdateMfa(activate bool, userId, token string) *model.AppError {
+// UpdateMfa activates or deactivates MFA for a user. Activating it also generates the recovery codes of the user, which
+// are returned since this is the only time they can be shown.
+func (a *App) UpdateMfa(activate bool, userId, token string) ([]string, *model.AppError) {
+	var recoveryCodes []string
 	if activate {
 		if err := a.ActivateMfa(userId, token); err != nil {
-			return err
+			return nil, err
+		}
+
+		codes, err := a.GenerateMfaRecoveryCodes(userId)
+		if err != nil {
+			return nil, err
 		}
+		recoveryCodes = codes
 	} else {
 		if err := a.DeactivateMfa(userId); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -1223,7 +1243,7 @@ func (a *App) UpdateMfa(activate bool, userId, token string) *model.AppError {
 		}
 	})
 
-	return nil
+	return recoveryCodes, nil
 }
 
 func (a *App) UpdatePasswordByUserIdSendEmail(userId, newPassword, method string) *model.AppError {
@@ -1258,6 +1278,7 @@ func (a *App) UpdatePasswordSendEmail(user *model.User, newPassword, method stri
 		if err := a.SendPasswordChangeEmail(user.Email, method, user.Locale, a.GetSiteURL()); err != nil {
 			mlog.Error("Failed to send password change email", mlog.Err(err))
 		}
+		a.notifyPasswordChanged(user)
 	})
 
 	return nil
@@ -1452,10 +1473,70 @@ func (a *App) PermanentDeleteUser(user *model.User) *model.AppError {
 		return err
 	}
 
+	if err := a.Srv.Store.EphemeralPost().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
 	if err := a.Srv.Store.Channel().PermanentDeleteMembersByUser(user.Id); err != nil {
 		return err
 	}
 
+	if err := a.Srv.Store.ChannelMemberExpiry().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.UserDeactivationSchedule().Delete(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.UserAttribute().PermanentDeleteValuesByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.PostStar().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.PendingPost().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.UserAvailability().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.LoginAttempt().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.MfaRecoveryCode().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Todo().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Reminder().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Poll().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.ChannelMembershipRule().PermanentDeleteOverridesByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Announcement().PermanentDeleteRecipientsByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Onboarding().PermanentDeleteCompletionsByUser(user.Id); err != nil {
+		return err
+	}
+
 	if err := a.Srv.Store.Post().PermanentDeleteByUser(user.Id); err != nil {
 		return err
 	}
@@ -1676,7 +1757,7 @@ func (a *App) SearchUsers(props *model.UserSearch, options *model.UserSearchOpti
 
 func (a *App) SearchUsersInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	term = strings.TrimSpace(term)
-	users, err := a.Srv.Store.User().SearchInChannel(channelId, term, options)
+	users, err := a.Srv.Store.User().SearchInChannel(a.Context(), channelId, term, options)
 	if err != nil {
 		return nil, err
 	}
@@ -1684,12 +1765,14 @@ func (a *App) SearchUsersInChannel(channelId string, term string, options *model
 		a.SanitizeProfile(user, options.IsAdmin)
 	}
 
+	a.rankUserSearchResults(term, users)
+
 	return users, nil
 }
 
 func (a *App) SearchUsersNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	term = strings.TrimSpace(term)
-	users, err := a.Srv.Store.User().SearchNotInChannel(teamId, channelId, term, options)
+	users, err := a.Srv.Store.User().SearchNotInChannel(a.Context(), teamId, channelId, term, options)
 	if err != nil {
 		return nil, err
 	}
@@ -1698,6 +1781,8 @@ func (a *App) SearchUsersNotInChannel(teamId string, channelId string, term stri
 		a.SanitizeProfile(user, options.IsAdmin)
 	}
 
+	a.rankUserSearchResults(term, users)
+
 	return users, nil
 }
 
@@ -1740,7 +1825,7 @@ func (a *App) SearchUsersInTeam(teamId, term string, options *model.UserSearchOp
 	}
 
 	if !a.IsESAutocompletionEnabled() || err != nil {
-		users, err = a.Srv.Store.User().Search(teamId, term, options)
+		users, err = a.Srv.Store.User().Search(a.Context(), teamId, term, options)
 		if err != nil {
 			return nil, err
 		}
@@ -1750,12 +1835,14 @@ func (a *App) SearchUsersInTeam(teamId, term string, options *model.UserSearchOp
 		}
 	}
 
+	a.rankUserSearchResults(term, users)
+
 	return users, nil
 }
 
 func (a *App) SearchUsersNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	term = strings.TrimSpace(term)
-	users, err := a.Srv.Store.User().SearchNotInTeam(notInTeamId, term, options)
+	users, err := a.Srv.Store.User().SearchNotInTeam(a.Context(), notInTeamId, term, options)
 	if err != nil {
 		return nil, err
 	}
@@ -1764,12 +1851,14 @@ func (a *App) SearchUsersNotInTeam(notInTeamId string, term string, options *mod
 		a.SanitizeProfile(user, options.IsAdmin)
 	}
 
+	a.rankUserSearchResults(term, users)
+
 	return users, nil
 }
 
 func (a *App) SearchUsersWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	term = strings.TrimSpace(term)
-	users, err := a.Srv.Store.User().SearchWithoutTeam(term, options)
+	users, err := a.Srv.Store.User().SearchWithoutTeam(a.Context(), term, options)
 	if err != nil {
 		return nil, err
 	}
@@ -1778,6 +1867,8 @@ func (a *App) SearchUsersWithoutTeam(term string, options *model.UserSearchOptio
 		a.SanitizeProfile(user, options.IsAdmin)
 	}
 
+	a.rankUserSearchResults(term, users)
+
 	return users, nil
 }
 
@@ -1860,14 +1951,14 @@ func (a *App) AutocompleteUsersInChannel(teamId string, channelId string, term s
 
 		uchan := make(chan store.StoreResult, 1)
 		go func() {
-			users, err := a.Srv.Store.User().SearchInChannel(channelId, term, options)
+			users, err := a.Srv.Store.User().SearchInChannel(a.Context(), channelId, term, options)
 			uchan <- store.StoreResult{Data: users, Err: err}
 			close(uchan)
 		}()
 
 		nuchan := make(chan store.StoreResult, 1)
 		go func() {
-			users, err := a.Srv.Store.User().SearchNotInChannel(teamId, channelId, term, options)
+			users, err := a.Srv.Store.User().SearchNotInChannel(a.Context(), teamId, channelId, term, options)
 			nuchan <- store.StoreResult{Data: users, Err: err}
 			close(nuchan)
 		}()
@@ -1898,6 +1989,8 @@ func (a *App) AutocompleteUsersInChannel(teamId string, channelId string, term s
 		autocomplete.OutOfChannel = users
 	}
 
+	a.rankUserSearchResults(term, autocomplete.InChannel, autocomplete.OutOfChannel)
+
 	return autocomplete, nil
 }
 
@@ -1945,7 +2038,7 @@ func (a *App) AutocompleteUsersInTeam(teamId string, term string, options *model
 
 	if !a.IsESAutocompletionEnabled() || err != nil {
 		autocomplete = &model.UserAutocompleteInTeam{}
-		users, err := a.Srv.Store.User().Search(teamId, term, options)
+		users, err := a.Srv.Store.User().Search(a.Context(), teamId, term, options)
 		if err != nil {
 			return nil, err
 		}
@@ -1957,9 +2050,24 @@ func (a *App) AutocompleteUsersInTeam(teamId string, term string, options *model
 		autocomplete.InTeam = users
 	}
 
+	a.rankUserSearchResults(term, autocomplete.InTeam)
+
 	return autocomplete, nil
 }
 
+// rankUserSearchResults sorts each list of users found for the term from the most to the least relevant ones to the
+// user of the session, who sees the users they interact with the most first.
+func (a *App) rankUserSearchResults(term string, userLists ...[]*model.User) {
+	interactionScores := map[string]int64{}
+	if a.Session.UserId != "" {
+		interactionScores = a.getUserInteractionScores(a.Session.UserId)
+	}
+
+	for _, users := range userLists {
+		model.RankUserSearchResults(users, term, interactionScores)
+	}
+}
+
 func (a *App) UpdateOAuthUserAttrs(userData io.Reader, user *model.User, provider einterfaces.OauthProvider, service string) *model.AppError {
 	oauthUser := provider.GetUserFromJson(userData)
 	if oauthUser == nil {
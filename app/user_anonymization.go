@@ -0,0 +1,207 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/timezones"
+)
+
+const USER_ANONYMIZATION_POSTS_BATCH_SIZE = 1000
+
+// CreateUserAnonymizationJob schedules a job erasing the personal data of a user, to honour a request to be
+// forgotten. The user is deactivated, but their posts are kept so that the threads they took part in stay whole.
+func (a *App) CreateUserAnonymizationJob(userId, requesterId string) (*model.Job, *model.AppError) {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsBot {
+		return nil, model.NewAppError("CreateUserAnonymizationJob", "app.user_anonymization.bot.app_error", nil, "user_id="+userId, http.StatusBadRequest)
+	}
+
+	if user.IsAnonymized() {
+		return nil, model.NewAppError("CreateUserAnonymizationJob", "app.user_anonymization.already_anonymized.app_error", nil, "user_id="+userId, http.StatusBadRequest)
+	}
+
+	jobData := map[string]string{
+		model.USER_ANONYMIZATION_JOB_DATA_USER_ID:      userId,
+		model.USER_ANONYMIZATION_JOB_DATA_REQUESTER_ID: requesterId,
+	}
+
+	return a.Srv.Jobs.CreateJob(model.JOB_TYPE_USER_ANONYMIZATION, jobData)
+}
+
+// AnonymizeUser erases the personal data of a user and returns how many of their posts were rewritten. The content
+// of the posts is left untouched for audit, but the display metadata they carry about their author is removed. Posts
+// are rewritten before the account, since they are matched against the username the user had, and running it again
+// on an anonymized user is harmless so that a job interrupted halfway can be retried.
+func (a *App) AnonymizeUser(userId string) (int, *model.AppError) {
+	user, err := a.Srv.Store.User().Get(userId)
+	if err != nil {
+		return 0, err
+	}
+
+	rewritten, err := a.anonymizeUserPosts(user)
+	if err != nil {
+		return rewritten, err
+	}
+
+	if err := a.anonymizeUserAccount(user); err != nil {
+		return rewritten, err
+	}
+
+	mlog.Info("Anonymized user", mlog.String("user_id", user.Id), mlog.Int("posts_rewritten", rewritten))
+
+	return rewritten, nil
+}
+
+func (a *App) anonymizeUserPosts(user *model.User) (int, *model.AppError) {
+	anonymizedUsername := model.AnonymizedUsername(user.Id)
+	channelIds := make(map[string]bool)
+	rewritten := 0
+
+	afterId := ""
+	for {
+		posts, err := a.Srv.Store.Post().GetByUserAfter(user.Id, afterId, USER_ANONYMIZATION_POSTS_BATCH_SIZE)
+		if err != nil {
+			return rewritten, err
+		}
+
+		for _, post := range posts {
+			if anonymizePostProps(post, user.Username, anonymizedUsername) {
+				if err := a.Srv.Store.Post().OverwriteProps(post); err != nil {
+					return rewritten, err
+				}
+				channelIds[post.ChannelId] = true
+				rewritten++
+			}
+		}
+
+		if len(posts) < USER_ANONYMIZATION_POSTS_BATCH_SIZE {
+			break
+		}
+		afterId = posts[len(posts)-1].Id
+	}
+
+	for channelId := range channelIds {
+		a.InvalidateCacheForChannelPosts(channelId)
+	}
+
+	return rewritten, nil
+}
+
+// anonymizePostProps removes the author display metadata from the props of a post and returns whether it changed.
+func anonymizePostProps(post *model.Post, username, anonymizedUsername string) bool {
+	changed := false
+
+	for _, key := range []string{"override_username", model.POST_PROPS_OVERRIDE_ICON_URL} {
+		if _, ok := post.Props[key]; ok {
+			delete(post.Props, key)
+			changed = true
+		}
+	}
+
+	if value, ok := post.Props["username"].(string); ok && value == username && value != anonymizedUsername {
+		post.AddProp("username", anonymizedUsername)
+		changed = true
+	}
+
+	return changed
+}
+
+func (a *App) anonymizeUserAccount(user *model.User) *model.AppError {
+	if user.DeleteAt == 0 {
+		var err *model.AppError
+		if user, err = a.UpdateActive(user, false); err != nil {
+			return err
+		}
+	}
+
+	if err := a.Srv.Store.Session().PermanentDeleteSessionsByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.UserAccessToken().DeleteAllForUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.OAuth().PermanentDeleteAuthDataByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.UserAttribute().PermanentDeleteValuesByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.LoginAttempt().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.MfaRecoveryCode().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Todo().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Reminder().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.ChannelMembershipRule().PermanentDeleteOverridesByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Announcement().PermanentDeleteRecipientsByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Onboarding().PermanentDeleteCompletionsByUser(user.Id); err != nil {
+		return err
+	}
+
+	// Clearing the auth data also clears the password and the MFA secret, so that nobody can log in as the user.
+	email := model.AnonymizedEmail(user.Id)
+	if _, err := a.Srv.Store.User().UpdateAuthData(user.Id, "", nil, email, true); err != nil {
+		return err
+	}
+
+	user.Username = model.AnonymizedUsername(user.Id)
+	user.Email = email
+	user.FirstName = ""
+	user.LastName = ""
+	user.Nickname = ""
+	user.Position = ""
+	user.Props = model.StringMap{}
+	user.Timezone = timezones.DefaultUserTimezone()
+	user.SetDefaultNotifications()
+
+	userUpdate, err := a.Srv.Store.User().Update(user, true)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.User().ResetLastPictureUpdate(user.Id); err != nil {
+		return err
+	}
+
+	path := "users/" + user.Id + "/profile.png"
+	if exists, _ := a.FileExists(path); exists {
+		if err := a.RemoveFile(path); err != nil {
+			mlog.Warn("Failed to remove the profile image of an anonymized user", mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+	}
+
+	a.invalidateUserChannelMembersCaches(userUpdate.New)
+	a.InvalidateCacheForUser(user.Id)
+	a.sendUpdatedUserEvent(*userUpdate.New)
+
+	return nil
+}
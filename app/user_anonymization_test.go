@@ -0,0 +1,92 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestAnonymizeUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+	th.AddUserToChannel(user, th.BasicChannel)
+
+	post, err := th.App.Srv.Store.Post().Save(&model.Post{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    user.Id,
+		Message:   "message kept for audit",
+		Props: model.StringInterface{
+			"override_username":                "webhook name",
+			model.POST_PROPS_OVERRIDE_ICON_URL: "https://example.com/icon.png",
+		},
+	})
+	require.Nil(t, err)
+
+	joinPost, err := th.App.Srv.Store.Post().Save(&model.Post{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    user.Id,
+		Type:      model.POST_JOIN_CHANNEL,
+		Message:   user.Username + " joined the channel",
+		Props:     model.StringInterface{"username": user.Username},
+	})
+	require.Nil(t, err)
+
+	untouched, err := th.App.Srv.Store.Post().Save(&model.Post{ChannelId: th.BasicChannel.Id, UserId: user.Id, Message: "plain"})
+	require.Nil(t, err)
+
+	session, err := th.App.CreateSession(&model.Session{UserId: user.Id})
+	require.Nil(t, err)
+
+	rewritten, err := th.App.AnonymizeUser(user.Id)
+	require.Nil(t, err)
+	assert.Equal(t, 2, rewritten)
+
+	anonymized, err := th.App.GetUser(user.Id)
+	require.Nil(t, err)
+	assert.True(t, anonymized.IsAnonymized())
+	assert.Equal(t, model.AnonymizedUsername(user.Id), anonymized.Username)
+	assert.Equal(t, model.AnonymizedEmail(user.Id), anonymized.Email)
+	assert.Empty(t, anonymized.FirstName)
+	assert.Empty(t, anonymized.LastName)
+	assert.Empty(t, anonymized.Nickname)
+	assert.Empty(t, anonymized.Position)
+	assert.NotZero(t, anonymized.DeleteAt)
+
+	_, err = th.App.GetSession(session.Token)
+	assert.NotNil(t, err)
+
+	post, err = th.App.GetSinglePost(post.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "message kept for audit", post.Message)
+	assert.Nil(t, post.Props["override_username"])
+	assert.Nil(t, post.Props[model.POST_PROPS_OVERRIDE_ICON_URL])
+
+	joinPost, err = th.App.GetSinglePost(joinPost.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.AnonymizedUsername(user.Id), joinPost.Props["username"])
+
+	untouched, err = th.App.GetSinglePost(untouched.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "plain", untouched.Message)
+
+	t.Run("running again changes nothing", func(t *testing.T) {
+		rewritten, err := th.App.AnonymizeUser(user.Id)
+		require.Nil(t, err)
+		assert.Equal(t, 0, rewritten)
+	})
+
+	t.Run("job can't be created twice", func(t *testing.T) {
+		_, err := th.App.CreateUserAnonymizationJob(user.Id, th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.user_anonymization.already_anonymized.app_error", err.Id)
+	})
+}
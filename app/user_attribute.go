@@ -0,0 +1,282 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (a *App) GetUserAttributeField(fieldId string) (*model.UserAttributeField, *model.AppError) {
+	return a.Srv.Store.UserAttribute().GetField(fieldId)
+}
+
+func (a *App) GetUserAttributeFields() ([]*model.UserAttributeField, *model.AppError) {
+	return a.Srv.Store.UserAttribute().GetFields()
+}
+
+func (a *App) CreateUserAttributeField(field *model.UserAttributeField) (*model.UserAttributeField, *model.AppError) {
+	field.Id = ""
+	field.Name = model.NormalizeUserAttributeName(field.Name)
+
+	if err := a.validateUserAttributeField(field); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.UserAttribute().SaveField(field)
+}
+
+func (a *App) UpdateUserAttributeField(oldField, updatedField *model.UserAttributeField) (*model.UserAttributeField, *model.AppError) {
+	oldField.Name = model.NormalizeUserAttributeName(updatedField.Name)
+	oldField.DisplayName = updatedField.DisplayName
+	oldField.LdapAttribute = updatedField.LdapAttribute
+	oldField.SamlAttribute = updatedField.SamlAttribute
+	oldField.Searchable = updatedField.Searchable
+
+	if err := a.validateUserAttributeField(oldField); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.UserAttribute().UpdateField(oldField)
+}
+
+func (a *App) DeleteUserAttributeField(fieldId string) *model.AppError {
+	return a.Srv.Store.UserAttribute().DeleteField(fieldId, model.GetMillis())
+}
+
+// validateUserAttributeField checks that the name of the field isn't used by another field and that creating it
+// doesn't exceed the number of fields user profiles can have.
+func (a *App) validateUserAttributeField(field *model.UserAttributeField) *model.AppError {
+	if !model.IsValidUserAttributeName(field.Name) {
+		return model.NewAppError("validateUserAttributeField", "model.user_attribute_field.is_valid.name.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	fields, err := a.Srv.Store.UserAttribute().GetFields()
+	if err != nil {
+		return err
+	}
+
+	for _, other := range fields {
+		if other.Id != field.Id && other.Name == field.Name {
+			return model.NewAppError("validateUserAttributeField", "app.user_attribute.name_exists.app_error", map[string]interface{}{"Name": field.Name}, "field_id="+other.Id, http.StatusBadRequest)
+		}
+	}
+
+	if field.Id == "" && len(fields) >= model.USER_ATTRIBUTE_MAX_FIELDS {
+		return model.NewAppError("validateUserAttributeField", "app.user_attribute.max_fields.app_error", map[string]interface{}{"Max": model.USER_ATTRIBUTE_MAX_FIELDS}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// FillInUserAttributes sets the attributes of the users, keyed by the name of their field.
+func (a *App) FillInUserAttributes(users []*model.User) *model.AppError {
+	if len(users) == 0 {
+		return nil
+	}
+
+	fields, err := a.Srv.Store.UserAttribute().GetFields()
+	if err != nil {
+		return err
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	fieldNames := make(map[string]string, len(fields))
+	for _, field := range fields {
+		fieldNames[field.Id] = field.Name
+	}
+
+	userIds := make([]string, 0, len(users))
+	for _, user := range users {
+		userIds = append(userIds, user.Id)
+	}
+
+	values, err := a.Srv.Store.UserAttribute().GetValuesForUsers(userIds)
+	if err != nil {
+		return err
+	}
+
+	attributes := make(map[string]model.StringMap)
+	for _, value := range values {
+		name, ok := fieldNames[value.FieldId]
+		if !ok {
+			continue
+		}
+		if attributes[value.UserId] == nil {
+			attributes[value.UserId] = model.StringMap{}
+		}
+		attributes[value.UserId][name] = value.Value
+	}
+
+	for _, user := range users {
+		user.Attributes = attributes[user.Id]
+	}
+
+	return nil
+}
+
+func (a *App) GetUserAttributes(userId string) (model.StringMap, *model.AppError) {
+	user := &model.User{Id: userId}
+	if err := a.FillInUserAttributes([]*model.User{user}); err != nil {
+		return nil, err
+	}
+
+	if user.Attributes == nil {
+		return model.StringMap{}, nil
+	}
+
+	return user.Attributes, nil
+}
+
+// UpdateUserAttributes sets the given attributes of the user, keyed by the name of their field, leaving the others
+// untouched. An empty value clears the attribute. Attributes synced from an identity provider can only be changed
+// when overrideSynced is true, until they are synced again.
+func (a *App) UpdateUserAttributes(userId string, attributes model.StringMap, overrideSynced bool) (model.StringMap, *model.AppError) {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := a.Srv.Store.UserAttribute().GetFields()
+	if err != nil {
+		return nil, err
+	}
+
+	fieldsByName := make(map[string]*model.UserAttributeField, len(fields))
+	for _, field := range fields {
+		fieldsByName[field.Name] = field
+	}
+
+	values, err := a.Srv.Store.UserAttribute().GetValuesForUsers([]string{user.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	valuesByField := make(map[string]*model.UserAttributeValue, len(values))
+	for _, value := range values {
+		valuesByField[value.FieldId] = value
+	}
+
+	for name := range attributes {
+		field, ok := fieldsByName[model.NormalizeUserAttributeName(name)]
+		if !ok {
+			return nil, model.NewAppError("UpdateUserAttributes", "app.user_attribute.unknown_field.app_error", map[string]interface{}{"Name": name}, "", http.StatusBadRequest)
+		}
+
+		if existing, ok := valuesByField[field.Id]; ok && existing.IsSynced() && !overrideSynced {
+			return nil, model.NewAppError("UpdateUserAttributes", "app.user_attribute.synced.app_error", map[string]interface{}{"Name": field.Name}, "source="+existing.Source, http.StatusForbidden)
+		}
+	}
+
+	for name, value := range attributes {
+		field := fieldsByName[model.NormalizeUserAttributeName(name)]
+
+		if strings.TrimSpace(value) == "" {
+			if err := a.Srv.Store.UserAttribute().DeleteValue(user.Id, field.Id); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if _, err := a.Srv.Store.UserAttribute().SaveValue(&model.UserAttributeValue{UserId: user.Id, FieldId: field.Id, Value: value}); err != nil {
+			return nil, err
+		}
+	}
+
+	return a.onUserAttributesUpdated(user)
+}
+
+// SyncUserAttributes sets the attributes of the user synced from the given identity provider, one of the
+// USER_ATTRIBUTE_SOURCE_* constants, to the values the provider has for them. The attributes are given keyed by
+// their name in the provider, and attributes missing from them are cleared. It's called by the LDAP and SAML
+// implementations when a user logs in or is synchronized.
+func (a *App) SyncUserAttributes(userId, source string, attributes map[string]string) *model.AppError {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return err
+	}
+
+	fields, err := a.Srv.Store.UserAttribute().GetFields()
+	if err != nil {
+		return err
+	}
+
+	values, err := a.Srv.Store.UserAttribute().GetValuesForUsers([]string{user.Id})
+	if err != nil {
+		return err
+	}
+
+	valuesByField := make(map[string]*model.UserAttributeValue, len(values))
+	for _, value := range values {
+		valuesByField[value.FieldId] = value
+	}
+
+	changed := false
+	for _, field := range fields {
+		sourceAttribute := field.SourceAttribute(source)
+		if sourceAttribute == "" {
+			continue
+		}
+
+		existing := valuesByField[field.Id]
+		value := strings.TrimSpace(attributes[sourceAttribute])
+
+		if value == "" {
+			if existing == nil || existing.Source != source {
+				continue
+			}
+			if err := a.Srv.Store.UserAttribute().DeleteValue(user.Id, field.Id); err != nil {
+				return err
+			}
+			changed = true
+			continue
+		}
+
+		if existing != nil && existing.Source == source && existing.Value == value {
+			continue
+		}
+
+		if _, err := a.Srv.Store.UserAttribute().SaveValue(&model.UserAttributeValue{UserId: user.Id, FieldId: field.Id, Value: value, Source: source}); err != nil {
+			return err
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	_, err = a.onUserAttributesUpdated(user)
+	return err
+}
+
+// onUserAttributesUpdated lets clients know that the attributes of the user changed, and returns the attributes.
+func (a *App) onUserAttributesUpdated(user *model.User) (model.StringMap, *model.AppError) {
+	updateAt, err := a.Srv.Store.User().UpdateUpdateAt(user.Id)
+	if err != nil {
+		return nil, err
+	}
+	user.UpdateAt = updateAt
+
+	a.InvalidateCacheForUser(user.Id)
+
+	if err := a.FillInUserAttributes([]*model.User{user}); err != nil {
+		return nil, err
+	}
+
+	a.sendUpdatedUserEvent(*user)
+
+	a.syncChannelMembershipRulesForUserInBackground(user.Id)
+
+	if user.Attributes == nil {
+		return model.StringMap{}, nil
+	}
+
+	return user.Attributes, nil
+}
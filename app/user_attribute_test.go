@@ -0,0 +1,84 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCreateUserAttributeField(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	field, err := th.App.CreateUserAttributeField(&model.UserAttributeField{Name: "Department", DisplayName: "Department"})
+	require.Nil(t, err)
+	assert.Equal(t, "department", field.Name)
+
+	_, err = th.App.CreateUserAttributeField(&model.UserAttributeField{Name: "department", DisplayName: "Other"})
+	require.NotNil(t, err)
+	assert.Equal(t, "app.user_attribute.name_exists.app_error", err.Id)
+
+	_, err = th.App.CreateUserAttributeField(&model.UserAttributeField{Name: "cost center", DisplayName: "Cost center"})
+	require.NotNil(t, err)
+	assert.Equal(t, "model.user_attribute_field.is_valid.name.app_error", err.Id)
+}
+
+func TestUpdateUserAttributes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	department, err := th.App.CreateUserAttributeField(&model.UserAttributeField{Name: "department", DisplayName: "Department", LdapAttribute: "departmentNumber"})
+	require.Nil(t, err)
+	_, err = th.App.CreateUserAttributeField(&model.UserAttributeField{Name: "pronouns", DisplayName: "Pronouns"})
+	require.Nil(t, err)
+
+	attributes, err := th.App.UpdateUserAttributes(th.BasicUser.Id, model.StringMap{"pronouns": "they/them", "department": "Sales"}, false)
+	require.Nil(t, err)
+	assert.Equal(t, model.StringMap{"pronouns": "they/them", "department": "Sales"}, attributes)
+
+	_, err = th.App.UpdateUserAttributes(th.BasicUser.Id, model.StringMap{"location": "Lisbon"}, false)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.user_attribute.unknown_field.app_error", err.Id)
+
+	t.Run("synced", func(t *testing.T) {
+		require.Nil(t, th.App.SyncUserAttributes(th.BasicUser.Id, model.USER_ATTRIBUTE_SOURCE_LDAP, map[string]string{"departmentNumber": "Engineering"}))
+
+		attributes, err := th.App.GetUserAttributes(th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.Equal(t, "Engineering", attributes["department"])
+
+		_, err = th.App.UpdateUserAttributes(th.BasicUser.Id, model.StringMap{"department": "Sales"}, false)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.user_attribute.synced.app_error", err.Id)
+
+		// Synced attributes missing from the provider are cleared.
+		require.Nil(t, th.App.SyncUserAttributes(th.BasicUser.Id, model.USER_ATTRIBUTE_SOURCE_LDAP, map[string]string{}))
+
+		attributes, err = th.App.GetUserAttributes(th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.Equal(t, model.StringMap{"pronouns": "they/them"}, attributes)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		attributes, err := th.App.UpdateUserAttributes(th.BasicUser.Id, model.StringMap{"pronouns": ""}, false)
+		require.Nil(t, err)
+		assert.Empty(t, attributes)
+	})
+
+	t.Run("deleted field", func(t *testing.T) {
+		_, err := th.App.UpdateUserAttributes(th.BasicUser.Id, model.StringMap{"department": "Sales"}, true)
+		require.Nil(t, err)
+
+		require.Nil(t, th.App.DeleteUserAttributeField(department.Id))
+
+		users := []*model.User{th.BasicUser}
+		require.Nil(t, th.App.FillInUserAttributes(users))
+		assert.Empty(t, users[0].Attributes)
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (a *App) GetUserAvailability(availabilityId string) (*model.UserAvailability, *model.AppError) {
+	return a.Srv.Store.UserAvailability().Get(availabilityId)
+}
+
+// GetUserAvailabilityForUser returns the blocks of a user's availability overlapping the given span of time.
+func (a *App) GetUserAvailabilityForUser(userId string, startAt, endAt int64) ([]*model.UserAvailability, *model.AppError) {
+	if endAt <= startAt || endAt-startAt > model.USER_AVAILABILITY_MAX_RANGE {
+		return nil, model.NewAppError("GetUserAvailabilityForUser", "app.user_availability.invalid_range.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return a.Srv.Store.UserAvailability().GetForUser(userId, startAt, endAt)
+}
+
+// GetUserAvailabilityStatuses returns whether each of the given users is busy or free at the given time.
+func (a *App) GetUserAvailabilityStatuses(userIds []string, at int64) (map[string]string, *model.AppError) {
+	blocks, err := a.Srv.Store.UserAvailability().GetForUsersAt(userIds, at)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksByUser := make(map[string][]*model.UserAvailability)
+	for _, block := range blocks {
+		blocksByUser[block.UserId] = append(blocksByUser[block.UserId], block)
+	}
+
+	statuses := make(map[string]string, len(userIds))
+	for _, userId := range userIds {
+		statuses[userId] = model.UserAvailabilityStatusAt(blocksByUser[userId], at)
+	}
+
+	return statuses, nil
+}
+
+// getBusyUserIds returns which of the given users are busy at the given time.
+func (a *App) getBusyUserIds(userIds []string, at int64) map[string]bool {
+	busyUserIds := make(map[string]bool)
+	if len(userIds) == 0 {
+		return busyUserIds
+	}
+
+	statuses, err := a.GetUserAvailabilityStatuses(userIds, at)
+	if err != nil {
+		mlog.Warn("Failed to get the availability of users", mlog.Err(err))
+		return busyUserIds
+	}
+
+	for userId, status := range statuses {
+		if status == model.USER_AVAILABILITY_BUSY {
+			busyUserIds[userId] = true
+		}
+	}
+
+	return busyUserIds
+}
+
+func (a *App) CreateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	if availability.CreatorId == "" {
+		availability.CreatorId = availability.UserId
+	}
+
+	if _, err := a.GetUser(availability.UserId); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.UserAvailability().Save(availability)
+}
+
+// UpdateUserAvailability updates the status, title and times of a block of availability. Who it's for and what
+// created it never change.
+func (a *App) UpdateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	oldAvailability, err := a.Srv.Store.UserAvailability().Get(availability.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	oldAvailability.Status = availability.Status
+	oldAvailability.Title = availability.Title
+	oldAvailability.StartAt = availability.StartAt
+	oldAvailability.EndAt = availability.EndAt
+
+	return a.Srv.Store.UserAvailability().Update(oldAvailability)
+}
+
+func (a *App) DeleteUserAvailability(availabilityId string) *model.AppError {
+	return a.Srv.Store.UserAvailability().Delete(availabilityId)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestUserAvailability(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	now := model.GetMillis()
+
+	meeting, err := th.App.CreateUserAvailability(&model.UserAvailability{
+		UserId:  th.BasicUser.Id,
+		Source:  "com.example.calendar",
+		Status:  model.USER_AVAILABILITY_BUSY,
+		Title:   "Planning",
+		StartAt: now - 60*1000,
+		EndAt:   now + 60*60*1000,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, th.BasicUser.Id, meeting.CreatorId)
+
+	_, err = th.App.CreateUserAvailability(&model.UserAvailability{UserId: model.NewId(), Status: model.USER_AVAILABILITY_BUSY, StartAt: now, EndAt: now + 1000})
+	require.NotNil(t, err)
+
+	blocks, err := th.App.GetUserAvailabilityForUser(th.BasicUser.Id, now, now+24*60*60*1000)
+	require.Nil(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, meeting.Id, blocks[0].Id)
+
+	_, err = th.App.GetUserAvailabilityForUser(th.BasicUser.Id, now, now+2*model.USER_AVAILABILITY_MAX_RANGE)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.user_availability.invalid_range.app_error", err.Id)
+
+	statuses, err := th.App.GetUserAvailabilityStatuses([]string{th.BasicUser.Id, th.BasicUser2.Id}, now)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		th.BasicUser.Id:  model.USER_AVAILABILITY_BUSY,
+		th.BasicUser2.Id: model.USER_AVAILABILITY_FREE,
+	}, statuses)
+	assert.Equal(t, map[string]bool{th.BasicUser.Id: true}, th.App.getBusyUserIds([]string{th.BasicUser.Id, th.BasicUser2.Id}, now))
+
+	t.Run("updates the status and times only", func(t *testing.T) {
+		update := *meeting
+		update.UserId = th.BasicUser2.Id
+		update.Source = "other"
+		update.Status = model.USER_AVAILABILITY_FREE
+
+		updated, err := th.App.UpdateUserAvailability(&update)
+		require.Nil(t, err)
+		assert.Equal(t, model.USER_AVAILABILITY_FREE, updated.Status)
+		assert.Equal(t, th.BasicUser.Id, updated.UserId)
+		assert.Equal(t, "com.example.calendar", updated.Source)
+	})
+
+	require.Nil(t, th.App.DeleteUserAvailability(meeting.Id))
+	_, err = th.App.GetUserAvailability(meeting.Id)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	BULK_USERS_DIRECTORY = "bulk_users/"
+)
+
+func bulkUsersOperationPath(operationId string) string {
+	return BULK_USERS_DIRECTORY + operationId + "/operation.json"
+}
+
+func bulkUsersResultsPath(operationId string) string {
+	return BULK_USERS_DIRECTORY + operationId + "/results.json"
+}
+
+// CreateBulkUsersJob stores the operation and schedules a job to apply it. As for bulk preferences, operations
+// are kept in the file store rather than in the job data since the list of users can be large.
+func (a *App) CreateBulkUsersJob(operation *model.BulkUsersOperation, requesterId string) (*model.Job, *model.AppError) {
+	if err := operation.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if operation.Action == model.BULK_USERS_ACTION_MOVE_TEAM {
+		if _, err := a.GetTeam(operation.ToTeamId); err != nil {
+			return nil, err
+		}
+		if len(operation.FromTeamId) > 0 {
+			if _, err := a.GetTeam(operation.FromTeamId); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	operationId := model.NewId()
+	if _, err := a.WriteFile(bytes.NewReader([]byte(operation.ToJson())), bulkUsersOperationPath(operationId)); err != nil {
+		return nil, err
+	}
+
+	jobData := map[string]string{
+		model.BULK_USERS_JOB_DATA_OPERATION_ID: operationId,
+		model.BULK_USERS_JOB_DATA_ACTION:       operation.Action,
+		model.BULK_USERS_JOB_DATA_REQUESTER_ID: requesterId,
+	}
+
+	return a.Srv.Jobs.CreateJob(model.JOB_TYPE_BULK_USERS, jobData)
+}
+
+// GetBulkUsersOperation returns the operation that the given bulk users job applies.
+func (a *App) GetBulkUsersOperation(job *model.Job) (*model.BulkUsersOperation, *model.AppError) {
+	data, err := a.ReadFile(bulkUsersOperationPath(job.Data[model.BULK_USERS_JOB_DATA_OPERATION_ID]))
+	if err != nil {
+		return nil, err
+	}
+
+	operation := model.BulkUsersOperationFromJson(bytes.NewReader(data))
+	if operation == nil {
+		return nil, model.NewAppError("GetBulkUsersOperation", "app.bulk_users.operation.parse.app_error", nil, "job_id="+job.Id, http.StatusInternalServerError)
+	}
+
+	return operation, nil
+}
+
+// GetBulkUsersResults returns the outcome for each user of a bulk users job, as far as the job went.
+func (a *App) GetBulkUsersResults(jobId string) (*model.BulkUsersResults, *model.AppError) {
+	job, err := a.GetJob(jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Type != model.JOB_TYPE_BULK_USERS {
+		return nil, model.NewAppError("GetBulkUsersResults", "app.bulk_users.job_type.app_error", nil, "job_id="+job.Id, http.StatusBadRequest)
+	}
+
+	path := bulkUsersResultsPath(job.Data[model.BULK_USERS_JOB_DATA_OPERATION_ID])
+	if exists, err := a.FileExists(path); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, model.NewAppError("GetBulkUsersResults", "app.bulk_users.results.not_found.app_error", nil, "job_id="+job.Id, http.StatusNotFound)
+	}
+
+	data, err := a.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	results := model.BulkUsersResultsFromJson(bytes.NewReader(data))
+	if results == nil {
+		return nil, model.NewAppError("GetBulkUsersResults", "app.bulk_users.results.parse.app_error", nil, "job_id="+job.Id, http.StatusInternalServerError)
+	}
+
+	return results, nil
+}
+
+// SaveBulkUsersResults writes the results of the given bulk users job.
+func (a *App) SaveBulkUsersResults(job *model.Job, results *model.BulkUsersResults) *model.AppError {
+	_, err := a.WriteFile(bytes.NewReader([]byte(results.ToJson())), bulkUsersResultsPath(job.Data[model.BULK_USERS_JOB_DATA_OPERATION_ID]))
+	return err
+}
+
+// ApplyBulkUsersOperation applies the operation to each of the given users. Failing to update a user doesn't
+// stop the operation, the error is reported in the result for that user instead.
+func (a *App) ApplyBulkUsersOperation(operation *model.BulkUsersOperation, userIds []string, requesterId string) []*model.BulkUsersResult {
+	var toTeam, fromTeam *model.Team
+	var teamErr *model.AppError
+	if operation.Action == model.BULK_USERS_ACTION_MOVE_TEAM {
+		toTeam, teamErr = a.GetTeam(operation.ToTeamId)
+		if teamErr == nil && len(operation.FromTeamId) > 0 {
+			fromTeam, teamErr = a.GetTeam(operation.FromTeamId)
+		}
+	}
+
+	results := make([]*model.BulkUsersResult, 0, len(userIds))
+	for _, userId := range userIds {
+		err := teamErr
+		if err == nil {
+			err = a.applyBulkUsersOperationToUser(operation, userId, requesterId, toTeam, fromTeam)
+		}
+
+		result := &model.BulkUsersResult{UserId: userId, Status: model.BULK_USERS_RESULT_SUCCESS}
+		if err != nil {
+			result.Status = model.BULK_USERS_RESULT_FAILED
+			result.Error = err.Id
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (a *App) applyBulkUsersOperationToUser(operation *model.BulkUsersOperation, userId, requesterId string, toTeam, fromTeam *model.Team) *model.AppError {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return err
+	}
+
+	switch operation.Action {
+	case model.BULK_USERS_ACTION_DEACTIVATE:
+		if user.Id == requesterId {
+			return model.NewAppError("applyBulkUsersOperationToUser", "app.bulk_users.requester.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+		}
+		if user.DeleteAt != 0 {
+			return nil
+		}
+		_, err = a.UpdateActive(user, false)
+		return err
+
+	case model.BULK_USERS_ACTION_UPDATE_ROLES:
+		if user.Id == requesterId {
+			return model.NewAppError("applyBulkUsersOperationToUser", "app.bulk_users.requester.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+		}
+		_, err = a.UpdateUserRoles(user.Id, operation.Roles, true)
+		return err
+
+	case model.BULK_USERS_ACTION_MOVE_TEAM:
+		if err = a.JoinUserToTeam(toTeam, user, requesterId); err != nil {
+			return err
+		}
+		if fromTeam == nil {
+			return nil
+		}
+		if member, memberErr := a.GetTeamMember(fromTeam.Id, user.Id); memberErr != nil || member.DeleteAt != 0 {
+			return nil
+		}
+		return a.LeaveTeam(fromTeam, user, requesterId)
+
+	case model.BULK_USERS_ACTION_RESET_PASSWORD:
+		return a.forcePasswordReset(user)
+	}
+
+	return model.NewAppError("applyBulkUsersOperationToUser", "model.bulk_users.is_valid.action.app_error", nil, "action="+operation.Action, http.StatusBadRequest)
+}
+
+// forcePasswordReset replaces the password of the user with a random one and revokes their sessions, so that
+// they have to follow the emailed password reset link to log in again.
+func (a *App) forcePasswordReset(user *model.User) *model.AppError {
+	if user.IsBot || user.IsSSOUser() || (user.AuthData != nil && len(*user.AuthData) > 0) {
+		return model.NewAppError("forcePasswordReset", "app.bulk_users.reset_password.auth_service.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	if err := a.Srv.Store.User().UpdatePassword(user.Id, model.HashPassword(model.NewId()+model.NewId())); err != nil {
+		return model.NewAppError("forcePasswordReset", "api.user.update_password.failed.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := a.RevokeAllSessions(user.Id); err != nil {
+		return err
+	}
+
+	if _, err := a.SendPasswordReset(user.Email, a.GetSiteURL()); err != nil {
+		return err
+	}
+
+	return nil
+}
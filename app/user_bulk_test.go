@@ -0,0 +1,95 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestApplyBulkUsersOperation(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("deactivate", func(t *testing.T) {
+		user := th.CreateUser()
+		operation := &model.BulkUsersOperation{
+			Action:  model.BULK_USERS_ACTION_DEACTIVATE,
+			UserIds: []string{user.Id, th.BasicUser.Id, model.NewId()},
+		}
+
+		results := th.App.ApplyBulkUsersOperation(operation, operation.UserIds, th.BasicUser.Id)
+		require.Len(t, results, 3)
+		assert.Equal(t, model.BULK_USERS_RESULT_SUCCESS, results[0].Status)
+		assert.Equal(t, model.BULK_USERS_RESULT_FAILED, results[1].Status)
+		assert.Equal(t, "app.bulk_users.requester.app_error", results[1].Error)
+		assert.Equal(t, model.BULK_USERS_RESULT_FAILED, results[2].Status)
+
+		deactivated, err := th.App.GetUser(user.Id)
+		require.Nil(t, err)
+		assert.NotZero(t, deactivated.DeleteAt)
+	})
+
+	t.Run("update roles", func(t *testing.T) {
+		user := th.CreateUser()
+		operation := &model.BulkUsersOperation{
+			Action:  model.BULK_USERS_ACTION_UPDATE_ROLES,
+			UserIds: []string{user.Id},
+			Roles:   model.SYSTEM_USER_ROLE_ID + " " + model.SYSTEM_POST_ALL_ROLE_ID,
+		}
+
+		results := th.App.ApplyBulkUsersOperation(operation, operation.UserIds, th.BasicUser.Id)
+		require.Len(t, results, 1)
+		assert.Equal(t, model.BULK_USERS_RESULT_SUCCESS, results[0].Status)
+
+		updated, err := th.App.GetUser(user.Id)
+		require.Nil(t, err)
+		assert.Equal(t, operation.Roles, updated.Roles)
+	})
+
+	t.Run("move team", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+		team := th.CreateTeam()
+
+		operation := &model.BulkUsersOperation{
+			Action:     model.BULK_USERS_ACTION_MOVE_TEAM,
+			UserIds:    []string{user.Id},
+			FromTeamId: th.BasicTeam.Id,
+			ToTeamId:   team.Id,
+		}
+
+		results := th.App.ApplyBulkUsersOperation(operation, operation.UserIds, th.BasicUser.Id)
+		require.Len(t, results, 1)
+		assert.Equal(t, model.BULK_USERS_RESULT_SUCCESS, results[0].Status)
+
+		member, err := th.App.GetTeamMember(team.Id, user.Id)
+		require.Nil(t, err)
+		assert.Zero(t, member.DeleteAt)
+
+		member, err = th.App.GetTeamMember(th.BasicTeam.Id, user.Id)
+		require.Nil(t, err)
+		assert.NotZero(t, member.DeleteAt)
+	})
+
+	t.Run("reset password", func(t *testing.T) {
+		user := th.CreateUser()
+		operation := &model.BulkUsersOperation{
+			Action:  model.BULK_USERS_ACTION_RESET_PASSWORD,
+			UserIds: []string{user.Id},
+		}
+
+		results := th.App.ApplyBulkUsersOperation(operation, operation.UserIds, th.BasicUser.Id)
+		require.Len(t, results, 1)
+		assert.Equal(t, model.BULK_USERS_RESULT_SUCCESS, results[0].Status)
+
+		updated, err := th.App.GetUser(user.Id)
+		require.Nil(t, err)
+		assert.False(t, model.ComparePassword(updated.Password, "Password1"))
+	})
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const (
+	USER_DEACTIVATION_BATCH_SIZE        = 100
+	USER_OFFBOARDING_INTEGRATIONS_LIMIT = 1000
+)
+
+// userOffboardingSummary counts what was done to offboard a user when their scheduled deactivation was applied.
+type userOffboardingSummary struct {
+	ChannelsRemoved            int
+	IncomingWebhooksReassigned int
+	OutgoingWebhooksReassigned int
+	CommandsReassigned         int
+	OAuthAppsReassigned        int
+}
+
+func (a *App) GetUserDeactivationSchedule(userId string) (*model.UserDeactivationSchedule, *model.AppError) {
+	return a.Srv.Store.UserDeactivationSchedule().Get(userId)
+}
+
+func (a *App) GetUserDeactivationSchedules(page, perPage int) ([]*model.UserDeactivationSchedule, *model.AppError) {
+	return a.Srv.Store.UserDeactivationSchedule().GetAll(page*perPage, perPage)
+}
+
+// ScheduleUserDeactivation sets when the user is deactivated and offboarded, replacing the date they were already
+// scheduled to be deactivated at if any.
+func (a *App) ScheduleUserDeactivation(userId string, deactivateAt int64, creatorId string) (*model.UserDeactivationSchedule, *model.AppError) {
+	if !*a.Config().OffboardingSettings.EnableScheduledDeactivation {
+		return nil, model.NewAppError("ScheduleUserDeactivation", "app.user_deactivation.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DeleteAt != 0 {
+		return nil, model.NewAppError("ScheduleUserDeactivation", "app.user_deactivation.inactive.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	if user.Id == creatorId {
+		return nil, model.NewAppError("ScheduleUserDeactivation", "app.user_deactivation.self.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	if deactivateAt <= model.GetMillis() {
+		return nil, model.NewAppError("ScheduleUserDeactivation", "app.user_deactivation.deactivate_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	schedule := &model.UserDeactivationSchedule{
+		UserId:       user.Id,
+		CreatorId:    creatorId,
+		DeactivateAt: deactivateAt,
+	}
+
+	if existing, err := a.Srv.Store.UserDeactivationSchedule().Get(user.Id); err == nil {
+		schedule.CreateAt = existing.CreateAt
+	}
+
+	return a.Srv.Store.UserDeactivationSchedule().Save(schedule)
+}
+
+func (a *App) CancelUserDeactivation(userId string) *model.AppError {
+	if _, err := a.Srv.Store.UserDeactivationSchedule().Get(userId); err != nil {
+		return err
+	}
+
+	return a.Srv.Store.UserDeactivationSchedule().Delete(userId)
+}
+
+// DeactivateScheduledUsers deactivates and offboards the users whose scheduled deactivation date has passed,
+// returning how many were deactivated and how many couldn't be.
+func (a *App) DeactivateScheduledUsers() (int, int, *model.AppError) {
+	deactivated := 0
+	failed := 0
+
+	for {
+		schedules, err := a.Srv.Store.UserDeactivationSchedule().GetDue(model.GetMillis(), USER_DEACTIVATION_BATCH_SIZE)
+		if err != nil {
+			return deactivated, failed, err
+		}
+
+		for _, schedule := range schedules {
+			if err := a.applyUserDeactivationSchedule(schedule); err != nil {
+				mlog.Error("Failed to apply a scheduled user deactivation", mlog.String("user_id", schedule.UserId), mlog.Err(err))
+				failed++
+			} else {
+				deactivated++
+			}
+		}
+
+		if len(schedules) < USER_DEACTIVATION_BATCH_SIZE {
+			return deactivated, failed, nil
+		}
+	}
+}
+
+func (a *App) applyUserDeactivationSchedule(schedule *model.UserDeactivationSchedule) *model.AppError {
+	// The schedule is deleted even when the user can't be offboarded, so that it isn't retried forever.
+	defer func() {
+		if err := a.Srv.Store.UserDeactivationSchedule().Delete(schedule.UserId); err != nil {
+			mlog.Error("Failed to delete an applied user deactivation schedule", mlog.String("user_id", schedule.UserId), mlog.Err(err))
+		}
+	}()
+
+	user, err := a.GetUser(schedule.UserId)
+	if err != nil {
+		return err
+	}
+
+	if user.DeleteAt != 0 {
+		return nil
+	}
+
+	settings := a.Config().OffboardingSettings
+	summary := &userOffboardingSummary{}
+
+	if *settings.RemoveFromChannels {
+		a.removeOffboardedUserFromChannels(user, summary)
+	}
+
+	if *settings.ReassignIntegrationsToUserId != "" {
+		a.reassignOffboardedUserIntegrations(user, *settings.ReassignIntegrationsToUserId, summary)
+	}
+
+	if _, err = a.UpdateActive(user, false); err != nil {
+		return err
+	}
+
+	if *settings.SummaryChannelId != "" {
+		if err := a.postUserOffboardingSummary(user, schedule, *settings.SummaryChannelId, summary); err != nil {
+			mlog.Error("Failed to post a user offboarding summary", mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+	}
+
+	return nil
+}
+
+func (a *App) removeOffboardedUserFromChannels(user *model.User, summary *userOffboardingSummary) {
+	members, err := a.Srv.Store.Channel().GetAllChannelMembersForUser(user.Id, false, false)
+	if err != nil {
+		mlog.Error("Failed to get the channels of an offboarded user", mlog.String("user_id", user.Id), mlog.Err(err))
+		return
+	}
+
+	for channelId := range members {
+		channel, err := a.GetChannel(channelId)
+		if err != nil {
+			mlog.Error("Failed to get a channel of an offboarded user", mlog.String("channel_id", channelId), mlog.Err(err))
+			continue
+		}
+
+		if channel.IsGroupOrDirect() || channel.Name == model.DEFAULT_CHANNEL {
+			continue
+		}
+
+		if err := a.removeUserFromChannel(user.Id, "", channel); err != nil {
+			mlog.Error("Failed to remove an offboarded user from a channel", mlog.String("channel_id", channel.Id), mlog.String("user_id", user.Id), mlog.Err(err))
+			continue
+		}
+
+		summary.ChannelsRemoved++
+	}
+}
+
+func (a *App) reassignOffboardedUserIntegrations(user *model.User, newOwnerId string, summary *userOffboardingSummary) {
+	if incomingHooks, err := a.Srv.Store.Webhook().GetIncomingListByUser(user.Id, 0, USER_OFFBOARDING_INTEGRATIONS_LIMIT); err != nil {
+		mlog.Error("Failed to get the incoming webhooks of an offboarded user", mlog.String("user_id", user.Id), mlog.Err(err))
+	} else {
+		for _, hook := range incomingHooks {
+			hook.UserId = newOwnerId
+			hook.UpdateAt = model.GetMillis()
+			if _, err := a.Srv.Store.Webhook().UpdateIncoming(hook); err != nil {
+				mlog.Error("Failed to reassign an incoming webhook", mlog.String("hook_id", hook.Id), mlog.Err(err))
+				continue
+			}
+			a.InvalidateCacheForWebhook(hook.Id)
+			summary.IncomingWebhooksReassigned++
+		}
+	}
+
+	if outgoingHooks, err := a.Srv.Store.Webhook().GetOutgoingListByUser(user.Id, 0, USER_OFFBOARDING_INTEGRATIONS_LIMIT); err != nil {
+		mlog.Error("Failed to get the outgoing webhooks of an offboarded user", mlog.String("user_id", user.Id), mlog.Err(err))
+	} else {
+		for _, hook := range outgoingHooks {
+			hook.CreatorId = newOwnerId
+			hook.UpdateAt = model.GetMillis()
+			if _, err := a.Srv.Store.Webhook().UpdateOutgoing(hook); err != nil {
+				mlog.Error("Failed to reassign an outgoing webhook", mlog.String("hook_id", hook.Id), mlog.Err(err))
+				continue
+			}
+			summary.OutgoingWebhooksReassigned++
+		}
+	}
+
+	if teams, err := a.GetTeamsForUser(user.Id); err != nil {
+		mlog.Error("Failed to get the teams of an offboarded user", mlog.String("user_id", user.Id), mlog.Err(err))
+	} else {
+		for _, team := range teams {
+			commands, err := a.Srv.Store.Command().GetByTeam(team.Id)
+			if err != nil {
+				mlog.Error("Failed to get the commands of a team", mlog.String("team_id", team.Id), mlog.Err(err))
+				continue
+			}
+
+			for _, command := range commands {
+				if command.CreatorId != user.Id {
+					continue
+				}
+
+				command.CreatorId = newOwnerId
+				command.UpdateAt = model.GetMillis()
+				if _, err := a.Srv.Store.Command().Update(command); err != nil {
+					mlog.Error("Failed to reassign a command", mlog.String("command_id", command.Id), mlog.Err(err))
+					continue
+				}
+				summary.CommandsReassigned++
+			}
+		}
+	}
+
+	if oauthApps, err := a.Srv.Store.OAuth().GetAppByUser(user.Id, 0, USER_OFFBOARDING_INTEGRATIONS_LIMIT); err != nil {
+		mlog.Error("Failed to get the OAuth apps of an offboarded user", mlog.String("user_id", user.Id), mlog.Err(err))
+	} else {
+		for _, oauthApp := range oauthApps {
+			oauthApp.CreatorId = newOwnerId
+			if _, err := a.Srv.Store.OAuth().UpdateApp(oauthApp); err != nil {
+				mlog.Error("Failed to reassign an OAuth app", mlog.String("app_id", oauthApp.Id), mlog.Err(err))
+				continue
+			}
+			summary.OAuthAppsReassigned++
+		}
+	}
+}
+
+func (a *App) postUserOffboardingSummary(user *model.User, schedule *model.UserDeactivationSchedule, channelId string, summary *userOffboardingSummary) *model.AppError {
+	channel, err := a.GetChannel(channelId)
+	if err != nil {
+		return err
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    schedule.CreatorId,
+		Message: utils.T("app.user_deactivation.summary.message", map[string]interface{}{
+			"Username":                   user.Username,
+			"ChannelsRemoved":            summary.ChannelsRemoved,
+			"IncomingWebhooksReassigned": summary.IncomingWebhooksReassigned,
+			"OutgoingWebhooksReassigned": summary.OutgoingWebhooksReassigned,
+			"CommandsReassigned":         summary.CommandsReassigned,
+			"OAuthAppsReassigned":        summary.OAuthAppsReassigned,
+		}),
+	}
+
+	if _, err := a.CreatePost(post, channel, false); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestScheduleUserDeactivation(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	deactivateAt := model.GetMillis() + 24*60*60*1000
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.OffboardingSettings.EnableScheduledDeactivation = false })
+
+	_, err := th.App.ScheduleUserDeactivation(th.BasicUser2.Id, deactivateAt, th.BasicUser.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.user_deactivation.disabled.app_error", err.Id)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.OffboardingSettings.EnableScheduledDeactivation = true })
+
+	_, err = th.App.ScheduleUserDeactivation(th.BasicUser2.Id, model.GetMillis()-1000, th.BasicUser.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.user_deactivation.deactivate_at.app_error", err.Id)
+
+	_, err = th.App.ScheduleUserDeactivation(th.BasicUser.Id, deactivateAt, th.BasicUser.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.user_deactivation.self.app_error", err.Id)
+
+	schedule, err := th.App.ScheduleUserDeactivation(th.BasicUser2.Id, deactivateAt, th.BasicUser.Id)
+	require.Nil(t, err)
+	assert.Equal(t, deactivateAt, schedule.DeactivateAt)
+
+	schedule, err = th.App.ScheduleUserDeactivation(th.BasicUser2.Id, deactivateAt+1000, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	schedules, err := th.App.GetUserDeactivationSchedules(0, 100)
+	require.Nil(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, schedule.DeactivateAt, schedules[0].DeactivateAt)
+
+	require.Nil(t, th.App.CancelUserDeactivation(th.BasicUser2.Id))
+
+	_, err = th.App.GetUserDeactivationSchedule(th.BasicUser2.Id)
+	require.NotNil(t, err)
+
+	require.NotNil(t, th.App.CancelUserDeactivation(th.BasicUser2.Id))
+}
+
+func TestDeactivateScheduledUsers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	summaryChannel := th.CreatePrivateChannel(th.BasicTeam)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableIncomingWebhooks = true
+		*cfg.OffboardingSettings.EnableScheduledDeactivation = true
+		*cfg.OffboardingSettings.RemoveFromChannels = true
+		*cfg.OffboardingSettings.ReassignIntegrationsToUserId = th.BasicUser.Id
+		*cfg.OffboardingSettings.SummaryChannelId = summaryChannel.Id
+	})
+
+	leaving := th.CreateUser()
+	th.LinkUserToTeam(leaving, th.BasicTeam)
+	th.AddUserToChannel(leaving, th.BasicChannel)
+
+	hook, err := th.App.CreateIncomingWebhookForChannel(leaving.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	require.Nil(t, err)
+
+	staying := th.CreateUser()
+	th.LinkUserToTeam(staying, th.BasicTeam)
+
+	_, err = th.App.Srv.Store.UserDeactivationSchedule().Save(&model.UserDeactivationSchedule{
+		UserId:       leaving.Id,
+		CreatorId:    th.BasicUser.Id,
+		DeactivateAt: model.GetMillis() - 1000,
+	})
+	require.Nil(t, err)
+
+	_, err = th.App.Srv.Store.UserDeactivationSchedule().Save(&model.UserDeactivationSchedule{
+		UserId:       staying.Id,
+		CreatorId:    th.BasicUser.Id,
+		DeactivateAt: model.GetMillis() + 24*60*60*1000,
+	})
+	require.Nil(t, err)
+
+	deactivated, failed, err := th.App.DeactivateScheduledUsers()
+	require.Nil(t, err)
+	assert.Equal(t, 1, deactivated)
+	assert.Equal(t, 0, failed)
+
+	user, err := th.App.GetUser(leaving.Id)
+	require.Nil(t, err)
+	assert.NotZero(t, user.DeleteAt)
+
+	_, err = th.App.GetChannelMember(th.BasicChannel.Id, leaving.Id)
+	assert.NotNil(t, err)
+
+	hook, err = th.App.GetIncomingWebhook(hook.Id)
+	require.Nil(t, err)
+	assert.Equal(t, th.BasicUser.Id, hook.UserId)
+
+	posts, err := th.App.GetPosts(summaryChannel.Id, 0, 10)
+	require.Nil(t, err)
+	found := false
+	for _, post := range posts.Posts {
+		if post.UserId == th.BasicUser.Id && post.Type == "" {
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	_, err = th.App.GetUserDeactivationSchedule(leaving.Id)
+	assert.NotNil(t, err)
+
+	user, err = th.App.GetUser(staying.Id)
+	require.Nil(t, err)
+	assert.Zero(t, user.DeleteAt)
+
+	_, err = th.App.GetUserDeactivationSchedule(staying.Id)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	USER_INTERACTION_CACHE_SIZE = 10000
+	USER_INTERACTION_CACHE_SEC  = 5 * 60
+
+	// USER_INTERACTIONS_LIMIT is the number of users of each kind of interaction that are boosted in user search
+	// results, and USER_INTERACTION_THREADS_DAYS how far back the threads users both posted in are looked for.
+	USER_INTERACTIONS_LIMIT       = 100
+	USER_INTERACTION_THREADS_DAYS = 30
+)
+
+// getUserInteractionScores returns how much the given user interacts with other users, keyed by their ids, from the
+// direct messages they exchanged, the threads they both recently posted in and the private and group channels they
+// share. The scores are cached for a few minutes since they're looked up every time the user searches for users.
+func (a *App) getUserInteractionScores(userId string) map[string]int64 {
+	if cached, ok := a.Srv.userInteractionCache.Get(userId); ok {
+		return cached.(map[string]int64)
+	}
+
+	interactions := map[string]*model.UserInteraction{}
+	interaction := func(otherUserId string) *model.UserInteraction {
+		if _, ok := interactions[otherUserId]; !ok {
+			interactions[otherUserId] = &model.UserInteraction{}
+		}
+		return interactions[otherUserId]
+	}
+
+	failed := false
+	now := model.GetMillis()
+
+	if partners, err := a.Srv.Store.Channel().GetDirectMessagePartners(userId, USER_INTERACTIONS_LIMIT); err != nil {
+		mlog.Warn("Failed to get the direct message partners of a user", mlog.String("user_id", userId), mlog.Err(err))
+		failed = true
+	} else {
+		for otherUserId, lastPostAt := range partners {
+			interaction(otherUserId).LastDirectMessageAt = lastPostAt
+		}
+	}
+
+	since := now - USER_INTERACTION_THREADS_DAYS*24*60*60*1000
+	if participants, err := a.Srv.Store.Post().GetThreadParticipantCounts(userId, since, USER_INTERACTIONS_LIMIT); err != nil {
+		mlog.Warn("Failed to get the users posting in the threads of a user", mlog.String("user_id", userId), mlog.Err(err))
+		failed = true
+	} else {
+		for otherUserId, count := range participants {
+			interaction(otherUserId).SharedThreads = count
+		}
+	}
+
+	if members, err := a.Srv.Store.Channel().GetSharedChannelCounts(userId, USER_INTERACTIONS_LIMIT); err != nil {
+		mlog.Warn("Failed to get the users sharing channels with a user", mlog.String("user_id", userId), mlog.Err(err))
+		failed = true
+	} else {
+		for otherUserId, count := range members {
+			interaction(otherUserId).SharedChannels = count
+		}
+	}
+
+	scores := make(map[string]int64, len(interactions))
+	for otherUserId, interaction := range interactions {
+		scores[otherUserId] = interaction.Score(now)
+	}
+
+	// Scores missing an interaction are only used once, so that the next search retries getting it
+	if !failed {
+		a.Srv.userInteractionCache.AddWithExpiresInSecs(userId, scores, USER_INTERACTION_CACHE_SEC)
+	}
+
+	return scores
+}
@@ -3,14 +3,34 @@
 
 package app
 
-import "github.com/mattermost/mattermost-server/model"
+import (
+	"net/http"
 
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// GetUserTermsOfService returns the latest acceptance of the terms of service by a user, unless a re-acceptance
+// campaign of the accepted version started since, in which case they have to accept it again.
 func (a *App) GetUserTermsOfService(userId string) (*model.UserTermsOfService, *model.AppError) {
-	return a.Srv.Store.UserTermsOfService().GetByUser(userId)
+	userTermsOfService, err := a.Srv.Store.UserTermsOfService().GetByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if termsOfService, err := a.GetTermsOfService(userTermsOfService.TermsOfServiceId); err == nil && termsOfService.RequiresReacceptance(userTermsOfService.CreateAt, model.GetMillis()) {
+		return nil, model.NewAppError("GetUserTermsOfService", "app.user_terms_of_service.reacceptance_required.app_error", nil, "user_id="+userId, http.StatusNotFound)
+	}
+
+	return userTermsOfService, nil
 }
 
 func (a *App) SaveUserTermsOfService(userId, termsOfServiceId string, accepted bool) *model.AppError {
 	if accepted {
+		termsOfService, err := a.GetTermsOfService(termsOfServiceId)
+		if err != nil {
+			return err
+		}
+
 		userTermsOfService := &model.UserTermsOfService{
 			UserId:           userId,
 			TermsOfServiceId: termsOfServiceId,
@@ -19,6 +39,15 @@ func (a *App) SaveUserTermsOfService(userId, termsOfServiceId string, accepted b
 		if _, err := a.Srv.Store.UserTermsOfService().Save(userTermsOfService); err != nil {
 			return err
 		}
+
+		if _, err := a.Srv.Store.UserTermsOfService().SaveAcceptance(&model.TermsOfServiceAcceptance{
+			UserId:           userId,
+			TermsOfServiceId: termsOfServiceId,
+			Version:          termsOfService.Version,
+			AcceptAt:         userTermsOfService.CreateAt,
+		}); err != nil {
+			return err
+		}
 	} else {
 		if err := a.Srv.Store.UserTermsOfService().Delete(userId, termsOfServiceId); err != nil {
 			return err
@@ -27,3 +56,9 @@ func (a *App) SaveUserTermsOfService(userId, termsOfServiceId string, accepted b
 
 	return nil
 }
+
+// GetTermsOfServiceAcceptances returns a page of the acceptances made after since of a version of the terms of
+// service, or of all of them when termsOfServiceId is empty, for compliance to export who accepted which version when.
+func (a *App) GetTermsOfServiceAcceptances(termsOfServiceId string, since int64, page, perPage int) ([]*model.TermsOfServiceAcceptance, *model.AppError) {
+	return a.Srv.Store.UserTermsOfService().GetAcceptances(termsOfServiceId, since, page*perPage, perPage)
+}
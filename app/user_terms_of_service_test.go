@@ -5,8 +5,12 @@ package app
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
 )
 
 func TestUserTermsOfService(t *testing.T) {
@@ -33,3 +37,46 @@ func TestUserTermsOfService(t *testing.T) {
 	assert.Equal(t, termsOfService.Id, userTermsOfService.TermsOfServiceId)
 	assert.NotEmpty(t, userTermsOfService.CreateAt)
 }
+
+func TestUserTermsOfServiceReacceptance(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	termsOfService, err := th.App.CreateTermsOfService("terms of service", th.BasicUser.Id)
+	require.Nil(t, err)
+
+	require.Nil(t, th.App.SaveUserTermsOfService(th.BasicUser.Id, termsOfService.Id, true))
+
+	acceptances, err := th.App.GetTermsOfServiceAcceptances(termsOfService.Id, 0, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, acceptances, 1)
+	assert.Equal(t, th.BasicUser.Id, acceptances[0].UserId)
+	assert.Equal(t, termsOfService.Version, acceptances[0].Version)
+
+	// A campaign that hasn't started yet doesn't require accepting again
+	_, err = th.App.ScheduleTermsOfServiceReacceptance(termsOfService.Id, model.GetMillis()+60*1000)
+	require.Nil(t, err)
+	_, err = th.App.GetUserTermsOfService(th.BasicUser.Id)
+	require.Nil(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+	_, err = th.App.ScheduleTermsOfServiceReacceptance(termsOfService.Id, model.GetMillis())
+	require.Nil(t, err)
+
+	_, err = th.App.GetUserTermsOfService(th.BasicUser.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.user_terms_of_service.reacceptance_required.app_error", err.Id)
+
+	time.Sleep(2 * time.Millisecond)
+	require.Nil(t, th.App.SaveUserTermsOfService(th.BasicUser.Id, termsOfService.Id, true))
+
+	_, err = th.App.GetUserTermsOfService(th.BasicUser.Id)
+	require.Nil(t, err)
+
+	acceptances, err = th.App.GetTermsOfServiceAcceptances(termsOfService.Id, 0, 0, 10)
+	require.Nil(t, err)
+	assert.Len(t, acceptances, 2)
+
+	_, err = th.App.ScheduleTermsOfServiceReacceptance(termsOfService.Id, -1)
+	require.NotNil(t, err)
+}
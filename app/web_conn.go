@@ -23,6 +23,7 @@ const (
 	PONG_WAIT                 = 100 * time.Second
 	PING_PERIOD               = (PONG_WAIT * 6) / 10
 	AUTH_TIMEOUT              = 5 * time.Second
+	CLOSE_FOR_RESTART_WAIT    = time.Second
 	WEBCONN_MEMBER_CACHE_TIME = 1000 * 60 * 30 // 30 minutes
 )
 
@@ -80,6 +81,16 @@ func (wc *WebConn) Close() {
 	<-wc.pumpFinished
 }
 
+// CloseForRestart closes the connection with a "service restart" close frame, telling the client that it can
+// reconnect right away, to another node of the cluster or to this one once it restarts.
+func (wc *WebConn) CloseForRestart() {
+	msg := websocket.FormatCloseMessage(websocket.CloseServiceRestart, "server is restarting")
+	if err := wc.WebSocket.WriteControl(websocket.CloseMessage, msg, time.Now().Add(CLOSE_FOR_RESTART_WAIT)); err != nil {
+		mlog.Debug("Failed to send the restart close message", mlog.String("user_id", wc.UserId), mlog.Err(err))
+	}
+	wc.Close()
+}
+
 func (c *WebConn) GetSessionExpiresAt() int64 {
 	return atomic.LoadInt64(&c.sessionExpiresAt)
 }
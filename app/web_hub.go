@@ -21,6 +21,7 @@ const (
 	BROADCAST_QUEUE_SIZE = 4096
 	DEADLOCK_TICKER      = 15 * time.Second                  // check every 15 seconds
 	DEADLOCK_WARN        = (BROADCAST_QUEUE_SIZE * 99) / 100 // number of buffered messages before printing stack trace
+	DRAIN_STEPS          = 10                                // number of batches connections are closed in while draining
 )
 
 type WebConnActivityMessage struct {
@@ -42,6 +43,7 @@ type Hub struct {
 	didStop         chan struct{}
 	invalidateUser  chan string
 	activity        chan *WebConnActivityMessage
+	closeConns      chan int
 	ExplicitStop    bool
 	goroutineId     int
 }
@@ -56,6 +58,7 @@ func (a *App) NewWebHub() *Hub {
 		didStop:        make(chan struct{}),
 		invalidateUser: make(chan string),
 		activity:       make(chan *WebConnActivityMessage),
+		closeConns:     make(chan int),
 		ExplicitStop:   false,
 	}
 }
@@ -132,6 +135,46 @@ func (a *App) HubStop() {
 	a.Srv.Hubs = []*Hub{}
 }
 
+// AcceptsWebSocketConnections returns false if this server shouldn't take new websocket connections, either
+// because it's an API node of a cluster with dedicated websocket gateways or because it's draining its connections.
+func (a *App) AcceptsWebSocketConnections() bool {
+	if *a.Config().ClusterSettings.WebSocketMode == model.CLUSTER_WEBSOCKET_MODE_API {
+		return false
+	}
+
+	return !a.IsDrainingWebSocketConnections()
+}
+
+func (a *App) IsDrainingWebSocketConnections() bool {
+	return atomic.LoadInt32(&a.Srv.webSocketsDraining) == 1
+}
+
+// DrainWebSocketConnections stops accepting new websocket connections and closes the existing ones in batches spread
+// over the given duration, so that clients reconnect to the other nodes of the cluster gradually instead of all at once.
+func (a *App) DrainWebSocketConnections(duration time.Duration) {
+	if !atomic.CompareAndSwapInt32(&a.Srv.webSocketsDraining, 0, 1) {
+		return
+	}
+
+	mlog.Info("Draining websocket connections", mlog.Int("connections", a.TotalWebsocketConnections()), mlog.String("duration", duration.String()))
+
+	interval := duration / DRAIN_STEPS
+	for step := DRAIN_STEPS; step > 0; step-- {
+		if a.TotalWebsocketConnections() == 0 {
+			break
+		}
+
+		for _, hub := range a.Srv.Hubs {
+			count := int(atomic.LoadInt64(&hub.connectionCount))
+			hub.CloseConnections((count + step - 1) / step)
+		}
+
+		time.Sleep(interval)
+	}
+
+	mlog.Info("Finished draining websocket connections", mlog.Int("remaining_connections", a.TotalWebsocketConnections()))
+}
+
 func (a *App) GetHubForUserId(userId string) *Hub {
 	if len(a.Srv.Hubs) == 0 {
 		return nil
@@ -410,6 +453,18 @@ func (h *Hub) UpdateActivity(userId, sessionToken string, activityAt int64) {
 	}
 }
 
+// CloseConnections closes up to count of the connections registered with the hub.
+func (h *Hub) CloseConnections(count int) {
+	if count <= 0 {
+		return
+	}
+
+	select {
+	case h.closeConns <- count:
+	case <-h.didStop:
+	}
+}
+
 func getGoroutineId() int {
 	var buf [64]byte
 	n := runtime.Stack(buf[:], false)
@@ -454,6 +509,7 @@ func (h *Hub) Start() {
 				if len(conns) == 0 {
 					h.app.Srv.Go(func() {
 						h.app.SetStatusOffline(webCon.UserId, false)
+						h.app.leaveWebrtcRooms(webCon.UserId)
 					})
 				} else {
 					var latestActivity int64 = 0
@@ -478,7 +534,17 @@ func (h *Hub) Start() {
 						webCon.LastUserActivityAt = activity.ActivityAt
 					}
 				}
+			case count := <-h.closeConns:
+				all := connections.All()
+				if count > len(all) {
+					count = len(all)
+				}
+				// Closing a connection waits for it to unregister from this hub, so it can't be done from the hub's goroutine.
+				for _, webCon := range all[:count] {
+					go webCon.CloseForRestart()
+				}
 			case msg := <-h.broadcast:
+				broadcastStart := time.Now()
 				candidates := connections.All()
 				if msg.Broadcast.UserId != "" {
 					candidates = connections.ForUser(msg.Broadcast.UserId)
@@ -495,12 +561,15 @@ func (h *Hub) Start() {
 						}
 					}
 				}
+				if metrics := h.app.Metrics; metrics != nil {
+					metrics.ObserveWebsocketBroadcastDuration(msg.EventType(), float64(time.Since(broadcastStart))/float64(time.Second))
+				}
 			case <-h.stop:
 				userIds := make(map[string]bool)
 
 				for _, webCon := range connections.All() {
 					userIds[webCon.UserId] = true
-					webCon.Close()
+					webCon.CloseForRestart()
 				}
 
 				for userId := range userIds {
@@ -103,3 +103,25 @@ func TestHubStopRaceCondition(t *testing.T) {
 		t.Fatalf("hub call did not return within 15 seconds after stop")
 	}
 }
+
+func TestDrainWebSocketConnections(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	s := httptest.NewServer(http.HandlerFunc(dummyWebsocketHandler(t)))
+	defer s.Close()
+
+	th.App.HubStart()
+	registerDummyWebConn(t, th.App, s.Listener.Addr(), th.BasicUser.Id)
+	registerDummyWebConn(t, th.App, s.Listener.Addr(), th.BasicUser.Id)
+	registerDummyWebConn(t, th.App, s.Listener.Addr(), th.BasicUser2.Id)
+
+	require.True(t, th.App.AcceptsWebSocketConnections())
+	require.Equal(t, 3, th.App.TotalWebsocketConnections())
+
+	th.App.DrainWebSocketConnections(time.Second)
+
+	require.False(t, th.App.AcceptsWebSocketConnections())
+	require.True(t, th.App.IsDrainingWebSocketConnections())
+	require.Equal(t, 0, th.App.TotalWebsocketConnections())
+}
@@ -4,10 +4,14 @@
 package app
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/mattermost/mattermost-server/mlog"
@@ -24,15 +28,7 @@ const (
 )
 
 func (a *App) handleWebhookEvents(post *model.Post, team *model.Team, channel *model.Channel, user *model.User) *model.AppError {
-	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
-		return nil
-	}
-
-	if channel.Type != model.CHANNEL_OPEN {
-		return nil
-	}
-
-	hooks, err := a.Srv.Store.Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+	hooks, err := a.getOutgoingWebhooksForChannel(channel)
 	if err != nil {
 		return err
 	}
@@ -50,17 +46,19 @@ func (a *App) handleWebhookEvents(post *model.Post, team *model.Team, channel *m
 
 	relevantHooks := []*model.OutgoingWebhook{}
 	for _, hook := range hooks {
-		if hook.ChannelId == post.ChannelId || len(hook.ChannelId) == 0 {
-			if hook.ChannelId == post.ChannelId && len(hook.TriggerWords) == 0 {
-				relevantHooks = append(relevantHooks, hook)
-				triggerWord = ""
-			} else if hook.TriggerWhen == TRIGGERWORDS_EXACT_MATCH && hook.TriggerWordExactMatch(firstWord) {
-				relevantHooks = append(relevantHooks, hook)
-				triggerWord = hook.GetTriggerWord(firstWord, true)
-			} else if hook.TriggerWhen == TRIGGERWORDS_STARTS_WITH && hook.TriggerWordStartsWith(firstWord) {
-				relevantHooks = append(relevantHooks, hook)
-				triggerWord = hook.GetTriggerWord(firstWord, false)
-			}
+		if !hook.HasEventType(model.OUTGOING_WEBHOOK_EVENT_POST_CREATED) {
+			continue
+		}
+
+		if hook.ChannelId == post.ChannelId && len(hook.TriggerWords) == 0 {
+			relevantHooks = append(relevantHooks, hook)
+			triggerWord = ""
+		} else if hook.TriggerWhen == TRIGGERWORDS_EXACT_MATCH && hook.TriggerWordExactMatch(firstWord) {
+			relevantHooks = append(relevantHooks, hook)
+			triggerWord = hook.GetTriggerWord(firstWord, true)
+		} else if hook.TriggerWhen == TRIGGERWORDS_STARTS_WITH && hook.TriggerWordStartsWith(firstWord) {
+			relevantHooks = append(relevantHooks, hook)
+			triggerWord = hook.GetTriggerWord(firstWord, false)
 		}
 	}
 
@@ -78,6 +76,7 @@ func (a *App) handleWebhookEvents(post *model.Post, team *model.Team, channel *m
 			Text:        post.Message,
 			TriggerWord: triggerWord,
 			FileIds:     strings.Join(post.FileIds, ","),
+			EventType:   model.OUTGOING_WEBHOOK_EVENT_POST_CREATED,
 		}
 		a.Srv.Go(func(hook *model.OutgoingWebhook) func() {
 			return func() {
@@ -86,9 +85,123 @@ func (a *App) handleWebhookEvents(post *model.Post, team *model.Team, channel *m
 		}(hook))
 	}
 
+	if len(post.FileIds) > 0 {
+		a.triggerWebhookEvent(model.OUTGOING_WEBHOOK_EVENT_FILE_ATTACHED, hooks, &model.OutgoingWebhookPayload{
+			TeamDomain:  team.Name,
+			ChannelId:   post.ChannelId,
+			ChannelName: channel.Name,
+			Timestamp:   post.CreateAt,
+			UserId:      post.UserId,
+			UserName:    user.Username,
+			PostId:      post.Id,
+			Text:        post.Message,
+			FileIds:     strings.Join(post.FileIds, ","),
+		}, post, channel)
+	}
+
+	return nil
+}
+
+// handleReactionWebhookEvents triggers the outgoing webhooks subscribed to reactions added in the channel.
+func (a *App) handleReactionWebhookEvents(reaction *model.Reaction, post *model.Post, channel *model.Channel) *model.AppError {
+	if len(channel.TeamId) == 0 {
+		return nil
+	}
+
+	team, err := a.Srv.Store.Team().Get(channel.TeamId)
+	if err != nil {
+		return err
+	}
+
+	hooks, err := a.getOutgoingWebhooksForChannel(channel)
+	if err != nil || len(hooks) == 0 {
+		return err
+	}
+
+	user, err := a.Srv.Store.User().Get(reaction.UserId)
+	if err != nil {
+		return err
+	}
+
+	a.triggerWebhookEvent(model.OUTGOING_WEBHOOK_EVENT_REACTION_ADDED, hooks, &model.OutgoingWebhookPayload{
+		TeamDomain:  team.Name,
+		ChannelId:   channel.Id,
+		ChannelName: channel.Name,
+		Timestamp:   reaction.CreateAt,
+		UserId:      user.Id,
+		UserName:    user.Username,
+		PostId:      post.Id,
+		Text:        post.Message,
+		FileIds:     strings.Join(post.FileIds, ","),
+		EmojiName:   reaction.EmojiName,
+	}, post, channel)
+
+	return nil
+}
+
+// handleChannelMemberWebhookEvents triggers the outgoing webhooks subscribed to users joining the channel.
+func (a *App) handleChannelMemberWebhookEvents(user *model.User, channel *model.Channel) *model.AppError {
+	if len(channel.TeamId) == 0 {
+		return nil
+	}
+
+	team, err := a.Srv.Store.Team().Get(channel.TeamId)
+	if err != nil {
+		return err
+	}
+
+	hooks, err := a.getOutgoingWebhooksForChannel(channel)
+	if err != nil || len(hooks) == 0 {
+		return err
+	}
+
+	a.triggerWebhookEvent(model.OUTGOING_WEBHOOK_EVENT_CHANNEL_MEMBER_ADDED, hooks, &model.OutgoingWebhookPayload{
+		TeamDomain:  team.Name,
+		ChannelId:   channel.Id,
+		ChannelName: channel.Name,
+		Timestamp:   model.GetMillis(),
+		UserId:      user.Id,
+		UserName:    user.Username,
+	}, nil, channel)
+
 	return nil
 }
 
+// getOutgoingWebhooksForChannel returns the outgoing webhooks of the channel's team that apply to the channel.
+// Outgoing webhooks are only triggered by activity in public channels.
+func (a *App) getOutgoingWebhooksForChannel(channel *model.Channel) ([]*model.OutgoingWebhook, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, nil
+	}
+
+	if channel.Type != model.CHANNEL_OPEN {
+		return nil, nil
+	}
+
+	return a.Srv.Store.Webhook().GetOutgoingForChannel(channel.TeamId, channel.Id)
+}
+
+// triggerWebhookEvent sends the payload to each of the hooks subscribed to the event. Trigger words only
+// apply to created posts, so they are ignored for other events.
+func (a *App) triggerWebhookEvent(eventType string, hooks []*model.OutgoingWebhook, payload *model.OutgoingWebhookPayload, post *model.Post, channel *model.Channel) {
+	for _, hook := range hooks {
+		if !hook.HasEventType(eventType) {
+			continue
+		}
+
+		hookPayload := *payload
+		hookPayload.Token = hook.Token
+		hookPayload.TeamId = hook.TeamId
+		hookPayload.EventType = eventType
+
+		a.Srv.Go(func(hook *model.OutgoingWebhook) func() {
+			return func() {
+				a.TriggerWebhook(&hookPayload, hook, post, channel)
+			}
+		}(hook))
+	}
+}
+
 func (a *App) TriggerWebhook(payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
 	var body io.Reader
 	var contentType string
@@ -100,12 +213,22 @@ func (a *App) TriggerWebhook(payload *model.OutgoingWebhookPayload, hook *model.
 		contentType = "application/x-www-form-urlencoded"
 	}
 
+	if hook.TestMode {
+		requestBody, _ := ioutil.ReadAll(body)
+		for _, url := range hook.CallbackURLs {
+			a.captureOutgoingWebhookDelivery(hook, payload.EventType, url, contentType, string(requestBody))
+		}
+		return
+	}
+
 	for i := range hook.CallbackURLs {
 		// Get the callback URL by index to properly capture it for the go func
 		url := hook.CallbackURLs[i]
 
 		a.Srv.Go(func() {
+			start := time.Now()
 			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType)
+			a.recordIntegrationRequest(model.INTEGRATION_TYPE_OUTGOING_WEBHOOK, hook.Id, time.Since(start), err != nil)
 			if err != nil {
 				mlog.Error("Event POST failed.", mlog.Err(err))
 				return
@@ -113,7 +236,7 @@ func (a *App) TriggerWebhook(payload *model.OutgoingWebhookPayload, hook *model.
 
 			if webhookResp != nil && (webhookResp.Text != nil || len(webhookResp.Attachments) > 0) {
 				postRootId := ""
-				if webhookResp.ResponseType == model.OUTGOING_HOOK_RESPONSE_TYPE_COMMENT {
+				if webhookResp.ResponseType == model.OUTGOING_HOOK_RESPONSE_TYPE_COMMENT && post != nil {
 					postRootId = post.Id
 				}
 				if len(webhookResp.Props) == 0 {
@@ -139,6 +262,8 @@ func (a *App) TriggerWebhook(payload *model.OutgoingWebhookPayload, hook *model.
 				}
 				if _, err := a.CreateWebhookPost(hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId); err != nil {
 					mlog.Error("Failed to create response post.", mlog.Err(err))
+				} else {
+					a.recordIntegrationPost(model.INTEGRATION_TYPE_OUTGOING_WEBHOOK, hook.Id)
 				}
 			}
 		})
@@ -297,6 +422,10 @@ func (a *App) CreateWebhookPost(userId string, channel *model.Channel, text, ove
 
 	for _, split := range splits {
 		if _, err := a.CreatePostMissingChannel(split, false); err != nil {
+			// Let the integration know when it isn't allowed to post rather than reporting a server error.
+			if err.StatusCode == http.StatusForbidden {
+				return nil, err
+			}
 			return nil, model.NewAppError("CreateWebhookPost", "api.post.create_webhook_post.creating.app_error", nil, "err="+err.Message, http.StatusInternalServerError)
 		}
 	}
@@ -367,6 +496,7 @@ func (a *App) DeleteIncomingWebhook(hookId string) *model.AppError {
 	}
 
 	a.InvalidateCacheForWebhook(hookId)
+	a.Srv.incomingWebhookUsage.remove(hookId)
 
 	return nil
 }
@@ -421,7 +551,7 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 		if channel.Type != model.CHANNEL_OPEN || channel.TeamId != hook.TeamId {
 			return nil, model.NewAppError("CreateOutgoingWebhook", "api.webhook.create_outgoing.permissions.app_error", nil, "", http.StatusForbidden)
 		}
-	} else if len(hook.TriggerWords) == 0 {
+	} else if len(hook.TriggerWords) == 0 && hook.HasEventType(model.OUTGOING_WEBHOOK_EVENT_POST_CREATED) {
 		return nil, model.NewAppError("CreateOutgoingWebhook", "api.webhook.create_outgoing.triggers.app_error", nil, "", http.StatusBadRequest)
 	}
 
@@ -465,7 +595,7 @@ func (a *App) UpdateOutgoingWebhook(oldHook, updatedHook *model.OutgoingWebhook)
 		if channel.TeamId != oldHook.TeamId {
 			return nil, model.NewAppError("UpdateOutgoingWebhook", "api.webhook.create_outgoing.permissions.app_error", nil, "", http.StatusForbidden)
 		}
-	} else if len(updatedHook.TriggerWords) == 0 {
+	} else if len(updatedHook.TriggerWords) == 0 && updatedHook.HasEventType(model.OUTGOING_WEBHOOK_EVENT_POST_CREATED) {
 		return nil, model.NewAppError("UpdateOutgoingWebhook", "api.webhook.create_outgoing.triggers.app_error", nil, "", http.StatusInternalServerError)
 	}
 
@@ -537,7 +667,12 @@ func (a *App) DeleteOutgoingWebhook(hookId string) *model.AppError {
 		return model.NewAppError("DeleteOutgoingWebhook", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Webhook().DeleteOutgoing(hookId, model.GetMillis())
+	if err := a.Srv.Store.Webhook().DeleteOutgoing(hookId, model.GetMillis()); err != nil {
+		return err
+	}
+
+	a.Srv.outgoingWebhookDeliveries.remove(hookId)
+	return nil
 }
 
 func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
@@ -550,7 +685,36 @@ func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.Out
 	return a.Srv.Store.Webhook().UpdateOutgoing(hook)
 }
 
-func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookRequest) *model.AppError {
+// RenderIncomingWebhookPayload applies the payload template of the webhook to the JSON payload that was
+// posted to it. Templates rendering a JSON object are decoded as a regular webhook request, so that they can
+// set attachments or override the channel, while any other output is used as the text of the post.
+func (a *App) RenderIncomingWebhookPayload(hook *model.IncomingWebhook, payload []byte) (*model.IncomingWebhookRequest, *model.AppError) {
+	tmpl, err := hook.ParsePayloadTemplate()
+	if err != nil {
+		return nil, model.NewAppError("RenderIncomingWebhookPayload", "web.incoming_webhook.payload_template.parse.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var data interface{}
+	if err = json.Unmarshal(payload, &data); err != nil {
+		return nil, model.NewAppError("RenderIncomingWebhookPayload", "web.incoming_webhook.parse.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	var rendered bytes.Buffer
+	if err = tmpl.Execute(&rendered, data); err != nil {
+		return nil, model.NewAppError("RenderIncomingWebhookPayload", "web.incoming_webhook.payload_template.execute.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	output := strings.TrimSpace(rendered.String())
+	if strings.HasPrefix(output, "{") {
+		return model.IncomingWebhookRequestFromJson(strings.NewReader(output))
+	}
+
+	return &model.IncomingWebhookRequest{Text: output}, nil
+}
+
+func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookRequest) (appErr *model.AppError) {
+	start := time.Now()
+
 	if !*a.Config().ServiceSettings.EnableIncomingWebhooks {
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
@@ -581,6 +745,14 @@ func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookReq
 		hook = result.Data.(*model.IncomingWebhook)
 	}
 
+	defer func() {
+		a.recordIntegrationRequest(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, hook.Id, time.Since(start), appErr != nil)
+	}()
+
+	if err := a.checkIncomingWebhookLimits(hook); err != nil {
+		return err
+	}
+
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
 		user, err := a.Srv.Store.User().Get(hook.UserId)
@@ -593,6 +765,7 @@ func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookReq
 	}
 
 	req.Props["webhook_display_name"] = hook.DisplayName
+	req.Props[model.POST_PROPS_WEBHOOK_ID] = hook.Id
 
 	text = a.ProcessSlackText(text)
 	req.Attachments = a.ProcessSlackAttachments(req.Attachments)
@@ -678,8 +851,12 @@ func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookReq
 		overrideIconUrl = req.IconURL
 	}
 
-	_, err := a.CreateWebhookPost(hook.UserId, channel, text, overrideUsername, overrideIconUrl, req.IconEmoji, req.Props, webhookType, "")
-	return err
+	if _, err := a.CreateWebhookPost(hook.UserId, channel, text, overrideUsername, overrideIconUrl, req.IconEmoji, req.Props, webhookType, ""); err != nil {
+		return err
+	}
+
+	a.recordIntegrationPost(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, hook.Id)
+	return nil
 }
 
 func (a *App) CreateCommandWebhook(commandId string, args *model.CommandArgs) (*model.CommandWebhook, *model.AppError) {
@@ -0,0 +1,139 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	OUTGOING_WEBHOOK_DELIVERIES_PER_HOOK = 50
+
+	// Recorded response bodies are truncated since they are only kept for inspection.
+	OUTGOING_WEBHOOK_DELIVERY_MAX_RESPONSE_SIZE = 64 * 1024
+)
+
+// outgoingWebhookDeliveryLog keeps the most recent deliveries of each outgoing webhook in test mode,
+// newest first. Deliveries are kept in memory by the server that made them.
+type outgoingWebhookDeliveryLog struct {
+	mutex      sync.RWMutex
+	deliveries map[string][]*model.OutgoingWebhookDelivery
+}
+
+func newOutgoingWebhookDeliveryLog() *outgoingWebhookDeliveryLog {
+	return &outgoingWebhookDeliveryLog{
+		deliveries: map[string][]*model.OutgoingWebhookDelivery{},
+	}
+}
+
+func (l *outgoingWebhookDeliveryLog) add(delivery *model.OutgoingWebhookDelivery) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	deliveries := append([]*model.OutgoingWebhookDelivery{delivery}, l.deliveries[delivery.HookId]...)
+	if len(deliveries) > OUTGOING_WEBHOOK_DELIVERIES_PER_HOOK {
+		deliveries = deliveries[:OUTGOING_WEBHOOK_DELIVERIES_PER_HOOK]
+	}
+	l.deliveries[delivery.HookId] = deliveries
+}
+
+func (l *outgoingWebhookDeliveryLog) list(hookId string) []*model.OutgoingWebhookDelivery {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	deliveries := make([]*model.OutgoingWebhookDelivery, len(l.deliveries[hookId]))
+	copy(deliveries, l.deliveries[hookId])
+	return deliveries
+}
+
+func (l *outgoingWebhookDeliveryLog) get(hookId, deliveryId string) *model.OutgoingWebhookDelivery {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	for _, delivery := range l.deliveries[hookId] {
+		if delivery.Id == deliveryId {
+			return delivery
+		}
+	}
+	return nil
+}
+
+func (l *outgoingWebhookDeliveryLog) remove(hookId string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.deliveries, hookId)
+}
+
+// captureOutgoingWebhookDelivery records a request that a webhook in test mode would have made instead
+// of sending it.
+func (a *App) captureOutgoingWebhookDelivery(hook *model.OutgoingWebhook, eventType, url, contentType, body string) {
+	a.Srv.outgoingWebhookDeliveries.add(&model.OutgoingWebhookDelivery{
+		Id:          model.NewId(),
+		HookId:      hook.Id,
+		CreateAt:    model.GetMillis(),
+		EventType:   eventType,
+		URL:         url,
+		ContentType: contentType,
+		RequestBody: body,
+	})
+}
+
+// GetOutgoingWebhookDeliveries returns the recent deliveries captured for the webhook, newest first.
+func (a *App) GetOutgoingWebhookDeliveries(hook *model.OutgoingWebhook) []*model.OutgoingWebhookDelivery {
+	return a.Srv.outgoingWebhookDeliveries.list(hook.Id)
+}
+
+// ReplayOutgoingWebhookDelivery sends a captured request to the URL it was meant for and records the
+// response as a new delivery. Responses to replayed requests are not posted to the channel.
+func (a *App) ReplayOutgoingWebhookDelivery(hook *model.OutgoingWebhook, deliveryId string) (*model.OutgoingWebhookDelivery, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	original := a.Srv.outgoingWebhookDeliveries.get(hook.Id, deliveryId)
+	if original == nil {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "app.outgoing_webhook.delivery.not_found.app_error", nil, "delivery_id="+deliveryId, http.StatusNotFound)
+	}
+
+	delivery := &model.OutgoingWebhookDelivery{
+		Id:          model.NewId(),
+		HookId:      hook.Id,
+		CreateAt:    model.GetMillis(),
+		EventType:   original.EventType,
+		URL:         original.URL,
+		ContentType: original.ContentType,
+		RequestBody: original.RequestBody,
+		Delivered:   true,
+		ReplayOf:    original.Id,
+	}
+
+	req, err := http.NewRequest("POST", delivery.URL, strings.NewReader(delivery.RequestBody))
+	if err != nil {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "app.outgoing_webhook.delivery.replay.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	req.Header.Set("Content-Type", delivery.ContentType)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.HTTPService.MakeClient(false).Do(req)
+	if err != nil {
+		delivery.Error = err.Error()
+	} else {
+		defer resp.Body.Close()
+
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, OUTGOING_WEBHOOK_DELIVERY_MAX_RESPONSE_SIZE))
+		delivery.StatusCode = resp.StatusCode
+		delivery.ResponseBody = string(body)
+	}
+
+	a.Srv.outgoingWebhookDeliveries.add(delivery)
+
+	return delivery, nil
+}
@@ -282,6 +282,48 @@ func TestUpdateIncomingWebhook(t *testing.T) {
 	}
 }
 
+func TestRenderIncomingWebhookPayload(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	payload := []byte(`{"status": "firing", "alerts": [{"labels": {"alertname": "HighLatency"}}, {"labels": {"alertname": "DiskFull"}}]}`)
+
+	t.Run("text output", func(t *testing.T) {
+		hook := &model.IncomingWebhook{PayloadTemplate: "Alerts {{ .status }}:{{ range .alerts }} {{ .labels.alertname }}{{ end }}"}
+
+		req, err := th.App.RenderIncomingWebhookPayload(hook, payload)
+		require.Nil(t, err)
+		assert.Equal(t, "Alerts firing: HighLatency DiskFull", req.Text)
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		hook := &model.IncomingWebhook{PayloadTemplate: `{"text": {{ json .status }}, "channel": "alerts", "attachments": [{"title": {{ json (index .alerts 0).labels.alertname }}}]}`}
+
+		req, err := th.App.RenderIncomingWebhookPayload(hook, payload)
+		require.Nil(t, err)
+		assert.Equal(t, "firing", req.Text)
+		assert.Equal(t, "alerts", req.ChannelName)
+		require.Len(t, req.Attachments, 1)
+		assert.Equal(t, "HighLatency", req.Attachments[0].Title)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		hook := &model.IncomingWebhook{PayloadTemplate: "{{ .status }}"}
+
+		_, err := th.App.RenderIncomingWebhookPayload(hook, []byte("not json"))
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		hook := &model.IncomingWebhook{PayloadTemplate: "{{ index .alerts 5 }}"}
+
+		_, err := th.App.RenderIncomingWebhookPayload(hook, payload)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+}
+
 func TestCreateWebhookPost(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -631,6 +673,78 @@ func TestTriggerOutGoingWebhookWithUsernameAndIconURL(t *testing.T) {
 
 }
 
+func TestOutgoingWebhookEventTypes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	payloads := make(chan *model.OutgoingWebhookPayload, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload model.OutgoingWebhookPayload
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads <- &payload
+	}))
+	defer ts.Close()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	hook, err := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:    channel.Id,
+		TeamId:       channel.TeamId,
+		CallbackURLs: []string{ts.URL},
+		CreatorId:    th.BasicUser.Id,
+		ContentType:  "application/json",
+		EventTypes:   model.StringArray{model.OUTGOING_WEBHOOK_EVENT_REACTION_ADDED, model.OUTGOING_WEBHOOK_EVENT_CHANNEL_MEMBER_ADDED},
+	})
+	require.Nil(t, err)
+
+	waitForPayload := func(t *testing.T) *model.OutgoingWebhookPayload {
+		select {
+		case payload := <-payloads:
+			return payload
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "Timeout, webhook was not triggered")
+		}
+		return nil
+	}
+
+	t.Run("created posts are ignored", func(t *testing.T) {
+		_, err := th.App.CreatePost(&model.Post{UserId: th.BasicUser.Id, ChannelId: channel.Id, Message: "message"}, channel, true)
+		require.Nil(t, err)
+
+		select {
+		case payload := <-payloads:
+			require.Fail(t, "Unexpected webhook event", payload.EventType)
+		case <-time.After(time.Second):
+		}
+	})
+
+	t.Run("reaction added", func(t *testing.T) {
+		post := th.CreatePost(channel)
+		_, err := th.App.SaveReactionForPost(&model.Reaction{UserId: th.BasicUser.Id, PostId: post.Id, EmojiName: "smile"})
+		require.Nil(t, err)
+
+		payload := waitForPayload(t)
+		assert.Equal(t, model.OUTGOING_WEBHOOK_EVENT_REACTION_ADDED, payload.EventType)
+		assert.Equal(t, hook.Token, payload.Token)
+		assert.Equal(t, post.Id, payload.PostId)
+		assert.Equal(t, "smile", payload.EmojiName)
+		assert.Equal(t, th.BasicUser.Username, payload.UserName)
+	})
+
+	t.Run("channel member added", func(t *testing.T) {
+		th.AddUserToChannel(th.BasicUser2, channel)
+
+		payload := waitForPayload(t)
+		assert.Equal(t, model.OUTGOING_WEBHOOK_EVENT_CHANNEL_MEMBER_ADDED, payload.EventType)
+		assert.Equal(t, channel.Id, payload.ChannelId)
+		assert.Equal(t, th.BasicUser2.Id, payload.UserId)
+	})
+}
+
 type InfiniteReader struct {
 	Prefix string
 }
@@ -0,0 +1,214 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	INCOMING_WEBHOOK_LIMIT_RATE  = "rate"
+	INCOMING_WEBHOOK_LIMIT_QUOTA = "quota"
+)
+
+type incomingWebhookUsage struct {
+	minute          int64
+	postsThisMinute int
+	day             string
+	postsToday      int
+	rejectedToday   int
+}
+
+// incomingWebhookUsageTracker counts the posts made by each incoming webhook over fixed one minute
+// and one day (UTC) windows. The counts are kept per server and are not shared across a cluster, and
+// the webhooks that haven't posted since the day changed are forgotten.
+type incomingWebhookUsageTracker struct {
+	mutex sync.Mutex
+	day   string
+	usage map[string]*incomingWebhookUsage
+}
+
+func newIncomingWebhookUsageTracker() *incomingWebhookUsageTracker {
+	return &incomingWebhookUsageTracker{
+		usage: map[string]*incomingWebhookUsage{},
+	}
+}
+
+// prune forgets the webhooks that haven't posted today, whose counts would be reset anyway.
+func (t *incomingWebhookUsageTracker) prune(now time.Time) {
+	day := now.UTC().Format("2006-01-02")
+	if t.day == day {
+		return
+	}
+
+	t.day = day
+	for hookId, usage := range t.usage {
+		if usage.day != day {
+			delete(t.usage, hookId)
+		}
+	}
+}
+
+func (t *incomingWebhookUsageTracker) current(hookId string, now time.Time) *incomingWebhookUsage {
+	usage, ok := t.usage[hookId]
+	if !ok {
+		usage = &incomingWebhookUsage{}
+		t.usage[hookId] = usage
+	}
+
+	if minute := now.Unix() / 60; usage.minute != minute {
+		usage.minute = minute
+		usage.postsThisMinute = 0
+	}
+
+	if day := now.UTC().Format("2006-01-02"); usage.day != day {
+		usage.day = day
+		usage.postsToday = 0
+		usage.rejectedToday = 0
+	}
+
+	return usage
+}
+
+// allow records a post made by the webhook if it is within the given limits, where zero means unlimited.
+// It returns the limit that was exceeded when the post is rejected, or an empty string otherwise.
+func (t *incomingWebhookUsageTracker) allow(hookId string, perMinute, daily int, now time.Time) string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.prune(now)
+	usage := t.current(hookId, now)
+
+	if daily > 0 && usage.postsToday >= daily {
+		usage.rejectedToday++
+		return INCOMING_WEBHOOK_LIMIT_QUOTA
+	}
+
+	if perMinute > 0 && usage.postsThisMinute >= perMinute {
+		usage.rejectedToday++
+		return INCOMING_WEBHOOK_LIMIT_RATE
+	}
+
+	usage.postsThisMinute++
+	usage.postsToday++
+	return ""
+}
+
+func (t *incomingWebhookUsageTracker) snapshot(now time.Time) map[string]incomingWebhookUsage {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.prune(now)
+	snapshot := make(map[string]incomingWebhookUsage, len(t.usage))
+	for hookId := range t.usage {
+		snapshot[hookId] = *t.current(hookId, now)
+	}
+	return snapshot
+}
+
+func (t *incomingWebhookUsageTracker) remove(hookId string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.usage, hookId)
+}
+
+// stricterIncomingWebhookLimit returns the stricter of the server wide limit and the limit set on the webhook,
+// where zero means unlimited.
+func stricterIncomingWebhookLimit(serverLimit int, hookLimit *int) int {
+	if hookLimit == nil || *hookLimit == 0 {
+		return serverLimit
+	}
+
+	if serverLimit == 0 || *hookLimit < serverLimit {
+		return *hookLimit
+	}
+
+	return serverLimit
+}
+
+// getIncomingWebhookLimits returns the rate limit and daily quota that apply to the webhook, where zero means
+// unlimited. The limits set on the webhook can only be stricter than the server wide limits, since the users
+// managing webhooks aren't necessarily system admins.
+func (a *App) getIncomingWebhookLimits(hook *model.IncomingWebhook) (int, int) {
+	perMinute := stricterIncomingWebhookLimit(*a.Config().ServiceSettings.IncomingWebhookRateLimitPerMinute, hook.RateLimitPerMinute)
+	daily := stricterIncomingWebhookLimit(*a.Config().ServiceSettings.IncomingWebhookDailyQuota, hook.DailyQuota)
+
+	return perMinute, daily
+}
+
+// checkIncomingWebhookLimits records a post made through the webhook, returning an error with a 429
+// status code if the webhook exceeded its rate limit or daily quota.
+func (a *App) checkIncomingWebhookLimits(hook *model.IncomingWebhook) *model.AppError {
+	perMinute, daily := a.getIncomingWebhookLimits(hook)
+
+	switch a.Srv.incomingWebhookUsage.allow(hook.Id, perMinute, daily, time.Now()) {
+	case INCOMING_WEBHOOK_LIMIT_RATE:
+		if a.Metrics != nil {
+			a.Metrics.IncrementWebhookRateLimited()
+		}
+		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.rate_limited.app_error", map[string]interface{}{"Limit": perMinute}, "hook_id="+hook.Id, http.StatusTooManyRequests)
+	case INCOMING_WEBHOOK_LIMIT_QUOTA:
+		if a.Metrics != nil {
+			a.Metrics.IncrementWebhookQuotaExceeded()
+		}
+		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.quota_exceeded.app_error", map[string]interface{}{"Quota": daily}, "hook_id="+hook.Id, http.StatusTooManyRequests)
+	}
+
+	return nil
+}
+
+// GetTopIncomingWebhookUsage returns the incoming webhooks that made the most posts today on this
+// server, busiest first.
+func (a *App) GetTopIncomingWebhookUsage(limit int) ([]*model.IncomingWebhookUsage, *model.AppError) {
+	now := time.Now()
+	snapshot := a.Srv.incomingWebhookUsage.snapshot(now)
+
+	list := make([]*model.IncomingWebhookUsage, 0, len(snapshot))
+	for hookId, usage := range snapshot {
+		if usage.postsToday == 0 && usage.rejectedToday == 0 {
+			continue
+		}
+
+		list = append(list, &model.IncomingWebhookUsage{
+			HookId:          hookId,
+			PostsLastMinute: usage.postsThisMinute,
+			PostsToday:      usage.postsToday,
+			RejectedToday:   usage.rejectedToday,
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].PostsToday != list[j].PostsToday {
+			return list[i].PostsToday > list[j].PostsToday
+		}
+		return list[i].RejectedToday > list[j].RejectedToday
+	})
+
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+
+	result := make([]*model.IncomingWebhookUsage, 0, len(list))
+	for _, item := range list {
+		hook, err := a.Srv.Store.Webhook().GetIncoming(item.HookId, true)
+		if err != nil {
+			// The webhook was deleted since it last posted.
+			continue
+		}
+
+		item.TeamId = hook.TeamId
+		item.ChannelId = hook.ChannelId
+		item.DisplayName = hook.DisplayName
+		item.RateLimitPerMinute, item.DailyQuota = a.getIncomingWebhookLimits(hook)
+		result = append(result, item)
+	}
+
+	return result, nil
+}
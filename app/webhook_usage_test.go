@@ -0,0 +1,111 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestIncomingWebhookUsageTracker(t *testing.T) {
+	tracker := newIncomingWebhookUsageTracker()
+	hookId := model.NewId()
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("unlimited", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			assert.Empty(t, tracker.allow(model.NewId(), 0, 0, now))
+		}
+	})
+
+	t.Run("rate limit resets every minute", func(t *testing.T) {
+		assert.Empty(t, tracker.allow(hookId, 2, 0, now))
+		assert.Empty(t, tracker.allow(hookId, 2, 0, now.Add(10*time.Second)))
+		assert.Equal(t, INCOMING_WEBHOOK_LIMIT_RATE, tracker.allow(hookId, 2, 0, now.Add(20*time.Second)))
+		assert.Empty(t, tracker.allow(hookId, 2, 0, now.Add(time.Minute)))
+	})
+
+	t.Run("quota resets every day", func(t *testing.T) {
+		assert.Equal(t, INCOMING_WEBHOOK_LIMIT_QUOTA, tracker.allow(hookId, 0, 3, now.Add(2*time.Minute)))
+		assert.Empty(t, tracker.allow(hookId, 0, 3, now.Add(24*time.Hour)))
+	})
+
+	snapshot := tracker.snapshot(now.Add(24 * time.Hour))
+	require.Contains(t, snapshot, hookId)
+	assert.Equal(t, 1, snapshot[hookId].postsToday)
+	assert.Equal(t, 0, snapshot[hookId].rejectedToday)
+	assert.Len(t, snapshot, 1, "the webhooks that didn't post since the day changed should be forgotten")
+
+	tracker.remove(hookId)
+	assert.Empty(t, tracker.snapshot(now.Add(24*time.Hour)))
+}
+
+func TestStricterIncomingWebhookLimit(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ServerLimit int
+		HookLimit   *int
+		Expected    int
+	}{
+		"not set":                        {ServerLimit: 10, HookLimit: nil, Expected: 10},
+		"stricter than the server":       {ServerLimit: 10, HookLimit: model.NewInt(5), Expected: 5},
+		"looser than the server":         {ServerLimit: 10, HookLimit: model.NewInt(50), Expected: 10},
+		"unlimited":                      {ServerLimit: 10, HookLimit: model.NewInt(0), Expected: 10},
+		"unlimited server":               {ServerLimit: 0, HookLimit: model.NewInt(50), Expected: 50},
+		"unlimited server and not set":   {ServerLimit: 0, HookLimit: nil, Expected: 0},
+		"unlimited server and unlimited": {ServerLimit: 0, HookLimit: model.NewInt(0), Expected: 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, stricterIncomingWebhookLimit(tc.ServerLimit, tc.HookLimit))
+		})
+	}
+}
+
+func TestHandleIncomingWebhookLimits(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableIncomingWebhooks = true
+		*cfg.ServiceSettings.IncomingWebhookRateLimitPerMinute = 0
+		*cfg.ServiceSettings.IncomingWebhookDailyQuota = 0
+	})
+
+	hook, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id, DailyQuota: model.NewInt(2)})
+	require.Nil(t, err)
+
+	require.Nil(t, th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{Text: "first"}))
+	require.Nil(t, th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{Text: "second"}))
+
+	err = th.App.HandleIncomingWebhook(hook.Id, &model.IncomingWebhookRequest{Text: "third"})
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, err.StatusCode)
+	assert.Equal(t, "web.incoming_webhook.quota_exceeded.app_error", err.Id)
+
+	usage, err := th.App.GetTopIncomingWebhookUsage(10)
+	require.Nil(t, err)
+	require.NotEmpty(t, usage)
+	assert.Equal(t, hook.Id, usage[0].HookId)
+	assert.Equal(t, 2, usage[0].PostsToday)
+	assert.Equal(t, 1, usage[0].RejectedToday)
+	assert.Equal(t, 2, usage[0].DailyQuota)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.IncomingWebhookDailyQuota = 1
+	})
+
+	unlimited, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id, DailyQuota: model.NewInt(0)})
+	require.Nil(t, err)
+
+	require.Nil(t, th.App.HandleIncomingWebhook(unlimited.Id, &model.IncomingWebhookRequest{Text: "first"}))
+
+	err = th.App.HandleIncomingWebhook(unlimited.Id, &model.IncomingWebhookRequest{Text: "second"})
+	require.NotNil(t, err, "a webhook shouldn't be able to lift the server wide quota")
+	assert.Equal(t, "web.incoming_webhook.quota_exceeded.app_error", err.Id)
+}
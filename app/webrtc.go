@@ -0,0 +1,197 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// webrtcRooms tracks the users who have joined the WebRTC room of each channel to exchange signals with each other.
+// The rooms are kept per server and are not shared across a cluster.
+type webrtcRooms struct {
+	mutex sync.Mutex
+	rooms map[string]map[string]bool
+}
+
+func newWebrtcRooms() *webrtcRooms {
+	return &webrtcRooms{
+		rooms: map[string]map[string]bool{},
+	}
+}
+
+// join adds a user to the room of a channel, returning false if they were already in it.
+func (r *webrtcRooms) join(channelId, userId string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	room, ok := r.rooms[channelId]
+	if !ok {
+		room = map[string]bool{}
+		r.rooms[channelId] = room
+	}
+
+	if room[userId] {
+		return false
+	}
+
+	room[userId] = true
+	return true
+}
+
+// leave removes a user from the room of a channel, returning false if they weren't in it.
+func (r *webrtcRooms) leave(channelId, userId string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	room := r.rooms[channelId]
+	if !room[userId] {
+		return false
+	}
+
+	delete(room, userId)
+	if len(room) == 0 {
+		delete(r.rooms, channelId)
+	}
+	return true
+}
+
+// leaveAll removes a user from every room, returning the ids of the channels they were in.
+func (r *webrtcRooms) leaveAll(userId string) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	channelIds := []string{}
+	for channelId, room := range r.rooms {
+		if room[userId] {
+			delete(room, userId)
+			if len(room) == 0 {
+				delete(r.rooms, channelId)
+			}
+			channelIds = append(channelIds, channelId)
+		}
+	}
+	return channelIds
+}
+
+func (r *webrtcRooms) contains(channelId, userId string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.rooms[channelId][userId]
+}
+
+func (r *webrtcRooms) userIds(channelId string) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	userIds := []string{}
+	for userId := range r.rooms[channelId] {
+		userIds = append(userIds, userId)
+	}
+	sort.Strings(userIds)
+	return userIds
+}
+
+// GetWebrtcRoomUserIds returns the ids of the users in the WebRTC room of a channel.
+func (a *App) GetWebrtcRoomUserIds(channelId string) []string {
+	return a.Srv.webrtcRooms.userIds(channelId)
+}
+
+// JoinWebrtcRoom adds a user to the WebRTC room of a channel and lets the channel know. It returns the ids of the
+// users in the room, so that the user can offer to connect to each of them.
+func (a *App) JoinWebrtcRoom(channelId, userId string) ([]string, *model.AppError) {
+	channel, err := a.GetChannel(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if channel.DeleteAt != 0 {
+		return nil, model.NewAppError("JoinWebrtcRoom", "app.webrtc.deleted_channel.app_error", nil, "channel_id="+channelId, http.StatusBadRequest)
+	}
+
+	if a.Srv.webrtcRooms.join(channelId, userId) {
+		a.publishWebrtcRoomEvent(model.WEBSOCKET_EVENT_WEBRTC_USER_JOINED, channelId, userId)
+	}
+
+	return a.GetWebrtcRoomUserIds(channelId), nil
+}
+
+// LeaveWebrtcRoom removes a user from the WebRTC room of a channel and lets the channel know.
+func (a *App) LeaveWebrtcRoom(channelId, userId string) {
+	if a.Srv.webrtcRooms.leave(channelId, userId) {
+		a.publishWebrtcRoomEvent(model.WEBSOCKET_EVENT_WEBRTC_USER_LEFT, channelId, userId)
+	}
+}
+
+// leaveWebrtcRooms removes a user from every WebRTC room, such as when their last websocket connection closes.
+func (a *App) leaveWebrtcRooms(userId string) {
+	for _, channelId := range a.Srv.webrtcRooms.leaveAll(userId) {
+		a.publishWebrtcRoomEvent(model.WEBSOCKET_EVENT_WEBRTC_USER_LEFT, channelId, userId)
+	}
+}
+
+func (a *App) publishWebrtcRoomEvent(event, channelId, userId string) {
+	message := model.NewWebSocketEvent(event, "", channelId, "", nil)
+	message.Add("user_id", userId)
+	a.Publish(message)
+}
+
+// RelayWebrtcSignal sends a signal to its recipient in the WebRTC room of its channel, or to everyone else in the room
+// when it has none. Users only send and receive signals while in the room, and plugins receive them through the
+// WebrtcSignalReceived hook.
+func (a *App) RelayWebrtcSignal(signal *model.WebrtcSignal) *model.AppError {
+	if err := signal.IsValid(); err != nil {
+		return err
+	}
+
+	if signal.UserId != "" && !a.Srv.webrtcRooms.contains(signal.ChannelId, signal.UserId) {
+		return model.NewAppError("RelayWebrtcSignal", "app.webrtc.not_in_room.app_error", nil, "channel_id="+signal.ChannelId+", user_id="+signal.UserId, http.StatusBadRequest)
+	}
+
+	if signal.ToPluginId != "" {
+		return a.relayWebrtcSignalToPlugin(signal)
+	}
+
+	userIds := []string{signal.ToUserId}
+	if signal.ToUserId == "" {
+		userIds = a.GetWebrtcRoomUserIds(signal.ChannelId)
+	} else if !a.Srv.webrtcRooms.contains(signal.ChannelId, signal.ToUserId) {
+		return model.NewAppError("RelayWebrtcSignal", "app.webrtc.not_in_room.app_error", nil, "channel_id="+signal.ChannelId+", user_id="+signal.ToUserId, http.StatusBadRequest)
+	}
+
+	signalJson := signal.ToJson()
+	for _, userId := range userIds {
+		if userId == signal.UserId {
+			continue
+		}
+
+		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_WEBRTC_SIGNAL, "", "", userId, nil)
+		message.Add("signal", signalJson)
+		a.Publish(message)
+	}
+
+	return nil
+}
+
+func (a *App) relayWebrtcSignalToPlugin(signal *model.WebrtcSignal) *model.AppError {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return model.NewAppError("RelayWebrtcSignal", "app.webrtc.plugin_not_found.app_error", nil, "plugin_id="+signal.ToPluginId, http.StatusNotFound)
+	}
+
+	hooks, err := pluginsEnvironment.HooksForPlugin(signal.ToPluginId)
+	if err != nil {
+		return model.NewAppError("RelayWebrtcSignal", "app.webrtc.plugin_not_found.app_error", nil, "plugin_id="+signal.ToPluginId+", "+err.Error(), http.StatusNotFound)
+	}
+
+	a.Srv.Go(func() {
+		hooks.WebrtcSignalReceived(a.PluginContext(), signal)
+	})
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestWebrtcRooms(t *testing.T) {
+	rooms := newWebrtcRooms()
+	channelId1 := model.NewId()
+	channelId2 := model.NewId()
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+
+	assert.True(t, rooms.join(channelId1, userId1))
+	assert.False(t, rooms.join(channelId1, userId1))
+	assert.True(t, rooms.join(channelId1, userId2))
+	assert.True(t, rooms.join(channelId2, userId1))
+	assert.ElementsMatch(t, []string{userId1, userId2}, rooms.userIds(channelId1))
+
+	assert.True(t, rooms.leave(channelId1, userId2))
+	assert.False(t, rooms.leave(channelId1, userId2))
+	assert.False(t, rooms.contains(channelId1, userId2))
+
+	assert.ElementsMatch(t, []string{channelId1, channelId2}, rooms.leaveAll(userId1))
+	assert.Empty(t, rooms.userIds(channelId1))
+	assert.Empty(t, rooms.rooms)
+}
+
+func TestRelayWebrtcSignal(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	userIds, err := th.App.JoinWebrtcRoom(th.BasicChannel.Id, th.BasicUser.Id)
+	require.Nil(t, err)
+	assert.Equal(t, []string{th.BasicUser.Id}, userIds)
+
+	signal := &model.WebrtcSignal{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		ToUserId:  th.BasicUser2.Id,
+		Type:      model.WEBRTC_SIGNAL_TYPE_OFFER,
+		Data:      `{"sdp":"v=0"}`,
+	}
+
+	t.Run("requires the recipient to be in the room", func(t *testing.T) {
+		err = th.App.RelayWebrtcSignal(signal)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.webrtc.not_in_room.app_error", err.Id)
+	})
+
+	t.Run("relays between users in the room", func(t *testing.T) {
+		_, err = th.App.JoinWebrtcRoom(th.BasicChannel.Id, th.BasicUser2.Id)
+		require.Nil(t, err)
+
+		require.Nil(t, th.App.RelayWebrtcSignal(signal))
+	})
+
+	t.Run("requires the sender to be in the room", func(t *testing.T) {
+		th.App.LeaveWebrtcRoom(th.BasicChannel.Id, th.BasicUser.Id)
+		assert.Equal(t, []string{th.BasicUser2.Id}, th.App.GetWebrtcRoomUserIds(th.BasicChannel.Id))
+
+		err = th.App.RelayWebrtcSignal(signal)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.webrtc.not_in_room.app_error", err.Id)
+	})
+
+	t.Run("requires an active plugin", func(t *testing.T) {
+		toPlugin := *signal
+		toPlugin.UserId = th.BasicUser2.Id
+		toPlugin.ToUserId = ""
+		toPlugin.ToPluginId = "com.example.missing"
+
+		err = th.App.RelayWebrtcSignal(&toPlugin)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.webrtc.plugin_not_found.app_error", err.Id)
+	})
+}
@@ -54,6 +54,12 @@ func (wr *WebSocketRouter) ServeWebSocket(conn *WebConn, r *model.WebSocketReque
 			return
 		}
 
+		// Events carry posts, so OAuth apps need to be allowed to read them
+		if !session.HasOAuthScope(model.OAUTH_SCOPE_READ_POSTS) {
+			conn.WebSocket.Close()
+			return
+		}
+
 		wr.app.Srv.Go(func() {
 			wr.app.SetStatusOnline(session.UserId, false)
 			wr.app.UpdateLastActivityAtIfNeeded(*session)
@@ -76,9 +76,11 @@ endif
 ifeq ($(BUILDER_GOOS_GOARCH),"darwin_amd64")
 	cp $(GOPATH)/bin/mattermost $(DIST_PATH)/bin # from native bin dir, not cross-compiled
 	cp $(GOPATH)/bin/platform $(DIST_PATH)/bin # from native bin dir, not cross-compiled
+	cp $(GOPATH)/bin/mmctl $(DIST_PATH)/bin # from native bin dir, not cross-compiled
 else
 	cp $(GOPATH)/bin/darwin_amd64/mattermost $(DIST_PATH)/bin # from cross-compiled bin dir
 	cp $(GOPATH)/bin/darwin_amd64/platform $(DIST_PATH)/bin # from cross-compiled bin dir
+	cp $(GOPATH)/bin/darwin_amd64/mmctl $(DIST_PATH)/bin # from cross-compiled bin dir
 endif
 	@# Strip and prepackage plugins
 	@for plugin_package in $(PLUGIN_PACKAGES) ; do \
@@ -89,6 +91,7 @@ endif
 	@# Cleanup
 	rm -f $(DIST_PATH)/bin/mattermost
 	rm -f $(DIST_PATH)/bin/platform
+	rm -f $(DIST_PATH)/bin/mmctl
 	rm -f $(DIST_PATH)/prepackaged_plugins/*
 
 	@# Make windows package
@@ -96,9 +99,11 @@ endif
 ifeq ($(BUILDER_GOOS_GOARCH),"windows_amd64")
 	cp $(GOPATH)/bin/mattermost.exe $(DIST_PATH)/bin # from native bin dir, not cross-compiled
 	cp $(GOPATH)/bin/platform.exe $(DIST_PATH)/bin # from native bin dir, not cross-compiled
+	cp $(GOPATH)/bin/mmctl.exe $(DIST_PATH)/bin # from native bin dir, not cross-compiled
 else
 	cp $(GOPATH)/bin/windows_amd64/mattermost.exe $(DIST_PATH)/bin # from cross-compiled bin dir
 	cp $(GOPATH)/bin/windows_amd64/platform.exe $(DIST_PATH)/bin # from cross-compiled bin dir
+	cp $(GOPATH)/bin/windows_amd64/mmctl.exe $(DIST_PATH)/bin # from cross-compiled bin dir
 endif
 	@# Strip and prepackage plugins
 	@for plugin_package in $(PLUGIN_PACKAGES) ; do \
@@ -109,6 +114,7 @@ endif
 	@# Cleanup
 	rm -f $(DIST_PATH)/bin/mattermost.exe
 	rm -f $(DIST_PATH)/bin/platform.exe
+	rm -f $(DIST_PATH)/bin/mmctl.exe
 	rm -f $(DIST_PATH)/prepackaged_plugins/*
 
 	@# Make linux package
@@ -116,9 +122,11 @@ endif
 ifeq ($(BUILDER_GOOS_GOARCH),"linux_amd64")
 	cp $(GOPATH)/bin/mattermost $(DIST_PATH)/bin # from native bin dir, not cross-compiled
 	cp $(GOPATH)/bin/platform $(DIST_PATH)/bin # from native bin dir, not cross-compiled
+	cp $(GOPATH)/bin/mmctl $(DIST_PATH)/bin # from native bin dir, not cross-compiled
 else
 	cp $(GOPATH)/bin/linux_amd64/mattermost $(DIST_PATH)/bin # from cross-compiled bin dir
 	cp $(GOPATH)/bin/linux_amd64/platform $(DIST_PATH)/bin # from cross-compiled bin dir
+	cp $(GOPATH)/bin/linux_amd64/mmctl $(DIST_PATH)/bin # from cross-compiled bin dir
 endif
 	@# Strip and prepackage plugins
 	@for plugin_package in $(PLUGIN_PACKAGES) ; do \
@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package bulkpreferences
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type BulkPreferencesJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsBulkPreferencesJobInterface(func(a *app.App) tjobs.BulkPreferencesJobInterface {
+		return &BulkPreferencesJobInterfaceImpl{a}
+	})
+}
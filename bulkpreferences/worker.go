@@ -0,0 +1,248 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package bulkpreferences
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/app"
+	"github.com/mattermost/mattermost-server/jobs"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	TIME_BETWEEN_BATCHES = 100
+	USERS_PER_BATCH      = 100
+)
+
+type Worker struct {
+	name      string
+	stop      chan bool
+	stopped   chan bool
+	jobs      chan model.Job
+	jobServer *jobs.JobServer
+	app       *app.App
+}
+
+func (m *BulkPreferencesJobInterfaceImpl) MakeWorker() model.Worker {
+	worker := Worker{
+		name:      "BulkPreferences",
+		stop:      make(chan bool, 1),
+		stopped:   make(chan bool, 1),
+		jobs:      make(chan model.Job),
+		jobServer: m.App.Srv.Jobs,
+		app:       m.App,
+	}
+
+	return &worker
+}
+
+func (worker *Worker) Run() {
+	mlog.Debug("Worker started", mlog.String("worker", worker.name))
+
+	defer func() {
+		mlog.Debug("Worker finished", mlog.String("worker", worker.name))
+		worker.stopped <- true
+	}()
+
+	for {
+		select {
+		case <-worker.stop:
+			mlog.Debug("Worker received stop signal", mlog.String("worker", worker.name))
+			return
+		case job := <-worker.jobs:
+			mlog.Debug("Worker received a new candidate job.", mlog.String("worker", worker.name))
+			worker.DoJob(&job)
+		}
+	}
+}
+
+func (worker *Worker) Stop() {
+	mlog.Debug("Worker stopping", mlog.String("worker", worker.name))
+	worker.stop <- true
+	<-worker.stopped
+}
+
+func (worker *Worker) JobChannel() chan<- model.Job {
+	return worker.jobs
+}
+
+func (worker *Worker) DoJob(job *model.Job) {
+	if claimed, err := worker.jobServer.ClaimJob(job); err != nil {
+		mlog.Info("Worker experienced an error while trying to claim job",
+			mlog.String("worker", worker.name),
+			mlog.String("job_id", job.Id),
+			mlog.String("error", err.Error()))
+		return
+	} else if !claimed {
+		return
+	}
+
+	cancelCtx, cancelCancelWatcher := context.WithCancel(context.Background())
+	cancelWatcherChan := make(chan interface{}, 1)
+	go worker.app.Srv.Jobs.CancellationWatcher(cancelCtx, job.Id, cancelWatcherChan)
+
+	defer cancelCancelWatcher()
+
+	operation, err := worker.app.GetBulkPreferencesOperation(job)
+	if err != nil {
+		mlog.Error("Worker: Failed to load bulk preferences operation", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+		return
+	}
+
+	// Rollbacks work through the entries of the original manifest, other operations through the targeted users.
+	var userIds []string
+	var restore []*model.BulkPreferencesManifestEntry
+	if operation.Action == model.BULK_PREFERENCES_ACTION_ROLLBACK {
+		original, appErr := worker.app.GetBulkPreferencesManifest(operation.RollbackJobId)
+		if appErr != nil {
+			mlog.Error("Worker: Failed to load rollback manifest", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", appErr.Error()))
+			worker.setJobError(job, appErr)
+			return
+		}
+		restore = original.Entries
+		userIds = usersInManifestOrder(restore)
+	} else {
+		var appErr *model.AppError
+		if userIds, appErr = worker.app.GetBulkPreferencesTargetUserIds(operation); appErr != nil {
+			mlog.Error("Worker: Failed to resolve bulk preferences target users", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", appErr.Error()))
+			worker.setJobError(job, appErr)
+			return
+		}
+	}
+
+	manifest := &model.BulkPreferencesManifest{
+		JobId:   job.Id,
+		Action:  operation.Action,
+		Entries: []*model.BulkPreferencesManifestEntry{},
+	}
+
+	done := 0
+	job.Data[model.BULK_PREFERENCES_JOB_DATA_USERS_TOTAL] = strconv.Itoa(len(userIds))
+
+	for {
+		select {
+		case <-cancelWatcherChan:
+			mlog.Debug("Worker: Job has been canceled via CancellationWatcher", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+			worker.saveManifest(job, manifest)
+			worker.setJobCanceled(job)
+			return
+
+		case <-worker.stop:
+			mlog.Debug("Worker: Job has been canceled via Worker Stop", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+			worker.saveManifest(job, manifest)
+			worker.setJobCanceled(job)
+			return
+
+		case <-time.After(TIME_BETWEEN_BATCHES * time.Millisecond):
+			end := done + USERS_PER_BATCH
+			if end > len(userIds) {
+				end = len(userIds)
+			}
+
+			var entries []*model.BulkPreferencesManifestEntry
+			var appErr *model.AppError
+			if operation.Action == model.BULK_PREFERENCES_ACTION_ROLLBACK {
+				entries, appErr = worker.app.RestoreBulkPreferences(entriesForUsers(restore, userIds[done:end]))
+			} else {
+				entries, appErr = worker.app.ApplyBulkPreferences(operation, userIds[done:end])
+			}
+			if appErr != nil {
+				mlog.Error("Worker: Failed to apply bulk preferences", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", appErr.Error()))
+				worker.saveManifest(job, manifest)
+				worker.setJobError(job, appErr)
+				return
+			}
+
+			manifest.Entries = append(manifest.Entries, entries...)
+			done = end
+
+			if done >= len(userIds) {
+				if appErr := worker.app.SaveBulkPreferencesManifest(job, manifest); appErr != nil {
+					mlog.Error("Worker: Failed to save bulk preferences manifest", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", appErr.Error()))
+					worker.setJobError(job, appErr)
+					return
+				}
+
+				mlog.Info("Worker: Job is complete", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+				job.Data[model.BULK_PREFERENCES_JOB_DATA_USERS_DONE] = strconv.Itoa(done)
+				worker.setJobProgress(job, 100)
+				worker.setJobSuccess(job)
+				return
+			}
+
+			job.Data[model.BULK_PREFERENCES_JOB_DATA_USERS_DONE] = strconv.Itoa(done)
+			if appErr := worker.app.Srv.Jobs.SetJobProgress(job, int64(done*100/len(userIds))); appErr != nil {
+				mlog.Error("Worker: Failed to update progress for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", appErr.Error()))
+				worker.saveManifest(job, manifest)
+				worker.setJobError(job, appErr)
+				return
+			}
+		}
+	}
+}
+
+// usersInManifestOrder returns the distinct users of the manifest entries in the order they first appear.
+func usersInManifestOrder(entries []*model.BulkPreferencesManifestEntry) []string {
+	seen := map[string]bool{}
+	userIds := []string{}
+	for _, entry := range entries {
+		if !seen[entry.UserId] {
+			seen[entry.UserId] = true
+			userIds = append(userIds, entry.UserId)
+		}
+	}
+	return userIds
+}
+
+func entriesForUsers(entries []*model.BulkPreferencesManifestEntry, userIds []string) []*model.BulkPreferencesManifestEntry {
+	users := make(map[string]bool, len(userIds))
+	for _, userId := range userIds {
+		users[userId] = true
+	}
+
+	filtered := []*model.BulkPreferencesManifestEntry{}
+	for _, entry := range entries {
+		if users[entry.UserId] {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// saveManifest records the changes made so far so that an interrupted job can still be rolled back.
+func (worker *Worker) saveManifest(job *model.Job, manifest *model.BulkPreferencesManifest) {
+	if err := worker.app.SaveBulkPreferencesManifest(job, manifest); err != nil {
+		mlog.Error("Worker: Failed to save bulk preferences manifest", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
+
+func (worker *Worker) setJobProgress(job *model.Job, progress int64) {
+	if err := worker.app.Srv.Jobs.SetJobProgress(job, progress); err != nil {
+		mlog.Error("Worker: Failed to set progress for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
+
+func (worker *Worker) setJobSuccess(job *model.Job) {
+	if err := worker.app.Srv.Jobs.SetJobSuccess(job); err != nil {
+		mlog.Error("Worker: Failed to set success for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+	}
+}
+
+func (worker *Worker) setJobError(job *model.Job, appError *model.AppError) {
+	if err := worker.app.Srv.Jobs.SetJobError(job, appError); err != nil {
+		mlog.Error("Worker: Failed to set job error", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
+
+func (worker *Worker) setJobCanceled(job *model.Job) {
+	if err := worker.app.Srv.Jobs.SetJobCanceled(job); err != nil {
+		mlog.Error("Worker: Failed to mark job as canceled", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
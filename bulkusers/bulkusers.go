@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package bulkusers
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type BulkUsersJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsBulkUsersJobInterface(func(a *app.App) tjobs.BulkUsersJobInterface {
+		return &BulkUsersJobInterfaceImpl{a}
+	})
+}
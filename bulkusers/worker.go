@@ -0,0 +1,195 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package bulkusers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/app"
+	"github.com/mattermost/mattermost-server/jobs"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	TIME_BETWEEN_BATCHES = 100
+	USERS_PER_BATCH      = 50
+)
+
+type Worker struct {
+	name      string
+	stop      chan bool
+	stopped   chan bool
+	jobs      chan model.Job
+	jobServer *jobs.JobServer
+	app       *app.App
+}
+
+func (m *BulkUsersJobInterfaceImpl) MakeWorker() model.Worker {
+	worker := Worker{
+		name:      "BulkUsers",
+		stop:      make(chan bool, 1),
+		stopped:   make(chan bool, 1),
+		jobs:      make(chan model.Job),
+		jobServer: m.App.Srv.Jobs,
+		app:       m.App,
+	}
+
+	return &worker
+}
+
+func (worker *Worker) Run() {
+	mlog.Debug("Worker started", mlog.String("worker", worker.name))
+
+	defer func() {
+		mlog.Debug("Worker finished", mlog.String("worker", worker.name))
+		worker.stopped <- true
+	}()
+
+	for {
+		select {
+		case <-worker.stop:
+			mlog.Debug("Worker received stop signal", mlog.String("worker", worker.name))
+			return
+		case job := <-worker.jobs:
+			mlog.Debug("Worker received a new candidate job.", mlog.String("worker", worker.name))
+			worker.DoJob(&job)
+		}
+	}
+}
+
+func (worker *Worker) Stop() {
+	mlog.Debug("Worker stopping", mlog.String("worker", worker.name))
+	worker.stop <- true
+	<-worker.stopped
+}
+
+func (worker *Worker) JobChannel() chan<- model.Job {
+	return worker.jobs
+}
+
+func (worker *Worker) DoJob(job *model.Job) {
+	if claimed, err := worker.jobServer.ClaimJob(job); err != nil {
+		mlog.Info("Worker experienced an error while trying to claim job",
+			mlog.String("worker", worker.name),
+			mlog.String("job_id", job.Id),
+			mlog.String("error", err.Error()))
+		return
+	} else if !claimed {
+		return
+	}
+
+	cancelCtx, cancelCancelWatcher := context.WithCancel(context.Background())
+	cancelWatcherChan := make(chan interface{}, 1)
+	go worker.app.Srv.Jobs.CancellationWatcher(cancelCtx, job.Id, cancelWatcherChan)
+
+	defer cancelCancelWatcher()
+
+	operation, err := worker.app.GetBulkUsersOperation(job)
+	if err != nil {
+		mlog.Error("Worker: Failed to load bulk users operation", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+		return
+	}
+
+	requesterId := job.Data[model.BULK_USERS_JOB_DATA_REQUESTER_ID]
+	userIds := operation.UserIds
+
+	results := &model.BulkUsersResults{
+		JobId:   job.Id,
+		Action:  operation.Action,
+		Results: []*model.BulkUsersResult{},
+	}
+
+	done := 0
+	failed := 0
+	job.Data[model.BULK_USERS_JOB_DATA_USERS_TOTAL] = strconv.Itoa(len(userIds))
+
+	for {
+		select {
+		case <-cancelWatcherChan:
+			mlog.Debug("Worker: Job has been canceled via CancellationWatcher", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+			worker.saveResults(job, results)
+			worker.setJobCanceled(job)
+			return
+
+		case <-worker.stop:
+			mlog.Debug("Worker: Job has been canceled via Worker Stop", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+			worker.saveResults(job, results)
+			worker.setJobCanceled(job)
+			return
+
+		case <-time.After(TIME_BETWEEN_BATCHES * time.Millisecond):
+			end := done + USERS_PER_BATCH
+			if end > len(userIds) {
+				end = len(userIds)
+			}
+
+			for _, result := range worker.app.ApplyBulkUsersOperation(operation, userIds[done:end], requesterId) {
+				if result.Status == model.BULK_USERS_RESULT_FAILED {
+					failed++
+				}
+				results.Results = append(results.Results, result)
+			}
+			done = end
+
+			job.Data[model.BULK_USERS_JOB_DATA_USERS_DONE] = strconv.Itoa(done)
+			job.Data[model.BULK_USERS_JOB_DATA_USERS_FAILED] = strconv.Itoa(failed)
+
+			if done >= len(userIds) {
+				if appErr := worker.app.SaveBulkUsersResults(job, results); appErr != nil {
+					mlog.Error("Worker: Failed to save bulk users results", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", appErr.Error()))
+					worker.setJobError(job, appErr)
+					return
+				}
+
+				mlog.Info("Worker: Job is complete", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+				worker.setJobProgress(job, 100)
+				worker.setJobSuccess(job)
+				return
+			}
+
+			if appErr := worker.app.Srv.Jobs.SetJobProgress(job, int64(done*100/len(userIds))); appErr != nil {
+				mlog.Error("Worker: Failed to update progress for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", appErr.Error()))
+				worker.saveResults(job, results)
+				worker.setJobError(job, appErr)
+				return
+			}
+		}
+	}
+}
+
+// saveResults records the results of the users processed so far when a job doesn't run to completion.
+func (worker *Worker) saveResults(job *model.Job, results *model.BulkUsersResults) {
+	if err := worker.app.SaveBulkUsersResults(job, results); err != nil {
+		mlog.Error("Worker: Failed to save bulk users results", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
+
+func (worker *Worker) setJobProgress(job *model.Job, progress int64) {
+	if err := worker.app.Srv.Jobs.SetJobProgress(job, progress); err != nil {
+		mlog.Error("Worker: Failed to set progress for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
+
+func (worker *Worker) setJobSuccess(job *model.Job) {
+	if err := worker.app.Srv.Jobs.SetJobSuccess(job); err != nil {
+		mlog.Error("Worker: Failed to set success for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+	}
+}
+
+func (worker *Worker) setJobError(job *model.Job, appError *model.AppError) {
+	if err := worker.app.Srv.Jobs.SetJobError(job, appError); err != nil {
+		mlog.Error("Worker: Failed to set job error", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
+
+func (worker *Worker) setJobCanceled(job *model.Job) {
+	if err := worker.app.Srv.Jobs.SetJobCanceled(job); err != nil {
+		mlog.Error("Worker: Failed to mark job as canceled", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package channelreadstats
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type ChannelReadStatsJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsChannelReadStatsJobInterface(func(a *app.App) tjobs.ChannelReadStatsJobInterface {
+		return &ChannelReadStatsJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package channeltimeline
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type ChannelTimelineJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsChannelTimelineJobInterface(func(a *app.App) tjobs.ChannelTimelineJobInterface {
+		return &ChannelTimelineJobInterfaceImpl{a}
+	})
+}
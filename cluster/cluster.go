@@ -0,0 +1,689 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package cluster
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+
+	"github.com/mattermost/mattermost-server/einterfaces"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	DISCOVERY_INTERVAL  = 30 * time.Second
+	LEAVE_TIMEOUT       = 5 * time.Second
+	REQUEST_TIMEOUT     = 10 * time.Second
+	UPDATE_NODE_TIMEOUT = 5 * time.Second
+
+	// Events used between the nodes of the gossip cluster itself, which aren't exposed to the handlers.
+	EVENT_RESPONSE            = "gossip_response"
+	EVENT_GET_CLUSTER_STATS   = "gossip_get_cluster_stats"
+	EVENT_GET_LOGS            = "gossip_get_logs"
+	EVENT_GET_PLUGIN_STATUSES = "gossip_get_plugin_statuses"
+	EVENT_CONFIG_CHANGED      = "gossip_config_changed"
+
+	PROP_REQUEST_ID = "request_id"
+	PROP_PAGE       = "page"
+	PROP_PER_PAGE   = "per_page"
+)
+
+// Node is the part of the server the gossip cluster relies on.
+type Node interface {
+	Config() *model.Config
+	ReloadConfig() error
+	InvokeClusterLeaderChangedListeners()
+
+	// StartDiscovery advertises the gossip address of this node to the other nodes of the cluster and returns a
+	// function to stop advertising it.
+	StartDiscovery(clusterName, hostname string, gossipPort int) func()
+	// DiscoverPeers returns the gossip addresses advertised by the nodes of the cluster, including this one.
+	DiscoverPeers(clusterName string) ([]string, error)
+
+	ClusterStats() *model.ClusterStats
+	Logs(page, perPage int) ([]string, *model.AppError)
+	PluginStatuses() (model.PluginStatuses, *model.AppError)
+}
+
+// nodeMeta is gossiped along with the membership of each node.
+type nodeMeta struct {
+	ClusterName string `json:"cn"`
+	Version     string `json:"v"`
+	ConfigHash  string `json:"ch"`
+	Hostname    string `json:"h"`
+	StreamPort  int    `json:"sp"`
+}
+
+type member struct {
+	id   string
+	addr string
+	meta nodeMeta
+}
+
+// GossipCluster implements the cluster interface with a gossip protocol: the nodes discover each other through the
+// database and keep track of the membership of the cluster with memberlist, while the cluster messages are delivered
+// over a stream between each pair of nodes.
+type GossipCluster struct {
+	node   Node
+	logger *log.Logger
+	id     string
+
+	handlers      map[string]einterfaces.ClusterMessageHandler
+	handlersMutex sync.RWMutex
+
+	lifecycleMutex sync.Mutex
+	started        bool
+	stopDiscovery  func()
+	stop           chan struct{}
+	stopped        chan struct{}
+
+	mutex      sync.RWMutex
+	memberlist *memberlist.Memberlist
+	stream     *stream
+	members    map[string]*member
+	leaderId   string
+
+	requests sync.Map
+}
+
+func NewGossipCluster(node Node, logger *log.Logger) *GossipCluster {
+	return &GossipCluster{
+		node:     node,
+		logger:   logger,
+		id:       model.NewId(),
+		handlers: make(map[string]einterfaces.ClusterMessageHandler),
+		members:  make(map[string]*member),
+	}
+}
+
+func (c *GossipCluster) StartInterNodeCommunication() {
+	cfg := c.node.Config()
+	if !*cfg.ClusterSettings.Enable {
+		return
+	}
+
+	c.lifecycleMutex.Lock()
+	defer c.lifecycleMutex.Unlock()
+
+	if c.started {
+		return
+	}
+
+	if *cfg.ClusterSettings.SecretKey == "" {
+		mlog.Error("Failed to start the cluster, ClusterSettings.SecretKey isn't set")
+		return
+	}
+	secretKey := sha256.Sum256([]byte(*cfg.ClusterSettings.SecretKey))
+
+	bindAddress := *cfg.ClusterSettings.BindAddress
+	if bindAddress == "" {
+		bindAddress = "0.0.0.0"
+	}
+
+	advertiseAddress, err := advertiseAddress(cfg)
+	if err != nil {
+		mlog.Error("Failed to resolve the cluster advertise address", mlog.Err(err))
+		return
+	}
+
+	stream := newStream(c.id, secretKey[:], c.isMemberAddr, c.receive)
+	if err := stream.Listen(net.JoinHostPort(bindAddress, strconv.Itoa(*cfg.ClusterSettings.StreamingPort))); err != nil {
+		mlog.Error("Failed to start the cluster stream listener", mlog.Int("port", *cfg.ClusterSettings.StreamingPort), mlog.Err(err))
+		return
+	}
+
+	c.mutex.Lock()
+	c.stream = stream
+	c.mutex.Unlock()
+
+	mlConfig := memberlist.DefaultLANConfig()
+	mlConfig.Name = c.id
+	mlConfig.BindAddr = bindAddress
+	mlConfig.BindPort = *cfg.ClusterSettings.GossipPort
+	mlConfig.AdvertiseAddr = advertiseAddress
+	mlConfig.AdvertisePort = *cfg.ClusterSettings.GossipPort
+	mlConfig.SecretKey = secretKey[:]
+	mlConfig.Delegate = &delegate{cluster: c}
+	mlConfig.Events = &eventDelegate{cluster: c}
+	mlConfig.Alive = &aliveDelegate{cluster: c}
+	mlConfig.Logger = c.logger
+
+	// The delegates are called while memberlist holds its own locks, including during Create, so c.mutex mustn't be
+	// held while calling into memberlist.
+	ml, err := memberlist.Create(mlConfig)
+	if err != nil {
+		mlog.Error("Failed to start the cluster gossip", mlog.Int("port", *cfg.ClusterSettings.GossipPort), mlog.Err(err))
+		stream.Close()
+		c.mutex.Lock()
+		c.stream = nil
+		c.mutex.Unlock()
+		return
+	}
+
+	c.mutex.Lock()
+	c.memberlist = ml
+	c.mutex.Unlock()
+
+	local := ml.LocalNode()
+	mlog.Info("Started cluster gossip", mlog.String("cluster_name", *cfg.ClusterSettings.ClusterName), mlog.String("node_id", c.id), mlog.String("addr", local.Address()))
+
+	c.stopDiscovery = c.node.StartDiscovery(*cfg.ClusterSettings.ClusterName, local.Addr.String(), int(local.Port))
+	c.stop = make(chan struct{})
+	c.stopped = make(chan struct{})
+	c.started = true
+
+	go c.discover()
+}
+
+func (c *GossipCluster) StopInterNodeCommunication() {
+	c.lifecycleMutex.Lock()
+	defer c.lifecycleMutex.Unlock()
+
+	if !c.started {
+		return
+	}
+	c.started = false
+
+	close(c.stop)
+	<-c.stopped
+	c.stopDiscovery()
+
+	c.mutex.RLock()
+	ml := c.memberlist
+	stream := c.stream
+	c.mutex.RUnlock()
+
+	if err := ml.Leave(LEAVE_TIMEOUT); err != nil {
+		mlog.Warn("Failed to leave the cluster gracefully", mlog.Err(err))
+	}
+	if err := ml.Shutdown(); err != nil {
+		mlog.Warn("Failed to shut down the cluster gossip", mlog.Err(err))
+	}
+
+	stream.Close()
+
+	c.mutex.Lock()
+	c.memberlist = nil
+	c.stream = nil
+	c.members = make(map[string]*member)
+	c.leaderId = ""
+	c.mutex.Unlock()
+
+	mlog.Info("Stopped cluster gossip", mlog.String("node_id", c.id))
+}
+
+// discover joins the nodes advertised in the database that aren't members of the cluster yet, at startup and then
+// periodically, so that partitioned clusters and nodes that started simultaneously eventually merge.
+func (c *GossipCluster) discover() {
+	defer close(c.stopped)
+
+	ticker := time.NewTicker(DISCOVERY_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		c.joinDiscoveredPeers()
+
+		select {
+		case <-ticker.C:
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *GossipCluster) joinDiscoveredPeers() {
+	addresses, err := c.node.DiscoverPeers(*c.node.Config().ClusterSettings.ClusterName)
+	if err != nil {
+		mlog.Error("Failed to discover the nodes of the cluster", mlog.Err(err))
+		return
+	}
+
+	known := make(map[string]bool)
+	c.mutex.RLock()
+	for _, m := range c.members {
+		known[m.addr] = true
+	}
+	ml := c.memberlist
+	c.mutex.RUnlock()
+
+	if ml == nil {
+		return
+	}
+
+	var unknown []string
+	for _, address := range addresses {
+		if !known[address] {
+			unknown = append(unknown, address)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return
+	}
+
+	if _, err := ml.Join(unknown); err != nil {
+		mlog.Debug("Failed to join some of the discovered cluster nodes", mlog.String("addresses", strings.Join(unknown, ",")), mlog.Err(err))
+	}
+}
+
+// Join contacts the given gossip addresses to join their cluster.
+func (c *GossipCluster) Join(addresses []string) (int, error) {
+	c.mutex.RLock()
+	ml := c.memberlist
+	c.mutex.RUnlock()
+
+	if ml == nil {
+		return 0, fmt.Errorf("cluster gossip isn't started")
+	}
+
+	return ml.Join(addresses)
+}
+
+func (c *GossipCluster) RegisterClusterMessageHandler(event string, crm einterfaces.ClusterMessageHandler) {
+	c.handlersMutex.Lock()
+	defer c.handlersMutex.Unlock()
+
+	c.handlers[event] = crm
+}
+
+func (c *GossipCluster) GetClusterId() string {
+	return c.id
+}
+
+func (c *GossipCluster) IsLeader() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.leaderId == "" || c.leaderId == c.id
+}
+
+func (c *GossipCluster) GetMyClusterInfo() *model.ClusterInfo {
+	meta := c.localMeta()
+
+	c.mutex.RLock()
+	ml := c.memberlist
+	c.mutex.RUnlock()
+
+	var addr string
+	if ml != nil {
+		addr = ml.LocalNode().Addr.String()
+	}
+
+	return &model.ClusterInfo{
+		Id:         c.id,
+		Version:    meta.Version,
+		ConfigHash: meta.ConfigHash,
+		IpAddress:  addr,
+		Hostname:   meta.Hostname,
+	}
+}
+
+// GetClusterInfos returns the nodes that are currently members of the cluster, including this one.
+func (c *GossipCluster) GetClusterInfos() []*model.ClusterInfo {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	infos := make([]*model.ClusterInfo, 0, len(c.members))
+	for _, m := range c.members {
+		host, _, _ := net.SplitHostPort(m.addr)
+		infos = append(infos, &model.ClusterInfo{
+			Id:         m.id,
+			Version:    m.meta.Version,
+			ConfigHash: m.meta.ConfigHash,
+			IpAddress:  host,
+			Hostname:   m.meta.Hostname,
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Id < infos[j].Id
+	})
+
+	return infos
+}
+
+func (c *GossipCluster) SendClusterMessage(msg *model.ClusterMessage) {
+	c.mutex.RLock()
+	stream := c.stream
+	started := c.memberlist != nil
+	c.mutex.RUnlock()
+
+	if !started {
+		return
+	}
+
+	stream.Broadcast(msg)
+}
+
+// NotifyMsg handles a cluster message received outside of the inter-node streams.
+func (c *GossipCluster) NotifyMsg(buf []byte) {
+	msg := model.ClusterMessageFromJson(strings.NewReader(string(buf)))
+	if msg == nil {
+		return
+	}
+
+	c.handle(msg)
+}
+
+func (c *GossipCluster) GetClusterStats() ([]*model.ClusterStats, *model.AppError) {
+	var stats []*model.ClusterStats
+	for _, response := range c.request(EVENT_GET_CLUSTER_STATS, nil) {
+		if stat := model.ClusterStatsFromJson(strings.NewReader(response.Data)); stat != nil {
+			stats = append(stats, stat)
+		}
+	}
+
+	return stats, nil
+}
+
+func (c *GossipCluster) GetLogs(page, perPage int) ([]string, *model.AppError) {
+	var lines []string
+	for _, response := range c.request(EVENT_GET_LOGS, map[string]string{PROP_PAGE: strconv.Itoa(page), PROP_PER_PAGE: strconv.Itoa(perPage)}) {
+		lines = append(lines, model.ArrayFromJson(strings.NewReader(response.Data))...)
+	}
+
+	return lines, nil
+}
+
+func (c *GossipCluster) GetPluginStatuses() (model.PluginStatuses, *model.AppError) {
+	var statuses model.PluginStatuses
+	for _, response := range c.request(EVENT_GET_PLUGIN_STATUSES, nil) {
+		statuses = append(statuses, model.PluginStatusesFromJson(strings.NewReader(response.Data))...)
+	}
+
+	return statuses, nil
+}
+
+func (c *GossipCluster) ConfigChanged(previousConfig *model.Config, newConfig *model.Config, sendToOtherServer bool) *model.AppError {
+	c.mutex.RLock()
+	ml := c.memberlist
+	c.mutex.RUnlock()
+
+	if ml == nil {
+		return nil
+	}
+
+	// Gossip the new config hash so that mismatched configurations show up in the cluster status.
+	go func() {
+		if err := ml.UpdateNode(UPDATE_NODE_TIMEOUT); err != nil {
+			mlog.Warn("Failed to gossip the updated cluster node metadata", mlog.Err(err))
+		}
+	}()
+
+	if sendToOtherServer {
+		c.SendClusterMessage(&model.ClusterMessage{
+			Event:    EVENT_CONFIG_CHANGED,
+			SendType: model.CLUSTER_SEND_RELIABLE,
+		})
+	}
+
+	return nil
+}
+
+// request sends a message to all the other nodes and waits for their responses, returning those received before the
+// request timed out.
+func (c *GossipCluster) request(event string, props map[string]string) []*model.ClusterMessage {
+	c.mutex.RLock()
+	stream := c.stream
+	started := c.memberlist != nil
+	c.mutex.RUnlock()
+
+	if !started {
+		return nil
+	}
+
+	peerIds := stream.PeerIds()
+	if len(peerIds) == 0 {
+		return nil
+	}
+
+	requestId := model.NewId()
+	responses := make(chan *model.ClusterMessage, len(peerIds))
+	c.requests.Store(requestId, responses)
+	defer c.requests.Delete(requestId)
+
+	msg := &model.ClusterMessage{
+		Event:    event,
+		SendType: model.CLUSTER_SEND_RELIABLE,
+		Props:    map[string]string{PROP_REQUEST_ID: requestId},
+	}
+	for key, value := range props {
+		msg.Props[key] = value
+	}
+
+	for _, id := range peerIds {
+		stream.Send(id, msg)
+	}
+
+	timer := time.NewTimer(REQUEST_TIMEOUT)
+	defer timer.Stop()
+
+	var received []*model.ClusterMessage
+	for len(received) < len(peerIds) {
+		select {
+		case response := <-responses:
+			received = append(received, response)
+		case <-timer.C:
+			mlog.Warn("Timed out waiting for cluster nodes to respond", mlog.String("event", event), mlog.Int("expected", len(peerIds)), mlog.Int("received", len(received)))
+			return received
+		}
+	}
+
+	return received
+}
+
+func (c *GossipCluster) respond(to string, request *model.ClusterMessage, data string) {
+	c.mutex.RLock()
+	stream := c.stream
+	c.mutex.RUnlock()
+
+	if stream == nil {
+		return
+	}
+
+	stream.Send(to, &model.ClusterMessage{
+		Event:    EVENT_RESPONSE,
+		SendType: model.CLUSTER_SEND_RELIABLE,
+		Props:    map[string]string{PROP_REQUEST_ID: request.Props[PROP_REQUEST_ID]},
+		Data:     data,
+	})
+}
+
+// receive handles a message received over the inter-node streams.
+func (c *GossipCluster) receive(from string, msg *model.ClusterMessage) {
+	switch msg.Event {
+	case EVENT_RESPONSE:
+		if responses, ok := c.requests.Load(msg.Props[PROP_REQUEST_ID]); ok {
+			select {
+			case responses.(chan *model.ClusterMessage) <- msg:
+			default:
+			}
+		}
+	case EVENT_GET_CLUSTER_STATS:
+		go func() {
+			stats := c.node.ClusterStats()
+			stats.Id = c.id
+			c.respond(from, msg, stats.ToJson())
+		}()
+	case EVENT_GET_LOGS:
+		go func() {
+			page, _ := strconv.Atoi(msg.Props[PROP_PAGE])
+			perPage, _ := strconv.Atoi(msg.Props[PROP_PER_PAGE])
+			lines, err := c.node.Logs(page, perPage)
+			if err != nil {
+				mlog.Error("Failed to get logs for cluster request", mlog.Err(err))
+			}
+			c.respond(from, msg, model.ArrayToJson(lines))
+		}()
+	case EVENT_GET_PLUGIN_STATUSES:
+		go func() {
+			statuses, err := c.node.PluginStatuses()
+			if err != nil {
+				mlog.Error("Failed to get plugin statuses for cluster request", mlog.Err(err))
+			}
+			c.respond(from, msg, statuses.ToJson())
+		}()
+	case EVENT_CONFIG_CHANGED:
+		go func() {
+			if err := c.node.ReloadConfig(); err != nil {
+				mlog.Error("Failed to reload the config changed by another cluster node", mlog.Err(err))
+			}
+		}()
+	default:
+		c.handle(msg)
+	}
+}
+
+func (c *GossipCluster) handle(msg *model.ClusterMessage) {
+	c.handlersMutex.RLock()
+	handler, ok := c.handlers[msg.Event]
+	c.handlersMutex.RUnlock()
+
+	if !ok {
+		mlog.Debug("No handler registered for cluster message", mlog.String("event", msg.Event))
+		return
+	}
+
+	handler(msg)
+}
+
+func (c *GossipCluster) localMeta() nodeMeta {
+	cfg := c.node.Config()
+
+	hostname := *cfg.ClusterSettings.OverrideHostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+
+	return nodeMeta{
+		ClusterName: *cfg.ClusterSettings.ClusterName,
+		Version:     model.CurrentVersion,
+		ConfigHash:  fmt.Sprintf("%x", md5.Sum([]byte(cfg.ToJson()))),
+		Hostname:    hostname,
+		StreamPort:  *cfg.ClusterSettings.StreamingPort,
+	}
+}
+
+// updateMember records a node joining the cluster or changing its metadata, and streams messages to it.
+func (c *GossipCluster) updateMember(node *memberlist.Node) {
+	var meta nodeMeta
+	if err := json.Unmarshal(node.Meta, &meta); err != nil {
+		mlog.Warn("Ignoring cluster node with invalid metadata", mlog.String("node_id", node.Name), mlog.Err(err))
+		return
+	}
+
+	c.mutex.Lock()
+	_, known := c.members[node.Name]
+	c.members[node.Name] = &member{
+		id:   node.Name,
+		addr: node.Address(),
+		meta: meta,
+	}
+	if node.Name != c.id && c.stream != nil {
+		c.stream.AddPeer(node.Name, net.JoinHostPort(node.Addr.String(), strconv.Itoa(meta.StreamPort)))
+	}
+	c.mutex.Unlock()
+
+	if !known {
+		mlog.Info("Cluster node joined", mlog.String("node_id", node.Name), mlog.String("hostname", meta.Hostname), mlog.String("addr", node.Address()))
+	}
+
+	c.electLeader()
+}
+
+// removeMember records a node leaving the cluster, either gracefully or because it stopped responding.
+func (c *GossipCluster) removeMember(node *memberlist.Node) {
+	c.mutex.Lock()
+	delete(c.members, node.Name)
+	stream := c.stream
+	c.mutex.Unlock()
+
+	mlog.Info("Cluster node left", mlog.String("node_id", node.Name), mlog.String("addr", node.Address()))
+
+	if stream != nil && node.Name != c.id {
+		// Stopping the stream waits for the frame in flight, so it mustn't block memberlist.
+		go stream.RemovePeer(node.Name)
+	}
+
+	c.electLeader()
+}
+
+// isMemberAddr returns whether the given node is a member of the cluster gossiping from the given address.
+func (c *GossipCluster) isMemberAddr(id string, addr net.Addr) bool {
+	c.mutex.RLock()
+	m, ok := c.members[id]
+	c.mutex.RUnlock()
+
+	if !ok || id == c.id {
+		return false
+	}
+
+	memberHost, _, err := net.SplitHostPort(m.addr)
+	if err != nil {
+		return false
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return false
+	}
+
+	memberIP, ip := net.ParseIP(memberHost), net.ParseIP(host)
+	return memberIP != nil && ip != nil && memberIP.Equal(ip)
+}
+
+// electLeader makes the member with the lowest id the leader of the cluster, notifying the listeners when it changes.
+func (c *GossipCluster) electLeader() {
+	c.mutex.Lock()
+	leaderId := ""
+	for id := range c.members {
+		if leaderId == "" || id < leaderId {
+			leaderId = id
+		}
+	}
+	changed := leaderId != c.leaderId
+	c.leaderId = leaderId
+	c.mutex.Unlock()
+
+	if changed {
+		mlog.Info("Cluster leader changed", mlog.String("leader_id", leaderId), mlog.Bool("is_leader", leaderId == c.id))
+		c.node.InvokeClusterLeaderChangedListeners()
+	}
+}
+
+func advertiseAddress(cfg *model.Config) (string, error) {
+	if address := *cfg.ClusterSettings.AdvertiseAddress; address != "" {
+		if net.ParseIP(address) != nil {
+			return address, nil
+		}
+
+		ips, err := net.LookupIP(address)
+		if err != nil {
+			return "", err
+		}
+		if len(ips) == 0 {
+			return "", fmt.Errorf("no address found for %v", address)
+		}
+		return ips[0].String(), nil
+	}
+
+	if *cfg.ClusterSettings.UseIpAddress {
+		return model.GetServerIpAddress(*cfg.ClusterSettings.NetworkInterface), nil
+	}
+
+	// Let memberlist pick the first private address of the bound interfaces.
+	return "", nil
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package cluster
+
+import (
+	"io/ioutil"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type testNode struct {
+	config         *model.Config
+	peers          []string
+	leaderChanges  int
+	websocketCount int
+	mutex          sync.Mutex
+}
+
+func newTestNode(t *testing.T, clusterName string, peers []string) *testNode {
+	config := &model.Config{}
+	config.SetDefaults()
+	*config.ClusterSettings.Enable = true
+	*config.ClusterSettings.ClusterName = clusterName
+	*config.ClusterSettings.SecretKey = "secret"
+	*config.ClusterSettings.BindAddress = "127.0.0.1"
+	*config.ClusterSettings.AdvertiseAddress = "127.0.0.1"
+	*config.ClusterSettings.GossipPort = freePort(t)
+	*config.ClusterSettings.StreamingPort = freePort(t)
+
+	return &testNode{config: config, peers: peers}
+}
+
+func (n *testNode) gossipAddress() string {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(*n.config.ClusterSettings.GossipPort))
+}
+
+func (n *testNode) Config() *model.Config { return n.config }
+func (n *testNode) ReloadConfig() error   { return nil }
+
+func (n *testNode) InvokeClusterLeaderChangedListeners() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.leaderChanges++
+}
+
+func (n *testNode) StartDiscovery(clusterName, hostname string, gossipPort int) func() {
+	return func() {}
+}
+
+func (n *testNode) DiscoverPeers(clusterName string) ([]string, error) {
+	return n.peers, nil
+}
+
+func (n *testNode) ClusterStats() *model.ClusterStats {
+	return &model.ClusterStats{TotalWebsocketConnections: n.websocketCount}
+}
+
+func (n *testNode) Logs(page, perPage int) ([]string, *model.AppError) {
+	return []string{"log line"}, nil
+}
+
+func (n *testNode) PluginStatuses() (model.PluginStatuses, *model.AppError) {
+	return model.PluginStatuses{{PluginId: "plugin", ClusterId: "cluster"}}, nil
+}
+
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(10 * time.Second)
+	for !condition() {
+		require.True(t, time.Now().Before(deadline), "timed out waiting for condition")
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func startTestCluster(t *testing.T, node *testNode) *GossipCluster {
+	c := NewGossipCluster(node, log.New(ioutil.Discard, "", 0))
+	c.StartInterNodeCommunication()
+	require.True(t, c.started)
+
+	return c
+}
+
+func TestGossipCluster(t *testing.T) {
+	node1 := newTestNode(t, "cluster", nil)
+	node1.websocketCount = 7
+	cluster1 := startTestCluster(t, node1)
+	defer cluster1.StopInterNodeCommunication()
+
+	node2 := newTestNode(t, "cluster", []string{node1.gossipAddress()})
+	cluster2 := startTestCluster(t, node2)
+	defer cluster2.StopInterNodeCommunication()
+
+	waitFor(t, func() bool {
+		return len(cluster1.GetClusterInfos()) == 2 && len(cluster2.GetClusterInfos()) == 2
+	})
+
+	t.Run("cluster status lists all the nodes", func(t *testing.T) {
+		infos := cluster1.GetClusterInfos()
+		ids := []string{infos[0].Id, infos[1].Id}
+		assert.Contains(t, ids, cluster1.GetClusterId())
+		assert.Contains(t, ids, cluster2.GetClusterId())
+		assert.Equal(t, model.CurrentVersion, infos[0].Version)
+		assert.Equal(t, "127.0.0.1", infos[0].IpAddress)
+	})
+
+	t.Run("exactly one leader", func(t *testing.T) {
+		assert.NotEqual(t, cluster1.IsLeader(), cluster2.IsLeader())
+	})
+
+	t.Run("messages are delivered to the registered handler", func(t *testing.T) {
+		received := make(chan *model.ClusterMessage, 2)
+		cluster2.RegisterClusterMessageHandler(model.CLUSTER_EVENT_PUBLISH, func(msg *model.ClusterMessage) {
+			received <- msg
+		})
+
+		cluster1.SendClusterMessage(&model.ClusterMessage{
+			Event:    model.CLUSTER_EVENT_PUBLISH,
+			SendType: model.CLUSTER_SEND_RELIABLE,
+			Data:     "data",
+			Props:    map[string]string{"key": "value"},
+		})
+
+		select {
+		case msg := <-received:
+			assert.Equal(t, "data", msg.Data)
+			assert.Equal(t, "value", msg.Props["key"])
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "message wasn't delivered")
+		}
+	})
+
+	t.Run("requests collect the responses of the other nodes", func(t *testing.T) {
+		stats, appErr := cluster2.GetClusterStats()
+		require.Nil(t, appErr)
+		require.Len(t, stats, 1)
+		assert.Equal(t, cluster1.GetClusterId(), stats[0].Id)
+		assert.Equal(t, 7, stats[0].TotalWebsocketConnections)
+
+		lines, appErr := cluster2.GetLogs(0, 10)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"log line"}, lines)
+
+		statuses, appErr := cluster2.GetPluginStatuses()
+		require.Nil(t, appErr)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "plugin", statuses[0].PluginId)
+	})
+
+	t.Run("leaving nodes are removed from the cluster status", func(t *testing.T) {
+		cluster2.StopInterNodeCommunication()
+
+		waitFor(t, func() bool {
+			return len(cluster1.GetClusterInfos()) == 1
+		})
+		assert.True(t, cluster1.IsLeader())
+	})
+}
+
+func TestGossipClusterRejectsOtherClusters(t *testing.T) {
+	node1 := newTestNode(t, "cluster1", nil)
+	cluster1 := startTestCluster(t, node1)
+	defer cluster1.StopInterNodeCommunication()
+
+	node2 := newTestNode(t, "cluster2", []string{node1.gossipAddress()})
+	cluster2 := startTestCluster(t, node2)
+	defer cluster2.StopInterNodeCommunication()
+
+	time.Sleep(time.Second)
+
+	assert.Len(t, cluster1.GetClusterInfos(), 1)
+	assert.Len(t, cluster2.GetClusterInfos(), 1)
+}
+
+func TestGossipClusterRejectsOtherSecretKeys(t *testing.T) {
+	node1 := newTestNode(t, "cluster", nil)
+	cluster1 := startTestCluster(t, node1)
+	defer cluster1.StopInterNodeCommunication()
+
+	node2 := newTestNode(t, "cluster", []string{node1.gossipAddress()})
+	*node2.config.ClusterSettings.SecretKey = "other secret"
+	cluster2 := startTestCluster(t, node2)
+	defer cluster2.StopInterNodeCommunication()
+
+	time.Sleep(time.Second)
+
+	assert.Len(t, cluster1.GetClusterInfos(), 1)
+	assert.Len(t, cluster2.GetClusterInfos(), 1)
+}
+
+func TestGossipClusterRequiresSecretKey(t *testing.T) {
+	node := newTestNode(t, "cluster", nil)
+	*node.config.ClusterSettings.SecretKey = ""
+
+	c := NewGossipCluster(node, log.New(ioutil.Discard, "", 0))
+	c.StartInterNodeCommunication()
+	assert.False(t, c.started)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/memberlist"
+
+	"github.com/mattermost/mattermost-server/mlog"
+)
+
+// delegate provides the metadata gossiped for this node. Cluster messages go through the inter-node streams rather
+// than the gossip messages, so the rest of the delegate is a no-op.
+type delegate struct {
+	cluster *GossipCluster
+}
+
+func (d *delegate) NodeMeta(limit int) []byte {
+	b, err := json.Marshal(d.cluster.localMeta())
+	if err != nil || len(b) > limit {
+		mlog.Error("Failed to encode the cluster node metadata", mlog.Int("size", len(b)), mlog.Int("limit", limit))
+		return nil
+	}
+	return b
+}
+
+func (d *delegate) NotifyMsg([]byte) {}
+
+func (d *delegate) GetBroadcasts(overhead, limit int) [][]byte {
+	return nil
+}
+
+func (d *delegate) LocalState(join bool) []byte {
+	return nil
+}
+
+func (d *delegate) MergeRemoteState(buf []byte, join bool) {}
+
+// eventDelegate keeps the members of the cluster up to date as nodes join and leave.
+type eventDelegate struct {
+	cluster *GossipCluster
+}
+
+func (d *eventDelegate) NotifyJoin(node *memberlist.Node) {
+	d.cluster.updateMember(node)
+}
+
+func (d *eventDelegate) NotifyLeave(node *memberlist.Node) {
+	d.cluster.removeMember(node)
+}
+
+func (d *eventDelegate) NotifyUpdate(node *memberlist.Node) {
+	d.cluster.updateMember(node)
+}
+
+// aliveDelegate keeps nodes of other clusters sharing the same database or network from joining this one.
+type aliveDelegate struct {
+	cluster *GossipCluster
+}
+
+func (d *aliveDelegate) NotifyAlive(node *memberlist.Node) error {
+	var meta nodeMeta
+	if err := json.Unmarshal(node.Meta, &meta); err != nil {
+		return fmt.Errorf("invalid metadata for node %v: %v", node.Name, err)
+	}
+
+	if clusterName := *d.cluster.node.Config().ClusterSettings.ClusterName; meta.ClusterName != clusterName {
+		return fmt.Errorf("node %v belongs to cluster %q instead of %q", node.Name, meta.ClusterName, clusterName)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package cluster
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/app"
+	"github.com/mattermost/mattermost-server/einterfaces"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func init() {
+	app.RegisterClusterInterface(func(s *app.Server) einterfaces.ClusterInterface {
+		return NewGossipCluster(&serverNode{server: s}, s.Log.StdLog(mlog.String("source", "memberlist")))
+	})
+}
+
+// serverNode adapts the server to the needs of the gossip cluster.
+type serverNode struct {
+	server *app.Server
+}
+
+func (n *serverNode) Config() *model.Config {
+	return n.server.Config()
+}
+
+func (n *serverNode) ReloadConfig() error {
+	return n.server.ReloadConfig()
+}
+
+func (n *serverNode) InvokeClusterLeaderChangedListeners() {
+	n.server.InvokeClusterLeaderChangedListeners()
+}
+
+func (n *serverNode) StartDiscovery(clusterName, hostname string, gossipPort int) func() {
+	ds := n.server.FakeApp().NewClusterDiscoveryService()
+	ds.Type = model.CDS_TYPE_APP
+	ds.ClusterName = clusterName
+	ds.Hostname = hostname
+	ds.GossipPort = int32(gossipPort)
+	ds.Port = int32(*n.server.Config().ClusterSettings.StreamingPort)
+	ds.Start()
+
+	return ds.Stop
+}
+
+func (n *serverNode) DiscoverPeers(clusterName string) ([]string, error) {
+	discoveries, err := n.server.Store.ClusterDiscovery().GetAll(model.CDS_TYPE_APP, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(discoveries))
+	for _, discovery := range discoveries {
+		addresses = append(addresses, net.JoinHostPort(discovery.Hostname, strconv.Itoa(int(discovery.GossipPort))))
+	}
+
+	return addresses, nil
+}
+
+func (n *serverNode) ClusterStats() *model.ClusterStats {
+	return &model.ClusterStats{
+		TotalWebsocketConnections: n.server.FakeApp().TotalWebsocketConnections(),
+		TotalReadDbConnections:    n.server.Store.TotalReadDbConnections(),
+		TotalMasterDbConnections:  n.server.Store.TotalMasterDbConnections(),
+	}
+}
+
+func (n *serverNode) Logs(page, perPage int) ([]string, *model.AppError) {
+	return n.server.FakeApp().GetLogsSkipSend(page, perPage)
+}
+
+func (n *serverNode) PluginStatuses() (model.PluginStatuses, *model.AppError) {
+	return n.server.FakeApp().GetPluginStatuses()
+}
@@ -0,0 +1,461 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	STREAM_QUEUE_SIZE            = 4096
+	STREAM_MAX_FRAME_SIZE        = 64 * 1024 * 1024
+	STREAM_DIAL_TIMEOUT          = 5 * time.Second
+	STREAM_WRITE_TIMEOUT         = 10 * time.Second
+	STREAM_RECONNECT_DELAY       = time.Second
+	STREAM_RELIABLE_SEND_TIMEOUT = 5 * time.Second
+	STREAM_HANDSHAKE_TIMEOUT     = 5 * time.Second
+	STREAM_CHALLENGE_SIZE        = 32
+)
+
+var streamHandshakeAccepted = []byte("ok")
+
+// streamFrame is the unit sent over the inter-node streams.
+type streamFrame struct {
+	Message *model.ClusterMessage `json:"message"`
+}
+
+// streamHello identifies the node dialing a stream, proving that it knows the secret key of the cluster by signing
+// the challenge sent by the accepting node.
+type streamHello struct {
+	From      string `json:"from"`
+	Signature []byte `json:"signature"`
+}
+
+// stream delivers cluster messages between nodes over long-lived TCP connections. Each node dials one connection to
+// each of its peers and sends its messages over it in order, while accepting the connections of the other nodes on
+// the streaming port.
+//
+// The node dialing a stream has to sign a challenge with the secret key of the cluster, and its connection is only
+// accepted if it's a member of the cluster connecting from its own address.
+type stream struct {
+	localId   string
+	secretKey []byte
+	authorize func(from string, remoteAddr net.Addr) bool
+	receive   func(from string, msg *model.ClusterMessage)
+	listener  net.Listener
+
+	mutex   sync.Mutex
+	peers   map[string]*streamPeer
+	conns   map[net.Conn]bool
+	closed  bool
+	readers sync.WaitGroup
+}
+
+type streamPeer struct {
+	stream  *stream
+	id      string
+	addr    string
+	queue   chan []byte
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+func newStream(localId string, secretKey []byte, authorize func(from string, remoteAddr net.Addr) bool, receive func(from string, msg *model.ClusterMessage)) *stream {
+	return &stream{
+		localId:   localId,
+		secretKey: secretKey,
+		authorize: authorize,
+		receive:   receive,
+		peers:     make(map[string]*streamPeer),
+		conns:     make(map[net.Conn]bool),
+	}
+}
+
+// Listen starts accepting the streams of the other nodes on the given address.
+func (s *stream) Listen(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			s.mutex.Lock()
+			if s.closed {
+				s.mutex.Unlock()
+				conn.Close()
+				return
+			}
+			s.conns[conn] = true
+			s.readers.Add(1)
+			s.mutex.Unlock()
+
+			go s.read(conn)
+		}
+	}()
+
+	return nil
+}
+
+// Addr returns the address the stream is listening on.
+func (s *stream) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+func (s *stream) read(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.mutex.Lock()
+		delete(s.conns, conn)
+		s.mutex.Unlock()
+		s.readers.Done()
+	}()
+
+	reader := bufio.NewReader(conn)
+
+	from, err := s.accept(conn, reader)
+	if err != nil {
+		mlog.Warn("Rejected cluster stream", mlog.String("remote_addr", conn.RemoteAddr().String()), mlog.Err(err))
+		return
+	}
+
+	for {
+		data, err := readFrame(reader)
+		if err != nil {
+			if err != io.EOF {
+				mlog.Debug("Closing cluster stream", mlog.String("remote_addr", conn.RemoteAddr().String()), mlog.Err(err))
+			}
+			return
+		}
+
+		var frame streamFrame
+		if err := json.Unmarshal(data, &frame); err != nil || frame.Message == nil {
+			mlog.Warn("Received an invalid cluster stream frame", mlog.String("remote_addr", conn.RemoteAddr().String()))
+			continue
+		}
+
+		s.receive(from, frame.Message)
+	}
+}
+
+func (s *stream) sign(challenge []byte, from string) []byte {
+	mac := hmac.New(sha256.New, s.secretKey)
+	mac.Write(challenge)
+	mac.Write([]byte(from))
+	return mac.Sum(nil)
+}
+
+// accept authenticates the node that dialed the stream, returning its id.
+func (s *stream) accept(conn net.Conn, reader io.Reader) (string, error) {
+	conn.SetDeadline(time.Now().Add(STREAM_HANDSHAKE_TIMEOUT))
+	defer conn.SetDeadline(time.Time{})
+
+	challenge := make([]byte, STREAM_CHALLENGE_SIZE)
+	if _, err := rand.Read(challenge); err != nil {
+		return "", err
+	}
+
+	if err := writeFrame(conn, challenge); err != nil {
+		return "", err
+	}
+
+	data, err := readFrame(reader)
+	if err != nil {
+		return "", err
+	}
+
+	var hello streamHello
+	if err := json.Unmarshal(data, &hello); err != nil {
+		return "", fmt.Errorf("invalid handshake: %v", err)
+	}
+
+	if !hmac.Equal(hello.Signature, s.sign(challenge, hello.From)) {
+		return "", fmt.Errorf("invalid signature for node %v", hello.From)
+	}
+
+	if !s.authorize(hello.From, conn.RemoteAddr()) {
+		return "", fmt.Errorf("node %v isn't a member of the cluster at this address", hello.From)
+	}
+
+	if err := writeFrame(conn, streamHandshakeAccepted); err != nil {
+		return "", err
+	}
+
+	return hello.From, nil
+}
+
+// dial connects to the stream of the given address and authenticates this node to it.
+func (s *stream) dial(addr string) (net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", addr, STREAM_DIAL_TIMEOUT)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.hello(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *stream) hello(conn net.Conn) error {
+	conn.SetDeadline(time.Now().Add(STREAM_HANDSHAKE_TIMEOUT))
+	defer conn.SetDeadline(time.Time{})
+
+	challenge, err := readFrame(conn)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(&streamHello{From: s.localId, Signature: s.sign(challenge, s.localId)})
+	if err != nil {
+		return err
+	}
+
+	if err := writeFrame(conn, data); err != nil {
+		return err
+	}
+
+	// Wait for the node to accept the stream, so that the messages aren't lost if it doesn't know this node yet.
+	response, err := readFrame(conn)
+	if err != nil {
+		return fmt.Errorf("stream not accepted: %v", err)
+	}
+	if !bytes.Equal(response, streamHandshakeAccepted) {
+		return fmt.Errorf("unexpected handshake response")
+	}
+
+	return nil
+}
+
+// AddPeer starts streaming to the given node, replacing the previous stream to it if its address changed.
+func (s *stream) AddPeer(id, addr string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	if peer, ok := s.peers[id]; ok {
+		if peer.addr == addr {
+			return
+		}
+		go peer.close()
+	}
+
+	peer := &streamPeer{
+		stream:  s,
+		id:      id,
+		addr:    addr,
+		queue:   make(chan []byte, STREAM_QUEUE_SIZE),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	s.peers[id] = peer
+
+	go peer.run()
+}
+
+// RemovePeer stops streaming to the given node, dropping the messages that haven't been sent to it yet.
+func (s *stream) RemovePeer(id string) {
+	s.mutex.Lock()
+	peer, ok := s.peers[id]
+	delete(s.peers, id)
+	s.mutex.Unlock()
+
+	if ok {
+		peer.close()
+	}
+}
+
+// PeerIds returns the ids of the nodes the stream is currently sending to.
+func (s *stream) PeerIds() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ids := make([]string, 0, len(s.peers))
+	for id := range s.peers {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// Send queues a message for the given node. Best effort messages are dropped if the queue of the node is full, while
+// reliable ones wait for room in the queue for a while.
+func (s *stream) Send(id string, msg *model.ClusterMessage) {
+	s.mutex.Lock()
+	peer, ok := s.peers[id]
+	s.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	data, err := json.Marshal(&streamFrame{Message: msg})
+	if err != nil {
+		mlog.Error("Failed to encode cluster message", mlog.String("event", msg.Event), mlog.Err(err))
+		return
+	}
+
+	peer.send(data, msg.SendType == model.CLUSTER_SEND_RELIABLE)
+}
+
+// Broadcast queues a message for all the nodes.
+func (s *stream) Broadcast(msg *model.ClusterMessage) {
+	for _, id := range s.PeerIds() {
+		s.Send(id, msg)
+	}
+}
+
+// Close stops listening and closes the streams to and from the other nodes.
+func (s *stream) Close() {
+	s.mutex.Lock()
+	s.closed = true
+	peers := s.peers
+	s.peers = make(map[string]*streamPeer)
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mutex.Unlock()
+
+	if s.listener != nil {
+		s.listener.Close()
+	}
+
+	for _, peer := range peers {
+		peer.close()
+	}
+
+	s.readers.Wait()
+}
+
+func (p *streamPeer) send(data []byte, reliable bool) {
+	if !reliable {
+		select {
+		case p.queue <- data:
+		case <-p.stop:
+		default:
+			mlog.Warn("Cluster stream queue is full, dropping best effort message", mlog.String("node_id", p.id))
+		}
+		return
+	}
+
+	timer := time.NewTimer(STREAM_RELIABLE_SEND_TIMEOUT)
+	defer timer.Stop()
+
+	select {
+	case p.queue <- data:
+	case <-p.stop:
+	case <-timer.C:
+		mlog.Error("Timed out queueing reliable cluster message", mlog.String("node_id", p.id))
+	}
+}
+
+func (p *streamPeer) close() {
+	close(p.stop)
+	<-p.stopped
+}
+
+func (p *streamPeer) run() {
+	var conn net.Conn
+
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+		close(p.stopped)
+	}()
+
+	for {
+		var data []byte
+		select {
+		case data = <-p.queue:
+		case <-p.stop:
+			return
+		}
+
+		// Retry the frame until it's written, reconnecting as needed, since the node is still a member of the
+		// cluster. The stream is stopped once the node is detected to have left.
+		for {
+			if conn == nil {
+				var err error
+				if conn, err = p.stream.dial(p.addr); err != nil {
+					conn = nil
+					mlog.Debug("Failed to connect cluster stream", mlog.String("node_id", p.id), mlog.String("addr", p.addr), mlog.Err(err))
+
+					select {
+					case <-time.After(STREAM_RECONNECT_DELAY):
+						continue
+					case <-p.stop:
+						return
+					}
+				}
+			}
+
+			conn.SetWriteDeadline(time.Now().Add(STREAM_WRITE_TIMEOUT))
+			if err := writeFrame(conn, data); err != nil {
+				mlog.Debug("Failed to write to cluster stream", mlog.String("node_id", p.id), mlog.String("addr", p.addr), mlog.Err(err))
+				conn.Close()
+				conn = nil
+				continue
+			}
+
+			break
+		}
+	}
+}
+
+func writeFrame(w io.Writer, data []byte) error {
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(data)))
+
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size > STREAM_MAX_FRAME_SIZE {
+		return nil, fmt.Errorf("frame of %d bytes exceeds the maximum size", size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
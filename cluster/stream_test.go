@@ -0,0 +1,87 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package cluster
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestStreamAuthentication(t *testing.T) {
+	secretKey := []byte("secret")
+	received := make(chan string, 10)
+
+	authorize := func(from string, remoteAddr net.Addr) bool {
+		return from == "member" && remoteAddr.(*net.TCPAddr).IP.IsLoopback()
+	}
+
+	receiver := newStream("receiver", secretKey, authorize, func(from string, msg *model.ClusterMessage) {
+		received <- from + ":" + msg.Data
+	})
+	require.NoError(t, receiver.Listen("127.0.0.1:0"))
+	defer receiver.Close()
+
+	addr := receiver.Addr().String()
+
+	t.Run("member with the secret key", func(t *testing.T) {
+		sender := newStream("member", secretKey, authorize, nil)
+		conn, err := sender.dial(addr)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		data, err := json.Marshal(&streamFrame{Message: &model.ClusterMessage{Data: "hello"}})
+		require.NoError(t, err)
+		require.NoError(t, writeFrame(conn, data))
+
+		select {
+		case msg := <-received:
+			assert.Equal(t, "member:hello", msg)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "message wasn't delivered")
+		}
+	})
+
+	t.Run("member without the secret key", func(t *testing.T) {
+		sender := newStream("member", []byte("other secret"), authorize, nil)
+		_, err := sender.dial(addr)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown node with the secret key", func(t *testing.T) {
+		sender := newStream("unknown", secretKey, authorize, nil)
+		_, err := sender.dial(addr)
+		assert.Error(t, err)
+	})
+
+	t.Run("frames without a handshake", func(t *testing.T) {
+		conn, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		data, err := json.Marshal(&streamFrame{Message: &model.ClusterMessage{Data: "forged"}})
+		require.NoError(t, err)
+		require.NoError(t, writeFrame(conn, data))
+
+		// The frame is read as the response to the challenge and the stream is closed.
+		reader := bufio.NewReader(conn)
+		_, err = readFrame(reader)
+		require.NoError(t, err)
+		_, err = readFrame(reader)
+		assert.Error(t, err)
+
+		select {
+		case msg := <-received:
+			assert.Fail(t, "unexpected message delivered", msg)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
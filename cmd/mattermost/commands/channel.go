@@ -117,6 +117,19 @@ Channel can be specified by [team]:[channel]. ie. myteam:mychannel or by channel
 	RunE:    modifyChannelCmdF,
 }
 
+var ChannelWelcomeCmd = &cobra.Command{
+	Use:   "welcome [channel] --message [message]",
+	Short: "Set a channel's welcome message",
+	Long: `Set the message sent to users when they're added to a channel, as an ephemeral post in the channel or as a direct message.
+{{user}} and {{channel}} in the message are replaced with the user's mention and the channel's display name.
+Channel can be specified by [team]:[channel]. ie. myteam:mychannel or by channel ID.`,
+	Example: `  channel welcome myteam:mychannel --message "Welcome to {{channel}}, {{user}}!"
+  channel welcome myteam:mychannel --message "Read the pinned posts first." --direct
+  channel welcome myteam:mychannel --clear`,
+	Args: cobra.ExactArgs(1),
+	RunE: channelWelcomeCmdF,
+}
+
 var SearchChannelCmd = &cobra.Command{
 	Use:   "search [channel]\n  mattermost search --team [team] [channel]",
 	Short: "Search a channel",
@@ -146,6 +159,11 @@ func init() {
 	ModifyChannelCmd.Flags().String("username", "", "Required. Username who changes the channel privacy.")
 
 	ChannelRenameCmd.Flags().String("display_name", "", "Channel Display Name")
+
+	ChannelWelcomeCmd.Flags().String("message", "", "The welcome message")
+	ChannelWelcomeCmd.Flags().Bool("direct", false, "Send the welcome message as a direct message instead of an ephemeral post")
+	ChannelWelcomeCmd.Flags().Bool("clear", false, "Remove the welcome message")
+
 	SearchChannelCmd.Flags().String("team", "", "Team name or ID")
 
 	RemoveChannelUsersCmd.Flags().Bool("all-users", false, "Remove all users from the indicated channel.")
@@ -161,6 +179,7 @@ func init() {
 		RestoreChannelsCmd,
 		ModifyChannelCmd,
 		ChannelRenameCmd,
+		ChannelWelcomeCmd,
 		SearchChannelCmd,
 	)
 
@@ -553,6 +572,42 @@ func renameChannelCmdF(command *cobra.Command, args []string) error {
 	return nil
 }
 
+func channelWelcomeCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	message, _ := command.Flags().GetString("message")
+	direct, _ := command.Flags().GetBool("direct")
+	clearMessage, _ := command.Flags().GetBool("clear")
+
+	if clearMessage == (message != "") {
+		return errors.New("You must specify only one of --message or --clear")
+	}
+
+	channel := getChannelFromChannelArg(a, args[0])
+	if channel == nil {
+		return errors.New("Unable to find channel '" + args[0] + "'")
+	}
+
+	messageType := ""
+	if message != "" {
+		messageType = model.CHANNEL_WELCOME_MESSAGE_TYPE_EPHEMERAL
+		if direct {
+			messageType = model.CHANNEL_WELCOME_MESSAGE_TYPE_DIRECT
+		}
+	}
+
+	patch := &model.ChannelPatch{WelcomeMessage: &message, WelcomeMessageType: &messageType}
+	if _, err := a.PatchChannel(channel, patch, ""); err != nil {
+		return errors.Wrapf(err, "Failed to update the welcome message of channel '%s'", args[0])
+	}
+
+	return nil
+}
+
 func searchChannelCmdF(command *cobra.Command, args []string) error {
 
 	a, err := InitDBCommandContextCobra(command)
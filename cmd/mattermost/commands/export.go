@@ -5,6 +5,8 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"time"
 
@@ -60,6 +62,25 @@ var BulkExportCmd = &cobra.Command{
 	Args:    cobra.ExactArgs(1),
 }
 
+var IntegrationsExportCmd = &cobra.Command{
+	Use:     "integrations [file]",
+	Short:   "Export integrations.",
+	Long:    "Export the webhooks, slash commands, OAuth apps and bots to a file that can be imported into another Mattermost instance. Tokens and secrets are replaced with placeholders.",
+	Example: "export integrations integrations.json",
+	RunE:    integrationsExportCmdF,
+	Args:    cobra.ExactArgs(1),
+}
+
+var ChannelExportCmd = &cobra.Command{
+	Use:   "channel [team]:[channel]",
+	Short: "Export the posts of a channel.",
+	Long:  "Export the posts of a channel, with their authors, timestamps, reactions and file links, as CSV or JSON for ad hoc audits. Without --output, the export is written to the standard output.",
+	Example: `  export channel myteam:mychannel --format csv --output mychannel.csv
+  export channel myteam:mychannel --format json --since 1571220000000 --until 1571824800000 --gzip --output mychannel.json.gz`,
+	RunE: channelExportCmdF,
+	Args: cobra.ExactArgs(1),
+}
+
 func init() {
 	ScheduleExportCmd.Flags().String("format", "actiance", "The format to export data")
 	ScheduleExportCmd.Flags().Int64("exportFrom", -1, "The timestamp of the earliest post to export, expressed in seconds since the unix epoch.")
@@ -72,11 +93,19 @@ func init() {
 
 	BulkExportCmd.Flags().Bool("all-teams", true, "Export all teams from the server.")
 
+	ChannelExportCmd.Flags().String("format", model.CHANNEL_POST_EXPORT_FORMAT_CSV, "The format to export the posts in, csv or json.")
+	ChannelExportCmd.Flags().Int64("since", 0, "The timestamp of the earliest post to export, expressed in milliseconds since the unix epoch.")
+	ChannelExportCmd.Flags().Int64("until", 0, "The timestamp of the latest post to export, expressed in milliseconds since the unix epoch.")
+	ChannelExportCmd.Flags().Bool("gzip", false, "Compress the export with gzip.")
+	ChannelExportCmd.Flags().String("output", "", "The file to write the export to.")
+
 	ExportCmd.AddCommand(ScheduleExportCmd)
 	ExportCmd.AddCommand(CsvExportCmd)
 	ExportCmd.AddCommand(ActianceExportCmd)
 	ExportCmd.AddCommand(GlobalRelayZipExportCmd)
 	ExportCmd.AddCommand(BulkExportCmd)
+	ExportCmd.AddCommand(IntegrationsExportCmd)
+	ExportCmd.AddCommand(ChannelExportCmd)
 
 	RootCmd.AddCommand(ExportCmd)
 }
@@ -201,3 +230,69 @@ func bulkExportCmdF(command *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func integrationsExportCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	export, appErr := a.ExportIntegrations()
+	if appErr != nil {
+		return appErr
+	}
+
+	if err := ioutil.WriteFile(args[0], []byte(export.ToJson()), 0600); err != nil {
+		return err
+	}
+
+	CommandPrettyPrintln(fmt.Sprintf("Exported %v incoming webhooks, %v outgoing webhooks, %v commands, %v OAuth apps and %v bots.",
+		len(export.IncomingWebhooks), len(export.OutgoingWebhooks), len(export.Commands), len(export.OAuthApps), len(export.Bots)))
+
+	return nil
+}
+
+func channelExportCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	channel := getChannelFromChannelArg(a, args[0])
+	if channel == nil {
+		return errors.New("Unable to find channel '" + args[0] + "'")
+	}
+
+	options := &model.ChannelPostExportOptions{}
+	options.Format, _ = command.Flags().GetString("format")
+	options.Since, _ = command.Flags().GetInt64("since")
+	options.Until, _ = command.Flags().GetInt64("until")
+	options.Gzip, _ = command.Flags().GetBool("gzip")
+	if appErr := options.IsValid(); appErr != nil {
+		return errors.Wrap(appErr, "invalid export options")
+	}
+
+	output, _ := command.Flags().GetString("output")
+	if output == "" {
+		if appErr := a.ExportChannelPosts(channel, options, os.Stdout); appErr != nil {
+			return errors.Wrap(appErr, "unable to export the posts of the channel")
+		}
+		return nil
+	}
+
+	fileWriter, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer fileWriter.Close()
+
+	if appErr := a.ExportChannelPosts(channel, options, fileWriter); appErr != nil {
+		return errors.Wrap(appErr, "unable to export the posts of the channel")
+	}
+
+	CommandPrettyPrintln("Exported the posts of channel " + channel.Name + " to " + output)
+
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost-server/model"
 )
 
 var ImportCmd = &cobra.Command{
@@ -33,7 +35,18 @@ var BulkImportCmd = &cobra.Command{
 	RunE:    bulkImportCmdF,
 }
 
+var IntegrationsImportCmd = &cobra.Command{
+	Use:     "integrations [file]",
+	Short:   "Import integrations.",
+	Long:    "Import the webhooks, slash commands, OAuth apps and bots exported from another Mattermost instance. Integrations whose creator doesn't exist are attributed to the given user.",
+	Example: "  import integrations integrations.json --user admin",
+	RunE:    integrationsImportCmdF,
+	Args:    cobra.ExactArgs(1),
+}
+
 func init() {
+	IntegrationsImportCmd.Flags().String("user", "", "Username, email or ID of the user owning integrations whose creator doesn't exist (required)")
+
 	BulkImportCmd.Flags().Bool("apply", false, "Save the import data to the database. Use with caution - this cannot be reverted.")
 	BulkImportCmd.Flags().Bool("validate", false, "Validate the import data without making any changes to the system.")
 	BulkImportCmd.Flags().Int("workers", 2, "How many workers to run whilst doing the import.")
@@ -41,6 +54,7 @@ func init() {
 	ImportCmd.AddCommand(
 		BulkImportCmd,
 		SlackImportCmd,
+		IntegrationsImportCmd,
 	)
 	RootCmd.AddCommand(ImportCmd)
 }
@@ -153,3 +167,45 @@ func bulkImportCmdF(command *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func integrationsImportCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	userArg, _ := command.Flags().GetString("user")
+	if userArg == "" {
+		return errors.New("User is required")
+	}
+	user := getUserFromUserArg(a, userArg)
+	if user == nil {
+		return errors.New("Unable to find user '" + userArg + "'")
+	}
+
+	fileReader, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer fileReader.Close()
+
+	export := model.IntegrationsExportFromJson(fileReader)
+	if export == nil {
+		return errors.New("Unable to parse the integrations file")
+	}
+
+	result, appErr := a.ImportIntegrations(export, user.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, importErr := range result.Errors {
+		CommandPrintErrorln(importErr)
+	}
+
+	CommandPrettyPrintln(fmt.Sprintf("Imported %v incoming webhooks, %v outgoing webhooks, %v commands, %v OAuth apps and %v bots.",
+		result.IncomingWebhooks, result.OutgoingWebhooks, result.Commands, result.OAuthApps, result.Bots))
+
+	return nil
+}
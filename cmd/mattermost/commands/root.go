@@ -24,10 +24,13 @@ var RootCmd = &cobra.Command{
 func init() {
 	RootCmd.PersistentFlags().StringP("config", "c", "config.json", "Configuration file to use.")
 	RootCmd.PersistentFlags().Bool("disableconfigwatch", false, "When set config.json will not be loaded from disk when the file is changed.")
+	RootCmd.PersistentFlags().String("bootstrapconfig", "", "Configuration file to initialize an empty configuration database from, when --config is a database connection string.")
 	RootCmd.PersistentFlags().Bool("platform", false, "This flag signifies that the user tried to start the command from the platform binary, so we can log a mssage")
 	RootCmd.PersistentFlags().MarkHidden("platform")
 
 	viper.SetEnvPrefix("mm")
 	viper.BindEnv("config")
 	viper.BindPFlag("config", RootCmd.PersistentFlags().Lookup("config"))
+	viper.BindEnv("bootstrapconfig", "MM_BOOTSTRAP_CONFIG")
+	viper.BindPFlag("bootstrapconfig", RootCmd.PersistentFlags().Lookup("bootstrapconfig"))
 }
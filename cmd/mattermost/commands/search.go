@@ -0,0 +1,124 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var SearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search index related utilities",
+}
+
+var SearchReindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rebuild the search indexes",
+	Long: `Rebuild the search indexes of the posts, channels and users in batches, without purging the existing indexes first unless asked to.
+The rebuild can be interrupted and resumed from the creation time of the last indexed post with --from.`,
+	Example: `  search reindex
+  search reindex --batch-size 1000 --delay 200
+  search reindex --from 1571220000000
+  search reindex --job`,
+	RunE: searchReindexCmdF,
+}
+
+func init() {
+	SearchReindexCmd.Flags().Int("batch-size", model.SEARCH_REINDEX_DEFAULT_BATCH_SIZE, "Number of objects indexed in each batch.")
+	SearchReindexCmd.Flags().Int("delay", 0, "Milliseconds to wait between batches, to limit the load on the database and the search engine.")
+	SearchReindexCmd.Flags().Int64("from", 0, "Creation time in milliseconds of the post to resume indexing from.")
+	SearchReindexCmd.Flags().Bool("purge", false, "Purge the search indexes before rebuilding them.")
+	SearchReindexCmd.Flags().Bool("job", false, "Create a job to rebuild the indexes in the background instead of rebuilding them now.")
+
+	SearchCmd.AddCommand(
+		SearchReindexCmd,
+	)
+	RootCmd.AddCommand(SearchCmd)
+}
+
+func searchReindexCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	batchSize, _ := command.Flags().GetInt("batch-size")
+	delay, _ := command.Flags().GetInt("delay")
+	from, _ := command.Flags().GetInt64("from")
+	purge, _ := command.Flags().GetBool("purge")
+	asJob, _ := command.Flags().GetBool("job")
+
+	if purge && from != 0 {
+		return errors.New("--purge can't be used when resuming with --from")
+	}
+
+	progress := model.NewSearchReindexProgress(from, model.GetMillis(), batchSize, delay)
+	if appErr := progress.IsValid(); appErr != nil {
+		return errors.Wrap(appErr, "invalid reindex options")
+	}
+
+	if appErr := a.CheckSearchReindexAvailable(); appErr != nil {
+		return errors.Wrap(appErr, "unable to rebuild the search indexes")
+	}
+
+	if purge {
+		if appErr := a.PurgeElasticsearchIndexes(); appErr != nil {
+			return errors.Wrap(appErr, "unable to purge the search indexes")
+		}
+		CommandPrettyPrintln("Purged the search indexes")
+	}
+
+	if asJob {
+		job, appErr := a.Srv.Jobs.CreateJob(model.JOB_TYPE_SEARCH_REINDEX, progress.ToJobData())
+		if appErr != nil {
+			return errors.Wrap(appErr, "unable to create the search reindex job")
+		}
+		CommandPrettyPrintln("Created search reindex job " + job.Id)
+		return nil
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupted)
+
+	for !progress.IsDone() {
+		select {
+		case <-interrupted:
+			CommandPrintErrorln(searchReindexResumeHint(progress))
+			return errors.New("search reindex interrupted")
+		case <-time.After(time.Duration(progress.BatchDelay) * time.Millisecond):
+		}
+
+		if appErr := a.ReindexSearchBatch(progress); appErr != nil {
+			CommandPrintErrorln(searchReindexResumeHint(progress))
+			return errors.Wrap(appErr, "unable to rebuild the search indexes")
+		}
+
+		line := fmt.Sprintf("%3d%% Indexed %d posts, %d channels and %d users", progress.Percent(), progress.PostsIndexed, progress.ChannelsIndexed, progress.UsersIndexed)
+		if progress.Stage == model.SEARCH_REINDEX_STAGE_POSTS {
+			line += fmt.Sprintf(", up to the posts created at %d", progress.LastCreateAt)
+		}
+		CommandPrettyPrintln(line)
+	}
+
+	CommandPrettyPrintln("Rebuilt the search indexes")
+
+	return nil
+}
+
+func searchReindexResumeHint(progress *model.SearchReindexProgress) string {
+	if progress.Stage != model.SEARCH_REINDEX_STAGE_POSTS {
+		return fmt.Sprintf("The posts were all indexed. Run the command again with --from %d to index the channels and users.", progress.EndCreateAt)
+	}
+	return fmt.Sprintf("Run the command again with --from %d to resume indexing.", progress.LastCreateAt)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/mattermost/mattermost-server/config"
 	"github.com/mattermost/mattermost-server/manualtesting"
 	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/utils"
 	"github.com/mattermost/mattermost-server/web"
 	"github.com/mattermost/mattermost-server/wsapi"
@@ -36,6 +37,7 @@ func init() {
 
 func serverCmdF(command *cobra.Command, args []string) error {
 	configDSN := viper.GetString("config")
+	bootstrapConfig := viper.GetString("bootstrapconfig")
 
 	disableConfigWatch, _ := command.Flags().GetBool("disableconfigwatch")
 	usedPlatform, _ := command.Flags().GetBool("platform")
@@ -45,7 +47,7 @@ func serverCmdF(command *cobra.Command, args []string) error {
 	if err := utils.TranslationsPreInit(); err != nil {
 		return errors.Wrapf(err, "unable to load Mattermost translation files")
 	}
-	configStore, err := config.NewStore(configDSN, !disableConfigWatch)
+	configStore, err := config.NewStoreWithBootstrap(configDSN, !disableConfigWatch, bootstrapConfig)
 	if err != nil {
 		return err
 	}
@@ -78,13 +80,19 @@ func runServer(configStore config.Store, disableConfigWatch bool, usedPlatform b
 		return serverErr
 	}
 
-	api := api4.Init(server, server.AppOptions, server.Router)
-	wsapi.Init(server.FakeApp(), server.WebSocketRouter)
-	web.New(server, server.AppOptions, server.Router)
-
-	// If we allow testing then listen for manual testing URL hits
-	if *server.Config().ServiceSettings.EnableTesting {
-		manualtesting.Init(api)
+	if *server.Config().ClusterSettings.WebSocketMode == model.CLUSTER_WEBSOCKET_MODE_GATEWAY {
+		mlog.Info("Running as a websocket gateway, only the websocket and ping endpoints are served.")
+		api4.InitWebSocketGateway(server, server.AppOptions, server.Router)
+		wsapi.Init(server.FakeApp(), server.WebSocketRouter)
+	} else {
+		api := api4.Init(server, server.AppOptions, server.Router)
+		wsapi.Init(server.FakeApp(), server.WebSocketRouter)
+		web.New(server, server.AppOptions, server.Router)
+
+		// If we allow testing then listen for manual testing URL hits
+		if *server.Config().ServiceSettings.EnableTesting {
+			manualtesting.Init(api)
+		}
 	}
 
 	notifyReady()
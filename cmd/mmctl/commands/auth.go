@@ -0,0 +1,128 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"os"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var AuthCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage the credentials used to reach the server",
+}
+
+var AuthLoginCmd = &cobra.Command{
+	Use:   "login [instance url]",
+	Short: "Log in to a server",
+	Long:  "Log in to a server with a username and password, or with a personal access token, and save the session for the other commands. The password can also be given in the MMCTL_PASSWORD environment variable.",
+	Example: `  auth login https://mattermost.example.com --username admin --password secret
+  auth login https://mattermost.example.com --access-token sb5kbugbk3gtbnbbj4dmpxuyuh`,
+	Args: cobra.ExactArgs(1),
+	RunE: authLoginCmdF,
+}
+
+var AuthLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Log out of the server",
+	Long:  "Revoke the saved session, unless it is a personal access token, and remove the saved credentials.",
+	Args:  cobra.NoArgs,
+	RunE:  authLogoutCmdF,
+}
+
+var AuthCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show the server and user of the saved credentials",
+	Args:  cobra.NoArgs,
+	RunE:  authCurrentCmdF,
+}
+
+func init() {
+	AuthLoginCmd.Flags().StringP("username", "u", "", "Username or email to log in with.")
+	AuthLoginCmd.Flags().StringP("password", "p", "", "Password to log in with.")
+	AuthLoginCmd.Flags().StringP("access-token", "t", "", "Personal access token to use instead of a username and password.")
+
+	AuthCmd.AddCommand(
+		AuthLoginCmd,
+		AuthLogoutCmd,
+		AuthCurrentCmd,
+	)
+	RootCmd.AddCommand(AuthCmd)
+}
+
+func authLoginCmdF(command *cobra.Command, args []string) error {
+	url := strings.TrimRight(args[0], "/")
+	username, _ := command.Flags().GetString("username")
+	password, _ := command.Flags().GetString("password")
+	accessToken, _ := command.Flags().GetString("access-token")
+
+	if password == "" {
+		password = os.Getenv("MMCTL_PASSWORD")
+	}
+
+	c := model.NewAPIv4Client(url)
+
+	var user *model.User
+	var response *model.Response
+	switch {
+	case accessToken != "":
+		c.SetToken(accessToken)
+		user, response = c.GetMe("")
+	case username != "" && password != "":
+		user, response = c.Login(username, password)
+	default:
+		return errors.New("either --access-token or --username and --password are required")
+	}
+	if err := responseError(response, "unable to log in"); err != nil {
+		return err
+	}
+
+	credentials := &Credentials{
+		InstanceUrl: url,
+		Username:    user.Username,
+		AuthToken:   c.AuthToken,
+	}
+	if err := SaveCredentials(credentials); err != nil {
+		return err
+	}
+
+	CommandPrettyPrintln("Logged in to " + url + " as " + user.Username)
+	return nil
+}
+
+func authLogoutCmdF(command *cobra.Command, args []string) error {
+	credentials, err := ReadCredentials()
+	if err != nil {
+		return err
+	}
+
+	c := model.NewAPIv4Client(credentials.InstanceUrl)
+	c.SetToken(credentials.AuthToken)
+
+	// Logging out of a personal access token would fail, and the token stays valid until it is revoked.
+	if _, response := c.Logout(); response.Error != nil {
+		CommandPrintErrorln("Unable to revoke the session: " + response.Error.Error())
+	}
+
+	if err := RemoveCredentials(); err != nil {
+		return err
+	}
+
+	CommandPrettyPrintln("Logged out of " + credentials.InstanceUrl)
+	return nil
+}
+
+func authCurrentCmdF(command *cobra.Command, args []string) error {
+	credentials, err := ReadCredentials()
+	if err != nil {
+		return err
+	}
+
+	CommandPrettyPrintln("Logged in to " + credentials.InstanceUrl + " as " + credentials.Username)
+	return nil
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var ChannelCmd = &cobra.Command{
+	Use:   "channel",
+	Short: "Management of channels",
+}
+
+var ChannelCreateCmd = &cobra.Command{
+	Use:     "create",
+	Short:   "Create a channel",
+	Example: "  channel create --team myteam --name mynewchannel --display-name \"My New Channel\" --private",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(channelCreateCmdF),
+}
+
+var ChannelListCmd = &cobra.Command{
+	Use:     "list [teams]",
+	Short:   "List the public channels of teams",
+	Example: "  channel list myteam",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(channelListCmdF),
+}
+
+var ChannelArchiveCmd = &cobra.Command{
+	Use:     "archive [channels]",
+	Short:   "Archive channels",
+	Long:    "Archive channels, given as team:channel.",
+	Example: "  channel archive myteam:mychannel",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(channelArchiveCmdF),
+}
+
+var ChannelAddCmd = &cobra.Command{
+	Use:     "add [channel] [users]",
+	Short:   "Add users to a channel",
+	Example: "  channel add myteam:mychannel user@example.com username",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(channelAddCmdF),
+}
+
+var ChannelRemoveCmd = &cobra.Command{
+	Use:     "remove [channel] [users]",
+	Short:   "Remove users from a channel",
+	Example: "  channel remove myteam:mychannel user@example.com username",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(channelRemoveCmdF),
+}
+
+func init() {
+	ChannelCreateCmd.Flags().String("team", "", "Name of the team to create the channel in.")
+	ChannelCreateCmd.Flags().String("name", "", "Name of the channel.")
+	ChannelCreateCmd.Flags().String("display-name", "", "Display name of the channel.")
+	ChannelCreateCmd.Flags().String("purpose", "", "Purpose of the channel.")
+	ChannelCreateCmd.Flags().String("header", "", "Header of the channel.")
+	ChannelCreateCmd.Flags().Bool("private", false, "Create a private channel.")
+
+	ChannelCmd.AddCommand(
+		ChannelCreateCmd,
+		ChannelListCmd,
+		ChannelArchiveCmd,
+		ChannelAddCmd,
+		ChannelRemoveCmd,
+	)
+	RootCmd.AddCommand(ChannelCmd)
+}
+
+func channelCreateCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	teamName, _ := command.Flags().GetString("team")
+	name, _ := command.Flags().GetString("name")
+	displayName, _ := command.Flags().GetString("display-name")
+	purpose, _ := command.Flags().GetString("purpose")
+	header, _ := command.Flags().GetString("header")
+	private, _ := command.Flags().GetBool("private")
+
+	if teamName == "" || name == "" || displayName == "" {
+		return errors.New("--team, --name and --display-name are required")
+	}
+
+	team, response := c.GetTeamByName(teamName, "")
+	if err := responseError(response, "unable to find team '"+teamName+"'"); err != nil {
+		return err
+	}
+
+	channel := &model.Channel{
+		TeamId:      team.Id,
+		Name:        name,
+		DisplayName: displayName,
+		Purpose:     purpose,
+		Header:      header,
+		Type:        model.CHANNEL_OPEN,
+	}
+	if private {
+		channel.Type = model.CHANNEL_PRIVATE
+	}
+
+	rchannel, response := c.CreateChannel(channel)
+	if err := responseError(response, "unable to create the channel"); err != nil {
+		return err
+	}
+
+	CommandPrettyPrintln("Created channel " + rchannel.Name + " (" + rchannel.Id + ")")
+	return nil
+}
+
+func channelListCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	for _, teamName := range args {
+		team, response := c.GetTeamByName(teamName, "")
+		if response.Error != nil {
+			CommandPrintErrorln("Unable to find team '" + teamName + "'")
+			continue
+		}
+
+		for page := 0; ; page++ {
+			channels, response := c.GetPublicChannelsForTeam(team.Id, page, 200, "")
+			if err := responseError(response, "unable to list the channels of team '"+teamName+"'"); err != nil {
+				return err
+			}
+
+			for _, channel := range channels {
+				CommandPrettyPrintln(teamName + ":" + channel.Name + " (" + channel.Id + ")")
+			}
+
+			if len(channels) < 200 {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func channelArchiveCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	for _, arg := range args {
+		channel := getChannelFromArg(c, arg)
+		if channel == nil {
+			CommandPrintErrorln("Unable to find channel '" + arg + "'")
+			continue
+		}
+
+		if _, response := c.DeleteChannel(channel.Id); response.Error != nil {
+			CommandPrintErrorln("Unable to archive channel '" + arg + "': " + response.Error.Error())
+			continue
+		}
+
+		CommandPrettyPrintln("Archived channel " + arg)
+	}
+	return nil
+}
+
+func channelAddCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	channel := getChannelFromArg(c, args[0])
+	if channel == nil {
+		return errors.New("Unable to find channel '" + args[0] + "'")
+	}
+
+	for _, arg := range args[1:] {
+		user := getUserFromArg(c, arg)
+		if user == nil {
+			CommandPrintErrorln("Unable to find user '" + arg + "'")
+			continue
+		}
+
+		if _, response := c.AddChannelMember(channel.Id, user.Id); response.Error != nil {
+			CommandPrintErrorln("Unable to add user '" + arg + "' to the channel: " + response.Error.Error())
+			continue
+		}
+
+		CommandPrettyPrintln("Added " + user.Username + " to " + args[0])
+	}
+	return nil
+}
+
+func channelRemoveCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	channel := getChannelFromArg(c, args[0])
+	if channel == nil {
+		return errors.New("Unable to find channel '" + args[0] + "'")
+	}
+
+	for _, arg := range args[1:] {
+		user := getUserFromArg(c, arg)
+		if user == nil {
+			CommandPrintErrorln("Unable to find user '" + arg + "'")
+			continue
+		}
+
+		if _, response := c.RemoveUserFromChannel(channel.Id, user.Id); response.Error != nil {
+			CommandPrintErrorln("Unable to remove user '" + arg + "' from the channel: " + response.Error.Error())
+			continue
+		}
+
+		CommandPrettyPrintln("Removed " + user.Username + " from " + args[0])
+	}
+	return nil
+}
+
+// getChannelFromArg looks up a channel given as team:channel, or by id.
+func getChannelFromArg(c *model.Client4, arg string) *model.Channel {
+	teamName, channelName := "", arg
+	if i := strings.Index(arg, ":"); i >= 0 {
+		teamName, channelName = arg[:i], arg[i+1:]
+	}
+
+	if teamName != "" {
+		if channel, response := c.GetChannelByNameForTeamName(channelName, teamName, ""); response.Error == nil {
+			return channel
+		}
+	}
+
+	if model.IsValidId(channelName) {
+		if channel, response := c.GetChannel(channelName, ""); response.Error == nil {
+			return channel
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CREDENTIALS_FILE_NAME = ".mmctl"
+
+	// The environment variables take precedence over the saved credentials, for scripts and CI.
+	ENV_INSTANCE_URL = "MMCTL_URL"
+	ENV_AUTH_TOKEN   = "MMCTL_TOKEN"
+)
+
+// Credentials identify the server mmctl talks to and the session it uses, as saved by "mmctl auth login".
+type Credentials struct {
+	InstanceUrl string `json:"instance_url"`
+	Username    string `json:"username"`
+	AuthToken   string `json:"auth_token"`
+}
+
+func credentialsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to find the home directory")
+	}
+	return filepath.Join(home, CREDENTIALS_FILE_NAME), nil
+}
+
+func ReadCredentials() (*Credentials, error) {
+	path, err := credentialsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.New("not logged in, run \"mmctl auth login\" first")
+	} else if err != nil {
+		return nil, errors.Wrap(err, "unable to read the credentials")
+	}
+
+	var credentials Credentials
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return nil, errors.Wrap(err, "unable to parse the credentials")
+	}
+
+	return &credentials, nil
+}
+
+// SaveCredentials writes the credentials readable only by the current user, since they hold a session token.
+func SaveCredentials(credentials *Credentials) error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(credentials, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "unable to encode the credentials")
+	}
+
+	return errors.Wrap(ioutil.WriteFile(path, data, 0600), "unable to save the credentials")
+}
+
+func RemoveCredentials() error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "unable to remove the credentials")
+	}
+	return nil
+}
+
+// InitClient returns a client authenticated against the server from the environment or the saved credentials.
+func InitClient() (*model.Client4, error) {
+	url, token := os.Getenv(ENV_INSTANCE_URL), os.Getenv(ENV_AUTH_TOKEN)
+	if url == "" || token == "" {
+		credentials, err := ReadCredentials()
+		if err != nil {
+			return nil, err
+		}
+		url, token = credentials.InstanceUrl, credentials.AuthToken
+	}
+
+	client := model.NewAPIv4Client(strings.TrimRight(url, "/"))
+	client.SetToken(token)
+	return client, nil
+}
+
+// withClient adapts a command function that needs a client into a cobra RunE.
+func withClient(f func(c *model.Client4, command *cobra.Command, args []string) error) func(command *cobra.Command, args []string) error {
+	return func(command *cobra.Command, args []string) error {
+		c, err := InitClient()
+		if err != nil {
+			return err
+		}
+		return f(c, command, args)
+	}
+}
+
+// responseError turns the error of a response, if any, into an error mentioning what was being attempted.
+func responseError(response *model.Response, message string) error {
+	if response.Error == nil {
+		return nil
+	}
+	return errors.Wrap(response.Error, message)
+}
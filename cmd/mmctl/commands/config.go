@@ -0,0 +1,237 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration of the server",
+}
+
+var ConfigGetCmd = &cobra.Command{
+	Use:     "get [setting]",
+	Short:   "Get a configuration setting",
+	Long:    "Get a configuration setting, or a whole section of settings, given its path. Secrets are shown masked.",
+	Example: "  config get SqlSettings.DriverName",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(configGetCmdF),
+}
+
+var ConfigSetCmd = &cobra.Command{
+	Use:   "set [setting] [values]",
+	Short: "Set a configuration setting",
+	Long:  "Set a configuration setting given its path. Settings holding a list take one value per argument.",
+	Example: `  config set SqlSettings.DriverName mysql
+  config set SqlSettings.DataSourceReplicas "replica1" "replica2"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: withClient(configSetCmdF),
+}
+
+var ConfigShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the configuration of the server",
+	Args:  cobra.NoArgs,
+	RunE:  withClient(configShowCmdF),
+}
+
+func init() {
+	ConfigCmd.AddCommand(
+		ConfigGetCmd,
+		ConfigSetCmd,
+		ConfigShowCmd,
+	)
+	RootCmd.AddCommand(ConfigCmd)
+}
+
+func configGetCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	config, response := c.GetConfig()
+	if err := responseError(response, "unable to get the configuration"); err != nil {
+		return err
+	}
+
+	configMap, err := configToMap(config)
+	if err != nil {
+		return err
+	}
+
+	value, err := getConfigValue(configMap, args[0])
+	if err != nil {
+		return err
+	}
+
+	out, err := formatConfigValue(value)
+	if err != nil {
+		return err
+	}
+
+	CommandPrettyPrintln(out)
+	return nil
+}
+
+func configSetCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	config, response := c.GetConfig()
+	if err := responseError(response, "unable to get the configuration"); err != nil {
+		return err
+	}
+
+	configMap, err := configToMap(config)
+	if err != nil {
+		return err
+	}
+
+	if err := setConfigValue(configMap, args[0], args[1:]); err != nil {
+		return err
+	}
+
+	// The masked secrets are sent back as they are, and the server keeps their current values.
+	newConfig, err := configFromMap(configMap)
+	if err != nil {
+		return err
+	}
+
+	if _, response := c.UpdateConfig(newConfig); response.Error != nil {
+		return errors.Wrap(response.Error, "unable to update the configuration")
+	}
+
+	CommandPrettyPrintln("Set " + args[0])
+	return nil
+}
+
+func configShowCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	config, response := c.GetConfig()
+	if err := responseError(response, "unable to get the configuration"); err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(config, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "unable to encode the configuration")
+	}
+
+	CommandPrettyPrintln(string(out))
+	return nil
+}
+
+func configToMap(config *model.Config) (map[string]interface{}, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode the configuration")
+	}
+
+	var configMap map[string]interface{}
+	if err := json.Unmarshal(data, &configMap); err != nil {
+		return nil, errors.Wrap(err, "unable to decode the configuration")
+	}
+	return configMap, nil
+}
+
+func configFromMap(configMap map[string]interface{}) (*model.Config, error) {
+	data, err := json.Marshal(configMap)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode the configuration")
+	}
+
+	var config model.Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "unable to decode the configuration")
+	}
+	return &config, nil
+}
+
+// getConfigValue returns the value at a dotted path such as "ServiceSettings.SiteURL" of the configuration.
+func getConfigValue(configMap map[string]interface{}, path string) (interface{}, error) {
+	var value interface{} = configMap
+	for _, part := range strings.Split(path, ".") {
+		section, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("unable to find setting " + path)
+		}
+
+		if value, ok = section[part]; !ok {
+			return nil, errors.New("unable to find setting " + path)
+		}
+	}
+	return value, nil
+}
+
+// setConfigValue replaces the value at a dotted path of the configuration, parsing the arguments according to the
+// type of the current value. Lists take each argument as an item, and other settings take a single argument.
+func setConfigValue(configMap map[string]interface{}, path string, args []string) error {
+	parts := strings.Split(path, ".")
+
+	section := configMap
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := section[part].(map[string]interface{})
+		if !ok {
+			return errors.New("unable to find setting " + path)
+		}
+		section = next
+	}
+
+	key := parts[len(parts)-1]
+	current, ok := section[key]
+	if !ok {
+		return errors.New("unable to find setting " + path)
+	}
+
+	if _, isList := current.([]interface{}); isList {
+		values := make([]interface{}, len(args))
+		for i, arg := range args {
+			values[i] = arg
+		}
+		section[key] = values
+		return nil
+	}
+
+	if len(args) != 1 {
+		return errors.New("setting " + path + " takes a single value")
+	}
+
+	switch current.(type) {
+	case map[string]interface{}:
+		return errors.New(path + " is a section of settings, set its settings one by one")
+	case bool:
+		value, err := strconv.ParseBool(args[0])
+		if err != nil {
+			return errors.New("setting " + path + " takes true or false")
+		}
+		section[key] = value
+	case float64:
+		value, err := strconv.ParseFloat(args[0], 64)
+		if err != nil {
+			return errors.New("setting " + path + " takes a number")
+		}
+		section[key] = value
+	default:
+		section[key] = args[0]
+	}
+
+	return nil
+}
+
+func formatConfigValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case map[string]interface{}, []interface{}:
+		out, err := json.MarshalIndent(value, "", "    ")
+		if err != nil {
+			return "", errors.Wrap(err, "unable to encode the setting")
+		}
+		return string(out), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case nil:
+		return "", nil
+	}
+	return fmt.Sprint(value), nil
+}
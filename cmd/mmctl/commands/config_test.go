@@ -0,0 +1,66 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestConfigValues(t *testing.T) {
+	config := &model.Config{}
+	config.SetDefaults()
+
+	configMap, err := configToMap(config)
+	require.Nil(t, err)
+
+	t.Run("get", func(t *testing.T) {
+		value, err := getConfigValue(configMap, "SqlSettings.DriverName")
+		require.Nil(t, err)
+		assert.Equal(t, "mysql", value)
+
+		value, err = getConfigValue(configMap, "SqlSettings")
+		require.Nil(t, err)
+		assert.IsType(t, map[string]interface{}{}, value)
+
+		_, err = getConfigValue(configMap, "SqlSettings.Missing")
+		assert.NotNil(t, err)
+
+		_, err = getConfigValue(configMap, "SqlSettings.DriverName.Deeper")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		require.Nil(t, setConfigValue(configMap, "SqlSettings.DriverName", []string{"postgres"}))
+		require.Nil(t, setConfigValue(configMap, "SqlSettings.MaxIdleConns", []string{"42"}))
+		require.Nil(t, setConfigValue(configMap, "SqlSettings.Trace", []string{"true"}))
+		require.Nil(t, setConfigValue(configMap, "SqlSettings.DataSourceReplicas", []string{"replica1", "replica2"}))
+
+		assert.NotNil(t, setConfigValue(configMap, "SqlSettings.MaxIdleConns", []string{"many"}))
+		assert.NotNil(t, setConfigValue(configMap, "SqlSettings.Trace", []string{"true", "false"}))
+		assert.NotNil(t, setConfigValue(configMap, "SqlSettings", []string{"value"}))
+		assert.NotNil(t, setConfigValue(configMap, "Missing.Setting", []string{"value"}))
+
+		newConfig, err := configFromMap(configMap)
+		require.Nil(t, err)
+		assert.Equal(t, "postgres", *newConfig.SqlSettings.DriverName)
+		assert.Equal(t, 42, *newConfig.SqlSettings.MaxIdleConns)
+		assert.True(t, *newConfig.SqlSettings.Trace)
+		assert.Equal(t, []string{"replica1", "replica2"}, newConfig.SqlSettings.DataSourceReplicas)
+	})
+}
+
+func TestFormatConfigValue(t *testing.T) {
+	out, err := formatConfigValue(float64(1000000))
+	require.Nil(t, err)
+	assert.Equal(t, "1000000", out)
+
+	out, err = formatConfigValue([]interface{}{"a", "b"})
+	require.Nil(t, err)
+	assert.Equal(t, "[\n    \"a\",\n    \"b\"\n]", out)
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"os"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/spf13/cobra"
+)
+
+var PluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Management of plugins",
+}
+
+var PluginAddCmd = &cobra.Command{
+	Use:     "add [plugin bundles]",
+	Short:   "Install plugins",
+	Long:    "Upload plugin bundles to the server and install them. The plugins are left disabled.",
+	Example: "  plugin add hovercardexample.tar.gz pluginexample.tar.gz",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(pluginAddCmdF),
+}
+
+var PluginRemoveCmd = &cobra.Command{
+	Use:     "remove [plugin ids]",
+	Short:   "Remove plugins",
+	Example: "  plugin remove hovercardexample pluginexample",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(pluginRemoveCmdF),
+}
+
+var PluginEnableCmd = &cobra.Command{
+	Use:     "enable [plugin ids]",
+	Short:   "Enable plugins",
+	Example: "  plugin enable hovercardexample pluginexample",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(pluginEnableCmdF(true)),
+}
+
+var PluginDisableCmd = &cobra.Command{
+	Use:     "disable [plugin ids]",
+	Short:   "Disable plugins",
+	Example: "  plugin disable hovercardexample pluginexample",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(pluginEnableCmdF(false)),
+}
+
+var PluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the installed plugins",
+	Args:  cobra.NoArgs,
+	RunE:  withClient(pluginListCmdF),
+}
+
+func init() {
+	PluginAddCmd.Flags().Bool("force", false, "Replace the plugins if they are already installed.")
+
+	PluginCmd.AddCommand(
+		PluginAddCmd,
+		PluginRemoveCmd,
+		PluginEnableCmd,
+		PluginDisableCmd,
+		PluginListCmd,
+	)
+	RootCmd.AddCommand(PluginCmd)
+}
+
+func pluginAddCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	force, _ := command.Flags().GetBool("force")
+
+	for _, path := range args {
+		file, err := os.Open(path)
+		if err != nil {
+			CommandPrintErrorln("Unable to open plugin bundle '" + path + "': " + err.Error())
+			continue
+		}
+
+		var manifest *model.Manifest
+		var response *model.Response
+		if force {
+			manifest, response = c.UploadPluginForced(file)
+		} else {
+			manifest, response = c.UploadPlugin(file)
+		}
+		file.Close()
+
+		if response.Error != nil {
+			CommandPrintErrorln("Unable to install plugin bundle '" + path + "': " + response.Error.Error())
+			continue
+		}
+
+		CommandPrettyPrintln("Installed plugin " + manifest.Id + " " + manifest.Version)
+	}
+	return nil
+}
+
+func pluginRemoveCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	for _, id := range args {
+		if _, response := c.RemovePlugin(id); response.Error != nil {
+			CommandPrintErrorln("Unable to remove plugin '" + id + "': " + response.Error.Error())
+			continue
+		}
+		CommandPrettyPrintln("Removed plugin " + id)
+	}
+	return nil
+}
+
+func pluginEnableCmdF(enable bool) func(c *model.Client4, command *cobra.Command, args []string) error {
+	return func(c *model.Client4, command *cobra.Command, args []string) error {
+		for _, id := range args {
+			if enable {
+				if _, response := c.EnablePlugin(id); response.Error != nil {
+					CommandPrintErrorln("Unable to enable plugin '" + id + "': " + response.Error.Error())
+					continue
+				}
+				CommandPrettyPrintln("Enabled plugin " + id)
+			} else {
+				if _, response := c.DisablePlugin(id); response.Error != nil {
+					CommandPrintErrorln("Unable to disable plugin '" + id + "': " + response.Error.Error())
+					continue
+				}
+				CommandPrettyPrintln("Disabled plugin " + id)
+			}
+		}
+		return nil
+	}
+}
+
+func pluginListCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	plugins, response := c.GetPlugins()
+	if err := responseError(response, "unable to list the plugins"); err != nil {
+		return err
+	}
+
+	CommandPrettyPrintln("Enabled:")
+	for _, plugin := range plugins.Active {
+		CommandPrettyPrintln("  " + plugin.Id + " " + plugin.Version)
+	}
+
+	CommandPrettyPrintln("Disabled:")
+	for _, plugin := range plugins.Inactive {
+		CommandPrettyPrintln("  " + plugin.Id + " " + plugin.Version)
+	}
+	return nil
+}
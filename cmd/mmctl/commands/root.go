@@ -0,0 +1,32 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func Run(args []string) error {
+	RootCmd.SetArgs(args)
+	return RootCmd.Execute()
+}
+
+var RootCmd = &cobra.Command{
+	Use:   "mmctl",
+	Short: "Remote administration of a Mattermost server",
+	Long: `mmctl administers a Mattermost server over its REST API, so that users, channels, the configuration and plugins
+can be managed without shell access to the server. Log in with "mmctl auth login" before running the other commands.`,
+	SilenceUsage: true,
+}
+
+func CommandPrettyPrintln(a ...interface{}) {
+	fmt.Fprintln(os.Stdout, a...)
+}
+
+func CommandPrintErrorln(a ...interface{}) {
+	fmt.Fprintln(os.Stderr, a...)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var SystemCmd = &cobra.Command{
+	Use:   "system",
+	Short: "Troubleshooting of the server",
+}
+
+var SystemSupportPacketCmd = &cobra.Command{
+	Use:   "supportpacket",
+	Short: "Download a support packet",
+	Long: `Generate a support packet and save it as a zip file. The packet holds the configuration, the recent logs,
+goroutine and heap profiles, and the status of the jobs and of the cluster. The secrets of the configuration are
+redacted.`,
+	Example: "  system supportpacket --output packet.zip",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(systemSupportPacketCmdF),
+}
+
+func init() {
+	SystemSupportPacketCmd.Flags().StringP("output", "o", "", "The file to save the support packet to. Defaults to a name including the current time.")
+
+	SystemCmd.AddCommand(
+		SystemSupportPacketCmd,
+	)
+	RootCmd.AddCommand(SystemCmd)
+}
+
+func systemSupportPacketCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	output, _ := command.Flags().GetString("output")
+	if output == "" {
+		output = "mattermost_support_packet_" + time.Now().UTC().Format("2006-01-02_15-04") + ".zip"
+	}
+
+	CommandPrettyPrintln("Generating the support packet...")
+	packet, response := c.GetSupportPacket()
+	if err := responseError(response, "unable to generate the support packet"); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(output, packet, 0600); err != nil {
+		return errors.Wrap(err, "unable to save the support packet")
+	}
+
+	CommandPrettyPrintln("Saved the support packet to " + output)
+	return nil
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var UserCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Management of users",
+}
+
+var UserCreateCmd = &cobra.Command{
+	Use:     "create",
+	Short:   "Create a user",
+	Example: "  user create --email user@example.com --username userexample --password Password1",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(userCreateCmdF),
+}
+
+var UserListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List users",
+	Example: "  user list --page 0 --per-page 100",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(userListCmdF),
+}
+
+var UserSearchCmd = &cobra.Command{
+	Use:     "search [users]",
+	Short:   "Look up users by id, username or email",
+	Example: "  user search user1@example.com user2",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(userSearchCmdF),
+}
+
+var UserActivateCmd = &cobra.Command{
+	Use:     "activate [users]",
+	Short:   "Activate users",
+	Example: "  user activate user1@example.com user2",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(userActivateCmdF(true)),
+}
+
+var UserDeactivateCmd = &cobra.Command{
+	Use:     "deactivate [users]",
+	Short:   "Deactivate users",
+	Long:    "Deactivate users, revoking their sessions.",
+	Example: "  user deactivate user1@example.com user2",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(userActivateCmdF(false)),
+}
+
+func init() {
+	UserCreateCmd.Flags().String("email", "", "Email of the user.")
+	UserCreateCmd.Flags().String("username", "", "Username of the user.")
+	UserCreateCmd.Flags().String("password", "", "Password of the user.")
+	UserCreateCmd.Flags().String("firstname", "", "First name of the user.")
+	UserCreateCmd.Flags().String("lastname", "", "Last name of the user.")
+	UserCreateCmd.Flags().Bool("system-admin", false, "Make the user a system administrator.")
+
+	UserListCmd.Flags().Int("page", 0, "Page of users to list.")
+	UserListCmd.Flags().Int("per-page", 200, "Number of users to list per page.")
+
+	UserCmd.AddCommand(
+		UserCreateCmd,
+		UserListCmd,
+		UserSearchCmd,
+		UserActivateCmd,
+		UserDeactivateCmd,
+	)
+	RootCmd.AddCommand(UserCmd)
+}
+
+func userCreateCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	user := &model.User{}
+	user.Email, _ = command.Flags().GetString("email")
+	user.Username, _ = command.Flags().GetString("username")
+	user.Password, _ = command.Flags().GetString("password")
+	user.FirstName, _ = command.Flags().GetString("firstname")
+	user.LastName, _ = command.Flags().GetString("lastname")
+	systemAdmin, _ := command.Flags().GetBool("system-admin")
+
+	if user.Email == "" || user.Username == "" || user.Password == "" {
+		return errors.New("--email, --username and --password are required")
+	}
+
+	ruser, response := c.CreateUser(user)
+	if err := responseError(response, "unable to create the user"); err != nil {
+		return err
+	}
+
+	if systemAdmin {
+		_, response = c.UpdateUserRoles(ruser.Id, model.SYSTEM_USER_ROLE_ID+" "+model.SYSTEM_ADMIN_ROLE_ID)
+		if err := responseError(response, "unable to make the user a system administrator"); err != nil {
+			return err
+		}
+	}
+
+	CommandPrettyPrintln("Created user " + ruser.Username + " (" + ruser.Id + ")")
+	return nil
+}
+
+func userListCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	page, _ := command.Flags().GetInt("page")
+	perPage, _ := command.Flags().GetInt("per-page")
+
+	users, response := c.GetUsers(page, perPage, "")
+	if err := responseError(response, "unable to list the users"); err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		CommandPrettyPrintln(formatUser(user))
+	}
+	return nil
+}
+
+func userSearchCmdF(c *model.Client4, command *cobra.Command, args []string) error {
+	for _, arg := range args {
+		user := getUserFromArg(c, arg)
+		if user == nil {
+			CommandPrintErrorln("Unable to find user '" + arg + "'")
+			continue
+		}
+		CommandPrettyPrintln(formatUser(user))
+	}
+	return nil
+}
+
+func userActivateCmdF(active bool) func(c *model.Client4, command *cobra.Command, args []string) error {
+	return func(c *model.Client4, command *cobra.Command, args []string) error {
+		for _, arg := range args {
+			user := getUserFromArg(c, arg)
+			if user == nil {
+				CommandPrintErrorln("Unable to find user '" + arg + "'")
+				continue
+			}
+
+			if _, response := c.UpdateUserActive(user.Id, active); response.Error != nil {
+				CommandPrintErrorln("Unable to update user '" + arg + "': " + response.Error.Error())
+				continue
+			}
+
+			if active {
+				CommandPrettyPrintln("Activated user " + user.Username)
+			} else {
+				CommandPrettyPrintln("Deactivated user " + user.Username)
+			}
+		}
+		return nil
+	}
+}
+
+// getUserFromArg looks up a user by id, then by username, then by email.
+func getUserFromArg(c *model.Client4, arg string) *model.User {
+	if model.IsValidId(arg) {
+		if user, response := c.GetUser(arg, ""); response.Error == nil {
+			return user
+		}
+	}
+
+	if user, response := c.GetUserByUsername(arg, ""); response.Error == nil {
+		return user
+	}
+
+	if user, response := c.GetUserByEmail(arg, ""); response.Error == nil {
+		return user
+	}
+
+	return nil
+}
+
+func formatUser(user *model.User) string {
+	status := ""
+	if user.DeleteAt != 0 {
+		status = " [deactivated]"
+	}
+	return fmt.Sprintf("%s: %s (%s)%s", user.Id, user.Username, user.Email, status)
+}
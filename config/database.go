@@ -8,6 +8,8 @@ import (
 	"database/sql"
 	"io/ioutil"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
@@ -27,6 +29,10 @@ import (
 // It is imposed by MySQL's default max_allowed_packet value of 4Mb.
 const MaxWriteLength = 4 * 1024 * 1024
 
+// DatabaseWatchInterval is how often a watched database store checks for a configuration saved by another process.
+// Servers in a cluster also reload as soon as another node tells them that it changed the configuration.
+var DatabaseWatchInterval = 10 * time.Second
+
 // DatabaseStore is a config store backed by a database.
 type DatabaseStore struct {
 	commonStore
@@ -34,11 +40,31 @@ type DatabaseStore struct {
 	originalDsn    string
 	driverName     string
 	dataSourceName string
+	bootstrapPath  string
 	db             *sqlx.DB
+
+	activeIdLock sync.Mutex
+	activeId     string
+
+	watch        bool
+	stopWatching chan struct{}
+	watcherDone  chan struct{}
 }
 
 // NewDatabaseStore creates a new instance of a config store backed by the given database.
 func NewDatabaseStore(dsn string) (ds *DatabaseStore, err error) {
+	return newDatabaseStore(dsn, false, "")
+}
+
+// NewDatabaseStoreWithBootstrap creates a new instance of a config store backed by the given database, initializing
+// an empty database from the configuration file at the bootstrap path rather than from the defaults.
+//
+// If watch is true, configurations saved to the database by other processes force a reload.
+func NewDatabaseStoreWithBootstrap(dsn string, watch bool, bootstrapPath string) (ds *DatabaseStore, err error) {
+	return newDatabaseStore(dsn, watch, bootstrapPath)
+}
+
+func newDatabaseStore(dsn string, watch bool, bootstrapPath string) (ds *DatabaseStore, err error) {
 	driverName, dataSourceName, err := parseDSN(dsn)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid DSN")
@@ -53,7 +79,9 @@ func NewDatabaseStore(dsn string) (ds *DatabaseStore, err error) {
 		driverName:     driverName,
 		originalDsn:    dsn,
 		dataSourceName: dataSourceName,
+		bootstrapPath:  bootstrapPath,
 		db:             db,
+		watch:          watch,
 	}
 	if err = initializeConfigurationsTable(ds.db); err != nil {
 		return nil, errors.Wrap(err, "failed to initialize")
@@ -63,6 +91,10 @@ func NewDatabaseStore(dsn string) (ds *DatabaseStore, err error) {
 		return nil, errors.Wrap(err, "failed to load")
 	}
 
+	if ds.watch {
+		ds.startWatcher()
+	}
+
 	return ds, nil
 }
 
@@ -213,20 +245,37 @@ func (ds *DatabaseStore) persist(cfg *model.Config) error {
 		return errors.Wrap(err, "failed to commit transaction")
 	}
 
+	ds.setActiveId(id)
+
 	return nil
 }
 
 // Load updates the current configuration from the backing store.
 func (ds *DatabaseStore) Load() (err error) {
 	var needsSave bool
+	var activeId string
 	var configurationData []byte
 
-	row := ds.db.QueryRow("SELECT Value FROM Configurations WHERE Active")
-	if err = row.Scan(&configurationData); err != nil && err != sql.ErrNoRows {
+	row := ds.db.QueryRow("SELECT Id, Value FROM Configurations WHERE Active")
+	if err = row.Scan(&activeId, &configurationData); err != nil && err != sql.ErrNoRows {
 		return errors.Wrap(err, "failed to query active configuration")
 	}
+	ds.setActiveId(activeId)
+
+	// Initialize from the bootstrap file if there is one and no active configuration could be found. Its database
+	// settings are kept, since the bootstrap file describes the whole deployment.
+	if len(configurationData) == 0 && ds.bootstrapPath != "" {
+		needsSave = true
+
+		configurationData, err = ioutil.ReadFile(ds.bootstrapPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read bootstrap config %s", ds.bootstrapPath)
+		}
+
+		mlog.Info("Initializing the configuration database from a bootstrap config file", mlog.String("path", ds.bootstrapPath))
+	}
 
-	// Initialize from the default config if no active configuration could be found.
+	// Otherwise initialize from the default config if no active configuration could be found.
 	if len(configurationData) == 0 {
 		needsSave = true
 
@@ -248,6 +297,72 @@ func (ds *DatabaseStore) Load() (err error) {
 	return ds.commonStore.load(ioutil.NopCloser(bytes.NewReader(configurationData)), needsSave, ds.commonStore.validate, ds.persist)
 }
 
+func (ds *DatabaseStore) getActiveId() string {
+	ds.activeIdLock.Lock()
+	defer ds.activeIdLock.Unlock()
+
+	return ds.activeId
+}
+
+func (ds *DatabaseStore) setActiveId(id string) {
+	ds.activeIdLock.Lock()
+	defer ds.activeIdLock.Unlock()
+
+	ds.activeId = id
+}
+
+// startWatcher polls for a configuration saved to the database by another process, such as another server or the
+// command line, and reloads it.
+func (ds *DatabaseStore) startWatcher() {
+	ds.stopWatching = make(chan struct{})
+	ds.watcherDone = make(chan struct{})
+
+	go func() {
+		defer close(ds.watcherDone)
+
+		ticker := time.NewTicker(DatabaseWatchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ds.checkForChanges()
+			case <-ds.stopWatching:
+				return
+			}
+		}
+	}()
+}
+
+func (ds *DatabaseStore) checkForChanges() {
+	var activeId string
+	row := ds.db.QueryRow("SELECT Id FROM Configurations WHERE Active")
+	if err := row.Scan(&activeId); err != nil && err != sql.ErrNoRows {
+		mlog.Error("Failed to check for configuration changes", mlog.Err(err))
+		return
+	}
+
+	if activeId == ds.getActiveId() {
+		return
+	}
+
+	mlog.Info("Reloading the configuration changed in the database", mlog.String("configuration_id", activeId))
+	if err := ds.Load(); err != nil {
+		mlog.Error("Failed to reload the configuration changed in the database", mlog.Err(err))
+	}
+}
+
+// stopWatcher stops any previously started watcher.
+func (ds *DatabaseStore) stopWatcher() {
+	if ds.stopWatching == nil {
+		return
+	}
+
+	close(ds.stopWatching)
+	<-ds.watcherDone
+	ds.stopWatching = nil
+}
+
 // GetFile fetches the contents of a previously persisted configuration file.
 func (ds *DatabaseStore) GetFile(name string) ([]byte, error) {
 	query, args, err := sqlx.Named("SELECT Data FROM ConfigurationFiles WHERE Name = :name", map[string]interface{}{
@@ -337,6 +452,8 @@ func (ds *DatabaseStore) String() string {
 
 // Close cleans up resources associated with the store.
 func (ds *DatabaseStore) Close() error {
+	ds.stopWatcher()
+
 	ds.configLock.Lock()
 	defer ds.configLock.Unlock()
 
@@ -143,6 +143,88 @@ func TestDatabaseStoreNew(t *testing.T) {
 	})
 }
 
+func TestDatabaseStoreNewWithBootstrap(t *testing.T) {
+	sqlSettings := mainHelper.GetSqlSettings()
+	dsn := fmt.Sprintf("%s://%s", *sqlSettings.DriverName, *sqlSettings.DataSource)
+
+	bootstrapPath, tearDownFile := setupConfigFile(t, minimalConfig)
+	defer tearDownFile()
+
+	t.Run("no existing configuration - initialized from the bootstrap file", func(t *testing.T) {
+		truncateTables(t)
+		defer truncateTables(t)
+
+		ds, err := config.NewDatabaseStoreWithBootstrap(dsn, false, bootstrapPath)
+		require.NoError(t, err)
+		defer ds.Close()
+
+		assert.Equal(t, "http://minimal", *ds.Get().ServiceSettings.SiteURL)
+		assertDatabaseEqualsConfig(t, minimalConfig)
+	})
+
+	t.Run("existing configuration - bootstrap file ignored", func(t *testing.T) {
+		_, tearDown := setupConfigDatabase(t, testConfig, nil)
+		defer tearDown()
+
+		ds, err := config.NewDatabaseStoreWithBootstrap(dsn, false, bootstrapPath)
+		require.NoError(t, err)
+		defer ds.Close()
+
+		assert.Equal(t, "http://TestStoreNew", *ds.Get().ServiceSettings.SiteURL)
+	})
+
+	t.Run("missing bootstrap file", func(t *testing.T) {
+		truncateTables(t)
+		defer truncateTables(t)
+
+		_, err := config.NewDatabaseStoreWithBootstrap(dsn, false, bootstrapPath+".missing")
+		require.Error(t, err)
+	})
+}
+
+func TestDatabaseStoreWatch(t *testing.T) {
+	sqlSettings := mainHelper.GetSqlSettings()
+	dsn := fmt.Sprintf("%s://%s", *sqlSettings.DriverName, *sqlSettings.DataSource)
+
+	oldInterval := config.DatabaseWatchInterval
+	config.DatabaseWatchInterval = 50 * time.Millisecond
+	defer func() { config.DatabaseWatchInterval = oldInterval }()
+
+	_, tearDown := setupConfigDatabase(t, minimalConfig, nil)
+	defer tearDown()
+
+	ds, err := config.NewDatabaseStoreWithBootstrap(dsn, true, "")
+	require.NoError(t, err)
+	defer ds.Close()
+
+	called := make(chan bool, 1)
+	ds.AddListener(func(oldCfg, newCfg *model.Config) {
+		if *newCfg.ServiceSettings.SiteURL == "http://changed" {
+			select {
+			case called <- true:
+			default:
+			}
+		}
+	})
+
+	// Save a new configuration from another store, as another server would.
+	other, err := config.NewDatabaseStore(dsn)
+	require.NoError(t, err)
+	defer other.Close()
+
+	newCfg := minimalConfig.Clone()
+	newCfg.ServiceSettings.SiteURL = model.NewString("http://changed")
+	_, err = other.Set(newCfg)
+	require.NoError(t, err)
+
+	select {
+	case <-called:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the configuration changed in the database was not reloaded")
+	}
+	assert.Equal(t, "http://changed", *ds.Get().ServiceSettings.SiteURL)
+}
+
 func TestDatabaseStoreGet(t *testing.T) {
 	_, tearDown := setupConfigDatabase(t, testConfig, nil)
 	defer tearDown()
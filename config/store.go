@@ -60,3 +60,14 @@ func NewStore(dsn string, watch bool) (Store, error) {
 
 	return NewFileStore(dsn, watch)
 }
+
+// NewStoreWithBootstrap creates a store as NewStore does, except that a database store is watched for changes made by
+// other processes and is initialized from the configuration file at the bootstrap path when the database is empty.
+// The bootstrap path is ignored for a file store.
+func NewStoreWithBootstrap(dsn string, watch bool, bootstrapPath string) (Store, error) {
+	if strings.HasPrefix(dsn, "mysql://") || strings.HasPrefix(dsn, "postgres://") {
+		return NewDatabaseStoreWithBootstrap(dsn, watch, bootstrapPath)
+	}
+
+	return NewFileStore(dsn, watch)
+}
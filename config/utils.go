@@ -4,6 +4,7 @@
 package config
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/mattermost/mattermost-server/mlog"
@@ -43,6 +44,24 @@ func desanitize(actual, target *model.Config) {
 		*target.ElasticsearchSettings.Password = *actual.ElasticsearchSettings.Password
 	}
 
+	if *target.AuditSettings.HTTPAuthorizationHeader == model.FAKE_SETTING {
+		*target.AuditSettings.HTTPAuthorizationHeader = *actual.AuditSettings.HTTPAuthorizationHeader
+	}
+
+	if *target.ClusterSettings.SecretKey == model.FAKE_SETTING {
+		*target.ClusterSettings.SecretKey = *actual.ClusterSettings.SecretKey
+	}
+
+	// Only the credentials of the proxy URL are sanitized, so that its address can still be changed.
+	if *target.ServiceSettings.OutboundProxyURL == model.FAKE_SETTING {
+		*target.ServiceSettings.OutboundProxyURL = *actual.ServiceSettings.OutboundProxyURL
+	} else if targetURL, err := url.Parse(*target.ServiceSettings.OutboundProxyURL); err == nil && targetURL.User != nil && targetURL.User.Username() == model.FAKE_SETTING {
+		if actualURL, err := url.Parse(*actual.ServiceSettings.OutboundProxyURL); err == nil {
+			targetURL.User = actualURL.User
+			*target.ServiceSettings.OutboundProxyURL = targetURL.String()
+		}
+	}
+
 	target.SqlSettings.DataSourceReplicas = make([]string, len(actual.SqlSettings.DataSourceReplicas))
 	for i := range target.SqlSettings.DataSourceReplicas {
 		target.SqlSettings.DataSourceReplicas[i] = actual.SqlSettings.DataSourceReplicas[i]
@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package dailystats
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type DailyStatsJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsDailyStatsJobInterface(func(a *app.App) tjobs.DailyStatsJobInterface {
+		return &DailyStatsJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package dailystats
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/app"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// dailyStatsJobDelay leaves some time after midnight for the posts of the previous day still being saved.
+const dailyStatsJobDelay = 15 * time.Minute
+
+type Scheduler struct {
+	App *app.App
+}
+
+func (m *DailyStatsJobInterfaceImpl) MakeScheduler() model.Scheduler {
+	return &Scheduler{m.App}
+}
+
+func (scheduler *Scheduler) Name() string {
+	return "DailyStatsScheduler"
+}
+
+func (scheduler *Scheduler) JobType() string {
+	return model.JOB_TYPE_DAILY_STATS
+}
+
+func (scheduler *Scheduler) Enabled(cfg *model.Config) bool {
+	return *cfg.AnalyticsSettings.EnableDailyStats
+}
+
+func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	nextTime := now.UTC().Truncate(24 * time.Hour).Add(dailyStatsJobDelay)
+	if !nextTime.After(now) {
+		nextTime = nextTime.AddDate(0, 0, 1)
+	}
+	return &nextTime
+}
+
+func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	mlog.Debug("Scheduling Job", mlog.String("scheduler", scheduler.Name()))
+
+	if job, err := scheduler.App.Srv.Jobs.CreateJob(model.JOB_TYPE_DAILY_STATS, nil); err != nil {
+		return nil, err
+	} else {
+		return job, nil
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package dailystats
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/app"
+	"github.com/mattermost/mattermost-server/jobs"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type Worker struct {
+	name      string
+	stop      chan bool
+	stopped   chan bool
+	jobs      chan model.Job
+	jobServer *jobs.JobServer
+	app       *app.App
+}
+
+func (m *DailyStatsJobInterfaceImpl) MakeWorker() model.Worker {
+	worker := Worker{
+		name:      "DailyStats",
+		stop:      make(chan bool, 1),
+		stopped:   make(chan bool, 1),
+		jobs:      make(chan model.Job),
+		jobServer: m.App.Srv.Jobs,
+		app:       m.App,
+	}
+
+	return &worker
+}
+
+func (worker *Worker) Run() {
+	mlog.Debug("Worker started", mlog.String("worker", worker.name))
+
+	defer func() {
+		mlog.Debug("Worker finished", mlog.String("worker", worker.name))
+		worker.stopped <- true
+	}()
+
+	for {
+		select {
+		case <-worker.stop:
+			mlog.Debug("Worker received stop signal", mlog.String("worker", worker.name))
+			return
+		case job := <-worker.jobs:
+			mlog.Debug("Worker received a new candidate job.", mlog.String("worker", worker.name))
+			worker.DoJob(&job)
+		}
+	}
+}
+
+func (worker *Worker) Stop() {
+	mlog.Debug("Worker stopping", mlog.String("worker", worker.name))
+	worker.stop <- true
+	<-worker.stopped
+}
+
+func (worker *Worker) JobChannel() chan<- model.Job {
+	return worker.jobs
+}
+func (worker *Worker) DoJob(job *model.Job) {
+	if claimed, err := worker.jobServer.ClaimJob(job); err != nil {
+		mlog.Info("Worker experienced an error while trying to claim job",
+			mlog.String("worker", worker.name),
+			mlog.String("job_id", job.Id),
+			mlog.String("error", err.Error()))
+		return
+	} else if !claimed {
+		return
+	}
+
+	cancelCtx, cancelCancelWatcher := context.WithCancel(context.Background())
+	cancelWatcherChan := make(chan interface{}, 1)
+	go worker.app.Srv.Jobs.CancellationWatcher(cancelCtx, job.Id, cancelWatcherChan)
+
+	defer cancelCancelWatcher()
+
+	var lastDate string
+	if lastSuccessfulJob, err := worker.jobServer.GetLastSuccessfulJobByType(model.JOB_TYPE_DAILY_STATS); err != nil {
+		mlog.Error("Worker: Failed to get the last successful job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+		return
+	} else if lastSuccessfulJob != nil {
+		lastDate = lastSuccessfulJob.Data[model.DAILY_STATS_JOB_DATA_LAST_DATE]
+	}
+
+	if job.Data == nil {
+		job.Data = make(map[string]string)
+	}
+
+	dates := model.GetDailyStatsRollupDates(lastDate, time.Now())
+	if len(dates) == 0 {
+		// The last date is carried over so that the next job doesn't roll up the past days again.
+		job.Data[model.DAILY_STATS_JOB_DATA_LAST_DATE] = lastDate
+
+		mlog.Info("Worker: Daily stats are up to date", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+		worker.setJobSuccess(job)
+		return
+	}
+
+	job.Data[model.DAILY_STATS_JOB_DATA_FIRST_DATE] = dates[0]
+
+	rows := 0
+	for i, date := range dates {
+		select {
+		case <-cancelWatcherChan:
+			mlog.Debug("Worker: Job has been canceled via CancellationWatcher", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+			worker.setJobCanceled(job)
+			return
+		default:
+		}
+
+		count, err := worker.app.ComputeDailyStats(date)
+		if err != nil {
+			mlog.Error("Worker: Failed to compute daily stats", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("date", date), mlog.String("error", err.Error()))
+			worker.setJobError(job, err)
+			return
+		}
+
+		rows += count
+		job.Data[model.DAILY_STATS_JOB_DATA_LAST_DATE] = date
+		job.Data[model.DAILY_STATS_JOB_DATA_ROWS] = strconv.Itoa(rows)
+		worker.setJobProgress(job, int64((i+1)*100/len(dates)))
+	}
+
+	mlog.Info("Worker: Job is complete", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.Int("days", len(dates)), mlog.Int("rows", rows))
+	worker.setJobSuccess(job)
+}
+
+func (worker *Worker) setJobProgress(job *model.Job, progress int64) {
+	if err := worker.app.Srv.Jobs.SetJobProgress(job, progress); err != nil {
+		mlog.Error("Worker: Failed to set progress for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
+
+func (worker *Worker) setJobSuccess(job *model.Job) {
+	if err := worker.app.Srv.Jobs.SetJobSuccess(job); err != nil {
+		mlog.Error("Worker: Failed to set success for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+	}
+}
+
+func (worker *Worker) setJobError(job *model.Job, appError *model.AppError) {
+	if err := worker.app.Srv.Jobs.SetJobError(job, appError); err != nil {
+		mlog.Error("Worker: Failed to set job error", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
+
+func (worker *Worker) setJobCanceled(job *model.Job) {
+	if err := worker.app.Srv.Jobs.SetJobCanceled(job); err != nil {
+		mlog.Error("Worker: Failed to mark job as canceled", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
@@ -9,14 +9,19 @@ type MetricsInterface interface {
 
 	IncrementPostCreate()
 	IncrementWebhookPost()
+	IncrementWebhookRateLimited()
+	IncrementWebhookQuotaExceeded()
 	IncrementPostSentEmail()
 	IncrementPostSentPush()
+	IncrementPushNotificationFailure()
+	IncrementPushNotificationDeviceInvalidated()
 	IncrementPostBroadcast()
 	IncrementPostFileAttachment(count int)
 
 	IncrementHttpRequest()
 	IncrementHttpError()
 	ObserveHttpRequestDuration(elapsed float64)
+	ObserveApiEndpointDuration(handler, method, statusCode string, elapsed float64)
 
 	IncrementClusterRequest()
 	ObserveClusterRequestDuration(elapsed float64)
@@ -37,6 +42,7 @@ type MetricsInterface interface {
 
 	IncrementWebsocketEvent(eventType string)
 	IncrementWebSocketBroadcast(eventType string)
+	ObserveWebsocketBroadcastDuration(eventType string, elapsed float64)
 
 	AddMemCacheHitCounter(cacheName string, amount float64)
 	AddMemCacheMissCounter(cacheName string, amount float64)
@@ -44,4 +50,7 @@ type MetricsInterface interface {
 	IncrementPostsSearchCounter()
 	ObservePostsSearchDuration(elapsed float64)
 	ObserveStoreMethodDuration(method string, success string, elapsed float64)
+	IncrementStoreMethodError(method string)
+
+	SetReplicaLagTime(node string, value float64)
 }
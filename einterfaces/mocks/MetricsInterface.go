@@ -121,6 +121,21 @@ func (_m *MetricsInterface) IncrementPostsSearchCounter() {
 	_m.Called()
 }
 
+// IncrementPushNotificationDeviceInvalidated provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementPushNotificationDeviceInvalidated() {
+	_m.Called()
+}
+
+// IncrementPushNotificationFailure provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementPushNotificationFailure() {
+	_m.Called()
+}
+
+// IncrementStoreMethodError provides a mock function with given fields: method
+func (_m *MetricsInterface) IncrementStoreMethodError(method string) {
+	_m.Called(method)
+}
+
 // IncrementWebSocketBroadcast provides a mock function with given fields: eventType
 func (_m *MetricsInterface) IncrementWebSocketBroadcast(eventType string) {
 	_m.Called(eventType)
@@ -131,11 +146,26 @@ func (_m *MetricsInterface) IncrementWebhookPost() {
 	_m.Called()
 }
 
+// IncrementWebhookQuotaExceeded provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementWebhookQuotaExceeded() {
+	_m.Called()
+}
+
+// IncrementWebhookRateLimited provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementWebhookRateLimited() {
+	_m.Called()
+}
+
 // IncrementWebsocketEvent provides a mock function with given fields: eventType
 func (_m *MetricsInterface) IncrementWebsocketEvent(eventType string) {
 	_m.Called(eventType)
 }
 
+// ObserveApiEndpointDuration provides a mock function with given fields: handler, method, statusCode, elapsed
+func (_m *MetricsInterface) ObserveApiEndpointDuration(handler string, method string, statusCode string, elapsed float64) {
+	_m.Called(handler, method, statusCode, elapsed)
+}
+
 // ObserveClusterRequestDuration provides a mock function with given fields: elapsed
 func (_m *MetricsInterface) ObserveClusterRequestDuration(elapsed float64) {
 	_m.Called(elapsed)
@@ -151,6 +181,21 @@ func (_m *MetricsInterface) ObservePostsSearchDuration(elapsed float64) {
 	_m.Called(elapsed)
 }
 
+// ObserveStoreMethodDuration provides a mock function with given fields: method, success, elapsed
+func (_m *MetricsInterface) ObserveStoreMethodDuration(method string, success string, elapsed float64) {
+	_m.Called(method, success, elapsed)
+}
+
+// ObserveWebsocketBroadcastDuration provides a mock function with given fields: eventType, elapsed
+func (_m *MetricsInterface) ObserveWebsocketBroadcastDuration(eventType string, elapsed float64) {
+	_m.Called(eventType, elapsed)
+}
+
+// SetReplicaLagTime provides a mock function with given fields: node, value
+func (_m *MetricsInterface) SetReplicaLagTime(node string, value float64) {
+	_m.Called(node, value)
+}
+
 // StartServer provides a mock function with given fields:
 func (_m *MetricsInterface) StartServer() {
 	_m.Called()
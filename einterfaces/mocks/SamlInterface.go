@@ -37,13 +37,13 @@ func (_m *SamlInterface) BuildRequest(relayState string) (*model.SamlAuthRequest
 	return r0, r1
 }
 
-// ConfigureSP provides a mock function with given fields:
-func (_m *SamlInterface) ConfigureSP() error {
-	ret := _m.Called()
+// ConfigureSP provides a mock function with given fields: signatureAlgorithm, canonicalAlgorithm
+func (_m *SamlInterface) ConfigureSP(signatureAlgorithm string, canonicalAlgorithm string) error {
+	ret := _m.Called(signatureAlgorithm, canonicalAlgorithm)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(signatureAlgorithm, canonicalAlgorithm)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,7 +52,7 @@ func (_m *SamlInterface) ConfigureSP() error {
 }
 
 // DoLogin provides a mock function with given fields: encodedXML, relayState
-func (_m *SamlInterface) DoLogin(encodedXML string, relayState map[string]string) (*model.User, *model.AppError) {
+func (_m *SamlInterface) DoLogin(encodedXML string, relayState map[string]string) (*model.User, []string, *model.AppError) {
 	ret := _m.Called(encodedXML, relayState)
 
 	var r0 *model.User
@@ -64,16 +64,25 @@ func (_m *SamlInterface) DoLogin(encodedXML string, relayState map[string]string
 		}
 	}
 
-	var r1 *model.AppError
-	if rf, ok := ret.Get(1).(func(string, map[string]string) *model.AppError); ok {
+	var r1 []string
+	if rf, ok := ret.Get(1).(func(string, map[string]string) []string); ok {
 		r1 = rf(encodedXML, relayState)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*model.AppError)
+			r1 = ret.Get(1).([]string)
 		}
 	}
 
-	return r0, r1
+	var r2 *model.AppError
+	if rf, ok := ret.Get(2).(func(string, map[string]string) *model.AppError); ok {
+		r2 = rf(encodedXML, relayState)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*model.AppError)
+		}
+	}
+
+	return r0, r1, r2
 }
 
 // GetMetadata provides a mock function with given fields:
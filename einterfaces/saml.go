@@ -8,8 +8,12 @@ import (
 )
 
 type SamlInterface interface {
-	ConfigureSP() error
+	// ConfigureSP configures the service provider, signing its requests with the given algorithms of the
+	// SamlSettings.
+	ConfigureSP(signatureAlgorithm, canonicalAlgorithm string) error
 	BuildRequest(relayState string) (*model.SamlAuthRequest, *model.AppError)
-	DoLogin(encodedXML string, relayState map[string]string) (*model.User, *model.AppError)
+	// DoLogin verifies, and decrypts when SamlSettings.Encrypt is set, the given assertion and returns its user
+	// along with the values of its SamlSettings.GroupAttribute, if any.
+	DoLogin(encodedXML string, relayState map[string]string) (*model.User, []string, *model.AppError)
 	GetMetadata() (string, *model.AppError)
 }
@@ -6,6 +6,17 @@ package imports
 // This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty
 
 import (
+	_ "github.com/mattermost/mattermost-server/bulkpreferences"
+	_ "github.com/mattermost/mattermost-server/bulkusers"
+	_ "github.com/mattermost/mattermost-server/channelreadstats"
+	_ "github.com/mattermost/mattermost-server/channeltimeline"
+	_ "github.com/mattermost/mattermost-server/cluster"
+	_ "github.com/mattermost/mattermost-server/dailystats"
 	_ "github.com/mattermost/mattermost-server/migrations"
 	_ "github.com/mattermost/mattermost-server/plugin/scheduler"
+	_ "github.com/mattermost/mattermost-server/postarchive"
+	_ "github.com/mattermost/mattermost-server/recurringpost"
+	_ "github.com/mattermost/mattermost-server/searchreindex"
+	_ "github.com/mattermost/mattermost-server/useranonymization"
+	_ "github.com/mattermost/mattermost-server/userdeactivation"
 )
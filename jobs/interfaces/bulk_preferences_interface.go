@@ -0,0 +1,10 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package interfaces
+
+import "github.com/mattermost/mattermost-server/model"
+
+type BulkPreferencesJobInterface interface {
+	MakeWorker() model.Worker
+}
@@ -21,6 +21,7 @@ func (srv *JobServer) CreateJob(jobType string, jobData map[string]string) (*mod
 	job := model.Job{
 		Id:       model.NewId(),
 		Type:     jobType,
+		Priority: model.GetJobTypeOptions(jobType).Priority,
 		CreateAt: model.GetMillis(),
 		Status:   model.JOB_STATUS_PENDING,
 		Data:     jobData,
@@ -41,8 +42,60 @@ func (srv *JobServer) GetJob(id string) (*model.Job, *model.AppError) {
 	return srv.Store.Job().Get(id)
 }
 
+// ClaimJob marks a pending job as in progress for this server's workers. Jobs re-queued after being stuck aren't
+// claimed until their backoff is over, and jobs whose type already runs as many jobs as its maximum concurrency are
+// left for later.
 func (srv *JobServer) ClaimJob(job *model.Job) (bool, *model.AppError) {
-	return srv.Store.Job().UpdateStatusOptimistically(job.Id, model.JOB_STATUS_PENDING, model.JOB_STATUS_IN_PROGRESS)
+	if job.RetryAfter() > model.GetMillis() {
+		return false, nil
+	}
+
+	if maxConcurrency := model.GetJobTypeOptions(job.Type).MaxConcurrency; maxConcurrency > 0 {
+		count, err := srv.Store.Job().GetCountByStatusAndType(model.JOB_STATUS_IN_PROGRESS, job.Type)
+		if err != nil {
+			return false, err
+		}
+		if count >= int64(maxConcurrency) {
+			return false, nil
+		}
+	}
+
+	claimed, err := srv.Store.Job().UpdateStatusOptimistically(job.Id, model.JOB_STATUS_PENDING, model.JOB_STATUS_IN_PROGRESS)
+	if claimed {
+		srv.claimedJobs.Store(job.Id, true)
+	}
+	return claimed, err
+}
+
+// HandOffJob puts a job claimed by this server back in the pending state, so that it is picked up again by the
+// workers of another node, or of this node once it restarts. The job keeps its data, which lets workers that record
+// their progress resume where they stopped.
+func (srv *JobServer) HandOffJob(job *model.Job) *model.AppError {
+	srv.claimedJobs.Delete(job.Id)
+
+	updated, err := srv.Store.Job().UpdateStatusOptimistically(job.Id, model.JOB_STATUS_IN_PROGRESS, model.JOB_STATUS_PENDING)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		return model.NewAppError("Jobs.HandOffJob", "jobs.hand_off_job.status.error", nil, "id="+job.Id, http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// HandOffClaimedJobs hands off all the jobs claimed by this server that haven't finished yet.
+func (srv *JobServer) HandOffClaimedJobs() {
+	srv.claimedJobs.Range(func(key, _ interface{}) bool {
+		jobId := key.(string)
+		if err := srv.HandOffJob(&model.Job{Id: jobId}); err != nil {
+			mlog.Warn("Failed to hand off job", mlog.String("job_id", jobId), mlog.Err(err))
+		} else {
+			mlog.Info("Handed off job", mlog.String("job_id", jobId))
+		}
+		return true
+	})
 }
 
 func (srv *JobServer) SetJobProgress(job *model.Job, progress int64) *model.AppError {
@@ -56,6 +109,8 @@ func (srv *JobServer) SetJobProgress(job *model.Job, progress int64) *model.AppE
 }
 
 func (srv *JobServer) SetJobSuccess(job *model.Job) *model.AppError {
+	srv.claimedJobs.Delete(job.Id)
+
 	if _, err := srv.Store.Job().UpdateStatus(job.Id, model.JOB_STATUS_SUCCESS); err != nil {
 		return err
 	}
@@ -63,9 +118,15 @@ func (srv *JobServer) SetJobSuccess(job *model.Job) *model.AppError {
 }
 
 func (srv *JobServer) SetJobError(job *model.Job, jobError *model.AppError) *model.AppError {
+	srv.claimedJobs.Delete(job.Id)
+
 	if jobError == nil {
-		_, err := srv.Store.Job().UpdateStatus(job.Id, model.JOB_STATUS_ERROR)
-		return err
+		if _, err := srv.Store.Job().UpdateStatus(job.Id, model.JOB_STATUS_ERROR); err != nil {
+			return err
+		}
+
+		srv.jobFailed(job)
+		return nil
 	}
 
 	job.Status = model.JOB_STATUS_ERROR
@@ -90,10 +151,19 @@ func (srv *JobServer) SetJobError(job *model.Job, jobError *model.AppError) *mod
 		}
 	}
 
+	srv.jobFailed(job)
 	return nil
 }
 
+func (srv *JobServer) jobFailed(job *model.Job) {
+	if srv.OnJobFailed != nil {
+		srv.OnJobFailed(job)
+	}
+}
+
 func (srv *JobServer) SetJobCanceled(job *model.Job) *model.AppError {
+	srv.claimedJobs.Delete(job.Id)
+
 	if _, err := srv.Store.Job().UpdateStatus(job.Id, model.JOB_STATUS_CANCELED); err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ package jobs
 
 import (
 	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/mattermost/mattermost-server/mlog"
@@ -65,13 +66,25 @@ func (watcher *Watcher) Stop() {
 }
 
 func (watcher *Watcher) PollAndNotify() {
+	watcher.srv.touchClaimedJobs()
+
 	jobs, err := watcher.srv.Store.Job().GetAllByStatus(model.JOB_STATUS_PENDING)
 	if err != nil {
 		mlog.Error("Error occurred getting all pending statuses.", mlog.Err(err))
 		return
 	}
 
+	// Jobs of higher priority are offered first, and the oldest first within a priority.
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Priority > jobs[j].Priority
+	})
+
+	now := model.GetMillis()
 	for _, job := range jobs {
+		if job.RetryAfter() > now {
+			continue
+		}
+
 		if job.Type == model.JOB_TYPE_DATA_RETENTION {
 			if watcher.workers.DataRetention != nil {
 				select {
@@ -121,6 +134,76 @@ func (watcher *Watcher) PollAndNotify() {
 				default:
 				}
 			}
+		} else if job.Type == model.JOB_TYPE_BULK_PREFERENCES {
+			if watcher.workers.BulkPreferences != nil {
+				select {
+				case watcher.workers.BulkPreferences.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_BULK_USERS {
+			if watcher.workers.BulkUsers != nil {
+				select {
+				case watcher.workers.BulkUsers.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_CHANNEL_READ_STATS {
+			if watcher.workers.ChannelReadStats != nil {
+				select {
+				case watcher.workers.ChannelReadStats.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_USER_DEACTIVATION {
+			if watcher.workers.UserDeactivation != nil {
+				select {
+				case watcher.workers.UserDeactivation.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_RECURRING_POSTS {
+			if watcher.workers.RecurringPosts != nil {
+				select {
+				case watcher.workers.RecurringPosts.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_DAILY_STATS {
+			if watcher.workers.DailyStats != nil {
+				select {
+				case watcher.workers.DailyStats.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_POST_ARCHIVE {
+			if watcher.workers.PostArchive != nil {
+				select {
+				case watcher.workers.PostArchive.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_CHANNEL_TIMELINE {
+			if watcher.workers.ChannelTimeline != nil {
+				select {
+				case watcher.workers.ChannelTimeline.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_SEARCH_REINDEX {
+			if watcher.workers.SearchReindex != nil {
+				select {
+				case watcher.workers.SearchReindex.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_USER_ANONYMIZATION {
+			if watcher.workers.UserAnonymization != nil {
+				select {
+				case watcher.workers.UserAnonymization.JobChannel() <- *job:
+				default:
+				}
+			}
 		}
 	}
 }
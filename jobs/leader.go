@@ -0,0 +1,102 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package jobs
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	JOBS_LEADER_LEASE_DURATION       = 30 * time.Second
+	JOBS_LEADER_LEASE_RENEW_INTERVAL = 10 * time.Second
+)
+
+// ServerId returns the id this server uses to hold the job scheduler leader lease.
+func (srv *JobServer) ServerId() string {
+	srv.leaderMutex.Lock()
+	defer srv.leaderMutex.Unlock()
+
+	if srv.serverId == "" {
+		srv.serverId = model.NewId()
+	}
+
+	return srv.serverId
+}
+
+// acquireLeaderLease takes or renews the lease that elects this server as the one running the job schedulers of the
+// cluster, returning whether it holds it. If the lease can't be checked, the server keeps leading until the lease it
+// last acquired expires, so that the other servers can take over once it does.
+func (srv *JobServer) acquireLeaderLease() bool {
+	hostname, _ := os.Hostname()
+	lease := &model.LeaderLease{
+		Name:     model.SYSTEM_JOBS_LEADER_LEASE,
+		HolderId: srv.ServerId(),
+		Hostname: hostname,
+		ExpireAt: model.GetMillis() + int64(JOBS_LEADER_LEASE_DURATION/time.Millisecond),
+	}
+
+	current, err := srv.Store.System().AcquireLease(lease)
+
+	srv.leaderMutex.Lock()
+	defer srv.leaderMutex.Unlock()
+
+	if err != nil {
+		mlog.Error("Failed to acquire the job scheduler leader lease", mlog.Err(err))
+		return srv.leaderLease != nil && !srv.leaderLease.IsExpired()
+	}
+
+	if current.HolderId != srv.serverId {
+		srv.leaderLease = nil
+		return false
+	}
+
+	srv.leaderLease = current
+	return true
+}
+
+// releaseLeaderLease gives up the job scheduler leader lease, letting another server take over right away.
+func (srv *JobServer) releaseLeaderLease() {
+	srv.leaderMutex.Lock()
+	srv.leaderLease = nil
+	srv.leaderMutex.Unlock()
+
+	if _, err := srv.Store.System().ReleaseLease(model.SYSTEM_JOBS_LEADER_LEASE, srv.ServerId()); err != nil {
+		mlog.Error("Failed to release the job scheduler leader lease", mlog.Err(err))
+	}
+}
+
+// GetLeaderStatus returns which server of the cluster currently runs the job schedulers.
+func (srv *JobServer) GetLeaderStatus() (*model.JobsLeaderStatus, *model.AppError) {
+	status := &model.JobsLeaderStatus{
+		ServerId: srv.ServerId(),
+	}
+
+	props, err := srv.Store.System().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	// The lease doesn't exist while no server runs the job schedulers.
+	value, ok := props[model.SYSTEM_JOBS_LEADER_LEASE]
+	if !ok {
+		return status, nil
+	}
+
+	lease := model.LeaderLeaseFromJson(strings.NewReader(value))
+	if lease == nil || lease.IsExpired() {
+		return status, nil
+	}
+
+	status.LeaderId = lease.HolderId
+	status.LeaderHostname = lease.Hostname
+	status.LeaseExpireAt = lease.ExpireAt
+	status.IsLeader = lease.HolderId == status.ServerId
+
+	return status, nil
+}
@@ -13,13 +13,13 @@ import (
 )
 
 type Schedulers struct {
-	stop                 chan bool
-	stopped              chan bool
-	configChanged        chan *model.Config
-	clusterLeaderChanged chan bool
-	listenerId           string
-	startOnce            sync.Once
-	jobs                 *JobServer
+	stop          chan bool
+	stopped       chan bool
+	configChanged chan *model.Config
+	listenerId    string
+	startOnce     sync.Once
+	jobs          *JobServer
+	isLeader      bool
 
 	schedulers   []model.Scheduler
 	nextRunTimes []*time.Time
@@ -29,11 +29,10 @@ func (srv *JobServer) InitSchedulers() *Schedulers {
 	mlog.Debug("Initialising schedulers.")
 
 	schedulers := &Schedulers{
-		stop:                 make(chan bool),
-		stopped:              make(chan bool),
-		configChanged:        make(chan *model.Config),
-		clusterLeaderChanged: make(chan bool),
-		jobs:                 srv,
+		stop:          make(chan bool),
+		stopped:       make(chan bool),
+		configChanged: make(chan *model.Config),
+		jobs:          srv,
 	}
 
 	if srv.DataRetentionJob != nil {
@@ -60,10 +59,32 @@ func (srv *JobServer) InitSchedulers() *Schedulers {
 		schedulers.schedulers = append(schedulers.schedulers, pluginsInterface.MakeScheduler())
 	}
 
+	if channelReadStatsInterface := srv.ChannelReadStats; channelReadStatsInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, channelReadStatsInterface.MakeScheduler())
+	}
+
+	if userDeactivationInterface := srv.UserDeactivation; userDeactivationInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, userDeactivationInterface.MakeScheduler())
+	}
+
+	if recurringPostsInterface := srv.RecurringPosts; recurringPostsInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, recurringPostsInterface.MakeScheduler())
+	}
+
+	if dailyStatsInterface := srv.DailyStats; dailyStatsInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, dailyStatsInterface.MakeScheduler())
+	}
+
+	if postArchiveInterface := srv.PostArchive; postArchiveInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, postArchiveInterface.MakeScheduler())
+	}
+
 	schedulers.nextRunTimes = make([]*time.Time, len(schedulers.schedulers))
 	return schedulers
 }
 
+// Start runs the schedulers. Only the server holding the job scheduler leader lease schedules jobs, so that
+// recurring jobs run once across the cluster, and another server takes over when the leader stops renewing it.
 func (schedulers *Schedulers) Start() *Schedulers {
 	schedulers.listenerId = schedulers.jobs.ConfigService.AddConfigListener(schedulers.handleConfigChange)
 
@@ -76,23 +97,34 @@ func (schedulers *Schedulers) Start() *Schedulers {
 				close(schedulers.stopped)
 			}()
 
+			leaseTicker := time.NewTicker(JOBS_LEADER_LEASE_RENEW_INTERVAL)
+			defer leaseTicker.Stop()
+
+			scheduleTicker := time.NewTicker(1 * time.Minute)
+			defer scheduleTicker.Stop()
+
 			now := time.Now()
-			for idx, scheduler := range schedulers.schedulers {
-				if !scheduler.Enabled(schedulers.jobs.Config()) {
-					schedulers.nextRunTimes[idx] = nil
-				} else {
-					schedulers.setNextRunTime(schedulers.jobs.Config(), idx, now, false)
-				}
-			}
+			schedulers.setLeader(schedulers.jobs.acquireLeaderLease(), now)
 
 			for {
 				select {
 				case <-schedulers.stop:
 					mlog.Debug("Schedulers received stop signal.")
+					if schedulers.isLeader {
+						schedulers.jobs.releaseLeaderLease()
+					}
 					return
-				case now = <-time.After(1 * time.Minute):
+				case <-leaseTicker.C:
+					if isLeader := schedulers.jobs.acquireLeaderLease(); isLeader != schedulers.isLeader {
+						schedulers.setLeader(isLeader, now)
+					}
+				case now = <-scheduleTicker.C:
 					cfg := schedulers.jobs.Config()
 
+					if schedulers.isLeader {
+						schedulers.jobs.RequeueStuckJobs()
+					}
+
 					for idx, nextTime := range schedulers.nextRunTimes {
 						if nextTime == nil {
 							continue
@@ -112,20 +144,8 @@ func (schedulers *Schedulers) Start() *Schedulers {
 						}
 					}
 				case newCfg := <-schedulers.configChanged:
-					for idx, scheduler := range schedulers.schedulers {
-						if !scheduler.Enabled(newCfg) {
-							schedulers.nextRunTimes[idx] = nil
-						} else {
-							schedulers.setNextRunTime(newCfg, idx, now, false)
-						}
-					}
-				case isLeader := <-schedulers.clusterLeaderChanged:
-					for idx := range schedulers.schedulers {
-						if !isLeader {
-							schedulers.nextRunTimes[idx] = nil
-						} else {
-							schedulers.setNextRunTime(schedulers.jobs.Config(), idx, now, false)
-						}
+					if schedulers.isLeader {
+						schedulers.setNextRunTimes(newCfg, now)
 					}
 				}
 			}
@@ -135,6 +155,29 @@ func (schedulers *Schedulers) Start() *Schedulers {
 	return schedulers
 }
 
+func (schedulers *Schedulers) setLeader(isLeader bool, now time.Time) {
+	mlog.Info("Job scheduler leadership determined.", mlog.Bool("is_leader", isLeader), mlog.String("server_id", schedulers.jobs.ServerId()))
+
+	schedulers.isLeader = isLeader
+	if isLeader {
+		schedulers.setNextRunTimes(schedulers.jobs.Config(), now)
+	} else {
+		for idx := range schedulers.nextRunTimes {
+			schedulers.nextRunTimes[idx] = nil
+		}
+	}
+}
+
+func (schedulers *Schedulers) setNextRunTimes(cfg *model.Config, now time.Time) {
+	for idx, scheduler := range schedulers.schedulers {
+		if !scheduler.Enabled(cfg) {
+			schedulers.nextRunTimes[idx] = nil
+		} else {
+			schedulers.setNextRunTime(cfg, idx, now, false)
+		}
+	}
+}
+
 func (schedulers *Schedulers) Stop() *Schedulers {
 	mlog.Info("Stopping schedulers.")
 	close(schedulers.stop)
@@ -145,6 +188,23 @@ func (schedulers *Schedulers) Stop() *Schedulers {
 func (schedulers *Schedulers) setNextRunTime(cfg *model.Config, idx int, now time.Time, pendingJobs bool) {
 	scheduler := schedulers.schedulers[idx]
 
+	// A cron schedule in the config overrides the schedule of the job type.
+	if spec, ok := cfg.JobSettings.Schedules[scheduler.JobType()]; ok {
+		schedulers.nextRunTimes[idx] = nil
+
+		schedule, err := model.ParseCronSchedule(spec)
+		if err != nil {
+			mlog.Error("Failed to parse job schedule", mlog.String("scheduler", scheduler.Name()), mlog.String("schedule", spec), mlog.Err(err))
+			return
+		}
+
+		if nextTime := schedule.Next(now); !nextTime.IsZero() {
+			schedulers.nextRunTimes[idx] = &nextTime
+		}
+		mlog.Debug("Next run time for scheduler", mlog.String("scheduler_name", scheduler.Name()), mlog.String("schedule", spec), mlog.String("next_runtime", fmt.Sprintf("%v", schedulers.nextRunTimes[idx])))
+		return
+	}
+
 	if !pendingJobs {
 		if pj, err := schedulers.jobs.CheckForPendingJobsByType(scheduler.JobType()); err != nil {
 			mlog.Error("Failed to set next job run time", mlog.Err(err))
@@ -184,11 +244,3 @@ func (schedulers *Schedulers) handleConfigChange(oldConfig *model.Config, newCon
 	mlog.Debug("Schedulers received config change.")
 	schedulers.configChanged <- newConfig
 }
-
-func (schedulers *Schedulers) HandleClusterLeaderChange(isLeader bool) {
-	select {
-	case schedulers.clusterLeaderChanged <- isLeader:
-	default:
-		mlog.Debug("Did not send cluster leader change message to schedulers as no schedulers listening to notification channel.")
-	}
-}
@@ -4,6 +4,9 @@
 package jobs
 
 import (
+	"sync"
+	"time"
+
 	ejobs "github.com/mattermost/mattermost-server/einterfaces/jobs"
 	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
 	"github.com/mattermost/mattermost-server/model"
@@ -24,6 +27,27 @@ type JobServer struct {
 	LdapSync                ejobs.LdapSyncInterface
 	Migrations              tjobs.MigrationsJobInterface
 	Plugins                 tjobs.PluginsJobInterface
+	BulkPreferences         tjobs.BulkPreferencesJobInterface
+	ChannelTimeline         tjobs.ChannelTimelineJobInterface
+	DailyStats              tjobs.DailyStatsJobInterface
+	PostArchive             tjobs.PostArchiveJobInterface
+	UserDeactivation        tjobs.UserDeactivationJobInterface
+	RecurringPosts          tjobs.RecurringPostsJobInterface
+	ChannelReadStats        tjobs.ChannelReadStatsJobInterface
+	BulkUsers               tjobs.BulkUsersJobInterface
+	SearchReindex           tjobs.SearchReindexJobInterface
+	UserAnonymization       tjobs.UserAnonymizationJobInterface
+
+	// OnJobFailed is called with each job that fails on this server, if set.
+	OnJobFailed func(job *model.Job)
+
+	// claimedJobs holds the ids of the jobs claimed by this server's workers that haven't finished yet, so that
+	// they can be handed off to the other nodes of the cluster on shutdown.
+	claimedJobs sync.Map
+
+	leaderMutex sync.Mutex
+	serverId    string
+	leaderLease *model.LeaderLease
 }
 
 func NewJobServer(configService configservice.ConfigService, store store.Store) *JobServer {
@@ -51,6 +75,23 @@ func (srv *JobServer) StopWorkers() {
 	}
 }
 
+// StopWorkersWithTimeout stops the workers, giving the jobs they are running until the timeout to finish. It returns
+// false if some of the workers were still busy when the timeout expired.
+func (srv *JobServer) StopWorkersWithTimeout(timeout time.Duration) bool {
+	stopped := make(chan struct{})
+	go func() {
+		srv.StopWorkers()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
 func (srv *JobServer) StopSchedulers() {
 	if srv.Schedulers != nil {
 		srv.Schedulers.Stop()
@@ -0,0 +1,105 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package jobs
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	STUCK_JOB_BASE_BACKOFF = 1 * time.Minute
+	STUCK_JOB_MAX_BACKOFF  = 1 * time.Hour
+)
+
+// touchClaimedJobs updates the last activity of the jobs run by this server's workers, so that jobs which don't
+// report their progress for a while aren't taken for stuck ones.
+func (srv *JobServer) touchClaimedJobs() {
+	var ids []string
+	srv.claimedJobs.Range(func(key, _ interface{}) bool {
+		ids = append(ids, key.(string))
+		return true
+	})
+
+	if err := srv.Store.Job().UpdateLastActivityAt(ids); err != nil {
+		mlog.Error("Failed to update the last activity of the running jobs", mlog.Err(err))
+	}
+}
+
+// StuckJobBackoff returns how long a stuck job waits before being run again after its given number of retries.
+func StuckJobBackoff(retries int) time.Duration {
+	backoff := STUCK_JOB_BASE_BACKOFF
+	for i := 0; i < retries && backoff < STUCK_JOB_MAX_BACKOFF; i++ {
+		backoff *= 2
+	}
+
+	if backoff > STUCK_JOB_MAX_BACKOFF {
+		return STUCK_JOB_MAX_BACKOFF
+	}
+	return backoff
+}
+
+// RequeueStuckJobs finds the running jobs that haven't shown any activity within the stuck job timeout, such as
+// those of a server that crashed, and puts them back in the pending state to be retried after an exponential
+// backoff. Jobs that were retried too many times fail instead, and those whose cancellation was requested are
+// canceled.
+func (srv *JobServer) RequeueStuckJobs() {
+	cfg := srv.Config()
+	if *cfg.JobSettings.StuckJobTimeoutMinutes == 0 {
+		return
+	}
+
+	cutoff := model.GetMillis() - int64(*cfg.JobSettings.StuckJobTimeoutMinutes)*int64(time.Minute/time.Millisecond)
+
+	for _, status := range []string{model.JOB_STATUS_IN_PROGRESS, model.JOB_STATUS_CANCEL_REQUESTED} {
+		jobs, err := srv.Store.Job().GetAllByStatus(status)
+		if err != nil {
+			mlog.Error("Failed to get the running jobs", mlog.Err(err))
+			return
+		}
+
+		for _, job := range jobs {
+			if job.LastActivityAt >= cutoff {
+				continue
+			}
+
+			if err := srv.requeueStuckJob(job, *cfg.JobSettings.StuckJobMaxRetries); err != nil {
+				mlog.Error("Failed to requeue stuck job", mlog.String("job_id", job.Id), mlog.Err(err))
+			}
+		}
+	}
+}
+
+func (srv *JobServer) requeueStuckJob(job *model.Job, maxRetries int) *model.AppError {
+	if job.Status == model.JOB_STATUS_CANCEL_REQUESTED {
+		mlog.Warn("Canceling stuck job", mlog.String("job_id", job.Id), mlog.String("type", job.Type))
+		return srv.SetJobCanceled(job)
+	}
+
+	retries := job.RetryCount()
+	if retries >= maxRetries {
+		mlog.Warn("Stuck job was retried too many times, failing it", mlog.String("job_id", job.Id), mlog.String("type", job.Type), mlog.Int("retries", retries))
+		return srv.SetJobError(job, model.NewAppError("Jobs.RequeueStuckJobs", "jobs.requeue_stuck_jobs.max_retries.error", nil, "retries="+strconv.Itoa(retries), http.StatusInternalServerError))
+	}
+
+	backoff := StuckJobBackoff(retries)
+	mlog.Warn("Requeueing stuck job", mlog.String("job_id", job.Id), mlog.String("type", job.Type), mlog.Int("retries", retries), mlog.String("backoff", backoff.String()))
+
+	if job.Data == nil {
+		job.Data = make(map[string]string)
+	}
+	job.Status = model.JOB_STATUS_PENDING
+	job.Data[model.JOB_DATA_RETRY_COUNT] = strconv.Itoa(retries + 1)
+	job.Data[model.JOB_DATA_RETRY_AFTER] = strconv.FormatInt(model.GetMillis()+int64(backoff/time.Millisecond), 10)
+
+	if _, err := srv.Store.Job().UpdateOptimistically(job, model.JOB_STATUS_IN_PROGRESS); err != nil {
+		return err
+	}
+
+	return nil
+}
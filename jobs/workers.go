@@ -23,6 +23,16 @@ type Workers struct {
 	LdapSync                 model.Worker
 	Migrations               model.Worker
 	Plugins                  model.Worker
+	BulkPreferences          model.Worker
+	ChannelTimeline          model.Worker
+	DailyStats               model.Worker
+	PostArchive              model.Worker
+	UserDeactivation         model.Worker
+	RecurringPosts           model.Worker
+	ChannelReadStats         model.Worker
+	BulkUsers                model.Worker
+	SearchReindex            model.Worker
+	UserAnonymization        model.Worker
 
 	listenerId string
 }
@@ -61,6 +71,46 @@ func (srv *JobServer) InitWorkers() *Workers {
 		workers.Plugins = pluginsInterface.MakeWorker()
 	}
 
+	if bulkPreferencesInterface := srv.BulkPreferences; bulkPreferencesInterface != nil {
+		workers.BulkPreferences = bulkPreferencesInterface.MakeWorker()
+	}
+
+	if channelTimelineInterface := srv.ChannelTimeline; channelTimelineInterface != nil {
+		workers.ChannelTimeline = channelTimelineInterface.MakeWorker()
+	}
+
+	if dailyStatsInterface := srv.DailyStats; dailyStatsInterface != nil {
+		workers.DailyStats = dailyStatsInterface.MakeWorker()
+	}
+
+	if postArchiveInterface := srv.PostArchive; postArchiveInterface != nil {
+		workers.PostArchive = postArchiveInterface.MakeWorker()
+	}
+
+	if userDeactivationInterface := srv.UserDeactivation; userDeactivationInterface != nil {
+		workers.UserDeactivation = userDeactivationInterface.MakeWorker()
+	}
+
+	if recurringPostsInterface := srv.RecurringPosts; recurringPostsInterface != nil {
+		workers.RecurringPosts = recurringPostsInterface.MakeWorker()
+	}
+
+	if channelReadStatsInterface := srv.ChannelReadStats; channelReadStatsInterface != nil {
+		workers.ChannelReadStats = channelReadStatsInterface.MakeWorker()
+	}
+
+	if bulkUsersInterface := srv.BulkUsers; bulkUsersInterface != nil {
+		workers.BulkUsers = bulkUsersInterface.MakeWorker()
+	}
+
+	if searchReindexInterface := srv.SearchReindex; searchReindexInterface != nil {
+		workers.SearchReindex = searchReindexInterface.MakeWorker()
+	}
+
+	if userAnonymizationInterface := srv.UserAnonymization; userAnonymizationInterface != nil {
+		workers.UserAnonymization = userAnonymizationInterface.MakeWorker()
+	}
+
 	return workers
 }
 
@@ -96,6 +146,46 @@ func (workers *Workers) Start() *Workers {
 			go workers.Plugins.Run()
 		}
 
+		if workers.BulkPreferences != nil {
+			go workers.BulkPreferences.Run()
+		}
+
+		if workers.ChannelTimeline != nil {
+			go workers.ChannelTimeline.Run()
+		}
+
+		if workers.DailyStats != nil {
+			go workers.DailyStats.Run()
+		}
+
+		if workers.PostArchive != nil {
+			go workers.PostArchive.Run()
+		}
+
+		if workers.UserDeactivation != nil {
+			go workers.UserDeactivation.Run()
+		}
+
+		if workers.RecurringPosts != nil {
+			go workers.RecurringPosts.Run()
+		}
+
+		if workers.ChannelReadStats != nil {
+			go workers.ChannelReadStats.Run()
+		}
+
+		if workers.BulkUsers != nil {
+			go workers.BulkUsers.Run()
+		}
+
+		if workers.SearchReindex != nil {
+			go workers.SearchReindex.Run()
+		}
+
+		if workers.UserAnonymization != nil {
+			go workers.UserAnonymization.Run()
+		}
+
 		go workers.Watcher.Start()
 	})
 
@@ -181,6 +271,46 @@ func (workers *Workers) Stop() *Workers {
 		workers.Plugins.Stop()
 	}
 
+	if workers.BulkPreferences != nil {
+		workers.BulkPreferences.Stop()
+	}
+
+	if workers.ChannelTimeline != nil {
+		workers.ChannelTimeline.Stop()
+	}
+
+	if workers.DailyStats != nil {
+		workers.DailyStats.Stop()
+	}
+
+	if workers.PostArchive != nil {
+		workers.PostArchive.Stop()
+	}
+
+	if workers.UserDeactivation != nil {
+		workers.UserDeactivation.Stop()
+	}
+
+	if workers.RecurringPosts != nil {
+		workers.RecurringPosts.Stop()
+	}
+
+	if workers.ChannelReadStats != nil {
+		workers.ChannelReadStats.Stop()
+	}
+
+	if workers.BulkUsers != nil {
+		workers.BulkUsers.Stop()
+	}
+
+	if workers.SearchReindex != nil {
+		workers.SearchReindex.Stop()
+	}
+
+	if workers.UserAnonymization != nil {
+		workers.UserAnonymization.Stop()
+	}
+
 	mlog.Info("Stopped workers")
 
 	return workers
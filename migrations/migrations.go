@@ -35,6 +35,24 @@ func MakeMigrationsList() []string {
 	}
 }
 
+// MakeSchemaBackfillMigrationsList returns the keys of the migrations backfilling the schema migrations that have been
+// applied but not backfilled yet.
+func MakeSchemaBackfillMigrationsList(store store.Store) ([]string, *model.AppError) {
+	schemaMigrations, err := store.SchemaMigration().GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, schemaMigration := range schemaMigrations {
+		if schemaMigration.Status == model.SCHEMA_MIGRATION_STATUS_BACKFILLING {
+			keys = append(keys, model.GetSchemaBackfillMigrationKey(schemaMigration.Version))
+		}
+	}
+
+	return keys, nil
+}
+
 func GetMigrationState(migration string, store store.Store) (string, *model.Job, *model.AppError) {
 	if _, err := store.System().GetByName(migration); err == nil {
 		return MIGRATION_STATE_COMPLETED, nil, nil
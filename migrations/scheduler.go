@@ -49,8 +49,20 @@ func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, p
 func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
 	mlog.Debug("Scheduling Job", mlog.String("scheduler", scheduler.Name()))
 
+	keys := MakeMigrationsList()
+
+	// Backfilling is left to a run without dry-run mode, as it migrates the existing rows.
+	if !*cfg.SqlSettings.MigrationDryRun {
+		backfillKeys, err := MakeSchemaBackfillMigrationsList(scheduler.App.Srv.Store)
+		if err != nil {
+			mlog.Error("Failed to get the schema migrations to backfill.", mlog.String("scheduler", scheduler.Name()), mlog.String("error", err.Error()))
+			return nil, nil
+		}
+		keys = append(keys, backfillKeys...)
+	}
+
 	// Work through the list of migrations in order. Schedule the first one that isn't done (assuming it isn't in progress already).
-	for _, key := range MakeMigrationsList() {
+	for _, key := range keys {
 		state, job, err := GetMigrationState(key, scheduler.App.Srv.Store)
 		if err != nil {
 			mlog.Error("Failed to determine status of migration: ", mlog.String("scheduler", scheduler.Name()), mlog.String("migration_key", key), mlog.String("error", err.Error()))
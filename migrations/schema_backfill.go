@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package migrations
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// runSchemaBackfillMigration backfills a batch of rows of a schema migration. The progress is kept by the store
+// alongside the schema migration, so the lastDone of the job is left empty.
+func (worker *Worker) runSchemaBackfillMigration(version int) (bool, string, *model.AppError) {
+	done, err := worker.app.Srv.Store.SchemaMigration().RunBackfillBatch(version, *worker.app.Config().SqlSettings.MigrationBatchSize)
+	if err != nil {
+		return false, "", err
+	}
+
+	return done, "", nil
+}
@@ -95,8 +95,8 @@ func (worker *Worker) DoJob(job *model.Job) {
 			return
 
 		case <-worker.stop:
-			mlog.Debug("Worker: Job has been canceled via Worker Stop", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
-			worker.setJobCanceled(job)
+			mlog.Debug("Worker: Job has been handed off via Worker Stop", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+			worker.handOffJob(job)
 			return
 
 		case <-time.After(TIME_BETWEEN_BATCHES * time.Millisecond):
@@ -134,6 +134,12 @@ func (worker *Worker) setJobError(job *model.Job, appError *model.AppError) {
 	}
 }
 
+func (worker *Worker) handOffJob(job *model.Job) {
+	if err := worker.app.Srv.Jobs.HandOffJob(job); err != nil {
+		mlog.Error("Worker: Failed to hand off job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
+
 func (worker *Worker) setJobCanceled(job *model.Job) {
 	if err := worker.app.Srv.Jobs.SetJobCanceled(job); err != nil {
 		mlog.Error("Worker: Failed to mark job as canceled", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
@@ -153,7 +159,11 @@ func (worker *Worker) runMigration(key string, lastDone string) (bool, string, *
 	case model.MIGRATION_KEY_ADVANCED_PERMISSIONS_PHASE_2:
 		done, progress, err = worker.runAdvancedPermissionsPhase2Migration(lastDone)
 	default:
-		return false, "", model.NewAppError("MigrationsWorker.runMigration", "migrations.worker.run_migration.unknown_key", map[string]interface{}{"key": key}, "", http.StatusInternalServerError)
+		version, ok := model.ParseSchemaBackfillMigrationKey(key)
+		if !ok {
+			return false, "", model.NewAppError("MigrationsWorker.runMigration", "migrations.worker.run_migration.unknown_key", map[string]interface{}{"key": key}, "", http.StatusInternalServerError)
+		}
+		done, progress, err = worker.runSchemaBackfillMigration(version)
 	}
 
 	if done {
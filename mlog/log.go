@@ -6,11 +6,9 @@ package mlog
 import (
 	"io"
 	"log"
-	"os"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 const (
@@ -45,12 +43,34 @@ type LoggerConfiguration struct {
 	FileJson      bool
 	FileLevel     string
 	FileLocation  string
+
+	// SubsystemLevels overrides the level of every sink for the entries logged by the given subsystems.
+	SubsystemLevels map[string]string
+
+	// Sampling logs, every second, the first SamplingInitial entries with a given level and message, then every
+	// SamplingThereafter-th entry, so that a flood of identical errors doesn't drown the logs.
+	EnableSampling     bool
+	SamplingInitial    int
+	SamplingThereafter int
+
+	EnableSyslog  bool
+	SyslogLevel   string
+	SyslogNetwork string
+	SyslogAddress string
+	SyslogTag     string
+
+	EnableTcp                    bool
+	TcpLevel                     string
+	TcpAddress                   string
+	TcpUseTLS                    bool
+	TcpSkipCertificateValidation bool
 }
 
 type Logger struct {
 	zap          *zap.Logger
 	consoleLevel zap.AtomicLevel
 	fileLevel    zap.AtomicLevel
+	sinks        *sinkSet
 }
 
 func getZapLevel(level string) zapcore.Level {
@@ -79,34 +99,25 @@ func makeEncoder(json bool) zapcore.Encoder {
 }
 
 func NewLogger(config *LoggerConfiguration) *Logger {
-	cores := []zapcore.Core{}
 	logger := &Logger{
 		consoleLevel: zap.NewAtomicLevelAt(getZapLevel(config.ConsoleLevel)),
 		fileLevel:    zap.NewAtomicLevelAt(getZapLevel(config.FileLevel)),
 	}
-
-	if config.EnableConsole {
-		writer := zapcore.Lock(os.Stdout)
-		core := zapcore.NewCore(makeEncoder(config.ConsoleJson), writer, logger.consoleLevel)
-		cores = append(cores, core)
-	}
-
-	if config.EnableFile {
-		writer := zapcore.AddSync(&lumberjack.Logger{
-			Filename: config.FileLocation,
-			MaxSize:  100,
-			Compress: true,
-		})
-		core := zapcore.NewCore(makeEncoder(config.FileJson), writer, logger.fileLevel)
-		cores = append(cores, core)
+	logger.sinks = &sinkSet{
+		consoleLevel: logger.consoleLevel,
+		fileLevel:    logger.fileLevel,
 	}
 
-	combinedCore := zapcore.NewTee(cores...)
+	errs := logger.sinks.configure(config)
 
-	logger.zap = zap.New(combinedCore,
+	logger.zap = zap.New(&dynamicCore{sinks: logger.sinks},
 		zap.AddCaller(),
 	)
 
+	for _, err := range errs {
+		logger.Error("Unable to set up a log sink", Err(err))
+	}
+
 	return logger
 }
 
@@ -115,6 +126,22 @@ func (l *Logger) ChangeLevels(config *LoggerConfiguration) {
 	l.fileLevel.SetLevel(getZapLevel(config.FileLevel))
 }
 
+// Reconfigure applies a new configuration to the logger and to the loggers derived from it, replacing their sinks
+// if needed, without losing the fields they were created with.
+func (l *Logger) Reconfigure(config *LoggerConfiguration) {
+	l.ChangeLevels(config)
+
+	for _, err := range l.sinks.configure(config) {
+		l.Error("Unable to set up a log sink", Err(err))
+	}
+}
+
+// Close stops shipping the logs to syslog and over TCP, dropping the entries not sent yet. The console and the log
+// file keep being logged to.
+func (l *Logger) Close() {
+	l.sinks.close()
+}
+
 func (l *Logger) SetConsoleLevel(level string) {
 	l.consoleLevel.SetLevel(getZapLevel(level))
 }
@@ -0,0 +1,189 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package mlog
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// sinkSet holds the sinks of a logger, shared with the loggers derived from it, and replaces them when the
+// configuration changes, without having to recreate the loggers.
+type sinkSet struct {
+	consoleLevel zap.AtomicLevel
+	fileLevel    zap.AtomicLevel
+
+	mutex      sync.Mutex
+	config     *LoggerConfiguration
+	generation uint64
+	file       *lumberjack.Logger
+	closers    []io.Closer
+
+	current atomic.Value // *sinkCore
+}
+
+type sinkCore struct {
+	generation uint64
+	core       zapcore.Core
+}
+
+func (s *sinkSet) load() *sinkCore {
+	return s.current.Load().(*sinkCore)
+}
+
+// configure replaces the sinks unless the configuration is unchanged. A sink which can't be set up is left out and
+// reported by the returned errors, while the others are still used.
+func (s *sinkSet) configure(config *LoggerConfiguration) []error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.config != nil && reflect.DeepEqual(*s.config, *config) {
+		return nil
+	}
+
+	// The log file is kept open while its location doesn't change, since the previous sinks may still be writing.
+	if s.file != nil && (!config.EnableFile || s.file.Filename != config.FileLocation) {
+		s.file.Close()
+		s.file = nil
+	}
+	if config.EnableFile && s.file == nil {
+		s.file = &lumberjack.Logger{
+			Filename: config.FileLocation,
+			MaxSize:  100,
+			Compress: true,
+		}
+	}
+
+	core, closers, errs := s.build(config)
+
+	s.generation++
+	s.current.Store(&sinkCore{generation: s.generation, core: core})
+
+	for _, closer := range s.closers {
+		closer.Close()
+	}
+	s.closers = closers
+
+	configCopy := *config
+	s.config = &configCopy
+
+	return errs
+}
+
+// close stops the sinks shipping the logs elsewhere than the console and the log file, which keep being used.
+func (s *sinkSet) close() {
+	s.mutex.Lock()
+	config := *s.config
+	s.mutex.Unlock()
+
+	config.EnableSyslog = false
+	config.EnableTcp = false
+	s.configure(&config)
+}
+
+func (s *sinkSet) build(config *LoggerConfiguration) (zapcore.Core, []io.Closer, []error) {
+	subsystemLevels := map[string]zapcore.Level{}
+	for subsystem, level := range config.SubsystemLevels {
+		subsystemLevels[subsystem] = getZapLevel(level)
+	}
+
+	cores := []zapcore.Core{}
+	closers := []io.Closer{}
+	errs := []error{}
+
+	if config.EnableConsole {
+		writer := zapcore.Lock(os.Stdout)
+		core := zapcore.NewCore(makeEncoder(config.ConsoleJson), writer, s.consoleLevel)
+		cores = append(cores, newSubsystemCore(core, s.consoleLevel, subsystemLevels))
+	}
+
+	if config.EnableFile {
+		core := zapcore.NewCore(makeEncoder(config.FileJson), zapcore.AddSync(s.file), s.fileLevel)
+		cores = append(cores, newSubsystemCore(core, s.fileLevel, subsystemLevels))
+	}
+
+	if config.EnableSyslog {
+		level := getZapLevel(config.SyslogLevel)
+		if core, closer, err := newSyslogCore(config, level); err != nil {
+			errs = append(errs, errors.Wrap(err, "unable to connect to syslog"))
+		} else {
+			cores = append(cores, newSubsystemCore(core, level, subsystemLevels))
+			closers = append(closers, closer)
+		}
+	}
+
+	if config.EnableTcp {
+		level := getZapLevel(config.TcpLevel)
+		writer := newTcpWriter(config.TcpAddress, config.TcpUseTLS, config.TcpSkipCertificateValidation)
+		core := zapcore.NewCore(makeEncoder(true), writer, level)
+		cores = append(cores, newSubsystemCore(core, level, subsystemLevels))
+		closers = append(closers, writer)
+	}
+
+	core := zapcore.NewTee(cores...)
+	if config.EnableSampling {
+		core = zapcore.NewSampler(core, time.Second, config.SamplingInitial, config.SamplingThereafter)
+	}
+
+	return core, closers, errs
+}
+
+// dynamicCore logs to the current sinks of a sinkSet, along with the fields added to the logger.
+type dynamicCore struct {
+	sinks  *sinkSet
+	fields []zapcore.Field
+
+	derived atomic.Value // *sinkCore, the current sinks with the fields added
+}
+
+func (c *dynamicCore) core() zapcore.Core {
+	current := c.sinks.load()
+	if len(c.fields) == 0 {
+		return current.core
+	}
+
+	if derived, ok := c.derived.Load().(*sinkCore); ok && derived.generation == current.generation {
+		return derived.core
+	}
+
+	derived := &sinkCore{generation: current.generation, core: current.core.With(c.fields)}
+	c.derived.Store(derived)
+	return derived.core
+}
+
+func (c *dynamicCore) Enabled(level zapcore.Level) bool {
+	return c.core().Enabled(level)
+}
+
+func (c *dynamicCore) With(fields []zapcore.Field) zapcore.Core {
+	combined := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	combined = append(combined, c.fields...)
+	combined = append(combined, fields...)
+
+	return &dynamicCore{
+		sinks:  c.sinks,
+		fields: combined,
+	}
+}
+
+func (c *dynamicCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.core().Check(entry, checked)
+}
+
+func (c *dynamicCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.core().Write(entry, fields)
+}
+
+func (c *dynamicCore) Sync() error {
+	return c.core().Sync()
+}
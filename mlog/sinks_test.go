@@ -0,0 +1,118 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package mlog_test
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/mlog"
+)
+
+func TestReconfigure(t *testing.T) {
+	tempDir, err := ioutil.TempDir(os.TempDir(), "TestReconfigure")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	config := &mlog.LoggerConfiguration{
+		EnableFile:   true,
+		FileJson:     true,
+		FileLevel:    mlog.LevelInfo,
+		FileLocation: filepath.Join(tempDir, "first.log"),
+	}
+	logger := mlog.NewLogger(config)
+	derived := logger.With(mlog.String("source", "derived"))
+
+	derived.Info("before")
+
+	newConfig := *config
+	newConfig.FileLocation = filepath.Join(tempDir, "second.log")
+	logger.Reconfigure(&newConfig)
+
+	derived.Info("after")
+
+	first, err := ioutil.ReadFile(config.FileLocation)
+	require.NoError(t, err)
+	second, err := ioutil.ReadFile(newConfig.FileLocation)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(first), `"msg":"before","source":"derived"`)
+	assert.NotContains(t, string(first), "after")
+	assert.Contains(t, string(second), `"msg":"after","source":"derived"`)
+}
+
+func TestSampling(t *testing.T) {
+	tempDir, err := ioutil.TempDir(os.TempDir(), "TestSampling")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	config := &mlog.LoggerConfiguration{
+		EnableFile:         true,
+		FileJson:           true,
+		FileLevel:          mlog.LevelInfo,
+		FileLocation:       filepath.Join(tempDir, "file.log"),
+		EnableSampling:     true,
+		SamplingInitial:    2,
+		SamplingThereafter: 100,
+	}
+	logger := mlog.NewLogger(config)
+
+	for i := 0; i < 10; i++ {
+		logger.Error("noisy error")
+	}
+	logger.Error("other error")
+
+	logs, err := ioutil.ReadFile(config.FileLocation)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, strings.Count(string(logs), "noisy error"))
+	assert.Equal(t, 1, strings.Count(string(logs), "other error"))
+}
+
+func TestTcpSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	logger := mlog.NewLogger(&mlog.LoggerConfiguration{
+		EnableTcp:  true,
+		TcpLevel:   mlog.LevelWarn,
+		TcpAddress: listener.Addr().String(),
+	})
+	defer logger.Close()
+
+	logger.Info("not shipped")
+	logger.Warn("shipped", mlog.String("key", "value"))
+
+	select {
+	case line := <-lines:
+		assert.Contains(t, line, `"level":"warn"`)
+		assert.Contains(t, line, `"msg":"shipped","key":"value"`)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for the log entry")
+	}
+}
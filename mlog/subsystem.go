@@ -0,0 +1,122 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package mlog
+
+import (
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	SubsystemStore     = "store"
+	SubsystemWebsocket = "websocket"
+	SubsystemPlugins   = "plugins"
+)
+
+// subsystemSources maps the source files, by their path relative to the root of the repository, to the subsystem
+// they log for. A path ending with a slash stands for a whole directory, and the longest matching path wins.
+var subsystemSources = map[string]string{
+	"store/":                  SubsystemStore,
+	"app/web_conn.go":         SubsystemWebsocket,
+	"app/web_hub.go":          SubsystemWebsocket,
+	"app/websocket_router.go": SubsystemWebsocket,
+	"api4/websocket.go":       SubsystemWebsocket,
+	"plugin/":                 SubsystemPlugins,
+	"app/plugin":              SubsystemPlugins,
+}
+
+// IsValidSubsystem reports whether the level of the given subsystem can be set.
+func IsValidSubsystem(subsystem string) bool {
+	for _, s := range subsystemSources {
+		if s == subsystem {
+			return true
+		}
+	}
+	return false
+}
+
+// subsystemOf returns the subsystem the caller of a logging function belongs to, or an empty string if it doesn't
+// belong to any.
+func subsystemOf(caller zapcore.EntryCaller) string {
+	if !caller.Defined {
+		return ""
+	}
+
+	file := filepath.ToSlash(caller.File)
+	if strings.Contains(file, "/vendor/") {
+		return ""
+	}
+
+	subsystem, longest := "", 0
+	for source, s := range subsystemSources {
+		if len(source) > longest && strings.Contains(file, "/"+source) {
+			subsystem, longest = s, len(source)
+		}
+	}
+	return subsystem
+}
+
+// subsystemCore overrides the level of a sink for the entries logged by the subsystems having their own level. As
+// the subsystem of an entry is only known once its caller is, the level is checked when writing the entry.
+type subsystemCore struct {
+	core   zapcore.Core
+	level  zapcore.LevelEnabler
+	levels map[string]zapcore.Level
+	lowest zapcore.Level
+}
+
+func newSubsystemCore(core zapcore.Core, level zapcore.LevelEnabler, levels map[string]zapcore.Level) zapcore.Core {
+	if len(levels) == 0 {
+		return core
+	}
+
+	lowest := zapcore.FatalLevel
+	for _, l := range levels {
+		if l < lowest {
+			lowest = l
+		}
+	}
+
+	return &subsystemCore{
+		core:   core,
+		level:  level,
+		levels: levels,
+		lowest: lowest,
+	}
+}
+
+func (c *subsystemCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level) || level >= c.lowest
+}
+
+func (c *subsystemCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.core = c.core.With(fields)
+	return &clone
+}
+
+func (c *subsystemCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *subsystemCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if level, ok := c.levels[subsystemOf(entry.Caller)]; ok {
+		if entry.Level < level {
+			return nil
+		}
+	} else if !c.level.Enabled(entry.Level) {
+		return nil
+	}
+
+	return c.core.Write(entry, fields)
+}
+
+func (c *subsystemCore) Sync() error {
+	return c.core.Sync()
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package mlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSubsystemOf(t *testing.T) {
+	for file, expected := range map[string]string{
+		"/go/src/github.com/mattermost/mattermost-server/store/sqlstore/post_store.go":             SubsystemStore,
+		"/go/src/github.com/mattermost/mattermost-server/app/web_conn.go":                          SubsystemWebsocket,
+		"/go/src/github.com/mattermost/mattermost-server/app/plugin_api.go":                        SubsystemPlugins,
+		"/go/src/github.com/mattermost/mattermost-server/plugin/supervisor.go":                     SubsystemPlugins,
+		"/go/src/github.com/mattermost/mattermost-server/app/post.go":                              "",
+		"/go/src/github.com/mattermost/mattermost-server/services/filesstore/s3store.go":           "",
+		"/go/src/github.com/mattermost/mattermost-server/vendor/github.com/example/store/store.go": "",
+	} {
+		assert.Equal(t, expected, subsystemOf(zapcore.EntryCaller{Defined: true, File: file}), file)
+	}
+
+	assert.Equal(t, "", subsystemOf(zapcore.EntryCaller{}))
+}
+
+func TestSubsystemCore(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	level := zapcore.InfoLevel
+	core := zapcore.NewCore(makeEncoder(true), zapcore.AddSync(buffer), level)
+
+	core = newSubsystemCore(core, level, map[string]zapcore.Level{
+		SubsystemStore:     zapcore.DebugLevel,
+		SubsystemWebsocket: zapcore.ErrorLevel,
+	})
+
+	log := func(file string, level zapcore.Level, message string) {
+		entry := zapcore.Entry{
+			Level:   level,
+			Message: message,
+			Caller:  zapcore.EntryCaller{Defined: true, File: "/mattermost-server/" + file},
+		}
+		if checked := core.Check(entry, nil); checked != nil {
+			checked.Write()
+		}
+	}
+
+	log("store/sqlstore/post_store.go", zapcore.DebugLevel, "store debug")
+	log("app/web_conn.go", zapcore.WarnLevel, "websocket warning")
+	log("app/web_conn.go", zapcore.ErrorLevel, "websocket error")
+	log("app/post.go", zapcore.DebugLevel, "app debug")
+	log("app/post.go", zapcore.InfoLevel, "app info")
+
+	logs := buffer.String()
+	assert.True(t, strings.Contains(logs, "store debug"))
+	assert.False(t, strings.Contains(logs, "websocket warning"))
+	assert.True(t, strings.Contains(logs, "websocket error"))
+	assert.False(t, strings.Contains(logs, "app debug"))
+	assert.True(t, strings.Contains(logs, "app info"))
+}
+
+func TestIsValidSubsystem(t *testing.T) {
+	assert.True(t, IsValidSubsystem(SubsystemStore))
+	assert.True(t, IsValidSubsystem(SubsystemWebsocket))
+	assert.True(t, IsValidSubsystem(SubsystemPlugins))
+	assert.False(t, IsValidSubsystem("junk"))
+	assert.False(t, IsValidSubsystem(""))
+}
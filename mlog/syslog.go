@@ -0,0 +1,82 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+//go:build !windows
+// +build !windows
+
+package mlog
+
+import (
+	"io"
+	"log/syslog"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// syslogCore sends each entry as JSON to the local syslog daemon, or to a remote one over UDP or TCP, with the
+// severity matching its level.
+type syslogCore struct {
+	zapcore.LevelEnabler
+	encoder zapcore.Encoder
+	writer  *syslog.Writer
+}
+
+func newSyslogCore(config *LoggerConfiguration, level zapcore.LevelEnabler) (zapcore.Core, io.Closer, error) {
+	address := config.SyslogAddress
+	if config.SyslogNetwork == "" {
+		address = ""
+	}
+
+	writer, err := syslog.Dial(config.SyslogNetwork, address, syslog.LOG_INFO|syslog.LOG_USER, config.SyslogTag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	core := &syslogCore{
+		LevelEnabler: level,
+		encoder:      makeEncoder(true),
+		writer:       writer,
+	}
+	return core, writer, nil
+}
+
+func (c *syslogCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.encoder = c.encoder.Clone()
+	for _, field := range fields {
+		field.AddTo(clone.encoder)
+	}
+	return &clone
+}
+
+func (c *syslogCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *syslogCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+	message := strings.TrimSuffix(buf.String(), "\n")
+	buf.Free()
+
+	switch entry.Level {
+	case zapcore.DebugLevel:
+		return c.writer.Debug(message)
+	case zapcore.InfoLevel:
+		return c.writer.Info(message)
+	case zapcore.WarnLevel:
+		return c.writer.Warning(message)
+	default:
+		return c.writer.Err(message)
+	}
+}
+
+func (c *syslogCore) Sync() error {
+	return nil
+}
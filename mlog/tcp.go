@@ -0,0 +1,120 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package mlog
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	TCP_SINK_QUEUE_SIZE    = 10000
+	TCP_SINK_DIAL_TIMEOUT  = 5 * time.Second
+	TCP_SINK_WRITE_TIMEOUT = 5 * time.Second
+	TCP_SINK_RETRY_DELAY   = 5 * time.Second
+)
+
+// tcpWriter ships log entries, one JSON document per line, to a TCP endpoint such as the tcp input of Logstash,
+// optionally over TLS. Entries are queued and sent from a goroutine, reconnecting as needed, so that an unreachable
+// endpoint never blocks logging: entries are dropped once the queue is full.
+type tcpWriter struct {
+	address   string
+	tlsConfig *tls.Config
+
+	queue     chan []byte
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+func newTcpWriter(address string, useTLS, skipCertificateValidation bool) *tcpWriter {
+	w := &tcpWriter{
+		address: address,
+		queue:   make(chan []byte, TCP_SINK_QUEUE_SIZE),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	if useTLS {
+		w.tlsConfig = &tls.Config{
+			InsecureSkipVerify: skipCertificateValidation,
+		}
+	}
+
+	go w.run()
+
+	return w
+}
+
+func (w *tcpWriter) Write(p []byte) (int, error) {
+	// The encoder reuses its buffer once the entry is written.
+	line := make([]byte, len(p))
+	copy(line, p)
+
+	select {
+	case w.queue <- line:
+	default:
+	}
+
+	return len(p), nil
+}
+
+func (w *tcpWriter) Sync() error {
+	return nil
+}
+
+func (w *tcpWriter) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.done)
+	})
+	<-w.stopped
+
+	return nil
+}
+
+func (w *tcpWriter) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: TCP_SINK_DIAL_TIMEOUT}
+	if w.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, "tcp", w.address, w.tlsConfig)
+	}
+	return dialer.Dial("tcp", w.address)
+}
+
+func (w *tcpWriter) run() {
+	defer close(w.stopped)
+
+	var conn net.Conn
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	for {
+		select {
+		case line := <-w.queue:
+			for conn == nil {
+				var err error
+				if conn, err = w.dial(); err != nil {
+					conn = nil
+					select {
+					case <-time.After(TCP_SINK_RETRY_DELAY):
+					case <-w.done:
+						return
+					}
+				}
+			}
+
+			conn.SetWriteDeadline(time.Now().Add(TCP_SINK_WRITE_TIMEOUT))
+			if _, err := conn.Write(line); err != nil {
+				conn.Close()
+				conn = nil
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
@@ -23,6 +23,9 @@ type AccessData struct {
 	RedirectUri  string `json:"redirect_uri"`
 	ExpiresAt    int64  `json:"expires_at"`
 	Scope        string `json:"scope"`
+	// PreviousRefreshToken is the refresh token replaced by the last rotation. Presenting it again means
+	// the token was leaked, so the grant is revoked.
+	PreviousRefreshToken string `json:"-"`
 }
 
 type AccessResponse struct {
@@ -49,7 +52,7 @@ func (ad *AccessData) IsValid() *AppError {
 		return NewAppError("AccessData.IsValid", "model.access.is_valid.access_token.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if len(ad.RefreshToken) > 26 {
+	if len(ad.RefreshToken) > 26 || len(ad.PreviousRefreshToken) > 26 {
 		return NewAppError("AccessData.IsValid", "model.access.is_valid.refresh_token.app_error", nil, "", http.StatusBadRequest)
 	}
 
@@ -0,0 +1,221 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	ANNOUNCEMENT_TITLE_MAX_RUNES   = 128
+	ANNOUNCEMENT_MESSAGE_MAX_RUNES = POST_MESSAGE_MAX_RUNES_V2
+	ANNOUNCEMENT_AUDIENCE_MAX_IDS  = 100
+
+	ANNOUNCEMENT_RECIPIENT_STATUS_PENDING   = "pending"
+	ANNOUNCEMENT_RECIPIENT_STATUS_DELIVERED = "delivered"
+	ANNOUNCEMENT_RECIPIENT_STATUS_FAILED    = "failed"
+
+	ANNOUNCEMENT_POST_PROP_ID = "announcement_id"
+)
+
+// Announcement is a message from the administrators to the users of its audience, sent as a direct message from the
+// system bot, shown as a banner until acknowledged, or both. The audience is narrowed down by teams, roles and
+// groups: a user must be on one of its teams, have one of its roles and belong to one of its groups, for each of
+// those that are set. Everyone is in the audience of an announcement without any.
+type Announcement struct {
+	Id                    string      `json:"id"`
+	CreatorId             string      `json:"creator_id"`
+	CreateAt              int64       `json:"create_at"`
+	DeleteAt              int64       `json:"delete_at"`
+	ExpireAt              int64       `json:"expire_at"`
+	Title                 string      `json:"title"`
+	Message               string      `json:"message"`
+	SendDirectMessage     bool        `json:"send_direct_message"`
+	ShowBanner            bool        `json:"show_banner"`
+	RequireAcknowledgment bool        `json:"require_acknowledgment"`
+	TeamIds               StringArray `json:"team_ids"`
+	Roles                 StringArray `json:"roles"`
+	GroupIds              StringArray `json:"group_ids"`
+}
+
+// AnnouncementRecipient tracks the delivery of an announcement to a user of its audience, and their acknowledgment
+// of it.
+type AnnouncementRecipient struct {
+	AnnouncementId string `json:"announcement_id"`
+	UserId         string `json:"user_id"`
+	Status         string `json:"status"`
+	PostId         string `json:"post_id"`
+	DeliverAt      int64  `json:"deliver_at"`
+	AcknowledgeAt  int64  `json:"acknowledge_at"`
+}
+
+// AnnouncementReport sums up the delivery of an announcement to its audience.
+type AnnouncementReport struct {
+	AnnouncementId string `json:"announcement_id"`
+	Total          int64  `json:"total"`
+	Pending        int64  `json:"pending"`
+	Delivered      int64  `json:"delivered"`
+	Failed         int64  `json:"failed"`
+	Acknowledged   int64  `json:"acknowledged"`
+}
+
+func (o *Announcement) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("Announcement.IsValid", "model.announcement.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("Announcement.IsValid", "model.announcement.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("Announcement.IsValid", "model.announcement.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.ExpireAt != 0 && o.ExpireAt <= o.CreateAt {
+		return NewAppError("Announcement.IsValid", "model.announcement.is_valid.expire_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Title == "" || utf8.RuneCountInString(o.Title) > ANNOUNCEMENT_TITLE_MAX_RUNES {
+		return NewAppError("Announcement.IsValid", "model.announcement.is_valid.title.app_error", map[string]interface{}{"Max": ANNOUNCEMENT_TITLE_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Message == "" || utf8.RuneCountInString(o.Message) > ANNOUNCEMENT_MESSAGE_MAX_RUNES {
+		return NewAppError("Announcement.IsValid", "model.announcement.is_valid.message.app_error", map[string]interface{}{"Max": ANNOUNCEMENT_MESSAGE_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !o.SendDirectMessage && !o.ShowBanner {
+		return NewAppError("Announcement.IsValid", "model.announcement.is_valid.delivery.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.TeamIds) > ANNOUNCEMENT_AUDIENCE_MAX_IDS || len(o.Roles) > ANNOUNCEMENT_AUDIENCE_MAX_IDS || len(o.GroupIds) > ANNOUNCEMENT_AUDIENCE_MAX_IDS {
+		return NewAppError("Announcement.IsValid", "model.announcement.is_valid.audience.app_error", map[string]interface{}{"Max": ANNOUNCEMENT_AUDIENCE_MAX_IDS}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, id := range append(append([]string{}, o.TeamIds...), o.GroupIds...) {
+		if !IsValidId(id) {
+			return NewAppError("Announcement.IsValid", "model.announcement.is_valid.audience.app_error", map[string]interface{}{"Max": ANNOUNCEMENT_AUDIENCE_MAX_IDS}, "id="+o.Id, http.StatusBadRequest)
+		}
+	}
+
+	for _, role := range o.Roles {
+		if !IsValidRoleName(role) {
+			return NewAppError("Announcement.IsValid", "model.announcement.is_valid.audience.app_error", map[string]interface{}{"Max": ANNOUNCEMENT_AUDIENCE_MAX_IDS}, "id="+o.Id, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (o *Announcement) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+
+	if o.TeamIds == nil {
+		o.TeamIds = StringArray{}
+	}
+
+	if o.Roles == nil {
+		o.Roles = StringArray{}
+	}
+
+	if o.GroupIds == nil {
+		o.GroupIds = StringArray{}
+	}
+}
+
+// IsActive returns whether the announcement is still shown to its audience at the given time.
+func (o *Announcement) IsActive(now int64) bool {
+	return o.DeleteAt == 0 && (o.ExpireAt == 0 || o.ExpireAt > now)
+}
+
+// IncludesUser returns whether the user is in the audience of the announcement, given the ids of the teams they are on
+// and of the groups they belong to. Bots and deactivated users never are.
+func (o *Announcement) IncludesUser(user *User, teamIds map[string]bool, groupIds map[string]bool) bool {
+	if user.IsBot || user.DeleteAt != 0 {
+		return false
+	}
+
+	if len(o.TeamIds) > 0 && !stringsIntersect(o.TeamIds, teamIds) {
+		return false
+	}
+
+	if len(o.Roles) > 0 {
+		userRoles := map[string]bool{}
+		for _, role := range user.GetRoles() {
+			userRoles[role] = true
+		}
+		if !stringsIntersect(o.Roles, userRoles) {
+			return false
+		}
+	}
+
+	if len(o.GroupIds) > 0 && !stringsIntersect(o.GroupIds, groupIds) {
+		return false
+	}
+
+	return true
+}
+
+func stringsIntersect(values []string, set map[string]bool) bool {
+	for _, value := range values {
+		if set[value] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *Announcement) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func AnnouncementFromJson(data io.Reader) *Announcement {
+	var o *Announcement
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func AnnouncementListToJson(l []*Announcement) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func AnnouncementListFromJson(data io.Reader) []*Announcement {
+	var o []*Announcement
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func AnnouncementRecipientListToJson(l []*AnnouncementRecipient) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func AnnouncementRecipientListFromJson(data io.Reader) []*AnnouncementRecipient {
+	var o []*AnnouncementRecipient
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *AnnouncementReport) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func AnnouncementReportFromJson(data io.Reader) *AnnouncementReport {
+	var o *AnnouncementReport
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnouncementJson(t *testing.T) {
+	announcement := Announcement{Id: NewId(), CreatorId: NewId(), Title: "title", Message: "message", ShowBanner: true, TeamIds: StringArray{NewId()}}
+	result := AnnouncementFromJson(strings.NewReader(announcement.ToJson()))
+	assert.Equal(t, announcement, *result)
+
+	list := AnnouncementListFromJson(strings.NewReader(AnnouncementListToJson([]*Announcement{&announcement})))
+	require.Len(t, list, 1)
+	assert.Equal(t, announcement, *list[0])
+
+	report := AnnouncementReport{AnnouncementId: announcement.Id, Total: 3, Delivered: 2, Failed: 1, Acknowledged: 1}
+	assert.Equal(t, report, *AnnouncementReportFromJson(strings.NewReader(report.ToJson())))
+}
+
+func TestAnnouncementIsValid(t *testing.T) {
+	announcement := Announcement{CreatorId: NewId(), Title: "title", Message: "message", SendDirectMessage: true}
+	announcement.PreSave()
+	require.Nil(t, announcement.IsValid())
+
+	for name, update := range map[string]func(a *Announcement){
+		"id":         func(a *Announcement) { a.Id = "abc" },
+		"creator id": func(a *Announcement) { a.CreatorId = "" },
+		"create at":  func(a *Announcement) { a.CreateAt = 0 },
+		"expire at":  func(a *Announcement) { a.ExpireAt = a.CreateAt },
+		"title":      func(a *Announcement) { a.Title = strings.Repeat("a", ANNOUNCEMENT_TITLE_MAX_RUNES+1) },
+		"message":    func(a *Announcement) { a.Message = "" },
+		"delivery":   func(a *Announcement) { a.SendDirectMessage = false },
+		"team ids":   func(a *Announcement) { a.TeamIds = StringArray{"abc"} },
+		"roles":      func(a *Announcement) { a.Roles = StringArray{"not a role"} },
+		"group ids":  func(a *Announcement) { a.GroupIds = make(StringArray, ANNOUNCEMENT_AUDIENCE_MAX_IDS+1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := announcement
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestAnnouncementIsActive(t *testing.T) {
+	announcement := Announcement{ExpireAt: 200}
+	assert.True(t, announcement.IsActive(100))
+	assert.False(t, announcement.IsActive(200))
+
+	announcement.ExpireAt = 0
+	assert.True(t, announcement.IsActive(100))
+
+	announcement.DeleteAt = 50
+	assert.False(t, announcement.IsActive(100))
+}
+
+func TestAnnouncementIncludesUser(t *testing.T) {
+	teamId := NewId()
+	groupId := NewId()
+	user := &User{Id: NewId(), Roles: SYSTEM_USER_ROLE_ID}
+
+	everyone := Announcement{}
+	assert.True(t, everyone.IncludesUser(user, nil, nil))
+	assert.False(t, everyone.IncludesUser(&User{Id: NewId(), DeleteAt: 1}, nil, nil), "deactivated users shouldn't be included")
+	assert.False(t, everyone.IncludesUser(&User{Id: NewId(), IsBot: true}, nil, nil), "bots shouldn't be included")
+
+	team := Announcement{TeamIds: StringArray{teamId, NewId()}}
+	assert.True(t, team.IncludesUser(user, map[string]bool{teamId: true}, nil))
+	assert.False(t, team.IncludesUser(user, map[string]bool{NewId(): true}, nil))
+
+	admins := Announcement{Roles: StringArray{SYSTEM_ADMIN_ROLE_ID}}
+	assert.False(t, admins.IncludesUser(user, nil, nil))
+	assert.True(t, admins.IncludesUser(&User{Id: NewId(), Roles: SYSTEM_USER_ROLE_ID + " " + SYSTEM_ADMIN_ROLE_ID}, nil, nil))
+
+	teamAndGroup := Announcement{TeamIds: StringArray{teamId}, GroupIds: StringArray{groupId}}
+	assert.False(t, teamAndGroup.IncludesUser(user, map[string]bool{teamId: true}, nil), "the user must match every part of the audience")
+	assert.True(t, teamAndGroup.IncludesUser(user, map[string]bool{teamId: true}, map[string]bool{groupId: true}))
+}
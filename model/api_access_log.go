@@ -0,0 +1,190 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+)
+
+const (
+	API_ACCESS_LOG_IDENTITY_TOKEN     = "token"
+	API_ACCESS_LOG_IDENTITY_WEBHOOK   = "webhook"
+	API_ACCESS_LOG_IDENTITY_USER      = "user"
+	API_ACCESS_LOG_IDENTITY_ANONYMOUS = "anonymous"
+
+	API_ACCESS_LOG_SUMMARY_MAX_SIZE = 100
+)
+
+// ApiAccessLogEntry records a request served by the API, along with who made it. Only a sample of the requests
+// may be logged, in which case SamplePercent is the share of the requests like this one that were.
+type ApiAccessLogEntry struct {
+	Timestamp     int64   `json:"timestamp"`
+	RequestId     string  `json:"request_id"`
+	Method        string  `json:"method"`
+	Route         string  `json:"route"`
+	Path          string  `json:"path"`
+	StatusCode    int     `json:"status_code"`
+	DurationMs    float64 `json:"duration_ms"`
+	UserId        string  `json:"user_id,omitempty"`
+	TokenId       string  `json:"token_id,omitempty"`
+	WebhookId     string  `json:"webhook_id,omitempty"`
+	IpAddress     string  `json:"ip_address"`
+	UserAgent     string  `json:"user_agent,omitempty"`
+	SamplePercent int     `json:"sample_percent"`
+}
+
+// ApiAccessLogQuery filters the API access log. Fields left empty match every entry.
+type ApiAccessLogQuery struct {
+	Since         int64
+	Until         int64
+	UserId        string
+	TokenId       string
+	WebhookId     string
+	Route         string
+	MinStatusCode int
+	Page          int
+	PerPage       int
+}
+
+// ApiAccessLogSummary sums up the requests made by the same user, user access token or webhook. Requests is
+// the number of requests logged, and EstimatedRequests accounts for those left out by sampling.
+type ApiAccessLogSummary struct {
+	IdentityType      string  `json:"identity_type"`
+	IdentityId        string  `json:"identity_id"`
+	Requests          int64   `json:"requests"`
+	EstimatedRequests int64   `json:"estimated_requests"`
+	Errors            int64   `json:"errors"`
+	TotalDurationMs   float64 `json:"total_duration_ms"`
+}
+
+// Identity returns who made the request, preferring the user access token or webhook used to make it over the
+// user it was made as.
+func (e *ApiAccessLogEntry) Identity() (string, string) {
+	switch {
+	case e.TokenId != "":
+		return API_ACCESS_LOG_IDENTITY_TOKEN, e.TokenId
+	case e.WebhookId != "":
+		return API_ACCESS_LOG_IDENTITY_WEBHOOK, e.WebhookId
+	case e.UserId != "":
+		return API_ACCESS_LOG_IDENTITY_USER, e.UserId
+	default:
+		return API_ACCESS_LOG_IDENTITY_ANONYMOUS, ""
+	}
+}
+
+func (e *ApiAccessLogEntry) IsError() bool {
+	return e.StatusCode >= 400
+}
+
+func (e *ApiAccessLogEntry) ToJson() string {
+	b, _ := json.Marshal(e)
+	return string(b)
+}
+
+func ApiAccessLogEntryFromJson(data io.Reader) *ApiAccessLogEntry {
+	var e *ApiAccessLogEntry
+	json.NewDecoder(data).Decode(&e)
+	return e
+}
+
+func ApiAccessLogEntryListToJson(l []*ApiAccessLogEntry) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ApiAccessLogEntryListFromJson(data io.Reader) []*ApiAccessLogEntry {
+	var l []*ApiAccessLogEntry
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
+
+func (q *ApiAccessLogQuery) Matches(e *ApiAccessLogEntry) bool {
+	if q.Since > 0 && e.Timestamp < q.Since {
+		return false
+	}
+
+	if q.Until > 0 && e.Timestamp > q.Until {
+		return false
+	}
+
+	if q.UserId != "" && e.UserId != q.UserId {
+		return false
+	}
+
+	if q.TokenId != "" && e.TokenId != q.TokenId {
+		return false
+	}
+
+	if q.WebhookId != "" && e.WebhookId != q.WebhookId {
+		return false
+	}
+
+	if q.Route != "" && e.Route != q.Route {
+		return false
+	}
+
+	return e.StatusCode >= q.MinStatusCode
+}
+
+// SummarizeApiAccessLog groups the entries by identity, with the identities making the most requests first.
+func SummarizeApiAccessLog(entries []*ApiAccessLogEntry) []*ApiAccessLogSummary {
+	summaries := map[string]*ApiAccessLogSummary{}
+	estimates := map[string]float64{}
+
+	for _, entry := range entries {
+		identityType, identityId := entry.Identity()
+		key := identityType + ":" + identityId
+
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &ApiAccessLogSummary{IdentityType: identityType, IdentityId: identityId}
+			summaries[key] = summary
+		}
+
+		summary.Requests++
+		summary.TotalDurationMs += entry.DurationMs
+		if entry.IsError() {
+			summary.Errors++
+		}
+
+		if entry.SamplePercent > 0 {
+			estimates[key] += 100 / float64(entry.SamplePercent)
+		} else {
+			estimates[key]++
+		}
+	}
+
+	list := make([]*ApiAccessLogSummary, 0, len(summaries))
+	for key, summary := range summaries {
+		summary.EstimatedRequests = int64(math.Round(estimates[key]))
+		list = append(list, summary)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].EstimatedRequests != list[j].EstimatedRequests {
+			return list[i].EstimatedRequests > list[j].EstimatedRequests
+		}
+		return list[i].IdentityType+list[i].IdentityId < list[j].IdentityType+list[j].IdentityId
+	})
+
+	if len(list) > API_ACCESS_LOG_SUMMARY_MAX_SIZE {
+		list = list[:API_ACCESS_LOG_SUMMARY_MAX_SIZE]
+	}
+
+	return list
+}
+
+func ApiAccessLogSummaryListToJson(l []*ApiAccessLogSummary) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ApiAccessLogSummaryListFromJson(data io.Reader) []*ApiAccessLogSummary {
+	var l []*ApiAccessLogSummary
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiAccessLogEntryIdentity(t *testing.T) {
+	userId, tokenId, webhookId := NewId(), NewId(), NewId()
+
+	identityType, identityId := (&ApiAccessLogEntry{UserId: userId, TokenId: tokenId}).Identity()
+	assert.Equal(t, API_ACCESS_LOG_IDENTITY_TOKEN, identityType)
+	assert.Equal(t, tokenId, identityId)
+
+	identityType, identityId = (&ApiAccessLogEntry{WebhookId: webhookId}).Identity()
+	assert.Equal(t, API_ACCESS_LOG_IDENTITY_WEBHOOK, identityType)
+	assert.Equal(t, webhookId, identityId)
+
+	identityType, identityId = (&ApiAccessLogEntry{UserId: userId}).Identity()
+	assert.Equal(t, API_ACCESS_LOG_IDENTITY_USER, identityType)
+	assert.Equal(t, userId, identityId)
+
+	identityType, identityId = (&ApiAccessLogEntry{}).Identity()
+	assert.Equal(t, API_ACCESS_LOG_IDENTITY_ANONYMOUS, identityType)
+	assert.Empty(t, identityId)
+}
+
+func TestApiAccessLogQueryMatches(t *testing.T) {
+	entry := &ApiAccessLogEntry{Timestamp: 100, Route: "getUser", StatusCode: http.StatusNotFound, UserId: NewId()}
+
+	assert.True(t, (&ApiAccessLogQuery{}).Matches(entry))
+	assert.True(t, (&ApiAccessLogQuery{Since: 100, Until: 100, UserId: entry.UserId, Route: "getUser", MinStatusCode: 400}).Matches(entry))
+	assert.False(t, (&ApiAccessLogQuery{Since: 101}).Matches(entry))
+	assert.False(t, (&ApiAccessLogQuery{Until: 99}).Matches(entry))
+	assert.False(t, (&ApiAccessLogQuery{UserId: NewId()}).Matches(entry))
+	assert.False(t, (&ApiAccessLogQuery{TokenId: NewId()}).Matches(entry))
+	assert.False(t, (&ApiAccessLogQuery{Route: "getUsers"}).Matches(entry))
+	assert.False(t, (&ApiAccessLogQuery{MinStatusCode: 500}).Matches(entry))
+}
+
+func TestSummarizeApiAccessLog(t *testing.T) {
+	userId, webhookId := NewId(), NewId()
+
+	summaries := SummarizeApiAccessLog([]*ApiAccessLogEntry{
+		{UserId: userId, StatusCode: http.StatusOK, DurationMs: 10, SamplePercent: 100},
+		{WebhookId: webhookId, StatusCode: http.StatusOK, DurationMs: 5, SamplePercent: 10},
+		{WebhookId: webhookId, StatusCode: http.StatusBadRequest, DurationMs: 5, SamplePercent: 100},
+		{UserId: userId, StatusCode: http.StatusOK, DurationMs: 20, SamplePercent: 100},
+	})
+
+	require.Len(t, summaries, 2)
+
+	assert.Equal(t, API_ACCESS_LOG_IDENTITY_WEBHOOK, summaries[0].IdentityType)
+	assert.Equal(t, webhookId, summaries[0].IdentityId)
+	assert.Equal(t, int64(2), summaries[0].Requests)
+	assert.Equal(t, int64(11), summaries[0].EstimatedRequests)
+	assert.Equal(t, int64(1), summaries[0].Errors)
+
+	assert.Equal(t, API_ACCESS_LOG_IDENTITY_USER, summaries[1].IdentityType)
+	assert.Equal(t, int64(2), summaries[1].EstimatedRequests)
+	assert.Equal(t, float64(30), summaries[1].TotalDurationMs)
+	assert.Zero(t, summaries[1].Errors)
+}
@@ -6,6 +6,13 @@ package model
 import (
 	"encoding/json"
 	"io"
+	"strings"
+)
+
+const (
+	AUDIT_RESULT_ATTEMPT = "attempt"
+	AUDIT_RESULT_SUCCESS = "success"
+	AUDIT_RESULT_FAIL    = "fail"
 )
 
 type Audit struct {
@@ -18,6 +25,21 @@ type Audit struct {
 	SessionId string `json:"session_id"`
 }
 
+// AuditEvent is a structured audit record of an action taken by an actor on a target, as it is sent to the
+// configured audit sinks. Events saved to the database are stored as an Audit.
+type AuditEvent struct {
+	Id        string    `json:"id"`
+	CreateAt  int64     `json:"create_at"`
+	ActorId   string    `json:"actor_id"`
+	SessionId string    `json:"session_id"`
+	IpAddress string    `json:"ip_address"`
+	Action    string    `json:"action"`
+	Method    string    `json:"method,omitempty"`
+	Target    StringMap `json:"target,omitempty"`
+	Result    string    `json:"result"`
+	Details   string    `json:"details,omitempty"`
+}
+
 func (o *Audit) ToJson() string {
 	b, _ := json.Marshal(o)
 	return string(b)
@@ -28,3 +50,53 @@ func AuditFromJson(data io.Reader) *Audit {
 	json.NewDecoder(data).Decode(&o)
 	return o
 }
+
+func (o *AuditEvent) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+// ToAudit returns the event in the form it is saved to the database with.
+func (o *AuditEvent) ToAudit() *Audit {
+	return &Audit{
+		Id:        o.Id,
+		CreateAt:  o.CreateAt,
+		UserId:    o.ActorId,
+		Action:    o.Action,
+		ExtraInfo: o.Details,
+		IpAddress: o.IpAddress,
+		SessionId: o.SessionId,
+	}
+}
+
+func (o *AuditEvent) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func AuditEventFromJson(data io.Reader) *AuditEvent {
+	var o *AuditEvent
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// AuditResultFromExtraInfo returns the result of an audited action from the free form extra info it was
+// historically logged with, such as "attempt" or "success - user_id=...". Anything not starting with a known
+// result is reported as a success, since those entries record completed actions.
+func AuditResultFromExtraInfo(extraInfo string) string {
+	extraInfo = strings.ToLower(strings.TrimSpace(extraInfo))
+
+	switch {
+	case strings.HasPrefix(extraInfo, AUDIT_RESULT_ATTEMPT):
+		return AUDIT_RESULT_ATTEMPT
+	case strings.HasPrefix(extraInfo, AUDIT_RESULT_FAIL):
+		return AUDIT_RESULT_FAIL
+	}
+
+	return AUDIT_RESULT_SUCCESS
+}
@@ -6,6 +6,8 @@ package model
 import (
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAuditJson(t *testing.T) {
@@ -17,3 +19,33 @@ func TestAuditJson(t *testing.T) {
 		t.Fatal("Ids do not match")
 	}
 }
+
+func TestAuditEventToAudit(t *testing.T) {
+	event := AuditEvent{ActorId: NewId(), SessionId: NewId(), IpAddress: "127.0.0.1", Action: "/api/v4/users", Result: AUDIT_RESULT_SUCCESS, Details: "success - user_id=abc"}
+	event.PreSave()
+
+	audit := event.ToAudit()
+	assert.Equal(t, event.Id, audit.Id)
+	assert.Equal(t, event.CreateAt, audit.CreateAt)
+	assert.Equal(t, event.ActorId, audit.UserId)
+	assert.Equal(t, event.SessionId, audit.SessionId)
+	assert.Equal(t, event.IpAddress, audit.IpAddress)
+	assert.Equal(t, event.Action, audit.Action)
+	assert.Equal(t, event.Details, audit.ExtraInfo)
+}
+
+func TestAuditResultFromExtraInfo(t *testing.T) {
+	for extraInfo, expected := range map[string]string{
+		"attempt":                          AUDIT_RESULT_ATTEMPT,
+		"attempt - user_id=abc":            AUDIT_RESULT_ATTEMPT,
+		"success":                          AUDIT_RESULT_SUCCESS,
+		"success - token":                  AUDIT_RESULT_SUCCESS,
+		"fail - inappropriate permissions": AUDIT_RESULT_FAIL,
+		"failed - invalid license":         AUDIT_RESULT_FAIL,
+		"Failure - Login attempt":          AUDIT_RESULT_FAIL,
+		"":                                 AUDIT_RESULT_SUCCESS,
+		"name=town-square":                 AUDIT_RESULT_SUCCESS,
+	} {
+		assert.Equal(t, expected, AuditResultFromExtraInfo(extraInfo), extraInfo)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 )
 
 const (
@@ -14,8 +16,26 @@ const (
 	AUTHCODE_RESPONSE_TYPE = "code"
 	IMPLICIT_RESPONSE_TYPE = "token"
 	DEFAULT_SCOPE          = "user"
+
+	OAUTH_SCOPE_READ_POSTS  = "read:posts"
+	OAUTH_SCOPE_WRITE_POSTS = "write:posts"
+	OAUTH_SCOPE_READ_USERS  = "read:users"
 )
 
+// OAuthScope describes a scope that an OAuth app can request, as shown to the user on the consent screen.
+type OAuthScope struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// OAuthScopes lists the granular scopes that OAuth apps can request in addition to the default scope,
+// which grants the same access as the user.
+var OAuthScopes = StringArray{
+	OAUTH_SCOPE_READ_POSTS,
+	OAUTH_SCOPE_WRITE_POSTS,
+	OAUTH_SCOPE_READ_USERS,
+}
+
 type AuthData struct {
 	ClientId    string `json:"client_id"`
 	UserId      string `json:"user_id"`
@@ -101,6 +121,53 @@ func (ar *AuthorizeRequest) IsValid() *AppError {
 	return nil
 }
 
+// ParseOAuthScope splits a space separated scope into its distinct scopes.
+func ParseOAuthScope(scope string) StringArray {
+	scopes := StringArray{}
+	for _, s := range strings.Fields(scope) {
+		if !scopes.Contains(s) {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// IsRestrictedOAuthScope returns true if the scope grants less than full access to the user's account,
+// which is the case when granular scopes were requested without the default scope. Other scopes are
+// ignored, so apps that never requested a granular scope keep full access.
+func IsRestrictedOAuthScope(scope string) bool {
+	scopes := ParseOAuthScope(scope)
+	if scopes.Contains(DEFAULT_SCOPE) {
+		return false
+	}
+
+	for _, s := range scopes {
+		if OAuthScopes.Contains(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// OAuthScopeAllows returns true if the granted scope gives access to the required one.
+func OAuthScopeAllows(granted, required string) bool {
+	if !IsRestrictedOAuthScope(granted) {
+		return true
+	}
+	return required != "" && ParseOAuthScope(granted).Contains(required)
+}
+
+// NormalizeOAuthScope returns the scope sorted, with duplicates removed and the default scope when empty,
+// so that scopes can be compared.
+func NormalizeOAuthScope(scope string) string {
+	scopes := ParseOAuthScope(scope)
+	if len(scopes) == 0 {
+		return DEFAULT_SCOPE
+	}
+	sort.Strings(scopes)
+	return strings.Join(scopes, " ")
+}
+
 func (ad *AuthData) PreSave() {
 	if ad.ExpiresIn == 0 {
 		ad.ExpiresIn = AUTHCODE_EXPIRE_TIME
@@ -140,3 +207,14 @@ func AuthorizeRequestFromJson(data io.Reader) *AuthorizeRequest {
 func (ad *AuthData) IsExpired() bool {
 	return GetMillis() > ad.CreateAt+int64(ad.ExpiresIn*1000)
 }
+
+func OAuthScopeListToJson(l []*OAuthScope) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func OAuthScopeListFromJson(data io.Reader) []*OAuthScope {
+	var o []*OAuthScope
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -133,3 +133,24 @@ func TestAuthIsValid(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestOAuthScope(t *testing.T) {
+	require.Equal(t, StringArray{OAUTH_SCOPE_READ_POSTS, OAUTH_SCOPE_READ_USERS}, ParseOAuthScope(" read:posts  read:users read:posts "))
+	require.Empty(t, ParseOAuthScope(""))
+
+	require.False(t, IsRestrictedOAuthScope(""))
+	require.False(t, IsRestrictedOAuthScope(DEFAULT_SCOPE))
+	require.False(t, IsRestrictedOAuthScope("all"))
+	require.False(t, IsRestrictedOAuthScope(DEFAULT_SCOPE+" "+OAUTH_SCOPE_READ_POSTS))
+	require.True(t, IsRestrictedOAuthScope(OAUTH_SCOPE_READ_POSTS))
+	require.True(t, IsRestrictedOAuthScope("all "+OAUTH_SCOPE_READ_USERS))
+
+	require.True(t, OAuthScopeAllows(DEFAULT_SCOPE, ""))
+	require.True(t, OAuthScopeAllows(DEFAULT_SCOPE, OAUTH_SCOPE_WRITE_POSTS))
+	require.True(t, OAuthScopeAllows(OAUTH_SCOPE_READ_POSTS+" "+OAUTH_SCOPE_WRITE_POSTS, OAUTH_SCOPE_WRITE_POSTS))
+	require.False(t, OAuthScopeAllows(OAUTH_SCOPE_READ_POSTS, OAUTH_SCOPE_WRITE_POSTS))
+	require.False(t, OAuthScopeAllows(OAUTH_SCOPE_READ_POSTS, ""))
+
+	require.Equal(t, DEFAULT_SCOPE, NormalizeOAuthScope(""))
+	require.Equal(t, NormalizeOAuthScope("read:users read:posts"), NormalizeOAuthScope("read:posts read:users read:users"))
+}
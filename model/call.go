@@ -0,0 +1,174 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	CALL_PROVIDER_MANUAL = "manual"
+
+	CALL_PROVIDER_MAX_LENGTH = 190
+	CALL_TITLE_MAX_RUNES     = 256
+	CALL_JOIN_URL_MAX_LENGTH = 1024
+
+	POST_PROPS_CALL_ID              = "call_id"
+	POST_PROPS_CALL_PROVIDER        = "call_provider"
+	POST_PROPS_CALL_TITLE           = "call_title"
+	POST_PROPS_CALL_JOIN_URL        = "call_join_url"
+	POST_PROPS_CALL_START_AT        = "call_start_at"
+	POST_PROPS_CALL_END_AT          = "call_end_at"
+	POST_PROPS_CALL_PARTICIPANT_IDS = "call_participant_ids"
+)
+
+// Call is a call or meeting held in a channel by a conferencing integration, such as a Zoom or Jitsi plugin. It's
+// shown in the channel by a post of type POST_CALL, which is updated as participants join and leave, and when it ends,
+// so that clients render calls the same way whatever the integration. Provider names the integration, such as the id
+// of the plugin.
+type Call struct {
+	Id        string `json:"id"`
+	CreateAt  int64  `json:"create_at"`
+	UpdateAt  int64  `json:"update_at"`
+	ChannelId string `json:"channel_id"`
+	CreatorId string `json:"creator_id"`
+	PostId    string `json:"post_id"`
+	RootId    string `json:"root_id"`
+	Provider  string `json:"provider"`
+	Title     string `json:"title"`
+	JoinUrl   string `json:"join_url"`
+	StartAt   int64  `json:"start_at"`
+	EndAt     int64  `json:"end_at"`
+
+	Participants []*CallParticipant `json:"participants" db:"-"`
+}
+
+// CallParticipant is a user who has joined a call, and who's still in it until they leave.
+type CallParticipant struct {
+	CallId  string `json:"call_id"`
+	UserId  string `json:"user_id"`
+	JoinAt  int64  `json:"join_at"`
+	LeaveAt int64  `json:"leave_at"`
+}
+
+func (o *Call) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("Call.IsValid", "model.call.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("Call.IsValid", "model.call.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("Call.IsValid", "model.call.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("Call.IsValid", "model.call.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("Call.IsValid", "model.call.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.PostId != "" && !IsValidId(o.PostId) {
+		return NewAppError("Call.IsValid", "model.call.is_valid.post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.RootId != "" && !IsValidId(o.RootId) {
+		return NewAppError("Call.IsValid", "model.call.is_valid.root_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Provider == "" || len(o.Provider) > CALL_PROVIDER_MAX_LENGTH {
+		return NewAppError("Call.IsValid", "model.call.is_valid.provider.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Title) > CALL_TITLE_MAX_RUNES {
+		return NewAppError("Call.IsValid", "model.call.is_valid.title.app_error", map[string]interface{}{"Max": CALL_TITLE_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.JoinUrl != "" && (len(o.JoinUrl) > CALL_JOIN_URL_MAX_LENGTH || !IsValidHttpUrl(o.JoinUrl)) {
+		return NewAppError("Call.IsValid", "model.call.is_valid.join_url.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.StartAt == 0 || (o.EndAt != 0 && o.EndAt < o.StartAt) {
+		return NewAppError("Call.IsValid", "model.call.is_valid.dates.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *Call) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Provider == "" {
+		o.Provider = CALL_PROVIDER_MANUAL
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+
+	if o.StartAt == 0 {
+		o.StartAt = o.CreateAt
+	}
+}
+
+func (o *Call) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+func (o *Call) IsEnded() bool {
+	return o.EndAt != 0
+}
+
+// ParticipantIds returns the ids of the users still in the call.
+func (o *Call) ParticipantIds() []string {
+	userIds := []string{}
+	for _, participant := range o.Participants {
+		if participant.LeaveAt == 0 {
+			userIds = append(userIds, participant.UserId)
+		}
+	}
+	return userIds
+}
+
+// SetPostProps sets the props of the post showing the call to its current state.
+func (o *Call) SetPostProps(post *Post) {
+	post.Type = POST_CALL
+	post.AddProp(POST_PROPS_CALL_ID, o.Id)
+	post.AddProp(POST_PROPS_CALL_PROVIDER, o.Provider)
+	post.AddProp(POST_PROPS_CALL_TITLE, o.Title)
+	post.AddProp(POST_PROPS_CALL_JOIN_URL, o.JoinUrl)
+	post.AddProp(POST_PROPS_CALL_START_AT, o.StartAt)
+	post.AddProp(POST_PROPS_CALL_END_AT, o.EndAt)
+	post.AddProp(POST_PROPS_CALL_PARTICIPANT_IDS, o.ParticipantIds())
+}
+
+func (o *Call) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func CallFromJson(data io.Reader) *Call {
+	var o *Call
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func CallListToJson(l []*Call) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func CallListFromJson(data io.Reader) []*Call {
+	var o []*Call
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
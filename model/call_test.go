@@ -0,0 +1,70 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallJson(t *testing.T) {
+	call := Call{Id: NewId(), ChannelId: NewId(), Title: "Standup", Participants: []*CallParticipant{{UserId: NewId(), JoinAt: 1000}}}
+	result := CallFromJson(strings.NewReader(call.ToJson()))
+	assert.Equal(t, call, *result)
+
+	list := CallListFromJson(strings.NewReader(CallListToJson([]*Call{&call})))
+	require.Len(t, list, 1)
+	assert.Equal(t, call, *list[0])
+}
+
+func TestCallIsValid(t *testing.T) {
+	call := Call{ChannelId: NewId(), CreatorId: NewId(), Title: "Standup", JoinUrl: "https://meet.example.com/standup"}
+	call.PreSave()
+	require.Nil(t, call.IsValid())
+	assert.Equal(t, CALL_PROVIDER_MANUAL, call.Provider)
+	assert.Equal(t, call.CreateAt, call.StartAt)
+
+	for name, update := range map[string]func(c *Call){
+		"channel id": func(c *Call) { c.ChannelId = "abc" },
+		"creator id": func(c *Call) { c.CreatorId = "" },
+		"post id":    func(c *Call) { c.PostId = "abc" },
+		"provider":   func(c *Call) { c.Provider = "" },
+		"title":      func(c *Call) { c.Title = strings.Repeat("a", CALL_TITLE_MAX_RUNES+1) },
+		"join url":   func(c *Call) { c.JoinUrl = "javascript:alert(1)" },
+		"end at":     func(c *Call) { c.EndAt = c.StartAt - 1 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := call
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestCallSetPostProps(t *testing.T) {
+	userId1 := NewId()
+	userId2 := NewId()
+
+	call := Call{
+		Id:       NewId(),
+		Provider: "com.example.video",
+		Title:    "Standup",
+		StartAt:  1000,
+		Participants: []*CallParticipant{
+			{UserId: userId1, JoinAt: 1000, LeaveAt: 2000},
+			{UserId: userId2, JoinAt: 1500},
+		},
+	}
+
+	post := &Post{}
+	call.SetPostProps(post)
+
+	assert.Equal(t, POST_CALL, post.Type)
+	assert.Equal(t, call.Id, post.Props[POST_PROPS_CALL_ID])
+	assert.Equal(t, "Standup", post.Props[POST_PROPS_CALL_TITLE])
+	assert.Equal(t, []string{userId2}, post.Props[POST_PROPS_CALL_PARTICIPANT_IDS])
+}
@@ -31,26 +31,57 @@ const (
 
 	CHANNEL_SORT_BY_USERNAME = "username"
 	CHANNEL_SORT_BY_STATUS   = "status"
+
+	CHANNEL_REPLY_BROADCAST_DEFAULT_CHANNEL = "default_channel"
+	CHANNEL_REPLY_BROADCAST_DEFAULT_THREAD  = "default_thread"
+	CHANNEL_REPLY_BROADCAST_THREAD_ONLY     = "thread_only"
+
+	CHANNEL_ALLOWED_INTEGRATIONS_MAX = 50
+
+	CHANNEL_WELCOME_MESSAGE_TYPE_EPHEMERAL = "ephemeral"
+	CHANNEL_WELCOME_MESSAGE_TYPE_DIRECT    = "direct"
+	CHANNEL_WELCOME_MESSAGE_MAX_RUNES      = 4000
 )
 
 type Channel struct {
-	Id               string                 `json:"id"`
-	CreateAt         int64                  `json:"create_at"`
-	UpdateAt         int64                  `json:"update_at"`
-	DeleteAt         int64                  `json:"delete_at"`
-	TeamId           string                 `json:"team_id"`
-	Type             string                 `json:"type"`
-	DisplayName      string                 `json:"display_name"`
-	Name             string                 `json:"name"`
-	Header           string                 `json:"header"`
-	Purpose          string                 `json:"purpose"`
-	LastPostAt       int64                  `json:"last_post_at"`
-	TotalMsgCount    int64                  `json:"total_msg_count"`
-	ExtraUpdateAt    int64                  `json:"extra_update_at"`
-	CreatorId        string                 `json:"creator_id"`
-	SchemeId         *string                `json:"scheme_id"`
-	Props            map[string]interface{} `json:"props" db:"-"`
-	GroupConstrained *bool                  `json:"group_constrained"`
+	Id                   string                 `json:"id"`
+	CreateAt             int64                  `json:"create_at"`
+	UpdateAt             int64                  `json:"update_at"`
+	DeleteAt             int64                  `json:"delete_at"`
+	TeamId               string                 `json:"team_id"`
+	Type                 string                 `json:"type"`
+	DisplayName          string                 `json:"display_name"`
+	Name                 string                 `json:"name"`
+	Header               string                 `json:"header"`
+	Purpose              string                 `json:"purpose"`
+	LastPostAt           int64                  `json:"last_post_at"`
+	TotalMsgCount        int64                  `json:"total_msg_count"`
+	ExtraUpdateAt        int64                  `json:"extra_update_at"`
+	CreatorId            string                 `json:"creator_id"`
+	SchemeId             *string                `json:"scheme_id"`
+	Props                map[string]interface{} `json:"props" db:"-"`
+	GroupConstrained     *bool                  `json:"group_constrained"`
+	ReplyBroadcastPolicy string                 `json:"reply_broadcast_policy"`
+	HashtagsDisabled     bool                   `json:"hashtags_disabled"`
+
+	// IntegrationsRestricted limits the incoming webhooks and bot accounts able to post to the channel to those
+	// listed in AllowedWebhookIds and AllowedBotIds.
+	IntegrationsRestricted bool        `json:"integrations_restricted"`
+	AllowedWebhookIds      StringArray `json:"allowed_webhook_ids"`
+	AllowedBotIds          StringArray `json:"allowed_bot_ids"`
+
+	// ModerationEnabled holds the posts of members who aren't moderators of the channel until a moderator
+	// approves them.
+	ModerationEnabled bool `json:"moderation_enabled"`
+
+	// WelcomeMessage is sent to users added to the channel, either as an ephemeral post in the channel or as a
+	// direct message, depending on WelcomeMessageType. See RenderWelcomeMessage for its template variables.
+	WelcomeMessage     string `json:"welcome_message"`
+	WelcomeMessageType string `json:"welcome_message_type"`
+
+	// LinkPreviewsDisabled stops the server from fetching previews and embedded images for the links posted in the
+	// channel.
+	LinkPreviewsDisabled bool `json:"link_previews_disabled"`
 }
 
 type ChannelWithTeamData struct {
@@ -66,11 +97,24 @@ type ChannelsWithCount struct {
 }
 
 type ChannelPatch struct {
-	DisplayName      *string `json:"display_name"`
-	Name             *string `json:"name"`
-	Header           *string `json:"header"`
-	Purpose          *string `json:"purpose"`
-	GroupConstrained *bool   `json:"group_constrained"`
+	DisplayName          *string `json:"display_name"`
+	Name                 *string `json:"name"`
+	Header               *string `json:"header"`
+	Purpose              *string `json:"purpose"`
+	GroupConstrained     *bool   `json:"group_constrained"`
+	ReplyBroadcastPolicy *string `json:"reply_broadcast_policy"`
+	HashtagsDisabled     *bool   `json:"hashtags_disabled"`
+
+	IntegrationsRestricted *bool        `json:"integrations_restricted"`
+	AllowedWebhookIds      *StringArray `json:"allowed_webhook_ids"`
+	AllowedBotIds          *StringArray `json:"allowed_bot_ids"`
+
+	ModerationEnabled *bool `json:"moderation_enabled"`
+
+	WelcomeMessage     *string `json:"welcome_message"`
+	WelcomeMessageType *string `json:"welcome_message_type"`
+
+	LinkPreviewsDisabled *bool `json:"link_previews_disabled"`
 }
 
 type ChannelForExport struct {
@@ -103,6 +147,12 @@ func (o *Channel) DeepCopy() *Channel {
 	if copy.SchemeId != nil {
 		copy.SchemeId = NewString(*o.SchemeId)
 	}
+	if copy.AllowedWebhookIds != nil {
+		copy.AllowedWebhookIds = append(StringArray{}, o.AllowedWebhookIds...)
+	}
+	if copy.AllowedBotIds != nil {
+		copy.AllowedBotIds = append(StringArray{}, o.AllowedBotIds...)
+	}
 	return &copy
 }
 
@@ -140,7 +190,7 @@ func ChannelPatchFromJson(data io.Reader) *ChannelPatch {
 }
 
 func (o *Channel) Etag() string {
-	return Etag(o.Id, o.UpdateAt)
+	return Etag(o.Id, o.UpdateAt, o.LastPostAt, o.TotalMsgCount)
 }
 
 func (o *Channel) IsValid() *AppError {
@@ -180,6 +230,27 @@ func (o *Channel) IsValid() *AppError {
 		return NewAppError("Channel.IsValid", "model.channel.is_valid.creator_id.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if !(o.ReplyBroadcastPolicy == "" || o.ReplyBroadcastPolicy == CHANNEL_REPLY_BROADCAST_DEFAULT_CHANNEL ||
+		o.ReplyBroadcastPolicy == CHANNEL_REPLY_BROADCAST_DEFAULT_THREAD || o.ReplyBroadcastPolicy == CHANNEL_REPLY_BROADCAST_THREAD_ONLY) {
+		return NewAppError("Channel.IsValid", "model.channel.is_valid.reply_broadcast_policy.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !isValidIdList(o.AllowedWebhookIds, CHANNEL_ALLOWED_INTEGRATIONS_MAX) {
+		return NewAppError("Channel.IsValid", "model.channel.is_valid.allowed_webhook_ids.app_error", map[string]interface{}{"Max": CHANNEL_ALLOWED_INTEGRATIONS_MAX}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !isValidIdList(o.AllowedBotIds, CHANNEL_ALLOWED_INTEGRATIONS_MAX) {
+		return NewAppError("Channel.IsValid", "model.channel.is_valid.allowed_bot_ids.app_error", map[string]interface{}{"Max": CHANNEL_ALLOWED_INTEGRATIONS_MAX}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.WelcomeMessage) > CHANNEL_WELCOME_MESSAGE_MAX_RUNES {
+		return NewAppError("Channel.IsValid", "model.channel.is_valid.welcome_message.app_error", map[string]interface{}{"Max": CHANNEL_WELCOME_MESSAGE_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !(o.WelcomeMessageType == "" || o.WelcomeMessageType == CHANNEL_WELCOME_MESSAGE_TYPE_EPHEMERAL || o.WelcomeMessageType == CHANNEL_WELCOME_MESSAGE_TYPE_DIRECT) {
+		return NewAppError("Channel.IsValid", "model.channel.is_valid.welcome_message_type.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -221,6 +292,51 @@ func (o *Channel) Patch(patch *ChannelPatch) {
 	if patch.GroupConstrained != nil {
 		o.GroupConstrained = patch.GroupConstrained
 	}
+
+	if patch.ReplyBroadcastPolicy != nil {
+		o.ReplyBroadcastPolicy = *patch.ReplyBroadcastPolicy
+	}
+
+	if patch.HashtagsDisabled != nil {
+		o.HashtagsDisabled = *patch.HashtagsDisabled
+	}
+
+	if patch.IntegrationsRestricted != nil {
+		o.IntegrationsRestricted = *patch.IntegrationsRestricted
+	}
+
+	if patch.AllowedWebhookIds != nil {
+		o.AllowedWebhookIds = *patch.AllowedWebhookIds
+	}
+
+	if patch.AllowedBotIds != nil {
+		o.AllowedBotIds = *patch.AllowedBotIds
+	}
+
+	if patch.ModerationEnabled != nil {
+		o.ModerationEnabled = *patch.ModerationEnabled
+	}
+
+	if patch.WelcomeMessage != nil {
+		o.WelcomeMessage = *patch.WelcomeMessage
+	}
+
+	if patch.WelcomeMessageType != nil {
+		o.WelcomeMessageType = *patch.WelcomeMessageType
+	}
+
+	if patch.LinkPreviewsDisabled != nil {
+		o.LinkPreviewsDisabled = *patch.LinkPreviewsDisabled
+	}
+}
+
+// RenderWelcomeMessage returns the channel's welcome message for a user, replacing {{user}} with a mention of the
+// user and {{channel}} with the channel's display name.
+func (o *Channel) RenderWelcomeMessage(user *User) string {
+	return strings.NewReplacer(
+		"{{user}}", "@"+user.Username,
+		"{{channel}}", o.DisplayName,
+	).Replace(o.WelcomeMessage)
 }
 
 func (o *Channel) MakeNonNil() {
@@ -239,6 +355,41 @@ func (o *Channel) IsGroupConstrained() bool {
 	return o.GroupConstrained != nil && *o.GroupConstrained
 }
 
+// ShouldBroadcastReply decides whether a reply posted to this channel is also shown in the channel itself,
+// given the choice made by the client, if any, and the reply broadcast policy of the channel.
+func (o *Channel) ShouldBroadcastReply(requested *bool) bool {
+	switch o.ReplyBroadcastPolicy {
+	case CHANNEL_REPLY_BROADCAST_THREAD_ONLY:
+		return false
+	case CHANNEL_REPLY_BROADCAST_DEFAULT_THREAD:
+		return requested != nil && *requested
+	default:
+		return requested == nil || *requested
+	}
+}
+
+// IsWebhookAllowed reports whether the incoming webhook with the given id may post to this channel.
+func (o *Channel) IsWebhookAllowed(hookId string) bool {
+	return !o.IntegrationsRestricted || o.AllowedWebhookIds.Contains(hookId)
+}
+
+// IsBotAllowed reports whether the bot account with the given user id may post to this channel.
+func (o *Channel) IsBotAllowed(botUserId string) bool {
+	return !o.IntegrationsRestricted || o.AllowedBotIds.Contains(botUserId)
+}
+
+func isValidIdList(ids StringArray, max int) bool {
+	if len(ids) > max {
+		return false
+	}
+	for _, id := range ids {
+		if !IsValidId(id) {
+			return false
+		}
+	}
+	return true
+}
+
 func (o *Channel) GetOtherUserIdForDM(userId string) string {
 	if o.Type != CHANNEL_DIRECT {
 		return ""
@@ -0,0 +1,36 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ChannelIntegration describes a bot, webhook or slash command acting in a channel, as shown to the members of the
+// channel so that they know what automation is at work there and who to ask about it.
+type ChannelIntegration struct {
+	Type         string   `json:"type"`
+	Id           string   `json:"id,omitempty"`
+	DisplayName  string   `json:"display_name"`
+	Description  string   `json:"description"`
+	Username     string   `json:"username,omitempty"`
+	Trigger      string   `json:"trigger,omitempty"`
+	TriggerWords []string `json:"trigger_words,omitempty"`
+	PluginId     string   `json:"plugin_id,omitempty"`
+	OwnerId      string   `json:"owner_id,omitempty"`
+	CreateAt     int64    `json:"create_at"`
+	CanManage    bool     `json:"can_manage"`
+}
+
+func ChannelIntegrationListToJson(l []*ChannelIntegration) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ChannelIntegrationListFromJson(data io.Reader) []*ChannelIntegration {
+	var o []*ChannelIntegration
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
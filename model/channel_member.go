@@ -15,12 +15,21 @@ const (
 	CHANNEL_NOTIFY_ALL                  = "all"
 	CHANNEL_NOTIFY_MENTION              = "mention"
 	CHANNEL_NOTIFY_NONE                 = "none"
+	CHANNEL_NOTIFY_THREADS              = "threads" // mentions and replies to the threads the member is in or follows
 	CHANNEL_MARK_UNREAD_ALL             = "all"
 	CHANNEL_MARK_UNREAD_MENTION         = "mention"
 	IGNORE_CHANNEL_MENTIONS_DEFAULT     = "default"
 	IGNORE_CHANNEL_MENTIONS_OFF         = "off"
 	IGNORE_CHANNEL_MENTIONS_ON          = "on"
 	IGNORE_CHANNEL_MENTIONS_NOTIFY_PROP = "ignore_channel_mentions"
+
+	CHANNEL_MEMBERS_SORT_USERNAME  = "username"
+	CHANNEL_MEMBERS_SORT_LAST_POST = "last_post"
+	CHANNEL_MEMBERS_SORT_ROLE      = "role"
+
+	CHANNEL_MEMBER_ROLE_FILTER_ADMIN = "admin"
+	CHANNEL_MEMBER_ROLE_FILTER_USER  = "user"
+	CHANNEL_MEMBER_ROLE_FILTER_GUEST = "guest"
 )
 
 type ChannelUnread struct {
@@ -48,6 +57,24 @@ type ChannelMember struct {
 
 type ChannelMembers []ChannelMember
 
+// ChannelMembersGetOptions sorts and filters the members of a channel. Members are sorted by username by default,
+// and can be sorted by the time of their last post in the channel, most recent first, or by role, admins first and
+// guests last. Role restricts the members to the ones with the given scheme role, one of the
+// CHANNEL_MEMBER_ROLE_FILTER_* constants.
+type ChannelMembersGetOptions struct {
+	Sort string
+	Role string
+}
+
+// ChannelMemberCounts are the numbers of active members of a channel with each scheme role.
+type ChannelMemberCounts struct {
+	ChannelId string `json:"channel_id"`
+	Total     int64  `json:"total"`
+	Admins    int64  `json:"admins"`
+	Users     int64  `json:"users"`
+	Guests    int64  `json:"guests"`
+}
+
 type ChannelMemberForExport struct {
 	ChannelMember
 	ChannelName string
@@ -62,6 +89,33 @@ func (o *ChannelMembers) ToJson() string {
 	}
 }
 
+func (o *ChannelMembersGetOptions) IsValid() *AppError {
+	switch o.Sort {
+	case "", CHANNEL_MEMBERS_SORT_USERNAME, CHANNEL_MEMBERS_SORT_LAST_POST, CHANNEL_MEMBERS_SORT_ROLE:
+	default:
+		return NewAppError("ChannelMembersGetOptions.IsValid", "model.channel_members_get_options.is_valid.sort.app_error", nil, "sort="+o.Sort, http.StatusBadRequest)
+	}
+
+	switch o.Role {
+	case "", CHANNEL_MEMBER_ROLE_FILTER_ADMIN, CHANNEL_MEMBER_ROLE_FILTER_USER, CHANNEL_MEMBER_ROLE_FILTER_GUEST:
+	default:
+		return NewAppError("ChannelMembersGetOptions.IsValid", "model.channel_members_get_options.is_valid.role.app_error", nil, "role="+o.Role, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *ChannelMemberCounts) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelMemberCountsFromJson(data io.Reader) *ChannelMemberCounts {
+	var o *ChannelMemberCounts
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
 func (o *ChannelUnread) ToJson() string {
 	b, _ := json.Marshal(o)
 	return string(b)
@@ -84,6 +138,10 @@ func (o *ChannelMember) ToJson() string {
 	return string(b)
 }
 
+func (o *ChannelMember) Etag() string {
+	return Etag(o.ChannelId, o.UserId, o.LastUpdateAt, o.LastViewedAt, o.MsgCount, o.MentionCount)
+}
+
 func ChannelMemberFromJson(data io.Reader) *ChannelMember {
 	var o *ChannelMember
 	json.NewDecoder(data).Decode(&o)
@@ -128,6 +186,12 @@ func (o *ChannelMember) IsValid() *AppError {
 		}
 	}
 
+	if reactionsLevel, ok := o.NotifyProps[REACTIONS_NOTIFY_PROP]; ok {
+		if reactionsLevel != CHANNEL_NOTIFY_DEFAULT && !IsReactionsNotifyLevelValid(reactionsLevel) {
+			return NewAppError("ChannelMember.IsValid", "model.channel_member.is_valid.reactions_level.app_error", nil, "reactions_notify_level="+reactionsLevel, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -147,6 +211,7 @@ func IsChannelNotifyLevelValid(notifyLevel string) bool {
 	return notifyLevel == CHANNEL_NOTIFY_DEFAULT ||
 		notifyLevel == CHANNEL_NOTIFY_ALL ||
 		notifyLevel == CHANNEL_NOTIFY_MENTION ||
+		notifyLevel == CHANNEL_NOTIFY_THREADS ||
 		notifyLevel == CHANNEL_NOTIFY_NONE
 }
 
@@ -169,5 +234,6 @@ func GetDefaultChannelNotifyProps() StringMap {
 		PUSH_NOTIFY_PROP:                    CHANNEL_NOTIFY_DEFAULT,
 		EMAIL_NOTIFY_PROP:                   CHANNEL_NOTIFY_DEFAULT,
 		IGNORE_CHANNEL_MENTIONS_NOTIFY_PROP: IGNORE_CHANNEL_MENTIONS_DEFAULT,
+		REACTIONS_NOTIFY_PROP:               CHANNEL_NOTIFY_DEFAULT,
 	}
 }
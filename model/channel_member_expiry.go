@@ -0,0 +1,82 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// ChannelMemberExpiry records when a temporary member, such as a contractor or an incident responder, is
+// removed from a channel.
+type ChannelMemberExpiry struct {
+	ChannelId string `json:"channel_id"`
+	UserId    string `json:"user_id"`
+	CreatorId string `json:"creator_id"`
+	CreateAt  int64  `json:"create_at"`
+	UpdateAt  int64  `json:"update_at"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+func (o *ChannelMemberExpiry) IsValid() *AppError {
+	if len(o.ChannelId) != 26 {
+		return NewAppError("ChannelMemberExpiry.IsValid", "model.channel_member_expiry.is_valid.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.UserId) != 26 {
+		return NewAppError("ChannelMemberExpiry.IsValid", "model.channel_member_expiry.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.CreatorId) > 26 {
+		return NewAppError("ChannelMemberExpiry.IsValid", "model.channel_member_expiry.is_valid.creator_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ChannelMemberExpiry.IsValid", "model.channel_member_expiry.is_valid.create_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("ChannelMemberExpiry.IsValid", "model.channel_member_expiry.is_valid.update_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.ExpiresAt <= 0 {
+		return NewAppError("ChannelMemberExpiry.IsValid", "model.channel_member_expiry.is_valid.expires_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *ChannelMemberExpiry) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+	o.UpdateAt = GetMillis()
+}
+
+func (o *ChannelMemberExpiry) IsExpired() bool {
+	return o.ExpiresAt <= GetMillis()
+}
+
+func (o *ChannelMemberExpiry) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelMemberExpiryFromJson(data io.Reader) *ChannelMemberExpiry {
+	var o *ChannelMemberExpiry
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func ChannelMemberExpiryListToJson(l []*ChannelMemberExpiry) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ChannelMemberExpiryListFromJson(data io.Reader) []*ChannelMemberExpiry {
+	var o []*ChannelMemberExpiry
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelMemberExpiryJson(t *testing.T) {
+	expiry := ChannelMemberExpiry{ChannelId: NewId(), UserId: NewId(), CreatorId: NewId(), ExpiresAt: GetMillis()}
+	result := ChannelMemberExpiryFromJson(strings.NewReader(expiry.ToJson()))
+	assert.Equal(t, expiry, *result)
+
+	list := ChannelMemberExpiryListFromJson(strings.NewReader(ChannelMemberExpiryListToJson([]*ChannelMemberExpiry{&expiry})))
+	require.Len(t, list, 1)
+	assert.Equal(t, expiry, *list[0])
+}
+
+func TestChannelMemberExpiryIsValid(t *testing.T) {
+	expiry := ChannelMemberExpiry{ChannelId: NewId(), UserId: NewId(), ExpiresAt: GetMillis() + 1000}
+	expiry.PreSave()
+	require.Nil(t, expiry.IsValid())
+	assert.False(t, expiry.IsExpired())
+
+	for name, update := range map[string]func(e *ChannelMemberExpiry){
+		"channel id": func(e *ChannelMemberExpiry) { e.ChannelId = "abc" },
+		"user id":    func(e *ChannelMemberExpiry) { e.UserId = "" },
+		"creator id": func(e *ChannelMemberExpiry) { e.CreatorId = strings.Repeat("a", 27) },
+		"create at":  func(e *ChannelMemberExpiry) { e.CreateAt = 0 },
+		"expires at": func(e *ChannelMemberExpiry) { e.ExpiresAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := expiry
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+
+	expiry.ExpiresAt = GetMillis() - 1000
+	assert.True(t, expiry.IsExpired())
+}
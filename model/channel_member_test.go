@@ -49,6 +49,11 @@ func TestChannelMemberIsValid(t *testing.T) {
 		t.Fatal("should be invalid")
 	}
 
+	o.NotifyProps["desktop"] = CHANNEL_NOTIFY_THREADS
+	if err := o.IsValid(); err != nil {
+		t.Fatal(err)
+	}
+
 	o.NotifyProps["desktop"] = CHANNEL_NOTIFY_ALL
 	if err := o.IsValid(); err != nil {
 		t.Fatal(err)
@@ -64,6 +69,16 @@ func TestChannelMemberIsValid(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	o.NotifyProps[REACTIONS_NOTIFY_PROP] = "junk"
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.NotifyProps[REACTIONS_NOTIFY_PROP] = REACTIONS_NOTIFY_DM
+	if err := o.IsValid(); err != nil {
+		t.Fatal(err)
+	}
+
 	o.Roles = ""
 	if err := o.IsValid(); err != nil {
 		t.Fatal(err)
@@ -83,3 +98,33 @@ func TestChannelUnreadJson(t *testing.T) {
 		t.Fatal("MentionCount do not match")
 	}
 }
+
+func TestChannelMembersGetOptionsIsValid(t *testing.T) {
+	for _, options := range []ChannelMembersGetOptions{
+		{},
+		{Sort: CHANNEL_MEMBERS_SORT_LAST_POST},
+		{Sort: CHANNEL_MEMBERS_SORT_ROLE, Role: CHANNEL_MEMBER_ROLE_FILTER_GUEST},
+	} {
+		if err := options.IsValid(); err != nil {
+			t.Fatalf("%v should be valid", options)
+		}
+	}
+
+	for _, options := range []ChannelMembersGetOptions{
+		{Sort: "email"},
+		{Role: "channel_admin"},
+	} {
+		if err := options.IsValid(); err == nil {
+			t.Fatalf("%v should be invalid", options)
+		}
+	}
+}
+
+func TestChannelMemberCountsJson(t *testing.T) {
+	o := ChannelMemberCounts{ChannelId: NewId(), Total: 5, Admins: 1, Users: 3, Guests: 1}
+	ro := ChannelMemberCountsFromJson(strings.NewReader(o.ToJson()))
+
+	if *ro != o {
+		t.Fatal("counts do not match")
+	}
+}
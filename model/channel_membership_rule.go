@@ -0,0 +1,226 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION     = "position"
+	CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_EMAIL_DOMAIN = "email_domain"
+	CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE       = "locale"
+	CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_AUTH_SERVICE = "auth_service"
+	CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP        = "group"
+
+	// Rules on an admin-defined user attribute name it after this prefix, as in "attributes.department".
+	CHANNEL_MEMBERSHIP_RULE_USER_ATTRIBUTE_PREFIX = "attributes."
+
+	CHANNEL_MEMBERSHIP_RULE_VALUE_MAX_RUNES  = USER_ATTRIBUTE_VALUE_MAX_RUNES
+	CHANNEL_MEMBERSHIP_RULES_MAX_PER_CHANNEL = 20
+
+	CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE = "include"
+	CHANNEL_MEMBERSHIP_OVERRIDE_EXCLUDE = "exclude"
+)
+
+// ChannelMembershipRule makes the users of the team matching it members of a channel. A channel with rules has its
+// membership maintained by them: the users matching any of its rules are added to it, and the members matching none
+// of them are removed from it, unless their membership was overridden by hand.
+type ChannelMembershipRule struct {
+	Id        string `json:"id"`
+	ChannelId string `json:"channel_id"`
+	CreatorId string `json:"creator_id"`
+	CreateAt  int64  `json:"create_at"`
+	Attribute string `json:"attribute"`
+	Value     string `json:"value"`
+}
+
+// ChannelMembershipOverride records that a user was added to or removed from a channel with membership rules by hand,
+// against its rules, so that syncing the channel leaves them be.
+type ChannelMembershipOverride struct {
+	ChannelId string `json:"channel_id"`
+	UserId    string `json:"user_id"`
+	Type      string `json:"type"`
+	CreatorId string `json:"creator_id"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+func (o *ChannelMembershipRule) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("ChannelMembershipRule.IsValid", "model.channel_membership_rule.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.ChannelId) != 26 {
+		return NewAppError("ChannelMembershipRule.IsValid", "model.channel_membership_rule.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.CreatorId) > 26 {
+		return NewAppError("ChannelMembershipRule.IsValid", "model.channel_membership_rule.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ChannelMembershipRule.IsValid", "model.channel_membership_rule.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidChannelMembershipRuleAttribute(o.Attribute) {
+		return NewAppError("ChannelMembershipRule.IsValid", "model.channel_membership_rule.is_valid.attribute.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if strings.TrimSpace(o.Value) == "" || utf8.RuneCountInString(o.Value) > CHANNEL_MEMBERSHIP_RULE_VALUE_MAX_RUNES {
+		return NewAppError("ChannelMembershipRule.IsValid", "model.channel_membership_rule.is_valid.value.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Attribute == CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP && !IsValidId(o.Value) {
+		return NewAppError("ChannelMembershipRule.IsValid", "model.channel_membership_rule.is_valid.value.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *ChannelMembershipRule) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+
+	if name := o.UserAttributeName(); name != "" {
+		o.Attribute = CHANNEL_MEMBERSHIP_RULE_USER_ATTRIBUTE_PREFIX + NormalizeUserAttributeName(name)
+	}
+	o.Value = strings.TrimSpace(o.Value)
+}
+
+// UserAttributeName returns the name of the admin-defined user attribute the rule is on, if any.
+func (o *ChannelMembershipRule) UserAttributeName() string {
+	if !strings.HasPrefix(o.Attribute, CHANNEL_MEMBERSHIP_RULE_USER_ATTRIBUTE_PREFIX) {
+		return ""
+	}
+
+	return strings.TrimPrefix(o.Attribute, CHANNEL_MEMBERSHIP_RULE_USER_ATTRIBUTE_PREFIX)
+}
+
+// Matches returns whether the user matches the rule, given the ids of the groups they belong to. Values are compared
+// regardless of case. The user attributes must have been filled in for rules on them to match.
+func (o *ChannelMembershipRule) Matches(user *User, groupIds map[string]bool) bool {
+	switch o.Attribute {
+	case CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION:
+		return strings.EqualFold(strings.TrimSpace(user.Position), o.Value)
+	case CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_EMAIL_DOMAIN:
+		at := strings.LastIndex(user.Email, "@")
+		return at != -1 && strings.EqualFold(user.Email[at+1:], o.Value)
+	case CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE:
+		return strings.EqualFold(user.Locale, o.Value)
+	case CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_AUTH_SERVICE:
+		if o.Value == USER_AUTH_SERVICE_EMAIL {
+			return !user.IsSSOUser()
+		}
+		return strings.EqualFold(user.AuthService, o.Value)
+	case CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP:
+		return groupIds[o.Value]
+	}
+
+	if name := o.UserAttributeName(); name != "" {
+		return strings.EqualFold(strings.TrimSpace(user.Attributes[name]), o.Value)
+	}
+
+	return false
+}
+
+// ChannelMembershipRulesMatch returns whether the user matches any of the rules.
+func ChannelMembershipRulesMatch(rules []*ChannelMembershipRule, user *User, groupIds map[string]bool) bool {
+	for _, rule := range rules {
+		if rule.Matches(user, groupIds) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsValidChannelMembershipRuleAttribute returns whether channel membership rules can be made on the attribute.
+func IsValidChannelMembershipRuleAttribute(attribute string) bool {
+	switch attribute {
+	case CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION,
+		CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_EMAIL_DOMAIN,
+		CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE,
+		CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_AUTH_SERVICE,
+		CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP:
+		return true
+	}
+
+	if !strings.HasPrefix(attribute, CHANNEL_MEMBERSHIP_RULE_USER_ATTRIBUTE_PREFIX) {
+		return false
+	}
+
+	return IsValidUserAttributeName(strings.TrimPrefix(attribute, CHANNEL_MEMBERSHIP_RULE_USER_ATTRIBUTE_PREFIX))
+}
+
+func (o *ChannelMembershipRule) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelMembershipRuleFromJson(data io.Reader) *ChannelMembershipRule {
+	var o *ChannelMembershipRule
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func ChannelMembershipRuleListToJson(l []*ChannelMembershipRule) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ChannelMembershipRuleListFromJson(data io.Reader) []*ChannelMembershipRule {
+	var o []*ChannelMembershipRule
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *ChannelMembershipOverride) IsValid() *AppError {
+	if len(o.ChannelId) != 26 {
+		return NewAppError("ChannelMembershipOverride.IsValid", "model.channel_membership_override.is_valid.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.UserId) != 26 {
+		return NewAppError("ChannelMembershipOverride.IsValid", "model.channel_membership_override.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.Type != CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE && o.Type != CHANNEL_MEMBERSHIP_OVERRIDE_EXCLUDE {
+		return NewAppError("ChannelMembershipOverride.IsValid", "model.channel_membership_override.is_valid.type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.CreatorId) > 26 {
+		return NewAppError("ChannelMembershipOverride.IsValid", "model.channel_membership_override.is_valid.creator_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ChannelMembershipOverride.IsValid", "model.channel_membership_override.is_valid.create_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *ChannelMembershipOverride) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func ChannelMembershipOverrideListToJson(l []*ChannelMembershipOverride) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ChannelMembershipOverrideListFromJson(data io.Reader) []*ChannelMembershipOverride {
+	var o []*ChannelMembershipOverride
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
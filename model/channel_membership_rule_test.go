@@ -0,0 +1,114 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelMembershipRuleJson(t *testing.T) {
+	rule := ChannelMembershipRule{Id: NewId(), ChannelId: NewId(), CreatorId: NewId(), CreateAt: GetMillis(), Attribute: CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION, Value: "Engineer"}
+	result := ChannelMembershipRuleFromJson(strings.NewReader(rule.ToJson()))
+	assert.Equal(t, rule, *result)
+
+	list := ChannelMembershipRuleListFromJson(strings.NewReader(ChannelMembershipRuleListToJson([]*ChannelMembershipRule{&rule})))
+	require.Len(t, list, 1)
+	assert.Equal(t, rule, *list[0])
+}
+
+func TestChannelMembershipRuleIsValid(t *testing.T) {
+	rule := ChannelMembershipRule{ChannelId: NewId(), Attribute: "attributes.Department ", Value: " Sales "}
+	rule.PreSave()
+	require.Nil(t, rule.IsValid())
+	assert.Equal(t, "attributes.department", rule.Attribute)
+	assert.Equal(t, "Sales", rule.Value)
+
+	for name, update := range map[string]func(r *ChannelMembershipRule){
+		"id":                func(r *ChannelMembershipRule) { r.Id = "abc" },
+		"channel id":        func(r *ChannelMembershipRule) { r.ChannelId = "" },
+		"creator id":        func(r *ChannelMembershipRule) { r.CreatorId = strings.Repeat("a", 27) },
+		"create at":         func(r *ChannelMembershipRule) { r.CreateAt = 0 },
+		"unknown attribute": func(r *ChannelMembershipRule) { r.Attribute = "password" },
+		"empty attribute":   func(r *ChannelMembershipRule) { r.Attribute = "attributes." },
+		"empty value":       func(r *ChannelMembershipRule) { r.Value = " " },
+		"long value": func(r *ChannelMembershipRule) {
+			r.Value = strings.Repeat("a", CHANNEL_MEMBERSHIP_RULE_VALUE_MAX_RUNES+1)
+		},
+		"group value": func(r *ChannelMembershipRule) {
+			r.Attribute = CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP
+			r.Value = "engineering"
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := rule
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestChannelMembershipRuleMatches(t *testing.T) {
+	groupId := NewId()
+	user := &User{
+		Email:      "jane@Example.com",
+		Position:   "Support Engineer",
+		Locale:     "fr",
+		Attributes: StringMap{"department": "Support"},
+	}
+
+	for name, tc := range map[string]struct {
+		Attribute string
+		Value     string
+		GroupIds  map[string]bool
+		Expected  bool
+	}{
+		"position":               {CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION, "support engineer", nil, true},
+		"other position":         {CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION, "engineer", nil, false},
+		"email domain":           {CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_EMAIL_DOMAIN, "example.com", nil, true},
+		"other email domain":     {CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_EMAIL_DOMAIN, "example.org", nil, false},
+		"locale":                 {CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE, "fr", nil, true},
+		"email auth service":     {CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_AUTH_SERVICE, USER_AUTH_SERVICE_EMAIL, nil, true},
+		"ldap auth service":      {CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_AUTH_SERVICE, USER_AUTH_SERVICE_LDAP, nil, false},
+		"group":                  {CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP, groupId, map[string]bool{groupId: true}, true},
+		"other group":            {CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_GROUP, NewId(), map[string]bool{groupId: true}, false},
+		"user attribute":         {"attributes.department", "support", nil, true},
+		"missing user attribute": {"attributes.location", "paris", nil, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rule := &ChannelMembershipRule{Attribute: tc.Attribute, Value: tc.Value}
+			assert.Equal(t, tc.Expected, rule.Matches(user, tc.GroupIds))
+		})
+	}
+
+	rules := []*ChannelMembershipRule{
+		{Attribute: CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION, Value: "manager"},
+		{Attribute: CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE, Value: "fr"},
+	}
+	assert.True(t, ChannelMembershipRulesMatch(rules, user, nil))
+	assert.False(t, ChannelMembershipRulesMatch(rules[:1], user, nil))
+}
+
+func TestChannelMembershipOverrideIsValid(t *testing.T) {
+	override := ChannelMembershipOverride{ChannelId: NewId(), UserId: NewId(), Type: CHANNEL_MEMBERSHIP_OVERRIDE_EXCLUDE}
+	override.PreSave()
+	require.Nil(t, override.IsValid())
+
+	for name, update := range map[string]func(o *ChannelMembershipOverride){
+		"channel id": func(o *ChannelMembershipOverride) { o.ChannelId = "abc" },
+		"user id":    func(o *ChannelMembershipOverride) { o.UserId = "" },
+		"type":       func(o *ChannelMembershipOverride) { o.Type = "maybe" },
+		"creator id": func(o *ChannelMembershipOverride) { o.CreatorId = strings.Repeat("a", 27) },
+		"create at":  func(o *ChannelMembershipOverride) { o.CreateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := override
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
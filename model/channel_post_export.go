@@ -0,0 +1,128 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CHANNEL_POST_EXPORT_FORMAT_CSV  = "csv"
+	CHANNEL_POST_EXPORT_FORMAT_JSON = "json"
+)
+
+// ChannelPostExportOptions selects the posts of a channel to export and how to encode them. Since and Until are
+// inclusive creation times in milliseconds, where zero leaves that end of the range open.
+type ChannelPostExportOptions struct {
+	Format string
+	Since  int64
+	Until  int64
+	Gzip   bool
+}
+
+// ChannelPostExportReaction is a reaction to an exported post.
+type ChannelPostExportReaction struct {
+	EmojiName string `json:"emoji_name"`
+	UserId    string `json:"user_id"`
+	Username  string `json:"username"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+// ChannelPostExportFile is a file attached to an exported post, with the link to download it from.
+type ChannelPostExportFile struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	Link string `json:"link"`
+}
+
+// ChannelPostExportRecord is a single post of a channel post export.
+type ChannelPostExportRecord struct {
+	PostId    string                       `json:"post_id"`
+	CreateAt  int64                        `json:"create_at"`
+	EditAt    int64                        `json:"edit_at,omitempty"`
+	UserId    string                       `json:"user_id"`
+	Username  string                       `json:"username"`
+	RootId    string                       `json:"root_id,omitempty"`
+	Type      string                       `json:"type,omitempty"`
+	Message   string                       `json:"message"`
+	Reactions []*ChannelPostExportReaction `json:"reactions"`
+	Files     []*ChannelPostExportFile     `json:"files"`
+}
+
+var ChannelPostExportCSVHeader = []string{"Post Id", "Created At", "Edited At", "User Id", "Username", "Root Id", "Type", "Message", "Reactions", "Files"}
+
+func (o *ChannelPostExportOptions) IsValid() *AppError {
+	if o.Format != CHANNEL_POST_EXPORT_FORMAT_CSV && o.Format != CHANNEL_POST_EXPORT_FORMAT_JSON {
+		return NewAppError("ChannelPostExportOptions.IsValid", "model.channel_post_export.is_valid.format.app_error", nil, "format="+o.Format, http.StatusBadRequest)
+	}
+
+	if o.Since < 0 || o.Until < 0 || (o.Until != 0 && o.Until < o.Since) {
+		return NewAppError("ChannelPostExportOptions.IsValid", "model.channel_post_export.is_valid.range.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// FileName is the name to save an export of the given channel under.
+func (o *ChannelPostExportOptions) FileName(channelName string) string {
+	name := channelName + "_posts." + o.Format
+	if o.Gzip {
+		name += ".gz"
+	}
+	return name
+}
+
+// ContentType is the media type of the export before any compression.
+func (o *ChannelPostExportOptions) ContentType() string {
+	if o.Format == CHANNEL_POST_EXPORT_FORMAT_JSON {
+		return "application/json"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ToCSVRow renders the record as a row matching ChannelPostExportCSVHeader. Reactions are summarized by emoji with
+// their counts, and files are listed by link.
+func (r *ChannelPostExportRecord) ToCSVRow() []string {
+	editedAt := ""
+	if r.EditAt != 0 {
+		editedAt = channelPostExportTime(r.EditAt)
+	}
+
+	counts := map[string]int{}
+	for _, reaction := range r.Reactions {
+		counts[reaction.EmojiName]++
+	}
+	reactions := make([]string, 0, len(counts))
+	for emojiName, count := range counts {
+		reactions = append(reactions, emojiName+":"+strconv.Itoa(count))
+	}
+	sort.Strings(reactions)
+
+	files := make([]string, len(r.Files))
+	for i, file := range r.Files {
+		files[i] = file.Link
+	}
+
+	return []string{
+		r.PostId,
+		channelPostExportTime(r.CreateAt),
+		editedAt,
+		r.UserId,
+		r.Username,
+		r.RootId,
+		r.Type,
+		r.Message,
+		strings.Join(reactions, " "),
+		strings.Join(files, " "),
+	}
+}
+
+func channelPostExportTime(millis int64) string {
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
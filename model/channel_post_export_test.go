@@ -0,0 +1,66 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelPostExportOptionsIsValid(t *testing.T) {
+	o := ChannelPostExportOptions{Format: CHANNEL_POST_EXPORT_FORMAT_CSV}
+	require.Nil(t, o.IsValid())
+
+	o.Format = "xml"
+	assert.NotNil(t, o.IsValid())
+
+	o.Format = CHANNEL_POST_EXPORT_FORMAT_JSON
+	o.Since = 2000
+	o.Until = 1000
+	assert.NotNil(t, o.IsValid())
+
+	o.Until = 0
+	assert.Nil(t, o.IsValid())
+
+	o.Since = -1
+	assert.NotNil(t, o.IsValid())
+}
+
+func TestChannelPostExportOptionsFileName(t *testing.T) {
+	o := ChannelPostExportOptions{Format: CHANNEL_POST_EXPORT_FORMAT_CSV}
+	assert.Equal(t, "town-square_posts.csv", o.FileName("town-square"))
+
+	o.Format = CHANNEL_POST_EXPORT_FORMAT_JSON
+	o.Gzip = true
+	assert.Equal(t, "town-square_posts.json.gz", o.FileName("town-square"))
+}
+
+func TestChannelPostExportRecordToCSVRow(t *testing.T) {
+	r := &ChannelPostExportRecord{
+		PostId:   "post1",
+		CreateAt: 1570000000000,
+		UserId:   "user1",
+		Username: "alice",
+		Message:  "hello, world",
+		Reactions: []*ChannelPostExportReaction{
+			{EmojiName: "smile"},
+			{EmojiName: "+1"},
+			{EmojiName: "smile"},
+		},
+		Files: []*ChannelPostExportFile{
+			{Id: "file1", Link: "http://localhost/api/v4/files/file1"},
+			{Id: "file2", Link: "http://localhost/api/v4/files/file2"},
+		},
+	}
+
+	row := r.ToCSVRow()
+	require.Len(t, row, len(ChannelPostExportCSVHeader))
+	assert.Equal(t, "2019-10-02T07:06:40Z", row[1])
+	assert.Equal(t, "", row[2])
+	assert.Equal(t, "hello, world", row[7])
+	assert.Equal(t, "+1:1 smile:2", row[8])
+	assert.Equal(t, "http://localhost/api/v4/files/file1 http://localhost/api/v4/files/file2", row[9])
+}
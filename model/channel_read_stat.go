@@ -0,0 +1,110 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	CHANNEL_READ_STATS_READ_WINDOW          = 24 * 60 * 60 * 1000
+	CHANNEL_READ_STATS_MAX_ROLLUP_PERIOD    = 7 * 24 * 60 * 60 * 1000
+	CHANNEL_READ_STATS_DEFAULT_ROLLUP_RANGE = 60 * 60 * 1000
+
+	CHANNEL_READ_STATS_JOB_DATA_WINDOW_START = "window_start"
+	CHANNEL_READ_STATS_JOB_DATA_WINDOW_END   = "window_end"
+	CHANNEL_READ_STATS_JOB_DATA_POSTS        = "posts"
+)
+
+// ChannelReadStat is the aggregated reach of a root post, measured once the post is a day old. It only records how
+// many of the channel members, excluding the author, had viewed the channel since the post was made, never who they
+// were.
+type ChannelReadStat struct {
+	PostId       string `json:"post_id"`
+	ChannelId    string `json:"channel_id"`
+	TeamId       string `json:"team_id"`
+	PostCreateAt int64  `json:"post_create_at"`
+	MemberCount  int64  `json:"member_count"`
+	ReadCount    int64  `json:"read_count"`
+	CreateAt     int64  `json:"create_at"`
+}
+
+func (o *ChannelReadStat) IsValid() *AppError {
+	if len(o.PostId) != 26 {
+		return NewAppError("ChannelReadStat.IsValid", "model.channel_read_stat.is_valid.post_id.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if len(o.ChannelId) != 26 {
+		return NewAppError("ChannelReadStat.IsValid", "model.channel_read_stat.is_valid.channel_id.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if o.PostCreateAt == 0 {
+		return NewAppError("ChannelReadStat.IsValid", "model.channel_read_stat.is_valid.post_create_at.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if o.MemberCount < 0 || o.ReadCount < 0 || o.ReadCount > o.MemberCount {
+		return NewAppError("ChannelReadStat.IsValid", "model.channel_read_stat.is_valid.counts.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ChannelReadStat.IsValid", "model.channel_read_stat.is_valid.create_at.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *ChannelReadStat) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+// ReadRatio returns the fraction of the channel members that read the post.
+func (o *ChannelReadStat) ReadRatio() float64 {
+	if o.MemberCount == 0 {
+		return 0
+	}
+
+	return float64(o.ReadCount) / float64(o.MemberCount)
+}
+
+// GetChannelReadStatsRollupWindow returns the range of post creation times a rollup running at the given time should
+// measure, continuing from the end of the previous rollup's window when there was one. Posts are only measured once
+// they are CHANNEL_READ_STATS_READ_WINDOW old, and a rollup never goes back more than CHANNEL_READ_STATS_MAX_ROLLUP_PERIOD.
+func GetChannelReadStatsRollupWindow(previousEnd, now int64) (int64, int64) {
+	until := now - CHANNEL_READ_STATS_READ_WINDOW
+	since := until - CHANNEL_READ_STATS_DEFAULT_ROLLUP_RANGE
+
+	if previousEnd > 0 {
+		since = previousEnd
+	}
+
+	if since < until-CHANNEL_READ_STATS_MAX_ROLLUP_PERIOD {
+		since = until - CHANNEL_READ_STATS_MAX_ROLLUP_PERIOD
+	}
+
+	if since > until {
+		since = until
+	}
+
+	return since, until
+}
+
+func (o *ChannelReadStat) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelReadStatListToJson(l []*ChannelReadStat) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ChannelReadStatListFromJson(data io.Reader) []*ChannelReadStat {
+	var o []*ChannelReadStat
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelReadStatJson(t *testing.T) {
+	stat := ChannelReadStat{PostId: NewId(), ChannelId: NewId(), TeamId: NewId(), PostCreateAt: 1, MemberCount: 4, ReadCount: 3, CreateAt: 2}
+	list := ChannelReadStatListFromJson(strings.NewReader(ChannelReadStatListToJson([]*ChannelReadStat{&stat})))
+	require.Len(t, list, 1)
+	assert.Equal(t, stat, *list[0])
+}
+
+func TestChannelReadStatIsValid(t *testing.T) {
+	stat := ChannelReadStat{PostId: NewId(), ChannelId: NewId(), PostCreateAt: GetMillis(), MemberCount: 10, ReadCount: 4}
+	stat.PreSave()
+	require.Nil(t, stat.IsValid())
+
+	for name, update := range map[string]func(s *ChannelReadStat){
+		"post id":              func(s *ChannelReadStat) { s.PostId = "abc" },
+		"channel id":           func(s *ChannelReadStat) { s.ChannelId = "" },
+		"post create at":       func(s *ChannelReadStat) { s.PostCreateAt = 0 },
+		"negative reads":       func(s *ChannelReadStat) { s.ReadCount = -1 },
+		"more reads than size": func(s *ChannelReadStat) { s.ReadCount = 11 },
+		"create at":            func(s *ChannelReadStat) { s.CreateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := stat
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestChannelReadStatReadRatio(t *testing.T) {
+	assert.Equal(t, 0.25, (&ChannelReadStat{MemberCount: 8, ReadCount: 2}).ReadRatio())
+	assert.Equal(t, float64(0), (&ChannelReadStat{}).ReadRatio())
+}
+
+func TestGetChannelReadStatsRollupWindow(t *testing.T) {
+	now := int64(100 * CHANNEL_READ_STATS_READ_WINDOW)
+	end := now - CHANNEL_READ_STATS_READ_WINDOW
+
+	since, until := GetChannelReadStatsRollupWindow(0, now)
+	assert.Equal(t, end-CHANNEL_READ_STATS_DEFAULT_ROLLUP_RANGE, since)
+	assert.Equal(t, end, until)
+
+	since, until = GetChannelReadStatsRollupWindow(end-5000, now)
+	assert.Equal(t, end-5000, since)
+	assert.Equal(t, end, until)
+
+	since, _ = GetChannelReadStatsRollupWindow(1, now)
+	assert.Equal(t, end-CHANNEL_READ_STATS_MAX_ROLLUP_PERIOD, since)
+
+	since, until = GetChannelReadStatsRollupWindow(end+5000, now)
+	assert.Equal(t, end, since)
+	assert.Equal(t, end, until)
+}
@@ -20,6 +20,10 @@ func (o *ChannelStats) ToJson() string {
 	return string(b)
 }
 
+func (o *ChannelStats) Etag() string {
+	return Etag(o.ChannelId, o.MemberCount, o.GuestCount, o.PinnedPostCount)
+}
+
 func ChannelStatsFromJson(data io.Reader) *ChannelStats {
 	var o *ChannelStats
 	json.NewDecoder(data).Decode(&o)
@@ -0,0 +1,68 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// ChannelTeamBinding surfaces a channel in a team other than its own. The channel stays a single channel, with one
+// set of members and posts, but the members of the bound team can find it, join it and search it from that team as
+// if it was one of its channels.
+type ChannelTeamBinding struct {
+	ChannelId string `json:"channel_id"`
+	TeamId    string `json:"team_id"`
+	CreatorId string `json:"creator_id"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+func (o *ChannelTeamBinding) IsValid() *AppError {
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("ChannelTeamBinding.IsValid", "model.channel_team_binding.is_valid.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.TeamId) {
+		return NewAppError("ChannelTeamBinding.IsValid", "model.channel_team_binding.is_valid.team_id.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	if len(o.CreatorId) > 26 {
+		return NewAppError("ChannelTeamBinding.IsValid", "model.channel_team_binding.is_valid.creator_id.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ChannelTeamBinding.IsValid", "model.channel_team_binding.is_valid.create_at.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *ChannelTeamBinding) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func (o *ChannelTeamBinding) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelTeamBindingFromJson(data io.Reader) *ChannelTeamBinding {
+	var o *ChannelTeamBinding
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func ChannelTeamBindingListToJson(l []*ChannelTeamBinding) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ChannelTeamBindingListFromJson(data io.Reader) []*ChannelTeamBinding {
+	var o []*ChannelTeamBinding
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelTeamBindingJson(t *testing.T) {
+	binding := ChannelTeamBinding{ChannelId: NewId(), TeamId: NewId(), CreatorId: NewId(), CreateAt: GetMillis()}
+	result := ChannelTeamBindingFromJson(strings.NewReader(binding.ToJson()))
+	assert.Equal(t, binding, *result)
+
+	list := ChannelTeamBindingListFromJson(strings.NewReader(ChannelTeamBindingListToJson([]*ChannelTeamBinding{&binding})))
+	require.Len(t, list, 1)
+	assert.Equal(t, binding, *list[0])
+}
+
+func TestChannelTeamBindingIsValid(t *testing.T) {
+	binding := ChannelTeamBinding{ChannelId: NewId(), TeamId: NewId()}
+	binding.PreSave()
+	require.Nil(t, binding.IsValid())
+
+	for name, update := range map[string]func(b *ChannelTeamBinding){
+		"channel id": func(b *ChannelTeamBinding) { b.ChannelId = "abc" },
+		"team id":    func(b *ChannelTeamBinding) { b.TeamId = "" },
+		"creator id": func(b *ChannelTeamBinding) { b.CreatorId = strings.Repeat("a", 27) },
+		"create at":  func(b *ChannelTeamBinding) { b.CreateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := binding
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
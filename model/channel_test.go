@@ -6,6 +6,8 @@ package model
 import (
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestChannelJson(t *testing.T) {
@@ -37,12 +39,17 @@ func TestChannelCopy(t *testing.T) {
 }
 
 func TestChannelPatch(t *testing.T) {
-	p := &ChannelPatch{Name: new(string), DisplayName: new(string), Header: new(string), Purpose: new(string), GroupConstrained: new(bool)}
+	p := &ChannelPatch{Name: new(string), DisplayName: new(string), Header: new(string), Purpose: new(string), GroupConstrained: new(bool), ReplyBroadcastPolicy: new(string), HashtagsDisabled: new(bool), ModerationEnabled: new(bool), WelcomeMessage: new(string), LinkPreviewsDisabled: new(bool)}
 	*p.Name = NewId()
 	*p.DisplayName = NewId()
 	*p.Header = NewId()
 	*p.Purpose = NewId()
 	*p.GroupConstrained = true
+	*p.ReplyBroadcastPolicy = CHANNEL_REPLY_BROADCAST_DEFAULT_THREAD
+	*p.HashtagsDisabled = true
+	*p.ModerationEnabled = true
+	*p.WelcomeMessage = "welcome"
+	*p.LinkPreviewsDisabled = true
 
 	o := Channel{Id: NewId(), Name: NewId()}
 	o.Patch(p)
@@ -62,6 +69,21 @@ func TestChannelPatch(t *testing.T) {
 	if *p.GroupConstrained != *o.GroupConstrained {
 		t.Fatalf("expected %v got %v", *p.GroupConstrained, *o.GroupConstrained)
 	}
+	if *p.ReplyBroadcastPolicy != o.ReplyBroadcastPolicy {
+		t.Fatal("do not match")
+	}
+	if *p.HashtagsDisabled != o.HashtagsDisabled {
+		t.Fatal("do not match")
+	}
+	if *p.ModerationEnabled != o.ModerationEnabled {
+		t.Fatal("do not match")
+	}
+	if *p.WelcomeMessage != o.WelcomeMessage {
+		t.Fatal("do not match")
+	}
+	if *p.LinkPreviewsDisabled != o.LinkPreviewsDisabled {
+		t.Fatal("do not match")
+	}
 }
 
 func TestChannelIsValid(t *testing.T) {
@@ -138,6 +160,103 @@ func TestChannelIsValid(t *testing.T) {
 	if err := o.IsValid(); err != nil {
 		t.Fatal(err)
 	}
+
+	o.ReplyBroadcastPolicy = "invalid"
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.ReplyBroadcastPolicy = CHANNEL_REPLY_BROADCAST_THREAD_ONLY
+	if err := o.IsValid(); err != nil {
+		t.Fatal(err)
+	}
+
+	o.AllowedWebhookIds = StringArray{NewId()}
+	o.AllowedBotIds = StringArray{NewId(), NewId()}
+	if err := o.IsValid(); err != nil {
+		t.Fatal(err)
+	}
+
+	o.AllowedWebhookIds = StringArray{"invalid"}
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.AllowedWebhookIds = nil
+	o.AllowedBotIds = make(StringArray, CHANNEL_ALLOWED_INTEGRATIONS_MAX+1)
+	for i := range o.AllowedBotIds {
+		o.AllowedBotIds[i] = NewId()
+	}
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.AllowedBotIds = nil
+	o.WelcomeMessage = strings.Repeat("a", CHANNEL_WELCOME_MESSAGE_MAX_RUNES)
+	o.WelcomeMessageType = CHANNEL_WELCOME_MESSAGE_TYPE_DIRECT
+	if err := o.IsValid(); err != nil {
+		t.Fatal(err)
+	}
+
+	o.WelcomeMessage += "a"
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.WelcomeMessage = "welcome"
+	o.WelcomeMessageType = "invalid"
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+}
+
+func TestChannelRenderWelcomeMessage(t *testing.T) {
+	o := Channel{DisplayName: "Town Square", WelcomeMessage: "Welcome to {{channel}}, {{user}}! {{other}}"}
+	assert.Equal(t, "Welcome to Town Square, @someone! {{other}}", o.RenderWelcomeMessage(&User{Username: "someone"}))
+}
+
+func TestChannelIsIntegrationAllowed(t *testing.T) {
+	hookId := NewId()
+	botId := NewId()
+
+	o := Channel{}
+	assert.True(t, o.IsWebhookAllowed(hookId))
+	assert.True(t, o.IsBotAllowed(botId))
+
+	o.IntegrationsRestricted = true
+	assert.False(t, o.IsWebhookAllowed(hookId))
+	assert.False(t, o.IsBotAllowed(botId))
+
+	o.AllowedWebhookIds = StringArray{hookId}
+	o.AllowedBotIds = StringArray{botId}
+	assert.True(t, o.IsWebhookAllowed(hookId))
+	assert.True(t, o.IsBotAllowed(botId))
+	assert.False(t, o.IsWebhookAllowed(NewId()))
+	assert.False(t, o.IsBotAllowed(NewId()))
+}
+
+func TestChannelShouldBroadcastReply(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Policy    string
+		Requested *bool
+		Expected  bool
+	}{
+		"no policy, no choice":       {"", nil, true},
+		"no policy, thread only":     {"", NewBool(false), false},
+		"default channel, no choice": {CHANNEL_REPLY_BROADCAST_DEFAULT_CHANNEL, nil, true},
+		"default channel, broadcast": {CHANNEL_REPLY_BROADCAST_DEFAULT_CHANNEL, NewBool(true), true},
+		"default thread, no choice":  {CHANNEL_REPLY_BROADCAST_DEFAULT_THREAD, nil, false},
+		"default thread, broadcast":  {CHANNEL_REPLY_BROADCAST_DEFAULT_THREAD, NewBool(true), true},
+		"thread only, broadcast":     {CHANNEL_REPLY_BROADCAST_THREAD_ONLY, NewBool(true), false},
+		"thread only, no choice":     {CHANNEL_REPLY_BROADCAST_THREAD_ONLY, nil, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			o := Channel{ReplyBroadcastPolicy: tc.Policy}
+			if actual := o.ShouldBroadcastReply(tc.Requested); actual != tc.Expected {
+				t.Fatalf("expected %v got %v", tc.Expected, actual)
+			}
+		})
+	}
 }
 
 func TestChannelPreSave(t *testing.T) {
@@ -0,0 +1,165 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	CHANNEL_TIMELINE_FORMAT_MARKDOWN = "markdown"
+	CHANNEL_TIMELINE_FORMAT_JSON     = "json"
+
+	CHANNEL_TIMELINE_EVENT_POST               = "post"
+	CHANNEL_TIMELINE_EVENT_JOIN               = "join"
+	CHANNEL_TIMELINE_EVENT_LEAVE              = "leave"
+	CHANNEL_TIMELINE_EVENT_HEADER_CHANGE      = "header_change"
+	CHANNEL_TIMELINE_EVENT_PURPOSE_CHANGE     = "purpose_change"
+	CHANNEL_TIMELINE_EVENT_DISPLAYNAME_CHANGE = "display_name_change"
+	CHANNEL_TIMELINE_EVENT_SYSTEM             = "system"
+	CHANNEL_TIMELINE_EVENT_PIN                = "pin"
+	CHANNEL_TIMELINE_EVENT_UNPIN              = "unpin"
+
+	CHANNEL_TIMELINE_MAX_RANGE = 90 * 24 * 60 * 60 * 1000
+
+	CHANNEL_TIMELINE_JOB_DATA_CHANNEL_ID   = "channel_id"
+	CHANNEL_TIMELINE_JOB_DATA_START_TIME   = "start_time"
+	CHANNEL_TIMELINE_JOB_DATA_END_TIME     = "end_time"
+	CHANNEL_TIMELINE_JOB_DATA_FORMAT       = "format"
+	CHANNEL_TIMELINE_JOB_DATA_REQUESTED_BY = "requested_by"
+	CHANNEL_TIMELINE_JOB_DATA_FILE_PATH    = "file_path"
+	CHANNEL_TIMELINE_JOB_DATA_ENTRY_COUNT  = "entry_count"
+
+	channelTimelineTimeFormat = "2006-01-02 15:04:05 MST"
+)
+
+// ChannelTimelineRequest describes the period of a channel to export as a timeline.
+type ChannelTimelineRequest struct {
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	Format    string `json:"format"`
+}
+
+// ChannelTimelineEntry is a single event of a channel timeline. Annotation describes events that are not
+// plain posts, such as a header change, in words.
+type ChannelTimelineEntry struct {
+	Timestamp  int64  `json:"timestamp"`
+	Type       string `json:"type"`
+	UserId     string `json:"user_id,omitempty"`
+	Username   string `json:"username,omitempty"`
+	PostId     string `json:"post_id,omitempty"`
+	RootId     string `json:"root_id,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Annotation string `json:"annotation,omitempty"`
+	Pinned     bool   `json:"pinned,omitempty"`
+	Edited     bool   `json:"edited,omitempty"`
+}
+
+// ChannelTimeline is a chronological record of the activity in a channel between two timestamps.
+type ChannelTimeline struct {
+	ChannelId   string                  `json:"channel_id"`
+	Name        string                  `json:"name"`
+	DisplayName string                  `json:"display_name"`
+	StartTime   int64                   `json:"start_time"`
+	EndTime     int64                   `json:"end_time"`
+	GeneratedAt int64                   `json:"generated_at"`
+	Entries     []*ChannelTimelineEntry `json:"entries"`
+}
+
+func (r *ChannelTimelineRequest) IsValid() *AppError {
+	if r.StartTime <= 0 || r.EndTime <= r.StartTime {
+		return NewAppError("ChannelTimelineRequest.IsValid", "model.channel_timeline.is_valid.range.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.EndTime-r.StartTime > CHANNEL_TIMELINE_MAX_RANGE {
+		return NewAppError("ChannelTimelineRequest.IsValid", "model.channel_timeline.is_valid.max_range.app_error", map[string]interface{}{"Days": CHANNEL_TIMELINE_MAX_RANGE / (24 * 60 * 60 * 1000)}, "", http.StatusBadRequest)
+	}
+
+	if r.Format != CHANNEL_TIMELINE_FORMAT_MARKDOWN && r.Format != CHANNEL_TIMELINE_FORMAT_JSON {
+		return NewAppError("ChannelTimelineRequest.IsValid", "model.channel_timeline.is_valid.format.app_error", nil, "format="+r.Format, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (r *ChannelTimelineRequest) ToJson() string {
+	b, _ := json.Marshal(r)
+	return string(b)
+}
+
+func ChannelTimelineRequestFromJson(data io.Reader) *ChannelTimelineRequest {
+	var r *ChannelTimelineRequest
+	json.NewDecoder(data).Decode(&r)
+	return r
+}
+
+func (t *ChannelTimeline) ToJson() string {
+	b, _ := json.MarshalIndent(t, "", "  ")
+	return string(b)
+}
+
+func ChannelTimelineFromJson(data io.Reader) *ChannelTimeline {
+	var t *ChannelTimeline
+	json.NewDecoder(data).Decode(&t)
+	return t
+}
+
+func formatChannelTimelineTime(millis int64) string {
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(channelTimelineTimeFormat)
+}
+
+// ToMarkdown renders the timeline as a Markdown document suitable for pasting into a postmortem.
+func (t *ChannelTimeline) ToMarkdown() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# Timeline of %s (~%s)\n\n", t.DisplayName, t.Name)
+	fmt.Fprintf(&b, "From %s to %s, generated %s.\n\n", formatChannelTimelineTime(t.StartTime), formatChannelTimelineTime(t.EndTime), formatChannelTimelineTime(t.GeneratedAt))
+
+	if len(t.Entries) == 0 {
+		b.WriteString("_No activity in this period._\n")
+		return b.String()
+	}
+
+	pinned := []*ChannelTimelineEntry{}
+	for _, entry := range t.Entries {
+		fmt.Fprintf(&b, "- **%s** ", formatChannelTimelineTime(entry.Timestamp))
+
+		if entry.Type == CHANNEL_TIMELINE_EVENT_POST {
+			if entry.RootId != "" {
+				b.WriteString("↳ ")
+			}
+			fmt.Fprintf(&b, "@%s: ", entry.Username)
+
+			message := strings.Replace(strings.TrimSpace(entry.Message), "\n", "\n  ", -1)
+			b.WriteString(message)
+
+			if entry.Edited {
+				b.WriteString(" _(edited)_")
+			}
+			if entry.Pinned {
+				b.WriteString(" 📌")
+				pinned = append(pinned, entry)
+			}
+		} else {
+			fmt.Fprintf(&b, "_%s_", entry.Annotation)
+		}
+
+		b.WriteString("\n")
+	}
+
+	if len(pinned) > 0 {
+		b.WriteString("\n## Pinned messages\n\n")
+		for _, entry := range pinned {
+			fmt.Fprintf(&b, "- **%s** @%s: %s\n", formatChannelTimelineTime(entry.Timestamp), entry.Username, strings.Replace(strings.TrimSpace(entry.Message), "\n", " ", -1))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelTimelineRequestIsValid(t *testing.T) {
+	r := ChannelTimelineRequest{StartTime: 1000, EndTime: 2000, Format: CHANNEL_TIMELINE_FORMAT_MARKDOWN}
+	require.Nil(t, r.IsValid())
+
+	r.Format = "pdf"
+	assert.NotNil(t, r.IsValid())
+
+	r.Format = CHANNEL_TIMELINE_FORMAT_JSON
+	r.EndTime = r.StartTime
+	assert.NotNil(t, r.IsValid())
+
+	r.EndTime = r.StartTime + CHANNEL_TIMELINE_MAX_RANGE + 1
+	assert.NotNil(t, r.IsValid())
+
+	r.StartTime = 0
+	r.EndTime = 1000
+	assert.NotNil(t, r.IsValid())
+}
+
+func TestChannelTimelineToMarkdown(t *testing.T) {
+	timeline := &ChannelTimeline{
+		Name:        "incident-42",
+		DisplayName: "Incident 42",
+		StartTime:   1570000000000,
+		EndTime:     1570003600000,
+		GeneratedAt: 1570007200000,
+	}
+
+	assert.Contains(t, timeline.ToMarkdown(), "_No activity in this period._")
+
+	timeline.Entries = []*ChannelTimelineEntry{
+		{Timestamp: 1570000001000, Type: CHANNEL_TIMELINE_EVENT_JOIN, Username: "alice", Annotation: "alice joined the channel."},
+		{Timestamp: 1570000002000, Type: CHANNEL_TIMELINE_EVENT_POST, Username: "alice", PostId: "a", Message: "database is down\nlooking into it", Pinned: true},
+		{Timestamp: 1570000003000, Type: CHANNEL_TIMELINE_EVENT_POST, Username: "bob", PostId: "b", RootId: "a", Message: "on it", Edited: true},
+	}
+
+	markdown := timeline.ToMarkdown()
+	assert.True(t, strings.HasPrefix(markdown, "# Timeline of Incident 42 (~incident-42)\n"))
+	assert.Contains(t, markdown, "From 2019-10-02 07:06:40 UTC to 2019-10-02 08:06:40 UTC")
+	assert.Contains(t, markdown, "- **2019-10-02 07:06:41 UTC** _alice joined the channel._\n")
+	assert.Contains(t, markdown, "- **2019-10-02 07:06:42 UTC** @alice: database is down\n  looking into it 📌\n")
+	assert.Contains(t, markdown, "- **2019-10-02 07:06:43 UTC** ↳ @bob: on it _(edited)_\n")
+	assert.Contains(t, markdown, "## Pinned messages\n\n- **2019-10-02 07:06:42 UTC** @alice: database is down looking into it\n")
+}
@@ -32,6 +32,7 @@ const (
 	HEADER_AUTH               = "Authorization"
 	HEADER_REQUESTED_WITH     = "X-Requested-With"
 	HEADER_REQUESTED_WITH_XML = "XMLHttpRequest"
+	HEADER_IMPERSONATOR_ID    = "X-Impersonator-ID"
 	STATUS                    = "status"
 	STATUS_OK                 = "OK"
 	STATUS_FAIL               = "FAIL"
@@ -196,6 +197,10 @@ func (c *Client4) GetTeamImportRoute(teamId string) string {
 	return fmt.Sprintf(c.GetTeamRoute(teamId) + "/import")
 }
 
+func (c *Client4) GetTeamHashtagsRoute(teamId string) string {
+	return fmt.Sprintf(c.GetTeamRoute(teamId) + "/hashtags")
+}
+
 func (c *Client4) GetChannelsRoute() string {
 	return fmt.Sprintf("/channels")
 }
@@ -360,10 +365,126 @@ func (c *Client4) GetEmojiRoute(emojiId string) string {
 	return fmt.Sprintf(c.GetEmojisRoute()+"/%v", emojiId)
 }
 
+func (c *Client4) GetPendingPostsRoute() string {
+	return fmt.Sprintf("/pending_posts")
+}
+
+func (c *Client4) GetPendingPostRoute(pendingPostId string) string {
+	return fmt.Sprintf(c.GetPendingPostsRoute()+"/%v", pendingPostId)
+}
+
+func (c *Client4) GetContentFiltersRoute() string {
+	return fmt.Sprintf("/content_filters")
+}
+
+func (c *Client4) GetContentFilterRoute(filterId string) string {
+	return fmt.Sprintf(c.GetContentFiltersRoute()+"/%v", filterId)
+}
+
+func (c *Client4) GetKeywordRulesRoute() string {
+	return fmt.Sprintf("/keyword_rules")
+}
+
+func (c *Client4) GetKeywordRuleRoute(ruleId string) string {
+	return fmt.Sprintf(c.GetKeywordRulesRoute()+"/%v", ruleId)
+}
+
+func (c *Client4) GetPostReportsRoute() string {
+	return fmt.Sprintf("/reports")
+}
+
+func (c *Client4) GetPostReportRoute(reportId string) string {
+	return fmt.Sprintf(c.GetPostReportsRoute()+"/%v", reportId)
+}
+
+func (c *Client4) GetRecurringPostsRoute() string {
+	return fmt.Sprintf("/recurring_posts")
+}
+
+func (c *Client4) GetRecurringPostRoute(recurringPostId string) string {
+	return fmt.Sprintf(c.GetRecurringPostsRoute()+"/%v", recurringPostId)
+}
+
+func (c *Client4) GetUserAvailabilityRoute(userId string) string {
+	return fmt.Sprintf(c.GetUserRoute(userId) + "/availability")
+}
+
+func (c *Client4) GetCallsRoute() string {
+	return fmt.Sprintf("/calls")
+}
+
+func (c *Client4) GetCallRoute(callId string) string {
+	return fmt.Sprintf(c.GetCallsRoute()+"/%v", callId)
+}
+
+func (c *Client4) GetMessageExportConsumersRoute() string {
+	return fmt.Sprintf("/compliance/export/consumers")
+}
+
+func (c *Client4) GetMessageExportConsumerRoute(consumerId string) string {
+	return fmt.Sprintf(c.GetMessageExportConsumersRoute()+"/%v", consumerId)
+}
+
+func (c *Client4) GetPostPurgesRoute() string {
+	return fmt.Sprintf("/post_purges")
+}
+
+func (c *Client4) GetPostPurgeRoute(purgeId string) string {
+	return fmt.Sprintf(c.GetPostPurgesRoute()+"/%v", purgeId)
+}
+
+func (c *Client4) GetAnnouncementsRoute() string {
+	return fmt.Sprintf("/announcements")
+}
+
+func (c *Client4) GetAnnouncementRoute(announcementId string) string {
+	return fmt.Sprintf(c.GetAnnouncementsRoute()+"/%v", announcementId)
+}
+
+func (c *Client4) GetOnboardingStepsRoute() string {
+	return fmt.Sprintf("/onboarding/steps")
+}
+
+func (c *Client4) GetOnboardingStepRoute(stepId string) string {
+	return fmt.Sprintf(c.GetOnboardingStepsRoute()+"/%v", stepId)
+}
+
+func (c *Client4) GetMentionAliasesRoute() string {
+	return fmt.Sprintf("/mention_aliases")
+}
+
+func (c *Client4) GetMentionAliasRoute(aliasId string) string {
+	return fmt.Sprintf(c.GetMentionAliasesRoute()+"/%v", aliasId)
+}
+
+func (c *Client4) GetUserAttributeFieldsRoute() string {
+	return fmt.Sprintf("/user_attribute_fields")
+}
+
+func (c *Client4) GetUserAttributeFieldRoute(fieldId string) string {
+	return fmt.Sprintf(c.GetUserAttributeFieldsRoute()+"/%v", fieldId)
+}
+
 func (c *Client4) GetEmojiByNameRoute(name string) string {
 	return fmt.Sprintf(c.GetEmojisRoute()+"/name/%v", name)
 }
 
+func (c *Client4) GetEmojiAliasesRoute() string {
+	return c.GetEmojisRoute() + "/aliases"
+}
+
+func (c *Client4) GetEmojiAliasRoute(alias string) string {
+	return fmt.Sprintf(c.GetEmojiAliasesRoute()+"/%v", alias)
+}
+
+func (c *Client4) GetPendingEmojisRoute() string {
+	return c.GetEmojisRoute() + "/pending"
+}
+
+func (c *Client4) GetPendingEmojiRoute(pendingEmojiId string) string {
+	return fmt.Sprintf(c.GetPendingEmojisRoute()+"/%v", pendingEmojiId)
+}
+
 func (c *Client4) GetReactionsRoute() string {
 	return fmt.Sprintf("/reactions")
 }
@@ -384,6 +505,10 @@ func (c *Client4) GetJobsRoute() string {
 	return fmt.Sprintf("/jobs")
 }
 
+func (c *Client4) GetIntegrationsRoute() string {
+	return fmt.Sprintf("/integrations")
+}
+
 func (c *Client4) GetRolesRoute() string {
 	return fmt.Sprintf("/roles")
 }
@@ -975,6 +1100,37 @@ func (c *Client4) GetUsersByUsernames(usernames []string) ([]*User, *Response) {
 	return UserListFromJson(r.Body), BuildResponse(r)
 }
 
+// CreateBulkUsersJob schedules a job deactivating, changing the roles, moving between teams or forcing a password
+// reset for a list of users.
+func (c *Client4) CreateBulkUsersJob(operation *BulkUsersOperation) (*Job, *Response) {
+	r, err := c.DoApiPost(c.GetUsersRoute()+"/bulk", operation.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return JobFromJson(r.Body), BuildResponse(r)
+}
+
+// GetBulkUsersResults returns the outcome for each user of a bulk users job.
+func (c *Client4) GetBulkUsersResults(jobId string) (*BulkUsersResults, *Response) {
+	r, err := c.DoApiGet(c.GetUsersRoute()+"/bulk/"+jobId+"/results", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return BulkUsersResultsFromJson(r.Body), BuildResponse(r)
+}
+
+// AnonymizeUser schedules a job erasing the personal data of a user while keeping their posts.
+func (c *Client4) AnonymizeUser(userId string) (*Job, *Response) {
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+"/anonymize", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return JobFromJson(r.Body), BuildResponse(r)
+}
+
 // GetUsersByGroupChannelIds returns a map with channel ids as keys
 // and a list of users as values based on the provided user ids.
 func (c *Client4) GetUsersByGroupChannelIds(groupChannelIds []string) (map[string][]*User, *Response) {
@@ -1042,7 +1198,24 @@ func (c *Client4) UpdateUserMfa(userId, code string, activate bool) (bool, *Resp
 		return false, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
-	return CheckStatusOK(r), BuildResponse(r)
+	return r.StatusCode == http.StatusOK, BuildResponse(r)
+}
+
+// ActivateUserMfa activates multi-factor authentication for a user with a code from their authenticator, returning
+// the recovery codes generated for them. They are only ever returned by this call.
+func (c *Client4) ActivateUserMfa(userId, code string) ([]string, *Response) {
+	requestBody := map[string]interface{}{"activate": true, "code": code}
+
+	r, err := c.DoApiPut(c.GetUserRoute(userId)+"/mfa", StringInterfaceToJson(requestBody))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	codes := MfaRecoveryCodesFromJson(r.Body)
+	if codes == nil {
+		return nil, BuildResponse(r)
+	}
+	return codes.Codes, BuildResponse(r)
 }
 
 // CheckUserMfa checks whether a user has MFA active on their account or not based on the
@@ -1076,6 +1249,41 @@ func (c *Client4) GenerateMfaSecret(userId string) (*MfaSecret, *Response) {
 	return MfaSecretFromJson(r.Body), BuildResponse(r)
 }
 
+// GenerateMfaRecoveryCodes replaces the MFA recovery codes of a user with new ones and returns them. Must be
+// logged in as the user.
+func (c *Client4) GenerateMfaRecoveryCodes(userId string) ([]string, *Response) {
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+"/mfa/recovery_codes", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	codes := MfaRecoveryCodesFromJson(r.Body)
+	if codes == nil {
+		return nil, BuildResponse(r)
+	}
+	return codes.Codes, BuildResponse(r)
+}
+
+// ResetUserMfa turns MFA off for a user so that they can enroll again. Must be a system administrator.
+func (c *Client4) ResetUserMfa(userId string) (bool, *Response) {
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+"/mfa/reset", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetMfaAdoption returns how many of the users who could enable MFA did. Must be a system administrator.
+func (c *Client4) GetMfaAdoption() (*MfaAdoption, *Response) {
+	r, err := c.DoApiGet(c.GetUsersRoute()+"/mfa/adoption", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MfaAdoptionFromJson(r.Body), BuildResponse(r)
+}
+
 // UpdateUserPassword updates a user's password. Must be logged in as the user or be a system administrator.
 func (c *Client4) UpdateUserPassword(userId, currentPassword, newPassword string) (bool, *Response) {
 	requestBody := map[string]string{"current_password": currentPassword, "new_password": newPassword}
@@ -1174,6 +1382,27 @@ func (c *Client4) GetSessions(userId, etag string) ([]*Session, *Response) {
 	return SessionsFromJson(r.Body), BuildResponse(r)
 }
 
+// GetLoginAttempts returns a page of the login attempts of a user, starting with the latest.
+func (c *Client4) GetLoginAttempts(userId string, page, perPage int) ([]*LoginAttempt, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/login_attempts"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return LoginAttemptListFromJson(r.Body), BuildResponse(r)
+}
+
+// UnlockUser lifts the lock failed logins put on the account of a user.
+func (c *Client4) UnlockUser(userId string) (bool, *Response) {
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+"/unlock", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // RevokeSession revokes a user session based on the provided user id and session id strings.
 func (c *Client4) RevokeSession(userId, sessionId string) (bool, *Response) {
 	requestBody := map[string]string{"session_id": sessionId}
@@ -1205,6 +1434,61 @@ func (c *Client4) RevokeSessionsFromAllUsers() (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// ImpersonateUser creates a time-boxed session letting the current system admin act as the given user. The token
+// of the returned session can be used to make requests as that user.
+func (c *Client4) ImpersonateUser(userId string) (*Session, *Response) {
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+"/impersonate", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SessionFromJson(r.Body), BuildResponse(r)
+}
+
+// GetUserDeactivationSchedule returns when a user is scheduled to be deactivated. Must be a system administrator.
+func (c *Client4) GetUserDeactivationSchedule(userId string) (*UserDeactivationSchedule, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/deactivation_schedule", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserDeactivationScheduleFromJson(r.Body), BuildResponse(r)
+}
+
+// GetUserDeactivationSchedules returns a page of the scheduled user deactivations, soonest first. Must be a system
+// administrator.
+func (c *Client4) GetUserDeactivationSchedules(page, perPage int) ([]*UserDeactivationSchedule, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetUsersRoute()+"/deactivation_schedules"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserDeactivationScheduleListFromJson(r.Body), BuildResponse(r)
+}
+
+// ScheduleUserDeactivation schedules a user to be deactivated and offboarded at the given time, in milliseconds since
+// the epoch. Must be a system administrator.
+func (c *Client4) ScheduleUserDeactivation(userId string, deactivateAt int64) (*UserDeactivationSchedule, *Response) {
+	requestBody := StringInterface{"deactivate_at": deactivateAt}
+	r, err := c.DoApiPut(c.GetUserRoute(userId)+"/deactivation_schedule", StringInterfaceToJson(requestBody))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserDeactivationScheduleFromJson(r.Body), BuildResponse(r)
+}
+
+// CancelUserDeactivation cancels the scheduled deactivation of a user. Must be a system administrator.
+func (c *Client4) CancelUserDeactivation(userId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetUserRoute(userId) + "/deactivation_schedule")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // AttachDeviceId attaches a mobile device ID to the current session.
 func (c *Client4) AttachDeviceId(deviceId string) (bool, *Response) {
 	requestBody := map[string]string{"device_id": deviceId}
@@ -1244,6 +1528,16 @@ func (c *Client4) GetUserAudits(userId string, page int, perPage int, etag strin
 	return AuditsFromJson(r.Body), BuildResponse(r)
 }
 
+// GetUserPushNotificationDiagnostics returns the push notification delivery diagnostics for a user.
+func (c *Client4) GetUserPushNotificationDiagnostics(userId string) (*PushNotificationDiagnostics, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/push_notifications/diagnostics", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PushNotificationDiagnosticsFromJson(r.Body), BuildResponse(r)
+}
+
 // VerifyUserEmail will verify a user's email using the supplied token.
 func (c *Client4) VerifyUserEmail(token string) (bool, *Response) {
 	requestBody := map[string]string{"token": token}
@@ -1345,6 +1639,22 @@ func (c *Client4) GetUserAccessTokens(page int, perPage int) ([]*UserAccessToken
 	return UserAccessTokenListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetExpiringUserAccessTokens will get a page of the active access tokens expiring within
+// the given number of days, soonest first. A days of 0 uses the server default. The actual
+// token will not be returned. Must have the 'manage_system' permission.
+func (c *Client4) GetExpiringUserAccessTokens(days int, page int, perPage int) ([]*UserAccessToken, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	if days > 0 {
+		query += fmt.Sprintf("&days=%v", days)
+	}
+	r, err := c.DoApiGet(c.GetUserAccessTokensRoute()+"/expiring"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserAccessTokenListFromJson(r.Body), BuildResponse(r)
+}
+
 // GetUserAccessToken will get a user access tokens' id, description, is_active
 // and the user_id of the user it is for. The actual token will not be returned.
 // Must have the 'read_user_access_token' permission and if getting for another
@@ -1526,6 +1836,26 @@ func (c *Client4) AssignBot(botUserId, newOwnerId string) (*Bot, *Response) {
 	return BotFromJson(r.Body), BuildResponse(r)
 }
 
+// BroadcastDirectMessage starts sending a post from a bot as a direct message to each of the requested users.
+func (c *Client4) BroadcastDirectMessage(botUserId string, request *DirectMessageBroadcastRequest) (*DirectMessageBroadcast, *Response) {
+	r, err := c.DoApiPost(c.GetBotRoute(botUserId)+"/broadcasts", request.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return DirectMessageBroadcastFromJson(r.Body), BuildResponse(r)
+}
+
+// GetDirectMessageBroadcast returns the progress of a broadcast of direct messages from a bot.
+func (c *Client4) GetDirectMessageBroadcast(botUserId, broadcastId string) (*DirectMessageBroadcast, *Response) {
+	r, err := c.DoApiGet(c.GetBotRoute(botUserId)+"/broadcasts/"+broadcastId, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return DirectMessageBroadcastFromJson(r.Body), BuildResponse(r)
+}
+
 // SetBotIconImage sets LHS bot icon image.
 func (c *Client4) SetBotIconImage(botUserId string, data []byte) (bool, *Response) {
 	body := &bytes.Buffer{}
@@ -1861,6 +2191,39 @@ func (c *Client4) GetTeamStats(teamId, etag string) (*TeamStats, *Response) {
 	return TeamStatsFromJson(r.Body), BuildResponse(r)
 }
 
+// GetTrendingHashtags returns the hashtags used in the most posts of the public channels of a team since the
+// given time. A since of 0 and a limit of 0 use the server defaults.
+// Must be authenticated.
+func (c *Client4) GetTrendingHashtags(teamId string, since int64, limit int) ([]*HashtagCount, *Response) {
+	query := url.Values{}
+	if since > 0 {
+		query.Set("since", strconv.FormatInt(since, 10))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	r, err := c.DoApiGet(c.GetTeamHashtagsRoute(teamId)+"/trending?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return HashtagCountListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetHashtagPosts returns a page of the posts of a team using a hashtag in the channels the user is a member of,
+// newest first. The hashtag may be given with or without its leading #.
+// Must be authenticated.
+func (c *Client4) GetHashtagPosts(teamId, hashtag string, page, perPage int) (*PostList, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetTeamHashtagsRoute(teamId)+"/"+url.PathEscape(strings.TrimLeft(hashtag, "#"))+"/posts"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostListFromJson(r.Body), BuildResponse(r)
+}
+
 // GetTotalUsersStats returns a total system user stats.
 // Must be authenticated.
 func (c *Client4) GetTotalUsersStats(etag string) (*UsersStats, *Response) {
@@ -2111,6 +2474,18 @@ func (c *Client4) UpdateChannelPrivacy(channelId string, privacy string) (*Chann
 	return ChannelFromJson(r.Body), BuildResponse(r)
 }
 
+// ConvertGroupMessageToChannel converts a group message into a private channel of a team, keeping its members and
+// history.
+func (c *Client4) ConvertGroupMessageToChannel(channelId, teamId, name, displayName string) (*Channel, *Response) {
+	requestBody := map[string]string{"team_id": teamId, "name": name, "display_name": displayName}
+	r, err := c.DoApiPost(c.GetChannelRoute(channelId)+"/convert_to_channel", MapToJson(requestBody))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelFromJson(r.Body), BuildResponse(r)
+}
+
 // RestoreChannel restores a previously deleted channel. Any missing fields are not updated.
 func (c *Client4) RestoreChannel(channelId string) (*Channel, *Response) {
 	r, err := c.DoApiPost(c.GetChannelRoute(channelId)+"/restore", "")
@@ -2163,6 +2538,16 @@ func (c *Client4) GetChannelStats(channelId string, etag string) (*ChannelStats,
 	return ChannelStatsFromJson(r.Body), BuildResponse(r)
 }
 
+// GetChannelIntegrations gets the bots, webhooks and slash commands active in a channel.
+func (c *Client4) GetChannelIntegrations(channelId string) ([]*ChannelIntegration, *Response) {
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/integrations", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelIntegrationListFromJson(r.Body), BuildResponse(r)
+}
+
 // GetChannelMembersTimezones gets a list of timezones for a channel.
 func (c *Client4) GetChannelMembersTimezones(channelId string) ([]string, *Response) {
 	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/timezones", "")
@@ -2183,6 +2568,58 @@ func (c *Client4) GetPinnedPosts(channelId string, etag string) (*PostList, *Res
 	return PostListFromJson(r.Body), BuildResponse(r)
 }
 
+// CreateChannelTimelineExport schedules a job exporting the timeline of a channel between two timestamps.
+func (c *Client4) CreateChannelTimelineExport(channelId string, request *ChannelTimelineRequest) (*Job, *Response) {
+	r, err := c.DoApiPost(c.GetChannelRoute(channelId)+"/timeline_exports", request.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return JobFromJson(r.Body), BuildResponse(r)
+}
+
+// GetChannelTimelineExport gets the job exporting the timeline of a channel.
+func (c *Client4) GetChannelTimelineExport(channelId, jobId string) (*Job, *Response) {
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/timeline_exports/"+jobId, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return JobFromJson(r.Body), BuildResponse(r)
+}
+
+// DownloadChannelTimelineExport gets the bytes of a finished channel timeline export.
+func (c *Client4) DownloadChannelTimelineExport(channelId, jobId string) ([]byte, *Response) {
+	r, appErr := c.DoApiGet(c.GetChannelRoute(channelId)+"/timeline_exports/"+jobId+"/download", "")
+	if appErr != nil {
+		return nil, BuildErrorResponse(r, appErr)
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, NewAppError("DownloadChannelTimelineExport", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode))
+	}
+	return data, BuildResponse(r)
+}
+
+// ExportChannelPosts gets the posts of a channel created between two timestamps as CSV or JSON, gzipped if asked
+// to. Zero timestamps leave that end of the range open.
+func (c *Client4) ExportChannelPosts(channelId string, options *ChannelPostExportOptions) ([]byte, *Response) {
+	query := fmt.Sprintf("?format=%v&since=%v&until=%v&gzip=%v", options.Format, options.Since, options.Until, options.Gzip)
+	r, appErr := c.DoApiGet(c.GetChannelRoute(channelId)+"/posts/export"+query, "")
+	if appErr != nil {
+		return nil, BuildErrorResponse(r, appErr)
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, NewAppError("ExportChannelPosts", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode))
+	}
+	return data, BuildResponse(r)
+}
+
 // GetPublicChannelsForTeam returns a list of public channels based on the provided team id string.
 func (c *Client4) GetPublicChannelsForTeam(teamId string, page int, perPage int, etag string) ([]*Channel, *Response) {
 	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
@@ -2316,9 +2753,31 @@ func (c *Client4) GetChannelMembers(channelId string, page, perPage int, etag st
 	return ChannelMembersFromJson(r.Body), BuildResponse(r)
 }
 
-// GetChannelMembersByIds gets the channel members in a channel for a list of user ids.
-func (c *Client4) GetChannelMembersByIds(channelId string, userIds []string) (*ChannelMembers, *Response) {
-	r, err := c.DoApiPost(c.GetChannelMembersRoute(channelId)+"/ids", ArrayToJson(userIds))
+// GetChannelMembersWithOptions gets a page of the channel members of a channel whose users are active, sorted and
+// filtered by role according to the options.
+func (c *Client4) GetChannelMembersWithOptions(channelId string, page, perPage int, options *ChannelMembersGetOptions, etag string) (*ChannelMembers, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v&sort=%v&role=%v", page, perPage, url.QueryEscape(options.Sort), url.QueryEscape(options.Role))
+	r, err := c.DoApiGet(c.GetChannelMembersRoute(channelId)+query, etag)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMembersFromJson(r.Body), BuildResponse(r)
+}
+
+// GetChannelMemberCountsByRole gets the number of active members of a channel with each role.
+func (c *Client4) GetChannelMemberCountsByRole(channelId string) (*ChannelMemberCounts, *Response) {
+	r, err := c.DoApiGet(c.GetChannelMembersRoute(channelId)+"/role_counts", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMemberCountsFromJson(r.Body), BuildResponse(r)
+}
+
+// GetChannelMembersByIds gets the channel members in a channel for a list of user ids.
+func (c *Client4) GetChannelMembersByIds(channelId string, userIds []string) (*ChannelMembers, *Response) {
+	r, err := c.DoApiPost(c.GetChannelMembersRoute(channelId)+"/ids", ArrayToJson(userIds))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
@@ -2410,6 +2869,120 @@ func (c *Client4) AddChannelMember(channelId, userId string) (*ChannelMember, *R
 	return ChannelMemberFromJson(r.Body), BuildResponse(r)
 }
 
+// AddChannelMemberWithExpiry adds user to channel as a temporary member, removed from the channel at expiresAt,
+// and return a channel member.
+func (c *Client4) AddChannelMemberWithExpiry(channelId, userId string, expiresAt int64) (*ChannelMember, *Response) {
+	requestBody := StringInterface{"user_id": userId, "expires_at": expiresAt}
+	r, err := c.DoApiPost(c.GetChannelMembersRoute(channelId)+"", StringInterfaceToJson(requestBody))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMemberFromJson(r.Body), BuildResponse(r)
+}
+
+// GetChannelMemberExpiries returns when the temporary members of the channel are removed from it.
+func (c *Client4) GetChannelMemberExpiries(channelId string) ([]*ChannelMemberExpiry, *Response) {
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/member_expiries", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMemberExpiryListFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateChannelMemberExpiry sets or extends when a member is removed from the channel. An expiry of 0 makes the
+// membership permanent.
+func (c *Client4) UpdateChannelMemberExpiry(channelId, userId string, expiresAt int64) (bool, *Response) {
+	requestBody := StringInterface{"expires_at": expiresAt}
+	r, err := c.DoApiPut(c.GetChannelMemberRoute(channelId, userId)+"/expiry", StringInterfaceToJson(requestBody))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetChannelMembershipRules returns the rules maintaining the membership of the channel.
+func (c *Client4) GetChannelMembershipRules(channelId string) ([]*ChannelMembershipRule, *Response) {
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/membership_rules", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMembershipRuleListFromJson(r.Body), BuildResponse(r)
+}
+
+// AddChannelMembershipRule adds a rule to the channel, whose membership is then synced with its rules.
+func (c *Client4) AddChannelMembershipRule(channelId string, rule *ChannelMembershipRule) (*ChannelMembershipRule, *Response) {
+	r, err := c.DoApiPost(c.GetChannelRoute(channelId)+"/membership_rules", rule.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMembershipRuleFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteChannelMembershipRule removes a rule from the channel.
+func (c *Client4) DeleteChannelMembershipRule(channelId, ruleId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetChannelRoute(channelId) + "/membership_rules/" + ruleId)
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetChannelMembershipOverrides returns the users added to or removed from the channel by hand, against its rules.
+func (c *Client4) GetChannelMembershipOverrides(channelId string) ([]*ChannelMembershipOverride, *Response) {
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/membership_overrides", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMembershipOverrideListFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteChannelMembershipOverride hands the membership of the user in the channel back to its rules.
+func (c *Client4) DeleteChannelMembershipOverride(channelId, userId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetChannelMemberRoute(channelId, userId) + "/membership_override")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetChannelTeamBindings returns the teams the channel is surfaced in besides its own.
+func (c *Client4) GetChannelTeamBindings(channelId string) ([]*ChannelTeamBinding, *Response) {
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/teams", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelTeamBindingListFromJson(r.Body), BuildResponse(r)
+}
+
+// BindChannelToTeam surfaces the channel in another team.
+func (c *Client4) BindChannelToTeam(channelId, teamId string) (*ChannelTeamBinding, *Response) {
+	r, err := c.DoApiPost(c.GetChannelRoute(channelId)+"/teams/"+teamId, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelTeamBindingFromJson(r.Body), BuildResponse(r)
+}
+
+// UnbindChannelFromTeam stops surfacing the channel in the team.
+func (c *Client4) UnbindChannelFromTeam(channelId, teamId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetChannelRoute(channelId) + "/teams/" + teamId)
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // AddChannelMemberWithRootId adds user to channel and return a channel member. Post add to channel message has the postRootId.
 func (c *Client4) AddChannelMemberWithRootId(channelId, userId, postRootId string) (*ChannelMember, *Response) {
 	requestBody := map[string]string{"user_id": userId, "post_root_id": postRootId}
@@ -2525,6 +3098,17 @@ func (c *Client4) GetPost(postId string, etag string) (*Post, *Response) {
 	return PostFromJson(r.Body), BuildResponse(r)
 }
 
+// GetPostsByIds gets the posts with the given ids that the user can read, along with the reason each of the other
+// ones couldn't be returned.
+func (c *Client4) GetPostsByIds(postIds []string) (*PostsByIds, *Response) {
+	r, err := c.DoApiPost(c.GetPostsRoute()+"/ids", ArrayToJson(postIds))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostsByIdsFromJson(r.Body), BuildResponse(r)
+}
+
 // DeletePost deletes a post from the provided post id string.
 func (c *Client4) DeletePost(postId string) (bool, *Response) {
 	r, err := c.DoApiDelete(c.GetPostRoute(postId))
@@ -2545,6 +3129,61 @@ func (c *Client4) GetPostThread(postId string, etag string) (*PostList, *Respons
 	return PostListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetPermalinkPreview resolves a permalink to a post without joining the channel of the post. The post is redacted if
+// the user can't read the channel. With withThread, the preview has the whole thread of the post.
+func (c *Client4) GetPermalinkPreview(postId string, withThread bool) (*PermalinkPreview, *Response) {
+	query := fmt.Sprintf("?thread=%v", withThread)
+	r, err := c.DoApiGet(c.GetPostRoute(postId)+"/permalink"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PermalinkPreviewFromJson(r.Body), BuildResponse(r)
+}
+
+// CreatePublicPostLink creates a link that lets anyone view a post, and its thread with includeThread, without
+// logging in. Public post links must be enabled.
+func (c *Client4) CreatePublicPostLink(postId string, includeThread bool) (*PublicPostLink, *Response) {
+	link := &PublicPostLink{IncludeThread: includeThread}
+	r, err := c.DoApiPost(c.GetPostRoute(postId)+"/public_links", link.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PublicPostLinkFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPublicPostLinks gets the public links of a post that haven't been revoked.
+func (c *Client4) GetPublicPostLinks(postId string) ([]*PublicPostLink, *Response) {
+	r, err := c.DoApiGet(c.GetPostRoute(postId)+"/public_links", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PublicPostLinkListFromJson(r.Body), BuildResponse(r)
+}
+
+// RevokePublicPostLink revokes a public link of a post. Must have created the link or have manage_system permission.
+func (c *Client4) RevokePublicPostLink(postId, linkId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetPostRoute(postId) + "/public_links/" + linkId)
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// RedactPost replaces the message and/or removes the attachments of a post with a redaction notice, keeping the post
+// in its thread. Must have manage_system permission.
+func (c *Client4) RedactPost(postId string, redaction *PostRedaction) (*Post, *Response) {
+	r, err := c.DoApiPost(c.GetPostRoute(postId)+"/redact", redaction.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostFromJson(r.Body), BuildResponse(r)
+}
+
 // GetPostsForChannel gets a page of posts with an array for ordering for a channel.
 func (c *Client4) GetPostsForChannel(channelId string, page, perPage int, etag string) (*PostList, *Response) {
 	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
@@ -2567,6 +3206,16 @@ func (c *Client4) GetFlaggedPostsForUser(userId string, page int, perPage int) (
 	return PostListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetEphemeralPostsForUser returns the persisted ephemeral posts of a user sent or updated after since.
+func (c *Client4) GetEphemeralPostsForUser(userId string, since int64) (*PostList, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+fmt.Sprintf("/posts/ephemeral?since=%v", since), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostListFromJson(r.Body), BuildResponse(r)
+}
+
 // GetFlaggedPostsForUserInTeam returns flagged posts in team of a user based on user id string.
 func (c *Client4) GetFlaggedPostsForUserInTeam(userId string, teamId string, page int, perPage int) (*PostList, *Response) {
 	if len(teamId) == 0 || len(teamId) != 26 {
@@ -2876,6 +3525,18 @@ func (c *Client4) GetFileInfo(fileId string) (*FileInfo, *Response) {
 	return FileInfoFromJson(r.Body), BuildResponse(r)
 }
 
+// SetFileInfoVerdict attaches the verdict of a scanning or DLP pipeline to a file. Must be authenticated as a system
+// admin.
+func (c *Client4) SetFileInfoVerdict(fileId, verdict, reason string) (*FileInfo, *Response) {
+	data := map[string]string{"verdict": verdict, "reason": reason}
+	r, err := c.DoApiPut(c.GetFileRoute(fileId)+"/verdict", MapToJson(data))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return FileInfoFromJson(r.Body), BuildResponse(r)
+}
+
 // GetFileInfosForPost gets all the file info objects attached to a post.
 func (c *Client4) GetFileInfosForPost(postId string, etag string) ([]*FileInfo, *Response) {
 	r, err := c.DoApiGet(c.GetPostRoute(postId)+"/files/info", etag)
@@ -2902,6 +3563,21 @@ func (c *Client4) GetPing() (string, *Response) {
 	return MapFromJson(r.Body)["status"], BuildResponse(r)
 }
 
+// GetSystemHealth probes the health of the server and its dependencies, or only checks that the server is running
+// with the liveness probe.
+func (c *Client4) GetSystemHealth(probe string) (*SystemHealth, *Response) {
+	r, err := c.DoApiGet(c.GetSystemRoute()+"/health?probe="+url.QueryEscape(probe), "")
+	if r != nil && r.StatusCode == http.StatusServiceUnavailable {
+		defer closeBody(r)
+		return SystemHealthFromJson(r.Body), BuildErrorResponse(r, err)
+	}
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SystemHealthFromJson(r.Body), BuildResponse(r)
+}
+
 // GetPingWithServerStatus will return ok if several basic server health checks
 // all pass successfully.
 func (c *Client4) GetPingWithServerStatus() (string, *Response) {
@@ -2917,6 +3593,22 @@ func (c *Client4) GetPingWithServerStatus() (string, *Response) {
 	return MapFromJson(r.Body)["status"], BuildResponse(r)
 }
 
+// GetSupportPacket generates a support packet and returns the zip file.
+func (c *Client4) GetSupportPacket() ([]byte, *Response) {
+	r, appErr := c.DoApiGet(c.GetSystemRoute()+"/support_packet", "")
+	if appErr != nil {
+		return nil, BuildErrorResponse(r, appErr)
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, NewAppError("GetSupportPacket", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode))
+	}
+
+	return data, BuildResponse(r)
+}
+
 // TestEmail will attempt to connect to the configured SMTP server.
 func (c *Client4) TestEmail(config *Config) (bool, *Response) {
 	r, err := c.DoApiPost(c.GetTestEmailRoute(), config.ToJson())
@@ -3013,6 +3705,26 @@ func (c *Client4) DatabaseRecycle() (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// GetSchemaMigrations returns the schema migrations known to the server and their status.
+func (c *Client4) GetSchemaMigrations() ([]*SchemaMigration, *Response) {
+	r, err := c.DoApiGet(c.GetDatabaseRoute()+"/migrations", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SchemaMigrationListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetSlowQueries returns the most recent slow queries run by the server, when the slow query log is enabled.
+func (c *Client4) GetSlowQueries() ([]*SlowQuery, *Response) {
+	r, err := c.DoApiGet(c.GetDatabaseRoute()+"/slow_queries", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SlowQueryListFromJson(r.Body), BuildResponse(r)
+}
+
 // InvalidateCaches will purge the cache and can affect the performance while is cleaning.
 func (c *Client4) InvalidateCaches() (bool, *Response) {
 	r, err := c.DoApiPost(c.GetCacheRoute()+"/invalidate", "")
@@ -3033,6 +3745,58 @@ func (c *Client4) UpdateConfig(config *Config) (*Config, *Response) {
 	return ConfigFromJson(r.Body), BuildResponse(r)
 }
 
+// PatchConfig will update the server configuration with the settings set in the given, partial, configuration and
+// leave the others as they are.
+func (c *Client4) PatchConfig(config *Config) (*Config, *Response) {
+	r, err := c.DoApiPut(c.GetConfigRoute()+"/patch", config.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigFromJson(r.Body), BuildResponse(r)
+}
+
+// GetConfigAudits returns a page of the recorded configuration changes, starting with the most recent.
+func (c *Client4) GetConfigAudits(page int, perPage int) ([]*ConfigAudit, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetConfigRoute()+"/audits"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigAuditListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetConfigAudit returns a recorded configuration change.
+func (c *Client4) GetConfigAudit(auditId string) (*ConfigAudit, *Response) {
+	r, err := c.DoApiGet(c.GetConfigRoute()+"/audits/"+auditId, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigAuditFromJson(r.Body), BuildResponse(r)
+}
+
+// RollbackConfig restores the configuration resulting from a recorded change and returns it, sanitized.
+func (c *Client4) RollbackConfig(auditId string) (*Config, *Response) {
+	r, err := c.DoApiPost(c.GetConfigRoute()+"/audits/"+auditId+"/rollback", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigFromJson(r.Body), BuildResponse(r)
+}
+
+// GetLicenseUsage returns the usage of the licensed seats, with the usage recorded over the given number of days.
+func (c *Client4) GetLicenseUsage(days int) (*LicenseUsageSummary, *Response) {
+	r, err := c.DoApiGet(c.GetLicenseRoute()+"/usage?days="+strconv.Itoa(days), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return LicenseUsageSummaryFromJson(r.Body), BuildResponse(r)
+}
+
 // UploadLicenseFile will add a license file to the system.
 func (c *Client4) UploadLicenseFile(data []byte) (bool, *Response) {
 	body := &bytes.Buffer{}
@@ -3099,6 +3863,56 @@ func (c *Client4) GetAnalyticsOld(name, teamId string) (AnalyticsRows, *Response
 	return AnalyticsRowsFromJson(r.Body), BuildResponse(r)
 }
 
+// GetChannelReadStats returns a page of the aggregated reach of the root posts created in [since, until), optionally
+// restricted to a team or a channel. Must be authenticated as a system admin.
+func (c *Client4) GetChannelReadStats(teamId, channelId string, since, until int64, page, perPage int) ([]*ChannelReadStat, *Response) {
+	query := fmt.Sprintf("?team_id=%v&channel_id=%v&since=%v&until=%v&page=%v&per_page=%v", teamId, channelId, since, until, page, perPage)
+	r, err := c.DoApiGet(c.GetAnalyticsRoute()+"/channel_read_stats"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelReadStatListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetDailyStats returns the daily stats of a channel, of a team when no channel is given, or the system wide ones
+// when neither is, for the dates in [since, until] formatted as YYYY-MM-DD. Empty dates default to the last 30 days.
+// Must be authenticated as an admin of the team, or as a system admin for the system wide stats.
+func (c *Client4) GetDailyStats(teamId, channelId, since, until string) ([]*DailyStat, *Response) {
+	query := fmt.Sprintf("?team_id=%v&channel_id=%v&since=%v&until=%v", teamId, channelId, since, until)
+	r, err := c.DoApiGet(c.GetAnalyticsRoute()+"/daily_stats"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return DailyStatListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetIntegrationStats returns the activity of each integration of the given type, or of every type when none is
+// given, for the dates in [since, until] formatted as YYYY-MM-DD, with the busiest integrations first. Empty dates
+// default to the last 30 days including the current one. Must be authenticated as a system admin.
+func (c *Client4) GetIntegrationStats(integrationType, since, until string) ([]*IntegrationStat, *Response) {
+	query := fmt.Sprintf("?type=%v&since=%v&until=%v", integrationType, since, until)
+	r, err := c.DoApiGet(c.GetAnalyticsRoute()+"/integrations"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return IntegrationStatListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetIntegrationDailyStats returns the activity of an integration for each of the dates in [since, until] formatted
+// as YYYY-MM-DD. Must be authenticated as a system admin.
+func (c *Client4) GetIntegrationDailyStats(integrationType, integrationId, since, until string) ([]*IntegrationStat, *Response) {
+	query := fmt.Sprintf("?type=%v&integration_id=%v&since=%v&until=%v", integrationType, integrationId, since, until)
+	r, err := c.DoApiGet(c.GetAnalyticsRoute()+"/integrations/daily"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return IntegrationStatListFromJson(r.Body), BuildResponse(r)
+}
+
 // Webhooks Section
 
 // CreateIncomingWebhook creates an incoming webhook for a channel.
@@ -3132,6 +3946,16 @@ func (c *Client4) GetIncomingWebhooks(page int, perPage int, etag string) ([]*In
 	return IncomingWebhookListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetTopIncomingWebhookUsage returns the incoming webhooks that posted the most today, busiest first.
+func (c *Client4) GetTopIncomingWebhookUsage(limit int) ([]*IncomingWebhookUsage, *Response) {
+	r, err := c.DoApiGet(fmt.Sprintf("/hooks/usage/incoming?per_page=%v", limit), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return IncomingWebhookUsageListFromJson(r.Body), BuildResponse(r)
+}
+
 // GetIncomingWebhooksForTeam returns a page of incoming webhooks for a team. Page counting starts at 0.
 func (c *Client4) GetIncomingWebhooksForTeam(teamId string, page int, perPage int, etag string) ([]*IncomingWebhook, *Response) {
 	query := fmt.Sprintf("?page=%v&per_page=%v&team_id=%v", page, perPage, teamId)
@@ -3236,6 +4060,26 @@ func (c *Client4) RegenOutgoingHookToken(hookId string) (*OutgoingWebhook, *Resp
 	return OutgoingWebhookFromJson(r.Body), BuildResponse(r)
 }
 
+// GetOutgoingWebhookDeliveries returns the recent deliveries captured for an outgoing webhook in test mode.
+func (c *Client4) GetOutgoingWebhookDeliveries(hookId string) ([]*OutgoingWebhookDelivery, *Response) {
+	r, err := c.DoApiGet(c.GetOutgoingWebhookRoute(hookId)+"/deliveries", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return OutgoingWebhookDeliveryListFromJson(r.Body), BuildResponse(r)
+}
+
+// ReplayOutgoingWebhookDelivery sends a captured outgoing webhook delivery to its callback URL.
+func (c *Client4) ReplayOutgoingWebhookDelivery(hookId, deliveryId string) (*OutgoingWebhookDelivery, *Response) {
+	r, err := c.DoApiPost(c.GetOutgoingWebhookRoute(hookId)+"/deliveries/"+deliveryId+"/replay", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return OutgoingWebhookDeliveryFromJson(r.Body), BuildResponse(r)
+}
+
 // DeleteOutgoingWebhook delete the outgoing webhook on the system requested by Hook Id.
 func (c *Client4) DeleteOutgoingWebhook(hookId string) (bool, *Response) {
 	r, err := c.DoApiDelete(c.GetOutgoingWebhookRoute(hookId))
@@ -3246,22 +4090,795 @@ func (c *Client4) DeleteOutgoingWebhook(hookId string) (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
-// Preferences Section
+// Mention Aliases Section
 
-// GetPreferences returns the user's preferences.
-func (c *Client4) GetPreferences(userId string) (Preferences, *Response) {
-	r, err := c.DoApiGet(c.GetPreferencesRoute(userId), "")
+// CreateMentionAlias creates a mention alias. Must have the 'manage_system' permission.
+func (c *Client4) CreateMentionAlias(alias *MentionAlias) (*MentionAlias, *Response) {
+	r, err := c.DoApiPost(c.GetMentionAliasesRoute(), alias.ToJson())
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
-	preferences, _ := PreferencesFromJson(r.Body)
-	return preferences, BuildResponse(r)
+	return MentionAliasFromJson(r.Body), BuildResponse(r)
 }
 
-// UpdatePreferences saves the user's preferences.
-func (c *Client4) UpdatePreferences(userId string, preferences *Preferences) (bool, *Response) {
-	r, err := c.DoApiPut(c.GetPreferencesRoute(userId), preferences.ToJson())
+// UpdateMentionAlias updates a mention alias. Must have the 'manage_system' permission.
+func (c *Client4) UpdateMentionAlias(alias *MentionAlias) (*MentionAlias, *Response) {
+	r, err := c.DoApiPut(c.GetMentionAliasRoute(alias.Id), alias.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MentionAliasFromJson(r.Body), BuildResponse(r)
+}
+
+// GetMentionAliases returns a page of the mention aliases on the system. Page counting starts at 0.
+// Must have the 'manage_system' permission.
+func (c *Client4) GetMentionAliases(page int, perPage int) ([]*MentionAlias, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetMentionAliasesRoute()+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MentionAliasListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetMentionAlias returns a mention alias. Must have the 'manage_system' permission.
+func (c *Client4) GetMentionAlias(aliasId string) (*MentionAlias, *Response) {
+	r, err := c.DoApiGet(c.GetMentionAliasRoute(aliasId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MentionAliasFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteMentionAlias deletes a mention alias. Must have the 'manage_system' permission.
+func (c *Client4) DeleteMentionAlias(aliasId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetMentionAliasRoute(aliasId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// Content Filters Section
+
+// CreateContentFilter creates a content filter. Must have the 'manage_system' permission.
+func (c *Client4) CreateContentFilter(filter *ContentFilter) (*ContentFilter, *Response) {
+	r, err := c.DoApiPost(c.GetContentFiltersRoute(), filter.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ContentFilterFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateContentFilter updates a content filter. Must have the 'manage_system' permission.
+func (c *Client4) UpdateContentFilter(filter *ContentFilter) (*ContentFilter, *Response) {
+	r, err := c.DoApiPut(c.GetContentFilterRoute(filter.Id), filter.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ContentFilterFromJson(r.Body), BuildResponse(r)
+}
+
+// GetContentFilters returns a page of the content filters on the system. Page counting starts at 0.
+// Must have the 'manage_system' permission.
+func (c *Client4) GetContentFilters(page int, perPage int) ([]*ContentFilter, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetContentFiltersRoute()+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ContentFilterListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetContentFilter returns a content filter. Must have the 'manage_system' permission.
+func (c *Client4) GetContentFilter(filterId string) (*ContentFilter, *Response) {
+	r, err := c.DoApiGet(c.GetContentFilterRoute(filterId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ContentFilterFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteContentFilter deletes a content filter. Must have the 'manage_system' permission.
+func (c *Client4) DeleteContentFilter(filterId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetContentFilterRoute(filterId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// Keyword Rules Section
+
+// CreateKeywordRule creates a keyword rule. Must have the 'manage_system' permission.
+func (c *Client4) CreateKeywordRule(rule *KeywordRule) (*KeywordRule, *Response) {
+	r, err := c.DoApiPost(c.GetKeywordRulesRoute(), rule.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return KeywordRuleFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateKeywordRule updates a keyword rule. Must have the 'manage_system' permission.
+func (c *Client4) UpdateKeywordRule(rule *KeywordRule) (*KeywordRule, *Response) {
+	r, err := c.DoApiPut(c.GetKeywordRuleRoute(rule.Id), rule.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return KeywordRuleFromJson(r.Body), BuildResponse(r)
+}
+
+// GetKeywordRules returns a page of the keyword rules on the system. Page counting starts at 0.
+// Must have the 'manage_system' permission.
+func (c *Client4) GetKeywordRules(page int, perPage int) ([]*KeywordRule, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetKeywordRulesRoute()+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return KeywordRuleListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetKeywordRule returns a keyword rule. Must have the 'manage_system' permission.
+func (c *Client4) GetKeywordRule(ruleId string) (*KeywordRule, *Response) {
+	r, err := c.DoApiGet(c.GetKeywordRuleRoute(ruleId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return KeywordRuleFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteKeywordRule deletes a keyword rule. Must have the 'manage_system' permission.
+func (c *Client4) DeleteKeywordRule(ruleId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetKeywordRuleRoute(ruleId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// ReportPost reports a post to the moderators for a reason, with an optional comment. Reporting a post again while
+// the user's report of it is still open returns that report.
+func (c *Client4) ReportPost(postId, reason, comment string) (*PostReport, *Response) {
+	r, err := c.DoApiPost(c.GetPostRoute(postId)+"/report", MapToJson(map[string]string{"reason": reason, "comment": comment}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostReportFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostReports returns a page of the post reports in a state, or of all of them if it's empty, oldest first.
+// Page counting starts at 0. Must have the 'manage_system' permission.
+func (c *Client4) GetPostReports(state string, page int, perPage int) ([]*PostReport, *Response) {
+	query := fmt.Sprintf("?state=%v&page=%v&per_page=%v", url.QueryEscape(state), page, perPage)
+	r, err := c.DoApiGet(c.GetPostReportsRoute()+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostReportListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostReport returns a post report. Must have the 'manage_system' permission.
+func (c *Client4) GetPostReport(reportId string) (*PostReport, *Response) {
+	r, err := c.DoApiGet(c.GetPostReportRoute(reportId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostReportFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdatePostReportState resolves a post report with a state, or reopens it, with an optional comment.
+// Must have the 'manage_system' permission.
+func (c *Client4) UpdatePostReportState(reportId, state, comment string) (*PostReport, *Response) {
+	r, err := c.DoApiPut(c.GetPostReportRoute(reportId)+"/state", MapToJson(map[string]string{"state": state, "comment": comment}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostReportFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostReportHistory returns the changes to the state of a post report, oldest first.
+// Must have the 'manage_system' permission.
+func (c *Client4) GetPostReportHistory(reportId string) ([]*PostReportEvent, *Response) {
+	r, err := c.DoApiGet(c.GetPostReportRoute(reportId)+"/history", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostReportEventListFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateRecurringPost schedules a message to be posted to a channel by a bot. Must be a channel admin able to
+// manage the bot.
+func (c *Client4) CreateRecurringPost(recurringPost *RecurringPost) (*RecurringPost, *Response) {
+	r, err := c.DoApiPost(c.GetChannelRoute(recurringPost.ChannelId)+"/recurring_posts", recurringPost.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return RecurringPostFromJson(r.Body), BuildResponse(r)
+}
+
+// GetRecurringPostsForChannel returns the recurring posts of a channel. Must be a channel admin.
+func (c *Client4) GetRecurringPostsForChannel(channelId string) ([]*RecurringPost, *Response) {
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/recurring_posts", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return RecurringPostListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetRecurringPost returns a recurring post. Must be an admin of its channel.
+func (c *Client4) GetRecurringPost(recurringPostId string) (*RecurringPost, *Response) {
+	r, err := c.DoApiGet(c.GetRecurringPostRoute(recurringPostId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return RecurringPostFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateRecurringPost edits the message, bot or schedule of a recurring post. Must be an admin of its channel.
+func (c *Client4) UpdateRecurringPost(recurringPost *RecurringPost) (*RecurringPost, *Response) {
+	r, err := c.DoApiPut(c.GetRecurringPostRoute(recurringPost.Id), recurringPost.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return RecurringPostFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteRecurringPost deletes a recurring post. Must be an admin of its channel.
+func (c *Client4) DeleteRecurringPost(recurringPostId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetRecurringPostRoute(recurringPostId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// PauseRecurringPost stops posting a recurring post until it's resumed. Must be an admin of its channel.
+func (c *Client4) PauseRecurringPost(recurringPostId string) (*RecurringPost, *Response) {
+	r, err := c.DoApiPost(c.GetRecurringPostRoute(recurringPostId)+"/pause", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return RecurringPostFromJson(r.Body), BuildResponse(r)
+}
+
+// ResumeRecurringPost resumes posting a paused recurring post from its next scheduled time. Must be an admin of its
+// channel.
+func (c *Client4) ResumeRecurringPost(recurringPostId string) (*RecurringPost, *Response) {
+	r, err := c.DoApiPost(c.GetRecurringPostRoute(recurringPostId)+"/resume", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return RecurringPostFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateUserAvailability adds a block of time during which a user is busy or free. Must be the user or have permission
+// to edit other users.
+func (c *Client4) CreateUserAvailability(availability *UserAvailability) (*UserAvailability, *Response) {
+	r, err := c.DoApiPost(c.GetUserAvailabilityRoute(availability.UserId), availability.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserAvailabilityFromJson(r.Body), BuildResponse(r)
+}
+
+// GetUserAvailability returns the blocks of a user's availability overlapping the given span of time, in milliseconds
+// since the epoch. Their titles are only returned to the user or those with permission to edit other users.
+func (c *Client4) GetUserAvailability(userId string, startAt, endAt int64) ([]*UserAvailability, *Response) {
+	query := fmt.Sprintf("?start_at=%v&end_at=%v", startAt, endAt)
+	r, err := c.DoApiGet(c.GetUserAvailabilityRoute(userId)+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserAvailabilityListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetUserAvailabilityStatusesByIds returns whether each of the given users is busy or free at the given time.
+func (c *Client4) GetUserAvailabilityStatusesByIds(userIds []string, at int64) (map[string]string, *Response) {
+	r, err := c.DoApiPost(c.GetUsersRoute()+fmt.Sprintf("/availability/ids?at=%v", at), ArrayToJson(userIds))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MapFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateUserAvailability updates the status, title and times of a block of a user's availability. Must be the user or
+// have permission to edit other users.
+func (c *Client4) UpdateUserAvailability(availability *UserAvailability) (*UserAvailability, *Response) {
+	r, err := c.DoApiPut(c.GetUserAvailabilityRoute(availability.UserId)+"/"+availability.Id, availability.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserAvailabilityFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteUserAvailability deletes a block of a user's availability. Must be the user or have permission to edit other
+// users.
+func (c *Client4) DeleteUserAvailability(userId, availabilityId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetUserAvailabilityRoute(userId) + "/" + availabilityId)
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// StartCall starts a call in a channel and posts it there. Must be able to post to the channel.
+func (c *Client4) StartCall(call *Call) (*Call, *Response) {
+	r, err := c.DoApiPost(c.GetCallsRoute(), call.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CallFromJson(r.Body), BuildResponse(r)
+}
+
+// GetActiveCallsForChannel returns the calls of a channel that haven't ended. Must be able to read the channel.
+func (c *Client4) GetActiveCallsForChannel(channelId string) ([]*Call, *Response) {
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/calls", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CallListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetCall returns a call along with its participants. Must be able to read its channel.
+func (c *Client4) GetCall(callId string) (*Call, *Response) {
+	r, err := c.DoApiGet(c.GetCallRoute(callId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CallFromJson(r.Body), BuildResponse(r)
+}
+
+// JoinCall adds the current user to the participants of a call.
+func (c *Client4) JoinCall(callId string) (*Call, *Response) {
+	r, err := c.DoApiPost(c.GetCallRoute(callId)+"/join", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CallFromJson(r.Body), BuildResponse(r)
+}
+
+// LeaveCall removes the current user from the participants of a call.
+func (c *Client4) LeaveCall(callId string) (*Call, *Response) {
+	r, err := c.DoApiPost(c.GetCallRoute(callId)+"/leave", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CallFromJson(r.Body), BuildResponse(r)
+}
+
+// EndCall ends a call. Must have started it or be an admin of its channel.
+func (c *Client4) EndCall(callId string) (*Call, *Response) {
+	r, err := c.DoApiPost(c.GetCallRoute(callId)+"/end", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CallFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateMessageExportConsumer registers a consumer of the message export stream. Must have manage_system permission.
+func (c *Client4) CreateMessageExportConsumer(consumer *MessageExportConsumer) (*MessageExportConsumer, *Response) {
+	r, err := c.DoApiPost(c.GetMessageExportConsumersRoute(), consumer.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MessageExportConsumerFromJson(r.Body), BuildResponse(r)
+}
+
+// GetMessageExportConsumers returns the consumers of the message export stream. Must have manage_system permission.
+func (c *Client4) GetMessageExportConsumers() ([]*MessageExportConsumer, *Response) {
+	r, err := c.DoApiGet(c.GetMessageExportConsumersRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MessageExportConsumerListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetMessageExportConsumer returns a consumer of the message export stream. Must have manage_system permission.
+func (c *Client4) GetMessageExportConsumer(consumerId string) (*MessageExportConsumer, *Response) {
+	r, err := c.DoApiGet(c.GetMessageExportConsumerRoute(consumerId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MessageExportConsumerFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteMessageExportConsumer deletes a consumer of the message export stream. Must have manage_system permission.
+func (c *Client4) DeleteMessageExportConsumer(consumerId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetMessageExportConsumerRoute(consumerId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetMessageExportChanges returns a page of the message export stream after the cursor the consumer last
+// acknowledged, with up to limit changes, or the default number of them if zero. Must have manage_system permission.
+func (c *Client4) GetMessageExportChanges(consumerId string, limit int) (*MessageExportChangeList, *Response) {
+	query := ""
+	if limit > 0 {
+		query = fmt.Sprintf("?limit=%v", limit)
+	}
+	return c.getMessageExportChanges(consumerId, query)
+}
+
+// GetMessageExportChangesAfter returns a page of the message export stream after a cursor, with up to limit changes,
+// or the default number of them if zero. Must have manage_system permission.
+func (c *Client4) GetMessageExportChangesAfter(consumerId, cursor string, limit int) (*MessageExportChangeList, *Response) {
+	query := fmt.Sprintf("?cursor=%v", url.QueryEscape(cursor))
+	if limit > 0 {
+		query += fmt.Sprintf("&limit=%v", limit)
+	}
+	return c.getMessageExportChanges(consumerId, query)
+}
+
+func (c *Client4) getMessageExportChanges(consumerId, query string) (*MessageExportChangeList, *Response) {
+	r, err := c.DoApiGet(c.GetMessageExportConsumerRoute(consumerId)+"/changes"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MessageExportChangeListFromJson(r.Body), BuildResponse(r)
+}
+
+// AckMessageExportChanges moves the cursor of a consumer of the message export stream to where it has ingested the
+// stream up to. Must have manage_system permission.
+func (c *Client4) AckMessageExportChanges(consumerId, cursor string) (*MessageExportConsumer, *Response) {
+	r, err := c.DoApiPost(c.GetMessageExportConsumerRoute(consumerId)+"/ack", MapToJson(map[string]string{"cursor": cursor}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MessageExportConsumerFromJson(r.Body), BuildResponse(r)
+}
+
+// CreatePostPurge requests a post, a thread or the posts of a channel to be permanently deleted, at the purge time of
+// the purge or right away if it has none. Must have manage_system permission.
+func (c *Client4) CreatePostPurge(purge *PostPurge) (*PostPurge, *Response) {
+	r, err := c.DoApiPost(c.GetPostPurgesRoute(), purge.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostPurgeFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostPurges returns a page of the purges, starting with the most recently requested. Must have manage_system
+// permission.
+func (c *Client4) GetPostPurges(page, perPage int) ([]*PostPurge, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetPostPurgesRoute()+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostPurgeListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostPurge returns a purge. Must have manage_system permission.
+func (c *Client4) GetPostPurge(purgeId string) (*PostPurge, *Response) {
+	r, err := c.DoApiGet(c.GetPostPurgeRoute(purgeId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostPurgeFromJson(r.Body), BuildResponse(r)
+}
+
+// CancelPostPurge cancels a purge that hasn't started yet. Must have manage_system permission.
+func (c *Client4) CancelPostPurge(purgeId string) (*PostPurge, *Response) {
+	r, err := c.DoApiPost(c.GetPostPurgeRoute(purgeId)+"/cancel", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostPurgeFromJson(r.Body), BuildResponse(r)
+}
+
+// Announcements Section
+
+// CreateAnnouncement creates an announcement and delivers it to its audience. Must have the 'manage_system'
+// permission.
+func (c *Client4) CreateAnnouncement(announcement *Announcement) (*Announcement, *Response) {
+	r, err := c.DoApiPost(c.GetAnnouncementsRoute(), announcement.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return AnnouncementFromJson(r.Body), BuildResponse(r)
+}
+
+// GetAnnouncements returns a page of the announcements, newest first. Page counting starts at 0. Must have the
+// 'manage_system' permission.
+func (c *Client4) GetAnnouncements(page int, perPage int) ([]*Announcement, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetAnnouncementsRoute()+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return AnnouncementListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetAnnouncement returns an announcement. Must have the 'manage_system' permission.
+func (c *Client4) GetAnnouncement(announcementId string) (*Announcement, *Response) {
+	r, err := c.DoApiGet(c.GetAnnouncementRoute(announcementId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return AnnouncementFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteAnnouncement stops showing an announcement. Must have the 'manage_system' permission.
+func (c *Client4) DeleteAnnouncement(announcementId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetAnnouncementRoute(announcementId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetAnnouncementReport returns how many of the recipients of an announcement got and acknowledged it. Must have the
+// 'manage_system' permission.
+func (c *Client4) GetAnnouncementReport(announcementId string) (*AnnouncementReport, *Response) {
+	r, err := c.DoApiGet(c.GetAnnouncementRoute(announcementId)+"/report", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return AnnouncementReportFromJson(r.Body), BuildResponse(r)
+}
+
+// GetAnnouncementRecipients returns a page of the recipients of an announcement. Page counting starts at 0. Must have
+// the 'manage_system' permission.
+func (c *Client4) GetAnnouncementRecipients(announcementId string, page int, perPage int) ([]*AnnouncementRecipient, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetAnnouncementRoute(announcementId)+"/recipients"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return AnnouncementRecipientListFromJson(r.Body), BuildResponse(r)
+}
+
+// AcknowledgeAnnouncement records that the current user read an announcement they received.
+func (c *Client4) AcknowledgeAnnouncement(announcementId string) (bool, *Response) {
+	r, err := c.DoApiPost(c.GetAnnouncementRoute(announcementId)+"/acknowledge", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetPendingAnnouncementsForUser returns the active announcements the user hasn't acknowledged yet that are shown as
+// banners or ask for an acknowledgment.
+func (c *Client4) GetPendingAnnouncementsForUser(userId string) ([]*Announcement, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/announcements/pending", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return AnnouncementListFromJson(r.Body), BuildResponse(r)
+}
+
+// Onboarding Section
+
+// GetOnboardingSteps returns the steps of the onboarding checklist, in the order they are shown in.
+func (c *Client4) GetOnboardingSteps() ([]*OnboardingStep, *Response) {
+	r, err := c.DoApiGet(c.GetOnboardingStepsRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return OnboardingStepListFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateOnboardingStep adds a step to the onboarding checklist. Must have the 'manage_system' permission.
+func (c *Client4) CreateOnboardingStep(step *OnboardingStep) (*OnboardingStep, *Response) {
+	r, err := c.DoApiPost(c.GetOnboardingStepsRoute(), step.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return OnboardingStepFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateOnboardingStep updates a step of the onboarding checklist. Must have the 'manage_system' permission.
+func (c *Client4) UpdateOnboardingStep(step *OnboardingStep) (*OnboardingStep, *Response) {
+	r, err := c.DoApiPut(c.GetOnboardingStepRoute(step.Id), step.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return OnboardingStepFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteOnboardingStep removes a step from the onboarding checklist. Must have the 'manage_system' permission.
+func (c *Client4) DeleteOnboardingStep(stepId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetOnboardingStepRoute(stepId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetOnboardingProgress returns which steps of the onboarding checklist the user completed.
+func (c *Client4) GetOnboardingProgress(userId string) (*OnboardingProgress, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/onboarding", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return OnboardingProgressFromJson(r.Body), BuildResponse(r)
+}
+
+// CompleteOnboardingStep marks a manual step of the onboarding checklist as completed by the user.
+func (c *Client4) CompleteOnboardingStep(userId, stepId string) (*OnboardingProgress, *Response) {
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+"/onboarding/steps/"+stepId+"/complete", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return OnboardingProgressFromJson(r.Body), BuildResponse(r)
+}
+
+// ResetOnboardingProgress clears the steps of the onboarding checklist the user completed.
+func (c *Client4) ResetOnboardingProgress(userId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetUserRoute(userId) + "/onboarding")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// ResolveMentions returns what the mentions of the message would resolve to if it was posted in the channel, along
+// with warnings about the mentions that won't notify the users they refer to.
+func (c *Client4) ResolveMentions(channelId, message string) (*MentionResolution, *Response) {
+	r, err := c.DoApiPost(c.GetChannelRoute(channelId)+"/mentions/resolve", MapToJson(map[string]string{"message": message}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MentionResolutionFromJson(r.Body), BuildResponse(r)
+}
+
+// User Attributes Section
+
+// CreateUserAttributeField creates a field of the user profiles. Must have the 'manage_system' permission.
+func (c *Client4) CreateUserAttributeField(field *UserAttributeField) (*UserAttributeField, *Response) {
+	r, err := c.DoApiPost(c.GetUserAttributeFieldsRoute(), field.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserAttributeFieldFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateUserAttributeField updates a field of the user profiles. Must have the 'manage_system' permission.
+func (c *Client4) UpdateUserAttributeField(field *UserAttributeField) (*UserAttributeField, *Response) {
+	r, err := c.DoApiPut(c.GetUserAttributeFieldRoute(field.Id), field.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserAttributeFieldFromJson(r.Body), BuildResponse(r)
+}
+
+// GetUserAttributeFields returns the fields of the user profiles.
+func (c *Client4) GetUserAttributeFields() ([]*UserAttributeField, *Response) {
+	r, err := c.DoApiGet(c.GetUserAttributeFieldsRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserAttributeFieldListFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteUserAttributeField deletes a field of the user profiles along with the values users have for it. Must have
+// the 'manage_system' permission.
+func (c *Client4) DeleteUserAttributeField(fieldId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetUserAttributeFieldRoute(fieldId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetUserAttributes returns the attributes of a user, keyed by the name of their field.
+func (c *Client4) GetUserAttributes(userId string) (map[string]string, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/attributes", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MapFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateUserAttributes sets the given attributes of a user, keyed by the name of their field, and returns all the
+// attributes of the user. An empty value clears the attribute.
+func (c *Client4) UpdateUserAttributes(userId string, attributes map[string]string) (map[string]string, *Response) {
+	r, err := c.DoApiPut(c.GetUserRoute(userId)+"/attributes", MapToJson(attributes))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MapFromJson(r.Body), BuildResponse(r)
+}
+
+// Preferences Section
+
+// GetPreferences returns the user's preferences.
+func (c *Client4) GetPreferences(userId string) (Preferences, *Response) {
+	r, err := c.DoApiGet(c.GetPreferencesRoute(userId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	preferences, _ := PreferencesFromJson(r.Body)
+	return preferences, BuildResponse(r)
+}
+
+// UpdatePreferences saves the user's preferences.
+func (c *Client4) UpdatePreferences(userId string, preferences *Preferences) (bool, *Response) {
+	r, err := c.DoApiPut(c.GetPreferencesRoute(userId), preferences.ToJson())
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return true, BuildResponse(r)
+}
+
+// DeletePreferences deletes the user's preferences.
+func (c *Client4) DeletePreferences(userId string, preferences *Preferences) (bool, *Response) {
+	r, err := c.DoApiPost(c.GetPreferencesRoute(userId)+"/delete", preferences.ToJson())
 	if err != nil {
 		return false, BuildErrorResponse(r, err)
 	}
@@ -3269,37 +4886,93 @@ func (c *Client4) UpdatePreferences(userId string, preferences *Preferences) (bo
 	return true, BuildResponse(r)
 }
 
-// DeletePreferences deletes the user's preferences.
-func (c *Client4) DeletePreferences(userId string, preferences *Preferences) (bool, *Response) {
-	r, err := c.DoApiPost(c.GetPreferencesRoute(userId)+"/delete", preferences.ToJson())
+// GetPreferencesByCategory returns the user's preferences from the provided category string.
+func (c *Client4) GetPreferencesByCategory(userId string, category string) (Preferences, *Response) {
+	url := fmt.Sprintf(c.GetPreferencesRoute(userId)+"/%s", category)
+	r, err := c.DoApiGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	preferences, _ := PreferencesFromJson(r.Body)
+	return preferences, BuildResponse(r)
+}
+
+// GetPreferenceByCategoryAndName returns the user's preferences from the provided category and preference name string.
+func (c *Client4) GetPreferenceByCategoryAndName(userId string, category string, preferenceName string) (*Preference, *Response) {
+	url := fmt.Sprintf(c.GetPreferencesRoute(userId)+"/%s/name/%v", category, preferenceName)
+	r, err := c.DoApiGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PreferenceFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPreferenceVersions returns the version of each namespace of the user's preferences.
+func (c *Client4) GetPreferenceVersions(userId string) (map[string]int64, *Response) {
+	r, err := c.DoApiGet(c.GetPreferencesRoute(userId)+"/namespaces/versions", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PreferenceVersionsFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPreferenceNamespaces returns the given namespaces of the user's preferences with their versions, or all of them
+// if no names are given.
+func (c *Client4) GetPreferenceNamespaces(userId string, names []string) (map[string]*PreferenceNamespace, *Response) {
+	query := ""
+	if len(names) > 0 {
+		query = "?names=" + url.QueryEscape(strings.Join(names, ","))
+	}
+	r, err := c.DoApiGet(c.GetPreferencesRoute(userId)+"/namespaces"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PreferenceNamespacesFromJson(r.Body), BuildResponse(r)
+}
+
+// PatchPreferenceNamespaces sets and deletes preferences in namespaces of the user's preferences, and returns what
+// changed in each of them.
+func (c *Client4) PatchPreferenceNamespaces(userId string, patch PreferencesPatch) ([]*PreferenceNamespaceDiff, *Response) {
+	r, err := c.DoApiPut(c.GetPreferencesRoute(userId)+"/namespaces/patch", patch.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PreferenceNamespaceDiffListFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateBulkPreferencesJob schedules a job setting or resetting preferences for a group of users.
+func (c *Client4) CreateBulkPreferencesJob(operation *BulkPreferencesOperation) (*Job, *Response) {
+	r, err := c.DoApiPost(c.GetUsersRoute()+"/preferences/bulk", operation.ToJson())
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
-	return true, BuildResponse(r)
+	return JobFromJson(r.Body), BuildResponse(r)
 }
 
-// GetPreferencesByCategory returns the user's preferences from the provided category string.
-func (c *Client4) GetPreferencesByCategory(userId string, category string) (Preferences, *Response) {
-	url := fmt.Sprintf(c.GetPreferencesRoute(userId)+"/%s", category)
-	r, err := c.DoApiGet(url, "")
+// GetBulkPreferencesManifest returns the rollback manifest of a finished bulk preferences job.
+func (c *Client4) GetBulkPreferencesManifest(jobId string) (*BulkPreferencesManifest, *Response) {
+	r, err := c.DoApiGet(c.GetUsersRoute()+"/preferences/bulk/"+jobId+"/manifest", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
-	preferences, _ := PreferencesFromJson(r.Body)
-	return preferences, BuildResponse(r)
+	return BulkPreferencesManifestFromJson(r.Body), BuildResponse(r)
 }
 
-// GetPreferenceByCategoryAndName returns the user's preferences from the provided category and preference name string.
-func (c *Client4) GetPreferenceByCategoryAndName(userId string, category string, preferenceName string) (*Preference, *Response) {
-	url := fmt.Sprintf(c.GetPreferencesRoute(userId)+"/%s/name/%v", category, preferenceName)
-	r, err := c.DoApiGet(url, "")
+// RollbackBulkPreferencesJob schedules a job restoring the preferences changed by a bulk preferences job.
+func (c *Client4) RollbackBulkPreferencesJob(jobId string) (*Job, *Response) {
+	r, err := c.DoApiPost(c.GetUsersRoute()+"/preferences/bulk/"+jobId+"/rollback", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
-	return PreferenceFromJson(r.Body), BuildResponse(r)
+	return JobFromJson(r.Body), BuildResponse(r)
 }
 
 // SAML Section
@@ -3498,6 +5171,17 @@ func (c *Client4) SyncLdap() (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// SyncLdapDryRun starts an AD/LDAP sync that only reports what it would change. The report is saved in the data of
+// the returned job once it is done.
+func (c *Client4) SyncLdapDryRun() (*Job, *Response) {
+	r, err := c.DoApiPost(c.GetLdapRoute()+"/sync?dry_run=true", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return JobFromJson(r.Body), BuildResponse(r)
+}
+
 // TestLdap will attempt to connect to the configured LDAP server and return OK if configured
 // correctly.
 func (c *Client4) TestLdap() (bool, *Response) {
@@ -3716,6 +5400,54 @@ func (c *Client4) GetLogs(page, perPage int) ([]string, *Response) {
 	return ArrayFromJson(r.Body), BuildResponse(r)
 }
 
+// GetApiAccessLog returns the requests in the API access log matching the query, newest first. Must be a system
+// administrator.
+func (c *Client4) GetApiAccessLog(query *ApiAccessLogQuery) ([]*ApiAccessLogEntry, *Response) {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(query.Page))
+	values.Set("per_page", strconv.Itoa(query.PerPage))
+	if query.Since > 0 {
+		values.Set("since", strconv.FormatInt(query.Since, 10))
+	}
+	if query.Until > 0 {
+		values.Set("until", strconv.FormatInt(query.Until, 10))
+	}
+	if query.UserId != "" {
+		values.Set("user_id", query.UserId)
+	}
+	if query.TokenId != "" {
+		values.Set("token_id", query.TokenId)
+	}
+	if query.WebhookId != "" {
+		values.Set("webhook_id", query.WebhookId)
+	}
+	if query.Route != "" {
+		values.Set("route", query.Route)
+	}
+	if query.MinStatusCode > 0 {
+		values.Set("min_status_code", strconv.Itoa(query.MinStatusCode))
+	}
+
+	r, err := c.DoApiGet("/access_logs?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ApiAccessLogEntryListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetApiAccessLogSummary returns the number of requests in the API access log made by each user, user access
+// token and webhook between the given times. Must be a system administrator.
+func (c *Client4) GetApiAccessLogSummary(since, until int64) ([]*ApiAccessLogSummary, *Response) {
+	query := fmt.Sprintf("?since=%v&until=%v", since, until)
+	r, err := c.DoApiGet("/access_logs/summary"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ApiAccessLogSummaryListFromJson(r.Body), BuildResponse(r)
+}
+
 // PostLog is a convenience Web Service call so clients can log messages into
 // the server-side logs. For example we typically log javascript error messages
 // into the server-side. It returns the log message if the logging was successful.
@@ -3781,6 +5513,16 @@ func (c *Client4) GetOAuthAppInfo(appId string) (*OAuthApp, *Response) {
 	return OAuthAppFromJson(r.Body), BuildResponse(r)
 }
 
+// GetOAuthScopes gets the scopes OAuth 2.0 client applications can request, with their descriptions.
+func (c *Client4) GetOAuthScopes() ([]*OAuthScope, *Response) {
+	r, err := c.DoApiGet("/oauth/scopes", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return OAuthScopeListFromJson(r.Body), BuildResponse(r)
+}
+
 // DeleteOAuthApp deletes a registered OAuth 2.0 client application.
 func (c *Client4) DeleteOAuthApp(appId string) (bool, *Response) {
 	r, err := c.DoApiDelete(c.GetOAuthAppRoute(appId))
@@ -3970,6 +5712,20 @@ func (c *Client4) ExecuteCommandWithTeam(channelId, teamId, command string) (*Co
 	return response, BuildResponse(r)
 }
 
+// ExplainCommand describes what executing a given slash command would do, without executing it.
+func (c *Client4) ExplainCommand(channelId, command string) (*CommandExplanation, *Response) {
+	commandArgs := &CommandArgs{
+		ChannelId: channelId,
+		Command:   command,
+	}
+	r, err := c.DoApiPost(c.GetCommandsRoute()+"/explain", commandArgs.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CommandExplanationFromJson(r.Body), BuildResponse(r)
+}
+
 // ListAutocompleteCommands will retrieve a list of commands available in the team.
 func (c *Client4) ListAutocompleteCommands(teamId string) ([]*Command, *Response) {
 	r, err := c.DoApiGet(c.GetTeamAutoCompleteCommandsRoute(teamId), "")
@@ -4141,6 +5897,156 @@ func (c *Client4) AutocompleteEmoji(name string, etag string) ([]*Emoji, *Respon
 	return EmojiListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetEmojiAliases returns every emoji alias, along with the name of the emoji each one stands for.
+func (c *Client4) GetEmojiAliases() ([]*EmojiAlias, *Response) {
+	r, err := c.DoApiGet(c.GetEmojiAliasesRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return EmojiAliasListFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateEmojiAlias gives an existing system or custom emoji another name. Must have create_emojis permission.
+func (c *Client4) CreateEmojiAlias(alias *EmojiAlias) (*EmojiAlias, *Response) {
+	r, err := c.DoApiPost(c.GetEmojiAliasesRoute(), alias.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return EmojiAliasFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteEmojiAlias deletes an emoji alias, leaving the emoji it stands for in place. Must have delete_emojis
+// permission, and delete_others_emojis permission for aliases created by others.
+func (c *Client4) DeleteEmojiAlias(alias string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetEmojiAliasRoute(alias))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetEmojiUsage returns a page of custom emoji with how much they have been used in reactions, starting with the
+// least used ones. Must have manage_system permission.
+func (c *Client4) GetEmojiUsage(page, perPage int) ([]*EmojiUsage, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetEmojisRoute()+"/usage"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return EmojiUsageListFromJson(r.Body), BuildResponse(r)
+}
+
+// ExportEmojiPack returns every custom emoji as a zipped emoji pack. Must have manage_system permission.
+func (c *Client4) ExportEmojiPack() ([]byte, *Response) {
+	r, apErr := c.DoApiGet(c.GetEmojisRoute()+"/export", "")
+	if apErr != nil {
+		return nil, BuildErrorResponse(r, apErr)
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, NewAppError("ExportEmojiPack", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode))
+	}
+
+	return data, BuildResponse(r)
+}
+
+// ImportEmojiPack creates a custom emoji for each image of a zipped emoji pack, reporting the emoji it skipped. Must
+// have manage_system permission.
+func (c *Client4) ImportEmojiPack(data []byte, filename string) (*EmojiPackImportResult, *Response) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("pack", filename)
+	if err != nil {
+		return nil, &Response{Error: NewAppError("ImportEmojiPack", "model.client.import_emoji_pack.file.app_error", nil, err.Error(), http.StatusBadRequest)}
+	}
+
+	if _, err = io.Copy(part, bytes.NewBuffer(data)); err != nil {
+		return nil, &Response{Error: NewAppError("ImportEmojiPack", "model.client.import_emoji_pack.file.app_error", nil, err.Error(), http.StatusBadRequest)}
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, &Response{Error: NewAppError("ImportEmojiPack", "model.client.import_emoji_pack.writer.app_error", nil, err.Error(), http.StatusBadRequest)}
+	}
+
+	rq, err := http.NewRequest("POST", c.ApiUrl+c.GetEmojisRoute()+"/import", bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return nil, &Response{Error: NewAppError("ImportEmojiPack", "model.client.connecting.app_error", nil, err.Error(), http.StatusBadRequest)}
+	}
+	rq.Header.Set("Content-Type", writer.FormDataContentType())
+
+	if len(c.AuthToken) > 0 {
+		rq.Header.Set(HEADER_AUTH, c.AuthType+" "+c.AuthToken)
+	}
+
+	rp, err := c.HttpClient.Do(rq)
+	if err != nil || rp == nil {
+		return nil, &Response{StatusCode: http.StatusForbidden, Error: NewAppError(c.GetEmojisRoute()+"/import", "model.client.connecting.app_error", nil, err.Error(), http.StatusForbidden)}
+	}
+	defer closeBody(rp)
+
+	if rp.StatusCode >= 300 {
+		return nil, BuildErrorResponse(rp, AppErrorFromJson(rp.Body))
+	}
+
+	return EmojiPackImportResultFromJson(rp.Body), BuildResponse(rp)
+}
+
+// GetPendingEmojiList returns a page of the custom emoji waiting for approval, starting with the oldest. Must have
+// manage_system permission.
+func (c *Client4) GetPendingEmojiList(page, perPage int) ([]*PendingEmoji, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetPendingEmojisRoute()+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PendingEmojiListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPendingEmojiImage returns the image of a custom emoji waiting for approval. Must have manage_system permission.
+func (c *Client4) GetPendingEmojiImage(pendingEmojiId string) ([]byte, *Response) {
+	r, apErr := c.DoApiGet(c.GetPendingEmojiRoute(pendingEmojiId)+"/image", "")
+	if apErr != nil {
+		return nil, BuildErrorResponse(r, apErr)
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, NewAppError("GetPendingEmojiImage", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode))
+	}
+
+	return data, BuildResponse(r)
+}
+
+// ApprovePendingEmoji makes a custom emoji waiting for approval visible to everyone, returning the emoji. Must have
+// manage_system permission.
+func (c *Client4) ApprovePendingEmoji(pendingEmojiId string) (*Emoji, *Response) {
+	r, err := c.DoApiPost(c.GetPendingEmojiRoute(pendingEmojiId)+"/approve", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return EmojiFromJson(r.Body), BuildResponse(r)
+}
+
+// RejectPendingEmoji drops a custom emoji waiting for approval. Must have manage_system permission.
+func (c *Client4) RejectPendingEmoji(pendingEmojiId string) (bool, *Response) {
+	r, err := c.DoApiPost(c.GetPendingEmojiRoute(pendingEmojiId)+"/reject", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // Reaction Section
 
 // SaveReaction saves an emoji reaction for a post. Returns the saved reaction if successful, otherwise an error will be returned.
@@ -4183,6 +6089,92 @@ func (c *Client4) GetBulkReactions(postIds []string) (map[string][]*Reaction, *R
 	return MapPostIdToReactionsFromJson(r.Body), BuildResponse(r)
 }
 
+// Pending Post Section
+
+// GetPendingPostsForChannel returns a page of the posts awaiting approval in a moderated channel, oldest first.
+func (c *Client4) GetPendingPostsForChannel(channelId string, page, perPage int) ([]*PendingPost, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/pending_posts"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PendingPostListFromJson(r.Body), BuildResponse(r)
+}
+
+// ApprovePendingPost posts a message held in a moderated channel on behalf of its author.
+func (c *Client4) ApprovePendingPost(pendingPostId string) (*Post, *Response) {
+	r, err := c.DoApiPost(c.GetPendingPostRoute(pendingPostId)+"/approve", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostFromJson(r.Body), BuildResponse(r)
+}
+
+// RejectPendingPost discards a message held in a moderated channel.
+func (c *Client4) RejectPendingPost(pendingPostId string) (bool, *Response) {
+	r, err := c.DoApiPost(c.GetPendingPostRoute(pendingPostId)+"/reject", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// Post Star Section
+
+// StarPost stars a post for a user. Unlike flagging a post, starring it is shown to the author of the post.
+func (c *Client4) StarPost(userId, postId string) (*PostStar, *Response) {
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+c.GetPostRoute(postId)+"/star", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostStarFromJson(r.Body), BuildResponse(r)
+}
+
+// UnstarPost removes the star of a user from a post.
+func (c *Client4) UnstarPost(userId, postId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetUserRoute(userId) + c.GetPostRoute(postId) + "/star")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetPostStar returns the star of a user on a post, if they starred it.
+func (c *Client4) GetPostStar(userId, postId string) (*PostStar, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+c.GetPostRoute(postId)+"/star", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostStarFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostStarCount returns the number of users who starred a post written by the current user.
+func (c *Client4) GetPostStarCount(postId string) (*PostStarCount, *Response) {
+	r, err := c.DoApiGet(c.GetPostRoute(postId)+"/stars/count", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostStarCountFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostStarCounts returns the number of users who starred each of the given posts, only including the starred
+// posts written by the current user.
+func (c *Client4) GetPostStarCounts(postIds []string) ([]*PostStarCount, *Response) {
+	r, err := c.DoApiPost(c.GetPostsRoute()+"/ids/stars/count", ArrayToJson(postIds))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostStarCountListFromJson(r.Body), BuildResponse(r)
+}
+
 // Timezone Section
 
 // GetSupportedTimezone returns a page of supported timezones on the system.
@@ -4212,6 +6204,28 @@ func (c *Client4) OpenGraph(url string) (map[string]string, *Response) {
 	return MapFromJson(r.Body), BuildResponse(r)
 }
 
+// Integrations Section
+
+// ExportIntegrations exports the webhooks, slash commands, OAuth apps and bots of the server.
+func (c *Client4) ExportIntegrations() (*IntegrationsExport, *Response) {
+	r, err := c.DoApiGet(c.GetIntegrationsRoute()+"/export", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return IntegrationsExportFromJson(r.Body), BuildResponse(r)
+}
+
+// ImportIntegrations creates the integrations described by an export on the server.
+func (c *Client4) ImportIntegrations(export *IntegrationsExport) (*IntegrationsImportResult, *Response) {
+	r, err := c.DoApiPost(c.GetIntegrationsRoute()+"/import", export.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return IntegrationsImportResultFromJson(r.Body), BuildResponse(r)
+}
+
 // Jobs Section
 
 // GetJob gets a single job.
@@ -4254,6 +6268,16 @@ func (c *Client4) CreateJob(job *Job) (*Job, *Response) {
 	return JobFromJson(r.Body), BuildResponse(r)
 }
 
+// GetJobsLeader gets which server of the cluster currently runs the job schedulers.
+func (c *Client4) GetJobsLeader() (*JobsLeaderStatus, *Response) {
+	r, err := c.DoApiGet(c.GetJobsRoute()+"/leader", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return JobsLeaderStatusFromJson(r.Body), BuildResponse(r)
+}
+
 // CancelJob requests the cancellation of the job with the provided Id.
 func (c *Client4) CancelJob(jobId string) (bool, *Response) {
 	r, err := c.DoApiPost(c.GetJobsRoute()+fmt.Sprintf("/%v/cancel", jobId), "")
@@ -4623,6 +6647,40 @@ func (c *Client4) CreateTermsOfService(text, userId string) (*TermsOfService, *R
 	return TermsOfServiceFromJson(r.Body), BuildResponse(r)
 }
 
+// GetTermsOfServiceVersions fetches every version of the terms of service, starting with the latest.
+func (c *Client4) GetTermsOfServiceVersions() ([]*TermsOfService, *Response) {
+	r, err := c.DoApiGet(c.GetTermsOfServiceRoute()+"/versions", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return TermsOfServiceListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetTermsOfServiceAcceptances fetches a page of the acceptances made after since of a version of the terms of
+// service, or of all of them when termsOfServiceId is empty.
+func (c *Client4) GetTermsOfServiceAcceptances(termsOfServiceId string, since int64, page, perPage int) ([]*TermsOfServiceAcceptance, *Response) {
+	query := fmt.Sprintf("?terms_of_service_id=%v&since=%v&page=%v&per_page=%v", termsOfServiceId, since, page, perPage)
+	r, err := c.DoApiGet(c.GetTermsOfServiceRoute()+"/acceptances"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return TermsOfServiceAcceptanceListFromJson(r.Body), BuildResponse(r)
+}
+
+// ScheduleTermsOfServiceReacceptance starts a re-acceptance campaign of a version of the terms of service at
+// reacceptAt, or cancels it when reacceptAt is 0.
+func (c *Client4) ScheduleTermsOfServiceReacceptance(termsOfServiceId string, reacceptAt int64) (*TermsOfService, *Response) {
+	data := map[string]interface{}{"reaccept_at": reacceptAt}
+	r, err := c.DoApiPut(c.GetTermsOfServiceRoute()+"/"+termsOfServiceId+"/reacceptance", StringInterfaceToJson(data))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return TermsOfServiceFromJson(r.Body), BuildResponse(r)
+}
+
 func (c *Client4) GetGroup(groupID, etag string) (*Group, *Response) {
 	r, appErr := c.DoApiGet(c.GetGroupRoute(groupID), etag)
 	if appErr != nil {
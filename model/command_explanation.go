@@ -0,0 +1,42 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	COMMAND_SOURCE_BUILT_IN = "built_in"
+	COMMAND_SOURCE_PLUGIN   = "plugin"
+	COMMAND_SOURCE_CUSTOM   = "custom"
+)
+
+// CommandExplanation describes what running a slash command would do, without running it: what handles the command,
+// the request sent for a custom command, and whether the user is allowed to run it.
+type CommandExplanation struct {
+	Trigger     string              `json:"trigger"`
+	Source      string              `json:"source"`
+	DisplayName string              `json:"display_name"`
+	Description string              `json:"description"`
+	PluginId    string              `json:"plugin_id,omitempty"`
+	CommandId   string              `json:"command_id,omitempty"`
+	Method      string              `json:"method,omitempty"`
+	URL         string              `json:"url,omitempty"`
+	Restriction *CommandRestriction `json:"restriction,omitempty"`
+	Allowed     bool                `json:"allowed"`
+	Reason      string              `json:"reason,omitempty"`
+}
+
+func (o *CommandExplanation) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func CommandExplanationFromJson(data io.Reader) *CommandExplanation {
+	var o *CommandExplanation
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -15,6 +15,10 @@ import (
 const (
 	COMMAND_RESPONSE_TYPE_IN_CHANNEL = "in_channel"
 	COMMAND_RESPONSE_TYPE_EPHEMERAL  = "ephemeral"
+
+	// COMMAND_RESPONSE_CONTENT_TYPE_STREAM is the content type of a command answering with a stream of responses, one
+	// JSON object per line, rather than with a single one.
+	COMMAND_RESPONSE_CONTENT_TYPE_STREAM = "application/x-ndjson"
 )
 
 type CommandResponse struct {
@@ -36,6 +40,32 @@ func (o *CommandResponse) ToJson() string {
 	return string(b)
 }
 
+// IsCommandResponseStream returns whether a command answered with a stream of responses.
+func IsCommandResponseStream(contentType string) bool {
+	return strings.TrimSpace(strings.Split(contentType, ";")[0]) == COMMAND_RESPONSE_CONTENT_TYPE_STREAM
+}
+
+// CommandResponseDecoder reads the responses of a command answering with a stream of them.
+type CommandResponseDecoder struct {
+	decoder *json.Decoder
+}
+
+func NewCommandResponseDecoder(data io.Reader) *CommandResponseDecoder {
+	return &CommandResponseDecoder{decoder: json.NewDecoder(data)}
+}
+
+// Next returns the next response of the stream, or io.EOF once the stream is over.
+func (d *CommandResponseDecoder) Next() (*CommandResponse, error) {
+	var o CommandResponse
+	if err := d.decoder.Decode(&o); err != nil {
+		return nil, err
+	}
+
+	o.Attachments = StringifySlackFieldValue(o.Attachments)
+
+	return &o, nil
+}
+
 func CommandResponseFromHTTPBody(contentType string, body io.Reader) (*CommandResponse, error) {
 	if strings.TrimSpace(strings.Split(contentType, ";")[0]) == "application/json" {
 		return CommandResponseFromJson(body)
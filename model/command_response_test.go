@@ -4,10 +4,12 @@
 package model
 
 import (
+	"io"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCommandResponseFromHTTPBody(t *testing.T) {
@@ -27,6 +29,33 @@ func TestCommandResponseFromHTTPBody(t *testing.T) {
 	}
 }
 
+func TestCommandResponseDecoder(t *testing.T) {
+	assert.True(t, IsCommandResponseStream("application/x-ndjson; charset=utf-8"))
+	assert.False(t, IsCommandResponseStream("application/json"))
+
+	decoder := NewCommandResponseDecoder(strings.NewReader(`{"text": "building"}
+{"text": "deploying", "attachments": [{"fields": [{"title": "Progress", "value": 50}]}]}
+{"response_type": "in_channel", "text": "deployed"}
+`))
+
+	response, err := decoder.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "building", response.Text)
+
+	response, err = decoder.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "deploying", response.Text)
+	require.Len(t, response.Attachments, 1)
+	assert.Equal(t, "50", response.Attachments[0].Fields[0].Value)
+
+	response, err = decoder.Next()
+	require.NoError(t, err)
+	assert.Equal(t, COMMAND_RESPONSE_TYPE_IN_CHANNEL, response.ResponseType)
+
+	_, err = decoder.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
 func TestCommandResponseFromPlainText(t *testing.T) {
 	response := CommandResponseFromPlainText("foo")
 	assert.Equal(t, "foo", response.Text)
@@ -0,0 +1,73 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+)
+
+// CommandRestriction limits who may run a slash command, and where. The command may be run by the users having one of
+// the roles, system, team or channel ones alike, in the given channels. An empty list of roles or channels doesn't
+// restrict them.
+type CommandRestriction struct {
+	Roles      []string
+	ChannelIds []string
+}
+
+func (r *CommandRestriction) IsValid(trigger string) *AppError {
+	if trigger == "" || trigger != strings.ToLower(trigger) || strings.ContainsAny(trigger, " /") {
+		return NewAppError("CommandRestriction.IsValid", "model.command_restriction.is_valid.trigger.app_error", map[string]interface{}{"Trigger": trigger}, "", http.StatusBadRequest)
+	}
+
+	if r == nil {
+		return NewAppError("CommandRestriction.IsValid", "model.command_restriction.is_valid.empty.app_error", map[string]interface{}{"Trigger": trigger}, "", http.StatusBadRequest)
+	}
+
+	for _, role := range r.Roles {
+		if !IsValidRoleName(role) {
+			return NewAppError("CommandRestriction.IsValid", "model.command_restriction.is_valid.role.app_error", map[string]interface{}{"Trigger": trigger}, "role="+role, http.StatusBadRequest)
+		}
+	}
+
+	for _, channelId := range r.ChannelIds {
+		if !IsValidId(channelId) {
+			return NewAppError("CommandRestriction.IsValid", "model.command_restriction.is_valid.channel_id.app_error", map[string]interface{}{"Trigger": trigger}, "channel_id="+channelId, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// AllowsChannel returns whether the command may be run in the given channel.
+func (r *CommandRestriction) AllowsChannel(channelId string) bool {
+	if len(r.ChannelIds) == 0 {
+		return true
+	}
+
+	for _, allowed := range r.ChannelIds {
+		if channelId == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsRoles returns whether the command may be run by a user having the given roles.
+func (r *CommandRestriction) AllowsRoles(roles []string) bool {
+	if len(r.Roles) == 0 {
+		return true
+	}
+
+	for _, role := range roles {
+		for _, allowed := range r.Roles {
+			if role == allowed {
+				return true
+			}
+		}
+	}
+
+	return false
+}
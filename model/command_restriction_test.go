@@ -0,0 +1,39 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandRestrictionIsValid(t *testing.T) {
+	restriction := &CommandRestriction{Roles: []string{SYSTEM_ADMIN_ROLE_ID}, ChannelIds: []string{NewId()}}
+	assert.Nil(t, restriction.IsValid("invite_people"))
+
+	assert.NotNil(t, restriction.IsValid(""))
+	assert.NotNil(t, restriction.IsValid("Invite_People"))
+	assert.NotNil(t, restriction.IsValid("/invite_people"))
+
+	var empty *CommandRestriction
+	assert.NotNil(t, empty.IsValid("invite_people"))
+
+	assert.NotNil(t, (&CommandRestriction{Roles: []string{"not a role"}}).IsValid("invite_people"))
+	assert.NotNil(t, (&CommandRestriction{ChannelIds: []string{"abc"}}).IsValid("invite_people"))
+}
+
+func TestCommandRestrictionAllows(t *testing.T) {
+	channelId := NewId()
+
+	unrestricted := &CommandRestriction{}
+	assert.True(t, unrestricted.AllowsChannel(NewId()))
+	assert.True(t, unrestricted.AllowsRoles(nil))
+
+	restriction := &CommandRestriction{Roles: []string{SYSTEM_ADMIN_ROLE_ID, TEAM_ADMIN_ROLE_ID}, ChannelIds: []string{channelId}}
+	assert.True(t, restriction.AllowsChannel(channelId))
+	assert.False(t, restriction.AllowsChannel(NewId()))
+	assert.True(t, restriction.AllowsRoles([]string{SYSTEM_USER_ROLE_ID, TEAM_ADMIN_ROLE_ID}))
+	assert.False(t, restriction.AllowsRoles([]string{SYSTEM_USER_ROLE_ID, TEAM_USER_ROLE_ID}))
+}
@@ -16,8 +16,10 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mattermost/ldap"
+	"github.com/mattermost/mattermost-server/mlog"
 )
 
 const (
@@ -29,9 +31,14 @@ const (
 	IMAGE_DRIVER_LOCAL = "local"
 	IMAGE_DRIVER_S3    = "amazons3"
 
-	DATABASE_DRIVER_SQLITE   = "sqlite3"
-	DATABASE_DRIVER_MYSQL    = "mysql"
-	DATABASE_DRIVER_POSTGRES = "postgres"
+	DATABASE_DRIVER_SQLITE    = "sqlite3"
+	DATABASE_DRIVER_MYSQL     = "mysql"
+	DATABASE_DRIVER_POSTGRES  = "postgres"
+	DATABASE_DRIVER_COCKROACH = "cockroach"
+
+	CLUSTER_WEBSOCKET_MODE_ALL     = "all"
+	CLUSTER_WEBSOCKET_MODE_API     = "api"
+	CLUSTER_WEBSOCKET_MODE_GATEWAY = "gateway"
 
 	MINIO_ACCESS_KEY = "minioaccesskey"
 	MINIO_SECRET_KEY = "miniosecretkey"
@@ -48,6 +55,9 @@ const (
 	GENERIC_NOTIFICATION            = "generic"
 	GENERIC_NOTIFICATION_SERVER     = "https://push-test.mattermost.com"
 	FULL_NOTIFICATION               = "full"
+	SENDER_ONLY_NOTIFICATION        = "sender_only"
+
+	PUSH_NOTIFICATION_MAX_FAILURES_DEFAULT = 5
 
 	DIRECT_MESSAGE_ANY  = "any"
 	DIRECT_MESSAGE_TEAM = "team"
@@ -98,13 +108,20 @@ const (
 	SERVICE_SETTINGS_DEFAULT_GFYCAT_API_KEY     = "2_KtH_W5"
 	SERVICE_SETTINGS_DEFAULT_GFYCAT_API_SECRET  = "3wLVZPiswc3DnaiaFoLkDvB4X0IV6CpMkj4tf2inJRsBY6-FnkT08zGmppWFgeof"
 
+	SERVICE_SETTINGS_DEFAULT_GRACEFUL_SHUTDOWN_TIMEOUT_SECONDS = 30
+	SERVICE_SETTINGS_DEFAULT_LICENSE_SEAT_WARNING_PERCENTAGE   = 90
+
+	SERVICE_SETTINGS_DEFAULT_OUTBOUND_CIRCUIT_BREAKER_THRESHOLD        = 5
+	SERVICE_SETTINGS_DEFAULT_OUTBOUND_CIRCUIT_BREAKER_COOLDOWN_SECONDS = 60
+
 	TEAM_SETTINGS_DEFAULT_SITE_NAME                = "Mattermost"
 	TEAM_SETTINGS_DEFAULT_MAX_USERS_PER_TEAM       = 50
 	TEAM_SETTINGS_DEFAULT_CUSTOM_BRAND_TEXT        = ""
 	TEAM_SETTINGS_DEFAULT_CUSTOM_DESCRIPTION_TEXT  = ""
 	TEAM_SETTINGS_DEFAULT_USER_STATUS_AWAY_TIMEOUT = 300
 
-	SQL_SETTINGS_DEFAULT_DATA_SOURCE = "mmuser:mostest@tcp(localhost:3306)/mattermost_test?charset=utf8mb4,utf8&readTimeout=30s&writeTimeout=30s"
+	SQL_SETTINGS_DEFAULT_DATA_SOURCE                       = "mmuser:mostest@tcp(localhost:3306)/mattermost_test?charset=utf8mb4,utf8&readTimeout=30s&writeTimeout=30s"
+	SQL_SETTINGS_DEFAULT_SLOW_QUERY_THRESHOLD_MILLISECONDS = 1000
 
 	FILE_SETTINGS_DEFAULT_DIRECTORY = "./data/"
 
@@ -128,6 +145,8 @@ const (
 	LDAP_SETTINGS_DEFAULT_LOGIN_FIELD_NAME             = ""
 	LDAP_SETTINGS_DEFAULT_GROUP_DISPLAY_NAME_ATTRIBUTE = ""
 	LDAP_SETTINGS_DEFAULT_GROUP_ID_ATTRIBUTE           = ""
+	LDAP_SETTINGS_DEFAULT_MODIFY_TIMESTAMP_ATTRIBUTE   = "modifyTimestamp"
+	LDAP_SETTINGS_DEFAULT_FULL_SYNC_INTERVAL_HOURS     = 24
 
 	SAML_SETTINGS_DEFAULT_ID_ATTRIBUTE         = ""
 	SAML_SETTINGS_DEFAULT_FIRST_NAME_ATTRIBUTE = ""
@@ -137,6 +156,16 @@ const (
 	SAML_SETTINGS_DEFAULT_NICKNAME_ATTRIBUTE   = ""
 	SAML_SETTINGS_DEFAULT_LOCALE_ATTRIBUTE     = ""
 	SAML_SETTINGS_DEFAULT_POSITION_ATTRIBUTE   = ""
+	SAML_SETTINGS_DEFAULT_GROUP_ATTRIBUTE      = ""
+
+	SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA1    = "RSAwithSHA1"
+	SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA256  = "RSAwithSHA256"
+	SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA512  = "RSAwithSHA512"
+	SAML_SETTINGS_DEFAULT_SIGNATURE_ALGORITHM = SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA1
+
+	SAML_SETTINGS_CANONICAL_ALGORITHM_C14N    = "Canonical1.0"
+	SAML_SETTINGS_CANONICAL_ALGORITHM_C14N11  = "Canonical1.1"
+	SAML_SETTINGS_DEFAULT_CANONICAL_ALGORITHM = SAML_SETTINGS_CANONICAL_ALGORITHM_C14N
 
 	NATIVEAPP_SETTINGS_DEFAULT_APP_DOWNLOAD_LINK         = "https://mattermost.com/download/#mattermostApps"
 	NATIVEAPP_SETTINGS_DEFAULT_ANDROID_APP_DOWNLOAD_LINK = "https://about.mattermost.com/mattermost-android-app/"
@@ -144,7 +173,9 @@ const (
 
 	EXPERIMENTAL_SETTINGS_DEFAULT_LINK_METADATA_TIMEOUT_MILLISECONDS = 5000
 
-	ANALYTICS_SETTINGS_DEFAULT_MAX_USERS_FOR_STATISTICS = 2500
+	ANALYTICS_SETTINGS_DEFAULT_MAX_USERS_FOR_STATISTICS           = 2500
+	ANALYTICS_SETTINGS_DEFAULT_CHANNEL_READ_STATS_MINIMUM_MEMBERS = 10
+	ANALYTICS_SETTINGS_DEFAULT_INTEGRATION_STATS_RETENTION_DAYS   = 90
 
 	ANNOUNCEMENT_SETTINGS_DEFAULT_BANNER_COLOR      = "#f2a93b"
 	ANNOUNCEMENT_SETTINGS_DEFAULT_BANNER_TEXT_COLOR = "#333333"
@@ -170,6 +201,8 @@ const (
 	DATA_RETENTION_SETTINGS_DEFAULT_MESSAGE_RETENTION_DAYS  = 365
 	DATA_RETENTION_SETTINGS_DEFAULT_FILE_RETENTION_DAYS     = 365
 	DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME = "02:00"
+	DATA_RETENTION_SETTINGS_DEFAULT_POST_ARCHIVE_MONTHS     = 12
+	DATA_RETENTION_SETTINGS_DEFAULT_ARCHIVE_JOB_START_TIME  = "03:00"
 
 	PLUGIN_SETTINGS_DEFAULT_DIRECTORY          = "./plugins"
 	PLUGIN_SETTINGS_DEFAULT_CLIENT_DIRECTORY   = "./client/plugins"
@@ -190,6 +223,37 @@ const (
 	IMAGE_PROXY_TYPE_LOCAL      = "local"
 	IMAGE_PROXY_TYPE_ATMOS_CAMO = "atmos/camo"
 
+	LOG_SETTINGS_SYSLOG_NETWORK_LOCAL = ""
+	LOG_SETTINGS_SYSLOG_NETWORK_UDP   = "udp"
+	LOG_SETTINGS_SYSLOG_NETWORK_TCP   = "tcp"
+
+	LOG_SETTINGS_DEFAULT_SYSLOG_TAG          = "mattermost"
+	LOG_SETTINGS_DEFAULT_SAMPLING_INITIAL    = 100
+	LOG_SETTINGS_DEFAULT_SAMPLING_THEREAFTER = 100
+
+	AUDIT_SETTINGS_SYSLOG_NETWORK_LOCAL = ""
+	AUDIT_SETTINGS_SYSLOG_NETWORK_UDP   = "udp"
+	AUDIT_SETTINGS_SYSLOG_NETWORK_TCP   = "tcp"
+
+	AUDIT_SETTINGS_HTTP_FORMAT_JSON         = "json"
+	AUDIT_SETTINGS_HTTP_FORMAT_SPLUNK_HEC   = "splunk_hec"
+	AUDIT_SETTINGS_HTTP_FORMAT_ELASTIC_BULK = "elastic_bulk"
+
+	AUDIT_SETTINGS_DEFAULT_FILE_MAX_SIZE_MB          = 100
+	AUDIT_SETTINGS_DEFAULT_SYSLOG_TAG                = "mattermost-audit"
+	AUDIT_SETTINGS_DEFAULT_HTTP_BATCH_SIZE           = 100
+	AUDIT_SETTINGS_DEFAULT_HTTP_FLUSH_INTERVAL_SECS  = 5
+	AUDIT_SETTINGS_DEFAULT_HTTP_MAX_QUEUE_SIZE       = 10000
+	AUDIT_SETTINGS_DEFAULT_HTTP_REQUEST_TIMEOUT_SECS = 10
+
+	API_ACCESS_LOG_SETTINGS_DEFAULT_FILE_MAX_SIZE_MB       = 100
+	API_ACCESS_LOG_SETTINGS_DEFAULT_SAMPLE_PERCENT         = 100
+	API_ACCESS_LOG_SETTINGS_DEFAULT_SLOW_REQUEST_THRESHOLD = 1000
+
+	CONTENT_FILTER_SETTINGS_DEFAULT_MASK_CHARACTER = "*"
+
+	LOGIN_SECURITY_SETTINGS_DEFAULT_ATTEMPTS_RETENTION_DAYS = 90
+
 	GOOGLE_SETTINGS_DEFAULT_SCOPE             = "profile email"
 	GOOGLE_SETTINGS_DEFAULT_AUTH_ENDPOINT     = "https://accounts.google.com/o/oauth2/v2/auth"
 	GOOGLE_SETTINGS_DEFAULT_TOKEN_ENDPOINT    = "https://www.googleapis.com/oauth2/v4/token"
@@ -230,6 +294,7 @@ type ServiceSettings struct {
 	SiteURL                                           *string  `restricted:"true"`
 	WebsocketURL                                      *string  `restricted:"true"`
 	LicenseFileLocation                               *string  `restricted:"true"`
+	LicenseSeatWarningPercentage                      *int     `restricted:"true"`
 	ListenAddress                                     *string  `restricted:"true"`
 	ConnectionSecurity                                *string  `restricted:"true"`
 	TLSCertFile                                       *string  `restricted:"true"`
@@ -249,19 +314,42 @@ type ServiceSettings struct {
 	GoogleDeveloperKey                                *string  `restricted:"true"`
 	EnableOAuthServiceProvider                        *bool
 	EnableIncomingWebhooks                            *bool
+	IncomingWebhookRateLimitPerMinute                 *int
+	IncomingWebhookDailyQuota                         *int
 	EnableOutgoingWebhooks                            *bool
 	EnableCommands                                    *bool
 	DEPRECATED_DO_NOT_USE_EnableOnlyAdminIntegrations *bool `json:"EnableOnlyAdminIntegrations" mapstructure:"EnableOnlyAdminIntegrations"` // This field is deprecated and must not be used.
 	EnablePostUsernameOverride                        *bool
 	EnablePostIconOverride                            *bool
 	EnableLinkPreviews                                *bool
+	LinkPreviewAllowedDomains                         *string
+	LinkPreviewBlockedDomains                         *string
+	EnableSystemBotNotifications                      *bool
 	EnableTesting                                     *bool   `restricted:"true"`
 	EnableDeveloper                                   *bool   `restricted:"true"`
 	EnableSecurityFixAlert                            *bool   `restricted:"true"`
 	EnableInsecureOutgoingConnections                 *bool   `restricted:"true"`
 	AllowedUntrustedInternalConnections               *string `restricted:"true"`
+
+	// OutboundProxyURL, OutboundRequestTimeouts and the circuit breaker settings apply to the requests made to
+	// untrusted URLs, such as those of integrations, link previews and proxied images.
+	OutboundProxyURL                      *string        `restricted:"true"`
+	OutboundRequestTimeouts               map[string]int `restricted:"true"`
+	OutboundCircuitBreakerThreshold       *int           `restricted:"true"`
+	OutboundCircuitBreakerCooldownSeconds *int           `restricted:"true"`
+
+	// CommandRestrictions limits who may run the slash commands with the given triggers, and where.
+	CommandRestrictions map[string]*CommandRestriction
+
+	// EnableOnboarding turns on the onboarding checklist. The users who complete every step of it are sent
+	// OnboardingCompletionMessage by the system bot and added to the OnboardingCompletionChannelIds channels.
+	EnableOnboarding               *bool
+	OnboardingCompletionMessage    *string
+	OnboardingCompletionChannelIds []string
+
 	EnableMultifactorAuthentication                   *bool
 	EnforceMultifactorAuthentication                  *bool
+	MultifactorAuthenticationGracePeriodDays          *int
 	EnableUserAccessTokens                            *bool
 	AllowCorsFrom                                     *string `restricted:"true"`
 	CorsExposedHeaders                                *string `restricted:"true"`
@@ -278,6 +366,8 @@ type ServiceSettings struct {
 	WebserverMode                                     *string `restricted:"true"`
 	EnableCustomEmoji                                 *bool
 	EnableEmojiPicker                                 *bool
+	CustomEmojiQuotaPerUser                           *int
+	RequireCustomEmojiApproval                        *bool
 	EnableGifPicker                                   *bool
 	GfycatApiKey                                      *string
 	GfycatApiSecret                                   *string
@@ -311,6 +401,12 @@ type ServiceSettings struct {
 	DisableBotsWhenOwnerIsDeactivated                 *bool `restricted:"true"`
 	EnableBotAccountCreation                          *bool
 	EnableSVGs                                        *bool
+	EnableUserImpersonation                           *bool `restricted:"true"`
+	UserImpersonationLengthInMinutes                  *int  `restricted:"true"`
+	NotifyImpersonatedUsers                           *bool `restricted:"true"`
+	EnableFuzzyUserSearch                             *bool
+	GracefulShutdownTimeoutSeconds                    *int `restricted:"true"`
+	EnablePublicPostLinks                             *bool
 }
 
 func (s *ServiceSettings) SetDefaults(isUpdate bool) {
@@ -339,6 +435,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.LicenseFileLocation = NewString("")
 	}
 
+	if s.LicenseSeatWarningPercentage == nil {
+		s.LicenseSeatWarningPercentage = NewInt(SERVICE_SETTINGS_DEFAULT_LICENSE_SEAT_WARNING_PERCENTAGE)
+	}
+
 	if s.ListenAddress == nil {
 		s.ListenAddress = NewString(SERVICE_SETTINGS_DEFAULT_LISTEN_AND_ADDRESS)
 	}
@@ -347,6 +447,14 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnableLinkPreviews = NewBool(false)
 	}
 
+	if s.LinkPreviewAllowedDomains == nil {
+		s.LinkPreviewAllowedDomains = NewString("")
+	}
+
+	if s.LinkPreviewBlockedDomains == nil {
+		s.LinkPreviewBlockedDomains = NewString("")
+	}
+
 	if s.EnableTesting == nil {
 		s.EnableTesting = NewBool(false)
 	}
@@ -375,6 +483,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnforceMultifactorAuthentication = NewBool(false)
 	}
 
+	if s.MultifactorAuthenticationGracePeriodDays == nil {
+		s.MultifactorAuthenticationGracePeriodDays = NewInt(0)
+	}
+
 	if s.EnableUserAccessTokens == nil {
 		s.EnableUserAccessTokens = NewBool(false)
 	}
@@ -399,6 +511,14 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnableIncomingWebhooks = NewBool(true)
 	}
 
+	if s.IncomingWebhookRateLimitPerMinute == nil {
+		s.IncomingWebhookRateLimitPerMinute = NewInt(0)
+	}
+
+	if s.IncomingWebhookDailyQuota == nil {
+		s.IncomingWebhookDailyQuota = NewInt(0)
+	}
+
 	if s.EnableOutgoingWebhooks == nil {
 		s.EnableOutgoingWebhooks = NewBool(true)
 	}
@@ -431,6 +551,42 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.TLSOverwriteCiphers = []string{}
 	}
 
+	if s.OutboundProxyURL == nil {
+		s.OutboundProxyURL = NewString("")
+	}
+
+	if s.OutboundRequestTimeouts == nil {
+		s.OutboundRequestTimeouts = map[string]int{}
+	}
+
+	if s.CommandRestrictions == nil {
+		s.CommandRestrictions = map[string]*CommandRestriction{}
+	}
+
+	if s.EnableOnboarding == nil {
+		s.EnableOnboarding = NewBool(true)
+	}
+
+	if s.OnboardingCompletionMessage == nil {
+		s.OnboardingCompletionMessage = NewString("")
+	}
+
+	if s.OnboardingCompletionChannelIds == nil {
+		s.OnboardingCompletionChannelIds = []string{}
+	}
+
+	if s.EnableSystemBotNotifications == nil {
+		s.EnableSystemBotNotifications = NewBool(false)
+	}
+
+	if s.OutboundCircuitBreakerThreshold == nil {
+		s.OutboundCircuitBreakerThreshold = NewInt(SERVICE_SETTINGS_DEFAULT_OUTBOUND_CIRCUIT_BREAKER_THRESHOLD)
+	}
+
+	if s.OutboundCircuitBreakerCooldownSeconds == nil {
+		s.OutboundCircuitBreakerCooldownSeconds = NewInt(SERVICE_SETTINGS_DEFAULT_OUTBOUND_CIRCUIT_BREAKER_COOLDOWN_SECONDS)
+	}
+
 	if s.UseLetsEncrypt == nil {
 		s.UseLetsEncrypt = NewBool(false)
 	}
@@ -579,6 +735,14 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnableEmojiPicker = NewBool(true)
 	}
 
+	if s.CustomEmojiQuotaPerUser == nil {
+		s.CustomEmojiQuotaPerUser = NewInt(0)
+	}
+
+	if s.RequireCustomEmojiApproval == nil {
+		s.RequireCustomEmojiApproval = NewBool(false)
+	}
+
 	if s.EnableGifPicker == nil {
 		s.EnableGifPicker = NewBool(false)
 	}
@@ -679,11 +843,75 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 			s.EnableSVGs = NewBool(false)
 		}
 	}
+
+	if s.EnableUserImpersonation == nil {
+		s.EnableUserImpersonation = NewBool(false)
+	}
+
+	if s.UserImpersonationLengthInMinutes == nil {
+		s.UserImpersonationLengthInMinutes = NewInt(30)
+	}
+
+	if s.NotifyImpersonatedUsers == nil {
+		s.NotifyImpersonatedUsers = NewBool(true)
+	}
+
+	if s.EnableFuzzyUserSearch == nil {
+		s.EnableFuzzyUserSearch = NewBool(true)
+	}
+
+	if s.GracefulShutdownTimeoutSeconds == nil {
+		s.GracefulShutdownTimeoutSeconds = NewInt(SERVICE_SETTINGS_DEFAULT_GRACEFUL_SHUTDOWN_TIMEOUT_SECONDS)
+	}
+
+	if s.EnablePublicPostLinks == nil {
+		s.EnablePublicPostLinks = NewBool(false)
+	}
+}
+
+// IsLinkPreviewURLAllowed returns whether previews and embedded images may be fetched from a URL. Its host must not be,
+// or be a subdomain of, a blocked domain and, when domains are allowed, it must be or be a subdomain of one of them.
+// Relative URLs are always allowed since they point to the server itself.
+func (s *ServiceSettings) IsLinkPreviewURLAllowed(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host == "" {
+		return parsed.Scheme == ""
+	}
+
+	matches := func(domains *string) bool {
+		if domains == nil {
+			return false
+		}
+
+		for _, domain := range strings.Fields(strings.ToLower(strings.Replace(*domains, ",", " ", -1))) {
+			domain = strings.TrimPrefix(domain, ".")
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matches(s.LinkPreviewBlockedDomains) {
+		return false
+	}
+
+	if s.LinkPreviewAllowedDomains != nil && strings.TrimSpace(*s.LinkPreviewAllowedDomains) != "" {
+		return matches(s.LinkPreviewAllowedDomains)
+	}
+
+	return true
 }
 
 type ClusterSettings struct {
 	Enable                      *bool   `restricted:"true"`
 	ClusterName                 *string `restricted:"true"`
+	SecretKey                   *string `restricted:"true"`
 	OverrideHostname            *string `restricted:"true"`
 	NetworkInterface            *string `restricted:"true"`
 	BindAddress                 *string `restricted:"true"`
@@ -696,6 +924,8 @@ type ClusterSettings struct {
 	MaxIdleConns                *int    `restricted:"true"`
 	MaxIdleConnsPerHost         *int    `restricted:"true"`
 	IdleConnTimeoutMilliseconds *int    `restricted:"true"`
+	WebSocketMode               *string `restricted:"true"`
+	WebSocketDrainSeconds       *int    `restricted:"true"`
 }
 
 func (s *ClusterSettings) SetDefaults() {
@@ -707,6 +937,10 @@ func (s *ClusterSettings) SetDefaults() {
 		s.ClusterName = NewString("")
 	}
 
+	if s.SecretKey == nil {
+		s.SecretKey = NewString("")
+	}
+
 	if s.OverrideHostname == nil {
 		s.OverrideHostname = NewString("")
 	}
@@ -754,6 +988,34 @@ func (s *ClusterSettings) SetDefaults() {
 	if s.IdleConnTimeoutMilliseconds == nil {
 		s.IdleConnTimeoutMilliseconds = NewInt(90000)
 	}
+
+	if s.WebSocketMode == nil {
+		s.WebSocketMode = NewString(CLUSTER_WEBSOCKET_MODE_ALL)
+	}
+
+	if s.WebSocketDrainSeconds == nil {
+		s.WebSocketDrainSeconds = NewInt(0)
+	}
+}
+
+func (s *ClusterSettings) isValid() *AppError {
+	if *s.Enable && *s.SecretKey == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.cluster_secret_key.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.WebSocketMode != CLUSTER_WEBSOCKET_MODE_ALL && *s.WebSocketMode != CLUSTER_WEBSOCKET_MODE_API && *s.WebSocketMode != CLUSTER_WEBSOCKET_MODE_GATEWAY {
+		return NewAppError("Config.IsValid", "model.config.is_valid.cluster_websocket_mode.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.WebSocketMode != CLUSTER_WEBSOCKET_MODE_ALL && !*s.Enable {
+		return NewAppError("Config.IsValid", "model.config.is_valid.cluster_websocket_mode_requires_cluster.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.WebSocketDrainSeconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.cluster_websocket_drain_seconds.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 type MetricsSettings struct {
@@ -807,13 +1069,50 @@ func (s *ExperimentalSettings) SetDefaults() {
 }
 
 type AnalyticsSettings struct {
-	MaxUsersForStatistics *int `restricted:"true"`
+	MaxUsersForStatistics          *int  `restricted:"true"`
+	EnableChannelReadStats         *bool `restricted:"true"`
+	ChannelReadStatsMinimumMembers *int  `restricted:"true"`
+	EnableDailyStats               *bool `restricted:"true"`
+	EnableIntegrationStats         *bool `restricted:"true"`
+	IntegrationStatsRetentionDays  *int  `restricted:"true"`
 }
 
 func (s *AnalyticsSettings) SetDefaults() {
 	if s.MaxUsersForStatistics == nil {
 		s.MaxUsersForStatistics = NewInt(ANALYTICS_SETTINGS_DEFAULT_MAX_USERS_FOR_STATISTICS)
 	}
+
+	if s.EnableChannelReadStats == nil {
+		s.EnableChannelReadStats = NewBool(false)
+	}
+
+	if s.ChannelReadStatsMinimumMembers == nil {
+		s.ChannelReadStatsMinimumMembers = NewInt(ANALYTICS_SETTINGS_DEFAULT_CHANNEL_READ_STATS_MINIMUM_MEMBERS)
+	}
+
+	if s.EnableDailyStats == nil {
+		s.EnableDailyStats = NewBool(true)
+	}
+
+	if s.EnableIntegrationStats == nil {
+		s.EnableIntegrationStats = NewBool(true)
+	}
+
+	if s.IntegrationStatsRetentionDays == nil {
+		s.IntegrationStatsRetentionDays = NewInt(ANALYTICS_SETTINGS_DEFAULT_INTEGRATION_STATS_RETENTION_DAYS)
+	}
+}
+
+func (s *AnalyticsSettings) isValid() *AppError {
+	if *s.ChannelReadStatsMinimumMembers < 2 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.channel_read_stats_minimum_members.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.IntegrationStatsRetentionDays < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.integration_stats_retention_days.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 type SSOSettings struct {
@@ -867,6 +1166,14 @@ type SqlSettings struct {
 	Trace                       *bool    `restricted:"true"`
 	AtRestEncryptKey            *string  `restricted:"true"`
 	QueryTimeout                *int     `restricted:"true"`
+	ReplicaMaxLagSeconds        *int     `restricted:"true"`
+	MigrationDryRun             *bool    `restricted:"true"`
+	MigrationBatchSize          *int     `restricted:"true"`
+	PartitionPostsArchive       *bool    `restricted:"true"`
+
+	EnableSlowQueryLog             *bool `restricted:"true"`
+	SlowQueryThresholdMilliseconds *int  `restricted:"true"`
+	ExplainSlowQueries             *bool `restricted:"true"`
 }
 
 func (s *SqlSettings) SetDefaults(isUpdate bool) {
@@ -915,6 +1222,34 @@ func (s *SqlSettings) SetDefaults(isUpdate bool) {
 	if s.QueryTimeout == nil {
 		s.QueryTimeout = NewInt(30)
 	}
+
+	if s.ReplicaMaxLagSeconds == nil {
+		s.ReplicaMaxLagSeconds = NewInt(10)
+	}
+
+	if s.MigrationDryRun == nil {
+		s.MigrationDryRun = NewBool(false)
+	}
+
+	if s.MigrationBatchSize == nil {
+		s.MigrationBatchSize = NewInt(SCHEMA_MIGRATION_BACKFILL_BATCH_SIZE_DEFAULT)
+	}
+
+	if s.PartitionPostsArchive == nil {
+		s.PartitionPostsArchive = NewBool(false)
+	}
+
+	if s.EnableSlowQueryLog == nil {
+		s.EnableSlowQueryLog = NewBool(false)
+	}
+
+	if s.SlowQueryThresholdMilliseconds == nil {
+		s.SlowQueryThresholdMilliseconds = NewInt(SQL_SETTINGS_DEFAULT_SLOW_QUERY_THRESHOLD_MILLISECONDS)
+	}
+
+	if s.ExplainSlowQueries == nil {
+		s.ExplainSlowQueries = NewBool(true)
+	}
 }
 
 type LogSettings struct {
@@ -927,6 +1262,21 @@ type LogSettings struct {
 	FileLocation           *string `restricted:"true"`
 	EnableWebhookDebugging *bool   `restricted:"true"`
 	EnableDiagnostics      *bool   `restricted:"true"`
+
+	SubsystemLevels              map[string]string `restricted:"true"`
+	EnableSampling               *bool             `restricted:"true"`
+	SamplingInitial              *int              `restricted:"true"`
+	SamplingThereafter           *int              `restricted:"true"`
+	EnableSyslog                 *bool             `restricted:"true"`
+	SyslogLevel                  *string           `restricted:"true"`
+	SyslogNetwork                *string           `restricted:"true"`
+	SyslogAddress                *string           `restricted:"true"`
+	SyslogTag                    *string           `restricted:"true"`
+	EnableTcp                    *bool             `restricted:"true"`
+	TcpLevel                     *string           `restricted:"true"`
+	TcpAddress                   *string           `restricted:"true"`
+	TcpUseTLS                    *bool             `restricted:"true"`
+	TcpSkipCertificateValidation *bool             `restricted:"true"`
 }
 
 func (s *LogSettings) SetDefaults() {
@@ -965,6 +1315,62 @@ func (s *LogSettings) SetDefaults() {
 	if s.FileJson == nil {
 		s.FileJson = NewBool(true)
 	}
+
+	if s.SubsystemLevels == nil {
+		s.SubsystemLevels = map[string]string{}
+	}
+
+	if s.EnableSampling == nil {
+		s.EnableSampling = NewBool(false)
+	}
+
+	if s.SamplingInitial == nil {
+		s.SamplingInitial = NewInt(LOG_SETTINGS_DEFAULT_SAMPLING_INITIAL)
+	}
+
+	if s.SamplingThereafter == nil {
+		s.SamplingThereafter = NewInt(LOG_SETTINGS_DEFAULT_SAMPLING_THEREAFTER)
+	}
+
+	if s.EnableSyslog == nil {
+		s.EnableSyslog = NewBool(false)
+	}
+
+	if s.SyslogLevel == nil {
+		s.SyslogLevel = NewString("INFO")
+	}
+
+	if s.SyslogNetwork == nil {
+		s.SyslogNetwork = NewString(LOG_SETTINGS_SYSLOG_NETWORK_LOCAL)
+	}
+
+	if s.SyslogAddress == nil {
+		s.SyslogAddress = NewString("")
+	}
+
+	if s.SyslogTag == nil {
+		s.SyslogTag = NewString(LOG_SETTINGS_DEFAULT_SYSLOG_TAG)
+	}
+
+	if s.EnableTcp == nil {
+		s.EnableTcp = NewBool(false)
+	}
+
+	if s.TcpLevel == nil {
+		s.TcpLevel = NewString("INFO")
+	}
+
+	if s.TcpAddress == nil {
+		s.TcpAddress = NewString("")
+	}
+
+	if s.TcpUseTLS == nil {
+		s.TcpUseTLS = NewBool(false)
+	}
+
+	if s.TcpSkipCertificateValidation == nil {
+		s.TcpSkipCertificateValidation = NewBool(false)
+	}
 }
 
 type NotificationLogSettings struct {
@@ -977,34 +1383,411 @@ type NotificationLogSettings struct {
 	FileLocation  *string `restricted:"true"`
 }
 
-func (s *NotificationLogSettings) SetDefaults() {
-	if s.EnableConsole == nil {
-		s.EnableConsole = NewBool(true)
+func (s *NotificationLogSettings) SetDefaults() {
+	if s.EnableConsole == nil {
+		s.EnableConsole = NewBool(true)
+	}
+
+	if s.ConsoleLevel == nil {
+		s.ConsoleLevel = NewString("DEBUG")
+	}
+
+	if s.EnableFile == nil {
+		s.EnableFile = NewBool(true)
+	}
+
+	if s.FileLevel == nil {
+		s.FileLevel = NewString("INFO")
+	}
+
+	if s.FileLocation == nil {
+		s.FileLocation = NewString("")
+	}
+
+	if s.ConsoleJson == nil {
+		s.ConsoleJson = NewBool(true)
+	}
+
+	if s.FileJson == nil {
+		s.FileJson = NewBool(true)
+	}
+}
+
+type AuditSettings struct {
+	EnableDatabase                *bool   `restricted:"true"`
+	LogAllApiMutations            *bool   `restricted:"true"`
+	EnableFile                    *bool   `restricted:"true"`
+	FileLocation                  *string `restricted:"true"`
+	FileMaxSizeMB                 *int    `restricted:"true"`
+	EnableSyslog                  *bool   `restricted:"true"`
+	SyslogNetwork                 *string `restricted:"true"`
+	SyslogAddress                 *string `restricted:"true"`
+	SyslogTag                     *string `restricted:"true"`
+	EnableHTTP                    *bool   `restricted:"true"`
+	HTTPEndpoint                  *string `restricted:"true"`
+	HTTPFormat                    *string `restricted:"true"`
+	HTTPAuthorizationHeader       *string `restricted:"true"`
+	HTTPIndex                     *string `restricted:"true"`
+	HTTPBatchSize                 *int    `restricted:"true"`
+	HTTPFlushIntervalSeconds      *int    `restricted:"true"`
+	HTTPMaxQueueSize              *int    `restricted:"true"`
+	HTTPRequestTimeoutSeconds     *int    `restricted:"true"`
+	HTTPSkipCertificateValidation *bool   `restricted:"true"`
+}
+
+func (s *AuditSettings) SetDefaults() {
+	if s.EnableDatabase == nil {
+		s.EnableDatabase = NewBool(true)
+	}
+
+	if s.LogAllApiMutations == nil {
+		s.LogAllApiMutations = NewBool(false)
+	}
+
+	if s.EnableFile == nil {
+		s.EnableFile = NewBool(false)
+	}
+
+	if s.FileLocation == nil {
+		s.FileLocation = NewString("")
+	}
+
+	if s.FileMaxSizeMB == nil {
+		s.FileMaxSizeMB = NewInt(AUDIT_SETTINGS_DEFAULT_FILE_MAX_SIZE_MB)
+	}
+
+	if s.EnableSyslog == nil {
+		s.EnableSyslog = NewBool(false)
+	}
+
+	if s.SyslogNetwork == nil {
+		s.SyslogNetwork = NewString(AUDIT_SETTINGS_SYSLOG_NETWORK_LOCAL)
+	}
+
+	if s.SyslogAddress == nil {
+		s.SyslogAddress = NewString("")
+	}
+
+	if s.SyslogTag == nil {
+		s.SyslogTag = NewString(AUDIT_SETTINGS_DEFAULT_SYSLOG_TAG)
+	}
+
+	if s.EnableHTTP == nil {
+		s.EnableHTTP = NewBool(false)
+	}
+
+	if s.HTTPEndpoint == nil {
+		s.HTTPEndpoint = NewString("")
+	}
+
+	if s.HTTPFormat == nil {
+		s.HTTPFormat = NewString(AUDIT_SETTINGS_HTTP_FORMAT_JSON)
+	}
+
+	if s.HTTPAuthorizationHeader == nil {
+		s.HTTPAuthorizationHeader = NewString("")
+	}
+
+	if s.HTTPIndex == nil {
+		s.HTTPIndex = NewString("")
+	}
+
+	if s.HTTPBatchSize == nil {
+		s.HTTPBatchSize = NewInt(AUDIT_SETTINGS_DEFAULT_HTTP_BATCH_SIZE)
+	}
+
+	if s.HTTPFlushIntervalSeconds == nil {
+		s.HTTPFlushIntervalSeconds = NewInt(AUDIT_SETTINGS_DEFAULT_HTTP_FLUSH_INTERVAL_SECS)
+	}
+
+	if s.HTTPMaxQueueSize == nil {
+		s.HTTPMaxQueueSize = NewInt(AUDIT_SETTINGS_DEFAULT_HTTP_MAX_QUEUE_SIZE)
+	}
+
+	if s.HTTPRequestTimeoutSeconds == nil {
+		s.HTTPRequestTimeoutSeconds = NewInt(AUDIT_SETTINGS_DEFAULT_HTTP_REQUEST_TIMEOUT_SECS)
+	}
+
+	if s.HTTPSkipCertificateValidation == nil {
+		s.HTTPSkipCertificateValidation = NewBool(false)
+	}
+}
+
+// ApiAccessLogSettings configures the log of the requests served by the API, which lets admins find out which
+// users and integrations are behind the load on the server.
+type ApiAccessLogSettings struct {
+	Enable                 *bool   `restricted:"true"`
+	FileLocation           *string `restricted:"true"`
+	FileMaxSizeMB          *int    `restricted:"true"`
+	SamplePercent          *int    `restricted:"true"`
+	AlwaysLogErrors        *bool   `restricted:"true"`
+	SlowRequestThresholdMs *int    `restricted:"true"`
+}
+
+func (s *ApiAccessLogSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewBool(false)
+	}
+
+	if s.FileLocation == nil {
+		s.FileLocation = NewString("")
+	}
+
+	if s.FileMaxSizeMB == nil {
+		s.FileMaxSizeMB = NewInt(API_ACCESS_LOG_SETTINGS_DEFAULT_FILE_MAX_SIZE_MB)
+	}
+
+	if s.SamplePercent == nil {
+		s.SamplePercent = NewInt(API_ACCESS_LOG_SETTINGS_DEFAULT_SAMPLE_PERCENT)
+	}
+
+	if s.AlwaysLogErrors == nil {
+		s.AlwaysLogErrors = NewBool(true)
+	}
+
+	if s.SlowRequestThresholdMs == nil {
+		s.SlowRequestThresholdMs = NewInt(API_ACCESS_LOG_SETTINGS_DEFAULT_SLOW_REQUEST_THRESHOLD)
+	}
+}
+
+// OffboardingSettings configures what is done when a user reaches their scheduled deactivation date.
+type OffboardingSettings struct {
+	EnableScheduledDeactivation  *bool   `restricted:"true"`
+	RemoveFromChannels           *bool   `restricted:"true"`
+	ReassignIntegrationsToUserId *string `restricted:"true"`
+	SummaryChannelId             *string `restricted:"true"`
+}
+
+func (s *OffboardingSettings) SetDefaults() {
+	if s.EnableScheduledDeactivation == nil {
+		s.EnableScheduledDeactivation = NewBool(false)
+	}
+
+	if s.RemoveFromChannels == nil {
+		s.RemoveFromChannels = NewBool(true)
+	}
+
+	if s.ReassignIntegrationsToUserId == nil {
+		s.ReassignIntegrationsToUserId = NewString("")
+	}
+
+	if s.SummaryChannelId == nil {
+		s.SummaryChannelId = NewString("")
+	}
+}
+
+func (s *OffboardingSettings) isValid() *AppError {
+	if *s.ReassignIntegrationsToUserId != "" && !IsValidId(*s.ReassignIntegrationsToUserId) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.offboarding.reassign_integrations_to_user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.SummaryChannelId != "" && !IsValidId(*s.SummaryChannelId) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.offboarding.summary_channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// ContentFilterSettings configures the checking of posts against the content filters.
+type ContentFilterSettings struct {
+	Enable        *bool   `restricted:"true"`
+	MaskCharacter *string `restricted:"true"`
+}
+
+func (s *ContentFilterSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewBool(false)
+	}
+
+	if s.MaskCharacter == nil {
+		s.MaskCharacter = NewString(CONTENT_FILTER_SETTINGS_DEFAULT_MASK_CHARACTER)
+	}
+}
+
+func (s *ContentFilterSettings) isValid() *AppError {
+	if utf8.RuneCountInString(*s.MaskCharacter) != 1 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.content_filter.mask_character.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// PostReportSettings configures the reporting of posts to moderators.
+type PostReportSettings struct {
+	Enable *bool
+	// ModeratorsChannelId is the channel notified of new reports, where its members act on them.
+	ModeratorsChannelId *string `restricted:"true"`
+}
+
+func (s *PostReportSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewBool(false)
+	}
+
+	if s.ModeratorsChannelId == nil {
+		s.ModeratorsChannelId = NewString("")
+	}
+}
+
+func (s *PostReportSettings) isValid() *AppError {
+	if *s.ModeratorsChannelId != "" && !IsValidId(*s.ModeratorsChannelId) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.post_report.moderators_channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// RecurringPostSettings configures the messages channel admins schedule to be posted to their channels by bots.
+type RecurringPostSettings struct {
+	Enable *bool
+}
+
+func (s *RecurringPostSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewBool(false)
+	}
+}
+
+// NetworkAccessSettings restricts the IP addresses and countries users can log in and use their sessions from.
+type NetworkAccessSettings struct {
+	Enable *bool `restricted:"true"`
+	// AllowedIPRanges are the space separated IP addresses and CIDR ranges every user must connect from, or empty
+	// to allow any.
+	AllowedIPRanges *string `restricted:"true"`
+	// RoleAllowedIPRanges further restricts the users having a role, by role name, to IP addresses and CIDR ranges.
+	RoleAllowedIPRanges map[string]string `restricted:"true"`
+	// CountryHeader is the header holding the ISO 3166 code of the country a request comes from, as set by a
+	// trusted proxy doing the GeoIP lookup. Countries aren't checked when it is empty.
+	CountryHeader    *string `restricted:"true"`
+	AllowedCountries *string `restricted:"true"`
+	BlockedCountries *string `restricted:"true"`
+	// BypassUserIds are the users the restrictions don't apply to, so that admins can't lock themselves out.
+	BypassUserIds []string `restricted:"true"`
+}
+
+func (s *NetworkAccessSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewBool(false)
+	}
+
+	if s.AllowedIPRanges == nil {
+		s.AllowedIPRanges = NewString("")
+	}
+
+	if s.RoleAllowedIPRanges == nil {
+		s.RoleAllowedIPRanges = map[string]string{}
+	}
+
+	if s.CountryHeader == nil {
+		s.CountryHeader = NewString("")
+	}
+
+	if s.AllowedCountries == nil {
+		s.AllowedCountries = NewString("")
+	}
+
+	if s.BlockedCountries == nil {
+		s.BlockedCountries = NewString("")
+	}
+
+	if s.BypassUserIds == nil {
+		s.BypassUserIds = []string{}
+	}
+}
+
+func (s *NetworkAccessSettings) isValid() *AppError {
+	if !IsValidIpRanges(*s.AllowedIPRanges) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.network_access.allowed_ip_ranges.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for role, ranges := range s.RoleAllowedIPRanges {
+		if !IsValidIpRanges(ranges) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.network_access.role_allowed_ip_ranges.app_error", map[string]interface{}{"Role": role}, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, countries := range []string{*s.AllowedCountries, *s.BlockedCountries} {
+		for _, country := range strings.Fields(countries) {
+			if !isValidCountryCode(country) {
+				return NewAppError("Config.IsValid", "model.config.is_valid.network_access.country.app_error", map[string]interface{}{"Country": country}, "", http.StatusBadRequest)
+			}
+		}
+	}
+
+	for _, userId := range s.BypassUserIds {
+		if !IsValidId(userId) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.network_access.bypass_user_ids.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// LoginSecuritySettings configures the lockout of accounts after ServiceSettings.MaximumLoginAttempts failed
+// logins, and the detection of logins from a new country or device.
+type LoginSecuritySettings struct {
+	// LockoutDurationMinutes is how long a locked account stays locked, or 0 to keep it locked until an admin
+	// unlocks it or its password is reset.
+	LockoutDurationMinutes *int `restricted:"true"`
+	EnableAnomalyDetection *bool
+	// EnableEmailAlerts emails users when their account is locked and when they log in from a new country or device.
+	EnableEmailAlerts     *bool
+	AttemptsRetentionDays *int `restricted:"true"`
+}
+
+func (s *LoginSecuritySettings) SetDefaults() {
+	if s.LockoutDurationMinutes == nil {
+		s.LockoutDurationMinutes = NewInt(0)
 	}
 
-	if s.ConsoleLevel == nil {
-		s.ConsoleLevel = NewString("DEBUG")
+	if s.EnableAnomalyDetection == nil {
+		s.EnableAnomalyDetection = NewBool(false)
 	}
 
-	if s.EnableFile == nil {
-		s.EnableFile = NewBool(true)
+	if s.EnableEmailAlerts == nil {
+		s.EnableEmailAlerts = NewBool(false)
 	}
 
-	if s.FileLevel == nil {
-		s.FileLevel = NewString("INFO")
+	if s.AttemptsRetentionDays == nil {
+		s.AttemptsRetentionDays = NewInt(LOGIN_SECURITY_SETTINGS_DEFAULT_ATTEMPTS_RETENTION_DAYS)
 	}
+}
 
-	if s.FileLocation == nil {
-		s.FileLocation = NewString("")
+func (s *LoginSecuritySettings) isValid() *AppError {
+	if *s.LockoutDurationMinutes < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.login_security.lockout_duration_minutes.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if s.ConsoleJson == nil {
-		s.ConsoleJson = NewBool(true)
+	if *s.AttemptsRetentionDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.login_security.attempts_retention_days.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if s.FileJson == nil {
-		s.FileJson = NewBool(true)
+	return nil
+}
+
+// FeatureFlagSettings holds the feature flags, by the name the code checks them by.
+type FeatureFlagSettings struct {
+	Flags map[string]*FeatureFlag
+}
+
+func (s *FeatureFlagSettings) SetDefaults() {
+	if s.Flags == nil {
+		s.Flags = map[string]*FeatureFlag{}
+	}
+}
+
+func (s *FeatureFlagSettings) isValid() *AppError {
+	for name, flag := range s.Flags {
+		if flag == nil {
+			continue
+		}
+
+		if err := flag.IsValid(name); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
 type PasswordSettings struct {
@@ -1161,6 +1944,10 @@ type EmailSettings struct {
 	SendPushNotifications             *bool
 	PushNotificationServer            *string
 	PushNotificationContents          *string
+	DirectPushNotificationContents    *string
+	GroupPushNotificationContents     *string
+	ChannelPushNotificationContents   *string
+	PushNotificationMaxFailures       *int
 	EnableEmailBatching               *bool
 	EmailBatchingBufferSize           *int
 	EmailBatchingInterval             *int
@@ -1257,6 +2044,22 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 		s.PushNotificationContents = NewString(GENERIC_NOTIFICATION)
 	}
 
+	if s.DirectPushNotificationContents == nil {
+		s.DirectPushNotificationContents = NewString("")
+	}
+
+	if s.GroupPushNotificationContents == nil {
+		s.GroupPushNotificationContents = NewString("")
+	}
+
+	if s.ChannelPushNotificationContents == nil {
+		s.ChannelPushNotificationContents = NewString("")
+	}
+
+	if s.PushNotificationMaxFailures == nil {
+		s.PushNotificationMaxFailures = NewInt(PUSH_NOTIFICATION_MAX_FAILURES_DEFAULT)
+	}
+
 	if s.EnableEmailBatching == nil {
 		s.EnableEmailBatching = NewBool(false)
 	}
@@ -1502,6 +2305,7 @@ type TeamSettings struct {
 	MaxChannelsPerTeam                                        *int64
 	MaxNotificationsPerChannel                                *int64
 	EnableConfirmNotificationsToChannel                       *bool
+	EnableCrossTeamChannelMentions                            *bool
 	TeammateNameDisplay                                       *string
 	ExperimentalViewArchivedChannels                          *bool
 	ExperimentalEnableAutomaticReplies                        *bool
@@ -1622,6 +2426,10 @@ func (s *TeamSettings) SetDefaults() {
 		s.EnableConfirmNotificationsToChannel = NewBool(true)
 	}
 
+	if s.EnableCrossTeamChannelMentions == nil {
+		s.EnableCrossTeamChannelMentions = NewBool(false)
+	}
+
 	if s.ExperimentalEnableAutomaticReplies == nil {
 		s.ExperimentalEnableAutomaticReplies = NewBool(false)
 	}
@@ -1694,7 +2502,10 @@ type LdapSettings struct {
 	LoginIdAttribute   *string
 
 	// Synchronization
-	SyncIntervalMinutes *int
+	SyncIntervalMinutes      *int
+	EnableIncrementalSync    *bool
+	ModifyTimestampAttribute *string
+	FullSyncIntervalHours    *int
 
 	// Advanced
 	SkipCertificateVerification *bool
@@ -1799,6 +2610,18 @@ func (s *LdapSettings) SetDefaults() {
 		s.SyncIntervalMinutes = NewInt(60)
 	}
 
+	if s.EnableIncrementalSync == nil {
+		s.EnableIncrementalSync = NewBool(false)
+	}
+
+	if s.ModifyTimestampAttribute == nil {
+		s.ModifyTimestampAttribute = NewString(LDAP_SETTINGS_DEFAULT_MODIFY_TIMESTAMP_ATTRIBUTE)
+	}
+
+	if s.FullSyncIntervalHours == nil {
+		s.FullSyncIntervalHours = NewInt(LDAP_SETTINGS_DEFAULT_FULL_SYNC_INTERVAL_HOURS)
+	}
+
 	if s.SkipCertificateVerification == nil {
 		s.SkipCertificateVerification = NewBool(false)
 	}
@@ -1882,6 +2705,9 @@ type SamlSettings struct {
 	Encrypt     *bool
 	SignRequest *bool
 
+	SignatureAlgorithm *string
+	CanonicalAlgorithm *string
+
 	IdpUrl                      *string
 	IdpDescriptorUrl            *string
 	AssertionConsumerServiceURL *string
@@ -1902,6 +2728,7 @@ type SamlSettings struct {
 	NicknameAttribute  *string
 	LocaleAttribute    *string
 	PositionAttribute  *string
+	GroupAttribute     *string
 
 	LoginButtonText *string
 
@@ -1935,6 +2762,14 @@ func (s *SamlSettings) SetDefaults() {
 		s.SignRequest = NewBool(false)
 	}
 
+	if s.SignatureAlgorithm == nil {
+		s.SignatureAlgorithm = NewString(SAML_SETTINGS_DEFAULT_SIGNATURE_ALGORITHM)
+	}
+
+	if s.CanonicalAlgorithm == nil {
+		s.CanonicalAlgorithm = NewString(SAML_SETTINGS_DEFAULT_CANONICAL_ALGORITHM)
+	}
+
 	if s.IdpUrl == nil {
 		s.IdpUrl = NewString("")
 	}
@@ -2003,6 +2838,10 @@ func (s *SamlSettings) SetDefaults() {
 		s.LocaleAttribute = NewString(SAML_SETTINGS_DEFAULT_LOCALE_ATTRIBUTE)
 	}
 
+	if s.GroupAttribute == nil {
+		s.GroupAttribute = NewString(SAML_SETTINGS_DEFAULT_GROUP_ATTRIBUTE)
+	}
+
 	if s.LoginButtonColor == nil {
 		s.LoginButtonColor = NewString("#34a28b")
 	}
@@ -2152,6 +2991,9 @@ type DataRetentionSettings struct {
 	MessageRetentionDays  *int
 	FileRetentionDays     *int
 	DeletionJobStartTime  *string
+	EnablePostArchive     *bool
+	PostArchiveMonths     *int
+	ArchiveJobStartTime   *string
 }
 
 func (s *DataRetentionSettings) SetDefaults() {
@@ -2174,11 +3016,26 @@ func (s *DataRetentionSettings) SetDefaults() {
 	if s.DeletionJobStartTime == nil {
 		s.DeletionJobStartTime = NewString(DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME)
 	}
+
+	if s.EnablePostArchive == nil {
+		s.EnablePostArchive = NewBool(false)
+	}
+
+	if s.PostArchiveMonths == nil {
+		s.PostArchiveMonths = NewInt(DATA_RETENTION_SETTINGS_DEFAULT_POST_ARCHIVE_MONTHS)
+	}
+
+	if s.ArchiveJobStartTime == nil {
+		s.ArchiveJobStartTime = NewString(DATA_RETENTION_SETTINGS_DEFAULT_ARCHIVE_JOB_START_TIME)
+	}
 }
 
 type JobSettings struct {
-	RunJobs      *bool `restricted:"true"`
-	RunScheduler *bool `restricted:"true"`
+	RunJobs                *bool             `restricted:"true"`
+	RunScheduler           *bool             `restricted:"true"`
+	Schedules              map[string]string `restricted:"true"`
+	StuckJobTimeoutMinutes *int              `restricted:"true"`
+	StuckJobMaxRetries     *int              `restricted:"true"`
 }
 
 func (s *JobSettings) SetDefaults() {
@@ -2189,6 +3046,40 @@ func (s *JobSettings) SetDefaults() {
 	if s.RunScheduler == nil {
 		s.RunScheduler = NewBool(true)
 	}
+
+	if s.Schedules == nil {
+		s.Schedules = make(map[string]string)
+	}
+
+	if s.StuckJobTimeoutMinutes == nil {
+		s.StuckJobTimeoutMinutes = NewInt(10)
+	}
+
+	if s.StuckJobMaxRetries == nil {
+		s.StuckJobMaxRetries = NewInt(3)
+	}
+}
+
+func (s *JobSettings) isValid() *AppError {
+	for jobType, schedule := range s.Schedules {
+		if !IsValidJobType(jobType) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.job_schedule_type.app_error", map[string]interface{}{"JobType": jobType}, "", http.StatusBadRequest)
+		}
+
+		if _, err := ParseCronSchedule(schedule); err != nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.job_schedule.app_error", map[string]interface{}{"JobType": jobType}, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	if *s.StuckJobTimeoutMinutes < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.stuck_job_timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.StuckJobMaxRetries < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.stuck_job_max_retries.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 type PluginState struct {
@@ -2406,6 +3297,8 @@ type Config struct {
 	SqlSettings             SqlSettings
 	LogSettings             LogSettings
 	NotificationLogSettings NotificationLogSettings
+	AuditSettings           AuditSettings
+	ApiAccessLogSettings    ApiAccessLogSettings
 	PasswordSettings        PasswordSettings
 	FileSettings            FileSettings
 	EmailSettings           EmailSettings
@@ -2434,6 +3327,13 @@ type Config struct {
 	DisplaySettings         DisplaySettings
 	GuestAccountsSettings   GuestAccountsSettings
 	ImageProxySettings      ImageProxySettings
+	OffboardingSettings     OffboardingSettings
+	ContentFilterSettings   ContentFilterSettings
+	PostReportSettings      PostReportSettings
+	RecurringPostSettings   RecurringPostSettings
+	FeatureFlagSettings     FeatureFlagSettings
+	NetworkAccessSettings   NetworkAccessSettings
+	LoginSecuritySettings   LoginSecuritySettings
 }
 
 func (o *Config) Clone() *Config {
@@ -2513,11 +3413,20 @@ func (o *Config) SetDefaults() {
 	o.RateLimitSettings.SetDefaults()
 	o.LogSettings.SetDefaults()
 	o.NotificationLogSettings.SetDefaults()
+	o.AuditSettings.SetDefaults()
+	o.ApiAccessLogSettings.SetDefaults()
 	o.JobSettings.SetDefaults()
 	o.MessageExportSettings.SetDefaults()
 	o.DisplaySettings.SetDefaults()
 	o.GuestAccountsSettings.SetDefaults()
 	o.ImageProxySettings.SetDefaults(o.ServiceSettings)
+	o.OffboardingSettings.SetDefaults()
+	o.ContentFilterSettings.SetDefaults()
+	o.PostReportSettings.SetDefaults()
+	o.RecurringPostSettings.SetDefaults()
+	o.FeatureFlagSettings.SetDefaults()
+	o.NetworkAccessSettings.SetDefaults()
+	o.LoginSecuritySettings.SetDefaults()
 }
 
 func (o *Config) IsValid() *AppError {
@@ -2541,6 +3450,10 @@ func (o *Config) IsValid() *AppError {
 		return err
 	}
 
+	if err := o.ClusterSettings.isValid(); err != nil {
+		return err
+	}
+
 	if err := o.FileSettings.isValid(); err != nil {
 		return err
 	}
@@ -2577,6 +3490,10 @@ func (o *Config) IsValid() *AppError {
 		return err
 	}
 
+	if err := o.JobSettings.isValid(); err != nil {
+		return err
+	}
+
 	if err := o.LocalizationSettings.isValid(); err != nil {
 		return err
 	}
@@ -2593,6 +3510,46 @@ func (o *Config) IsValid() *AppError {
 		return err
 	}
 
+	if err := o.LogSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.AuditSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.ApiAccessLogSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.AnalyticsSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.OffboardingSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.ContentFilterSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.PostReportSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.FeatureFlagSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.NetworkAccessSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.LoginSecuritySettings.isValid(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -2633,7 +3590,7 @@ func (ss *SqlSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.encrypt_sql.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if !(*ss.DriverName == DATABASE_DRIVER_MYSQL || *ss.DriverName == DATABASE_DRIVER_POSTGRES) {
+	if !(*ss.DriverName == DATABASE_DRIVER_MYSQL || *ss.DriverName == DATABASE_DRIVER_POSTGRES || *ss.DriverName == DATABASE_DRIVER_COCKROACH) {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sql_driver.app_error", nil, "", http.StatusBadRequest)
 	}
 
@@ -2649,6 +3606,22 @@ func (ss *SqlSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sql_query_timeout.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *ss.ReplicaMaxLagSeconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_replica_max_lag_seconds.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.MigrationBatchSize <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_migration_batch_size.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.PartitionPostsArchive && *ss.DriverName != DATABASE_DRIVER_POSTGRES {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_partition_posts_archive.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.EnableSlowQueryLog && *ss.SlowQueryThresholdMilliseconds <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_slow_query_threshold.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if len(*ss.DataSource) == 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sql_data_src.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -2693,9 +3666,52 @@ func (es *EmailSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.email_notification_contents_type.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if !isValidPushNotificationContents(*es.PushNotificationContents) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.push_notification_contents.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *es.PushNotificationMaxFailures < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.push_notification_max_failures.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, contents := range []string{*es.DirectPushNotificationContents, *es.GroupPushNotificationContents, *es.ChannelPushNotificationContents} {
+		if contents != "" && !isValidPushNotificationContents(contents) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.push_notification_contents.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
+func isValidPushNotificationContents(contents string) bool {
+	switch contents {
+	case FULL_NOTIFICATION, SENDER_ONLY_NOTIFICATION, GENERIC_NOTIFICATION, GENERIC_NO_CHANNEL_NOTIFICATION:
+		return true
+	}
+
+	return false
+}
+
+// GetPushNotificationContents returns the push notification contents that apply to posts in a channel of
+// the given type. The per channel type settings take precedence over PushNotificationContents when set.
+func (es *EmailSettings) GetPushNotificationContents(channelType string) string {
+	var contents *string
+	switch channelType {
+	case CHANNEL_DIRECT:
+		contents = es.DirectPushNotificationContents
+	case CHANNEL_GROUP:
+		contents = es.GroupPushNotificationContents
+	case CHANNEL_OPEN, CHANNEL_PRIVATE:
+		contents = es.ChannelPushNotificationContents
+	}
+
+	if contents != nil && *contents != "" {
+		return *contents
+	}
+
+	return *es.PushNotificationContents
+}
+
 func (rls *RateLimitSettings) isValid() *AppError {
 	if *rls.MemoryStoreSize <= 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.rate_mem.app_error", nil, "", http.StatusBadRequest)
@@ -2721,6 +3737,16 @@ func (ls *LdapSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.ldap_sync_interval.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *ls.EnableIncrementalSync {
+		if *ls.ModifyTimestampAttribute == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.ldap_modify_timestamp_attribute.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if *ls.FullSyncIntervalHours <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.ldap_full_sync_interval.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
 	if *ls.MaxPageSize < 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.ldap_max_page_size.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -2798,6 +3824,18 @@ func (ss *SamlSettings) isValid() *AppError {
 			}
 		}
 
+		switch *ss.SignatureAlgorithm {
+		case SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA1, SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA256, SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA512:
+		default:
+			return NewAppError("Config.IsValid", "model.config.is_valid.saml_signature_algorithm.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		switch *ss.CanonicalAlgorithm {
+		case SAML_SETTINGS_CANONICAL_ALGORITHM_C14N, SAML_SETTINGS_CANONICAL_ALGORITHM_C14N11:
+		default:
+			return NewAppError("Config.IsValid", "model.config.is_valid.saml_canonical_algorithm.app_error", nil, "", http.StatusBadRequest)
+		}
+
 		if len(*ss.EmailAttribute) == 0 {
 			return NewAppError("Config.IsValid", "model.config.is_valid.saml_email_attribute.app_error", nil, "", http.StatusBadRequest)
 		}
@@ -2807,6 +3845,10 @@ func (ss *SamlSettings) isValid() *AppError {
 }
 
 func (ss *ServiceSettings) isValid() *AppError {
+	if *ss.LicenseSeatWarningPercentage < 1 || *ss.LicenseSeatWarningPercentage > 100 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.license_seat_warning_percentage.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if !(*ss.ConnectionSecurity == CONN_SECURITY_NONE || *ss.ConnectionSecurity == CONN_SECURITY_TLS) {
 		return NewAppError("Config.IsValid", "model.config.is_valid.webserver_security.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -2849,10 +3891,22 @@ func (ss *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.time_between_user_typing.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *ss.MultifactorAuthenticationGracePeriodDays < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.mfa_grace_period.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *ss.MaximumLoginAttempts <= 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.login_attempts.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *ss.GracefulShutdownTimeoutSeconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.graceful_shutdown_timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.CustomEmojiQuotaPerUser < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.custom_emoji_quota.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if len(*ss.SiteURL) != 0 {
 		if _, err := url.ParseRequestURI(*ss.SiteURL); err != nil {
 			return NewAppError("Config.IsValid", "model.config.is_valid.site_url.app_error", nil, "", http.StatusBadRequest)
@@ -2883,6 +3937,50 @@ func (ss *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.group_unread_channels.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *ss.IncomingWebhookRateLimitPerMinute < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.incoming_webhook_rate_limit.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.IncomingWebhookDailyQuota < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.incoming_webhook_daily_quota.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.UserImpersonationLengthInMinutes <= 0 || *ss.UserImpersonationLengthInMinutes > 24*60 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.user_impersonation_length.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(*ss.OutboundProxyURL) != 0 {
+		if proxyURL, err := url.Parse(*ss.OutboundProxyURL); err != nil || proxyURL.Host == "" || !(proxyURL.Scheme == "http" || proxyURL.Scheme == "https" || proxyURL.Scheme == "socks5") {
+			return NewAppError("Config.IsValid", "model.config.is_valid.outbound_proxy_url.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, channelId := range ss.OnboardingCompletionChannelIds {
+		if !IsValidId(channelId) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.onboarding_completion_channel_id.app_error", nil, "channel_id="+channelId, http.StatusBadRequest)
+		}
+	}
+
+	for trigger, restriction := range ss.CommandRestrictions {
+		if err := restriction.IsValid(trigger); err != nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.command_restriction.app_error", map[string]interface{}{"Trigger": trigger}, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	for destination, seconds := range ss.OutboundRequestTimeouts {
+		if destination == "" || seconds <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.outbound_request_timeout.app_error", map[string]interface{}{"Destination": destination}, "", http.StatusBadRequest)
+		}
+	}
+
+	if *ss.OutboundCircuitBreakerThreshold < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.outbound_circuit_breaker_threshold.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.OutboundCircuitBreakerCooldownSeconds <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.outbound_circuit_breaker_cooldown.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -2937,6 +4035,14 @@ func (drs *DataRetentionSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.deletion_job_start_time.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
+	if *drs.PostArchiveMonths <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.post_archive_months_too_low.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if _, err := time.Parse("15:04", *drs.ArchiveJobStartTime); err != nil {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.archive_job_start_time.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -3027,6 +4133,122 @@ func (ips *ImageProxySettings) isValid() *AppError {
 	return nil
 }
 
+func isValidLogLevel(level string) bool {
+	switch strings.ToLower(level) {
+	case mlog.LevelDebug, mlog.LevelInfo, mlog.LevelWarn, mlog.LevelError:
+		return true
+	}
+	return false
+}
+
+func (s *LogSettings) isValid() *AppError {
+	for subsystem, level := range s.SubsystemLevels {
+		if !mlog.IsValidSubsystem(subsystem) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.log.subsystem.app_error", map[string]interface{}{"Subsystem": subsystem}, "", http.StatusBadRequest)
+		}
+
+		if !isValidLogLevel(level) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.log.subsystem_level.app_error", map[string]interface{}{"Subsystem": subsystem}, "level="+level, http.StatusBadRequest)
+		}
+	}
+
+	if *s.EnableSampling && (*s.SamplingInitial <= 0 || *s.SamplingThereafter <= 0) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.log.sampling.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.EnableSyslog {
+		if !isValidLogLevel(*s.SyslogLevel) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.log.syslog_level.app_error", nil, "level="+*s.SyslogLevel, http.StatusBadRequest)
+		}
+
+		switch *s.SyslogNetwork {
+		case LOG_SETTINGS_SYSLOG_NETWORK_LOCAL:
+		case LOG_SETTINGS_SYSLOG_NETWORK_UDP, LOG_SETTINGS_SYSLOG_NETWORK_TCP:
+			if _, _, err := net.SplitHostPort(*s.SyslogAddress); err != nil {
+				return NewAppError("Config.IsValid", "model.config.is_valid.log.syslog_address.app_error", nil, err.Error(), http.StatusBadRequest)
+			}
+		default:
+			return NewAppError("Config.IsValid", "model.config.is_valid.log.syslog_network.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if *s.EnableTcp {
+		if !isValidLogLevel(*s.TcpLevel) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.log.tcp_level.app_error", nil, "level="+*s.TcpLevel, http.StatusBadRequest)
+		}
+
+		if _, _, err := net.SplitHostPort(*s.TcpAddress); err != nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.log.tcp_address.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (s *AuditSettings) isValid() *AppError {
+	if *s.EnableFile && *s.FileMaxSizeMB <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.audit.file_max_size.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.EnableSyslog {
+		switch *s.SyslogNetwork {
+		case AUDIT_SETTINGS_SYSLOG_NETWORK_LOCAL:
+		case AUDIT_SETTINGS_SYSLOG_NETWORK_UDP, AUDIT_SETTINGS_SYSLOG_NETWORK_TCP:
+			if _, _, err := net.SplitHostPort(*s.SyslogAddress); err != nil {
+				return NewAppError("Config.IsValid", "model.config.is_valid.audit.syslog_address.app_error", nil, err.Error(), http.StatusBadRequest)
+			}
+		default:
+			return NewAppError("Config.IsValid", "model.config.is_valid.audit.syslog_network.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if *s.EnableHTTP {
+		if u, err := url.Parse(*s.HTTPEndpoint); err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+			return NewAppError("Config.IsValid", "model.config.is_valid.audit.http_endpoint.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		switch *s.HTTPFormat {
+		case AUDIT_SETTINGS_HTTP_FORMAT_JSON, AUDIT_SETTINGS_HTTP_FORMAT_SPLUNK_HEC:
+		case AUDIT_SETTINGS_HTTP_FORMAT_ELASTIC_BULK:
+			if *s.HTTPIndex == "" {
+				return NewAppError("Config.IsValid", "model.config.is_valid.audit.http_index.app_error", nil, "", http.StatusBadRequest)
+			}
+		default:
+			return NewAppError("Config.IsValid", "model.config.is_valid.audit.http_format.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if *s.HTTPBatchSize <= 0 || *s.HTTPMaxQueueSize < *s.HTTPBatchSize {
+			return NewAppError("Config.IsValid", "model.config.is_valid.audit.http_batch_size.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if *s.HTTPFlushIntervalSeconds <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.audit.http_flush_interval.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if *s.HTTPRequestTimeoutSeconds <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.audit.http_request_timeout.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (s *ApiAccessLogSettings) isValid() *AppError {
+	if *s.Enable && *s.FileMaxSizeMB <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.api_access_log.file_max_size.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.SamplePercent < 0 || *s.SamplePercent > 100 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.api_access_log.sample_percent.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.SlowRequestThresholdMs < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.api_access_log.slow_request_threshold.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (o *Config) GetSanitizeOptions() map[string]bool {
 	options := map[string]bool{}
 	options["fullname"] = *o.PrivacySettings.ShowFullName
@@ -3059,6 +4281,18 @@ func (o *Config) Sanitize() {
 
 	*o.ElasticsearchSettings.Password = FAKE_SETTING
 
+	if len(*o.AuditSettings.HTTPAuthorizationHeader) > 0 {
+		*o.AuditSettings.HTTPAuthorizationHeader = FAKE_SETTING
+	}
+
+	if len(*o.ClusterSettings.SecretKey) > 0 {
+		*o.ClusterSettings.SecretKey = FAKE_SETTING
+	}
+
+	if o.ServiceSettings.OutboundProxyURL != nil {
+		*o.ServiceSettings.OutboundProxyURL = sanitizeURLUserinfo(*o.ServiceSettings.OutboundProxyURL)
+	}
+
 	for i := range o.SqlSettings.DataSourceReplicas {
 		o.SqlSettings.DataSourceReplicas[i] = FAKE_SETTING
 	}
@@ -3067,3 +4301,18 @@ func (o *Config) Sanitize() {
 		o.SqlSettings.DataSourceSearchReplicas[i] = FAKE_SETTING
 	}
 }
+
+// sanitizeURLUserinfo replaces the credentials of a URL, such as those of a proxy, with FAKE_SETTING.
+func sanitizeURLUserinfo(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return FAKE_SETTING
+	}
+
+	if u.User == nil {
+		return rawURL
+	}
+
+	u.User = nil
+	return strings.Replace(u.String(), "//", "//"+FAKE_SETTING+"@", 1)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// ConfigChange is a single setting changed by a configuration change. The values of secrets are masked.
+type ConfigChange struct {
+	Path     string      `json:"path"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+// ConfigAudit records a change to the configuration: who made it, when, and the settings it changed. The whole
+// configuration it resulted in is kept, unsanitized, so that it can be rolled back to, but is never sent to clients.
+type ConfigAudit struct {
+	Id          string          `json:"id"`
+	CreateAt    int64           `json:"create_at"`
+	UserId      string          `json:"user_id"`
+	RollbackOf  string          `json:"rollback_of,omitempty"`
+	Changes     []*ConfigChange `json:"changes" db:"-"`
+	ChangesJson string          `json:"-" db:"Changes"`
+	Config      string          `json:"-"`
+}
+
+func (o *ConfigAudit) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("ConfigAudit.IsValid", "model.config_audit.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ConfigAudit.IsValid", "model.config_audit.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.UserId) != 0 && len(o.UserId) != 26 {
+		return NewAppError("ConfigAudit.IsValid", "model.config_audit.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.RollbackOf) != 0 && len(o.RollbackOf) != 26 {
+		return NewAppError("ConfigAudit.IsValid", "model.config_audit.is_valid.rollback_of.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Config) == 0 {
+		return NewAppError("ConfigAudit.IsValid", "model.config_audit.is_valid.config.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *ConfigAudit) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+
+	if o.Changes == nil {
+		o.Changes = []*ConfigChange{}
+	}
+	b, _ := json.Marshal(o.Changes)
+	o.ChangesJson = string(b)
+}
+
+// PostLoad decodes the changes read from the database.
+func (o *ConfigAudit) PostLoad() {
+	o.Changes = []*ConfigChange{}
+	if o.ChangesJson != "" {
+		json.Unmarshal([]byte(o.ChangesJson), &o.Changes)
+	}
+}
+
+// GetConfig decodes the configuration the change resulted in.
+func (o *ConfigAudit) GetConfig() *Config {
+	var config *Config
+	if err := json.Unmarshal([]byte(o.Config), &config); err != nil {
+		return nil
+	}
+	return config
+}
+
+func (o *ConfigAudit) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ConfigAuditFromJson(data io.Reader) *ConfigAudit {
+	var o *ConfigAudit
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func ConfigAuditListToJson(audits []*ConfigAudit) string {
+	b, _ := json.Marshal(audits)
+	return string(b)
+}
+
+func ConfigAuditListFromJson(data io.Reader) []*ConfigAudit {
+	var audits []*ConfigAudit
+	json.NewDecoder(data).Decode(&audits)
+	return audits
+}
+
+// DiffConfigs lists the settings that differ between two configurations, by their dotted paths such as
+// "ServiceSettings.EnablePostSearch" and in order. The values of the settings sanitized by Config.Sanitize are
+// replaced with FAKE_SETTING, so that the change of a secret shows without revealing it, while the settings only
+// partly sanitized, such as the credentials of a URL, are listed with their sanitized values.
+func DiffConfigs(oldConfig, newConfig *Config) []*ConfigChange {
+	oldSettings := flattenConfig(oldConfig)
+	newSettings := flattenConfig(newConfig)
+
+	sanitizedOld := oldConfig.Clone()
+	sanitizedOld.Sanitize()
+	sanitizedNew := newConfig.Clone()
+	sanitizedNew.Sanitize()
+	sanitizedOldSettings := flattenConfig(sanitizedOld)
+	sanitizedNewSettings := flattenConfig(sanitizedNew)
+	secrets := map[string]bool{}
+	for _, settings := range []map[string]interface{}{sanitizedOldSettings, sanitizedNewSettings} {
+		for path, value := range settings {
+			if value == FAKE_SETTING {
+				secrets[path] = true
+			}
+		}
+	}
+
+	paths := map[string]bool{}
+	for path := range oldSettings {
+		paths[path] = true
+	}
+	for path := range newSettings {
+		paths[path] = true
+	}
+
+	changes := []*ConfigChange{}
+	for path := range paths {
+		oldValue, newValue := oldSettings[path], newSettings[path]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		if secrets[path] {
+			oldValue, newValue = FAKE_SETTING, FAKE_SETTING
+		} else if !reflect.DeepEqual(oldValue, sanitizedOldSettings[path]) || !reflect.DeepEqual(newValue, sanitizedNewSettings[path]) {
+			oldValue, newValue = sanitizedOldSettings[path], sanitizedNewSettings[path]
+		}
+		changes = append(changes, &ConfigChange{Path: path, OldValue: oldValue, NewValue: newValue})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+// flattenConfig maps the dotted path of every setting of the configuration to its value as decoded from JSON.
+func flattenConfig(config *Config) map[string]interface{} {
+	var settings map[string]interface{}
+	json.Unmarshal([]byte(config.ToJson()), &settings)
+
+	flattened := map[string]interface{}{}
+	flattenSettings("", settings, flattened)
+	return flattened
+}
+
+func flattenSettings(prefix string, settings map[string]interface{}, flattened map[string]interface{}) {
+	for key, value := range settings {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if section, ok := value.(map[string]interface{}); ok {
+			flattenSettings(path, section, flattened)
+			continue
+		}
+		flattened[path] = value
+	}
+}
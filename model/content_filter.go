@@ -0,0 +1,190 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	CONTENT_FILTER_ACTION_BLOCK = "block"
+	CONTENT_FILTER_ACTION_MASK  = "mask"
+	CONTENT_FILTER_ACTION_FLAG  = "flag"
+
+	CONTENT_FILTER_NAME_MAX_LENGTH    = 64
+	CONTENT_FILTER_MAX_WORDS          = 500
+	CONTENT_FILTER_WORD_MAX_LENGTH    = 64
+	CONTENT_FILTER_MAX_PATTERNS       = 50
+	CONTENT_FILTER_PATTERN_MAX_LENGTH = 256
+)
+
+// ContentFilter is an admin-defined list of words and regular expressions which are blocked, masked or flagged
+// when they're posted. Filters without a team apply to all teams.
+type ContentFilter struct {
+	Id        string      `json:"id"`
+	CreateAt  int64       `json:"create_at"`
+	UpdateAt  int64       `json:"update_at"`
+	DeleteAt  int64       `json:"delete_at"`
+	CreatorId string      `json:"creator_id"`
+	TeamId    string      `json:"team_id"`
+	Name      string      `json:"name"`
+	Action    string      `json:"action"`
+	Words     StringArray `json:"words"`
+	Patterns  StringArray `json:"patterns"`
+}
+
+func (o *ContentFilter) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.CreatorId) > 26 {
+		return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.TeamId) != 0 && len(o.TeamId) != 26 {
+		return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.team_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Name) == 0 || len(o.Name) > CONTENT_FILTER_NAME_MAX_LENGTH {
+		return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.name.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Action {
+	case CONTENT_FILTER_ACTION_BLOCK, CONTENT_FILTER_ACTION_MASK, CONTENT_FILTER_ACTION_FLAG:
+	default:
+		return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.action.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Words) == 0 && len(o.Patterns) == 0 {
+		return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.empty.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Words) > CONTENT_FILTER_MAX_WORDS {
+		return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.words.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, word := range o.Words {
+		if len(strings.TrimSpace(word)) == 0 || len(word) > CONTENT_FILTER_WORD_MAX_LENGTH {
+			return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.words.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	}
+
+	if len(o.Patterns) > CONTENT_FILTER_MAX_PATTERNS {
+		return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.patterns.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, pattern := range o.Patterns {
+		if len(pattern) == 0 || len(pattern) > CONTENT_FILTER_PATTERN_MAX_LENGTH {
+			return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.patterns.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+
+		if _, err := regexp.Compile(pattern); err != nil {
+			return NewAppError("ContentFilter.IsValid", "model.content_filter.is_valid.pattern_syntax.app_error", map[string]interface{}{"Pattern": pattern}, "id="+o.Id+", "+err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (o *ContentFilter) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+	o.normalize()
+}
+
+func (o *ContentFilter) PreUpdate() {
+	o.UpdateAt = GetMillis()
+	o.normalize()
+}
+
+func (o *ContentFilter) normalize() {
+	o.Name = strings.TrimSpace(o.Name)
+
+	if o.Words == nil {
+		o.Words = StringArray{}
+	}
+	for i, word := range o.Words {
+		o.Words[i] = strings.TrimSpace(word)
+	}
+
+	if o.Patterns == nil {
+		o.Patterns = StringArray{}
+	}
+}
+
+// Regexp compiles the words and patterns of the filter into a single expression.
+func (o *ContentFilter) Regexp() (*regexp.Regexp, error) {
+	return compileWordsAndPatterns(o.Words, o.Patterns)
+}
+
+// compileWordsAndPatterns compiles words and regular expressions into a single expression matching any of them.
+// Words match case-insensitively and only as whole words, while patterns are used as given.
+func compileWordsAndPatterns(words, patterns StringArray) (*regexp.Regexp, error) {
+	var alternatives []string
+
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+
+		alternative := regexp.QuoteMeta(word)
+		if isWordByte(word[0]) {
+			alternative = `\b` + alternative
+		}
+		if isWordByte(word[len(word)-1]) {
+			alternative += `\b`
+		}
+		alternatives = append(alternatives, "(?i:"+alternative+")")
+	}
+
+	for _, pattern := range patterns {
+		alternatives = append(alternatives, "(?:"+pattern+")")
+	}
+
+	return regexp.Compile(strings.Join(alternatives, "|"))
+}
+
+// isWordByte reports whether the byte is matched by \w, so a word starting or ending with it is bounded by \b.
+func isWordByte(b byte) bool {
+	return b == '_' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+func (o *ContentFilter) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ContentFilterFromJson(data io.Reader) *ContentFilter {
+	var o *ContentFilter
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func ContentFilterListToJson(l []*ContentFilter) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ContentFilterListFromJson(data io.Reader) []*ContentFilter {
+	var o []*ContentFilter
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
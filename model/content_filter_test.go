@@ -0,0 +1,70 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentFilterJson(t *testing.T) {
+	filter := ContentFilter{Id: NewId(), Name: "profanity", Action: CONTENT_FILTER_ACTION_MASK, Words: StringArray{"darn"}, Patterns: StringArray{`\d{3}-\d{4}`}}
+	result := ContentFilterFromJson(strings.NewReader(filter.ToJson()))
+	assert.Equal(t, filter, *result)
+
+	list := ContentFilterListFromJson(strings.NewReader(ContentFilterListToJson([]*ContentFilter{&filter})))
+	require.Len(t, list, 1)
+	assert.Equal(t, filter, *list[0])
+}
+
+func TestContentFilterIsValid(t *testing.T) {
+	filter := ContentFilter{Name: " profanity ", Action: CONTENT_FILTER_ACTION_BLOCK, Words: StringArray{" darn "}}
+	filter.PreSave()
+	assert.Equal(t, "profanity", filter.Name)
+	assert.Equal(t, StringArray{"darn"}, filter.Words)
+	assert.Equal(t, StringArray{}, filter.Patterns)
+	require.Nil(t, filter.IsValid())
+
+	for name, test := range map[string]struct {
+		Update func(filter *ContentFilter)
+		ErrId  string
+	}{
+		"team id":        {func(o *ContentFilter) { o.TeamId = "abc" }, "model.content_filter.is_valid.team_id.app_error"},
+		"no name":        {func(o *ContentFilter) { o.Name = "" }, "model.content_filter.is_valid.name.app_error"},
+		"action":         {func(o *ContentFilter) { o.Action = "delete" }, "model.content_filter.is_valid.action.app_error"},
+		"empty":          {func(o *ContentFilter) { o.Words = StringArray{} }, "model.content_filter.is_valid.empty.app_error"},
+		"blank word":     {func(o *ContentFilter) { o.Words = StringArray{" "} }, "model.content_filter.is_valid.words.app_error"},
+		"word length":    {func(o *ContentFilter) { o.Words = StringArray{strings.Repeat("a", CONTENT_FILTER_WORD_MAX_LENGTH+1)} }, "model.content_filter.is_valid.words.app_error"},
+		"blank pattern":  {func(o *ContentFilter) { o.Patterns = StringArray{""} }, "model.content_filter.is_valid.patterns.app_error"},
+		"pattern syntax": {func(o *ContentFilter) { o.Patterns = StringArray{"(unclosed"} }, "model.content_filter.is_valid.pattern_syntax.app_error"},
+		"lookahead":      {func(o *ContentFilter) { o.Patterns = StringArray{"a(?=b)"} }, "model.content_filter.is_valid.pattern_syntax.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := filter
+			test.Update(&invalid)
+
+			err := invalid.IsValid()
+			require.NotNil(t, err)
+			assert.Equal(t, test.ErrId, err.Id)
+		})
+	}
+}
+
+func TestContentFilterRegexp(t *testing.T) {
+	filter := ContentFilter{Words: StringArray{"darn", "c++"}, Patterns: StringArray{`\b\d{3}-\d{4}\b`}}
+
+	re, err := filter.Regexp()
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"Darn", "C++", "555-1234"}, re.FindAllString("Darn, C++ call 555-1234", -1))
+	assert.Empty(t, re.FindAllString("darned cxx 5551234", -1))
+
+	filter.Words = StringArray{}
+	re, err = filter.Regexp()
+	require.Nil(t, err)
+	assert.Empty(t, re.FindAllString("darn", -1))
+}
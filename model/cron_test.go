@@ -0,0 +1,51 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, spec := range []string{"* * * * *", "*/15 * * * *", "0 2 * * 1-5", "5,35 0-23/2 1 1,7 *", "0 0 * * 7", "@daily", "@HOURLY", "10/20 * * * *"} {
+		_, err := ParseCronSchedule(spec)
+		assert.Nil(t, err, spec)
+	}
+
+	for _, spec := range []string{"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "a * * * *", "5-1 * * * *", "@sometimes"} {
+		_, err := ParseCronSchedule(spec)
+		assert.NotNil(t, err, spec)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2019, time.October, 16, 10, 7, 30, 0, time.UTC)
+
+	for _, tc := range []struct {
+		Spec     string
+		Expected time.Time
+	}{
+		{"* * * * *", time.Date(2019, time.October, 16, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2019, time.October, 16, 10, 15, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2019, time.October, 17, 2, 0, 0, 0, time.UTC)},
+		{"30 9 * * 1-5", time.Date(2019, time.October, 17, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2019, time.October, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2019, time.October, 20, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2019, time.November, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2020, time.February, 29, 12, 0, 0, 0, time.UTC)},
+		// Either restricted day matches.
+		{"0 0 1 * 5", time.Date(2019, time.October, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	} {
+		schedule, err := ParseCronSchedule(tc.Spec)
+		require.Nil(t, err, tc.Spec)
+		assert.Equal(t, tc.Expected, schedule.Next(now), tc.Spec)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	DAILY_STAT_DATE_FORMAT = "2006-01-02"
+
+	DAILY_STATS_MAX_ROLLUP_DAYS = 31
+	DAILY_STATS_MAX_RANGE_DAYS  = 366
+
+	DAILY_STATS_JOB_DATA_FIRST_DATE = "first_date"
+	DAILY_STATS_JOB_DATA_LAST_DATE  = "last_date"
+	DAILY_STATS_JOB_DATA_ROWS       = "rows"
+)
+
+// DailyStat is the activity of a single UTC day, rolled up once the day is over. Stats of a channel have both TeamId
+// and ChannelId set, stats of a team only TeamId, and the system wide stats neither. Direct and group messages are only
+// counted in the system wide stats.
+//
+// ActiveUserCount is the number of distinct users who posted, and FileCount and FileSize describe the files attached
+// to posts that were uploaded during the day.
+type DailyStat struct {
+	Date            string `json:"date"`
+	TeamId          string `json:"team_id"`
+	ChannelId       string `json:"channel_id"`
+	PostCount       int64  `json:"post_count"`
+	ActiveUserCount int64  `json:"active_user_count"`
+	FileCount       int64  `json:"file_count"`
+	FileSize        int64  `json:"file_size"`
+	UpdateAt        int64  `json:"update_at"`
+}
+
+func (o *DailyStat) IsValid() *AppError {
+	if !IsValidDailyStatDate(o.Date) {
+		return NewAppError("DailyStat.IsValid", "model.daily_stat.is_valid.date.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	if o.TeamId != "" && !IsValidId(o.TeamId) {
+		return NewAppError("DailyStat.IsValid", "model.daily_stat.is_valid.team_id.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	if o.ChannelId != "" && (!IsValidId(o.ChannelId) || o.TeamId == "") {
+		return NewAppError("DailyStat.IsValid", "model.daily_stat.is_valid.channel_id.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	if o.PostCount < 0 || o.ActiveUserCount < 0 || o.FileCount < 0 || o.FileSize < 0 {
+		return NewAppError("DailyStat.IsValid", "model.daily_stat.is_valid.counts.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("DailyStat.IsValid", "model.daily_stat.is_valid.update_at.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *DailyStat) PreSave() {
+	o.UpdateAt = GetMillis()
+}
+
+// Add adds the counts of another stat to this one. Active users can't be added up since the same users may be
+// counted in both, so they're left untouched.
+func (o *DailyStat) Add(other *DailyStat) {
+	o.PostCount += other.PostCount
+	o.FileCount += other.FileCount
+	o.FileSize += other.FileSize
+}
+
+func (o *DailyStat) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func DailyStatListToJson(l []*DailyStat) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func DailyStatListFromJson(data io.Reader) []*DailyStat {
+	var o []*DailyStat
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// IsValidDailyStatDate returns true if the given string is a date in the YYYY-MM-DD format.
+func IsValidDailyStatDate(date string) bool {
+	_, err := time.Parse(DAILY_STAT_DATE_FORMAT, date)
+	return err == nil
+}
+
+// GetDailyStatDateRange returns the range of times covered by the given date.
+func GetDailyStatDateRange(date string) (int64, int64, error) {
+	day, err := time.Parse(DAILY_STAT_DATE_FORMAT, date)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return GetMillisForTime(day), GetMillisForTime(day.AddDate(0, 0, 1)), nil
+}
+
+// GetDailyStatsRollupDates returns the dates a rollup running at the given time should compute, oldest first,
+// continuing from the last date computed by the previous rollup when there was one. Only days that are over are
+// rolled up, and a rollup never goes back more than DAILY_STATS_MAX_ROLLUP_DAYS.
+func GetDailyStatsRollupDates(lastDate string, now time.Time) []string {
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	first := today.AddDate(0, 0, -DAILY_STATS_MAX_ROLLUP_DAYS)
+	if last, err := time.Parse(DAILY_STAT_DATE_FORMAT, lastDate); err == nil && last.AddDate(0, 0, 1).After(first) {
+		first = last.AddDate(0, 0, 1)
+	}
+
+	dates := []string{}
+	for day := first; day.Before(today); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day.Format(DAILY_STAT_DATE_FORMAT))
+	}
+
+	return dates
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyStatJson(t *testing.T) {
+	stat := DailyStat{Date: "2019-10-01", TeamId: NewId(), ChannelId: NewId(), PostCount: 12, ActiveUserCount: 3, FileCount: 2, FileSize: 2048, UpdateAt: 1}
+	list := DailyStatListFromJson(strings.NewReader(DailyStatListToJson([]*DailyStat{&stat})))
+	require.Len(t, list, 1)
+	assert.Equal(t, stat, *list[0])
+}
+
+func TestDailyStatIsValid(t *testing.T) {
+	stat := DailyStat{Date: "2019-10-01", TeamId: NewId(), ChannelId: NewId(), PostCount: 12, ActiveUserCount: 3}
+	stat.PreSave()
+	require.Nil(t, stat.IsValid())
+
+	system := DailyStat{Date: "2019-10-01", UpdateAt: 1}
+	require.Nil(t, system.IsValid())
+
+	for name, update := range map[string]func(s *DailyStat){
+		"date":                 func(s *DailyStat) { s.Date = "2019-13-01" },
+		"team id":              func(s *DailyStat) { s.TeamId = "abc" },
+		"channel id":           func(s *DailyStat) { s.ChannelId = "abc" },
+		"channel without team": func(s *DailyStat) { s.TeamId = "" },
+		"negative count":       func(s *DailyStat) { s.FileSize = -1 },
+		"update at":            func(s *DailyStat) { s.UpdateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := stat
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestDailyStatAdd(t *testing.T) {
+	stat := DailyStat{PostCount: 1, ActiveUserCount: 1, FileCount: 1, FileSize: 10}
+	stat.Add(&DailyStat{PostCount: 2, ActiveUserCount: 2, FileCount: 3, FileSize: 20})
+	assert.Equal(t, DailyStat{PostCount: 3, ActiveUserCount: 1, FileCount: 4, FileSize: 30}, stat)
+}
+
+func TestGetDailyStatDateRange(t *testing.T) {
+	since, until, err := GetDailyStatDateRange("2019-10-01")
+	require.Nil(t, err)
+	assert.Equal(t, GetMillisForTime(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)), since)
+	assert.Equal(t, since+24*60*60*1000, until)
+
+	_, _, err = GetDailyStatDateRange("yesterday")
+	assert.NotNil(t, err)
+}
+
+func TestGetDailyStatsRollupDates(t *testing.T) {
+	now := time.Date(2019, 10, 15, 0, 5, 0, 0, time.UTC)
+
+	dates := GetDailyStatsRollupDates("", now)
+	require.Len(t, dates, DAILY_STATS_MAX_ROLLUP_DAYS)
+	assert.Equal(t, "2019-09-14", dates[0])
+	assert.Equal(t, "2019-10-14", dates[len(dates)-1])
+
+	assert.Equal(t, []string{"2019-10-13", "2019-10-14"}, GetDailyStatsRollupDates("2019-10-12", now))
+	assert.Len(t, GetDailyStatsRollupDates("2018-01-01", now), DAILY_STATS_MAX_ROLLUP_DAYS)
+	assert.Empty(t, GetDailyStatsRollupDates("2019-10-14", now))
+	assert.Empty(t, GetDailyStatsRollupDates("2019-10-20", now))
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	DIRECT_MESSAGE_BROADCAST_STATUS_IN_PROGRESS = "in_progress"
+	DIRECT_MESSAGE_BROADCAST_STATUS_DONE        = "done"
+
+	DIRECT_MESSAGE_BROADCAST_MAX_USERS = 10000
+)
+
+// DirectMessageBroadcastRequest asks for a post to be sent by a bot as a direct message to each of the given users, or
+// to each member of a team when TeamId is set.
+type DirectMessageBroadcastRequest struct {
+	UserIds []string `json:"user_ids"`
+	TeamId  string   `json:"team_id"`
+	Post    *Post    `json:"post"`
+}
+
+// DirectMessageBroadcast tracks the progress of a broadcast of direct messages sent by a bot. Sent and Failed count the
+// users who got the message and those it couldn't be sent to, until they add up to Total and the broadcast is done.
+type DirectMessageBroadcast struct {
+	Id            string   `json:"id"`
+	BotUserId     string   `json:"bot_user_id"`
+	Status        string   `json:"status"`
+	Total         int      `json:"total"`
+	Sent          int      `json:"sent"`
+	Failed        int      `json:"failed"`
+	FailedUserIds []string `json:"failed_user_ids"`
+	CreateAt      int64    `json:"create_at"`
+	FinishAt      int64    `json:"finish_at"`
+}
+
+func (o *DirectMessageBroadcastRequest) IsValid() *AppError {
+	if len(o.UserIds) == 0 && o.TeamId == "" {
+		return NewAppError("DirectMessageBroadcastRequest.IsValid", "model.direct_message_broadcast.is_valid.recipients.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.UserIds) != 0 && o.TeamId != "" {
+		return NewAppError("DirectMessageBroadcastRequest.IsValid", "model.direct_message_broadcast.is_valid.recipients.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.UserIds) > DIRECT_MESSAGE_BROADCAST_MAX_USERS {
+		return NewAppError("DirectMessageBroadcastRequest.IsValid", "model.direct_message_broadcast.is_valid.max_users.app_error", map[string]interface{}{"Max": DIRECT_MESSAGE_BROADCAST_MAX_USERS}, "", http.StatusBadRequest)
+	}
+
+	for _, userId := range o.UserIds {
+		if !IsValidId(userId) {
+			return NewAppError("DirectMessageBroadcastRequest.IsValid", "model.direct_message_broadcast.is_valid.user_id.app_error", nil, "user_id="+userId, http.StatusBadRequest)
+		}
+	}
+
+	if o.TeamId != "" && !IsValidId(o.TeamId) {
+		return NewAppError("DirectMessageBroadcastRequest.IsValid", "model.direct_message_broadcast.is_valid.team_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.Post == nil || (o.Post.Message == "" && len(o.Post.Attachments()) == 0) {
+		return NewAppError("DirectMessageBroadcastRequest.IsValid", "model.direct_message_broadcast.is_valid.post.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *DirectMessageBroadcast) IsDone() bool {
+	return o.Status == DIRECT_MESSAGE_BROADCAST_STATUS_DONE
+}
+
+func (o *DirectMessageBroadcastRequest) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func DirectMessageBroadcastRequestFromJson(data io.Reader) *DirectMessageBroadcastRequest {
+	var o *DirectMessageBroadcastRequest
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *DirectMessageBroadcast) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func DirectMessageBroadcastFromJson(data io.Reader) *DirectMessageBroadcast {
+	var o *DirectMessageBroadcast
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
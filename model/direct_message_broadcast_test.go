@@ -0,0 +1,56 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectMessageBroadcastRequestIsValid(t *testing.T) {
+	post := &Post{Message: "announcement"}
+
+	for name, tc := range map[string]struct {
+		Request *DirectMessageBroadcastRequest
+		ErrorId string
+	}{
+		"users":                 {&DirectMessageBroadcastRequest{UserIds: []string{NewId(), NewId()}, Post: post}, ""},
+		"team":                  {&DirectMessageBroadcastRequest{TeamId: NewId(), Post: post}, ""},
+		"no recipients":         {&DirectMessageBroadcastRequest{Post: post}, "model.direct_message_broadcast.is_valid.recipients.app_error"},
+		"users and team":        {&DirectMessageBroadcastRequest{UserIds: []string{NewId()}, TeamId: NewId(), Post: post}, "model.direct_message_broadcast.is_valid.recipients.app_error"},
+		"too many users":        {&DirectMessageBroadcastRequest{UserIds: make([]string, DIRECT_MESSAGE_BROADCAST_MAX_USERS+1), Post: post}, "model.direct_message_broadcast.is_valid.max_users.app_error"},
+		"invalid user id":       {&DirectMessageBroadcastRequest{UserIds: []string{"invalid"}, Post: post}, "model.direct_message_broadcast.is_valid.user_id.app_error"},
+		"invalid team id":       {&DirectMessageBroadcastRequest{TeamId: "invalid", Post: post}, "model.direct_message_broadcast.is_valid.team_id.app_error"},
+		"no post":               {&DirectMessageBroadcastRequest{UserIds: []string{NewId()}}, "model.direct_message_broadcast.is_valid.post.app_error"},
+		"post without anything": {&DirectMessageBroadcastRequest{UserIds: []string{NewId()}, Post: &Post{}}, "model.direct_message_broadcast.is_valid.post.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.Request.IsValid()
+			if tc.ErrorId == "" {
+				assert.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.ErrorId, err.Id)
+			}
+		})
+	}
+}
+
+func TestDirectMessageBroadcastJson(t *testing.T) {
+	broadcast := &DirectMessageBroadcast{
+		Id:            NewId(),
+		BotUserId:     NewId(),
+		Status:        DIRECT_MESSAGE_BROADCAST_STATUS_DONE,
+		Total:         2,
+		Sent:          1,
+		Failed:        1,
+		FailedUserIds: []string{NewId()},
+	}
+
+	assert.Equal(t, broadcast, DirectMessageBroadcastFromJson(strings.NewReader(broadcast.ToJson())))
+	assert.True(t, broadcast.IsDone())
+}
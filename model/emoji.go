@@ -24,6 +24,11 @@ type Emoji struct {
 	DeleteAt  int64  `json:"delete_at"`
 	CreatorId string `json:"creator_id"`
 	Name      string `json:"name"`
+	Animated  bool   `json:"animated"`
+
+	// PendingApproval is set on an emoji that was created as a request, which only becomes visible once a system admin
+	// approves it.
+	PendingApproval bool `json:"pending_approval,omitempty" db:"-"`
 }
 
 func inSystemEmoji(emojiName string) bool {
@@ -94,3 +99,24 @@ func EmojiListFromJson(data io.Reader) []*Emoji {
 	json.NewDecoder(data).Decode(&emojiList)
 	return emojiList
 }
+
+// EmojiUsage is how much a custom emoji has been used in reactions, to find emoji that could be cleaned up.
+type EmojiUsage struct {
+	EmojiId       string `json:"emoji_id"`
+	Name          string `json:"name"`
+	CreatorId     string `json:"creator_id"`
+	CreateAt      int64  `json:"create_at"`
+	ReactionCount int64  `json:"reaction_count"`
+	LastUsedAt    int64  `json:"last_used_at"`
+}
+
+func EmojiUsageListToJson(l []*EmojiUsage) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func EmojiUsageListFromJson(data io.Reader) []*EmojiUsage {
+	var o []*EmojiUsage
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// EmojiAlias is another name for an existing system or custom emoji. Clients render :alias: as the emoji it stands for,
+// and the server resolves aliases when looking up the custom emoji used in a post.
+type EmojiAlias struct {
+	Alias     string `json:"alias"`
+	EmojiName string `json:"emoji_name"`
+	CreatorId string `json:"creator_id"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+func (o *EmojiAlias) IsValid() *AppError {
+	if err := IsValidEmojiName(o.Alias); err != nil {
+		return NewAppError("EmojiAlias.IsValid", "model.emoji_alias.is_valid.alias.app_error", nil, "alias="+o.Alias, http.StatusBadRequest)
+	}
+
+	if len(o.EmojiName) == 0 || len(o.EmojiName) > EMOJI_NAME_MAX_LENGTH || o.EmojiName == o.Alias {
+		return NewAppError("EmojiAlias.IsValid", "model.emoji_alias.is_valid.emoji_name.app_error", nil, "alias="+o.Alias, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("EmojiAlias.IsValid", "model.emoji_alias.is_valid.creator_id.app_error", nil, "alias="+o.Alias, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("EmojiAlias.IsValid", "model.emoji_alias.is_valid.create_at.app_error", nil, "alias="+o.Alias, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *EmojiAlias) PreSave() {
+	o.CreateAt = GetMillis()
+}
+
+func (o *EmojiAlias) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func EmojiAliasFromJson(data io.Reader) *EmojiAlias {
+	var o *EmojiAlias
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func EmojiAliasListToJson(l []*EmojiAlias) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func EmojiAliasListFromJson(data io.Reader) []*EmojiAlias {
+	var o []*EmojiAlias
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// EmojiAliasesToMap maps each alias to the name of the emoji it stands for.
+func EmojiAliasesToMap(aliases []*EmojiAlias) map[string]string {
+	m := make(map[string]string, len(aliases))
+	for _, alias := range aliases {
+		m[alias.Alias] = alias.EmojiName
+	}
+	return m
+}
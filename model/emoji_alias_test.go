@@ -0,0 +1,63 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmojiAliasIsValid(t *testing.T) {
+	alias := EmojiAlias{
+		Alias:     "thumbsup_alias",
+		EmojiName: "+1",
+		CreatorId: NewId(),
+		CreateAt:  1234,
+	}
+	require.Nil(t, alias.IsValid())
+
+	alias.Alias = "smile"
+	require.NotNil(t, alias.IsValid(), "shouldn't shadow a system emoji")
+
+	alias.Alias = "name:"
+	require.NotNil(t, alias.IsValid())
+
+	alias.Alias = "thumbsup_alias"
+	alias.EmojiName = ""
+	require.NotNil(t, alias.IsValid())
+
+	alias.EmojiName = strings.Repeat("a", EMOJI_NAME_MAX_LENGTH+1)
+	require.NotNil(t, alias.IsValid())
+
+	alias.EmojiName = alias.Alias
+	require.NotNil(t, alias.IsValid(), "shouldn't stand for itself")
+
+	alias.EmojiName = "+1"
+	alias.CreatorId = "1234"
+	require.NotNil(t, alias.IsValid())
+
+	alias.CreatorId = NewId()
+	alias.CreateAt = 0
+	require.NotNil(t, alias.IsValid())
+}
+
+func TestEmojiAliasesToMap(t *testing.T) {
+	aliases := []*EmojiAlias{
+		{Alias: "yes", EmojiName: "+1"},
+		{Alias: "party", EmojiName: "custom_party"},
+	}
+
+	assert.Equal(t, map[string]string{"yes": "+1", "party": "custom_party"}, EmojiAliasesToMap(aliases))
+	assert.Empty(t, EmojiAliasesToMap(nil))
+}
+
+func TestEmojiAliasJson(t *testing.T) {
+	alias := &EmojiAlias{Alias: "yes", EmojiName: "+1", CreatorId: NewId(), CreateAt: 1234}
+
+	assert.Equal(t, alias, EmojiAliasFromJson(strings.NewReader(alias.ToJson())))
+	assert.Equal(t, []*EmojiAlias{alias}, EmojiAliasListFromJson(strings.NewReader(EmojiAliasListToJson([]*EmojiAlias{alias}))))
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	EMOJI_PACK_MANIFEST_NAME = "emoji.json"
+)
+
+// EmojiPack is the manifest of an emoji pack, a zip file with the images of custom emoji. Packs without a manifest
+// name each emoji after the file of its image.
+type EmojiPack struct {
+	Emojis []*EmojiPackEntry `json:"emojis"`
+}
+
+type EmojiPackEntry struct {
+	Name     string `json:"name"`
+	Filename string `json:"filename"`
+}
+
+func (o *EmojiPack) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func EmojiPackFromJson(data io.Reader) *EmojiPack {
+	var o *EmojiPack
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// EmojiPackImportResult is what came of importing each emoji of a pack. Errors has the reason each emoji that wasn't
+// imported was skipped for, by its name.
+type EmojiPackImportResult struct {
+	Imported []*Emoji          `json:"imported"`
+	Errors   map[string]string `json:"errors"`
+}
+
+func (o *EmojiPackImportResult) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func EmojiPackImportResultFromJson(data io.Reader) *EmojiPackImportResult {
+	var o *EmojiPackImportResult
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
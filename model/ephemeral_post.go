@@ -0,0 +1,81 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	EPHEMERAL_POST_RETENTION = 7 * 24 * 60 * 60 * 1000
+)
+
+// EphemeralPost is an ephemeral post kept for the user it was sent to, for users who choose to persist their ephemeral
+// posts so that they survive reloading the client. It's stored apart from the posts of channels, which never include
+// it, and expires after EPHEMERAL_POST_RETENTION. Its Id is the id of the post.
+type EphemeralPost struct {
+	Id        string `json:"id"`
+	UserId    string `json:"user_id"`
+	ChannelId string `json:"channel_id"`
+	CreateAt  int64  `json:"create_at"`
+	UpdateAt  int64  `json:"update_at"`
+	ExpireAt  int64  `json:"expire_at"`
+	PostJson  string `json:"-"`
+}
+
+func NewEphemeralPost(userId string, post *Post) *EphemeralPost {
+	return &EphemeralPost{
+		Id:        post.Id,
+		UserId:    userId,
+		ChannelId: post.ChannelId,
+		CreateAt:  post.CreateAt,
+		PostJson:  post.ToUnsanitizedJson(),
+	}
+}
+
+// ToPost returns the ephemeral post as it was sent, or nil if it can't be read.
+func (o *EphemeralPost) ToPost() *Post {
+	var post *Post
+	if err := json.Unmarshal([]byte(o.PostJson), &post); err != nil {
+		return nil
+	}
+	return post
+}
+
+func (o *EphemeralPost) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+	o.PreUpdate()
+}
+
+func (o *EphemeralPost) PreUpdate() {
+	o.UpdateAt = GetMillis()
+	o.ExpireAt = o.UpdateAt + EPHEMERAL_POST_RETENTION
+}
+
+func (o *EphemeralPost) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("EphemeralPost.IsValid", "model.ephemeral_post.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("EphemeralPost.IsValid", "model.ephemeral_post.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("EphemeralPost.IsValid", "model.ephemeral_post.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 || o.UpdateAt == 0 || o.ExpireAt == 0 {
+		return NewAppError("EphemeralPost.IsValid", "model.ephemeral_post.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.PostJson) == 0 {
+		return NewAppError("EphemeralPost.IsValid", "model.ephemeral_post.is_valid.post.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
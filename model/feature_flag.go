@@ -0,0 +1,91 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"hash/crc32"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var validFeatureFlagName = regexp.MustCompile(`^[A-Za-z0-9_\-.]{1,64}$`)
+
+// FeatureFlag gradually enables an experimental feature. A feature is enabled for a user when the flag is enabled for
+// everyone, when the user or one of their teams is listed, or when the user falls within the rollout percentage.
+type FeatureFlag struct {
+	Enabled           bool
+	UserIds           []string
+	TeamIds           []string
+	RolloutPercentage int
+}
+
+func (f *FeatureFlag) IsValid(name string) *AppError {
+	if !validFeatureFlagName.MatchString(name) {
+		return NewAppError("FeatureFlag.IsValid", "model.feature_flag.is_valid.name.app_error", nil, "name="+name, http.StatusBadRequest)
+	}
+
+	for _, userId := range f.UserIds {
+		if !IsValidId(userId) {
+			return NewAppError("FeatureFlag.IsValid", "model.feature_flag.is_valid.user_id.app_error", map[string]interface{}{"Name": name}, "user_id="+userId, http.StatusBadRequest)
+		}
+	}
+
+	for _, teamId := range f.TeamIds {
+		if !IsValidId(teamId) {
+			return NewAppError("FeatureFlag.IsValid", "model.feature_flag.is_valid.team_id.app_error", map[string]interface{}{"Name": name}, "team_id="+teamId, http.StatusBadRequest)
+		}
+	}
+
+	if f.RolloutPercentage < 0 || f.RolloutPercentage > 100 {
+		return NewAppError("FeatureFlag.IsValid", "model.feature_flag.is_valid.rollout_percentage.app_error", map[string]interface{}{"Name": name}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsEnabledFor evaluates the flag named name for a user belonging to the given teams. A user keeps falling within the
+// rollout percentage as it grows, and different flags roll out to different users.
+func (f *FeatureFlag) IsEnabledFor(name, userId string, teamIds []string) bool {
+	if f.Enabled {
+		return true
+	}
+
+	if userId == "" {
+		return false
+	}
+
+	for _, id := range f.UserIds {
+		if id == userId {
+			return true
+		}
+	}
+
+	for _, id := range f.TeamIds {
+		for _, teamId := range teamIds {
+			if id == teamId {
+				return true
+			}
+		}
+	}
+
+	if f.RolloutPercentage > 0 {
+		return int(crc32.ChecksumIEEE([]byte(name+":"+userId))%100) < f.RolloutPercentage
+	}
+
+	return false
+}
+
+// FeatureFlagsEtag summarizes the features enabled for a user, to be part of the etag of a response including them.
+func FeatureFlagsEtag(flags map[string]bool) string {
+	enabled := []string{}
+	for name, isEnabled := range flags {
+		if isEnabled {
+			enabled = append(enabled, name)
+		}
+	}
+	sort.Strings(enabled)
+	return strings.Join(enabled, ",")
+}
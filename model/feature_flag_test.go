@@ -0,0 +1,62 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureFlagIsValid(t *testing.T) {
+	flag := &FeatureFlag{UserIds: []string{NewId()}, TeamIds: []string{NewId()}, RolloutPercentage: 50}
+	assert.Nil(t, flag.IsValid("NewSearch"))
+
+	assert.NotNil(t, flag.IsValid(""))
+	assert.NotNil(t, flag.IsValid("new search"))
+
+	flag.RolloutPercentage = 101
+	assert.NotNil(t, flag.IsValid("NewSearch"))
+
+	flag.RolloutPercentage = 0
+	flag.UserIds = []string{"junk"}
+	assert.NotNil(t, flag.IsValid("NewSearch"))
+}
+
+func TestFeatureFlagIsEnabledFor(t *testing.T) {
+	userId := NewId()
+	teamId := NewId()
+
+	assert.False(t, (&FeatureFlag{}).IsEnabledFor("NewSearch", userId, []string{teamId}))
+	assert.True(t, (&FeatureFlag{Enabled: true}).IsEnabledFor("NewSearch", "", nil))
+	assert.True(t, (&FeatureFlag{UserIds: []string{userId}}).IsEnabledFor("NewSearch", userId, nil))
+	assert.False(t, (&FeatureFlag{UserIds: []string{userId}}).IsEnabledFor("NewSearch", NewId(), nil))
+	assert.True(t, (&FeatureFlag{TeamIds: []string{teamId}}).IsEnabledFor("NewSearch", userId, []string{NewId(), teamId}))
+	assert.False(t, (&FeatureFlag{RolloutPercentage: 100}).IsEnabledFor("NewSearch", "", nil))
+
+	t.Run("rollout percentage", func(t *testing.T) {
+		enabled := 0
+		for i := 0; i < 1000; i++ {
+			if (&FeatureFlag{RolloutPercentage: 30}).IsEnabledFor("NewSearch", NewId(), nil) {
+				enabled++
+			}
+		}
+		assert.InDelta(t, 300, enabled, 100)
+
+		// Growing the rollout keeps the users it was enabled for.
+		for i := 0; i < 100; i++ {
+			id := NewId()
+			if (&FeatureFlag{RolloutPercentage: 10}).IsEnabledFor("NewSearch", id, nil) {
+				assert.True(t, (&FeatureFlag{RolloutPercentage: 20}).IsEnabledFor("NewSearch", id, nil))
+			}
+		}
+
+		assert.True(t, (&FeatureFlag{RolloutPercentage: 100}).IsEnabledFor("NewSearch", userId, nil))
+	})
+}
+
+func TestFeatureFlagsEtag(t *testing.T) {
+	assert.Equal(t, "a,b", FeatureFlagsEtag(map[string]bool{"b": true, "c": false, "a": true}))
+	assert.Equal(t, "", FeatureFlagsEtag(nil))
+}
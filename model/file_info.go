@@ -13,6 +13,15 @@ import (
 	"net/http"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
+)
+
+const (
+	FILE_VERDICT_CLEAN   = "clean"
+	FILE_VERDICT_FLAGGED = "flagged"
+	FILE_VERDICT_BLOCKED = "blocked"
+
+	FILE_VERDICT_REASON_MAX_RUNES = 256
 )
 
 type FileInfo struct {
@@ -32,6 +41,9 @@ type FileInfo struct {
 	Width           int    `json:"width,omitempty"`
 	Height          int    `json:"height,omitempty"`
 	HasPreviewImage bool   `json:"has_preview_image,omitempty"`
+	Verdict         string `json:"verdict,omitempty"`
+	VerdictReason   string `json:"verdict_reason,omitempty"`
+	VerdictAt       int64  `json:"verdict_at,omitempty"`
 }
 
 func (info *FileInfo) ToJson() string {
@@ -105,9 +117,32 @@ func (o *FileInfo) IsValid() *AppError {
 		return NewAppError("FileInfo.IsValid", "model.file_info.is_valid.path.app_error", nil, "id="+o.Id, http.StatusBadRequest)
 	}
 
+	if o.Verdict != "" && !IsValidFileVerdict(o.Verdict) {
+		return NewAppError("FileInfo.IsValid", "model.file_info.is_valid.verdict.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.VerdictReason) > FILE_VERDICT_REASON_MAX_RUNES {
+		return NewAppError("FileInfo.IsValid", "model.file_info.is_valid.verdict_reason.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
 	return nil
 }
 
+// IsValidFileVerdict returns whether the verdict is one a scanning or DLP pipeline can attach to a file. A flagged
+// file is still served but should be marked by the clients, while a blocked file is no longer served at all.
+func IsValidFileVerdict(verdict string) bool {
+	switch verdict {
+	case FILE_VERDICT_CLEAN, FILE_VERDICT_FLAGGED, FILE_VERDICT_BLOCKED:
+		return true
+	}
+
+	return false
+}
+
+func (o *FileInfo) IsBlocked() bool {
+	return o.Verdict == FILE_VERDICT_BLOCKED
+}
+
 func (o *FileInfo) IsImage() bool {
 	return strings.HasPrefix(o.MimeType, "image")
 }
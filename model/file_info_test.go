@@ -58,6 +58,39 @@ func TestFileInfoIsValid(t *testing.T) {
 	if err := info.IsValid(); err != nil {
 		t.Fatal(err)
 	}
+
+	info.Verdict = "junk"
+	if err := info.IsValid(); err == nil {
+		t.Fatal("unknown Verdict isn't valid")
+	}
+
+	info.Verdict = FILE_VERDICT_BLOCKED
+	info.VerdictReason = strings.Repeat("a", FILE_VERDICT_REASON_MAX_RUNES+1)
+	if err := info.IsValid(); err == nil {
+		t.Fatal("too long VerdictReason isn't valid")
+	}
+
+	info.VerdictReason = "EICAR test signature"
+	if err := info.IsValid(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileInfoIsBlocked(t *testing.T) {
+	info := &FileInfo{}
+	if info.IsBlocked() {
+		t.Fatal("file without a verdict isn't blocked")
+	}
+
+	info.Verdict = FILE_VERDICT_FLAGGED
+	if info.IsBlocked() {
+		t.Fatal("flagged file isn't blocked")
+	}
+
+	info.Verdict = FILE_VERDICT_BLOCKED
+	if !info.IsBlocked() {
+		t.Fatal("file is blocked")
+	}
 }
 
 func TestFileInfoIsImage(t *testing.T) {
@@ -11,6 +11,7 @@ import (
 
 const (
 	GroupSourceLdap GroupSource = "ldap"
+	GroupSourceSaml GroupSource = "saml"
 
 	GroupNameMaxLength        = 64
 	GroupSourceMaxLength      = 64
@@ -23,10 +24,12 @@ type GroupSource string
 
 var allGroupSources = []GroupSource{
 	GroupSourceLdap,
+	GroupSourceSaml,
 }
 
 var groupSourcesRequiringRemoteID = []GroupSource{
 	GroupSourceLdap,
+	GroupSourceSaml,
 }
 
 type Group struct {
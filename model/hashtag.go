@@ -0,0 +1,102 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	HASHTAG_MAX_LENGTH = 64
+
+	HASHTAG_TRENDING_DEFAULT_PERIOD = 7 * 24 * 60 * 60 * 1000 // 7 days
+	HASHTAG_TRENDING_DEFAULT_LIMIT  = 10
+	HASHTAG_TRENDING_MAX_LIMIT      = 100
+)
+
+// PostHashtag indexes a post by one of the hashtags used in its message.
+type PostHashtag struct {
+	PostId    string `json:"post_id"`
+	Hashtag   string `json:"hashtag"`
+	ChannelId string `json:"channel_id"`
+	TeamId    string `json:"team_id"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+// HashtagCount is the number of posts using a hashtag.
+type HashtagCount struct {
+	Hashtag string `json:"hashtag"`
+	Count   int64  `json:"count"`
+}
+
+func (o *PostHashtag) IsValid() *AppError {
+	if len(o.PostId) != 26 {
+		return NewAppError("PostHashtag.IsValid", "model.post_hashtag.is_valid.post_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.ChannelId) != 26 {
+		return NewAppError("PostHashtag.IsValid", "model.post_hashtag.is_valid.channel_id.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if len(o.TeamId) != 0 && len(o.TeamId) != 26 {
+		return NewAppError("PostHashtag.IsValid", "model.post_hashtag.is_valid.team_id.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if len(o.Hashtag) < 2 || utf8.RuneCountInString(o.Hashtag) > HASHTAG_MAX_LENGTH {
+		return NewAppError("PostHashtag.IsValid", "model.post_hashtag.is_valid.hashtag.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// NormalizeHashtag returns the form hashtags are indexed under: lower case and starting with a single #.
+func NormalizeHashtag(hashtag string) string {
+	return "#" + strings.ToLower(strings.TrimLeft(strings.TrimSpace(hashtag), "#"))
+}
+
+// IsValidHashtag returns true if the hashtag would be parsed from a message and is short enough to be indexed.
+func IsValidHashtag(hashtag string) bool {
+	parsed, _ := ParseHashtags(hashtag)
+	return parsed == hashtag && utf8.RuneCountInString(hashtag) <= HASHTAG_MAX_LENGTH
+}
+
+// PostHashtagsForPost returns the index entries for the hashtags of the post, as parsed when it was saved.
+// Hashtags longer than HASHTAG_MAX_LENGTH are not indexed.
+func PostHashtagsForPost(post *Post, teamId string) []*PostHashtag {
+	hashtags := []*PostHashtag{}
+	seen := map[string]bool{}
+
+	for _, hashtag := range strings.Fields(post.Hashtags) {
+		hashtag = NormalizeHashtag(hashtag)
+		if seen[hashtag] || utf8.RuneCountInString(hashtag) > HASHTAG_MAX_LENGTH {
+			continue
+		}
+		seen[hashtag] = true
+
+		hashtags = append(hashtags, &PostHashtag{
+			PostId:    post.Id,
+			Hashtag:   hashtag,
+			ChannelId: post.ChannelId,
+			TeamId:    teamId,
+			CreateAt:  post.CreateAt,
+		})
+	}
+
+	return hashtags
+}
+
+func HashtagCountListToJson(l []*HashtagCount) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func HashtagCountListFromJson(data io.Reader) []*HashtagCount {
+	var o []*HashtagCount
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -6,13 +6,18 @@ package model
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"regexp"
+	"strings"
+	"text/template"
 )
 
 const (
 	DEFAULT_WEBHOOK_USERNAME = "webhook"
+
+	INCOMING_WEBHOOK_PAYLOAD_TEMPLATE_MAX_LENGTH = 4096
 )
 
 type IncomingWebhook struct {
@@ -28,6 +33,16 @@ type IncomingWebhook struct {
 	Username      string `json:"username"`
 	IconURL       string `json:"icon_url"`
 	ChannelLocked bool   `json:"channel_locked"`
+
+	// PayloadTemplate is an optional Go template applied to the JSON body posted to the webhook. It allows
+	// services that cannot produce the Slack compatible format to post directly to the webhook.
+	PayloadTemplate string `json:"payload_template"`
+
+	// RateLimitPerMinute and DailyQuota limit the number of posts made through the webhook, where zero means
+	// unlimited. They can only make the server wide limits from the service settings stricter, which apply
+	// when they're not set.
+	RateLimitPerMinute *int `json:"rate_limit_per_minute,omitempty"`
+	DailyQuota         *int `json:"daily_quota,omitempty"`
 }
 
 type IncomingWebhookRequest struct {
@@ -106,9 +121,45 @@ func (o *IncomingWebhook) IsValid() *AppError {
 		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if o.RateLimitPerMinute != nil && *o.RateLimitPerMinute < 0 {
+		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.rate_limit_per_minute.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.DailyQuota != nil && *o.DailyQuota < 0 {
+		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.daily_quota.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.PayloadTemplate) > INCOMING_WEBHOOK_PAYLOAD_TEMPLATE_MAX_LENGTH {
+		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.payload_template.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.PayloadTemplate) > 0 {
+		if _, err := o.ParsePayloadTemplate(); err != nil {
+			return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.payload_template.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
+// ParsePayloadTemplate parses the payload template of the webhook. Besides the builtin template functions,
+// "json" renders a value as JSON and "join" joins the elements of a list with a separator.
+func (o *IncomingWebhook) ParsePayloadTemplate() (*template.Template, error) {
+	return template.New("payload").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		"join": func(sep string, list []interface{}) string {
+			items := make([]string, 0, len(list))
+			for _, item := range list {
+				items = append(items, fmt.Sprint(item))
+			}
+			return strings.Join(items, sep)
+		},
+	}).Option("missingkey=zero").Parse(o.PayloadTemplate)
+}
+
 func (o *IncomingWebhook) PreSave() {
 	if o.Id == "" {
 		o.Id = NewId()
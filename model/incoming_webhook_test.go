@@ -109,6 +109,37 @@ func TestIncomingWebhookIsValid(t *testing.T) {
 	if err := o.IsValid(); err != nil {
 		t.Fatal(err)
 	}
+
+	o.PayloadTemplate = "{{ .status "
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.PayloadTemplate = strings.Repeat("1", INCOMING_WEBHOOK_PAYLOAD_TEMPLATE_MAX_LENGTH+1)
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.PayloadTemplate = "{{ .status }}: {{ join \", \" .labels }}"
+	if err := o.IsValid(); err != nil {
+		t.Fatal(err)
+	}
+
+	o.RateLimitPerMinute = NewInt(-1)
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.RateLimitPerMinute = NewInt(0)
+	o.DailyQuota = NewInt(-1)
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.DailyQuota = NewInt(1000)
+	if err := o.IsValid(); err != nil {
+		t.Fatal(err)
+	}
 }
 
 func TestIncomingWebhookPreSave(t *testing.T) {
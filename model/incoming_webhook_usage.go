@@ -0,0 +1,34 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// IncomingWebhookUsage reports how many posts an incoming webhook made recently and the limits that
+// apply to it. Usage is tracked in memory by each server and resets when it restarts.
+type IncomingWebhookUsage struct {
+	HookId             string `json:"hook_id"`
+	TeamId             string `json:"team_id"`
+	ChannelId          string `json:"channel_id"`
+	DisplayName        string `json:"display_name"`
+	PostsLastMinute    int    `json:"posts_last_minute"`
+	PostsToday         int    `json:"posts_today"`
+	RejectedToday      int    `json:"rejected_today"`
+	RateLimitPerMinute int    `json:"rate_limit_per_minute"`
+	DailyQuota         int    `json:"daily_quota"`
+}
+
+func IncomingWebhookUsageListToJson(l []*IncomingWebhookUsage) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func IncomingWebhookUsageListFromJson(data io.Reader) []*IncomingWebhookUsage {
+	var o []*IncomingWebhookUsage
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
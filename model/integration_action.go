@@ -26,6 +26,25 @@ const (
 	INTERACTIVE_DIALOG_TRIGGER_TIMEOUT_MILLISECONDS = 3000
 )
 
+const (
+	DIALOG_ELEMENT_TYPE_TEXT     = "text"
+	DIALOG_ELEMENT_TYPE_TEXTAREA = "textarea"
+	DIALOG_ELEMENT_TYPE_SELECT   = "select"
+	DIALOG_ELEMENT_TYPE_RADIO    = "radio"
+	DIALOG_ELEMENT_TYPE_BOOL     = "bool"
+
+	// The value of a file element is the id of a file, or a list of ids, that the user uploaded before submitting
+	DIALOG_ELEMENT_TYPE_FILE    = "file"
+	DIALOG_ELEMENT_TYPE_DATE    = "date"
+	DIALOG_ELEMENT_TYPE_USER    = "user"
+	DIALOG_ELEMENT_TYPE_CHANNEL = "channel"
+
+	DIALOG_DATA_SOURCE_USERS    = "users"
+	DIALOG_DATA_SOURCE_CHANNELS = "channels"
+
+	DIALOG_DATE_FORMAT = "2006-01-02"
+)
+
 var PostActionRetainPropKeys = []string{"from_webhook", "override_username", "override_icon_url"}
 
 type DoPostActionRequest struct {
@@ -207,6 +226,9 @@ type OpenDialogRequest struct {
 	TriggerId string `json:"trigger_id"`
 	URL       string `json:"url"`
 	Dialog    Dialog `json:"dialog"`
+
+	// DialogId identifies the dialog's URL, state and elements kept on the server, set when the dialog is opened.
+	DialogId string `json:"dialog_id,omitempty"`
 }
 
 type SubmitDialogRequest struct {
@@ -219,11 +241,20 @@ type SubmitDialogRequest struct {
 	TeamId     string                 `json:"team_id"`
 	Submission map[string]interface{} `json:"submission"`
 	Cancelled  bool                   `json:"cancelled"`
+
+	// DialogId is the id of the dialog being submitted, whose URL, callback id and state are then taken from the
+	// server rather than from the request. Step counts the dialogs submitted before this one in a multi-step flow.
+	DialogId string `json:"dialog_id,omitempty"`
+	Step     int    `json:"step"`
 }
 
 type SubmitDialogResponse struct {
 	Error  string            `json:"error,omitempty"`
 	Errors map[string]string `json:"errors,omitempty"`
+
+	// Next is the dialog of the next step, shown in place of the submitted one, with the same DialogId.
+	Next     *Dialog `json:"next,omitempty"`
+	DialogId string  `json:"dialog_id,omitempty"`
 }
 
 func GenerateTriggerId(userId string, s crypto.Signer) (string, string, *AppError) {
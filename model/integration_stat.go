@@ -0,0 +1,105 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	INTEGRATION_TYPE_INCOMING_WEBHOOK = "incoming_webhook"
+	INTEGRATION_TYPE_OUTGOING_WEBHOOK = "outgoing_webhook"
+	INTEGRATION_TYPE_COMMAND          = "command"
+	INTEGRATION_TYPE_BOT              = "bot"
+)
+
+// IntegrationStat is the activity of an incoming webhook, outgoing webhook, slash command or bot during a single UTC
+// day. Requests are the calls made to or by the integration: the posts to an incoming webhook, the callbacks of an
+// outgoing webhook or slash command and the API requests of a bot. Posts are the posts created as a result, and the
+// latency is the time taken to handle the requests. The stats of the current day keep growing until the day is over.
+type IntegrationStat struct {
+	Date            string `json:"date,omitempty"`
+	IntegrationType string `json:"integration_type"`
+	IntegrationId   string `json:"integration_id"`
+	Requests        int64  `json:"requests"`
+	Posts           int64  `json:"posts"`
+	Errors          int64  `json:"errors"`
+	TotalLatencyMs  int64  `json:"total_latency_ms"`
+	MaxLatencyMs    int64  `json:"max_latency_ms"`
+	UpdateAt        int64  `json:"update_at"`
+}
+
+func IsValidIntegrationType(integrationType string) bool {
+	switch integrationType {
+	case INTEGRATION_TYPE_INCOMING_WEBHOOK, INTEGRATION_TYPE_OUTGOING_WEBHOOK, INTEGRATION_TYPE_COMMAND, INTEGRATION_TYPE_BOT:
+		return true
+	}
+
+	return false
+}
+
+func (o *IntegrationStat) IsValid() *AppError {
+	if !IsValidDailyStatDate(o.Date) {
+		return NewAppError("IntegrationStat.IsValid", "model.integration_stat.is_valid.date.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	if !IsValidIntegrationType(o.IntegrationType) {
+		return NewAppError("IntegrationStat.IsValid", "model.integration_stat.is_valid.integration_type.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.IntegrationId) {
+		return NewAppError("IntegrationStat.IsValid", "model.integration_stat.is_valid.integration_id.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	if o.Requests < 0 || o.Posts < 0 || o.Errors < 0 || o.TotalLatencyMs < 0 || o.MaxLatencyMs < 0 {
+		return NewAppError("IntegrationStat.IsValid", "model.integration_stat.is_valid.counts.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("IntegrationStat.IsValid", "model.integration_stat.is_valid.update_at.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *IntegrationStat) PreSave() {
+	o.UpdateAt = GetMillis()
+}
+
+// Add adds the activity of another stat of the same integration to this one.
+func (o *IntegrationStat) Add(other *IntegrationStat) {
+	o.Requests += other.Requests
+	o.Posts += other.Posts
+	o.Errors += other.Errors
+	o.TotalLatencyMs += other.TotalLatencyMs
+	if other.MaxLatencyMs > o.MaxLatencyMs {
+		o.MaxLatencyMs = other.MaxLatencyMs
+	}
+}
+
+func (o *IntegrationStat) AverageLatencyMs() int64 {
+	if o.Requests == 0 {
+		return 0
+	}
+
+	return o.TotalLatencyMs / o.Requests
+}
+
+func (o *IntegrationStat) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func IntegrationStatListToJson(l []*IntegrationStat) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func IntegrationStatListFromJson(data io.Reader) []*IntegrationStat {
+	var l []*IntegrationStat
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
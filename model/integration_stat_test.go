@@ -0,0 +1,48 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationStatJson(t *testing.T) {
+	stat := IntegrationStat{Date: "2019-10-01", IntegrationType: INTEGRATION_TYPE_COMMAND, IntegrationId: NewId(), Requests: 4, Posts: 3, Errors: 1, TotalLatencyMs: 200, MaxLatencyMs: 120, UpdateAt: 1}
+	list := IntegrationStatListFromJson(strings.NewReader(IntegrationStatListToJson([]*IntegrationStat{&stat})))
+	require.Len(t, list, 1)
+	assert.Equal(t, stat, *list[0])
+}
+
+func TestIntegrationStatIsValid(t *testing.T) {
+	stat := IntegrationStat{Date: "2019-10-01", IntegrationType: INTEGRATION_TYPE_INCOMING_WEBHOOK, IntegrationId: NewId(), Requests: 2, Posts: 2}
+	stat.PreSave()
+	require.Nil(t, stat.IsValid())
+
+	for name, update := range map[string]func(s *IntegrationStat){
+		"date":             func(s *IntegrationStat) { s.Date = "2019-10-32" },
+		"integration type": func(s *IntegrationStat) { s.IntegrationType = "plugin" },
+		"integration id":   func(s *IntegrationStat) { s.IntegrationId = "abc" },
+		"negative count":   func(s *IntegrationStat) { s.Errors = -1 },
+		"update at":        func(s *IntegrationStat) { s.UpdateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := stat
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestIntegrationStatAdd(t *testing.T) {
+	stat := IntegrationStat{Requests: 1, Posts: 1, TotalLatencyMs: 50, MaxLatencyMs: 50}
+	stat.Add(&IntegrationStat{Requests: 2, Errors: 1, TotalLatencyMs: 250, MaxLatencyMs: 200})
+	assert.Equal(t, IntegrationStat{Requests: 3, Posts: 1, Errors: 1, TotalLatencyMs: 300, MaxLatencyMs: 200}, stat)
+	assert.Equal(t, int64(100), stat.AverageLatencyMs())
+
+	assert.Equal(t, int64(0), (&IntegrationStat{}).AverageLatencyMs())
+}
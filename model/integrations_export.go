@@ -0,0 +1,133 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	INTEGRATIONS_EXPORT_VERSION = 1
+
+	// INTEGRATIONS_EXPORT_SECRET_PLACEHOLDER replaces tokens and client secrets in exports. Importing an
+	// integration whose secret still holds the placeholder generates a new secret for it.
+	INTEGRATIONS_EXPORT_SECRET_PLACEHOLDER = "{{secret}}"
+)
+
+// IntegrationsExport describes the integrations configured on a server in a form that can be imported
+// into another one. Teams, channels and users are referenced by name rather than by id.
+type IntegrationsExport struct {
+	Version          int                      `json:"version"`
+	IncomingWebhooks []*IncomingWebhookExport `json:"incoming_webhooks"`
+	OutgoingWebhooks []*OutgoingWebhookExport `json:"outgoing_webhooks"`
+	Commands         []*CommandExport         `json:"commands"`
+	OAuthApps        []*OAuthAppExport        `json:"oauth_apps"`
+	Bots             []*BotExport             `json:"bots"`
+}
+
+type IncomingWebhookExport struct {
+	Team               string `json:"team"`
+	Channel            string `json:"channel"`
+	Creator            string `json:"creator"`
+	DisplayName        string `json:"display_name"`
+	Description        string `json:"description"`
+	Username           string `json:"username"`
+	IconURL            string `json:"icon_url"`
+	ChannelLocked      bool   `json:"channel_locked"`
+	PayloadTemplate    string `json:"payload_template"`
+	RateLimitPerMinute *int   `json:"rate_limit_per_minute,omitempty"`
+	DailyQuota         *int   `json:"daily_quota,omitempty"`
+}
+
+type OutgoingWebhookExport struct {
+	Team         string      `json:"team"`
+	Channel      string      `json:"channel,omitempty"`
+	Creator      string      `json:"creator"`
+	Token        string      `json:"token"`
+	TriggerWords StringArray `json:"trigger_words"`
+	TriggerWhen  int         `json:"trigger_when"`
+	CallbackURLs StringArray `json:"callback_urls"`
+	DisplayName  string      `json:"display_name"`
+	Description  string      `json:"description"`
+	ContentType  string      `json:"content_type"`
+	Username     string      `json:"username"`
+	IconURL      string      `json:"icon_url"`
+	EventTypes   StringArray `json:"event_types,omitempty"`
+	TestMode     bool        `json:"test_mode,omitempty"`
+}
+
+type CommandExport struct {
+	Team             string `json:"team"`
+	Creator          string `json:"creator"`
+	Token            string `json:"token"`
+	Trigger          string `json:"trigger"`
+	Method           string `json:"method"`
+	Username         string `json:"username"`
+	IconURL          string `json:"icon_url"`
+	AutoComplete     bool   `json:"auto_complete"`
+	AutoCompleteDesc string `json:"auto_complete_desc"`
+	AutoCompleteHint string `json:"auto_complete_hint"`
+	DisplayName      string `json:"display_name"`
+	Description      string `json:"description"`
+	URL              string `json:"url"`
+}
+
+type OAuthAppExport struct {
+	Creator      string      `json:"creator"`
+	ClientSecret string      `json:"client_secret"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	IconURL      string      `json:"icon_url"`
+	CallbackUrls StringArray `json:"callback_urls"`
+	Homepage     string      `json:"homepage"`
+	IsTrusted    bool        `json:"is_trusted"`
+}
+
+type BotExport struct {
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	// Owner is the username of the owning user, or the id of the owning plugin. Bots owned by plugins are exported
+	// for reference but not imported, since plugins create their bots themselves.
+	Owner string `json:"owner"`
+}
+
+// IntegrationsImportResult counts the integrations created by an import. Integrations that could not be
+// imported are skipped and reported in Errors.
+type IntegrationsImportResult struct {
+	IncomingWebhooks int      `json:"incoming_webhooks"`
+	OutgoingWebhooks int      `json:"outgoing_webhooks"`
+	Commands         int      `json:"commands"`
+	OAuthApps        int      `json:"oauth_apps"`
+	Bots             int      `json:"bots"`
+	Errors           []string `json:"errors"`
+}
+
+// IsSecretPlaceholder returns true if the secret of an imported integration should be generated.
+func IsSecretPlaceholder(secret string) bool {
+	return secret == "" || secret == INTEGRATIONS_EXPORT_SECRET_PLACEHOLDER
+}
+
+func (e *IntegrationsExport) ToJson() string {
+	b, _ := json.MarshalIndent(e, "", "  ")
+	return string(b)
+}
+
+func IntegrationsExportFromJson(data io.Reader) *IntegrationsExport {
+	var e *IntegrationsExport
+	json.NewDecoder(data).Decode(&e)
+	return e
+}
+
+func (r *IntegrationsImportResult) ToJson() string {
+	b, _ := json.Marshal(r)
+	return string(b)
+}
+
+func IntegrationsImportResultFromJson(data io.Reader) *IntegrationsImportResult {
+	var r *IntegrationsImportResult
+	json.NewDecoder(data).Decode(&r)
+	return r
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -18,6 +19,16 @@ const (
 	JOB_TYPE_LDAP_SYNC                      = "ldap_sync"
 	JOB_TYPE_MIGRATIONS                     = "migrations"
 	JOB_TYPE_PLUGINS                        = "plugins"
+	JOB_TYPE_BULK_PREFERENCES               = "bulk_preferences"
+	JOB_TYPE_CHANNEL_TIMELINE               = "channel_timeline"
+	JOB_TYPE_BULK_USERS                     = "bulk_users"
+	JOB_TYPE_CHANNEL_READ_STATS             = "channel_read_stats"
+	JOB_TYPE_USER_DEACTIVATION              = "user_deactivation"
+	JOB_TYPE_RECURRING_POSTS                = "recurring_posts"
+	JOB_TYPE_DAILY_STATS                    = "daily_stats"
+	JOB_TYPE_POST_ARCHIVE                   = "post_archive"
+	JOB_TYPE_SEARCH_REINDEX                 = "search_reindex"
+	JOB_TYPE_USER_ANONYMIZATION             = "user_anonymization"
 
 	JOB_STATUS_PENDING          = "pending"
 	JOB_STATUS_IN_PROGRESS      = "in_progress"
@@ -25,8 +36,54 @@ const (
 	JOB_STATUS_ERROR            = "error"
 	JOB_STATUS_CANCEL_REQUESTED = "cancel_requested"
 	JOB_STATUS_CANCELED         = "canceled"
+
+	JOB_PRIORITY_LOW    = 0
+	JOB_PRIORITY_NORMAL = 50
+	JOB_PRIORITY_HIGH   = 100
+
+	JOB_DATA_RETRY_COUNT = "retry_count"
+	JOB_DATA_RETRY_AFTER = "retry_after"
 )
 
+// JobTypeOptions declares how the jobs of a type are run. Pending jobs of a higher priority are handed to the
+// workers first, and MaxConcurrency limits how many jobs of the type run at once across the cluster, with 0 meaning
+// no limit.
+type JobTypeOptions struct {
+	Priority       int64
+	MaxConcurrency int
+}
+
+var jobTypeOptions = map[string]JobTypeOptions{
+	JOB_TYPE_DATA_RETENTION:                 {Priority: JOB_PRIORITY_LOW, MaxConcurrency: 1},
+	JOB_TYPE_MESSAGE_EXPORT:                 {Priority: JOB_PRIORITY_LOW, MaxConcurrency: 1},
+	JOB_TYPE_ELASTICSEARCH_POST_INDEXING:    {Priority: JOB_PRIORITY_NORMAL, MaxConcurrency: 1},
+	JOB_TYPE_ELASTICSEARCH_POST_AGGREGATION: {Priority: JOB_PRIORITY_LOW, MaxConcurrency: 1},
+	JOB_TYPE_LDAP_SYNC:                      {Priority: JOB_PRIORITY_NORMAL, MaxConcurrency: 1},
+	JOB_TYPE_MIGRATIONS:                     {Priority: JOB_PRIORITY_HIGH, MaxConcurrency: 1},
+	JOB_TYPE_PLUGINS:                        {Priority: JOB_PRIORITY_NORMAL, MaxConcurrency: 1},
+	JOB_TYPE_BULK_PREFERENCES:               {Priority: JOB_PRIORITY_NORMAL},
+	JOB_TYPE_CHANNEL_TIMELINE:               {Priority: JOB_PRIORITY_NORMAL},
+	JOB_TYPE_BULK_USERS:                     {Priority: JOB_PRIORITY_NORMAL},
+	JOB_TYPE_CHANNEL_READ_STATS:             {Priority: JOB_PRIORITY_LOW, MaxConcurrency: 1},
+	JOB_TYPE_USER_DEACTIVATION:              {Priority: JOB_PRIORITY_LOW, MaxConcurrency: 1},
+	JOB_TYPE_DAILY_STATS:                    {Priority: JOB_PRIORITY_LOW, MaxConcurrency: 1},
+	JOB_TYPE_POST_ARCHIVE:                   {Priority: JOB_PRIORITY_LOW, MaxConcurrency: 1},
+	JOB_TYPE_SEARCH_REINDEX:                 {Priority: JOB_PRIORITY_LOW, MaxConcurrency: 1},
+	JOB_TYPE_USER_ANONYMIZATION:             {Priority: JOB_PRIORITY_NORMAL},
+}
+
+func GetJobTypeOptions(jobType string) JobTypeOptions {
+	if options, ok := jobTypeOptions[jobType]; ok {
+		return options
+	}
+	return JobTypeOptions{Priority: JOB_PRIORITY_NORMAL}
+}
+
+func IsValidJobType(jobType string) bool {
+	_, ok := jobTypeOptions[jobType]
+	return ok
+}
+
 type Job struct {
 	Id             string            `json:"id"`
 	Type           string            `json:"type"`
@@ -56,6 +113,16 @@ func (j *Job) IsValid() *AppError {
 	case JOB_TYPE_MESSAGE_EXPORT:
 	case JOB_TYPE_MIGRATIONS:
 	case JOB_TYPE_PLUGINS:
+	case JOB_TYPE_BULK_PREFERENCES:
+	case JOB_TYPE_CHANNEL_TIMELINE:
+	case JOB_TYPE_BULK_USERS:
+	case JOB_TYPE_CHANNEL_READ_STATS:
+	case JOB_TYPE_USER_DEACTIVATION:
+	case JOB_TYPE_RECURRING_POSTS:
+	case JOB_TYPE_DAILY_STATS:
+	case JOB_TYPE_POST_ARCHIVE:
+	case JOB_TYPE_SEARCH_REINDEX:
+	case JOB_TYPE_USER_ANONYMIZATION:
 	default:
 		return NewAppError("Job.IsValid", "model.job.is_valid.type.app_error", nil, "id="+j.Id, http.StatusBadRequest)
 	}
@@ -102,6 +169,18 @@ func JobsFromJson(data io.Reader) []*Job {
 	}
 }
 
+// RetryCount returns how many times the job was re-queued after being found stuck.
+func (js *Job) RetryCount() int {
+	count, _ := strconv.Atoi(js.Data[JOB_DATA_RETRY_COUNT])
+	return count
+}
+
+// RetryAfter returns the time in milliseconds before which a re-queued job must not be run again, or 0.
+func (js *Job) RetryAfter() int64 {
+	retryAfter, _ := strconv.ParseInt(js.Data[JOB_DATA_RETRY_AFTER], 10, 64)
+	return retryAfter
+}
+
 func (js *Job) DataToJson() string {
 	b, _ := json.Marshal(js.Data)
 	return string(b)
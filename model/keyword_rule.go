@@ -0,0 +1,199 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	KEYWORD_RULE_NAME_MAX_LENGTH          = 64
+	KEYWORD_RULE_MAX_CHANNELS             = 50
+	KEYWORD_RULE_MAX_KEYWORDS             = 100
+	KEYWORD_RULE_KEYWORD_MAX_LENGTH       = 64
+	KEYWORD_RULE_MAX_PATTERNS             = 20
+	KEYWORD_RULE_PATTERN_MAX_LENGTH       = 256
+	KEYWORD_RULE_REPLY_MESSAGE_MAX_LENGTH = 4000
+
+	// KEYWORD_RULE_NOTIFY_GROUP_MAX_MEMBERS is the number of members of a group that are notified of a post.
+	KEYWORD_RULE_NOTIFY_GROUP_MAX_MEMBERS = 100
+)
+
+// KeywordRule is an admin-defined rule which acts on the posts matching its keywords or patterns, in its channels
+// if it has any, by replying to them with a canned response, reacting to them, copying them to another channel or
+// notifying the members of a group. Replies, reactions and copies are made by the responder. Rules without a team
+// apply to all teams.
+type KeywordRule struct {
+	Id                string      `json:"id"`
+	CreateAt          int64       `json:"create_at"`
+	UpdateAt          int64       `json:"update_at"`
+	DeleteAt          int64       `json:"delete_at"`
+	CreatorId         string      `json:"creator_id"`
+	TeamId            string      `json:"team_id"`
+	Name              string      `json:"name"`
+	ChannelIds        StringArray `json:"channel_ids"`
+	Keywords          StringArray `json:"keywords"`
+	Patterns          StringArray `json:"patterns"`
+	ResponderId       string      `json:"responder_id"`
+	ReplyMessage      string      `json:"reply_message"`
+	ReactionEmojiName string      `json:"reaction_emoji_name"`
+	CopyToChannelId   string      `json:"copy_to_channel_id"`
+	NotifyGroupId     string      `json:"notify_group_id"`
+}
+
+func (o *KeywordRule) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.CreatorId) > 26 {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.TeamId) != 0 && len(o.TeamId) != 26 {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.team_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Name) == 0 || len(o.Name) > KEYWORD_RULE_NAME_MAX_LENGTH {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.name.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !isValidIdList(o.ChannelIds, KEYWORD_RULE_MAX_CHANNELS) {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.channel_ids.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.ChannelIds) == 0 && len(o.Keywords) == 0 && len(o.Patterns) == 0 {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.no_conditions.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Keywords) > KEYWORD_RULE_MAX_KEYWORDS {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.keywords.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, keyword := range o.Keywords {
+		if len(strings.TrimSpace(keyword)) == 0 || len(keyword) > KEYWORD_RULE_KEYWORD_MAX_LENGTH {
+			return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.keywords.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	}
+
+	if len(o.Patterns) > KEYWORD_RULE_MAX_PATTERNS {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.patterns.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, pattern := range o.Patterns {
+		if len(pattern) == 0 || len(pattern) > KEYWORD_RULE_PATTERN_MAX_LENGTH {
+			return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.patterns.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+
+		if _, err := regexp.Compile(pattern); err != nil {
+			return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.pattern_syntax.app_error", map[string]interface{}{"Pattern": pattern}, "id="+o.Id+", "+err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	if o.ReplyMessage == "" && o.ReactionEmojiName == "" && o.CopyToChannelId == "" && o.NotifyGroupId == "" {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.no_actions.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.ReplyMessage) > KEYWORD_RULE_REPLY_MESSAGE_MAX_LENGTH {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.reply_message.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.ReactionEmojiName != "" && !IsValidAlphaNumHyphenUnderscore(o.ReactionEmojiName, false) {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.reaction_emoji_name.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CopyToChannelId != "" && !IsValidId(o.CopyToChannelId) {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.copy_to_channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.NotifyGroupId != "" && !IsValidId(o.NotifyGroupId) {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.notify_group_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ResponderId) {
+		return NewAppError("KeywordRule.IsValid", "model.keyword_rule.is_valid.responder_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *KeywordRule) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+	o.normalize()
+}
+
+func (o *KeywordRule) PreUpdate() {
+	o.UpdateAt = GetMillis()
+	o.normalize()
+}
+
+func (o *KeywordRule) normalize() {
+	o.Name = strings.TrimSpace(o.Name)
+	o.ReactionEmojiName = strings.Trim(strings.TrimSpace(o.ReactionEmojiName), ":")
+
+	if o.ChannelIds == nil {
+		o.ChannelIds = StringArray{}
+	}
+
+	if o.Keywords == nil {
+		o.Keywords = StringArray{}
+	}
+	for i, keyword := range o.Keywords {
+		o.Keywords[i] = strings.TrimSpace(keyword)
+	}
+
+	if o.Patterns == nil {
+		o.Patterns = StringArray{}
+	}
+}
+
+// Regexp compiles the keywords and patterns of the rule into a single expression. It returns nil if the rule has
+// neither, in which case it matches every post in its channels.
+func (o *KeywordRule) Regexp() (*regexp.Regexp, error) {
+	if len(o.Keywords) == 0 && len(o.Patterns) == 0 {
+		return nil, nil
+	}
+
+	return compileWordsAndPatterns(o.Keywords, o.Patterns)
+}
+
+func (o *KeywordRule) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func KeywordRuleFromJson(data io.Reader) *KeywordRule {
+	var o *KeywordRule
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func KeywordRuleListToJson(l []*KeywordRule) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func KeywordRuleListFromJson(data io.Reader) []*KeywordRule {
+	var o []*KeywordRule
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeywordRuleJson(t *testing.T) {
+	rule := KeywordRule{Id: NewId(), Name: "outage", Keywords: StringArray{"down"}, ResponderId: NewId(), ReplyMessage: "Check the status page."}
+	result := KeywordRuleFromJson(strings.NewReader(rule.ToJson()))
+	assert.Equal(t, rule, *result)
+
+	list := KeywordRuleListFromJson(strings.NewReader(KeywordRuleListToJson([]*KeywordRule{&rule})))
+	require.Len(t, list, 1)
+	assert.Equal(t, rule, *list[0])
+}
+
+func TestKeywordRuleIsValid(t *testing.T) {
+	rule := KeywordRule{Name: " outage ", Keywords: StringArray{" down "}, ResponderId: NewId(), ReactionEmojiName: ":eyes:"}
+	rule.PreSave()
+	assert.Equal(t, "outage", rule.Name)
+	assert.Equal(t, StringArray{"down"}, rule.Keywords)
+	assert.Equal(t, "eyes", rule.ReactionEmojiName)
+	require.Nil(t, rule.IsValid())
+
+	for name, test := range map[string]struct {
+		Update func(rule *KeywordRule)
+		ErrId  string
+	}{
+		"team id":         {func(o *KeywordRule) { o.TeamId = "abc" }, "model.keyword_rule.is_valid.team_id.app_error"},
+		"no name":         {func(o *KeywordRule) { o.Name = "" }, "model.keyword_rule.is_valid.name.app_error"},
+		"channel ids":     {func(o *KeywordRule) { o.ChannelIds = StringArray{"abc"} }, "model.keyword_rule.is_valid.channel_ids.app_error"},
+		"no conditions":   {func(o *KeywordRule) { o.Keywords = StringArray{} }, "model.keyword_rule.is_valid.no_conditions.app_error"},
+		"blank keyword":   {func(o *KeywordRule) { o.Keywords = StringArray{" "} }, "model.keyword_rule.is_valid.keywords.app_error"},
+		"pattern syntax":  {func(o *KeywordRule) { o.Patterns = StringArray{"(unclosed"} }, "model.keyword_rule.is_valid.pattern_syntax.app_error"},
+		"no actions":      {func(o *KeywordRule) { o.ReactionEmojiName = "" }, "model.keyword_rule.is_valid.no_actions.app_error"},
+		"emoji name":      {func(o *KeywordRule) { o.ReactionEmojiName = "thumbs up" }, "model.keyword_rule.is_valid.reaction_emoji_name.app_error"},
+		"copy to channel": {func(o *KeywordRule) { o.CopyToChannelId = "abc" }, "model.keyword_rule.is_valid.copy_to_channel_id.app_error"},
+		"notify group":    {func(o *KeywordRule) { o.NotifyGroupId = "abc" }, "model.keyword_rule.is_valid.notify_group_id.app_error"},
+		"no responder":    {func(o *KeywordRule) { o.ResponderId = "" }, "model.keyword_rule.is_valid.responder_id.app_error"},
+		"reply length": {func(o *KeywordRule) {
+			o.ReplyMessage = strings.Repeat("a", KEYWORD_RULE_REPLY_MESSAGE_MAX_LENGTH+1)
+		}, "model.keyword_rule.is_valid.reply_message.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := rule
+			test.Update(&invalid)
+
+			err := invalid.IsValid()
+			require.NotNil(t, err)
+			assert.Equal(t, test.ErrId, err.Id)
+		})
+	}
+}
+
+func TestKeywordRuleRegexp(t *testing.T) {
+	rule := KeywordRule{ChannelIds: StringArray{NewId()}}
+
+	re, err := rule.Regexp()
+	require.Nil(t, err)
+	assert.Nil(t, re)
+
+	rule.Keywords = StringArray{"outage"}
+	rule.Patterns = StringArray{`(?i)error \d+`}
+	re, err = rule.Regexp()
+	require.Nil(t, err)
+	assert.True(t, re.MatchString("Is there an OUTAGE?"))
+	assert.True(t, re.MatchString("I get ERROR 500"))
+	assert.False(t, re.MatchString("outages happen"))
+}
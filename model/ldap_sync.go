@@ -0,0 +1,121 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LDAP_SYNC_JOB_DATA_DRY_RUN = "dry_run"
+	LDAP_SYNC_JOB_DATA_SINCE   = "since"
+	LDAP_SYNC_JOB_DATA_REPORT  = "report"
+
+	LDAP_SYNC_ACTION_ADD_USER            = "add_user"
+	LDAP_SYNC_ACTION_UPDATE_USER         = "update_user"
+	LDAP_SYNC_ACTION_DEACTIVATE_USER     = "deactivate_user"
+	LDAP_SYNC_ACTION_ADD_GROUP_MEMBER    = "add_group_member"
+	LDAP_SYNC_ACTION_REMOVE_GROUP_MEMBER = "remove_group_member"
+
+	LDAP_SYNC_REPORT_MAX_CHANGES = 1000
+
+	ldapGeneralizedTimeFormat = "20060102150405Z"
+)
+
+// LdapSyncChange is a change an LDAP sync made, or would have made when it is a dry run.
+type LdapSyncChange struct {
+	Action   string `json:"action"`
+	Username string `json:"username"`
+	UserId   string `json:"user_id,omitempty"`
+	GroupId  string `json:"group_id,omitempty"`
+}
+
+// LdapSyncReport sums up the changes of an LDAP sync. Every change is counted, but only the first
+// LDAP_SYNC_REPORT_MAX_CHANGES are listed so that the report of a large directory still fits in the job data.
+type LdapSyncReport struct {
+	DryRun    bool              `json:"dry_run"`
+	Since     int64             `json:"since"`
+	Counts    map[string]int    `json:"counts"`
+	Changes   []*LdapSyncChange `json:"changes"`
+	Truncated bool              `json:"truncated"`
+}
+
+func NewLdapSyncReport(job *Job) *LdapSyncReport {
+	return &LdapSyncReport{
+		DryRun:  IsLdapSyncDryRun(job),
+		Since:   LdapSyncSince(job),
+		Counts:  map[string]int{},
+		Changes: []*LdapSyncChange{},
+	}
+}
+
+func (r *LdapSyncReport) AddChange(change *LdapSyncChange) {
+	r.Counts[change.Action]++
+
+	if len(r.Changes) >= LDAP_SYNC_REPORT_MAX_CHANGES {
+		r.Truncated = true
+		return
+	}
+
+	r.Changes = append(r.Changes, change)
+}
+
+func (r *LdapSyncReport) ToJson() string {
+	b, _ := json.Marshal(r)
+	return string(b)
+}
+
+func LdapSyncReportFromJson(data io.Reader) *LdapSyncReport {
+	var r *LdapSyncReport
+	json.NewDecoder(data).Decode(&r)
+	return r
+}
+
+// LdapSyncReportFromJob returns the report an LDAP sync job saved in its data, or nil if it has none.
+func LdapSyncReportFromJob(job *Job) *LdapSyncReport {
+	report, ok := job.Data[LDAP_SYNC_JOB_DATA_REPORT]
+	if !ok {
+		return nil
+	}
+
+	return LdapSyncReportFromJson(strings.NewReader(report))
+}
+
+// IsLdapSyncDryRun returns whether an LDAP sync job only reports the changes it would make.
+func IsLdapSyncDryRun(job *Job) bool {
+	return job.Data[LDAP_SYNC_JOB_DATA_DRY_RUN] == "true"
+}
+
+// LdapSyncSince returns the time from which an LDAP sync job only syncs the entries that changed, or 0 for a full
+// sync.
+func LdapSyncSince(job *Job) int64 {
+	since, _ := strconv.ParseInt(job.Data[LDAP_SYNC_JOB_DATA_SINCE], 10, 64)
+	return since
+}
+
+// LdapModifyTimestampFilter returns an LDAP filter matching the entries modified since the given time, in
+// milliseconds, according to the given attribute.
+func LdapModifyTimestampFilter(attribute string, since int64) string {
+	return "(" + attribute + ">=" + time.Unix(0, since*int64(time.Millisecond)).UTC().Format(ldapGeneralizedTimeFormat) + ")"
+}
+
+// LdapIncrementalUserFilter restricts the user filter to the entries modified since the given time.
+func LdapIncrementalUserFilter(userFilter, attribute string, since int64) string {
+	timestampFilter := LdapModifyTimestampFilter(attribute, since)
+
+	userFilter = strings.TrimSpace(userFilter)
+	if userFilter == "" {
+		return timestampFilter
+	}
+
+	if !strings.HasPrefix(userFilter, "(") {
+		userFilter = "(" + userFilter + ")"
+	}
+
+	return "(&" + userFilter + timestampFilter + ")"
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLdapIncrementalUserFilter(t *testing.T) {
+	since := time.Date(2019, time.October, 18, 12, 30, 5, 0, time.UTC).UnixNano() / int64(time.Millisecond)
+
+	assert.Equal(t, "(modifyTimestamp>=20191018123005Z)", LdapModifyTimestampFilter("modifyTimestamp", since))
+
+	assert.Equal(t, "(modifyTimestamp>=20191018123005Z)", LdapIncrementalUserFilter("", "modifyTimestamp", since))
+	assert.Equal(t, "(&(objectClass=user)(modifyTimestamp>=20191018123005Z))", LdapIncrementalUserFilter("(objectClass=user)", "modifyTimestamp", since))
+	assert.Equal(t, "(&(objectClass=user)(whenChanged>=20191018123005Z))", LdapIncrementalUserFilter(" objectClass=user ", "whenChanged", since))
+}
+
+func TestLdapSyncReport(t *testing.T) {
+	job := &Job{Data: map[string]string{LDAP_SYNC_JOB_DATA_DRY_RUN: "true", LDAP_SYNC_JOB_DATA_SINCE: "1234"}}
+
+	report := NewLdapSyncReport(job)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, int64(1234), report.Since)
+
+	for i := 0; i < LDAP_SYNC_REPORT_MAX_CHANGES+1; i++ {
+		report.AddChange(&LdapSyncChange{Action: LDAP_SYNC_ACTION_ADD_USER, Username: NewId()})
+	}
+	report.AddChange(&LdapSyncChange{Action: LDAP_SYNC_ACTION_DEACTIVATE_USER, Username: NewId(), UserId: NewId()})
+
+	assert.Len(t, report.Changes, LDAP_SYNC_REPORT_MAX_CHANGES)
+	assert.True(t, report.Truncated)
+	assert.Equal(t, LDAP_SYNC_REPORT_MAX_CHANGES+1, report.Counts[LDAP_SYNC_ACTION_ADD_USER])
+	assert.Equal(t, 1, report.Counts[LDAP_SYNC_ACTION_DEACTIVATE_USER])
+
+	job.Data[LDAP_SYNC_JOB_DATA_REPORT] = report.ToJson()
+	saved := LdapSyncReportFromJob(job)
+	require.NotNil(t, saved)
+	assert.Equal(t, report.Counts, saved.Counts)
+	assert.Len(t, saved.Changes, LDAP_SYNC_REPORT_MAX_CHANGES)
+
+	assert.Nil(t, LdapSyncReportFromJob(&Job{Data: map[string]string{}}))
+	assert.False(t, IsLdapSyncDryRun(&Job{Data: map[string]string{}}))
+	assert.Zero(t, LdapSyncSince(&Job{Data: map[string]string{}}))
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	SYSTEM_JOBS_LEADER_LEASE = "JobsLeaderLease"
+)
+
+// LeaderLease records which server holds a leadership role, such as running the job schedulers, and until when. It is
+// stored in the Systems table under the name of the role, and is renewed by its holder while it is running.
+type LeaderLease struct {
+	Name     string `json:"name"`
+	HolderId string `json:"holder_id"`
+	Hostname string `json:"hostname"`
+	ExpireAt int64  `json:"expire_at"`
+}
+
+func (o *LeaderLease) IsExpired() bool {
+	return o.ExpireAt <= GetMillis()
+}
+
+func (o *LeaderLease) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func LeaderLeaseFromJson(data io.Reader) *LeaderLease {
+	var o *LeaderLease
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// JobsLeaderStatus describes which server of the cluster currently runs the job schedulers.
+type JobsLeaderStatus struct {
+	LeaderId       string `json:"leader_id"`
+	LeaderHostname string `json:"leader_hostname"`
+	LeaseExpireAt  int64  `json:"lease_expire_at"`
+	ServerId       string `json:"server_id"`
+	IsLeader       bool   `json:"is_leader"`
+}
+
+func (o *JobsLeaderStatus) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func JobsLeaderStatusFromJson(data io.Reader) *JobsLeaderStatus {
+	var o *JobsLeaderStatus
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderLeaseJson(t *testing.T) {
+	lease := &LeaderLease{Name: SYSTEM_JOBS_LEADER_LEASE, HolderId: NewId(), Hostname: "host", ExpireAt: GetMillis()}
+
+	result := LeaderLeaseFromJson(strings.NewReader(lease.ToJson()))
+	require.NotNil(t, result)
+	assert.Equal(t, lease, result)
+}
+
+func TestLeaderLeaseIsExpired(t *testing.T) {
+	assert.True(t, (&LeaderLease{ExpireAt: GetMillis() - 1000}).IsExpired())
+	assert.False(t, (&LeaderLease{ExpireAt: GetMillis() + 60000}).IsExpired())
+}
+
+func TestJobsLeaderStatusJson(t *testing.T) {
+	status := &JobsLeaderStatus{LeaderId: NewId(), LeaderHostname: "host", LeaseExpireAt: GetMillis(), ServerId: NewId(), IsLeader: true}
+
+	result := JobsLeaderStatusFromJson(strings.NewReader(status.ToJson()))
+	require.NotNil(t, result)
+	assert.Equal(t, status, result)
+}
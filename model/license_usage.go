@@ -0,0 +1,145 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"time"
+)
+
+const (
+	// LICENSE_USAGE_FORECAST_DAYS is how many days of history the seat forecast is based on.
+	LICENSE_USAGE_FORECAST_DAYS = 30
+
+	// LICENSE_USAGE_MAX_FORECAST_DAYS bounds how far ahead the seats are forecast to run out.
+	LICENSE_USAGE_MAX_FORECAST_DAYS = 365
+
+	// LICENSE_SEAT_WARNING_INTERVAL is how often the system admins are warned while the seats are running out.
+	LICENSE_SEAT_WARNING_INTERVAL = 7 * 24 * time.Hour
+)
+
+// LicenseUsage is the peak usage of the licensed seats during a UTC day. RegisteredUsers counts the active accounts,
+// which take a seat, and ActiveUsers those who used the server during the past 24 hours.
+type LicenseUsage struct {
+	Date            string `json:"date"`
+	RegisteredUsers int64  `json:"registered_users"`
+	ActiveUsers     int64  `json:"active_users"`
+	Seats           int64  `json:"seats"`
+	UpdateAt        int64  `json:"update_at"`
+}
+
+// LicenseUsageSummary is the current usage of the licensed seats along with its history. Warning is set once the
+// registered users reach WarningPercentage of the seats, and SeatsExhaustedAt forecasts the date they'll all be taken
+// if the registrations keep growing as they did recently.
+type LicenseUsageSummary struct {
+	Seats             int64           `json:"seats"`
+	RegisteredUsers   int64           `json:"registered_users"`
+	ActiveUsers       int64           `json:"active_users"`
+	WarningPercentage int             `json:"warning_percentage"`
+	Warning           bool            `json:"warning"`
+	SeatsExhaustedAt  string          `json:"seats_exhausted_at,omitempty"`
+	History           []*LicenseUsage `json:"history"`
+}
+
+func (o *LicenseUsage) IsValid() *AppError {
+	if !IsValidDailyStatDate(o.Date) {
+		return NewAppError("LicenseUsage.IsValid", "model.license_usage.is_valid.date.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	if o.RegisteredUsers < 0 || o.ActiveUsers < 0 || o.Seats < 0 {
+		return NewAppError("LicenseUsage.IsValid", "model.license_usage.is_valid.count.app_error", nil, "date="+o.Date, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *LicenseUsage) PreSave() {
+	o.UpdateAt = GetMillis()
+}
+
+func (o *LicenseUsageSummary) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func LicenseUsageSummaryFromJson(data io.Reader) *LicenseUsageSummary {
+	var o *LicenseUsageSummary
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// IsLicenseSeatWarning reports whether the registered users have reached the given percentage of the seats.
+func IsLicenseSeatWarning(registeredUsers, seats int64, percentage int) bool {
+	if seats <= 0 {
+		return false
+	}
+
+	return registeredUsers*100 >= seats*int64(percentage)
+}
+
+// ForecastLicenseSeatsExhaustion returns the date the registered users are forecast to take all the seats, fitting a
+// line through the usage history, which is ordered by date. It returns an empty string when the registrations aren't
+// growing, or wouldn't reach the seats within LICENSE_USAGE_MAX_FORECAST_DAYS.
+func ForecastLicenseSeatsExhaustion(history []*LicenseUsage, seats int64) string {
+	if len(history) == 0 || seats <= 0 {
+		return ""
+	}
+
+	last := history[len(history)-1]
+	if last.RegisteredUsers >= seats {
+		return last.Date
+	}
+
+	if len(history) < 2 {
+		return ""
+	}
+
+	first, err := time.Parse(DAILY_STAT_DATE_FORMAT, history[0].Date)
+	if err != nil {
+		return ""
+	}
+
+	var n, sumX, sumY, sumXY, sumXX float64
+	for _, usage := range history {
+		date, err := time.Parse(DAILY_STAT_DATE_FORMAT, usage.Date)
+		if err != nil {
+			return ""
+		}
+
+		x := date.Sub(first).Hours() / 24
+		y := float64(usage.RegisteredUsers)
+		n++
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return ""
+	}
+
+	slope := (n*sumXY - sumX*sumY) / denominator
+	if slope <= 0 {
+		return ""
+	}
+	intercept := (sumY - slope*sumX) / n
+
+	lastDate, _ := time.Parse(DAILY_STAT_DATE_FORMAT, last.Date)
+	lastX := lastDate.Sub(first).Hours() / 24
+
+	days := math.Ceil((float64(seats)-intercept)/slope - lastX)
+	if days < 1 {
+		days = 1
+	}
+	if days > LICENSE_USAGE_MAX_FORECAST_DAYS {
+		return ""
+	}
+
+	return lastDate.AddDate(0, 0, int(days)).Format(DAILY_STAT_DATE_FORMAT)
+}
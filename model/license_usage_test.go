@@ -0,0 +1,51 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseUsageIsValid(t *testing.T) {
+	usage := LicenseUsage{Date: "2019-10-01", RegisteredUsers: 10, ActiveUsers: 4, Seats: 20}
+	usage.PreSave()
+	require.Nil(t, usage.IsValid())
+
+	usage.Date = "2019-10-32"
+	assert.NotNil(t, usage.IsValid())
+
+	usage.Date = "2019-10-01"
+	usage.ActiveUsers = -1
+	assert.NotNil(t, usage.IsValid())
+}
+
+func TestIsLicenseSeatWarning(t *testing.T) {
+	assert.False(t, IsLicenseSeatWarning(89, 100, 90))
+	assert.True(t, IsLicenseSeatWarning(90, 100, 90))
+	assert.True(t, IsLicenseSeatWarning(120, 100, 90))
+	assert.False(t, IsLicenseSeatWarning(10, 0, 90))
+}
+
+func TestForecastLicenseSeatsExhaustion(t *testing.T) {
+	usages := func(counts ...int64) []*LicenseUsage {
+		history := []*LicenseUsage{}
+		for i, count := range counts {
+			history = append(history, &LicenseUsage{Date: "2019-10-0" + string('1'+rune(i)), RegisteredUsers: count})
+		}
+		return history
+	}
+
+	assert.Equal(t, "", ForecastLicenseSeatsExhaustion(nil, 100))
+	assert.Equal(t, "", ForecastLicenseSeatsExhaustion(usages(50), 100))
+	assert.Equal(t, "", ForecastLicenseSeatsExhaustion(usages(50, 50, 50), 100), "registrations aren't growing")
+	assert.Equal(t, "", ForecastLicenseSeatsExhaustion(usages(50, 49, 48), 100), "registrations are shrinking")
+	assert.Equal(t, "", ForecastLicenseSeatsExhaustion(usages(50, 50, 51), 100000), "too far ahead")
+
+	assert.Equal(t, "2019-10-03", ForecastLicenseSeatsExhaustion(usages(80, 90, 100), 100), "seats already taken")
+	assert.Equal(t, "2019-10-07", ForecastLicenseSeatsExhaustion(usages(60, 70, 80), 120))
+	assert.Equal(t, "2019-10-04", ForecastLicenseSeatsExhaustion(usages(60, 70, 80), 81))
+}
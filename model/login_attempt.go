@@ -0,0 +1,103 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	LOGIN_ATTEMPT_DEVICE_MAX_LENGTH = 256
+
+	LOGIN_ANOMALY_NEW_COUNTRY = "new_country"
+	LOGIN_ANOMALY_NEW_DEVICE  = "new_device"
+)
+
+// LoginAttempt records a successful or failed attempt to log in as a user with their password, with where it came
+// from. Failed attempts lock the account and successful ones are compared against the previous ones to tell the
+// user when they log in from a new country or device.
+type LoginAttempt struct {
+	Id        string `json:"id"`
+	UserId    string `json:"user_id"`
+	CreateAt  int64  `json:"create_at"`
+	Success   bool   `json:"success"`
+	IpAddress string `json:"ip_address"`
+	Country   string `json:"country"`
+	Device    string `json:"device"`
+}
+
+func (o *LoginAttempt) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+
+	if len(o.Device) > LOGIN_ATTEMPT_DEVICE_MAX_LENGTH {
+		o.Device = o.Device[:LOGIN_ATTEMPT_DEVICE_MAX_LENGTH]
+	}
+}
+
+func (o *LoginAttempt) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("LoginAttempt.IsValid", "model.login_attempt.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("LoginAttempt.IsValid", "model.login_attempt.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("LoginAttempt.IsValid", "model.login_attempt.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.IpAddress) > 64 || len(o.Country) > 2 || len(o.Device) > LOGIN_ATTEMPT_DEVICE_MAX_LENGTH {
+		return NewAppError("LoginAttempt.IsValid", "model.login_attempt.is_valid.location.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// DetectLoginAnomalies compares a successful login with the previous ones of the user and returns how it stands
+// out. A first login has nothing to be compared with, and a country is only new when previous logins had one.
+func DetectLoginAnomalies(previous []*LoginAttempt, attempt *LoginAttempt) []string {
+	if len(previous) == 0 {
+		return nil
+	}
+
+	knownCountry, knownDevice := false, false
+	hasCountries, hasDevices := false, false
+	for _, p := range previous {
+		hasCountries = hasCountries || p.Country != ""
+		hasDevices = hasDevices || p.Device != ""
+		knownCountry = knownCountry || p.Country == attempt.Country
+		knownDevice = knownDevice || p.Device == attempt.Device
+	}
+
+	var anomalies []string
+	if attempt.Country != "" && hasCountries && !knownCountry {
+		anomalies = append(anomalies, LOGIN_ANOMALY_NEW_COUNTRY)
+	}
+
+	if attempt.Device != "" && hasDevices && !knownDevice {
+		anomalies = append(anomalies, LOGIN_ANOMALY_NEW_DEVICE)
+	}
+
+	return anomalies
+}
+
+func LoginAttemptListToJson(o []*LoginAttempt) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func LoginAttemptListFromJson(data io.Reader) []*LoginAttempt {
+	var o []*LoginAttempt
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAttemptIsValid(t *testing.T) {
+	attempt := &LoginAttempt{UserId: NewId(), Device: strings.Repeat("a", LOGIN_ATTEMPT_DEVICE_MAX_LENGTH+1)}
+	require.NotNil(t, attempt.IsValid())
+
+	attempt.PreSave()
+	assert.Len(t, attempt.Device, LOGIN_ATTEMPT_DEVICE_MAX_LENGTH)
+	require.Nil(t, attempt.IsValid())
+
+	attempt.Country = "USA"
+	require.NotNil(t, attempt.IsValid())
+}
+
+func TestDetectLoginAnomalies(t *testing.T) {
+	attempt := &LoginAttempt{Country: "CA", Device: "Linux/Linux/Firefox"}
+
+	assert.Empty(t, DetectLoginAnomalies(nil, attempt))
+
+	previous := []*LoginAttempt{
+		{Country: "CA", Device: "Macintosh/Mac OS/Chrome"},
+		{Country: "US", Device: "Linux/Linux/Firefox"},
+	}
+	assert.Empty(t, DetectLoginAnomalies(previous, attempt))
+
+	assert.Equal(t, []string{LOGIN_ANOMALY_NEW_COUNTRY}, DetectLoginAnomalies(previous, &LoginAttempt{Country: "FR", Device: "Linux/Linux/Firefox"}))
+	assert.Equal(t, []string{LOGIN_ANOMALY_NEW_DEVICE}, DetectLoginAnomalies(previous, &LoginAttempt{Country: "US", Device: "Windows/Windows/Edge"}))
+	assert.Equal(t, []string{LOGIN_ANOMALY_NEW_COUNTRY, LOGIN_ANOMALY_NEW_DEVICE}, DetectLoginAnomalies(previous, &LoginAttempt{Country: "FR", Device: "Windows/Windows/Edge"}))
+
+	t.Run("unknown country", func(t *testing.T) {
+		assert.Empty(t, DetectLoginAnomalies(previous, &LoginAttempt{Device: "Linux/Linux/Firefox"}))
+		assert.Empty(t, DetectLoginAnomalies([]*LoginAttempt{{Device: "Linux/Linux/Firefox"}}, attempt))
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	MENTION_ALIAS_TYPE_USERS   = "users"
+	MENTION_ALIAS_TYPE_GROUP   = "group"
+	MENTION_ALIAS_TYPE_CHANNEL = "channel"
+	MENTION_ALIAS_TYPE_ALIAS   = "alias"
+
+	MENTION_ALIAS_NAME_MAX_LENGTH        = 64
+	MENTION_ALIAS_DESCRIPTION_MAX_LENGTH = 255
+	MENTION_ALIAS_MAX_TARGETS            = 256
+
+	// MENTION_ALIAS_MAX_DEPTH is the number of aliases that can be followed when an alias expands to other aliases.
+	MENTION_ALIAS_MAX_DEPTH = 5
+)
+
+// MentionAlias is an admin-defined at-mention, such as @frontend-team, that expands to a set of users, the
+// members of a group or other aliases, or that redirects the mention to a channel.
+type MentionAlias struct {
+	Id          string      `json:"id"`
+	CreateAt    int64       `json:"create_at"`
+	UpdateAt    int64       `json:"update_at"`
+	DeleteAt    int64       `json:"delete_at"`
+	CreatorId   string      `json:"creator_id"`
+	TeamId      string      `json:"team_id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	TargetIds   StringArray `json:"target_ids"`
+}
+
+func (o *MentionAlias) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("MentionAlias.IsValid", "model.mention_alias.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("MentionAlias.IsValid", "model.mention_alias.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("MentionAlias.IsValid", "model.mention_alias.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.CreatorId) > 26 {
+		return NewAppError("MentionAlias.IsValid", "model.mention_alias.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.TeamId) != 0 && len(o.TeamId) != 26 {
+		return NewAppError("MentionAlias.IsValid", "model.mention_alias.is_valid.team_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidMentionAliasName(o.Name) {
+		return NewAppError("MentionAlias.IsValid", "model.mention_alias.is_valid.name.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Description) > MENTION_ALIAS_DESCRIPTION_MAX_LENGTH {
+		return NewAppError("MentionAlias.IsValid", "model.mention_alias.is_valid.description.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	maxTargets := MENTION_ALIAS_MAX_TARGETS
+	switch o.Type {
+	case MENTION_ALIAS_TYPE_USERS, MENTION_ALIAS_TYPE_ALIAS:
+	case MENTION_ALIAS_TYPE_GROUP, MENTION_ALIAS_TYPE_CHANNEL:
+		maxTargets = 1
+	default:
+		return NewAppError("MentionAlias.IsValid", "model.mention_alias.is_valid.type.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.TargetIds) == 0 || len(o.TargetIds) > maxTargets {
+		return NewAppError("MentionAlias.IsValid", "model.mention_alias.is_valid.target_ids.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, targetId := range o.TargetIds {
+		if len(targetId) != 26 || targetId == o.Id {
+			return NewAppError("MentionAlias.IsValid", "model.mention_alias.is_valid.target_ids.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// NormalizeMentionAliasName returns the name an alias is saved with: lower case and without the @.
+func NormalizeMentionAliasName(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "@"))
+}
+
+// IsValidMentionAliasName returns true if the name can be used as an alias. Names are lower case, given
+// without the @ and can't be one of the special mentions.
+func IsValidMentionAliasName(name string) bool {
+	if len(name) == 0 || len(name) > MENTION_ALIAS_NAME_MAX_LENGTH || !IsValidAlphaNumHyphenUnderscore(name, true) {
+		return false
+	}
+
+	switch name {
+	case "here", "channel", "all":
+		return false
+	}
+
+	return true
+}
+
+func (o *MentionAlias) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.Name = NormalizeMentionAliasName(o.Name)
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *MentionAlias) PreUpdate() {
+	o.Name = NormalizeMentionAliasName(o.Name)
+	o.UpdateAt = GetMillis()
+}
+
+// Mention returns the keyword the alias is mentioned with.
+func (o *MentionAlias) Mention() string {
+	return "@" + o.Name
+}
+
+func (o *MentionAlias) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func MentionAliasFromJson(data io.Reader) *MentionAlias {
+	var o *MentionAlias
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func MentionAliasListToJson(l []*MentionAlias) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func MentionAliasListFromJson(data io.Reader) []*MentionAlias {
+	var o []*MentionAlias
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMentionAliasJson(t *testing.T) {
+	alias := MentionAlias{Id: NewId(), Name: "frontend-team", Type: MENTION_ALIAS_TYPE_USERS, TargetIds: StringArray{NewId()}}
+	result := MentionAliasFromJson(strings.NewReader(alias.ToJson()))
+	assert.Equal(t, alias, *result)
+
+	list := MentionAliasListFromJson(strings.NewReader(MentionAliasListToJson([]*MentionAlias{&alias})))
+	require.Len(t, list, 1)
+	assert.Equal(t, alias, *list[0])
+}
+
+func TestMentionAliasIsValid(t *testing.T) {
+	alias := MentionAlias{Name: "@Frontend-Team", Type: MENTION_ALIAS_TYPE_USERS, TargetIds: StringArray{NewId(), NewId()}}
+	alias.PreSave()
+	assert.Equal(t, "frontend-team", alias.Name)
+	require.Nil(t, alias.IsValid())
+
+	for name, test := range map[string]struct {
+		Update func(alias *MentionAlias)
+		ErrId  string
+	}{
+		"team id":            {func(o *MentionAlias) { o.TeamId = "abc" }, "model.mention_alias.is_valid.team_id.app_error"},
+		"special mention":    {func(o *MentionAlias) { o.Name = "channel" }, "model.mention_alias.is_valid.name.app_error"},
+		"invalid name":       {func(o *MentionAlias) { o.Name = "front end" }, "model.mention_alias.is_valid.name.app_error"},
+		"type":               {func(o *MentionAlias) { o.Type = "role" }, "model.mention_alias.is_valid.type.app_error"},
+		"no targets":         {func(o *MentionAlias) { o.TargetIds = StringArray{} }, "model.mention_alias.is_valid.target_ids.app_error"},
+		"two channels":       {func(o *MentionAlias) { o.Type = MENTION_ALIAS_TYPE_CHANNEL }, "model.mention_alias.is_valid.target_ids.app_error"},
+		"itself":             {func(o *MentionAlias) { o.Type = MENTION_ALIAS_TYPE_ALIAS; o.TargetIds = StringArray{o.Id} }, "model.mention_alias.is_valid.target_ids.app_error"},
+		"invalid target id":  {func(o *MentionAlias) { o.TargetIds = StringArray{"abc"} }, "model.mention_alias.is_valid.target_ids.app_error"},
+		"description length": {func(o *MentionAlias) { o.Description = strings.Repeat("a", MENTION_ALIAS_DESCRIPTION_MAX_LENGTH+1) }, "model.mention_alias.is_valid.description.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := alias
+			test.Update(&invalid)
+
+			err := invalid.IsValid()
+			require.NotNil(t, err)
+			assert.Equal(t, test.ErrId, err.Id)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	RESOLVED_MENTION_TYPE_USER    = "user"
+	RESOLVED_MENTION_TYPE_CHANNEL = "channel"
+	RESOLVED_MENTION_TYPE_ALIAS   = "alias"
+	RESOLVED_MENTION_TYPE_SPECIAL = "special"
+
+	// RESOLVED_MENTION_WARNING_NOT_IN_CHANNEL is given to mentions of users that can be added to the channel but
+	// aren't members of it, so they won't be notified.
+	RESOLVED_MENTION_WARNING_NOT_IN_CHANNEL = "not_in_channel"
+	// RESOLVED_MENTION_WARNING_NOT_IN_GROUPS is given to mentions of users that aren't members of the channel and
+	// can't be added to it since they aren't in the groups the channel is constrained to.
+	RESOLVED_MENTION_WARNING_NOT_IN_GROUPS = "not_in_groups"
+	// RESOLVED_MENTION_WARNING_DISABLED is given to @here, @channel and @all when the channel has more members than
+	// they can notify.
+	RESOLVED_MENTION_WARNING_DISABLED = "disabled"
+	// RESOLVED_MENTION_WARNING_NO_MEMBERS is given to mentions of aliases that don't expand to any member of the
+	// channel.
+	RESOLVED_MENTION_WARNING_NO_MEMBERS = "no_members"
+)
+
+// ResolvedMention is a mention found in a message, along with what it refers to. UserCount is the number of members
+// of the channel the mention notifies, for aliases and special mentions. TeamId is the team of channels mentioned from
+// another team.
+type ResolvedMention struct {
+	Mention   string `json:"mention"`
+	Type      string `json:"type"`
+	Id        string `json:"id,omitempty"`
+	TeamId    string `json:"team_id,omitempty"`
+	UserCount int    `json:"user_count,omitempty"`
+	Warning   string `json:"warning,omitempty"`
+}
+
+// MentionResolution describes what the mentions of a message would resolve to if it was posted in a channel.
+// NotificationCount is an estimate of the number of users who would be notified by the mentions.
+type MentionResolution struct {
+	Mentions          []*ResolvedMention `json:"mentions"`
+	NotificationCount int                `json:"notification_count"`
+}
+
+// HasWarnings returns true if any of the mentions has a warning.
+func (o *MentionResolution) HasWarnings() bool {
+	for _, mention := range o.Mentions {
+		if mention.Warning != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetMention returns the resolved mention with the given text, such as "@username" or "~channel-name", if any.
+func (o *MentionResolution) GetMention(mention string) *ResolvedMention {
+	for _, resolved := range o.Mentions {
+		if resolved.Mention == mention {
+			return resolved
+		}
+	}
+
+	return nil
+}
+
+func (o *MentionResolution) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func MentionResolutionFromJson(data io.Reader) *MentionResolution {
+	var o *MentionResolution
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMentionResolutionJson(t *testing.T) {
+	resolution := MentionResolution{
+		Mentions: []*ResolvedMention{
+			{Mention: "@user", Type: RESOLVED_MENTION_TYPE_USER, Id: NewId()},
+			{Mention: "@channel", Type: RESOLVED_MENTION_TYPE_SPECIAL, UserCount: 12},
+		},
+		NotificationCount: 13,
+	}
+
+	result := MentionResolutionFromJson(strings.NewReader(resolution.ToJson()))
+	assert.Equal(t, resolution, *result)
+}
+
+func TestMentionResolutionWarnings(t *testing.T) {
+	resolution := MentionResolution{
+		Mentions: []*ResolvedMention{
+			{Mention: "@user", Type: RESOLVED_MENTION_TYPE_USER, Id: NewId()},
+			{Mention: "~town-square", Type: RESOLVED_MENTION_TYPE_CHANNEL, Id: NewId()},
+		},
+	}
+
+	assert.False(t, resolution.HasWarnings())
+	assert.Nil(t, resolution.GetMention("@other"))
+
+	resolution.Mentions = append(resolution.Mentions, &ResolvedMention{Mention: "@other", Type: RESOLVED_MENTION_TYPE_USER, Id: NewId(), Warning: RESOLVED_MENTION_WARNING_NOT_IN_CHANNEL})
+
+	assert.True(t, resolution.HasWarnings())
+	assert.Equal(t, RESOLVED_MENTION_WARNING_NOT_IN_CHANNEL, resolution.GetMention("@other").Warning)
+}
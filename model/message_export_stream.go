@@ -0,0 +1,226 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MESSAGE_EXPORT_CHANGE_CREATED  = "created"
+	MESSAGE_EXPORT_CHANGE_EDITED   = "edited"
+	MESSAGE_EXPORT_CHANGE_DELETED  = "deleted"
+	MESSAGE_EXPORT_CHANGE_REDACTED = "redacted"
+
+	MESSAGE_EXPORT_CONSUMER_NAME_MAX_RUNES = 64
+	MESSAGE_EXPORT_CHANGES_DEFAULT_LIMIT   = 200
+	MESSAGE_EXPORT_CHANGES_MAX_LIMIT       = 1000
+)
+
+// MessageExportCursor is a position in the stream of message export changes, which are ordered by the time the posts
+// were last updated and then by their ids. It's passed around as an opaque token.
+type MessageExportCursor struct {
+	UpdateAt int64
+	PostId   string
+}
+
+// Token encodes the cursor for consumers to resume the stream from. The zero cursor is the empty token, which starts
+// the stream from the beginning.
+func (c MessageExportCursor) Token() string {
+	if c.UpdateAt == 0 && c.PostId == "" {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.UpdateAt, 10) + ":" + c.PostId))
+}
+
+// MessageExportCursorFromToken decodes a token returned by Token, returning false if it isn't one.
+func MessageExportCursorFromToken(token string) (MessageExportCursor, bool) {
+	if token == "" {
+		return MessageExportCursor{}, true
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return MessageExportCursor{}, false
+	}
+
+	parts := strings.SplitN(string(b), ":", 2)
+	if len(parts) != 2 || (parts[1] != "" && !IsValidId(parts[1])) {
+		return MessageExportCursor{}, false
+	}
+
+	updateAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || updateAt < 0 {
+		return MessageExportCursor{}, false
+	}
+
+	return MessageExportCursor{UpdateAt: updateAt, PostId: parts[1]}, true
+}
+
+// MessageExportConsumer is an external archiving system, such as Global Relay or Smarsh, that continuously ingests the
+// message export stream. The server keeps its cursor so that it resumes where it last acknowledged.
+type MessageExportConsumer struct {
+	Id             string `json:"id"`
+	CreateAt       int64  `json:"create_at"`
+	UpdateAt       int64  `json:"update_at"`
+	CreatorId      string `json:"creator_id"`
+	Name           string `json:"name"`
+	CursorUpdateAt int64  `json:"-"`
+	CursorPostId   string `json:"-"`
+	Cursor         string `json:"cursor" db:"-"`
+	LastAckAt      int64  `json:"last_ack_at"`
+}
+
+func (o *MessageExportConsumer) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("MessageExportConsumer.IsValid", "model.message_export_consumer.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("MessageExportConsumer.IsValid", "model.message_export_consumer.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("MessageExportConsumer.IsValid", "model.message_export_consumer.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("MessageExportConsumer.IsValid", "model.message_export_consumer.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Name == "" || utf8.RuneCountInString(o.Name) > MESSAGE_EXPORT_CONSUMER_NAME_MAX_RUNES {
+		return NewAppError("MessageExportConsumer.IsValid", "model.message_export_consumer.is_valid.name.app_error", map[string]interface{}{"Max": MESSAGE_EXPORT_CONSUMER_NAME_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CursorPostId != "" && !IsValidId(o.CursorPostId) {
+		return NewAppError("MessageExportConsumer.IsValid", "model.message_export_consumer.is_valid.cursor.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *MessageExportConsumer) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *MessageExportConsumer) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+// GetCursor returns the position the consumer last acknowledged.
+func (o *MessageExportConsumer) GetCursor() MessageExportCursor {
+	return MessageExportCursor{UpdateAt: o.CursorUpdateAt, PostId: o.CursorPostId}
+}
+
+// SetCursor moves the consumer to a position in the stream.
+func (o *MessageExportConsumer) SetCursor(cursor MessageExportCursor) {
+	o.CursorUpdateAt = cursor.UpdateAt
+	o.CursorPostId = cursor.PostId
+	o.Cursor = cursor.Token()
+}
+
+func (o *MessageExportConsumer) ToJson() string {
+	o.Cursor = o.GetCursor().Token()
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func MessageExportConsumerFromJson(data io.Reader) *MessageExportConsumer {
+	var o *MessageExportConsumer
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func MessageExportConsumerListToJson(l []*MessageExportConsumer) string {
+	for _, o := range l {
+		o.Cursor = o.GetCursor().Token()
+	}
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func MessageExportConsumerListFromJson(data io.Reader) []*MessageExportConsumer {
+	var o []*MessageExportConsumer
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// MessageExportChange is a change record in the message export stream. It holds the state of a post as of its last
+// update, so a post that's edited or deleted appears again further along the stream, and consumers keep the latest
+// record of each post along with the history they've already ingested.
+type MessageExportChange struct {
+	ChangeType string      `json:"change_type"`
+	PostId     string      `json:"post_id"`
+	CreateAt   int64       `json:"create_at"`
+	UpdateAt   int64       `json:"update_at"`
+	EditAt     int64       `json:"edit_at"`
+	DeleteAt   int64       `json:"delete_at"`
+	RootId     string      `json:"root_id"`
+	Type       string      `json:"type"`
+	Message    string      `json:"message"`
+	Props      string      `json:"props"`
+	FileIds    StringArray `json:"file_ids"`
+
+	TeamId             string `json:"team_id"`
+	TeamName           string `json:"team_name"`
+	ChannelId          string `json:"channel_id"`
+	ChannelName        string `json:"channel_name"`
+	ChannelDisplayName string `json:"channel_display_name"`
+	ChannelType        string `json:"channel_type"`
+
+	UserId    string `json:"user_id"`
+	Username  string `json:"username"`
+	UserEmail string `json:"user_email"`
+	IsBot     bool   `json:"is_bot"`
+}
+
+// IsRedacted reports whether an admin redacted the content of the post.
+func (o *MessageExportChange) IsRedacted() bool {
+	_, ok := StringInterfaceFromJson(strings.NewReader(o.Props))[POST_PROPS_REDACTED_AT]
+	return ok
+}
+
+// SetChangeType sets the type of the change from the state of the post.
+func (o *MessageExportChange) SetChangeType() {
+	switch {
+	case o.DeleteAt != 0:
+		o.ChangeType = MESSAGE_EXPORT_CHANGE_DELETED
+	case o.IsRedacted():
+		o.ChangeType = MESSAGE_EXPORT_CHANGE_REDACTED
+	case o.EditAt != 0:
+		o.ChangeType = MESSAGE_EXPORT_CHANGE_EDITED
+	default:
+		o.ChangeType = MESSAGE_EXPORT_CHANGE_CREATED
+	}
+}
+
+// MessageExportChangeList is a page of the message export stream, along with the cursor to fetch the next page from.
+type MessageExportChangeList struct {
+	Changes []*MessageExportChange `json:"changes"`
+	Cursor  string                 `json:"cursor"`
+	HasMore bool                   `json:"has_more"`
+}
+
+func (o *MessageExportChangeList) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func MessageExportChangeListFromJson(data io.Reader) *MessageExportChangeList {
+	var o *MessageExportChangeList
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
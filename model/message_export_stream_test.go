@@ -0,0 +1,81 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageExportCursorToken(t *testing.T) {
+	assert.Equal(t, "", MessageExportCursor{}.Token())
+
+	cursor, ok := MessageExportCursorFromToken("")
+	require.True(t, ok)
+	assert.Equal(t, MessageExportCursor{}, cursor)
+
+	expected := MessageExportCursor{UpdateAt: GetMillis(), PostId: NewId()}
+	cursor, ok = MessageExportCursorFromToken(expected.Token())
+	require.True(t, ok)
+	assert.Equal(t, expected, cursor)
+
+	for _, token := range []string{"not base64!", "MTIz", "YWJjOg", "LTE6"} {
+		_, ok = MessageExportCursorFromToken(token)
+		assert.False(t, ok, token)
+	}
+}
+
+func TestMessageExportConsumerIsValid(t *testing.T) {
+	consumer := MessageExportConsumer{CreatorId: NewId(), Name: "Archive"}
+	consumer.PreSave()
+	require.Nil(t, consumer.IsValid())
+
+	for name, update := range map[string]func(c *MessageExportConsumer){
+		"creator id":   func(c *MessageExportConsumer) { c.CreatorId = "" },
+		"empty name":   func(c *MessageExportConsumer) { c.Name = "" },
+		"long name":    func(c *MessageExportConsumer) { c.Name = strings.Repeat("a", MESSAGE_EXPORT_CONSUMER_NAME_MAX_RUNES+1) },
+		"cursor":       func(c *MessageExportConsumer) { c.CursorPostId = "abc" },
+		"missing date": func(c *MessageExportConsumer) { c.UpdateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := consumer
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestMessageExportConsumerJson(t *testing.T) {
+	consumer := MessageExportConsumer{Id: NewId(), Name: "Archive"}
+	consumer.SetCursor(MessageExportCursor{UpdateAt: 1000, PostId: NewId()})
+
+	result := MessageExportConsumerFromJson(strings.NewReader(consumer.ToJson()))
+	assert.Equal(t, consumer.Cursor, result.Cursor)
+	assert.Zero(t, result.CursorUpdateAt)
+
+	list := MessageExportConsumerListFromJson(strings.NewReader(MessageExportConsumerListToJson([]*MessageExportConsumer{&consumer})))
+	require.Len(t, list, 1)
+	assert.Equal(t, consumer.Cursor, list[0].Cursor)
+}
+
+func TestMessageExportChangeSetChangeType(t *testing.T) {
+	change := MessageExportChange{CreateAt: 1000, UpdateAt: 1000}
+	change.SetChangeType()
+	assert.Equal(t, MESSAGE_EXPORT_CHANGE_CREATED, change.ChangeType)
+
+	change.EditAt = 2000
+	change.SetChangeType()
+	assert.Equal(t, MESSAGE_EXPORT_CHANGE_EDITED, change.ChangeType)
+
+	change.Props = `{"redacted_at":2500,"redacted_by":"` + NewId() + `"}`
+	change.SetChangeType()
+	assert.Equal(t, MESSAGE_EXPORT_CHANGE_REDACTED, change.ChangeType)
+
+	change.DeleteAt = 3000
+	change.SetChangeType()
+	assert.Equal(t, MESSAGE_EXPORT_CHANGE_DELETED, change.ChangeType)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	MFA_RECOVERY_CODES_COUNT = 10
+	MFA_RECOVERY_CODE_LENGTH = 12
+)
+
+// MfaRecoveryCode is a one-time code a user can log in with instead of an MFA token, when they lost their
+// authenticator. Only the hash of the code is kept, since the codes are random enough not to need salting.
+type MfaRecoveryCode struct {
+	Id       string `json:"id"`
+	UserId   string `json:"user_id"`
+	CodeHash string `json:"-"`
+	CreateAt int64  `json:"create_at"`
+	UsedAt   int64  `json:"used_at"`
+}
+
+// MfaRecoveryCodes holds the recovery codes of a user, which are only ever shown once when they are generated.
+type MfaRecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// MfaAdoption counts the users who can log in with MFA among those who could enable it.
+type MfaAdoption struct {
+	TotalUsers     int64 `json:"total_users"`
+	MfaActiveUsers int64 `json:"mfa_active_users"`
+}
+
+func (o *MfaRecoveryCode) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func (o *MfaRecoveryCode) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.CodeHash) != sha256.Size*2 {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.code_hash.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// NewMfaRecoveryCodes generates a set of recovery codes for a user, returning the codes to show them and the
+// hashed codes to save.
+func NewMfaRecoveryCodes(userId string) ([]string, []*MfaRecoveryCode) {
+	codes := make([]string, MFA_RECOVERY_CODES_COUNT)
+	recoveryCodes := make([]*MfaRecoveryCode, MFA_RECOVERY_CODES_COUNT)
+
+	for i := range codes {
+		code := NewRandomString(MFA_RECOVERY_CODE_LENGTH)
+		codes[i] = code[:MFA_RECOVERY_CODE_LENGTH/2] + "-" + code[MFA_RECOVERY_CODE_LENGTH/2:]
+		recoveryCodes[i] = &MfaRecoveryCode{UserId: userId, CodeHash: HashMfaRecoveryCode(code)}
+	}
+
+	return codes, recoveryCodes
+}
+
+func normalizeMfaRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+}
+
+// IsMfaRecoveryCode returns whether a token entered in place of an MFA token looks like a recovery code.
+func IsMfaRecoveryCode(token string) bool {
+	return len(normalizeMfaRecoveryCode(token)) == MFA_RECOVERY_CODE_LENGTH
+}
+
+// HashMfaRecoveryCode returns the hash a recovery code is saved as, ignoring the case and the separators it was
+// entered with.
+func HashMfaRecoveryCode(code string) string {
+	hash := sha256.Sum256([]byte(normalizeMfaRecoveryCode(code)))
+	return hex.EncodeToString(hash[:])
+}
+
+func (o *MfaRecoveryCodes) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func MfaRecoveryCodesFromJson(data io.Reader) *MfaRecoveryCodes {
+	var o *MfaRecoveryCodes
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *MfaAdoption) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func MfaAdoptionFromJson(data io.Reader) *MfaAdoption {
+	var o *MfaAdoption
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMfaRecoveryCodes(t *testing.T) {
+	userId := NewId()
+	codes, recoveryCodes := NewMfaRecoveryCodes(userId)
+
+	require.Len(t, codes, MFA_RECOVERY_CODES_COUNT)
+	require.Len(t, recoveryCodes, MFA_RECOVERY_CODES_COUNT)
+
+	seen := map[string]bool{}
+	for i, code := range codes {
+		assert.True(t, IsMfaRecoveryCode(code))
+		assert.False(t, seen[code])
+		seen[code] = true
+
+		recoveryCode := recoveryCodes[i]
+		assert.Equal(t, userId, recoveryCode.UserId)
+		assert.Equal(t, HashMfaRecoveryCode(code), recoveryCode.CodeHash)
+		assert.NotContains(t, recoveryCode.CodeHash, strings.Replace(code, "-", "", 1))
+
+		recoveryCode.PreSave()
+		assert.Nil(t, recoveryCode.IsValid())
+	}
+}
+
+func TestHashMfaRecoveryCode(t *testing.T) {
+	assert.Equal(t, HashMfaRecoveryCode("abcdef-ghijkm"), HashMfaRecoveryCode(" ABCDEF GHIJKM "))
+	assert.Equal(t, HashMfaRecoveryCode("abcdef-ghijkm"), HashMfaRecoveryCode("abcdefghijkm"))
+	assert.NotEqual(t, HashMfaRecoveryCode("abcdef-ghijkm"), HashMfaRecoveryCode("abcdef-ghijkn"))
+
+	assert.False(t, IsMfaRecoveryCode("123456"))
+	assert.True(t, IsMfaRecoveryCode("ABCDEF-GHIJKM"))
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"net"
+	"strings"
+)
+
+const (
+	NETWORK_ACCESS_DENIED_IP_ADDRESS      = "ip_address"
+	NETWORK_ACCESS_DENIED_ROLE_IP_ADDRESS = "role_ip_address"
+	NETWORK_ACCESS_DENIED_COUNTRY         = "country"
+)
+
+// CheckAccess returns why a user with the given roles can't connect from the IP address and country, or an empty
+// string when they can. The IP address must be allowed globally and by each of the roles of the user that has IP
+// ranges of its own. A request from an unknown country is only denied when the allowed countries are set.
+func (s *NetworkAccessSettings) CheckAccess(userId string, roles []string, ipAddress, country string) string {
+	if !*s.Enable {
+		return ""
+	}
+
+	for _, bypassUserId := range s.BypassUserIds {
+		if bypassUserId == userId {
+			return ""
+		}
+	}
+
+	if !IsIpAddressAllowed(*s.AllowedIPRanges, ipAddress) {
+		return NETWORK_ACCESS_DENIED_IP_ADDRESS
+	}
+
+	for _, role := range roles {
+		if ranges, ok := s.RoleAllowedIPRanges[role]; ok && !IsIpAddressAllowed(ranges, ipAddress) {
+			return NETWORK_ACCESS_DENIED_ROLE_IP_ADDRESS
+		}
+	}
+
+	if *s.CountryHeader != "" && !s.isCountryAllowed(strings.ToUpper(strings.TrimSpace(country))) {
+		return NETWORK_ACCESS_DENIED_COUNTRY
+	}
+
+	return ""
+}
+
+func (s *NetworkAccessSettings) isCountryAllowed(country string) bool {
+	for _, blocked := range strings.Fields(*s.BlockedCountries) {
+		if strings.EqualFold(blocked, country) {
+			return false
+		}
+	}
+
+	allowed := strings.Fields(*s.AllowedCountries)
+	if len(allowed) == 0 {
+		return true
+	}
+
+	for _, allowedCountry := range allowed {
+		if strings.EqualFold(allowedCountry, country) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsValidIpRanges returns true if each of the space separated values is an IP address or a CIDR range.
+func IsValidIpRanges(ranges string) bool {
+	for _, value := range strings.Fields(ranges) {
+		if _, _, err := net.ParseCIDR(value); err != nil && net.ParseIP(value) == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isValidCountryCode(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+
+	for _, c := range country {
+		if !(c >= 'A' && c <= 'Z') && !(c >= 'a' && c <= 'z') {
+			return false
+		}
+	}
+
+	return true
+}
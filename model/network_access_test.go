@@ -0,0 +1,88 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkAccessSettingsCheckAccess(t *testing.T) {
+	userId := NewId()
+	bypassUserId := NewId()
+
+	s := &NetworkAccessSettings{}
+	s.SetDefaults()
+	assert.Equal(t, "", s.CheckAccess(userId, nil, "10.0.0.1", ""))
+
+	*s.Enable = true
+	*s.AllowedIPRanges = "10.0.0.0/8 192.168.1.1"
+	s.RoleAllowedIPRanges = map[string]string{SYSTEM_ADMIN_ROLE_ID: "10.1.0.0/16"}
+	s.BypassUserIds = []string{bypassUserId}
+
+	t.Run("ip address", func(t *testing.T) {
+		assert.Equal(t, "", s.CheckAccess(userId, []string{SYSTEM_USER_ROLE_ID}, "10.0.0.1", ""))
+		assert.Equal(t, "", s.CheckAccess(userId, []string{SYSTEM_USER_ROLE_ID}, "192.168.1.1", ""))
+		assert.Equal(t, NETWORK_ACCESS_DENIED_IP_ADDRESS, s.CheckAccess(userId, []string{SYSTEM_USER_ROLE_ID}, "192.168.1.2", ""))
+		assert.Equal(t, NETWORK_ACCESS_DENIED_IP_ADDRESS, s.CheckAccess(userId, []string{SYSTEM_USER_ROLE_ID}, "", ""))
+	})
+
+	t.Run("role ip address", func(t *testing.T) {
+		roles := []string{SYSTEM_USER_ROLE_ID, SYSTEM_ADMIN_ROLE_ID}
+		assert.Equal(t, "", s.CheckAccess(userId, roles, "10.1.2.3", ""))
+		assert.Equal(t, NETWORK_ACCESS_DENIED_ROLE_IP_ADDRESS, s.CheckAccess(userId, roles, "10.2.2.3", ""))
+	})
+
+	t.Run("bypass", func(t *testing.T) {
+		assert.Equal(t, "", s.CheckAccess(bypassUserId, []string{SYSTEM_ADMIN_ROLE_ID}, "172.16.0.1", ""))
+	})
+
+	t.Run("country", func(t *testing.T) {
+		s := &NetworkAccessSettings{}
+		s.SetDefaults()
+		*s.Enable = true
+		*s.BlockedCountries = "KP"
+
+		// Countries aren't checked without a header to read them from.
+		assert.Equal(t, "", s.CheckAccess(userId, nil, "10.0.0.1", "KP"))
+
+		*s.CountryHeader = "CF-IPCountry"
+		assert.Equal(t, NETWORK_ACCESS_DENIED_COUNTRY, s.CheckAccess(userId, nil, "10.0.0.1", "kp"))
+		assert.Equal(t, "", s.CheckAccess(userId, nil, "10.0.0.1", "FR"))
+		assert.Equal(t, "", s.CheckAccess(userId, nil, "10.0.0.1", ""))
+
+		*s.AllowedCountries = "FR DE"
+		assert.Equal(t, "", s.CheckAccess(userId, nil, "10.0.0.1", "DE"))
+		assert.Equal(t, NETWORK_ACCESS_DENIED_COUNTRY, s.CheckAccess(userId, nil, "10.0.0.1", "US"))
+		assert.Equal(t, NETWORK_ACCESS_DENIED_COUNTRY, s.CheckAccess(userId, nil, "10.0.0.1", ""))
+	})
+}
+
+func TestNetworkAccessSettingsIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		update func(s *NetworkAccessSettings)
+		valid  bool
+	}{
+		"defaults":               {func(s *NetworkAccessSettings) {}, true},
+		"ip ranges":              {func(s *NetworkAccessSettings) { *s.AllowedIPRanges = "10.0.0.0/8 ::1" }, true},
+		"invalid ip ranges":      {func(s *NetworkAccessSettings) { *s.AllowedIPRanges = "10.0.0.0/33" }, false},
+		"invalid role ip ranges": {func(s *NetworkAccessSettings) { s.RoleAllowedIPRanges["system_admin"] = "nope" }, false},
+		"countries":              {func(s *NetworkAccessSettings) { *s.AllowedCountries = "FR de" }, true},
+		"invalid country":        {func(s *NetworkAccessSettings) { *s.BlockedCountries = "FRA" }, false},
+		"invalid bypass user id": {func(s *NetworkAccessSettings) { s.BypassUserIds = []string{"junk"} }, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := &NetworkAccessSettings{}
+			s.SetDefaults()
+			tc.update(s)
+
+			if tc.valid {
+				assert.Nil(t, s.isValid())
+			} else {
+				assert.NotNil(t, s.isValid())
+			}
+		})
+	}
+}
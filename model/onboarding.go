@@ -0,0 +1,194 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// Manual steps are completed by the user themselves, the others when the server sees them done.
+	ONBOARDING_STEP_TYPE_MANUAL       = "manual"
+	ONBOARDING_STEP_TYPE_JOIN_CHANNEL = "join_channel"
+	ONBOARDING_STEP_TYPE_SET_AVATAR   = "set_avatar"
+	ONBOARDING_STEP_TYPE_INSTALL_APP  = "install_app"
+
+	ONBOARDING_STEP_DISPLAY_NAME_MAX_RUNES = 64
+	ONBOARDING_STEP_DESCRIPTION_MAX_RUNES  = 1024
+	ONBOARDING_MAX_STEPS                   = 50
+)
+
+// OnboardingStep is a step of the onboarding checklist shown to every user. Join channel steps are completed when
+// the user joins the channel.
+type OnboardingStep struct {
+	Id          string `json:"id"`
+	CreateAt    int64  `json:"create_at"`
+	UpdateAt    int64  `json:"update_at"`
+	DeleteAt    int64  `json:"delete_at"`
+	Type        string `json:"type"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	ChannelId   string `json:"channel_id"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// OnboardingStepCompletion records when a user completed a step.
+type OnboardingStepCompletion struct {
+	UserId     string `json:"user_id"`
+	StepId     string `json:"step_id"`
+	CompleteAt int64  `json:"complete_at"`
+}
+
+type OnboardingStepProgress struct {
+	Step       *OnboardingStep `json:"step"`
+	CompleteAt int64           `json:"complete_at"`
+}
+
+// OnboardingProgress is how far a user got through the onboarding checklist.
+type OnboardingProgress struct {
+	UserId    string                    `json:"user_id"`
+	Steps     []*OnboardingStepProgress `json:"steps"`
+	Completed int                       `json:"completed"`
+	Total     int                       `json:"total"`
+}
+
+func (o *OnboardingStep) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("OnboardingStep.IsValid", "model.onboarding_step.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("OnboardingStep.IsValid", "model.onboarding_step.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("OnboardingStep.IsValid", "model.onboarding_step.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Type {
+	case ONBOARDING_STEP_TYPE_MANUAL, ONBOARDING_STEP_TYPE_SET_AVATAR, ONBOARDING_STEP_TYPE_INSTALL_APP:
+		if o.ChannelId != "" {
+			return NewAppError("OnboardingStep.IsValid", "model.onboarding_step.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	case ONBOARDING_STEP_TYPE_JOIN_CHANNEL:
+		if !IsValidId(o.ChannelId) {
+			return NewAppError("OnboardingStep.IsValid", "model.onboarding_step.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("OnboardingStep.IsValid", "model.onboarding_step.is_valid.type.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.DisplayName == "" || utf8.RuneCountInString(o.DisplayName) > ONBOARDING_STEP_DISPLAY_NAME_MAX_RUNES {
+		return NewAppError("OnboardingStep.IsValid", "model.onboarding_step.is_valid.display_name.app_error", map[string]interface{}{"Max": ONBOARDING_STEP_DISPLAY_NAME_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Description) > ONBOARDING_STEP_DESCRIPTION_MAX_RUNES {
+		return NewAppError("OnboardingStep.IsValid", "model.onboarding_step.is_valid.description.app_error", map[string]interface{}{"Max": ONBOARDING_STEP_DESCRIPTION_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *OnboardingStep) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+	o.DisplayName = strings.TrimSpace(o.DisplayName)
+}
+
+func (o *OnboardingStep) PreUpdate() {
+	o.UpdateAt = GetMillis()
+	o.DisplayName = strings.TrimSpace(o.DisplayName)
+}
+
+// MakeDefaultOnboardingSteps returns the steps the onboarding checklist starts with.
+func MakeDefaultOnboardingSteps() []*OnboardingStep {
+	return []*OnboardingStep{
+		{
+			Type:        ONBOARDING_STEP_TYPE_SET_AVATAR,
+			DisplayName: "Set a profile picture",
+			Description: "Help your teammates recognize you by uploading a profile picture.",
+			SortOrder:   0,
+		},
+		{
+			Type:        ONBOARDING_STEP_TYPE_INSTALL_APP,
+			DisplayName: "Install the desktop and mobile apps",
+			Description: "Stay in touch with your team from your computer and your phone.",
+			SortOrder:   1,
+		},
+		{
+			Type:        ONBOARDING_STEP_TYPE_MANUAL,
+			DisplayName: "Say hello",
+			Description: "Introduce yourself to your team with your first message.",
+			SortOrder:   2,
+		},
+	}
+}
+
+// NewOnboardingProgress returns the progress of the user through the steps given the steps they completed.
+func NewOnboardingProgress(userId string, steps []*OnboardingStep, completions []*OnboardingStepCompletion) *OnboardingProgress {
+	completeAt := make(map[string]int64, len(completions))
+	for _, completion := range completions {
+		completeAt[completion.StepId] = completion.CompleteAt
+	}
+
+	progress := &OnboardingProgress{
+		UserId: userId,
+		Steps:  make([]*OnboardingStepProgress, 0, len(steps)),
+		Total:  len(steps),
+	}
+	for _, step := range steps {
+		progress.Steps = append(progress.Steps, &OnboardingStepProgress{Step: step, CompleteAt: completeAt[step.Id]})
+		if completeAt[step.Id] != 0 {
+			progress.Completed++
+		}
+	}
+
+	return progress
+}
+
+// IsComplete returns whether the user completed every step of a checklist that has any.
+func (o *OnboardingProgress) IsComplete() bool {
+	return o.Total > 0 && o.Completed == o.Total
+}
+
+func (o *OnboardingStep) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func OnboardingStepFromJson(data io.Reader) *OnboardingStep {
+	var o *OnboardingStep
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func OnboardingStepListToJson(l []*OnboardingStep) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func OnboardingStepListFromJson(data io.Reader) []*OnboardingStep {
+	var o []*OnboardingStep
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *OnboardingProgress) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func OnboardingProgressFromJson(data io.Reader) *OnboardingProgress {
+	var o *OnboardingProgress
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
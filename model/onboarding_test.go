@@ -0,0 +1,82 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnboardingStepJson(t *testing.T) {
+	step := OnboardingStep{Id: NewId(), Type: ONBOARDING_STEP_TYPE_JOIN_CHANNEL, DisplayName: "Join", ChannelId: NewId(), SortOrder: 2}
+	result := OnboardingStepFromJson(strings.NewReader(step.ToJson()))
+	assert.Equal(t, step, *result)
+
+	list := OnboardingStepListFromJson(strings.NewReader(OnboardingStepListToJson([]*OnboardingStep{&step})))
+	require.Len(t, list, 1)
+	assert.Equal(t, step, *list[0])
+}
+
+func TestOnboardingStepIsValid(t *testing.T) {
+	step := OnboardingStep{Type: ONBOARDING_STEP_TYPE_MANUAL, DisplayName: " Say hello "}
+	step.PreSave()
+	require.Nil(t, step.IsValid())
+	assert.Equal(t, "Say hello", step.DisplayName)
+
+	for _, step := range MakeDefaultOnboardingSteps() {
+		step.PreSave()
+		assert.Nil(t, step.IsValid())
+	}
+
+	for name, update := range map[string]func(s *OnboardingStep){
+		"id":                    func(s *OnboardingStep) { s.Id = "abc" },
+		"create at":             func(s *OnboardingStep) { s.CreateAt = 0 },
+		"update at":             func(s *OnboardingStep) { s.UpdateAt = 0 },
+		"type":                  func(s *OnboardingStep) { s.Type = "unknown" },
+		"display name":          func(s *OnboardingStep) { s.DisplayName = "" },
+		"description":           func(s *OnboardingStep) { s.Description = strings.Repeat("a", ONBOARDING_STEP_DESCRIPTION_MAX_RUNES+1) },
+		"channel id":            func(s *OnboardingStep) { s.ChannelId = NewId() },
+		"join channel, no id":   func(s *OnboardingStep) { s.Type = ONBOARDING_STEP_TYPE_JOIN_CHANNEL },
+		"join channel, bad id":  func(s *OnboardingStep) { s.Type, s.ChannelId = ONBOARDING_STEP_TYPE_JOIN_CHANNEL, "abc" },
+		"display name too long": func(s *OnboardingStep) { s.DisplayName = strings.Repeat("a", ONBOARDING_STEP_DISPLAY_NAME_MAX_RUNES+1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := step
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestNewOnboardingProgress(t *testing.T) {
+	userId := NewId()
+	steps := []*OnboardingStep{{Id: NewId()}, {Id: NewId()}}
+
+	progress := NewOnboardingProgress(userId, steps, []*OnboardingStepCompletion{
+		{UserId: userId, StepId: steps[1].Id, CompleteAt: 100},
+		{UserId: userId, StepId: NewId(), CompleteAt: 200},
+	})
+	assert.Equal(t, userId, progress.UserId)
+	assert.Equal(t, 2, progress.Total)
+	assert.Equal(t, 1, progress.Completed)
+	require.Len(t, progress.Steps, 2)
+	assert.Equal(t, steps[0], progress.Steps[0].Step)
+	assert.Zero(t, progress.Steps[0].CompleteAt)
+	assert.Equal(t, int64(100), progress.Steps[1].CompleteAt)
+	assert.False(t, progress.IsComplete())
+
+	progress = NewOnboardingProgress(userId, steps, []*OnboardingStepCompletion{
+		{UserId: userId, StepId: steps[0].Id, CompleteAt: 100},
+		{UserId: userId, StepId: steps[1].Id, CompleteAt: 200},
+	})
+	assert.True(t, progress.IsComplete())
+
+	assert.False(t, NewOnboardingProgress(userId, nil, nil).IsComplete(), "an empty checklist is never complete")
+
+	result := OnboardingProgressFromJson(strings.NewReader(progress.ToJson()))
+	assert.Equal(t, progress, result)
+}
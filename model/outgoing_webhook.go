@@ -30,6 +30,8 @@ type OutgoingWebhook struct {
 	ContentType  string      `json:"content_type"`
 	Username     string      `json:"username"`
 	IconURL      string      `json:"icon_url"`
+	EventTypes   StringArray `json:"event_types"`
+	TestMode     bool        `json:"test_mode"`
 }
 
 type OutgoingWebhookPayload struct {
@@ -45,6 +47,8 @@ type OutgoingWebhookPayload struct {
 	Text        string `json:"text"`
 	TriggerWord string `json:"trigger_word"`
 	FileIds     string `json:"file_ids"`
+	EventType   string `json:"event_type"`
+	EmojiName   string `json:"emoji_name,omitempty"`
 }
 
 type OutgoingWebhookResponse struct {
@@ -59,6 +63,25 @@ type OutgoingWebhookResponse struct {
 
 const OUTGOING_HOOK_RESPONSE_TYPE_COMMENT = "comment"
 
+const (
+	OUTGOING_WEBHOOK_EVENT_POST_CREATED         = "post_created"
+	OUTGOING_WEBHOOK_EVENT_FILE_ATTACHED        = "file_attached"
+	OUTGOING_WEBHOOK_EVENT_REACTION_ADDED       = "reaction_added"
+	OUTGOING_WEBHOOK_EVENT_CHANNEL_MEMBER_ADDED = "channel_member_added"
+)
+
+func IsValidOutgoingWebhookEventType(eventType string) bool {
+	switch eventType {
+	case OUTGOING_WEBHOOK_EVENT_POST_CREATED,
+		OUTGOING_WEBHOOK_EVENT_FILE_ATTACHED,
+		OUTGOING_WEBHOOK_EVENT_REACTION_ADDED,
+		OUTGOING_WEBHOOK_EVENT_CHANNEL_MEMBER_ADDED:
+		return true
+	}
+
+	return false
+}
+
 func (o *OutgoingWebhookPayload) ToJSON() string {
 	b, _ := json.Marshal(o)
 	return string(b)
@@ -78,6 +101,12 @@ func (o *OutgoingWebhookPayload) ToFormValues() string {
 	v.Set("text", o.Text)
 	v.Set("trigger_word", o.TriggerWord)
 	v.Set("file_ids", o.FileIds)
+	if o.EventType != "" {
+		v.Set("event_type", o.EventType)
+	}
+	if o.EmojiName != "" {
+		v.Set("emoji_name", o.EmojiName)
+	}
 
 	return v.Encode()
 }
@@ -191,6 +220,12 @@ func (o *OutgoingWebhook) IsValid() *AppError {
 		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	for _, eventType := range o.EventTypes {
+		if !IsValidOutgoingWebhookEventType(eventType) {
+			return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.event_types.app_error", nil, "event_type="+eventType, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -211,6 +246,22 @@ func (o *OutgoingWebhook) PreUpdate() {
 	o.UpdateAt = GetMillis()
 }
 
+// HasEventType returns true if the webhook subscribes to the given event. Webhooks without any event
+// types only subscribe to created posts.
+func (o *OutgoingWebhook) HasEventType(eventType string) bool {
+	if len(o.EventTypes) == 0 {
+		return eventType == OUTGOING_WEBHOOK_EVENT_POST_CREATED
+	}
+
+	for _, t := range o.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (o *OutgoingWebhook) TriggerWordExactMatch(word string) bool {
 	if len(word) == 0 {
 		return false
@@ -0,0 +1,49 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// OutgoingWebhookDelivery records a request made, or captured in test mode, for an outgoing webhook.
+type OutgoingWebhookDelivery struct {
+	Id           string `json:"id"`
+	HookId       string `json:"hook_id"`
+	CreateAt     int64  `json:"create_at"`
+	EventType    string `json:"event_type"`
+	URL          string `json:"url"`
+	ContentType  string `json:"content_type"`
+	RequestBody  string `json:"request_body"`
+	Delivered    bool   `json:"delivered"`
+	StatusCode   int    `json:"status_code,omitempty"`
+	ResponseBody string `json:"response_body,omitempty"`
+	Error        string `json:"error,omitempty"`
+
+	// ReplayOf holds the id of the delivery that was replayed to make this one.
+	ReplayOf string `json:"replay_of,omitempty"`
+}
+
+func (o *OutgoingWebhookDelivery) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func OutgoingWebhookDeliveryFromJson(data io.Reader) *OutgoingWebhookDelivery {
+	var o *OutgoingWebhookDelivery
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func OutgoingWebhookDeliveryListToJson(l []*OutgoingWebhookDelivery) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func OutgoingWebhookDeliveryListFromJson(data io.Reader) []*OutgoingWebhookDelivery {
+	var o []*OutgoingWebhookDelivery
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
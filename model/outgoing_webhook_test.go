@@ -141,6 +141,16 @@ func TestOutgoingWebhookIsValid(t *testing.T) {
 	if err := o.IsValid(); err != nil {
 		t.Fatal(err)
 	}
+
+	o.EventTypes = StringArray{OUTGOING_WEBHOOK_EVENT_REACTION_ADDED, "unknown"}
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.EventTypes = StringArray{OUTGOING_WEBHOOK_EVENT_REACTION_ADDED, OUTGOING_WEBHOOK_EVENT_FILE_ATTACHED}
+	if err := o.IsValid(); err != nil {
+		t.Fatal(err)
+	}
 }
 
 func TestOutgoingWebhookPayloadToFormValues(t *testing.T) {
@@ -174,6 +184,32 @@ func TestOutgoingWebhookPayloadToFormValues(t *testing.T) {
 	if got, want := p.ToFormValues(), v.Encode(); !reflect.DeepEqual(got, want) {
 		t.Fatalf("Got %+v, wanted %+v", got, want)
 	}
+
+	p.EventType = OUTGOING_WEBHOOK_EVENT_REACTION_ADDED
+	p.EmojiName = "smile"
+	v.Set("event_type", OUTGOING_WEBHOOK_EVENT_REACTION_ADDED)
+	v.Set("emoji_name", "smile")
+	if got, want := p.ToFormValues(), v.Encode(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Got %+v, wanted %+v", got, want)
+	}
+}
+
+func TestOutgoingWebhookHasEventType(t *testing.T) {
+	o := OutgoingWebhook{}
+	if !o.HasEventType(OUTGOING_WEBHOOK_EVENT_POST_CREATED) {
+		t.Fatal("should subscribe to created posts by default")
+	}
+	if o.HasEventType(OUTGOING_WEBHOOK_EVENT_REACTION_ADDED) {
+		t.Fatal("should not subscribe to reactions by default")
+	}
+
+	o.EventTypes = StringArray{OUTGOING_WEBHOOK_EVENT_REACTION_ADDED, OUTGOING_WEBHOOK_EVENT_CHANNEL_MEMBER_ADDED}
+	if o.HasEventType(OUTGOING_WEBHOOK_EVENT_POST_CREATED) {
+		t.Fatal("should not subscribe to created posts")
+	}
+	if !o.HasEventType(OUTGOING_WEBHOOK_EVENT_REACTION_ADDED) {
+		t.Fatal("should subscribe to reactions")
+	}
 }
 
 func TestOutgoingWebhookPreSave(t *testing.T) {
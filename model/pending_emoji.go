@@ -0,0 +1,76 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// PendingEmoji is a custom emoji waiting for a system admin to approve it. Its image is uploaded when it's requested,
+// under the id the emoji keeps once approved.
+type PendingEmoji struct {
+	Id        string `json:"id"`
+	CreateAt  int64  `json:"create_at"`
+	CreatorId string `json:"creator_id"`
+	Name      string `json:"name"`
+	Animated  bool   `json:"animated"`
+}
+
+func (o *PendingEmoji) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("PendingEmoji.IsValid", "model.pending_emoji.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PendingEmoji.IsValid", "model.pending_emoji.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("PendingEmoji.IsValid", "model.pending_emoji.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return IsValidEmojiName(o.Name)
+}
+
+func (o *PendingEmoji) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+}
+
+// ToEmoji returns the emoji the request becomes once approved.
+func (o *PendingEmoji) ToEmoji() *Emoji {
+	return &Emoji{
+		Id:        o.Id,
+		CreatorId: o.CreatorId,
+		Name:      o.Name,
+		Animated:  o.Animated,
+	}
+}
+
+func (o *PendingEmoji) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PendingEmojiFromJson(data io.Reader) *PendingEmoji {
+	var o *PendingEmoji
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func PendingEmojiListToJson(l []*PendingEmoji) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func PendingEmojiListFromJson(data io.Reader) []*PendingEmoji {
+	var o []*PendingEmoji
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingEmojiIsValid(t *testing.T) {
+	emoji := PendingEmoji{
+		Id:        NewId(),
+		CreateAt:  1234,
+		CreatorId: NewId(),
+		Name:      "name",
+	}
+	require.Nil(t, emoji.IsValid())
+
+	emoji.Id = "1234"
+	require.NotNil(t, emoji.IsValid())
+
+	emoji.Id = NewId()
+	emoji.CreateAt = 0
+	require.NotNil(t, emoji.IsValid())
+
+	emoji.CreateAt = 1234
+	emoji.CreatorId = strings.Repeat("1", 27)
+	require.NotNil(t, emoji.IsValid())
+
+	emoji.CreatorId = NewId()
+	emoji.Name = "name:"
+	require.NotNil(t, emoji.IsValid())
+
+	emoji.Name = "smile"
+	require.NotNil(t, emoji.IsValid(), "shouldn't shadow a system emoji")
+}
+
+func TestPendingEmojiPreSave(t *testing.T) {
+	id := NewId()
+	emoji := PendingEmoji{Id: id}
+	emoji.PreSave()
+	assert.Equal(t, id, emoji.Id)
+	assert.NotZero(t, emoji.CreateAt)
+
+	emoji = PendingEmoji{}
+	emoji.PreSave()
+	assert.Len(t, emoji.Id, 26)
+}
+
+func TestPendingEmojiToEmoji(t *testing.T) {
+	pending := PendingEmoji{Id: NewId(), CreateAt: 1234, CreatorId: NewId(), Name: "name"}
+	emoji := pending.ToEmoji()
+
+	assert.Equal(t, pending.Id, emoji.Id)
+	assert.Equal(t, pending.CreatorId, emoji.CreatorId)
+	assert.Equal(t, pending.Name, emoji.Name)
+	assert.False(t, emoji.PendingApproval)
+}
+
+func TestPendingEmojiJson(t *testing.T) {
+	emoji := &PendingEmoji{Id: NewId(), CreateAt: 1234, CreatorId: NewId(), Name: "name"}
+
+	assert.Equal(t, emoji, PendingEmojiFromJson(strings.NewReader(emoji.ToJson())))
+	assert.Equal(t, []*PendingEmoji{emoji}, PendingEmojiListFromJson(strings.NewReader(PendingEmojiListToJson([]*PendingEmoji{emoji}))))
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	PENDING_POSTS_NOTIFY_MODERATORS_MAX = 50
+)
+
+// PendingPost is a post made to a moderated channel which is held until a moderator of the channel approves it,
+// when it's posted on behalf of its author, or rejects it.
+type PendingPost struct {
+	Id            string          `json:"id"`
+	CreateAt      int64           `json:"create_at"`
+	UserId        string          `json:"user_id"`
+	ChannelId     string          `json:"channel_id"`
+	RootId        string          `json:"root_id"`
+	ParentId      string          `json:"parent_id"`
+	Message       string          `json:"message"`
+	Type          string          `json:"type"`
+	Props         StringInterface `json:"props"`
+	FileIds       StringArray     `json:"file_ids"`
+	PendingPostId string          `json:"pending_post_id"`
+}
+
+// NewPendingPostFromPost holds the given post, which wasn't saved yet.
+func NewPendingPostFromPost(post *Post) *PendingPost {
+	pendingPost := &PendingPost{
+		UserId:        post.UserId,
+		ChannelId:     post.ChannelId,
+		RootId:        post.RootId,
+		ParentId:      post.ParentId,
+		Message:       post.Message,
+		Type:          post.Type,
+		FileIds:       post.FileIds,
+		PendingPostId: post.PendingPostId,
+	}
+	if post.Props != nil {
+		pendingPost.Props = StringInterface{}
+		for key, value := range post.Props {
+			pendingPost.Props[key] = value
+		}
+	}
+	return pendingPost
+}
+
+// ToPost returns the post to create once the pending post is approved. The id the client gave the post isn't
+// kept, since retries of the original request are answered with the pending post.
+func (o *PendingPost) ToPost() *Post {
+	post := &Post{
+		UserId:    o.UserId,
+		ChannelId: o.ChannelId,
+		RootId:    o.RootId,
+		ParentId:  o.ParentId,
+		Message:   o.Message,
+		Type:      o.Type,
+		FileIds:   o.FileIds,
+	}
+	for key, value := range o.Props {
+		post.AddProp(key, value)
+	}
+	return post
+}
+
+func (o *PendingPost) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("PendingPost.IsValid", "model.pending_post.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PendingPost.IsValid", "model.pending_post.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.UserId) != 26 {
+		return NewAppError("PendingPost.IsValid", "model.pending_post.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.ChannelId) != 26 {
+		return NewAppError("PendingPost.IsValid", "model.pending_post.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !(len(o.RootId) == 26 || len(o.RootId) == 0) || !(len(o.ParentId) == 26 || len(o.ParentId) == 0) {
+		return NewAppError("PendingPost.IsValid", "model.pending_post.is_valid.root_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Message) > POST_MESSAGE_MAX_RUNES_V2 {
+		return NewAppError("PendingPost.IsValid", "model.pending_post.is_valid.msg.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PendingPost) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+
+	if o.Props == nil {
+		o.Props = StringInterface{}
+	}
+
+	if o.FileIds == nil {
+		o.FileIds = StringArray{}
+	}
+}
+
+func (o *PendingPost) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PendingPostFromJson(data io.Reader) *PendingPost {
+	var o *PendingPost
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func PendingPostListToJson(l []*PendingPost) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func PendingPostListFromJson(data io.Reader) []*PendingPost {
+	var o []*PendingPost
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
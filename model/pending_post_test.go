@@ -0,0 +1,65 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingPostIsValid(t *testing.T) {
+	o := &PendingPost{UserId: NewId(), ChannelId: NewId(), Message: "message"}
+	o.PreSave()
+	require.Nil(t, o.IsValid())
+
+	o.RootId = "invalid"
+	require.NotNil(t, o.IsValid())
+
+	o.RootId = NewId()
+	o.ParentId = o.RootId
+	require.Nil(t, o.IsValid())
+
+	o.Message = strings.Repeat("0", POST_MESSAGE_MAX_RUNES_V2+1)
+	require.NotNil(t, o.IsValid())
+
+	o.Message = "message"
+	o.UserId = ""
+	require.NotNil(t, o.IsValid())
+}
+
+func TestPendingPostFromPost(t *testing.T) {
+	post := &Post{
+		UserId:        NewId(),
+		ChannelId:     NewId(),
+		RootId:        NewId(),
+		ParentId:      NewId(),
+		Message:       "message",
+		FileIds:       StringArray{NewId()},
+		PendingPostId: NewId() + ":1",
+	}
+	post.AddProp("from_bot", "true")
+
+	pendingPost := NewPendingPostFromPost(post)
+	assert.Equal(t, post.PendingPostId, pendingPost.PendingPostId)
+
+	// Changing the post afterwards doesn't change the pending post.
+	post.AddProp(POST_PROPS_PENDING_APPROVAL, true)
+	assert.NotContains(t, pendingPost.Props, POST_PROPS_PENDING_APPROVAL)
+
+	approved := pendingPost.ToPost()
+	assert.Equal(t, post.UserId, approved.UserId)
+	assert.Equal(t, post.ChannelId, approved.ChannelId)
+	assert.Equal(t, post.RootId, approved.RootId)
+	assert.Equal(t, post.ParentId, approved.ParentId)
+	assert.Equal(t, post.Message, approved.Message)
+	assert.Equal(t, post.FileIds, approved.FileIds)
+	assert.Equal(t, "true", approved.Props["from_bot"])
+	assert.Empty(t, approved.Id)
+	assert.Empty(t, approved.PendingPostId)
+	assert.False(t, approved.IsPendingApproval())
+	assert.True(t, post.IsPendingApproval())
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	PERMALINK_ACCESS_CHANNEL = "channel"
+	PERMALINK_ACCESS_PUBLIC  = "public"
+	PERMALINK_ACCESS_NONE    = "none"
+)
+
+// PermalinkPreview is what a permalink resolves to for a user, without joining them to the channel of the post.
+// Access is channel when the user can read the channel, public when they can only read it by joining it as a public
+// channel, and none when they can't read it, in which case the preview is a stub that only has the id of the post.
+// Thread is the thread of the post when it was asked for, so that a permalink to a reply can be shown in context.
+type PermalinkPreview struct {
+	PostId    string    `json:"post_id"`
+	RootId    string    `json:"root_id,omitempty"`
+	ChannelId string    `json:"channel_id,omitempty"`
+	TeamId    string    `json:"team_id,omitempty"`
+	Access    string    `json:"access"`
+	Post      *Post     `json:"post,omitempty"`
+	Thread    *PostList `json:"thread,omitempty"`
+}
+
+// IsRedacted returns whether the preview is a stub that hides the post.
+func (o *PermalinkPreview) IsRedacted() bool {
+	return o.Access == PERMALINK_ACCESS_NONE
+}
+
+// ThreadRootId returns the id of the root of the thread the post is in, which is the post itself when it isn't a
+// reply.
+func (o *PermalinkPreview) ThreadRootId() string {
+	if o.RootId != "" {
+		return o.RootId
+	}
+	return o.PostId
+}
+
+func (o *PermalinkPreview) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PermalinkPreviewFromJson(data io.Reader) *PermalinkPreview {
+	var o *PermalinkPreview
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
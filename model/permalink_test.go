@@ -0,0 +1,23 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermalinkPreview(t *testing.T) {
+	redacted := &PermalinkPreview{PostId: NewId(), Access: PERMALINK_ACCESS_NONE}
+	assert.True(t, redacted.IsRedacted())
+	assert.Equal(t, redacted.PostId, redacted.ThreadRootId())
+	assert.Equal(t, `{"post_id":"`+redacted.PostId+`","access":"none"}`, redacted.ToJson())
+
+	reply := &PermalinkPreview{PostId: NewId(), RootId: NewId(), Access: PERMALINK_ACCESS_PUBLIC}
+	assert.False(t, reply.IsRedacted())
+	assert.Equal(t, reply.RootId, reply.ThreadRootId())
+	assert.Equal(t, reply, PermalinkPreviewFromJson(strings.NewReader(reply.ToJson())))
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	POLL_QUESTION_MAX_RUNES = 512
+	POLL_OPTION_MAX_RUNES   = 256
+	POLL_MIN_OPTIONS        = 2
+	POLL_MAX_OPTIONS        = 10
+
+	POST_PROPS_POLL_ID = "poll_id"
+)
+
+// Poll is a question asked in a channel, which its members answer by voting for one of its options.
+type Poll struct {
+	Id        string      `json:"id"`
+	CreateAt  int64       `json:"create_at"`
+	UpdateAt  int64       `json:"update_at"`
+	CreatorId string      `json:"creator_id"`
+	ChannelId string      `json:"channel_id"`
+	PostId    string      `json:"post_id"`
+	Question  string      `json:"question"`
+	Options   StringArray `json:"options"`
+	CloseAt   int64       `json:"close_at"`
+}
+
+// PollVote is the option of a poll a user voted for. Each user has at most one vote per poll.
+type PollVote struct {
+	PollId   string `json:"poll_id"`
+	UserId   string `json:"user_id"`
+	Option   int    `json:"option"`
+	CreateAt int64  `json:"create_at"`
+}
+
+func (o *Poll) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.PostId != "" && !IsValidId(o.PostId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Question == "" || utf8.RuneCountInString(o.Question) > POLL_QUESTION_MAX_RUNES {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.question.app_error", map[string]interface{}{"Max": POLL_QUESTION_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Options) < POLL_MIN_OPTIONS || len(o.Options) > POLL_MAX_OPTIONS {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.options.app_error", map[string]interface{}{"Min": POLL_MIN_OPTIONS, "Max": POLL_MAX_OPTIONS}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, option := range o.Options {
+		if option == "" || utf8.RuneCountInString(option) > POLL_OPTION_MAX_RUNES {
+			return NewAppError("Poll.IsValid", "model.poll.is_valid.option.app_error", map[string]interface{}{"Max": POLL_OPTION_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (o *Poll) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *Poll) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+func (o *Poll) IsClosed() bool {
+	return o.CloseAt != 0
+}
+
+func (o *Poll) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PollFromJson(data io.Reader) *Poll {
+	var o *Poll
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *PollVote) IsValid() *AppError {
+	if !IsValidId(o.PollId) {
+		return NewAppError("PollVote.IsValid", "model.poll_vote.is_valid.poll_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("PollVote.IsValid", "model.poll_vote.is_valid.user_id.app_error", nil, "poll_id="+o.PollId, http.StatusBadRequest)
+	}
+
+	if o.Option < 0 || o.Option >= POLL_MAX_OPTIONS {
+		return NewAppError("PollVote.IsValid", "model.poll_vote.is_valid.option.app_error", nil, "poll_id="+o.PollId, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PollVote.IsValid", "model.poll_vote.is_valid.create_at.app_error", nil, "poll_id="+o.PollId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PollVote) PreSave() {
+	o.CreateAt = GetMillis()
+}
+
+// CountPollVotes returns the number of votes for each option of a poll.
+func CountPollVotes(poll *Poll, votes []*PollVote) []int {
+	counts := make([]int, len(poll.Options))
+	for _, vote := range votes {
+		if vote.Option >= 0 && vote.Option < len(counts) {
+			counts[vote.Option]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollJson(t *testing.T) {
+	poll := Poll{Id: NewId(), CreateAt: 1, UpdateAt: 2, CreatorId: NewId(), ChannelId: NewId(), Question: "Lunch?", Options: StringArray{"Pizza", "Sushi"}}
+	assert.Equal(t, poll, *PollFromJson(strings.NewReader(poll.ToJson())))
+}
+
+func TestPollIsValid(t *testing.T) {
+	poll := Poll{CreatorId: NewId(), ChannelId: NewId(), Question: "Lunch?", Options: StringArray{"Pizza", "Sushi"}}
+	poll.PreSave()
+	require.Nil(t, poll.IsValid())
+	assert.False(t, poll.IsClosed())
+
+	for name, update := range map[string]func(o *Poll){
+		"id":            func(o *Poll) { o.Id = "abc" },
+		"create at":     func(o *Poll) { o.CreateAt = 0 },
+		"update at":     func(o *Poll) { o.UpdateAt = 0 },
+		"creator id":    func(o *Poll) { o.CreatorId = "abc" },
+		"channel id":    func(o *Poll) { o.ChannelId = "abc" },
+		"post id":       func(o *Poll) { o.PostId = "abc" },
+		"no question":   func(o *Poll) { o.Question = "" },
+		"long question": func(o *Poll) { o.Question = strings.Repeat("a", POLL_QUESTION_MAX_RUNES+1) },
+		"one option":    func(o *Poll) { o.Options = StringArray{"Pizza"} },
+		"empty option":  func(o *Poll) { o.Options = StringArray{"Pizza", ""} },
+		"long option":   func(o *Poll) { o.Options = StringArray{"Pizza", strings.Repeat("a", POLL_OPTION_MAX_RUNES+1)} },
+		"many options":  func(o *Poll) { o.Options = make(StringArray, POLL_MAX_OPTIONS+1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := poll
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestCountPollVotes(t *testing.T) {
+	poll := &Poll{Options: StringArray{"Pizza", "Sushi", "Salad"}}
+	votes := []*PollVote{{Option: 0}, {Option: 2}, {Option: 2}, {Option: 7}}
+
+	assert.Equal(t, []int{1, 0, 2}, CountPollVotes(poll, votes))
+}
@@ -40,7 +40,12 @@ const (
 	POST_CHANNEL_DELETED        = "system_channel_deleted"
 	POST_EPHEMERAL              = "system_ephemeral"
 	POST_CHANGE_CHANNEL_PRIVACY = "system_change_chan_privacy"
+	POST_MENTION_ALIAS_REDIRECT = "system_mention_alias"
+	POST_MEMBERSHIP_EXPIRED     = "system_membership_expired"
+	POST_MEMBERSHIP_RULES       = "system_membership_rules"
+	POST_GROUP_TO_CHANNEL       = "system_gm_to_channel"
 	POST_ADD_BOT_TEAMS_CHANNELS = "add_bot_teams_channels"
+	POST_CALL                   = "call"
 	POST_FILEIDS_MAX_RUNES      = 150
 	POST_FILENAMES_MAX_RUNES    = 4000
 	POST_HASHTAGS_MAX_RUNES     = 1000
@@ -57,6 +62,16 @@ const (
 	POST_PROPS_DELETE_BY           = "deleteBy"
 	POST_PROPS_OVERRIDE_ICON_URL   = "override_icon_url"
 	POST_PROPS_OVERRIDE_ICON_EMOJI = "override_icon_emoji"
+	POST_PROPS_ADD_TO_CHANNEL      = "add_to_channel"
+	POST_PROPS_MENTION_ALIAS_ID    = "mention_alias_id"
+	POST_PROPS_REDIRECTED_POST_ID  = "redirected_post_id"
+	POST_PROPS_WEBHOOK_ID          = "webhook_id"
+	POST_PROPS_PENDING_APPROVAL    = "pending_approval"
+	POST_PROPS_CONTENT_FILTER_IDS  = "content_filter_ids"
+	POST_PROPS_KEYWORD_RULE_ID     = "keyword_rule_id"
+	POST_PROPS_BLOCKED_USERS       = "blocked_users"
+	POST_PROPS_REDACTED_AT         = "redacted_at"
+	POST_PROPS_REDACTED_BY         = "redacted_by"
 )
 
 type Post struct {
@@ -250,8 +265,13 @@ func (o *Post) IsValid(maxPostSize int) *AppError {
 		POST_CONVERT_CHANNEL,
 		POST_CHANNEL_DELETED,
 		POST_CHANGE_CHANNEL_PRIVACY,
+		POST_MENTION_ALIAS_REDIRECT,
+		POST_MEMBERSHIP_EXPIRED,
+		POST_MEMBERSHIP_RULES,
+		POST_GROUP_TO_CHANNEL,
 		POST_ME,
-		POST_ADD_BOT_TEAMS_CHANNELS:
+		POST_ADD_BOT_TEAMS_CHANNELS,
+		POST_CALL:
 	default:
 		if !strings.HasPrefix(o.Type, POST_CUSTOM_TYPE_PREFIX) {
 			return NewAppError("Post.IsValid", "model.post.is_valid.type.app_error", nil, "id="+o.Type, http.StatusBadRequest)
@@ -276,6 +296,7 @@ func (o *Post) IsValid(maxPostSize int) *AppError {
 func (o *Post) SanitizeProps() {
 	membersToSanitize := []string{
 		PROPS_ADD_CHANNEL_MEMBER,
+		POST_PROPS_BLOCKED_USERS,
 	}
 
 	for _, member := range membersToSanitize {
@@ -285,6 +306,34 @@ func (o *Post) SanitizeProps() {
 	}
 }
 
+// GetAddToChannel returns whether the client asked for this reply to also be shown in the channel, or nil
+// when no choice was made.
+func (o *Post) GetAddToChannel() *bool {
+	switch value := o.Props[POST_PROPS_ADD_TO_CHANNEL].(type) {
+	case bool:
+		return NewBool(value)
+	case string:
+		if value == "true" || value == "false" {
+			return NewBool(value == "true")
+		}
+	}
+
+	return nil
+}
+
+// IsPendingApproval reports whether the post was held for the moderators of a moderated channel to review,
+// rather than posted.
+func (o *Post) IsPendingApproval() bool {
+	pending, _ := o.Props[POST_PROPS_PENDING_APPROVAL].(bool)
+	return pending
+}
+
+// IsRedacted reports whether an admin replaced the content of the post with a redaction notice.
+func (o *Post) IsRedacted() bool {
+	_, ok := o.Props[POST_PROPS_REDACTED_AT]
+	return ok
+}
+
 func (o *Post) PreSave() {
 	if o.Id == "" {
 		o.Id = NewId()
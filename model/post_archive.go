@@ -0,0 +1,21 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"time"
+)
+
+const (
+	POST_ARCHIVE_BATCH_SIZE = 1000
+
+	POST_ARCHIVE_JOB_DATA_BEFORE   = "before"
+	POST_ARCHIVE_JOB_DATA_ARCHIVED = "archived"
+)
+
+// GetPostArchiveCutoff returns the time before which the threads that have seen no activity since are moved to the
+// posts archive, that is, the given number of months before now.
+func GetPostArchiveCutoff(months int, now time.Time) int64 {
+	return GetMillisForTime(now.AddDate(0, -months, 0))
+}
@@ -0,0 +1,18 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPostArchiveCutoff(t *testing.T) {
+	now := time.Date(2019, time.October, 15, 3, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, GetMillisForTime(time.Date(2019, time.September, 15, 3, 0, 0, 0, time.UTC)), GetPostArchiveCutoff(1, now))
+	assert.Equal(t, GetMillisForTime(time.Date(2018, time.October, 15, 3, 0, 0, 0, time.UTC)), GetPostArchiveCutoff(12, now))
+}
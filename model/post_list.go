@@ -4,9 +4,12 @@
 package model
 
 import (
+	"crypto/md5"
 	"encoding/json"
+	"fmt"
 	"io"
 	"sort"
+	"strings"
 )
 
 type PostList struct {
@@ -141,12 +144,9 @@ func (o *PostList) Etag() string {
 		}
 	}
 
-	orderId := ""
-	if len(o.Order) > 0 {
-		orderId = o.Order[0]
-	}
+	md5Order := fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(o.Order, ","))))
 
-	return Etag(orderId, id, t)
+	return Etag(md5Order, id, t, len(o.Posts))
 }
 
 func (o *PostList) IsChannelId(channelId string) bool {
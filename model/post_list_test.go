@@ -151,3 +151,50 @@ func TestPostListToSlice(t *testing.T) {
 
 	assert.Equal(t, want, pl.ToSlice())
 }
+
+func TestPostListEtag(t *testing.T) {
+	p1 := &Post{Id: NewId(), UpdateAt: 1}
+	p2 := &Post{Id: NewId(), UpdateAt: 2}
+	p3 := &Post{Id: NewId(), UpdateAt: 3}
+	p4 := &Post{Id: NewId(), UpdateAt: 1}
+
+	pl := PostList{}
+	pl.AddPost(p3)
+	pl.AddPost(p2)
+	pl.AddPost(p1)
+	pl.AddOrder(p3.Id)
+	pl.AddOrder(p2.Id)
+	pl.AddOrder(p1.Id)
+	etag := pl.Etag()
+
+	withoutOlderPost := PostList{}
+	withoutOlderPost.AddPost(p3)
+	withoutOlderPost.AddPost(p1)
+	withoutOlderPost.AddOrder(p3.Id)
+	withoutOlderPost.AddOrder(p1.Id)
+
+	assert.NotEqual(t, etag, withoutOlderPost.Etag(), "removing a post other than the newest should change the etag")
+
+	reordered := PostList{}
+	reordered.AddPost(p3)
+	reordered.AddPost(p2)
+	reordered.AddPost(p1)
+	reordered.AddOrder(p3.Id)
+	reordered.AddOrder(p1.Id)
+	reordered.AddOrder(p2.Id)
+
+	assert.NotEqual(t, etag, reordered.Etag(), "reordering the posts should change the etag")
+
+	swapped := PostList{}
+	swapped.AddPost(p3)
+	swapped.AddPost(p2)
+	swapped.AddPost(p4)
+	swapped.AddOrder(p3.Id)
+	swapped.AddOrder(p2.Id)
+	swapped.AddOrder(p4.Id)
+
+	assert.NotEqual(t, etag, swapped.Etag(), "replacing an older post should change the etag")
+
+	p2.UpdateAt = 4
+	assert.NotEqual(t, etag, pl.Etag(), "updating a post should change the etag")
+}
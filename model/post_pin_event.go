@@ -0,0 +1,52 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+// PostPinEvent records a post being pinned to or unpinned from its channel, and by whom.
+type PostPinEvent struct {
+	Id        string `json:"id"`
+	CreateAt  int64  `json:"create_at"`
+	PostId    string `json:"post_id"`
+	ChannelId string `json:"channel_id"`
+	UserId    string `json:"user_id"`
+	Pinned    bool   `json:"pinned"`
+}
+
+func (o *PostPinEvent) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("PostPinEvent.IsValid", "model.post_pin_event.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PostPinEvent.IsValid", "model.post_pin_event.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.PostId) {
+		return NewAppError("PostPinEvent.IsValid", "model.post_pin_event.is_valid.post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("PostPinEvent.IsValid", "model.post_pin_event.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("PostPinEvent.IsValid", "model.post_pin_event.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PostPinEvent) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
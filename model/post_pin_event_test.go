@@ -0,0 +1,32 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPinEventIsValid(t *testing.T) {
+	event := &PostPinEvent{PostId: NewId(), ChannelId: NewId(), UserId: NewId(), Pinned: true}
+	require.NotNil(t, event.IsValid())
+
+	event.PreSave()
+	assert.Len(t, event.Id, 26)
+	assert.NotZero(t, event.CreateAt)
+	require.Nil(t, event.IsValid())
+
+	event.PostId = "junk"
+	require.NotNil(t, event.IsValid())
+	event.PostId = NewId()
+
+	event.ChannelId = ""
+	require.NotNil(t, event.IsValid())
+	event.ChannelId = NewId()
+
+	event.UserId = "junk"
+	require.NotNil(t, event.IsValid())
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	POST_PURGE_SCOPE_POST    = "post"
+	POST_PURGE_SCOPE_THREAD  = "thread"
+	POST_PURGE_SCOPE_CHANNEL = "channel"
+
+	POST_PURGE_STATUS_PENDING  = "pending"
+	POST_PURGE_STATUS_RUNNING  = "running"
+	POST_PURGE_STATUS_DONE     = "done"
+	POST_PURGE_STATUS_CANCELED = "canceled"
+
+	POST_PURGE_MAX_DELAY = 30 * 24 * 60 * 60 * 1000
+)
+
+// PostPurge is a request by an admin to permanently delete posts, as opposed to deleting them from view while keeping
+// them in the database. A purge removes a post and its edit history, a whole thread or every post of a channel, along
+// with their reactions, files and search documents. It runs at PurgeAt, and can be canceled until then.
+type PostPurge struct {
+	Id        string `json:"id"`
+	CreateAt  int64  `json:"create_at"`
+	UpdateAt  int64  `json:"update_at"`
+	CreatorId string `json:"creator_id"`
+	Scope     string `json:"scope"`
+	TargetId  string `json:"target_id"`
+	ChannelId string `json:"channel_id"`
+	PurgeAt   int64  `json:"purge_at"`
+	Status    string `json:"status"`
+	FinishAt  int64  `json:"finish_at"`
+	PostCount int64  `json:"post_count"`
+	FileCount int64  `json:"file_count"`
+}
+
+func (o *PostPurge) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("PostPurge.IsValid", "model.post_purge.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PostPurge.IsValid", "model.post_purge.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("PostPurge.IsValid", "model.post_purge.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("PostPurge.IsValid", "model.post_purge.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Scope {
+	case POST_PURGE_SCOPE_POST, POST_PURGE_SCOPE_THREAD, POST_PURGE_SCOPE_CHANNEL:
+	default:
+		return NewAppError("PostPurge.IsValid", "model.post_purge.is_valid.scope.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.TargetId) {
+		return NewAppError("PostPurge.IsValid", "model.post_purge.is_valid.target_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("PostPurge.IsValid", "model.post_purge.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.PurgeAt < o.CreateAt || o.PurgeAt > o.CreateAt+POST_PURGE_MAX_DELAY {
+		return NewAppError("PostPurge.IsValid", "model.post_purge.is_valid.purge_at.app_error", map[string]interface{}{"MaxDays": POST_PURGE_MAX_DELAY / (24 * 60 * 60 * 1000)}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Status {
+	case POST_PURGE_STATUS_PENDING, POST_PURGE_STATUS_RUNNING, POST_PURGE_STATUS_DONE, POST_PURGE_STATUS_CANCELED:
+	default:
+		return NewAppError("PostPurge.IsValid", "model.post_purge.is_valid.status.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PostPurge) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+
+	if o.PurgeAt == 0 {
+		o.PurgeAt = o.CreateAt
+	}
+
+	if o.Status == "" {
+		o.Status = POST_PURGE_STATUS_PENDING
+	}
+}
+
+func (o *PostPurge) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+func (o *PostPurge) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostPurgeFromJson(data io.Reader) *PostPurge {
+	var o *PostPurge
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func PostPurgeListToJson(l []*PostPurge) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func PostPurgeListFromJson(data io.Reader) []*PostPurge {
+	var o []*PostPurge
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPurgeIsValid(t *testing.T) {
+	purge := PostPurge{CreatorId: NewId(), Scope: POST_PURGE_SCOPE_THREAD, TargetId: NewId(), ChannelId: NewId()}
+	purge.PreSave()
+	require.Nil(t, purge.IsValid())
+	assert.Equal(t, purge.CreateAt, purge.PurgeAt)
+	assert.Equal(t, POST_PURGE_STATUS_PENDING, purge.Status)
+
+	for name, update := range map[string]func(p *PostPurge){
+		"creator id":   func(p *PostPurge) { p.CreatorId = "" },
+		"scope":        func(p *PostPurge) { p.Scope = "team" },
+		"target id":    func(p *PostPurge) { p.TargetId = "" },
+		"channel id":   func(p *PostPurge) { p.ChannelId = "" },
+		"past purge":   func(p *PostPurge) { p.PurgeAt = p.CreateAt - 1 },
+		"late purge":   func(p *PostPurge) { p.PurgeAt = p.CreateAt + POST_PURGE_MAX_DELAY + 1 },
+		"status":       func(p *PostPurge) { p.Status = "paused" },
+		"missing id":   func(p *PostPurge) { p.Id = "" },
+		"missing time": func(p *PostPurge) { p.CreateAt = 0 },
+	} {
+		invalid := purge
+		update(&invalid)
+		assert.NotNil(t, invalid.IsValid(), name)
+	}
+
+	purge.PurgeAt = purge.CreateAt + POST_PURGE_MAX_DELAY
+	assert.Nil(t, purge.IsValid())
+}
+
+func TestPostPurgeJson(t *testing.T) {
+	purge := &PostPurge{Id: NewId(), Scope: POST_PURGE_SCOPE_CHANNEL, TargetId: NewId(), PostCount: 3}
+
+	received := PostPurgeFromJson(strings.NewReader(purge.ToJson()))
+	assert.Equal(t, purge, received)
+
+	list := PostPurgeListFromJson(strings.NewReader(PostPurgeListToJson([]*PostPurge{purge})))
+	require.Len(t, list, 1)
+	assert.Equal(t, purge, list[0])
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	POST_REDACTION_REASON_MAX_RUNES = 1024
+)
+
+// PostRedaction is what an admin redacts from a post. Redacting the message replaces it, along with any message
+// attachments, with a redaction notice. Redacting the attachments removes the files of the post.
+type PostRedaction struct {
+	Message     bool   `json:"message"`
+	Attachments bool   `json:"attachments"`
+	Reason      string `json:"reason"`
+}
+
+func (o *PostRedaction) IsValid() *AppError {
+	if !o.Message && !o.Attachments {
+		return NewAppError("PostRedaction.IsValid", "model.post_redaction.is_valid.empty.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Reason) > POST_REDACTION_REASON_MAX_RUNES {
+		return NewAppError("PostRedaction.IsValid", "model.post_redaction.is_valid.reason.app_error", map[string]interface{}{"MaxLength": POST_REDACTION_REASON_MAX_RUNES}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PostRedaction) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostRedactionFromJson(data io.Reader) *PostRedaction {
+	var o *PostRedaction
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
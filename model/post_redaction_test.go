@@ -0,0 +1,26 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostRedactionIsValid(t *testing.T) {
+	assert.NotNil(t, (&PostRedaction{}).IsValid())
+	assert.Nil(t, (&PostRedaction{Message: true}).IsValid())
+	assert.Nil(t, (&PostRedaction{Attachments: true, Reason: "leaked credentials"}).IsValid())
+	assert.NotNil(t, (&PostRedaction{Message: true, Reason: strings.Repeat("a", POST_REDACTION_REASON_MAX_RUNES+1)}).IsValid())
+}
+
+func TestPostIsRedacted(t *testing.T) {
+	post := &Post{}
+	assert.False(t, post.IsRedacted())
+
+	post.AddProp(POST_PROPS_REDACTED_AT, GetMillis())
+	assert.True(t, post.IsRedacted())
+}
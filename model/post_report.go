@@ -0,0 +1,266 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	POST_REPORT_REASON_SPAM          = "spam"
+	POST_REPORT_REASON_HARASSMENT    = "harassment"
+	POST_REPORT_REASON_INAPPROPRIATE = "inappropriate"
+	POST_REPORT_REASON_OTHER         = "other"
+
+	POST_REPORT_STATE_OPEN      = "open"
+	POST_REPORT_STATE_DISMISSED = "dismissed"
+	POST_REPORT_STATE_WARNED    = "warned"
+	POST_REPORT_STATE_DELETED   = "deleted"
+
+	// The actions moderators take on a report from the moderators channel, each resolving it with a state.
+	POST_REPORT_ACTION_DELETE  = "delete"
+	POST_REPORT_ACTION_WARN    = "warn"
+	POST_REPORT_ACTION_DISMISS = "dismiss"
+
+	POST_REPORT_COMMENT_MAX_LENGTH = 1000
+)
+
+// PostReport is a user's report of a post to the moderators, along with a snapshot of the post at the time it was
+// reported. A report is open until a moderator deletes the post, warns its author or dismisses the report.
+type PostReport struct {
+	Id           string          `json:"id"`
+	CreateAt     int64           `json:"create_at"`
+	UpdateAt     int64           `json:"update_at"`
+	ReporterId   string          `json:"reporter_id"`
+	PostId       string          `json:"post_id"`
+	ChannelId    string          `json:"channel_id"`
+	Reason       string          `json:"reason"`
+	Comment      string          `json:"comment"`
+	State        string          `json:"state"`
+	ResolverId   string          `json:"resolver_id"`
+	PostUserId   string          `json:"post_user_id"`
+	PostCreateAt int64           `json:"post_create_at"`
+	PostMessage  string          `json:"post_message"`
+	PostProps    StringInterface `json:"post_props"`
+	PostFileIds  StringArray     `json:"post_file_ids"`
+
+	// NotificationPostId is the post notifying the moderators channel of the report, whose actions resolve it.
+	NotificationPostId string `json:"notification_post_id"`
+}
+
+// PostReportEvent records a change to the state of a post report.
+type PostReportEvent struct {
+	Id        string `json:"id"`
+	CreateAt  int64  `json:"create_at"`
+	ReportId  string `json:"report_id"`
+	ActorId   string `json:"actor_id"`
+	FromState string `json:"from_state"`
+	ToState   string `json:"to_state"`
+	Comment   string `json:"comment"`
+}
+
+// NewPostReport snapshots the reported post.
+func NewPostReport(post *Post, reporterId, reason, comment string) *PostReport {
+	report := &PostReport{
+		ReporterId:   reporterId,
+		PostId:       post.Id,
+		ChannelId:    post.ChannelId,
+		Reason:       reason,
+		Comment:      comment,
+		PostUserId:   post.UserId,
+		PostCreateAt: post.CreateAt,
+		PostMessage:  post.Message,
+		PostFileIds:  post.FileIds,
+	}
+	if post.Props != nil {
+		report.PostProps = StringInterface{}
+		for key, value := range post.Props {
+			report.PostProps[key] = value
+		}
+	}
+	return report
+}
+
+func IsValidPostReportReason(reason string) bool {
+	switch reason {
+	case POST_REPORT_REASON_SPAM, POST_REPORT_REASON_HARASSMENT, POST_REPORT_REASON_INAPPROPRIATE, POST_REPORT_REASON_OTHER:
+		return true
+	}
+	return false
+}
+
+func IsValidPostReportState(state string) bool {
+	switch state {
+	case POST_REPORT_STATE_OPEN, POST_REPORT_STATE_DISMISSED, POST_REPORT_STATE_WARNED, POST_REPORT_STATE_DELETED:
+		return true
+	}
+	return false
+}
+
+// PostReportStateForAction returns the state a report is resolved with by a moderator's action, or "" if the action
+// isn't known.
+func PostReportStateForAction(action string) string {
+	return map[string]string{
+		POST_REPORT_ACTION_DELETE:  POST_REPORT_STATE_DELETED,
+		POST_REPORT_ACTION_WARN:    POST_REPORT_STATE_WARNED,
+		POST_REPORT_ACTION_DISMISS: POST_REPORT_STATE_DISMISSED,
+	}[action]
+}
+
+// CanTransitionTo reports whether the report may move to the given state. Open reports are resolved with any other
+// state, and resolved reports may only be reopened.
+func (o *PostReport) CanTransitionTo(state string) bool {
+	if !IsValidPostReportState(state) || state == o.State {
+		return false
+	}
+
+	return o.State == POST_REPORT_STATE_OPEN || state == POST_REPORT_STATE_OPEN
+}
+
+func (o *PostReport) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ReporterId) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.reporter_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.PostId) || !IsValidId(o.ChannelId) || !IsValidId(o.PostUserId) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.post.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidPostReportReason(o.Reason) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.reason.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Comment) > POST_REPORT_COMMENT_MAX_LENGTH {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.comment.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidPostReportState(o.State) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.state.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.ResolverId != "" && !IsValidId(o.ResolverId) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.resolver_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.PostMessage) > POST_MESSAGE_MAX_RUNES_V2 {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.post_message.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.NotificationPostId != "" && !IsValidId(o.NotificationPostId) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.notification_post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PostReport) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+
+	if o.State == "" {
+		o.State = POST_REPORT_STATE_OPEN
+	}
+
+	if o.PostProps == nil {
+		o.PostProps = StringInterface{}
+	}
+
+	if o.PostFileIds == nil {
+		o.PostFileIds = StringArray{}
+	}
+}
+
+func (o *PostReport) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+func (o *PostReport) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostReportFromJson(data io.Reader) *PostReport {
+	var o *PostReport
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func PostReportListToJson(l []*PostReport) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func PostReportListFromJson(data io.Reader) []*PostReport {
+	var o []*PostReport
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *PostReportEvent) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("PostReportEvent.IsValid", "model.post_report_event.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PostReportEvent.IsValid", "model.post_report_event.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ReportId) {
+		return NewAppError("PostReportEvent.IsValid", "model.post_report_event.is_valid.report_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ActorId) {
+		return NewAppError("PostReportEvent.IsValid", "model.post_report_event.is_valid.actor_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if (o.FromState != "" && !IsValidPostReportState(o.FromState)) || !IsValidPostReportState(o.ToState) {
+		return NewAppError("PostReportEvent.IsValid", "model.post_report_event.is_valid.state.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Comment) > POST_REPORT_COMMENT_MAX_LENGTH {
+		return NewAppError("PostReportEvent.IsValid", "model.post_report_event.is_valid.comment.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PostReportEvent) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func PostReportEventListToJson(l []*PostReportEvent) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func PostReportEventListFromJson(data io.Reader) []*PostReportEvent {
+	var o []*PostReportEvent
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostReportIsValid(t *testing.T) {
+	post := &Post{Id: NewId(), ChannelId: NewId(), UserId: NewId(), Message: "message", Props: StringInterface{"key": "value"}}
+	report := NewPostReport(post, NewId(), POST_REPORT_REASON_SPAM, "")
+	report.PreSave()
+	require.Nil(t, report.IsValid())
+	assert.Equal(t, POST_REPORT_STATE_OPEN, report.State)
+	assert.Equal(t, "message", report.PostMessage)
+
+	post.Props["key"] = "changed"
+	assert.Equal(t, "value", report.PostProps["key"], "should have snapshotted the props")
+
+	report.Reason = "junk"
+	require.NotNil(t, report.IsValid())
+	report.Reason = POST_REPORT_REASON_OTHER
+
+	report.Comment = strings.Repeat("a", POST_REPORT_COMMENT_MAX_LENGTH+1)
+	require.NotNil(t, report.IsValid())
+	report.Comment = ""
+
+	report.State = "junk"
+	require.NotNil(t, report.IsValid())
+	report.State = POST_REPORT_STATE_OPEN
+
+	report.ResolverId = "junk"
+	require.NotNil(t, report.IsValid())
+	report.ResolverId = NewId()
+	require.Nil(t, report.IsValid())
+
+	report.ReporterId = ""
+	require.NotNil(t, report.IsValid())
+}
+
+func TestPostReportCanTransitionTo(t *testing.T) {
+	report := &PostReport{State: POST_REPORT_STATE_OPEN}
+	assert.False(t, report.CanTransitionTo(POST_REPORT_STATE_OPEN))
+	assert.True(t, report.CanTransitionTo(POST_REPORT_STATE_DISMISSED))
+	assert.True(t, report.CanTransitionTo(POST_REPORT_STATE_WARNED))
+	assert.True(t, report.CanTransitionTo(POST_REPORT_STATE_DELETED))
+	assert.False(t, report.CanTransitionTo("junk"))
+
+	report.State = POST_REPORT_STATE_WARNED
+	assert.True(t, report.CanTransitionTo(POST_REPORT_STATE_OPEN))
+	assert.False(t, report.CanTransitionTo(POST_REPORT_STATE_DELETED))
+	assert.False(t, report.CanTransitionTo(POST_REPORT_STATE_WARNED))
+}
+
+func TestPostReportStateForAction(t *testing.T) {
+	assert.Equal(t, POST_REPORT_STATE_DELETED, PostReportStateForAction(POST_REPORT_ACTION_DELETE))
+	assert.Equal(t, POST_REPORT_STATE_WARNED, PostReportStateForAction(POST_REPORT_ACTION_WARN))
+	assert.Equal(t, POST_REPORT_STATE_DISMISSED, PostReportStateForAction(POST_REPORT_ACTION_DISMISS))
+	assert.Equal(t, "", PostReportStateForAction("junk"))
+}
@@ -4,8 +4,11 @@
 package model
 
 import (
+	"crypto/md5"
 	"encoding/json"
+	"fmt"
 	"io"
+	"strings"
 )
 
 type PostSearchMatches map[string][]string
@@ -22,6 +25,17 @@ func MakePostSearchResults(posts *PostList, matches PostSearchMatches) *PostSear
 	}
 }
 
+// Etag changes when either the posts found or the words they matched change, since different searches may find the
+// same posts.
+func (o *PostSearchResults) Etag() string {
+	matches := make([]string, 0, len(o.Order))
+	for _, postId := range o.Order {
+		matches = append(matches, postId+":"+strings.Join(o.Matches[postId], " "))
+	}
+
+	return Etag(o.PostList.Etag(), fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(matches, ",")))))
+}
+
 func (o *PostSearchResults) ToJson() string {
 	copy := *o
 	copy.PostList.StripActionIntegrations()
@@ -0,0 +1,25 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostSearchResultsEtag(t *testing.T) {
+	post := &Post{Id: NewId(), UpdateAt: 1}
+
+	pl := NewPostList()
+	pl.AddPost(post)
+	pl.AddOrder(post.Id)
+
+	results := MakePostSearchResults(pl, PostSearchMatches{post.Id: {"apple"}})
+	sameResults := MakePostSearchResults(pl, PostSearchMatches{post.Id: {"apple"}})
+	otherMatches := MakePostSearchResults(pl, PostSearchMatches{post.Id: {"banana"}})
+
+	assert.Equal(t, results.Etag(), sameResults.Etag())
+	assert.NotEqual(t, results.Etag(), otherMatches.Etag(), "matching other words should change the etag")
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	POST_STAR_COUNTS_MAX_POST_IDS = 200
+)
+
+// PostStar records that a user appreciated a post. Unlike flagged posts, which are private bookmarks, the number
+// of stars a post received is shown to its author.
+type PostStar struct {
+	PostId   string `json:"post_id"`
+	UserId   string `json:"user_id"`
+	CreateAt int64  `json:"create_at"`
+}
+
+func (o *PostStar) IsValid() *AppError {
+	if len(o.PostId) != 26 {
+		return NewAppError("PostStar.IsValid", "model.post_star.is_valid.post_id.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if len(o.UserId) != 26 {
+		return NewAppError("PostStar.IsValid", "model.post_star.is_valid.user_id.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PostStar.IsValid", "model.post_star.is_valid.create_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PostStar) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func (o *PostStar) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostStarFromJson(data io.Reader) *PostStar {
+	var o *PostStar
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// PostStarCount is the number of users who starred a post.
+type PostStarCount struct {
+	PostId string `json:"post_id"`
+	Count  int64  `json:"count"`
+}
+
+func (o *PostStarCount) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostStarCountFromJson(data io.Reader) *PostStarCount {
+	var o *PostStarCount
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func PostStarCountListToJson(l []*PostStarCount) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func PostStarCountListFromJson(data io.Reader) []*PostStarCount {
+	var o []*PostStarCount
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostStarJson(t *testing.T) {
+	star := PostStar{PostId: NewId(), UserId: NewId(), CreateAt: GetMillis()}
+	result := PostStarFromJson(strings.NewReader(star.ToJson()))
+	assert.Equal(t, star, *result)
+
+	count := PostStarCount{PostId: NewId(), Count: 3}
+	assert.Equal(t, count, *PostStarCountFromJson(strings.NewReader(count.ToJson())))
+
+	list := PostStarCountListFromJson(strings.NewReader(PostStarCountListToJson([]*PostStarCount{&count})))
+	require.Len(t, list, 1)
+	assert.Equal(t, count, *list[0])
+}
+
+func TestPostStarIsValid(t *testing.T) {
+	star := PostStar{PostId: NewId(), UserId: NewId()}
+	star.PreSave()
+	require.Nil(t, star.IsValid())
+
+	for name, update := range map[string]func(s *PostStar){
+		"post id":   func(s *PostStar) { s.PostId = "abc" },
+		"user id":   func(s *PostStar) { s.UserId = "" },
+		"create at": func(s *PostStar) { s.CreateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := star
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
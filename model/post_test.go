@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPostToJson(t *testing.T) {
@@ -180,6 +181,22 @@ func TestPostSanitizeProps(t *testing.T) {
 	}
 }
 
+func TestPostGetAddToChannel(t *testing.T) {
+	post := Post{}
+	assert.Nil(t, post.GetAddToChannel())
+
+	post.AddProp(POST_PROPS_ADD_TO_CHANNEL, true)
+	require.NotNil(t, post.GetAddToChannel())
+	assert.True(t, *post.GetAddToChannel())
+
+	post.AddProp(POST_PROPS_ADD_TO_CHANNEL, "false")
+	require.NotNil(t, post.GetAddToChannel())
+	assert.False(t, *post.GetAddToChannel())
+
+	post.AddProp(POST_PROPS_ADD_TO_CHANNEL, "maybe")
+	assert.Nil(t, post.GetAddToChannel())
+}
+
 func TestPost_AttachmentsEqual(t *testing.T) {
 	post1 := &Post{}
 	post2 := &Post{}
@@ -0,0 +1,31 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	POSTS_BY_IDS_MAX_POST_IDS = 200
+)
+
+// PostsByIds holds the posts requested by id that the user is allowed to read, sorted from newest to oldest, and
+// the reason each of the other requested posts couldn't be returned, keyed by post id.
+type PostsByIds struct {
+	Posts  []*Post              `json:"posts"`
+	Errors map[string]*AppError `json:"errors"`
+}
+
+func (o *PostsByIds) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostsByIdsFromJson(data io.Reader) *PostsByIds {
+	var o *PostsByIds
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostsByIdsJson(t *testing.T) {
+	missingId := NewId()
+	o := PostsByIds{
+		Posts: []*Post{{Id: NewId(), Message: NewId()}},
+		Errors: map[string]*AppError{
+			missingId: NewAppError("getPostsByIds", "api.post.get_posts_by_ids.not_found.app_error", nil, "", http.StatusNotFound),
+		},
+	}
+
+	ro := PostsByIdsFromJson(strings.NewReader(o.ToJson()))
+	require.NotNil(t, ro)
+	require.Len(t, ro.Posts, 1)
+	assert.Equal(t, o.Posts[0].Id, ro.Posts[0].Id)
+	require.Contains(t, ro.Errors, missingId)
+	assert.Equal(t, "api.post.get_posts_by_ids.not_found.app_error", ro.Errors[missingId].Id)
+	assert.Equal(t, http.StatusNotFound, ro.Errors[missingId].StatusCode)
+}
@@ -26,6 +26,7 @@ const (
 	PREFERENCE_NAME_MESSAGE_DISPLAY      = "message_display"
 	PREFERENCE_NAME_NAME_FORMAT          = "name_format"
 	PREFERENCE_NAME_USE_MILITARY_TIME    = "use_military_time"
+	PREFERENCE_NAME_PERSIST_EPHEMERAL    = "persist_ephemeral_posts"
 
 	PREFERENCE_CATEGORY_THEME = "theme"
 	// the name for theme props is the team id
@@ -37,6 +38,19 @@ const (
 	PREFERENCE_NAME_LAST_CHANNEL = "channel"
 	PREFERENCE_NAME_LAST_TEAM    = "team"
 
+	PREFERENCE_CATEGORY_BLOCKED_USER = "blocked_user"
+	// the name for blocked_user is the id of the blocked user and value is whether their direct messages are
+	// rejected or hidden
+	PREFERENCE_BLOCKED_USER_REJECT = "reject"
+	PREFERENCE_BLOCKED_USER_HIDE   = "hide"
+
+	PREFERENCE_CATEGORY_FOLLOWED_THREAD = "followed_thread"
+	// the name for followed_thread is the id of the root post of the thread and value is "true"
+
+	PREFERENCE_CATEGORY_ONBOARDING       = "onboarding"
+	PREFERENCE_NAME_ONBOARDING_COMPLETED = "completed"
+	// the value of onboarding completed is when the user completed the onboarding checklist and got its rewards
+
 	PREFERENCE_CATEGORY_NOTIFICATIONS = "notifications"
 	PREFERENCE_NAME_EMAIL_INTERVAL    = "email_interval"
 
@@ -91,6 +105,12 @@ func (o *Preference) IsValid() *AppError {
 		}
 	}
 
+	if o.Category == PREFERENCE_CATEGORY_BLOCKED_USER {
+		if !IsValidId(o.Name) || o.Name == o.UserId || (o.Value != PREFERENCE_BLOCKED_USER_REJECT && o.Value != PREFERENCE_BLOCKED_USER_HIDE) {
+			return NewAppError("Preference.IsValid", "model.preference.is_valid.blocked_user.app_error", nil, "name="+o.Name+", value="+o.Value, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,131 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	BULK_PREFERENCES_ACTION_SET      = "set"
+	BULK_PREFERENCES_ACTION_RESET    = "reset"
+	BULK_PREFERENCES_ACTION_ROLLBACK = "rollback"
+
+	BULK_PREFERENCES_MAX_PREFERENCES = 100
+	BULK_PREFERENCES_MAX_USER_IDS    = 1000
+
+	BULK_PREFERENCES_JOB_DATA_OPERATION_ID = "operation_id"
+	BULK_PREFERENCES_JOB_DATA_ACTION       = "action"
+	BULK_PREFERENCES_JOB_DATA_ROLLBACK_OF  = "rollback_of"
+	BULK_PREFERENCES_JOB_DATA_USERS_DONE   = "users_done"
+	BULK_PREFERENCES_JOB_DATA_USERS_TOTAL  = "users_total"
+)
+
+// BulkPreferencesOperation describes a change to apply to the preferences of a group of users. The
+// targeted users are either the given user ids, the members of a team, or every user on the system.
+// For the set action the preferences carry the value to store, for the reset action only the category
+// and name are used and matching preferences are deleted so that clients fall back to their defaults.
+type BulkPreferencesOperation struct {
+	Action      string      `json:"action"`
+	TeamId      string      `json:"team_id,omitempty"`
+	UserIds     []string    `json:"user_ids,omitempty"`
+	AllUsers    bool        `json:"all_users,omitempty"`
+	Preferences Preferences `json:"preferences,omitempty"`
+
+	// RollbackJobId is only used by rollback operations and references the job to undo.
+	RollbackJobId string `json:"rollback_job_id,omitempty"`
+}
+
+// BulkPreferencesManifestEntry records the state of a single preference before a bulk operation
+// changed it so that the operation can be rolled back.
+type BulkPreferencesManifestEntry struct {
+	UserId   string `json:"user_id"`
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	Existed  bool   `json:"existed"`
+	Value    string `json:"value,omitempty"`
+}
+
+// BulkPreferencesManifest is the rollback manifest written by a bulk preferences job.
+type BulkPreferencesManifest struct {
+	JobId   string                          `json:"job_id"`
+	Action  string                          `json:"action"`
+	Entries []*BulkPreferencesManifestEntry `json:"entries"`
+}
+
+func (o *BulkPreferencesOperation) IsValid() *AppError {
+	if o.Action == BULK_PREFERENCES_ACTION_ROLLBACK {
+		if !IsValidId(o.RollbackJobId) {
+			return NewAppError("BulkPreferencesOperation.IsValid", "model.bulk_preferences.is_valid.rollback_job_id.app_error", nil, "rollback_job_id="+o.RollbackJobId, http.StatusBadRequest)
+		}
+		return nil
+	}
+
+	if o.Action != BULK_PREFERENCES_ACTION_SET && o.Action != BULK_PREFERENCES_ACTION_RESET {
+		return NewAppError("BulkPreferencesOperation.IsValid", "model.bulk_preferences.is_valid.action.app_error", nil, "action="+o.Action, http.StatusBadRequest)
+	}
+
+	targets := 0
+	if len(o.TeamId) > 0 {
+		if !IsValidId(o.TeamId) {
+			return NewAppError("BulkPreferencesOperation.IsValid", "model.bulk_preferences.is_valid.team_id.app_error", nil, "team_id="+o.TeamId, http.StatusBadRequest)
+		}
+		targets++
+	}
+	if len(o.UserIds) > 0 {
+		if len(o.UserIds) > BULK_PREFERENCES_MAX_USER_IDS {
+			return NewAppError("BulkPreferencesOperation.IsValid", "model.bulk_preferences.is_valid.user_ids.app_error", nil, "", http.StatusBadRequest)
+		}
+		for _, userId := range o.UserIds {
+			if !IsValidId(userId) {
+				return NewAppError("BulkPreferencesOperation.IsValid", "model.bulk_preferences.is_valid.user_ids.app_error", nil, "user_id="+userId, http.StatusBadRequest)
+			}
+		}
+		targets++
+	}
+	if o.AllUsers {
+		targets++
+	}
+	if targets != 1 {
+		return NewAppError("BulkPreferencesOperation.IsValid", "model.bulk_preferences.is_valid.targets.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.Preferences) == 0 || len(o.Preferences) > BULK_PREFERENCES_MAX_PREFERENCES {
+		return NewAppError("BulkPreferencesOperation.IsValid", "model.bulk_preferences.is_valid.preferences.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, preference := range o.Preferences {
+		// The preferences are applied to every targeted user, so validate them against a placeholder user.
+		check := Preference{UserId: NewId(), Category: preference.Category, Name: preference.Name, Value: preference.Value}
+		if err := check.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *BulkPreferencesOperation) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func BulkPreferencesOperationFromJson(data io.Reader) *BulkPreferencesOperation {
+	var o *BulkPreferencesOperation
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (m *BulkPreferencesManifest) ToJson() string {
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+func BulkPreferencesManifestFromJson(data io.Reader) *BulkPreferencesManifest {
+	var m *BulkPreferencesManifest
+	json.NewDecoder(data).Decode(&m)
+	return m
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkPreferencesOperationIsValid(t *testing.T) {
+	preferences := Preferences{{Category: PREFERENCE_CATEGORY_NOTIFICATIONS, Name: PREFERENCE_NAME_EMAIL_INTERVAL, Value: PREFERENCE_EMAIL_INTERVAL_HOUR_AS_SECONDS}}
+
+	for name, tc := range map[string]struct {
+		Operation BulkPreferencesOperation
+		Valid     bool
+	}{
+		"set for team": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_SET, TeamId: NewId(), Preferences: preferences},
+			Valid:     true,
+		},
+		"reset for all users": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_RESET, AllUsers: true, Preferences: preferences},
+			Valid:     true,
+		},
+		"set for users": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_SET, UserIds: []string{NewId(), NewId()}, Preferences: preferences},
+			Valid:     true,
+		},
+		"rollback": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_ROLLBACK, RollbackJobId: NewId()},
+			Valid:     true,
+		},
+		"rollback without job": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_ROLLBACK},
+		},
+		"unknown action": {
+			Operation: BulkPreferencesOperation{Action: "delete", AllUsers: true, Preferences: preferences},
+		},
+		"no target": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_SET, Preferences: preferences},
+		},
+		"several targets": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_SET, TeamId: NewId(), AllUsers: true, Preferences: preferences},
+		},
+		"invalid team": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_SET, TeamId: "junk", Preferences: preferences},
+		},
+		"invalid user": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_SET, UserIds: []string{NewId(), "junk"}, Preferences: preferences},
+		},
+		"no preferences": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_RESET, AllUsers: true},
+		},
+		"invalid preference": {
+			Operation: BulkPreferencesOperation{Action: BULK_PREFERENCES_ACTION_SET, AllUsers: true, Preferences: Preferences{{Category: strings.Repeat("a", 33), Name: "name"}}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.Operation.IsValid()
+			if tc.Valid {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestBulkPreferencesManifestJson(t *testing.T) {
+	manifest := &BulkPreferencesManifest{
+		JobId:  NewId(),
+		Action: BULK_PREFERENCES_ACTION_SET,
+		Entries: []*BulkPreferencesManifestEntry{
+			{UserId: NewId(), Category: "category", Name: "name", Existed: true, Value: "value"},
+			{UserId: NewId(), Category: "category", Name: "name"},
+		},
+	}
+
+	decoded := BulkPreferencesManifestFromJson(strings.NewReader(manifest.ToJson()))
+	require.NotNil(t, decoded)
+	assert.Equal(t, manifest, decoded)
+}
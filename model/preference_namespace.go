@@ -0,0 +1,210 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+const (
+	PREFERENCE_NAMESPACE_MAX_LENGTH = 32
+	PREFERENCE_PATCH_MAX_CHANGES    = 1000
+)
+
+var preferenceNamespacePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// A preference namespace is a category of the preferences of a user. Its version goes up with each change to the
+// preferences in it, so that clients only download the namespaces that changed since they last synced.
+type PreferenceNamespace struct {
+	Version     int64             `json:"version"`
+	Preferences map[string]string `json:"preferences"`
+}
+
+// PreferenceNamespacePatch sets and deletes preferences in a namespace. If BaseVersion is given, the patch is only
+// applied if the namespace is still at that version.
+type PreferenceNamespacePatch struct {
+	Set         map[string]string `json:"set"`
+	Delete      []string          `json:"delete"`
+	BaseVersion *int64            `json:"base_version"`
+}
+
+// PreferencesPatch is the patches to apply to namespaces, by namespace.
+type PreferencesPatch map[string]*PreferenceNamespacePatch
+
+// PreferenceNamespaceDiff is what a patch actually changed in a namespace, leaving out the preferences it set to
+// their current value and the ones it deleted that didn't exist.
+type PreferenceNamespaceDiff struct {
+	Namespace string            `json:"namespace"`
+	Version   int64             `json:"version"`
+	Set       map[string]string `json:"set"`
+	Deleted   []string          `json:"deleted"`
+}
+
+// PreferenceVersion is the version of a namespace of the preferences of a user.
+type PreferenceVersion struct {
+	UserId   string
+	Category string
+	Version  int64
+}
+
+func IsValidPreferenceNamespace(namespace string) bool {
+	return len(namespace) > 0 && len(namespace) <= PREFERENCE_NAMESPACE_MAX_LENGTH && preferenceNamespacePattern.MatchString(namespace)
+}
+
+// IsValid checks the patch against the schemas of the preferences known to the server.
+func (o PreferencesPatch) IsValid(userId string) *AppError {
+	if len(o) == 0 {
+		return NewAppError("PreferencesPatch.IsValid", "model.preferences_patch.is_valid.empty.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	changes := 0
+	for namespace, patch := range o {
+		if !IsValidPreferenceNamespace(namespace) || patch == nil {
+			return NewAppError("PreferencesPatch.IsValid", "model.preferences_patch.is_valid.namespace.app_error", nil, "namespace="+namespace, http.StatusBadRequest)
+		}
+
+		for name, value := range patch.Set {
+			preference := Preference{UserId: userId, Category: namespace, Name: name, Value: value}
+			if err := preference.IsValidForSchema(); err != nil {
+				return err
+			}
+		}
+
+		for _, name := range patch.Delete {
+			if _, ok := patch.Set[name]; ok || len(name) > 32 {
+				return NewAppError("PreferencesPatch.IsValid", "model.preferences_patch.is_valid.delete.app_error", nil, "namespace="+namespace+", name="+name, http.StatusBadRequest)
+			}
+		}
+
+		changes += len(patch.Set) + len(patch.Delete)
+	}
+
+	if changes > PREFERENCE_PATCH_MAX_CHANGES {
+		return NewAppError("PreferencesPatch.IsValid", "model.preferences_patch.is_valid.too_many.app_error", map[string]interface{}{"Max": PREFERENCE_PATCH_MAX_CHANGES}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Namespaces returns the namespaces of the patch in a stable order.
+func (o PreferencesPatch) Namespaces() []string {
+	namespaces := make([]string, 0, len(o))
+	for namespace := range o {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+func (o *PreferenceNamespaceDiff) IsEmpty() bool {
+	return len(o.Set) == 0 && len(o.Deleted) == 0
+}
+
+// preferenceSchemas validates the values of the preferences known to the server, by category. Names missing from the
+// schema of a category are accepted so that clients can add preferences without waiting for the server.
+var preferenceSchemas = map[string]func(name, value string) bool{
+	PREFERENCE_CATEGORY_DISPLAY_SETTINGS: func(name, value string) bool {
+		switch name {
+		case PREFERENCE_NAME_USE_MILITARY_TIME, PREFERENCE_NAME_COLLAPSE_SETTING, PREFERENCE_NAME_PERSIST_EPHEMERAL:
+			return isPreferenceBool(value)
+		case PREFERENCE_NAME_NAME_FORMAT:
+			return value == SHOW_USERNAME || value == SHOW_NICKNAME_FULLNAME || value == SHOW_FULLNAME
+		case PREFERENCE_NAME_MESSAGE_DISPLAY:
+			return value == "clean" || value == "compact"
+		case PREFERENCE_NAME_CHANNEL_DISPLAY_MODE:
+			return value == "full" || value == "centered"
+		}
+		return true
+	},
+	PREFERENCE_CATEGORY_NOTIFICATIONS: func(name, value string) bool {
+		if name == PREFERENCE_NAME_EMAIL_INTERVAL {
+			seconds, err := strconv.Atoi(value)
+			return err == nil && seconds >= 0
+		}
+		return true
+	},
+	PREFERENCE_CATEGORY_DIRECT_CHANNEL_SHOW: isPreferenceIdFlag,
+	PREFERENCE_CATEGORY_FLAGGED_POST:        isPreferenceIdFlag,
+	PREFERENCE_CATEGORY_FAVORITE_CHANNEL:    isPreferenceIdFlag,
+	PREFERENCE_CATEGORY_FOLLOWED_THREAD:     isPreferenceIdFlag,
+	PREFERENCE_CATEGORY_TUTORIAL_STEPS: func(name, value string) bool {
+		_, err := strconv.Atoi(value)
+		return err == nil
+	},
+}
+
+func isPreferenceBool(value string) bool {
+	return value == "true" || value == "false"
+}
+
+func isPreferenceIdFlag(name, value string) bool {
+	return IsValidId(name) && isPreferenceBool(value)
+}
+
+// IsValidForSchema checks the preference like IsValid, and also against the schema of its category if the server
+// knows it.
+func (o *Preference) IsValidForSchema() *AppError {
+	if err := o.IsValid(); err != nil {
+		return err
+	}
+
+	if !IsValidPreferenceNamespace(o.Category) || o.Name == "" {
+		return NewAppError("Preference.IsValidForSchema", "model.preference.is_valid.namespace.app_error", nil, "category="+o.Category+", name="+o.Name, http.StatusBadRequest)
+	}
+
+	if schema, ok := preferenceSchemas[o.Category]; ok && !schema(o.Name, o.Value) {
+		return NewAppError("Preference.IsValidForSchema", "model.preference.is_valid.schema.app_error", map[string]interface{}{"Category": o.Category, "Name": o.Name}, "value="+o.Value, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func PreferenceVersionsToJson(m map[string]int64) string {
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+func PreferenceVersionsFromJson(data io.Reader) map[string]int64 {
+	var o map[string]int64
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func PreferenceNamespacesToJson(m map[string]*PreferenceNamespace) string {
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+func PreferenceNamespacesFromJson(data io.Reader) map[string]*PreferenceNamespace {
+	var o map[string]*PreferenceNamespace
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o PreferencesPatch) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PreferencesPatchFromJson(data io.Reader) PreferencesPatch {
+	var o PreferencesPatch
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func PreferenceNamespaceDiffListToJson(l []*PreferenceNamespaceDiff) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func PreferenceNamespaceDiffListFromJson(data io.Reader) []*PreferenceNamespaceDiff {
+	var o []*PreferenceNamespaceDiff
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferenceIsValidForSchema(t *testing.T) {
+	userId := NewId()
+
+	for name, tc := range map[string]struct {
+		Category string
+		Name     string
+		Value    string
+		Valid    bool
+	}{
+		"military time":             {PREFERENCE_CATEGORY_DISPLAY_SETTINGS, PREFERENCE_NAME_USE_MILITARY_TIME, "true", true},
+		"military time not bool":    {PREFERENCE_CATEGORY_DISPLAY_SETTINGS, PREFERENCE_NAME_USE_MILITARY_TIME, "yes", false},
+		"name format":               {PREFERENCE_CATEGORY_DISPLAY_SETTINGS, PREFERENCE_NAME_NAME_FORMAT, SHOW_FULLNAME, true},
+		"unknown name format":       {PREFERENCE_CATEGORY_DISPLAY_SETTINGS, PREFERENCE_NAME_NAME_FORMAT, "initials", false},
+		"message display":           {PREFERENCE_CATEGORY_DISPLAY_SETTINGS, PREFERENCE_NAME_MESSAGE_DISPLAY, "compact", true},
+		"unknown display setting":   {PREFERENCE_CATEGORY_DISPLAY_SETTINGS, "new_setting", "anything", true},
+		"email interval":            {PREFERENCE_CATEGORY_NOTIFICATIONS, PREFERENCE_NAME_EMAIL_INTERVAL, "900", true},
+		"negative email interval":   {PREFERENCE_CATEGORY_NOTIFICATIONS, PREFERENCE_NAME_EMAIL_INTERVAL, "-1", false},
+		"flagged post":              {PREFERENCE_CATEGORY_FLAGGED_POST, NewId(), "true", true},
+		"flagged post without id":   {PREFERENCE_CATEGORY_FLAGGED_POST, "post", "true", false},
+		"favorite channel not bool": {PREFERENCE_CATEGORY_FAVORITE_CHANNEL, NewId(), "1", false},
+		"tutorial step":             {PREFERENCE_CATEGORY_TUTORIAL_STEPS, userId, "3", true},
+		"unknown category":          {"custom", "anything", "anything", true},
+		"invalid category":          {"custom-category", "anything", "anything", false},
+		"missing name":              {"custom", "", "anything", false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			preference := Preference{UserId: userId, Category: tc.Category, Name: tc.Name, Value: tc.Value}
+			err := preference.IsValidForSchema()
+			if tc.Valid {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestPreferencesPatchIsValid(t *testing.T) {
+	userId := NewId()
+
+	require.NotNil(t, PreferencesPatch{}.IsValid(userId))
+
+	patch := PreferencesPatch{
+		PREFERENCE_CATEGORY_DISPLAY_SETTINGS: {
+			Set:    map[string]string{PREFERENCE_NAME_USE_MILITARY_TIME: "true"},
+			Delete: []string{PREFERENCE_NAME_NAME_FORMAT},
+		},
+	}
+	require.Nil(t, patch.IsValid(userId))
+
+	patch[PREFERENCE_CATEGORY_DISPLAY_SETTINGS].Delete = []string{PREFERENCE_NAME_USE_MILITARY_TIME}
+	require.NotNil(t, patch.IsValid(userId), "should not set and delete the same preference")
+
+	patch[PREFERENCE_CATEGORY_DISPLAY_SETTINGS].Delete = nil
+	patch[PREFERENCE_CATEGORY_DISPLAY_SETTINGS].Set[PREFERENCE_NAME_USE_MILITARY_TIME] = "sometimes"
+	require.NotNil(t, patch.IsValid(userId))
+
+	require.NotNil(t, PreferencesPatch{"bad namespace": {}}.IsValid(userId))
+	require.NotNil(t, PreferencesPatch{"custom": nil}.IsValid(userId))
+}
+
+func TestPreferencesPatchJson(t *testing.T) {
+	baseVersion := int64(3)
+	patch := PreferencesPatch{
+		"custom": {Set: map[string]string{"a": "1"}, Delete: []string{"b"}, BaseVersion: &baseVersion},
+	}
+
+	decoded := PreferencesPatchFromJson(strings.NewReader(patch.ToJson()))
+	require.NotNil(t, decoded["custom"])
+	assert.Equal(t, "1", decoded["custom"].Set["a"])
+	assert.Equal(t, []string{"b"}, decoded["custom"].Delete)
+	assert.Equal(t, baseVersion, *decoded["custom"].BaseVersion)
+	assert.Equal(t, []string{"custom"}, decoded.Namespaces())
+}
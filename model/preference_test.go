@@ -46,6 +46,23 @@ func TestPreferenceIsValid(t *testing.T) {
 
 	preference.Value = `{"color": "#ff0000", "color2": "#faf"}`
 	require.Nil(t, preference.IsValid())
+
+	preference.Category = PREFERENCE_CATEGORY_BLOCKED_USER
+	preference.Value = PREFERENCE_BLOCKED_USER_HIDE
+	require.Nil(t, preference.IsValid())
+
+	preference.Value = PREFERENCE_BLOCKED_USER_REJECT
+	require.Nil(t, preference.IsValid())
+
+	preference.Value = "true"
+	require.NotNil(t, preference.IsValid())
+
+	preference.Value = PREFERENCE_BLOCKED_USER_REJECT
+	preference.Name = preference.UserId
+	require.NotNil(t, preference.IsValid())
+
+	preference.Name = "junk"
+	require.NotNil(t, preference.IsValid())
 }
 
 func TestPreferencePreUpdate(t *testing.T) {
@@ -0,0 +1,83 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// PublicPostLink lets a post, and optionally its thread, be viewed by anyone with the link, including people outside
+// of the workspace. Each link can be revoked on its own, which sets its DeleteAt. Link is the signed URL of the link,
+// which is only filled in when the link is returned to a user.
+type PublicPostLink struct {
+	Id            string `json:"id"`
+	CreateAt      int64  `json:"create_at"`
+	DeleteAt      int64  `json:"delete_at"`
+	CreatorId     string `json:"creator_id"`
+	PostId        string `json:"post_id"`
+	ChannelId     string `json:"channel_id"`
+	IncludeThread bool   `json:"include_thread"`
+	Link          string `json:"link,omitempty" db:"-"`
+}
+
+func (o *PublicPostLink) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("PublicPostLink.IsValid", "model.public_post_link.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PublicPostLink.IsValid", "model.public_post_link.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("PublicPostLink.IsValid", "model.public_post_link.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.PostId) {
+		return NewAppError("PublicPostLink.IsValid", "model.public_post_link.is_valid.post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("PublicPostLink.IsValid", "model.public_post_link.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PublicPostLink) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.DeleteAt = 0
+}
+
+func (o *PublicPostLink) IsRevoked() bool {
+	return o.DeleteAt != 0
+}
+
+func (o *PublicPostLink) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PublicPostLinkFromJson(data io.Reader) *PublicPostLink {
+	var o *PublicPostLink
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func PublicPostLinkListToJson(l []*PublicPostLink) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func PublicPostLinkListFromJson(data io.Reader) []*PublicPostLink {
+	var o []*PublicPostLink
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
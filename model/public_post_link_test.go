@@ -0,0 +1,41 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicPostLinkIsValid(t *testing.T) {
+	link := PublicPostLink{CreatorId: NewId(), PostId: NewId(), ChannelId: NewId(), DeleteAt: 1}
+	link.PreSave()
+	require.Nil(t, link.IsValid())
+	assert.False(t, link.IsRevoked())
+
+	for name, update := range map[string]func(l *PublicPostLink){
+		"id":         func(l *PublicPostLink) { l.Id = "" },
+		"create at":  func(l *PublicPostLink) { l.CreateAt = 0 },
+		"creator id": func(l *PublicPostLink) { l.CreatorId = "" },
+		"post id":    func(l *PublicPostLink) { l.PostId = "junk" },
+		"channel id": func(l *PublicPostLink) { l.ChannelId = "" },
+	} {
+		invalid := link
+		update(&invalid)
+		assert.NotNil(t, invalid.IsValid(), name)
+	}
+}
+
+func TestPublicPostLinkJson(t *testing.T) {
+	link := &PublicPostLink{Id: NewId(), PostId: NewId(), IncludeThread: true, Link: "http://localhost/posts/x/public?h=y"}
+
+	assert.Equal(t, link, PublicPostLinkFromJson(strings.NewReader(link.ToJson())))
+
+	list := PublicPostLinkListFromJson(strings.NewReader(PublicPostLinkListToJson([]*PublicPostLink{link})))
+	require.Len(t, list, 1)
+	assert.Equal(t, link, list[0])
+}
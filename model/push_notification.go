@@ -52,6 +52,8 @@ type PushNotification struct {
 	ChannelId        string `json:"channel_id"`
 	PostId           string `json:"post_id"`
 	RootId           string `json:"root_id"`
+	ThreadId         string `json:"thread_id"`
+	CollapseKey      string `json:"collapse_key"`
 	ChannelName      string `json:"channel_name"`
 	Type             string `json:"type"`
 	SenderId         string `json:"sender_id"`
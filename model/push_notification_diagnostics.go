@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+const (
+	PUSH_DIAGNOSTIC_SERVER_DISABLED   = "push_notifications_disabled"
+	PUSH_DIAGNOSTIC_NO_SERVER         = "no_push_notification_server"
+	PUSH_DIAGNOSTIC_NO_DEVICES        = "no_registered_devices"
+	PUSH_DIAGNOSTIC_USER_DISABLED     = "user_push_notifications_disabled"
+	PUSH_DIAGNOSTIC_STATUS_SUPPRESSED = "status_suppresses_push_notifications"
+	PUSH_DIAGNOSTIC_DEVICE_FAILURES   = "device_delivery_failures"
+	PUSH_DIAGNOSTIC_NO_ACTIVE_DEVICES = "no_active_devices"
+)
+
+// PushNotificationSessionDiagnostics describes the push notification delivery state of a single session.
+type PushNotificationSessionDiagnostics struct {
+	SessionId      string `json:"session_id"`
+	Platform       string `json:"platform"`
+	HasDeviceId    bool   `json:"has_device_id"`
+	ExpiresAt      int64  `json:"expires_at"`
+	LastActivityAt int64  `json:"last_activity_at"`
+	Failures       int    `json:"failures"`
+	LastFailure    string `json:"last_failure"`
+	LastFailureAt  int64  `json:"last_failure_at"`
+}
+
+// PushNotificationDiagnostics collects everything that determines whether a user receives push notifications,
+// along with the list of problems found that would prevent delivery.
+type PushNotificationDiagnostics struct {
+	UserId                 string                                `json:"user_id"`
+	SendPushNotifications  bool                                  `json:"send_push_notifications"`
+	PushNotificationServer bool                                  `json:"push_notification_server"`
+	PushNotifyProp         string                                `json:"push_notify_prop"`
+	PushStatusNotifyProp   string                                `json:"push_status_notify_prop"`
+	Status                 string                                `json:"status"`
+	Sessions               []*PushNotificationSessionDiagnostics `json:"sessions"`
+	Problems               []string                              `json:"problems"`
+}
+
+func NewPushNotificationSessionDiagnostics(session *Session) *PushNotificationSessionDiagnostics {
+	diagnostics := &PushNotificationSessionDiagnostics{
+		SessionId:      session.Id,
+		HasDeviceId:    session.DeviceId != "",
+		ExpiresAt:      session.ExpiresAt,
+		LastActivityAt: session.LastActivityAt,
+		LastFailure:    session.Props[SESSION_PROP_PUSH_LAST_FAILURE],
+	}
+
+	var probe PushNotification
+	probe.SetDeviceIdAndPlatform(session.DeviceId)
+	diagnostics.Platform = probe.Platform
+
+	diagnostics.Failures, _ = strconv.Atoi(session.Props[SESSION_PROP_PUSH_FAILURES])
+	diagnostics.LastFailureAt, _ = strconv.ParseInt(session.Props[SESSION_PROP_PUSH_LAST_FAILURE_AT], 10, 64)
+
+	return diagnostics
+}
+
+func (d *PushNotificationDiagnostics) ToJson() string {
+	b, _ := json.Marshal(d)
+	return string(b)
+}
+
+func PushNotificationDiagnosticsFromJson(data io.Reader) *PushNotificationDiagnostics {
+	var d *PushNotificationDiagnostics
+	json.NewDecoder(data).Decode(&d)
+	return d
+}
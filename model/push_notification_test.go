@@ -92,3 +92,26 @@ func TestPushNotificationDeviceId(t *testing.T) {
 	msg.Platform = ""
 	msg.DeviceId = ""
 }
+
+func TestNewPushNotificationSessionDiagnostics(t *testing.T) {
+	session := &Session{
+		Id:       NewId(),
+		DeviceId: PUSH_NOTIFY_APPLE_REACT_NATIVE + ":12345",
+		Props: StringMap{
+			SESSION_PROP_PUSH_FAILURES:        "3",
+			SESSION_PROP_PUSH_LAST_FAILURE:    "bad token",
+			SESSION_PROP_PUSH_LAST_FAILURE_AT: "1000",
+		},
+	}
+
+	diagnostics := NewPushNotificationSessionDiagnostics(session)
+	if diagnostics.Platform != PUSH_NOTIFY_APPLE_REACT_NATIVE {
+		t.Fatal(diagnostics.Platform)
+	}
+	if !diagnostics.HasDeviceId {
+		t.Fatal("should have a device id")
+	}
+	if diagnostics.Failures != 3 || diagnostics.LastFailure != "bad token" || diagnostics.LastFailureAt != 1000 {
+		t.Fatal("failures do not match")
+	}
+}
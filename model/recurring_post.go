@@ -0,0 +1,142 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	RECURRING_POST_MAX_PER_CHANNEL = 50
+	RECURRING_POST_TIMEZONE_MAX    = 64
+
+	RECURRING_POST_JOB_DATA_POSTED = "posted"
+	RECURRING_POST_JOB_DATA_FAILED = "failed"
+)
+
+// RecurringPost is a message posted to a channel by a bot on a cron schedule, such as a standup reminder or a weekly
+// announcement.
+type RecurringPost struct {
+	Id        string `json:"id"`
+	CreateAt  int64  `json:"create_at"`
+	UpdateAt  int64  `json:"update_at"`
+	ChannelId string `json:"channel_id"`
+	CreatorId string `json:"creator_id"`
+	BotUserId string `json:"bot_user_id"`
+	Message   string `json:"message"`
+
+	// Schedule is a cron expression evaluated in Timezone, which is an IANA time zone name that defaults to UTC.
+	Schedule string `json:"schedule"`
+	Timezone string `json:"timezone"`
+
+	Paused    bool  `json:"paused"`
+	LastRunAt int64 `json:"last_run_at"`
+	NextRunAt int64 `json:"next_run_at"`
+}
+
+func (o *RecurringPost) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("RecurringPost.IsValid", "model.recurring_post.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("RecurringPost.IsValid", "model.recurring_post.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("RecurringPost.IsValid", "model.recurring_post.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("RecurringPost.IsValid", "model.recurring_post.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("RecurringPost.IsValid", "model.recurring_post.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.BotUserId) {
+		return NewAppError("RecurringPost.IsValid", "model.recurring_post.is_valid.bot_user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Message == "" || utf8.RuneCountInString(o.Message) > POST_MESSAGE_MAX_RUNES_V2 {
+		return NewAppError("RecurringPost.IsValid", "model.recurring_post.is_valid.message.app_error", map[string]interface{}{"Max": POST_MESSAGE_MAX_RUNES_V2}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if _, err := ParseCronSchedule(o.Schedule); err != nil {
+		return NewAppError("RecurringPost.IsValid", "model.recurring_post.is_valid.schedule.app_error", nil, "id="+o.Id+", "+err.Error(), http.StatusBadRequest)
+	}
+
+	if _, err := o.Location(); err != nil || len(o.Timezone) > RECURRING_POST_TIMEZONE_MAX {
+		return NewAppError("RecurringPost.IsValid", "model.recurring_post.is_valid.timezone.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *RecurringPost) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *RecurringPost) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+// Location returns the time zone the schedule is evaluated in.
+func (o *RecurringPost) Location() (*time.Location, error) {
+	if o.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(o.Timezone)
+}
+
+// SetNextRunAt sets when the post is next due, after the given time, or 0 if its schedule never matches.
+func (o *RecurringPost) SetNextRunAt(after time.Time) {
+	o.NextRunAt = 0
+
+	schedule, err := ParseCronSchedule(o.Schedule)
+	if err != nil {
+		return
+	}
+
+	location, err := o.Location()
+	if err != nil {
+		return
+	}
+
+	if next := schedule.Next(after.In(location)); !next.IsZero() {
+		o.NextRunAt = next.UnixNano() / int64(time.Millisecond)
+	}
+}
+
+func (o *RecurringPost) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func RecurringPostFromJson(data io.Reader) *RecurringPost {
+	var o *RecurringPost
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func RecurringPostListToJson(l []*RecurringPost) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func RecurringPostListFromJson(data io.Reader) []*RecurringPost {
+	var o []*RecurringPost
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
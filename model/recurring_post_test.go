@@ -0,0 +1,64 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurringPostJson(t *testing.T) {
+	post := RecurringPost{Id: NewId(), ChannelId: NewId(), BotUserId: NewId(), Message: "standup", Schedule: "0 9 * * 1-5"}
+	result := RecurringPostFromJson(strings.NewReader(post.ToJson()))
+	assert.Equal(t, post, *result)
+
+	list := RecurringPostListFromJson(strings.NewReader(RecurringPostListToJson([]*RecurringPost{&post})))
+	require.Len(t, list, 1)
+	assert.Equal(t, post, *list[0])
+}
+
+func TestRecurringPostIsValid(t *testing.T) {
+	post := RecurringPost{ChannelId: NewId(), CreatorId: NewId(), BotUserId: NewId(), Message: "standup", Schedule: "0 9 * * 1-5", Timezone: "Europe/Berlin"}
+	post.PreSave()
+	require.Nil(t, post.IsValid())
+
+	for name, update := range map[string]func(p *RecurringPost){
+		"channel id":  func(p *RecurringPost) { p.ChannelId = "abc" },
+		"creator id":  func(p *RecurringPost) { p.CreatorId = "" },
+		"bot user id": func(p *RecurringPost) { p.BotUserId = "" },
+		"message":     func(p *RecurringPost) { p.Message = "" },
+		"schedule":    func(p *RecurringPost) { p.Schedule = "every day" },
+		"timezone":    func(p *RecurringPost) { p.Timezone = "Mars/Olympus" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := post
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestRecurringPostSetNextRunAt(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.Nil(t, err)
+
+	// A Monday, at 9:00 in Berlin
+	now := time.Date(2019, 11, 4, 9, 0, 0, 0, berlin)
+
+	post := RecurringPost{Schedule: "0 9 * * 1-5", Timezone: "Europe/Berlin"}
+	post.SetNextRunAt(now)
+	assert.Equal(t, time.Date(2019, 11, 5, 9, 0, 0, 0, berlin).Unix()*1000, post.NextRunAt)
+
+	post.Timezone = ""
+	post.SetNextRunAt(now)
+	assert.Equal(t, time.Date(2019, 11, 4, 9, 0, 0, 0, time.UTC).Unix()*1000, post.NextRunAt)
+
+	post.Schedule = "0 0 31 2 *"
+	post.SetNextRunAt(now)
+	assert.Equal(t, int64(0), post.NextRunAt)
+}
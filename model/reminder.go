@@ -0,0 +1,73 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	REMINDER_MESSAGE_MAX_RUNES = 1024
+	REMINDER_MAX_PER_USER      = 50
+)
+
+// Reminder is a message the system bot sends to a user at the time they asked for.
+type Reminder struct {
+	Id       string `json:"id"`
+	CreateAt int64  `json:"create_at"`
+	UserId   string `json:"user_id"`
+	Message  string `json:"message"`
+	RemindAt int64  `json:"remind_at"`
+	SentAt   int64  `json:"sent_at"`
+}
+
+func (o *Reminder) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Message == "" || utf8.RuneCountInString(o.Message) > REMINDER_MESSAGE_MAX_RUNES {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.message.app_error", map[string]interface{}{"Max": REMINDER_MESSAGE_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.RemindAt == 0 {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.remind_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *Reminder) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+}
+
+func (o *Reminder) IsSent() bool {
+	return o.SentAt != 0
+}
+
+func (o *Reminder) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ReminderFromJson(data io.Reader) *Reminder {
+	var o *Reminder
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
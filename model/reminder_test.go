@@ -0,0 +1,39 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderJson(t *testing.T) {
+	reminder := Reminder{Id: NewId(), CreateAt: 1, UserId: NewId(), Message: "Submit the report", RemindAt: 2}
+	assert.Equal(t, reminder, *ReminderFromJson(strings.NewReader(reminder.ToJson())))
+}
+
+func TestReminderIsValid(t *testing.T) {
+	reminder := Reminder{UserId: NewId(), Message: "Submit the report", RemindAt: GetMillis()}
+	reminder.PreSave()
+	require.Nil(t, reminder.IsValid())
+	assert.False(t, reminder.IsSent())
+
+	for name, update := range map[string]func(o *Reminder){
+		"id":           func(o *Reminder) { o.Id = "abc" },
+		"create at":    func(o *Reminder) { o.CreateAt = 0 },
+		"user id":      func(o *Reminder) { o.UserId = "abc" },
+		"no message":   func(o *Reminder) { o.Message = "" },
+		"long message": func(o *Reminder) { o.Message = strings.Repeat("a", REMINDER_MESSAGE_MAX_RUNES+1) },
+		"remind at":    func(o *Reminder) { o.RemindAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := reminder
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	SCHEMA_MIGRATION_STATUS_PENDING     = "pending"
+	SCHEMA_MIGRATION_STATUS_BACKFILLING = "backfilling"
+	SCHEMA_MIGRATION_STATUS_COMPLETED   = "completed"
+
+	SCHEMA_MIGRATION_BACKFILL_BATCH_SIZE_DEFAULT = 1000
+)
+
+// SchemaMigration is a versioned change of the database schema, made while the server is running instead of during
+// the upgrade. Changes that need the existing rows to be migrated are applied first and then backfilled in batches by
+// the migrations job, so Status is pending until the change has been applied, backfilling until all the rows have
+// been migrated and completed afterwards.
+type SchemaMigration struct {
+	Version             int    `json:"version"`
+	Name                string `json:"name"`
+	Status              string `json:"status" db:"-"`
+	AppliedAt           int64  `json:"applied_at"`
+	BackfillLastDone    string `json:"backfill_last_done,omitempty"`
+	BackfillCompletedAt int64  `json:"backfill_completed_at"`
+}
+
+func (o *SchemaMigration) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SchemaMigrationListToJson(l []*SchemaMigration) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func SchemaMigrationListFromJson(data io.Reader) []*SchemaMigration {
+	var o []*SchemaMigration
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// GetSchemaBackfillMigrationKey returns the key of the migrations job backfilling the schema migration with the given
+// version.
+func GetSchemaBackfillMigrationKey(version int) string {
+	return MIGRATION_KEY_SCHEMA_BACKFILL_PREFIX + strconv.Itoa(version)
+}
+
+// ParseSchemaBackfillMigrationKey returns the version of the schema migration backfilled by the migrations job with
+// the given key, and false if the key isn't the one of a backfill.
+func ParseSchemaBackfillMigrationKey(key string) (int, bool) {
+	if !strings.HasPrefix(key, MIGRATION_KEY_SCHEMA_BACKFILL_PREFIX) {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(strings.TrimPrefix(key, MIGRATION_KEY_SCHEMA_BACKFILL_PREFIX))
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+
+	return version, true
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaMigrationJson(t *testing.T) {
+	migration := SchemaMigration{Version: 2, Name: "backfill_posts", Status: SCHEMA_MIGRATION_STATUS_BACKFILLING, AppliedAt: 1, BackfillLastDone: NewId()}
+	list := SchemaMigrationListFromJson(strings.NewReader(SchemaMigrationListToJson([]*SchemaMigration{&migration})))
+	require.Len(t, list, 1)
+	assert.Equal(t, migration, *list[0])
+}
+
+func TestSchemaBackfillMigrationKey(t *testing.T) {
+	key := GetSchemaBackfillMigrationKey(12)
+	assert.Equal(t, "migration_schema_backfill_12", key)
+
+	version, ok := ParseSchemaBackfillMigrationKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, 12, version)
+
+	for _, invalid := range []string{MIGRATION_KEY_ADVANCED_PERMISSIONS_PHASE_2, MIGRATION_KEY_SCHEMA_BACKFILL_PREFIX, MIGRATION_KEY_SCHEMA_BACKFILL_PREFIX + "0", MIGRATION_KEY_SCHEMA_BACKFILL_PREFIX + "abc"} {
+		_, ok := ParseSchemaBackfillMigrationKey(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const (
+	SEARCH_REINDEX_STAGE_POSTS    = "posts"
+	SEARCH_REINDEX_STAGE_CHANNELS = "channels"
+	SEARCH_REINDEX_STAGE_USERS    = "users"
+	SEARCH_REINDEX_STAGE_DONE     = "done"
+
+	SEARCH_REINDEX_DEFAULT_BATCH_SIZE = 500
+	SEARCH_REINDEX_MAX_BATCH_SIZE     = 10000
+
+	SEARCH_REINDEX_JOB_DATA_STAGE            = "stage"
+	SEARCH_REINDEX_JOB_DATA_START_CREATE_AT  = "start_create_at"
+	SEARCH_REINDEX_JOB_DATA_END_CREATE_AT    = "end_create_at"
+	SEARCH_REINDEX_JOB_DATA_LAST_CREATE_AT   = "last_create_at"
+	SEARCH_REINDEX_JOB_DATA_BATCH_SIZE       = "batch_size"
+	SEARCH_REINDEX_JOB_DATA_BATCH_DELAY      = "batch_delay_ms"
+	SEARCH_REINDEX_JOB_DATA_POSTS_INDEXED    = "posts_indexed"
+	SEARCH_REINDEX_JOB_DATA_CHANNELS_INDEXED = "channels_indexed"
+	SEARCH_REINDEX_JOB_DATA_USERS_INDEXED    = "users_indexed"
+)
+
+var searchReindexStages = []string{SEARCH_REINDEX_STAGE_POSTS, SEARCH_REINDEX_STAGE_CHANNELS, SEARCH_REINDEX_STAGE_USERS, SEARCH_REINDEX_STAGE_DONE}
+
+// SearchReindexProgress tracks a rebuild of the search indexes, which indexes the posts, then the channels, then the
+// users in batches ordered by their creation time. LastCreateAt is where the current stage resumes from, so that an
+// interrupted rebuild carries on instead of starting over. Only the posts stage starts from StartCreateAt, since the
+// channels and users are few in comparison.
+type SearchReindexProgress struct {
+	Stage           string
+	StartCreateAt   int64
+	EndCreateAt     int64
+	LastCreateAt    int64
+	BatchSize       int
+	BatchDelay      int
+	PostsIndexed    int64
+	ChannelsIndexed int64
+	UsersIndexed    int64
+}
+
+func NewSearchReindexProgress(startCreateAt, endCreateAt int64, batchSize, batchDelay int) *SearchReindexProgress {
+	return &SearchReindexProgress{
+		Stage:         SEARCH_REINDEX_STAGE_POSTS,
+		StartCreateAt: startCreateAt,
+		EndCreateAt:   endCreateAt,
+		LastCreateAt:  startCreateAt,
+		BatchSize:     batchSize,
+		BatchDelay:    batchDelay,
+	}
+}
+
+func (p *SearchReindexProgress) IsValid() *AppError {
+	if p.BatchSize <= 0 || p.BatchSize > SEARCH_REINDEX_MAX_BATCH_SIZE {
+		return NewAppError("SearchReindexProgress.IsValid", "model.search_reindex.is_valid.batch_size.app_error", map[string]interface{}{"Max": SEARCH_REINDEX_MAX_BATCH_SIZE}, "", http.StatusBadRequest)
+	}
+
+	if p.BatchDelay < 0 {
+		return NewAppError("SearchReindexProgress.IsValid", "model.search_reindex.is_valid.batch_delay.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if p.StartCreateAt < 0 || p.EndCreateAt <= p.StartCreateAt {
+		return NewAppError("SearchReindexProgress.IsValid", "model.search_reindex.is_valid.create_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !p.isValidStage() {
+		return NewAppError("SearchReindexProgress.IsValid", "model.search_reindex.is_valid.stage.app_error", nil, "stage="+p.Stage, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (p *SearchReindexProgress) isValidStage() bool {
+	for _, stage := range searchReindexStages {
+		if p.Stage == stage {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *SearchReindexProgress) IsDone() bool {
+	return p.Stage == SEARCH_REINDEX_STAGE_DONE
+}
+
+// NextStage moves on to the next kind of object to index.
+func (p *SearchReindexProgress) NextStage() {
+	for i, stage := range searchReindexStages {
+		if p.Stage == stage && i+1 < len(searchReindexStages) {
+			p.Stage = searchReindexStages[i+1]
+			break
+		}
+	}
+	p.LastCreateAt = 0
+}
+
+// Percent estimates how far the rebuild is, counting the posts for most of it.
+func (p *SearchReindexProgress) Percent() int64 {
+	switch p.Stage {
+	case SEARCH_REINDEX_STAGE_POSTS:
+		if p.EndCreateAt <= p.StartCreateAt {
+			return 0
+		}
+		return (p.LastCreateAt - p.StartCreateAt) * 80 / (p.EndCreateAt - p.StartCreateAt)
+	case SEARCH_REINDEX_STAGE_CHANNELS:
+		return 80
+	case SEARCH_REINDEX_STAGE_USERS:
+		return 90
+	default:
+		return 100
+	}
+}
+
+func (p *SearchReindexProgress) ToJobData() map[string]string {
+	return map[string]string{
+		SEARCH_REINDEX_JOB_DATA_STAGE:            p.Stage,
+		SEARCH_REINDEX_JOB_DATA_START_CREATE_AT:  strconv.FormatInt(p.StartCreateAt, 10),
+		SEARCH_REINDEX_JOB_DATA_END_CREATE_AT:    strconv.FormatInt(p.EndCreateAt, 10),
+		SEARCH_REINDEX_JOB_DATA_LAST_CREATE_AT:   strconv.FormatInt(p.LastCreateAt, 10),
+		SEARCH_REINDEX_JOB_DATA_BATCH_SIZE:       strconv.Itoa(p.BatchSize),
+		SEARCH_REINDEX_JOB_DATA_BATCH_DELAY:      strconv.Itoa(p.BatchDelay),
+		SEARCH_REINDEX_JOB_DATA_POSTS_INDEXED:    strconv.FormatInt(p.PostsIndexed, 10),
+		SEARCH_REINDEX_JOB_DATA_CHANNELS_INDEXED: strconv.FormatInt(p.ChannelsIndexed, 10),
+		SEARCH_REINDEX_JOB_DATA_USERS_INDEXED:    strconv.FormatInt(p.UsersIndexed, 10),
+	}
+}
+
+// SearchReindexProgressFromJobData reads the progress of a search reindex job, as saved by ToJobData.
+func SearchReindexProgressFromJobData(data map[string]string) (*SearchReindexProgress, *AppError) {
+	p := &SearchReindexProgress{Stage: data[SEARCH_REINDEX_JOB_DATA_STAGE]}
+
+	var err error
+	for key, value := range map[string]*int64{
+		SEARCH_REINDEX_JOB_DATA_START_CREATE_AT:  &p.StartCreateAt,
+		SEARCH_REINDEX_JOB_DATA_END_CREATE_AT:    &p.EndCreateAt,
+		SEARCH_REINDEX_JOB_DATA_LAST_CREATE_AT:   &p.LastCreateAt,
+		SEARCH_REINDEX_JOB_DATA_POSTS_INDEXED:    &p.PostsIndexed,
+		SEARCH_REINDEX_JOB_DATA_CHANNELS_INDEXED: &p.ChannelsIndexed,
+		SEARCH_REINDEX_JOB_DATA_USERS_INDEXED:    &p.UsersIndexed,
+	} {
+		if *value, err = strconv.ParseInt(data[key], 10, 64); err != nil {
+			return nil, NewAppError("SearchReindexProgressFromJobData", "model.search_reindex.job_data.app_error", nil, key+"="+data[key], http.StatusBadRequest)
+		}
+	}
+
+	for key, value := range map[string]*int{
+		SEARCH_REINDEX_JOB_DATA_BATCH_SIZE:  &p.BatchSize,
+		SEARCH_REINDEX_JOB_DATA_BATCH_DELAY: &p.BatchDelay,
+	} {
+		if *value, err = strconv.Atoi(data[key]); err != nil {
+			return nil, NewAppError("SearchReindexProgressFromJobData", "model.search_reindex.job_data.app_error", nil, key+"="+data[key], http.StatusBadRequest)
+		}
+	}
+
+	if appErr := p.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	return p, nil
+}
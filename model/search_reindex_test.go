@@ -0,0 +1,67 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchReindexProgressIsValid(t *testing.T) {
+	assert.Nil(t, NewSearchReindexProgress(0, 1000, SEARCH_REINDEX_DEFAULT_BATCH_SIZE, 0).IsValid())
+	assert.NotNil(t, NewSearchReindexProgress(0, 1000, 0, 0).IsValid())
+	assert.NotNil(t, NewSearchReindexProgress(0, 1000, SEARCH_REINDEX_MAX_BATCH_SIZE+1, 0).IsValid())
+	assert.NotNil(t, NewSearchReindexProgress(0, 1000, 100, -1).IsValid())
+	assert.NotNil(t, NewSearchReindexProgress(1000, 1000, 100, 0).IsValid())
+
+	progress := NewSearchReindexProgress(0, 1000, 100, 0)
+	progress.Stage = "garbage"
+	assert.NotNil(t, progress.IsValid())
+}
+
+func TestSearchReindexProgressStages(t *testing.T) {
+	progress := NewSearchReindexProgress(1000, 2000, 100, 0)
+	assert.Equal(t, SEARCH_REINDEX_STAGE_POSTS, progress.Stage)
+	assert.Equal(t, int64(0), progress.Percent())
+
+	progress.LastCreateAt = 1500
+	assert.Equal(t, int64(40), progress.Percent())
+
+	progress.NextStage()
+	assert.Equal(t, SEARCH_REINDEX_STAGE_CHANNELS, progress.Stage)
+	assert.Equal(t, int64(0), progress.LastCreateAt)
+	assert.Equal(t, int64(80), progress.Percent())
+
+	progress.NextStage()
+	assert.Equal(t, SEARCH_REINDEX_STAGE_USERS, progress.Stage)
+
+	progress.NextStage()
+	assert.True(t, progress.IsDone())
+	assert.Equal(t, int64(100), progress.Percent())
+
+	progress.NextStage()
+	assert.True(t, progress.IsDone())
+}
+
+func TestSearchReindexProgressJobData(t *testing.T) {
+	progress := NewSearchReindexProgress(1000, 2000, 100, 50)
+	progress.NextStage()
+	progress.LastCreateAt = 1234
+	progress.PostsIndexed = 10
+	progress.ChannelsIndexed = 2
+
+	result, err := SearchReindexProgressFromJobData(progress.ToJobData())
+	require.Nil(t, err)
+	assert.Equal(t, progress, result)
+
+	data := progress.ToJobData()
+	data[SEARCH_REINDEX_JOB_DATA_BATCH_SIZE] = "garbage"
+	_, err = SearchReindexProgressFromJobData(data)
+	assert.NotNil(t, err)
+
+	_, err = SearchReindexProgressFromJobData(map[string]string{})
+	assert.NotNil(t, err)
+}
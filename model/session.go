@@ -23,6 +23,13 @@ const (
 	SESSION_PROP_IS_BOT_VALUE         = "true"
 	SESSION_TYPE_USER_ACCESS_TOKEN    = "UserAccessToken"
 	SESSION_PROP_IS_GUEST             = "is_guest"
+	SESSION_PROP_PUSH_FAILURES        = "push_failures"
+	SESSION_PROP_PUSH_LAST_FAILURE    = "push_last_failure"
+	SESSION_PROP_PUSH_LAST_FAILURE_AT = "push_last_failure_at"
+	SESSION_PROP_OAUTH_SCOPE          = "oauth_scope"
+	SESSION_PROP_ALLOWED_IPS          = "allowed_ips"
+	SESSION_PROP_IMPERSONATOR_ID      = "impersonator_id"
+	SESSION_TYPE_IMPERSONATION        = "Impersonation"
 	SESSION_ACTIVITY_TIMEOUT          = 1000 * 60 * 5 // 5 minutes
 	SESSION_USER_ACCESS_TOKEN_EXPIRY  = 100 * 365     // 100 years
 )
@@ -135,6 +142,33 @@ func (me *Session) IsMobileApp() bool {
 	return len(me.DeviceId) > 0
 }
 
+// HasOAuthScope returns true unless the session belongs to an OAuth app or a personal access token that was
+// only granted scopes not including the given one.
+func (me *Session) HasOAuthScope(scope string) bool {
+	if !me.IsOAuth && me.Props[SESSION_PROP_TYPE] != SESSION_TYPE_USER_ACCESS_TOKEN {
+		return true
+	}
+	return OAuthScopeAllows(me.Props[SESSION_PROP_OAUTH_SCOPE], scope)
+}
+
+// IsAllowedIpAddress returns true unless the session is restricted to IP addresses not including the given one.
+func (me *Session) IsAllowedIpAddress(ipAddress string) bool {
+	return IsIpAddressAllowed(me.Props[SESSION_PROP_ALLOWED_IPS], ipAddress)
+}
+
+// IsImpersonated returns true if the session was created by a system admin to act as its user.
+func (me *Session) IsImpersonated() bool {
+	return me.Props[SESSION_PROP_TYPE] == SESSION_TYPE_IMPERSONATION && len(me.Props[SESSION_PROP_IMPERSONATOR_ID]) > 0
+}
+
+// GetImpersonatorId returns the id of the system admin acting as the session's user, if any.
+func (me *Session) GetImpersonatorId() string {
+	if !me.IsImpersonated() {
+		return ""
+	}
+	return me.Props[SESSION_PROP_IMPERSONATOR_ID]
+}
+
 func (me *Session) GetUserRoles() []string {
 	return strings.Fields(me.Roles)
 }
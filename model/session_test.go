@@ -76,3 +76,53 @@ func TestSessionCSRF(t *testing.T) {
 	assert.NotEmpty(t, token2)
 	assert.Equal(t, token, token2)
 }
+
+func TestSessionHasOAuthScope(t *testing.T) {
+	s := Session{}
+	assert.True(t, s.HasOAuthScope(OAUTH_SCOPE_READ_POSTS))
+
+	s.IsOAuth = true
+	assert.True(t, s.HasOAuthScope(OAUTH_SCOPE_READ_POSTS))
+	assert.True(t, s.HasOAuthScope(""))
+
+	s.AddProp(SESSION_PROP_OAUTH_SCOPE, OAUTH_SCOPE_READ_POSTS)
+	assert.True(t, s.HasOAuthScope(OAUTH_SCOPE_READ_POSTS))
+	assert.False(t, s.HasOAuthScope(OAUTH_SCOPE_WRITE_POSTS))
+	assert.False(t, s.HasOAuthScope(""))
+}
+
+func TestSessionHasOAuthScopeUserAccessToken(t *testing.T) {
+	s := Session{}
+	s.AddProp(SESSION_PROP_TYPE, SESSION_TYPE_USER_ACCESS_TOKEN)
+	assert.True(t, s.HasOAuthScope(OAUTH_SCOPE_WRITE_POSTS))
+
+	s.AddProp(SESSION_PROP_OAUTH_SCOPE, OAUTH_SCOPE_READ_POSTS)
+	assert.True(t, s.HasOAuthScope(OAUTH_SCOPE_READ_POSTS))
+	assert.False(t, s.HasOAuthScope(OAUTH_SCOPE_WRITE_POSTS))
+}
+
+func TestSessionIsAllowedIpAddress(t *testing.T) {
+	s := Session{}
+	assert.True(t, s.IsAllowedIpAddress("10.1.2.3"))
+
+	s.AddProp(SESSION_PROP_ALLOWED_IPS, "10.0.0.0/8 192.168.1.1")
+	assert.True(t, s.IsAllowedIpAddress("10.1.2.3"))
+	assert.True(t, s.IsAllowedIpAddress("192.168.1.1"))
+	assert.False(t, s.IsAllowedIpAddress("192.168.1.2"))
+	assert.False(t, s.IsAllowedIpAddress(""))
+}
+
+func TestSessionIsImpersonated(t *testing.T) {
+	s := Session{}
+	assert.False(t, s.IsImpersonated())
+	assert.Equal(t, "", s.GetImpersonatorId())
+
+	impersonatorId := NewId()
+	s.AddProp(SESSION_PROP_IMPERSONATOR_ID, impersonatorId)
+	assert.False(t, s.IsImpersonated())
+	assert.Equal(t, "", s.GetImpersonatorId())
+
+	s.AddProp(SESSION_PROP_TYPE, SESSION_TYPE_IMPERSONATION)
+	assert.True(t, s.IsImpersonated())
+	assert.Equal(t, impersonatorId, s.GetImpersonatorId())
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// SlowQuery is a database query which took longer than SqlSettings.SlowQueryThresholdMilliseconds. The values of its
+// parameters are left out, except for numbers, booleans and times, since they may hold secrets such as session
+// tokens. Plan is the execution plan of the query, when it could be explained.
+type SlowQuery struct {
+	CreateAt   int64    `json:"create_at"`
+	Database   string   `json:"database"`
+	Query      string   `json:"query"`
+	Parameters []string `json:"parameters"`
+	Caller     string   `json:"caller"`
+	Duration   int64    `json:"duration"`
+	Plan       string   `json:"plan,omitempty"`
+}
+
+func SlowQueryListToJson(queries []*SlowQuery) string {
+	b, _ := json.Marshal(queries)
+	return string(b)
+}
+
+func SlowQueryListFromJson(data io.Reader) []*SlowQuery {
+	var queries []*SlowQuery
+	json.NewDecoder(data).Decode(&queries)
+	return queries
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+const (
+	// SUPPORT_PACKET_LOG_LINES is how many of the most recent log lines are included in a support packet.
+	SUPPORT_PACKET_LOG_LINES = 10000
+
+	// SUPPORT_PACKET_JOBS is how many of the most recent jobs are included in a support packet.
+	SUPPORT_PACKET_JOBS = 100
+)
+
+// SupportPacketMetadata describes the server a support packet was generated on.
+type SupportPacketMetadata struct {
+	GeneratedAt     int64  `json:"generated_at"`
+	ServerVersion   string `json:"server_version"`
+	BuildNumber     string `json:"build_number"`
+	BuildHash       string `json:"build_hash"`
+	BuildEnterprise string `json:"build_enterprise"`
+	SchemaVersion   string `json:"schema_version"`
+	DatabaseType    string `json:"database_type"`
+	OperatingSystem string `json:"operating_system"`
+	Architecture    string `json:"architecture"`
+	GoVersion       string `json:"go_version"`
+	NumGoroutine    int    `json:"num_goroutine"`
+	LicenseId       string `json:"license_id,omitempty"`
+	LicenseSku      string `json:"license_sku,omitempty"`
+	ClusterId       string `json:"cluster_id,omitempty"`
+}
+
+func (o *SupportPacketMetadata) ToJson() string {
+	b, _ := json.MarshalIndent(o, "", "  ")
+	return string(b)
+}
+
+// SupportPacketConfig returns a copy of the configuration which is safe to share with support: on top of the
+// settings masked by Sanitize, the secrets of the OAuth providers and of the integrations are masked.
+func SupportPacketConfig(config *Config) *Config {
+	sanitized := config.Clone()
+	sanitized.Sanitize()
+
+	for _, secret := range []*string{
+		sanitized.GoogleSettings.Secret,
+		sanitized.Office365Settings.Secret,
+		sanitized.ServiceSettings.GfycatApiSecret,
+	} {
+		if secret != nil && *secret != "" {
+			*secret = FAKE_SETTING
+		}
+	}
+
+	if relay := sanitized.MessageExportSettings.GlobalRelaySettings; relay != nil && relay.SmtpPassword != nil && *relay.SmtpPassword != "" {
+		*relay.SmtpPassword = FAKE_SETTING
+	}
+
+	return sanitized
+}
+
+// SupportPacketSecrets lists the values of the secrets masked by SupportPacketConfig, longest first, so that they can
+// be redacted wherever else they appear in a support packet, such as in the logs.
+func SupportPacketSecrets(config *Config) []string {
+	settings := flattenConfig(config)
+	sanitized := flattenConfig(SupportPacketConfig(config))
+
+	secrets := []string{}
+	for path, value := range settings {
+		switch value := value.(type) {
+		case string:
+			if value != "" && value != FAKE_SETTING && sanitized[path] == FAKE_SETTING {
+				secrets = append(secrets, value)
+			}
+		case []interface{}:
+			masked, _ := sanitized[path].([]interface{})
+			for i, item := range value {
+				if item, ok := item.(string); ok && item != "" && i < len(masked) && masked[i] == FAKE_SETTING {
+					secrets = append(secrets, item)
+				}
+			}
+		}
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+
+	return secrets
+}
+
+// RedactSecrets masks every occurrence of the given secrets within text.
+func RedactSecrets(text string, secrets []string) string {
+	for _, secret := range secrets {
+		text = strings.Replace(text, secret, FAKE_SETTING, -1)
+	}
+	return text
+}
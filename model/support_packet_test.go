@@ -0,0 +1,52 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportPacketConfig(t *testing.T) {
+	config := &Config{}
+	config.SetDefaults()
+	*config.SqlSettings.DataSource = "mmuser:secretpassword@tcp(localhost:3306)/mattermost"
+	*config.GoogleSettings.Secret = "googlesecret"
+	*config.EmailSettings.SMTPServer = "smtp.example.com"
+
+	sanitized := SupportPacketConfig(config)
+	assert.Equal(t, FAKE_SETTING, *sanitized.SqlSettings.DataSource)
+	assert.Equal(t, FAKE_SETTING, *sanitized.GoogleSettings.Secret)
+	assert.Equal(t, "smtp.example.com", *sanitized.EmailSettings.SMTPServer)
+
+	assert.Equal(t, "googlesecret", *config.GoogleSettings.Secret, "the configuration shouldn't be modified")
+}
+
+func TestSupportPacketSecrets(t *testing.T) {
+	config := &Config{}
+	config.SetDefaults()
+	*config.SqlSettings.DataSource = "mmuser:secretpassword@tcp(localhost:3306)/mattermost"
+	config.SqlSettings.DataSourceReplicas = []string{"mmuser:replicapassword@tcp(replica:3306)/mattermost"}
+	*config.GoogleSettings.Secret = "googlesecret"
+	*config.EmailSettings.SMTPServer = "smtp.example.com"
+
+	secrets := SupportPacketSecrets(config)
+	assert.Contains(t, secrets, "mmuser:secretpassword@tcp(localhost:3306)/mattermost")
+	assert.Contains(t, secrets, "mmuser:replicapassword@tcp(replica:3306)/mattermost")
+	assert.Contains(t, secrets, "googlesecret")
+	assert.NotContains(t, secrets, "smtp.example.com")
+
+	for i := 1; i < len(secrets); i++ {
+		assert.True(t, len(secrets[i-1]) >= len(secrets[i]), "the secrets should be ordered longest first")
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	secrets := []string{"googlesecret", "secret"}
+
+	assert.Equal(t, "the password is "+FAKE_SETTING+" and "+FAKE_SETTING, RedactSecrets("the password is googlesecret and secret", secrets))
+	assert.Equal(t, "nothing to hide", RedactSecrets("nothing to hide", secrets))
+	assert.Equal(t, "nothing to hide", RedactSecrets("nothing to hide", nil))
+}
@@ -18,6 +18,9 @@ const (
 	SYSTEM_ASYMMETRIC_SIGNING_KEY    = "AsymmetricSigningKey"
 	SYSTEM_POST_ACTION_COOKIE_SECRET = "PostActionCookieSecret"
 	SYSTEM_INSTALLATION_DATE_KEY     = "InstallationDate"
+	SYSTEM_REPLICA_HEARTBEAT         = "ReplicaHeartbeat"
+	SYSTEM_LICENSE_SEAT_WARNING_TIME = "LicenseSeatWarningTime"
+	SYSTEM_MFA_ENFORCEMENT_START_AT  = "MfaEnforcementStartAt"
 )
 
 type System struct {
@@ -0,0 +1,59 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	HEALTH_CHECK_DATABASE      = "database"
+	HEALTH_CHECK_FILESTORE     = "filestore"
+	HEALTH_CHECK_ELASTICSEARCH = "elasticsearch"
+	HEALTH_CHECK_PUSH_PROXY    = "push_proxy"
+	HEALTH_CHECK_SMTP          = "smtp"
+	HEALTH_CHECK_CLUSTER       = "cluster"
+
+	// STATUS_DISABLED is the status of a dependency the server isn't configured to use, which isn't probed.
+	STATUS_DISABLED = "DISABLED"
+
+	HEALTH_CHECK_PROBE_LIVENESS  = "liveness"
+	HEALTH_CHECK_PROBE_READINESS = "readiness"
+)
+
+// DependencyHealth is the result of probing a dependency of the server. Latency is how long the probe took, in
+// milliseconds.
+type DependencyHealth struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Latency int64  `json:"latency"`
+}
+
+// SystemHealth is the health of the server, which is unhealthy as soon as one of its dependencies is.
+type SystemHealth struct {
+	Status       string              `json:"status"`
+	Dependencies []*DependencyHealth `json:"dependencies"`
+}
+
+func (o *SystemHealth) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SystemHealthFromJson(data io.Reader) *SystemHealth {
+	var o *SystemHealth
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// GetDependency returns the health of the named dependency, or nil if it wasn't probed.
+func (o *SystemHealth) GetDependency(name string) *DependencyHealth {
+	for _, dependency := range o.Dependencies {
+		if dependency.Name == name {
+			return dependency
+		}
+	}
+	return nil
+}
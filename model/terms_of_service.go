@@ -13,11 +13,16 @@ import (
 
 const TERMS_OF_SERVICE_CACHE_SIZE = 1
 
+// TermsOfService is a version of the custom terms of service, numbered by Version in the order they were created.
+// ReacceptAt schedules a re-acceptance campaign: from then on, users who accepted the version before it must accept
+// it again.
 type TermsOfService struct {
-	Id       string `json:"id"`
-	CreateAt int64  `json:"create_at"`
-	UserId   string `json:"user_id"`
-	Text     string `json:"text"`
+	Id         string `json:"id"`
+	CreateAt   int64  `json:"create_at"`
+	UserId     string `json:"user_id"`
+	Text       string `json:"text"`
+	Version    int64  `json:"version"`
+	ReacceptAt int64  `json:"reaccept_at"`
 }
 
 func (t *TermsOfService) IsValid() *AppError {
@@ -40,6 +45,12 @@ func (t *TermsOfService) IsValid() *AppError {
 	return nil
 }
 
+// RequiresReacceptance returns whether an acceptance of the version made at acceptedAt no longer counts at now,
+// because a re-acceptance campaign started since.
+func (t *TermsOfService) RequiresReacceptance(acceptedAt, now int64) bool {
+	return t.ReacceptAt != 0 && now >= t.ReacceptAt && acceptedAt < t.ReacceptAt
+}
+
 func (t *TermsOfService) ToJson() string {
 	b, _ := json.Marshal(t)
 	return string(b)
@@ -67,3 +78,14 @@ func (t *TermsOfService) PreSave() {
 
 	t.CreateAt = GetMillis()
 }
+
+func TermsOfServiceListToJson(l []*TermsOfService) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func TermsOfServiceListFromJson(data io.Reader) []*TermsOfService {
+	var l []*TermsOfService
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
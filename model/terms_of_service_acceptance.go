@@ -0,0 +1,57 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	TERMS_OF_SERVICE_ACCEPTANCES_PER_PAGE_MAX = 1000
+)
+
+// TermsOfServiceAcceptance records that a user accepted a version of the terms of service. Unlike the
+// UserTermsOfService of a user, which only holds their latest acceptance, every acceptance is kept, so that
+// compliance can tell who accepted which version when.
+type TermsOfServiceAcceptance struct {
+	UserId           string `json:"user_id"`
+	TermsOfServiceId string `json:"terms_of_service_id"`
+	Version          int64  `json:"version"`
+	AcceptAt         int64  `json:"accept_at"`
+}
+
+func (o *TermsOfServiceAcceptance) PreSave() {
+	if o.AcceptAt == 0 {
+		o.AcceptAt = GetMillis()
+	}
+}
+
+func (o *TermsOfServiceAcceptance) IsValid() *AppError {
+	if !IsValidId(o.UserId) {
+		return NewAppError("TermsOfServiceAcceptance.IsValid", "model.terms_of_service_acceptance.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.TermsOfServiceId) {
+		return NewAppError("TermsOfServiceAcceptance.IsValid", "model.terms_of_service_acceptance.is_valid.terms_of_service_id.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.AcceptAt == 0 {
+		return NewAppError("TermsOfServiceAcceptance.IsValid", "model.terms_of_service_acceptance.is_valid.accept_at.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func TermsOfServiceAcceptanceListToJson(l []*TermsOfServiceAcceptance) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func TermsOfServiceAcceptanceListFromJson(data io.Reader) []*TermsOfServiceAcceptance {
+	var l []*TermsOfServiceAcceptance
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
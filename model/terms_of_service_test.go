@@ -61,3 +61,13 @@ func TestTermsOfServiceJson(t *testing.T) {
 	assert.NotNil(t, ro)
 	assert.Equal(t, o, *ro)
 }
+
+func TestTermsOfServiceRequiresReacceptance(t *testing.T) {
+	termsOfService := &TermsOfService{}
+	assert.False(t, termsOfService.RequiresReacceptance(1000, 3000))
+
+	termsOfService.ReacceptAt = 2000
+	assert.True(t, termsOfService.RequiresReacceptance(1000, 3000))
+	assert.False(t, termsOfService.RequiresReacceptance(1000, 1500), "the campaign hasn't started yet")
+	assert.False(t, termsOfService.RequiresReacceptance(2500, 3000), "accepted again since the campaign started")
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	TODO_MESSAGE_MAX_RUNES = 1024
+	TODO_MAX_PER_USER      = 100
+)
+
+// Todo is an item of a user's personal todo list, which only they can see.
+type Todo struct {
+	Id         string `json:"id"`
+	CreateAt   int64  `json:"create_at"`
+	UpdateAt   int64  `json:"update_at"`
+	UserId     string `json:"user_id"`
+	Message    string `json:"message"`
+	CompleteAt int64  `json:"complete_at"`
+}
+
+func (o *Todo) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("Todo.IsValid", "model.todo.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("Todo.IsValid", "model.todo.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("Todo.IsValid", "model.todo.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("Todo.IsValid", "model.todo.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Message == "" || utf8.RuneCountInString(o.Message) > TODO_MESSAGE_MAX_RUNES {
+		return NewAppError("Todo.IsValid", "model.todo.is_valid.message.app_error", map[string]interface{}{"Max": TODO_MESSAGE_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *Todo) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *Todo) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+func (o *Todo) IsComplete() bool {
+	return o.CompleteAt != 0
+}
+
+func (o *Todo) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func TodoFromJson(data io.Reader) *Todo {
+	var o *Todo
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func TodoListToJson(l []*Todo) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func TodoListFromJson(data io.Reader) []*Todo {
+	var l []*Todo
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoJson(t *testing.T) {
+	todo := Todo{Id: NewId(), CreateAt: 1, UpdateAt: 2, UserId: NewId(), Message: "Review the release notes", CompleteAt: 3}
+	assert.Equal(t, todo, *TodoFromJson(strings.NewReader(todo.ToJson())))
+
+	list := TodoListFromJson(strings.NewReader(TodoListToJson([]*Todo{&todo})))
+	require.Len(t, list, 1)
+	assert.Equal(t, todo, *list[0])
+}
+
+func TestTodoIsValid(t *testing.T) {
+	todo := Todo{UserId: NewId(), Message: "Review the release notes"}
+	todo.PreSave()
+	require.Nil(t, todo.IsValid())
+	assert.False(t, todo.IsComplete())
+
+	for name, update := range map[string]func(o *Todo){
+		"id":           func(o *Todo) { o.Id = "abc" },
+		"create at":    func(o *Todo) { o.CreateAt = 0 },
+		"update at":    func(o *Todo) { o.UpdateAt = 0 },
+		"user id":      func(o *Todo) { o.UserId = "abc" },
+		"no message":   func(o *Todo) { o.Message = "" },
+		"long message": func(o *Todo) { o.Message = strings.Repeat("a", TODO_MESSAGE_MAX_RUNES+1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := todo
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
@@ -12,7 +12,9 @@ import (
 	"net/http"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/mattermost/mattermost-server/services/timezones"
@@ -41,6 +43,21 @@ const (
 	AUTO_RESPONDER_ACTIVE_NOTIFY_PROP  = "auto_responder_active"
 	AUTO_RESPONDER_MESSAGE_NOTIFY_PROP = "auto_responder_message"
 
+	// The auto-responder only responds between the start and end times, in milliseconds since the epoch, when they're
+	// set. It responds to mentions in channels with the mention message, if there is one, and won't respond to the
+	// same sender again until the cooldown, in minutes, has passed.
+	AUTO_RESPONDER_START_AT_NOTIFY_PROP        = "auto_responder_start_at"
+	AUTO_RESPONDER_END_AT_NOTIFY_PROP          = "auto_responder_end_at"
+	AUTO_RESPONDER_MENTION_MESSAGE_NOTIFY_PROP = "auto_responder_mention_message"
+	AUTO_RESPONDER_COOLDOWN_NOTIFY_PROP        = "auto_responder_cooldown"
+
+	// Users can be notified when someone reacts to their posts, which channel members can override for a channel.
+	REACTIONS_NOTIFY_PROP  = "reactions"
+	REACTIONS_NOTIFY_NONE  = "none"
+	REACTIONS_NOTIFY_BADGE = "badge"
+	REACTIONS_NOTIFY_PUSH  = "push"
+	REACTIONS_NOTIFY_DM    = "dm"
+
 	DEFAULT_LOCALE          = "en"
 	USER_AUTH_SERVICE_EMAIL = "email"
 
@@ -57,36 +74,38 @@ const (
 )
 
 type User struct {
-	Id                     string    `json:"id"`
-	CreateAt               int64     `json:"create_at,omitempty"`
-	UpdateAt               int64     `json:"update_at,omitempty"`
-	DeleteAt               int64     `json:"delete_at"`
-	Username               string    `json:"username"`
-	Password               string    `json:"password,omitempty"`
-	AuthData               *string   `json:"auth_data,omitempty"`
-	AuthService            string    `json:"auth_service"`
-	Email                  string    `json:"email"`
-	EmailVerified          bool      `json:"email_verified,omitempty"`
-	Nickname               string    `json:"nickname"`
-	FirstName              string    `json:"first_name"`
-	LastName               string    `json:"last_name"`
-	Position               string    `json:"position"`
-	Roles                  string    `json:"roles"`
-	AllowMarketing         bool      `json:"allow_marketing,omitempty"`
-	Props                  StringMap `json:"props,omitempty"`
-	NotifyProps            StringMap `json:"notify_props,omitempty"`
-	LastPasswordUpdate     int64     `json:"last_password_update,omitempty"`
-	LastPictureUpdate      int64     `json:"last_picture_update,omitempty"`
-	FailedAttempts         int       `json:"failed_attempts,omitempty"`
-	Locale                 string    `json:"locale"`
-	Timezone               StringMap `json:"timezone"`
-	MfaActive              bool      `json:"mfa_active,omitempty"`
-	MfaSecret              string    `json:"mfa_secret,omitempty"`
-	LastActivityAt         int64     `db:"-" json:"last_activity_at,omitempty"`
-	IsBot                  bool      `db:"-" json:"is_bot,omitempty"`
-	BotDescription         string    `db:"-" json:"bot_description,omitempty"`
-	TermsOfServiceId       string    `db:"-" json:"terms_of_service_id,omitempty"`
-	TermsOfServiceCreateAt int64     `db:"-" json:"terms_of_service_create_at,omitempty"`
+	Id                     string          `json:"id"`
+	CreateAt               int64           `json:"create_at,omitempty"`
+	UpdateAt               int64           `json:"update_at,omitempty"`
+	DeleteAt               int64           `json:"delete_at"`
+	Username               string          `json:"username"`
+	Password               string          `json:"password,omitempty"`
+	AuthData               *string         `json:"auth_data,omitempty"`
+	AuthService            string          `json:"auth_service"`
+	Email                  string          `json:"email"`
+	EmailVerified          bool            `json:"email_verified,omitempty"`
+	Nickname               string          `json:"nickname"`
+	FirstName              string          `json:"first_name"`
+	LastName               string          `json:"last_name"`
+	Position               string          `json:"position"`
+	Roles                  string          `json:"roles"`
+	AllowMarketing         bool            `json:"allow_marketing,omitempty"`
+	Props                  StringMap       `json:"props,omitempty"`
+	NotifyProps            StringMap       `json:"notify_props,omitempty"`
+	LastPasswordUpdate     int64           `json:"last_password_update,omitempty"`
+	LastPictureUpdate      int64           `json:"last_picture_update,omitempty"`
+	FailedAttempts         int             `json:"failed_attempts,omitempty"`
+	Locale                 string          `json:"locale"`
+	Timezone               StringMap       `json:"timezone"`
+	MfaActive              bool            `json:"mfa_active,omitempty"`
+	MfaSecret              string          `json:"mfa_secret,omitempty"`
+	LastActivityAt         int64           `db:"-" json:"last_activity_at,omitempty"`
+	IsBot                  bool            `db:"-" json:"is_bot,omitempty"`
+	BotDescription         string          `db:"-" json:"bot_description,omitempty"`
+	TermsOfServiceId       string          `db:"-" json:"terms_of_service_id,omitempty"`
+	TermsOfServiceCreateAt int64           `db:"-" json:"terms_of_service_create_at,omitempty"`
+	Attributes             StringMap       `db:"-" json:"attributes,omitempty"`
+	FeatureFlags           map[string]bool `db:"-" json:"feature_flags,omitempty"`
 }
 
 type UserUpdate struct {
@@ -227,6 +246,15 @@ func (u *User) DeepCopy() *User {
 	if u.Timezone != nil {
 		copyUser.Timezone = CopyStringMap(u.Timezone)
 	}
+	if u.Attributes != nil {
+		copyUser.Attributes = CopyStringMap(u.Attributes)
+	}
+	if u.FeatureFlags != nil {
+		copyUser.FeatureFlags = make(map[string]bool, len(u.FeatureFlags))
+		for name, enabled := range u.FeatureFlags {
+			copyUser.FeatureFlags[name] = enabled
+		}
+	}
 	return &copyUser
 }
 
@@ -290,6 +318,20 @@ func (u *User) IsValid() *AppError {
 		return InvalidUserError("locale", u.Id)
 	}
 
+	startAt, startErr := u.autoResponderNotifyPropInt(AUTO_RESPONDER_START_AT_NOTIFY_PROP)
+	endAt, endErr := u.autoResponderNotifyPropInt(AUTO_RESPONDER_END_AT_NOTIFY_PROP)
+	if startErr != nil || endErr != nil || (startAt > 0 && endAt > 0 && endAt <= startAt) {
+		return InvalidUserError("auto_responder_dates", u.Id)
+	}
+
+	if _, err := u.autoResponderNotifyPropInt(AUTO_RESPONDER_COOLDOWN_NOTIFY_PROP); err != nil {
+		return InvalidUserError("auto_responder_cooldown", u.Id)
+	}
+
+	if level, ok := u.NotifyProps[REACTIONS_NOTIFY_PROP]; ok && !IsReactionsNotifyLevelValid(level) {
+		return InvalidUserError("reactions_notify_level", u.Id)
+	}
+
 	return nil
 }
 
@@ -393,6 +435,69 @@ func (u *User) SetDefaultNotifications() {
 	u.NotifyProps[PUSH_STATUS_NOTIFY_PROP] = STATUS_AWAY
 	u.NotifyProps[COMMENTS_NOTIFY_PROP] = COMMENTS_NOTIFY_NEVER
 	u.NotifyProps[FIRST_NAME_NOTIFY_PROP] = "false"
+	u.NotifyProps[REACTIONS_NOTIFY_PROP] = REACTIONS_NOTIFY_NONE
+}
+
+// GetReactionsNotifyLevel returns how the user wants to be notified of reactions to their posts in a channel, given
+// the notify props of their membership of it, which override their own unless they're set to the default.
+func (u *User) GetReactionsNotifyLevel(channelNotifyProps StringMap) string {
+	if level := channelNotifyProps[REACTIONS_NOTIFY_PROP]; level != "" && level != CHANNEL_NOTIFY_DEFAULT {
+		return level
+	}
+
+	if level := u.NotifyProps[REACTIONS_NOTIFY_PROP]; level != "" {
+		return level
+	}
+
+	return REACTIONS_NOTIFY_NONE
+}
+
+func IsReactionsNotifyLevelValid(level string) bool {
+	return level == REACTIONS_NOTIFY_NONE ||
+		level == REACTIONS_NOTIFY_BADGE ||
+		level == REACTIONS_NOTIFY_PUSH ||
+		level == REACTIONS_NOTIFY_DM
+}
+
+// IsAutoResponderActiveAt returns whether the user's auto-responder is on at the given time, in milliseconds since the
+// epoch, which is only the case between its start and end times if it's scheduled.
+func (u *User) IsAutoResponderActiveAt(millis int64) bool {
+	if u.NotifyProps[AUTO_RESPONDER_ACTIVE_NOTIFY_PROP] != "true" {
+		return false
+	}
+
+	if startAt, _ := u.autoResponderNotifyPropInt(AUTO_RESPONDER_START_AT_NOTIFY_PROP); startAt > 0 && millis < startAt {
+		return false
+	}
+
+	if endAt, _ := u.autoResponderNotifyPropInt(AUTO_RESPONDER_END_AT_NOTIFY_PROP); endAt > 0 && millis >= endAt {
+		return false
+	}
+
+	return true
+}
+
+// AutoResponderCooldown returns how long the auto-responder waits before responding to the same sender again.
+func (u *User) AutoResponderCooldown() time.Duration {
+	minutes, _ := u.autoResponderNotifyPropInt(AUTO_RESPONDER_COOLDOWN_NOTIFY_PROP)
+	return time.Duration(minutes) * time.Minute
+}
+
+func (u *User) autoResponderNotifyPropInt(name string) (int64, error) {
+	value := u.NotifyProps[name]
+	if value == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if i < 0 {
+		return 0, fmt.Errorf("%v must not be negative", name)
+	}
+
+	return i, nil
 }
 
 func (user *User) UpdateMentionKeysFromUsername(oldUsername string) {
@@ -6,7 +6,13 @@ package model
 import (
 	"encoding/json"
 	"io"
+	"net"
 	"net/http"
+	"strings"
+)
+
+const (
+	USER_ACCESS_TOKEN_EXPIRING_DEFAULT_DAYS = 7
 )
 
 type UserAccessToken struct {
@@ -15,6 +21,10 @@ type UserAccessToken struct {
 	UserId      string `json:"user_id"`
 	Description string `json:"description"`
 	IsActive    bool   `json:"is_active"`
+	ExpiresAt   int64  `json:"expires_at"`
+	LastUsedAt  int64  `json:"last_used_at"`
+	AllowedIPs  string `json:"allowed_ips"`
+	Scope       string `json:"scope"`
 }
 
 func (t *UserAccessToken) IsValid() *AppError {
@@ -34,12 +44,78 @@ func (t *UserAccessToken) IsValid() *AppError {
 		return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.description.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if t.ExpiresAt < 0 {
+		return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.expires_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(t.AllowedIPs) > 1024 {
+		return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.allowed_ips.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, allowed := range strings.Fields(t.AllowedIPs) {
+		if _, _, err := net.ParseCIDR(allowed); err != nil && net.ParseIP(allowed) == nil {
+			return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.allowed_ips.app_error", nil, "allowed_ip="+allowed, http.StatusBadRequest)
+		}
+	}
+
+	if len(t.Scope) > 128 {
+		return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.scope.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, scope := range ParseOAuthScope(t.Scope) {
+		if scope != DEFAULT_SCOPE && !OAuthScopes.Contains(scope) {
+			return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.scope.app_error", nil, "scope="+scope, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
+// IsExpired returns true if the token has an expiry date that has passed.
+func (t *UserAccessToken) IsExpired() bool {
+	return t.ExpiresAt > 0 && GetMillis() > t.ExpiresAt
+}
+
+// IsAllowedIpAddress returns true if the token can be used from the IP address, which is the case when it
+// matches one of the allowed IP addresses or CIDR ranges of the token, or when the token has none.
+func (t *UserAccessToken) IsAllowedIpAddress(ipAddress string) bool {
+	return IsIpAddressAllowed(t.AllowedIPs, ipAddress)
+}
+
+// IsIpAddressAllowed returns true if the IP address matches one of the space separated IP addresses or CIDR
+// ranges, or if there are none.
+func IsIpAddressAllowed(allowed string, ipAddress string) bool {
+	ranges := strings.Fields(allowed)
+	if len(ranges) == 0 {
+		return true
+	}
+
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return false
+	}
+
+	for _, allowed := range ranges {
+		if _, ipRange, err := net.ParseCIDR(allowed); err == nil {
+			if ipRange.Contains(ip) {
+				return true
+			}
+		} else if allowedIp := net.ParseIP(allowed); allowedIp != nil && allowedIp.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (t *UserAccessToken) PreSave() {
 	t.Id = NewId()
 	t.IsActive = true
+	t.LastUsedAt = 0
+	t.AllowedIPs = strings.Join(strings.Fields(t.AllowedIPs), " ")
+	if len(t.Scope) > 0 {
+		t.Scope = NormalizeOAuthScope(t.Scope)
+	}
 }
 
 func (t *UserAccessToken) ToJson() string {
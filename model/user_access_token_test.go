@@ -6,6 +6,8 @@ package model
 import (
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestUserAccessTokenJson(t *testing.T) {
@@ -55,4 +57,61 @@ func TestUserAccessTokenIsValid(t *testing.T) {
 	if err := ad.IsValid(); err == nil || err.Id != "model.user_access_token.is_valid.description.app_error" {
 		t.Fatal(err)
 	}
+
+	ad.Description = ""
+	ad.ExpiresAt = -1
+	if err := ad.IsValid(); err == nil || err.Id != "model.user_access_token.is_valid.expires_at.app_error" {
+		t.Fatal(err)
+	}
+
+	ad.ExpiresAt = GetMillis()
+	ad.AllowedIPs = "10.0.0.0/8 not-an-ip"
+	if err := ad.IsValid(); err == nil || err.Id != "model.user_access_token.is_valid.allowed_ips.app_error" {
+		t.Fatal(err)
+	}
+
+	ad.AllowedIPs = "10.0.0.0/8 192.168.1.1 ::1"
+	ad.Scope = "read:posts admin"
+	if err := ad.IsValid(); err == nil || err.Id != "model.user_access_token.is_valid.scope.app_error" {
+		t.Fatal(err)
+	}
+
+	ad.Scope = "read:posts user"
+	if err := ad.IsValid(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUserAccessTokenIsExpired(t *testing.T) {
+	token := UserAccessToken{}
+	assert.False(t, token.IsExpired())
+
+	token.ExpiresAt = GetMillis() + 60000
+	assert.False(t, token.IsExpired())
+
+	token.ExpiresAt = GetMillis() - 1
+	assert.True(t, token.IsExpired())
+}
+
+func TestUserAccessTokenIsAllowedIpAddress(t *testing.T) {
+	token := UserAccessToken{}
+	assert.True(t, token.IsAllowedIpAddress("127.0.0.1"))
+
+	token.AllowedIPs = "10.0.0.0/8 192.168.1.1 2001:db8::/32"
+	assert.True(t, token.IsAllowedIpAddress("10.20.30.40"))
+	assert.True(t, token.IsAllowedIpAddress("192.168.1.1"))
+	assert.True(t, token.IsAllowedIpAddress("2001:db8::1"))
+	assert.False(t, token.IsAllowedIpAddress("127.0.0.1"))
+	assert.False(t, token.IsAllowedIpAddress("not-an-ip"))
+}
+
+func TestUserAccessTokenPreSave(t *testing.T) {
+	token := UserAccessToken{AllowedIPs: " 10.0.0.0/8   192.168.1.1 ", Scope: "write:posts read:posts write:posts", LastUsedAt: 5}
+	token.PreSave()
+
+	assert.Len(t, token.Id, 26)
+	assert.True(t, token.IsActive)
+	assert.Equal(t, int64(0), token.LastUsedAt)
+	assert.Equal(t, "10.0.0.0/8 192.168.1.1", token.AllowedIPs)
+	assert.Equal(t, "read:posts write:posts", token.Scope)
 }
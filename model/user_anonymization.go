@@ -0,0 +1,32 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import "strings"
+
+const (
+	USER_ANONYMIZATION_USERNAME_PREFIX = "anonymized-"
+	USER_ANONYMIZATION_EMAIL_DOMAIN    = "anonymized.invalid"
+
+	USER_ANONYMIZATION_JOB_DATA_USER_ID         = "user_id"
+	USER_ANONYMIZATION_JOB_DATA_REQUESTER_ID    = "requester_id"
+	USER_ANONYMIZATION_JOB_DATA_POSTS_REWRITTEN = "posts_rewritten"
+)
+
+// AnonymizedUsername returns the username given to a user once their personal data is erased. It is derived from
+// the id of the user so that it stays unique.
+func AnonymizedUsername(userId string) string {
+	return USER_ANONYMIZATION_USERNAME_PREFIX + userId
+}
+
+// AnonymizedEmail returns the email given to a user once their personal data is erased. The domain is reserved, so
+// that no email is ever sent to it.
+func AnonymizedEmail(userId string) string {
+	return userId + "@" + USER_ANONYMIZATION_EMAIL_DOMAIN
+}
+
+// IsAnonymized returns whether the personal data of the user was erased by a user anonymization job.
+func (u *User) IsAnonymized() bool {
+	return u.Username == AnonymizedUsername(u.Id) && strings.HasSuffix(u.Email, "@"+USER_ANONYMIZATION_EMAIL_DOMAIN)
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserIsAnonymized(t *testing.T) {
+	user := &User{Id: NewId(), Username: "someone", Email: "someone@example.com"}
+	assert.False(t, user.IsAnonymized())
+
+	user.Username = AnonymizedUsername(user.Id)
+	assert.False(t, user.IsAnonymized())
+
+	user.Email = AnonymizedEmail(user.Id)
+	assert.True(t, user.IsAnonymized())
+	assert.True(t, IsValidUsername(user.Username))
+	assert.True(t, IsValidEmail(user.Email))
+}
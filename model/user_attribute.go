@@ -0,0 +1,178 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	USER_ATTRIBUTE_SOURCE_LDAP = "ldap"
+	USER_ATTRIBUTE_SOURCE_SAML = "saml"
+
+	USER_ATTRIBUTE_NAME_MAX_LENGTH             = 64
+	USER_ATTRIBUTE_DISPLAY_NAME_MAX_RUNES      = 64
+	USER_ATTRIBUTE_SOURCE_ATTRIBUTE_MAX_LENGTH = 128
+	USER_ATTRIBUTE_VALUE_MAX_RUNES             = 256
+	USER_ATTRIBUTE_MAX_FIELDS                  = 32
+)
+
+// UserAttributeField is an admin-defined attribute of user profiles, such as a department, a location or pronouns.
+// The attribute can be synced from the given attribute of the LDAP server or of the SAML assertion of the user.
+type UserAttributeField struct {
+	Id            string `json:"id"`
+	CreateAt      int64  `json:"create_at"`
+	UpdateAt      int64  `json:"update_at"`
+	DeleteAt      int64  `json:"delete_at"`
+	Name          string `json:"name"`
+	DisplayName   string `json:"display_name"`
+	LdapAttribute string `json:"ldap_attribute"`
+	SamlAttribute string `json:"saml_attribute"`
+	Searchable    bool   `json:"searchable"`
+}
+
+// UserAttributeValue is the value of a user attribute for a user. Values synced from an identity provider record
+// it as their source, and can't be edited by the user.
+type UserAttributeValue struct {
+	UserId   string `json:"user_id"`
+	FieldId  string `json:"field_id"`
+	Value    string `json:"value"`
+	Source   string `json:"source"`
+	UpdateAt int64  `json:"update_at"`
+}
+
+func (o *UserAttributeField) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("UserAttributeField.IsValid", "model.user_attribute_field.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("UserAttributeField.IsValid", "model.user_attribute_field.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("UserAttributeField.IsValid", "model.user_attribute_field.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidUserAttributeName(o.Name) {
+		return NewAppError("UserAttributeField.IsValid", "model.user_attribute_field.is_valid.name.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.DisplayName == "" || utf8.RuneCountInString(o.DisplayName) > USER_ATTRIBUTE_DISPLAY_NAME_MAX_RUNES {
+		return NewAppError("UserAttributeField.IsValid", "model.user_attribute_field.is_valid.display_name.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.LdapAttribute) > USER_ATTRIBUTE_SOURCE_ATTRIBUTE_MAX_LENGTH {
+		return NewAppError("UserAttributeField.IsValid", "model.user_attribute_field.is_valid.ldap_attribute.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.SamlAttribute) > USER_ATTRIBUTE_SOURCE_ATTRIBUTE_MAX_LENGTH {
+		return NewAppError("UserAttributeField.IsValid", "model.user_attribute_field.is_valid.saml_attribute.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// NormalizeUserAttributeName returns the name an attribute is saved with, which is lower case.
+func NormalizeUserAttributeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// IsValidUserAttributeName returns true if the name can be used as the key of an attribute in user profiles.
+func IsValidUserAttributeName(name string) bool {
+	return len(name) > 0 && len(name) <= USER_ATTRIBUTE_NAME_MAX_LENGTH && IsValidAlphaNumHyphenUnderscore(name, true)
+}
+
+func (o *UserAttributeField) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.Name = NormalizeUserAttributeName(o.Name)
+	o.LdapAttribute = strings.TrimSpace(o.LdapAttribute)
+	o.SamlAttribute = strings.TrimSpace(o.SamlAttribute)
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *UserAttributeField) PreUpdate() {
+	o.Name = NormalizeUserAttributeName(o.Name)
+	o.LdapAttribute = strings.TrimSpace(o.LdapAttribute)
+	o.SamlAttribute = strings.TrimSpace(o.SamlAttribute)
+	o.UpdateAt = GetMillis()
+}
+
+// SourceAttribute returns the attribute of the identity provider the field is synced from, if any.
+func (o *UserAttributeField) SourceAttribute(source string) string {
+	switch source {
+	case USER_ATTRIBUTE_SOURCE_LDAP:
+		return o.LdapAttribute
+	case USER_ATTRIBUTE_SOURCE_SAML:
+		return o.SamlAttribute
+	}
+
+	return ""
+}
+
+func (o *UserAttributeField) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func UserAttributeFieldFromJson(data io.Reader) *UserAttributeField {
+	var o *UserAttributeField
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func UserAttributeFieldListToJson(l []*UserAttributeField) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func UserAttributeFieldListFromJson(data io.Reader) []*UserAttributeField {
+	var o []*UserAttributeField
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *UserAttributeValue) IsValid() *AppError {
+	if len(o.UserId) != 26 {
+		return NewAppError("UserAttributeValue.IsValid", "model.user_attribute_value.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.FieldId) != 26 {
+		return NewAppError("UserAttributeValue.IsValid", "model.user_attribute_value.is_valid.field_id.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.Value == "" || utf8.RuneCountInString(o.Value) > USER_ATTRIBUTE_VALUE_MAX_RUNES {
+		return NewAppError("UserAttributeValue.IsValid", "model.user_attribute_value.is_valid.value.app_error", nil, "user_id="+o.UserId+", field_id="+o.FieldId, http.StatusBadRequest)
+	}
+
+	switch o.Source {
+	case "", USER_ATTRIBUTE_SOURCE_LDAP, USER_ATTRIBUTE_SOURCE_SAML:
+	default:
+		return NewAppError("UserAttributeValue.IsValid", "model.user_attribute_value.is_valid.source.app_error", nil, "user_id="+o.UserId+", field_id="+o.FieldId, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("UserAttributeValue.IsValid", "model.user_attribute_value.is_valid.update_at.app_error", nil, "user_id="+o.UserId+", field_id="+o.FieldId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *UserAttributeValue) PreSave() {
+	o.Value = strings.TrimSpace(o.Value)
+	o.UpdateAt = GetMillis()
+}
+
+// IsSynced returns true if the value was synced from an identity provider.
+func (o *UserAttributeValue) IsSynced() bool {
+	return o.Source != ""
+}
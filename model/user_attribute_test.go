@@ -0,0 +1,97 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAttributeFieldJson(t *testing.T) {
+	field := UserAttributeField{Id: NewId(), Name: "department", DisplayName: "Department", LdapAttribute: "departmentNumber", Searchable: true}
+	result := UserAttributeFieldFromJson(strings.NewReader(field.ToJson()))
+	assert.Equal(t, field, *result)
+
+	list := UserAttributeFieldListFromJson(strings.NewReader(UserAttributeFieldListToJson([]*UserAttributeField{&field})))
+	require.Len(t, list, 1)
+	assert.Equal(t, field, *list[0])
+}
+
+func TestUserAttributeFieldIsValid(t *testing.T) {
+	field := UserAttributeField{Name: " Cost_Center ", DisplayName: "Cost center", SamlAttribute: " costCenter "}
+	field.PreSave()
+	assert.Equal(t, "cost_center", field.Name)
+	assert.Equal(t, "costCenter", field.SamlAttribute)
+	require.Nil(t, field.IsValid())
+
+	for name, test := range map[string]struct {
+		Update func(field *UserAttributeField)
+		ErrId  string
+	}{
+		"id":           {func(o *UserAttributeField) { o.Id = "abc" }, "model.user_attribute_field.is_valid.id.app_error"},
+		"no name":      {func(o *UserAttributeField) { o.Name = "" }, "model.user_attribute_field.is_valid.name.app_error"},
+		"invalid name": {func(o *UserAttributeField) { o.Name = "cost center" }, "model.user_attribute_field.is_valid.name.app_error"},
+		"no label":     {func(o *UserAttributeField) { o.DisplayName = "" }, "model.user_attribute_field.is_valid.display_name.app_error"},
+		"label length": {func(o *UserAttributeField) {
+			o.DisplayName = strings.Repeat("é", USER_ATTRIBUTE_DISPLAY_NAME_MAX_RUNES+1)
+		}, "model.user_attribute_field.is_valid.display_name.app_error"},
+		"ldap attribute": {func(o *UserAttributeField) {
+			o.LdapAttribute = strings.Repeat("a", USER_ATTRIBUTE_SOURCE_ATTRIBUTE_MAX_LENGTH+1)
+		}, "model.user_attribute_field.is_valid.ldap_attribute.app_error"},
+		"saml attribute": {func(o *UserAttributeField) {
+			o.SamlAttribute = strings.Repeat("a", USER_ATTRIBUTE_SOURCE_ATTRIBUTE_MAX_LENGTH+1)
+		}, "model.user_attribute_field.is_valid.saml_attribute.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := field
+			test.Update(&invalid)
+
+			err := invalid.IsValid()
+			require.NotNil(t, err)
+			assert.Equal(t, test.ErrId, err.Id)
+		})
+	}
+}
+
+func TestUserAttributeFieldSourceAttribute(t *testing.T) {
+	field := UserAttributeField{LdapAttribute: "l", SamlAttribute: "location"}
+	assert.Equal(t, "l", field.SourceAttribute(USER_ATTRIBUTE_SOURCE_LDAP))
+	assert.Equal(t, "location", field.SourceAttribute(USER_ATTRIBUTE_SOURCE_SAML))
+	assert.Equal(t, "", field.SourceAttribute("gitlab"))
+}
+
+func TestUserAttributeValueIsValid(t *testing.T) {
+	value := UserAttributeValue{UserId: NewId(), FieldId: NewId(), Value: " Engineering "}
+	value.PreSave()
+	assert.Equal(t, "Engineering", value.Value)
+	require.Nil(t, value.IsValid())
+	assert.False(t, value.IsSynced())
+
+	value.Source = USER_ATTRIBUTE_SOURCE_LDAP
+	require.Nil(t, value.IsValid())
+	assert.True(t, value.IsSynced())
+
+	for name, test := range map[string]struct {
+		Update func(value *UserAttributeValue)
+		ErrId  string
+	}{
+		"user id":      {func(o *UserAttributeValue) { o.UserId = "abc" }, "model.user_attribute_value.is_valid.user_id.app_error"},
+		"field id":     {func(o *UserAttributeValue) { o.FieldId = "abc" }, "model.user_attribute_value.is_valid.field_id.app_error"},
+		"no value":     {func(o *UserAttributeValue) { o.Value = "" }, "model.user_attribute_value.is_valid.value.app_error"},
+		"value length": {func(o *UserAttributeValue) { o.Value = strings.Repeat("é", USER_ATTRIBUTE_VALUE_MAX_RUNES+1) }, "model.user_attribute_value.is_valid.value.app_error"},
+		"source":       {func(o *UserAttributeValue) { o.Source = "gitlab" }, "model.user_attribute_value.is_valid.source.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := value
+			test.Update(&invalid)
+
+			err := invalid.IsValid()
+			require.NotNil(t, err)
+			assert.Equal(t, test.ErrId, err.Id)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	USER_AVAILABILITY_BUSY = "busy"
+	USER_AVAILABILITY_FREE = "free"
+
+	USER_AVAILABILITY_SOURCE_MANUAL = "manual"
+
+	USER_AVAILABILITY_SOURCE_MAX_LENGTH = 190
+	USER_AVAILABILITY_TITLE_MAX_RUNES   = 256
+
+	// USER_AVAILABILITY_MAX_RANGE is the longest span of time, in milliseconds, that availability can be queried for.
+	USER_AVAILABILITY_MAX_RANGE = 366 * 24 * 60 * 60 * 1000
+)
+
+// UserAvailability is a block of time during which a user is busy or free, such as a meeting synced from their
+// calendar. Source attributes it to what created it, such as the id of a calendar plugin, so that integrations can
+// tell their blocks apart from those of others.
+type UserAvailability struct {
+	Id        string `json:"id"`
+	CreateAt  int64  `json:"create_at"`
+	UpdateAt  int64  `json:"update_at"`
+	UserId    string `json:"user_id"`
+	CreatorId string `json:"creator_id"`
+	Source    string `json:"source"`
+	Status    string `json:"status"`
+	Title     string `json:"title"`
+	StartAt   int64  `json:"start_at"`
+	EndAt     int64  `json:"end_at"`
+}
+
+func (o *UserAvailability) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("UserAvailability.IsValid", "model.user_availability.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("UserAvailability.IsValid", "model.user_availability.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("UserAvailability.IsValid", "model.user_availability.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("UserAvailability.IsValid", "model.user_availability.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("UserAvailability.IsValid", "model.user_availability.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Source == "" || len(o.Source) > USER_AVAILABILITY_SOURCE_MAX_LENGTH {
+		return NewAppError("UserAvailability.IsValid", "model.user_availability.is_valid.source.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Status != USER_AVAILABILITY_BUSY && o.Status != USER_AVAILABILITY_FREE {
+		return NewAppError("UserAvailability.IsValid", "model.user_availability.is_valid.status.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Title) > USER_AVAILABILITY_TITLE_MAX_RUNES {
+		return NewAppError("UserAvailability.IsValid", "model.user_availability.is_valid.title.app_error", map[string]interface{}{"Max": USER_AVAILABILITY_TITLE_MAX_RUNES}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.StartAt <= 0 || o.EndAt <= o.StartAt {
+		return NewAppError("UserAvailability.IsValid", "model.user_availability.is_valid.dates.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *UserAvailability) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Source == "" {
+		o.Source = USER_AVAILABILITY_SOURCE_MANUAL
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *UserAvailability) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+// Covers returns whether the block includes the given time, in milliseconds since the epoch.
+func (o *UserAvailability) Covers(millis int64) bool {
+	return o.StartAt <= millis && millis < o.EndAt
+}
+
+// UserAvailabilityStatusAt returns whether a user is busy or free at the given time according to their blocks of
+// availability. Blocks marking them free take precedence over overlapping busy ones, so that an integration can open
+// up time within a longer busy block.
+func UserAvailabilityStatusAt(blocks []*UserAvailability, millis int64) string {
+	status := USER_AVAILABILITY_FREE
+
+	for _, block := range blocks {
+		if !block.Covers(millis) {
+			continue
+		}
+
+		if block.Status == USER_AVAILABILITY_FREE {
+			return USER_AVAILABILITY_FREE
+		}
+		status = USER_AVAILABILITY_BUSY
+	}
+
+	return status
+}
+
+func (o *UserAvailability) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func UserAvailabilityFromJson(data io.Reader) *UserAvailability {
+	var o *UserAvailability
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func UserAvailabilityListToJson(l []*UserAvailability) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func UserAvailabilityListFromJson(data io.Reader) []*UserAvailability {
+	var o []*UserAvailability
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
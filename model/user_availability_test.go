@@ -0,0 +1,59 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAvailabilityJson(t *testing.T) {
+	block := UserAvailability{Id: NewId(), UserId: NewId(), Status: USER_AVAILABILITY_BUSY, StartAt: 1000, EndAt: 2000}
+	result := UserAvailabilityFromJson(strings.NewReader(block.ToJson()))
+	assert.Equal(t, block, *result)
+
+	list := UserAvailabilityListFromJson(strings.NewReader(UserAvailabilityListToJson([]*UserAvailability{&block})))
+	require.Len(t, list, 1)
+	assert.Equal(t, block, *list[0])
+}
+
+func TestUserAvailabilityIsValid(t *testing.T) {
+	block := UserAvailability{UserId: NewId(), CreatorId: NewId(), Status: USER_AVAILABILITY_BUSY, Title: "Planning", StartAt: 1000, EndAt: 2000}
+	block.PreSave()
+	require.Nil(t, block.IsValid())
+	assert.Equal(t, USER_AVAILABILITY_SOURCE_MANUAL, block.Source)
+
+	for name, update := range map[string]func(b *UserAvailability){
+		"user id":    func(b *UserAvailability) { b.UserId = "abc" },
+		"creator id": func(b *UserAvailability) { b.CreatorId = "" },
+		"source":     func(b *UserAvailability) { b.Source = strings.Repeat("a", USER_AVAILABILITY_SOURCE_MAX_LENGTH+1) },
+		"status":     func(b *UserAvailability) { b.Status = "away" },
+		"title":      func(b *UserAvailability) { b.Title = strings.Repeat("a", USER_AVAILABILITY_TITLE_MAX_RUNES+1) },
+		"start at":   func(b *UserAvailability) { b.StartAt = 0 },
+		"end at":     func(b *UserAvailability) { b.EndAt = b.StartAt },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := block
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestUserAvailabilityStatusAt(t *testing.T) {
+	blocks := []*UserAvailability{
+		{Status: USER_AVAILABILITY_BUSY, StartAt: 1000, EndAt: 5000},
+		{Status: USER_AVAILABILITY_FREE, StartAt: 2000, EndAt: 3000},
+	}
+
+	assert.Equal(t, USER_AVAILABILITY_FREE, UserAvailabilityStatusAt(blocks, 999))
+	assert.Equal(t, USER_AVAILABILITY_BUSY, UserAvailabilityStatusAt(blocks, 1000))
+	assert.Equal(t, USER_AVAILABILITY_FREE, UserAvailabilityStatusAt(blocks, 2500))
+	assert.Equal(t, USER_AVAILABILITY_BUSY, UserAvailabilityStatusAt(blocks, 3000))
+	assert.Equal(t, USER_AVAILABILITY_FREE, UserAvailabilityStatusAt(blocks, 5000))
+	assert.Equal(t, USER_AVAILABILITY_FREE, UserAvailabilityStatusAt(nil, 1000))
+}
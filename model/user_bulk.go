@@ -0,0 +1,109 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	BULK_USERS_ACTION_DEACTIVATE     = "deactivate"
+	BULK_USERS_ACTION_UPDATE_ROLES   = "update_roles"
+	BULK_USERS_ACTION_MOVE_TEAM      = "move_team"
+	BULK_USERS_ACTION_RESET_PASSWORD = "reset_password"
+
+	BULK_USERS_MAX_USER_IDS = 10000
+
+	BULK_USERS_RESULT_SUCCESS = "success"
+	BULK_USERS_RESULT_FAILED  = "failed"
+
+	BULK_USERS_JOB_DATA_OPERATION_ID = "operation_id"
+	BULK_USERS_JOB_DATA_ACTION       = "action"
+	BULK_USERS_JOB_DATA_REQUESTER_ID = "requester_id"
+	BULK_USERS_JOB_DATA_USERS_DONE   = "users_done"
+	BULK_USERS_JOB_DATA_USERS_FAILED = "users_failed"
+	BULK_USERS_JOB_DATA_USERS_TOTAL  = "users_total"
+)
+
+// BulkUsersOperation describes a change to apply to a list of users. The update roles action replaces the
+// system roles of the users with the given roles. The move team action adds the users to the target team and,
+// when a source team is given, removes them from it. The reset password action invalidates the password and
+// the sessions of the users and emails them a link to choose a new password.
+type BulkUsersOperation struct {
+	Action     string   `json:"action"`
+	UserIds    []string `json:"user_ids"`
+	Roles      string   `json:"roles,omitempty"`
+	FromTeamId string   `json:"from_team_id,omitempty"`
+	ToTeamId   string   `json:"to_team_id,omitempty"`
+}
+
+// BulkUsersResult records the outcome of a bulk operation for a single user.
+type BulkUsersResult struct {
+	UserId string `json:"user_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkUsersResults is the report written by a bulk users job.
+type BulkUsersResults struct {
+	JobId   string             `json:"job_id"`
+	Action  string             `json:"action"`
+	Results []*BulkUsersResult `json:"results"`
+}
+
+func (o *BulkUsersOperation) IsValid() *AppError {
+	switch o.Action {
+	case BULK_USERS_ACTION_DEACTIVATE, BULK_USERS_ACTION_RESET_PASSWORD:
+	case BULK_USERS_ACTION_UPDATE_ROLES:
+		if len(strings.TrimSpace(o.Roles)) == 0 || !IsValidUserRoles(o.Roles) {
+			return NewAppError("BulkUsersOperation.IsValid", "model.bulk_users.is_valid.roles.app_error", nil, "roles="+o.Roles, http.StatusBadRequest)
+		}
+	case BULK_USERS_ACTION_MOVE_TEAM:
+		if !IsValidId(o.ToTeamId) {
+			return NewAppError("BulkUsersOperation.IsValid", "model.bulk_users.is_valid.to_team_id.app_error", nil, "to_team_id="+o.ToTeamId, http.StatusBadRequest)
+		}
+		if len(o.FromTeamId) > 0 && (!IsValidId(o.FromTeamId) || o.FromTeamId == o.ToTeamId) {
+			return NewAppError("BulkUsersOperation.IsValid", "model.bulk_users.is_valid.from_team_id.app_error", nil, "from_team_id="+o.FromTeamId, http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("BulkUsersOperation.IsValid", "model.bulk_users.is_valid.action.app_error", nil, "action="+o.Action, http.StatusBadRequest)
+	}
+
+	if len(o.UserIds) == 0 || len(o.UserIds) > BULK_USERS_MAX_USER_IDS {
+		return NewAppError("BulkUsersOperation.IsValid", "model.bulk_users.is_valid.user_ids.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, userId := range o.UserIds {
+		if !IsValidId(userId) {
+			return NewAppError("BulkUsersOperation.IsValid", "model.bulk_users.is_valid.user_ids.app_error", nil, "user_id="+userId, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (o *BulkUsersOperation) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func BulkUsersOperationFromJson(data io.Reader) *BulkUsersOperation {
+	var o *BulkUsersOperation
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (r *BulkUsersResults) ToJson() string {
+	b, _ := json.Marshal(r)
+	return string(b)
+}
+
+func BulkUsersResultsFromJson(data io.Reader) *BulkUsersResults {
+	var r *BulkUsersResults
+	json.NewDecoder(data).Decode(&r)
+	return r
+}
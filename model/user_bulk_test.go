@@ -0,0 +1,94 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkUsersOperationIsValid(t *testing.T) {
+	userIds := []string{NewId(), NewId()}
+	tooMany := make([]string, BULK_USERS_MAX_USER_IDS+1)
+	for i := range tooMany {
+		tooMany[i] = NewId()
+	}
+
+	for name, tc := range map[string]struct {
+		Operation BulkUsersOperation
+		Valid     bool
+	}{
+		"deactivate": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_DEACTIVATE, UserIds: userIds},
+			Valid:     true,
+		},
+		"reset password": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_RESET_PASSWORD, UserIds: userIds},
+			Valid:     true,
+		},
+		"update roles": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_UPDATE_ROLES, UserIds: userIds, Roles: SYSTEM_USER_ROLE_ID},
+			Valid:     true,
+		},
+		"move team": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_MOVE_TEAM, UserIds: userIds, FromTeamId: NewId(), ToTeamId: NewId()},
+			Valid:     true,
+		},
+		"add to team": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_MOVE_TEAM, UserIds: userIds, ToTeamId: NewId()},
+			Valid:     true,
+		},
+		"unknown action": {
+			Operation: BulkUsersOperation{Action: "delete", UserIds: userIds},
+		},
+		"no users": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_DEACTIVATE},
+		},
+		"too many users": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_DEACTIVATE, UserIds: tooMany},
+		},
+		"invalid user": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_DEACTIVATE, UserIds: []string{NewId(), "junk"}},
+		},
+		"no roles": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_UPDATE_ROLES, UserIds: userIds},
+		},
+		"invalid roles": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_UPDATE_ROLES, UserIds: userIds, Roles: "junk!"},
+		},
+		"no target team": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_MOVE_TEAM, UserIds: userIds, FromTeamId: NewId()},
+		},
+		"same teams": {
+			Operation: BulkUsersOperation{Action: BULK_USERS_ACTION_MOVE_TEAM, UserIds: userIds, FromTeamId: userIds[0], ToTeamId: userIds[0]},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.Operation.IsValid()
+			if tc.Valid {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestBulkUsersResultsJson(t *testing.T) {
+	results := &BulkUsersResults{
+		JobId:  NewId(),
+		Action: BULK_USERS_ACTION_DEACTIVATE,
+		Results: []*BulkUsersResult{
+			{UserId: NewId(), Status: BULK_USERS_RESULT_SUCCESS},
+			{UserId: NewId(), Status: BULK_USERS_RESULT_FAILED, Error: "error"},
+		},
+	}
+
+	decoded := BulkUsersResultsFromJson(strings.NewReader(results.ToJson()))
+	require.NotNil(t, decoded)
+	assert.Equal(t, results, decoded)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	USER_DEACTIVATION_JOB_DATA_USERS_DEACTIVATED = "users_deactivated"
+	USER_DEACTIVATION_JOB_DATA_USERS_FAILED      = "users_failed"
+)
+
+// UserDeactivationSchedule records when a user, such as a contractor reaching their end date, is deactivated and
+// offboarded.
+type UserDeactivationSchedule struct {
+	UserId       string `json:"user_id"`
+	CreatorId    string `json:"creator_id"`
+	CreateAt     int64  `json:"create_at"`
+	UpdateAt     int64  `json:"update_at"`
+	DeactivateAt int64  `json:"deactivate_at"`
+}
+
+func (o *UserDeactivationSchedule) IsValid() *AppError {
+	if len(o.UserId) != 26 {
+		return NewAppError("UserDeactivationSchedule.IsValid", "model.user_deactivation_schedule.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.CreatorId) != 26 {
+		return NewAppError("UserDeactivationSchedule.IsValid", "model.user_deactivation_schedule.is_valid.creator_id.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("UserDeactivationSchedule.IsValid", "model.user_deactivation_schedule.is_valid.create_at.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("UserDeactivationSchedule.IsValid", "model.user_deactivation_schedule.is_valid.update_at.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.DeactivateAt <= 0 {
+		return NewAppError("UserDeactivationSchedule.IsValid", "model.user_deactivation_schedule.is_valid.deactivate_at.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *UserDeactivationSchedule) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+	o.UpdateAt = GetMillis()
+}
+
+func (o *UserDeactivationSchedule) IsDue() bool {
+	return o.DeactivateAt <= GetMillis()
+}
+
+func (o *UserDeactivationSchedule) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func UserDeactivationScheduleFromJson(data io.Reader) *UserDeactivationSchedule {
+	var o *UserDeactivationSchedule
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func UserDeactivationScheduleListToJson(l []*UserDeactivationSchedule) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func UserDeactivationScheduleListFromJson(data io.Reader) []*UserDeactivationSchedule {
+	var o []*UserDeactivationSchedule
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
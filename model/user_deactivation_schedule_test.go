@@ -0,0 +1,46 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserDeactivationScheduleJson(t *testing.T) {
+	schedule := UserDeactivationSchedule{UserId: NewId(), CreatorId: NewId(), DeactivateAt: GetMillis()}
+	result := UserDeactivationScheduleFromJson(strings.NewReader(schedule.ToJson()))
+	assert.Equal(t, schedule, *result)
+
+	list := UserDeactivationScheduleListFromJson(strings.NewReader(UserDeactivationScheduleListToJson([]*UserDeactivationSchedule{&schedule})))
+	require.Len(t, list, 1)
+	assert.Equal(t, schedule, *list[0])
+}
+
+func TestUserDeactivationScheduleIsValid(t *testing.T) {
+	schedule := UserDeactivationSchedule{UserId: NewId(), CreatorId: NewId(), DeactivateAt: GetMillis() + 1000}
+	schedule.PreSave()
+	require.Nil(t, schedule.IsValid())
+	assert.False(t, schedule.IsDue())
+
+	for name, update := range map[string]func(s *UserDeactivationSchedule){
+		"user id":       func(s *UserDeactivationSchedule) { s.UserId = "abc" },
+		"creator id":    func(s *UserDeactivationSchedule) { s.CreatorId = "" },
+		"create at":     func(s *UserDeactivationSchedule) { s.CreateAt = 0 },
+		"update at":     func(s *UserDeactivationSchedule) { s.UpdateAt = 0 },
+		"deactivate at": func(s *UserDeactivationSchedule) { s.DeactivateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := schedule
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+
+	schedule.DeactivateAt = GetMillis() - 1000
+	assert.True(t, schedule.IsDue())
+}
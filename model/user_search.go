@@ -6,11 +6,35 @@ package model
 import (
 	"encoding/json"
 	"io"
+	"sort"
+	"strings"
 )
 
 const USER_SEARCH_MAX_LIMIT = 1000
 const USER_SEARCH_DEFAULT_LIMIT = 100
 
+// USER_SEARCH_FUZZY_MIN_LENGTH is the length the terms of a search must have for users to be matched fuzzily.
+const USER_SEARCH_FUZZY_MIN_LENGTH = 3
+
+// How well a user matches the term of a search, from the worst to the best match.
+const (
+	USER_SEARCH_MATCH_OTHER = iota + 1
+	USER_SEARCH_MATCH_NAME_PREFIX
+	USER_SEARCH_MATCH_USERNAME_PREFIX
+	USER_SEARCH_MATCH_USERNAME
+)
+
+// How much each kind of interaction between users adds to their interaction score. A direct message scores less the
+// longer ago it was, halving after USER_INTERACTION_DIRECT_MESSAGE_HALF_LIFE milliseconds, while shared threads and
+// channels stop adding to the score past USER_INTERACTION_MAX_SHARED of each.
+const (
+	USER_INTERACTION_DIRECT_MESSAGE_SCORE     = 1000
+	USER_INTERACTION_DIRECT_MESSAGE_HALF_LIFE = 7 * 24 * 60 * 60 * 1000
+	USER_INTERACTION_SHARED_THREAD_SCORE      = 40
+	USER_INTERACTION_SHARED_CHANNEL_SCORE     = 20
+	USER_INTERACTION_MAX_SHARED               = 20
+)
+
 // UserSearch captures the parameters provided by a client for initiating a user search.
 type UserSearch struct {
 	Term             string `json:"term"`
@@ -62,4 +86,108 @@ type UserSearchOptions struct {
 	Role string
 	// Restrict to search in a list of teams and channels
 	ViewRestrictions *ViewUsersRestrictions
+	// Fuzzy allows search to match users whose usernames, nicknames or full names contain the letters of the
+	// terms in order, in addition to the ones starting with the terms.
+	Fuzzy bool
+}
+
+// UserSearchMatchScore returns how well the user matches the term of a search, as one of the USER_SEARCH_MATCH_*
+// constants. Every word of the term must match the user, so the score is the one of the word matching the worst.
+// Users matched by fields other than their username, nickname or full name, or fuzzily, get the lowest score.
+func UserSearchMatchScore(user *User, term string) int {
+	term = strings.ToLower(strings.TrimLeft(strings.TrimSpace(term), "@"))
+	username := strings.ToLower(user.Username)
+
+	if term == "" || term == username {
+		return USER_SEARCH_MATCH_USERNAME
+	}
+
+	names := strings.Fields(strings.ToLower(user.FirstName + " " + user.LastName + " " + user.Nickname))
+
+	score := USER_SEARCH_MATCH_USERNAME
+	for _, word := range strings.Fields(term) {
+		word = strings.TrimLeft(word, "@")
+
+		wordScore := USER_SEARCH_MATCH_OTHER
+		if strings.HasPrefix(username, word) {
+			wordScore = USER_SEARCH_MATCH_USERNAME_PREFIX
+		} else {
+			for _, name := range names {
+				if strings.HasPrefix(name, word) {
+					wordScore = USER_SEARCH_MATCH_NAME_PREFIX
+					break
+				}
+			}
+		}
+
+		if wordScore < score {
+			score = wordScore
+		}
+	}
+
+	return score
+}
+
+// UserInteraction is how a user recently interacted with another one, which ranks the other user in their searches.
+type UserInteraction struct {
+	LastDirectMessageAt int64
+	SharedThreads       int64
+	SharedChannels      int64
+}
+
+// Score returns the interaction score at the given time, in milliseconds since the epoch.
+func (i *UserInteraction) Score(now int64) int64 {
+	var score int64
+
+	if i.LastDirectMessageAt > 0 {
+		age := now - i.LastDirectMessageAt
+		if age < 0 {
+			age = 0
+		}
+		score += USER_INTERACTION_DIRECT_MESSAGE_SCORE * USER_INTERACTION_DIRECT_MESSAGE_HALF_LIFE / (USER_INTERACTION_DIRECT_MESSAGE_HALF_LIFE + age)
+	}
+
+	sharedThreads, sharedChannels := i.SharedThreads, i.SharedChannels
+	if sharedThreads > USER_INTERACTION_MAX_SHARED {
+		sharedThreads = USER_INTERACTION_MAX_SHARED
+	}
+	if sharedChannels > USER_INTERACTION_MAX_SHARED {
+		sharedChannels = USER_INTERACTION_MAX_SHARED
+	}
+	score += sharedThreads*USER_INTERACTION_SHARED_THREAD_SCORE + sharedChannels*USER_INTERACTION_SHARED_CHANNEL_SCORE
+
+	return score
+}
+
+// RankUserSearchResults sorts the users found by a search from the most to the least relevant ones. Users whose
+// username is the term come first, followed by the users the searcher interacts with, highest interaction score
+// first, as given by their scores keyed by user id. Other users are ranked by how well they match.
+func RankUserSearchResults(users []*User, term string, interactionScores map[string]int64) {
+	scores := make(map[string]int, len(users))
+	for _, user := range users {
+		scores[user.Id] = UserSearchMatchScore(user, term)
+	}
+
+	hasTerm := strings.TrimSpace(term) != ""
+	isExact := func(user *User) bool {
+		return hasTerm && scores[user.Id] == USER_SEARCH_MATCH_USERNAME
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		a, b := users[i], users[j]
+
+		if isExact(a) != isExact(b) {
+			return isExact(a)
+		}
+
+		if interactionScores[a.Id] != interactionScores[b.Id] {
+			return interactionScores[a.Id] > interactionScores[b.Id]
+		}
+
+		if scores[a.Id] != scores[b.Id] {
+			return scores[a.Id] > scores[b.Id]
+		}
+
+		return a.Username < b.Username
+	})
 }
@@ -6,6 +6,8 @@ package model
 import (
 	"bytes"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestUserSearchJson(t *testing.T) {
@@ -17,3 +19,52 @@ func TestUserSearchJson(t *testing.T) {
 		t.Fatal("Terms do not match")
 	}
 }
+
+func TestUserSearchMatchScore(t *testing.T) {
+	user := &User{Username: "jdoe", FirstName: "John", LastName: "Doe", Nickname: "Johnny Bravo"}
+
+	for term, expected := range map[string]int{
+		"jdoe":       USER_SEARCH_MATCH_USERNAME,
+		"@JDoe":      USER_SEARCH_MATCH_USERNAME,
+		"jd":         USER_SEARCH_MATCH_USERNAME_PREFIX,
+		"doe":        USER_SEARCH_MATCH_NAME_PREFIX,
+		"bravo":      USER_SEARCH_MATCH_NAME_PREFIX,
+		"jd bravo":   USER_SEARCH_MATCH_NAME_PREFIX,
+		"jhn":        USER_SEARCH_MATCH_OTHER,
+		"jd example": USER_SEARCH_MATCH_OTHER,
+	} {
+		if score := UserSearchMatchScore(user, term); score != expected {
+			t.Errorf("term %q: expected score %v, got %v", term, expected, score)
+		}
+	}
+}
+
+func TestRankUserSearchResults(t *testing.T) {
+	exact := &User{Id: NewId(), Username: "john"}
+	usernamePrefix := &User{Id: NewId(), Username: "johnny"}
+	namePrefix := &User{Id: NewId(), Username: "bravo", FirstName: "John"}
+	fuzzy := &User{Id: NewId(), Username: "jhon"}
+	partner := &User{Id: NewId(), Username: "zjohn", Nickname: "jo"}
+	recentPartner := &User{Id: NewId(), Username: "zzjohn", Nickname: "jo"}
+
+	users := []*User{fuzzy, namePrefix, partner, usernamePrefix, recentPartner, exact}
+	RankUserSearchResults(users, "john", map[string]int64{partner.Id: 1000, recentPartner.Id: 2000})
+
+	expected := []*User{exact, recentPartner, partner, usernamePrefix, namePrefix, fuzzy}
+	for i := range expected {
+		if users[i] != expected[i] {
+			t.Fatalf("expected %v at position %v, got %v", expected[i].Username, i, users[i].Username)
+		}
+	}
+}
+
+func TestUserInteractionScore(t *testing.T) {
+	now := int64(USER_INTERACTION_DIRECT_MESSAGE_HALF_LIFE * 10)
+
+	assert.Equal(t, int64(0), (&UserInteraction{}).Score(now))
+	assert.Equal(t, int64(USER_INTERACTION_DIRECT_MESSAGE_SCORE), (&UserInteraction{LastDirectMessageAt: now}).Score(now))
+	assert.Equal(t, int64(USER_INTERACTION_DIRECT_MESSAGE_SCORE/2), (&UserInteraction{LastDirectMessageAt: now - USER_INTERACTION_DIRECT_MESSAGE_HALF_LIFE}).Score(now))
+
+	assert.Equal(t, int64(2*USER_INTERACTION_SHARED_THREAD_SCORE+3*USER_INTERACTION_SHARED_CHANNEL_SCORE), (&UserInteraction{SharedThreads: 2, SharedChannels: 3}).Score(now))
+	assert.Equal(t, (&UserInteraction{SharedThreads: USER_INTERACTION_MAX_SHARED}).Score(now), (&UserInteraction{SharedThreads: 1000}).Score(now))
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -179,6 +180,74 @@ func TestUserIsValid(t *testing.T) {
 	if err := user.IsValid(); !HasExpectedUserIsValidError(err, "position", user.Id) {
 		t.Fatal(err)
 	}
+
+	user.Position = ""
+	user.NotifyProps = StringMap{
+		AUTO_RESPONDER_START_AT_NOTIFY_PROP: "2000",
+		AUTO_RESPONDER_END_AT_NOTIFY_PROP:   "1000",
+	}
+	err = user.IsValid()
+	require.True(t, HasExpectedUserIsValidError(err, "auto_responder_dates", user.Id), "expected user is valid error: %s", err.Error())
+
+	user.NotifyProps[AUTO_RESPONDER_END_AT_NOTIFY_PROP] = "tomorrow"
+	err = user.IsValid()
+	require.True(t, HasExpectedUserIsValidError(err, "auto_responder_dates", user.Id), "expected user is valid error: %s", err.Error())
+
+	user.NotifyProps[AUTO_RESPONDER_END_AT_NOTIFY_PROP] = "3000"
+	user.NotifyProps[AUTO_RESPONDER_COOLDOWN_NOTIFY_PROP] = "-5"
+	err = user.IsValid()
+	require.True(t, HasExpectedUserIsValidError(err, "auto_responder_cooldown", user.Id), "expected user is valid error: %s", err.Error())
+
+	user.NotifyProps[AUTO_RESPONDER_COOLDOWN_NOTIFY_PROP] = "60"
+	require.Nil(t, user.IsValid())
+}
+
+func TestUserIsAutoResponderActiveAt(t *testing.T) {
+	user := User{NotifyProps: StringMap{}}
+	assert.False(t, user.IsAutoResponderActiveAt(1500))
+
+	user.NotifyProps[AUTO_RESPONDER_ACTIVE_NOTIFY_PROP] = "true"
+	assert.True(t, user.IsAutoResponderActiveAt(1500))
+
+	user.NotifyProps[AUTO_RESPONDER_START_AT_NOTIFY_PROP] = "1000"
+	user.NotifyProps[AUTO_RESPONDER_END_AT_NOTIFY_PROP] = "2000"
+	assert.False(t, user.IsAutoResponderActiveAt(999))
+	assert.True(t, user.IsAutoResponderActiveAt(1000))
+	assert.True(t, user.IsAutoResponderActiveAt(1999))
+	assert.False(t, user.IsAutoResponderActiveAt(2000))
+
+	delete(user.NotifyProps, AUTO_RESPONDER_END_AT_NOTIFY_PROP)
+	assert.True(t, user.IsAutoResponderActiveAt(5000))
+}
+
+func TestUserGetReactionsNotifyLevel(t *testing.T) {
+	user := User{NotifyProps: StringMap{}}
+	assert.Equal(t, REACTIONS_NOTIFY_NONE, user.GetReactionsNotifyLevel(nil))
+
+	user.NotifyProps[REACTIONS_NOTIFY_PROP] = REACTIONS_NOTIFY_PUSH
+	assert.Equal(t, REACTIONS_NOTIFY_PUSH, user.GetReactionsNotifyLevel(GetDefaultChannelNotifyProps()))
+	assert.Equal(t, REACTIONS_NOTIFY_DM, user.GetReactionsNotifyLevel(StringMap{REACTIONS_NOTIFY_PROP: REACTIONS_NOTIFY_DM}))
+	assert.Equal(t, REACTIONS_NOTIFY_NONE, user.GetReactionsNotifyLevel(StringMap{REACTIONS_NOTIFY_PROP: REACTIONS_NOTIFY_NONE}))
+}
+
+func TestUserIsValidReactionsNotifyLevel(t *testing.T) {
+	user := User{Id: NewId(), Username: "user", Email: "user@example.com", CreateAt: 1, UpdateAt: 1, Locale: DEFAULT_LOCALE}
+	user.SetDefaultNotifications()
+	require.Nil(t, user.IsValid())
+
+	user.NotifyProps[REACTIONS_NOTIFY_PROP] = "junk"
+	require.NotNil(t, user.IsValid())
+
+	user.NotifyProps[REACTIONS_NOTIFY_PROP] = REACTIONS_NOTIFY_BADGE
+	require.Nil(t, user.IsValid())
+}
+
+func TestUserAutoResponderCooldown(t *testing.T) {
+	user := User{NotifyProps: StringMap{}}
+	assert.Equal(t, time.Duration(0), user.AutoResponderCooldown())
+
+	user.NotifyProps[AUTO_RESPONDER_COOLDOWN_NOTIFY_PROP] = "90"
+	assert.Equal(t, 90*time.Minute, user.AutoResponderCooldown())
 }
 
 func HasExpectedUserIsValidError(err *AppError, fieldName string, userId string) bool {
@@ -52,6 +52,16 @@ func (sa StringArray) Equals(input StringArray) bool {
 	return true
 }
 
+func (sa StringArray) Contains(input string) bool {
+	for _, s := range sa {
+		if s == input {
+			return true
+		}
+	}
+
+	return false
+}
+
 var translateFunc goi18n.TranslateFunc = nil
 
 func AppErrorInit(t goi18n.TranslateFunc) {
@@ -0,0 +1,71 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	WEBRTC_SIGNAL_TYPE_OFFER         = "offer"
+	WEBRTC_SIGNAL_TYPE_ANSWER        = "answer"
+	WEBRTC_SIGNAL_TYPE_ICE_CANDIDATE = "ice_candidate"
+	WEBRTC_SIGNAL_TYPE_HANGUP        = "hangup"
+
+	WEBRTC_SIGNAL_DATA_MAX_LENGTH = 64 * 1024
+)
+
+// WebrtcSignal is a WebRTC signaling message, such as a session description or an ICE candidate, relayed between the
+// peers of the room of a channel. The server doesn't look at its data, which is passed through as the peers sent it.
+//
+// A signal is sent by a user or by a plugin, and goes to a single user of the room, to a plugin, or to everyone else
+// in the room when no recipient is given.
+type WebrtcSignal struct {
+	ChannelId  string `json:"channel_id"`
+	UserId     string `json:"user_id,omitempty"`
+	PluginId   string `json:"plugin_id,omitempty"`
+	ToUserId   string `json:"to_user_id,omitempty"`
+	ToPluginId string `json:"to_plugin_id,omitempty"`
+	Type       string `json:"type"`
+	Data       string `json:"data"`
+}
+
+func (o *WebrtcSignal) IsValid() *AppError {
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("WebrtcSignal.IsValid", "model.webrtc_signal.is_valid.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if (o.UserId == "") == (o.PluginId == "") || (o.UserId != "" && !IsValidId(o.UserId)) {
+		return NewAppError("WebrtcSignal.IsValid", "model.webrtc_signal.is_valid.sender.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	if (o.ToUserId != "" && o.ToPluginId != "") || (o.ToUserId != "" && !IsValidId(o.ToUserId)) {
+		return NewAppError("WebrtcSignal.IsValid", "model.webrtc_signal.is_valid.recipient.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	switch o.Type {
+	case WEBRTC_SIGNAL_TYPE_OFFER, WEBRTC_SIGNAL_TYPE_ANSWER, WEBRTC_SIGNAL_TYPE_ICE_CANDIDATE, WEBRTC_SIGNAL_TYPE_HANGUP:
+	default:
+		return NewAppError("WebrtcSignal.IsValid", "model.webrtc_signal.is_valid.type.app_error", nil, "channel_id="+o.ChannelId+", type="+o.Type, http.StatusBadRequest)
+	}
+
+	if len(o.Data) > WEBRTC_SIGNAL_DATA_MAX_LENGTH {
+		return NewAppError("WebrtcSignal.IsValid", "model.webrtc_signal.is_valid.data.app_error", map[string]interface{}{"Max": WEBRTC_SIGNAL_DATA_MAX_LENGTH}, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *WebrtcSignal) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func WebrtcSignalFromJson(data io.Reader) *WebrtcSignal {
+	var o *WebrtcSignal
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebrtcSignalJson(t *testing.T) {
+	signal := WebrtcSignal{ChannelId: NewId(), UserId: NewId(), ToUserId: NewId(), Type: WEBRTC_SIGNAL_TYPE_OFFER, Data: `{"sdp":"v=0"}`}
+	result := WebrtcSignalFromJson(strings.NewReader(signal.ToJson()))
+	assert.Equal(t, signal, *result)
+}
+
+func TestWebrtcSignalIsValid(t *testing.T) {
+	signal := WebrtcSignal{ChannelId: NewId(), UserId: NewId(), ToUserId: NewId(), Type: WEBRTC_SIGNAL_TYPE_ANSWER, Data: `{"sdp":"v=0"}`}
+	require.Nil(t, signal.IsValid())
+
+	fromPlugin := signal
+	fromPlugin.UserId = ""
+	fromPlugin.PluginId = "com.example.video"
+	require.Nil(t, fromPlugin.IsValid())
+
+	toRoom := signal
+	toRoom.ToUserId = ""
+	require.Nil(t, toRoom.IsValid())
+
+	for name, update := range map[string]func(s *WebrtcSignal){
+		"channel id":     func(s *WebrtcSignal) { s.ChannelId = "abc" },
+		"no sender":      func(s *WebrtcSignal) { s.UserId = "" },
+		"two senders":    func(s *WebrtcSignal) { s.PluginId = "com.example.video" },
+		"user id":        func(s *WebrtcSignal) { s.UserId = "abc" },
+		"to user id":     func(s *WebrtcSignal) { s.ToUserId = "abc" },
+		"two recipients": func(s *WebrtcSignal) { s.ToPluginId = "com.example.video" },
+		"type":           func(s *WebrtcSignal) { s.Type = "renegotiate" },
+		"data":           func(s *WebrtcSignal) { s.Data = strings.Repeat("a", WEBRTC_SIGNAL_DATA_MAX_LENGTH+1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := signal
+			update(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
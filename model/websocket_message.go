@@ -10,47 +10,63 @@ import (
 )
 
 const (
-	WEBSOCKET_EVENT_TYPING                  = "typing"
-	WEBSOCKET_EVENT_POSTED                  = "posted"
-	WEBSOCKET_EVENT_POST_EDITED             = "post_edited"
-	WEBSOCKET_EVENT_POST_DELETED            = "post_deleted"
-	WEBSOCKET_EVENT_CHANNEL_CONVERTED       = "channel_converted"
-	WEBSOCKET_EVENT_CHANNEL_CREATED         = "channel_created"
-	WEBSOCKET_EVENT_CHANNEL_DELETED         = "channel_deleted"
-	WEBSOCKET_EVENT_CHANNEL_UPDATED         = "channel_updated"
-	WEBSOCKET_EVENT_CHANNEL_MEMBER_UPDATED  = "channel_member_updated"
-	WEBSOCKET_EVENT_DIRECT_ADDED            = "direct_added"
-	WEBSOCKET_EVENT_GROUP_ADDED             = "group_added"
-	WEBSOCKET_EVENT_NEW_USER                = "new_user"
-	WEBSOCKET_EVENT_ADDED_TO_TEAM           = "added_to_team"
-	WEBSOCKET_EVENT_LEAVE_TEAM              = "leave_team"
-	WEBSOCKET_EVENT_UPDATE_TEAM             = "update_team"
-	WEBSOCKET_EVENT_DELETE_TEAM             = "delete_team"
-	WEBSOCKET_EVENT_RESTORE_TEAM            = "restore_team"
-	WEBSOCKET_EVENT_USER_ADDED              = "user_added"
-	WEBSOCKET_EVENT_USER_UPDATED            = "user_updated"
-	WEBSOCKET_EVENT_USER_ROLE_UPDATED       = "user_role_updated"
-	WEBSOCKET_EVENT_MEMBERROLE_UPDATED      = "memberrole_updated"
-	WEBSOCKET_EVENT_USER_REMOVED            = "user_removed"
-	WEBSOCKET_EVENT_PREFERENCE_CHANGED      = "preference_changed"
-	WEBSOCKET_EVENT_PREFERENCES_CHANGED     = "preferences_changed"
-	WEBSOCKET_EVENT_PREFERENCES_DELETED     = "preferences_deleted"
-	WEBSOCKET_EVENT_EPHEMERAL_MESSAGE       = "ephemeral_message"
-	WEBSOCKET_EVENT_STATUS_CHANGE           = "status_change"
-	WEBSOCKET_EVENT_HELLO                   = "hello"
-	WEBSOCKET_AUTHENTICATION_CHALLENGE      = "authentication_challenge"
-	WEBSOCKET_EVENT_REACTION_ADDED          = "reaction_added"
-	WEBSOCKET_EVENT_REACTION_REMOVED        = "reaction_removed"
-	WEBSOCKET_EVENT_RESPONSE                = "response"
-	WEBSOCKET_EVENT_EMOJI_ADDED             = "emoji_added"
-	WEBSOCKET_EVENT_CHANNEL_VIEWED          = "channel_viewed"
-	WEBSOCKET_EVENT_PLUGIN_STATUSES_CHANGED = "plugin_statuses_changed"
-	WEBSOCKET_EVENT_PLUGIN_ENABLED          = "plugin_enabled"
-	WEBSOCKET_EVENT_PLUGIN_DISABLED         = "plugin_disabled"
-	WEBSOCKET_EVENT_ROLE_UPDATED            = "role_updated"
-	WEBSOCKET_EVENT_LICENSE_CHANGED         = "license_changed"
-	WEBSOCKET_EVENT_CONFIG_CHANGED          = "config_changed"
-	WEBSOCKET_EVENT_OPEN_DIALOG             = "open_dialog"
+	WEBSOCKET_EVENT_TYPING                    = "typing"
+	WEBSOCKET_EVENT_POSTED                    = "posted"
+	WEBSOCKET_EVENT_POST_EDITED               = "post_edited"
+	WEBSOCKET_EVENT_POST_METADATA_UPDATED     = "post_metadata_updated"
+	WEBSOCKET_EVENT_POST_DELETED              = "post_deleted"
+	WEBSOCKET_EVENT_CHANNEL_CONVERTED         = "channel_converted"
+	WEBSOCKET_EVENT_CHANNEL_CREATED           = "channel_created"
+	WEBSOCKET_EVENT_CHANNEL_DELETED           = "channel_deleted"
+	WEBSOCKET_EVENT_CHANNEL_UPDATED           = "channel_updated"
+	WEBSOCKET_EVENT_CHANNEL_MEMBER_UPDATED    = "channel_member_updated"
+	WEBSOCKET_EVENT_DIRECT_ADDED              = "direct_added"
+	WEBSOCKET_EVENT_GROUP_ADDED               = "group_added"
+	WEBSOCKET_EVENT_NEW_USER                  = "new_user"
+	WEBSOCKET_EVENT_ADDED_TO_TEAM             = "added_to_team"
+	WEBSOCKET_EVENT_LEAVE_TEAM                = "leave_team"
+	WEBSOCKET_EVENT_UPDATE_TEAM               = "update_team"
+	WEBSOCKET_EVENT_DELETE_TEAM               = "delete_team"
+	WEBSOCKET_EVENT_RESTORE_TEAM              = "restore_team"
+	WEBSOCKET_EVENT_USER_ADDED                = "user_added"
+	WEBSOCKET_EVENT_USER_UPDATED              = "user_updated"
+	WEBSOCKET_EVENT_USER_ROLE_UPDATED         = "user_role_updated"
+	WEBSOCKET_EVENT_MEMBERROLE_UPDATED        = "memberrole_updated"
+	WEBSOCKET_EVENT_USER_REMOVED              = "user_removed"
+	WEBSOCKET_EVENT_PREFERENCE_CHANGED        = "preference_changed"
+	WEBSOCKET_EVENT_PREFERENCES_CHANGED       = "preferences_changed"
+	WEBSOCKET_EVENT_PREFERENCES_DELETED       = "preferences_deleted"
+	WEBSOCKET_EVENT_EPHEMERAL_MESSAGE         = "ephemeral_message"
+	WEBSOCKET_EVENT_STATUS_CHANGE             = "status_change"
+	WEBSOCKET_EVENT_HELLO                     = "hello"
+	WEBSOCKET_AUTHENTICATION_CHALLENGE        = "authentication_challenge"
+	WEBSOCKET_EVENT_REACTION_ADDED            = "reaction_added"
+	WEBSOCKET_EVENT_REACTION_REMOVED          = "reaction_removed"
+	WEBSOCKET_EVENT_REACTION_NOTIFICATION     = "reaction_notification"
+	WEBSOCKET_EVENT_POST_STARS_UPDATED        = "post_stars_updated"
+	WEBSOCKET_EVENT_RESPONSE                  = "response"
+	WEBSOCKET_EVENT_EMOJI_ADDED               = "emoji_added"
+	WEBSOCKET_EVENT_EMOJI_ALIAS_ADDED         = "emoji_alias_added"
+	WEBSOCKET_EVENT_EMOJI_ALIAS_REMOVED       = "emoji_alias_removed"
+	WEBSOCKET_EVENT_CHANNEL_VIEWED            = "channel_viewed"
+	WEBSOCKET_EVENT_PLUGIN_STATUSES_CHANGED   = "plugin_statuses_changed"
+	WEBSOCKET_EVENT_PLUGIN_ENABLED            = "plugin_enabled"
+	WEBSOCKET_EVENT_PLUGIN_DISABLED           = "plugin_disabled"
+	WEBSOCKET_EVENT_ROLE_UPDATED              = "role_updated"
+	WEBSOCKET_EVENT_LICENSE_CHANGED           = "license_changed"
+	WEBSOCKET_EVENT_CONFIG_CHANGED            = "config_changed"
+	WEBSOCKET_EVENT_OPEN_DIALOG               = "open_dialog"
+	WEBSOCKET_EVENT_COMMAND_RESPONSE          = "command_response"
+	WEBSOCKET_EVENT_WEBRTC_SIGNAL             = "webrtc_signal"
+	WEBSOCKET_EVENT_WEBRTC_USER_JOINED        = "webrtc_user_joined"
+	WEBSOCKET_EVENT_WEBRTC_USER_LEFT          = "webrtc_user_left"
+	WEBSOCKET_EVENT_CHANNEL_TEAM_BOUND        = "channel_team_bound"
+	WEBSOCKET_EVENT_CHANNEL_TEAM_UNBOUND      = "channel_team_unbound"
+	WEBSOCKET_EVENT_ANNOUNCEMENT_ADDED        = "announcement_added"
+	WEBSOCKET_EVENT_ANNOUNCEMENT_REMOVED      = "announcement_removed"
+	WEBSOCKET_EVENT_ANNOUNCEMENT_ACKNOWLEDGED = "announcement_acknowledged"
+	WEBSOCKET_EVENT_ONBOARDING_UPDATED        = "onboarding_updated"
+	WEBSOCKET_EVENT_PREFERENCES_PATCHED       = "preferences_patched"
 )
 
 type WebSocketMessage interface {
@@ -61,6 +61,12 @@ type API interface {
 	// Minimum server version: 5.6
 	SavePluginConfig(config map[string]interface{}) *model.AppError
 
+	// IsFeatureEnabled reports whether the feature flag named name is enabled for the user. Leave the user id empty
+	// to know whether the feature is enabled for everyone.
+	//
+	// Minimum server version: 5.17
+	IsFeatureEnabled(name, userId string) bool
+
 	// GetBundlePath returns the absolute path where the plugin's bundle was unpacked.
 	//
 	// Minimum server version: 5.10
@@ -163,6 +169,28 @@ type API interface {
 	// Minimum server version: 5.2
 	UpdateUserStatus(userId, status string) (*model.Status, *model.AppError)
 
+	// GetUserAvailability returns the blocks of time during which a user is busy or free that overlap the given span
+	// of time, in milliseconds since the epoch.
+	//
+	// Minimum server version: 5.17
+	GetUserAvailability(userId string, startAt, endAt int64) ([]*model.UserAvailability, *model.AppError)
+
+	// CreateUserAvailability adds a block of time during which a user is busy or free, such as a meeting synced from
+	// their calendar. Its source is the plugin's id.
+	//
+	// Minimum server version: 5.17
+	CreateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError)
+
+	// UpdateUserAvailability updates the status, title and times of a block of availability created by the plugin.
+	//
+	// Minimum server version: 5.17
+	UpdateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError)
+
+	// DeleteUserAvailability deletes a block of availability created by the plugin.
+	//
+	// Minimum server version: 5.17
+	DeleteUserAvailability(availabilityId string) *model.AppError
+
 	// UpdateUserActive deactivates or reactivates an user.
 	//
 	// Minimum server version: 5.8
@@ -411,6 +439,14 @@ type API interface {
 	// Minimum server version: 5.2
 	DeleteEphemeralPost(userId, postId string)
 
+	// StreamCommandResponse shows the progress of a command whose responses keep coming after the ExecuteCommand hook
+	// returned, such as a deployment or the generation of a report. The first response sends an ephemeral post to the
+	// user who ran the command, and the next ones replace its text and attachments. The args must be those the hook was
+	// called with, since the responses of a command are recognized by its trigger id.
+	//
+	// Minimum server version: 5.17
+	StreamCommandResponse(args *model.CommandArgs, response *model.CommandResponse) *model.AppError
+
 	// DeletePost deletes a post.
 	//
 	// Minimum server version: 5.2
@@ -456,6 +492,56 @@ type API interface {
 	// Minimum server version: 5.2
 	UpdatePost(post *model.Post) (*model.Post, *model.AppError)
 
+	// StartCall starts a call in a channel, with its creator as its first participant, and posts a call card to the
+	// channel that clients keep up to date as the call goes on. Its provider is the plugin's id.
+	//
+	// Minimum server version: 5.17
+	StartCall(call *model.Call) (*model.Call, *model.AppError)
+
+	// GetCall returns a call along with its participants.
+	//
+	// Minimum server version: 5.17
+	GetCall(callId string) (*model.Call, *model.AppError)
+
+	// JoinCall adds a user to the participants of a call started by the plugin.
+	//
+	// Minimum server version: 5.17
+	JoinCall(callId, userId string) (*model.Call, *model.AppError)
+
+	// LeaveCall removes a user from the participants of a call started by the plugin.
+	//
+	// Minimum server version: 5.17
+	LeaveCall(callId, userId string) (*model.Call, *model.AppError)
+
+	// EndCall ends a call started by the plugin.
+	//
+	// Minimum server version: 5.17
+	EndCall(callId string) (*model.Call, *model.AppError)
+
+	// SendWebrtcSignal sends a WebRTC signal from the plugin to a user in the room of a channel, or to everyone in the
+	// room when it has no recipient. Users join rooms over the websocket to exchange signals with each other and with
+	// plugins, which receive them through the WebrtcSignalReceived hook.
+	//
+	// Minimum server version: 5.17
+	SendWebrtcSignal(signal *model.WebrtcSignal) *model.AppError
+
+	// GetWebrtcRoomUserIds returns the ids of the users in the WebRTC room of a channel.
+	//
+	// Minimum server version: 5.17
+	GetWebrtcRoomUserIds(channelId string) []string
+
+	// BroadcastDirectMessage sends a post from a bot as a direct message to each of the given users, or to each member
+	// of a team when teamId is set. The messages are sent in the background at a limited rate, and the returned
+	// broadcast is used to follow their progress with GetDirectMessageBroadcast.
+	//
+	// Minimum server version: 5.17
+	BroadcastDirectMessage(botUserId string, userIds []string, teamId string, post *model.Post) (*model.DirectMessageBroadcast, *model.AppError)
+
+	// GetDirectMessageBroadcast returns the progress of a broadcast started by BroadcastDirectMessage.
+	//
+	// Minimum server version: 5.17
+	GetDirectMessageBroadcast(broadcastId string) (*model.DirectMessageBroadcast, *model.AppError)
+
 	// GetProfileImage gets user's profile image.
 	//
 	// Minimum server version: 5.6
@@ -498,6 +584,13 @@ type API interface {
 	// Minimum server version: 5.3
 	GetFileInfo(fileId string) (*model.FileInfo, *model.AppError)
 
+	// SetFileInfoVerdict attaches the verdict of an asynchronous scanning or DLP pipeline to a file.
+	// Clients are notified of the change in the metadata of the post the file is attached to, and
+	// blocked files are no longer served.
+	//
+	// Minimum server version: 5.17
+	SetFileInfoVerdict(fileId, verdict, reason string) (*model.FileInfo, *model.AppError)
+
 	// GetFile gets content of a file by it's ID
 	//
 	// Minimum server version: 5.8
@@ -475,6 +475,41 @@ func (s *hooksRPCServer) UserHasLeftTeam(args *Z_UserHasLeftTeamArgs, returns *Z
 	return nil
 }
 
+func init() {
+	hookNameToId["WebrtcSignalReceived"] = WebrtcSignalReceivedId
+}
+
+type Z_WebrtcSignalReceivedArgs struct {
+	A *Context
+	B *model.WebrtcSignal
+}
+
+type Z_WebrtcSignalReceivedReturns struct {
+}
+
+func (g *hooksRPCClient) WebrtcSignalReceived(c *Context, signal *model.WebrtcSignal) {
+	_args := &Z_WebrtcSignalReceivedArgs{c, signal}
+	_returns := &Z_WebrtcSignalReceivedReturns{}
+	if g.implemented[WebrtcSignalReceivedId] {
+		if err := g.client.Call("Plugin.WebrtcSignalReceived", _args, _returns); err != nil {
+			g.log.Error("RPC call WebrtcSignalReceived to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) WebrtcSignalReceived(args *Z_WebrtcSignalReceivedArgs, returns *Z_WebrtcSignalReceivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		WebrtcSignalReceived(c *Context, signal *model.WebrtcSignal)
+	}); ok {
+		hook.WebrtcSignalReceived(args.A, args.B)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook WebrtcSignalReceived called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
@@ -698,6 +733,35 @@ func (s *apiRPCServer) SavePluginConfig(args *Z_SavePluginConfigArgs, returns *Z
 	return nil
 }
 
+type Z_IsFeatureEnabledArgs struct {
+	A string
+	B string
+}
+
+type Z_IsFeatureEnabledReturns struct {
+	A bool
+}
+
+func (g *apiRPCClient) IsFeatureEnabled(name, userId string) bool {
+	_args := &Z_IsFeatureEnabledArgs{name, userId}
+	_returns := &Z_IsFeatureEnabledReturns{}
+	if err := g.client.Call("Plugin.IsFeatureEnabled", _args, _returns); err != nil {
+		log.Printf("RPC call to IsFeatureEnabled API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) IsFeatureEnabled(args *Z_IsFeatureEnabledArgs, returns *Z_IsFeatureEnabledReturns) error {
+	if hook, ok := s.impl.(interface {
+		IsFeatureEnabled(name, userId string) bool
+	}); ok {
+		returns.A = hook.IsFeatureEnabled(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API IsFeatureEnabled called but not implemented."))
+	}
+	return nil
+}
+
 type Z_GetBundlePathArgs struct {
 }
 
@@ -1271,6 +1335,123 @@ func (s *apiRPCServer) UpdateUserStatus(args *Z_UpdateUserStatusArgs, returns *Z
 	return nil
 }
 
+type Z_GetUserAvailabilityArgs struct {
+	A string
+	B int64
+	C int64
+}
+
+type Z_GetUserAvailabilityReturns struct {
+	A []*model.UserAvailability
+	B *model.AppError
+}
+
+func (g *apiRPCClient) GetUserAvailability(userId string, startAt, endAt int64) ([]*model.UserAvailability, *model.AppError) {
+	_args := &Z_GetUserAvailabilityArgs{userId, startAt, endAt}
+	_returns := &Z_GetUserAvailabilityReturns{}
+	if err := g.client.Call("Plugin.GetUserAvailability", _args, _returns); err != nil {
+		log.Printf("RPC call to GetUserAvailability API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) GetUserAvailability(args *Z_GetUserAvailabilityArgs, returns *Z_GetUserAvailabilityReturns) error {
+	if hook, ok := s.impl.(interface {
+		GetUserAvailability(userId string, startAt, endAt int64) ([]*model.UserAvailability, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.GetUserAvailability(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("API GetUserAvailability called but not implemented."))
+	}
+	return nil
+}
+
+type Z_CreateUserAvailabilityArgs struct {
+	A *model.UserAvailability
+}
+
+type Z_CreateUserAvailabilityReturns struct {
+	A *model.UserAvailability
+	B *model.AppError
+}
+
+func (g *apiRPCClient) CreateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	_args := &Z_CreateUserAvailabilityArgs{availability}
+	_returns := &Z_CreateUserAvailabilityReturns{}
+	if err := g.client.Call("Plugin.CreateUserAvailability", _args, _returns); err != nil {
+		log.Printf("RPC call to CreateUserAvailability API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) CreateUserAvailability(args *Z_CreateUserAvailabilityArgs, returns *Z_CreateUserAvailabilityReturns) error {
+	if hook, ok := s.impl.(interface {
+		CreateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.CreateUserAvailability(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API CreateUserAvailability called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UpdateUserAvailabilityArgs struct {
+	A *model.UserAvailability
+}
+
+type Z_UpdateUserAvailabilityReturns struct {
+	A *model.UserAvailability
+	B *model.AppError
+}
+
+func (g *apiRPCClient) UpdateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	_args := &Z_UpdateUserAvailabilityArgs{availability}
+	_returns := &Z_UpdateUserAvailabilityReturns{}
+	if err := g.client.Call("Plugin.UpdateUserAvailability", _args, _returns); err != nil {
+		log.Printf("RPC call to UpdateUserAvailability API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) UpdateUserAvailability(args *Z_UpdateUserAvailabilityArgs, returns *Z_UpdateUserAvailabilityReturns) error {
+	if hook, ok := s.impl.(interface {
+		UpdateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.UpdateUserAvailability(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API UpdateUserAvailability called but not implemented."))
+	}
+	return nil
+}
+
+type Z_DeleteUserAvailabilityArgs struct {
+	A string
+}
+
+type Z_DeleteUserAvailabilityReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) DeleteUserAvailability(availabilityId string) *model.AppError {
+	_args := &Z_DeleteUserAvailabilityArgs{availabilityId}
+	_returns := &Z_DeleteUserAvailabilityReturns{}
+	if err := g.client.Call("Plugin.DeleteUserAvailability", _args, _returns); err != nil {
+		log.Printf("RPC call to DeleteUserAvailability API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) DeleteUserAvailability(args *Z_DeleteUserAvailabilityArgs, returns *Z_DeleteUserAvailabilityReturns) error {
+	if hook, ok := s.impl.(interface {
+		DeleteUserAvailability(availabilityId string) *model.AppError
+	}); ok {
+		returns.A = hook.DeleteUserAvailability(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API DeleteUserAvailability called but not implemented."))
+	}
+	return nil
+}
+
 type Z_UpdateUserActiveArgs struct {
 	A string
 	B bool
@@ -2696,6 +2877,35 @@ func (s *apiRPCServer) DeleteEphemeralPost(args *Z_DeleteEphemeralPostArgs, retu
 	return nil
 }
 
+type Z_StreamCommandResponseArgs struct {
+	A *model.CommandArgs
+	B *model.CommandResponse
+}
+
+type Z_StreamCommandResponseReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) StreamCommandResponse(args *model.CommandArgs, response *model.CommandResponse) *model.AppError {
+	_args := &Z_StreamCommandResponseArgs{args, response}
+	_returns := &Z_StreamCommandResponseReturns{}
+	if err := g.client.Call("Plugin.StreamCommandResponse", _args, _returns); err != nil {
+		log.Printf("RPC call to StreamCommandResponse API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) StreamCommandResponse(args *Z_StreamCommandResponseArgs, returns *Z_StreamCommandResponseReturns) error {
+	if hook, ok := s.impl.(interface {
+		StreamCommandResponse(args *model.CommandArgs, response *model.CommandResponse) *model.AppError
+	}); ok {
+		returns.A = hook.StreamCommandResponse(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API StreamCommandResponse called but not implemented."))
+	}
+	return nil
+}
+
 type Z_DeletePostArgs struct {
 	A string
 }
@@ -2965,6 +3175,270 @@ func (s *apiRPCServer) UpdatePost(args *Z_UpdatePostArgs, returns *Z_UpdatePostR
 	return nil
 }
 
+type Z_StartCallArgs struct {
+	A *model.Call
+}
+
+type Z_StartCallReturns struct {
+	A *model.Call
+	B *model.AppError
+}
+
+func (g *apiRPCClient) StartCall(call *model.Call) (*model.Call, *model.AppError) {
+	_args := &Z_StartCallArgs{call}
+	_returns := &Z_StartCallReturns{}
+	if err := g.client.Call("Plugin.StartCall", _args, _returns); err != nil {
+		log.Printf("RPC call to StartCall API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) StartCall(args *Z_StartCallArgs, returns *Z_StartCallReturns) error {
+	if hook, ok := s.impl.(interface {
+		StartCall(call *model.Call) (*model.Call, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.StartCall(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API StartCall called but not implemented."))
+	}
+	return nil
+}
+
+type Z_GetCallArgs struct {
+	A string
+}
+
+type Z_GetCallReturns struct {
+	A *model.Call
+	B *model.AppError
+}
+
+func (g *apiRPCClient) GetCall(callId string) (*model.Call, *model.AppError) {
+	_args := &Z_GetCallArgs{callId}
+	_returns := &Z_GetCallReturns{}
+	if err := g.client.Call("Plugin.GetCall", _args, _returns); err != nil {
+		log.Printf("RPC call to GetCall API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) GetCall(args *Z_GetCallArgs, returns *Z_GetCallReturns) error {
+	if hook, ok := s.impl.(interface {
+		GetCall(callId string) (*model.Call, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.GetCall(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API GetCall called but not implemented."))
+	}
+	return nil
+}
+
+type Z_JoinCallArgs struct {
+	A string
+	B string
+}
+
+type Z_JoinCallReturns struct {
+	A *model.Call
+	B *model.AppError
+}
+
+func (g *apiRPCClient) JoinCall(callId, userId string) (*model.Call, *model.AppError) {
+	_args := &Z_JoinCallArgs{callId, userId}
+	_returns := &Z_JoinCallReturns{}
+	if err := g.client.Call("Plugin.JoinCall", _args, _returns); err != nil {
+		log.Printf("RPC call to JoinCall API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) JoinCall(args *Z_JoinCallArgs, returns *Z_JoinCallReturns) error {
+	if hook, ok := s.impl.(interface {
+		JoinCall(callId, userId string) (*model.Call, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.JoinCall(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API JoinCall called but not implemented."))
+	}
+	return nil
+}
+
+type Z_LeaveCallArgs struct {
+	A string
+	B string
+}
+
+type Z_LeaveCallReturns struct {
+	A *model.Call
+	B *model.AppError
+}
+
+func (g *apiRPCClient) LeaveCall(callId, userId string) (*model.Call, *model.AppError) {
+	_args := &Z_LeaveCallArgs{callId, userId}
+	_returns := &Z_LeaveCallReturns{}
+	if err := g.client.Call("Plugin.LeaveCall", _args, _returns); err != nil {
+		log.Printf("RPC call to LeaveCall API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) LeaveCall(args *Z_LeaveCallArgs, returns *Z_LeaveCallReturns) error {
+	if hook, ok := s.impl.(interface {
+		LeaveCall(callId, userId string) (*model.Call, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.LeaveCall(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API LeaveCall called but not implemented."))
+	}
+	return nil
+}
+
+type Z_EndCallArgs struct {
+	A string
+}
+
+type Z_EndCallReturns struct {
+	A *model.Call
+	B *model.AppError
+}
+
+func (g *apiRPCClient) EndCall(callId string) (*model.Call, *model.AppError) {
+	_args := &Z_EndCallArgs{callId}
+	_returns := &Z_EndCallReturns{}
+	if err := g.client.Call("Plugin.EndCall", _args, _returns); err != nil {
+		log.Printf("RPC call to EndCall API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) EndCall(args *Z_EndCallArgs, returns *Z_EndCallReturns) error {
+	if hook, ok := s.impl.(interface {
+		EndCall(callId string) (*model.Call, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.EndCall(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API EndCall called but not implemented."))
+	}
+	return nil
+}
+
+type Z_SendWebrtcSignalArgs struct {
+	A *model.WebrtcSignal
+}
+
+type Z_SendWebrtcSignalReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) SendWebrtcSignal(signal *model.WebrtcSignal) *model.AppError {
+	_args := &Z_SendWebrtcSignalArgs{signal}
+	_returns := &Z_SendWebrtcSignalReturns{}
+	if err := g.client.Call("Plugin.SendWebrtcSignal", _args, _returns); err != nil {
+		log.Printf("RPC call to SendWebrtcSignal API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) SendWebrtcSignal(args *Z_SendWebrtcSignalArgs, returns *Z_SendWebrtcSignalReturns) error {
+	if hook, ok := s.impl.(interface {
+		SendWebrtcSignal(signal *model.WebrtcSignal) *model.AppError
+	}); ok {
+		returns.A = hook.SendWebrtcSignal(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API SendWebrtcSignal called but not implemented."))
+	}
+	return nil
+}
+
+type Z_GetWebrtcRoomUserIdsArgs struct {
+	A string
+}
+
+type Z_GetWebrtcRoomUserIdsReturns struct {
+	A []string
+}
+
+func (g *apiRPCClient) GetWebrtcRoomUserIds(channelId string) []string {
+	_args := &Z_GetWebrtcRoomUserIdsArgs{channelId}
+	_returns := &Z_GetWebrtcRoomUserIdsReturns{}
+	if err := g.client.Call("Plugin.GetWebrtcRoomUserIds", _args, _returns); err != nil {
+		log.Printf("RPC call to GetWebrtcRoomUserIds API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) GetWebrtcRoomUserIds(args *Z_GetWebrtcRoomUserIdsArgs, returns *Z_GetWebrtcRoomUserIdsReturns) error {
+	if hook, ok := s.impl.(interface {
+		GetWebrtcRoomUserIds(channelId string) []string
+	}); ok {
+		returns.A = hook.GetWebrtcRoomUserIds(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API GetWebrtcRoomUserIds called but not implemented."))
+	}
+	return nil
+}
+
+type Z_BroadcastDirectMessageArgs struct {
+	A string
+	B []string
+	C string
+	D *model.Post
+}
+
+type Z_BroadcastDirectMessageReturns struct {
+	A *model.DirectMessageBroadcast
+	B *model.AppError
+}
+
+func (g *apiRPCClient) BroadcastDirectMessage(botUserId string, userIds []string, teamId string, post *model.Post) (*model.DirectMessageBroadcast, *model.AppError) {
+	_args := &Z_BroadcastDirectMessageArgs{botUserId, userIds, teamId, post}
+	_returns := &Z_BroadcastDirectMessageReturns{}
+	if err := g.client.Call("Plugin.BroadcastDirectMessage", _args, _returns); err != nil {
+		log.Printf("RPC call to BroadcastDirectMessage API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) BroadcastDirectMessage(args *Z_BroadcastDirectMessageArgs, returns *Z_BroadcastDirectMessageReturns) error {
+	if hook, ok := s.impl.(interface {
+		BroadcastDirectMessage(botUserId string, userIds []string, teamId string, post *model.Post) (*model.DirectMessageBroadcast, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.BroadcastDirectMessage(args.A, args.B, args.C, args.D)
+	} else {
+		return encodableError(fmt.Errorf("API BroadcastDirectMessage called but not implemented."))
+	}
+	return nil
+}
+
+type Z_GetDirectMessageBroadcastArgs struct {
+	A string
+}
+
+type Z_GetDirectMessageBroadcastReturns struct {
+	A *model.DirectMessageBroadcast
+	B *model.AppError
+}
+
+func (g *apiRPCClient) GetDirectMessageBroadcast(broadcastId string) (*model.DirectMessageBroadcast, *model.AppError) {
+	_args := &Z_GetDirectMessageBroadcastArgs{broadcastId}
+	_returns := &Z_GetDirectMessageBroadcastReturns{}
+	if err := g.client.Call("Plugin.GetDirectMessageBroadcast", _args, _returns); err != nil {
+		log.Printf("RPC call to GetDirectMessageBroadcast API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) GetDirectMessageBroadcast(args *Z_GetDirectMessageBroadcastArgs, returns *Z_GetDirectMessageBroadcastReturns) error {
+	if hook, ok := s.impl.(interface {
+		GetDirectMessageBroadcast(broadcastId string) (*model.DirectMessageBroadcast, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.GetDirectMessageBroadcast(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API GetDirectMessageBroadcast called but not implemented."))
+	}
+	return nil
+}
+
 type Z_GetProfileImageArgs struct {
 	A string
 }
@@ -3171,6 +3645,37 @@ func (s *apiRPCServer) GetFileInfo(args *Z_GetFileInfoArgs, returns *Z_GetFileIn
 	return nil
 }
 
+type Z_SetFileInfoVerdictArgs struct {
+	A string
+	B string
+	C string
+}
+
+type Z_SetFileInfoVerdictReturns struct {
+	A *model.FileInfo
+	B *model.AppError
+}
+
+func (g *apiRPCClient) SetFileInfoVerdict(fileId, verdict, reason string) (*model.FileInfo, *model.AppError) {
+	_args := &Z_SetFileInfoVerdictArgs{fileId, verdict, reason}
+	_returns := &Z_SetFileInfoVerdictReturns{}
+	if err := g.client.Call("Plugin.SetFileInfoVerdict", _args, _returns); err != nil {
+		log.Printf("RPC call to SetFileInfoVerdict API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) SetFileInfoVerdict(args *Z_SetFileInfoVerdictArgs, returns *Z_SetFileInfoVerdictReturns) error {
+	if hook, ok := s.impl.(interface {
+		SetFileInfoVerdict(fileId, verdict, reason string) (*model.FileInfo, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.SetFileInfoVerdict(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("API SetFileInfoVerdict called but not implemented."))
+	}
+	return nil
+}
+
 type Z_GetFileArgs struct {
 	A string
 }
@@ -33,6 +33,7 @@ const (
 	UserWillLogInId         = 15
 	UserHasLoggedInId       = 16
 	UserHasBeenCreatedId    = 17
+	WebrtcSignalReceivedId  = 18
 	TotalHooksId            = iota
 )
 
@@ -155,4 +156,10 @@ type Hooks interface {
 	// Note that this method will be called for files uploaded by plugins, including the plugin that uploaded the post.
 	// FileInfo.Size will be automatically set properly if you modify the file.
 	FileWillBeUploaded(c *Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string)
+
+	// WebrtcSignalReceived is invoked when a user in the WebRTC room of a channel sends a signal to the plugin, such
+	// as an offer to connect to a media server run by the plugin. Use SendWebrtcSignal to answer it.
+	//
+	// Minimum server version: 5.17
+	WebrtcSignalReceived(c *Context, signal *model.WebrtcSignal)
 }
@@ -64,6 +64,31 @@ func (_m *API) AddReaction(reaction *model.Reaction) (*model.Reaction, *model.Ap
 	return r0, r1
 }
 
+// BroadcastDirectMessage provides a mock function with given fields: botUserId, userIds, teamId, post
+func (_m *API) BroadcastDirectMessage(botUserId string, userIds []string, teamId string, post *model.Post) (*model.DirectMessageBroadcast, *model.AppError) {
+	ret := _m.Called(botUserId, userIds, teamId, post)
+
+	var r0 *model.DirectMessageBroadcast
+	if rf, ok := ret.Get(0).(func(string, []string, string, *model.Post) *model.DirectMessageBroadcast); ok {
+		r0 = rf(botUserId, userIds, teamId, post)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DirectMessageBroadcast)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, []string, string, *model.Post) *model.AppError); ok {
+		r1 = rf(botUserId, userIds, teamId, post)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // CopyFileInfos provides a mock function with given fields: userId, fileIds
 func (_m *API) CopyFileInfos(userId string, fileIds []string) ([]string, *model.AppError) {
 	ret := _m.Called(userId, fileIds)
@@ -264,6 +289,31 @@ func (_m *API) CreateUser(user *model.User) (*model.User, *model.AppError) {
 	return r0, r1
 }
 
+// CreateUserAvailability provides a mock function with given fields: availability
+func (_m *API) CreateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	ret := _m.Called(availability)
+
+	var r0 *model.UserAvailability
+	if rf, ok := ret.Get(0).(func(*model.UserAvailability) *model.UserAvailability); ok {
+		r0 = rf(availability)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserAvailability)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*model.UserAvailability) *model.AppError); ok {
+		r1 = rf(availability)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // DeleteBotIconImage provides a mock function with given fields: botUserId
 func (_m *API) DeleteBotIconImage(botUserId string) *model.AppError {
 	ret := _m.Called(botUserId)
@@ -381,6 +431,22 @@ func (_m *API) DeleteUser(userId string) *model.AppError {
 	return r0
 }
 
+// DeleteUserAvailability provides a mock function with given fields: availabilityId
+func (_m *API) DeleteUserAvailability(availabilityId string) *model.AppError {
+	ret := _m.Called(availabilityId)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(availabilityId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // DisablePlugin provides a mock function with given fields: id
 func (_m *API) DisablePlugin(id string) *model.AppError {
 	ret := _m.Called(id)
@@ -413,6 +479,31 @@ func (_m *API) EnablePlugin(id string) *model.AppError {
 	return r0
 }
 
+// EndCall provides a mock function with given fields: callId
+func (_m *API) EndCall(callId string) (*model.Call, *model.AppError) {
+	ret := _m.Called(callId)
+
+	var r0 *model.Call
+	if rf, ok := ret.Get(0).(func(string) *model.Call); ok {
+		r0 = rf(callId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Call)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(callId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetBot provides a mock function with given fields: botUserId, includeDeleted
 func (_m *API) GetBot(botUserId string, includeDeleted bool) (*model.Bot, *model.AppError) {
 	ret := _m.Called(botUserId, includeDeleted)
@@ -509,6 +600,31 @@ func (_m *API) GetBundlePath() (string, error) {
 	return r0, r1
 }
 
+// GetCall provides a mock function with given fields: callId
+func (_m *API) GetCall(callId string) (*model.Call, *model.AppError) {
+	ret := _m.Called(callId)
+
+	var r0 *model.Call
+	if rf, ok := ret.Get(0).(func(string) *model.Call); ok {
+		r0 = rf(callId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Call)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(callId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetChannel provides a mock function with given fields: channelId
 func (_m *API) GetChannel(channelId string) (*model.Channel, *model.AppError) {
 	ret := _m.Called(channelId)
@@ -789,6 +905,31 @@ func (_m *API) GetDirectChannel(userId1 string, userId2 string) (*model.Channel,
 	return r0, r1
 }
 
+// GetDirectMessageBroadcast provides a mock function with given fields: broadcastId
+func (_m *API) GetDirectMessageBroadcast(broadcastId string) (*model.DirectMessageBroadcast, *model.AppError) {
+	ret := _m.Called(broadcastId)
+
+	var r0 *model.DirectMessageBroadcast
+	if rf, ok := ret.Get(0).(func(string) *model.DirectMessageBroadcast); ok {
+		r0 = rf(broadcastId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DirectMessageBroadcast)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(broadcastId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetEmoji provides a mock function with given fields: emojiId
 func (_m *API) GetEmoji(emojiId string) (*model.Emoji, *model.AppError) {
 	ret := _m.Called(emojiId)
@@ -1679,6 +1820,31 @@ func (_m *API) GetUser(userId string) (*model.User, *model.AppError) {
 	return r0, r1
 }
 
+// GetUserAvailability provides a mock function with given fields: userId, startAt, endAt
+func (_m *API) GetUserAvailability(userId string, startAt int64, endAt int64) ([]*model.UserAvailability, *model.AppError) {
+	ret := _m.Called(userId, startAt, endAt)
+
+	var r0 []*model.UserAvailability
+	if rf, ok := ret.Get(0).(func(string, int64, int64) []*model.UserAvailability); ok {
+		r0 = rf(userId, startAt, endAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserAvailability)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, int64, int64) *model.AppError); ok {
+		r1 = rf(userId, startAt, endAt)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetUserByEmail provides a mock function with given fields: email
 func (_m *API) GetUserByEmail(email string) (*model.User, *model.AppError) {
 	ret := _m.Called(email)
@@ -1879,6 +2045,22 @@ func (_m *API) GetUsersInTeam(teamId string, page int, perPage int) ([]*model.Us
 	return r0, r1
 }
 
+// GetWebrtcRoomUserIds provides a mock function with given fields: channelId
+func (_m *API) GetWebrtcRoomUserIds(channelId string) []string {
+	ret := _m.Called(channelId)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // HasPermissionTo provides a mock function with given fields: userId, permission
 func (_m *API) HasPermissionTo(userId string, permission *model.Permission) bool {
 	ret := _m.Called(userId, permission)
@@ -1921,6 +2103,45 @@ func (_m *API) HasPermissionToTeam(userId string, teamId string, permission *mod
 	return r0
 }
 
+// IsFeatureEnabled provides a mock function with given fields: name, userId
+func (_m *API) IsFeatureEnabled(name string, userId string) bool {
+	ret := _m.Called(name, userId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(name, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// JoinCall provides a mock function with given fields: callId, userId
+func (_m *API) JoinCall(callId string, userId string) (*model.Call, *model.AppError) {
+	ret := _m.Called(callId, userId)
+
+	var r0 *model.Call
+	if rf, ok := ret.Get(0).(func(string, string) *model.Call); ok {
+		r0 = rf(callId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Call)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string) *model.AppError); ok {
+		r1 = rf(callId, userId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVCompareAndDelete provides a mock function with given fields: key, oldValue
 func (_m *API) KVCompareAndDelete(key string, oldValue []byte) (bool, *model.AppError) {
 	ret := _m.Called(key, oldValue)
@@ -2081,6 +2302,31 @@ func (_m *API) KVSetWithExpiry(key string, value []byte, expireInSeconds int64)
 	return r0
 }
 
+// LeaveCall provides a mock function with given fields: callId, userId
+func (_m *API) LeaveCall(callId string, userId string) (*model.Call, *model.AppError) {
+	ret := _m.Called(callId, userId)
+
+	var r0 *model.Call
+	if rf, ok := ret.Get(0).(func(string, string) *model.Call); ok {
+		r0 = rf(callId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Call)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string) *model.AppError); ok {
+		r1 = rf(callId, userId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // LoadPluginConfiguration provides a mock function with given fields: dest
 func (_m *API) LoadPluginConfiguration(dest interface{}) error {
 	ret := _m.Called(dest)
@@ -2440,6 +2686,22 @@ func (_m *API) SendMail(to string, subject string, htmlBody string) *model.AppEr
 	return r0
 }
 
+// SendWebrtcSignal provides a mock function with given fields: signal
+func (_m *API) SendWebrtcSignal(signal *model.WebrtcSignal) *model.AppError {
+	ret := _m.Called(signal)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(*model.WebrtcSignal) *model.AppError); ok {
+		r0 = rf(signal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // SetBotIconImage provides a mock function with given fields: botUserId, data
 func (_m *API) SetBotIconImage(botUserId string, data []byte) *model.AppError {
 	ret := _m.Called(botUserId, data)
@@ -2456,6 +2718,31 @@ func (_m *API) SetBotIconImage(botUserId string, data []byte) *model.AppError {
 	return r0
 }
 
+// SetFileInfoVerdict provides a mock function with given fields: fileId, verdict, reason
+func (_m *API) SetFileInfoVerdict(fileId string, verdict string, reason string) (*model.FileInfo, *model.AppError) {
+	ret := _m.Called(fileId, verdict, reason)
+
+	var r0 *model.FileInfo
+	if rf, ok := ret.Get(0).(func(string, string, string) *model.FileInfo); ok {
+		r0 = rf(fileId, verdict, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileInfo)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string, string) *model.AppError); ok {
+		r1 = rf(fileId, verdict, reason)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // SetProfileImage provides a mock function with given fields: userId, data
 func (_m *API) SetProfileImage(userId string, data []byte) *model.AppError {
 	ret := _m.Called(userId, data)
@@ -2488,6 +2775,47 @@ func (_m *API) SetTeamIcon(teamId string, data []byte) *model.AppError {
 	return r0
 }
 
+// StartCall provides a mock function with given fields: call
+func (_m *API) StartCall(call *model.Call) (*model.Call, *model.AppError) {
+	ret := _m.Called(call)
+
+	var r0 *model.Call
+	if rf, ok := ret.Get(0).(func(*model.Call) *model.Call); ok {
+		r0 = rf(call)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Call)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*model.Call) *model.AppError); ok {
+		r1 = rf(call)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// StreamCommandResponse provides a mock function with given fields: args, response
+func (_m *API) StreamCommandResponse(args *model.CommandArgs, response *model.CommandResponse) *model.AppError {
+	ret := _m.Called(args, response)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(*model.CommandArgs, *model.CommandResponse) *model.AppError); ok {
+		r0 = rf(args, response)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UnregisterCommand provides a mock function with given fields: teamId, trigger
 func (_m *API) UnregisterCommand(teamId string, trigger string) error {
 	ret := _m.Called(teamId, trigger)
@@ -2734,6 +3062,31 @@ func (_m *API) UpdateUserActive(userId string, active bool) *model.AppError {
 	return r0
 }
 
+// UpdateUserAvailability provides a mock function with given fields: availability
+func (_m *API) UpdateUserAvailability(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	ret := _m.Called(availability)
+
+	var r0 *model.UserAvailability
+	if rf, ok := ret.Get(0).(func(*model.UserAvailability) *model.UserAvailability); ok {
+		r0 = rf(availability)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserAvailability)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*model.UserAvailability) *model.AppError); ok {
+		r1 = rf(availability)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // UpdateUserStatus provides a mock function with given fields: userId, status
 func (_m *API) UpdateUserStatus(userId string, status string) (*model.Status, *model.AppError) {
 	ret := _m.Called(userId, status)
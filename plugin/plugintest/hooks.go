@@ -242,3 +242,8 @@ func (_m *Hooks) UserWillLogIn(c *plugin.Context, user *model.User) string {
 
 	return r0
 }
+
+// WebrtcSignalReceived provides a mock function with given fields: c, signal
+func (_m *Hooks) WebrtcSignalReceived(c *plugin.Context, signal *model.WebrtcSignal) {
+	_m.Called(c, signal)
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package postarchive
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type PostArchiveJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsPostArchiveJobInterface(func(a *app.App) tjobs.PostArchiveJobInterface {
+		return &PostArchiveJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package recurringpost
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type RecurringPostsJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsRecurringPostsJobInterface(func(a *app.App) tjobs.RecurringPostsJobInterface {
+		return &RecurringPostsJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package recurringpost
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/app"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// The finest cron schedules are per minute, which is also how often the schedulers run.
+const recurringPostsJobInterval = 1 * time.Minute
+
+type Scheduler struct {
+	App *app.App
+}
+
+func (m *RecurringPostsJobInterfaceImpl) MakeScheduler() model.Scheduler {
+	return &Scheduler{m.App}
+}
+
+func (scheduler *Scheduler) Name() string {
+	return "RecurringPostsScheduler"
+}
+
+func (scheduler *Scheduler) JobType() string {
+	return model.JOB_TYPE_RECURRING_POSTS
+}
+
+func (scheduler *Scheduler) Enabled(cfg *model.Config) bool {
+	return *cfg.RecurringPostSettings.Enable
+}
+
+func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	nextTime := time.Now().Add(recurringPostsJobInterval)
+	return &nextTime
+}
+
+// ScheduleJob only creates a job when a post is due and no job is already pending, rather than once a minute.
+func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	if pendingJobs {
+		return nil, nil
+	}
+
+	if due, err := scheduler.App.HasDueRecurringPosts(); err != nil || !due {
+		return nil, err
+	}
+
+	mlog.Debug("Scheduling Job", mlog.String("scheduler", scheduler.Name()))
+
+	if job, err := scheduler.App.Srv.Jobs.CreateJob(model.JOB_TYPE_RECURRING_POSTS, nil); err != nil {
+		return nil, err
+	} else {
+		return job, nil
+	}
+}
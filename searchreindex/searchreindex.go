@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package searchreindex
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type SearchReindexJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsSearchReindexJobInterface(func(a *app.App) tjobs.SearchReindexJobInterface {
+		return &SearchReindexJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package accesslog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/configservice"
+	"github.com/mattermost/mattermost-server/utils/fileutils"
+)
+
+const ACCESS_LOG_FILENAME = "api_access.log"
+
+// An AccessLog writes a sample of the requests served by the API to a dedicated file, as lines of JSON. An
+// instance of AccessLog should be created using MakeAccessLog, and the file is reopened whenever the
+// ApiAccessLogSettings change.
+type AccessLog struct {
+	ConfigService    configservice.ConfigService
+	configListenerId string
+
+	Logger *mlog.Logger
+
+	lock     sync.RWMutex
+	settings model.ApiAccessLogSettings
+	writer   *lumberjack.Logger
+}
+
+func GetAccessLogFileLocation(fileLocation string) string {
+	if fileLocation == "" {
+		fileLocation, _ = fileutils.FindDir("logs")
+	}
+
+	return filepath.Join(fileLocation, ACCESS_LOG_FILENAME)
+}
+
+func MakeAccessLog(configService configservice.ConfigService, logger *mlog.Logger) *AccessLog {
+	accessLog := &AccessLog{
+		ConfigService: configService,
+		Logger:        logger,
+	}
+
+	accessLog.configListenerId = accessLog.ConfigService.AddConfigListener(accessLog.OnConfigChange)
+	accessLog.configure(&accessLog.ConfigService.Config().ApiAccessLogSettings)
+
+	return accessLog
+}
+
+func (accessLog *AccessLog) configure(settings *model.ApiAccessLogSettings) {
+	var writer *lumberjack.Logger
+	if *settings.Enable {
+		writer = &lumberjack.Logger{
+			Filename: GetAccessLogFileLocation(*settings.FileLocation),
+			MaxSize:  *settings.FileMaxSizeMB,
+			Compress: true,
+		}
+	}
+
+	accessLog.lock.Lock()
+	oldWriter := accessLog.writer
+	accessLog.settings = *settings
+	accessLog.writer = writer
+	accessLog.lock.Unlock()
+
+	if oldWriter != nil {
+		if err := oldWriter.Close(); err != nil {
+			accessLog.Logger.Error("Unable to close the API access log", mlog.Err(err))
+		}
+	}
+}
+
+func (accessLog *AccessLog) OnConfigChange(oldConfig, newConfig *model.Config) {
+	if reflect.DeepEqual(oldConfig.ApiAccessLogSettings, newConfig.ApiAccessLogSettings) {
+		return
+	}
+
+	accessLog.configure(&newConfig.ApiAccessLogSettings)
+}
+
+// samplePercent returns the share of the requests like the entry that are logged, or 0 when the entry is left
+// out of the sample. Errors and slow requests can be logged regardless of the sample, since they are the ones
+// admins look for. The roll is a random number from 0 to 99.
+func samplePercent(settings *model.ApiAccessLogSettings, entry *model.ApiAccessLogEntry, roll int) int {
+	if *settings.AlwaysLogErrors && entry.IsError() {
+		return 100
+	}
+
+	if *settings.SlowRequestThresholdMs > 0 && entry.DurationMs >= float64(*settings.SlowRequestThresholdMs) {
+		return 100
+	}
+
+	if roll < *settings.SamplePercent {
+		return *settings.SamplePercent
+	}
+
+	return 0
+}
+
+// Log writes the entry to the access log, unless it is disabled or the entry is left out of the sample. Failures
+// are logged rather than returned so that they never affect the request.
+func (accessLog *AccessLog) Log(entry *model.ApiAccessLogEntry) {
+	accessLog.lock.RLock()
+	defer accessLog.lock.RUnlock()
+
+	if accessLog.writer == nil {
+		return
+	}
+
+	entry.SamplePercent = samplePercent(&accessLog.settings, entry, rand.Intn(100))
+	if entry.SamplePercent == 0 {
+		return
+	}
+
+	if _, err := accessLog.writer.Write([]byte(entry.ToJson() + "\n")); err != nil {
+		accessLog.Logger.Error("Unable to write to the API access log", mlog.Err(err))
+	}
+}
+
+func (accessLog *AccessLog) Close() {
+	accessLog.ConfigService.RemoveConfigListener(accessLog.configListenerId)
+
+	accessLog.lock.Lock()
+	writer := accessLog.writer
+	accessLog.writer = nil
+	accessLog.lock.Unlock()
+
+	if writer != nil {
+		if err := writer.Close(); err != nil {
+			accessLog.Logger.Error("Unable to close the API access log", mlog.Err(err))
+		}
+	}
+}
+
+// Query returns the entries of the access log file matching the query, newest first. Only the current file is
+// read, so the entries in files that were already rotated are left out. A query without a page size returns every
+// matching entry.
+func Query(fileLocation string, query *model.ApiAccessLogQuery) ([]*model.ApiAccessLogEntry, error) {
+	file, err := os.Open(GetAccessLogFileLocation(fileLocation))
+	if os.IsNotExist(err) {
+		return []*model.ApiAccessLogEntry{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []*model.ApiAccessLogEntry
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var entry model.ApiAccessLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			// A line cut short by a crash shouldn't prevent reading the rest of the log
+			continue
+		}
+
+		if query.Matches(&entry) {
+			entries = append(entries, &entry)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	if query.PerPage > 0 {
+		start := query.Page * query.PerPage
+		if start >= len(entries) {
+			return []*model.ApiAccessLogEntry{}, nil
+		}
+
+		end := start + query.PerPage
+		if end > len(entries) {
+			end = len(entries)
+		}
+		entries = entries[start:end]
+	}
+
+	if entries == nil {
+		entries = []*model.ApiAccessLogEntry{}
+	}
+
+	return entries, nil
+}
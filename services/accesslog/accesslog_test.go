@@ -0,0 +1,104 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package accesslog
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils/testutils"
+)
+
+func TestSamplePercent(t *testing.T) {
+	settings := &model.ApiAccessLogSettings{}
+	settings.SetDefaults()
+	*settings.SamplePercent = 10
+
+	ok := &model.ApiAccessLogEntry{StatusCode: http.StatusOK, DurationMs: 5}
+	assert.Equal(t, 10, samplePercent(settings, ok, 9))
+	assert.Zero(t, samplePercent(settings, ok, 10))
+
+	failed := &model.ApiAccessLogEntry{StatusCode: http.StatusForbidden, DurationMs: 5}
+	assert.Equal(t, 100, samplePercent(settings, failed, 99))
+
+	slow := &model.ApiAccessLogEntry{StatusCode: http.StatusOK, DurationMs: 1500}
+	assert.Equal(t, 100, samplePercent(settings, slow, 99))
+
+	*settings.AlwaysLogErrors = false
+	*settings.SlowRequestThresholdMs = 0
+	assert.Zero(t, samplePercent(settings, failed, 99))
+	assert.Zero(t, samplePercent(settings, slow, 99))
+}
+
+func TestAccessLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "accesslog")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	*cfg.ApiAccessLogSettings.FileLocation = dir
+
+	accessLog := MakeAccessLog(&testutils.StaticConfigService{Cfg: cfg}, mlog.NewLogger(&mlog.LoggerConfiguration{}))
+	defer accessLog.Close()
+
+	userId := model.NewId()
+	tokenId := model.NewId()
+
+	accessLog.Log(&model.ApiAccessLogEntry{Timestamp: 1, Route: "getUser", StatusCode: http.StatusOK, UserId: userId})
+	entries, err := Query(dir, &model.ApiAccessLogQuery{})
+	require.Nil(t, err)
+	assert.Empty(t, entries, "the access log is disabled by default")
+
+	newCfg := cfg.Clone()
+	*newCfg.ApiAccessLogSettings.Enable = true
+	accessLog.OnConfigChange(cfg, newCfg)
+
+	accessLog.Log(&model.ApiAccessLogEntry{Timestamp: 1, Route: "getUser", StatusCode: http.StatusOK, UserId: userId})
+	accessLog.Log(&model.ApiAccessLogEntry{Timestamp: 2, Route: "createPost", StatusCode: http.StatusCreated, UserId: userId, TokenId: tokenId})
+	accessLog.Log(&model.ApiAccessLogEntry{Timestamp: 3, Route: "createPost", StatusCode: http.StatusBadRequest, UserId: userId, TokenId: tokenId})
+
+	t.Run("newest first", func(t *testing.T) {
+		entries, err := Query(dir, &model.ApiAccessLogQuery{})
+		require.Nil(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, int64(3), entries[0].Timestamp)
+		assert.Equal(t, int64(1), entries[2].Timestamp)
+		assert.Equal(t, 100, entries[0].SamplePercent)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		entries, err := Query(dir, &model.ApiAccessLogQuery{TokenId: tokenId})
+		require.Nil(t, err)
+		assert.Len(t, entries, 2)
+
+		entries, err = Query(dir, &model.ApiAccessLogQuery{Route: "createPost", MinStatusCode: 400})
+		require.Nil(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, int64(3), entries[0].Timestamp)
+
+		entries, err = Query(dir, &model.ApiAccessLogQuery{Since: 2, Until: 2})
+		require.Nil(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, int64(2), entries[0].Timestamp)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		entries, err := Query(dir, &model.ApiAccessLogQuery{Page: 1, PerPage: 2})
+		require.Nil(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, int64(1), entries[0].Timestamp)
+
+		entries, err = Query(dir, &model.ApiAccessLogQuery{Page: 2, PerPage: 2})
+		require.Nil(t, err)
+		assert.Empty(t, entries)
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package audit
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/configservice"
+)
+
+// A Sink receives the audit events emitted by the server and stores or forwards them.
+type Sink interface {
+	// Log records the event. It may buffer the event and return before it has been delivered.
+	Log(event *model.AuditEvent) error
+
+	// Close flushes any buffered events and releases the resources held by the sink.
+	Close() error
+}
+
+// An Audit dispatches audit events to the sinks enabled in the AuditSettings. An instance of Audit should be
+// created using MakeAudit, and the sinks are recreated whenever the AuditSettings change.
+type Audit struct {
+	ConfigService    configservice.ConfigService
+	configListenerId string
+
+	Logger *mlog.Logger
+
+	databaseSink Sink
+
+	lock  sync.RWMutex
+	sinks []Sink
+}
+
+// MakeAudit creates an Audit sending events to the sinks configured in the AuditSettings. The database sink is
+// provided by the server since it depends on the store.
+func MakeAudit(configService configservice.ConfigService, databaseSink Sink, logger *mlog.Logger) *Audit {
+	audit := &Audit{
+		ConfigService: configService,
+		Logger:        logger,
+		databaseSink:  databaseSink,
+	}
+
+	audit.configListenerId = audit.ConfigService.AddConfigListener(audit.OnConfigChange)
+	audit.sinks = audit.makeSinks(&audit.ConfigService.Config().AuditSettings)
+
+	return audit
+}
+
+func (audit *Audit) makeSinks(settings *model.AuditSettings) []Sink {
+	var sinks []Sink
+
+	if *settings.EnableDatabase && audit.databaseSink != nil {
+		sinks = append(sinks, audit.databaseSink)
+	}
+
+	if *settings.EnableFile {
+		sinks = append(sinks, makeFileSink(*settings.FileLocation, *settings.FileMaxSizeMB))
+	}
+
+	if *settings.EnableSyslog {
+		sink, err := makeSyslogSink(*settings.SyslogNetwork, *settings.SyslogAddress, *settings.SyslogTag)
+		if err != nil {
+			audit.Logger.Error("Unable to connect to the audit syslog server", mlog.String("address", *settings.SyslogAddress), mlog.Err(err))
+		} else {
+			sinks = append(sinks, sink)
+		}
+	}
+
+	if *settings.EnableHTTP {
+		sinks = append(sinks, makeHTTPSink(settings, audit.Logger))
+	}
+
+	return sinks
+}
+
+func (audit *Audit) OnConfigChange(oldConfig, newConfig *model.Config) {
+	if reflect.DeepEqual(oldConfig.AuditSettings, newConfig.AuditSettings) {
+		return
+	}
+
+	sinks := audit.makeSinks(&newConfig.AuditSettings)
+
+	audit.lock.Lock()
+	oldSinks := audit.sinks
+	audit.sinks = sinks
+	audit.lock.Unlock()
+
+	audit.closeSinks(oldSinks)
+}
+
+// Log sends the event to every enabled sink. Failures are logged rather than returned so that an unavailable
+// sink never prevents the audited action.
+func (audit *Audit) Log(event *model.AuditEvent) {
+	event.PreSave()
+
+	audit.lock.RLock()
+	defer audit.lock.RUnlock()
+
+	for _, sink := range audit.sinks {
+		if err := sink.Log(event); err != nil {
+			audit.Logger.Error("Unable to log audit event", mlog.String("action", event.Action), mlog.Err(err))
+		}
+	}
+}
+
+func (audit *Audit) Close() {
+	audit.ConfigService.RemoveConfigListener(audit.configListenerId)
+
+	audit.lock.Lock()
+	sinks := audit.sinks
+	audit.sinks = nil
+	audit.lock.Unlock()
+
+	audit.closeSinks(sinks)
+}
+
+func (audit *Audit) closeSinks(sinks []Sink) {
+	for _, sink := range sinks {
+		// The database sink is owned by the server and outlives the sinks built from the config.
+		if sink == audit.databaseSink {
+			continue
+		}
+
+		if err := sink.Close(); err != nil {
+			audit.Logger.Error("Unable to close audit sink", mlog.Err(err))
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils/testutils"
+)
+
+type testSink struct {
+	mutex  sync.Mutex
+	events []*model.AuditEvent
+	closed bool
+}
+
+func (s *testSink) Log(event *model.AuditEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *testSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func makeTestLogger() *mlog.Logger {
+	return mlog.NewLogger(&mlog.LoggerConfiguration{})
+}
+
+func makeTestAuditEvent(action string) *model.AuditEvent {
+	event := &model.AuditEvent{
+		ActorId:   model.NewId(),
+		SessionId: model.NewId(),
+		IpAddress: "127.0.0.1",
+		Action:    action,
+		Method:    http.MethodPost,
+		Target:    model.StringMap{"user_id": model.NewId()},
+		Result:    model.AUDIT_RESULT_SUCCESS,
+	}
+	event.PreSave()
+	return event
+}
+
+func TestAudit(t *testing.T) {
+	t.Run("database sink", func(t *testing.T) {
+		cfg := &model.Config{}
+		cfg.SetDefaults()
+
+		sink := &testSink{}
+		audit := MakeAudit(&testutils.StaticConfigService{Cfg: cfg}, sink, makeTestLogger())
+
+		audit.Log(&model.AuditEvent{Action: "/api/v4/users", Result: model.AUDIT_RESULT_SUCCESS})
+		require.Len(t, sink.events, 1)
+		assert.Len(t, sink.events[0].Id, 26)
+		assert.NotZero(t, sink.events[0].CreateAt)
+
+		audit.Close()
+		assert.False(t, sink.closed, "the database sink is owned by the server")
+	})
+
+	t.Run("database sink disabled", func(t *testing.T) {
+		cfg := &model.Config{}
+		cfg.SetDefaults()
+		*cfg.AuditSettings.EnableDatabase = false
+
+		sink := &testSink{}
+		audit := MakeAudit(&testutils.StaticConfigService{Cfg: cfg}, sink, makeTestLogger())
+		defer audit.Close()
+
+		audit.Log(&model.AuditEvent{Action: "/api/v4/users", Result: model.AUDIT_RESULT_SUCCESS})
+		assert.Empty(t, sink.events)
+	})
+
+	t.Run("config change", func(t *testing.T) {
+		cfg := &model.Config{}
+		cfg.SetDefaults()
+
+		sink := &testSink{}
+		audit := MakeAudit(&testutils.StaticConfigService{Cfg: cfg}, sink, makeTestLogger())
+		defer audit.Close()
+
+		newCfg := cfg.Clone()
+		*newCfg.AuditSettings.EnableDatabase = false
+		audit.OnConfigChange(cfg, newCfg)
+
+		audit.Log(&model.AuditEvent{Action: "/api/v4/users", Result: model.AUDIT_RESULT_SUCCESS})
+		assert.Empty(t, sink.events)
+	})
+}
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	sink := makeFileSink(dir, 1)
+
+	first := makeTestAuditEvent("/api/v4/users")
+	second := makeTestAuditEvent("/api/v4/teams")
+	require.Nil(t, sink.Log(first))
+	require.Nil(t, sink.Log(second))
+	require.Nil(t, sink.Close())
+
+	file, err := os.Open(filepath.Join(dir, AUDIT_FILENAME))
+	require.Nil(t, err)
+	defer file.Close()
+
+	var lines []*model.AuditEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, model.AuditEventFromJson(strings.NewReader(scanner.Text())))
+	}
+
+	require.Len(t, lines, 2)
+	assert.Equal(t, first, lines[0])
+	assert.Equal(t, second, lines[1])
+}
+
+func TestHTTPSink(t *testing.T) {
+	makeSettings := func(endpoint, format string, batchSize int) *model.AuditSettings {
+		settings := &model.AuditSettings{
+			EnableHTTP:              model.NewBool(true),
+			HTTPEndpoint:            model.NewString(endpoint),
+			HTTPFormat:              model.NewString(format),
+			HTTPAuthorizationHeader: model.NewString("Splunk token"),
+			HTTPIndex:               model.NewString("audit"),
+			HTTPBatchSize:           model.NewInt(batchSize),
+		}
+		settings.SetDefaults()
+		return settings
+	}
+
+	type request struct {
+		authorization string
+		contentType   string
+		body          string
+	}
+
+	makeServer := func(response string) (*httptest.Server, chan request) {
+		requests := make(chan request, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			requests <- request{r.Header.Get("Authorization"), r.Header.Get("Content-Type"), string(body)}
+			w.Write([]byte(response))
+		}))
+		return server, requests
+	}
+
+	t.Run("json", func(t *testing.T) {
+		server, requests := makeServer("")
+		defer server.Close()
+
+		sink := makeHTTPSink(makeSettings(server.URL, model.AUDIT_SETTINGS_HTTP_FORMAT_JSON, 2), makeTestLogger())
+		defer sink.Close()
+
+		events := []*model.AuditEvent{makeTestAuditEvent("/api/v4/users"), makeTestAuditEvent("/api/v4/teams")}
+		for _, event := range events {
+			require.Nil(t, sink.Log(event))
+		}
+
+		req := <-requests
+		assert.Equal(t, "Splunk token", req.authorization)
+		assert.Equal(t, "application/json", req.contentType)
+
+		var received []*model.AuditEvent
+		require.Nil(t, json.Unmarshal([]byte(req.body), &received))
+		assert.Equal(t, events, received)
+	})
+
+	t.Run("splunk hec", func(t *testing.T) {
+		server, requests := makeServer(`{"text":"Success","code":0}`)
+		defer server.Close()
+
+		sink := makeHTTPSink(makeSettings(server.URL, model.AUDIT_SETTINGS_HTTP_FORMAT_SPLUNK_HEC, 2), makeTestLogger())
+		defer sink.Close()
+
+		events := []*model.AuditEvent{makeTestAuditEvent("/api/v4/users"), makeTestAuditEvent("/api/v4/teams")}
+		for _, event := range events {
+			require.Nil(t, sink.Log(event))
+		}
+
+		req := <-requests
+		lines := strings.Split(strings.TrimSpace(req.body), "\n")
+		require.Len(t, lines, 2)
+
+		for i, line := range lines {
+			var received struct {
+				Time       float64           `json:"time"`
+				SourceType string            `json:"sourcetype"`
+				Event      *model.AuditEvent `json:"event"`
+			}
+			require.Nil(t, json.Unmarshal([]byte(line), &received))
+			assert.Equal(t, SPLUNK_SOURCE_TYPE, received.SourceType)
+			assert.Equal(t, events[i].CreateAt/1000, int64(received.Time))
+			assert.Equal(t, events[i], received.Event)
+		}
+	})
+
+	t.Run("elastic bulk", func(t *testing.T) {
+		server, requests := makeServer(`{"errors":false,"items":[]}`)
+		defer server.Close()
+
+		sink := makeHTTPSink(makeSettings(server.URL, model.AUDIT_SETTINGS_HTTP_FORMAT_ELASTIC_BULK, 1), makeTestLogger())
+		defer sink.Close()
+
+		event := makeTestAuditEvent("/api/v4/users")
+		require.Nil(t, sink.Log(event))
+
+		req := <-requests
+		assert.Equal(t, "application/x-ndjson", req.contentType)
+
+		lines := strings.Split(strings.TrimSpace(req.body), "\n")
+		require.Len(t, lines, 2)
+		assert.JSONEq(t, `{"index":{"_index":"audit","_id":"`+event.Id+`"}}`, lines[0])
+		assert.Equal(t, event, model.AuditEventFromJson(strings.NewReader(lines[1])))
+	})
+
+	t.Run("flushes on close", func(t *testing.T) {
+		server, requests := makeServer("")
+		defer server.Close()
+
+		sink := makeHTTPSink(makeSettings(server.URL, model.AUDIT_SETTINGS_HTTP_FORMAT_JSON, 100), makeTestLogger())
+
+		require.Nil(t, sink.Log(makeTestAuditEvent("/api/v4/users")))
+		require.Nil(t, sink.Close())
+
+		select {
+		case req := <-requests:
+			var received []*model.AuditEvent
+			require.Nil(t, json.Unmarshal([]byte(req.body), &received))
+			assert.Len(t, received, 1)
+		default:
+			require.Fail(t, "expected the queued event to be sent when closing")
+		}
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package audit
+
+import (
+	"path/filepath"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils/fileutils"
+)
+
+const AUDIT_FILENAME = "audit.log"
+
+// fileSink writes each event as a line of JSON to a file that is rotated once it reaches its maximum size.
+type fileSink struct {
+	writer *lumberjack.Logger
+}
+
+func GetAuditFileLocation(fileLocation string) string {
+	if fileLocation == "" {
+		fileLocation, _ = fileutils.FindDir("logs")
+	}
+
+	return filepath.Join(fileLocation, AUDIT_FILENAME)
+}
+
+func makeFileSink(fileLocation string, maxSizeMB int) *fileSink {
+	return &fileSink{
+		writer: &lumberjack.Logger{
+			Filename: GetAuditFileLocation(fileLocation),
+			MaxSize:  maxSizeMB,
+			Compress: true,
+		},
+	}
+}
+
+func (s *fileSink) Log(event *model.AuditEvent) error {
+	_, err := s.writer.Write([]byte(event.ToJson() + "\n"))
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.writer.Close()
+}
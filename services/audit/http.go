@@ -0,0 +1,239 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package audit
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	HTTP_SINK_MAX_ATTEMPTS = 3
+	HTTP_SINK_RETRY_DELAY  = time.Second
+
+	SPLUNK_SOURCE      = "mattermost"
+	SPLUNK_SOURCE_TYPE = "mattermost:audit"
+)
+
+// httpSink queues events and posts them in batches to a SIEM collector, such as a Splunk HTTP Event Collector
+// or the bulk API of Elasticsearch. A batch is sent once it is full or when the flush interval elapses. Events
+// are dropped when the queue is full or the collector can't be reached after a few attempts, so a slow
+// collector never blocks the server.
+type httpSink struct {
+	client        *http.Client
+	endpoint      string
+	format        string
+	authorization string
+	index         string
+	batchSize     int
+	flushInterval time.Duration
+
+	logger  *mlog.Logger
+	dropped int64
+
+	queue     chan *model.AuditEvent
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+func makeHTTPSink(settings *model.AuditSettings, logger *mlog.Logger) *httpSink {
+	sink := &httpSink{
+		client: &http.Client{
+			Timeout: time.Duration(*settings.HTTPRequestTimeoutSeconds) * time.Second,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: *settings.HTTPSkipCertificateValidation,
+				},
+			},
+		},
+		endpoint:      *settings.HTTPEndpoint,
+		format:        *settings.HTTPFormat,
+		authorization: *settings.HTTPAuthorizationHeader,
+		index:         *settings.HTTPIndex,
+		batchSize:     *settings.HTTPBatchSize,
+		flushInterval: time.Duration(*settings.HTTPFlushIntervalSeconds) * time.Second,
+		logger:        logger,
+		queue:         make(chan *model.AuditEvent, *settings.HTTPMaxQueueSize),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+
+	go sink.run()
+
+	return sink
+}
+
+func (s *httpSink) Log(event *model.AuditEvent) error {
+	select {
+	case s.queue <- event:
+	default:
+		atomic.AddInt64(&s.dropped, 1)
+	}
+
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	<-s.stopped
+
+	return nil
+}
+
+func (s *httpSink) run() {
+	defer close(s.stopped)
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*model.AuditEvent, 0, s.batchSize)
+	flush := func() {
+		if dropped := atomic.SwapInt64(&s.dropped, 0); dropped > 0 {
+			s.logger.Warn("Dropped audit events because the queue of the audit HTTP exporter is full", mlog.Int64("dropped", dropped))
+		}
+
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := s.send(batch); err != nil {
+			s.logger.Error("Unable to export audit events", mlog.String("endpoint", s.endpoint), mlog.Int("events", len(batch)), mlog.Err(err))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case event := <-s.queue:
+			batch = append(batch, event)
+			if len(batch) >= s.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.done:
+			for {
+				select {
+				case event := <-s.queue:
+					batch = append(batch, event)
+					if len(batch) >= s.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *httpSink) send(batch []*model.AuditEvent) error {
+	body, contentType, err := s.encode(batch)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = s.post(body, contentType)
+		if err == nil || attempt == HTTP_SINK_MAX_ATTEMPTS {
+			return err
+		}
+
+		select {
+		case <-time.After(time.Duration(attempt) * HTTP_SINK_RETRY_DELAY):
+		case <-s.done:
+			// Don't hold up the shutdown by waiting for a collector that is failing.
+			return err
+		}
+	}
+}
+
+func (s *httpSink) post(body []byte, contentType string) error {
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	if s.authorization != "" {
+		req.Header.Set("Authorization", s.authorization)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		io.Copy(ioutil.Discard, resp.Body)
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	// The bulk API of Elasticsearch reports the documents it failed to index in a successful response.
+	if s.format == model.AUDIT_SETTINGS_HTTP_FORMAT_ELASTIC_BULK {
+		var result struct {
+			Errors bool `json:"errors"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err == nil && result.Errors {
+			return fmt.Errorf("some audit events were not indexed")
+		}
+	}
+
+	io.Copy(ioutil.Discard, resp.Body)
+
+	return nil
+}
+
+// encode returns the body of the request sending the batch and its content type.
+func (s *httpSink) encode(batch []*model.AuditEvent) ([]byte, string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+
+	switch s.format {
+	case model.AUDIT_SETTINGS_HTTP_FORMAT_SPLUNK_HEC:
+		for _, event := range batch {
+			if err := encoder.Encode(map[string]interface{}{
+				"time":       float64(event.CreateAt) / 1000,
+				"source":     SPLUNK_SOURCE,
+				"sourcetype": SPLUNK_SOURCE_TYPE,
+				"event":      event,
+			}); err != nil {
+				return nil, "", err
+			}
+		}
+		return buf.Bytes(), "application/json", nil
+	case model.AUDIT_SETTINGS_HTTP_FORMAT_ELASTIC_BULK:
+		for _, event := range batch {
+			if err := encoder.Encode(map[string]interface{}{"index": map[string]string{"_index": s.index, "_id": event.Id}}); err != nil {
+				return nil, "", err
+			}
+			if err := encoder.Encode(event); err != nil {
+				return nil, "", err
+			}
+		}
+		return buf.Bytes(), "application/x-ndjson", nil
+	default:
+		if err := encoder.Encode(batch); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "application/json", nil
+	}
+}
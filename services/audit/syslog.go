@@ -0,0 +1,44 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+//go:build !windows
+// +build !windows
+
+package audit
+
+import (
+	"log/syslog"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// syslogSink sends each event as JSON to the local syslog daemon, or to a remote one over UDP or TCP. Failed
+// actions are logged with the warning severity so they can be told apart without parsing the message.
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+func makeSyslogSink(network, address, tag string) (*syslogSink, error) {
+	if network == model.AUDIT_SETTINGS_SYSLOG_NETWORK_LOCAL {
+		address = ""
+	}
+
+	writer, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_AUTHPRIV, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &syslogSink{writer: writer}, nil
+}
+
+func (s *syslogSink) Log(event *model.AuditEvent) error {
+	if event.Result == model.AUDIT_RESULT_FAIL {
+		return s.writer.Warning(event.ToJson())
+	}
+
+	return s.writer.Info(event.ToJson())
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
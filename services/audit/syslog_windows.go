@@ -0,0 +1,24 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package audit
+
+import (
+	"errors"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type syslogSink struct{}
+
+func makeSyslogSink(network, address, tag string) (*syslogSink, error) {
+	return nil, errors.New("syslog is not supported on Windows")
+}
+
+func (s *syslogSink) Log(event *model.AuditEvent) error {
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+// Package featureflag evaluates the feature flags gradually enabling experimental features.
+package featureflag
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/configservice"
+)
+
+// Source provides the current feature flags, by name.
+type Source interface {
+	FeatureFlags() map[string]*model.FeatureFlag
+}
+
+type configSource struct {
+	configService configservice.ConfigService
+}
+
+// NewConfigSource returns a source reading the feature flags from the FeatureFlagSettings of the configuration.
+func NewConfigSource(configService configservice.ConfigService) Source {
+	return &configSource{configService: configService}
+}
+
+func (s *configSource) FeatureFlags() map[string]*model.FeatureFlag {
+	return s.configService.Config().FeatureFlagSettings.Flags
+}
+
+type FeatureFlags struct {
+	source Source
+}
+
+func New(source Source) *FeatureFlags {
+	return &FeatureFlags{source: source}
+}
+
+// IsEnabled reports whether the feature is enabled for a user belonging to the given teams. Unknown features are
+// disabled.
+func (f *FeatureFlags) IsEnabled(name, userId string, teamIds []string) bool {
+	flag := f.source.FeatureFlags()[name]
+	if flag == nil {
+		return false
+	}
+
+	return flag.IsEnabledFor(name, userId, teamIds)
+}
+
+// EnabledFor evaluates every feature flag for a user belonging to the given teams.
+func (f *FeatureFlags) EnabledFor(userId string, teamIds []string) map[string]bool {
+	flags := f.source.FeatureFlags()
+
+	enabled := make(map[string]bool, len(flags))
+	for name, flag := range flags {
+		if flag == nil {
+			continue
+		}
+		enabled[name] = flag.IsEnabledFor(name, userId, teamIds)
+	}
+
+	return enabled
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package featureflag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type staticSource map[string]*model.FeatureFlag
+
+func (s staticSource) FeatureFlags() map[string]*model.FeatureFlag {
+	return s
+}
+
+func TestFeatureFlags(t *testing.T) {
+	userId := model.NewId()
+	teamId := model.NewId()
+
+	flags := New(staticSource{
+		"Everyone": {Enabled: true},
+		"Team":     {TeamIds: []string{teamId}},
+		"Nobody":   {},
+		"Missing":  nil,
+	})
+
+	assert.True(t, flags.IsEnabled("Everyone", userId, nil))
+	assert.True(t, flags.IsEnabled("Team", userId, []string{teamId}))
+	assert.False(t, flags.IsEnabled("Team", userId, nil))
+	assert.False(t, flags.IsEnabled("Nobody", userId, nil))
+	assert.False(t, flags.IsEnabled("Missing", userId, nil))
+	assert.False(t, flags.IsEnabled("Unknown", userId, nil))
+
+	assert.Equal(t, map[string]bool{"Everyone": true, "Team": true, "Nobody": false}, flags.EnabledFor(userId, []string{teamId}))
+}
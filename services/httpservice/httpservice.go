@@ -6,10 +6,12 @@ package httpservice
 import (
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 	"unicode"
 
+	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/services/configservice"
 )
 
@@ -25,6 +27,7 @@ type HTTPService interface {
 	// - A timeout for end-to-end requests
 	// - A Mattermost-specific user agent header
 	// - Additional security for untrusted and insecure connections
+	// - An egress proxy, timeouts per destination and a circuit breaker for untrusted connections
 	MakeTransport(trustURLs bool) http.RoundTripper
 }
 
@@ -32,6 +35,8 @@ type HTTPServiceImpl struct {
 	configService configservice.ConfigService
 
 	RequestTimeout time.Duration
+
+	circuitBreaker *circuitBreaker
 }
 
 func splitFields(c rune) bool {
@@ -40,16 +45,23 @@ func splitFields(c rune) bool {
 
 func MakeHTTPService(configService configservice.ConfigService) HTTPService {
 	return &HTTPServiceImpl{
-		configService,
-		RequestTimeout,
+		configService:  configService,
+		RequestTimeout: RequestTimeout,
+		circuitBreaker: newCircuitBreaker(),
 	}
 }
 
 func (h *HTTPServiceImpl) MakeClient(trustURLs bool) *http.Client {
-	return &http.Client{
+	client := &http.Client{
 		Transport: h.MakeTransport(trustURLs),
-		Timeout:   h.RequestTimeout,
 	}
+
+	// The transport of untrusted connections applies the timeout of each destination itself
+	if trustURLs {
+		client.Timeout = h.RequestTimeout
+	}
+
+	return client
 }
 
 func (h *HTTPServiceImpl) MakeTransport(trustURLs bool) http.RoundTripper {
@@ -98,5 +110,58 @@ func (h *HTTPServiceImpl) MakeTransport(trustURLs bool) http.RoundTripper {
 		return false
 	}
 
-	return NewTransport(insecure, allowHost, allowIP)
+	var proxyURL *url.URL
+	if h.configService.Config().ServiceSettings.OutboundProxyURL != nil && *h.configService.Config().ServiceSettings.OutboundProxyURL != "" {
+		var err error
+		if proxyURL, err = url.Parse(*h.configService.Config().ServiceSettings.OutboundProxyURL); err != nil {
+			mlog.Error("Failed to parse the outbound proxy URL, connecting directly instead", mlog.Err(err))
+			proxyURL = nil
+		}
+	}
+
+	if proxyURL == nil {
+		return &OutboundTransport{
+			Transport: NewTransport(insecure, allowHost, allowIP),
+			service:   h,
+		}
+	}
+
+	// The connections to the egress proxy itself are always allowed, so the destinations of the requests are checked
+	// before they're handed over to it instead of when connecting
+	transport := NewTransport(insecure, func(host string) bool {
+		return host == proxyURL.Hostname() || allowHost(host)
+	}, allowIP)
+	transport.(*MattermostTransport).Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
+
+	return &OutboundTransport{
+		Transport: transport,
+		service:   h,
+		checkDestination: func(host string) bool {
+			return isDestinationAllowed(host, allowHost, allowIP, net.LookupIP)
+		},
+	}
+}
+
+// isDestinationAllowed returns whether a connection may be made to a host, as checked when dialing it directly. Since
+// the proxy may connect to any of the addresses of the host, all of them have to be allowed.
+//
+// The host is resolved again by the proxy, which may get different addresses if the DNS records changed in between,
+// so this doesn't protect against DNS rebinding. The proxy itself has to deny the internal addresses for that.
+func isDestinationAllowed(host string, allowHost func(host string) bool, allowIP func(ip net.IP) bool, lookupIP func(host string) ([]net.IP, error)) bool {
+	if allowHost(host) {
+		return true
+	}
+
+	ips, err := lookupIP(host)
+	if err != nil || len(ips) == 0 {
+		return false
+	}
+
+	for _, ip := range ips {
+		if !allowIP(ip) {
+			return false
+		}
+	}
+
+	return true
 }
@@ -0,0 +1,159 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package httpservice
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var DestinationUnavailable error = errors.New("destination unavailable, too many recent requests to it failed so requests are paused for a while")
+
+// circuitBreaker stops requests to destinations that keep failing, so that a slow or broken integration doesn't tie up
+// the server. Once a destination has failed the given number of times in a row, requests to it fail right away until
+// the cooldown has passed, after which the next request is let through to find whether it has recovered.
+type circuitBreaker struct {
+	mutex    sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{
+		circuits: map[string]*circuit{},
+	}
+}
+
+// allow returns whether a request to the destination may be made.
+func (b *circuitBreaker) allow(destination string, now time.Time) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c, ok := b.circuits[destination]
+	return !ok || !now.Before(c.openUntil)
+}
+
+// record records whether a request to the destination failed, opening its circuit for the cooldown once it has failed
+// threshold times in a row. A threshold of 0 disables the circuit breaker.
+func (b *circuitBreaker) record(destination string, failed bool, threshold int, cooldown time.Duration, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !failed || threshold <= 0 {
+		delete(b.circuits, destination)
+		return
+	}
+
+	c, ok := b.circuits[destination]
+	if !ok {
+		c = &circuit{}
+		b.circuits[destination] = c
+	}
+
+	c.failures++
+	if c.failures >= threshold {
+		c.openUntil = now.Add(cooldown)
+	}
+}
+
+// getDestinationTimeout returns the timeout configured for the most specific of the given domains that host is or is a
+// subdomain of, or defaultTimeout if there's none.
+func getDestinationTimeout(host string, timeouts map[string]int, defaultTimeout time.Duration) time.Duration {
+	host = strings.ToLower(host)
+
+	timeout := defaultTimeout
+	matched := ""
+	for destination, seconds := range timeouts {
+		destination = strings.ToLower(destination)
+		if (host == destination || strings.HasSuffix(host, "."+destination)) && len(destination) > len(matched) {
+			timeout = time.Duration(seconds) * time.Second
+			matched = destination
+		}
+	}
+
+	return timeout
+}
+
+// OutboundTransport is an implementation of http.RoundTripper for requests to untrusted URLs. It applies the timeout of
+// the destination of each request and the circuit breaker of the HTTPService and, when the requests go through an
+// egress proxy, checks that their destination is allowed since the proxy is what connects to it.
+type OutboundTransport struct {
+	// Transport is the underlying http.RoundTripper that is actually used to make the request
+	Transport http.RoundTripper
+
+	service          *HTTPServiceImpl
+	checkDestination func(host string) bool
+}
+
+func (t *OutboundTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+
+	if t.checkDestination != nil && !t.checkDestination(host) {
+		return nil, AddressForbidden
+	}
+
+	settings := t.service.configService.Config().ServiceSettings
+
+	threshold := 0
+	if settings.OutboundCircuitBreakerThreshold != nil {
+		threshold = *settings.OutboundCircuitBreakerThreshold
+	}
+
+	cooldown := time.Minute
+	if settings.OutboundCircuitBreakerCooldownSeconds != nil {
+		cooldown = time.Duration(*settings.OutboundCircuitBreakerCooldownSeconds) * time.Second
+	}
+
+	if threshold > 0 && !t.service.circuitBreaker.allow(req.URL.Host, time.Now()) {
+		return nil, DestinationUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), getDestinationTimeout(host, settings.OutboundRequestTimeouts, t.service.RequestTimeout))
+
+	resp, err := t.Transport.RoundTrip(req.WithContext(ctx))
+
+	// Requests canceled by the caller or refused before they're made don't count as failures of the destination
+	if req.Context().Err() == nil && !isAddressForbidden(err) {
+		failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		t.service.circuitBreaker.record(req.URL.Host, failed, threshold, cooldown, time.Now())
+	}
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The timeout covers reading the body as well, so it's only released once the body is closed
+	resp.Body = &cancelOnCloseBody{resp.Body, cancel}
+
+	return resp, nil
+}
+
+func isAddressForbidden(err error) bool {
+	if opErr, ok := err.(*net.OpError); ok {
+		err = opErr.Err
+	}
+	return err == AddressForbidden
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package httpservice
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils/testutils"
+)
+
+func makeTestHTTPService(updateConfig func(*model.Config)) HTTPService {
+	config := &model.Config{}
+	config.SetDefaults()
+	*config.ServiceSettings.AllowedUntrustedInternalConnections = "127.0.0.1"
+
+	if updateConfig != nil {
+		updateConfig(config)
+	}
+
+	return MakeHTTPService(&testutils.StaticConfigService{Cfg: config})
+}
+
+func TestIsDestinationAllowed(t *testing.T) {
+	allowHost := func(host string) bool {
+		return host == "allowed.example.com"
+	}
+	allowIP := func(ip net.IP) bool {
+		return !IsReservedIP(ip)
+	}
+	lookupIP := func(host string) ([]net.IP, error) {
+		switch host {
+		case "public.example.com":
+			return []net.IP{net.IPv4(8, 8, 8, 8), net.IPv4(8, 8, 4, 4)}, nil
+		case "internal.example.com":
+			return []net.IP{net.IPv4(10, 0, 0, 1)}, nil
+		case "mixed.example.com":
+			return []net.IP{net.IPv4(8, 8, 8, 8), net.IPv4(169, 254, 169, 254)}, nil
+		case "empty.example.com":
+			return nil, nil
+		}
+		return nil, fmt.Errorf("no such host %v", host)
+	}
+
+	assert.True(t, isDestinationAllowed("allowed.example.com", allowHost, allowIP, lookupIP))
+	assert.True(t, isDestinationAllowed("public.example.com", allowHost, allowIP, lookupIP))
+	assert.False(t, isDestinationAllowed("internal.example.com", allowHost, allowIP, lookupIP))
+	assert.False(t, isDestinationAllowed("mixed.example.com", allowHost, allowIP, lookupIP), "every address of the host should be checked")
+	assert.False(t, isDestinationAllowed("empty.example.com", allowHost, allowIP, lookupIP))
+	assert.False(t, isDestinationAllowed("unknown.example.com", allowHost, allowIP, lookupIP))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := newCircuitBreaker()
+	now := time.Now()
+
+	breaker.record("example.com", true, 2, time.Minute, now)
+	assert.True(t, breaker.allow("example.com", now))
+
+	breaker.record("example.com", true, 2, time.Minute, now)
+	assert.False(t, breaker.allow("example.com", now))
+	assert.True(t, breaker.allow("example.org", now))
+
+	// Once the cooldown has passed, a request is let through and opens the circuit again if it fails
+	later := now.Add(time.Minute)
+	assert.True(t, breaker.allow("example.com", later))
+	breaker.record("example.com", true, 2, time.Minute, later)
+	assert.False(t, breaker.allow("example.com", later))
+
+	// A successful request closes the circuit
+	breaker.record("example.com", false, 2, time.Minute, later.Add(time.Minute))
+	assert.True(t, breaker.allow("example.com", later))
+}
+
+func TestGetDestinationTimeout(t *testing.T) {
+	timeouts := map[string]int{
+		"example.com":       5,
+		"hooks.example.com": 60,
+	}
+
+	assert.Equal(t, 5*time.Second, getDestinationTimeout("example.com", timeouts, RequestTimeout))
+	assert.Equal(t, 5*time.Second, getDestinationTimeout("www.Example.com", timeouts, RequestTimeout))
+	assert.Equal(t, 60*time.Second, getDestinationTimeout("ci.hooks.example.com", timeouts, RequestTimeout))
+	assert.Equal(t, RequestTimeout, getDestinationTimeout("example.org", timeouts, RequestTimeout))
+	assert.Equal(t, RequestTimeout, getDestinationTimeout("notexample.com", timeouts, RequestTimeout))
+}
+
+func TestOutboundTransport(t *testing.T) {
+	t.Run("circuit breaker", func(t *testing.T) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := makeTestHTTPService(func(config *model.Config) {
+			*config.ServiceSettings.OutboundCircuitBreakerThreshold = 2
+		}).MakeClient(false)
+
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(server.URL)
+			require.Nil(t, err)
+			resp.Body.Close()
+		}
+
+		_, err := client.Get(server.URL)
+		require.NotNil(t, err)
+		assert.Equal(t, DestinationUnavailable, err.(*url.Error).Err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("destination timeout", func(t *testing.T) {
+		wait := make(chan bool)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-wait
+		}))
+		defer server.Close()
+		defer close(wait)
+
+		client := makeTestHTTPService(func(config *model.Config) {
+			config.ServiceSettings.OutboundRequestTimeouts = map[string]int{"127.0.0.1": 1}
+		}).MakeClient(false)
+
+		start := time.Now()
+		_, err := client.Get(server.URL)
+		require.NotNil(t, err)
+		assert.True(t, time.Since(start) < RequestTimeout)
+	})
+
+	t.Run("egress proxy", func(t *testing.T) {
+		proxy := createProxyServer()
+		defer proxy.Close()
+
+		client := makeTestHTTPService(func(config *model.Config) {
+			*config.ServiceSettings.OutboundProxyURL = proxy.URL
+			*config.ServiceSettings.AllowedUntrustedInternalConnections = "acme.internal"
+		}).MakeClient(false)
+
+		resp, err := client.Get("http://acme.internal")
+		require.Nil(t, err)
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		require.Nil(t, err)
+		assert.Equal(t, "proxy", string(body))
+
+		// The destinations of the requests are still checked even though only the proxy is connected to
+		_, err = client.Get("http://127.0.0.1:8065")
+		require.NotNil(t, err)
+		assert.Equal(t, AddressForbidden, err.(*url.Error).Err)
+	})
+}
@@ -18,6 +18,7 @@ import (
 )
 
 var ErrNotEnabled = Error{errors.New("imageproxy.ImageProxy: image proxy not enabled")}
+var ErrDomainNotAllowed = Error{errors.New("imageproxy.ImageProxy: image domain not allowed")}
 
 // An ImageProxy is the public interface for Mattermost's image proxy. An instance of ImageProxy should be created
 // using MakeImageProxy which requires a configService and an HTTPService provided by the server.
@@ -100,6 +101,11 @@ func (proxy *ImageProxy) GetImage(w http.ResponseWriter, r *http.Request, imageU
 		return
 	}
 
+	if !proxy.isImageURLAllowed(imageURL) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	proxy.backend.GetImage(w, r, imageURL)
 }
 
@@ -112,9 +118,19 @@ func (proxy *ImageProxy) GetImageDirect(imageURL string) (io.ReadCloser, string,
 		return nil, "", ErrNotEnabled
 	}
 
+	if !proxy.isImageURLAllowed(imageURL) {
+		return nil, "", ErrDomainNotAllowed
+	}
+
 	return proxy.backend.GetImageDirect(imageURL)
 }
 
+// isImageURLAllowed returns whether the image proxy may fetch an image according to the domains allowed and blocked
+// for link previews.
+func (proxy *ImageProxy) isImageURLAllowed(imageURL string) bool {
+	return proxy.ConfigService.Config().ServiceSettings.IsLinkPreviewURLAllowed(imageURL)
+}
+
 // GetProxiedImageURL takes the URL of an image and returns a URL that can be used to view that image through the
 // image proxy.
 func (proxy *ImageProxy) GetProxiedImageURL(imageURL string) string {
@@ -164,6 +164,25 @@ func TestLocalBackend_GetImage(t *testing.T) {
 
 		wait <- true
 	})
+
+	t.Run("domain not allowed", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Fail(t, "the image shouldn't be requested")
+		})
+
+		mock := httptest.NewServer(handler)
+		defer mock.Close()
+
+		proxy := makeTestLocalProxy()
+		proxy.ConfigService.Config().ServiceSettings.LinkPreviewAllowedDomains = model.NewString("example.com")
+
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest(http.MethodGet, "", nil)
+		proxy.GetImage(recorder, request, mock.URL+"/image.png")
+		resp := recorder.Result()
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
 }
 
 func TestLocalBackend_GetImageDirect(t *testing.T) {
@@ -292,4 +311,22 @@ func TestLocalBackend_GetImageDirect(t *testing.T) {
 
 		wait <- true
 	})
+
+	t.Run("blocked domain", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Fail(t, "the image shouldn't be requested")
+		})
+
+		mock := httptest.NewServer(handler)
+		defer mock.Close()
+
+		proxy := makeTestLocalProxy()
+		proxy.ConfigService.Config().ServiceSettings.LinkPreviewBlockedDomains = model.NewString("127.0.0.1")
+
+		body, contentType, err := proxy.GetImageDirect(mock.URL + "/image.png")
+
+		assert.Equal(t, ErrDomainNotAllowed, err)
+		assert.Equal(t, "", contentType)
+		assert.Nil(t, body)
+	})
 }
@@ -18,14 +18,17 @@ import (
 )
 
 func main() {
-	code := GenerateTimerLayer()
+	writeLayer("timer_layer.go", GenerateTimerLayer())
+	writeLayer("retry_layer.go", GenerateRetryLayer())
+}
 
+func writeLayer(fileName string, code string) {
 	formatedCode, err := format.Source([]byte(code))
 	if err != nil {
 		panic(err)
 	}
 
-	err = ioutil.WriteFile(path.Join("timer_layer.go"), formatedCode, 0644)
+	err = ioutil.WriteFile(path.Join(fileName), formatedCode, 0644)
 	if err != nil {
 		panic(err)
 	}
@@ -152,12 +155,8 @@ func ExtractStoreMetadata() StoreMetadata {
 	return metadata
 }
 
-func GenerateTimerLayer() string {
-	out := bytes.NewBufferString("")
-	metadata := ExtractStoreMetadata()
-	metadata.Name = "TimerLayer"
-
-	myFuncs := template.FuncMap{
+func layerFuncs() template.FuncMap {
+	return template.FuncMap{
 		"joinResults": func(results []string) string {
 			return strings.Join(results, ", ")
 		},
@@ -185,6 +184,14 @@ func GenerateTimerLayer() string {
 			}
 			return "true"
 		},
+		"errorVar": func(results []string) string {
+			for idx, typeName := range results {
+				if typeName == "*model.AppError" {
+					return fmt.Sprintf("resultVar%d", idx)
+				}
+			}
+			return ""
+		},
 		"joinParams": func(params []Param) string {
 			paramsNames := []string{}
 			for _, param := range params {
@@ -200,8 +207,14 @@ func GenerateTimerLayer() string {
 			return strings.Join(paramsWithType, ", ")
 		},
 	}
+}
+
+func GenerateTimerLayer() string {
+	out := bytes.NewBufferString("")
+	metadata := ExtractStoreMetadata()
+	metadata.Name = "TimerLayer"
 
-	t, err := template.New("timer-layer").Funcs(myFuncs).Parse(`
+	t, err := template.New("timer-layer").Funcs(layerFuncs()).Parse(`
 // Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
 // See LICENSE.txt for license information.
 
@@ -211,6 +224,7 @@ func GenerateTimerLayer() string {
 package store
 
 import (
+	"context"
 	timemodule "time"
 
     "github.com/mattermost/mattermost-server/einterfaces"
@@ -251,7 +265,9 @@ func (s *{{$.Name}}{{$substoreName}}Store) {{$index}}({{$element.Params | joinPa
 		success := "false"
 		if {{$element.Results | errorToBoolean}} {
 			success = "true"
-		}
+		}{{if ne ($element.Results | errorToBoolean) "true"}} else {
+			s.Root.Metrics.IncrementStoreMethodError("{{$substoreName}}Store.{{$index}}")
+		}{{end}}
 		s.Root.Metrics.ObserveStoreMethodDuration("{{$substoreName}}Store.{{$index}}", success, elapsed)
 	}
 	return {{$element.Results | genResultsVars}}
@@ -285,3 +301,89 @@ func New{{.Name}}(childStore Store, metrics einterfaces.MetricsInterface) *{{.Na
 	}
 	return out.String()
 }
+
+func GenerateRetryLayer() string {
+	out := bytes.NewBufferString("")
+	metadata := ExtractStoreMetadata()
+	metadata.Name = "RetryLayer"
+
+	t, err := template.New("retry-layer").Funcs(layerFuncs()).Parse(`
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package store
+
+import (
+	"context"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type {{.Name}} struct {
+	Store
+{{range $index, $element := .SubStores}}	{{$index}}Store {{$index}}Store
+{{end}}
+}
+
+{{range $index, $element := .SubStores}}func (s *{{$.Name}}) {{$index}}() {{$index}}Store {
+	return s.{{$index}}Store
+}
+
+{{end}}
+
+{{range $index, $element := .SubStores}}type {{$.Name}}{{$index}}Store struct {
+	{{$index}}Store
+	Root *{{$.Name}}
+}
+
+{{end}}
+
+{{range $substoreName, $substore := .SubStores}}
+{{range $index, $element := $substore.Methods}}
+func (s *{{$.Name}}{{$substoreName}}Store) {{$index}}({{$element.Params | joinParamsWithType}}) {{$element.Results | joinResultsForSignature}} {
+	{{- if eq ($element.Results | errorVar) ""}}
+	{{if $element.Results | len | eq 0}}s.{{$substoreName}}Store.{{$index}}({{$element.Params | joinParams}})
+	{{- else}}return s.{{$substoreName}}Store.{{$index}}({{$element.Params | joinParams}})
+	{{- end}}
+	{{- else}}
+	tries := 0
+	for {
+		{{$element.Results | genResultsVars}} := s.{{$substoreName}}Store.{{$index}}({{$element.Params | joinParams}})
+		tries++
+		if {{$element.Results | errorVar}} == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError({{$element.Results | errorVar}}) {
+			return {{$element.Results | genResultsVars}}
+		}
+	}
+	{{- end}}
+}
+{{end}}
+{{end}}
+
+{{range $index, $element := .Methods}}
+func (s *{{$.Name}}) {{$index}}({{$element.Params | joinParamsWithType}}) {{$element.Results | joinResultsForSignature}} {
+	{{if $element.Results | len | eq 0}}s.Store.{{$index}}({{$element.Params | joinParams}})
+	{{ else }}return s.Store.{{$index}}({{$element.Params | joinParams}})
+	{{ end}}}
+{{end}}
+
+func New{{.Name}}(childStore Store) *{{.Name}} {
+	newStore := {{.Name}}{
+		Store: childStore,
+	}
+	{{range $substoreName, $substore := .SubStores}}
+	newStore.{{$substoreName}}Store = &{{$.Name}}{{$substoreName}}Store{{"{"}}{{$substoreName}}Store: childStore.{{$substoreName}}(), Root: &newStore}{{end}}
+	return &newStore
+}
+`)
+	if err != nil {
+		panic(err)
+	}
+	err = t.Execute(out, metadata)
+	if err != nil {
+		panic(err)
+	}
+	return out.String()
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/einterfaces"
 	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
 )
 
 const (
@@ -178,6 +179,146 @@ func (s *LayeredStore) LinkMetadata() LinkMetadataStore {
 	return s.DatabaseLayer.LinkMetadata()
 }
 
+func (s *LayeredStore) Hashtag() HashtagStore {
+	return s.DatabaseLayer.Hashtag()
+}
+
+func (s *LayeredStore) MentionAlias() MentionAliasStore {
+	return s.DatabaseLayer.MentionAlias()
+}
+
+func (s *LayeredStore) ChannelMemberExpiry() ChannelMemberExpiryStore {
+	return s.DatabaseLayer.ChannelMemberExpiry()
+}
+
+func (s *LayeredStore) PostStar() PostStarStore {
+	return s.DatabaseLayer.PostStar()
+}
+
+func (s *LayeredStore) ChannelReadStat() ChannelReadStatStore {
+	return s.DatabaseLayer.ChannelReadStat()
+}
+
+func (s *LayeredStore) UserDeactivationSchedule() UserDeactivationScheduleStore {
+	return s.DatabaseLayer.UserDeactivationSchedule()
+}
+
+func (s *LayeredStore) UserAttribute() UserAttributeStore {
+	return s.DatabaseLayer.UserAttribute()
+}
+
+func (s *LayeredStore) DailyStat() DailyStatStore {
+	return s.DatabaseLayer.DailyStat()
+}
+
+func (s *LayeredStore) SchemaMigration() SchemaMigrationStore {
+	return s.DatabaseLayer.SchemaMigration()
+}
+
+func (s *LayeredStore) ConfigAudit() ConfigAuditStore {
+	return s.DatabaseLayer.ConfigAudit()
+}
+
+func (s *LayeredStore) LicenseUsage() LicenseUsageStore {
+	return s.DatabaseLayer.LicenseUsage()
+}
+
+func (s *LayeredStore) PendingPost() PendingPostStore {
+	return s.DatabaseLayer.PendingPost()
+}
+
+func (s *LayeredStore) ContentFilter() ContentFilterStore {
+	return s.DatabaseLayer.ContentFilter()
+}
+
+func (s *LayeredStore) KeywordRule() KeywordRuleStore {
+	return s.DatabaseLayer.KeywordRule()
+}
+
+func (s *LayeredStore) PostReport() PostReportStore {
+	return s.DatabaseLayer.PostReport()
+}
+
+func (s *LayeredStore) RecurringPost() RecurringPostStore {
+	return s.DatabaseLayer.RecurringPost()
+}
+
+func (s *LayeredStore) UserAvailability() UserAvailabilityStore {
+	return s.DatabaseLayer.UserAvailability()
+}
+
+func (s *LayeredStore) Call() CallStore {
+	return s.DatabaseLayer.Call()
+}
+
+func (s *LayeredStore) MessageExportConsumer() MessageExportConsumerStore {
+	return s.DatabaseLayer.MessageExportConsumer()
+}
+
+func (s *LayeredStore) PostPurge() PostPurgeStore {
+	return s.DatabaseLayer.PostPurge()
+}
+
+func (s *LayeredStore) PublicPostLink() PublicPostLinkStore {
+	return s.DatabaseLayer.PublicPostLink()
+}
+
+func (s *LayeredStore) PendingEmoji() PendingEmojiStore {
+	return s.DatabaseLayer.PendingEmoji()
+}
+
+func (s *LayeredStore) EmojiAlias() EmojiAliasStore {
+	return s.DatabaseLayer.EmojiAlias()
+}
+
+func (s *LayeredStore) EphemeralPost() EphemeralPostStore {
+	return s.DatabaseLayer.EphemeralPost()
+}
+
+func (s *LayeredStore) LoginAttempt() LoginAttemptStore {
+	return s.DatabaseLayer.LoginAttempt()
+}
+
+func (s *LayeredStore) MfaRecoveryCode() MfaRecoveryCodeStore {
+	return s.DatabaseLayer.MfaRecoveryCode()
+}
+
+func (s *LayeredStore) IntegrationStat() IntegrationStatStore {
+	return s.DatabaseLayer.IntegrationStat()
+}
+
+func (s *LayeredStore) Todo() TodoStore {
+	return s.DatabaseLayer.Todo()
+}
+
+func (s *LayeredStore) Poll() PollStore {
+	return s.DatabaseLayer.Poll()
+}
+
+func (s *LayeredStore) Reminder() ReminderStore {
+	return s.DatabaseLayer.Reminder()
+}
+
+func (s *LayeredStore) ChannelMembershipRule() ChannelMembershipRuleStore {
+	return s.DatabaseLayer.ChannelMembershipRule()
+}
+
+func (s *LayeredStore) ChannelTeamBinding() ChannelTeamBindingStore {
+	return s.DatabaseLayer.ChannelTeamBinding()
+}
+
+func (s *LayeredStore) Announcement() AnnouncementStore {
+	return s.DatabaseLayer.Announcement()
+}
+
+func (s *LayeredStore) Onboarding() OnboardingStore {
+	return s.DatabaseLayer.Onboarding()
+}
+
+func (s *LayeredStore) PostPinEvent() PostPinEventStore {
+	return s.DatabaseLayer.PostPinEvent()
+}
+
 func (s *LayeredStore) MarkSystemRanUnitTests() {
 	s.DatabaseLayer.MarkSystemRanUnitTests()
 }
@@ -211,6 +352,10 @@ func (s *LayeredStore) TotalSearchDbConnections() int {
 	return s.DatabaseLayer.TotalSearchDbConnections()
 }
 
+func (s *LayeredStore) RecentSlowQueries() []*model.SlowQuery {
+	return s.DatabaseLayer.RecentSlowQueries()
+}
+
 func (s *LayeredStore) CheckIntegrity() <-chan IntegrityCheckResult {
 	return s.DatabaseLayer.CheckIntegrity()
 }
@@ -0,0 +1,32 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package store
+
+import (
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// RETRY_LAYER_MAX_TRIES is how many times the retry layer calls a store method failing with a repeatable error before
+// giving up.
+const RETRY_LAYER_MAX_TRIES = 3
+
+// repeatableErrorMessages identify the errors of the transactions the database aborted because they conflicted with
+// concurrent ones, and that succeed when run again. CockroachDB, which only runs serializable transactions, asks its
+// clients to restart them rather than blocking them.
+var repeatableErrorMessages = []string{
+	"restart transaction",
+	"could not serialize access",
+}
+
+func isRepeatableError(err *model.AppError) bool {
+	for _, message := range repeatableErrorMessages {
+		if strings.Contains(err.DetailedError, message) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,8205 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package store
+
+import (
+	"context"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type RetryLayer struct {
+	Store
+	AnnouncementStore             AnnouncementStore
+	AuditStore                    AuditStore
+	BotStore                      BotStore
+	CallStore                     CallStore
+	ChannelStore                  ChannelStore
+	ChannelMemberExpiryStore      ChannelMemberExpiryStore
+	ChannelMemberHistoryStore     ChannelMemberHistoryStore
+	ChannelMembershipRuleStore    ChannelMembershipRuleStore
+	ChannelReadStatStore          ChannelReadStatStore
+	ChannelTeamBindingStore       ChannelTeamBindingStore
+	ClusterDiscoveryStore         ClusterDiscoveryStore
+	CommandStore                  CommandStore
+	CommandWebhookStore           CommandWebhookStore
+	ComplianceStore               ComplianceStore
+	ConfigAuditStore              ConfigAuditStore
+	ContentFilterStore            ContentFilterStore
+	DailyStatStore                DailyStatStore
+	EmojiStore                    EmojiStore
+	EmojiAliasStore               EmojiAliasStore
+	EphemeralPostStore            EphemeralPostStore
+	FileInfoStore                 FileInfoStore
+	GroupStore                    GroupStore
+	HashtagStore                  HashtagStore
+	IntegrationStatStore          IntegrationStatStore
+	JobStore                      JobStore
+	KeywordRuleStore              KeywordRuleStore
+	LicenseStore                  LicenseStore
+	LicenseUsageStore             LicenseUsageStore
+	LinkMetadataStore             LinkMetadataStore
+	LoginAttemptStore             LoginAttemptStore
+	MentionAliasStore             MentionAliasStore
+	MessageExportConsumerStore    MessageExportConsumerStore
+	MfaRecoveryCodeStore          MfaRecoveryCodeStore
+	OAuthStore                    OAuthStore
+	OnboardingStore               OnboardingStore
+	PendingEmojiStore             PendingEmojiStore
+	PendingPostStore              PendingPostStore
+	PluginStore                   PluginStore
+	PollStore                     PollStore
+	PostStore                     PostStore
+	PostPinEventStore             PostPinEventStore
+	PostPurgeStore                PostPurgeStore
+	PostReportStore               PostReportStore
+	PostStarStore                 PostStarStore
+	PreferenceStore               PreferenceStore
+	PublicPostLinkStore           PublicPostLinkStore
+	ReactionStore                 ReactionStore
+	RecurringPostStore            RecurringPostStore
+	ReminderStore                 ReminderStore
+	RoleStore                     RoleStore
+	SchemaMigrationStore          SchemaMigrationStore
+	SchemeStore                   SchemeStore
+	SessionStore                  SessionStore
+	StatusStore                   StatusStore
+	SystemStore                   SystemStore
+	TeamStore                     TeamStore
+	TermsOfServiceStore           TermsOfServiceStore
+	TodoStore                     TodoStore
+	TokenStore                    TokenStore
+	UserStore                     UserStore
+	UserAccessTokenStore          UserAccessTokenStore
+	UserAttributeStore            UserAttributeStore
+	UserAvailabilityStore         UserAvailabilityStore
+	UserDeactivationScheduleStore UserDeactivationScheduleStore
+	UserTermsOfServiceStore       UserTermsOfServiceStore
+	WebhookStore                  WebhookStore
+}
+
+func (s *RetryLayer) Announcement() AnnouncementStore {
+	return s.AnnouncementStore
+}
+
+func (s *RetryLayer) Audit() AuditStore {
+	return s.AuditStore
+}
+
+func (s *RetryLayer) Bot() BotStore {
+	return s.BotStore
+}
+
+func (s *RetryLayer) Call() CallStore {
+	return s.CallStore
+}
+
+func (s *RetryLayer) Channel() ChannelStore {
+	return s.ChannelStore
+}
+
+func (s *RetryLayer) ChannelMemberExpiry() ChannelMemberExpiryStore {
+	return s.ChannelMemberExpiryStore
+}
+
+func (s *RetryLayer) ChannelMemberHistory() ChannelMemberHistoryStore {
+	return s.ChannelMemberHistoryStore
+}
+
+func (s *RetryLayer) ChannelMembershipRule() ChannelMembershipRuleStore {
+	return s.ChannelMembershipRuleStore
+}
+
+func (s *RetryLayer) ChannelReadStat() ChannelReadStatStore {
+	return s.ChannelReadStatStore
+}
+
+func (s *RetryLayer) ChannelTeamBinding() ChannelTeamBindingStore {
+	return s.ChannelTeamBindingStore
+}
+
+func (s *RetryLayer) ClusterDiscovery() ClusterDiscoveryStore {
+	return s.ClusterDiscoveryStore
+}
+
+func (s *RetryLayer) Command() CommandStore {
+	return s.CommandStore
+}
+
+func (s *RetryLayer) CommandWebhook() CommandWebhookStore {
+	return s.CommandWebhookStore
+}
+
+func (s *RetryLayer) Compliance() ComplianceStore {
+	return s.ComplianceStore
+}
+
+func (s *RetryLayer) ConfigAudit() ConfigAuditStore {
+	return s.ConfigAuditStore
+}
+
+func (s *RetryLayer) ContentFilter() ContentFilterStore {
+	return s.ContentFilterStore
+}
+
+func (s *RetryLayer) DailyStat() DailyStatStore {
+	return s.DailyStatStore
+}
+
+func (s *RetryLayer) Emoji() EmojiStore {
+	return s.EmojiStore
+}
+
+func (s *RetryLayer) EmojiAlias() EmojiAliasStore {
+	return s.EmojiAliasStore
+}
+
+func (s *RetryLayer) EphemeralPost() EphemeralPostStore {
+	return s.EphemeralPostStore
+}
+
+func (s *RetryLayer) FileInfo() FileInfoStore {
+	return s.FileInfoStore
+}
+
+func (s *RetryLayer) Group() GroupStore {
+	return s.GroupStore
+}
+
+func (s *RetryLayer) Hashtag() HashtagStore {
+	return s.HashtagStore
+}
+
+func (s *RetryLayer) IntegrationStat() IntegrationStatStore {
+	return s.IntegrationStatStore
+}
+
+func (s *RetryLayer) Job() JobStore {
+	return s.JobStore
+}
+
+func (s *RetryLayer) KeywordRule() KeywordRuleStore {
+	return s.KeywordRuleStore
+}
+
+func (s *RetryLayer) License() LicenseStore {
+	return s.LicenseStore
+}
+
+func (s *RetryLayer) LicenseUsage() LicenseUsageStore {
+	return s.LicenseUsageStore
+}
+
+func (s *RetryLayer) LinkMetadata() LinkMetadataStore {
+	return s.LinkMetadataStore
+}
+
+func (s *RetryLayer) LoginAttempt() LoginAttemptStore {
+	return s.LoginAttemptStore
+}
+
+func (s *RetryLayer) MentionAlias() MentionAliasStore {
+	return s.MentionAliasStore
+}
+
+func (s *RetryLayer) MessageExportConsumer() MessageExportConsumerStore {
+	return s.MessageExportConsumerStore
+}
+
+func (s *RetryLayer) MfaRecoveryCode() MfaRecoveryCodeStore {
+	return s.MfaRecoveryCodeStore
+}
+
+func (s *RetryLayer) OAuth() OAuthStore {
+	return s.OAuthStore
+}
+
+func (s *RetryLayer) Onboarding() OnboardingStore {
+	return s.OnboardingStore
+}
+
+func (s *RetryLayer) PendingEmoji() PendingEmojiStore {
+	return s.PendingEmojiStore
+}
+
+func (s *RetryLayer) PendingPost() PendingPostStore {
+	return s.PendingPostStore
+}
+
+func (s *RetryLayer) Plugin() PluginStore {
+	return s.PluginStore
+}
+
+func (s *RetryLayer) Poll() PollStore {
+	return s.PollStore
+}
+
+func (s *RetryLayer) Post() PostStore {
+	return s.PostStore
+}
+
+func (s *RetryLayer) PostPinEvent() PostPinEventStore {
+	return s.PostPinEventStore
+}
+
+func (s *RetryLayer) PostPurge() PostPurgeStore {
+	return s.PostPurgeStore
+}
+
+func (s *RetryLayer) PostReport() PostReportStore {
+	return s.PostReportStore
+}
+
+func (s *RetryLayer) PostStar() PostStarStore {
+	return s.PostStarStore
+}
+
+func (s *RetryLayer) Preference() PreferenceStore {
+	return s.PreferenceStore
+}
+
+func (s *RetryLayer) PublicPostLink() PublicPostLinkStore {
+	return s.PublicPostLinkStore
+}
+
+func (s *RetryLayer) Reaction() ReactionStore {
+	return s.ReactionStore
+}
+
+func (s *RetryLayer) RecurringPost() RecurringPostStore {
+	return s.RecurringPostStore
+}
+
+func (s *RetryLayer) Reminder() ReminderStore {
+	return s.ReminderStore
+}
+
+func (s *RetryLayer) Role() RoleStore {
+	return s.RoleStore
+}
+
+func (s *RetryLayer) SchemaMigration() SchemaMigrationStore {
+	return s.SchemaMigrationStore
+}
+
+func (s *RetryLayer) Scheme() SchemeStore {
+	return s.SchemeStore
+}
+
+func (s *RetryLayer) Session() SessionStore {
+	return s.SessionStore
+}
+
+func (s *RetryLayer) Status() StatusStore {
+	return s.StatusStore
+}
+
+func (s *RetryLayer) System() SystemStore {
+	return s.SystemStore
+}
+
+func (s *RetryLayer) Team() TeamStore {
+	return s.TeamStore
+}
+
+func (s *RetryLayer) TermsOfService() TermsOfServiceStore {
+	return s.TermsOfServiceStore
+}
+
+func (s *RetryLayer) Todo() TodoStore {
+	return s.TodoStore
+}
+
+func (s *RetryLayer) Token() TokenStore {
+	return s.TokenStore
+}
+
+func (s *RetryLayer) User() UserStore {
+	return s.UserStore
+}
+
+func (s *RetryLayer) UserAccessToken() UserAccessTokenStore {
+	return s.UserAccessTokenStore
+}
+
+func (s *RetryLayer) UserAttribute() UserAttributeStore {
+	return s.UserAttributeStore
+}
+
+func (s *RetryLayer) UserAvailability() UserAvailabilityStore {
+	return s.UserAvailabilityStore
+}
+
+func (s *RetryLayer) UserDeactivationSchedule() UserDeactivationScheduleStore {
+	return s.UserDeactivationScheduleStore
+}
+
+func (s *RetryLayer) UserTermsOfService() UserTermsOfServiceStore {
+	return s.UserTermsOfServiceStore
+}
+
+func (s *RetryLayer) Webhook() WebhookStore {
+	return s.WebhookStore
+}
+
+type RetryLayerAnnouncementStore struct {
+	AnnouncementStore
+	Root *RetryLayer
+}
+
+type RetryLayerAuditStore struct {
+	AuditStore
+	Root *RetryLayer
+}
+
+type RetryLayerBotStore struct {
+	BotStore
+	Root *RetryLayer
+}
+
+type RetryLayerCallStore struct {
+	CallStore
+	Root *RetryLayer
+}
+
+type RetryLayerChannelStore struct {
+	ChannelStore
+	Root *RetryLayer
+}
+
+type RetryLayerChannelMemberExpiryStore struct {
+	ChannelMemberExpiryStore
+	Root *RetryLayer
+}
+
+type RetryLayerChannelMemberHistoryStore struct {
+	ChannelMemberHistoryStore
+	Root *RetryLayer
+}
+
+type RetryLayerChannelMembershipRuleStore struct {
+	ChannelMembershipRuleStore
+	Root *RetryLayer
+}
+
+type RetryLayerChannelReadStatStore struct {
+	ChannelReadStatStore
+	Root *RetryLayer
+}
+
+type RetryLayerChannelTeamBindingStore struct {
+	ChannelTeamBindingStore
+	Root *RetryLayer
+}
+
+type RetryLayerClusterDiscoveryStore struct {
+	ClusterDiscoveryStore
+	Root *RetryLayer
+}
+
+type RetryLayerCommandStore struct {
+	CommandStore
+	Root *RetryLayer
+}
+
+type RetryLayerCommandWebhookStore struct {
+	CommandWebhookStore
+	Root *RetryLayer
+}
+
+type RetryLayerComplianceStore struct {
+	ComplianceStore
+	Root *RetryLayer
+}
+
+type RetryLayerConfigAuditStore struct {
+	ConfigAuditStore
+	Root *RetryLayer
+}
+
+type RetryLayerContentFilterStore struct {
+	ContentFilterStore
+	Root *RetryLayer
+}
+
+type RetryLayerDailyStatStore struct {
+	DailyStatStore
+	Root *RetryLayer
+}
+
+type RetryLayerEmojiStore struct {
+	EmojiStore
+	Root *RetryLayer
+}
+
+type RetryLayerEmojiAliasStore struct {
+	EmojiAliasStore
+	Root *RetryLayer
+}
+
+type RetryLayerEphemeralPostStore struct {
+	EphemeralPostStore
+	Root *RetryLayer
+}
+
+type RetryLayerFileInfoStore struct {
+	FileInfoStore
+	Root *RetryLayer
+}
+
+type RetryLayerGroupStore struct {
+	GroupStore
+	Root *RetryLayer
+}
+
+type RetryLayerHashtagStore struct {
+	HashtagStore
+	Root *RetryLayer
+}
+
+type RetryLayerIntegrationStatStore struct {
+	IntegrationStatStore
+	Root *RetryLayer
+}
+
+type RetryLayerJobStore struct {
+	JobStore
+	Root *RetryLayer
+}
+
+type RetryLayerKeywordRuleStore struct {
+	KeywordRuleStore
+	Root *RetryLayer
+}
+
+type RetryLayerLicenseStore struct {
+	LicenseStore
+	Root *RetryLayer
+}
+
+type RetryLayerLicenseUsageStore struct {
+	LicenseUsageStore
+	Root *RetryLayer
+}
+
+type RetryLayerLinkMetadataStore struct {
+	LinkMetadataStore
+	Root *RetryLayer
+}
+
+type RetryLayerLoginAttemptStore struct {
+	LoginAttemptStore
+	Root *RetryLayer
+}
+
+type RetryLayerMentionAliasStore struct {
+	MentionAliasStore
+	Root *RetryLayer
+}
+
+type RetryLayerMessageExportConsumerStore struct {
+	MessageExportConsumerStore
+	Root *RetryLayer
+}
+
+type RetryLayerMfaRecoveryCodeStore struct {
+	MfaRecoveryCodeStore
+	Root *RetryLayer
+}
+
+type RetryLayerOAuthStore struct {
+	OAuthStore
+	Root *RetryLayer
+}
+
+type RetryLayerOnboardingStore struct {
+	OnboardingStore
+	Root *RetryLayer
+}
+
+type RetryLayerPendingEmojiStore struct {
+	PendingEmojiStore
+	Root *RetryLayer
+}
+
+type RetryLayerPendingPostStore struct {
+	PendingPostStore
+	Root *RetryLayer
+}
+
+type RetryLayerPluginStore struct {
+	PluginStore
+	Root *RetryLayer
+}
+
+type RetryLayerPollStore struct {
+	PollStore
+	Root *RetryLayer
+}
+
+type RetryLayerPostStore struct {
+	PostStore
+	Root *RetryLayer
+}
+
+type RetryLayerPostPinEventStore struct {
+	PostPinEventStore
+	Root *RetryLayer
+}
+
+type RetryLayerPostPurgeStore struct {
+	PostPurgeStore
+	Root *RetryLayer
+}
+
+type RetryLayerPostReportStore struct {
+	PostReportStore
+	Root *RetryLayer
+}
+
+type RetryLayerPostStarStore struct {
+	PostStarStore
+	Root *RetryLayer
+}
+
+type RetryLayerPreferenceStore struct {
+	PreferenceStore
+	Root *RetryLayer
+}
+
+type RetryLayerPublicPostLinkStore struct {
+	PublicPostLinkStore
+	Root *RetryLayer
+}
+
+type RetryLayerReactionStore struct {
+	ReactionStore
+	Root *RetryLayer
+}
+
+type RetryLayerRecurringPostStore struct {
+	RecurringPostStore
+	Root *RetryLayer
+}
+
+type RetryLayerReminderStore struct {
+	ReminderStore
+	Root *RetryLayer
+}
+
+type RetryLayerRoleStore struct {
+	RoleStore
+	Root *RetryLayer
+}
+
+type RetryLayerSchemaMigrationStore struct {
+	SchemaMigrationStore
+	Root *RetryLayer
+}
+
+type RetryLayerSchemeStore struct {
+	SchemeStore
+	Root *RetryLayer
+}
+
+type RetryLayerSessionStore struct {
+	SessionStore
+	Root *RetryLayer
+}
+
+type RetryLayerStatusStore struct {
+	StatusStore
+	Root *RetryLayer
+}
+
+type RetryLayerSystemStore struct {
+	SystemStore
+	Root *RetryLayer
+}
+
+type RetryLayerTeamStore struct {
+	TeamStore
+	Root *RetryLayer
+}
+
+type RetryLayerTermsOfServiceStore struct {
+	TermsOfServiceStore
+	Root *RetryLayer
+}
+
+type RetryLayerTodoStore struct {
+	TodoStore
+	Root *RetryLayer
+}
+
+type RetryLayerTokenStore struct {
+	TokenStore
+	Root *RetryLayer
+}
+
+type RetryLayerUserStore struct {
+	UserStore
+	Root *RetryLayer
+}
+
+type RetryLayerUserAccessTokenStore struct {
+	UserAccessTokenStore
+	Root *RetryLayer
+}
+
+type RetryLayerUserAttributeStore struct {
+	UserAttributeStore
+	Root *RetryLayer
+}
+
+type RetryLayerUserAvailabilityStore struct {
+	UserAvailabilityStore
+	Root *RetryLayer
+}
+
+type RetryLayerUserDeactivationScheduleStore struct {
+	UserDeactivationScheduleStore
+	Root *RetryLayer
+}
+
+type RetryLayerUserTermsOfServiceStore struct {
+	UserTermsOfServiceStore
+	Root *RetryLayer
+}
+
+type RetryLayerWebhookStore struct {
+	WebhookStore
+	Root *RetryLayer
+}
+
+func (s *RetryLayerAnnouncementStore) Acknowledge(announcementId string, userId string, acknowledgeAt int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.AnnouncementStore.Acknowledge(announcementId, userId, acknowledgeAt)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) Delete(id string, deleteAt int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.AnnouncementStore.Delete(id, deleteAt)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) Get(id string) (*model.Announcement, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.AnnouncementStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) GetAll(offset int, limit int) ([]*model.Announcement, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.AnnouncementStore.GetAll(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) GetPendingForUser(userId string, now int64) ([]*model.Announcement, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.AnnouncementStore.GetPendingForUser(userId, now)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) GetRecipient(announcementId string, userId string) (*model.AnnouncementRecipient, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.AnnouncementStore.GetRecipient(announcementId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) GetRecipients(announcementId string, offset int, limit int) ([]*model.AnnouncementRecipient, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.AnnouncementStore.GetRecipients(announcementId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) GetReport(announcementId string) (*model.AnnouncementReport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.AnnouncementStore.GetReport(announcementId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) PermanentDeleteRecipientsByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.AnnouncementStore.PermanentDeleteRecipientsByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) Save(announcement *model.Announcement) (*model.Announcement, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.AnnouncementStore.Save(announcement)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) SaveRecipients(recipients []*model.AnnouncementRecipient) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.AnnouncementStore.SaveRecipients(recipients)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerAnnouncementStore) UpdateRecipientDelivery(announcementId string, userId string, status string, postId string, deliverAt int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.AnnouncementStore.UpdateRecipientDelivery(announcementId, userId, status, postId, deliverAt)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerAuditStore) Get(user_id string, offset int, limit int) (model.Audits, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.AuditStore.Get(user_id, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerAuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.AuditStore.PermanentDeleteBatch(endTime, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerAuditStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.AuditStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerAuditStore) Save(audit *model.Audit) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.AuditStore.Save(audit)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.BotStore.Get(userId, includeDeleted)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.BotStore.GetAll(options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerBotStore) GetAllForChannel(channelId string) ([]*model.Bot, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.BotStore.GetAllForChannel(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerBotStore) PermanentDelete(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.BotStore.PermanentDelete(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerBotStore) Save(bot *model.Bot) (*model.Bot, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.BotStore.Save(bot)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerBotStore) Update(bot *model.Bot) (*model.Bot, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.BotStore.Update(bot)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCallStore) Get(id string) (*model.Call, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CallStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCallStore) GetActiveForChannel(channelId string) ([]*model.Call, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CallStore.GetActiveForChannel(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCallStore) GetParticipants(callId string) ([]*model.CallParticipant, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CallStore.GetParticipants(callId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCallStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.CallStore.PermanentDeleteByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerCallStore) Save(call *model.Call) (*model.Call, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CallStore.Save(call)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCallStore) SaveParticipant(participant *model.CallParticipant) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.CallStore.SaveParticipant(participant)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerCallStore) Update(call *model.Call) (*model.Call, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CallStore.Update(call)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) AnalyticsDeletedTypeCount(teamId string, channelType string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.AnalyticsDeletedTypeCount(teamId, channelType)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) AnalyticsTypeCount(teamId string, channelType string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.AnalyticsTypeCount(teamId, channelType)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) AutocompleteInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.AutocompleteInTeam(teamId, term, includeDeleted)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) AutocompleteInTeamForSearch(teamId string, userId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.AutocompleteInTeamForSearch(teamId, userId, term, includeDeleted)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) ClearAllCustomRoleAssignments() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.ClearAllCustomRoleAssignments()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) ClearCaches() {
+	s.ChannelStore.ClearCaches()
+}
+
+func (s *RetryLayerChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.CreateDirectChannel(userId, otherUserId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) Delete(channelId string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.Delete(channelId, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) Get(id string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.Get(id, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetAll(teamId string) ([]*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetAll(teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetAllChannelMembersForUser(userId string, allowFromCache bool, includeDeleted bool) (map[string]string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetAllChannelMembersForUser(userId, allowFromCache, includeDeleted)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel(channelId string, allowFromCache bool) (map[string]model.StringMap, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetAllChannelMembersNotifyPropsForChannel(channelId, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetAllChannels(page int, perPage int, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetAllChannels(page, perPage, opts)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetAllChannelsCount(opts ChannelSearchOpts) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetAllChannelsCount(opts)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetAllChannelsForExportAfter(limit int, afterId string) ([]*model.ChannelForExport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetAllChannelsForExportAfter(limit, afterId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterId string) ([]*model.DirectChannelForExport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetAllDirectChannelsForExportAfter(limit, afterId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetByName(team_id string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetByName(team_id, name, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetByNameIncludeDeleted(team_id string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetByNameIncludeDeleted(team_id, name, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetByNames(team_id string, names []string, allowFromCache bool) ([]*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetByNames(team_id, names, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetChannelCounts(teamId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetChannelMembersForExport(userId string, teamId string) ([]*model.ChannelMemberForExport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetChannelMembersForExport(userId, teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetChannelMembersTimezones(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetChannelUnread(channelId string, userId string) (*model.ChannelUnread, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetChannelUnread(channelId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetChannels(teamId string, userId string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetChannels(teamId, userId, includeDeleted)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetChannelsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetChannelsBatchForIndexing(startTime, endTime, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetChannelsByIds(channelIds []string) ([]*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetChannelsByIds(channelIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetChannelsByScheme(schemeId string, offset int, limit int) (model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetChannelsByScheme(schemeId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetDeleted(team_id string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetDeleted(team_id, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetDeletedByName(team_id string, name string) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetDeletedByName(team_id, name)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetDirectMessagePartners(userId string, limit int) (map[string]int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetDirectMessagePartners(userId, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetForPost(postId string) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetForPost(postId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetFromMaster(id string) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetFromMaster(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetGuestCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetGuestCount(channelId, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetGuestCountFromCache(channelId string) int64 {
+	return s.ChannelStore.GetGuestCountFromCache(channelId)
+}
+
+func (s *RetryLayerChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetMember(channelId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetMemberCount(channelId, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetMemberCountFromCache(channelId string) int64 {
+	return s.ChannelStore.GetMemberCountFromCache(channelId)
+}
+
+func (s *RetryLayerChannelStore) GetMemberCountsByRole(channelId string) (*model.ChannelMemberCounts, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetMemberCountsByRole(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetMemberForPost(postId string, userId string) (*model.ChannelMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetMemberForPost(postId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetMembers(channelId string, offset int, limit int) (*model.ChannelMembers, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetMembers(channelId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetMembersByIds(channelId string, userIds []string) (*model.ChannelMembers, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetMembersByIds(channelId, userIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetMembersForUser(teamId string, userId string) (*model.ChannelMembers, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetMembersForUser(teamId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetMembersForUserWithPagination(teamId string, userId string, page int, perPage int) (*model.ChannelMembers, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetMembersForUserWithPagination(teamId, userId, page, perPage)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetMembersWithOptions(channelId string, offset int, limit int, options *model.ChannelMembersGetOptions) (*model.ChannelMembers, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetMembersWithOptions(channelId, offset, limit, options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetPinnedPostCountFromCache(channelId string) int64 {
+	return s.ChannelStore.GetPinnedPostCountFromCache(channelId)
+}
+
+func (s *RetryLayerChannelStore) GetPinnedPosts(channelId string) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetPinnedPosts(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetPublicChannelsByIdsForTeam(teamId, channelIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetPublicChannelsForTeam(teamId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetSharedChannelCounts(userId string, limit int) (map[string]int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetSharedChannelCounts(userId, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.GetTeamChannels(teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) IncrementMentionCount(channelId string, userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.IncrementMentionCount(channelId, userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) IncrementMsgCount(channelId string, userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.IncrementMsgCount(channelId, userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) InvalidateAllChannelMembersForUser(userId string) {
+	s.ChannelStore.InvalidateAllChannelMembersForUser(userId)
+}
+
+func (s *RetryLayerChannelStore) InvalidateCacheForChannelMembersNotifyProps(channelId string) {
+	s.ChannelStore.InvalidateCacheForChannelMembersNotifyProps(channelId)
+}
+
+func (s *RetryLayerChannelStore) InvalidateChannel(id string) {
+	s.ChannelStore.InvalidateChannel(id)
+}
+
+func (s *RetryLayerChannelStore) InvalidateChannelByName(teamId string, name string) {
+	s.ChannelStore.InvalidateChannelByName(teamId, name)
+}
+
+func (s *RetryLayerChannelStore) InvalidateGuestCount(channelId string) {
+	s.ChannelStore.InvalidateGuestCount(channelId)
+}
+
+func (s *RetryLayerChannelStore) InvalidateMemberCount(channelId string) {
+	s.ChannelStore.InvalidateMemberCount(channelId)
+}
+
+func (s *RetryLayerChannelStore) InvalidatePinnedPostCount(channelId string) {
+	s.ChannelStore.InvalidatePinnedPostCount(channelId)
+}
+
+func (s *RetryLayerChannelStore) IsUserInChannelUseCache(userId string, channelId string) bool {
+	return s.ChannelStore.IsUserInChannelUseCache(userId, channelId)
+}
+
+func (s *RetryLayerChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) MigratePublicChannels() error {
+	return s.ChannelStore.MigratePublicChannels()
+}
+
+func (s *RetryLayerChannelStore) PermanentDelete(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.PermanentDelete(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.PermanentDeleteByTeam(teamId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) PermanentDeleteMembersByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) PermanentDeleteMembersByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.PermanentDeleteMembersByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) RemoveMember(channelId string, userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.RemoveMember(channelId, userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) ResetAllChannelSchemes() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.ResetAllChannelSchemes()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) Restore(channelId string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.Restore(channelId, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.Save(channel, maxChannelsPerTeam)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.SaveMember(member)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) SearchAllChannels(term string, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.SearchAllChannels(term, opts)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) SearchForUserInTeam(userId string, teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.SearchForUserInTeam(userId, teamId, term, includeDeleted)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) SearchGroupChannels(userId string, term string) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.SearchGroupChannels(userId, term)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) SearchInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.SearchInTeam(teamId, term, includeDeleted)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) SearchMore(userId string, teamId string, term string) (*model.ChannelList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.SearchMore(userId, teamId, term)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) Update(channel *model.Channel) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.Update(channel)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId string) (map[string]int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.UpdateLastViewedAt(channelIds, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.UpdateMember(member)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelStore) UserBelongsToChannels(userId string, channelIds []string) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelStore.UserBelongsToChannels(userId, channelIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMemberExpiryStore) Delete(channelId string, userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelMemberExpiryStore.Delete(channelId, userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelMemberExpiryStore) Get(channelId string, userId string) (*model.ChannelMemberExpiry, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMemberExpiryStore.Get(channelId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMemberExpiryStore) GetExpired(before int64, limit int) ([]*model.ChannelMemberExpiry, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMemberExpiryStore.GetExpired(before, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMemberExpiryStore) GetForChannel(channelId string) ([]*model.ChannelMemberExpiry, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMemberExpiryStore.GetForChannel(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMemberExpiryStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelMemberExpiryStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelMemberExpiryStore) Save(expiry *model.ChannelMemberExpiry) (*model.ChannelMemberExpiry, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMemberExpiryStore.Save(expiry)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) Delete(id string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelMembershipRuleStore.Delete(id)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) DeleteOverride(channelId string, userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelMembershipRuleStore.DeleteOverride(channelId, userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) Get(id string) (*model.ChannelMembershipRule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMembershipRuleStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) GetChannelIds() ([]string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMembershipRuleStore.GetChannelIds()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) GetForChannel(channelId string) ([]*model.ChannelMembershipRule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMembershipRuleStore.GetForChannel(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) GetOverride(channelId string, userId string) (*model.ChannelMembershipOverride, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMembershipRuleStore.GetOverride(channelId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) GetOverridesForChannel(channelId string) ([]*model.ChannelMembershipOverride, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMembershipRuleStore.GetOverridesForChannel(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelMembershipRuleStore.PermanentDeleteByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) PermanentDeleteOverridesByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelMembershipRuleStore.PermanentDeleteOverridesByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) Save(rule *model.ChannelMembershipRule) (*model.ChannelMembershipRule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMembershipRuleStore.Save(rule)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelMembershipRuleStore) SaveOverride(override *model.ChannelMembershipOverride) (*model.ChannelMembershipOverride, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelMembershipRuleStore.SaveOverride(override)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelReadStatStore) Compute(since int64, until int64, minimumMembers int) ([]*model.ChannelReadStat, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelReadStatStore.Compute(since, until, minimumMembers)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelReadStatStore) Get(teamId string, channelId string, since int64, until int64, offset int, limit int) ([]*model.ChannelReadStat, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelReadStatStore.Get(teamId, channelId, since, until, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelReadStatStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelReadStatStore.PermanentDeleteByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelReadStatStore) Save(stat *model.ChannelReadStat) (*model.ChannelReadStat, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelReadStatStore.Save(stat)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelTeamBindingStore) Delete(channelId string, teamId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelTeamBindingStore.Delete(channelId, teamId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelTeamBindingStore) Get(channelId string, teamId string) (*model.ChannelTeamBinding, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelTeamBindingStore.Get(channelId, teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelTeamBindingStore) GetChannelByName(teamId string, name string, includeDeleted bool) (*model.Channel, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelTeamBindingStore.GetChannelByName(teamId, name, includeDeleted)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelTeamBindingStore) GetForChannel(channelId string) ([]*model.ChannelTeamBinding, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelTeamBindingStore.GetForChannel(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerChannelTeamBindingStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelTeamBindingStore.PermanentDeleteByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelTeamBindingStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ChannelTeamBindingStore.PermanentDeleteByTeam(teamId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerChannelTeamBindingStore) Save(binding *model.ChannelTeamBinding) (*model.ChannelTeamBinding, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ChannelTeamBindingStore.Save(binding)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerClusterDiscoveryStore) Cleanup() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ClusterDiscoveryStore.Cleanup()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ClusterDiscoveryStore.Delete(discovery)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ClusterDiscoveryStore.Exists(discovery)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ClusterDiscoveryStore.Save(discovery)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerCommandStore) AnalyticsCommandCount(teamId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CommandStore.AnalyticsCommandCount(teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCommandStore) Delete(commandId string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.CommandStore.Delete(commandId, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerCommandStore) Get(id string) (*model.Command, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CommandStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCommandStore) GetByTeam(teamId string) ([]*model.Command, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CommandStore.GetByTeam(teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCommandStore) GetByTrigger(teamId string, trigger string) (*model.Command, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CommandStore.GetByTrigger(teamId, trigger)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCommandStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.CommandStore.PermanentDeleteByTeam(teamId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerCommandStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.CommandStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerCommandStore) Save(webhook *model.Command) (*model.Command, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CommandStore.Save(webhook)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCommandStore) Update(hook *model.Command) (*model.Command, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CommandStore.Update(hook)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCommandWebhookStore) Cleanup() {
+	s.CommandWebhookStore.Cleanup()
+}
+
+func (s *RetryLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CommandWebhookStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.CommandWebhookStore.Save(webhook)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerCommandWebhookStore) TryUse(id string, limit int) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.CommandWebhookStore.TryUse(id, limit)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerComplianceStore) ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ComplianceStore.ComplianceExport(compliance)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerComplianceStore) Get(id string) (*model.Compliance, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ComplianceStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ComplianceStore.GetAll(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ComplianceStore.MessageExport(after, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerComplianceStore) MessageExportChanges(cursor model.MessageExportCursor, limit int) ([]*model.MessageExportChange, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ComplianceStore.MessageExportChanges(cursor, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ComplianceStore.Save(compliance)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ComplianceStore.Update(compliance)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerConfigAuditStore) Get(id string) (*model.ConfigAudit, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ConfigAuditStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerConfigAuditStore) GetAll(offset int, limit int) ([]*model.ConfigAudit, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ConfigAuditStore.GetAll(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerConfigAuditStore) Save(audit *model.ConfigAudit) (*model.ConfigAudit, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ConfigAuditStore.Save(audit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerContentFilterStore) Delete(id string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ContentFilterStore.Delete(id, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerContentFilterStore) Get(id string) (*model.ContentFilter, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ContentFilterStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerContentFilterStore) GetAll(offset int, limit int) ([]*model.ContentFilter, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ContentFilterStore.GetAll(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerContentFilterStore) GetForTeam(teamId string) ([]*model.ContentFilter, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ContentFilterStore.GetForTeam(teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerContentFilterStore) Save(filter *model.ContentFilter) (*model.ContentFilter, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ContentFilterStore.Save(filter)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerContentFilterStore) Update(filter *model.ContentFilter) (*model.ContentFilter, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ContentFilterStore.Update(filter)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerDailyStatStore) Compute(date string, since int64, until int64) ([]*model.DailyStat, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.DailyStatStore.Compute(date, since, until)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerDailyStatStore) Get(teamId string, channelId string, since string, until string) ([]*model.DailyStat, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.DailyStatStore.Get(teamId, channelId, since, until)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerDailyStatStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.DailyStatStore.PermanentDeleteByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerDailyStatStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.DailyStatStore.PermanentDeleteByTeam(teamId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerDailyStatStore) Save(stat *model.DailyStat) (*model.DailyStat, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.DailyStatStore.Save(stat)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiStore) CountByCreator(creatorId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiStore.CountByCreator(creatorId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiStore) Delete(emoji *model.Emoji, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.EmojiStore.Delete(emoji, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiStore.Get(id, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiStore.GetByName(name, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiStore.GetList(offset, limit, sort)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiStore.GetMultipleByName(names)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiStore) GetUsage(offset int, limit int) ([]*model.EmojiUsage, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiStore.GetUsage(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiStore.Save(emoji)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiStore.Search(name, prefixOnly, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiAliasStore) Delete(alias string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.EmojiAliasStore.Delete(alias)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerEmojiAliasStore) DeleteForEmoji(emojiName string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.EmojiAliasStore.DeleteForEmoji(emojiName)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerEmojiAliasStore) Get(alias string) (*model.EmojiAlias, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiAliasStore.Get(alias)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiAliasStore) GetAll() ([]*model.EmojiAlias, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiAliasStore.GetAll()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEmojiAliasStore) Save(alias *model.EmojiAlias) (*model.EmojiAlias, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EmojiAliasStore.Save(alias)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEphemeralPostStore) Delete(userId string, id string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.EphemeralPostStore.Delete(userId, id)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerEphemeralPostStore) DeleteExpired(now int64, limit int) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EphemeralPostStore.DeleteExpired(now, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEphemeralPostStore) GetForUser(userId string, since int64, limit int) ([]*model.EphemeralPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EphemeralPostStore.GetForUser(userId, since, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEphemeralPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.EphemeralPostStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerEphemeralPostStore) Save(ephemeralPost *model.EphemeralPost) (*model.EphemeralPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EphemeralPostStore.Save(ephemeralPost)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerEphemeralPostStore) Update(ephemeralPost *model.EphemeralPost) (*model.EphemeralPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.EphemeralPostStore.Update(ephemeralPost)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) ClearCaches() {
+	s.FileInfoStore.ClearCaches()
+}
+
+func (s *RetryLayerFileInfoStore) DeleteForPost(postId string) (string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.FileInfoStore.DeleteForPost(postId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) Get(id string) (*model.FileInfo, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.FileInfoStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.FileInfoStore.GetByPath(path)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) GetForPosts(postIds []string, readFromMaster bool) (map[string][]*model.FileInfo, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.FileInfoStore.GetForPosts(postIds, readFromMaster)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.FileInfoStore.GetForUser(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string) {
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postId)
+}
+
+func (s *RetryLayerFileInfoStore) PermanentDelete(fileId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.FileInfoStore.PermanentDelete(fileId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.FileInfoStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.FileInfoStore.Save(info)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerFileInfoStore) SetVerdict(fileId string, verdict string, reason string, verdictAt int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.FileInfoStore.SetVerdict(fileId, verdict, reason, verdictAt)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) ChannelMembersToAdd(since int64) ([]*model.UserChannelIDPair, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.ChannelMembersToAdd(since)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) ChannelMembersToRemove() ([]*model.ChannelMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.ChannelMembersToRemove()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) CountGroupsByChannel(channelId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.CountGroupsByChannel(channelId, opts)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) CountGroupsByTeam(teamId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.CountGroupsByTeam(teamId, opts)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) Create(group *model.Group) (*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.Create(group)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.CreateGroupSyncable(groupSyncable)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) Delete(groupID string) (*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.Delete(groupID)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.DeleteMember(groupID, userID)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) Get(groupID string) (*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.Get(groupID)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetAllBySource(groupSource)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetByIDs(groupIDs)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetByRemoteID(remoteID, groupSource)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetByUser(userId string) ([]*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetByUser(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetGroups(page, perPage, opts)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetGroupsByChannel(channelId string, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetGroupsByChannel(channelId, opts)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetGroupsByTeam(teamId, opts)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetMemberCount(groupID string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetMemberCount(groupID)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetMemberUsers(groupID)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) TeamMembersToAdd(since int64) ([]*model.UserTeamIDPair, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.TeamMembersToAdd(since)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) TeamMembersToRemove() ([]*model.TeamMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.TeamMembersToRemove()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) Update(group *model.Group) (*model.Group, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.Update(group)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.UpdateGroupSyncable(groupSyncable)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.GroupStore.UpsertMember(groupID, userID)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerHashtagStore) DeleteForPost(postId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.HashtagStore.DeleteForPost(postId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerHashtagStore) GetPostsForUser(userId string, teamId string, hashtag string, offset int, limit int) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.HashtagStore.GetPostsForUser(userId, teamId, hashtag, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerHashtagStore) GetTrending(teamId string, since int64, limit int) ([]*model.HashtagCount, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.HashtagStore.GetTrending(teamId, since, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerHashtagStore) SaveForPost(postId string, hashtags []*model.PostHashtag) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.HashtagStore.SaveForPost(postId, hashtags)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerIntegrationStatStore) GetDaily(integrationType string, integrationId string, since string, until string) ([]*model.IntegrationStat, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.IntegrationStatStore.GetDaily(integrationType, integrationId, since, until)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerIntegrationStatStore) GetTotals(integrationType string, since string, until string) ([]*model.IntegrationStat, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.IntegrationStatStore.GetTotals(integrationType, since, until)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerIntegrationStatStore) Increment(stat *model.IntegrationStat) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.IntegrationStatStore.Increment(stat)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerIntegrationStatStore) PermanentDeleteBefore(date string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.IntegrationStatStore.PermanentDeleteBefore(date)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) Delete(id string) (string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.Delete(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) Get(id string) (*model.Job, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) GetAllByStatus(status string) ([]*model.Job, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.GetAllByStatus(status)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) GetAllByType(jobType string) ([]*model.Job, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.GetAllByType(jobType)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.GetAllPage(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.GetCountByStatusAndType(status, jobType)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) Save(job *model.Job) (*model.Job, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.Save(job)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) UpdateLastActivityAt(ids []string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.JobStore.UpdateLastActivityAt(ids)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.UpdateOptimistically(job, currentStatus)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) UpdateStatus(id string, status string) (*model.Job, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.UpdateStatus(id, status)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerKeywordRuleStore) Delete(id string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.KeywordRuleStore.Delete(id, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerKeywordRuleStore) Get(id string) (*model.KeywordRule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.KeywordRuleStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerKeywordRuleStore) GetAll(offset int, limit int) ([]*model.KeywordRule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.KeywordRuleStore.GetAll(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerKeywordRuleStore) GetForTeam(teamId string) ([]*model.KeywordRule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.KeywordRuleStore.GetForTeam(teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerKeywordRuleStore) Save(rule *model.KeywordRule) (*model.KeywordRule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.KeywordRuleStore.Save(rule)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerKeywordRuleStore) Update(rule *model.KeywordRule) (*model.KeywordRule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.KeywordRuleStore.Update(rule)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLicenseStore) Get(id string) (*model.LicenseRecord, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LicenseStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LicenseStore.Save(license)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLicenseUsageStore) Get(date string) (*model.LicenseUsage, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LicenseUsageStore.Get(date)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLicenseUsageStore) GetRange(since string, until string) ([]*model.LicenseUsage, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LicenseUsageStore.GetRange(since, until)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLicenseUsageStore) Save(usage *model.LicenseUsage) (*model.LicenseUsage, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LicenseUsageStore.Save(usage)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LinkMetadataStore.Get(url, timestamp)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LinkMetadataStore.Save(linkMetadata)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLoginAttemptStore) GetForUser(userId string, offset int, limit int) ([]*model.LoginAttempt, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LoginAttemptStore.GetForUser(userId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLoginAttemptStore) GetLatestFailure(userId string) (*model.LoginAttempt, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LoginAttemptStore.GetLatestFailure(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLoginAttemptStore) GetSuccessfulForUser(userId string, limit int) ([]*model.LoginAttempt, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LoginAttemptStore.GetSuccessfulForUser(userId, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLoginAttemptStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LoginAttemptStore.PermanentDeleteBatch(endTime, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerLoginAttemptStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.LoginAttemptStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerLoginAttemptStore) Save(attempt *model.LoginAttempt) (*model.LoginAttempt, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.LoginAttemptStore.Save(attempt)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMentionAliasStore) Delete(id string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.MentionAliasStore.Delete(id, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerMentionAliasStore) Get(id string) (*model.MentionAlias, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MentionAliasStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMentionAliasStore) GetAll(offset int, limit int) ([]*model.MentionAlias, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MentionAliasStore.GetAll(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMentionAliasStore) GetByName(name string) ([]*model.MentionAlias, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MentionAliasStore.GetByName(name)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMentionAliasStore) GetForTeam(teamId string) ([]*model.MentionAlias, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MentionAliasStore.GetForTeam(teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMentionAliasStore) Save(alias *model.MentionAlias) (*model.MentionAlias, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MentionAliasStore.Save(alias)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMentionAliasStore) Update(alias *model.MentionAlias) (*model.MentionAlias, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MentionAliasStore.Update(alias)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMessageExportConsumerStore) Delete(id string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.MessageExportConsumerStore.Delete(id)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerMessageExportConsumerStore) Get(id string) (*model.MessageExportConsumer, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MessageExportConsumerStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMessageExportConsumerStore) GetAll() ([]*model.MessageExportConsumer, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MessageExportConsumerStore.GetAll()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMessageExportConsumerStore) Save(consumer *model.MessageExportConsumer) (*model.MessageExportConsumer, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MessageExportConsumerStore.Save(consumer)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMessageExportConsumerStore) Update(consumer *model.MessageExportConsumer) (*model.MessageExportConsumer, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MessageExportConsumerStore.Update(consumer)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMfaRecoveryCodeStore) CountUnused(userId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MfaRecoveryCodeStore.CountUnused(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerMfaRecoveryCodeStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.MfaRecoveryCodeStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerMfaRecoveryCodeStore) SaveForUser(userId string, codes []*model.MfaRecoveryCode) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.MfaRecoveryCodeStore.SaveForUser(userId, codes)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerMfaRecoveryCodeStore) Use(userId string, codeHash string) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.MfaRecoveryCodeStore.Use(userId, codeHash)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) DeleteApp(id string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.OAuthStore.DeleteApp(id)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) GetAccessData(token string) (*model.AccessData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.GetAccessData(token)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) GetAccessDataByPreviousRefreshToken(token string) (*model.AccessData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.GetAccessDataByPreviousRefreshToken(token)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.GetAccessDataByRefreshToken(token)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId string) ([]*model.AccessData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.GetAccessDataByUserForApp(userId, clientId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) GetApp(id string) (*model.OAuthApp, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.GetApp(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) GetAppByUser(userId string, offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.GetAppByUser(userId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.GetApps(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) GetAuthData(code string) (*model.AuthData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.GetAuthData(code)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.GetAuthorizedApps(userId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) GetPreviousAccessData(userId string, clientId string) (*model.AccessData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.GetPreviousAccessData(userId, clientId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) RemoveAccessData(token string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.OAuthStore.RemoveAccessData(token)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) RemoveAllAccessData() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.OAuthStore.RemoveAllAccessData()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) RemoveAuthData(code string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.OAuthStore.RemoveAuthData(code)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.SaveAccessData(accessData)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.SaveApp(app)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.SaveAuthData(authData)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.UpdateAccessData(accessData)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OAuthStore.UpdateApp(app)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOnboardingStore) DeleteStep(id string, deleteAt int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.OnboardingStore.DeleteStep(id, deleteAt)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerOnboardingStore) GetCompletionsForUser(userId string) ([]*model.OnboardingStepCompletion, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OnboardingStore.GetCompletionsForUser(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOnboardingStore) GetStep(id string) (*model.OnboardingStep, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OnboardingStore.GetStep(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOnboardingStore) GetSteps() ([]*model.OnboardingStep, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OnboardingStore.GetSteps()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOnboardingStore) PermanentDeleteCompletionsByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.OnboardingStore.PermanentDeleteCompletionsByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerOnboardingStore) SaveCompletion(completion *model.OnboardingStepCompletion) (*model.OnboardingStepCompletion, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OnboardingStore.SaveCompletion(completion)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOnboardingStore) SaveStep(step *model.OnboardingStep) (*model.OnboardingStep, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OnboardingStore.SaveStep(step)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerOnboardingStore) UpdateStep(step *model.OnboardingStep) (*model.OnboardingStep, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.OnboardingStore.UpdateStep(step)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPendingEmojiStore) CountByCreator(creatorId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PendingEmojiStore.CountByCreator(creatorId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPendingEmojiStore) Delete(id string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PendingEmojiStore.Delete(id)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPendingEmojiStore) Get(id string) (*model.PendingEmoji, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PendingEmojiStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPendingEmojiStore) GetByName(name string) (*model.PendingEmoji, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PendingEmojiStore.GetByName(name)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPendingEmojiStore) GetList(offset int, limit int) ([]*model.PendingEmoji, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PendingEmojiStore.GetList(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPendingEmojiStore) Save(emoji *model.PendingEmoji) (*model.PendingEmoji, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PendingEmojiStore.Save(emoji)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPendingPostStore) Delete(id string) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PendingPostStore.Delete(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPendingPostStore) Get(id string) (*model.PendingPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PendingPostStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPendingPostStore) GetCountForChannel(channelId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PendingPostStore.GetCountForChannel(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPendingPostStore) GetForChannel(channelId string, offset int, limit int) ([]*model.PendingPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PendingPostStore.GetForChannel(channelId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPendingPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PendingPostStore.PermanentDeleteByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPendingPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PendingPostStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPendingPostStore) Save(pendingPost *model.PendingPost) (*model.PendingPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PendingPostStore.Save(pendingPost)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PluginStore.CompareAndSet(keyVal, oldValue)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPluginStore) Delete(pluginId string, key string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PluginStore.Delete(pluginId, key)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPluginStore) DeleteAllExpired() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PluginStore.DeleteAllExpired()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPluginStore) DeleteAllForPlugin(PluginId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PluginStore.DeleteAllForPlugin(PluginId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPluginStore) Get(pluginId string, key string) (*model.PluginKeyValue, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PluginStore.Get(pluginId, key)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPluginStore) List(pluginId string, page int, perPage int) ([]string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PluginStore.List(pluginId, page, perPage)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PluginStore.SaveOrUpdate(keyVal)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPollStore) Get(id string) (*model.Poll, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PollStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPollStore) GetVotes(pollId string) ([]*model.PollVote, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PollStore.GetVotes(pollId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPollStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PollStore.PermanentDeleteByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPollStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PollStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPollStore) Save(poll *model.Poll) (*model.Poll, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PollStore.Save(poll)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPollStore) SaveVote(vote *model.PollVote) (*model.PollVote, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PollStore.SaveVote(vote)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPollStore) Update(poll *model.Poll) (*model.Poll, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PollStore.Update(poll)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.AnalyticsPostCountsByDay(options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) ArchiveBatch(before int64, limit int) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.ArchiveBatch(before, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) ClearCaches() {
+	s.PostStore.ClearCaches()
+}
+
+func (s *RetryLayerPostStore) Delete(postId string, time int64, deleteByID string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PostStore.Delete(postId, time, deleteByID)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) Get(id string) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetByUserAfter(userId string, afterId string, limit int) ([]*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetByUserAfter(userId, afterId, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
+	return s.PostStore.GetEtag(channelId, allowFromCache)
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetFlaggedPosts(userId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetForChannelAfter(channelId string, afterCreateAt int64, afterId string, endTime int64, limit int) ([]*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetForChannelAfter(channelId, afterCreateAt, afterId, endTime, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetForPurge(scope string, targetId string, limit int) ([]*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetForPurge(scope, targetId, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetMaxPostSize() int {
+	return s.PostStore.GetMaxPostSize()
+}
+
+func (s *RetryLayerPostStore) GetOldest() (*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetOldest()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetParentsForExportAfter(limit, afterId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetPostAfterTime(channelId, time)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetPostIdAfterTime(channelId, time)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetPostIdBeforeTime(channelId, time)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetPosts(channelId string, offset int, limit int, allowFromCache bool) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetPosts(channelId, offset, limit, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetPostsAfter(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetPostsAfter(channelId, postId, numPosts, offset)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetPostsBefore(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetPostsBefore(channelId, postId, numPosts, offset)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetPostsByIds(postIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetPostsCreatedAt(channelId, time)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetPostsSince(channelId string, time int64, allowFromCache bool) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetPostsSince(channelId, time, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetRepliesForExport(parentId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetSingle(id string) (*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetSingle(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetSingleFromMaster(id string) (*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetSingleFromMaster(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) GetThreadParticipantCounts(userId string, since int64, limit int) (map[string]int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.GetThreadParticipantCounts(userId, since, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
+	s.PostStore.InvalidateLastPostTimeCache(channelId)
+}
+
+func (s *RetryLayerPostStore) Overwrite(post *model.Post) (*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.Overwrite(post)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) OverwriteProps(post *model.Post) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PostStore.OverwriteProps(post)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.PermanentDeleteBatch(endTime, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PostStore.PermanentDeleteByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PostStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) PermanentDeleteForPurge(postIds []string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PostStore.PermanentDeleteForPurge(postIds)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.Save(post)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) Search(ctx context.Context, teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.Search(ctx, teamId, userId, params)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStore.Update(newPost, oldPost)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostPinEventStore) GetForChannel(channelId string, startTime int64, endTime int64) ([]*model.PostPinEvent, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostPinEventStore.GetForChannel(channelId, startTime, endTime)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostPinEventStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PostPinEventStore.PermanentDeleteByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPostPinEventStore) Save(event *model.PostPinEvent) (*model.PostPinEvent, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostPinEventStore.Save(event)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostPurgeStore) Claim(id string) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostPurgeStore.Claim(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostPurgeStore) Get(id string) (*model.PostPurge, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostPurgeStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostPurgeStore) GetAll(offset int, limit int) ([]*model.PostPurge, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostPurgeStore.GetAll(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostPurgeStore) GetDue(before int64, limit int) ([]*model.PostPurge, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostPurgeStore.GetDue(before, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostPurgeStore) ResetStale(staleBefore int64) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostPurgeStore.ResetStale(staleBefore)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostPurgeStore) Save(purge *model.PostPurge) (*model.PostPurge, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostPurgeStore.Save(purge)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostPurgeStore) Update(purge *model.PostPurge) (*model.PostPurge, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostPurgeStore.Update(purge)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostReportStore) Get(id string) (*model.PostReport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostReportStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostReportStore) GetAll(state string, offset int, limit int) ([]*model.PostReport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostReportStore.GetAll(state, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostReportStore) GetEvents(reportId string) ([]*model.PostReportEvent, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostReportStore.GetEvents(reportId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostReportStore) GetOpenForPostAndReporter(postId string, reporterId string) (*model.PostReport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostReportStore.GetOpenForPostAndReporter(postId, reporterId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostReportStore) Save(report *model.PostReport) (*model.PostReport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostReportStore.Save(report)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostReportStore) SaveEvent(event *model.PostReportEvent) (*model.PostReportEvent, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostReportStore.SaveEvent(event)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostReportStore) Update(report *model.PostReport, previousState string) (*model.PostReport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostReportStore.Update(report, previousState)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStarStore) Delete(postId string, userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PostStarStore.Delete(postId, userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPostStarStore) Get(postId string, userId string) (*model.PostStar, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStarStore.Get(postId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStarStore) GetCountForPost(postId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStarStore.GetCountForPost(postId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStarStore) GetCountsForPosts(postIds []string) ([]*model.PostStarCount, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStarStore.GetCountsForPosts(postIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPostStarStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PostStarStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPostStarStore) Save(star *model.PostStar) (*model.PostStar, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PostStarStore.Save(star)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PreferenceStore.CleanupFlagsBatch(limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) Delete(userId string, category string, name string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PreferenceStore.Delete(userId, category, name)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) DeleteCategory(userId string, category string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PreferenceStore.DeleteCategory(userId, category)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) DeleteCategoryAndName(category string, name string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PreferenceStore.DeleteCategoryAndName(category, name)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) Get(userId string, category string, name string) (*model.Preference, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PreferenceStore.Get(userId, category, name)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) GetAll(userId string) (model.Preferences, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PreferenceStore.GetAll(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) GetCategory(userId string, category string) (model.Preferences, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PreferenceStore.GetCategory(userId, category)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PreferenceStore.GetCategoryAndName(category, name)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) GetNamespaces(userId string, categories []string) (map[string]*model.PreferenceNamespace, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PreferenceStore.GetNamespaces(userId, categories)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) GetVersions(userId string) (map[string]int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PreferenceStore.GetVersions(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) Patch(userId string, patch model.PreferencesPatch) ([]*model.PreferenceNamespaceDiff, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PreferenceStore.Patch(userId, patch)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PreferenceStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPreferenceStore) Save(preferences *model.Preferences) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PreferenceStore.Save(preferences)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPublicPostLinkStore) Get(id string) (*model.PublicPostLink, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PublicPostLinkStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPublicPostLinkStore) GetForPost(postId string) ([]*model.PublicPostLink, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PublicPostLinkStore.GetForPost(postId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerPublicPostLinkStore) Revoke(id string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.PublicPostLinkStore.Revoke(id, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerPublicPostLinkStore) Save(link *model.PublicPostLink) (*model.PublicPostLink, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.PublicPostLinkStore.Save(link)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ReactionStore.BulkGetForPosts(postIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ReactionStore.Delete(reaction)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerReactionStore) DeleteAllWithEmojiName(emojiName string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ReactionStore.GetForPost(postId, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ReactionStore.Save(reaction)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRecurringPostStore) Delete(id string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.RecurringPostStore.Delete(id)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerRecurringPostStore) Get(id string) (*model.RecurringPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RecurringPostStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRecurringPostStore) GetDue(now int64, limit int) ([]*model.RecurringPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RecurringPostStore.GetDue(now, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRecurringPostStore) GetForChannel(channelId string) ([]*model.RecurringPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RecurringPostStore.GetForChannel(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRecurringPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.RecurringPostStore.PermanentDeleteByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerRecurringPostStore) Save(recurringPost *model.RecurringPost) (*model.RecurringPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RecurringPostStore.Save(recurringPost)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRecurringPostStore) Update(recurringPost *model.RecurringPost) (*model.RecurringPost, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RecurringPostStore.Update(recurringPost)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerReminderStore) Delete(id string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ReminderStore.Delete(id)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerReminderStore) Get(id string) (*model.Reminder, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ReminderStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerReminderStore) GetDue(now int64, limit int) ([]*model.Reminder, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ReminderStore.GetDue(now, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerReminderStore) GetPendingForUser(userId string) ([]*model.Reminder, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ReminderStore.GetPendingForUser(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerReminderStore) MarkSent(id string, sentAt int64) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ReminderStore.MarkSent(id, sentAt)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerReminderStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.ReminderStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerReminderStore) Save(reminder *model.Reminder) (*model.Reminder, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.ReminderStore.Save(reminder)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRoleStore) Delete(roldId string) (*model.Role, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RoleStore.Delete(roldId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRoleStore) Get(roleId string) (*model.Role, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RoleStore.Get(roleId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RoleStore.GetAll()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRoleStore) GetByName(name string) (*model.Role, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RoleStore.GetByName(name)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RoleStore.GetByNames(names)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerRoleStore) PermanentDeleteAll() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.RoleStore.PermanentDeleteAll()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.RoleStore.Save(role)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSchemaMigrationStore) GetAll() ([]*model.SchemaMigration, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SchemaMigrationStore.GetAll()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSchemaMigrationStore) RunBackfillBatch(version int, batchSize int) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SchemaMigrationStore.RunBackfillBatch(version, batchSize)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSchemeStore) Delete(schemeId string) (*model.Scheme, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SchemeStore.Delete(schemeId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSchemeStore) Get(schemeId string) (*model.Scheme, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SchemeStore.Get(schemeId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SchemeStore.GetAllPage(scope, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SchemeStore.GetByName(schemeName)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSchemeStore) PermanentDeleteAll() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.SchemeStore.PermanentDeleteAll()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SchemeStore.Save(scheme)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) AnalyticsSessionCount() (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SessionStore.AnalyticsSessionCount()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
+	s.SessionStore.Cleanup(expiryTime, batchSize)
+}
+
+func (s *RetryLayerSessionStore) Get(sessionIdOrToken string) (*model.Session, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SessionStore.Get(sessionIdOrToken)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) GetSessions(userId string) ([]*model.Session, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SessionStore.GetSessions(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*model.Session, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SessionStore.GetSessionsWithActiveDeviceIds(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.SessionStore.PermanentDeleteSessionsByUser(teamId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) Remove(sessionIdOrToken string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.SessionStore.Remove(sessionIdOrToken)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) RemoveAllSessions() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.SessionStore.RemoveAllSessions()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) Save(session *model.Session) (*model.Session, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SessionStore.Save(session)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SessionStore.UpdateDeviceId(id, deviceId, expiresAt)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) UpdateLastActivityAt(sessionId string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.SessionStore.UpdateLastActivityAt(sessionId, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) UpdateProps(session *model.Session) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.SessionStore.UpdateProps(session)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerSessionStore) UpdateRoles(userId string, roles string) (string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SessionStore.UpdateRoles(userId, roles)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerStatusStore) Get(userId string) (*model.Status, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.StatusStore.Get(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.StatusStore.GetByIds(userIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerStatusStore) GetTotalActiveUsersCount() (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.StatusStore.GetTotalActiveUsersCount()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerStatusStore) ResetAll() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.StatusStore.ResetAll()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerStatusStore) SaveOrUpdate(status *model.Status) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.StatusStore.SaveOrUpdate(status)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.StatusStore.UpdateLastActivityAt(userId, lastActivityAt)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerSystemStore) AcquireLease(lease *model.LeaderLease) (*model.LeaderLease, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SystemStore.AcquireLease(lease)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSystemStore) Get() (model.StringMap, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SystemStore.Get()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSystemStore) GetByName(name string) (*model.System, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SystemStore.GetByName(name)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSystemStore) PermanentDeleteByName(name string) (*model.System, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SystemStore.PermanentDeleteByName(name)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSystemStore) ReleaseLease(name string, holderId string) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.SystemStore.ReleaseLease(name, holderId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerSystemStore) Save(system *model.System) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.SystemStore.Save(system)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerSystemStore) SaveOrUpdate(system *model.System) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.SystemStore.SaveOrUpdate(system)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerSystemStore) Update(system *model.System) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.SystemStore.Update(system)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) AnalyticsPrivateTeamCount() (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.AnalyticsPrivateTeamCount()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) AnalyticsPublicTeamCount() (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.AnalyticsPublicTeamCount()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) AnalyticsTeamCount() (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.AnalyticsTeamCount()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) ClearAllCustomRoleAssignments() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TeamStore.ClearAllCustomRoleAssignments()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) ClearCaches() {
+	s.TeamStore.ClearCaches()
+}
+
+func (s *RetryLayerTeamStore) Get(id string) (*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetActiveMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetActiveMemberCount(teamId, restrictions)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetAll() ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetAll()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.TeamForExport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetAllForExportAfter(limit, afterId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetAllPage(offset int, limit int) ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetAllPage(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetAllPrivateTeamListing()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetAllPrivateTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetAllPrivateTeamPageListing(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetAllPublicTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetAllPublicTeamPageListing(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetAllTeamListing() ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetAllTeamListing()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetAllTeamPageListing(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetByInviteId(inviteId string) (*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetByInviteId(inviteId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetByName(name string) (*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetByName(name)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId string, userId string) ([]*model.ChannelUnread, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetChannelUnreadsForTeam(teamId string, userId string) ([]*model.ChannelUnread, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetChannelUnreadsForTeam(teamId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetMember(teamId string, userId string) (*model.TeamMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetMember(teamId, userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetMembers(teamId string, offset int, limit int, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetMembers(teamId, offset, limit, restrictions)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetMembersByIds(teamId, userIds, restrictions)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMemberForExport, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetTeamMembersForExport(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetTeamsByScheme(schemeId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetTeamsByUserId(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetTeamsForUser(userId string) ([]*model.TeamMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetTeamsForUser(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetTeamsForUserWithPagination(userId string, page int, perPage int) ([]*model.TeamMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetTeamsForUserWithPagination(userId, page, perPage)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetTotalMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetTotalMemberCount(teamId, restrictions)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) GetUserTeamIds(userId string, allowFromCache bool) ([]string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.GetUserTeamIds(userId, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) InvalidateAllTeamIdsForUser(userId string) {
+	s.TeamStore.InvalidateAllTeamIdsForUser(userId)
+}
+
+func (s *RetryLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.MigrateTeamMembers(fromTeamId, fromUserId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) PermanentDelete(teamId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TeamStore.PermanentDelete(teamId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) RemoveAllMembersByTeam(teamId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TeamStore.RemoveAllMembersByTeam(teamId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) RemoveAllMembersByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TeamStore.RemoveAllMembersByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) RemoveMember(teamId string, userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TeamStore.RemoveMember(teamId, userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) ResetAllTeamSchemes() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TeamStore.ResetAllTeamSchemes()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) Save(team *model.Team) (*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.Save(team)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.SaveMember(member, maxUsersPerTeam)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) SearchAll(term string) ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.SearchAll(term)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) SearchOpen(term string) ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.SearchOpen(term)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) SearchPrivate(term string) ([]*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.SearchPrivate(term)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) Update(team *model.Team) (*model.Team, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.Update(team)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TeamStore.UpdateLastTeamIconUpdate(teamId, curTime)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.UpdateMember(member)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTeamStore) UserBelongsToTeams(userId string, teamIds []string) (bool, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TeamStore.UserBelongsToTeams(userId, teamIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TermsOfServiceStore.Get(id, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTermsOfServiceStore) GetAll() ([]*model.TermsOfService, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TermsOfServiceStore.GetAll()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TermsOfServiceStore.GetLatest(allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TermsOfServiceStore.Save(termsOfService)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTermsOfServiceStore) UpdateReacceptAt(id string, reacceptAt int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TermsOfServiceStore.UpdateReacceptAt(id, reacceptAt)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTodoStore) Delete(id string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TodoStore.Delete(id)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTodoStore) Get(id string) (*model.Todo, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TodoStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTodoStore) GetForUser(userId string, includeCompleted bool) ([]*model.Todo, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TodoStore.GetForUser(userId, includeCompleted)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTodoStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TodoStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTodoStore) Save(todo *model.Todo) (*model.Todo, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TodoStore.Save(todo)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTodoStore) Update(todo *model.Todo) (*model.Todo, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TodoStore.Update(todo)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTokenStore) Cleanup() {
+	s.TokenStore.Cleanup()
+}
+
+func (s *RetryLayerTokenStore) Delete(token string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TokenStore.Delete(token)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTokenStore) GetByToken(token string) (*model.Token, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.TokenStore.GetByToken(token)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerTokenStore) RemoveAllTokensByType(tokenType string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TokenStore.RemoveAllTokensByType(tokenType)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerTokenStore) Save(recovery *model.Token) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.TokenStore.Save(recovery)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) AnalyticsActiveCount(time int64, options model.UserCountOptions) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.AnalyticsActiveCount(time, options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.AnalyticsGetInactiveUsersCount()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) AnalyticsGetMfaAdoption() (*model.MfaAdoption, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.AnalyticsGetMfaAdoption()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) AnalyticsGetSystemAdminCount() (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.AnalyticsGetSystemAdminCount()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) ClearAllCustomRoleAssignments() *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserStore.ClearAllCustomRoleAssignments()
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) ClearCaches() {
+	s.UserStore.ClearCaches()
+}
+
+func (s *RetryLayerUserStore) Count(options model.UserCountOptions) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.Count(options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) DemoteUserToGuest(userID string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserStore.DemoteUserToGuest(userID)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) Get(id string) (*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetAll() ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetAll()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetAllAfter(limit, afterId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetAllProfiles(options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetAllProfilesInChannel(channelId string, allowFromCache bool) (map[string]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetAllProfilesInChannel(channelId, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetAllUsingAuthService(authService string) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetAllUsingAuthService(authService)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetAnyUnreadPostCountForChannel(userId string, channelId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetAnyUnreadPostCountForChannel(userId, channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetByAuth(authData, authService)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetByEmail(email string) (*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetByEmail(email)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetByUsername(username string) (*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetByUsername(username)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetChannelGroupUsers(channelID)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetEtagForAllProfiles() string {
+	return s.UserStore.GetEtagForAllProfiles()
+}
+
+func (s *RetryLayerUserStore) GetEtagForProfiles(teamId string) string {
+	return s.UserStore.GetEtagForProfiles(teamId)
+}
+
+func (s *RetryLayerUserStore) GetEtagForProfilesNotInTeam(teamId string) string {
+	return s.UserStore.GetEtagForProfilesNotInTeam(teamId)
+}
+
+func (s *RetryLayerUserStore) GetForLogin(loginId string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetForLogin(loginId, allowSignInWithUsername, allowSignInWithEmail)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetNewUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetNewUsersForTeam(teamId, offset, limit, viewRestrictions)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetProfileByGroupChannelIdsForUser(userId string, channelIds []string) (map[string][]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetProfileByGroupChannelIdsForUser(userId, channelIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetProfileByIds(userIds []string, options *UserGetByIdsOpts, allowFromCache bool) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetProfileByIds(userIds, options, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetProfiles(options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetProfilesInChannel(channelId string, offset int, limit int) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetProfilesInChannel(channelId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetProfilesInChannelByStatus(channelId string, offset int, limit int) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetProfilesInChannelByStatus(channelId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetProfilesNotInChannel(teamId string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetProfilesNotInChannel(teamId, channelId, groupConstrained, offset, limit, viewRestrictions)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetProfilesNotInTeam(teamId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetProfilesNotInTeam(teamId, groupConstrained, offset, limit, viewRestrictions)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetProfilesWithoutTeam(offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetProfilesWithoutTeam(offset, limit, viewRestrictions)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetRecentlyActiveUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetRecentlyActiveUsersForTeam(teamId, offset, limit, viewRestrictions)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetSystemAdminProfiles()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetTeamGroupUsers(teamID)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetUnreadCount(userId string) (int64, error) {
+	return s.UserStore.GetUnreadCount(userId)
+}
+
+func (s *RetryLayerUserStore) GetUnreadCountForChannel(userId string, channelId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetUnreadCountForChannel(userId, channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.GetUsersBatchForIndexing(startTime, endTime, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) InferSystemInstallDate() (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.InferSystemInstallDate()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) InvalidatProfileCacheForUser(userId string) {
+	s.UserStore.InvalidatProfileCacheForUser(userId)
+}
+
+func (s *RetryLayerUserStore) InvalidateProfilesInChannelCache(channelId string) {
+	s.UserStore.InvalidateProfilesInChannelCache(channelId)
+}
+
+func (s *RetryLayerUserStore) InvalidateProfilesInChannelCacheByUser(userId string) {
+	s.UserStore.InvalidateProfilesInChannelCacheByUser(userId)
+}
+
+func (s *RetryLayerUserStore) PermanentDelete(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserStore.PermanentDelete(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) PromoteGuestToUser(userID string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserStore.PromoteGuestToUser(userID)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) ResetLastPictureUpdate(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserStore.ResetLastPictureUpdate(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) Save(user *model.User) (*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.Save(user)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) Search(ctx context.Context, teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.Search(ctx, teamId, term, options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) SearchInChannel(ctx context.Context, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.SearchInChannel(ctx, channelId, term, options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) SearchNotInChannel(ctx context.Context, teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.SearchNotInChannel(ctx, teamId, channelId, term, options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) SearchNotInTeam(ctx context.Context, notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.SearchNotInTeam(ctx, notInTeamId, term, options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) SearchWithoutTeam(ctx context.Context, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.SearchWithoutTeam(ctx, term, options)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.Update(user, allowRoleUpdate)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) UpdateLastPictureUpdate(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserStore.UpdateLastPictureUpdate(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) UpdateMfaActive(userId string, active bool) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserStore.UpdateMfaActive(userId, active)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) UpdateMfaSecret(userId string, secret string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserStore.UpdateMfaSecret(userId, secret)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) UpdatePassword(userId string, newPassword string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserStore.UpdatePassword(userId, newPassword)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) UpdateUpdateAt(userId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.UpdateUpdateAt(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserStore) VerifyEmail(userId string, email string) (string, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserStore.VerifyEmail(userId, email)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) Delete(tokenId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserAccessTokenStore.Delete(tokenId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) DeleteAllForUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserAccessTokenStore.DeleteAllForUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) Get(tokenId string) (*model.UserAccessToken, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAccessTokenStore.Get(tokenId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAccessTokenStore.GetAll(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAccessTokenStore.GetByToken(tokenString)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) GetByUser(userId string, page int, perPage int) ([]*model.UserAccessToken, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAccessTokenStore.GetByUser(userId, page, perPage)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) GetExpiring(from int64, to int64, offset int, limit int) ([]*model.UserAccessToken, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAccessTokenStore.GetExpiring(from, to, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAccessTokenStore.Save(token)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAccessTokenStore.Search(term)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) UpdateLastUsedAt(tokenId string, lastUsedAt int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserAccessTokenStore.UpdateLastUsedAt(tokenId, lastUsedAt)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserAccessTokenStore.UpdateTokenDisable(tokenId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserAccessTokenStore.UpdateTokenEnable(tokenId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserAttributeStore) DeleteField(id string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserAttributeStore.DeleteField(id, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserAttributeStore) DeleteValue(userId string, fieldId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserAttributeStore.DeleteValue(userId, fieldId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserAttributeStore) GetField(id string) (*model.UserAttributeField, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAttributeStore.GetField(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAttributeStore) GetFields() ([]*model.UserAttributeField, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAttributeStore.GetFields()
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAttributeStore) GetValuesForUsers(userIds []string) ([]*model.UserAttributeValue, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAttributeStore.GetValuesForUsers(userIds)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAttributeStore) PermanentDeleteValuesByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserAttributeStore.PermanentDeleteValuesByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserAttributeStore) SaveField(field *model.UserAttributeField) (*model.UserAttributeField, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAttributeStore.SaveField(field)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAttributeStore) SaveValue(value *model.UserAttributeValue) (*model.UserAttributeValue, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAttributeStore.SaveValue(value)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAttributeStore) UpdateField(field *model.UserAttributeField) (*model.UserAttributeField, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAttributeStore.UpdateField(field)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAvailabilityStore) Delete(id string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserAvailabilityStore.Delete(id)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserAvailabilityStore) Get(id string) (*model.UserAvailability, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAvailabilityStore.Get(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAvailabilityStore) GetForUser(userId string, startAt int64, endAt int64) ([]*model.UserAvailability, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAvailabilityStore.GetForUser(userId, startAt, endAt)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAvailabilityStore) GetForUsersAt(userIds []string, at int64) ([]*model.UserAvailability, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAvailabilityStore.GetForUsersAt(userIds, at)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAvailabilityStore) PermanentDeleteByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserAvailabilityStore.PermanentDeleteByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserAvailabilityStore) Save(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAvailabilityStore.Save(availability)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserAvailabilityStore) Update(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserAvailabilityStore.Update(availability)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserDeactivationScheduleStore) Delete(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserDeactivationScheduleStore.Delete(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserDeactivationScheduleStore) Get(userId string) (*model.UserDeactivationSchedule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserDeactivationScheduleStore.Get(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserDeactivationScheduleStore) GetAll(offset int, limit int) ([]*model.UserDeactivationSchedule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserDeactivationScheduleStore.GetAll(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserDeactivationScheduleStore) GetDue(before int64, limit int) ([]*model.UserDeactivationSchedule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserDeactivationScheduleStore.GetDue(before, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserDeactivationScheduleStore) Save(schedule *model.UserDeactivationSchedule) (*model.UserDeactivationSchedule, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserDeactivationScheduleStore.Save(schedule)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.UserTermsOfServiceStore.Delete(userId, termsOfServiceId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerUserTermsOfServiceStore) GetAcceptances(termsOfServiceId string, since int64, offset int, limit int) ([]*model.TermsOfServiceAcceptance, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserTermsOfServiceStore.GetAcceptances(termsOfServiceId, since, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOfService, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserTermsOfServiceStore.GetByUser(userId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserTermsOfServiceStore.Save(userTermsOfService)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerUserTermsOfServiceStore) SaveAcceptance(acceptance *model.TermsOfServiceAcceptance) (*model.TermsOfServiceAcceptance, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.UserTermsOfServiceStore.SaveAcceptance(acceptance)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) AnalyticsIncomingCount(teamId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.AnalyticsIncomingCount(teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) AnalyticsOutgoingCount(teamId string) (int64, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.AnalyticsOutgoingCount(teamId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) ClearCaches() {
+	s.WebhookStore.ClearCaches()
+}
+
+func (s *RetryLayerWebhookStore) DeleteIncoming(webhookId string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.WebhookStore.DeleteIncoming(webhookId, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) DeleteOutgoing(webhookId string, time int64) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.WebhookStore.DeleteOutgoing(webhookId, time)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetIncoming(id, allowFromCache)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetIncomingByChannel(channelId string) ([]*model.IncomingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetIncomingByChannel(channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetIncomingByTeam(teamId string, offset int, limit int) ([]*model.IncomingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetIncomingByTeam(teamId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetIncomingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.IncomingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetIncomingByTeamByUser(teamId, userId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetIncomingList(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetIncomingListByUser(userId string, offset int, limit int) ([]*model.IncomingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetIncomingListByUser(userId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetOutgoing(id)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoingByChannel(channelId string, offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetOutgoingByChannel(channelId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoingByChannelByUser(channelId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetOutgoingByChannelByUser(channelId, userId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoingByTeam(teamId string, offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetOutgoingByTeam(teamId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetOutgoingByTeamByUser(teamId, userId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoingForChannel(teamId string, channelId string) ([]*model.OutgoingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetOutgoingForChannel(teamId, channelId)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetOutgoingList(offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoingListByUser(userId string, offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.GetOutgoingListByUser(userId, offset, limit)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) InvalidateWebhookCache(webhook string) {
+	s.WebhookStore.InvalidateWebhookCache(webhook)
+}
+
+func (s *RetryLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.WebhookStore.PermanentDeleteIncomingByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) PermanentDeleteIncomingByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.WebhookStore.PermanentDeleteIncomingByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.WebhookStore.PermanentDeleteOutgoingByChannel(channelId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) PermanentDeleteOutgoingByUser(userId string) *model.AppError {
+	tries := 0
+	for {
+		resultVar0 := s.WebhookStore.PermanentDeleteOutgoingByUser(userId)
+		tries++
+		if resultVar0 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar0) {
+			return resultVar0
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.SaveIncoming(webhook)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.SaveOutgoing(webhook)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.UpdateIncoming(webhook)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	tries := 0
+	for {
+		resultVar0, resultVar1 := s.WebhookStore.UpdateOutgoing(hook)
+		tries++
+		if resultVar1 == nil || tries >= RETRY_LAYER_MAX_TRIES || !isRepeatableError(resultVar1) {
+			return resultVar0, resultVar1
+		}
+	}
+}
+
+func (s *RetryLayer) Close() {
+	s.Store.Close()
+}
+
+func (s *RetryLayer) DropAllTables() {
+	s.Store.DropAllTables()
+}
+
+func (s *RetryLayer) GetCurrentSchemaVersion() string {
+	return s.Store.GetCurrentSchemaVersion()
+}
+
+func (s *RetryLayer) LockToMaster() {
+	s.Store.LockToMaster()
+}
+
+func (s *RetryLayer) MarkSystemRanUnitTests() {
+	s.Store.MarkSystemRanUnitTests()
+}
+
+func (s *RetryLayer) TotalMasterDbConnections() int {
+	return s.Store.TotalMasterDbConnections()
+}
+
+func (s *RetryLayer) TotalReadDbConnections() int {
+	return s.Store.TotalReadDbConnections()
+}
+
+func (s *RetryLayer) TotalSearchDbConnections() int {
+	return s.Store.TotalSearchDbConnections()
+}
+
+func (s *RetryLayer) UnlockFromMaster() {
+	s.Store.UnlockFromMaster()
+}
+
+func NewRetryLayer(childStore Store) *RetryLayer {
+	newStore := RetryLayer{
+		Store: childStore,
+	}
+
+	newStore.AnnouncementStore = &RetryLayerAnnouncementStore{AnnouncementStore: childStore.Announcement(), Root: &newStore}
+	newStore.AuditStore = &RetryLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.BotStore = &RetryLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.CallStore = &RetryLayerCallStore{CallStore: childStore.Call(), Root: &newStore}
+	newStore.ChannelStore = &RetryLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelMemberExpiryStore = &RetryLayerChannelMemberExpiryStore{ChannelMemberExpiryStore: childStore.ChannelMemberExpiry(), Root: &newStore}
+	newStore.ChannelMemberHistoryStore = &RetryLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ChannelMembershipRuleStore = &RetryLayerChannelMembershipRuleStore{ChannelMembershipRuleStore: childStore.ChannelMembershipRule(), Root: &newStore}
+	newStore.ChannelReadStatStore = &RetryLayerChannelReadStatStore{ChannelReadStatStore: childStore.ChannelReadStat(), Root: &newStore}
+	newStore.ChannelTeamBindingStore = &RetryLayerChannelTeamBindingStore{ChannelTeamBindingStore: childStore.ChannelTeamBinding(), Root: &newStore}
+	newStore.ClusterDiscoveryStore = &RetryLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.CommandStore = &RetryLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
+	newStore.CommandWebhookStore = &RetryLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
+	newStore.ComplianceStore = &RetryLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.ConfigAuditStore = &RetryLayerConfigAuditStore{ConfigAuditStore: childStore.ConfigAudit(), Root: &newStore}
+	newStore.ContentFilterStore = &RetryLayerContentFilterStore{ContentFilterStore: childStore.ContentFilter(), Root: &newStore}
+	newStore.DailyStatStore = &RetryLayerDailyStatStore{DailyStatStore: childStore.DailyStat(), Root: &newStore}
+	newStore.EmojiStore = &RetryLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.EmojiAliasStore = &RetryLayerEmojiAliasStore{EmojiAliasStore: childStore.EmojiAlias(), Root: &newStore}
+	newStore.EphemeralPostStore = &RetryLayerEphemeralPostStore{EphemeralPostStore: childStore.EphemeralPost(), Root: &newStore}
+	newStore.FileInfoStore = &RetryLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
+	newStore.GroupStore = &RetryLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.HashtagStore = &RetryLayerHashtagStore{HashtagStore: childStore.Hashtag(), Root: &newStore}
+	newStore.IntegrationStatStore = &RetryLayerIntegrationStatStore{IntegrationStatStore: childStore.IntegrationStat(), Root: &newStore}
+	newStore.JobStore = &RetryLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.KeywordRuleStore = &RetryLayerKeywordRuleStore{KeywordRuleStore: childStore.KeywordRule(), Root: &newStore}
+	newStore.LicenseStore = &RetryLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
+	newStore.LicenseUsageStore = &RetryLayerLicenseUsageStore{LicenseUsageStore: childStore.LicenseUsage(), Root: &newStore}
+	newStore.LinkMetadataStore = &RetryLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.LoginAttemptStore = &RetryLayerLoginAttemptStore{LoginAttemptStore: childStore.LoginAttempt(), Root: &newStore}
+	newStore.MentionAliasStore = &RetryLayerMentionAliasStore{MentionAliasStore: childStore.MentionAlias(), Root: &newStore}
+	newStore.MessageExportConsumerStore = &RetryLayerMessageExportConsumerStore{MessageExportConsumerStore: childStore.MessageExportConsumer(), Root: &newStore}
+	newStore.MfaRecoveryCodeStore = &RetryLayerMfaRecoveryCodeStore{MfaRecoveryCodeStore: childStore.MfaRecoveryCode(), Root: &newStore}
+	newStore.OAuthStore = &RetryLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OnboardingStore = &RetryLayerOnboardingStore{OnboardingStore: childStore.Onboarding(), Root: &newStore}
+	newStore.PendingEmojiStore = &RetryLayerPendingEmojiStore{PendingEmojiStore: childStore.PendingEmoji(), Root: &newStore}
+	newStore.PendingPostStore = &RetryLayerPendingPostStore{PendingPostStore: childStore.PendingPost(), Root: &newStore}
+	newStore.PluginStore = &RetryLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PollStore = &RetryLayerPollStore{PollStore: childStore.Poll(), Root: &newStore}
+	newStore.PostStore = &RetryLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostPinEventStore = &RetryLayerPostPinEventStore{PostPinEventStore: childStore.PostPinEvent(), Root: &newStore}
+	newStore.PostPurgeStore = &RetryLayerPostPurgeStore{PostPurgeStore: childStore.PostPurge(), Root: &newStore}
+	newStore.PostReportStore = &RetryLayerPostReportStore{PostReportStore: childStore.PostReport(), Root: &newStore}
+	newStore.PostStarStore = &RetryLayerPostStarStore{PostStarStore: childStore.PostStar(), Root: &newStore}
+	newStore.PreferenceStore = &RetryLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.PublicPostLinkStore = &RetryLayerPublicPostLinkStore{PublicPostLinkStore: childStore.PublicPostLink(), Root: &newStore}
+	newStore.ReactionStore = &RetryLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.RecurringPostStore = &RetryLayerRecurringPostStore{RecurringPostStore: childStore.RecurringPost(), Root: &newStore}
+	newStore.ReminderStore = &RetryLayerReminderStore{ReminderStore: childStore.Reminder(), Root: &newStore}
+	newStore.RoleStore = &RetryLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SchemaMigrationStore = &RetryLayerSchemaMigrationStore{SchemaMigrationStore: childStore.SchemaMigration(), Root: &newStore}
+	newStore.SchemeStore = &RetryLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
+	newStore.SessionStore = &RetryLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.StatusStore = &RetryLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.SystemStore = &RetryLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TeamStore = &RetryLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TermsOfServiceStore = &RetryLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
+	newStore.TodoStore = &RetryLayerTodoStore{TodoStore: childStore.Todo(), Root: &newStore}
+	newStore.TokenStore = &RetryLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
+	newStore.UserStore = &RetryLayerUserStore{UserStore: childStore.User(), Root: &newStore}
+	newStore.UserAccessTokenStore = &RetryLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserAttributeStore = &RetryLayerUserAttributeStore{UserAttributeStore: childStore.UserAttribute(), Root: &newStore}
+	newStore.UserAvailabilityStore = &RetryLayerUserAvailabilityStore{UserAvailabilityStore: childStore.UserAvailability(), Root: &newStore}
+	newStore.UserDeactivationScheduleStore = &RetryLayerUserDeactivationScheduleStore{UserDeactivationScheduleStore: childStore.UserDeactivationSchedule(), Root: &newStore}
+	newStore.UserTermsOfServiceStore = &RetryLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebhookStore = &RetryLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	return &newStore
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package store_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/storetest/mocks"
+)
+
+func newRetryLayerWithPostStore(postStore *mocks.PostStore) store.Store {
+	mockStore := &mocks.Store{}
+
+	storeType := reflect.TypeOf((*store.Store)(nil)).Elem()
+	for i := 0; i < storeType.NumMethod(); i++ {
+		method := storeType.Method(i)
+		if method.Name != "Post" && method.Type.NumIn() == 0 && method.Type.NumOut() == 1 && method.Type.Out(0).Kind() == reflect.Interface {
+			mockStore.On(method.Name).Return(nil)
+		}
+	}
+	mockStore.On("Post").Return(postStore)
+
+	return store.NewRetryLayer(mockStore)
+}
+
+func TestRetryLayer(t *testing.T) {
+	repeatableErr := model.NewAppError("SqlPostStore.GetSingle", "store.sql_post.get.app_error", nil, "id=post1, pq: restart transaction: TransactionRetryWithProtoRefreshError: WriteTooOldError", http.StatusInternalServerError)
+	otherErr := model.NewAppError("SqlPostStore.GetSingle", "store.sql_post.get.app_error", nil, "id=post1, sql: no rows in result set", http.StatusNotFound)
+
+	t.Run("retries repeatable errors", func(t *testing.T) {
+		postStore := &mocks.PostStore{}
+		postStore.On("GetSingle", "post1").Return(nil, repeatableErr).Once()
+		postStore.On("GetSingle", "post1").Return(&model.Post{Id: "post1"}, nil).Once()
+
+		post, err := newRetryLayerWithPostStore(postStore).Post().GetSingle("post1")
+		assert.Nil(t, err)
+		assert.Equal(t, "post1", post.Id)
+		postStore.AssertNumberOfCalls(t, "GetSingle", 2)
+	})
+
+	t.Run("gives up after the maximum number of tries", func(t *testing.T) {
+		postStore := &mocks.PostStore{}
+		postStore.On("GetSingle", "post1").Return(nil, repeatableErr)
+
+		_, err := newRetryLayerWithPostStore(postStore).Post().GetSingle("post1")
+		assert.Equal(t, repeatableErr, err)
+		postStore.AssertNumberOfCalls(t, "GetSingle", store.RETRY_LAYER_MAX_TRIES)
+	})
+
+	t.Run("doesn't retry other errors", func(t *testing.T) {
+		postStore := &mocks.PostStore{}
+		postStore.On("GetSingle", "post1").Return(nil, otherErr)
+
+		_, err := newRetryLayerWithPostStore(postStore).Post().GetSingle("post1")
+		assert.Equal(t, otherErr, err)
+		postStore.AssertNumberOfCalls(t, "GetSingle", 1)
+	})
+}
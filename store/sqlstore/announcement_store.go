@@ -0,0 +1,215 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlAnnouncementStore struct {
+	SqlStore
+}
+
+func NewSqlAnnouncementStore(sqlStore SqlStore) store.AnnouncementStore {
+	s := &SqlAnnouncementStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		announcements := db.AddTableWithName(model.Announcement{}, "Announcements").SetKeys(false, "Id")
+		announcements.ColMap("Id").SetMaxSize(26)
+		announcements.ColMap("CreatorId").SetMaxSize(26)
+		announcements.ColMap("Title").SetMaxSize(model.ANNOUNCEMENT_TITLE_MAX_RUNES * 4)
+		announcements.ColMap("Message").SetMaxSize(model.ANNOUNCEMENT_MESSAGE_MAX_RUNES * 4)
+		announcements.ColMap("TeamIds").SetMaxSize(model.ANNOUNCEMENT_AUDIENCE_MAX_IDS * 30)
+		announcements.ColMap("Roles").SetMaxSize(model.ANNOUNCEMENT_AUDIENCE_MAX_IDS * 70)
+		announcements.ColMap("GroupIds").SetMaxSize(model.ANNOUNCEMENT_AUDIENCE_MAX_IDS * 30)
+
+		recipients := db.AddTableWithName(model.AnnouncementRecipient{}, "AnnouncementRecipients").SetKeys(false, "AnnouncementId", "UserId")
+		recipients.ColMap("AnnouncementId").SetMaxSize(26)
+		recipients.ColMap("UserId").SetMaxSize(26)
+		recipients.ColMap("Status").SetMaxSize(16)
+		recipients.ColMap("PostId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlAnnouncementStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_announcements_create_at", "Announcements", "CreateAt")
+	s.CreateIndexIfNotExists("idx_announcementrecipients_user_id", "AnnouncementRecipients", "UserId")
+}
+
+func (s SqlAnnouncementStore) Save(announcement *model.Announcement) (*model.Announcement, *model.AppError) {
+	if len(announcement.Id) > 0 {
+		return nil, model.NewAppError("SqlAnnouncementStore.Save", "store.sql_announcement.save.existing.app_error", nil, "id="+announcement.Id, http.StatusBadRequest)
+	}
+
+	announcement.PreSave()
+	if err := announcement.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(announcement); err != nil {
+		return nil, model.NewAppError("SqlAnnouncementStore.Save", "store.sql_announcement.save.app_error", nil, "id="+announcement.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return announcement, nil
+}
+
+func (s SqlAnnouncementStore) Get(id string) (*model.Announcement, *model.AppError) {
+	var announcement model.Announcement
+
+	if err := s.GetReplica().SelectOne(&announcement, "SELECT * FROM Announcements WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlAnnouncementStore.Get", "store.sql_announcement.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlAnnouncementStore.Get", "store.sql_announcement.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &announcement, nil
+}
+
+// GetAll returns the announcements that weren't deleted, newest first.
+func (s SqlAnnouncementStore) GetAll(offset, limit int) ([]*model.Announcement, *model.AppError) {
+	var announcements []*model.Announcement
+
+	if _, err := s.GetReplica().Select(&announcements, "SELECT * FROM Announcements WHERE DeleteAt = 0 ORDER BY CreateAt DESC, Id LIMIT :Limit OFFSET :Offset", map[string]interface{}{"Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlAnnouncementStore.GetAll", "store.sql_announcement.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return announcements, nil
+}
+
+func (s SqlAnnouncementStore) Delete(id string, deleteAt int64) *model.AppError {
+	if _, err := s.GetMaster().Exec("UPDATE Announcements SET DeleteAt = :DeleteAt WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"DeleteAt": deleteAt, "Id": id}); err != nil {
+		return model.NewAppError("SqlAnnouncementStore.Delete", "store.sql_announcement.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlAnnouncementStore) SaveRecipients(recipients []*model.AnnouncementRecipient) *model.AppError {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlAnnouncementStore.SaveRecipients", "store.sql_announcement.save_recipients.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	for _, recipient := range recipients {
+		if err := transaction.Insert(recipient); err != nil {
+			return model.NewAppError("SqlAnnouncementStore.SaveRecipients", "store.sql_announcement.save_recipients.app_error", nil, "announcement_id="+recipient.AnnouncementId+", user_id="+recipient.UserId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlAnnouncementStore.SaveRecipients", "store.sql_announcement.save_recipients.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlAnnouncementStore) UpdateRecipientDelivery(announcementId, userId, status, postId string, deliverAt int64) *model.AppError {
+	if _, err := s.GetMaster().Exec("UPDATE AnnouncementRecipients SET Status = :Status, PostId = :PostId, DeliverAt = :DeliverAt WHERE AnnouncementId = :AnnouncementId AND UserId = :UserId",
+		map[string]interface{}{"Status": status, "PostId": postId, "DeliverAt": deliverAt, "AnnouncementId": announcementId, "UserId": userId}); err != nil {
+		return model.NewAppError("SqlAnnouncementStore.UpdateRecipientDelivery", "store.sql_announcement.update_recipient_delivery.app_error", nil, "announcement_id="+announcementId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// Acknowledge records that the user acknowledged the announcement. Only their first acknowledgment is kept.
+func (s SqlAnnouncementStore) Acknowledge(announcementId, userId string, acknowledgeAt int64) *model.AppError {
+	if _, err := s.GetMaster().Exec("UPDATE AnnouncementRecipients SET AcknowledgeAt = :AcknowledgeAt WHERE AnnouncementId = :AnnouncementId AND UserId = :UserId AND AcknowledgeAt = 0",
+		map[string]interface{}{"AcknowledgeAt": acknowledgeAt, "AnnouncementId": announcementId, "UserId": userId}); err != nil {
+		return model.NewAppError("SqlAnnouncementStore.Acknowledge", "store.sql_announcement.acknowledge.app_error", nil, "announcement_id="+announcementId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlAnnouncementStore) GetRecipient(announcementId, userId string) (*model.AnnouncementRecipient, *model.AppError) {
+	var recipient model.AnnouncementRecipient
+
+	if err := s.GetReplica().SelectOne(&recipient, "SELECT * FROM AnnouncementRecipients WHERE AnnouncementId = :AnnouncementId AND UserId = :UserId", map[string]interface{}{"AnnouncementId": announcementId, "UserId": userId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlAnnouncementStore.GetRecipient", "store.sql_announcement.get_recipient.app_error", nil, "announcement_id="+announcementId+", user_id="+userId+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlAnnouncementStore.GetRecipient", "store.sql_announcement.get_recipient.app_error", nil, "announcement_id="+announcementId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &recipient, nil
+}
+
+func (s SqlAnnouncementStore) GetRecipients(announcementId string, offset, limit int) ([]*model.AnnouncementRecipient, *model.AppError) {
+	var recipients []*model.AnnouncementRecipient
+
+	if _, err := s.GetReplica().Select(&recipients, "SELECT * FROM AnnouncementRecipients WHERE AnnouncementId = :AnnouncementId ORDER BY UserId LIMIT :Limit OFFSET :Offset",
+		map[string]interface{}{"AnnouncementId": announcementId, "Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlAnnouncementStore.GetRecipients", "store.sql_announcement.get_recipients.app_error", nil, "announcement_id="+announcementId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return recipients, nil
+}
+
+func (s SqlAnnouncementStore) GetReport(announcementId string) (*model.AnnouncementReport, *model.AppError) {
+	report := model.AnnouncementReport{AnnouncementId: announcementId}
+
+	if err := s.GetReplica().SelectOne(&report, `
+		SELECT
+			COUNT(*) AS Total,
+			COALESCE(SUM(CASE WHEN Status = :Pending THEN 1 ELSE 0 END), 0) AS Pending,
+			COALESCE(SUM(CASE WHEN Status = :Delivered THEN 1 ELSE 0 END), 0) AS Delivered,
+			COALESCE(SUM(CASE WHEN Status = :Failed THEN 1 ELSE 0 END), 0) AS Failed,
+			COALESCE(SUM(CASE WHEN AcknowledgeAt > 0 THEN 1 ELSE 0 END), 0) AS Acknowledged
+		FROM
+			AnnouncementRecipients
+		WHERE
+			AnnouncementId = :AnnouncementId`, map[string]interface{}{
+		"AnnouncementId": announcementId,
+		"Pending":        model.ANNOUNCEMENT_RECIPIENT_STATUS_PENDING,
+		"Delivered":      model.ANNOUNCEMENT_RECIPIENT_STATUS_DELIVERED,
+		"Failed":         model.ANNOUNCEMENT_RECIPIENT_STATUS_FAILED,
+	}); err != nil {
+		return nil, model.NewAppError("SqlAnnouncementStore.GetReport", "store.sql_announcement.get_report.app_error", nil, "announcement_id="+announcementId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &report, nil
+}
+
+// GetPendingForUser returns the active announcements the user has yet to acknowledge, among those shown as banners or
+// requiring an acknowledgment, oldest first.
+func (s SqlAnnouncementStore) GetPendingForUser(userId string, now int64) ([]*model.Announcement, *model.AppError) {
+	var announcements []*model.Announcement
+
+	if _, err := s.GetReplica().Select(&announcements, `
+		SELECT
+			Announcements.*
+		FROM
+			Announcements
+		JOIN
+			AnnouncementRecipients ON (AnnouncementRecipients.AnnouncementId = Announcements.Id)
+		WHERE
+			AnnouncementRecipients.UserId = :UserId
+			AND AnnouncementRecipients.AcknowledgeAt = 0
+			AND Announcements.DeleteAt = 0
+			AND (Announcements.ExpireAt = 0 OR Announcements.ExpireAt > :Now)
+			AND (Announcements.ShowBanner = :True OR Announcements.RequireAcknowledgment = :True)
+		ORDER BY
+			Announcements.CreateAt, Announcements.Id`, map[string]interface{}{"UserId": userId, "Now": now, "True": true}); err != nil {
+		return nil, model.NewAppError("SqlAnnouncementStore.GetPendingForUser", "store.sql_announcement.get_pending_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return announcements, nil
+}
+
+func (s SqlAnnouncementStore) PermanentDeleteRecipientsByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM AnnouncementRecipients WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlAnnouncementStore.PermanentDeleteRecipientsByUser", "store.sql_announcement.permanent_delete_recipients_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
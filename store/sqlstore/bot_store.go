@@ -171,6 +171,40 @@ func (us SqlBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, *model
 	return bots, nil
 }
 
+// GetAllForChannel fetches the active bots that are members of the given channel.
+func (us SqlBotStore) GetAllForChannel(channelId string) ([]*model.Bot, *model.AppError) {
+	sql := `
+			SELECT
+			    b.UserId,
+			    u.Username,
+			    u.FirstName AS DisplayName,
+			    b.Description,
+			    b.OwnerId,
+			    b.CreateAt,
+			    b.UpdateAt,
+			    b.DeleteAt
+			FROM
+			    Bots b
+			JOIN
+			    Users u ON (u.Id = b.UserId)
+			JOIN
+			    ChannelMembers cm ON (cm.UserId = b.UserId)
+			WHERE
+			    cm.ChannelId = :channel_id
+			    AND b.DeleteAt = 0
+			    AND u.DeleteAt = 0
+			ORDER BY
+			    u.Username ASC
+		`
+
+	var bots []*model.Bot
+	if _, err := us.GetReplica().Select(&bots, sql, map[string]interface{}{"channel_id": channelId}); err != nil {
+		return nil, model.NewAppError("SqlBotStore.GetAllForChannel", "store.sql_bot.get_all_for_channel.app_error", map[string]interface{}{"channel_id": channelId}, err.Error(), http.StatusInternalServerError)
+	}
+
+	return bots, nil
+}
+
 // Save persists a new bot to the database.
 // It assumes the corresponding user was saved via the user store.
 func (us SqlBotStore) Save(bot *model.Bot) (*model.Bot, *model.AppError) {
@@ -0,0 +1,138 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlCallStore struct {
+	SqlStore
+}
+
+func NewSqlCallStore(sqlStore SqlStore) store.CallStore {
+	s := &SqlCallStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.Call{}, "Calls").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("RootId").SetMaxSize(26)
+		table.ColMap("Provider").SetMaxSize(model.CALL_PROVIDER_MAX_LENGTH)
+		table.ColMap("Title").SetMaxSize(model.CALL_TITLE_MAX_RUNES * 4)
+		table.ColMap("JoinUrl").SetMaxSize(model.CALL_JOIN_URL_MAX_LENGTH)
+
+		participants := db.AddTableWithName(model.CallParticipant{}, "CallParticipants").SetKeys(false, "CallId", "UserId")
+		participants.ColMap("CallId").SetMaxSize(26)
+		participants.ColMap("UserId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlCallStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_calls_channel_id", "Calls", "ChannelId")
+}
+
+func (s SqlCallStore) Save(call *model.Call) (*model.Call, *model.AppError) {
+	if len(call.Id) > 0 {
+		return nil, model.NewAppError("SqlCallStore.Save", "store.sql_call.save.existing.app_error", nil, "id="+call.Id, http.StatusBadRequest)
+	}
+
+	call.PreSave()
+	if err := call.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(call); err != nil {
+		return nil, model.NewAppError("SqlCallStore.Save", "store.sql_call.save.app_error", nil, "id="+call.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return call, nil
+}
+
+func (s SqlCallStore) Update(call *model.Call) (*model.Call, *model.AppError) {
+	call.PreUpdate()
+	if err := call.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(call)
+	if err != nil {
+		return nil, model.NewAppError("SqlCallStore.Update", "store.sql_call.update.app_error", nil, "id="+call.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		return nil, model.NewAppError("SqlCallStore.Update", "store.sql_call.get.app_error", nil, "id="+call.Id, http.StatusNotFound)
+	}
+
+	return call, nil
+}
+
+func (s SqlCallStore) Get(id string) (*model.Call, *model.AppError) {
+	var call model.Call
+
+	if err := s.GetMaster().SelectOne(&call, "SELECT * FROM Calls WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlCallStore.Get", "store.sql_call.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlCallStore.Get", "store.sql_call.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &call, nil
+}
+
+// GetActiveForChannel returns the calls of a channel that haven't ended, starting with the earliest.
+func (s SqlCallStore) GetActiveForChannel(channelId string) ([]*model.Call, *model.AppError) {
+	calls := []*model.Call{}
+
+	if _, err := s.GetReplica().Select(&calls, "SELECT * FROM Calls WHERE ChannelId = :ChannelId AND EndAt = 0 ORDER BY StartAt, Id", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, model.NewAppError("SqlCallStore.GetActiveForChannel", "store.sql_call.get_active_for_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return calls, nil
+}
+
+// SaveParticipant records a user joining or leaving a call, replacing what was recorded for them before.
+func (s SqlCallStore) SaveParticipant(participant *model.CallParticipant) *model.AppError {
+	count, err := s.GetMaster().Update(participant)
+	if err == nil && count == 0 {
+		err = s.GetMaster().Insert(participant)
+	}
+
+	if err != nil {
+		return model.NewAppError("SqlCallStore.SaveParticipant", "store.sql_call.save_participant.app_error", nil, "call_id="+participant.CallId+", user_id="+participant.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetParticipants returns the users who have joined a call, in the order they joined.
+func (s SqlCallStore) GetParticipants(callId string) ([]*model.CallParticipant, *model.AppError) {
+	participants := []*model.CallParticipant{}
+
+	if _, err := s.GetMaster().Select(&participants, "SELECT * FROM CallParticipants WHERE CallId = :CallId ORDER BY JoinAt, UserId", map[string]interface{}{"CallId": callId}); err != nil {
+		return nil, model.NewAppError("SqlCallStore.GetParticipants", "store.sql_call.get_participants.app_error", nil, "call_id="+callId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return participants, nil
+}
+
+func (s SqlCallStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM CallParticipants WHERE CallId IN (SELECT Id FROM Calls WHERE ChannelId = :ChannelId)", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlCallStore.PermanentDeleteByChannel", "store.sql_call.delete.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, err := s.GetMaster().Exec("DELETE FROM Calls WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlCallStore.PermanentDeleteByChannel", "store.sql_call.delete.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
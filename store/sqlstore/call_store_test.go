@@ -0,0 +1,14 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/store/storetest"
+)
+
+func TestCallStore(t *testing.T) {
+	StoreTest(t, storetest.TestCallStore)
+}
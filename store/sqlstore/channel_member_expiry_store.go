@@ -0,0 +1,118 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlChannelMemberExpiryStore struct {
+	SqlStore
+}
+
+func NewSqlChannelMemberExpiryStore(sqlStore SqlStore) store.ChannelMemberExpiryStore {
+	s := &SqlChannelMemberExpiryStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.ChannelMemberExpiry{}, "ChannelMemberExpiries").SetKeys(false, "ChannelId", "UserId")
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlChannelMemberExpiryStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_channelmemberexpiries_user_id", "ChannelMemberExpiries", "UserId")
+	s.CreateIndexIfNotExists("idx_channelmemberexpiries_expires_at", "ChannelMemberExpiries", "ExpiresAt")
+}
+
+// Save sets when the member is removed from the channel, replacing any expiry previously set for them.
+func (s SqlChannelMemberExpiryStore) Save(expiry *model.ChannelMemberExpiry) (*model.ChannelMemberExpiry, *model.AppError) {
+	expiry.PreSave()
+	if err := expiry.IsValid(); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelMemberExpiryStore.Save", "store.sql_channel_member_expiry.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	count, err := transaction.SelectInt("SELECT COUNT(*) FROM ChannelMemberExpiries WHERE ChannelId = :ChannelId AND UserId = :UserId", map[string]interface{}{"ChannelId": expiry.ChannelId, "UserId": expiry.UserId})
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelMemberExpiryStore.Save", "store.sql_channel_member_expiry.save.app_error", nil, "channel_id="+expiry.ChannelId+", user_id="+expiry.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		err = transaction.Insert(expiry)
+	} else {
+		_, err = transaction.Update(expiry)
+	}
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelMemberExpiryStore.Save", "store.sql_channel_member_expiry.save.app_error", nil, "channel_id="+expiry.ChannelId+", user_id="+expiry.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, model.NewAppError("SqlChannelMemberExpiryStore.Save", "store.sql_channel_member_expiry.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return expiry, nil
+}
+
+func (s SqlChannelMemberExpiryStore) Get(channelId, userId string) (*model.ChannelMemberExpiry, *model.AppError) {
+	var expiry model.ChannelMemberExpiry
+
+	if err := s.GetReplica().SelectOne(&expiry, "SELECT * FROM ChannelMemberExpiries WHERE ChannelId = :ChannelId AND UserId = :UserId", map[string]interface{}{"ChannelId": channelId, "UserId": userId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlChannelMemberExpiryStore.Get", "store.sql_channel_member_expiry.get.app_error", nil, "channel_id="+channelId+", user_id="+userId+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlChannelMemberExpiryStore.Get", "store.sql_channel_member_expiry.get.app_error", nil, "channel_id="+channelId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &expiry, nil
+}
+
+func (s SqlChannelMemberExpiryStore) GetForChannel(channelId string) ([]*model.ChannelMemberExpiry, *model.AppError) {
+	var expiries []*model.ChannelMemberExpiry
+
+	if _, err := s.GetReplica().Select(&expiries, "SELECT * FROM ChannelMemberExpiries WHERE ChannelId = :ChannelId ORDER BY ExpiresAt, UserId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, model.NewAppError("SqlChannelMemberExpiryStore.GetForChannel", "store.sql_channel_member_expiry.get_for_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return expiries, nil
+}
+
+// GetExpired returns the expiries that are due before the given time, starting with the earliest.
+func (s SqlChannelMemberExpiryStore) GetExpired(before int64, limit int) ([]*model.ChannelMemberExpiry, *model.AppError) {
+	var expiries []*model.ChannelMemberExpiry
+
+	if _, err := s.GetMaster().Select(&expiries, "SELECT * FROM ChannelMemberExpiries WHERE ExpiresAt <= :Before ORDER BY ExpiresAt LIMIT :Limit", map[string]interface{}{"Before": before, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlChannelMemberExpiryStore.GetExpired", "store.sql_channel_member_expiry.get_expired.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return expiries, nil
+}
+
+func (s SqlChannelMemberExpiryStore) Delete(channelId, userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelMemberExpiries WHERE ChannelId = :ChannelId AND UserId = :UserId", map[string]interface{}{"ChannelId": channelId, "UserId": userId}); err != nil {
+		return model.NewAppError("SqlChannelMemberExpiryStore.Delete", "store.sql_channel_member_expiry.delete.app_error", nil, "channel_id="+channelId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlChannelMemberExpiryStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelMemberExpiries WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlChannelMemberExpiryStore.PermanentDeleteByUser", "store.sql_channel_member_expiry.permanent_delete_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlChannelMembershipRuleStore struct {
+	SqlStore
+}
+
+func NewSqlChannelMembershipRuleStore(sqlStore SqlStore) store.ChannelMembershipRuleStore {
+	s := &SqlChannelMembershipRuleStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		rules := db.AddTableWithName(model.ChannelMembershipRule{}, "ChannelMembershipRules").SetKeys(false, "Id")
+		rules.ColMap("Id").SetMaxSize(26)
+		rules.ColMap("ChannelId").SetMaxSize(26)
+		rules.ColMap("CreatorId").SetMaxSize(26)
+		rules.ColMap("Attribute").SetMaxSize(128)
+		rules.ColMap("Value").SetMaxSize(model.CHANNEL_MEMBERSHIP_RULE_VALUE_MAX_RUNES * 4)
+
+		overrides := db.AddTableWithName(model.ChannelMembershipOverride{}, "ChannelMembershipOverrides").SetKeys(false, "ChannelId", "UserId")
+		overrides.ColMap("ChannelId").SetMaxSize(26)
+		overrides.ColMap("UserId").SetMaxSize(26)
+		overrides.ColMap("Type").SetMaxSize(16)
+		overrides.ColMap("CreatorId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlChannelMembershipRuleStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_channelmembershiprules_channel_id", "ChannelMembershipRules", "ChannelId")
+	s.CreateIndexIfNotExists("idx_channelmembershipoverrides_user_id", "ChannelMembershipOverrides", "UserId")
+}
+
+func (s SqlChannelMembershipRuleStore) Save(rule *model.ChannelMembershipRule) (*model.ChannelMembershipRule, *model.AppError) {
+	if len(rule.Id) > 0 {
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.Save", "store.sql_channel_membership_rule.save.existing.app_error", nil, "id="+rule.Id, http.StatusBadRequest)
+	}
+
+	rule.PreSave()
+	if err := rule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(rule); err != nil {
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.Save", "store.sql_channel_membership_rule.save.app_error", nil, "id="+rule.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return rule, nil
+}
+
+func (s SqlChannelMembershipRuleStore) Get(id string) (*model.ChannelMembershipRule, *model.AppError) {
+	var rule model.ChannelMembershipRule
+
+	if err := s.GetReplica().SelectOne(&rule, "SELECT * FROM ChannelMembershipRules WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlChannelMembershipRuleStore.Get", "store.sql_channel_membership_rule.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.Get", "store.sql_channel_membership_rule.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &rule, nil
+}
+
+func (s SqlChannelMembershipRuleStore) GetForChannel(channelId string) ([]*model.ChannelMembershipRule, *model.AppError) {
+	var rules []*model.ChannelMembershipRule
+
+	if _, err := s.GetReplica().Select(&rules, "SELECT * FROM ChannelMembershipRules WHERE ChannelId = :ChannelId ORDER BY CreateAt, Id", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.GetForChannel", "store.sql_channel_membership_rule.get_for_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return rules, nil
+}
+
+// GetChannelIds returns the ids of the channels having membership rules.
+func (s SqlChannelMembershipRuleStore) GetChannelIds() ([]string, *model.AppError) {
+	var channelIds []string
+
+	if _, err := s.GetReplica().Select(&channelIds, "SELECT DISTINCT ChannelId FROM ChannelMembershipRules ORDER BY ChannelId"); err != nil {
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.GetChannelIds", "store.sql_channel_membership_rule.get_channel_ids.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return channelIds, nil
+}
+
+func (s SqlChannelMembershipRuleStore) Delete(id string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelMembershipRules WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		return model.NewAppError("SqlChannelMembershipRuleStore.Delete", "store.sql_channel_membership_rule.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// SaveOverride records the manual membership of the user in the channel, replacing any previous override for them.
+func (s SqlChannelMembershipRuleStore) SaveOverride(override *model.ChannelMembershipOverride) (*model.ChannelMembershipOverride, *model.AppError) {
+	override.PreSave()
+	if err := override.IsValid(); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.SaveOverride", "store.sql_channel_membership_rule.save_override.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	count, err := transaction.SelectInt("SELECT COUNT(*) FROM ChannelMembershipOverrides WHERE ChannelId = :ChannelId AND UserId = :UserId", map[string]interface{}{"ChannelId": override.ChannelId, "UserId": override.UserId})
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.SaveOverride", "store.sql_channel_membership_rule.save_override.app_error", nil, "channel_id="+override.ChannelId+", user_id="+override.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		err = transaction.Insert(override)
+	} else {
+		_, err = transaction.Update(override)
+	}
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.SaveOverride", "store.sql_channel_membership_rule.save_override.app_error", nil, "channel_id="+override.ChannelId+", user_id="+override.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.SaveOverride", "store.sql_channel_membership_rule.save_override.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return override, nil
+}
+
+func (s SqlChannelMembershipRuleStore) GetOverride(channelId, userId string) (*model.ChannelMembershipOverride, *model.AppError) {
+	var override model.ChannelMembershipOverride
+
+	if err := s.GetReplica().SelectOne(&override, "SELECT * FROM ChannelMembershipOverrides WHERE ChannelId = :ChannelId AND UserId = :UserId", map[string]interface{}{"ChannelId": channelId, "UserId": userId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlChannelMembershipRuleStore.GetOverride", "store.sql_channel_membership_rule.get_override.app_error", nil, "channel_id="+channelId+", user_id="+userId+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.GetOverride", "store.sql_channel_membership_rule.get_override.app_error", nil, "channel_id="+channelId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &override, nil
+}
+
+func (s SqlChannelMembershipRuleStore) GetOverridesForChannel(channelId string) ([]*model.ChannelMembershipOverride, *model.AppError) {
+	var overrides []*model.ChannelMembershipOverride
+
+	if _, err := s.GetReplica().Select(&overrides, "SELECT * FROM ChannelMembershipOverrides WHERE ChannelId = :ChannelId ORDER BY CreateAt, UserId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, model.NewAppError("SqlChannelMembershipRuleStore.GetOverridesForChannel", "store.sql_channel_membership_rule.get_overrides_for_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return overrides, nil
+}
+
+func (s SqlChannelMembershipRuleStore) DeleteOverride(channelId, userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelMembershipOverrides WHERE ChannelId = :ChannelId AND UserId = :UserId", map[string]interface{}{"ChannelId": channelId, "UserId": userId}); err != nil {
+		return model.NewAppError("SqlChannelMembershipRuleStore.DeleteOverride", "store.sql_channel_membership_rule.delete_override.app_error", nil, "channel_id="+channelId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// PermanentDeleteByChannel deletes the membership rules of the channel along with its overrides.
+func (s SqlChannelMembershipRuleStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelMembershipRules WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlChannelMembershipRuleStore.PermanentDeleteByChannel", "store.sql_channel_membership_rule.permanent_delete_by_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelMembershipOverrides WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlChannelMembershipRuleStore.PermanentDeleteByChannel", "store.sql_channel_membership_rule.permanent_delete_by_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlChannelMembershipRuleStore) PermanentDeleteOverridesByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelMembershipOverrides WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlChannelMembershipRuleStore.PermanentDeleteOverridesByUser", "store.sql_channel_membership_rule.permanent_delete_overrides_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
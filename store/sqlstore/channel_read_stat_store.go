@@ -0,0 +1,132 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlChannelReadStatStore struct {
+	SqlStore
+}
+
+func NewSqlChannelReadStatStore(sqlStore SqlStore) store.ChannelReadStatStore {
+	s := &SqlChannelReadStatStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.ChannelReadStat{}, "ChannelReadStats").SetKeys(false, "PostId")
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlChannelReadStatStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_channelreadstats_channel_id", "ChannelReadStats", "ChannelId")
+	s.CreateIndexIfNotExists("idx_channelreadstats_team_id", "ChannelReadStats", "TeamId")
+	s.CreateIndexIfNotExists("idx_channelreadstats_post_create_at", "ChannelReadStats", "PostCreateAt")
+}
+
+// Save stores the stat of a post, replacing the one previously computed for it if any.
+func (s SqlChannelReadStatStore) Save(stat *model.ChannelReadStat) (*model.ChannelReadStat, *model.AppError) {
+	stat.PreSave()
+	if err := stat.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(stat)
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelReadStatStore.Save", "store.sql_channel_read_stat.save.app_error", nil, "post_id="+stat.PostId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		if err := s.GetMaster().Insert(stat); err != nil {
+			return nil, model.NewAppError("SqlChannelReadStatStore.Save", "store.sql_channel_read_stat.save.app_error", nil, "post_id="+stat.PostId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return stat, nil
+}
+
+// Compute measures, for every root post created in [since, until) in an open or private channel with at least the
+// given number of members besides the author, how many of those members have viewed the channel since the post was
+// made. Nothing identifying the members is returned.
+func (s SqlChannelReadStatStore) Compute(since, until int64, minimumMembers int) ([]*model.ChannelReadStat, *model.AppError) {
+	var stats []*model.ChannelReadStat
+
+	if _, err := s.GetReplica().Select(&stats, `
+		SELECT
+			p.Id AS PostId,
+			p.ChannelId AS ChannelId,
+			c.TeamId AS TeamId,
+			p.CreateAt AS PostCreateAt,
+			COUNT(cm.UserId) AS MemberCount,
+			SUM(CASE WHEN cm.LastViewedAt >= p.CreateAt THEN 1 ELSE 0 END) AS ReadCount
+		FROM
+			Posts p
+			INNER JOIN Channels c ON c.Id = p.ChannelId
+			INNER JOIN ChannelMembers cm ON cm.ChannelId = p.ChannelId AND cm.UserId != p.UserId
+		WHERE
+			p.CreateAt >= :Since
+			AND p.CreateAt < :Until
+			AND p.DeleteAt = 0
+			AND p.RootId = ''
+			AND p.Type NOT LIKE '`+model.POST_SYSTEM_MESSAGE_PREFIX+`%'
+			AND c.Type IN ('`+model.CHANNEL_OPEN+`', '`+model.CHANNEL_PRIVATE+`')
+			AND c.DeleteAt = 0
+		GROUP BY
+			p.Id, p.ChannelId, c.TeamId, p.CreateAt
+		HAVING
+			COUNT(cm.UserId) >= :MinimumMembers`, map[string]interface{}{"Since": since, "Until": until, "MinimumMembers": minimumMembers}); err != nil {
+		return nil, model.NewAppError("SqlChannelReadStatStore.Compute", "store.sql_channel_read_stat.compute.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return stats, nil
+}
+
+// Get returns the stats of the posts created in [since, until), newest first, optionally restricted to a team or to
+// a channel.
+func (s SqlChannelReadStatStore) Get(teamId, channelId string, since, until int64, offset, limit int) ([]*model.ChannelReadStat, *model.AppError) {
+	query := s.getQueryBuilder().
+		Select("*").
+		From("ChannelReadStats").
+		Where("PostCreateAt >= ?", since).
+		Where("PostCreateAt < ?", until).
+		OrderBy("PostCreateAt DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if teamId != "" {
+		query = query.Where("TeamId = ?", teamId)
+	}
+
+	if channelId != "" {
+		query = query.Where("ChannelId = ?", channelId)
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelReadStatStore.Get", "store.sql_channel_read_stat.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var stats []*model.ChannelReadStat
+	if _, err := s.GetReplica().Select(&stats, queryString, args...); err != nil {
+		return nil, model.NewAppError("SqlChannelReadStatStore.Get", "store.sql_channel_read_stat.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return stats, nil
+}
+
+func (s SqlChannelReadStatStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelReadStats WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlChannelReadStatStore.PermanentDeleteByChannel", "store.sql_channel_read_stat.permanent_delete_by_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -328,6 +328,11 @@ func NewSqlChannelStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface)
 		table.ColMap("Purpose").SetMaxSize(250)
 		table.ColMap("CreatorId").SetMaxSize(26)
 		table.ColMap("SchemeId").SetMaxSize(26)
+		table.ColMap("ReplyBroadcastPolicy").SetMaxSize(32)
+		table.ColMap("AllowedWebhookIds").SetMaxSize(1500)
+		table.ColMap("AllowedBotIds").SetMaxSize(1500)
+		table.ColMap("WelcomeMessage").SetMaxSize(model.CHANNEL_WELCOME_MESSAGE_MAX_RUNES)
+		table.ColMap("WelcomeMessageType").SetMaxSize(16)
 
 		tablem := db.AddTableWithName(channelMember{}, "ChannelMembers").SetKeys(false, "ChannelId", "UserId")
 		tablem.ColMap("ChannelId").SetMaxSize(26)
@@ -897,9 +902,9 @@ func (s SqlChannelStore) PermanentDeleteMembersByChannel(channelId string) *mode
 }
 
 func (s SqlChannelStore) GetChannels(teamId string, userId string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
-	query := "SELECT Channels.* FROM Channels, ChannelMembers WHERE Id = ChannelId AND UserId = :UserId AND DeleteAt = 0 AND (TeamId = :TeamId OR TeamId = '') ORDER BY DisplayName"
+	query := "SELECT Channels.* FROM Channels, ChannelMembers WHERE Id = ChannelId AND UserId = :UserId AND DeleteAt = 0 AND (TeamId = :TeamId OR TeamId = '' OR Id IN (SELECT ChannelId FROM ChannelTeamBindings WHERE TeamId = :TeamId)) ORDER BY DisplayName"
 	if includeDeleted {
-		query = "SELECT Channels.* FROM Channels, ChannelMembers WHERE Id = ChannelId AND UserId = :UserId AND (TeamId = :TeamId OR TeamId = '') ORDER BY DisplayName"
+		query = "SELECT Channels.* FROM Channels, ChannelMembers WHERE Id = ChannelId AND UserId = :UserId AND (TeamId = :TeamId OR TeamId = '' OR Id IN (SELECT ChannelId FROM ChannelTeamBindings WHERE TeamId = :TeamId)) ORDER BY DisplayName"
 	}
 	channels := &model.ChannelList{}
 	_, err := s.GetReplica().Select(channels, query, map[string]interface{}{"TeamId": teamId, "UserId": userId})
@@ -993,19 +998,15 @@ func (s SqlChannelStore) GetMoreChannels(teamId string, userId string, offset in
 		JOIN
 			PublicChannels c ON (c.Id = Channels.Id)
 		WHERE
-			c.TeamId = :TeamId
+			(c.TeamId = :TeamId OR c.Id IN (SELECT ChannelId FROM ChannelTeamBindings WHERE TeamId = :TeamId))
 		AND c.DeleteAt = 0
 		AND c.Id NOT IN (
 			SELECT
-				c.Id
+				cm.ChannelId
 			FROM
-				PublicChannels c
-			JOIN
-				ChannelMembers cm ON (cm.ChannelId = c.Id)
+				ChannelMembers cm
 			WHERE
-				c.TeamId = :TeamId
-			AND cm.UserId = :UserId
-			AND c.DeleteAt = 0
+				cm.UserId = :UserId
 		)
 		ORDER BY
 			c.DisplayName
@@ -1361,6 +1362,91 @@ func (s SqlChannelStore) GetMembers(channelId string, offset, limit int) (*model
 	return dbMembers.ToModel(), nil
 }
 
+// channelMemberRoleFilters are the conditions restricting members to the ones with each scheme role.
+var channelMemberRoleFilters = map[string]string{
+	model.CHANNEL_MEMBER_ROLE_FILTER_ADMIN: "ChannelMembers.SchemeAdmin = true",
+	model.CHANNEL_MEMBER_ROLE_FILTER_USER:  "ChannelMembers.SchemeUser = true AND (ChannelMembers.SchemeAdmin IS NULL OR ChannelMembers.SchemeAdmin = false)",
+	model.CHANNEL_MEMBER_ROLE_FILTER_GUEST: "ChannelMembers.SchemeGuest = true",
+}
+
+// GetMembersWithOptions returns a page of the members of the channel whose users are active, sorted and filtered
+// according to the options.
+func (s SqlChannelStore) GetMembersWithOptions(channelId string, offset, limit int, options *model.ChannelMembersGetOptions) (*model.ChannelMembers, *model.AppError) {
+	query := CHANNEL_MEMBERS_WITH_SCHEME_SELECT_QUERY + `
+	INNER JOIN
+		Users ON ChannelMembers.UserId = Users.Id`
+
+	orderBy := "Users.Username ASC"
+	switch options.Sort {
+	case model.CHANNEL_MEMBERS_SORT_LAST_POST:
+		query += `
+	LEFT JOIN
+		(SELECT UserId, MAX(CreateAt) AS LastPostAt FROM Posts WHERE ChannelId = :ChannelId AND DeleteAt = 0 GROUP BY UserId) LastPosts
+		ON ChannelMembers.UserId = LastPosts.UserId`
+		orderBy = "COALESCE(LastPosts.LastPostAt, 0) DESC, Users.Username ASC"
+	case model.CHANNEL_MEMBERS_SORT_ROLE:
+		orderBy = "CASE WHEN ChannelMembers.SchemeAdmin = true THEN 0 WHEN ChannelMembers.SchemeGuest = true THEN 2 ELSE 1 END, Users.Username ASC"
+	}
+
+	query += `
+	WHERE
+		ChannelMembers.ChannelId = :ChannelId
+		AND Users.DeleteAt = 0`
+
+	if filter, ok := channelMemberRoleFilters[options.Role]; ok {
+		query += " AND " + filter
+	}
+
+	query += " ORDER BY " + orderBy + " LIMIT :Limit OFFSET :Offset"
+
+	var dbMembers channelMemberWithSchemeRolesList
+	if _, err := s.GetReplica().Select(&dbMembers, query, map[string]interface{}{"ChannelId": channelId, "Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlChannelStore.GetMembersWithOptions", "store.sql_channel.get_members.app_error", nil, "channel_id="+channelId+","+err.Error(), http.StatusInternalServerError)
+	}
+
+	return dbMembers.ToModel(), nil
+}
+
+// GetMemberCountsByRole counts the members of the channel whose users are active, by scheme role.
+func (s SqlChannelStore) GetMemberCountsByRole(channelId string) (*model.ChannelMemberCounts, *model.AppError) {
+	var counts struct {
+		Total  int64
+		Admins int64
+		Users  int64
+		Guests int64
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(*) AS Total,
+			COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) AS Admins,
+			COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) AS Users,
+			COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) AS Guests
+		FROM
+			ChannelMembers
+		INNER JOIN
+			Users ON ChannelMembers.UserId = Users.Id
+		WHERE
+			ChannelMembers.ChannelId = :ChannelId
+			AND Users.DeleteAt = 0`,
+		channelMemberRoleFilters[model.CHANNEL_MEMBER_ROLE_FILTER_ADMIN],
+		channelMemberRoleFilters[model.CHANNEL_MEMBER_ROLE_FILTER_USER],
+		channelMemberRoleFilters[model.CHANNEL_MEMBER_ROLE_FILTER_GUEST],
+	)
+
+	if err := s.GetReplica().SelectOne(&counts, query, map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, model.NewAppError("SqlChannelStore.GetMemberCountsByRole", "store.sql_channel.get_member_counts_by_role.app_error", nil, "channel_id="+channelId+","+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &model.ChannelMemberCounts{
+		ChannelId: channelId,
+		Total:     counts.Total,
+		Admins:    counts.Admins,
+		Users:     counts.Users,
+		Guests:    counts.Guests,
+	}, nil
+}
+
 func (s SqlChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, *model.AppError) {
 	var dbMembersTimezone []model.StringMap
 	_, err := s.GetReplica().Select(&dbMembersTimezone, `
@@ -1898,6 +1984,26 @@ func (s SqlChannelStore) IncrementMentionCount(channelId string, userId string)
 	return nil
 }
 
+// IncrementMsgCount marks one more message of a channel as read by a member without updating when they last viewed
+// it, so that a post made on their behalf doesn't show as unread while earlier posts still do.
+func (s SqlChannelStore) IncrementMsgCount(channelId string, userId string) *model.AppError {
+	_, err := s.GetMaster().Exec(
+		`UPDATE
+			ChannelMembers
+		SET
+			MsgCount = MsgCount + 1,
+			LastUpdateAt = :LastUpdateAt
+		WHERE
+			UserId = :UserId
+				AND ChannelId = :ChannelId`,
+		map[string]interface{}{"ChannelId": channelId, "UserId": userId, "LastUpdateAt": model.GetMillis()})
+	if err != nil {
+		return model.NewAppError("SqlChannelStore.IncrementMsgCount", "store.sql_channel.increment_msg_count.app_error", nil, "channel_id="+channelId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
 func (s SqlChannelStore) GetAll(teamId string) ([]*model.Channel, *model.AppError) {
 	var data []*model.Channel
 	_, err := s.GetReplica().Select(&data, "SELECT * FROM Channels WHERE TeamId = :TeamId AND Type != 'D' ORDER BY Name", map[string]interface{}{"TeamId": teamId})
@@ -2781,3 +2887,80 @@ func (s SqlChannelStore) UserBelongsToChannels(userId string, channelIds []strin
 	}
 	return c > 0, nil
 }
+
+// GetDirectMessagePartners returns the users the given user has exchanged direct messages with, along with the time
+// of the last message in their direct channel, for the limit most recent ones.
+func (s SqlChannelStore) GetDirectMessagePartners(userId string, limit int) (map[string]int64, *model.AppError) {
+	query := s.getQueryBuilder().
+		Select("Partner.UserId AS UserId, Channels.LastPostAt AS LastPostAt").
+		From("ChannelMembers Self").
+		Join("Channels ON Channels.Id = Self.ChannelId").
+		Join("ChannelMembers Partner ON Partner.ChannelId = Self.ChannelId AND Partner.UserId != Self.UserId").
+		Where(sq.And{
+			sq.Eq{"Self.UserId": userId},
+			sq.Eq{"Channels.Type": model.CHANNEL_DIRECT},
+			sq.Eq{"Channels.DeleteAt": int(0)},
+			sq.Gt{"Channels.LastPostAt": int(0)},
+		}).
+		OrderBy("Channels.LastPostAt DESC").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelStore.GetDirectMessagePartners", "store.sql_channel.get_direct_message_partners.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var partners []struct {
+		UserId     string
+		LastPostAt int64
+	}
+	if _, err := s.GetReplica().Select(&partners, queryString, args...); err != nil {
+		return nil, model.NewAppError("SqlChannelStore.GetDirectMessagePartners", "store.sql_channel.get_direct_message_partners.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	lastPostAt := make(map[string]int64, len(partners))
+	for _, partner := range partners {
+		lastPostAt[partner.UserId] = partner.LastPostAt
+	}
+
+	return lastPostAt, nil
+}
+
+// GetSharedChannelCounts returns the users who are members of private or group channels the given user is a member
+// of, along with how many of these channels they share, for the limit users sharing the most. Public channels are
+// left out since anyone can join them.
+func (s SqlChannelStore) GetSharedChannelCounts(userId string, limit int) (map[string]int64, *model.AppError) {
+	query := s.getQueryBuilder().
+		Select("Other.UserId AS UserId, COUNT(*) AS Shared").
+		From("ChannelMembers Self").
+		Join("Channels ON Channels.Id = Self.ChannelId").
+		Join("ChannelMembers Other ON Other.ChannelId = Self.ChannelId AND Other.UserId != Self.UserId").
+		Where(sq.And{
+			sq.Eq{"Self.UserId": userId},
+			sq.Eq{"Channels.Type": []string{model.CHANNEL_PRIVATE, model.CHANNEL_GROUP}},
+			sq.Eq{"Channels.DeleteAt": int(0)},
+		}).
+		GroupBy("Other.UserId").
+		OrderBy("Shared DESC").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelStore.GetSharedChannelCounts", "store.sql_channel.get_shared_channel_counts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var members []struct {
+		UserId string
+		Shared int64
+	}
+	if _, err := s.GetReplica().Select(&members, queryString, args...); err != nil {
+		return nil, model.NewAppError("SqlChannelStore.GetSharedChannelCounts", "store.sql_channel.get_shared_channel_counts.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	counts := make(map[string]int64, len(members))
+	for _, member := range members {
+		counts[member.UserId] = member.Shared
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlChannelTeamBindingStore struct {
+	SqlStore
+}
+
+func NewSqlChannelTeamBindingStore(sqlStore SqlStore) store.ChannelTeamBindingStore {
+	s := &SqlChannelTeamBindingStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.ChannelTeamBinding{}, "ChannelTeamBindings").SetKeys(false, "ChannelId", "TeamId")
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlChannelTeamBindingStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_channelteambindings_team_id", "ChannelTeamBindings", "TeamId")
+}
+
+func (s SqlChannelTeamBindingStore) Save(binding *model.ChannelTeamBinding) (*model.ChannelTeamBinding, *model.AppError) {
+	binding.PreSave()
+	if err := binding.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(binding); err != nil {
+		if IsUniqueConstraintError(err, []string{"PRIMARY", "channelteambindings_pkey"}) {
+			return nil, model.NewAppError("SqlChannelTeamBindingStore.Save", "store.sql_channel_team_binding.save.exists.app_error", nil, "channel_id="+binding.ChannelId+", team_id="+binding.TeamId+", "+err.Error(), http.StatusBadRequest)
+		}
+		return nil, model.NewAppError("SqlChannelTeamBindingStore.Save", "store.sql_channel_team_binding.save.app_error", nil, "channel_id="+binding.ChannelId+", team_id="+binding.TeamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return binding, nil
+}
+
+func (s SqlChannelTeamBindingStore) Get(channelId, teamId string) (*model.ChannelTeamBinding, *model.AppError) {
+	var binding model.ChannelTeamBinding
+
+	if err := s.GetReplica().SelectOne(&binding, "SELECT * FROM ChannelTeamBindings WHERE ChannelId = :ChannelId AND TeamId = :TeamId", map[string]interface{}{"ChannelId": channelId, "TeamId": teamId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlChannelTeamBindingStore.Get", "store.sql_channel_team_binding.get.app_error", nil, "channel_id="+channelId+", team_id="+teamId+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlChannelTeamBindingStore.Get", "store.sql_channel_team_binding.get.app_error", nil, "channel_id="+channelId+", team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &binding, nil
+}
+
+func (s SqlChannelTeamBindingStore) GetForChannel(channelId string) ([]*model.ChannelTeamBinding, *model.AppError) {
+	var bindings []*model.ChannelTeamBinding
+
+	if _, err := s.GetReplica().Select(&bindings, "SELECT * FROM ChannelTeamBindings WHERE ChannelId = :ChannelId ORDER BY CreateAt, TeamId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, model.NewAppError("SqlChannelTeamBindingStore.GetForChannel", "store.sql_channel_team_binding.get_for_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return bindings, nil
+}
+
+// GetChannelByName returns the channel with the given name bound to the team.
+func (s SqlChannelTeamBindingStore) GetChannelByName(teamId, name string, includeDeleted bool) (*model.Channel, *model.AppError) {
+	query := `
+		SELECT
+			Channels.*
+		FROM
+			Channels
+		JOIN
+			ChannelTeamBindings ON (ChannelTeamBindings.ChannelId = Channels.Id)
+		WHERE
+			ChannelTeamBindings.TeamId = :TeamId
+			AND Channels.Name = :Name`
+
+	if !includeDeleted {
+		query += " AND Channels.DeleteAt = 0"
+	}
+
+	var channel model.Channel
+	if err := s.GetReplica().SelectOne(&channel, query, map[string]interface{}{"TeamId": teamId, "Name": name}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlChannelTeamBindingStore.GetChannelByName", "store.sql_channel.get_by_name.missing.app_error", nil, "team_id="+teamId+", name="+name+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlChannelTeamBindingStore.GetChannelByName", "store.sql_channel_team_binding.get_channel_by_name.app_error", nil, "team_id="+teamId+", name="+name+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &channel, nil
+}
+
+func (s SqlChannelTeamBindingStore) Delete(channelId, teamId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelTeamBindings WHERE ChannelId = :ChannelId AND TeamId = :TeamId", map[string]interface{}{"ChannelId": channelId, "TeamId": teamId}); err != nil {
+		return model.NewAppError("SqlChannelTeamBindingStore.Delete", "store.sql_channel_team_binding.delete.app_error", nil, "channel_id="+channelId+", team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlChannelTeamBindingStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelTeamBindings WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlChannelTeamBindingStore.PermanentDeleteByChannel", "store.sql_channel_team_binding.permanent_delete_by_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlChannelTeamBindingStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelTeamBindings WHERE TeamId = :TeamId", map[string]interface{}{"TeamId": teamId}); err != nil {
+		return model.NewAppError("SqlChannelTeamBindingStore.PermanentDeleteByTeam", "store.sql_channel_team_binding.permanent_delete_by_team.app_error", nil, "team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -252,3 +252,55 @@ func (s SqlComplianceStore) MessageExport(after int64, limit int) ([]*model.Mess
 	}
 	return cposts, nil
 }
+
+// MessageExportChanges returns the posts updated after a cursor, ordered by when they were last updated and then by
+// id, as change records for consumers of the message export stream. The copies of edited posts that keep their
+// history are left out, since the edits themselves appear in the stream.
+func (s SqlComplianceStore) MessageExportChanges(cursor model.MessageExportCursor, limit int) ([]*model.MessageExportChange, *model.AppError) {
+	props := map[string]interface{}{"UpdateAt": cursor.UpdateAt, "PostId": cursor.PostId, "Limit": limit}
+	query :=
+		`SELECT
+			Posts.Id AS PostId,
+			Posts.CreateAt,
+			Posts.UpdateAt,
+			Posts.EditAt,
+			Posts.DeleteAt,
+			Posts.RootId,
+			Posts.Type,
+			Posts.Message,
+			Posts.Props,
+			Posts.FileIds,
+			COALESCE(Teams.Id, '') AS TeamId,
+			COALESCE(Teams.Name, '') AS TeamName,
+			Posts.ChannelId,
+			COALESCE(Channels.Name, '') AS ChannelName,
+			COALESCE(Channels.DisplayName, '') AS ChannelDisplayName,
+			COALESCE(Channels.Type, '') AS ChannelType,
+			Posts.UserId,
+			COALESCE(Users.Username, '') AS Username,
+			COALESCE(Users.Email, '') AS UserEmail,
+			Bots.UserId IS NOT NULL AS IsBot
+		FROM
+			Posts
+		LEFT OUTER JOIN Channels ON Posts.ChannelId = Channels.Id
+		LEFT OUTER JOIN Teams ON Channels.TeamId = Teams.Id
+		LEFT OUTER JOIN Users ON Posts.UserId = Users.Id
+		LEFT JOIN Bots ON Bots.UserId = Posts.UserId
+		WHERE
+			(Posts.UpdateAt > :UpdateAt OR (Posts.UpdateAt = :UpdateAt AND Posts.Id > :PostId)) AND
+			Posts.OriginalId = '' AND
+			Posts.Type NOT LIKE 'system_%'
+		ORDER BY Posts.UpdateAt, Posts.Id
+		LIMIT :Limit`
+
+	changes := []*model.MessageExportChange{}
+	if _, err := s.GetReplica().Select(&changes, query, props); err != nil {
+		return nil, model.NewAppError("SqlComplianceStore.MessageExportChanges", "store.sql_compliance.message_export_changes.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, change := range changes {
+		change.SetChangeType()
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlConfigAuditStore struct {
+	SqlStore
+}
+
+func NewSqlConfigAuditStore(sqlStore SqlStore) store.ConfigAuditStore {
+	s := &SqlConfigAuditStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.ConfigAudit{}, "ConfigAudits").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("RollbackOf").SetMaxSize(26)
+		table.ColMap("Changes").SetMaxSize(65535)
+		table.ColMap("Config").SetMaxSize(65535)
+	}
+
+	return s
+}
+
+func (s SqlConfigAuditStore) CreateIndexesIfNotExists() {
+	// A whole configuration can outgrow the TEXT columns of MySQL.
+	s.AlterColumnTypeIfExists("ConfigAudits", "Changes", "MEDIUMTEXT", "text")
+	s.AlterColumnTypeIfExists("ConfigAudits", "Config", "MEDIUMTEXT", "text")
+
+	s.CreateIndexIfNotExists("idx_configaudits_create_at", "ConfigAudits", "CreateAt")
+}
+
+func (s SqlConfigAuditStore) Save(audit *model.ConfigAudit) (*model.ConfigAudit, *model.AppError) {
+	audit.PreSave()
+	if err := audit.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(audit); err != nil {
+		return nil, model.NewAppError("SqlConfigAuditStore.Save", "store.sql_config_audit.save.app_error", nil, "id="+audit.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return audit, nil
+}
+
+func (s SqlConfigAuditStore) Get(id string) (*model.ConfigAudit, *model.AppError) {
+	var audit model.ConfigAudit
+
+	if err := s.GetReplica().SelectOne(&audit, "SELECT * FROM ConfigAudits WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlConfigAuditStore.Get", "store.sql_config_audit.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlConfigAuditStore.Get", "store.sql_config_audit.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+	audit.PostLoad()
+
+	return &audit, nil
+}
+
+// GetAll returns a page of the configuration changes, starting with the most recent.
+func (s SqlConfigAuditStore) GetAll(offset, limit int) ([]*model.ConfigAudit, *model.AppError) {
+	var audits []*model.ConfigAudit
+
+	if _, err := s.GetReplica().Select(&audits, "SELECT * FROM ConfigAudits ORDER BY CreateAt DESC, Id LIMIT :Limit OFFSET :Offset", map[string]interface{}{"Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlConfigAuditStore.GetAll", "store.sql_config_audit.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, audit := range audits {
+		audit.PostLoad()
+	}
+
+	return audits, nil
+}
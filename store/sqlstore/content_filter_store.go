@@ -0,0 +1,116 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlContentFilterStore struct {
+	SqlStore
+}
+
+func NewSqlContentFilterStore(sqlStore SqlStore) store.ContentFilterStore {
+	s := &SqlContentFilterStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.ContentFilter{}, "ContentFilters").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("Name").SetMaxSize(model.CONTENT_FILTER_NAME_MAX_LENGTH)
+		table.ColMap("Action").SetMaxSize(16)
+		table.ColMap("Words").SetMaxSize(model.CONTENT_FILTER_MAX_WORDS * (model.CONTENT_FILTER_WORD_MAX_LENGTH + 3))
+		table.ColMap("Patterns").SetMaxSize(model.CONTENT_FILTER_MAX_PATTERNS * (model.CONTENT_FILTER_PATTERN_MAX_LENGTH + 3))
+	}
+
+	return s
+}
+
+func (s SqlContentFilterStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_contentfilters_team_id", "ContentFilters", "TeamId")
+	s.CreateIndexIfNotExists("idx_contentfilters_delete_at", "ContentFilters", "DeleteAt")
+}
+
+func (s SqlContentFilterStore) Save(filter *model.ContentFilter) (*model.ContentFilter, *model.AppError) {
+	if len(filter.Id) > 0 {
+		return nil, model.NewAppError("SqlContentFilterStore.Save", "store.sql_content_filter.save.existing.app_error", nil, "id="+filter.Id, http.StatusBadRequest)
+	}
+
+	filter.PreSave()
+	if err := filter.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(filter); err != nil {
+		return nil, model.NewAppError("SqlContentFilterStore.Save", "store.sql_content_filter.save.app_error", nil, "id="+filter.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return filter, nil
+}
+
+func (s SqlContentFilterStore) Update(filter *model.ContentFilter) (*model.ContentFilter, *model.AppError) {
+	filter.PreUpdate()
+	if err := filter.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().Update(filter); err != nil {
+		return nil, model.NewAppError("SqlContentFilterStore.Update", "store.sql_content_filter.update.app_error", nil, "id="+filter.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return filter, nil
+}
+
+func (s SqlContentFilterStore) Get(id string) (*model.ContentFilter, *model.AppError) {
+	var filter model.ContentFilter
+
+	if err := s.GetReplica().SelectOne(&filter, "SELECT * FROM ContentFilters WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlContentFilterStore.Get", "store.sql_content_filter.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlContentFilterStore.Get", "store.sql_content_filter.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &filter, nil
+}
+
+func (s SqlContentFilterStore) GetAll(offset, limit int) ([]*model.ContentFilter, *model.AppError) {
+	var filters []*model.ContentFilter
+
+	if _, err := s.GetReplica().Select(&filters, "SELECT * FROM ContentFilters WHERE DeleteAt = 0 ORDER BY Name, TeamId LIMIT :Limit OFFSET :Offset", map[string]interface{}{"Offset": offset, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlContentFilterStore.GetAll", "store.sql_content_filter.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return filters, nil
+}
+
+// GetForTeam returns the filters which apply to posts in the team, which are the ones of the team and the ones
+// that apply to all teams.
+func (s SqlContentFilterStore) GetForTeam(teamId string) ([]*model.ContentFilter, *model.AppError) {
+	var filters []*model.ContentFilter
+
+	if _, err := s.GetReplica().Select(&filters, "SELECT * FROM ContentFilters WHERE (TeamId = :TeamId OR TeamId = '') AND DeleteAt = 0 ORDER BY CreateAt", map[string]interface{}{"TeamId": teamId}); err != nil {
+		return nil, model.NewAppError("SqlContentFilterStore.GetForTeam", "store.sql_content_filter.get_for_team.app_error", nil, "team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return filters, nil
+}
+
+func (s SqlContentFilterStore) Delete(id string, time int64) *model.AppError {
+	sqlResult, err := s.GetMaster().Exec("UPDATE ContentFilters SET DeleteAt = :DeleteAt, UpdateAt = :UpdateAt WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"DeleteAt": time, "UpdateAt": time, "Id": id})
+	if err != nil {
+		return model.NewAppError("SqlContentFilterStore.Delete", "store.sql_content_filter.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if rows, _ := sqlResult.RowsAffected(); rows == 0 {
+		return model.NewAppError("SqlContentFilterStore.Delete", "store.sql_content_filter.get.app_error", nil, "id="+id, http.StatusNotFound)
+	}
+
+	return nil
+}
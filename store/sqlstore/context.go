@@ -0,0 +1,144 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/gorp"
+)
+
+var namedParamRegexp = regexp.MustCompile(`:[[:word:]]+`)
+
+// selectContext binds the results of the query into the holder like gorp's Select does, but runs the query with the
+// given context so that it's aborted as soon as the context is done, such as when the request it's made for is
+// cancelled. The query timeout of the database still applies when the context has no earlier deadline.
+//
+// The holder must be a pointer to a slice of structs or of pointers to structs. Like with gorp, a single map argument
+// provides the values of the named parameters of the query.
+func selectContext(ctx context.Context, db *gorp.DbMap, holder interface{}, query string, args ...interface{}) error {
+	sliceValue := reflect.ValueOf(holder)
+	if sliceValue.Kind() != reflect.Ptr || sliceValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("selectContext: holder must be a pointer to a slice, got %T", holder)
+	}
+	sliceValue = sliceValue.Elem()
+
+	elemType := sliceValue.Type().Elem()
+	pointerElements := elemType.Kind() == reflect.Ptr
+	if pointerElements {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("selectContext: holder must be a pointer to a slice of structs, got %T", holder)
+	}
+
+	if len(args) == 1 {
+		if params, ok := args[0].(map[string]interface{}); ok {
+			query, args = expandNamedParams(db, query, params)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, db.QueryTimeout)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	fieldIndexes := columnFieldIndexes(elemType, columns)
+
+	for rows.Next() {
+		elem := reflect.New(elemType)
+
+		dest := make([]interface{}, len(columns))
+		var scanners []gorp.CustomScanner
+		for i, fieldIndex := range fieldIndexes {
+			// Columns that aren't part of the struct are ignored, as gorp does.
+			if fieldIndex == nil {
+				dest[i] = new(interface{})
+				continue
+			}
+
+			target := elem.Elem().FieldByIndex(fieldIndex).Addr().Interface()
+			if db.TypeConverter != nil {
+				if scanner, ok := db.TypeConverter.FromDb(target); ok {
+					target = scanner.Holder
+					scanners = append(scanners, scanner)
+				}
+			}
+			dest[i] = target
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		for _, scanner := range scanners {
+			if err := scanner.Bind(); err != nil {
+				return err
+			}
+		}
+
+		if pointerElements {
+			sliceValue.Set(reflect.Append(sliceValue, elem))
+		} else {
+			sliceValue.Set(reflect.Append(sliceValue, elem.Elem()))
+		}
+	}
+
+	return rows.Err()
+}
+
+// expandNamedParams replaces the named parameters of the query, such as :UserId, by the placeholders of the database,
+// returning the values to bind to them in order. Names that aren't in the map are left untouched, as gorp does.
+func expandNamedParams(db *gorp.DbMap, query string, params map[string]interface{}) (string, []interface{}) {
+	var args []interface{}
+
+	query = namedParamRegexp.ReplaceAllStringFunc(query, func(key string) string {
+		value, ok := params[key[1:]]
+		if !ok {
+			return key
+		}
+
+		placeholder := db.Dialect.BindVar(len(args))
+		args = append(args, value)
+		return placeholder
+	})
+
+	return query, args
+}
+
+// columnFieldIndexes returns the index of the field of the struct each column is bound to, matching the names of the
+// columns to the names of the fields, or to their db tags, regardless of case. Columns that don't match any field
+// have a nil index.
+func columnFieldIndexes(t reflect.Type, columns []string) [][]int {
+	fieldIndexes := make([][]int, len(columns))
+
+	for i, column := range columns {
+		column = strings.ToLower(column)
+
+		field, found := t.FieldByNameFunc(func(fieldName string) bool {
+			field, _ := t.FieldByName(fieldName)
+			if tagName := strings.Split(field.Tag.Get("db"), ",")[0]; tagName != "" && tagName != "-" {
+				fieldName = tagName
+			}
+			return strings.ToLower(fieldName) == column
+		})
+		if found {
+			fieldIndexes[i] = field.Index
+		}
+	}
+
+	return fieldIndexes
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlDailyStatStore struct {
+	SqlStore
+}
+
+func NewSqlDailyStatStore(sqlStore SqlStore) store.DailyStatStore {
+	s := &SqlDailyStatStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.DailyStat{}, "DailyStats").SetKeys(false, "Date", "TeamId", "ChannelId")
+		table.ColMap("Date").SetMaxSize(10)
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlDailyStatStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_dailystats_team_id", "DailyStats", "TeamId")
+	s.CreateIndexIfNotExists("idx_dailystats_channel_id", "DailyStats", "ChannelId")
+}
+
+// Save stores the stat, replacing the one previously computed for the same date and scope if any.
+func (s SqlDailyStatStore) Save(stat *model.DailyStat) (*model.DailyStat, *model.AppError) {
+	stat.PreSave()
+	if err := stat.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(stat)
+	if err != nil {
+		return nil, model.NewAppError("SqlDailyStatStore.Save", "store.sql_daily_stat.save.app_error", nil, "date="+stat.Date+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		if err := s.GetMaster().Insert(stat); err != nil {
+			return nil, model.NewAppError("SqlDailyStatStore.Save", "store.sql_daily_stat.save.app_error", nil, "date="+stat.Date+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return stat, nil
+}
+
+type dailyChannelActivity struct {
+	ChannelId       string
+	TeamId          string
+	Type            string
+	PostCount       int64
+	ActiveUserCount int64
+	FileCount       int64
+	FileSize        int64
+}
+
+type dailyTeamActivity struct {
+	TeamId          string
+	ActiveUserCount int64
+}
+
+// Compute measures the activity in [since, until) and returns it as the stats of the given date: one for each open or
+// private channel that had any, one for each of their teams, and the system wide one, which is always returned. Posts
+// are counted the same way as the post analytics, including the deleted ones and system messages.
+func (s SqlDailyStatStore) Compute(date string, since, until int64) ([]*model.DailyStat, *model.AppError) {
+	params := map[string]interface{}{"Since": since, "Until": until}
+
+	var posts []*dailyChannelActivity
+	if _, err := s.GetReplica().Select(&posts, `
+		SELECT
+			p.ChannelId AS ChannelId,
+			c.TeamId AS TeamId,
+			c.Type AS Type,
+			COUNT(p.Id) AS PostCount,
+			COUNT(DISTINCT p.UserId) AS ActiveUserCount
+		FROM
+			Posts p
+			INNER JOIN Channels c ON c.Id = p.ChannelId
+		WHERE
+			p.CreateAt >= :Since
+			AND p.CreateAt < :Until
+		GROUP BY
+			p.ChannelId, c.TeamId, c.Type`, params); err != nil {
+		return nil, model.NewAppError("SqlDailyStatStore.Compute", "store.sql_daily_stat.compute.app_error", nil, "date="+date+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	var files []*dailyChannelActivity
+	if _, err := s.GetReplica().Select(&files, `
+		SELECT
+			p.ChannelId AS ChannelId,
+			c.TeamId AS TeamId,
+			c.Type AS Type,
+			COUNT(f.Id) AS FileCount,
+			COALESCE(SUM(f.Size), 0) AS FileSize
+		FROM
+			FileInfo f
+			INNER JOIN Posts p ON p.Id = f.PostId
+			INNER JOIN Channels c ON c.Id = p.ChannelId
+		WHERE
+			f.CreateAt >= :Since
+			AND f.CreateAt < :Until
+			AND f.DeleteAt = 0
+		GROUP BY
+			p.ChannelId, c.TeamId, c.Type`, params); err != nil {
+		return nil, model.NewAppError("SqlDailyStatStore.Compute", "store.sql_daily_stat.compute.app_error", nil, "date="+date+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	// Users active in several channels of a team are only counted once, so the active users can't be summed up from
+	// the channel stats.
+	var teams []*dailyTeamActivity
+	if _, err := s.GetReplica().Select(&teams, `
+		SELECT
+			c.TeamId AS TeamId,
+			COUNT(DISTINCT p.UserId) AS ActiveUserCount
+		FROM
+			Posts p
+			INNER JOIN Channels c ON c.Id = p.ChannelId
+		WHERE
+			p.CreateAt >= :Since
+			AND p.CreateAt < :Until
+			AND c.TeamId != ''
+		GROUP BY
+			c.TeamId`, params); err != nil {
+		return nil, model.NewAppError("SqlDailyStatStore.Compute", "store.sql_daily_stat.compute.app_error", nil, "date="+date+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	activeUsers, err := s.GetReplica().SelectInt(`
+		SELECT
+			COUNT(DISTINCT UserId)
+		FROM
+			Posts
+		WHERE
+			CreateAt >= :Since
+			AND CreateAt < :Until`, params)
+	if err != nil {
+		return nil, model.NewAppError("SqlDailyStatStore.Compute", "store.sql_daily_stat.compute.app_error", nil, "date="+date+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	system := &model.DailyStat{Date: date, ActiveUserCount: activeUsers}
+	statsByTeam := make(map[string]*model.DailyStat, len(teams))
+	for _, team := range teams {
+		statsByTeam[team.TeamId] = &model.DailyStat{Date: date, TeamId: team.TeamId, ActiveUserCount: team.ActiveUserCount}
+	}
+
+	statsByChannel := make(map[string]*model.DailyStat)
+	channelStats := []*model.DailyStat{}
+	for _, activity := range append(posts, files...) {
+		stat := &model.DailyStat{
+			Date:            date,
+			TeamId:          activity.TeamId,
+			ChannelId:       activity.ChannelId,
+			PostCount:       activity.PostCount,
+			ActiveUserCount: activity.ActiveUserCount,
+			FileCount:       activity.FileCount,
+			FileSize:        activity.FileSize,
+		}
+
+		system.Add(stat)
+
+		if activity.Type != model.CHANNEL_OPEN && activity.Type != model.CHANNEL_PRIVATE {
+			continue
+		}
+
+		teamStat, ok := statsByTeam[activity.TeamId]
+		if !ok {
+			teamStat = &model.DailyStat{Date: date, TeamId: activity.TeamId}
+			statsByTeam[activity.TeamId] = teamStat
+		}
+		teamStat.Add(stat)
+
+		if channelStat, ok := statsByChannel[activity.ChannelId]; ok {
+			channelStat.Add(stat)
+		} else {
+			statsByChannel[activity.ChannelId] = stat
+			channelStats = append(channelStats, stat)
+		}
+	}
+
+	stats := append(channelStats, system)
+	for _, teamStat := range statsByTeam {
+		stats = append(stats, teamStat)
+	}
+
+	return stats, nil
+}
+
+// Get returns the stats of a channel, of a team when no channel is given, or the system wide ones when neither is,
+// for the dates in [since, until], oldest first.
+func (s SqlDailyStatStore) Get(teamId, channelId string, since, until string) ([]*model.DailyStat, *model.AppError) {
+	var stats []*model.DailyStat
+	if _, err := s.GetReplica().Select(&stats, `
+		SELECT
+			*
+		FROM
+			DailyStats
+		WHERE
+			TeamId = :TeamId
+			AND ChannelId = :ChannelId
+			AND Date >= :Since
+			AND Date <= :Until
+		ORDER BY
+			Date ASC`, map[string]interface{}{"TeamId": teamId, "ChannelId": channelId, "Since": since, "Until": until}); err != nil {
+		return nil, model.NewAppError("SqlDailyStatStore.Get", "store.sql_daily_stat.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return stats, nil
+}
+
+func (s SqlDailyStatStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM DailyStats WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlDailyStatStore.PermanentDeleteByChannel", "store.sql_daily_stat.permanent_delete_by_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlDailyStatStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM DailyStats WHERE TeamId = :TeamId", map[string]interface{}{"TeamId": teamId}); err != nil {
+		return model.NewAppError("SqlDailyStatStore.PermanentDeleteByTeam", "store.sql_daily_stat.permanent_delete_by_team.app_error", nil, "team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
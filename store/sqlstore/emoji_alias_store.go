@@ -0,0 +1,89 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlEmojiAliasStore struct {
+	SqlStore
+}
+
+func NewSqlEmojiAliasStore(sqlStore SqlStore) store.EmojiAliasStore {
+	s := &SqlEmojiAliasStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.EmojiAlias{}, "EmojiAliases").SetKeys(false, "Alias")
+		table.ColMap("Alias").SetMaxSize(64)
+		table.ColMap("EmojiName").SetMaxSize(64)
+		table.ColMap("CreatorId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlEmojiAliasStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_emojialiases_emoji_name", "EmojiAliases", "EmojiName")
+}
+
+func (s SqlEmojiAliasStore) Save(alias *model.EmojiAlias) (*model.EmojiAlias, *model.AppError) {
+	alias.PreSave()
+	if err := alias.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(alias); err != nil {
+		if IsUniqueConstraintError(err, []string{"PRIMARY", "emojialiases_pkey"}) {
+			return nil, model.NewAppError("SqlEmojiAliasStore.Save", "store.sql_emoji_alias.save.duplicate.app_error", nil, "alias="+alias.Alias+", "+err.Error(), http.StatusBadRequest)
+		}
+		return nil, model.NewAppError("SqlEmojiAliasStore.Save", "store.sql_emoji_alias.save.app_error", nil, "alias="+alias.Alias+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return alias, nil
+}
+
+func (s SqlEmojiAliasStore) Get(alias string) (*model.EmojiAlias, *model.AppError) {
+	var emojiAlias model.EmojiAlias
+
+	if err := s.GetReplica().SelectOne(&emojiAlias, "SELECT * FROM EmojiAliases WHERE Alias = :Alias", map[string]interface{}{"Alias": alias}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlEmojiAliasStore.Get", "store.sql_emoji_alias.get.app_error", nil, "alias="+alias+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlEmojiAliasStore.Get", "store.sql_emoji_alias.get.app_error", nil, "alias="+alias+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &emojiAlias, nil
+}
+
+func (s SqlEmojiAliasStore) GetAll() ([]*model.EmojiAlias, *model.AppError) {
+	aliases := []*model.EmojiAlias{}
+
+	if _, err := s.GetReplica().Select(&aliases, "SELECT * FROM EmojiAliases ORDER BY Alias"); err != nil {
+		return nil, model.NewAppError("SqlEmojiAliasStore.GetAll", "store.sql_emoji_alias.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return aliases, nil
+}
+
+func (s SqlEmojiAliasStore) Delete(alias string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM EmojiAliases WHERE Alias = :Alias", map[string]interface{}{"Alias": alias}); err != nil {
+		return model.NewAppError("SqlEmojiAliasStore.Delete", "store.sql_emoji_alias.delete.app_error", nil, "alias="+alias+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// DeleteForEmoji deletes the aliases of an emoji, for when the emoji is deleted.
+func (s SqlEmojiAliasStore) DeleteForEmoji(emojiName string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM EmojiAliases WHERE EmojiName = :EmojiName", map[string]interface{}{"EmojiName": emojiName}); err != nil {
+		return model.NewAppError("SqlEmojiAliasStore.DeleteForEmoji", "store.sql_emoji_alias.delete.app_error", nil, "emoji_name="+emojiName+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
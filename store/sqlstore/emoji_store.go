@@ -171,6 +171,46 @@ func (es SqlEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*mode
 	return emojis, nil
 }
 
+// CountByCreator returns how many emoji a user has created that haven't been deleted.
+func (es SqlEmojiStore) CountByCreator(creatorId string) (int64, *model.AppError) {
+	count, err := es.GetReplica().SelectInt("SELECT COUNT(*) FROM Emoji WHERE CreatorId = :CreatorId AND DeleteAt = 0", map[string]interface{}{"CreatorId": creatorId})
+	if err != nil {
+		return 0, model.NewAppError("SqlEmojiStore.CountByCreator", "store.sql_emoji.count_by_creator.app_error", nil, "creator_id="+creatorId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+// GetUsage returns how much each emoji has been used in reactions, starting with the least used and, among those, the
+// ones used longest ago.
+func (es SqlEmojiStore) GetUsage(offset, limit int) ([]*model.EmojiUsage, *model.AppError) {
+	var usage []*model.EmojiUsage
+
+	if _, err := es.GetReplica().Select(&usage,
+		`SELECT
+			Emoji.Id AS EmojiId,
+			Emoji.Name,
+			Emoji.CreatorId,
+			Emoji.CreateAt,
+			COUNT(Reactions.PostId) AS ReactionCount,
+			COALESCE(MAX(Reactions.CreateAt), 0) AS LastUsedAt
+		FROM
+			Emoji
+			LEFT JOIN Reactions ON Reactions.EmojiName = Emoji.Name
+		WHERE
+			Emoji.DeleteAt = 0
+		GROUP BY
+			Emoji.Id, Emoji.Name, Emoji.CreatorId, Emoji.CreateAt
+		ORDER BY
+			ReactionCount, LastUsedAt, Emoji.Name
+		LIMIT :Limit
+		OFFSET :Offset`, map[string]interface{}{"Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlEmojiStore.GetUsage", "store.sql_emoji.get_usage.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return usage, nil
+}
+
 // getBy returns one active (not deleted) emoji, found by any one column (what/key).
 func (es SqlEmojiStore) getBy(what string, key interface{}, addToCache bool) (*model.Emoji, *model.AppError) {
 	var emoji *model.Emoji
@@ -0,0 +1,115 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlEphemeralPostStore struct {
+	SqlStore
+}
+
+func NewSqlEphemeralPostStore(sqlStore SqlStore) store.EphemeralPostStore {
+	s := &SqlEphemeralPostStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.EphemeralPost{}, "EphemeralPosts").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("PostJson").SetMaxSize(65535)
+	}
+
+	return s
+}
+
+func (s SqlEphemeralPostStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_ephemeralposts_user_id_update_at", "EphemeralPosts", "UserId, UpdateAt")
+	s.CreateIndexIfNotExists("idx_ephemeralposts_expire_at", "EphemeralPosts", "ExpireAt")
+}
+
+func (s SqlEphemeralPostStore) Save(ephemeralPost *model.EphemeralPost) (*model.EphemeralPost, *model.AppError) {
+	ephemeralPost.PreSave()
+	if err := ephemeralPost.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(ephemeralPost); err != nil {
+		return nil, model.NewAppError("SqlEphemeralPostStore.Save", "store.sql_ephemeral_post.save.app_error", nil, "id="+ephemeralPost.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return ephemeralPost, nil
+}
+
+// Update replaces a stored ephemeral post of its user, extending its expiry.
+func (s SqlEphemeralPostStore) Update(ephemeralPost *model.EphemeralPost) (*model.EphemeralPost, *model.AppError) {
+	ephemeralPost.PreUpdate()
+	if err := ephemeralPost.IsValid(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.GetMaster().Exec("UPDATE EphemeralPosts SET UpdateAt = :UpdateAt, ExpireAt = :ExpireAt, PostJson = :PostJson WHERE Id = :Id AND UserId = :UserId", map[string]interface{}{"UpdateAt": ephemeralPost.UpdateAt, "ExpireAt": ephemeralPost.ExpireAt, "PostJson": ephemeralPost.PostJson, "Id": ephemeralPost.Id, "UserId": ephemeralPost.UserId})
+	if err != nil {
+		return nil, model.NewAppError("SqlEphemeralPostStore.Update", "store.sql_ephemeral_post.update.app_error", nil, "id="+ephemeralPost.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return nil, model.NewAppError("SqlEphemeralPostStore.Update", "store.sql_ephemeral_post.get.app_error", nil, "id="+ephemeralPost.Id, http.StatusNotFound)
+	}
+
+	return ephemeralPost, nil
+}
+
+// GetForUser returns the unexpired ephemeral posts of a user sent or updated after since, starting with the oldest.
+func (s SqlEphemeralPostStore) GetForUser(userId string, since int64, limit int) ([]*model.EphemeralPost, *model.AppError) {
+	ephemeralPosts := []*model.EphemeralPost{}
+
+	if _, err := s.GetReplica().Select(&ephemeralPosts, "SELECT * FROM EphemeralPosts WHERE UserId = :UserId AND UpdateAt > :Since AND ExpireAt > :Now ORDER BY CreateAt, Id LIMIT :Limit", map[string]interface{}{"UserId": userId, "Since": since, "Now": model.GetMillis(), "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlEphemeralPostStore.GetForUser", "store.sql_ephemeral_post.get_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return ephemeralPosts, nil
+}
+
+func (s SqlEphemeralPostStore) Delete(userId, id string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM EphemeralPosts WHERE Id = :Id AND UserId = :UserId", map[string]interface{}{"Id": id, "UserId": userId}); err != nil {
+		return model.NewAppError("SqlEphemeralPostStore.Delete", "store.sql_ephemeral_post.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes up to limit ephemeral posts that expired before now, returning how many it deleted.
+func (s SqlEphemeralPostStore) DeleteExpired(now int64, limit int) (int64, *model.AppError) {
+	var query string
+	if s.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+		query = "DELETE FROM EphemeralPosts WHERE Id = any (array (SELECT Id FROM EphemeralPosts WHERE ExpireAt <= :Now LIMIT :Limit))"
+	} else {
+		query = "DELETE FROM EphemeralPosts WHERE ExpireAt <= :Now LIMIT :Limit"
+	}
+
+	result, err := s.GetMaster().Exec(query, map[string]interface{}{"Now": now, "Limit": limit})
+	if err != nil {
+		return 0, model.NewAppError("SqlEphemeralPostStore.DeleteExpired", "store.sql_ephemeral_post.delete_expired.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, model.NewAppError("SqlEphemeralPostStore.DeleteExpired", "store.sql_ephemeral_post.delete_expired.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+func (s SqlEphemeralPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM EphemeralPosts WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlEphemeralPostStore.PermanentDeleteByUser", "store.sql_ephemeral_post.permanent_delete_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -51,6 +51,8 @@ func NewSqlFileInfoStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface
 		table.ColMap("Name").SetMaxSize(256)
 		table.ColMap("Extension").SetMaxSize(64)
 		table.ColMap("MimeType").SetMaxSize(256)
+		table.ColMap("Verdict").SetMaxSize(16)
+		table.ColMap("VerdictReason").SetMaxSize(1024)
 	}
 
 	return s
@@ -174,6 +176,67 @@ func (fs SqlFileInfoStore) GetForPost(postId string, readFromMaster, includeDele
 	return infos, nil
 }
 
+// GetForPosts returns the file infos attached to each of the given posts that haven't been deleted, keyed by post
+// id, reading the ones of all the posts that aren't cached in a single query.
+func (fs SqlFileInfoStore) GetForPosts(postIds []string, readFromMaster bool) (map[string][]*model.FileInfo, *model.AppError) {
+	infosByPost := make(map[string][]*model.FileInfo, len(postIds))
+
+	uncachedIds := []string{}
+	for _, postId := range postIds {
+		if cacheItem, ok := fileInfoCache.Get(postId); ok {
+			if fs.metrics != nil {
+				fs.metrics.IncrementMemCacheHitCounter("File Info Cache")
+			}
+
+			infosByPost[postId] = cacheItem.([]*model.FileInfo)
+			continue
+		}
+
+		if fs.metrics != nil {
+			fs.metrics.IncrementMemCacheMissCounter("File Info Cache")
+		}
+
+		uncachedIds = append(uncachedIds, postId)
+	}
+
+	if len(uncachedIds) == 0 {
+		return infosByPost, nil
+	}
+
+	dbmap := fs.GetReplica()
+	if readFromMaster {
+		dbmap = fs.GetMaster()
+	}
+
+	queryString, args, err := fs.getQueryBuilder().
+		Select("*").
+		From("FileInfo").
+		Where(sq.Eq{"PostId": uncachedIds}).
+		Where("DeleteAt = 0").
+		OrderBy("CreateAt").
+		ToSql()
+	if err != nil {
+		return nil, model.NewAppError("SqlFileInfoStore.GetForPosts", "store.sql_file_info.get_for_post.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var infos []*model.FileInfo
+	if _, err := dbmap.Select(&infos, queryString, args...); err != nil {
+		return nil, model.NewAppError("SqlFileInfoStore.GetForPosts", "store.sql_file_info.get_for_post.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, info := range infos {
+		infosByPost[info.PostId] = append(infosByPost[info.PostId], info)
+	}
+
+	for _, postId := range uncachedIds {
+		if postInfos, ok := infosByPost[postId]; ok {
+			fileInfoCache.AddWithExpiresInSecs(postId, postInfos, FILE_INFO_CACHE_SEC)
+		}
+	}
+
+	return infosByPost, nil
+}
+
 func (fs SqlFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, *model.AppError) {
 	var infos []*model.FileInfo
 
@@ -228,6 +291,42 @@ func (fs SqlFileInfoStore) AttachToPost(fileId, postId, creatorId string) *model
 	return nil
 }
 
+// SetVerdict records the verdict of a scanning or DLP pipeline on a file, replacing any previous one.
+func (fs SqlFileInfoStore) SetVerdict(fileId, verdict, reason string, verdictAt int64) *model.AppError {
+	sqlResult, err := fs.GetMaster().Exec(`
+		UPDATE
+			FileInfo
+		SET
+			Verdict = :Verdict,
+			VerdictReason = :VerdictReason,
+			VerdictAt = :VerdictAt,
+			UpdateAt = :UpdateAt
+		WHERE
+			Id = :Id
+	`, map[string]interface{}{
+		"Verdict":       verdict,
+		"VerdictReason": reason,
+		"VerdictAt":     verdictAt,
+		"UpdateAt":      verdictAt,
+		"Id":            fileId,
+	})
+	if err != nil {
+		return model.NewAppError("SqlFileInfoStore.SetVerdict",
+			"store.sql_file_info.set_verdict.app_error", nil, "file_id="+fileId+", err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	count, err := sqlResult.RowsAffected()
+	if err != nil {
+		return model.NewAppError("SqlFileInfoStore.SetVerdict",
+			"store.sql_file_info.set_verdict.app_error", nil, "file_id="+fileId+", err="+err.Error(), http.StatusInternalServerError)
+	} else if count == 0 {
+		return model.NewAppError("SqlFileInfoStore.SetVerdict",
+			"store.sql_file_info.set_verdict.app_error", nil, "file_id="+fileId, http.StatusNotFound)
+	}
+
+	return nil
+}
+
 func (fs SqlFileInfoStore) DeleteForPost(postId string) (string, *model.AppError) {
 	if _, err := fs.GetMaster().Exec(
 		`UPDATE
@@ -0,0 +1,134 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlHashtagStore struct {
+	SqlStore
+}
+
+func NewSqlHashtagStore(sqlStore SqlStore) store.HashtagStore {
+	s := &SqlHashtagStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PostHashtag{}, "PostHashtags").SetKeys(false, "PostId", "Hashtag")
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("Hashtag").SetMaxSize(model.HASHTAG_MAX_LENGTH)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlHashtagStore) CreateIndexesIfNotExists() {
+	s.CreateCompositeIndexIfNotExists("idx_posthashtags_team_id_hashtag_create_at", "PostHashtags", []string{"TeamId", "Hashtag", "CreateAt"})
+	s.CreateIndexIfNotExists("idx_posthashtags_create_at", "PostHashtags", "CreateAt")
+}
+
+// SaveForPost replaces the hashtags indexed for the post.
+func (s SqlHashtagStore) SaveForPost(postId string, hashtags []*model.PostHashtag) *model.AppError {
+	for _, hashtag := range hashtags {
+		if err := hashtag.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlHashtagStore.SaveForPost", "store.sql_hashtag.save_for_post.open_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	if _, err := transaction.Exec("DELETE FROM PostHashtags WHERE PostId = :PostId", map[string]interface{}{"PostId": postId}); err != nil {
+		return model.NewAppError("SqlHashtagStore.SaveForPost", "store.sql_hashtag.save_for_post.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, hashtag := range hashtags {
+		if err := transaction.Insert(hashtag); err != nil {
+			return model.NewAppError("SqlHashtagStore.SaveForPost", "store.sql_hashtag.save_for_post.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlHashtagStore.SaveForPost", "store.sql_hashtag.save_for_post.commit_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlHashtagStore) DeleteForPost(postId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PostHashtags WHERE PostId = :PostId", map[string]interface{}{"PostId": postId}); err != nil {
+		return model.NewAppError("SqlHashtagStore.DeleteForPost", "store.sql_hashtag.delete_for_post.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetTrending returns the hashtags used in the most posts of the public channels of the team since the
+// given time, most used first.
+func (s SqlHashtagStore) GetTrending(teamId string, since int64, limit int) ([]*model.HashtagCount, *model.AppError) {
+	var counts []*model.HashtagCount
+
+	query := `
+		SELECT
+			PostHashtags.Hashtag AS Hashtag,
+			COUNT(*) AS Count
+		FROM
+			PostHashtags
+			INNER JOIN Channels ON Channels.Id = PostHashtags.ChannelId
+		WHERE
+			PostHashtags.TeamId = :TeamId
+			AND PostHashtags.CreateAt >= :Since
+			AND Channels.Type = :ChannelType
+			AND Channels.DeleteAt = 0
+		GROUP BY PostHashtags.Hashtag
+		ORDER BY COUNT(*) DESC, PostHashtags.Hashtag ASC
+		LIMIT :Limit`
+
+	if _, err := s.GetReplica().Select(&counts, query, map[string]interface{}{"TeamId": teamId, "Since": since, "ChannelType": model.CHANNEL_OPEN, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlHashtagStore.GetTrending", "store.sql_hashtag.get_trending.app_error", nil, "team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return counts, nil
+}
+
+// GetPostsForUser returns the posts using the hashtag in the channels of the team the user is a member of,
+// newest first.
+func (s SqlHashtagStore) GetPostsForUser(userId, teamId, hashtag string, offset, limit int) (*model.PostList, *model.AppError) {
+	var posts []*model.Post
+
+	query := `
+		SELECT
+			Posts.*
+		FROM
+			PostHashtags
+			INNER JOIN Posts ON Posts.Id = PostHashtags.PostId
+			INNER JOIN ChannelMembers ON ChannelMembers.ChannelId = PostHashtags.ChannelId
+		WHERE
+			PostHashtags.TeamId = :TeamId
+			AND PostHashtags.Hashtag = :Hashtag
+			AND ChannelMembers.UserId = :UserId
+			AND Posts.DeleteAt = 0
+		ORDER BY PostHashtags.CreateAt DESC
+		LIMIT :Limit OFFSET :Offset`
+
+	if _, err := s.GetReplica().Select(&posts, query, map[string]interface{}{"TeamId": teamId, "Hashtag": hashtag, "UserId": userId, "Offset": offset, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlHashtagStore.GetPostsForUser", "store.sql_hashtag.get_posts_for_user.app_error", nil, "team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	pl := model.NewPostList()
+	for _, post := range posts {
+		pl.AddPost(post)
+		pl.AddOrder(post.Id)
+	}
+
+	return pl, nil
+}
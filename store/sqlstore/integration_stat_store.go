@@ -0,0 +1,168 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlIntegrationStatStore struct {
+	SqlStore
+}
+
+func NewSqlIntegrationStatStore(sqlStore SqlStore) store.IntegrationStatStore {
+	s := &SqlIntegrationStatStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.IntegrationStat{}, "IntegrationStats").SetKeys(false, "Date", "IntegrationType", "IntegrationId")
+		table.ColMap("Date").SetMaxSize(10)
+		table.ColMap("IntegrationType").SetMaxSize(32)
+		table.ColMap("IntegrationId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlIntegrationStatStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_integrationstats_integration_id", "IntegrationStats", "IntegrationId")
+}
+
+// Increment adds the activity of the stat to the one stored for the same date and integration, creating it if there
+// is none yet. Several servers may be adding to the same stat at the same time.
+func (s SqlIntegrationStatStore) Increment(stat *model.IntegrationStat) *model.AppError {
+	stat.PreSave()
+	if err := stat.IsValid(); err != nil {
+		return err
+	}
+
+	updated, err := s.add(stat)
+	if err != nil {
+		return model.NewAppError("SqlIntegrationStatStore.Increment", "store.sql_integration_stat.increment.app_error", nil, "integration_id="+stat.IntegrationId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if updated {
+		return nil
+	}
+
+	if insertErr := s.GetMaster().Insert(stat); insertErr != nil {
+		// Another server may have created the stat in the meantime, in which case it can be added to.
+		if updated, err = s.add(stat); err != nil || !updated {
+			return model.NewAppError("SqlIntegrationStatStore.Increment", "store.sql_integration_stat.increment.app_error", nil, "integration_id="+stat.IntegrationId+", "+insertErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+func (s SqlIntegrationStatStore) add(stat *model.IntegrationStat) (bool, error) {
+	result, err := s.GetMaster().Exec(`
+		UPDATE
+			IntegrationStats
+		SET
+			Requests = Requests + :Requests,
+			Posts = Posts + :Posts,
+			Errors = Errors + :Errors,
+			TotalLatencyMs = TotalLatencyMs + :TotalLatencyMs,
+			MaxLatencyMs = CASE WHEN MaxLatencyMs < :MaxLatencyMs THEN :MaxLatencyMs ELSE MaxLatencyMs END,
+			UpdateAt = :UpdateAt
+		WHERE
+			Date = :Date
+			AND IntegrationType = :IntegrationType
+			AND IntegrationId = :IntegrationId`, map[string]interface{}{
+		"Requests":        stat.Requests,
+		"Posts":           stat.Posts,
+		"Errors":          stat.Errors,
+		"TotalLatencyMs":  stat.TotalLatencyMs,
+		"MaxLatencyMs":    stat.MaxLatencyMs,
+		"UpdateAt":        stat.UpdateAt,
+		"Date":            stat.Date,
+		"IntegrationType": stat.IntegrationType,
+		"IntegrationId":   stat.IntegrationId,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetTotals sums up the stats of each integration for the dates in [since, until], with the integrations handling
+// the most requests first. The stats of every type of integration are returned when no type is given.
+func (s SqlIntegrationStatStore) GetTotals(integrationType string, since, until string) ([]*model.IntegrationStat, *model.AppError) {
+	params := map[string]interface{}{"IntegrationType": integrationType, "Since": since, "Until": until}
+
+	typeClause := ""
+	if integrationType != "" {
+		typeClause = "AND IntegrationType = :IntegrationType"
+	}
+
+	var stats []*model.IntegrationStat
+	if _, err := s.GetReplica().Select(&stats, `
+		SELECT
+			IntegrationType,
+			IntegrationId,
+			SUM(Requests) AS Requests,
+			SUM(Posts) AS Posts,
+			SUM(Errors) AS Errors,
+			SUM(TotalLatencyMs) AS TotalLatencyMs,
+			MAX(MaxLatencyMs) AS MaxLatencyMs,
+			MAX(UpdateAt) AS UpdateAt
+		FROM
+			IntegrationStats
+		WHERE
+			Date >= :Since
+			AND Date <= :Until
+			`+typeClause+`
+		GROUP BY
+			IntegrationType, IntegrationId
+		ORDER BY
+			SUM(Requests) DESC, IntegrationId ASC`, params); err != nil {
+		return nil, model.NewAppError("SqlIntegrationStatStore.GetTotals", "store.sql_integration_stat.get_totals.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return stats, nil
+}
+
+func (s SqlIntegrationStatStore) GetDaily(integrationType, integrationId string, since, until string) ([]*model.IntegrationStat, *model.AppError) {
+	var stats []*model.IntegrationStat
+	if _, err := s.GetReplica().Select(&stats, `
+		SELECT
+			*
+		FROM
+			IntegrationStats
+		WHERE
+			IntegrationType = :IntegrationType
+			AND IntegrationId = :IntegrationId
+			AND Date >= :Since
+			AND Date <= :Until
+		ORDER BY
+			Date ASC`, map[string]interface{}{"IntegrationType": integrationType, "IntegrationId": integrationId, "Since": since, "Until": until}); err != nil {
+		return nil, model.NewAppError("SqlIntegrationStatStore.GetDaily", "store.sql_integration_stat.get_daily.app_error", nil, "integration_id="+integrationId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return stats, nil
+}
+
+// PermanentDeleteBefore deletes the stats of the dates before the given one, returning how many were deleted.
+func (s SqlIntegrationStatStore) PermanentDeleteBefore(date string) (int64, *model.AppError) {
+	result, err := s.GetMaster().Exec("DELETE FROM IntegrationStats WHERE Date < :Date", map[string]interface{}{"Date": date})
+	if err != nil {
+		return 0, model.NewAppError("SqlIntegrationStatStore.PermanentDeleteBefore", "store.sql_integration_stat.permanent_delete_before.app_error", nil, "date="+date+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, model.NewAppError("SqlIntegrationStatStore.PermanentDeleteBefore", "store.sql_integration_stat.permanent_delete_before.app_error", nil, "date="+date+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return rowsAffected, nil
+}
@@ -185,6 +185,24 @@ func (jss SqlJobStore) GetCountByStatusAndType(status string, jobType string) (i
 	return count, nil
 }
 
+func (jss SqlJobStore) UpdateLastActivityAt(ids []string) *model.AppError {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	keys, params := MapStringsToQueryParams(ids, "Id")
+	params["LastActivityAt"] = model.GetMillis()
+	params["InProgress"] = model.JOB_STATUS_IN_PROGRESS
+	params["CancelRequested"] = model.JOB_STATUS_CANCEL_REQUESTED
+
+	query := "UPDATE Jobs SET LastActivityAt = :LastActivityAt WHERE Id IN " + keys + " AND Status IN (:InProgress, :CancelRequested)"
+	if _, err := jss.GetMaster().Exec(query, params); err != nil {
+		return model.NewAppError("SqlJobStore.UpdateLastActivityAt", "store.sql_job.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
 func (jss SqlJobStore) Delete(id string) (string, *model.AppError) {
 	query := "DELETE FROM Jobs WHERE Id = :Id"
 	if _, err := jss.GetMaster().Exec(query, map[string]interface{}{"Id": id}); err != nil {
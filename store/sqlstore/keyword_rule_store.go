@@ -0,0 +1,121 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlKeywordRuleStore struct {
+	SqlStore
+}
+
+func NewSqlKeywordRuleStore(sqlStore SqlStore) store.KeywordRuleStore {
+	s := &SqlKeywordRuleStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.KeywordRule{}, "KeywordRules").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("Name").SetMaxSize(model.KEYWORD_RULE_NAME_MAX_LENGTH)
+		table.ColMap("ChannelIds").SetMaxSize(model.KEYWORD_RULE_MAX_CHANNELS * 29)
+		table.ColMap("Keywords").SetMaxSize(model.KEYWORD_RULE_MAX_KEYWORDS * (model.KEYWORD_RULE_KEYWORD_MAX_LENGTH + 3))
+		table.ColMap("Patterns").SetMaxSize(model.KEYWORD_RULE_MAX_PATTERNS * (model.KEYWORD_RULE_PATTERN_MAX_LENGTH + 3))
+		table.ColMap("ResponderId").SetMaxSize(26)
+		table.ColMap("ReplyMessage").SetMaxSize(model.KEYWORD_RULE_REPLY_MESSAGE_MAX_LENGTH)
+		table.ColMap("ReactionEmojiName").SetMaxSize(model.EMOJI_NAME_MAX_LENGTH)
+		table.ColMap("CopyToChannelId").SetMaxSize(26)
+		table.ColMap("NotifyGroupId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlKeywordRuleStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_keywordrules_team_id", "KeywordRules", "TeamId")
+	s.CreateIndexIfNotExists("idx_keywordrules_delete_at", "KeywordRules", "DeleteAt")
+}
+
+func (s SqlKeywordRuleStore) Save(rule *model.KeywordRule) (*model.KeywordRule, *model.AppError) {
+	if len(rule.Id) > 0 {
+		return nil, model.NewAppError("SqlKeywordRuleStore.Save", "store.sql_keyword_rule.save.existing.app_error", nil, "id="+rule.Id, http.StatusBadRequest)
+	}
+
+	rule.PreSave()
+	if err := rule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(rule); err != nil {
+		return nil, model.NewAppError("SqlKeywordRuleStore.Save", "store.sql_keyword_rule.save.app_error", nil, "id="+rule.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return rule, nil
+}
+
+func (s SqlKeywordRuleStore) Update(rule *model.KeywordRule) (*model.KeywordRule, *model.AppError) {
+	rule.PreUpdate()
+	if err := rule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().Update(rule); err != nil {
+		return nil, model.NewAppError("SqlKeywordRuleStore.Update", "store.sql_keyword_rule.update.app_error", nil, "id="+rule.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return rule, nil
+}
+
+func (s SqlKeywordRuleStore) Get(id string) (*model.KeywordRule, *model.AppError) {
+	var rule model.KeywordRule
+
+	if err := s.GetReplica().SelectOne(&rule, "SELECT * FROM KeywordRules WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlKeywordRuleStore.Get", "store.sql_keyword_rule.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlKeywordRuleStore.Get", "store.sql_keyword_rule.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &rule, nil
+}
+
+func (s SqlKeywordRuleStore) GetAll(offset, limit int) ([]*model.KeywordRule, *model.AppError) {
+	var rules []*model.KeywordRule
+
+	if _, err := s.GetReplica().Select(&rules, "SELECT * FROM KeywordRules WHERE DeleteAt = 0 ORDER BY Name, TeamId LIMIT :Limit OFFSET :Offset", map[string]interface{}{"Offset": offset, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlKeywordRuleStore.GetAll", "store.sql_keyword_rule.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return rules, nil
+}
+
+// GetForTeam returns the rules which act on posts in the team, which are the ones of the team and the ones
+// that apply to all teams.
+func (s SqlKeywordRuleStore) GetForTeam(teamId string) ([]*model.KeywordRule, *model.AppError) {
+	var rules []*model.KeywordRule
+
+	if _, err := s.GetReplica().Select(&rules, "SELECT * FROM KeywordRules WHERE (TeamId = :TeamId OR TeamId = '') AND DeleteAt = 0 ORDER BY CreateAt", map[string]interface{}{"TeamId": teamId}); err != nil {
+		return nil, model.NewAppError("SqlKeywordRuleStore.GetForTeam", "store.sql_keyword_rule.get_for_team.app_error", nil, "team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return rules, nil
+}
+
+func (s SqlKeywordRuleStore) Delete(id string, time int64) *model.AppError {
+	sqlResult, err := s.GetMaster().Exec("UPDATE KeywordRules SET DeleteAt = :DeleteAt, UpdateAt = :UpdateAt WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"DeleteAt": time, "UpdateAt": time, "Id": id})
+	if err != nil {
+		return model.NewAppError("SqlKeywordRuleStore.Delete", "store.sql_keyword_rule.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if rows, _ := sqlResult.RowsAffected(); rows == 0 {
+		return model.NewAppError("SqlKeywordRuleStore.Delete", "store.sql_keyword_rule.get.app_error", nil, "id="+id, http.StatusNotFound)
+	}
+
+	return nil
+}
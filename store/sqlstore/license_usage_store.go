@@ -0,0 +1,75 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlLicenseUsageStore struct {
+	SqlStore
+}
+
+func NewSqlLicenseUsageStore(sqlStore SqlStore) store.LicenseUsageStore {
+	s := &SqlLicenseUsageStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.LicenseUsage{}, "LicenseUsages").SetKeys(false, "Date")
+		table.ColMap("Date").SetMaxSize(10)
+	}
+
+	return s
+}
+
+func (s SqlLicenseUsageStore) CreateIndexesIfNotExists() {
+}
+
+// Save stores the usage, replacing the one previously recorded for the same date if any.
+func (s SqlLicenseUsageStore) Save(usage *model.LicenseUsage) (*model.LicenseUsage, *model.AppError) {
+	usage.PreSave()
+	if err := usage.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(usage)
+	if err != nil {
+		return nil, model.NewAppError("SqlLicenseUsageStore.Save", "store.sql_license_usage.save.app_error", nil, "date="+usage.Date+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		if err := s.GetMaster().Insert(usage); err != nil {
+			return nil, model.NewAppError("SqlLicenseUsageStore.Save", "store.sql_license_usage.save.app_error", nil, "date="+usage.Date+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return usage, nil
+}
+
+func (s SqlLicenseUsageStore) Get(date string) (*model.LicenseUsage, *model.AppError) {
+	var usage model.LicenseUsage
+
+	if err := s.GetMaster().SelectOne(&usage, "SELECT * FROM LicenseUsages WHERE Date = :Date", map[string]interface{}{"Date": date}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlLicenseUsageStore.Get", "store.sql_license_usage.get.app_error", nil, "date="+date+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlLicenseUsageStore.Get", "store.sql_license_usage.get.app_error", nil, "date="+date+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &usage, nil
+}
+
+// GetRange returns the usage recorded between the dates, inclusive, in order.
+func (s SqlLicenseUsageStore) GetRange(since, until string) ([]*model.LicenseUsage, *model.AppError) {
+	var usages []*model.LicenseUsage
+
+	if _, err := s.GetReplica().Select(&usages, "SELECT * FROM LicenseUsages WHERE Date >= :Since AND Date <= :Until ORDER BY Date", map[string]interface{}{"Since": since, "Until": until}); err != nil {
+		return nil, model.NewAppError("SqlLicenseUsageStore.GetRange", "store.sql_license_usage.get_range.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return usages, nil
+}
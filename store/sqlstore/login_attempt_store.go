@@ -0,0 +1,115 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlLoginAttemptStore struct {
+	SqlStore
+}
+
+func NewSqlLoginAttemptStore(sqlStore SqlStore) store.LoginAttemptStore {
+	s := &SqlLoginAttemptStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.LoginAttempt{}, "LoginAttempts").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("IpAddress").SetMaxSize(64)
+		table.ColMap("Country").SetMaxSize(2)
+		table.ColMap("Device").SetMaxSize(model.LOGIN_ATTEMPT_DEVICE_MAX_LENGTH)
+	}
+
+	return s
+}
+
+func (s SqlLoginAttemptStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_loginattempts_user_id_create_at", "LoginAttempts", "UserId, CreateAt")
+	s.CreateIndexIfNotExists("idx_loginattempts_create_at", "LoginAttempts", "CreateAt")
+}
+
+func (s SqlLoginAttemptStore) Save(attempt *model.LoginAttempt) (*model.LoginAttempt, *model.AppError) {
+	attempt.PreSave()
+	if err := attempt.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(attempt); err != nil {
+		return nil, model.NewAppError("SqlLoginAttemptStore.Save", "store.sql_login_attempt.save.app_error", nil, "id="+attempt.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return attempt, nil
+}
+
+// GetForUser returns the login attempts of a user, starting with the latest.
+func (s SqlLoginAttemptStore) GetForUser(userId string, offset, limit int) ([]*model.LoginAttempt, *model.AppError) {
+	attempts := []*model.LoginAttempt{}
+
+	if _, err := s.GetReplica().Select(&attempts, "SELECT * FROM LoginAttempts WHERE UserId = :UserId ORDER BY CreateAt DESC, Id LIMIT :Limit OFFSET :Offset", map[string]interface{}{"UserId": userId, "Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlLoginAttemptStore.GetForUser", "store.sql_login_attempt.get_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return attempts, nil
+}
+
+// GetSuccessfulForUser returns the latest successful login attempts of a user, starting with the latest.
+func (s SqlLoginAttemptStore) GetSuccessfulForUser(userId string, limit int) ([]*model.LoginAttempt, *model.AppError) {
+	attempts := []*model.LoginAttempt{}
+
+	if _, err := s.GetMaster().Select(&attempts, "SELECT * FROM LoginAttempts WHERE UserId = :UserId AND Success = :Success ORDER BY CreateAt DESC, Id LIMIT :Limit", map[string]interface{}{"UserId": userId, "Success": true, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlLoginAttemptStore.GetSuccessfulForUser", "store.sql_login_attempt.get_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return attempts, nil
+}
+
+// GetLatestFailure returns the last failed login attempt of a user.
+func (s SqlLoginAttemptStore) GetLatestFailure(userId string) (*model.LoginAttempt, *model.AppError) {
+	var attempt model.LoginAttempt
+
+	if err := s.GetMaster().SelectOne(&attempt, "SELECT * FROM LoginAttempts WHERE UserId = :UserId AND Success = :Success ORDER BY CreateAt DESC, Id LIMIT 1", map[string]interface{}{"UserId": userId, "Success": false}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlLoginAttemptStore.GetLatestFailure", "store.sql_login_attempt.get_latest_failure.not_found.app_error", nil, "user_id="+userId, http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlLoginAttemptStore.GetLatestFailure", "store.sql_login_attempt.get_latest_failure.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &attempt, nil
+}
+
+// PermanentDeleteBatch deletes up to limit login attempts made before endTime, returning how many it deleted.
+func (s SqlLoginAttemptStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	var query string
+	if s.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+		query = "DELETE FROM LoginAttempts WHERE Id = any (array (SELECT Id FROM LoginAttempts WHERE CreateAt < :EndTime LIMIT :Limit))"
+	} else {
+		query = "DELETE FROM LoginAttempts WHERE CreateAt < :EndTime LIMIT :Limit"
+	}
+
+	result, err := s.GetMaster().Exec(query, map[string]interface{}{"EndTime": endTime, "Limit": limit})
+	if err != nil {
+		return 0, model.NewAppError("SqlLoginAttemptStore.PermanentDeleteBatch", "store.sql_login_attempt.permanent_delete_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, model.NewAppError("SqlLoginAttemptStore.PermanentDeleteBatch", "store.sql_login_attempt.permanent_delete_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+func (s SqlLoginAttemptStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM LoginAttempts WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlLoginAttemptStore.PermanentDeleteByUser", "store.sql_login_attempt.permanent_delete_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlMentionAliasStore struct {
+	SqlStore
+}
+
+func NewSqlMentionAliasStore(sqlStore SqlStore) store.MentionAliasStore {
+	s := &SqlMentionAliasStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.MentionAlias{}, "MentionAliases").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("Name").SetMaxSize(model.MENTION_ALIAS_NAME_MAX_LENGTH)
+		table.ColMap("Description").SetMaxSize(model.MENTION_ALIAS_DESCRIPTION_MAX_LENGTH)
+		table.ColMap("Type").SetMaxSize(16)
+		table.ColMap("TargetIds").SetMaxSize(8000)
+
+		table.SetUniqueTogether("TeamId", "Name", "DeleteAt")
+	}
+
+	return s
+}
+
+func (s SqlMentionAliasStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_mentionaliases_team_id", "MentionAliases", "TeamId")
+	s.CreateIndexIfNotExists("idx_mentionaliases_name", "MentionAliases", "Name")
+	s.CreateIndexIfNotExists("idx_mentionaliases_delete_at", "MentionAliases", "DeleteAt")
+}
+
+func (s SqlMentionAliasStore) Save(alias *model.MentionAlias) (*model.MentionAlias, *model.AppError) {
+	if len(alias.Id) > 0 {
+		return nil, model.NewAppError("SqlMentionAliasStore.Save", "store.sql_mention_alias.save.existing.app_error", nil, "id="+alias.Id, http.StatusBadRequest)
+	}
+
+	alias.PreSave()
+	if err := alias.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(alias); err != nil {
+		if IsUniqueConstraintError(err, []string{"Name", "mentionaliases_teamid_name_deleteat_key"}) {
+			return nil, model.NewAppError("SqlMentionAliasStore.Save", "store.sql_mention_alias.save.name_exists.app_error", nil, "name="+alias.Name, http.StatusBadRequest)
+		}
+		return nil, model.NewAppError("SqlMentionAliasStore.Save", "store.sql_mention_alias.save.app_error", nil, "id="+alias.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return alias, nil
+}
+
+func (s SqlMentionAliasStore) Update(alias *model.MentionAlias) (*model.MentionAlias, *model.AppError) {
+	alias.PreUpdate()
+	if err := alias.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().Update(alias); err != nil {
+		if IsUniqueConstraintError(err, []string{"Name", "mentionaliases_teamid_name_deleteat_key"}) {
+			return nil, model.NewAppError("SqlMentionAliasStore.Update", "store.sql_mention_alias.save.name_exists.app_error", nil, "name="+alias.Name, http.StatusBadRequest)
+		}
+		return nil, model.NewAppError("SqlMentionAliasStore.Update", "store.sql_mention_alias.update.app_error", nil, "id="+alias.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return alias, nil
+}
+
+func (s SqlMentionAliasStore) Get(id string) (*model.MentionAlias, *model.AppError) {
+	var alias model.MentionAlias
+
+	if err := s.GetReplica().SelectOne(&alias, "SELECT * FROM MentionAliases WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlMentionAliasStore.Get", "store.sql_mention_alias.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlMentionAliasStore.Get", "store.sql_mention_alias.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &alias, nil
+}
+
+// GetByName returns the aliases with the name in any team, including the ones that apply to all teams.
+func (s SqlMentionAliasStore) GetByName(name string) ([]*model.MentionAlias, *model.AppError) {
+	var aliases []*model.MentionAlias
+
+	if _, err := s.GetReplica().Select(&aliases, "SELECT * FROM MentionAliases WHERE Name = :Name AND DeleteAt = 0", map[string]interface{}{"Name": name}); err != nil {
+		return nil, model.NewAppError("SqlMentionAliasStore.GetByName", "store.sql_mention_alias.get_by_name.app_error", nil, "name="+name+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return aliases, nil
+}
+
+func (s SqlMentionAliasStore) GetAll(offset, limit int) ([]*model.MentionAlias, *model.AppError) {
+	var aliases []*model.MentionAlias
+
+	if _, err := s.GetReplica().Select(&aliases, "SELECT * FROM MentionAliases WHERE DeleteAt = 0 ORDER BY Name, TeamId LIMIT :Limit OFFSET :Offset", map[string]interface{}{"Offset": offset, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlMentionAliasStore.GetAll", "store.sql_mention_alias.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return aliases, nil
+}
+
+// GetForTeam returns the aliases that can be mentioned in the team, which are the ones of the team and the ones
+// that apply to all teams.
+func (s SqlMentionAliasStore) GetForTeam(teamId string) ([]*model.MentionAlias, *model.AppError) {
+	var aliases []*model.MentionAlias
+
+	if _, err := s.GetReplica().Select(&aliases, "SELECT * FROM MentionAliases WHERE (TeamId = :TeamId OR TeamId = '') AND DeleteAt = 0", map[string]interface{}{"TeamId": teamId}); err != nil {
+		return nil, model.NewAppError("SqlMentionAliasStore.GetForTeam", "store.sql_mention_alias.get_for_team.app_error", nil, "team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return aliases, nil
+}
+
+func (s SqlMentionAliasStore) Delete(id string, time int64) *model.AppError {
+	sqlResult, err := s.GetMaster().Exec("UPDATE MentionAliases SET DeleteAt = :DeleteAt, UpdateAt = :UpdateAt WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"DeleteAt": time, "UpdateAt": time, "Id": id})
+	if err != nil {
+		return model.NewAppError("SqlMentionAliasStore.Delete", "store.sql_mention_alias.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if rows, _ := sqlResult.RowsAffected(); rows == 0 {
+		return model.NewAppError("SqlMentionAliasStore.Delete", "store.sql_mention_alias.get.app_error", nil, "id="+id, http.StatusNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlMessageExportConsumerStore struct {
+	SqlStore
+}
+
+func NewSqlMessageExportConsumerStore(sqlStore SqlStore) store.MessageExportConsumerStore {
+	s := &SqlMessageExportConsumerStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.MessageExportConsumer{}, "MessageExportConsumers").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("Name").SetMaxSize(model.MESSAGE_EXPORT_CONSUMER_NAME_MAX_RUNES * 4)
+		table.ColMap("CursorPostId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlMessageExportConsumerStore) CreateIndexesIfNotExists() {
+}
+
+func (s SqlMessageExportConsumerStore) Save(consumer *model.MessageExportConsumer) (*model.MessageExportConsumer, *model.AppError) {
+	if len(consumer.Id) > 0 {
+		return nil, model.NewAppError("SqlMessageExportConsumerStore.Save", "store.sql_message_export_consumer.save.existing.app_error", nil, "id="+consumer.Id, http.StatusBadRequest)
+	}
+
+	consumer.PreSave()
+	if err := consumer.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(consumer); err != nil {
+		return nil, model.NewAppError("SqlMessageExportConsumerStore.Save", "store.sql_message_export_consumer.save.app_error", nil, "id="+consumer.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return consumer, nil
+}
+
+func (s SqlMessageExportConsumerStore) Update(consumer *model.MessageExportConsumer) (*model.MessageExportConsumer, *model.AppError) {
+	consumer.PreUpdate()
+	if err := consumer.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(consumer)
+	if err != nil {
+		return nil, model.NewAppError("SqlMessageExportConsumerStore.Update", "store.sql_message_export_consumer.update.app_error", nil, "id="+consumer.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		return nil, model.NewAppError("SqlMessageExportConsumerStore.Update", "store.sql_message_export_consumer.get.app_error", nil, "id="+consumer.Id, http.StatusNotFound)
+	}
+
+	return consumer, nil
+}
+
+func (s SqlMessageExportConsumerStore) Get(id string) (*model.MessageExportConsumer, *model.AppError) {
+	var consumer model.MessageExportConsumer
+
+	if err := s.GetMaster().SelectOne(&consumer, "SELECT * FROM MessageExportConsumers WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlMessageExportConsumerStore.Get", "store.sql_message_export_consumer.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlMessageExportConsumerStore.Get", "store.sql_message_export_consumer.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &consumer, nil
+}
+
+func (s SqlMessageExportConsumerStore) GetAll() ([]*model.MessageExportConsumer, *model.AppError) {
+	consumers := []*model.MessageExportConsumer{}
+
+	if _, err := s.GetReplica().Select(&consumers, "SELECT * FROM MessageExportConsumers ORDER BY Name, Id"); err != nil {
+		return nil, model.NewAppError("SqlMessageExportConsumerStore.GetAll", "store.sql_message_export_consumer.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return consumers, nil
+}
+
+func (s SqlMessageExportConsumerStore) Delete(id string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM MessageExportConsumers WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		return model.NewAppError("SqlMessageExportConsumerStore.Delete", "store.sql_message_export_consumer.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlMfaRecoveryCodeStore struct {
+	SqlStore
+}
+
+func NewSqlMfaRecoveryCodeStore(sqlStore SqlStore) store.MfaRecoveryCodeStore {
+	s := &SqlMfaRecoveryCodeStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.MfaRecoveryCode{}, "MfaRecoveryCodes").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("CodeHash").SetMaxSize(64)
+		table.SetUniqueTogether("UserId", "CodeHash")
+	}
+
+	return s
+}
+
+func (s SqlMfaRecoveryCodeStore) CreateIndexesIfNotExists() {
+}
+
+// SaveForUser replaces the recovery codes of a user, so that the codes generated before can't be used anymore.
+func (s SqlMfaRecoveryCodeStore) SaveForUser(userId string, codes []*model.MfaRecoveryCode) *model.AppError {
+	for _, code := range codes {
+		code.UserId = userId
+		code.PreSave()
+		if err := code.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlMfaRecoveryCodeStore.SaveForUser", "store.sql_mfa_recovery_code.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	if _, err := transaction.Exec("DELETE FROM MfaRecoveryCodes WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlMfaRecoveryCodeStore.SaveForUser", "store.sql_mfa_recovery_code.save.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, code := range codes {
+		if err := transaction.Insert(code); err != nil {
+			return model.NewAppError("SqlMfaRecoveryCodeStore.SaveForUser", "store.sql_mfa_recovery_code.save.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlMfaRecoveryCodeStore.SaveForUser", "store.sql_mfa_recovery_code.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// Use marks the unused recovery code of a user with the given hash as used, returning false when there is none.
+func (s SqlMfaRecoveryCodeStore) Use(userId, codeHash string) (bool, *model.AppError) {
+	result, err := s.GetMaster().Exec("UPDATE MfaRecoveryCodes SET UsedAt = :UsedAt WHERE UserId = :UserId AND CodeHash = :CodeHash AND UsedAt = 0", map[string]interface{}{"UsedAt": model.GetMillis(), "UserId": userId, "CodeHash": codeHash})
+	if err != nil {
+		return false, model.NewAppError("SqlMfaRecoveryCodeStore.Use", "store.sql_mfa_recovery_code.use.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, model.NewAppError("SqlMfaRecoveryCodeStore.Use", "store.sql_mfa_recovery_code.use.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (s SqlMfaRecoveryCodeStore) CountUnused(userId string) (int64, *model.AppError) {
+	count, err := s.GetReplica().SelectInt("SELECT COUNT(*) FROM MfaRecoveryCodes WHERE UserId = :UserId AND UsedAt = 0", map[string]interface{}{"UserId": userId})
+	if err != nil {
+		return 0, model.NewAppError("SqlMfaRecoveryCodeStore.CountUnused", "store.sql_mfa_recovery_code.count_unused.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+func (s SqlMfaRecoveryCodeStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM MfaRecoveryCodes WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlMfaRecoveryCodeStore.PermanentDeleteByUser", "store.sql_mfa_recovery_code.permanent_delete_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@
 package sqlstore
 
 import (
+	"database/sql"
 	"net/http"
 	"strings"
 
@@ -43,6 +44,7 @@ func NewSqlOAuthStore(sqlStore SqlStore) store.OAuthStore {
 		tableAccess.ColMap("UserId").SetMaxSize(26)
 		tableAccess.ColMap("Token").SetMaxSize(26)
 		tableAccess.ColMap("RefreshToken").SetMaxSize(26)
+		tableAccess.ColMap("PreviousRefreshToken").SetMaxSize(26)
 		tableAccess.ColMap("RedirectUri").SetMaxSize(256)
 		tableAccess.ColMap("Scope").SetMaxSize(128)
 		tableAccess.SetUniqueTogether("ClientId", "UserId")
@@ -56,6 +58,7 @@ func (as SqlOAuthStore) CreateIndexesIfNotExists() {
 	as.CreateIndexIfNotExists("idx_oauthaccessdata_client_id", "OAuthAccessData", "ClientId")
 	as.CreateIndexIfNotExists("idx_oauthaccessdata_user_id", "OAuthAccessData", "UserId")
 	as.CreateIndexIfNotExists("idx_oauthaccessdata_refresh_token", "OAuthAccessData", "RefreshToken")
+	as.CreateIndexIfNotExists("idx_oauthaccessdata_previous_refresh_token", "OAuthAccessData", "PreviousRefreshToken")
 	as.CreateIndexIfNotExists("idx_oauthauthdata_client_id", "OAuthAuthData", "Code")
 }
 
@@ -206,6 +209,18 @@ func (as SqlOAuthStore) GetAccessDataByRefreshToken(token string) (*model.Access
 	return &accessData, nil
 }
 
+func (as SqlOAuthStore) GetAccessDataByPreviousRefreshToken(token string) (*model.AccessData, *model.AppError) {
+	accessData := model.AccessData{}
+
+	if err := as.GetReplica().SelectOne(&accessData, "SELECT * FROM OAuthAccessData WHERE PreviousRefreshToken = :Token", map[string]interface{}{"Token": token}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlOAuthStore.GetAccessDataByPreviousRefreshToken", "store.sql_oauth.get_access_data.app_error", nil, err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlOAuthStore.GetAccessDataByPreviousRefreshToken", "store.sql_oauth.get_access_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &accessData, nil
+}
+
 func (as SqlOAuthStore) GetPreviousAccessData(userId, clientId string) (*model.AccessData, *model.AppError) {
 	accessData := model.AccessData{}
 
@@ -224,8 +239,8 @@ func (as SqlOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.A
 		return nil, err
 	}
 
-	if _, err := as.GetMaster().Exec("UPDATE OAuthAccessData SET Token = :Token, ExpiresAt = :ExpiresAt, RefreshToken = :RefreshToken WHERE ClientId = :ClientId AND UserID = :UserId",
-		map[string]interface{}{"Token": accessData.Token, "ExpiresAt": accessData.ExpiresAt, "RefreshToken": accessData.RefreshToken, "ClientId": accessData.ClientId, "UserId": accessData.UserId}); err != nil {
+	if _, err := as.GetMaster().Exec("UPDATE OAuthAccessData SET Token = :Token, ExpiresAt = :ExpiresAt, RefreshToken = :RefreshToken, PreviousRefreshToken = :PreviousRefreshToken, Scope = :Scope WHERE ClientId = :ClientId AND UserID = :UserId",
+		map[string]interface{}{"Token": accessData.Token, "ExpiresAt": accessData.ExpiresAt, "RefreshToken": accessData.RefreshToken, "PreviousRefreshToken": accessData.PreviousRefreshToken, "Scope": accessData.Scope, "ClientId": accessData.ClientId, "UserId": accessData.UserId}); err != nil {
 		return nil, model.NewAppError("SqlOAuthStore.Update", "store.sql_oauth.update_access_data.app_error", nil,
 			"clientId="+accessData.ClientId+",userId="+accessData.UserId+", "+err.Error(), http.StatusInternalServerError)
 	}
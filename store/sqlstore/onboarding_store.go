@@ -0,0 +1,134 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlOnboardingStore struct {
+	SqlStore
+}
+
+func NewSqlOnboardingStore(sqlStore SqlStore) store.OnboardingStore {
+	s := &SqlOnboardingStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		steps := db.AddTableWithName(model.OnboardingStep{}, "OnboardingSteps").SetKeys(false, "Id")
+		steps.ColMap("Id").SetMaxSize(26)
+		steps.ColMap("Type").SetMaxSize(32)
+		steps.ColMap("DisplayName").SetMaxSize(model.ONBOARDING_STEP_DISPLAY_NAME_MAX_RUNES * 4)
+		steps.ColMap("Description").SetMaxSize(model.ONBOARDING_STEP_DESCRIPTION_MAX_RUNES * 4)
+		steps.ColMap("ChannelId").SetMaxSize(26)
+
+		completions := db.AddTableWithName(model.OnboardingStepCompletion{}, "OnboardingStepCompletions").SetKeys(false, "UserId", "StepId")
+		completions.ColMap("UserId").SetMaxSize(26)
+		completions.ColMap("StepId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlOnboardingStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_onboardingsteps_channel_id", "OnboardingSteps", "ChannelId")
+}
+
+func (s SqlOnboardingStore) SaveStep(step *model.OnboardingStep) (*model.OnboardingStep, *model.AppError) {
+	if len(step.Id) > 0 {
+		return nil, model.NewAppError("SqlOnboardingStore.SaveStep", "store.sql_onboarding.save_step.existing.app_error", nil, "id="+step.Id, http.StatusBadRequest)
+	}
+
+	step.PreSave()
+	if err := step.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(step); err != nil {
+		return nil, model.NewAppError("SqlOnboardingStore.SaveStep", "store.sql_onboarding.save_step.app_error", nil, "id="+step.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return step, nil
+}
+
+func (s SqlOnboardingStore) UpdateStep(step *model.OnboardingStep) (*model.OnboardingStep, *model.AppError) {
+	step.PreUpdate()
+	if err := step.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().Update(step); err != nil {
+		return nil, model.NewAppError("SqlOnboardingStore.UpdateStep", "store.sql_onboarding.update_step.app_error", nil, "id="+step.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return step, nil
+}
+
+func (s SqlOnboardingStore) GetStep(id string) (*model.OnboardingStep, *model.AppError) {
+	var step model.OnboardingStep
+
+	if err := s.GetReplica().SelectOne(&step, "SELECT * FROM OnboardingSteps WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlOnboardingStore.GetStep", "store.sql_onboarding.get_step.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlOnboardingStore.GetStep", "store.sql_onboarding.get_step.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &step, nil
+}
+
+// GetSteps returns the steps of the onboarding checklist in the order they are shown in.
+func (s SqlOnboardingStore) GetSteps() ([]*model.OnboardingStep, *model.AppError) {
+	var steps []*model.OnboardingStep
+
+	if _, err := s.GetReplica().Select(&steps, "SELECT * FROM OnboardingSteps WHERE DeleteAt = 0 ORDER BY SortOrder, CreateAt, Id"); err != nil {
+		return nil, model.NewAppError("SqlOnboardingStore.GetSteps", "store.sql_onboarding.get_steps.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return steps, nil
+}
+
+func (s SqlOnboardingStore) DeleteStep(id string, deleteAt int64) *model.AppError {
+	if _, err := s.GetMaster().Exec("UPDATE OnboardingSteps SET DeleteAt = :DeleteAt, UpdateAt = :DeleteAt WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"DeleteAt": deleteAt, "Id": id}); err != nil {
+		return model.NewAppError("SqlOnboardingStore.DeleteStep", "store.sql_onboarding.delete_step.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlOnboardingStore) SaveCompletion(completion *model.OnboardingStepCompletion) (*model.OnboardingStepCompletion, *model.AppError) {
+	if completion.CompleteAt == 0 {
+		completion.CompleteAt = model.GetMillis()
+	}
+
+	if err := s.GetMaster().Insert(completion); err != nil {
+		if IsUniqueConstraintError(err, []string{"PRIMARY", "onboardingstepcompletions_pkey"}) {
+			return nil, model.NewAppError("SqlOnboardingStore.SaveCompletion", "store.sql_onboarding.save_completion.exists.app_error", nil, "user_id="+completion.UserId+", step_id="+completion.StepId+", "+err.Error(), http.StatusBadRequest)
+		}
+		return nil, model.NewAppError("SqlOnboardingStore.SaveCompletion", "store.sql_onboarding.save_completion.app_error", nil, "user_id="+completion.UserId+", step_id="+completion.StepId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return completion, nil
+}
+
+func (s SqlOnboardingStore) GetCompletionsForUser(userId string) ([]*model.OnboardingStepCompletion, *model.AppError) {
+	var completions []*model.OnboardingStepCompletion
+
+	if _, err := s.GetReplica().Select(&completions, "SELECT * FROM OnboardingStepCompletions WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return nil, model.NewAppError("SqlOnboardingStore.GetCompletionsForUser", "store.sql_onboarding.get_completions_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return completions, nil
+}
+
+func (s SqlOnboardingStore) PermanentDeleteCompletionsByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM OnboardingStepCompletions WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlOnboardingStore.PermanentDeleteCompletionsByUser", "store.sql_onboarding.permanent_delete_completions_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPendingEmojiStore struct {
+	SqlStore
+}
+
+func NewSqlPendingEmojiStore(sqlStore SqlStore) store.PendingEmojiStore {
+	s := &SqlPendingEmojiStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PendingEmoji{}, "PendingEmoji").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("Name").SetMaxSize(64).SetUnique(true)
+	}
+
+	return s
+}
+
+func (s SqlPendingEmojiStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_pendingemoji_creator_id", "PendingEmoji", "CreatorId")
+}
+
+func (s SqlPendingEmojiStore) Save(emoji *model.PendingEmoji) (*model.PendingEmoji, *model.AppError) {
+	emoji.PreSave()
+	if err := emoji.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(emoji); err != nil {
+		if IsUniqueConstraintError(err, []string{"Name", "pendingemoji_name_key"}) {
+			return nil, model.NewAppError("SqlPendingEmojiStore.Save", "store.sql_pending_emoji.save.duplicate.app_error", nil, "name="+emoji.Name+", "+err.Error(), http.StatusBadRequest)
+		}
+		return nil, model.NewAppError("SqlPendingEmojiStore.Save", "store.sql_pending_emoji.save.app_error", nil, "id="+emoji.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return emoji, nil
+}
+
+func (s SqlPendingEmojiStore) Get(id string) (*model.PendingEmoji, *model.AppError) {
+	return s.getBy("Id", id)
+}
+
+func (s SqlPendingEmojiStore) GetByName(name string) (*model.PendingEmoji, *model.AppError) {
+	return s.getBy("Name", name)
+}
+
+func (s SqlPendingEmojiStore) getBy(column, key string) (*model.PendingEmoji, *model.AppError) {
+	var emoji model.PendingEmoji
+
+	if err := s.GetMaster().SelectOne(&emoji, "SELECT * FROM PendingEmoji WHERE "+column+" = :Key", map[string]interface{}{"Key": key}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlPendingEmojiStore.Get", "store.sql_pending_emoji.get.app_error", nil, column+"="+key+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlPendingEmojiStore.Get", "store.sql_pending_emoji.get.app_error", nil, column+"="+key+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &emoji, nil
+}
+
+// GetList returns the emoji waiting for approval, starting with the oldest request.
+func (s SqlPendingEmojiStore) GetList(offset, limit int) ([]*model.PendingEmoji, *model.AppError) {
+	emojis := []*model.PendingEmoji{}
+
+	if _, err := s.GetReplica().Select(&emojis, "SELECT * FROM PendingEmoji ORDER BY CreateAt, Id LIMIT :Limit OFFSET :Offset", map[string]interface{}{"Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlPendingEmojiStore.GetList", "store.sql_pending_emoji.get_list.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return emojis, nil
+}
+
+func (s SqlPendingEmojiStore) CountByCreator(creatorId string) (int64, *model.AppError) {
+	count, err := s.GetReplica().SelectInt("SELECT COUNT(*) FROM PendingEmoji WHERE CreatorId = :CreatorId", map[string]interface{}{"CreatorId": creatorId})
+	if err != nil {
+		return 0, model.NewAppError("SqlPendingEmojiStore.CountByCreator", "store.sql_pending_emoji.count_by_creator.app_error", nil, "creator_id="+creatorId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+func (s SqlPendingEmojiStore) Delete(id string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PendingEmoji WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		return model.NewAppError("SqlPendingEmojiStore.Delete", "store.sql_pending_emoji.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
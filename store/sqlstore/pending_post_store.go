@@ -0,0 +1,128 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPendingPostStore struct {
+	SqlStore
+}
+
+func NewSqlPendingPostStore(sqlStore SqlStore) store.PendingPostStore {
+	s := &SqlPendingPostStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PendingPost{}, "PendingPosts").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("RootId").SetMaxSize(26)
+		table.ColMap("ParentId").SetMaxSize(26)
+		table.ColMap("Message").SetMaxSize(model.POST_MESSAGE_MAX_BYTES_V2)
+		table.ColMap("Type").SetMaxSize(26)
+		table.ColMap("Props").SetMaxSize(8000)
+		table.ColMap("FileIds").SetMaxSize(150)
+		table.ColMap("PendingPostId").SetMaxSize(100)
+	}
+
+	return s
+}
+
+func (s SqlPendingPostStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_pendingposts_channel_id_create_at", "PendingPosts", "ChannelId, CreateAt")
+	s.CreateIndexIfNotExists("idx_pendingposts_user_id", "PendingPosts", "UserId")
+}
+
+func (s SqlPendingPostStore) Save(pendingPost *model.PendingPost) (*model.PendingPost, *model.AppError) {
+	pendingPost.PreSave()
+	if err := pendingPost.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(pendingPost); err != nil {
+		return nil, model.NewAppError("SqlPendingPostStore.Save", "store.sql_pending_post.save.app_error", nil, "id="+pendingPost.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return pendingPost, nil
+}
+
+func (s SqlPendingPostStore) Get(id string) (*model.PendingPost, *model.AppError) {
+	var pendingPost model.PendingPost
+
+	if err := s.GetMaster().SelectOne(&pendingPost, "SELECT * FROM PendingPosts WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlPendingPostStore.Get", "store.sql_pending_post.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlPendingPostStore.Get", "store.sql_pending_post.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &pendingPost, nil
+}
+
+// GetForChannel returns a page of the posts awaiting review in a channel, oldest first.
+func (s SqlPendingPostStore) GetForChannel(channelId string, offset, limit int) ([]*model.PendingPost, *model.AppError) {
+	pendingPosts := []*model.PendingPost{}
+
+	if _, err := s.GetReplica().Select(&pendingPosts, `SELECT
+			*
+		FROM
+			PendingPosts
+		WHERE
+			ChannelId = :ChannelId
+		ORDER BY
+			CreateAt, Id
+		LIMIT :Limit
+		OFFSET :Offset`, map[string]interface{}{"ChannelId": channelId, "Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlPendingPostStore.GetForChannel", "store.sql_pending_post.get_for_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return pendingPosts, nil
+}
+
+func (s SqlPendingPostStore) GetCountForChannel(channelId string) (int64, *model.AppError) {
+	count, err := s.GetReplica().SelectInt("SELECT COUNT(*) FROM PendingPosts WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId})
+	if err != nil {
+		return 0, model.NewAppError("SqlPendingPostStore.GetCountForChannel", "store.sql_pending_post.get_count_for_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+// Delete removes a reviewed pending post, reporting whether it was still pending so that it's approved or rejected
+// only once when moderators review it concurrently.
+func (s SqlPendingPostStore) Delete(id string) (bool, *model.AppError) {
+	result, err := s.GetMaster().Exec("DELETE FROM PendingPosts WHERE Id = :Id", map[string]interface{}{"Id": id})
+	if err != nil {
+		return false, model.NewAppError("SqlPendingPostStore.Delete", "store.sql_pending_post.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, model.NewAppError("SqlPendingPostStore.Delete", "store.sql_pending_post.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (s SqlPendingPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PendingPosts WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlPendingPostStore.PermanentDeleteByUser", "store.sql_pending_post.permanent_delete_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlPendingPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PendingPosts WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlPendingPostStore.PermanentDeleteByChannel", "store.sql_pending_post.permanent_delete_by_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
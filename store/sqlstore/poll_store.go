@@ -0,0 +1,160 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPollStore struct {
+	SqlStore
+}
+
+func NewSqlPollStore(sqlStore SqlStore) store.PollStore {
+	s := &SqlPollStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.Poll{}, "Polls").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("Question").SetMaxSize(model.POLL_QUESTION_MAX_RUNES * 4)
+		table.ColMap("Options").SetMaxSize(model.POLL_MAX_OPTIONS * (model.POLL_OPTION_MAX_RUNES*4 + 3))
+
+		tableVotes := db.AddTableWithName(model.PollVote{}, "PollVotes").SetKeys(false, "PollId", "UserId")
+		tableVotes.ColMap("PollId").SetMaxSize(26)
+		tableVotes.ColMap("UserId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlPollStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_polls_channel_id", "Polls", "ChannelId")
+	s.CreateIndexIfNotExists("idx_polls_creator_id", "Polls", "CreatorId")
+	s.CreateIndexIfNotExists("idx_pollvotes_user_id", "PollVotes", "UserId")
+}
+
+func (s SqlPollStore) Save(poll *model.Poll) (*model.Poll, *model.AppError) {
+	if len(poll.Id) > 0 {
+		return nil, model.NewAppError("SqlPollStore.Save", "store.sql_poll.save.existing.app_error", nil, "id="+poll.Id, http.StatusBadRequest)
+	}
+
+	poll.PreSave()
+	if err := poll.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(poll); err != nil {
+		return nil, model.NewAppError("SqlPollStore.Save", "store.sql_poll.save.app_error", nil, "id="+poll.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return poll, nil
+}
+
+func (s SqlPollStore) Update(poll *model.Poll) (*model.Poll, *model.AppError) {
+	poll.PreUpdate()
+	if err := poll.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(poll)
+	if err != nil {
+		return nil, model.NewAppError("SqlPollStore.Update", "store.sql_poll.update.app_error", nil, "id="+poll.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		return nil, model.NewAppError("SqlPollStore.Update", "store.sql_poll.get.app_error", nil, "id="+poll.Id, http.StatusNotFound)
+	}
+
+	return poll, nil
+}
+
+func (s SqlPollStore) Get(id string) (*model.Poll, *model.AppError) {
+	var poll model.Poll
+
+	if err := s.GetMaster().SelectOne(&poll, "SELECT * FROM Polls WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlPollStore.Get", "store.sql_poll.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlPollStore.Get", "store.sql_poll.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &poll, nil
+}
+
+// SaveVote records the option a user voted for, replacing their previous vote on the poll if any.
+func (s SqlPollStore) SaveVote(vote *model.PollVote) (*model.PollVote, *model.AppError) {
+	vote.PreSave()
+	if err := vote.IsValid(); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, model.NewAppError("SqlPollStore.SaveVote", "store.sql_poll.save_vote.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	count, err := transaction.SelectInt("SELECT COUNT(*) FROM PollVotes WHERE PollId = :PollId AND UserId = :UserId", map[string]interface{}{"PollId": vote.PollId, "UserId": vote.UserId})
+	if err != nil {
+		return nil, model.NewAppError("SqlPollStore.SaveVote", "store.sql_poll.save_vote.app_error", nil, "poll_id="+vote.PollId+", user_id="+vote.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		err = transaction.Insert(vote)
+	} else {
+		_, err = transaction.Update(vote)
+	}
+	if err != nil {
+		return nil, model.NewAppError("SqlPollStore.SaveVote", "store.sql_poll.save_vote.app_error", nil, "poll_id="+vote.PollId+", user_id="+vote.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, model.NewAppError("SqlPollStore.SaveVote", "store.sql_poll.save_vote.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return vote, nil
+}
+
+func (s SqlPollStore) GetVotes(pollId string) ([]*model.PollVote, *model.AppError) {
+	votes := []*model.PollVote{}
+
+	if _, err := s.GetMaster().Select(&votes, "SELECT * FROM PollVotes WHERE PollId = :PollId ORDER BY CreateAt, UserId", map[string]interface{}{"PollId": pollId}); err != nil {
+		return nil, model.NewAppError("SqlPollStore.GetVotes", "store.sql_poll.get_votes.app_error", nil, "poll_id="+pollId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return votes, nil
+}
+
+func (s SqlPollStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PollVotes WHERE PollId IN (SELECT Id FROM Polls WHERE ChannelId = :ChannelId)", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlPollStore.PermanentDeleteByChannel", "store.sql_poll.delete.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, err := s.GetMaster().Exec("DELETE FROM Polls WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlPollStore.PermanentDeleteByChannel", "store.sql_poll.delete.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// PermanentDeleteByUser deletes the votes of a user along with the polls they created, since the posts of those polls
+// are deleted with the user.
+func (s SqlPollStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PollVotes WHERE UserId = :UserId OR PollId IN (SELECT Id FROM Polls WHERE CreatorId = :UserId)", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlPollStore.PermanentDeleteByUser", "store.sql_poll.delete.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, err := s.GetMaster().Exec("DELETE FROM Polls WHERE CreatorId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlPollStore.PermanentDeleteByUser", "store.sql_poll.delete.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
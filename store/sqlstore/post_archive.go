@@ -0,0 +1,227 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// CreateArchiveTableIfNotExists creates the table the threads that have seen no activity for a long time are moved to,
+// keeping the Posts table and its indexes small. The archive has the same columns as the Posts table, in the same
+// order, so a column added to the Posts table must be added to the archive too.
+//
+// On PostgreSQL, the archive can be partitioned by month of creation, which requires version 11 or later. Whether it
+// is can only be chosen when it's created.
+func (s *SqlPostStore) CreateArchiveTableIfNotExists(partitioned bool) {
+	if s.DoesTableExist("PostsArchive") {
+		s.archivePartitioned = s.isArchivePartitioned()
+		return
+	}
+
+	var query string
+	if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		query = "CREATE TABLE PostsArchive LIKE Posts"
+	} else if partitioned {
+		query = "CREATE TABLE PostsArchive (LIKE Posts INCLUDING DEFAULTS) PARTITION BY RANGE (CreateAt)"
+	} else {
+		query = "CREATE TABLE PostsArchive (LIKE Posts INCLUDING ALL)"
+	}
+
+	if _, err := s.GetMaster().ExecNoTimeout(query); err != nil {
+		mlog.Critical("Error creating the posts archive table.", mlog.Err(err))
+		time.Sleep(time.Second)
+		os.Exit(EXIT_CREATE_TABLE)
+	}
+
+	if partitioned {
+		// The indexes of a partitioned table are created on each of its partitions.
+		s.CreateIndexIfNotExists("idx_postsarchive_id", "PostsArchive", "Id")
+		s.CreateIndexIfNotExists("idx_postsarchive_create_at", "PostsArchive", "CreateAt")
+		s.CreateIndexIfNotExists("idx_postsarchive_root_id", "PostsArchive", "RootId")
+		s.CreateIndexIfNotExists("idx_postsarchive_user_id", "PostsArchive", "UserId")
+		s.CreateCompositeIndexIfNotExists("idx_postsarchive_channel_id_delete_at_create_at", "PostsArchive", []string{"ChannelId", "DeleteAt", "CreateAt"})
+		s.CreateFullTextIndexIfNotExists("idx_postsarchive_message_txt", "PostsArchive", "Message")
+		s.CreateFullTextIndexIfNotExists("idx_postsarchive_hashtags_txt", "PostsArchive", "Hashtags")
+	}
+
+	s.archivePartitioned = partitioned
+}
+
+func (s *SqlPostStore) isArchivePartitioned() bool {
+	if s.DriverName() != model.DATABASE_DRIVER_POSTGRES {
+		return false
+	}
+
+	// The catalog of partitioned tables doesn't exist before PostgreSQL 10, where no table can be partitioned.
+	count, err := s.GetMaster().SelectInt("SELECT COUNT(0) FROM pg_partitioned_table pt JOIN pg_class c ON c.oid = pt.partrelid WHERE c.relname = 'postsarchive'")
+	return err == nil && count > 0
+}
+
+// createArchivePartitions creates the monthly partitions of the archive that the posts created in [since, until] are
+// stored in.
+func (s *SqlPostStore) createArchivePartitions(since, until int64) error {
+	first := time.Unix(0, since*int64(time.Millisecond)).UTC()
+	last := time.Unix(0, until*int64(time.Millisecond)).UTC()
+
+	for month := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(last); month = month.AddDate(0, 1, 0) {
+		query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS PostsArchive_%s PARTITION OF PostsArchive FOR VALUES FROM (%d) TO (%d)",
+			month.Format("200601"), model.GetMillisForTime(month), model.GetMillisForTime(month.AddDate(0, 1, 0)))
+		if _, err := s.GetMaster().ExecNoTimeout(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ArchiveBatch moves at most limit threads that have seen no activity since the given time to the archive, oldest
+// first, returning the number of posts moved. Threads are moved as a whole, so that a thread is never split between
+// the Posts table and the archive.
+func (s *SqlPostStore) ArchiveBatch(before int64, limit int) (int64, *model.AppError) {
+	var rootIds []string
+	if _, err := s.GetMaster().Select(&rootIds, `
+		SELECT
+			p.Id
+		FROM
+			Posts p
+		WHERE
+			p.RootId = ''
+			AND p.CreateAt < :Before
+			AND NOT EXISTS (SELECT 1 FROM Posts r WHERE r.RootId = p.Id AND r.CreateAt >= :Before)
+		ORDER BY p.CreateAt
+		LIMIT :Limit`, map[string]interface{}{"Before": before, "Limit": limit}); err != nil {
+		return 0, model.NewAppError("SqlPostStore.ArchiveBatch", "store.sql_post.archive_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if len(rootIds) == 0 {
+		return 0, nil
+	}
+
+	keys, params := MapStringsToQueryParams(rootIds, "PostId")
+	where := "Id IN " + keys + " OR RootId IN " + keys
+
+	if s.archivePartitioned {
+		var bounds struct {
+			Since int64
+			Until int64
+		}
+		if err := s.GetMaster().SelectOne(&bounds, "SELECT MIN(CreateAt) AS Since, MAX(CreateAt) AS Until FROM Posts WHERE "+where, params); err != nil {
+			return 0, model.NewAppError("SqlPostStore.ArchiveBatch", "store.sql_post.archive_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		if err := s.createArchivePartitions(bounds.Since, bounds.Until); err != nil {
+			return 0, model.NewAppError("SqlPostStore.ArchiveBatch", "store.sql_post.archive_batch.partition.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return 0, model.NewAppError("SqlPostStore.ArchiveBatch", "store.sql_post.archive_batch.open_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	if _, err := transaction.Exec("INSERT INTO PostsArchive SELECT * FROM Posts WHERE "+where, params); err != nil {
+		return 0, model.NewAppError("SqlPostStore.ArchiveBatch", "store.sql_post.archive_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	result, err := transaction.Exec("DELETE FROM Posts WHERE "+where, params)
+	if err != nil {
+		return 0, model.NewAppError("SqlPostStore.ArchiveBatch", "store.sql_post.archive_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, model.NewAppError("SqlPostStore.ArchiveBatch", "store.sql_post.archive_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return 0, model.NewAppError("SqlPostStore.ArchiveBatch", "store.sql_post.archive_batch.commit_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+// getNewestArchivedCreateAt returns the creation time of the newest archived post, or 0 if no post has been archived.
+func (s *SqlPostStore) getNewestArchivedCreateAt() (int64, error) {
+	return s.GetReplica().SelectInt("SELECT COALESCE(MAX(CreateAt), 0) FROM PostsArchive")
+}
+
+// needsArchivedPosts returns true if the archive may hold posts belonging to a page of posts, sorted from newest to
+// oldest, read from the Posts table only: the page either isn't full or reaches back to the archived posts.
+func needsArchivedPosts(posts []*model.Post, limit int, newestArchivedCreateAt int64) bool {
+	if newestArchivedCreateAt == 0 {
+		return false
+	}
+
+	return len(posts) < limit || posts[len(posts)-1].CreateAt <= newestArchivedCreateAt
+}
+
+// getPostsBeforeWithArchive returns the posts of a channel created before the given post, from both the Posts table
+// and the archive.
+func (s *SqlPostStore) getPostsBeforeWithArchive(channelId string, postId string, limit int, offset int) ([]*model.Post, error) {
+	params := map[string]interface{}{"ChannelId": channelId, "PostId": postId, "Limit": limit, "Offset": offset, "Total": limit + offset}
+
+	createAt, err := s.GetReplica().SelectInt("SELECT CreateAt FROM Posts WHERE Id = :PostId UNION ALL SELECT CreateAt FROM PostsArchive WHERE Id = :PostId", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []*model.Post
+	if createAt == 0 {
+		return posts, nil
+	}
+	params["CreateAt"] = createAt
+
+	query := `
+		SELECT
+			*
+		FROM
+			POSTS_TABLE
+		WHERE
+			CreateAt < :CreateAt
+			AND ChannelId = :ChannelId
+			AND DeleteAt = 0
+		ORDER BY CreateAt DESC
+		LIMIT :Total`
+
+	_, err = s.GetReplica().Select(&posts, `
+		SELECT
+			*
+		FROM
+			(`+unionWithArchive(query)+`) AS AllPosts
+		ORDER BY CreateAt DESC
+		LIMIT :Limit
+		OFFSET :Offset`, params)
+
+	return posts, err
+}
+
+// unionWithArchive returns the union of a query selecting posts from POSTS_TABLE run on the Posts table and on the
+// archive.
+func unionWithArchive(query string) string {
+	return "(" + strings.Replace(query, "POSTS_TABLE", "Posts", -1) + ") UNION ALL (" + strings.Replace(query, "POSTS_TABLE", "PostsArchive", -1) + ")"
+}
+
+// mergeArchivedPosts merges posts read from the archive into ones read from the Posts table, both sorted from newest
+// to oldest, keeping at most limit posts.
+func mergeArchivedPosts(posts []*model.Post, archived []*model.Post, limit int) []*model.Post {
+	merged := append(posts, archived...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].CreateAt > merged[j].CreateAt
+	})
+
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+
+	return merged
+}
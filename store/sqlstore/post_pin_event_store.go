@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPostPinEventStore struct {
+	SqlStore
+}
+
+func NewSqlPostPinEventStore(sqlStore SqlStore) store.PostPinEventStore {
+	s := &SqlPostPinEventStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PostPinEvent{}, "PostPinEvents").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlPostPinEventStore) CreateIndexesIfNotExists() {
+	s.CreateCompositeIndexIfNotExists("idx_postpinevents_channel_id_create_at", "PostPinEvents", []string{"ChannelId", "CreateAt"})
+}
+
+func (s SqlPostPinEventStore) Save(event *model.PostPinEvent) (*model.PostPinEvent, *model.AppError) {
+	event.PreSave()
+	if err := event.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(event); err != nil {
+		return nil, model.NewAppError("SqlPostPinEventStore.Save", "store.sql_post_pin_event.save.app_error", nil, "post_id="+event.PostId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return event, nil
+}
+
+// GetForChannel returns the pin events of the channel that happened between the two timestamps, oldest first.
+func (s SqlPostPinEventStore) GetForChannel(channelId string, startTime, endTime int64) ([]*model.PostPinEvent, *model.AppError) {
+	var events []*model.PostPinEvent
+
+	if _, err := s.GetReplica().Select(&events, `
+		SELECT *
+		FROM PostPinEvents
+		WHERE ChannelId = :ChannelId AND CreateAt >= :StartTime AND CreateAt <= :EndTime
+		ORDER BY CreateAt, Id`, map[string]interface{}{"ChannelId": channelId, "StartTime": startTime, "EndTime": endTime}); err != nil {
+		return nil, model.NewAppError("SqlPostPinEventStore.GetForChannel", "store.sql_post_pin_event.get_for_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return events, nil
+}
+
+func (s SqlPostPinEventStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PostPinEvents WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlPostPinEventStore.PermanentDeleteByChannel", "store.sql_post_pin_event.permanent_delete_by_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPostPurgeStore struct {
+	SqlStore
+}
+
+func NewSqlPostPurgeStore(sqlStore SqlStore) store.PostPurgeStore {
+	s := &SqlPostPurgeStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PostPurge{}, "PostPurges").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("Scope").SetMaxSize(16)
+		table.ColMap("TargetId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("Status").SetMaxSize(16)
+	}
+
+	return s
+}
+
+func (s SqlPostPurgeStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_postpurges_purge_at", "PostPurges", "PurgeAt")
+}
+
+func (s SqlPostPurgeStore) Save(purge *model.PostPurge) (*model.PostPurge, *model.AppError) {
+	if len(purge.Id) > 0 {
+		return nil, model.NewAppError("SqlPostPurgeStore.Save", "store.sql_post_purge.save.existing.app_error", nil, "id="+purge.Id, http.StatusBadRequest)
+	}
+
+	purge.PreSave()
+	if err := purge.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(purge); err != nil {
+		return nil, model.NewAppError("SqlPostPurgeStore.Save", "store.sql_post_purge.save.app_error", nil, "id="+purge.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return purge, nil
+}
+
+func (s SqlPostPurgeStore) Update(purge *model.PostPurge) (*model.PostPurge, *model.AppError) {
+	purge.PreUpdate()
+	if err := purge.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(purge)
+	if err != nil {
+		return nil, model.NewAppError("SqlPostPurgeStore.Update", "store.sql_post_purge.update.app_error", nil, "id="+purge.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		return nil, model.NewAppError("SqlPostPurgeStore.Update", "store.sql_post_purge.get.app_error", nil, "id="+purge.Id, http.StatusNotFound)
+	}
+
+	return purge, nil
+}
+
+func (s SqlPostPurgeStore) Get(id string) (*model.PostPurge, *model.AppError) {
+	var purge model.PostPurge
+
+	if err := s.GetMaster().SelectOne(&purge, "SELECT * FROM PostPurges WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlPostPurgeStore.Get", "store.sql_post_purge.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlPostPurgeStore.Get", "store.sql_post_purge.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &purge, nil
+}
+
+// GetAll returns the purges starting with the most recently requested.
+func (s SqlPostPurgeStore) GetAll(offset, limit int) ([]*model.PostPurge, *model.AppError) {
+	purges := []*model.PostPurge{}
+
+	if _, err := s.GetReplica().Select(&purges, "SELECT * FROM PostPurges ORDER BY CreateAt DESC, Id LIMIT :Limit OFFSET :Offset", map[string]interface{}{"Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlPostPurgeStore.GetAll", "store.sql_post_purge.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return purges, nil
+}
+
+// GetDue returns the pending purges whose delay has passed, starting with the earliest.
+func (s SqlPostPurgeStore) GetDue(before int64, limit int) ([]*model.PostPurge, *model.AppError) {
+	purges := []*model.PostPurge{}
+
+	if _, err := s.GetMaster().Select(&purges, "SELECT * FROM PostPurges WHERE Status = :Status AND PurgeAt <= :Before ORDER BY PurgeAt, Id LIMIT :Limit", map[string]interface{}{"Status": model.POST_PURGE_STATUS_PENDING, "Before": before, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlPostPurgeStore.GetDue", "store.sql_post_purge.get_due.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return purges, nil
+}
+
+// ResetStale puts the purges that have been running without making progress since staleBefore back to pending, so that
+// they are picked up again after the server running them went away. It returns how many purges it reset.
+func (s SqlPostPurgeStore) ResetStale(staleBefore int64) (int64, *model.AppError) {
+	result, err := s.GetMaster().Exec("UPDATE PostPurges SET Status = :Pending, UpdateAt = :UpdateAt WHERE Status = :Running AND UpdateAt < :StaleBefore", map[string]interface{}{"Pending": model.POST_PURGE_STATUS_PENDING, "Running": model.POST_PURGE_STATUS_RUNNING, "UpdateAt": model.GetMillis(), "StaleBefore": staleBefore})
+	if err != nil {
+		return 0, model.NewAppError("SqlPostPurgeStore.ResetStale", "store.sql_post_purge.reset_stale.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, model.NewAppError("SqlPostPurgeStore.ResetStale", "store.sql_post_purge.reset_stale.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+// Claim marks a pending purge as running, returning false if it isn't pending anymore, such as when another server
+// claimed it first or it was canceled.
+func (s SqlPostPurgeStore) Claim(id string) (bool, *model.AppError) {
+	result, err := s.GetMaster().Exec("UPDATE PostPurges SET Status = :Running, UpdateAt = :UpdateAt WHERE Id = :Id AND Status = :Pending", map[string]interface{}{"Running": model.POST_PURGE_STATUS_RUNNING, "Pending": model.POST_PURGE_STATUS_PENDING, "UpdateAt": model.GetMillis(), "Id": id})
+	if err != nil {
+		return false, model.NewAppError("SqlPostPurgeStore.Claim", "store.sql_post_purge.claim.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, model.NewAppError("SqlPostPurgeStore.Claim", "store.sql_post_purge.claim.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return count == 1, nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPostReportStore struct {
+	SqlStore
+}
+
+func NewSqlPostReportStore(sqlStore SqlStore) store.PostReportStore {
+	s := &SqlPostReportStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PostReport{}, "PostReports").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("ReporterId").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("Reason").SetMaxSize(32)
+		table.ColMap("Comment").SetMaxSize(model.POST_REPORT_COMMENT_MAX_LENGTH)
+		table.ColMap("State").SetMaxSize(32)
+		table.ColMap("ResolverId").SetMaxSize(26)
+		table.ColMap("PostUserId").SetMaxSize(26)
+		table.ColMap("PostMessage").SetMaxSize(model.POST_MESSAGE_MAX_BYTES_V2)
+		table.ColMap("PostProps").SetMaxSize(8000)
+		table.ColMap("PostFileIds").SetMaxSize(150)
+		table.ColMap("NotificationPostId").SetMaxSize(26)
+
+		eventTable := db.AddTableWithName(model.PostReportEvent{}, "PostReportEvents").SetKeys(false, "Id")
+		eventTable.ColMap("Id").SetMaxSize(26)
+		eventTable.ColMap("ReportId").SetMaxSize(26)
+		eventTable.ColMap("ActorId").SetMaxSize(26)
+		eventTable.ColMap("FromState").SetMaxSize(32)
+		eventTable.ColMap("ToState").SetMaxSize(32)
+		eventTable.ColMap("Comment").SetMaxSize(model.POST_REPORT_COMMENT_MAX_LENGTH)
+	}
+
+	return s
+}
+
+func (s SqlPostReportStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_postreports_state_create_at", "PostReports", "State, CreateAt")
+	s.CreateIndexIfNotExists("idx_postreports_post_id", "PostReports", "PostId")
+	s.CreateIndexIfNotExists("idx_postreportevents_report_id", "PostReportEvents", "ReportId")
+}
+
+func (s SqlPostReportStore) Save(report *model.PostReport) (*model.PostReport, *model.AppError) {
+	if len(report.Id) > 0 {
+		return nil, model.NewAppError("SqlPostReportStore.Save", "store.sql_post_report.save.existing.app_error", nil, "id="+report.Id, http.StatusBadRequest)
+	}
+
+	report.PreSave()
+	if err := report.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(report); err != nil {
+		return nil, model.NewAppError("SqlPostReportStore.Save", "store.sql_post_report.save.app_error", nil, "id="+report.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return report, nil
+}
+
+// Update saves the state of a report and the post notifying moderators of it, as long as the report is still in
+// its previous state, so that concurrent changes by different moderators don't overwrite each other.
+func (s SqlPostReportStore) Update(report *model.PostReport, previousState string) (*model.PostReport, *model.AppError) {
+	report.PreUpdate()
+	if err := report.IsValid(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.GetMaster().Exec(`UPDATE
+			PostReports
+		SET
+			UpdateAt = :UpdateAt,
+			State = :State,
+			ResolverId = :ResolverId,
+			NotificationPostId = :NotificationPostId
+		WHERE
+			Id = :Id
+			AND State = :PreviousState`, map[string]interface{}{
+		"UpdateAt":           report.UpdateAt,
+		"State":              report.State,
+		"ResolverId":         report.ResolverId,
+		"NotificationPostId": report.NotificationPostId,
+		"Id":                 report.Id,
+		"PreviousState":      previousState,
+	})
+	if err != nil {
+		return nil, model.NewAppError("SqlPostReportStore.Update", "store.sql_post_report.update.app_error", nil, "id="+report.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, model.NewAppError("SqlPostReportStore.Update", "store.sql_post_report.update.conflict.app_error", nil, "id="+report.Id, http.StatusConflict)
+	}
+
+	return report, nil
+}
+
+func (s SqlPostReportStore) Get(id string) (*model.PostReport, *model.AppError) {
+	var report model.PostReport
+
+	if err := s.GetReplica().SelectOne(&report, "SELECT * FROM PostReports WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlPostReportStore.Get", "store.sql_post_report.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlPostReportStore.Get", "store.sql_post_report.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &report, nil
+}
+
+// GetOpenForPostAndReporter returns the open report a user made of a post, if any.
+func (s SqlPostReportStore) GetOpenForPostAndReporter(postId, reporterId string) (*model.PostReport, *model.AppError) {
+	var report model.PostReport
+
+	if err := s.GetMaster().SelectOne(&report, "SELECT * FROM PostReports WHERE PostId = :PostId AND ReporterId = :ReporterId AND State = :State", map[string]interface{}{"PostId": postId, "ReporterId": reporterId, "State": model.POST_REPORT_STATE_OPEN}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlPostReportStore.GetOpenForPostAndReporter", "store.sql_post_report.get.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlPostReportStore.GetOpenForPostAndReporter", "store.sql_post_report.get.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &report, nil
+}
+
+// GetAll returns a page of the reports in the given state, or of all reports if it's empty, oldest first.
+func (s SqlPostReportStore) GetAll(state string, offset, limit int) ([]*model.PostReport, *model.AppError) {
+	reports := []*model.PostReport{}
+
+	query := "SELECT * FROM PostReports"
+	if state != "" {
+		query += " WHERE State = :State"
+	}
+	query += " ORDER BY CreateAt, Id LIMIT :Limit OFFSET :Offset"
+
+	if _, err := s.GetReplica().Select(&reports, query, map[string]interface{}{"State": state, "Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlPostReportStore.GetAll", "store.sql_post_report.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return reports, nil
+}
+
+func (s SqlPostReportStore) SaveEvent(event *model.PostReportEvent) (*model.PostReportEvent, *model.AppError) {
+	event.PreSave()
+	if err := event.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(event); err != nil {
+		return nil, model.NewAppError("SqlPostReportStore.SaveEvent", "store.sql_post_report.save_event.app_error", nil, "report_id="+event.ReportId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return event, nil
+}
+
+// GetEvents returns the history of a report, oldest first.
+func (s SqlPostReportStore) GetEvents(reportId string) ([]*model.PostReportEvent, *model.AppError) {
+	events := []*model.PostReportEvent{}
+
+	if _, err := s.GetReplica().Select(&events, "SELECT * FROM PostReportEvents WHERE ReportId = :ReportId ORDER BY CreateAt, Id", map[string]interface{}{"ReportId": reportId}); err != nil {
+		return nil, model.NewAppError("SqlPostReportStore.GetEvents", "store.sql_post_report.get_events.app_error", nil, "report_id="+reportId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPostStarStore struct {
+	SqlStore
+}
+
+func NewSqlPostStarStore(sqlStore SqlStore) store.PostStarStore {
+	s := &SqlPostStarStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PostStar{}, "PostStars").SetKeys(false, "PostId", "UserId")
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlPostStarStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_poststars_user_id", "PostStars", "UserId")
+}
+
+// Save stars the post for the user, returning the existing star if they already starred it.
+func (s SqlPostStarStore) Save(star *model.PostStar) (*model.PostStar, *model.AppError) {
+	star.PreSave()
+	if err := star.IsValid(); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, model.NewAppError("SqlPostStarStore.Save", "store.sql_post_star.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	var existing model.PostStar
+	err = transaction.SelectOne(&existing, "SELECT * FROM PostStars WHERE PostId = :PostId AND UserId = :UserId", map[string]interface{}{"PostId": star.PostId, "UserId": star.UserId})
+	if err == nil {
+		return &existing, nil
+	} else if err != sql.ErrNoRows {
+		return nil, model.NewAppError("SqlPostStarStore.Save", "store.sql_post_star.save.app_error", nil, "post_id="+star.PostId+", user_id="+star.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if err = transaction.Insert(star); err != nil {
+		return nil, model.NewAppError("SqlPostStarStore.Save", "store.sql_post_star.save.app_error", nil, "post_id="+star.PostId+", user_id="+star.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, model.NewAppError("SqlPostStarStore.Save", "store.sql_post_star.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return star, nil
+}
+
+func (s SqlPostStarStore) Get(postId, userId string) (*model.PostStar, *model.AppError) {
+	var star model.PostStar
+
+	if err := s.GetReplica().SelectOne(&star, "SELECT * FROM PostStars WHERE PostId = :PostId AND UserId = :UserId", map[string]interface{}{"PostId": postId, "UserId": userId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlPostStarStore.Get", "store.sql_post_star.get.app_error", nil, "post_id="+postId+", user_id="+userId+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlPostStarStore.Get", "store.sql_post_star.get.app_error", nil, "post_id="+postId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &star, nil
+}
+
+func (s SqlPostStarStore) Delete(postId, userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PostStars WHERE PostId = :PostId AND UserId = :UserId", map[string]interface{}{"PostId": postId, "UserId": userId}); err != nil {
+		return model.NewAppError("SqlPostStarStore.Delete", "store.sql_post_star.delete.app_error", nil, "post_id="+postId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlPostStarStore) GetCountForPost(postId string) (int64, *model.AppError) {
+	count, err := s.GetReplica().SelectInt("SELECT COUNT(*) FROM PostStars WHERE PostId = :PostId", map[string]interface{}{"PostId": postId})
+	if err != nil {
+		return 0, model.NewAppError("SqlPostStarStore.GetCountForPost", "store.sql_post_star.get_count_for_post.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+// GetCountsForPosts returns the number of stars of each of the given posts. Posts without any star are omitted.
+func (s SqlPostStarStore) GetCountsForPosts(postIds []string) ([]*model.PostStarCount, *model.AppError) {
+	if len(postIds) == 0 {
+		return []*model.PostStarCount{}, nil
+	}
+
+	keys, params := MapStringsToQueryParams(postIds, "postId")
+	var counts []*model.PostStarCount
+
+	if _, err := s.GetReplica().Select(&counts, `SELECT
+			PostId, COUNT(*) AS Count
+		FROM
+			PostStars
+		WHERE
+			PostId IN `+keys+`
+		GROUP BY
+			PostId`, params); err != nil {
+		return nil, model.NewAppError("SqlPostStarStore.GetCountsForPosts", "store.sql_post_star.get_counts_for_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return counts, nil
+}
+
+func (s SqlPostStarStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PostStars WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlPostStarStore.PermanentDeleteByUser", "store.sql_post_star.permanent_delete_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
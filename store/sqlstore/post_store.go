@@ -4,6 +4,7 @@
 package sqlstore
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net/http"
@@ -27,6 +28,8 @@ type SqlPostStore struct {
 	lastPostsCache    *utils.Cache
 	maxPostSizeOnce   sync.Once
 	maxPostSizeCached int
+
+	archivePartitioned bool
 }
 
 const (
@@ -35,6 +38,8 @@ const (
 
 	LAST_POSTS_CACHE_SIZE = 1000
 	LAST_POSTS_CACHE_SEC  = 900 // 15 minutes
+
+	POSTS_SEARCH_LIMIT = 100
 )
 
 func (s *SqlPostStore) ClearCaches() {
@@ -181,6 +186,20 @@ func (s *SqlPostStore) Overwrite(post *model.Post) (*model.Post, *model.AppError
 	return post, nil
 }
 
+// OverwriteProps replaces the props of a post, whether archived or not, without going through the checks made when
+// a post is edited.
+func (s *SqlPostStore) OverwriteProps(post *model.Post) *model.AppError {
+	post.UpdateAt = model.GetMillis()
+
+	for _, table := range []string{"Posts", "PostsArchive"} {
+		if _, err := s.GetMaster().Exec("UPDATE "+table+" SET Props = :Props, UpdateAt = :UpdateAt WHERE Id = :Id", map[string]interface{}{"Props": model.StringInterfaceToJson(post.Props), "UpdateAt": post.UpdateAt, "Id": post.Id}); err != nil {
+			return model.NewAppError("SqlPostStore.OverwriteProps", "store.sql_post.overwrite.app_error", nil, "id="+post.Id+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
 func (s *SqlPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, *model.AppError) {
 	pl := model.NewPostList()
 
@@ -307,8 +326,22 @@ func (s *SqlPostStore) Get(id string) (*model.PostList, *model.AppError) {
 }
 
 func (s *SqlPostStore) GetSingle(id string) (*model.Post, *model.AppError) {
+	return s.getSingle(id, false)
+}
+
+// GetSingleFromMaster reads the post from the master, for callers that must see it right after it's been saved.
+func (s *SqlPostStore) GetSingleFromMaster(id string) (*model.Post, *model.AppError) {
+	return s.getSingle(id, true)
+}
+
+func (s *SqlPostStore) getSingle(id string, master bool) (*model.Post, *model.AppError) {
+	db := s.GetReplica()
+	if master {
+		db = s.GetMaster()
+	}
+
 	var post model.Post
-	err := s.GetReplica().SelectOne(&post, "SELECT * FROM Posts WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id})
+	err := db.SelectOne(&post, "SELECT * FROM Posts WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id})
 	if err != nil {
 		return nil, model.NewAppError("SqlPostStore.GetSingle", "store.sql_post.get.app_error", nil, "id="+id+err.Error(), http.StatusNotFound)
 	}
@@ -439,12 +472,155 @@ func (s *SqlPostStore) PermanentDeleteByUser(userId string) *model.AppError {
 }
 
 func (s *SqlPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
-	if _, err := s.GetMaster().Exec("DELETE FROM Posts WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
-		return model.NewAppError("SqlPostStore.PermanentDeleteByChannel", "store.sql_post.permanent_delete_by_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	for _, table := range []string{"Posts", "PostsArchive"} {
+		if _, err := s.GetMaster().Exec("DELETE FROM "+table+" WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+			return model.NewAppError("SqlPostStore.PermanentDeleteByChannel", "store.sql_post.permanent_delete_by_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+	return nil
+}
+
+// GetForPurge returns posts to permanently delete within the scope of a purge, whether deleted or archived, including
+// the copies that keep the edit history of the posts. The posts are returned in batches, which are expected to be
+// deleted before fetching the next one.
+func (s *SqlPostStore) GetForPurge(scope, targetId string, limit int) ([]*model.Post, *model.AppError) {
+	var condition string
+	switch scope {
+	case model.POST_PURGE_SCOPE_POST:
+		condition = "Id = :TargetId OR OriginalId = :TargetId"
+	case model.POST_PURGE_SCOPE_THREAD:
+		condition = "Id = :TargetId OR RootId = :TargetId OR OriginalId = :TargetId"
+	case model.POST_PURGE_SCOPE_CHANNEL:
+		condition = "ChannelId = :TargetId"
+	default:
+		return nil, model.NewAppError("SqlPostStore.GetForPurge", "store.sql_post.get_for_purge.app_error", nil, "scope="+scope, http.StatusBadRequest)
+	}
+
+	query := "SELECT * FROM Posts WHERE " + condition + " UNION ALL SELECT * FROM PostsArchive WHERE " + condition + " LIMIT :Limit"
+
+	var posts []*model.Post
+	if _, err := s.GetMaster().Select(&posts, query, map[string]interface{}{"TargetId": targetId, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlPostStore.GetForPurge", "store.sql_post.get_for_purge.app_error", nil, "scope="+scope+", target_id="+targetId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return posts, nil
+}
+
+// GetByUserAfter returns the posts of a user, whether archived or not, ordered by id, that come after the given post id.
+func (s *SqlPostStore) GetByUserAfter(userId, afterId string, limit int) ([]*model.Post, *model.AppError) {
+	query := `
+		SELECT
+			*
+		FROM
+			POSTS_TABLE
+		WHERE
+			UserId = :UserId
+			AND Id > :AfterId
+		ORDER BY Id
+		LIMIT :Limit`
+
+	var posts []*model.Post
+	if _, err := s.GetMaster().Select(&posts, "SELECT * FROM ("+unionWithArchive(query)+") AS AllPosts ORDER BY Id LIMIT :Limit", map[string]interface{}{"UserId": userId, "AfterId": afterId, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlPostStore.GetByUserAfter", "store.sql_post.get_by_user_after.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return posts, nil
+}
+
+// GetForChannelAfter pages through the posts of a channel in the order they were created, returning those that come
+// after the given creation time and id and were created no later than endTime. Paging by both the creation time and
+// the id means that posts created in the same millisecond are neither skipped nor repeated across pages.
+func (s *SqlPostStore) GetForChannelAfter(channelId string, afterCreateAt int64, afterId string, endTime int64, limit int) ([]*model.Post, *model.AppError) {
+	var posts []*model.Post
+	if _, err := s.GetReplica().Select(&posts, `
+		SELECT *
+		FROM Posts
+		WHERE ChannelId = :ChannelId
+			AND DeleteAt = 0
+			AND (CreateAt > :AfterCreateAt OR (CreateAt = :AfterCreateAt AND Id > :AfterId))
+			AND CreateAt <= :EndTime
+		ORDER BY CreateAt, Id
+		LIMIT :Limit`, map[string]interface{}{"ChannelId": channelId, "AfterCreateAt": afterCreateAt, "AfterId": afterId, "EndTime": endTime, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlPostStore.GetForChannelAfter", "store.sql_post.get_for_channel_after.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return posts, nil
+}
+
+// PermanentDeleteForPurge deletes posts from the database, whether archived or not, along with what refers to them:
+// their reactions, stars, pin events, the flags users put on them, the reports made about them and the pending replies
+// to them.
+func (s *SqlPostStore) PermanentDeleteForPurge(postIds []string) *model.AppError {
+	if len(postIds) == 0 {
+		return nil
+	}
+
+	keys, params := MapStringsToQueryParams(postIds, "PostId")
+	params["FlaggedPost"] = model.PREFERENCE_CATEGORY_FLAGGED_POST
+
+	for _, query := range []string{
+		"DELETE FROM Reactions WHERE PostId IN " + keys,
+		"DELETE FROM PostStars WHERE PostId IN " + keys,
+		"DELETE FROM PostPinEvents WHERE PostId IN " + keys,
+		"DELETE FROM Preferences WHERE Category = :FlaggedPost AND Name IN " + keys,
+		"DELETE FROM PostReportEvents WHERE ReportId IN (SELECT Id FROM PostReports WHERE PostId IN " + keys + ")",
+		"DELETE FROM PostReports WHERE PostId IN " + keys,
+		"DELETE FROM PendingPosts WHERE RootId IN " + keys,
+	} {
+		if _, err := s.GetMaster().Exec(query, params); err != nil {
+			return model.NewAppError("SqlPostStore.PermanentDeleteForPurge", "store.sql_post.permanent_delete_for_purge.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
 	}
+
+	for _, table := range []string{"Posts", "PostsArchive"} {
+		if _, err := s.GetMaster().Exec("DELETE FROM "+table+" WHERE Id IN "+keys, params); err != nil {
+			return model.NewAppError("SqlPostStore.PermanentDeleteForPurge", "store.sql_post.permanent_delete_for_purge.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
 	return nil
 }
 
+// GetThreadParticipantCounts returns the users who posted in the threads the given user posted in since the given
+// time, along with in how many of these threads, for the limit users sharing the most.
+func (s *SqlPostStore) GetThreadParticipantCounts(userId string, since int64, limit int) (map[string]int64, *model.AppError) {
+	query := `SELECT
+			Others.UserId AS UserId, COUNT(DISTINCT UserThreads.RootId) AS Shared
+		FROM
+			(SELECT DISTINCT
+				CASE WHEN RootId = '' THEN Id ELSE RootId END AS RootId
+			FROM
+				Posts
+			WHERE
+				UserId = :UserId
+				AND CreateAt > :Since
+				AND DeleteAt = 0
+				AND Type NOT LIKE '` + model.POST_SYSTEM_MESSAGE_PREFIX + `%') UserThreads
+			JOIN Posts Others ON Others.Id = UserThreads.RootId OR Others.RootId = UserThreads.RootId
+		WHERE
+			Others.UserId != :UserId
+			AND Others.DeleteAt = 0
+			AND Others.Type NOT LIKE '` + model.POST_SYSTEM_MESSAGE_PREFIX + `%'
+		GROUP BY Others.UserId
+		ORDER BY Shared DESC
+		LIMIT :Limit`
+
+	var participants []struct {
+		UserId string
+		Shared int64
+	}
+	if _, err := s.GetReplica().Select(&participants, query, map[string]interface{}{"UserId": userId, "Since": since, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlPostStore.GetThreadParticipantCounts", "store.sql_post.get_thread_participant_counts.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	counts := make(map[string]int64, len(participants))
+	for _, participant := range participants {
+		counts[participant.UserId] = participant.Shared
+	}
+
+	return counts, nil
+}
+
 func (s *SqlPostStore) GetPosts(channelId string, offset int, limit int, allowFromCache bool) (*model.PostList, *model.AppError) {
 	if limit > 1000 {
 		return nil, model.NewAppError("SqlPostStore.GetLinearPosts", "store.sql_post.get_posts.app_error", nil, "channelId="+channelId, http.StatusBadRequest)
@@ -617,6 +793,22 @@ func (s *SqlPostStore) getPostsAround(channelId string, postId string, limit int
 		return nil, model.NewAppError("SqlPostStore.GetPostContext", "store.sql_post.get_posts_around.get.app_error", nil, "channelId="+channelId+err.Error(), http.StatusInternalServerError)
 	}
 
+	// Older posts may have been archived, in which case they're read from the archive as well.
+	withArchive := false
+	if before {
+		newestArchivedCreateAt, err := s.getNewestArchivedCreateAt()
+		if err != nil {
+			return nil, model.NewAppError("SqlPostStore.GetPostContext", "store.sql_post.get_posts_around.get.app_error", nil, "channelId="+channelId+err.Error(), http.StatusInternalServerError)
+		}
+
+		if needsArchivedPosts(posts, limit, newestArchivedCreateAt) {
+			withArchive = true
+			if posts, err = s.getPostsBeforeWithArchive(channelId, postId, limit, offset); err != nil {
+				return nil, model.NewAppError("SqlPostStore.GetPostContext", "store.sql_post.get_posts_around.get.app_error", nil, "channelId="+channelId+err.Error(), http.StatusInternalServerError)
+			}
+		}
+	}
+
 	if len(posts) > 0 {
 		rootIds := []string{}
 		for _, post := range posts {
@@ -633,17 +825,21 @@ func (s *SqlPostStore) getPostsAround(channelId string, postId string, limit int
 		params["Limit"] = limit
 		params["Offset"] = offset
 
-		_, err = s.GetReplica().Select(&parents,
-			`SELECT
+		query := `SELECT
 				*
 			FROM
-				Posts
+				POSTS_TABLE
 			WHERE
-				(Id IN `+keys+` OR RootId IN `+keys+`)
+				(Id IN ` + keys + ` OR RootId IN ` + keys + `)
 				AND ChannelId = :ChannelId
-				AND DeleteAt = 0
-			ORDER BY CreateAt DESC`,
-			params)
+				AND DeleteAt = 0`
+		if withArchive {
+			query = unionWithArchive(query)
+		} else {
+			query = strings.Replace(query, "POSTS_TABLE", "Posts", 1)
+		}
+
+		_, err = s.GetReplica().Select(&parents, query+" ORDER BY CreateAt DESC", params)
 
 		if err != nil {
 			return nil, model.NewAppError("SqlPostStore.GetPostContext", "store.sql_post.get_posts_around.get_parent.app_error", nil, "channelId="+channelId+err.Error(), http.StatusInternalServerError)
@@ -915,7 +1111,7 @@ func (s *SqlPostStore) buildSearchPostFilterClause(fromUsers []string, excludedU
 	return filterQuery, queryParams
 }
 
-func (s *SqlPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+func (s *SqlPostStore) Search(ctx context.Context, teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
 	queryParams := map[string]interface{}{
 		"TeamId": teamId,
 		"UserId": userId,
@@ -945,7 +1141,7 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 			SELECT
 				*
 			FROM
-				Posts
+				POSTS_TABLE
 			WHERE
 				DeleteAt = 0
 				AND Type NOT LIKE '` + model.POST_SYSTEM_MESSAGE_PREFIX + `%'
@@ -958,7 +1154,7 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 						ChannelMembers
 					WHERE
 						Id = ChannelId
-							AND (TeamId = :TeamId OR TeamId = '')
+							AND (TeamId = :TeamId OR TeamId = '' OR Id IN (SELECT ChannelId FROM ChannelTeamBindings WHERE TeamId = :TeamId))
 							` + userIdPart + `
 							` + deletedQueryPart + `
 							IN_CHANNEL_FILTER
@@ -966,7 +1162,7 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 				CREATEDATE_CLAUSE
 				SEARCH_CLAUSE
 				ORDER BY CreateAt DESC
-			LIMIT 100`
+			LIMIT ` + strconv.Itoa(POSTS_SEARCH_LIMIT)
 
 	inChannelClause, queryParams := s.buildSearchChannelFilterClause(params.InChannels, "InChannel", false, queryParams)
 	searchQuery = strings.Replace(searchQuery, "IN_CHANNEL_FILTER", inChannelClause, 1)
@@ -1041,7 +1237,18 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 		}
 	}
 
-	_, err := s.GetSearchReplica().Select(&posts, searchQuery, queryParams)
+	err := selectContext(ctx, s.GetSearchReplica(), &posts, strings.Replace(searchQuery, "POSTS_TABLE", "Posts", 1), queryParams)
+	if err == nil {
+		// Older posts may have been archived, in which case they're searched for in the archive as well.
+		var newestArchivedCreateAt int64
+		if newestArchivedCreateAt, err = s.getNewestArchivedCreateAt(); err == nil && needsArchivedPosts(posts, POSTS_SEARCH_LIMIT, newestArchivedCreateAt) {
+			var archived []*model.Post
+			if err = selectContext(ctx, s.GetSearchReplica(), &archived, strings.Replace(searchQuery, "POSTS_TABLE", "PostsArchive", 1), queryParams); err == nil {
+				posts = mergeArchivedPosts(posts, archived, POSTS_SEARCH_LIMIT)
+			}
+		}
+	}
+
 	if err != nil {
 		mlog.Warn("Query error searching posts.", mlog.Err(err))
 		// Don't return the error to the caller as it is of no use to the user. Instead return an empty set of search results.
@@ -1255,7 +1462,7 @@ func (s *SqlPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64,
 			ORDER BY
 				CreateAt ASC
 			LIMIT
-				1000
+				:NumPosts
 			)
 		AS
 			PostsQuery
@@ -1275,24 +1482,34 @@ func (s *SqlPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64,
 	return posts, nil
 }
 
+// PermanentDeleteBatch deletes at most limit posts created before the given time, the archived ones once there are
+// none left in the Posts table.
 func (s *SqlPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
-	var query string
-	if s.DriverName() == "postgres" {
-		query = "DELETE from Posts WHERE Id = any (array (SELECT Id FROM Posts WHERE CreateAt < :EndTime LIMIT :Limit))"
-	} else {
-		query = "DELETE from Posts WHERE CreateAt < :EndTime LIMIT :Limit"
-	}
+	var deleted int64
+	for _, table := range []string{"Posts", "PostsArchive"} {
+		var query string
+		if s.DriverName() == "postgres" {
+			query = "DELETE from " + table + " WHERE Id = any (array (SELECT Id FROM " + table + " WHERE CreateAt < :EndTime LIMIT :Limit))"
+		} else {
+			query = "DELETE from " + table + " WHERE CreateAt < :EndTime LIMIT :Limit"
+		}
 
-	sqlResult, err := s.GetMaster().Exec(query, map[string]interface{}{"EndTime": endTime, "Limit": limit})
-	if err != nil {
-		return 0, model.NewAppError("SqlPostStore.PermanentDeleteBatch", "store.sql_post.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
-	}
+		sqlResult, err := s.GetMaster().Exec(query, map[string]interface{}{"EndTime": endTime, "Limit": limit - deleted})
+		if err != nil {
+			return 0, model.NewAppError("SqlPostStore.PermanentDeleteBatch", "store.sql_post.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
+		}
 
-	rowsAffected, err := sqlResult.RowsAffected()
-	if err != nil {
-		return 0, model.NewAppError("SqlPostStore.PermanentDeleteBatch", "store.sql_post.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
+		rowsAffected, err := sqlResult.RowsAffected()
+		if err != nil {
+			return 0, model.NewAppError("SqlPostStore.PermanentDeleteBatch", "store.sql_post.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
+		}
+
+		deleted += rowsAffected
+		if deleted >= limit {
+			break
+		}
 	}
-	return rowsAffected, nil
+	return deleted, nil
 }
 
 func (s *SqlPostStore) GetOldest() (*model.Post, *model.AppError) {
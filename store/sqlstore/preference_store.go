@@ -4,7 +4,10 @@
 package sqlstore
 
 import (
+	"database/sql"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/mattermost/gorp"
 
@@ -26,6 +29,10 @@ func NewSqlPreferenceStore(sqlStore SqlStore) store.PreferenceStore {
 		table.ColMap("Category").SetMaxSize(32)
 		table.ColMap("Name").SetMaxSize(32)
 		table.ColMap("Value").SetMaxSize(2000)
+
+		tableVersions := db.AddTableWithName(model.PreferenceVersion{}, "PreferenceVersions").SetKeys(false, "UserId", "Category")
+		tableVersions.ColMap("UserId").SetMaxSize(26)
+		tableVersions.ColMap("Category").SetMaxSize(32)
 	}
 
 	return s
@@ -62,10 +69,18 @@ func (s SqlPreferenceStore) Save(preferences *model.Preferences) *model.AppError
 	}
 
 	defer finalizeTransaction(transaction)
+	namespaces := map[model.PreferenceVersion]bool{}
 	for _, preference := range *preferences {
 		if upsertErr := s.save(transaction, &preference); upsertErr != nil {
 			return upsertErr
 		}
+		namespaces[model.PreferenceVersion{UserId: preference.UserId, Category: preference.Category}] = true
+	}
+
+	for namespace := range namespaces {
+		if _, err := s.bumpVersion(transaction, namespace.UserId, namespace.Category); err != nil {
+			return err
+		}
 	}
 
 	if err := transaction.Commit(); err != nil {
@@ -181,6 +196,23 @@ func (s SqlPreferenceStore) GetCategory(userId string, category string) (model.P
 
 }
 
+func (s SqlPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, *model.AppError) {
+	var preferences model.Preferences
+
+	if _, err := s.GetReplica().Select(&preferences,
+		`SELECT
+				*
+			FROM
+				Preferences
+			WHERE
+				Category = :Category
+				AND Name = :Name`, map[string]interface{}{"Category": category, "Name": name}); err != nil {
+		return nil, model.NewAppError("SqlPreferenceStore.GetCategoryAndName", "store.sql_preference.get_category.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return preferences, nil
+}
+
 func (s SqlPreferenceStore) GetAll(userId string) (model.Preferences, *model.AppError) {
 	var preferences model.Preferences
 
@@ -207,6 +239,10 @@ func (s SqlPreferenceStore) PermanentDeleteByUser(userId string) *model.AppError
 		return model.NewAppError("SqlPreferenceStore.Delete", "store.sql_preference.permanent_delete_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	if _, err := s.GetMaster().Exec("DELETE FROM PreferenceVersions WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlPreferenceStore.Delete", "store.sql_preference.permanent_delete_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
 	return nil
 }
 
@@ -218,17 +254,17 @@ func (s SqlPreferenceStore) Delete(userId, category, name string) *model.AppErro
 			AND Category = :Category
 			AND Name = :Name`
 
-	_, err := s.GetMaster().Exec(query, map[string]interface{}{"UserId": userId, "Category": category, "Name": name})
+	result, err := s.GetMaster().Exec(query, map[string]interface{}{"UserId": userId, "Category": category, "Name": name})
 
 	if err != nil {
 		return model.NewAppError("SqlPreferenceStore.Delete", "store.sql_preference.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	return nil
+	return s.bumpVersionIfDeleted(result, userId, category)
 }
 
 func (s SqlPreferenceStore) DeleteCategory(userId string, category string) *model.AppError {
-	_, err := s.GetMaster().Exec(
+	result, err := s.GetMaster().Exec(
 		`DELETE FROM
 			Preferences
 		WHERE
@@ -239,10 +275,22 @@ func (s SqlPreferenceStore) DeleteCategory(userId string, category string) *mode
 		return model.NewAppError("SqlPreferenceStore.DeleteCategory", "store.sql_preference.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	return nil
+	return s.bumpVersionIfDeleted(result, userId, category)
 }
 
 func (s SqlPreferenceStore) DeleteCategoryAndName(category string, name string) *model.AppError {
+	var userIds []string
+	if _, err := s.GetMaster().Select(&userIds,
+		`SELECT
+			UserId
+		FROM
+			Preferences
+		WHERE
+			Name = :Name
+			AND Category = :Category`, map[string]interface{}{"Name": name, "Category": category}); err != nil {
+		return model.NewAppError("SqlPreferenceStore.DeleteCategoryAndName", "store.sql_preference.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
 	_, err := s.GetMaster().Exec(
 		`DELETE FROM
 			Preferences
@@ -254,6 +302,12 @@ func (s SqlPreferenceStore) DeleteCategoryAndName(category string, name string)
 		return model.NewAppError("SqlPreferenceStore.DeleteCategoryAndName", "store.sql_preference.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	for _, userId := range userIds {
+		if _, err := s.bumpVersion(s.GetMaster(), userId, category); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -296,3 +350,209 @@ func (s SqlPreferenceStore) CleanupFlagsBatch(limit int64) (int64, *model.AppErr
 
 	return rowsAffected, nil
 }
+
+func (s SqlPreferenceStore) bumpVersionIfDeleted(result sql.Result, userId, category string) *model.AppError {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return model.NewAppError("SqlPreferenceStore.bumpVersionIfDeleted", "store.sql_preference.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if rowsAffected > 0 {
+		if _, err := s.bumpVersion(s.GetMaster(), userId, category); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bumpVersion increments the version of a namespace of the preferences of a user, starting it at 1, and returns it.
+func (s SqlPreferenceStore) bumpVersion(executor gorp.SqlExecutor, userId, category string) (int64, *model.AppError) {
+	params := map[string]interface{}{"UserId": userId, "Category": category}
+
+	if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		if _, err := executor.Exec(
+			`INSERT INTO
+				PreferenceVersions
+				(UserId, Category, Version)
+			VALUES
+				(:UserId, :Category, 1)
+			ON DUPLICATE KEY UPDATE
+				Version = Version + 1`, params); err != nil {
+			return 0, model.NewAppError("SqlPreferenceStore.bumpVersion", "store.sql_preference.bump_version.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	} else {
+		// as in save, update first and only insert when the namespace has no version yet
+		result, err := executor.Exec("UPDATE PreferenceVersions SET Version = Version + 1 WHERE UserId = :UserId AND Category = :Category", params)
+		if err != nil {
+			return 0, model.NewAppError("SqlPreferenceStore.bumpVersion", "store.sql_preference.bump_version.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+			if err := executor.Insert(&model.PreferenceVersion{UserId: userId, Category: category, Version: 1}); err != nil {
+				return 0, model.NewAppError("SqlPreferenceStore.bumpVersion", "store.sql_preference.bump_version.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+		}
+	}
+
+	version, err := executor.SelectInt("SELECT Version FROM PreferenceVersions WHERE UserId = :UserId AND Category = :Category", params)
+	if err != nil {
+		return 0, model.NewAppError("SqlPreferenceStore.bumpVersion", "store.sql_preference.bump_version.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return version, nil
+}
+
+func (s SqlPreferenceStore) GetVersions(userId string) (map[string]int64, *model.AppError) {
+	var versions []*model.PreferenceVersion
+
+	if _, err := s.GetReplica().Select(&versions, "SELECT * FROM PreferenceVersions WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return nil, model.NewAppError("SqlPreferenceStore.GetVersions", "store.sql_preference.get_versions.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	result := make(map[string]int64, len(versions))
+	for _, version := range versions {
+		result[version.Category] = version.Version
+	}
+
+	return result, nil
+}
+
+// GetNamespaces returns the given namespaces of the preferences of a user, including the ones without any preferences,
+// or all of their namespaces when none are given.
+func (s SqlPreferenceStore) GetNamespaces(userId string, categories []string) (map[string]*model.PreferenceNamespace, *model.AppError) {
+	var preferences model.Preferences
+	var versions []*model.PreferenceVersion
+
+	params := map[string]interface{}{"UserId": userId}
+	filter := ""
+	if len(categories) > 0 {
+		keys := []string{}
+		for i, category := range categories {
+			key := fmt.Sprintf("Category%d", i)
+			params[key] = category
+			keys = append(keys, ":"+key)
+		}
+		filter = " AND Category IN (" + strings.Join(keys, ", ") + ")"
+	}
+
+	if _, err := s.GetReplica().Select(&preferences, "SELECT * FROM Preferences WHERE UserId = :UserId"+filter, params); err != nil {
+		return nil, model.NewAppError("SqlPreferenceStore.GetNamespaces", "store.sql_preference.get_namespaces.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, err := s.GetReplica().Select(&versions, "SELECT * FROM PreferenceVersions WHERE UserId = :UserId"+filter, params); err != nil {
+		return nil, model.NewAppError("SqlPreferenceStore.GetNamespaces", "store.sql_preference.get_namespaces.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	namespaces := map[string]*model.PreferenceNamespace{}
+	namespace := func(category string) *model.PreferenceNamespace {
+		if _, ok := namespaces[category]; !ok {
+			namespaces[category] = &model.PreferenceNamespace{Preferences: map[string]string{}}
+		}
+		return namespaces[category]
+	}
+
+	for _, category := range categories {
+		namespace(category)
+	}
+
+	for _, version := range versions {
+		namespace(version.Category).Version = version.Version
+	}
+
+	for _, preference := range preferences {
+		namespace(preference.Category).Preferences[preference.Name] = preference.Value
+	}
+
+	return namespaces, nil
+}
+
+// Patch applies the patch to the namespaces of the preferences of a user in a single transaction, and returns what it
+// changed in each of them. The version of a namespace is only incremented when it changed.
+func (s SqlPreferenceStore) Patch(userId string, patch model.PreferencesPatch) ([]*model.PreferenceNamespaceDiff, *model.AppError) {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, model.NewAppError("SqlPreferenceStore.Patch", "store.sql_preference.save.open_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	diffs := []*model.PreferenceNamespaceDiff{}
+	for _, category := range patch.Namespaces() {
+		diff, appErr := s.patchNamespace(transaction, userId, category, patch[category])
+		if appErr != nil {
+			return nil, appErr
+		}
+		diffs = append(diffs, diff)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, model.NewAppError("SqlPreferenceStore.Patch", "store.sql_preference.save.commit_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return diffs, nil
+}
+
+func (s SqlPreferenceStore) patchNamespace(transaction *gorp.Transaction, userId, category string, patch *model.PreferenceNamespacePatch) (*model.PreferenceNamespaceDiff, *model.AppError) {
+	params := map[string]interface{}{"UserId": userId, "Category": category}
+
+	version, err := transaction.SelectInt("SELECT COALESCE(MAX(Version), 0) FROM PreferenceVersions WHERE UserId = :UserId AND Category = :Category", params)
+	if err != nil {
+		return nil, model.NewAppError("SqlPreferenceStore.Patch", "store.sql_preference.patch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if patch.BaseVersion != nil && *patch.BaseVersion != version {
+		return nil, model.NewAppError("SqlPreferenceStore.Patch", "store.sql_preference.patch.conflict.app_error", map[string]interface{}{"Namespace": category}, fmt.Sprintf("base_version=%d, version=%d", *patch.BaseVersion, version), http.StatusConflict)
+	}
+
+	var preferences model.Preferences
+	if _, err := transaction.Select(&preferences, "SELECT * FROM Preferences WHERE UserId = :UserId AND Category = :Category", params); err != nil {
+		return nil, model.NewAppError("SqlPreferenceStore.Patch", "store.sql_preference.patch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	current := make(map[string]string, len(preferences))
+	for _, preference := range preferences {
+		current[preference.Name] = preference.Value
+	}
+
+	diff := &model.PreferenceNamespaceDiff{Namespace: category, Version: version, Set: map[string]string{}, Deleted: []string{}}
+
+	for name, value := range patch.Set {
+		if currentValue, ok := current[name]; ok && currentValue == value {
+			continue
+		}
+
+		preference := &model.Preference{UserId: userId, Category: category, Name: name, Value: value}
+		if appErr := s.save(transaction, preference); appErr != nil {
+			return nil, appErr
+		}
+		diff.Set[name] = preference.Value
+	}
+
+	for _, name := range patch.Delete {
+		if _, ok := current[name]; !ok {
+			continue
+		}
+
+		if _, err := transaction.Exec("DELETE FROM Preferences WHERE UserId = :UserId AND Category = :Category AND Name = :Name", map[string]interface{}{"UserId": userId, "Category": category, "Name": name}); err != nil {
+			return nil, model.NewAppError("SqlPreferenceStore.Patch", "store.sql_preference.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		diff.Deleted = append(diff.Deleted, name)
+	}
+
+	if diff.IsEmpty() {
+		return diff, nil
+	}
+
+	newVersion, appErr := s.bumpVersion(transaction, userId, category)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if newVersion != version+1 {
+		// another patch changed the namespace since it was read
+		return nil, model.NewAppError("SqlPreferenceStore.Patch", "store.sql_preference.patch.conflict.app_error", map[string]interface{}{"Namespace": category}, fmt.Sprintf("version=%d", version), http.StatusConflict)
+	}
+	diff.Version = newVersion
+
+	return diff, nil
+}
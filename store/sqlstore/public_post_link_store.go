@@ -0,0 +1,84 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPublicPostLinkStore struct {
+	SqlStore
+}
+
+func NewSqlPublicPostLinkStore(sqlStore SqlStore) store.PublicPostLinkStore {
+	s := &SqlPublicPostLinkStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PublicPostLink{}, "PublicPostLinks").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlPublicPostLinkStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_publicpostlinks_post_id", "PublicPostLinks", "PostId")
+}
+
+func (s SqlPublicPostLinkStore) Save(link *model.PublicPostLink) (*model.PublicPostLink, *model.AppError) {
+	if len(link.Id) > 0 {
+		return nil, model.NewAppError("SqlPublicPostLinkStore.Save", "store.sql_public_post_link.save.existing.app_error", nil, "id="+link.Id, http.StatusBadRequest)
+	}
+
+	link.PreSave()
+	if err := link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(link); err != nil {
+		return nil, model.NewAppError("SqlPublicPostLinkStore.Save", "store.sql_public_post_link.save.app_error", nil, "id="+link.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return link, nil
+}
+
+// Get returns a link, whether it has been revoked or not.
+func (s SqlPublicPostLinkStore) Get(id string) (*model.PublicPostLink, *model.AppError) {
+	var link model.PublicPostLink
+
+	if err := s.GetReplica().SelectOne(&link, "SELECT * FROM PublicPostLinks WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlPublicPostLinkStore.Get", "store.sql_public_post_link.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlPublicPostLinkStore.Get", "store.sql_public_post_link.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &link, nil
+}
+
+// GetForPost returns the links of a post that haven't been revoked, starting with the oldest.
+func (s SqlPublicPostLinkStore) GetForPost(postId string) ([]*model.PublicPostLink, *model.AppError) {
+	links := []*model.PublicPostLink{}
+
+	if _, err := s.GetReplica().Select(&links, "SELECT * FROM PublicPostLinks WHERE PostId = :PostId AND DeleteAt = 0 ORDER BY CreateAt, Id", map[string]interface{}{"PostId": postId}); err != nil {
+		return nil, model.NewAppError("SqlPublicPostLinkStore.GetForPost", "store.sql_public_post_link.get_for_post.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return links, nil
+}
+
+func (s SqlPublicPostLinkStore) Revoke(id string, time int64) *model.AppError {
+	if _, err := s.GetMaster().Exec("UPDATE PublicPostLinks SET DeleteAt = :DeleteAt WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"DeleteAt": time, "Id": id}); err != nil {
+		return model.NewAppError("SqlPublicPostLinkStore.Revoke", "store.sql_public_post_link.revoke.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
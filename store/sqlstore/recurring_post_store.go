@@ -0,0 +1,133 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlRecurringPostStore struct {
+	SqlStore
+}
+
+func NewSqlRecurringPostStore(sqlStore SqlStore) store.RecurringPostStore {
+	s := &SqlRecurringPostStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.RecurringPost{}, "RecurringPosts").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("BotUserId").SetMaxSize(26)
+		table.ColMap("Message").SetMaxSize(model.POST_MESSAGE_MAX_BYTES_V2)
+		table.ColMap("Schedule").SetMaxSize(128)
+		table.ColMap("Timezone").SetMaxSize(model.RECURRING_POST_TIMEZONE_MAX)
+	}
+
+	return s
+}
+
+func (s SqlRecurringPostStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_recurringposts_channel_id", "RecurringPosts", "ChannelId")
+	s.CreateIndexIfNotExists("idx_recurringposts_next_run_at", "RecurringPosts", "NextRunAt")
+}
+
+func (s SqlRecurringPostStore) Save(recurringPost *model.RecurringPost) (*model.RecurringPost, *model.AppError) {
+	if len(recurringPost.Id) > 0 {
+		return nil, model.NewAppError("SqlRecurringPostStore.Save", "store.sql_recurring_post.save.existing.app_error", nil, "id="+recurringPost.Id, http.StatusBadRequest)
+	}
+
+	recurringPost.PreSave()
+	if err := recurringPost.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(recurringPost); err != nil {
+		return nil, model.NewAppError("SqlRecurringPostStore.Save", "store.sql_recurring_post.save.app_error", nil, "id="+recurringPost.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return recurringPost, nil
+}
+
+func (s SqlRecurringPostStore) Update(recurringPost *model.RecurringPost) (*model.RecurringPost, *model.AppError) {
+	recurringPost.PreUpdate()
+	if err := recurringPost.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(recurringPost)
+	if err != nil {
+		return nil, model.NewAppError("SqlRecurringPostStore.Update", "store.sql_recurring_post.update.app_error", nil, "id="+recurringPost.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		return nil, model.NewAppError("SqlRecurringPostStore.Update", "store.sql_recurring_post.get.app_error", nil, "id="+recurringPost.Id, http.StatusNotFound)
+	}
+
+	return recurringPost, nil
+}
+
+func (s SqlRecurringPostStore) Get(id string) (*model.RecurringPost, *model.AppError) {
+	var recurringPost model.RecurringPost
+
+	if err := s.GetReplica().SelectOne(&recurringPost, "SELECT * FROM RecurringPosts WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlRecurringPostStore.Get", "store.sql_recurring_post.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlRecurringPostStore.Get", "store.sql_recurring_post.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &recurringPost, nil
+}
+
+func (s SqlRecurringPostStore) GetForChannel(channelId string) ([]*model.RecurringPost, *model.AppError) {
+	recurringPosts := []*model.RecurringPost{}
+
+	if _, err := s.GetReplica().Select(&recurringPosts, "SELECT * FROM RecurringPosts WHERE ChannelId = :ChannelId ORDER BY CreateAt, Id", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, model.NewAppError("SqlRecurringPostStore.GetForChannel", "store.sql_recurring_post.get_for_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return recurringPosts, nil
+}
+
+// GetDue returns the unpaused posts that are due by the given time, starting with the earliest.
+func (s SqlRecurringPostStore) GetDue(now int64, limit int) ([]*model.RecurringPost, *model.AppError) {
+	recurringPosts := []*model.RecurringPost{}
+
+	if _, err := s.GetMaster().Select(&recurringPosts, `SELECT
+			*
+		FROM
+			RecurringPosts
+		WHERE
+			Paused = :Paused
+			AND NextRunAt > 0
+			AND NextRunAt <= :Now
+		ORDER BY
+			NextRunAt, Id
+		LIMIT :Limit`, map[string]interface{}{"Paused": false, "Now": now, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlRecurringPostStore.GetDue", "store.sql_recurring_post.get_due.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return recurringPosts, nil
+}
+
+func (s SqlRecurringPostStore) Delete(id string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM RecurringPosts WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		return model.NewAppError("SqlRecurringPostStore.Delete", "store.sql_recurring_post.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlRecurringPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM RecurringPosts WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlRecurringPostStore.PermanentDeleteByChannel", "store.sql_recurring_post.delete.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlReminderStore struct {
+	SqlStore
+}
+
+func NewSqlReminderStore(sqlStore SqlStore) store.ReminderStore {
+	s := &SqlReminderStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.Reminder{}, "Reminders").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("Message").SetMaxSize(model.REMINDER_MESSAGE_MAX_RUNES * 4)
+	}
+
+	return s
+}
+
+func (s SqlReminderStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_reminders_user_id", "Reminders", "UserId")
+	s.CreateIndexIfNotExists("idx_reminders_remind_at", "Reminders", "RemindAt")
+}
+
+func (s SqlReminderStore) Save(reminder *model.Reminder) (*model.Reminder, *model.AppError) {
+	if len(reminder.Id) > 0 {
+		return nil, model.NewAppError("SqlReminderStore.Save", "store.sql_reminder.save.existing.app_error", nil, "id="+reminder.Id, http.StatusBadRequest)
+	}
+
+	reminder.PreSave()
+	if err := reminder.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(reminder); err != nil {
+		return nil, model.NewAppError("SqlReminderStore.Save", "store.sql_reminder.save.app_error", nil, "id="+reminder.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return reminder, nil
+}
+
+func (s SqlReminderStore) Get(id string) (*model.Reminder, *model.AppError) {
+	var reminder model.Reminder
+
+	if err := s.GetReplica().SelectOne(&reminder, "SELECT * FROM Reminders WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlReminderStore.Get", "store.sql_reminder.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlReminderStore.Get", "store.sql_reminder.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &reminder, nil
+}
+
+// GetPendingForUser returns the reminders of a user that weren't sent yet, the soonest first.
+func (s SqlReminderStore) GetPendingForUser(userId string) ([]*model.Reminder, *model.AppError) {
+	reminders := []*model.Reminder{}
+
+	if _, err := s.GetReplica().Select(&reminders, "SELECT * FROM Reminders WHERE UserId = :UserId AND SentAt = 0 ORDER BY RemindAt, Id", map[string]interface{}{"UserId": userId}); err != nil {
+		return nil, model.NewAppError("SqlReminderStore.GetPendingForUser", "store.sql_reminder.get_pending_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return reminders, nil
+}
+
+func (s SqlReminderStore) GetDue(now int64, limit int) ([]*model.Reminder, *model.AppError) {
+	reminders := []*model.Reminder{}
+
+	if _, err := s.GetMaster().Select(&reminders, "SELECT * FROM Reminders WHERE SentAt = 0 AND RemindAt <= :Now ORDER BY RemindAt, Id LIMIT :Limit", map[string]interface{}{"Now": now, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlReminderStore.GetDue", "store.sql_reminder.get_due.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return reminders, nil
+}
+
+// MarkSent marks a reminder as sent, returning false if it was already, such as when another server sent it first.
+func (s SqlReminderStore) MarkSent(id string, sentAt int64) (bool, *model.AppError) {
+	result, err := s.GetMaster().Exec("UPDATE Reminders SET SentAt = :SentAt WHERE Id = :Id AND SentAt = 0", map[string]interface{}{"SentAt": sentAt, "Id": id})
+	if err != nil {
+		return false, model.NewAppError("SqlReminderStore.MarkSent", "store.sql_reminder.mark_sent.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, model.NewAppError("SqlReminderStore.MarkSent", "store.sql_reminder.mark_sent.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return count == 1, nil
+}
+
+func (s SqlReminderStore) Delete(id string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM Reminders WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		return model.NewAppError("SqlReminderStore.Delete", "store.sql_reminder.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlReminderStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM Reminders WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlReminderStore.PermanentDeleteByUser", "store.sql_reminder.delete.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
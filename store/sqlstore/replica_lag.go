@@ -0,0 +1,147 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/gorp"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// REPLICA_LAG_CHECK_INTERVAL is how often the heartbeat is written to the master and read back from the replicas,
+// which is also the precision of the measured lag.
+const REPLICA_LAG_CHECK_INTERVAL = time.Second
+
+// startReplicaLagChecks periodically measures how far behind the master each replica is, so that the replicas lagging
+// more than allowed by the settings aren't read from until they've caught up.
+func (ss *SqlSupplier) startReplicaLagChecks() {
+	if len(ss.replicas) == 0 && len(ss.searchReplicas) == 0 {
+		return
+	}
+
+	if ss.settings.ReplicaMaxLagSeconds == nil || *ss.settings.ReplicaMaxLagSeconds == 0 {
+		return
+	}
+
+	ss.replicaLagStop = make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(REPLICA_LAG_CHECK_INTERVAL)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ss.checkReplicaLag()
+			case <-ss.replicaLagStop:
+				return
+			}
+		}
+	}()
+}
+
+func (ss *SqlSupplier) stopReplicaLagChecks() {
+	if ss.replicaLagStop != nil {
+		close(ss.replicaLagStop)
+		ss.replicaLagStop = nil
+	}
+}
+
+// checkReplicaLag writes the current time to the master and compares it to the one the replicas have replicated.
+func (ss *SqlSupplier) checkReplicaLag() {
+	heartbeat := model.GetMillis()
+	if err := ss.saveReplicaHeartbeat(heartbeat); err != nil {
+		mlog.Warn("Failed to save the replica heartbeat.", mlog.Err(err))
+		return
+	}
+
+	maxLag := time.Duration(*ss.settings.ReplicaMaxLagSeconds) * time.Second
+
+	for i, replica := range ss.replicas {
+		ss.updateReplicaLag(fmt.Sprintf("replica-%v", i), replica, &ss.replicasLagging[i], heartbeat, maxLag)
+	}
+
+	for i, replica := range ss.searchReplicas {
+		ss.updateReplicaLag(fmt.Sprintf("search-replica-%v", i), replica, &ss.searchReplicasLagging[i], heartbeat, maxLag)
+	}
+}
+
+func (ss *SqlSupplier) saveReplicaHeartbeat(heartbeat int64) error {
+	system := &model.System{Name: model.SYSTEM_REPLICA_HEARTBEAT, Value: strconv.FormatInt(heartbeat, 10)}
+
+	count, err := ss.GetMaster().Update(system)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ss.GetMaster().Insert(system)
+	}
+
+	return nil
+}
+
+// updateReplicaLag flags the replica as lagging when the heartbeat it has replicated is older than allowed, or when
+// it can't be read at all.
+func (ss *SqlSupplier) updateReplicaLag(name string, replica *gorp.DbMap, lagging *int32, heartbeat int64, maxLag time.Duration) {
+	lag, err := measureReplicaLag(replica, heartbeat)
+	if err != nil {
+		mlog.Warn("Failed to measure the replica lag, reading from the master instead.", mlog.String("replica", name), mlog.Err(err))
+		ss.setReplicaLagging(name, lagging, true)
+		return
+	}
+
+	if ss.metrics != nil {
+		ss.metrics.SetReplicaLagTime(name, lag.Seconds())
+	}
+
+	ss.setReplicaLagging(name, lagging, lag > maxLag)
+}
+
+func (ss *SqlSupplier) setReplicaLagging(name string, lagging *int32, isLagging bool) {
+	value := int32(0)
+	if isLagging {
+		value = 1
+	}
+
+	if previous := atomic.SwapInt32(lagging, value); previous != value {
+		if isLagging {
+			mlog.Warn("Replica is lagging behind the master, reading from the master instead.", mlog.String("replica", name))
+		} else {
+			mlog.Info("Replica has caught up with the master, reading from it again.", mlog.String("replica", name))
+		}
+	}
+}
+
+// measureReplicaLag returns how long ago the heartbeat the replica has replicated was written to the master, given
+// the last one written.
+func measureReplicaLag(replica *gorp.DbMap, heartbeat int64) (time.Duration, error) {
+	value, err := replica.SelectStr("SELECT Value FROM Systems WHERE Name = :Name", map[string]interface{}{"Name": model.SYSTEM_REPLICA_HEARTBEAT})
+	if err != nil {
+		return 0, err
+	}
+
+	// The replica hasn't received any heartbeat yet.
+	if value == "" {
+		return 0, fmt.Errorf("no heartbeat replicated yet")
+	}
+
+	replicated, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	lag := time.Duration(heartbeat-replicated) * time.Millisecond
+	if lag < 0 {
+		// Another server wrote a later heartbeat in the meantime.
+		lag = 0
+	}
+
+	return lag, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlSchemaMigrationStore struct {
+	SqlStore
+
+	migrations []schemaMigration
+}
+
+func NewSqlSchemaMigrationStore(sqlStore SqlStore) store.SchemaMigrationStore {
+	s := &SqlSchemaMigrationStore{
+		SqlStore:   sqlStore,
+		migrations: schemaMigrations,
+	}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.SchemaMigration{}, "SchemaMigrations").SetKeys(false, "Version")
+		table.ColMap("Name").SetMaxSize(128)
+		table.ColMap("BackfillLastDone").SetMaxSize(1024)
+	}
+
+	return s
+}
+
+// GetAll returns every known schema migration, applied or not, in the order they're applied in.
+func (s SqlSchemaMigrationStore) GetAll() ([]*model.SchemaMigration, *model.AppError) {
+	var applied []*model.SchemaMigration
+	if _, err := s.GetMaster().Select(&applied, "SELECT * FROM SchemaMigrations"); err != nil {
+		return nil, model.NewAppError("SqlSchemaMigrationStore.GetAll", "store.sql_schema_migration.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	appliedByVersion := make(map[int]*model.SchemaMigration, len(applied))
+	for _, migration := range applied {
+		appliedByVersion[migration.Version] = migration
+	}
+
+	migrations := make([]*model.SchemaMigration, 0, len(s.migrations))
+	for _, known := range s.migrations {
+		migration, ok := appliedByVersion[known.Version]
+		if !ok {
+			migration = &model.SchemaMigration{Version: known.Version, Name: known.Name, Status: model.SCHEMA_MIGRATION_STATUS_PENDING}
+		} else if migration.BackfillCompletedAt == 0 {
+			migration.Status = model.SCHEMA_MIGRATION_STATUS_BACKFILLING
+		} else {
+			migration.Status = model.SCHEMA_MIGRATION_STATUS_COMPLETED
+		}
+
+		migrations = append(migrations, migration)
+	}
+
+	return migrations, nil
+}
+
+// RunBackfillBatch migrates the next batch of rows of an applied schema migration, returning true once there are none
+// left to migrate.
+func (s SqlSchemaMigrationStore) RunBackfillBatch(version int, batchSize int) (bool, *model.AppError) {
+	var known *schemaMigration
+	for i := range s.migrations {
+		if s.migrations[i].Version == version {
+			known = &s.migrations[i]
+			break
+		}
+	}
+
+	if known == nil {
+		return false, model.NewAppError("SqlSchemaMigrationStore.RunBackfillBatch", "store.sql_schema_migration.run_backfill_batch.unknown.app_error", nil, "version="+strconv.Itoa(version), http.StatusBadRequest)
+	}
+
+	migration, err := getSchemaMigration(s, version)
+	if err != nil {
+		return false, model.NewAppError("SqlSchemaMigrationStore.RunBackfillBatch", "store.sql_schema_migration.get.app_error", nil, "version="+strconv.Itoa(version)+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if migration == nil {
+		return false, model.NewAppError("SqlSchemaMigrationStore.RunBackfillBatch", "store.sql_schema_migration.run_backfill_batch.not_applied.app_error", nil, "version="+strconv.Itoa(version), http.StatusBadRequest)
+	}
+
+	if migration.BackfillCompletedAt != 0 {
+		return true, nil
+	}
+
+	lastDone, err := known.Backfill(s, migration.BackfillLastDone, batchSize)
+	if err != nil {
+		return false, model.NewAppError("SqlSchemaMigrationStore.RunBackfillBatch", "store.sql_schema_migration.run_backfill_batch.app_error", nil, "version="+strconv.Itoa(version)+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	migration.BackfillLastDone = lastDone
+	if lastDone == "" {
+		migration.BackfillCompletedAt = model.GetMillis()
+	}
+
+	if _, err := s.GetMaster().Update(migration); err != nil {
+		return false, model.NewAppError("SqlSchemaMigrationStore.RunBackfillBatch", "store.sql_schema_migration.update.app_error", nil, "version="+strconv.Itoa(version)+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return migration.BackfillCompletedAt != 0, nil
+}
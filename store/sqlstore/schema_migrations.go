@@ -0,0 +1,163 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// schemaMigration is a change of the schema applied without blocking the tables it alters, unlike the changes made by
+// UpgradeDatabase, which the upgrades wait for. Its version must be greater than the one of every migration before it.
+type schemaMigration struct {
+	Version int
+	Name    string
+
+	// Apply makes the change, issuing its statements through the migrator so that they're only logged in dry-run
+	// mode. It must be safe to apply again after having been interrupted.
+	Apply func(m *schemaMigrator) error
+
+	// Backfill, when set, migrates a batch of at most batchSize existing rows once the change has been applied,
+	// continuing after lastDone, which is empty for the first batch. It returns the lastDone of the next batch, or an
+	// empty string once there are no rows left to migrate.
+	Backfill func(ss SqlStore, lastDone string, batchSize int) (string, error)
+}
+
+// schemaMigrations lists the migrations in the order they're applied in. New changes of the schema that would lock a
+// large table for a long time belong here rather than in UpgradeDatabase.
+var schemaMigrations = []schemaMigration{
+	{
+		Version: 1,
+		Name:    "add_posts_channel_id_delete_at_create_at_index",
+		Apply: func(m *schemaMigrator) error {
+			return m.CreateIndexOnline("idx_posts_channel_id_delete_at_create_at", "Posts", []string{"ChannelId", "DeleteAt", "CreateAt"})
+		},
+	},
+	{
+		Version: 2,
+		Name:    "add_posts_root_id_create_at_index",
+		Apply: func(m *schemaMigrator) error {
+			return m.CreateIndexOnline("idx_posts_root_id_create_at", "Posts", []string{"RootId", "CreateAt"})
+		},
+	},
+}
+
+// schemaMigrator runs the statements of a schema migration, or only logs them in dry-run mode.
+type schemaMigrator struct {
+	sqlStore SqlStore
+	dryRun   bool
+}
+
+func (m *schemaMigrator) Exec(query string) error {
+	if m.dryRun {
+		mlog.Info("Dry run of schema migration statement.", mlog.String("statement", query))
+		return nil
+	}
+
+	_, err := m.sqlStore.GetMaster().ExecNoTimeout(query)
+	return err
+}
+
+// CreateIndexOnline creates the index if it doesn't exist yet while still allowing writes to the table. An index left
+// invalid by an interrupted concurrent build on PostgreSQL is dropped and built again.
+func (m *schemaMigrator) CreateIndexOnline(indexName string, tableName string, columnNames []string) error {
+	columns := strings.Join(columnNames, ", ")
+
+	switch m.sqlStore.DriverName() {
+	case model.DATABASE_DRIVER_POSTGRES:
+		valid, err := m.sqlStore.GetMaster().SelectNullStr("SELECT CASE WHEN i.indisvalid THEN 'true' ELSE 'false' END FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid WHERE c.relname = $1", strings.ToLower(indexName))
+		if err != nil {
+			return errors.Wrapf(err, "failed to check index %s", indexName)
+		}
+
+		if valid.Valid && valid.String == "true" {
+			return nil
+		}
+
+		if valid.Valid {
+			if err := m.Exec("DROP INDEX CONCURRENTLY " + indexName); err != nil {
+				return errors.Wrapf(err, "failed to drop invalid index %s", indexName)
+			}
+		}
+
+		return m.Exec("CREATE INDEX CONCURRENTLY " + indexName + " ON " + tableName + " (" + columns + ")")
+	case model.DATABASE_DRIVER_MYSQL:
+		count, err := m.sqlStore.GetMaster().SelectInt("SELECT COUNT(0) FROM information_schema.statistics WHERE TABLE_SCHEMA = DATABASE() AND table_name = ? AND index_name = ?", tableName, indexName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check index %s", indexName)
+		}
+
+		if count > 0 {
+			return nil
+		}
+
+		return m.Exec("ALTER TABLE " + tableName + " ADD INDEX " + indexName + " (" + columns + "), ALGORITHM=INPLACE, LOCK=NONE")
+	case model.DATABASE_DRIVER_SQLITE:
+		return m.Exec("CREATE INDEX IF NOT EXISTS " + indexName + " ON " + tableName + " (" + columns + ")")
+	}
+
+	return errors.Errorf("unsupported driver %s", m.sqlStore.DriverName())
+}
+
+// RunSchemaMigrations applies the schema migrations that haven't been yet. In dry-run mode, their statements are only
+// logged and they're left pending.
+func RunSchemaMigrations(sqlStore SqlStore, dryRun bool) error {
+	return runSchemaMigrations(sqlStore, schemaMigrations, dryRun)
+}
+
+func runSchemaMigrations(sqlStore SqlStore, migrations []schemaMigration, dryRun bool) error {
+	for _, migration := range migrations {
+		count, err := sqlStore.GetMaster().SelectInt("SELECT COUNT(0) FROM SchemaMigrations WHERE Version = :Version", map[string]interface{}{"Version": migration.Version})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get schema migration %d", migration.Version)
+		}
+
+		if count > 0 {
+			continue
+		}
+
+		mlog.Info("Applying schema migration.", mlog.Int("version", migration.Version), mlog.String("name", migration.Name), mlog.Bool("dry_run", dryRun))
+
+		if err := migration.Apply(&schemaMigrator{sqlStore: sqlStore, dryRun: dryRun}); err != nil {
+			return errors.Wrapf(err, "failed to apply schema migration %d %s", migration.Version, migration.Name)
+		}
+
+		if dryRun {
+			continue
+		}
+
+		applied := &model.SchemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: model.GetMillis(),
+		}
+		if migration.Backfill == nil {
+			applied.BackfillCompletedAt = applied.AppliedAt
+		}
+
+		if err := sqlStore.GetMaster().Insert(applied); err != nil {
+			return errors.Wrapf(err, "failed to save schema migration %d %s", migration.Version, migration.Name)
+		}
+	}
+
+	return nil
+}
+
+// getSchemaMigration returns the applied migration with the given version, or nil if it hasn't been applied.
+func getSchemaMigration(sqlStore SqlStore, version int) (*model.SchemaMigration, error) {
+	var migration model.SchemaMigration
+	if err := sqlStore.GetMaster().SelectOne(&migration, "SELECT * FROM SchemaMigrations WHERE Version = :Version", map[string]interface{}{"Version": version}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &migration, nil
+}
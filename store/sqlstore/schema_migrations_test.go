@@ -0,0 +1,95 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaMigrations(t *testing.T) {
+	StoreTest(t, func(t *testing.T, ss store.Store) {
+		sqlStore := ss.(*store.LayeredStore).DatabaseLayer.(SqlStore)
+
+		var applied []bool
+		var batches []string
+		migrations := []schemaMigration{
+			{
+				Version: 1000001,
+				Name:    "test_apply",
+				Apply: func(m *schemaMigrator) error {
+					applied = append(applied, m.dryRun)
+					return nil
+				},
+			},
+			{
+				Version: 1000002,
+				Name:    "test_backfill",
+				Apply:   func(m *schemaMigrator) error { return nil },
+				Backfill: func(ss SqlStore, lastDone string, batchSize int) (string, error) {
+					batches = append(batches, lastDone)
+
+					done, _ := strconv.Atoi(lastDone)
+					if done += batchSize; done >= 5 {
+						return "", nil
+					}
+					return strconv.Itoa(done), nil
+				},
+			},
+		}
+		defer sqlStore.GetMaster().Exec("DELETE FROM SchemaMigrations WHERE Version IN (1000001, 1000002)")
+
+		migrationStore := &SqlSchemaMigrationStore{SqlStore: sqlStore, migrations: migrations}
+		getStatuses := func() []string {
+			all, err := migrationStore.GetAll()
+			require.Nil(t, err)
+			require.Len(t, all, 2)
+			return []string{all[0].Status, all[1].Status}
+		}
+
+		t.Run("dry run", func(t *testing.T) {
+			require.NoError(t, runSchemaMigrations(sqlStore, migrations, true))
+			assert.Equal(t, []bool{true}, applied)
+			assert.Equal(t, []string{model.SCHEMA_MIGRATION_STATUS_PENDING, model.SCHEMA_MIGRATION_STATUS_PENDING}, getStatuses())
+
+			_, err := migrationStore.RunBackfillBatch(1000002, 2)
+			require.NotNil(t, err)
+			assert.Equal(t, "store.sql_schema_migration.run_backfill_batch.not_applied.app_error", err.Id)
+		})
+
+		t.Run("apply", func(t *testing.T) {
+			require.NoError(t, runSchemaMigrations(sqlStore, migrations, false))
+			assert.Equal(t, []bool{true, false}, applied)
+			assert.Equal(t, []string{model.SCHEMA_MIGRATION_STATUS_COMPLETED, model.SCHEMA_MIGRATION_STATUS_BACKFILLING}, getStatuses())
+
+			require.NoError(t, runSchemaMigrations(sqlStore, migrations, false))
+			assert.Equal(t, []bool{true, false}, applied, "applied migrations shouldn't be applied again")
+		})
+
+		t.Run("backfill", func(t *testing.T) {
+			for _, expectedDone := range []bool{false, false, true, true} {
+				done, err := migrationStore.RunBackfillBatch(1000002, 2)
+				require.Nil(t, err)
+				assert.Equal(t, expectedDone, done)
+			}
+
+			assert.Equal(t, []string{"", "2", "4"}, batches)
+			assert.Equal(t, []string{model.SCHEMA_MIGRATION_STATUS_COMPLETED, model.SCHEMA_MIGRATION_STATUS_COMPLETED}, getStatuses())
+		})
+
+		t.Run("create index online", func(t *testing.T) {
+			m := &schemaMigrator{sqlStore: sqlStore}
+			defer sqlStore.RemoveIndexIfExists("idx_test_schema_migrations", "Posts")
+
+			require.NoError(t, m.CreateIndexOnline("idx_test_schema_migrations", "Posts", []string{"ChannelId", "UpdateAt"}))
+			require.NoError(t, m.CreateIndexOnline("idx_test_schema_migrations", "Posts", []string{"ChannelId", "UpdateAt"}))
+			assert.True(t, sqlStore.RemoveIndexIfExists("idx_test_schema_migrations", "Posts"))
+		})
+	})
+}
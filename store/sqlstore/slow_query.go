@@ -0,0 +1,421 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	dbsql "database/sql"
+	"database/sql/driver"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	// SLOW_QUERY_LOG_SIZE is how many of the most recent slow queries are kept for the admins to look at.
+	SLOW_QUERY_LOG_SIZE = 100
+
+	SLOW_QUERY_EXPLAIN_TIMEOUT = 10 * time.Second
+)
+
+type skipSlowQueryLogKey struct{}
+
+// slowQueryLog keeps the most recent queries which took longer than a threshold, and logs them. On PostgreSQL, the
+// execution plan of a slow query is fetched with EXPLAIN, one query at a time so that a burst of slow queries
+// doesn't load the database further.
+type slowQueryLog struct {
+	threshold time.Duration
+	explain   bool
+
+	mutex   sync.Mutex
+	queries []*model.SlowQuery
+	next    int
+
+	explaining chan struct{}
+}
+
+func newSlowQueryLog(settings *model.SqlSettings) *slowQueryLog {
+	return &slowQueryLog{
+		threshold:  time.Duration(*settings.SlowQueryThresholdMilliseconds) * time.Millisecond,
+		explain:    *settings.ExplainSlowQueries && *settings.DriverName == model.DATABASE_DRIVER_POSTGRES,
+		queries:    make([]*model.SlowQuery, 0, SLOW_QUERY_LOG_SIZE),
+		explaining: make(chan struct{}, 1),
+	}
+}
+
+// Recent returns copies of the slow queries kept, starting with the most recent.
+func (l *slowQueryLog) Recent() []*model.SlowQuery {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	queries := make([]*model.SlowQuery, 0, len(l.queries))
+	for i := 0; i < len(l.queries); i++ {
+		index := (l.next - 1 - i + len(l.queries)) % len(l.queries)
+		query := *l.queries[index]
+		queries = append(queries, &query)
+	}
+	return queries
+}
+
+func (l *slowQueryLog) add(query *model.SlowQuery) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if len(l.queries) < SLOW_QUERY_LOG_SIZE {
+		l.queries = append(l.queries, query)
+	} else {
+		l.queries[l.next] = query
+	}
+	l.next = (l.next + 1) % SLOW_QUERY_LOG_SIZE
+}
+
+func (l *slowQueryLog) setPlan(query *model.SlowQuery, plan string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	query.Plan = plan
+}
+
+// record is called after every query run on the given database, with its arguments as passed to the driver.
+func (l *slowQueryLog) record(db *dbsql.DB, database, query string, args []driver.NamedValue, duration time.Duration) {
+	if duration < l.threshold {
+		return
+	}
+
+	slowQuery := &model.SlowQuery{
+		CreateAt:   model.GetMillis(),
+		Database:   database,
+		Query:      query,
+		Parameters: sanitizeQueryArgs(args),
+		Caller:     queryCaller(),
+		Duration:   int64(duration / time.Millisecond),
+	}
+	l.add(slowQuery)
+
+	if l.explain && isExplainable(query) {
+		select {
+		case l.explaining <- struct{}{}:
+			go func() {
+				defer func() { <-l.explaining }()
+
+				plan, err := explainQuery(db, query, args)
+				if err != nil {
+					mlog.Warn("Unable to explain a slow query", mlog.String("query", query), mlog.Err(err))
+				} else {
+					l.setPlan(slowQuery, plan)
+				}
+				logSlowQuery(slowQuery, plan)
+			}()
+			return
+		default:
+		}
+	}
+
+	logSlowQuery(slowQuery, "")
+}
+
+func logSlowQuery(query *model.SlowQuery, plan string) {
+	fields := []mlog.Field{
+		mlog.String("database", query.Database),
+		mlog.String("query", query.Query),
+		mlog.Any("parameters", query.Parameters),
+		mlog.String("caller", query.Caller),
+		mlog.Int64("duration_ms", query.Duration),
+	}
+	if plan != "" {
+		fields = append(fields, mlog.String("plan", plan))
+	}
+
+	mlog.Warn("Slow query", fields...)
+}
+
+// isExplainable reports whether EXPLAIN, which plans without running, accepts the query.
+func isExplainable(query string) bool {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return false
+	}
+
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "WITH":
+		return true
+	}
+	return false
+}
+
+func explainQuery(db *dbsql.DB, query string, args []driver.NamedValue) (string, error) {
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), skipSlowQueryLogKey{}, true), SLOW_QUERY_EXPLAIN_TIMEOUT)
+	defer cancel()
+
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+
+	rows, err := db.QueryContext(ctx, "EXPLAIN "+query, values...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	lines := []string{}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), rows.Err()
+}
+
+// sanitizeQueryArgs describes the arguments of a query without revealing the strings and bytes they may hold.
+func sanitizeQueryArgs(args []driver.NamedValue) []string {
+	parameters := make([]string, len(args))
+	for i, arg := range args {
+		switch value := arg.Value.(type) {
+		case nil:
+			parameters[i] = "NULL"
+		case string:
+			parameters[i] = fmt.Sprintf("<string len=%d>", len(value))
+		case []byte:
+			parameters[i] = fmt.Sprintf("<bytes len=%d>", len(value))
+		case time.Time:
+			parameters[i] = value.UTC().Format(time.RFC3339Nano)
+		default:
+			parameters[i] = fmt.Sprint(value)
+		}
+	}
+	return parameters
+}
+
+// queryCaller finds the store method which ran a query, skipping the database packages.
+func queryCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if strings.Contains(frame.Function, "/store/sqlstore.") && !strings.HasSuffix(frame.File, "slow_query.go") {
+			function := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+			return fmt.Sprintf("%s (%s:%d)", function, filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// slowQueryConnector opens connections which time the queries run on them and report the slow ones to a
+// slowQueryLog. The connections otherwise behave like those of the underlying driver.
+type slowQueryConnector struct {
+	driver.Connector
+	log      *slowQueryLog
+	database string
+	db       *dbsql.DB
+}
+
+// openWithSlowQueryLog opens a database like sql.Open, recording its slow queries to the given log.
+func openWithSlowQueryLog(driverName, dataSource, database string, log *slowQueryLog) (*dbsql.DB, error) {
+	db, err := dbsql.Open(driverName, dataSource)
+	if err != nil {
+		return nil, err
+	}
+	underlying := db.Driver()
+	db.Close()
+
+	var connector driver.Connector
+	if driverContext, ok := underlying.(driver.DriverContext); ok {
+		if connector, err = driverContext.OpenConnector(dataSource); err != nil {
+			return nil, err
+		}
+	} else {
+		connector = &dsnConnector{driver: underlying, dataSource: dataSource}
+	}
+
+	slowConnector := &slowQueryConnector{Connector: connector, log: log, database: database}
+	slowConnector.db = dbsql.OpenDB(slowConnector)
+	return slowConnector.db, nil
+}
+
+func (c *slowQueryConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &slowQueryConn{Conn: conn, connector: c}, nil
+}
+
+func (c *slowQueryConnector) record(ctx context.Context, query string, args []driver.NamedValue, started time.Time) {
+	if ctx.Value(skipSlowQueryLogKey{}) != nil {
+		return
+	}
+	c.log.record(c.db, c.database, query, args, time.Since(started))
+}
+
+type dsnConnector struct {
+	driver     driver.Driver
+	dataSource string
+}
+
+func (c *dsnConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dataSource)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// slowQueryConn times the queries run directly on a connection, and those of the statements it prepares.
+type slowQueryConn struct {
+	driver.Conn
+	connector *slowQueryConnector
+}
+
+func (c *slowQueryConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *slowQueryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowQueryStmt{Stmt: stmt, query: query, conn: c}, nil
+}
+
+func (c *slowQueryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *slowQueryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	started := time.Now()
+	defer c.connector.record(ctx, query, args, started)
+
+	if execer, ok := c.Conn.(driver.ExecerContext); ok {
+		return execer.ExecContext(ctx, query, args)
+	}
+	if execer, ok := c.Conn.(driver.Execer); ok {
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+		return execer.Exec(query, values)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *slowQueryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	started := time.Now()
+	defer c.connector.record(ctx, query, args, started)
+
+	if queryer, ok := c.Conn.(driver.QueryerContext); ok {
+		return queryer.QueryContext(ctx, query, args)
+	}
+	if queryer, ok := c.Conn.(driver.Queryer); ok {
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+		return queryer.Query(query, values)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *slowQueryConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *slowQueryConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *slowQueryConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+type slowQueryStmt struct {
+	driver.Stmt
+	query string
+	conn  *slowQueryConn
+}
+
+func (s *slowQueryStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	started := time.Now()
+	defer s.conn.connector.record(ctx, s.query, args, started)
+
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Exec(values)
+}
+
+func (s *slowQueryStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	started := time.Now()
+	defer s.conn.connector.record(ctx, s.query, args, started)
+
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Query(values)
+}
+
+func (s *slowQueryStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return s.conn.CheckNamedValue(value)
+}
+
+func (s *slowQueryStmt) ColumnConverter(index int) driver.ValueConverter {
+	if converter, ok := s.Stmt.(driver.ColumnConverter); ok {
+		return converter.ColumnConverter(index)
+	}
+	return driver.DefaultParameterConverter
+}
+
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, fmt.Errorf("the driver doesn't support the named parameter %v", arg.Name)
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestSanitizeQueryArgs(t *testing.T) {
+	createAt := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	parameters := sanitizeQueryArgs([]driver.NamedValue{
+		{Ordinal: 1, Value: "hunter2"},
+		{Ordinal: 2, Value: []byte("abc")},
+		{Ordinal: 3, Value: int64(42)},
+		{Ordinal: 4, Value: true},
+		{Ordinal: 5, Value: nil},
+		{Ordinal: 6, Value: createAt},
+	})
+
+	assert.Equal(t, []string{
+		"<string len=7>",
+		"<bytes len=3>",
+		"42",
+		"true",
+		"NULL",
+		"2019-10-01T12:00:00Z",
+	}, parameters)
+}
+
+func TestIsExplainable(t *testing.T) {
+	assert.True(t, isExplainable("SELECT * FROM Users"))
+	assert.True(t, isExplainable("  select 1"))
+	assert.True(t, isExplainable("WITH t AS (SELECT 1) SELECT * FROM t"))
+	assert.True(t, isExplainable("UPDATE Users SET Nickname = ''"))
+	assert.False(t, isExplainable("CREATE INDEX idx ON Users (Email)"))
+	assert.False(t, isExplainable(""))
+}
+
+func TestSlowQueryLogRecent(t *testing.T) {
+	log := &slowQueryLog{explaining: make(chan struct{}, 1)}
+
+	for i := 0; i < SLOW_QUERY_LOG_SIZE+10; i++ {
+		log.add(&model.SlowQuery{Duration: int64(i)})
+	}
+
+	queries := log.Recent()
+	require.Len(t, queries, SLOW_QUERY_LOG_SIZE)
+	assert.Equal(t, int64(SLOW_QUERY_LOG_SIZE+9), queries[0].Duration)
+	assert.Equal(t, int64(10), queries[SLOW_QUERY_LOG_SIZE-1].Duration)
+
+	queries[0].Duration = -1
+	assert.Equal(t, int64(SLOW_QUERY_LOG_SIZE+9), log.Recent()[0].Duration, "should return copies")
+}
+
+func TestOpenWithSlowQueryLog(t *testing.T) {
+	t.Run("queries over the threshold are recorded", func(t *testing.T) {
+		log := &slowQueryLog{explaining: make(chan struct{}, 1)}
+
+		db, err := openWithSlowQueryLog("sqlite3", ":memory:", "master", log)
+		require.Nil(t, err)
+		defer db.Close()
+
+		var value string
+		require.Nil(t, db.QueryRow("SELECT ?", "secret").Scan(&value))
+		assert.Equal(t, "secret", value)
+
+		queries := log.Recent()
+		require.Len(t, queries, 1)
+		assert.Equal(t, "master", queries[0].Database)
+		assert.Equal(t, "SELECT ?", queries[0].Query)
+		assert.Equal(t, []string{"<string len=6>"}, queries[0].Parameters)
+		assert.Contains(t, queries[0].Caller, "slow_query_test.go")
+	})
+
+	t.Run("queries under the threshold are not recorded", func(t *testing.T) {
+		log := &slowQueryLog{threshold: time.Hour, explaining: make(chan struct{}, 1)}
+
+		db, err := openWithSlowQueryLog("sqlite3", ":memory:", "master", log)
+		require.Nil(t, err)
+		defer db.Close()
+
+		_, err = db.Exec("CREATE TABLE Test (Id TEXT)")
+		require.Nil(t, err)
+
+		assert.Empty(t, log.Recent())
+	})
+}
@@ -8,6 +8,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
 	"github.com/mattermost/gorp"
+	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
 )
 
@@ -48,6 +49,7 @@ type SqlStore interface {
 	TotalMasterDbConnections() int
 	TotalReadDbConnections() int
 	TotalSearchDbConnections() int
+	RecentSlowQueries() []*model.SlowQuery
 	MarkSystemRanUnitTests()
 	DoesTableExist(tablename string) bool
 	DoesColumnExist(tableName string, columName string) bool
@@ -98,5 +100,40 @@ type SqlStore interface {
 	TermsOfService() store.TermsOfServiceStore
 	UserTermsOfService() store.UserTermsOfServiceStore
 	LinkMetadata() store.LinkMetadataStore
+	Hashtag() store.HashtagStore
+	MentionAlias() store.MentionAliasStore
+	ChannelMemberExpiry() store.ChannelMemberExpiryStore
+	PostStar() store.PostStarStore
+	ChannelReadStat() store.ChannelReadStatStore
+	UserDeactivationSchedule() store.UserDeactivationScheduleStore
+	UserAttribute() store.UserAttributeStore
+	DailyStat() store.DailyStatStore
+	SchemaMigration() store.SchemaMigrationStore
+	ConfigAudit() store.ConfigAuditStore
+	LicenseUsage() store.LicenseUsageStore
+	PendingPost() store.PendingPostStore
+	ContentFilter() store.ContentFilterStore
+	KeywordRule() store.KeywordRuleStore
+	PostReport() store.PostReportStore
+	RecurringPost() store.RecurringPostStore
+	UserAvailability() store.UserAvailabilityStore
+	Call() store.CallStore
+	MessageExportConsumer() store.MessageExportConsumerStore
+	PostPurge() store.PostPurgeStore
+	PublicPostLink() store.PublicPostLinkStore
+	PendingEmoji() store.PendingEmojiStore
+	EmojiAlias() store.EmojiAliasStore
+	EphemeralPost() store.EphemeralPostStore
+	LoginAttempt() store.LoginAttemptStore
+	MfaRecoveryCode() store.MfaRecoveryCodeStore
+	IntegrationStat() store.IntegrationStatStore
+	Todo() store.TodoStore
+	Poll() store.PollStore
+	Reminder() store.ReminderStore
+	ChannelMembershipRule() store.ChannelMembershipRuleStore
+	ChannelTeamBinding() store.ChannelTeamBindingStore
+	Announcement() store.AnnouncementStore
+	Onboarding() store.OnboardingStore
+	PostPinEvent() store.PostPinEventStore
 	getQueryBuilder() sq.StatementBuilderType
 }
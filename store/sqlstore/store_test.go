@@ -4,6 +4,7 @@
 package sqlstore
 
 import (
+	"os"
 	"sync"
 	"testing"
 
@@ -56,6 +57,12 @@ func initStores() {
 		Name:        "PostgreSQL",
 		SqlSettings: storetest.MakeSqlSettings(model.DATABASE_DRIVER_POSTGRES),
 	})
+	if os.Getenv(storetest.CockroachDBDSNEnv) != "" {
+		storeTypes = append(storeTypes, &storeType{
+			Name:        "CockroachDB",
+			SqlSettings: storetest.MakeSqlSettings(model.DATABASE_DRIVER_COCKROACH),
+		})
+	}
 
 	defer func() {
 		if err := recover(); err != nil {
@@ -71,6 +78,9 @@ func initStores() {
 			defer wg.Done()
 			st.SqlSupplier = NewSqlSupplier(*st.SqlSettings, nil)
 			st.Store = store.NewLayeredStore(st.SqlSupplier, nil, nil)
+			if *st.SqlSettings.DriverName == model.DATABASE_DRIVER_COCKROACH {
+				st.Store = store.NewRetryLayer(st.Store)
+			}
 			st.Store.DropAllTables()
 			st.Store.MarkSystemRanUnitTests()
 		}()
@@ -68,37 +68,72 @@ const (
 )
 
 type SqlSupplierOldStores struct {
-	team                 store.TeamStore
-	channel              store.ChannelStore
-	post                 store.PostStore
-	user                 store.UserStore
-	bot                  store.BotStore
-	audit                store.AuditStore
-	cluster              store.ClusterDiscoveryStore
-	compliance           store.ComplianceStore
-	session              store.SessionStore
-	oauth                store.OAuthStore
-	system               store.SystemStore
-	webhook              store.WebhookStore
-	command              store.CommandStore
-	commandWebhook       store.CommandWebhookStore
-	preference           store.PreferenceStore
-	license              store.LicenseStore
-	token                store.TokenStore
-	emoji                store.EmojiStore
-	status               store.StatusStore
-	fileInfo             store.FileInfoStore
-	reaction             store.ReactionStore
-	job                  store.JobStore
-	userAccessToken      store.UserAccessTokenStore
-	plugin               store.PluginStore
-	channelMemberHistory store.ChannelMemberHistoryStore
-	role                 store.RoleStore
-	scheme               store.SchemeStore
-	TermsOfService       store.TermsOfServiceStore
-	group                store.GroupStore
-	UserTermsOfService   store.UserTermsOfServiceStore
-	linkMetadata         store.LinkMetadataStore
+	team                     store.TeamStore
+	channel                  store.ChannelStore
+	post                     store.PostStore
+	user                     store.UserStore
+	bot                      store.BotStore
+	audit                    store.AuditStore
+	cluster                  store.ClusterDiscoveryStore
+	compliance               store.ComplianceStore
+	session                  store.SessionStore
+	oauth                    store.OAuthStore
+	system                   store.SystemStore
+	webhook                  store.WebhookStore
+	command                  store.CommandStore
+	commandWebhook           store.CommandWebhookStore
+	preference               store.PreferenceStore
+	license                  store.LicenseStore
+	token                    store.TokenStore
+	emoji                    store.EmojiStore
+	status                   store.StatusStore
+	fileInfo                 store.FileInfoStore
+	reaction                 store.ReactionStore
+	job                      store.JobStore
+	userAccessToken          store.UserAccessTokenStore
+	plugin                   store.PluginStore
+	channelMemberHistory     store.ChannelMemberHistoryStore
+	role                     store.RoleStore
+	scheme                   store.SchemeStore
+	TermsOfService           store.TermsOfServiceStore
+	group                    store.GroupStore
+	UserTermsOfService       store.UserTermsOfServiceStore
+	linkMetadata             store.LinkMetadataStore
+	hashtag                  store.HashtagStore
+	mentionAlias             store.MentionAliasStore
+	channelMemberExpiry      store.ChannelMemberExpiryStore
+	postStar                 store.PostStarStore
+	channelReadStat          store.ChannelReadStatStore
+	userDeactivationSchedule store.UserDeactivationScheduleStore
+	userAttribute            store.UserAttributeStore
+	dailyStat                store.DailyStatStore
+	schemaMigration          store.SchemaMigrationStore
+	configAudit              store.ConfigAuditStore
+	licenseUsage             store.LicenseUsageStore
+	pendingPost              store.PendingPostStore
+	contentFilter            store.ContentFilterStore
+	keywordRule              store.KeywordRuleStore
+	postReport               store.PostReportStore
+	recurringPost            store.RecurringPostStore
+	userAvailability         store.UserAvailabilityStore
+	call                     store.CallStore
+	messageExportConsumer    store.MessageExportConsumerStore
+	postPurge                store.PostPurgeStore
+	publicPostLink           store.PublicPostLinkStore
+	pendingEmoji             store.PendingEmojiStore
+	emojiAlias               store.EmojiAliasStore
+	ephemeralPost            store.EphemeralPostStore
+	loginAttempt             store.LoginAttemptStore
+	mfaRecoveryCode          store.MfaRecoveryCodeStore
+	integrationStat          store.IntegrationStatStore
+	todo                     store.TodoStore
+	poll                     store.PollStore
+	reminder                 store.ReminderStore
+	channelMembershipRule    store.ChannelMembershipRuleStore
+	channelTeamBinding       store.ChannelTeamBindingStore
+	announcement             store.AnnouncementStore
+	onboarding               store.OnboardingStore
+	postPinEvent             store.PostPinEventStore
 }
 
 type SqlSupplier struct {
@@ -113,6 +148,16 @@ type SqlSupplier struct {
 	oldStores      SqlSupplierOldStores
 	settings       *model.SqlSettings
 	lockedToMaster bool
+	metrics        einterfaces.MetricsInterface
+
+	// replicasLagging and searchReplicasLagging flag, with 1, the replicas that are too far behind the master to be
+	// read from.
+	replicasLagging       []int32
+	searchReplicasLagging []int32
+	replicaLagStop        chan struct{}
+
+	// slowQueries records the slow queries run on any of the connections, if enabled.
+	slowQueries *slowQueryLog
 }
 
 func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInterface) *SqlSupplier {
@@ -120,6 +165,11 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 		rrCounter: 0,
 		srCounter: 0,
 		settings:  &settings,
+		metrics:   metrics,
+	}
+
+	if *settings.EnableSlowQueryLog {
+		supplier.slowQueries = newSlowQueryLog(&settings)
 	}
 
 	supplier.initConnection()
@@ -151,10 +201,45 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.oldStores.TermsOfService = NewSqlTermsOfServiceStore(supplier, metrics)
 	supplier.oldStores.UserTermsOfService = NewSqlUserTermsOfServiceStore(supplier)
 	supplier.oldStores.linkMetadata = NewSqlLinkMetadataStore(supplier)
+	supplier.oldStores.hashtag = NewSqlHashtagStore(supplier)
+	supplier.oldStores.mentionAlias = NewSqlMentionAliasStore(supplier)
+	supplier.oldStores.channelMemberExpiry = NewSqlChannelMemberExpiryStore(supplier)
+	supplier.oldStores.postStar = NewSqlPostStarStore(supplier)
+	supplier.oldStores.channelReadStat = NewSqlChannelReadStatStore(supplier)
+	supplier.oldStores.userDeactivationSchedule = NewSqlUserDeactivationScheduleStore(supplier)
+	supplier.oldStores.userAttribute = NewSqlUserAttributeStore(supplier)
+	supplier.oldStores.dailyStat = NewSqlDailyStatStore(supplier)
+	supplier.oldStores.schemaMigration = NewSqlSchemaMigrationStore(supplier)
+	supplier.oldStores.configAudit = NewSqlConfigAuditStore(supplier)
 	supplier.oldStores.reaction = NewSqlReactionStore(supplier)
 	supplier.oldStores.role = NewSqlRoleStore(supplier)
 	supplier.oldStores.scheme = NewSqlSchemeStore(supplier)
 	supplier.oldStores.group = NewSqlGroupStore(supplier)
+	supplier.oldStores.licenseUsage = NewSqlLicenseUsageStore(supplier)
+	supplier.oldStores.pendingPost = NewSqlPendingPostStore(supplier)
+	supplier.oldStores.contentFilter = NewSqlContentFilterStore(supplier)
+	supplier.oldStores.keywordRule = NewSqlKeywordRuleStore(supplier)
+	supplier.oldStores.postReport = NewSqlPostReportStore(supplier)
+	supplier.oldStores.recurringPost = NewSqlRecurringPostStore(supplier)
+	supplier.oldStores.userAvailability = NewSqlUserAvailabilityStore(supplier)
+	supplier.oldStores.call = NewSqlCallStore(supplier)
+	supplier.oldStores.messageExportConsumer = NewSqlMessageExportConsumerStore(supplier)
+	supplier.oldStores.postPurge = NewSqlPostPurgeStore(supplier)
+	supplier.oldStores.publicPostLink = NewSqlPublicPostLinkStore(supplier)
+	supplier.oldStores.pendingEmoji = NewSqlPendingEmojiStore(supplier)
+	supplier.oldStores.emojiAlias = NewSqlEmojiAliasStore(supplier)
+	supplier.oldStores.ephemeralPost = NewSqlEphemeralPostStore(supplier)
+	supplier.oldStores.loginAttempt = NewSqlLoginAttemptStore(supplier)
+	supplier.oldStores.mfaRecoveryCode = NewSqlMfaRecoveryCodeStore(supplier)
+	supplier.oldStores.integrationStat = NewSqlIntegrationStatStore(supplier)
+	supplier.oldStores.todo = NewSqlTodoStore(supplier)
+	supplier.oldStores.poll = NewSqlPollStore(supplier)
+	supplier.oldStores.reminder = NewSqlReminderStore(supplier)
+	supplier.oldStores.channelMembershipRule = NewSqlChannelMembershipRuleStore(supplier)
+	supplier.oldStores.channelTeamBinding = NewSqlChannelTeamBindingStore(supplier)
+	supplier.oldStores.announcement = NewSqlAnnouncementStore(supplier)
+	supplier.oldStores.onboarding = NewSqlOnboardingStore(supplier)
+	supplier.oldStores.postPinEvent = NewSqlPostPinEventStore(supplier)
 
 	err := supplier.GetMaster().CreateTablesIfNotExists()
 	if err != nil {
@@ -170,9 +255,17 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 		os.Exit(EXIT_GENERIC_FAILURE)
 	}
 
+	err = RunSchemaMigrations(supplier, settings.MigrationDryRun != nil && *settings.MigrationDryRun)
+	if err != nil {
+		mlog.Critical("Failed to run schema migrations.", mlog.Err(err))
+		time.Sleep(time.Second)
+		os.Exit(EXIT_GENERIC_FAILURE)
+	}
+
 	supplier.oldStores.team.(*SqlTeamStore).CreateIndexesIfNotExists()
 	supplier.oldStores.channel.(*SqlChannelStore).CreateIndexesIfNotExists()
 	supplier.oldStores.post.(*SqlPostStore).CreateIndexesIfNotExists()
+	supplier.oldStores.post.(*SqlPostStore).CreateArchiveTableIfNotExists(settings.PartitionPostsArchive != nil && *settings.PartitionPostsArchive)
 	supplier.oldStores.user.(*SqlUserStore).CreateIndexesIfNotExists()
 	supplier.oldStores.bot.(*SqlBotStore).CreateIndexesIfNotExists()
 	supplier.oldStores.audit.(*SqlAuditStore).CreateIndexesIfNotExists()
@@ -195,10 +288,46 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.oldStores.TermsOfService.(SqlTermsOfServiceStore).CreateIndexesIfNotExists()
 	supplier.oldStores.UserTermsOfService.(SqlUserTermsOfServiceStore).CreateIndexesIfNotExists()
 	supplier.oldStores.linkMetadata.(*SqlLinkMetadataStore).CreateIndexesIfNotExists()
+	supplier.oldStores.hashtag.(*SqlHashtagStore).CreateIndexesIfNotExists()
+	supplier.oldStores.mentionAlias.(*SqlMentionAliasStore).CreateIndexesIfNotExists()
+	supplier.oldStores.channelMemberExpiry.(*SqlChannelMemberExpiryStore).CreateIndexesIfNotExists()
+	supplier.oldStores.postStar.(*SqlPostStarStore).CreateIndexesIfNotExists()
+	supplier.oldStores.channelReadStat.(*SqlChannelReadStatStore).CreateIndexesIfNotExists()
+	supplier.oldStores.userDeactivationSchedule.(*SqlUserDeactivationScheduleStore).CreateIndexesIfNotExists()
+	supplier.oldStores.userAttribute.(*SqlUserAttributeStore).CreateIndexesIfNotExists()
+	supplier.oldStores.dailyStat.(*SqlDailyStatStore).CreateIndexesIfNotExists()
+	supplier.oldStores.configAudit.(*SqlConfigAuditStore).CreateIndexesIfNotExists()
 	supplier.oldStores.group.(*SqlGroupStore).CreateIndexesIfNotExists()
+	supplier.oldStores.licenseUsage.(*SqlLicenseUsageStore).CreateIndexesIfNotExists()
+	supplier.oldStores.pendingPost.(*SqlPendingPostStore).CreateIndexesIfNotExists()
+	supplier.oldStores.contentFilter.(*SqlContentFilterStore).CreateIndexesIfNotExists()
+	supplier.oldStores.keywordRule.(*SqlKeywordRuleStore).CreateIndexesIfNotExists()
+	supplier.oldStores.postReport.(*SqlPostReportStore).CreateIndexesIfNotExists()
+	supplier.oldStores.recurringPost.(*SqlRecurringPostStore).CreateIndexesIfNotExists()
+	supplier.oldStores.userAvailability.(*SqlUserAvailabilityStore).CreateIndexesIfNotExists()
+	supplier.oldStores.call.(*SqlCallStore).CreateIndexesIfNotExists()
+	supplier.oldStores.messageExportConsumer.(*SqlMessageExportConsumerStore).CreateIndexesIfNotExists()
+	supplier.oldStores.postPurge.(*SqlPostPurgeStore).CreateIndexesIfNotExists()
+	supplier.oldStores.publicPostLink.(*SqlPublicPostLinkStore).CreateIndexesIfNotExists()
+	supplier.oldStores.pendingEmoji.(*SqlPendingEmojiStore).CreateIndexesIfNotExists()
+	supplier.oldStores.emojiAlias.(*SqlEmojiAliasStore).CreateIndexesIfNotExists()
+	supplier.oldStores.ephemeralPost.(*SqlEphemeralPostStore).CreateIndexesIfNotExists()
+	supplier.oldStores.loginAttempt.(*SqlLoginAttemptStore).CreateIndexesIfNotExists()
+	supplier.oldStores.mfaRecoveryCode.(*SqlMfaRecoveryCodeStore).CreateIndexesIfNotExists()
+	supplier.oldStores.integrationStat.(*SqlIntegrationStatStore).CreateIndexesIfNotExists()
+	supplier.oldStores.todo.(*SqlTodoStore).CreateIndexesIfNotExists()
+	supplier.oldStores.poll.(*SqlPollStore).CreateIndexesIfNotExists()
+	supplier.oldStores.reminder.(*SqlReminderStore).CreateIndexesIfNotExists()
+	supplier.oldStores.channelMembershipRule.(*SqlChannelMembershipRuleStore).CreateIndexesIfNotExists()
+	supplier.oldStores.channelTeamBinding.(*SqlChannelTeamBindingStore).CreateIndexesIfNotExists()
+	supplier.oldStores.announcement.(*SqlAnnouncementStore).CreateIndexesIfNotExists()
+	supplier.oldStores.onboarding.(*SqlOnboardingStore).CreateIndexesIfNotExists()
+	supplier.oldStores.postPinEvent.(*SqlPostPinEventStore).CreateIndexesIfNotExists()
 
 	supplier.oldStores.preference.(*SqlPreferenceStore).DeleteUnusedFeatures()
 
+	supplier.startReplicaLagChecks()
+
 	return supplier
 }
 
@@ -210,8 +339,20 @@ func (s *SqlSupplier) Next() store.LayeredStoreSupplier {
 	return s.next
 }
 
-func setupConnection(con_type string, dataSource string, settings *model.SqlSettings) *gorp.DbMap {
-	db, err := dbsql.Open(*settings.DriverName, dataSource)
+func setupConnection(con_type string, dataSource string, settings *model.SqlSettings, slowQueries *slowQueryLog) *gorp.DbMap {
+	// CockroachDB speaks the PostgreSQL wire protocol, so it's connected to with the same driver.
+	driverName := *settings.DriverName
+	if driverName == model.DATABASE_DRIVER_COCKROACH {
+		driverName = model.DATABASE_DRIVER_POSTGRES
+	}
+
+	var db *dbsql.DB
+	var err error
+	if slowQueries != nil {
+		db, err = openWithSlowQueryLog(driverName, dataSource, con_type, slowQueries)
+	} else {
+		db, err = dbsql.Open(driverName, dataSource)
+	}
 	if err != nil {
 		mlog.Critical("Failed to open SQL connection to err.", mlog.Err(err))
 		time.Sleep(time.Second)
@@ -249,7 +390,7 @@ func setupConnection(con_type string, dataSource string, settings *model.SqlSett
 		dbmap = &gorp.DbMap{Db: db, TypeConverter: mattermConverter{}, Dialect: gorp.SqliteDialect{}, QueryTimeout: connectionTimeout}
 	} else if *settings.DriverName == model.DATABASE_DRIVER_MYSQL {
 		dbmap = &gorp.DbMap{Db: db, TypeConverter: mattermConverter{}, Dialect: gorp.MySQLDialect{Engine: "InnoDB", Encoding: "UTF8MB4"}, QueryTimeout: connectionTimeout}
-	} else if *settings.DriverName == model.DATABASE_DRIVER_POSTGRES {
+	} else if driverName == model.DATABASE_DRIVER_POSTGRES {
 		dbmap = &gorp.DbMap{Db: db, TypeConverter: mattermConverter{}, Dialect: gorp.PostgresDialect{}, QueryTimeout: connectionTimeout}
 	} else {
 		mlog.Critical("Failed to create dialect specific driver")
@@ -265,27 +406,47 @@ func setupConnection(con_type string, dataSource string, settings *model.SqlSett
 }
 
 func (s *SqlSupplier) initConnection() {
-	s.master = setupConnection("master", *s.settings.DataSource, s.settings)
+	s.master = setupConnection("master", *s.settings.DataSource, s.settings, s.slowQueries)
 
 	if len(s.settings.DataSourceReplicas) > 0 {
 		s.replicas = make([]*gorp.DbMap, len(s.settings.DataSourceReplicas))
+		s.replicasLagging = make([]int32, len(s.settings.DataSourceReplicas))
 		for i, replica := range s.settings.DataSourceReplicas {
-			s.replicas[i] = setupConnection(fmt.Sprintf("replica-%v", i), replica, s.settings)
+			s.replicas[i] = setupConnection(fmt.Sprintf("replica-%v", i), replica, s.settings, s.slowQueries)
 		}
 	}
 
 	if len(s.settings.DataSourceSearchReplicas) > 0 {
 		s.searchReplicas = make([]*gorp.DbMap, len(s.settings.DataSourceSearchReplicas))
+		s.searchReplicasLagging = make([]int32, len(s.settings.DataSourceSearchReplicas))
 		for i, replica := range s.settings.DataSourceSearchReplicas {
-			s.searchReplicas[i] = setupConnection(fmt.Sprintf("search-replica-%v", i), replica, s.settings)
+			s.searchReplicas[i] = setupConnection(fmt.Sprintf("search-replica-%v", i), replica, s.settings, s.slowQueries)
 		}
 	}
 }
 
+// DriverName returns the SQL dialect the queries must be written in, which for CockroachDB is the PostgreSQL one.
 func (ss *SqlSupplier) DriverName() string {
+	if ss.isCockroachDB() {
+		return model.DATABASE_DRIVER_POSTGRES
+	}
+
 	return *ss.settings.DriverName
 }
 
+func (ss *SqlSupplier) isCockroachDB() bool {
+	return *ss.settings.DriverName == model.DATABASE_DRIVER_COCKROACH
+}
+
+// RecentSlowQueries returns the most recent slow queries, starting with the most recent, or nothing if the slow query
+// log isn't enabled.
+func (ss *SqlSupplier) RecentSlowQueries() []*model.SlowQuery {
+	if ss.slowQueries == nil {
+		return []*model.SlowQuery{}
+	}
+	return ss.slowQueries.Recent()
+}
+
 func (ss *SqlSupplier) GetCurrentSchemaVersion() string {
 	version, _ := ss.GetMaster().SelectStr("SELECT Value FROM Systems WHERE Name='Version'")
 	return version
@@ -300,8 +461,12 @@ func (ss *SqlSupplier) GetSearchReplica() *gorp.DbMap {
 		return ss.GetReplica()
 	}
 
-	rrNum := atomic.AddInt64(&ss.srCounter, 1) % int64(len(ss.searchReplicas))
-	return ss.searchReplicas[rrNum]
+	rrNum := atomic.AddInt64(&ss.srCounter, 1)
+	if replica := pickReplica(ss.searchReplicas, ss.searchReplicasLagging, rrNum); replica != nil {
+		return replica
+	}
+
+	return ss.GetReplica()
 }
 
 func (ss *SqlSupplier) GetReplica() *gorp.DbMap {
@@ -309,8 +474,25 @@ func (ss *SqlSupplier) GetReplica() *gorp.DbMap {
 		return ss.GetMaster()
 	}
 
-	rrNum := atomic.AddInt64(&ss.rrCounter, 1) % int64(len(ss.replicas))
-	return ss.replicas[rrNum]
+	rrNum := atomic.AddInt64(&ss.rrCounter, 1)
+	if replica := pickReplica(ss.replicas, ss.replicasLagging, rrNum); replica != nil {
+		return replica
+	}
+
+	return ss.GetMaster()
+}
+
+// pickReplica returns the replica at the given round robin position, or the next one that isn't lagging behind the
+// master, or nil if they all are.
+func pickReplica(replicas []*gorp.DbMap, lagging []int32, rrNum int64) *gorp.DbMap {
+	for i := int64(0); i < int64(len(replicas)); i++ {
+		num := (rrNum + i) % int64(len(replicas))
+		if atomic.LoadInt32(&lagging[num]) == 0 {
+			return replicas[num]
+		}
+	}
+
+	return nil
 }
 
 func (ss *SqlSupplier) TotalMasterDbConnections() int {
@@ -768,9 +950,7 @@ func (ss *SqlSupplier) createIndexIfNotExists(indexName string, tableName string
 	}
 
 	if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
-		_, errExists := ss.GetMaster().SelectStr("SELECT $1::regclass", indexName)
-		// It should fail if the index does not exist
-		if errExists == nil {
+		if ss.doesPostgresIndexExist(indexName, tableName) {
 			return false
 		}
 
@@ -789,7 +969,7 @@ func (ss *SqlSupplier) createIndexIfNotExists(indexName string, tableName string
 
 		_, err := ss.GetMaster().ExecNoTimeout(query)
 		if err != nil {
-			mlog.Critical("Failed to create index", mlog.Err(errExists), mlog.Err(err))
+			mlog.Critical("Failed to create index", mlog.Err(err))
 			time.Sleep(time.Second)
 			os.Exit(EXIT_CREATE_INDEX_POSTGRES)
 		}
@@ -836,13 +1016,17 @@ func (ss *SqlSupplier) createIndexIfNotExists(indexName string, tableName string
 func (ss *SqlSupplier) RemoveIndexIfExists(indexName string, tableName string) bool {
 
 	if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
-		_, err := ss.GetMaster().SelectStr("SELECT $1::regclass", indexName)
-		// It should fail if the index does not exist
-		if err != nil {
+		if !ss.doesPostgresIndexExist(indexName, tableName) {
 			return false
 		}
 
-		_, err = ss.GetMaster().ExecNoTimeout("DROP INDEX " + indexName)
+		// CockroachDB names the indexes by table.
+		query := "DROP INDEX " + indexName
+		if ss.isCockroachDB() {
+			query = "DROP INDEX " + tableName + "@" + indexName
+		}
+
+		_, err := ss.GetMaster().ExecNoTimeout(query)
 		if err != nil {
 			mlog.Critical("Failed to remove index", mlog.Err(err))
 			time.Sleep(time.Second)
@@ -906,6 +1090,25 @@ func IsUniqueConstraintError(err error, indexName []string) bool {
 	return unique && field
 }
 
+// doesPostgresIndexExist returns true if the index exists. Unlike PostgreSQL, CockroachDB doesn't resolve the names of
+// the indexes as relations, so its catalog is looked up instead.
+func (ss *SqlSupplier) doesPostgresIndexExist(indexName string, tableName string) bool {
+	if ss.isCockroachDB() {
+		count, err := ss.GetMaster().SelectInt("SELECT COUNT(0) FROM pg_indexes WHERE tablename = $1 AND indexname = $2", strings.ToLower(tableName), strings.ToLower(indexName))
+		if err != nil {
+			mlog.Critical("Failed to check index", mlog.Err(err))
+			time.Sleep(time.Second)
+			os.Exit(EXIT_GENERIC_FAILURE)
+		}
+
+		return count > 0
+	}
+
+	// It should fail if the index does not exist
+	_, err := ss.GetMaster().SelectStr("SELECT $1::regclass", indexName)
+	return err == nil
+}
+
 func (ss *SqlSupplier) GetAllConns() []*gorp.DbMap {
 	all := make([]*gorp.DbMap, len(ss.replicas)+1)
 	copy(all, ss.replicas)
@@ -915,6 +1118,7 @@ func (ss *SqlSupplier) GetAllConns() []*gorp.DbMap {
 
 func (ss *SqlSupplier) Close() {
 	mlog.Info("Closing SqlStore")
+	ss.stopReplicaLagChecks()
 	ss.master.Db.Close()
 	for _, replica := range ss.replicas {
 		replica.Db.Close()
@@ -1053,6 +1257,146 @@ func (ss *SqlSupplier) LinkMetadata() store.LinkMetadataStore {
 	return ss.oldStores.linkMetadata
 }
 
+func (ss *SqlSupplier) Hashtag() store.HashtagStore {
+	return ss.oldStores.hashtag
+}
+
+func (ss *SqlSupplier) MentionAlias() store.MentionAliasStore {
+	return ss.oldStores.mentionAlias
+}
+
+func (ss *SqlSupplier) ChannelMemberExpiry() store.ChannelMemberExpiryStore {
+	return ss.oldStores.channelMemberExpiry
+}
+
+func (ss *SqlSupplier) PostStar() store.PostStarStore {
+	return ss.oldStores.postStar
+}
+
+func (ss *SqlSupplier) ChannelReadStat() store.ChannelReadStatStore {
+	return ss.oldStores.channelReadStat
+}
+
+func (ss *SqlSupplier) UserDeactivationSchedule() store.UserDeactivationScheduleStore {
+	return ss.oldStores.userDeactivationSchedule
+}
+
+func (ss *SqlSupplier) UserAttribute() store.UserAttributeStore {
+	return ss.oldStores.userAttribute
+}
+
+func (ss *SqlSupplier) DailyStat() store.DailyStatStore {
+	return ss.oldStores.dailyStat
+}
+
+func (ss *SqlSupplier) SchemaMigration() store.SchemaMigrationStore {
+	return ss.oldStores.schemaMigration
+}
+
+func (ss *SqlSupplier) ConfigAudit() store.ConfigAuditStore {
+	return ss.oldStores.configAudit
+}
+
+func (ss *SqlSupplier) LicenseUsage() store.LicenseUsageStore {
+	return ss.oldStores.licenseUsage
+}
+
+func (ss *SqlSupplier) PendingPost() store.PendingPostStore {
+	return ss.oldStores.pendingPost
+}
+
+func (ss *SqlSupplier) ContentFilter() store.ContentFilterStore {
+	return ss.oldStores.contentFilter
+}
+
+func (ss *SqlSupplier) KeywordRule() store.KeywordRuleStore {
+	return ss.oldStores.keywordRule
+}
+
+func (ss *SqlSupplier) PostReport() store.PostReportStore {
+	return ss.oldStores.postReport
+}
+
+func (ss *SqlSupplier) RecurringPost() store.RecurringPostStore {
+	return ss.oldStores.recurringPost
+}
+
+func (ss *SqlSupplier) UserAvailability() store.UserAvailabilityStore {
+	return ss.oldStores.userAvailability
+}
+
+func (ss *SqlSupplier) Call() store.CallStore {
+	return ss.oldStores.call
+}
+
+func (ss *SqlSupplier) MessageExportConsumer() store.MessageExportConsumerStore {
+	return ss.oldStores.messageExportConsumer
+}
+
+func (ss *SqlSupplier) PostPurge() store.PostPurgeStore {
+	return ss.oldStores.postPurge
+}
+
+func (ss *SqlSupplier) PublicPostLink() store.PublicPostLinkStore {
+	return ss.oldStores.publicPostLink
+}
+
+func (ss *SqlSupplier) PendingEmoji() store.PendingEmojiStore {
+	return ss.oldStores.pendingEmoji
+}
+
+func (ss *SqlSupplier) EmojiAlias() store.EmojiAliasStore {
+	return ss.oldStores.emojiAlias
+}
+
+func (ss *SqlSupplier) EphemeralPost() store.EphemeralPostStore {
+	return ss.oldStores.ephemeralPost
+}
+
+func (ss *SqlSupplier) LoginAttempt() store.LoginAttemptStore {
+	return ss.oldStores.loginAttempt
+}
+
+func (ss *SqlSupplier) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return ss.oldStores.mfaRecoveryCode
+}
+
+func (ss *SqlSupplier) IntegrationStat() store.IntegrationStatStore {
+	return ss.oldStores.integrationStat
+}
+
+func (ss *SqlSupplier) Todo() store.TodoStore {
+	return ss.oldStores.todo
+}
+
+func (ss *SqlSupplier) Poll() store.PollStore {
+	return ss.oldStores.poll
+}
+
+func (ss *SqlSupplier) Reminder() store.ReminderStore {
+	return ss.oldStores.reminder
+}
+
+func (ss *SqlSupplier) ChannelMembershipRule() store.ChannelMembershipRuleStore {
+	return ss.oldStores.channelMembershipRule
+}
+
+func (ss *SqlSupplier) ChannelTeamBinding() store.ChannelTeamBindingStore {
+	return ss.oldStores.channelTeamBinding
+}
+
+func (ss *SqlSupplier) Announcement() store.AnnouncementStore {
+	return ss.oldStores.announcement
+}
+
+func (ss *SqlSupplier) Onboarding() store.OnboardingStore {
+	return ss.oldStores.onboarding
+}
+
+func (ss *SqlSupplier) PostPinEvent() store.PostPinEventStore {
+	return ss.oldStores.postPinEvent
+}
+
 func (ss *SqlSupplier) DropAllTables() {
 	ss.master.TruncateTables()
 }
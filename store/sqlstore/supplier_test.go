@@ -2,6 +2,7 @@ package sqlstore_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mattermost/gorp"
 	_ "github.com/mattn/go-sqlite3"
@@ -231,3 +232,42 @@ func TestGetAllConns(t *testing.T) {
 		})
 	}
 }
+
+func TestGetReplicaLagging(t *testing.T) {
+	t.Parallel()
+
+	driverName := model.DATABASE_DRIVER_SQLITE
+	dataSource := ":memory:"
+	maxIdleConns := 1
+	connMaxLifetimeMilliseconds := 3600000
+	maxOpenConns := 1
+	queryTimeout := 5
+	replicaMaxLagSeconds := 1
+
+	settings := model.SqlSettings{
+		DriverName:                  &driverName,
+		DataSource:                  &dataSource,
+		MaxIdleConns:                &maxIdleConns,
+		ConnMaxLifetimeMilliseconds: &connMaxLifetimeMilliseconds,
+		MaxOpenConns:                &maxOpenConns,
+		QueryTimeout:                &queryTimeout,
+		DataSourceReplicas:          []string{":memory:", ":memory:"},
+		DataSourceSearchReplicas:    []string{":memory:"},
+		ReplicaMaxLagSeconds:        &replicaMaxLagSeconds,
+	}
+	supplier := sqlstore.NewSqlSupplier(settings, nil)
+	defer supplier.Close()
+
+	assert.NotEqual(t, supplier.GetMaster(), supplier.GetReplica())
+
+	// The replicas never receive the heartbeat written to the master, so reads fall back to it.
+	deadline := time.Now().Add(5 * time.Second)
+	for supplier.GetReplica() != supplier.GetMaster() && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, supplier.GetMaster(), supplier.GetReplica())
+		assert.Equal(t, supplier.GetMaster(), supplier.GetSearchReplica())
+	}
+}
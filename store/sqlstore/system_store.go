@@ -4,7 +4,9 @@
 package sqlstore
 
 import (
+	"database/sql"
 	"net/http"
+	"strings"
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
@@ -86,3 +88,74 @@ func (s SqlSystemStore) PermanentDeleteByName(name string) (*model.System, *mode
 
 	return &system, nil
 }
+
+// AcquireLease takes or renews the given lease unless it is held by another server and hasn't expired yet. The lease
+// is updated with a compare-and-set on its previous value, so that only one of the servers racing for an expired
+// lease gets it. It returns the lease in effect afterwards, which is the given one if it was acquired.
+func (s SqlSystemStore) AcquireLease(lease *model.LeaderLease) (*model.LeaderLease, *model.AppError) {
+	for {
+		var system model.System
+		if err := s.GetMaster().SelectOne(&system, "SELECT * FROM Systems WHERE Name = :Name", map[string]interface{}{"Name": lease.Name}); err != nil {
+			if err != sql.ErrNoRows {
+				return nil, model.NewAppError("SqlSystemStore.AcquireLease", "store.sql_system.acquire_lease.app_error", nil, "name="+lease.Name+", "+err.Error(), http.StatusInternalServerError)
+			}
+
+			if err := s.GetMaster().Insert(&model.System{Name: lease.Name, Value: lease.ToJson()}); err != nil {
+				if IsUniqueConstraintError(err, []string{"PRIMARY", "systems_pkey"}) {
+					// Another server inserted the lease first, so check whether it still can be taken.
+					continue
+				}
+				return nil, model.NewAppError("SqlSystemStore.AcquireLease", "store.sql_system.acquire_lease.app_error", nil, "name="+lease.Name+", "+err.Error(), http.StatusInternalServerError)
+			}
+
+			return lease, nil
+		}
+
+		current := model.LeaderLeaseFromJson(strings.NewReader(system.Value))
+		if current != nil && current.HolderId != lease.HolderId && !current.IsExpired() {
+			return current, nil
+		}
+
+		sqlResult, err := s.GetMaster().Exec("UPDATE Systems SET Value = :NewValue WHERE Name = :Name AND Value = :OldValue", map[string]interface{}{"Name": lease.Name, "NewValue": lease.ToJson(), "OldValue": system.Value})
+		if err != nil {
+			return nil, model.NewAppError("SqlSystemStore.AcquireLease", "store.sql_system.acquire_lease.app_error", nil, "name="+lease.Name+", "+err.Error(), http.StatusInternalServerError)
+		}
+
+		rows, err := sqlResult.RowsAffected()
+		if err != nil {
+			return nil, model.NewAppError("SqlSystemStore.AcquireLease", "store.sql_system.acquire_lease.app_error", nil, "name="+lease.Name+", "+err.Error(), http.StatusInternalServerError)
+		}
+
+		if rows == 1 {
+			return lease, nil
+		}
+	}
+}
+
+// ReleaseLease gives up the named lease if it is still held by the given server, returning whether it was released.
+func (s SqlSystemStore) ReleaseLease(name string, holderId string) (bool, *model.AppError) {
+	var system model.System
+	if err := s.GetMaster().SelectOne(&system, "SELECT * FROM Systems WHERE Name = :Name", map[string]interface{}{"Name": name}); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, model.NewAppError("SqlSystemStore.ReleaseLease", "store.sql_system.release_lease.app_error", nil, "name="+name+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	current := model.LeaderLeaseFromJson(strings.NewReader(system.Value))
+	if current == nil || current.HolderId != holderId {
+		return false, nil
+	}
+
+	sqlResult, err := s.GetMaster().Exec("DELETE FROM Systems WHERE Name = :Name AND Value = :Value", map[string]interface{}{"Name": name, "Value": system.Value})
+	if err != nil {
+		return false, model.NewAppError("SqlSystemStore.ReleaseLease", "store.sql_system.release_lease.app_error", nil, "name="+name+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	rows, err := sqlResult.RowsAffected()
+	if err != nil {
+		return false, model.NewAppError("SqlSystemStore.ReleaseLease", "store.sql_system.release_lease.app_error", nil, "name="+name+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return rows == 1, nil
+}
@@ -760,7 +760,8 @@ func (s SqlTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId, userId string)
 			Id = ChannelId
 			AND UserId = :UserId
 			AND DeleteAt = 0
-			AND TeamId != :TeamId`,
+			AND TeamId != :TeamId
+			AND Id NOT IN (SELECT ChannelId FROM ChannelTeamBindings WHERE TeamId = :TeamId)`,
 		map[string]interface{}{"UserId": userId, "TeamId": excludeTeamId})
 
 	if err != nil {
@@ -779,7 +780,7 @@ func (s SqlTeamStore) GetChannelUnreadsForTeam(teamId, userId string) ([]*model.
 		WHERE
 			Id = ChannelId
 			AND UserId = :UserId
-			AND TeamId = :TeamId
+			AND (TeamId = :TeamId OR Id IN (SELECT ChannelId FROM ChannelTeamBindings WHERE TeamId = :TeamId))
 			AND DeleteAt = 0`
 
 	var channels []*model.ChannelUnread
@@ -51,6 +51,13 @@ func (s SqlTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*mod
 		return nil, err
 	}
 
+	// Versions are numbered in the order they're created
+	maxVersion, err := s.GetMaster().SelectInt("SELECT COALESCE(MAX(Version), 0) FROM TermsOfService")
+	if err != nil {
+		return nil, model.NewAppError("SqlTermsOfServiceStore.Save", "store.sql_terms_of_service.save.app_error", nil, "terms_of_service_id="+termsOfService.Id+",err="+err.Error(), http.StatusInternalServerError)
+	}
+	termsOfService.Version = maxVersion + 1
+
 	if err := s.GetMaster().Insert(termsOfService); err != nil {
 		return nil, model.NewAppError("SqlTermsOfServiceStore.Save", "store.sql_terms_of_service.save.app_error", nil, "terms_of_service_id="+termsOfService.Id+",err="+err.Error(), http.StatusInternalServerError)
 	}
@@ -118,3 +125,30 @@ func (s SqlTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.Term
 	}
 	return obj.(*model.TermsOfService), nil
 }
+
+// GetAll returns every version of the terms of service, starting with the latest.
+func (s SqlTermsOfServiceStore) GetAll() ([]*model.TermsOfService, *model.AppError) {
+	var termsOfServices []*model.TermsOfService
+	if _, err := s.GetReplica().Select(&termsOfServices, "SELECT * FROM TermsOfService ORDER BY CreateAt DESC"); err != nil {
+		return nil, model.NewAppError("SqlTermsOfServiceStore.GetAll", "store.sql_terms_of_service_store.get.app_error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	return termsOfServices, nil
+}
+
+// UpdateReacceptAt schedules when the users who accepted a version of the terms of service before must accept it
+// again, or cancels it when reacceptAt is 0.
+func (s SqlTermsOfServiceStore) UpdateReacceptAt(id string, reacceptAt int64) *model.AppError {
+	result, err := s.GetMaster().Exec("UPDATE TermsOfService SET ReacceptAt = :ReacceptAt WHERE Id = :Id", map[string]interface{}{"ReacceptAt": reacceptAt, "Id": id})
+	if err != nil {
+		return model.NewAppError("SqlTermsOfServiceStore.UpdateReacceptAt", "store.sql_terms_of_service.update_reaccept_at.app_error", nil, "terms_of_service_id="+id+",err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return model.NewAppError("SqlTermsOfServiceStore.UpdateReacceptAt", "store.sql_terms_of_service_store.get.no_rows.app_error", nil, "terms_of_service_id="+id, http.StatusNotFound)
+	}
+
+	termsOfServiceCache.Remove(id)
+
+	return nil
+}
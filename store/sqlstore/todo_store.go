@@ -0,0 +1,121 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlTodoStore struct {
+	SqlStore
+}
+
+func NewSqlTodoStore(sqlStore SqlStore) store.TodoStore {
+	s := &SqlTodoStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.Todo{}, "Todos").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("Message").SetMaxSize(model.TODO_MESSAGE_MAX_RUNES * 4)
+	}
+
+	return s
+}
+
+func (s SqlTodoStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_todos_user_id", "Todos", "UserId")
+}
+
+func (s SqlTodoStore) Save(todo *model.Todo) (*model.Todo, *model.AppError) {
+	if len(todo.Id) > 0 {
+		return nil, model.NewAppError("SqlTodoStore.Save", "store.sql_todo.save.existing.app_error", nil, "id="+todo.Id, http.StatusBadRequest)
+	}
+
+	todo.PreSave()
+	if err := todo.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(todo); err != nil {
+		return nil, model.NewAppError("SqlTodoStore.Save", "store.sql_todo.save.app_error", nil, "id="+todo.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return todo, nil
+}
+
+func (s SqlTodoStore) Update(todo *model.Todo) (*model.Todo, *model.AppError) {
+	todo.PreUpdate()
+	if err := todo.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(todo)
+	if err != nil {
+		return nil, model.NewAppError("SqlTodoStore.Update", "store.sql_todo.update.app_error", nil, "id="+todo.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		return nil, model.NewAppError("SqlTodoStore.Update", "store.sql_todo.get.app_error", nil, "id="+todo.Id, http.StatusNotFound)
+	}
+
+	return todo, nil
+}
+
+func (s SqlTodoStore) Get(id string) (*model.Todo, *model.AppError) {
+	var todo model.Todo
+
+	if err := s.GetReplica().SelectOne(&todo, "SELECT * FROM Todos WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlTodoStore.Get", "store.sql_todo.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlTodoStore.Get", "store.sql_todo.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &todo, nil
+}
+
+// GetForUser returns the items of a user's todo list in the order they were added, leaving out the completed ones
+// unless asked for.
+func (s SqlTodoStore) GetForUser(userId string, includeCompleted bool) ([]*model.Todo, *model.AppError) {
+	completedClause := ""
+	if !includeCompleted {
+		completedClause = "AND CompleteAt = 0"
+	}
+
+	todos := []*model.Todo{}
+	if _, err := s.GetReplica().Select(&todos, `SELECT
+			*
+		FROM
+			Todos
+		WHERE
+			UserId = :UserId
+			`+completedClause+`
+		ORDER BY
+			CreateAt, Id`, map[string]interface{}{"UserId": userId}); err != nil {
+		return nil, model.NewAppError("SqlTodoStore.GetForUser", "store.sql_todo.get_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return todos, nil
+}
+
+func (s SqlTodoStore) Delete(id string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM Todos WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		return model.NewAppError("SqlTodoStore.Delete", "store.sql_todo.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlTodoStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM Todos WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlTodoStore.PermanentDeleteByUser", "store.sql_todo.delete.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 
 const (
 	CURRENT_SCHEMA_VERSION   = VERSION_5_16_0
+	VERSION_5_17_0           = "5.17.0"
 	VERSION_5_16_0           = "5.16.0"
 	VERSION_5_15_0           = "5.15.0"
 	VERSION_5_14_0           = "5.14.0"
@@ -165,6 +166,7 @@ func UpgradeDatabase(sqlStore SqlStore, currentModelVersionString string) error
 	UpgradeDatabaseToVersion514(sqlStore)
 	UpgradeDatabaseToVersion515(sqlStore)
 	UpgradeDatabaseToVersion516(sqlStore)
+	UpgradeDatabaseToVersion517(sqlStore)
 
 	return nil
 }
@@ -750,3 +752,37 @@ func UpgradeDatabaseToVersion516(sqlStore SqlStore) {
 		sqlStore.CreateIndexIfNotExists("idx_groupchannels_channelid", "GroupChannels", "ChannelId")
 	}
 }
+
+func UpgradeDatabaseToVersion517(sqlStore SqlStore) {
+	// TODO: Uncomment following condition when version 5.17.0 is released
+	// if shouldPerformUpgrade(sqlStore, VERSION_5_16_0, VERSION_5_17_0) {
+
+	sqlStore.CreateColumnIfNotExists("Channels", "ReplyBroadcastPolicy", "varchar(32)", "varchar(32)", "")
+	sqlStore.CreateColumnIfNotExistsNoDefault("IncomingWebhooks", "PayloadTemplate", "text", "varchar(4096)")
+	sqlStore.CreateColumnIfNotExistsNoDefault("IncomingWebhooks", "RateLimitPerMinute", "integer", "integer")
+	sqlStore.CreateColumnIfNotExistsNoDefault("IncomingWebhooks", "DailyQuota", "integer", "integer")
+	sqlStore.CreateColumnIfNotExists("OutgoingWebhooks", "EventTypes", "varchar(256)", "varchar(256)", "[]")
+	sqlStore.CreateColumnIfNotExists("OutgoingWebhooks", "TestMode", "boolean", "boolean", "0")
+	sqlStore.CreateColumnIfNotExists("OAuthAccessData", "PreviousRefreshToken", "varchar(26)", "varchar(26)", "")
+	sqlStore.CreateColumnIfNotExists("Channels", "HashtagsDisabled", "boolean", "boolean", "0")
+	sqlStore.CreateColumnIfNotExists("UserAccessTokens", "ExpiresAt", "bigint", "bigint", "0")
+	sqlStore.CreateColumnIfNotExists("UserAccessTokens", "LastUsedAt", "bigint", "bigint", "0")
+	sqlStore.CreateColumnIfNotExists("UserAccessTokens", "AllowedIPs", "varchar(1024)", "varchar(1024)", "")
+	sqlStore.CreateColumnIfNotExists("UserAccessTokens", "Scope", "varchar(128)", "varchar(128)", "")
+	sqlStore.CreateColumnIfNotExists("FileInfo", "Verdict", "varchar(16)", "varchar(16)", "")
+	sqlStore.CreateColumnIfNotExists("FileInfo", "VerdictReason", "varchar(1024)", "varchar(1024)", "")
+	sqlStore.CreateColumnIfNotExists("FileInfo", "VerdictAt", "bigint", "bigint", "0")
+	sqlStore.CreateColumnIfNotExists("Channels", "IntegrationsRestricted", "boolean", "boolean", "0")
+	sqlStore.CreateColumnIfNotExists("Channels", "AllowedWebhookIds", "varchar(1500)", "varchar(1500)", "[]")
+	sqlStore.CreateColumnIfNotExists("Channels", "AllowedBotIds", "varchar(1500)", "varchar(1500)", "[]")
+	sqlStore.CreateColumnIfNotExists("Channels", "ModerationEnabled", "boolean", "boolean", "0")
+	sqlStore.CreateColumnIfNotExists("Channels", "WelcomeMessage", "varchar(4000)", "varchar(4000)", "")
+	sqlStore.CreateColumnIfNotExists("Channels", "WelcomeMessageType", "varchar(16)", "varchar(16)", "")
+	sqlStore.CreateColumnIfNotExists("Emoji", "Animated", "boolean", "boolean", "0")
+	sqlStore.CreateColumnIfNotExists("Channels", "LinkPreviewsDisabled", "boolean", "boolean", "0")
+	sqlStore.CreateColumnIfNotExists("TermsOfService", "Version", "bigint", "bigint", "0")
+	sqlStore.CreateColumnIfNotExists("TermsOfService", "ReacceptAt", "bigint", "bigint", "0")
+
+	// 	saveSchemaVersion(sqlStore, VERSION_5_17_0)
+	// }
+}
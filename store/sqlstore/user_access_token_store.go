@@ -25,6 +25,8 @@ func NewSqlUserAccessTokenStore(sqlStore SqlStore) store.UserAccessTokenStore {
 		table.ColMap("Token").SetMaxSize(26).SetUnique(true)
 		table.ColMap("UserId").SetMaxSize(26)
 		table.ColMap("Description").SetMaxSize(512)
+		table.ColMap("AllowedIPs").SetMaxSize(1024)
+		table.ColMap("Scope").SetMaxSize(128)
 	}
 
 	return s
@@ -33,6 +35,7 @@ func NewSqlUserAccessTokenStore(sqlStore SqlStore) store.UserAccessTokenStore {
 func (s SqlUserAccessTokenStore) CreateIndexesIfNotExists() {
 	s.CreateIndexIfNotExists("idx_user_access_tokens_token", "UserAccessTokens", "Token")
 	s.CreateIndexIfNotExists("idx_user_access_tokens_user_id", "UserAccessTokens", "UserId")
+	s.CreateIndexIfNotExists("idx_user_access_tokens_expires_at", "UserAccessTokens", "ExpiresAt")
 }
 
 func (s SqlUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
@@ -178,6 +181,27 @@ func (s SqlUserAccessTokenStore) GetByUser(userId string, offset, limit int) ([]
 	return tokens, nil
 }
 
+// GetExpiring returns the active tokens expiring between the given times, soonest first.
+func (s SqlUserAccessTokenStore) GetExpiring(from, to int64, offset, limit int) ([]*model.UserAccessToken, *model.AppError) {
+	tokens := []*model.UserAccessToken{}
+
+	query := `
+		SELECT
+			*
+		FROM UserAccessTokens
+		WHERE IsActive = :IsActive
+			AND ExpiresAt > :From
+			AND ExpiresAt <= :To
+		ORDER BY ExpiresAt ASC, Id ASC
+		LIMIT :Limit OFFSET :Offset`
+
+	if _, err := s.GetReplica().Select(&tokens, query, map[string]interface{}{"IsActive": true, "From": from, "To": to, "Offset": offset, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlUserAccessTokenStore.GetExpiring", "store.sql_user_access_token.get_expiring.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return tokens, nil
+}
+
 func (s SqlUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, *model.AppError) {
 	term = sanitizeSearchTerm(term, "\\")
 	tokens := []*model.UserAccessToken{}
@@ -204,6 +228,13 @@ func (s SqlUserAccessTokenStore) UpdateTokenEnable(tokenId string) *model.AppErr
 	return nil
 }
 
+func (s SqlUserAccessTokenStore) UpdateLastUsedAt(tokenId string, lastUsedAt int64) *model.AppError {
+	if _, err := s.GetMaster().Exec("UPDATE UserAccessTokens SET LastUsedAt = :LastUsedAt WHERE Id = :Id", map[string]interface{}{"Id": tokenId, "LastUsedAt": lastUsedAt}); err != nil {
+		return model.NewAppError("SqlUserAccessTokenStore.UpdateLastUsedAt", "store.sql_user_access_token.update_last_used_at.app_error", nil, "id="+tokenId+", "+err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
 func (s SqlUserAccessTokenStore) UpdateTokenDisable(tokenId string) *model.AppError {
 	transaction, err := s.GetMaster().Begin()
 	if err != nil {
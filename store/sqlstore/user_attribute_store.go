@@ -0,0 +1,188 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlUserAttributeStore struct {
+	SqlStore
+}
+
+func NewSqlUserAttributeStore(sqlStore SqlStore) store.UserAttributeStore {
+	s := &SqlUserAttributeStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		fields := db.AddTableWithName(model.UserAttributeField{}, "UserAttributeFields").SetKeys(false, "Id")
+		fields.ColMap("Id").SetMaxSize(26)
+		fields.ColMap("Name").SetMaxSize(model.USER_ATTRIBUTE_NAME_MAX_LENGTH)
+		fields.ColMap("DisplayName").SetMaxSize(model.USER_ATTRIBUTE_DISPLAY_NAME_MAX_RUNES)
+		fields.ColMap("LdapAttribute").SetMaxSize(model.USER_ATTRIBUTE_SOURCE_ATTRIBUTE_MAX_LENGTH)
+		fields.ColMap("SamlAttribute").SetMaxSize(model.USER_ATTRIBUTE_SOURCE_ATTRIBUTE_MAX_LENGTH)
+
+		fields.SetUniqueTogether("Name", "DeleteAt")
+
+		values := db.AddTableWithName(model.UserAttributeValue{}, "UserAttributeValues").SetKeys(false, "UserId", "FieldId")
+		values.ColMap("UserId").SetMaxSize(26)
+		values.ColMap("FieldId").SetMaxSize(26)
+		values.ColMap("Value").SetMaxSize(model.USER_ATTRIBUTE_VALUE_MAX_RUNES)
+		values.ColMap("Source").SetMaxSize(16)
+	}
+
+	return s
+}
+
+func (s SqlUserAttributeStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_userattributefields_delete_at", "UserAttributeFields", "DeleteAt")
+	s.CreateIndexIfNotExists("idx_userattributevalues_field_id", "UserAttributeValues", "FieldId")
+	s.CreateIndexIfNotExists("idx_userattributevalues_value", "UserAttributeValues", "Value")
+}
+
+func (s SqlUserAttributeStore) SaveField(field *model.UserAttributeField) (*model.UserAttributeField, *model.AppError) {
+	if len(field.Id) > 0 {
+		return nil, model.NewAppError("SqlUserAttributeStore.SaveField", "store.sql_user_attribute.save_field.existing.app_error", nil, "id="+field.Id, http.StatusBadRequest)
+	}
+
+	field.PreSave()
+	if err := field.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(field); err != nil {
+		if IsUniqueConstraintError(err, []string{"Name", "userattributefields_name_deleteat_key"}) {
+			return nil, model.NewAppError("SqlUserAttributeStore.SaveField", "store.sql_user_attribute.save_field.name_exists.app_error", nil, "name="+field.Name, http.StatusBadRequest)
+		}
+		return nil, model.NewAppError("SqlUserAttributeStore.SaveField", "store.sql_user_attribute.save_field.app_error", nil, "id="+field.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return field, nil
+}
+
+func (s SqlUserAttributeStore) UpdateField(field *model.UserAttributeField) (*model.UserAttributeField, *model.AppError) {
+	field.PreUpdate()
+	if err := field.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().Update(field); err != nil {
+		if IsUniqueConstraintError(err, []string{"Name", "userattributefields_name_deleteat_key"}) {
+			return nil, model.NewAppError("SqlUserAttributeStore.UpdateField", "store.sql_user_attribute.save_field.name_exists.app_error", nil, "name="+field.Name, http.StatusBadRequest)
+		}
+		return nil, model.NewAppError("SqlUserAttributeStore.UpdateField", "store.sql_user_attribute.update_field.app_error", nil, "id="+field.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return field, nil
+}
+
+func (s SqlUserAttributeStore) GetField(id string) (*model.UserAttributeField, *model.AppError) {
+	var field model.UserAttributeField
+
+	if err := s.GetReplica().SelectOne(&field, "SELECT * FROM UserAttributeFields WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlUserAttributeStore.GetField", "store.sql_user_attribute.get_field.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlUserAttributeStore.GetField", "store.sql_user_attribute.get_field.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &field, nil
+}
+
+func (s SqlUserAttributeStore) GetFields() ([]*model.UserAttributeField, *model.AppError) {
+	var fields []*model.UserAttributeField
+
+	if _, err := s.GetReplica().Select(&fields, "SELECT * FROM UserAttributeFields WHERE DeleteAt = 0 ORDER BY Name"); err != nil {
+		return nil, model.NewAppError("SqlUserAttributeStore.GetFields", "store.sql_user_attribute.get_fields.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return fields, nil
+}
+
+// DeleteField deletes the field along with the values users had for it.
+func (s SqlUserAttributeStore) DeleteField(id string, time int64) *model.AppError {
+	sqlResult, err := s.GetMaster().Exec("UPDATE UserAttributeFields SET DeleteAt = :DeleteAt, UpdateAt = :UpdateAt WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"DeleteAt": time, "UpdateAt": time, "Id": id})
+	if err != nil {
+		return model.NewAppError("SqlUserAttributeStore.DeleteField", "store.sql_user_attribute.delete_field.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if rows, _ := sqlResult.RowsAffected(); rows == 0 {
+		return model.NewAppError("SqlUserAttributeStore.DeleteField", "store.sql_user_attribute.get_field.app_error", nil, "id="+id, http.StatusNotFound)
+	}
+
+	if _, err := s.GetMaster().Exec("DELETE FROM UserAttributeValues WHERE FieldId = :FieldId", map[string]interface{}{"FieldId": id}); err != nil {
+		return model.NewAppError("SqlUserAttributeStore.DeleteField", "store.sql_user_attribute.delete_field.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// SaveValue stores the value of an attribute for a user, replacing the one they previously had if any.
+func (s SqlUserAttributeStore) SaveValue(value *model.UserAttributeValue) (*model.UserAttributeValue, *model.AppError) {
+	value.PreSave()
+	if err := value.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(value)
+	if err != nil {
+		return nil, model.NewAppError("SqlUserAttributeStore.SaveValue", "store.sql_user_attribute.save_value.app_error", nil, "user_id="+value.UserId+", field_id="+value.FieldId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		if err := s.GetMaster().Insert(value); err != nil {
+			return nil, model.NewAppError("SqlUserAttributeStore.SaveValue", "store.sql_user_attribute.save_value.app_error", nil, "user_id="+value.UserId+", field_id="+value.FieldId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return value, nil
+}
+
+func (s SqlUserAttributeStore) DeleteValue(userId, fieldId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM UserAttributeValues WHERE UserId = :UserId AND FieldId = :FieldId", map[string]interface{}{"UserId": userId, "FieldId": fieldId}); err != nil {
+		return model.NewAppError("SqlUserAttributeStore.DeleteValue", "store.sql_user_attribute.delete_value.app_error", nil, "user_id="+userId+", field_id="+fieldId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetValuesForUsers returns the values the users have for the fields that haven't been deleted.
+func (s SqlUserAttributeStore) GetValuesForUsers(userIds []string) ([]*model.UserAttributeValue, *model.AppError) {
+	var values []*model.UserAttributeValue
+
+	if len(userIds) == 0 {
+		return values, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select("v.*").
+		From("UserAttributeValues v").
+		Join("UserAttributeFields f ON f.Id = v.FieldId").
+		Where("f.DeleteAt = 0").
+		Where(sq.Eq{"v.UserId": userIds})
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, model.NewAppError("SqlUserAttributeStore.GetValuesForUsers", "store.sql_user_attribute.get_values_for_users.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, err := s.GetReplica().Select(&values, queryString, args...); err != nil {
+		return nil, model.NewAppError("SqlUserAttributeStore.GetValuesForUsers", "store.sql_user_attribute.get_values_for_users.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return values, nil
+}
+
+func (s SqlUserAttributeStore) PermanentDeleteValuesByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM UserAttributeValues WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlUserAttributeStore.PermanentDeleteValuesByUser", "store.sql_user_attribute.permanent_delete_values_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlUserAvailabilityStore struct {
+	SqlStore
+}
+
+func NewSqlUserAvailabilityStore(sqlStore SqlStore) store.UserAvailabilityStore {
+	s := &SqlUserAvailabilityStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.UserAvailability{}, "UserAvailability").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+		table.ColMap("Source").SetMaxSize(model.USER_AVAILABILITY_SOURCE_MAX_LENGTH)
+		table.ColMap("Status").SetMaxSize(16)
+		table.ColMap("Title").SetMaxSize(model.USER_AVAILABILITY_TITLE_MAX_RUNES * 4)
+	}
+
+	return s
+}
+
+func (s SqlUserAvailabilityStore) CreateIndexesIfNotExists() {
+	s.CreateCompositeIndexIfNotExists("idx_useravailability_user_id_end_at", "UserAvailability", []string{"UserId", "EndAt"})
+}
+
+func (s SqlUserAvailabilityStore) Save(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	if len(availability.Id) > 0 {
+		return nil, model.NewAppError("SqlUserAvailabilityStore.Save", "store.sql_user_availability.save.existing.app_error", nil, "id="+availability.Id, http.StatusBadRequest)
+	}
+
+	availability.PreSave()
+	if err := availability.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(availability); err != nil {
+		return nil, model.NewAppError("SqlUserAvailabilityStore.Save", "store.sql_user_availability.save.app_error", nil, "id="+availability.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return availability, nil
+}
+
+func (s SqlUserAvailabilityStore) Update(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
+	availability.PreUpdate()
+	if err := availability.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(availability)
+	if err != nil {
+		return nil, model.NewAppError("SqlUserAvailabilityStore.Update", "store.sql_user_availability.update.app_error", nil, "id="+availability.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		return nil, model.NewAppError("SqlUserAvailabilityStore.Update", "store.sql_user_availability.get.app_error", nil, "id="+availability.Id, http.StatusNotFound)
+	}
+
+	return availability, nil
+}
+
+func (s SqlUserAvailabilityStore) Get(id string) (*model.UserAvailability, *model.AppError) {
+	var availability model.UserAvailability
+
+	if err := s.GetReplica().SelectOne(&availability, "SELECT * FROM UserAvailability WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlUserAvailabilityStore.Get", "store.sql_user_availability.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlUserAvailabilityStore.Get", "store.sql_user_availability.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &availability, nil
+}
+
+// GetForUser returns the blocks of a user's availability overlapping the given span of time, in the order they start.
+func (s SqlUserAvailabilityStore) GetForUser(userId string, startAt, endAt int64) ([]*model.UserAvailability, *model.AppError) {
+	blocks := []*model.UserAvailability{}
+
+	if _, err := s.GetReplica().Select(&blocks, `SELECT
+			*
+		FROM
+			UserAvailability
+		WHERE
+			UserId = :UserId
+			AND EndAt > :StartAt
+			AND StartAt < :EndAt
+		ORDER BY
+			StartAt, Id`, map[string]interface{}{"UserId": userId, "StartAt": startAt, "EndAt": endAt}); err != nil {
+		return nil, model.NewAppError("SqlUserAvailabilityStore.GetForUser", "store.sql_user_availability.get_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return blocks, nil
+}
+
+// GetForUsersAt returns the blocks of the given users' availability that include the given time.
+func (s SqlUserAvailabilityStore) GetForUsersAt(userIds []string, at int64) ([]*model.UserAvailability, *model.AppError) {
+	blocks := []*model.UserAvailability{}
+	if len(userIds) == 0 {
+		return blocks, nil
+	}
+
+	props := map[string]interface{}{"At": at}
+	idQuery := ""
+
+	for index, userId := range userIds {
+		if len(idQuery) > 0 {
+			idQuery += ", "
+		}
+
+		props["userId"+strconv.Itoa(index)] = userId
+		idQuery += ":userId" + strconv.Itoa(index)
+	}
+
+	if _, err := s.GetReplica().Select(&blocks, `SELECT
+			*
+		FROM
+			UserAvailability
+		WHERE
+			UserId IN (`+idQuery+`)
+			AND StartAt <= :At
+			AND EndAt > :At`, props); err != nil {
+		return nil, model.NewAppError("SqlUserAvailabilityStore.GetForUsersAt", "store.sql_user_availability.get_for_users.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return blocks, nil
+}
+
+func (s SqlUserAvailabilityStore) Delete(id string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM UserAvailability WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		return model.NewAppError("SqlUserAvailabilityStore.Delete", "store.sql_user_availability.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlUserAvailabilityStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM UserAvailability WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlUserAvailabilityStore.PermanentDeleteByUser", "store.sql_user_availability.delete.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
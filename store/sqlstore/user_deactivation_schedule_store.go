@@ -0,0 +1,109 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlUserDeactivationScheduleStore struct {
+	SqlStore
+}
+
+func NewSqlUserDeactivationScheduleStore(sqlStore SqlStore) store.UserDeactivationScheduleStore {
+	s := &SqlUserDeactivationScheduleStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.UserDeactivationSchedule{}, "UserDeactivationSchedules").SetKeys(false, "UserId")
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlUserDeactivationScheduleStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_userdeactivationschedules_deactivate_at", "UserDeactivationSchedules", "DeactivateAt")
+}
+
+// Save sets when the user is deactivated, replacing any schedule previously set for them.
+func (s SqlUserDeactivationScheduleStore) Save(schedule *model.UserDeactivationSchedule) (*model.UserDeactivationSchedule, *model.AppError) {
+	schedule.PreSave()
+	if err := schedule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, model.NewAppError("SqlUserDeactivationScheduleStore.Save", "store.sql_user_deactivation_schedule.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	count, err := transaction.SelectInt("SELECT COUNT(*) FROM UserDeactivationSchedules WHERE UserId = :UserId", map[string]interface{}{"UserId": schedule.UserId})
+	if err != nil {
+		return nil, model.NewAppError("SqlUserDeactivationScheduleStore.Save", "store.sql_user_deactivation_schedule.save.app_error", nil, "user_id="+schedule.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count == 0 {
+		err = transaction.Insert(schedule)
+	} else {
+		_, err = transaction.Update(schedule)
+	}
+	if err != nil {
+		return nil, model.NewAppError("SqlUserDeactivationScheduleStore.Save", "store.sql_user_deactivation_schedule.save.app_error", nil, "user_id="+schedule.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, model.NewAppError("SqlUserDeactivationScheduleStore.Save", "store.sql_user_deactivation_schedule.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return schedule, nil
+}
+
+func (s SqlUserDeactivationScheduleStore) Get(userId string) (*model.UserDeactivationSchedule, *model.AppError) {
+	var schedule model.UserDeactivationSchedule
+
+	if err := s.GetReplica().SelectOne(&schedule, "SELECT * FROM UserDeactivationSchedules WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlUserDeactivationScheduleStore.Get", "store.sql_user_deactivation_schedule.get.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlUserDeactivationScheduleStore.Get", "store.sql_user_deactivation_schedule.get.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &schedule, nil
+}
+
+// GetAll returns a page of the schedules, starting with the users deactivated the soonest.
+func (s SqlUserDeactivationScheduleStore) GetAll(offset, limit int) ([]*model.UserDeactivationSchedule, *model.AppError) {
+	var schedules []*model.UserDeactivationSchedule
+
+	if _, err := s.GetReplica().Select(&schedules, "SELECT * FROM UserDeactivationSchedules ORDER BY DeactivateAt, UserId LIMIT :Limit OFFSET :Offset", map[string]interface{}{"Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlUserDeactivationScheduleStore.GetAll", "store.sql_user_deactivation_schedule.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return schedules, nil
+}
+
+// GetDue returns the schedules that are due before the given time, starting with the earliest.
+func (s SqlUserDeactivationScheduleStore) GetDue(before int64, limit int) ([]*model.UserDeactivationSchedule, *model.AppError) {
+	var schedules []*model.UserDeactivationSchedule
+
+	if _, err := s.GetMaster().Select(&schedules, "SELECT * FROM UserDeactivationSchedules WHERE DeactivateAt <= :Before ORDER BY DeactivateAt LIMIT :Limit", map[string]interface{}{"Before": before, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlUserDeactivationScheduleStore.GetDue", "store.sql_user_deactivation_schedule.get_due.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return schedules, nil
+}
+
+func (s SqlUserDeactivationScheduleStore) Delete(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM UserDeactivationSchedules WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlUserDeactivationScheduleStore.Delete", "store.sql_user_deactivation_schedule.delete.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -4,11 +4,13 @@
 package sqlstore
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net/http"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/Masterminds/squirrel"
 	sq "github.com/Masterminds/squirrel"
@@ -1122,6 +1124,29 @@ func (us SqlUserStore) AnalyticsActiveCount(timePeriod int64, options model.User
 	return v, nil
 }
 
+// AnalyticsGetMfaAdoption counts the active users who can enable MFA, which are those logging in with a password,
+// and how many of them did.
+func (us SqlUserStore) AnalyticsGetMfaAdoption() (*model.MfaAdoption, *model.AppError) {
+	query := `
+		SELECT
+			COUNT(*) AS TotalUsers,
+			COALESCE(SUM(CASE WHEN Users.MfaActive THEN 1 ELSE 0 END), 0) AS MfaActiveUsers
+		FROM
+			Users
+			LEFT JOIN Bots ON Users.Id = Bots.UserId
+		WHERE
+			Users.DeleteAt = 0
+			AND Bots.UserId IS NULL
+			AND Users.AuthService IN ('', :EmailAuthService, :LdapAuthService)`
+
+	var adoption model.MfaAdoption
+	if err := us.GetReplica().SelectOne(&adoption, query, map[string]interface{}{"EmailAuthService": model.USER_AUTH_SERVICE_EMAIL, "LdapAuthService": model.USER_AUTH_SERVICE_LDAP}); err != nil {
+		return nil, model.NewAppError("SqlUserStore.AnalyticsGetMfaAdoption", "store.sql_user.analytics_get_mfa_adoption.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return &adoption, nil
+}
+
 func (us SqlUserStore) GetUnreadCount(userId string) (int64, error) {
 	query := `
 		SELECT SUM(CASE WHEN c.Type = 'D' THEN (c.TotalMsgCount - cm.MsgCount) ELSE cm.MentionCount END)
@@ -1155,7 +1180,7 @@ func (us SqlUserStore) GetAnyUnreadPostCountForChannel(userId string, channelId
 	return count, nil
 }
 
-func (us SqlUserStore) Search(teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+func (us SqlUserStore) Search(ctx context.Context, teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	query := us.usersQuery.
 		OrderBy("Username ASC").
 		Limit(uint64(options.Limit))
@@ -1163,10 +1188,10 @@ func (us SqlUserStore) Search(teamId string, term string, options *model.UserSea
 	if teamId != "" {
 		query = query.Join("TeamMembers tm ON ( tm.UserId = u.Id AND tm.DeleteAt = 0 AND tm.TeamId = ? )", teamId)
 	}
-	return us.performSearch(query, term, options)
+	return us.performSearch(ctx, query, term, options)
 }
 
-func (us SqlUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+func (us SqlUserStore) SearchWithoutTeam(ctx context.Context, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	query := us.usersQuery.
 		Where(`(
 				SELECT
@@ -1180,10 +1205,10 @@ func (us SqlUserStore) SearchWithoutTeam(term string, options *model.UserSearchO
 		OrderBy("u.Username ASC").
 		Limit(uint64(options.Limit))
 
-	return us.performSearch(query, term, options)
+	return us.performSearch(ctx, query, term, options)
 }
 
-func (us SqlUserStore) SearchNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+func (us SqlUserStore) SearchNotInTeam(ctx context.Context, notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	query := us.usersQuery.
 		LeftJoin("TeamMembers tm ON ( tm.UserId = u.Id AND tm.DeleteAt = 0 AND tm.TeamId = ? )", notInTeamId).
 		Where("tm.UserId IS NULL").
@@ -1194,10 +1219,10 @@ func (us SqlUserStore) SearchNotInTeam(notInTeamId string, term string, options
 		query = applyTeamGroupConstrainedFilter(query, notInTeamId)
 	}
 
-	return us.performSearch(query, term, options)
+	return us.performSearch(ctx, query, term, options)
 }
 
-func (us SqlUserStore) SearchNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+func (us SqlUserStore) SearchNotInChannel(ctx context.Context, teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	query := us.usersQuery.
 		LeftJoin("ChannelMembers cm ON ( cm.UserId = u.Id AND cm.ChannelId = ? )", channelId).
 		Where("cm.UserId IS NULL").
@@ -1212,16 +1237,16 @@ func (us SqlUserStore) SearchNotInChannel(teamId string, channelId string, term
 		query = applyChannelGroupConstrainedFilter(query, channelId)
 	}
 
-	return us.performSearch(query, term, options)
+	return us.performSearch(ctx, query, term, options)
 }
 
-func (us SqlUserStore) SearchInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+func (us SqlUserStore) SearchInChannel(ctx context.Context, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	query := us.usersQuery.
 		Join("ChannelMembers cm ON ( cm.UserId = u.Id AND cm.ChannelId = ? )", channelId).
 		OrderBy("Username ASC").
 		Limit(uint64(options.Limit))
 
-	return us.performSearch(query, term, options)
+	return us.performSearch(ctx, query, term, options)
 }
 
 var spaceFulltextSearchChar = []string{
@@ -1251,13 +1276,80 @@ func generateSearchQuery(query sq.SelectBuilder, terms []string, fields []string
 			}
 			termArgs = append(termArgs, fmt.Sprintf("%s%%", strings.TrimLeft(term, "@")))
 		}
+
+		// Terms also match the start of any word of the values of the searchable user attributes.
+		valueMatch := "v.Value LIKE ? escape '*' OR v.Value LIKE ? escape '*'"
+		if isPostgreSQL {
+			valueMatch = "lower(v.Value) LIKE lower(?) escape '*' OR lower(v.Value) LIKE lower(?) escape '*'"
+		}
+		searchFields = append(searchFields, fmt.Sprintf(`u.Id IN (
+				SELECT
+					v.UserId
+				FROM
+					UserAttributeValues v
+					INNER JOIN UserAttributeFields f ON f.Id = v.FieldId
+				WHERE
+					f.Searchable = true
+					AND f.DeleteAt = 0
+					AND (%s)
+			)`, valueMatch))
+		termArgs = append(termArgs, fmt.Sprintf("%s%%", strings.TrimLeft(term, "@")), fmt.Sprintf("%% %s%%", strings.TrimLeft(term, "@")))
+
 		query = query.Where(fmt.Sprintf("(%s)", strings.Join(searchFields, " OR ")), termArgs...)
 	}
 
 	return query
 }
 
-func (us SqlUserStore) performSearch(query sq.SelectBuilder, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+// generateFuzzySearchQuery restricts the query to the users with a field containing the characters of every term in
+// order, such as "jhn" for "john". The terms must have been sanitized with '*' as escape character.
+func generateFuzzySearchQuery(query sq.SelectBuilder, terms []string, fields []string, isPostgreSQL bool) sq.SelectBuilder {
+	for _, term := range terms {
+		pattern := "%"
+		escaped := false
+		for _, c := range strings.TrimLeft(term, "@") {
+			pattern += string(c)
+			if c == '*' && !escaped {
+				escaped = true
+				continue
+			}
+			escaped = false
+			pattern += "%"
+		}
+
+		searchFields := []string{}
+		termArgs := []interface{}{}
+		for _, field := range fields {
+			if isPostgreSQL {
+				searchFields = append(searchFields, fmt.Sprintf("lower(%s) LIKE lower(?) escape '*' ", field))
+			} else {
+				searchFields = append(searchFields, fmt.Sprintf("%s LIKE ? escape '*' ", field))
+			}
+			termArgs = append(termArgs, pattern)
+		}
+
+		query = query.Where(fmt.Sprintf("(%s)", strings.Join(searchFields, " OR ")), termArgs...)
+	}
+
+	return query
+}
+
+// canSearchFuzzily returns true if every term is long enough to be matched fuzzily without matching most users.
+func canSearchFuzzily(terms []string) bool {
+	if len(terms) == 0 {
+		return false
+	}
+
+	for _, term := range terms {
+		if utf8.RuneCountInString(strings.Replace(strings.TrimLeft(term, "@"), "*", "", -1)) < model.USER_SEARCH_FUZZY_MIN_LENGTH {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (us SqlUserStore) performSearch(ctx context.Context, query sq.SelectBuilder, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	term = sanitizeSearchTerm(term, "*")
 
 	searchType := USER_SEARCH_TYPE_NAMES_NO_FULL_NAME
@@ -1283,25 +1375,56 @@ func (us SqlUserStore) performSearch(query sq.SelectBuilder, term string, option
 		query = query.Where("u.DeleteAt = 0")
 	}
 
-	if strings.TrimSpace(term) != "" {
-		query = generateSearchQuery(query, strings.Fields(term), searchType, isPostgreSQL)
+	query = applyViewRestrictionsFilter(query, options.ViewRestrictions, true)
+
+	terms := strings.Fields(term)
+
+	users, err := us.selectSearchResults(ctx, generateSearchQuery(query, terms, searchType, isPostgreSQL), term, searchType)
+	if err != nil {
+		return nil, err
 	}
 
-	query = applyViewRestrictionsFilter(query, options.ViewRestrictions, true)
+	// Users that only match fuzzily are looked for once the users starting with the terms are found, so that they
+	// come after them.
+	if options.Fuzzy && len(users) < options.Limit && canSearchFuzzily(terms) {
+		fuzzyFields := []string{}
+		for _, field := range searchType {
+			if field != "Email" {
+				fuzzyFields = append(fuzzyFields, field)
+			}
+		}
 
+		fuzzyQuery := generateFuzzySearchQuery(query, terms, fuzzyFields, isPostgreSQL).
+			Limit(uint64(options.Limit - len(users)))
+		if len(users) > 0 {
+			fuzzyQuery = fuzzyQuery.Where(sq.NotEq{"u.Id": model.UserSlice(users).IDs()})
+		}
+
+		fuzzyUsers, err := us.selectSearchResults(ctx, fuzzyQuery, term, searchType)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, fuzzyUsers...)
+	}
+
+	for _, u := range users {
+		u.Sanitize(map[string]bool{})
+	}
+
+	return users, nil
+}
+
+func (us SqlUserStore) selectSearchResults(ctx context.Context, query sq.SelectBuilder, term string, searchType []string) ([]*model.User, *model.AppError) {
 	queryString, args, err := query.ToSql()
 	if err != nil {
 		return nil, model.NewAppError("SqlUserStore.Search", "store.sql_user.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
 	var users []*model.User
-	if _, err := us.GetReplica().Select(&users, queryString, args...); err != nil {
+	if err := selectContext(ctx, us.GetReplica(), &users, queryString, args...); err != nil {
 		return nil, model.NewAppError("SqlUserStore.Search", "store.sql_user.search.app_error", nil,
 			fmt.Sprintf("term=%v, search_type=%v, %v", term, searchType, err.Error()), http.StatusInternalServerError)
 	}
-	for _, u := range users {
-		u.Sanitize(map[string]bool{})
-	}
 
 	return users, nil
 }
@@ -22,6 +22,10 @@ func NewSqlUserTermsOfServiceStore(sqlStore SqlStore) store.UserTermsOfServiceSt
 		table := db.AddTableWithName(model.UserTermsOfService{}, "UserTermsOfService").SetKeys(false, "UserId")
 		table.ColMap("UserId").SetMaxSize(26)
 		table.ColMap("TermsOfServiceId").SetMaxSize(26)
+
+		acceptances := db.AddTableWithName(model.TermsOfServiceAcceptance{}, "TermsOfServiceAcceptances").SetKeys(false, "UserId", "TermsOfServiceId", "AcceptAt")
+		acceptances.ColMap("UserId").SetMaxSize(26)
+		acceptances.ColMap("TermsOfServiceId").SetMaxSize(26)
 	}
 
 	return s
@@ -29,6 +33,8 @@ func NewSqlUserTermsOfServiceStore(sqlStore SqlStore) store.UserTermsOfServiceSt
 
 func (s SqlUserTermsOfServiceStore) CreateIndexesIfNotExists() {
 	s.CreateIndexIfNotExists("idx_user_terms_of_service_user_id", "UserTermsOfService", "UserId")
+	s.CreateIndexIfNotExists("idx_terms_of_service_acceptances_terms_of_service_id_accept_at", "TermsOfServiceAcceptances", "TermsOfServiceId, AcceptAt")
+	s.CreateIndexIfNotExists("idx_terms_of_service_acceptances_accept_at", "TermsOfServiceAcceptances", "AcceptAt")
 }
 
 func (s SqlUserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOfService, *model.AppError) {
@@ -71,3 +77,34 @@ func (s SqlUserTermsOfServiceStore) Delete(userId, termsOfServiceId string) *mod
 	}
 	return nil
 }
+
+func (s SqlUserTermsOfServiceStore) SaveAcceptance(acceptance *model.TermsOfServiceAcceptance) (*model.TermsOfServiceAcceptance, *model.AppError) {
+	acceptance.PreSave()
+
+	if err := acceptance.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(acceptance); err != nil {
+		return nil, model.NewAppError("SqlUserTermsOfServiceStore.SaveAcceptance", "store.sql_user_terms_of_service.save_acceptance.app_error", nil, "user_id="+acceptance.UserId+", terms_of_service_id="+acceptance.TermsOfServiceId+", err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	return acceptance, nil
+}
+
+// GetAcceptances returns a page of the acceptances made after since, oldest first, of a version of the terms of
+// service or of all of them when termsOfServiceId is empty.
+func (s SqlUserTermsOfServiceStore) GetAcceptances(termsOfServiceId string, since int64, offset, limit int) ([]*model.TermsOfServiceAcceptance, *model.AppError) {
+	query := "SELECT * FROM TermsOfServiceAcceptances WHERE AcceptAt > :Since"
+	if termsOfServiceId != "" {
+		query += " AND TermsOfServiceId = :TermsOfServiceId"
+	}
+	query += " ORDER BY AcceptAt, UserId LIMIT :Limit OFFSET :Offset"
+
+	acceptances := []*model.TermsOfServiceAcceptance{}
+	if _, err := s.GetReplica().Select(&acceptances, query, map[string]interface{}{"Since": since, "TermsOfServiceId": termsOfServiceId, "Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlUserTermsOfServiceStore.GetAcceptances", "store.sql_user_terms_of_service.get_acceptances.app_error", nil, "terms_of_service_id="+termsOfServiceId+", err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	return acceptances, nil
+}
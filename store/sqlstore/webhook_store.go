@@ -48,6 +48,7 @@ func NewSqlWebhookStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface)
 		table.ColMap("TeamId").SetMaxSize(26)
 		table.ColMap("DisplayName").SetMaxSize(64)
 		table.ColMap("Description").SetMaxSize(500)
+		table.ColMap("PayloadTemplate").SetMaxSize(model.INCOMING_WEBHOOK_PAYLOAD_TEMPLATE_MAX_LENGTH)
 
 		tableo := db.AddTableWithName(model.OutgoingWebhook{}, "OutgoingWebhooks").SetKeys(false, "Id")
 		tableo.ColMap("Id").SetMaxSize(26)
@@ -63,6 +64,7 @@ func NewSqlWebhookStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface)
 		tableo.ColMap("TriggerWhen").SetMaxSize(1)
 		tableo.ColMap("Username").SetMaxSize(64)
 		tableo.ColMap("IconURL").SetMaxSize(1024)
+		tableo.ColMap("EventTypes").SetMaxSize(256)
 	}
 
 	return s
@@ -333,6 +335,33 @@ func (s SqlWebhookStore) GetOutgoingByChannelByUser(channelId string, userId str
 	return webhooks, nil
 }
 
+// GetOutgoingForChannel returns the outgoing webhooks of the team that listen to the given channel, including the
+// ones listening to every channel of the team.
+func (s SqlWebhookStore) GetOutgoingForChannel(teamId string, channelId string) ([]*model.OutgoingWebhook, *model.AppError) {
+	var webhooks []*model.OutgoingWebhook
+
+	query := s.getQueryBuilder().
+		Select("*").
+		From("OutgoingWebhooks").
+		Where(sq.And{
+			sq.Eq{"TeamId": teamId},
+			sq.Eq{"ChannelId": []string{channelId, ""}},
+			sq.Eq{"DeleteAt": int(0)},
+		}).
+		OrderBy("CreateAt")
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, model.NewAppError("SqlWebhookStore.GetOutgoingForChannel", "store.sql_webhooks.get_outgoing_for_channel.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, err := s.GetReplica().Select(&webhooks, queryString, args...); err != nil {
+		return nil, model.NewAppError("SqlWebhookStore.GetOutgoingForChannel", "store.sql_webhooks.get_outgoing_for_channel.app_error", nil, "teamId="+teamId+", channelId="+channelId+", err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	return webhooks, nil
+}
+
 func (s SqlWebhookStore) GetOutgoingByChannel(channelId string, offset, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
 	return s.GetOutgoingByChannelByUser(channelId, "", offset, limit)
 }
@@ -6,6 +6,8 @@
 package store
 
 import (
+	"context"
+
 	"github.com/mattermost/mattermost-server/model"
 )
 
@@ -46,6 +48,41 @@ type Store interface {
 	Group() GroupStore
 	UserTermsOfService() UserTermsOfServiceStore
 	LinkMetadata() LinkMetadataStore
+	Hashtag() HashtagStore
+	MentionAlias() MentionAliasStore
+	ChannelMemberExpiry() ChannelMemberExpiryStore
+	PostStar() PostStarStore
+	ChannelReadStat() ChannelReadStatStore
+	UserDeactivationSchedule() UserDeactivationScheduleStore
+	UserAttribute() UserAttributeStore
+	DailyStat() DailyStatStore
+	SchemaMigration() SchemaMigrationStore
+	ConfigAudit() ConfigAuditStore
+	LicenseUsage() LicenseUsageStore
+	PendingPost() PendingPostStore
+	ContentFilter() ContentFilterStore
+	KeywordRule() KeywordRuleStore
+	PostReport() PostReportStore
+	RecurringPost() RecurringPostStore
+	UserAvailability() UserAvailabilityStore
+	Call() CallStore
+	MessageExportConsumer() MessageExportConsumerStore
+	PostPurge() PostPurgeStore
+	PublicPostLink() PublicPostLinkStore
+	PendingEmoji() PendingEmojiStore
+	EmojiAlias() EmojiAliasStore
+	EphemeralPost() EphemeralPostStore
+	LoginAttempt() LoginAttemptStore
+	MfaRecoveryCode() MfaRecoveryCodeStore
+	IntegrationStat() IntegrationStatStore
+	Todo() TodoStore
+	Poll() PollStore
+	Reminder() ReminderStore
+	ChannelMembershipRule() ChannelMembershipRuleStore
+	ChannelTeamBinding() ChannelTeamBindingStore
+	Announcement() AnnouncementStore
+	Onboarding() OnboardingStore
+	PostPinEvent() PostPinEventStore
 	MarkSystemRanUnitTests()
 	Close()
 	LockToMaster()
@@ -55,6 +92,7 @@ type Store interface {
 	TotalMasterDbConnections() int
 	TotalReadDbConnections() int
 	TotalSearchDbConnections() int
+	RecentSlowQueries() []*model.SlowQuery
 	CheckIntegrity() <-chan IntegrityCheckResult
 }
 
@@ -140,6 +178,8 @@ type ChannelStore interface {
 	SaveMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError)
 	UpdateMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError)
 	GetMembers(channelId string, offset, limit int) (*model.ChannelMembers, *model.AppError)
+	GetMembersWithOptions(channelId string, offset, limit int, options *model.ChannelMembersGetOptions) (*model.ChannelMembers, *model.AppError)
+	GetMemberCountsByRole(channelId string) (*model.ChannelMemberCounts, *model.AppError)
 	GetMember(channelId string, userId string) (*model.ChannelMember, *model.AppError)
 	GetChannelMembersTimezones(channelId string) ([]model.StringMap, *model.AppError)
 	GetAllChannelMembersForUser(userId string, allowFromCache bool, includeDeleted bool) (map[string]string, *model.AppError)
@@ -163,6 +203,7 @@ type ChannelStore interface {
 	PermanentDeleteMembersByChannel(channelId string) *model.AppError
 	UpdateLastViewedAt(channelIds []string, userId string) (map[string]int64, *model.AppError)
 	IncrementMentionCount(channelId string, userId string) *model.AppError
+	IncrementMsgCount(channelId string, userId string) *model.AppError
 	AnalyticsTypeCount(teamId string, channelType string) (int64, *model.AppError)
 	GetMembersForUser(teamId string, userId string) (*model.ChannelMembers, *model.AppError)
 	GetMembersForUserWithPagination(teamId, userId string, page, perPage int) (*model.ChannelMembers, *model.AppError)
@@ -188,6 +229,8 @@ type ChannelStore interface {
 	RemoveAllDeactivatedMembers(channelId string) *model.AppError
 	GetChannelsBatchForIndexing(startTime, endTime int64, limit int) ([]*model.Channel, *model.AppError)
 	UserBelongsToChannels(userId string, channelIds []string) (bool, *model.AppError)
+	GetDirectMessagePartners(userId string, limit int) (map[string]int64, *model.AppError)
+	GetSharedChannelCounts(userId string, limit int) (map[string]int64, *model.AppError)
 }
 
 type ChannelMemberHistoryStore interface {
@@ -202,9 +245,15 @@ type PostStore interface {
 	Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError)
 	Get(id string) (*model.PostList, *model.AppError)
 	GetSingle(id string) (*model.Post, *model.AppError)
+	GetSingleFromMaster(id string) (*model.Post, *model.AppError)
 	Delete(postId string, time int64, deleteByID string) *model.AppError
 	PermanentDeleteByUser(userId string) *model.AppError
 	PermanentDeleteByChannel(channelId string) *model.AppError
+	GetForPurge(scope, targetId string, limit int) ([]*model.Post, *model.AppError)
+	GetByUserAfter(userId, afterId string, limit int) ([]*model.Post, *model.AppError)
+	GetForChannelAfter(channelId string, afterCreateAt int64, afterId string, endTime int64, limit int) ([]*model.Post, *model.AppError)
+	PermanentDeleteForPurge(postIds []string) *model.AppError
+	GetThreadParticipantCounts(userId string, since int64, limit int) (map[string]int64, *model.AppError)
 	GetPosts(channelId string, offset int, limit int, allowFromCache bool) (*model.PostList, *model.AppError)
 	GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, *model.AppError)
 	GetFlaggedPostsForTeam(userId, teamId string, offset int, limit int) (*model.PostList, *model.AppError)
@@ -216,7 +265,7 @@ type PostStore interface {
 	GetPostIdAfterTime(channelId string, time int64) (string, *model.AppError)
 	GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError)
 	GetEtag(channelId string, allowFromCache bool) string
-	Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError)
+	Search(ctx context.Context, teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError)
 	AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError)
 	AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, *model.AppError)
 	AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, *model.AppError)
@@ -224,9 +273,11 @@ type PostStore interface {
 	InvalidateLastPostTimeCache(channelId string)
 	GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, *model.AppError)
 	Overwrite(post *model.Post) (*model.Post, *model.AppError)
+	OverwriteProps(post *model.Post) *model.AppError
 	GetPostsByIds(postIds []string) ([]*model.Post, *model.AppError)
 	GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, *model.AppError)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError)
+	ArchiveBatch(before int64, limit int) (int64, *model.AppError)
 	GetOldest() (*model.Post, *model.AppError)
 	GetMaxPostSize() int
 	GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, *model.AppError)
@@ -272,16 +323,17 @@ type UserStore interface {
 	GetSystemAdminProfiles() (map[string]*model.User, *model.AppError)
 	PermanentDelete(userId string) *model.AppError
 	AnalyticsActiveCount(time int64, options model.UserCountOptions) (int64, *model.AppError)
+	AnalyticsGetMfaAdoption() (*model.MfaAdoption, *model.AppError)
 	GetUnreadCount(userId string) (int64, error)
 	GetUnreadCountForChannel(userId string, channelId string) (int64, *model.AppError)
 	GetAnyUnreadPostCountForChannel(userId string, channelId string) (int64, *model.AppError)
 	GetRecentlyActiveUsersForTeam(teamId string, offset, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
 	GetNewUsersForTeam(teamId string, offset, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
-	Search(teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
-	SearchNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
-	SearchInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
-	SearchNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
-	SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	Search(ctx context.Context, teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchNotInTeam(ctx context.Context, notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchInChannel(ctx context.Context, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchNotInChannel(ctx context.Context, teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchWithoutTeam(ctx context.Context, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
 	AnalyticsGetInactiveUsersCount() (int64, *model.AppError)
 	AnalyticsGetSystemAdminCount() (int64, *model.AppError)
 	GetProfilesNotInTeam(teamId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
@@ -300,6 +352,7 @@ type UserStore interface {
 type BotStore interface {
 	Get(userId string, includeDeleted bool) (*model.Bot, *model.AppError)
 	GetAll(options *model.BotGetOptions) ([]*model.Bot, *model.AppError)
+	GetAllForChannel(channelId string) ([]*model.Bot, *model.AppError)
 	Save(bot *model.Bot) (*model.Bot, *model.AppError)
 	Update(bot *model.Bot) (*model.Bot, *model.AppError)
 	PermanentDelete(userId string) *model.AppError
@@ -344,6 +397,7 @@ type ComplianceStore interface {
 	GetAll(offset, limit int) (model.Compliances, *model.AppError)
 	ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, *model.AppError)
 	MessageExport(after int64, limit int) ([]*model.MessageExport, *model.AppError)
+	MessageExportChanges(cursor model.MessageExportCursor, limit int) ([]*model.MessageExportChange, *model.AppError)
 }
 
 type OAuthStore interface {
@@ -363,6 +417,7 @@ type OAuthStore interface {
 	GetAccessData(token string) (*model.AccessData, *model.AppError)
 	GetAccessDataByUserForApp(userId, clientId string) ([]*model.AccessData, *model.AppError)
 	GetAccessDataByRefreshToken(token string) (*model.AccessData, *model.AppError)
+	GetAccessDataByPreviousRefreshToken(token string) (*model.AccessData, *model.AppError)
 	GetPreviousAccessData(userId, clientId string) (*model.AccessData, *model.AppError)
 	RemoveAccessData(token string) *model.AppError
 	RemoveAllAccessData() *model.AppError
@@ -375,6 +430,8 @@ type SystemStore interface {
 	Get() (model.StringMap, *model.AppError)
 	GetByName(name string) (*model.System, *model.AppError)
 	PermanentDeleteByName(name string) (*model.System, *model.AppError)
+	AcquireLease(lease *model.LeaderLease) (*model.LeaderLease, *model.AppError)
+	ReleaseLease(name string, holderId string) (bool, *model.AppError)
 }
 
 type WebhookStore interface {
@@ -394,6 +451,7 @@ type WebhookStore interface {
 	GetOutgoing(id string) (*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingByChannel(channelId string, offset, limit int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingByChannelByUser(channelId string, userId string, offset, limit int) ([]*model.OutgoingWebhook, *model.AppError)
+	GetOutgoingForChannel(teamId string, channelId string) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingList(offset, limit int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingListByUser(userId string, offset, limit int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingByTeam(teamId string, offset, limit int) ([]*model.OutgoingWebhook, *model.AppError)
@@ -431,6 +489,7 @@ type CommandWebhookStore interface {
 type PreferenceStore interface {
 	Save(preferences *model.Preferences) *model.AppError
 	GetCategory(userId string, category string) (model.Preferences, *model.AppError)
+	GetCategoryAndName(category string, name string) (model.Preferences, *model.AppError)
 	Get(userId string, category string, name string) (*model.Preference, *model.AppError)
 	GetAll(userId string) (model.Preferences, *model.AppError)
 	Delete(userId, category, name string) *model.AppError
@@ -438,6 +497,9 @@ type PreferenceStore interface {
 	DeleteCategoryAndName(category string, name string) *model.AppError
 	PermanentDeleteByUser(userId string) *model.AppError
 	CleanupFlagsBatch(limit int64) (int64, *model.AppError)
+	GetVersions(userId string) (map[string]int64, *model.AppError)
+	GetNamespaces(userId string, categories []string) (map[string]*model.PreferenceNamespace, *model.AppError)
+	Patch(userId string, patch model.PreferencesPatch) ([]*model.PreferenceNamespaceDiff, *model.AppError)
 }
 
 type LicenseStore interface {
@@ -461,6 +523,8 @@ type EmojiStore interface {
 	GetList(offset, limit int, sort string) ([]*model.Emoji, *model.AppError)
 	Delete(emoji *model.Emoji, time int64) *model.AppError
 	Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, *model.AppError)
+	CountByCreator(creatorId string) (int64, *model.AppError)
+	GetUsage(offset, limit int) ([]*model.EmojiUsage, *model.AppError)
 }
 
 type StatusStore interface {
@@ -477,9 +541,11 @@ type FileInfoStore interface {
 	Get(id string) (*model.FileInfo, *model.AppError)
 	GetByPath(path string) (*model.FileInfo, *model.AppError)
 	GetForPost(postId string, readFromMaster, includeDeleted, allowFromCache bool) ([]*model.FileInfo, *model.AppError)
+	GetForPosts(postIds []string, readFromMaster bool) (map[string][]*model.FileInfo, *model.AppError)
 	GetForUser(userId string) ([]*model.FileInfo, *model.AppError)
 	InvalidateFileInfosForPostCache(postId string)
 	AttachToPost(fileId string, postId string, creatorId string) *model.AppError
+	SetVerdict(fileId, verdict, reason string, verdictAt int64) *model.AppError
 	DeleteForPost(postId string) (string, *model.AppError)
 	PermanentDelete(fileId string) *model.AppError
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError)
@@ -501,6 +567,7 @@ type JobStore interface {
 	UpdateOptimistically(job *model.Job, currentStatus string) (bool, *model.AppError)
 	UpdateStatus(id string, status string) (*model.Job, *model.AppError)
 	UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, *model.AppError)
+	UpdateLastActivityAt(ids []string) *model.AppError
 	Get(id string) (*model.Job, *model.AppError)
 	GetAllPage(offset int, limit int) ([]*model.Job, *model.AppError)
 	GetAllByType(jobType string) ([]*model.Job, *model.AppError)
@@ -522,6 +589,8 @@ type UserAccessTokenStore interface {
 	Search(term string) ([]*model.UserAccessToken, *model.AppError)
 	UpdateTokenEnable(tokenId string) *model.AppError
 	UpdateTokenDisable(tokenId string) *model.AppError
+	UpdateLastUsedAt(tokenId string, lastUsedAt int64) *model.AppError
+	GetExpiring(from, to int64, offset, limit int) ([]*model.UserAccessToken, *model.AppError)
 }
 
 type PluginStore interface {
@@ -558,12 +627,16 @@ type TermsOfServiceStore interface {
 	Save(termsOfService *model.TermsOfService) (*model.TermsOfService, *model.AppError)
 	GetLatest(allowFromCache bool) (*model.TermsOfService, *model.AppError)
 	Get(id string, allowFromCache bool) (*model.TermsOfService, *model.AppError)
+	GetAll() ([]*model.TermsOfService, *model.AppError)
+	UpdateReacceptAt(id string, reacceptAt int64) *model.AppError
 }
 
 type UserTermsOfServiceStore interface {
 	GetByUser(userId string) (*model.UserTermsOfService, *model.AppError)
 	Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, *model.AppError)
 	Delete(userId, termsOfServiceId string) *model.AppError
+	SaveAcceptance(acceptance *model.TermsOfServiceAcceptance) (*model.TermsOfServiceAcceptance, *model.AppError)
+	GetAcceptances(termsOfServiceId string, since int64, offset, limit int) ([]*model.TermsOfServiceAcceptance, *model.AppError)
 }
 
 type GroupStore interface {
@@ -572,6 +645,7 @@ type GroupStore interface {
 	GetByIDs(groupIDs []string) ([]*model.Group, *model.AppError)
 	GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError)
 	GetAllBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError)
+	GetByUser(userId string) ([]*model.Group, *model.AppError)
 	Update(group *model.Group) (*model.Group, *model.AppError)
 	Delete(groupID string) (*model.Group, *model.AppError)
 
@@ -612,6 +686,197 @@ type LinkMetadataStore interface {
 	Get(url string, timestamp int64) (*model.LinkMetadata, *model.AppError)
 }
 
+type HashtagStore interface {
+	SaveForPost(postId string, hashtags []*model.PostHashtag) *model.AppError
+	DeleteForPost(postId string) *model.AppError
+	GetTrending(teamId string, since int64, limit int) ([]*model.HashtagCount, *model.AppError)
+	GetPostsForUser(userId, teamId, hashtag string, offset, limit int) (*model.PostList, *model.AppError)
+}
+
+type MentionAliasStore interface {
+	Save(alias *model.MentionAlias) (*model.MentionAlias, *model.AppError)
+	Update(alias *model.MentionAlias) (*model.MentionAlias, *model.AppError)
+	Get(id string) (*model.MentionAlias, *model.AppError)
+	GetByName(name string) ([]*model.MentionAlias, *model.AppError)
+	GetAll(offset, limit int) ([]*model.MentionAlias, *model.AppError)
+	GetForTeam(teamId string) ([]*model.MentionAlias, *model.AppError)
+	Delete(id string, time int64) *model.AppError
+}
+
+type ChannelMemberExpiryStore interface {
+	Save(expiry *model.ChannelMemberExpiry) (*model.ChannelMemberExpiry, *model.AppError)
+	Get(channelId, userId string) (*model.ChannelMemberExpiry, *model.AppError)
+	GetForChannel(channelId string) ([]*model.ChannelMemberExpiry, *model.AppError)
+	GetExpired(before int64, limit int) ([]*model.ChannelMemberExpiry, *model.AppError)
+	Delete(channelId, userId string) *model.AppError
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type PostStarStore interface {
+	Save(star *model.PostStar) (*model.PostStar, *model.AppError)
+	Get(postId, userId string) (*model.PostStar, *model.AppError)
+	Delete(postId, userId string) *model.AppError
+	GetCountForPost(postId string) (int64, *model.AppError)
+	GetCountsForPosts(postIds []string) ([]*model.PostStarCount, *model.AppError)
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type PendingPostStore interface {
+	Save(pendingPost *model.PendingPost) (*model.PendingPost, *model.AppError)
+	Get(id string) (*model.PendingPost, *model.AppError)
+	GetForChannel(channelId string, offset, limit int) ([]*model.PendingPost, *model.AppError)
+	GetCountForChannel(channelId string) (int64, *model.AppError)
+	Delete(id string) (bool, *model.AppError)
+	PermanentDeleteByUser(userId string) *model.AppError
+	PermanentDeleteByChannel(channelId string) *model.AppError
+}
+
+type ContentFilterStore interface {
+	Save(filter *model.ContentFilter) (*model.ContentFilter, *model.AppError)
+	Update(filter *model.ContentFilter) (*model.ContentFilter, *model.AppError)
+	Get(id string) (*model.ContentFilter, *model.AppError)
+	GetAll(offset, limit int) ([]*model.ContentFilter, *model.AppError)
+	GetForTeam(teamId string) ([]*model.ContentFilter, *model.AppError)
+	Delete(id string, time int64) *model.AppError
+}
+
+type KeywordRuleStore interface {
+	Save(rule *model.KeywordRule) (*model.KeywordRule, *model.AppError)
+	Update(rule *model.KeywordRule) (*model.KeywordRule, *model.AppError)
+	Get(id string) (*model.KeywordRule, *model.AppError)
+	GetAll(offset, limit int) ([]*model.KeywordRule, *model.AppError)
+	GetForTeam(teamId string) ([]*model.KeywordRule, *model.AppError)
+	Delete(id string, time int64) *model.AppError
+}
+
+type PostReportStore interface {
+	Save(report *model.PostReport) (*model.PostReport, *model.AppError)
+	Update(report *model.PostReport, previousState string) (*model.PostReport, *model.AppError)
+	Get(id string) (*model.PostReport, *model.AppError)
+	GetOpenForPostAndReporter(postId, reporterId string) (*model.PostReport, *model.AppError)
+	GetAll(state string, offset, limit int) ([]*model.PostReport, *model.AppError)
+	SaveEvent(event *model.PostReportEvent) (*model.PostReportEvent, *model.AppError)
+	GetEvents(reportId string) ([]*model.PostReportEvent, *model.AppError)
+}
+
+type RecurringPostStore interface {
+	Save(recurringPost *model.RecurringPost) (*model.RecurringPost, *model.AppError)
+	Update(recurringPost *model.RecurringPost) (*model.RecurringPost, *model.AppError)
+	Get(id string) (*model.RecurringPost, *model.AppError)
+	GetForChannel(channelId string) ([]*model.RecurringPost, *model.AppError)
+	GetDue(now int64, limit int) ([]*model.RecurringPost, *model.AppError)
+	Delete(id string) *model.AppError
+	PermanentDeleteByChannel(channelId string) *model.AppError
+}
+
+type UserAvailabilityStore interface {
+	Save(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError)
+	Update(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError)
+	Get(id string) (*model.UserAvailability, *model.AppError)
+	GetForUser(userId string, startAt, endAt int64) ([]*model.UserAvailability, *model.AppError)
+	GetForUsersAt(userIds []string, at int64) ([]*model.UserAvailability, *model.AppError)
+	Delete(id string) *model.AppError
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type CallStore interface {
+	Save(call *model.Call) (*model.Call, *model.AppError)
+	Update(call *model.Call) (*model.Call, *model.AppError)
+	Get(id string) (*model.Call, *model.AppError)
+	GetActiveForChannel(channelId string) ([]*model.Call, *model.AppError)
+	SaveParticipant(participant *model.CallParticipant) *model.AppError
+	GetParticipants(callId string) ([]*model.CallParticipant, *model.AppError)
+	PermanentDeleteByChannel(channelId string) *model.AppError
+}
+
+type MessageExportConsumerStore interface {
+	Save(consumer *model.MessageExportConsumer) (*model.MessageExportConsumer, *model.AppError)
+	Update(consumer *model.MessageExportConsumer) (*model.MessageExportConsumer, *model.AppError)
+	Get(id string) (*model.MessageExportConsumer, *model.AppError)
+	GetAll() ([]*model.MessageExportConsumer, *model.AppError)
+	Delete(id string) *model.AppError
+}
+
+type PostPurgeStore interface {
+	Save(purge *model.PostPurge) (*model.PostPurge, *model.AppError)
+	Update(purge *model.PostPurge) (*model.PostPurge, *model.AppError)
+	Get(id string) (*model.PostPurge, *model.AppError)
+	GetAll(offset, limit int) ([]*model.PostPurge, *model.AppError)
+	GetDue(before int64, limit int) ([]*model.PostPurge, *model.AppError)
+	Claim(id string) (bool, *model.AppError)
+	ResetStale(staleBefore int64) (int64, *model.AppError)
+}
+
+type PendingEmojiStore interface {
+	Save(emoji *model.PendingEmoji) (*model.PendingEmoji, *model.AppError)
+	Get(id string) (*model.PendingEmoji, *model.AppError)
+	GetByName(name string) (*model.PendingEmoji, *model.AppError)
+	GetList(offset, limit int) ([]*model.PendingEmoji, *model.AppError)
+	CountByCreator(creatorId string) (int64, *model.AppError)
+	Delete(id string) *model.AppError
+}
+
+type EmojiAliasStore interface {
+	Save(alias *model.EmojiAlias) (*model.EmojiAlias, *model.AppError)
+	Get(alias string) (*model.EmojiAlias, *model.AppError)
+	GetAll() ([]*model.EmojiAlias, *model.AppError)
+	Delete(alias string) *model.AppError
+	DeleteForEmoji(emojiName string) *model.AppError
+}
+
+type PublicPostLinkStore interface {
+	Save(link *model.PublicPostLink) (*model.PublicPostLink, *model.AppError)
+	Get(id string) (*model.PublicPostLink, *model.AppError)
+	GetForPost(postId string) ([]*model.PublicPostLink, *model.AppError)
+	Revoke(id string, time int64) *model.AppError
+}
+
+type ChannelReadStatStore interface {
+	Save(stat *model.ChannelReadStat) (*model.ChannelReadStat, *model.AppError)
+	Compute(since, until int64, minimumMembers int) ([]*model.ChannelReadStat, *model.AppError)
+	Get(teamId, channelId string, since, until int64, offset, limit int) ([]*model.ChannelReadStat, *model.AppError)
+	PermanentDeleteByChannel(channelId string) *model.AppError
+}
+
+type UserDeactivationScheduleStore interface {
+	Save(schedule *model.UserDeactivationSchedule) (*model.UserDeactivationSchedule, *model.AppError)
+	Get(userId string) (*model.UserDeactivationSchedule, *model.AppError)
+	GetAll(offset, limit int) ([]*model.UserDeactivationSchedule, *model.AppError)
+	GetDue(before int64, limit int) ([]*model.UserDeactivationSchedule, *model.AppError)
+	Delete(userId string) *model.AppError
+}
+
+type UserAttributeStore interface {
+	SaveField(field *model.UserAttributeField) (*model.UserAttributeField, *model.AppError)
+	UpdateField(field *model.UserAttributeField) (*model.UserAttributeField, *model.AppError)
+	GetField(id string) (*model.UserAttributeField, *model.AppError)
+	GetFields() ([]*model.UserAttributeField, *model.AppError)
+	DeleteField(id string, time int64) *model.AppError
+	SaveValue(value *model.UserAttributeValue) (*model.UserAttributeValue, *model.AppError)
+	DeleteValue(userId, fieldId string) *model.AppError
+	GetValuesForUsers(userIds []string) ([]*model.UserAttributeValue, *model.AppError)
+	PermanentDeleteValuesByUser(userId string) *model.AppError
+}
+
+type DailyStatStore interface {
+	Save(stat *model.DailyStat) (*model.DailyStat, *model.AppError)
+	Compute(date string, since, until int64) ([]*model.DailyStat, *model.AppError)
+	Get(teamId, channelId string, since, until string) ([]*model.DailyStat, *model.AppError)
+	PermanentDeleteByChannel(channelId string) *model.AppError
+	PermanentDeleteByTeam(teamId string) *model.AppError
+}
+
+type LicenseUsageStore interface {
+	Save(usage *model.LicenseUsage) (*model.LicenseUsage, *model.AppError)
+	Get(date string) (*model.LicenseUsage, *model.AppError)
+	GetRange(since, until string) ([]*model.LicenseUsage, *model.AppError)
+}
+
+type SchemaMigrationStore interface {
+	GetAll() ([]*model.SchemaMigration, *model.AppError)
+	RunBackfillBatch(version int, batchSize int) (bool, *model.AppError)
+}
+
 // ChannelSearchOpts contains options for searching channels.
 //
 // NotAssociatedToGroup will exclude channels that have associated, active GroupChannels records.
@@ -652,3 +917,126 @@ type IntegrityCheckResult struct {
 	Data interface{}
 	Err  error
 }
+
+type ConfigAuditStore interface {
+	Save(audit *model.ConfigAudit) (*model.ConfigAudit, *model.AppError)
+	Get(id string) (*model.ConfigAudit, *model.AppError)
+	GetAll(offset, limit int) ([]*model.ConfigAudit, *model.AppError)
+}
+
+type EphemeralPostStore interface {
+	Save(ephemeralPost *model.EphemeralPost) (*model.EphemeralPost, *model.AppError)
+	Update(ephemeralPost *model.EphemeralPost) (*model.EphemeralPost, *model.AppError)
+	GetForUser(userId string, since int64, limit int) ([]*model.EphemeralPost, *model.AppError)
+	Delete(userId, id string) *model.AppError
+	DeleteExpired(now int64, limit int) (int64, *model.AppError)
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type LoginAttemptStore interface {
+	Save(attempt *model.LoginAttempt) (*model.LoginAttempt, *model.AppError)
+	GetForUser(userId string, offset, limit int) ([]*model.LoginAttempt, *model.AppError)
+	GetSuccessfulForUser(userId string, limit int) ([]*model.LoginAttempt, *model.AppError)
+	GetLatestFailure(userId string) (*model.LoginAttempt, *model.AppError)
+	PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError)
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type MfaRecoveryCodeStore interface {
+	SaveForUser(userId string, codes []*model.MfaRecoveryCode) *model.AppError
+	Use(userId, codeHash string) (bool, *model.AppError)
+	CountUnused(userId string) (int64, *model.AppError)
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type IntegrationStatStore interface {
+	Increment(stat *model.IntegrationStat) *model.AppError
+	GetTotals(integrationType string, since, until string) ([]*model.IntegrationStat, *model.AppError)
+	GetDaily(integrationType, integrationId string, since, until string) ([]*model.IntegrationStat, *model.AppError)
+	PermanentDeleteBefore(date string) (int64, *model.AppError)
+}
+
+type TodoStore interface {
+	Save(todo *model.Todo) (*model.Todo, *model.AppError)
+	Update(todo *model.Todo) (*model.Todo, *model.AppError)
+	Get(id string) (*model.Todo, *model.AppError)
+	GetForUser(userId string, includeCompleted bool) ([]*model.Todo, *model.AppError)
+	Delete(id string) *model.AppError
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type PollStore interface {
+	Save(poll *model.Poll) (*model.Poll, *model.AppError)
+	Update(poll *model.Poll) (*model.Poll, *model.AppError)
+	Get(id string) (*model.Poll, *model.AppError)
+	SaveVote(vote *model.PollVote) (*model.PollVote, *model.AppError)
+	GetVotes(pollId string) ([]*model.PollVote, *model.AppError)
+	PermanentDeleteByChannel(channelId string) *model.AppError
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type ReminderStore interface {
+	Save(reminder *model.Reminder) (*model.Reminder, *model.AppError)
+	Get(id string) (*model.Reminder, *model.AppError)
+	GetPendingForUser(userId string) ([]*model.Reminder, *model.AppError)
+	GetDue(now int64, limit int) ([]*model.Reminder, *model.AppError)
+	MarkSent(id string, sentAt int64) (bool, *model.AppError)
+	Delete(id string) *model.AppError
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type ChannelMembershipRuleStore interface {
+	Save(rule *model.ChannelMembershipRule) (*model.ChannelMembershipRule, *model.AppError)
+	Get(id string) (*model.ChannelMembershipRule, *model.AppError)
+	GetForChannel(channelId string) ([]*model.ChannelMembershipRule, *model.AppError)
+	GetChannelIds() ([]string, *model.AppError)
+	Delete(id string) *model.AppError
+	SaveOverride(override *model.ChannelMembershipOverride) (*model.ChannelMembershipOverride, *model.AppError)
+	GetOverride(channelId, userId string) (*model.ChannelMembershipOverride, *model.AppError)
+	GetOverridesForChannel(channelId string) ([]*model.ChannelMembershipOverride, *model.AppError)
+	DeleteOverride(channelId, userId string) *model.AppError
+	PermanentDeleteByChannel(channelId string) *model.AppError
+	PermanentDeleteOverridesByUser(userId string) *model.AppError
+}
+
+type ChannelTeamBindingStore interface {
+	Save(binding *model.ChannelTeamBinding) (*model.ChannelTeamBinding, *model.AppError)
+	Get(channelId, teamId string) (*model.ChannelTeamBinding, *model.AppError)
+	GetForChannel(channelId string) ([]*model.ChannelTeamBinding, *model.AppError)
+	GetChannelByName(teamId, name string, includeDeleted bool) (*model.Channel, *model.AppError)
+	Delete(channelId, teamId string) *model.AppError
+	PermanentDeleteByChannel(channelId string) *model.AppError
+	PermanentDeleteByTeam(teamId string) *model.AppError
+}
+
+type AnnouncementStore interface {
+	Save(announcement *model.Announcement) (*model.Announcement, *model.AppError)
+	Get(id string) (*model.Announcement, *model.AppError)
+	GetAll(offset, limit int) ([]*model.Announcement, *model.AppError)
+	Delete(id string, deleteAt int64) *model.AppError
+	SaveRecipients(recipients []*model.AnnouncementRecipient) *model.AppError
+	UpdateRecipientDelivery(announcementId, userId, status, postId string, deliverAt int64) *model.AppError
+	Acknowledge(announcementId, userId string, acknowledgeAt int64) *model.AppError
+	GetRecipient(announcementId, userId string) (*model.AnnouncementRecipient, *model.AppError)
+	GetRecipients(announcementId string, offset, limit int) ([]*model.AnnouncementRecipient, *model.AppError)
+	GetReport(announcementId string) (*model.AnnouncementReport, *model.AppError)
+	GetPendingForUser(userId string, now int64) ([]*model.Announcement, *model.AppError)
+	PermanentDeleteRecipientsByUser(userId string) *model.AppError
+}
+
+type OnboardingStore interface {
+	SaveStep(step *model.OnboardingStep) (*model.OnboardingStep, *model.AppError)
+	UpdateStep(step *model.OnboardingStep) (*model.OnboardingStep, *model.AppError)
+	GetStep(id string) (*model.OnboardingStep, *model.AppError)
+	GetSteps() ([]*model.OnboardingStep, *model.AppError)
+	DeleteStep(id string, deleteAt int64) *model.AppError
+	SaveCompletion(completion *model.OnboardingStepCompletion) (*model.OnboardingStepCompletion, *model.AppError)
+	GetCompletionsForUser(userId string) ([]*model.OnboardingStepCompletion, *model.AppError)
+	PermanentDeleteCompletionsByUser(userId string) *model.AppError
+}
+
+type PostPinEventStore interface {
+	Save(event *model.PostPinEvent) (*model.PostPinEvent, *model.AppError)
+	GetForChannel(channelId string, startTime, endTime int64) ([]*model.PostPinEvent, *model.AppError)
+	PermanentDeleteByChannel(channelId string) *model.AppError
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnouncementStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testAnnouncementStoreSaveGetDelete(t, ss) })
+	t.Run("Recipients", func(t *testing.T) { testAnnouncementStoreRecipients(t, ss) })
+	t.Run("GetPendingForUser", func(t *testing.T) { testAnnouncementStoreGetPendingForUser(t, ss) })
+}
+
+func newTestAnnouncement() *model.Announcement {
+	return &model.Announcement{
+		CreatorId:  model.NewId(),
+		Title:      "Maintenance",
+		Message:    "The server will be down tonight.",
+		ShowBanner: true,
+	}
+}
+
+func testAnnouncementStoreSaveGetDelete(t *testing.T, ss store.Store) {
+	announcement, err := ss.Announcement().Save(newTestAnnouncement())
+	require.Nil(t, err)
+	assert.NotEmpty(t, announcement.Id)
+	assert.NotZero(t, announcement.CreateAt)
+
+	_, err = ss.Announcement().Save(announcement)
+	require.NotNil(t, err, "shouldn't be able to save an announcement twice")
+
+	_, err = ss.Announcement().Save(&model.Announcement{CreatorId: model.NewId(), Title: "No delivery", Message: "message"})
+	require.NotNil(t, err, "shouldn't be able to save an invalid announcement")
+
+	got, err := ss.Announcement().Get(announcement.Id)
+	require.Nil(t, err)
+	assert.Equal(t, announcement, got)
+
+	announcements, err := ss.Announcement().GetAll(0, 1000)
+	require.Nil(t, err)
+	assert.Contains(t, announcements, announcement)
+
+	require.Nil(t, ss.Announcement().Delete(announcement.Id, model.GetMillis()))
+
+	got, err = ss.Announcement().Get(announcement.Id)
+	require.Nil(t, err)
+	assert.NotZero(t, got.DeleteAt)
+
+	announcements, err = ss.Announcement().GetAll(0, 1000)
+	require.Nil(t, err)
+	for _, a := range announcements {
+		assert.NotEqual(t, announcement.Id, a.Id, "deleted announcements shouldn't be listed")
+	}
+
+	_, err = ss.Announcement().Get(model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testAnnouncementStoreRecipients(t *testing.T, ss store.Store) {
+	announcement := newTestAnnouncement()
+	announcement.SendDirectMessage = true
+	announcement, err := ss.Announcement().Save(announcement)
+	require.Nil(t, err)
+
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+	userId3 := model.NewId()
+	require.Nil(t, ss.Announcement().SaveRecipients([]*model.AnnouncementRecipient{
+		{AnnouncementId: announcement.Id, UserId: userId1, Status: model.ANNOUNCEMENT_RECIPIENT_STATUS_PENDING},
+		{AnnouncementId: announcement.Id, UserId: userId2, Status: model.ANNOUNCEMENT_RECIPIENT_STATUS_PENDING},
+		{AnnouncementId: announcement.Id, UserId: userId3, Status: model.ANNOUNCEMENT_RECIPIENT_STATUS_PENDING},
+	}))
+
+	postId := model.NewId()
+	require.Nil(t, ss.Announcement().UpdateRecipientDelivery(announcement.Id, userId1, model.ANNOUNCEMENT_RECIPIENT_STATUS_DELIVERED, postId, 100))
+	require.Nil(t, ss.Announcement().UpdateRecipientDelivery(announcement.Id, userId2, model.ANNOUNCEMENT_RECIPIENT_STATUS_FAILED, "", 100))
+
+	require.Nil(t, ss.Announcement().Acknowledge(announcement.Id, userId1, 200))
+	require.Nil(t, ss.Announcement().Acknowledge(announcement.Id, userId1, 300))
+
+	recipient, err := ss.Announcement().GetRecipient(announcement.Id, userId1)
+	require.Nil(t, err)
+	assert.Equal(t, model.ANNOUNCEMENT_RECIPIENT_STATUS_DELIVERED, recipient.Status)
+	assert.Equal(t, postId, recipient.PostId)
+	assert.Equal(t, int64(100), recipient.DeliverAt)
+	assert.Equal(t, int64(200), recipient.AcknowledgeAt, "only the first acknowledgment should be kept")
+
+	_, err = ss.Announcement().GetRecipient(announcement.Id, model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	recipients, err := ss.Announcement().GetRecipients(announcement.Id, 0, 2)
+	require.Nil(t, err)
+	assert.Len(t, recipients, 2)
+
+	report, err := ss.Announcement().GetReport(announcement.Id)
+	require.Nil(t, err)
+	assert.Equal(t, &model.AnnouncementReport{
+		AnnouncementId: announcement.Id,
+		Total:          3,
+		Pending:        1,
+		Delivered:      1,
+		Failed:         1,
+		Acknowledged:   1,
+	}, report)
+
+	require.Nil(t, ss.Announcement().PermanentDeleteRecipientsByUser(userId3))
+
+	report, err = ss.Announcement().GetReport(announcement.Id)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), report.Total)
+	assert.Equal(t, int64(0), report.Pending)
+}
+
+func testAnnouncementStoreGetPendingForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	banner, err := ss.Announcement().Save(newTestAnnouncement())
+	require.Nil(t, err)
+
+	acknowledged, err := ss.Announcement().Save(newTestAnnouncement())
+	require.Nil(t, err)
+
+	directMessageOnly := newTestAnnouncement()
+	directMessageOnly.ShowBanner = false
+	directMessageOnly.SendDirectMessage = true
+	directMessageOnly, err = ss.Announcement().Save(directMessageOnly)
+	require.Nil(t, err)
+
+	expired := newTestAnnouncement()
+	expired.ExpireAt = model.GetMillis() + 1000
+	expired, err = ss.Announcement().Save(expired)
+	require.Nil(t, err)
+
+	deleted, err := ss.Announcement().Save(newTestAnnouncement())
+	require.Nil(t, err)
+	require.Nil(t, ss.Announcement().Delete(deleted.Id, model.GetMillis()))
+
+	var recipients []*model.AnnouncementRecipient
+	for _, announcement := range []*model.Announcement{banner, acknowledged, directMessageOnly, expired, deleted} {
+		recipients = append(recipients, &model.AnnouncementRecipient{AnnouncementId: announcement.Id, UserId: userId, Status: model.ANNOUNCEMENT_RECIPIENT_STATUS_DELIVERED})
+	}
+	require.Nil(t, ss.Announcement().SaveRecipients(recipients))
+	require.Nil(t, ss.Announcement().Acknowledge(acknowledged.Id, userId, model.GetMillis()))
+
+	announcements, err := ss.Announcement().GetPendingForUser(userId, model.GetMillis())
+	require.Nil(t, err)
+	require.Len(t, announcements, 2)
+	assert.ElementsMatch(t, []string{banner.Id, expired.Id}, []string{announcements[0].Id, announcements[1].Id})
+
+	announcements, err = ss.Announcement().GetPendingForUser(userId, expired.ExpireAt)
+	require.Nil(t, err)
+	require.Len(t, announcements, 1)
+	assert.Equal(t, banner.Id, announcements[0].Id)
+
+	announcements, err = ss.Announcement().GetPendingForUser(model.NewId(), model.GetMillis())
+	require.Nil(t, err)
+	assert.Empty(t, announcements)
+}
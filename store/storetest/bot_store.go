@@ -27,6 +27,7 @@ func makeBotWithUser(t *testing.T, ss store.Store, bot *model.Bot) (*model.Bot,
 func TestBotStore(t *testing.T, ss store.Store) {
 	t.Run("Get", func(t *testing.T) { testBotStoreGet(t, ss) })
 	t.Run("GetAll", func(t *testing.T) { testBotStoreGetAll(t, ss) })
+	t.Run("GetAllForChannel", func(t *testing.T) { testBotStoreGetAllForChannel(t, ss) })
 	t.Run("Save", func(t *testing.T) { testBotStoreSave(t, ss) })
 	t.Run("Update", func(t *testing.T) { testBotStoreUpdate(t, ss) })
 	t.Run("PermanentDelete", func(t *testing.T) { testBotStorePermanentDelete(t, ss) })
@@ -291,6 +292,65 @@ func testBotStoreGetAll(t *testing.T, ss store.Store) {
 	})
 }
 
+func testBotStoreGetAllForChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	b1, _ := makeBotWithUser(t, ss, &model.Bot{
+		Username: "channel_b1",
+		OwnerId:  model.NewId(),
+	})
+	defer func() { require.Nil(t, ss.Bot().PermanentDelete(b1.UserId)) }()
+	defer func() { require.Nil(t, ss.User().PermanentDelete(b1.UserId)) }()
+
+	b2, _ := makeBotWithUser(t, ss, &model.Bot{
+		Username: "channel_b2",
+		OwnerId:  model.NewId(),
+	})
+	defer func() { require.Nil(t, ss.Bot().PermanentDelete(b2.UserId)) }()
+	defer func() { require.Nil(t, ss.User().PermanentDelete(b2.UserId)) }()
+
+	deletedBot, _ := makeBotWithUser(t, ss, &model.Bot{
+		Username: "channel_deleted_bot",
+		OwnerId:  model.NewId(),
+	})
+	deletedBot.DeleteAt = 1
+	deletedBot, err := ss.Bot().Update(deletedBot)
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.Bot().PermanentDelete(deletedBot.UserId)) }()
+	defer func() { require.Nil(t, ss.User().PermanentDelete(deletedBot.UserId)) }()
+
+	u1, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u" + model.NewId(),
+	})
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u1.Id)) }()
+
+	for _, userId := range []string{b1.UserId, deletedBot.UserId, u1.Id} {
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channelId,
+			UserId:      userId,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.Nil(t, err)
+	}
+	defer func() { require.Nil(t, ss.Channel().PermanentDeleteMembersByChannel(channelId)) }()
+
+	t.Run("only active bots in the channel", func(t *testing.T) {
+		bots, err := ss.Bot().GetAllForChannel(channelId)
+		require.Nil(t, err)
+		require.Len(t, bots, 1)
+		require.Equal(t, b1.UserId, bots[0].UserId)
+		require.Equal(t, "channel_b1", bots[0].Username)
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		bots, err := ss.Bot().GetAllForChannel(model.NewId())
+		require.Nil(t, err)
+		require.Empty(t, bots)
+	})
+}
+
 func testBotStoreSave(t *testing.T, ss store.Store) {
 	t.Run("invalid bot", func(t *testing.T) {
 		bot := &model.Bot{
@@ -0,0 +1,101 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdate", func(t *testing.T) { testCallStoreSaveGetUpdate(t, ss) })
+	t.Run("GetActiveForChannel", func(t *testing.T) { testCallStoreGetActiveForChannel(t, ss) })
+	t.Run("Participants", func(t *testing.T) { testCallStoreParticipants(t, ss) })
+}
+
+func newTestCall(channelId string) *model.Call {
+	return &model.Call{
+		ChannelId: channelId,
+		CreatorId: model.NewId(),
+		Provider:  "com.example.video",
+		Title:     "Standup",
+		JoinUrl:   "https://video.example.com/standup",
+	}
+}
+
+func testCallStoreSaveGetUpdate(t *testing.T, ss store.Store) {
+	call, err := ss.Call().Save(newTestCall(model.NewId()))
+	require.Nil(t, err)
+	assert.Len(t, call.Id, 26)
+	assert.NotZero(t, call.StartAt)
+
+	_, err = ss.Call().Save(call)
+	require.NotNil(t, err)
+
+	got, err := ss.Call().Get(call.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "Standup", got.Title)
+
+	got.EndAt = got.StartAt + 1000
+	_, err = ss.Call().Update(got)
+	require.Nil(t, err)
+
+	got, err = ss.Call().Get(call.Id)
+	require.Nil(t, err)
+	assert.True(t, got.IsEnded())
+
+	_, err = ss.Call().Get(model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testCallStoreGetActiveForChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	c1, err := ss.Call().Save(newTestCall(channelId))
+	require.Nil(t, err)
+	c2, err := ss.Call().Save(newTestCall(channelId))
+	require.Nil(t, err)
+	_, err = ss.Call().Save(newTestCall(model.NewId()))
+	require.Nil(t, err)
+
+	c2.EndAt = c2.StartAt + 1000
+	_, err = ss.Call().Update(c2)
+	require.Nil(t, err)
+
+	calls, err := ss.Call().GetActiveForChannel(channelId)
+	require.Nil(t, err)
+	require.Len(t, calls, 1)
+	assert.Equal(t, c1.Id, calls[0].Id)
+
+	require.Nil(t, ss.Call().PermanentDeleteByChannel(channelId))
+
+	_, err = ss.Call().Get(c1.Id)
+	require.NotNil(t, err)
+}
+
+func testCallStoreParticipants(t *testing.T, ss store.Store) {
+	call, err := ss.Call().Save(newTestCall(model.NewId()))
+	require.Nil(t, err)
+
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+
+	require.Nil(t, ss.Call().SaveParticipant(&model.CallParticipant{CallId: call.Id, UserId: userId1, JoinAt: 1000}))
+	require.Nil(t, ss.Call().SaveParticipant(&model.CallParticipant{CallId: call.Id, UserId: userId2, JoinAt: 2000}))
+	require.Nil(t, ss.Call().SaveParticipant(&model.CallParticipant{CallId: call.Id, UserId: userId1, JoinAt: 1000, LeaveAt: 3000}))
+
+	participants, err := ss.Call().GetParticipants(call.Id)
+	require.Nil(t, err)
+	require.Len(t, participants, 2)
+	assert.Equal(t, userId1, participants[0].UserId)
+	assert.Equal(t, int64(3000), participants[0].LeaveAt)
+	assert.Equal(t, userId2, participants[1].UserId)
+	assert.Zero(t, participants[1].LeaveAt)
+}
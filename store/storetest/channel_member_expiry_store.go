@@ -0,0 +1,125 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelMemberExpiryStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testChannelMemberExpiryStoreSaveGetDelete(t, ss) })
+	t.Run("GetForChannel", func(t *testing.T) { testChannelMemberExpiryStoreGetForChannel(t, ss) })
+	t.Run("GetExpired", func(t *testing.T) { testChannelMemberExpiryStoreGetExpired(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testChannelMemberExpiryStorePermanentDeleteByUser(t, ss) })
+}
+
+func testChannelMemberExpiryStoreSaveGetDelete(t *testing.T, ss store.Store) {
+	expiry := &model.ChannelMemberExpiry{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		CreatorId: model.NewId(),
+		ExpiresAt: model.GetMillis() + 60000,
+	}
+
+	saved, err := ss.ChannelMemberExpiry().Save(expiry)
+	require.Nil(t, err)
+	assert.NotZero(t, saved.CreateAt)
+
+	got, err := ss.ChannelMemberExpiry().Get(expiry.ChannelId, expiry.UserId)
+	require.Nil(t, err)
+	assert.Equal(t, expiry.ExpiresAt, got.ExpiresAt)
+	assert.Equal(t, expiry.CreatorId, got.CreatorId)
+
+	// Saving again extends the existing expiry.
+	extended := &model.ChannelMemberExpiry{
+		ChannelId: expiry.ChannelId,
+		UserId:    expiry.UserId,
+		CreatorId: model.NewId(),
+		CreateAt:  got.CreateAt,
+		ExpiresAt: expiry.ExpiresAt + 60000,
+	}
+	_, err = ss.ChannelMemberExpiry().Save(extended)
+	require.Nil(t, err)
+
+	got, err = ss.ChannelMemberExpiry().Get(expiry.ChannelId, expiry.UserId)
+	require.Nil(t, err)
+	assert.Equal(t, extended.ExpiresAt, got.ExpiresAt)
+	assert.Equal(t, extended.CreatorId, got.CreatorId)
+
+	_, err = ss.ChannelMemberExpiry().Save(&model.ChannelMemberExpiry{ChannelId: expiry.ChannelId, UserId: expiry.UserId})
+	require.NotNil(t, err)
+
+	require.Nil(t, ss.ChannelMemberExpiry().Delete(expiry.ChannelId, expiry.UserId))
+
+	_, err = ss.ChannelMemberExpiry().Get(expiry.ChannelId, expiry.UserId)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	// Deleting an expiry that doesn't exist isn't an error.
+	require.Nil(t, ss.ChannelMemberExpiry().Delete(expiry.ChannelId, expiry.UserId))
+}
+
+func testChannelMemberExpiryStoreGetForChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	now := model.GetMillis()
+
+	later, err := ss.ChannelMemberExpiry().Save(&model.ChannelMemberExpiry{ChannelId: channelId, UserId: model.NewId(), ExpiresAt: now + 120000})
+	require.Nil(t, err)
+	sooner, err := ss.ChannelMemberExpiry().Save(&model.ChannelMemberExpiry{ChannelId: channelId, UserId: model.NewId(), ExpiresAt: now + 60000})
+	require.Nil(t, err)
+	_, err = ss.ChannelMemberExpiry().Save(&model.ChannelMemberExpiry{ChannelId: model.NewId(), UserId: sooner.UserId, ExpiresAt: now + 60000})
+	require.Nil(t, err)
+
+	expiries, err := ss.ChannelMemberExpiry().GetForChannel(channelId)
+	require.Nil(t, err)
+	require.Len(t, expiries, 2)
+	assert.Equal(t, sooner.UserId, expiries[0].UserId)
+	assert.Equal(t, later.UserId, expiries[1].UserId)
+}
+
+func testChannelMemberExpiryStoreGetExpired(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	expired, err := ss.ChannelMemberExpiry().Save(&model.ChannelMemberExpiry{ChannelId: model.NewId(), UserId: model.NewId(), ExpiresAt: now - 1000})
+	require.Nil(t, err)
+	notExpired, err := ss.ChannelMemberExpiry().Save(&model.ChannelMemberExpiry{ChannelId: model.NewId(), UserId: model.NewId(), ExpiresAt: now + 60000})
+	require.Nil(t, err)
+
+	expiries, err := ss.ChannelMemberExpiry().GetExpired(now, 1000)
+	require.Nil(t, err)
+
+	var found, foundNotExpired bool
+	for _, expiry := range expiries {
+		if expiry.ChannelId == expired.ChannelId && expiry.UserId == expired.UserId {
+			found = true
+		}
+		if expiry.ChannelId == notExpired.ChannelId {
+			foundNotExpired = true
+		}
+	}
+	assert.True(t, found)
+	assert.False(t, foundNotExpired)
+}
+
+func testChannelMemberExpiryStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	first, err := ss.ChannelMemberExpiry().Save(&model.ChannelMemberExpiry{ChannelId: model.NewId(), UserId: userId, ExpiresAt: model.GetMillis() + 60000})
+	require.Nil(t, err)
+	second, err := ss.ChannelMemberExpiry().Save(&model.ChannelMemberExpiry{ChannelId: model.NewId(), UserId: userId, ExpiresAt: model.GetMillis() + 60000})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.ChannelMemberExpiry().PermanentDeleteByUser(userId))
+
+	_, err = ss.ChannelMemberExpiry().Get(first.ChannelId, userId)
+	assert.NotNil(t, err)
+	_, err = ss.ChannelMemberExpiry().Get(second.ChannelId, userId)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelMembershipRuleStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testChannelMembershipRuleStoreSaveGetDelete(t, ss) })
+	t.Run("GetForChannel", func(t *testing.T) { testChannelMembershipRuleStoreGetForChannel(t, ss) })
+	t.Run("Overrides", func(t *testing.T) { testChannelMembershipRuleStoreOverrides(t, ss) })
+	t.Run("PermanentDeleteByChannel", func(t *testing.T) { testChannelMembershipRuleStorePermanentDeleteByChannel(t, ss) })
+	t.Run("PermanentDeleteOverridesByUser", func(t *testing.T) { testChannelMembershipRuleStorePermanentDeleteOverridesByUser(t, ss) })
+}
+
+func testChannelMembershipRuleStoreSaveGetDelete(t *testing.T, ss store.Store) {
+	rule := &model.ChannelMembershipRule{
+		ChannelId: model.NewId(),
+		CreatorId: model.NewId(),
+		Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_POSITION,
+		Value:     "Engineer",
+	}
+
+	saved, err := ss.ChannelMembershipRule().Save(rule)
+	require.Nil(t, err)
+	assert.Len(t, saved.Id, 26)
+	assert.NotZero(t, saved.CreateAt)
+
+	_, err = ss.ChannelMembershipRule().Save(rule)
+	require.NotNil(t, err, "shouldn't be able to save an existing rule")
+
+	got, err := ss.ChannelMembershipRule().Get(rule.Id)
+	require.Nil(t, err)
+	assert.Equal(t, rule, got)
+
+	_, err = ss.ChannelMembershipRule().Save(&model.ChannelMembershipRule{ChannelId: model.NewId(), Attribute: "password", Value: "secret"})
+	require.NotNil(t, err)
+
+	require.Nil(t, ss.ChannelMembershipRule().Delete(rule.Id))
+
+	_, err = ss.ChannelMembershipRule().Get(rule.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testChannelMembershipRuleStoreGetForChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	rule1, err := ss.ChannelMembershipRule().Save(&model.ChannelMembershipRule{ChannelId: channelId, Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE, Value: "fr", CreateAt: 1000})
+	require.Nil(t, err)
+	rule2, err := ss.ChannelMembershipRule().Save(&model.ChannelMembershipRule{ChannelId: channelId, Attribute: "attributes.department", Value: "Sales", CreateAt: 2000})
+	require.Nil(t, err)
+	_, err = ss.ChannelMembershipRule().Save(&model.ChannelMembershipRule{ChannelId: model.NewId(), Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE, Value: "fr"})
+	require.Nil(t, err)
+
+	rules, err := ss.ChannelMembershipRule().GetForChannel(channelId)
+	require.Nil(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, rule1.Id, rules[0].Id)
+	assert.Equal(t, rule2.Id, rules[1].Id)
+
+	channelIds, err := ss.ChannelMembershipRule().GetChannelIds()
+	require.Nil(t, err)
+	assert.Contains(t, channelIds, channelId)
+
+	rules, err = ss.ChannelMembershipRule().GetForChannel(model.NewId())
+	require.Nil(t, err)
+	assert.Empty(t, rules)
+}
+
+func testChannelMembershipRuleStoreOverrides(t *testing.T, ss store.Store) {
+	override := &model.ChannelMembershipOverride{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Type:      model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE,
+		CreatorId: model.NewId(),
+	}
+
+	_, err := ss.ChannelMembershipRule().SaveOverride(override)
+	require.Nil(t, err)
+
+	got, err := ss.ChannelMembershipRule().GetOverride(override.ChannelId, override.UserId)
+	require.Nil(t, err)
+	assert.Equal(t, model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE, got.Type)
+
+	// Saving again replaces the existing override.
+	_, err = ss.ChannelMembershipRule().SaveOverride(&model.ChannelMembershipOverride{
+		ChannelId: override.ChannelId,
+		UserId:    override.UserId,
+		Type:      model.CHANNEL_MEMBERSHIP_OVERRIDE_EXCLUDE,
+	})
+	require.Nil(t, err)
+
+	overrides, err := ss.ChannelMembershipRule().GetOverridesForChannel(override.ChannelId)
+	require.Nil(t, err)
+	require.Len(t, overrides, 1)
+	assert.Equal(t, model.CHANNEL_MEMBERSHIP_OVERRIDE_EXCLUDE, overrides[0].Type)
+
+	_, err = ss.ChannelMembershipRule().SaveOverride(&model.ChannelMembershipOverride{ChannelId: override.ChannelId, UserId: override.UserId, Type: "maybe"})
+	require.NotNil(t, err)
+
+	require.Nil(t, ss.ChannelMembershipRule().DeleteOverride(override.ChannelId, override.UserId))
+
+	_, err = ss.ChannelMembershipRule().GetOverride(override.ChannelId, override.UserId)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testChannelMembershipRuleStorePermanentDeleteByChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	otherChannelId := model.NewId()
+
+	_, err := ss.ChannelMembershipRule().Save(&model.ChannelMembershipRule{ChannelId: channelId, Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE, Value: "fr"})
+	require.Nil(t, err)
+	_, err = ss.ChannelMembershipRule().Save(&model.ChannelMembershipRule{ChannelId: otherChannelId, Attribute: model.CHANNEL_MEMBERSHIP_RULE_ATTRIBUTE_LOCALE, Value: "fr"})
+	require.Nil(t, err)
+	_, err = ss.ChannelMembershipRule().SaveOverride(&model.ChannelMembershipOverride{ChannelId: channelId, UserId: model.NewId(), Type: model.CHANNEL_MEMBERSHIP_OVERRIDE_EXCLUDE})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.ChannelMembershipRule().PermanentDeleteByChannel(channelId))
+
+	rules, err := ss.ChannelMembershipRule().GetForChannel(channelId)
+	require.Nil(t, err)
+	assert.Empty(t, rules)
+
+	overrides, err := ss.ChannelMembershipRule().GetOverridesForChannel(channelId)
+	require.Nil(t, err)
+	assert.Empty(t, overrides)
+
+	rules, err = ss.ChannelMembershipRule().GetForChannel(otherChannelId)
+	require.Nil(t, err)
+	assert.Len(t, rules, 1)
+}
+
+func testChannelMembershipRuleStorePermanentDeleteOverridesByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	channelId := model.NewId()
+
+	_, err := ss.ChannelMembershipRule().SaveOverride(&model.ChannelMembershipOverride{ChannelId: channelId, UserId: userId, Type: model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE})
+	require.Nil(t, err)
+	_, err = ss.ChannelMembershipRule().SaveOverride(&model.ChannelMembershipOverride{ChannelId: channelId, UserId: model.NewId(), Type: model.CHANNEL_MEMBERSHIP_OVERRIDE_INCLUDE})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.ChannelMembershipRule().PermanentDeleteOverridesByUser(userId))
+
+	overrides, err := ss.ChannelMembershipRule().GetOverridesForChannel(channelId)
+	require.Nil(t, err)
+	require.Len(t, overrides, 1)
+	assert.NotEqual(t, userId, overrides[0].UserId)
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelReadStatStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testChannelReadStatStoreSaveGet(t, ss) })
+	t.Run("Compute", func(t *testing.T) { testChannelReadStatStoreCompute(t, ss) })
+	t.Run("PermanentDeleteByChannel", func(t *testing.T) { testChannelReadStatStorePermanentDeleteByChannel(t, ss) })
+}
+
+func testChannelReadStatStoreSaveGet(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	channelId := model.NewId()
+	now := model.GetMillis()
+
+	stat1, err := ss.ChannelReadStat().Save(&model.ChannelReadStat{PostId: model.NewId(), ChannelId: channelId, TeamId: teamId, PostCreateAt: now - 2000, MemberCount: 10, ReadCount: 5})
+	require.Nil(t, err)
+	assert.NotZero(t, stat1.CreateAt)
+
+	stat2, err := ss.ChannelReadStat().Save(&model.ChannelReadStat{PostId: model.NewId(), ChannelId: model.NewId(), TeamId: teamId, PostCreateAt: now - 1000, MemberCount: 10, ReadCount: 7})
+	require.Nil(t, err)
+
+	_, err = ss.ChannelReadStat().Save(&model.ChannelReadStat{PostId: model.NewId(), ChannelId: channelId, TeamId: teamId, PostCreateAt: now - 1000, MemberCount: 1, ReadCount: 2})
+	require.NotNil(t, err)
+
+	stats, err := ss.ChannelReadStat().Get(teamId, "", 0, now, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, stat2.PostId, stats[0].PostId)
+	assert.Equal(t, stat1.PostId, stats[1].PostId)
+
+	stats, err = ss.ChannelReadStat().Get("", channelId, 0, now, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, stat1.PostId, stats[0].PostId)
+
+	stats, err = ss.ChannelReadStat().Get(teamId, "", now-1500, now, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, stat2.PostId, stats[0].PostId)
+
+	stats, err = ss.ChannelReadStat().Get(teamId, "", 0, now, 1, 10)
+	require.Nil(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, stat1.PostId, stats[0].PostId)
+
+	// Saving the stat of a post again replaces it.
+	stat1.ReadCount = 9
+	_, err = ss.ChannelReadStat().Save(stat1)
+	require.Nil(t, err)
+
+	stats, err = ss.ChannelReadStat().Get("", channelId, 0, now, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(9), stats[0].ReadCount)
+}
+
+func testChannelReadStatStoreCompute(t *testing.T, ss store.Store) {
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Announcements",
+		Name:        "zz" + model.NewId(),
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	authorId := model.NewId()
+	postCreateAt := model.GetMillis() - 60*60*1000
+
+	post, err := ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: authorId, Message: "announcement", CreateAt: postCreateAt})
+	require.Nil(t, err)
+
+	_, err = ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: authorId, Message: "reply", RootId: post.Id, ParentId: post.Id, CreateAt: postCreateAt + 1})
+	require.Nil(t, err)
+
+	_, err = ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: authorId, Type: model.POST_JOIN_CHANNEL, CreateAt: postCreateAt + 2})
+	require.Nil(t, err)
+
+	for i, lastViewedAt := range []int64{postCreateAt + 100, postCreateAt - 100, postCreateAt + 200, 0} {
+		userId := model.NewId()
+		if i == 0 {
+			userId = authorId
+		}
+
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:    channel.Id,
+			UserId:       userId,
+			NotifyProps:  model.GetDefaultChannelNotifyProps(),
+			LastViewedAt: lastViewedAt,
+		})
+		require.Nil(t, err)
+	}
+
+	stats, err := ss.ChannelReadStat().Compute(postCreateAt, postCreateAt+1000, 2)
+	require.Nil(t, err)
+
+	var found []*model.ChannelReadStat
+	for _, stat := range stats {
+		if stat.ChannelId == channel.Id {
+			found = append(found, stat)
+		}
+	}
+
+	// Only the root post is measured, and its author doesn't count as a member reading it.
+	require.Len(t, found, 1)
+	assert.Equal(t, post.Id, found[0].PostId)
+	assert.Equal(t, channel.TeamId, found[0].TeamId)
+	assert.Equal(t, postCreateAt, found[0].PostCreateAt)
+	assert.Equal(t, int64(3), found[0].MemberCount)
+	assert.Equal(t, int64(1), found[0].ReadCount)
+
+	// Channels that are too small aren't measured so that reads can't be attributed to individuals.
+	stats, err = ss.ChannelReadStat().Compute(postCreateAt, postCreateAt+1000, 4)
+	require.Nil(t, err)
+	for _, stat := range stats {
+		assert.NotEqual(t, channel.Id, stat.ChannelId)
+	}
+
+	stats, err = ss.ChannelReadStat().Compute(postCreateAt+1, postCreateAt+1000, 2)
+	require.Nil(t, err)
+	for _, stat := range stats {
+		assert.NotEqual(t, channel.Id, stat.ChannelId)
+	}
+}
+
+func testChannelReadStatStorePermanentDeleteByChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	now := model.GetMillis()
+
+	_, err := ss.ChannelReadStat().Save(&model.ChannelReadStat{PostId: model.NewId(), ChannelId: channelId, PostCreateAt: now, MemberCount: 3, ReadCount: 1})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.ChannelReadStat().PermanentDeleteByChannel(channelId))
+
+	stats, err := ss.ChannelReadStat().Get("", channelId, 0, now+1, 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, stats)
+}
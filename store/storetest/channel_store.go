@@ -61,10 +61,13 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetMembersForUserWithPagination", func(t *testing.T) { testChannelStoreGetMembersForUserWithPagination(t, ss) })
 	t.Run("UpdateLastViewedAt", func(t *testing.T) { testChannelStoreUpdateLastViewedAt(t, ss) })
 	t.Run("IncrementMentionCount", func(t *testing.T) { testChannelStoreIncrementMentionCount(t, ss) })
+	t.Run("IncrementMsgCount", func(t *testing.T) { testChannelStoreIncrementMsgCount(t, ss) })
 	t.Run("UpdateChannelMember", func(t *testing.T) { testUpdateChannelMember(t, ss) })
 	t.Run("GetMember", func(t *testing.T) { testGetMember(t, ss) })
 	t.Run("GetMemberForPost", func(t *testing.T) { testChannelStoreGetMemberForPost(t, ss) })
 	t.Run("GetMemberCount", func(t *testing.T) { testGetMemberCount(t, ss) })
+	t.Run("GetMembersWithOptions", func(t *testing.T) { testChannelStoreGetMembersWithOptions(t, ss) })
+	t.Run("GetMemberCountsByRole", func(t *testing.T) { testChannelStoreGetMemberCountsByRole(t, ss) })
 	t.Run("GetGuestCount", func(t *testing.T) { testGetGuestCount(t, ss) })
 	t.Run("SearchMore", func(t *testing.T) { testChannelStoreSearchMore(t, ss) })
 	t.Run("SearchInTeam", func(t *testing.T) { testChannelStoreSearchInTeam(t, ss) })
@@ -89,6 +92,8 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("ExportAllDirectChannelsExcludePrivateAndPublic", func(t *testing.T) { testChannelStoreExportAllDirectChannelsExcludePrivateAndPublic(t, ss, s) })
 	t.Run("ExportAllDirectChannelsDeletedChannel", func(t *testing.T) { testChannelStoreExportAllDirectChannelsDeletedChannel(t, ss, s) })
 	t.Run("GetChannelsBatchForIndexing", func(t *testing.T) { testChannelStoreGetChannelsBatchForIndexing(t, ss) })
+	t.Run("GetDirectMessagePartners", func(t *testing.T) { testChannelStoreGetDirectMessagePartners(t, ss) })
+	t.Run("GetSharedChannelCounts", func(t *testing.T) { testChannelStoreGetSharedChannelCounts(t, ss) })
 }
 
 func testChannelStoreSave(t *testing.T, ss store.Store) {
@@ -1852,6 +1857,37 @@ func testChannelStoreIncrementMentionCount(t *testing.T, ss store.Store) {
 	}
 }
 
+func testChannelStoreIncrementMsgCount(t *testing.T, ss store.Store) {
+	o1 := model.Channel{}
+	o1.TeamId = model.NewId()
+	o1.DisplayName = "Channel1"
+	o1.Name = "zz" + model.NewId() + "b"
+	o1.Type = model.CHANNEL_OPEN
+	o1.TotalMsgCount = 25
+	_, err := ss.Channel().Save(&o1, -1)
+	require.Nil(t, err)
+
+	m1 := model.ChannelMember{}
+	m1.ChannelId = o1.Id
+	m1.UserId = model.NewId()
+	m1.NotifyProps = model.GetDefaultChannelNotifyProps()
+	m1.MsgCount = 10
+	m1.LastViewedAt = 1000
+	_, err = ss.Channel().SaveMember(&m1)
+	require.Nil(t, err)
+
+	err = ss.Channel().IncrementMsgCount(m1.ChannelId, m1.UserId)
+	require.Nil(t, err)
+
+	member, err := ss.Channel().GetMember(m1.ChannelId, m1.UserId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(11), member.MsgCount)
+	assert.Equal(t, int64(1000), member.LastViewedAt)
+
+	err = ss.Channel().IncrementMsgCount("missing id", "missing id")
+	require.Nil(t, err)
+}
+
 func testUpdateChannelMember(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 
@@ -4275,3 +4311,186 @@ func testChannelStoreGetChannelsBatchForIndexing(t *testing.T, ss store.Store) {
 	assert.Nil(t, err)
 	assert.ElementsMatch(t, []*model.Channel{c2, c3}, channels)
 }
+
+func testChannelStoreGetDirectMessagePartners(t *testing.T, ss store.Store) {
+	u1, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+	u2, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+	u3, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+	u4, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+
+	dm2, err := ss.Channel().CreateDirectChannel(u1, u2)
+	require.Nil(t, err)
+	dm3, err := ss.Channel().CreateDirectChannel(u1, u3)
+	require.Nil(t, err)
+
+	// A direct channel without messages isn't an interaction.
+	_, err = ss.Channel().CreateDirectChannel(u1, u4)
+	require.Nil(t, err)
+
+	// Nor is a direct channel with themselves.
+	self, err := ss.Channel().CreateDirectChannel(u1, u1)
+	require.Nil(t, err)
+
+	for _, channelId := range []string{dm2.Id, dm3.Id, self.Id} {
+		_, err = ss.Post().Save(&model.Post{ChannelId: channelId, UserId: u1.Id, Message: "hello"})
+		require.Nil(t, err)
+	}
+
+	partners, err := ss.Channel().GetDirectMessagePartners(u1.Id, 100)
+	require.Nil(t, err)
+	require.Len(t, partners, 2)
+	assert.NotZero(t, partners[u2.Id])
+	assert.NotZero(t, partners[u3.Id])
+
+	partners, err = ss.Channel().GetDirectMessagePartners(u1.Id, 1)
+	require.Nil(t, err)
+	assert.Len(t, partners, 1)
+
+	partners, err = ss.Channel().GetDirectMessagePartners(u2.Id, 100)
+	require.Nil(t, err)
+	require.Len(t, partners, 1)
+	assert.NotZero(t, partners[u1.Id])
+}
+
+func testChannelStoreGetSharedChannelCounts(t *testing.T, ss store.Store) {
+	u1, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+	u2, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+	u3, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+
+	saveChannel := func(channelType string, deleteAt int64, userIds ...string) {
+		channel, err := ss.Channel().Save(&model.Channel{TeamId: model.NewId(), DisplayName: "Shared", Name: "zz" + model.NewId() + "b", Type: channelType, DeleteAt: deleteAt}, -1)
+		require.Nil(t, err)
+
+		for _, userId := range userIds {
+			_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: userId, NotifyProps: model.GetDefaultChannelNotifyProps()})
+			require.Nil(t, err)
+		}
+	}
+
+	saveChannel(model.CHANNEL_PRIVATE, 0, u1.Id, u2.Id, u3.Id)
+	saveChannel(model.CHANNEL_PRIVATE, 0, u1.Id, u2.Id)
+
+	// Public and archived channels aren't counted
+	saveChannel(model.CHANNEL_OPEN, 0, u1.Id, u3.Id)
+	saveChannel(model.CHANNEL_PRIVATE, model.GetMillis(), u1.Id, u3.Id)
+
+	counts, err := ss.Channel().GetSharedChannelCounts(u1.Id, 100)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]int64{u2.Id: 2, u3.Id: 1}, counts)
+
+	counts, err = ss.Channel().GetSharedChannelCounts(u1.Id, 1)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]int64{u2.Id: 2}, counts)
+
+	counts, err = ss.Channel().GetSharedChannelCounts(u3.Id, 100)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]int64{u1.Id: 1, u2.Id: 1}, counts)
+}
+
+// saveChannelMembersWithRoles saves a channel with an admin, a user, a guest and a deactivated user as members, and
+// returns the channel followed by the users in that order.
+func saveChannelMembersWithRoles(t *testing.T, ss store.Store) (*model.Channel, []*model.User) {
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Channel",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	id := model.NewId()
+	users := []*model.User{
+		{Email: MakeEmail(), Username: "c" + id},
+		{Email: MakeEmail(), Username: "a" + id},
+		{Email: MakeEmail(), Username: "b" + id},
+		{Email: MakeEmail(), Username: "d" + id, DeleteAt: model.GetMillis()},
+	}
+	for i, user := range users {
+		_, err = ss.User().Save(user)
+		require.Nil(t, err)
+
+		member := &model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      user.Id,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+			SchemeUser:  i != 2,
+			SchemeAdmin: i == 0,
+			SchemeGuest: i == 2,
+		}
+		_, err = ss.Channel().SaveMember(member)
+		require.Nil(t, err)
+	}
+
+	return channel, users
+}
+
+func testChannelStoreGetMembersWithOptions(t *testing.T, ss store.Store) {
+	channel, users := saveChannelMembersWithRoles(t, ss)
+	admin, user, guest := users[0], users[1], users[2]
+
+	memberIds := func(members *model.ChannelMembers) []string {
+		ids := []string{}
+		for _, member := range *members {
+			ids = append(ids, member.UserId)
+		}
+		return ids
+	}
+
+	t.Run("by username", func(t *testing.T) {
+		members, err := ss.Channel().GetMembersWithOptions(channel.Id, 0, 10, &model.ChannelMembersGetOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, []string{user.Id, guest.Id, admin.Id}, memberIds(members))
+
+		members, err = ss.Channel().GetMembersWithOptions(channel.Id, 1, 1, &model.ChannelMembersGetOptions{Sort: model.CHANNEL_MEMBERS_SORT_USERNAME})
+		require.Nil(t, err)
+		assert.Equal(t, []string{guest.Id}, memberIds(members))
+	})
+
+	t.Run("by role", func(t *testing.T) {
+		members, err := ss.Channel().GetMembersWithOptions(channel.Id, 0, 10, &model.ChannelMembersGetOptions{Sort: model.CHANNEL_MEMBERS_SORT_ROLE})
+		require.Nil(t, err)
+		assert.Equal(t, []string{admin.Id, user.Id, guest.Id}, memberIds(members))
+	})
+
+	t.Run("by last post", func(t *testing.T) {
+		_, err := ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: admin.Id, Message: "first", CreateAt: 1000})
+		require.Nil(t, err)
+		_, err = ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: guest.Id, Message: "second", CreateAt: 2000})
+		require.Nil(t, err)
+
+		members, err := ss.Channel().GetMembersWithOptions(channel.Id, 0, 10, &model.ChannelMembersGetOptions{Sort: model.CHANNEL_MEMBERS_SORT_LAST_POST})
+		require.Nil(t, err)
+		assert.Equal(t, []string{guest.Id, admin.Id, user.Id}, memberIds(members))
+	})
+
+	t.Run("filtered by role", func(t *testing.T) {
+		for role, expected := range map[string][]string{
+			model.CHANNEL_MEMBER_ROLE_FILTER_ADMIN: {admin.Id},
+			model.CHANNEL_MEMBER_ROLE_FILTER_USER:  {user.Id},
+			model.CHANNEL_MEMBER_ROLE_FILTER_GUEST: {guest.Id},
+		} {
+			members, err := ss.Channel().GetMembersWithOptions(channel.Id, 0, 10, &model.ChannelMembersGetOptions{Role: role})
+			require.Nil(t, err)
+			assert.Equal(t, expected, memberIds(members), role)
+		}
+	})
+}
+
+func testChannelStoreGetMemberCountsByRole(t *testing.T, ss store.Store) {
+	channel, _ := saveChannelMembersWithRoles(t, ss)
+
+	counts, err := ss.Channel().GetMemberCountsByRole(channel.Id)
+	require.Nil(t, err)
+	assert.Equal(t, &model.ChannelMemberCounts{ChannelId: channel.Id, Total: 3, Admins: 1, Users: 1, Guests: 1}, counts)
+
+	counts, err = ss.Channel().GetMemberCountsByRole(model.NewId())
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), counts.Total)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelTeamBindingStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testChannelTeamBindingStoreSaveGetDelete(t, ss) })
+	t.Run("GetChannelByName", func(t *testing.T) { testChannelTeamBindingStoreGetChannelByName(t, ss) })
+	t.Run("BoundChannelsInTeam", func(t *testing.T) { testChannelTeamBindingStoreBoundChannelsInTeam(t, ss) })
+	t.Run("PermanentDelete", func(t *testing.T) { testChannelTeamBindingStorePermanentDelete(t, ss) })
+}
+
+func testChannelTeamBindingStoreSaveGetDelete(t *testing.T, ss store.Store) {
+	binding := &model.ChannelTeamBinding{ChannelId: model.NewId(), TeamId: model.NewId(), CreatorId: model.NewId()}
+
+	_, err := ss.ChannelTeamBinding().Save(binding)
+	require.Nil(t, err)
+	assert.NotZero(t, binding.CreateAt)
+
+	_, err = ss.ChannelTeamBinding().Save(&model.ChannelTeamBinding{ChannelId: binding.ChannelId, TeamId: binding.TeamId})
+	require.NotNil(t, err, "shouldn't be able to bind a channel to a team twice")
+	assert.Equal(t, "store.sql_channel_team_binding.save.exists.app_error", err.Id)
+
+	got, err := ss.ChannelTeamBinding().Get(binding.ChannelId, binding.TeamId)
+	require.Nil(t, err)
+	assert.Equal(t, binding, got)
+
+	bindings, err := ss.ChannelTeamBinding().GetForChannel(binding.ChannelId)
+	require.Nil(t, err)
+	require.Len(t, bindings, 1)
+
+	require.Nil(t, ss.ChannelTeamBinding().Delete(binding.ChannelId, binding.TeamId))
+
+	_, err = ss.ChannelTeamBinding().Get(binding.ChannelId, binding.TeamId)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testChannelTeamBindingStoreGetChannelByName(t *testing.T, ss store.Store) {
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Shared",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	teamId := model.NewId()
+	_, err = ss.ChannelTeamBinding().Save(&model.ChannelTeamBinding{ChannelId: channel.Id, TeamId: teamId})
+	require.Nil(t, err)
+
+	got, err := ss.ChannelTeamBinding().GetChannelByName(teamId, channel.Name, false)
+	require.Nil(t, err)
+	assert.Equal(t, channel.Id, got.Id)
+
+	_, err = ss.ChannelTeamBinding().GetChannelByName(model.NewId(), channel.Name, false)
+	require.NotNil(t, err)
+	assert.Equal(t, "store.sql_channel.get_by_name.missing.app_error", err.Id)
+}
+
+func testChannelTeamBindingStoreBoundChannelsInTeam(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Shared",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	_, err = ss.ChannelTeamBinding().Save(&model.ChannelTeamBinding{ChannelId: channel.Id, TeamId: teamId})
+	require.Nil(t, err)
+
+	moreChannels, err := ss.Channel().GetMoreChannels(teamId, userId, 0, 100)
+	require.Nil(t, err)
+	require.Len(t, *moreChannels, 1)
+	assert.Equal(t, channel.Id, (*moreChannels)[0].Id)
+
+	_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: userId, NotifyProps: model.GetDefaultChannelNotifyProps()})
+	require.Nil(t, err)
+
+	channels, err := ss.Channel().GetChannels(teamId, userId, false)
+	require.Nil(t, err)
+	require.Len(t, *channels, 1)
+	assert.Equal(t, channel.Id, (*channels)[0].Id)
+
+	moreChannels, err = ss.Channel().GetMoreChannels(teamId, userId, 0, 100)
+	require.Nil(t, err)
+	assert.Empty(t, *moreChannels)
+
+	unreads, err := ss.Team().GetChannelUnreadsForTeam(teamId, userId)
+	require.Nil(t, err)
+	require.Len(t, unreads, 1)
+	assert.Equal(t, channel.Id, unreads[0].ChannelId)
+
+	unreads, err = ss.Team().GetChannelUnreadsForAllTeams(teamId, userId)
+	require.Nil(t, err)
+	assert.Empty(t, unreads, "the channel is already counted in the bound team")
+}
+
+func testChannelTeamBindingStorePermanentDelete(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	teamId := model.NewId()
+
+	_, err := ss.ChannelTeamBinding().Save(&model.ChannelTeamBinding{ChannelId: channelId, TeamId: teamId})
+	require.Nil(t, err)
+	_, err = ss.ChannelTeamBinding().Save(&model.ChannelTeamBinding{ChannelId: channelId, TeamId: model.NewId()})
+	require.Nil(t, err)
+	_, err = ss.ChannelTeamBinding().Save(&model.ChannelTeamBinding{ChannelId: model.NewId(), TeamId: teamId})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.ChannelTeamBinding().PermanentDeleteByTeam(teamId))
+
+	bindings, err := ss.ChannelTeamBinding().GetForChannel(channelId)
+	require.Nil(t, err)
+	require.Len(t, bindings, 1)
+	assert.NotEqual(t, teamId, bindings[0].TeamId)
+
+	require.Nil(t, ss.ChannelTeamBinding().PermanentDeleteByChannel(channelId))
+
+	bindings, err = ss.ChannelTeamBinding().GetForChannel(channelId)
+	require.Nil(t, err)
+	assert.Empty(t, bindings)
+}
@@ -21,6 +21,7 @@ func TestComplianceStore(t *testing.T, ss store.Store) {
 	t.Run("MessageExportPrivateChannel", func(t *testing.T) { testMessageExportPrivateChannel(t, ss) })
 	t.Run("MessageExportDirectMessageChannel", func(t *testing.T) { testMessageExportDirectMessageChannel(t, ss) })
 	t.Run("MessageExportGroupMessageChannel", func(t *testing.T) { testMessageExportGroupMessageChannel(t, ss) })
+	t.Run("MessageExportChanges", func(t *testing.T) { testMessageExportChanges(t, ss) })
 }
 
 func testComplianceStore(t *testing.T, ss store.Store) {
@@ -637,3 +638,98 @@ func testMessageExportGroupMessageChannel(t *testing.T, ss store.Store) {
 	assert.Equal(t, user1.Email, *messageExportMap[post.Id].UserEmail)
 	assert.Equal(t, user1.Username, *messageExportMap[post.Id].Username)
 }
+
+func testMessageExportChanges(t *testing.T, ss store.Store) {
+	startTime := model.GetMillis()
+
+	user := &model.User{
+		Email:    MakeEmail(),
+		Username: model.NewId(),
+	}
+	user, err := ss.User().Save(user)
+	require.Nil(t, err)
+
+	channel := &model.Channel{
+		TeamId:      model.NewId(),
+		Name:        model.NewId(),
+		DisplayName: "Exported Channel",
+		Type:        model.CHANNEL_OPEN,
+	}
+	channel, err = ss.Channel().Save(channel, -1)
+	require.Nil(t, err)
+
+	var posts []*model.Post
+	for i := 0; i < 3; i++ {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channel.Id,
+			UserId:    user.Id,
+			CreateAt:  startTime + int64(i),
+			Message:   "zz" + model.NewId(),
+		})
+		require.Nil(t, err)
+		posts = append(posts, post)
+	}
+
+	_, err = ss.Post().Save(&model.Post{
+		ChannelId: channel.Id,
+		UserId:    user.Id,
+		CreateAt:  startTime + 3,
+		Type:      model.POST_JOIN_CHANNEL,
+		Message:   "joined",
+	})
+	require.Nil(t, err)
+
+	// Edit the first post, which keeps a copy of it as it was, and delete the second
+	edited := posts[0].Clone()
+	edited.Message = "edited"
+	edited.EditAt = startTime + 10
+	original := posts[0].Clone()
+	original.Id = model.NewId()
+	original.OriginalId = posts[0].Id
+	original.DeleteAt = startTime + 10
+	_, err = ss.Post().Overwrite(edited)
+	require.Nil(t, err)
+	_, err = ss.Post().Save(original)
+	require.Nil(t, err)
+
+	err = ss.Post().Delete(posts[1].Id, startTime+20, user.Id)
+	require.Nil(t, err)
+
+	// Page through the stream two changes at a time, keeping those of the channel
+	var changes []*model.MessageExportChange
+	cursor := model.MessageExportCursor{UpdateAt: startTime - 1}
+	for {
+		page, err := ss.Compliance().MessageExportChanges(cursor, 2)
+		require.Nil(t, err)
+		if len(page) == 0 {
+			break
+		}
+
+		for _, change := range page {
+			if change.ChannelId == channel.Id {
+				changes = append(changes, change)
+			}
+		}
+
+		last := page[len(page)-1]
+		cursor = model.MessageExportCursor{UpdateAt: last.UpdateAt, PostId: last.PostId}
+	}
+
+	require.Len(t, changes, 3)
+	changesByPostId := map[string]*model.MessageExportChange{}
+	for _, change := range changes {
+		changesByPostId[change.PostId] = change
+	}
+
+	require.NotNil(t, changesByPostId[posts[0].Id])
+	assert.Equal(t, model.MESSAGE_EXPORT_CHANGE_EDITED, changesByPostId[posts[0].Id].ChangeType)
+	assert.Equal(t, "edited", changesByPostId[posts[0].Id].Message)
+
+	require.NotNil(t, changesByPostId[posts[1].Id])
+	assert.Equal(t, model.MESSAGE_EXPORT_CHANGE_DELETED, changesByPostId[posts[1].Id].ChangeType)
+
+	require.NotNil(t, changesByPostId[posts[2].Id])
+	assert.Equal(t, model.MESSAGE_EXPORT_CHANGE_CREATED, changesByPostId[posts[2].Id].ChangeType)
+	assert.Equal(t, user.Username, changesByPostId[posts[2].Id].Username)
+	assert.Equal(t, channel.DisplayName, changesByPostId[posts[2].Id].ChannelDisplayName)
+}
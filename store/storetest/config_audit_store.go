@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigAuditStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testConfigAuditStoreSaveGet(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testConfigAuditStoreGetAll(t, ss) })
+}
+
+func testConfigAuditStoreSaveGet(t *testing.T, ss store.Store) {
+	audit := &model.ConfigAudit{
+		UserId:  model.NewId(),
+		Changes: []*model.ConfigChange{{Path: "TeamSettings.SiteName", OldValue: "Mattermost", NewValue: "Changed"}},
+		Config:  "{}",
+	}
+
+	saved, err := ss.ConfigAudit().Save(audit)
+	require.Nil(t, err)
+	require.NotEmpty(t, saved.Id)
+
+	_, err = ss.ConfigAudit().Save(&model.ConfigAudit{UserId: model.NewId()})
+	require.NotNil(t, err)
+
+	loaded, err := ss.ConfigAudit().Get(saved.Id)
+	require.Nil(t, err)
+	assert.Equal(t, audit.UserId, loaded.UserId)
+	assert.Equal(t, audit.Config, loaded.Config)
+	require.Len(t, loaded.Changes, 1)
+	assert.Equal(t, "TeamSettings.SiteName", loaded.Changes[0].Path)
+	assert.Equal(t, "Changed", loaded.Changes[0].NewValue)
+
+	_, err = ss.ConfigAudit().Get(model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testConfigAuditStoreGetAll(t *testing.T, ss store.Store) {
+	// Created in the future so as to come before the audits saved by other tests.
+	now := model.GetMillis() + 1000000
+
+	audit1, err := ss.ConfigAudit().Save(&model.ConfigAudit{CreateAt: now, Config: "{}"})
+	require.Nil(t, err)
+
+	audit2, err := ss.ConfigAudit().Save(&model.ConfigAudit{CreateAt: now + 1, RollbackOf: audit1.Id, Config: "{}"})
+	require.Nil(t, err)
+
+	audits, err := ss.ConfigAudit().GetAll(0, 2)
+	require.Nil(t, err)
+	require.Len(t, audits, 2)
+	assert.Equal(t, audit2.Id, audits[0].Id)
+	assert.Equal(t, audit1.Id, audits[0].RollbackOf)
+	assert.Equal(t, audit1.Id, audits[1].Id)
+
+	audits, err = ss.ConfigAudit().GetAll(1, 1)
+	require.Nil(t, err)
+	require.Len(t, audits, 1)
+	assert.Equal(t, audit1.Id, audits[0].Id)
+}
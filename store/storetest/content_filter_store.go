@@ -0,0 +1,94 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentFilterStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testContentFilterStoreSaveGetUpdateDelete(t, ss) })
+	t.Run("GetForTeam", func(t *testing.T) { testContentFilterStoreGetForTeam(t, ss) })
+}
+
+func testContentFilterStoreSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	filter := &model.ContentFilter{
+		CreatorId: model.NewId(),
+		TeamId:    model.NewId(),
+		Name:      "profanity",
+		Action:    model.CONTENT_FILTER_ACTION_MASK,
+		Words:     model.StringArray{"darn", "heck"},
+	}
+
+	saved, err := ss.ContentFilter().Save(filter)
+	require.Nil(t, err)
+	assert.Len(t, saved.Id, 26)
+	assert.NotZero(t, saved.CreateAt)
+
+	_, err = ss.ContentFilter().Save(saved)
+	require.NotNil(t, err)
+
+	_, err = ss.ContentFilter().Save(&model.ContentFilter{Name: "invalid", Action: model.CONTENT_FILTER_ACTION_BLOCK, Patterns: model.StringArray{"(unclosed"}})
+	require.NotNil(t, err)
+	assert.Equal(t, "model.content_filter.is_valid.pattern_syntax.app_error", err.Id)
+
+	got, err := ss.ContentFilter().Get(saved.Id)
+	require.Nil(t, err)
+	assert.Equal(t, saved.Words, got.Words)
+	assert.Equal(t, model.StringArray{}, got.Patterns)
+
+	got.Action = model.CONTENT_FILTER_ACTION_BLOCK
+	got.Patterns = model.StringArray{`\d{3}-\d{4}`}
+	updated, err := ss.ContentFilter().Update(got)
+	require.Nil(t, err)
+
+	got, err = ss.ContentFilter().Get(saved.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.CONTENT_FILTER_ACTION_BLOCK, got.Action)
+	assert.Equal(t, updated.Patterns, got.Patterns)
+
+	require.Nil(t, ss.ContentFilter().Delete(saved.Id, model.GetMillis()))
+
+	_, err = ss.ContentFilter().Get(saved.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	err = ss.ContentFilter().Delete(saved.Id, model.GetMillis())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testContentFilterStoreGetForTeam(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	team, err := ss.ContentFilter().Save(&model.ContentFilter{TeamId: teamId, Name: "team", Action: model.CONTENT_FILTER_ACTION_FLAG, Words: model.StringArray{"confidential"}})
+	require.Nil(t, err)
+	global, err := ss.ContentFilter().Save(&model.ContentFilter{Name: "global", Action: model.CONTENT_FILTER_ACTION_BLOCK, Words: model.StringArray{"darn"}})
+	require.Nil(t, err)
+	other, err := ss.ContentFilter().Save(&model.ContentFilter{TeamId: model.NewId(), Name: "other", Action: model.CONTENT_FILTER_ACTION_MASK, Words: model.StringArray{"heck"}})
+	require.Nil(t, err)
+	deleted, err := ss.ContentFilter().Save(&model.ContentFilter{TeamId: teamId, Name: "deleted", Action: model.CONTENT_FILTER_ACTION_MASK, Words: model.StringArray{"gosh"}})
+	require.Nil(t, err)
+	require.Nil(t, ss.ContentFilter().Delete(deleted.Id, model.GetMillis()))
+
+	filters, err := ss.ContentFilter().GetForTeam(teamId)
+	require.Nil(t, err)
+
+	ids := make(map[string]bool)
+	for _, filter := range filters {
+		ids[filter.Id] = true
+	}
+	assert.True(t, ids[team.Id])
+	assert.True(t, ids[global.Id])
+	assert.False(t, ids[other.Id])
+	assert.False(t, ids[deleted.Id])
+
+	require.Nil(t, ss.ContentFilter().Delete(global.Id, model.GetMillis()))
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyStatStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testDailyStatStoreSaveGet(t, ss) })
+	t.Run("Compute", func(t *testing.T) { testDailyStatStoreCompute(t, ss) })
+	t.Run("PermanentDelete", func(t *testing.T) { testDailyStatStorePermanentDelete(t, ss) })
+}
+
+func testDailyStatStoreSaveGet(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	channelId := model.NewId()
+
+	for _, stat := range []*model.DailyStat{
+		{Date: "2019-10-01", TeamId: teamId, PostCount: 10},
+		{Date: "2019-10-02", TeamId: teamId, PostCount: 20},
+		{Date: "2019-10-03", TeamId: teamId, PostCount: 30},
+		{Date: "2019-10-02", TeamId: teamId, ChannelId: channelId, PostCount: 5},
+	} {
+		saved, err := ss.DailyStat().Save(stat)
+		require.Nil(t, err)
+		assert.NotZero(t, saved.UpdateAt)
+	}
+
+	_, err := ss.DailyStat().Save(&model.DailyStat{Date: "yesterday", TeamId: teamId})
+	require.NotNil(t, err)
+
+	stats, err := ss.DailyStat().Get(teamId, "", "2019-10-01", "2019-10-02")
+	require.Nil(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, "2019-10-01", stats[0].Date)
+	assert.Equal(t, int64(10), stats[0].PostCount)
+	assert.Equal(t, "2019-10-02", stats[1].Date)
+	assert.Equal(t, int64(20), stats[1].PostCount)
+
+	stats, err = ss.DailyStat().Get(teamId, channelId, "2019-09-01", "2019-10-31")
+	require.Nil(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(5), stats[0].PostCount)
+
+	// Saving the stat of a date and scope again replaces it.
+	_, err = ss.DailyStat().Save(&model.DailyStat{Date: "2019-10-02", TeamId: teamId, ChannelId: channelId, PostCount: 7})
+	require.Nil(t, err)
+
+	stats, err = ss.DailyStat().Get(teamId, channelId, "2019-09-01", "2019-10-31")
+	require.Nil(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(7), stats[0].PostCount)
+}
+
+func testDailyStatStoreCompute(t *testing.T, ss store.Store) {
+	date := "2001-02-03"
+	since, until, parseErr := model.GetDailyStatDateRange(date)
+	require.Nil(t, parseErr)
+
+	teamId := model.NewId()
+	channels := make([]*model.Channel, 2)
+	for i := range channels {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamId,
+			DisplayName: "Stats",
+			Name:        "zz" + model.NewId(),
+			Type:        model.CHANNEL_OPEN,
+		}, -1)
+		require.Nil(t, err)
+		channels[i] = channel
+	}
+
+	user1Id := model.NewId()
+	user2Id := model.NewId()
+	dm, err := ss.Channel().CreateDirectChannel(&model.User{Id: user1Id}, &model.User{Id: user2Id})
+	require.Nil(t, err)
+
+	for _, post := range []*model.Post{
+		{ChannelId: channels[0].Id, UserId: user1Id, Message: "first", CreateAt: since},
+		{ChannelId: channels[0].Id, UserId: user2Id, Message: "second", CreateAt: since + 1},
+		{ChannelId: channels[1].Id, UserId: user1Id, Message: "third", CreateAt: until - 1},
+		{ChannelId: dm.Id, UserId: user2Id, Message: "direct", CreateAt: since + 2},
+		{ChannelId: channels[1].Id, UserId: model.NewId(), Message: "next day", CreateAt: until},
+	} {
+		saved, err := ss.Post().Save(post)
+		require.Nil(t, err)
+
+		if post.Message == "first" || post.Message == "direct" {
+			_, err = ss.FileInfo().Save(&model.FileInfo{CreatorId: post.UserId, PostId: saved.Id, Path: "file.txt", Size: 100, CreateAt: post.CreateAt})
+			require.Nil(t, err)
+		}
+	}
+
+	stats, err := ss.DailyStat().Compute(date, since, until)
+	require.Nil(t, err)
+
+	statsByScope := make(map[string]*model.DailyStat)
+	for _, stat := range stats {
+		assert.Equal(t, date, stat.Date)
+		statsByScope[stat.TeamId+stat.ChannelId] = stat
+	}
+
+	assert.NotContains(t, statsByScope, dm.Id, "direct messages aren't rolled up on their own")
+
+	require.Contains(t, statsByScope, teamId+channels[0].Id)
+	stat := statsByScope[teamId+channels[0].Id]
+	assert.Equal(t, int64(2), stat.PostCount)
+	assert.Equal(t, int64(2), stat.ActiveUserCount)
+	assert.Equal(t, int64(1), stat.FileCount)
+	assert.Equal(t, int64(100), stat.FileSize)
+
+	require.Contains(t, statsByScope, teamId+channels[1].Id)
+	stat = statsByScope[teamId+channels[1].Id]
+	assert.Equal(t, int64(1), stat.PostCount)
+	assert.Equal(t, int64(1), stat.ActiveUserCount)
+	assert.Zero(t, stat.FileCount)
+
+	require.Contains(t, statsByScope, teamId)
+	stat = statsByScope[teamId]
+	assert.Equal(t, int64(3), stat.PostCount)
+	assert.Equal(t, int64(2), stat.ActiveUserCount)
+	assert.Equal(t, int64(1), stat.FileCount)
+
+	require.Contains(t, statsByScope, "")
+	stat = statsByScope[""]
+	assert.Equal(t, int64(4), stat.PostCount)
+	assert.Equal(t, int64(2), stat.ActiveUserCount)
+	assert.Equal(t, int64(2), stat.FileCount)
+	assert.Equal(t, int64(200), stat.FileSize)
+}
+
+func testDailyStatStorePermanentDelete(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	channelId := model.NewId()
+
+	_, err := ss.DailyStat().Save(&model.DailyStat{Date: "2019-10-01", TeamId: teamId, PostCount: 3})
+	require.Nil(t, err)
+	_, err = ss.DailyStat().Save(&model.DailyStat{Date: "2019-10-01", TeamId: teamId, ChannelId: channelId, PostCount: 3})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.DailyStat().PermanentDeleteByChannel(channelId))
+
+	stats, err := ss.DailyStat().Get(teamId, channelId, "2019-10-01", "2019-10-01")
+	require.Nil(t, err)
+	assert.Empty(t, stats)
+
+	stats, err = ss.DailyStat().Get(teamId, "", "2019-10-01", "2019-10-01")
+	require.Nil(t, err)
+	assert.Len(t, stats, 1)
+
+	require.Nil(t, ss.DailyStat().PermanentDeleteByTeam(teamId))
+
+	stats, err = ss.DailyStat().Get(teamId, "", "2019-10-01", "2019-10-01")
+	require.Nil(t, err)
+	assert.Empty(t, stats)
+}
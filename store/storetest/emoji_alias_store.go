@@ -0,0 +1,79 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmojiAliasStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testEmojiAliasSaveGetDelete(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testEmojiAliasGetAll(t, ss) })
+	t.Run("DeleteForEmoji", func(t *testing.T) { testEmojiAliasDeleteForEmoji(t, ss) })
+}
+
+func testEmojiAliasSaveGetDelete(t *testing.T, ss store.Store) {
+	alias, err := ss.EmojiAlias().Save(&model.EmojiAlias{
+		Alias:     model.NewId(),
+		EmojiName: "+1",
+		CreatorId: model.NewId(),
+	})
+	require.Nil(t, err)
+	assert.NotZero(t, alias.CreateAt)
+
+	_, err = ss.EmojiAlias().Save(&model.EmojiAlias{Alias: alias.Alias, EmojiName: "smile", CreatorId: model.NewId()})
+	require.NotNil(t, err, "shouldn't save two aliases with the same name")
+
+	received, err := ss.EmojiAlias().Get(alias.Alias)
+	require.Nil(t, err)
+	assert.Equal(t, alias, received)
+
+	require.Nil(t, ss.EmojiAlias().Delete(alias.Alias))
+
+	_, err = ss.EmojiAlias().Get(alias.Alias)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testEmojiAliasGetAll(t *testing.T, ss store.Store) {
+	alias1, err := ss.EmojiAlias().Save(&model.EmojiAlias{Alias: model.NewId(), EmojiName: "+1", CreatorId: model.NewId()})
+	require.Nil(t, err)
+	defer ss.EmojiAlias().Delete(alias1.Alias)
+	alias2, err := ss.EmojiAlias().Save(&model.EmojiAlias{Alias: model.NewId(), EmojiName: "smile", CreatorId: model.NewId()})
+	require.Nil(t, err)
+	defer ss.EmojiAlias().Delete(alias2.Alias)
+
+	aliases, err := ss.EmojiAlias().GetAll()
+	require.Nil(t, err)
+	assert.Contains(t, aliases, alias1)
+	assert.Contains(t, aliases, alias2)
+}
+
+func testEmojiAliasDeleteForEmoji(t *testing.T, ss store.Store) {
+	emojiName := model.NewId()
+
+	alias1, err := ss.EmojiAlias().Save(&model.EmojiAlias{Alias: model.NewId(), EmojiName: emojiName, CreatorId: model.NewId()})
+	require.Nil(t, err)
+	alias2, err := ss.EmojiAlias().Save(&model.EmojiAlias{Alias: model.NewId(), EmojiName: emojiName, CreatorId: model.NewId()})
+	require.Nil(t, err)
+	other, err := ss.EmojiAlias().Save(&model.EmojiAlias{Alias: model.NewId(), EmojiName: "+1", CreatorId: model.NewId()})
+	require.Nil(t, err)
+	defer ss.EmojiAlias().Delete(other.Alias)
+
+	require.Nil(t, ss.EmojiAlias().DeleteForEmoji(emojiName))
+
+	_, err = ss.EmojiAlias().Get(alias1.Alias)
+	assert.NotNil(t, err)
+	_, err = ss.EmojiAlias().Get(alias2.Alias)
+	assert.NotNil(t, err)
+	_, err = ss.EmojiAlias().Get(other.Alias)
+	assert.Nil(t, err)
+}
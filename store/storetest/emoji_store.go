@@ -22,6 +22,8 @@ func TestEmojiStore(t *testing.T, ss store.Store) {
 	t.Run("EmojiGetList", func(t *testing.T) { testEmojiGetList(t, ss) })
 	t.Run("EmojiSearch", func(t *testing.T) { testEmojiSearch(t, ss) })
 	t.Run("EmojiCaching", func(t *testing.T) { testEmojiCaching(t, ss) })
+	t.Run("EmojiCountByCreator", func(t *testing.T) { testEmojiCountByCreator(t, ss) })
+	t.Run("EmojiGetUsage", func(t *testing.T) { testEmojiGetUsage(t, ss) })
 }
 
 func testEmojiSaveDelete(t *testing.T, ss store.Store) {
@@ -382,3 +384,69 @@ func testEmojiSearch(t *testing.T, ss store.Store) {
 		}
 	}
 }
+
+func testEmojiCountByCreator(t *testing.T, ss store.Store) {
+	creatorId := model.NewId()
+
+	emoji1, err := ss.Emoji().Save(&model.Emoji{CreatorId: creatorId, Name: model.NewId()})
+	require.Nil(t, err)
+	emoji2, err := ss.Emoji().Save(&model.Emoji{CreatorId: creatorId, Name: model.NewId()})
+	require.Nil(t, err)
+	other, err := ss.Emoji().Save(&model.Emoji{CreatorId: model.NewId(), Name: model.NewId()})
+	require.Nil(t, err)
+	defer func() {
+		ss.Emoji().Delete(emoji1, time.Now().Unix())
+		ss.Emoji().Delete(other, time.Now().Unix())
+	}()
+
+	count, err := ss.Emoji().CountByCreator(creatorId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+
+	require.Nil(t, ss.Emoji().Delete(emoji2, time.Now().Unix()))
+
+	count, err = ss.Emoji().CountByCreator(creatorId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), count, "deleted emoji shouldn't be counted")
+}
+
+func testEmojiGetUsage(t *testing.T, ss store.Store) {
+	unused, err := ss.Emoji().Save(&model.Emoji{CreatorId: model.NewId(), Name: model.NewId()})
+	require.Nil(t, err)
+	used, err := ss.Emoji().Save(&model.Emoji{CreatorId: model.NewId(), Name: model.NewId()})
+	require.Nil(t, err)
+	defer func() {
+		ss.Emoji().Delete(unused, time.Now().Unix())
+		ss.Emoji().Delete(used, time.Now().Unix())
+	}()
+
+	for i := 0; i < 2; i++ {
+		_, err = ss.Reaction().Save(&model.Reaction{
+			UserId:    model.NewId(),
+			PostId:    model.NewId(),
+			EmojiName: used.Name,
+		})
+		require.Nil(t, err)
+	}
+
+	usage, err := ss.Emoji().GetUsage(0, 10000)
+	require.Nil(t, err)
+
+	unusedIndex, usedIndex := -1, -1
+	for i, u := range usage {
+		switch u.EmojiId {
+		case unused.Id:
+			unusedIndex = i
+			assert.Equal(t, unused.Name, u.Name)
+			assert.Equal(t, int64(0), u.ReactionCount)
+			assert.Equal(t, int64(0), u.LastUsedAt)
+		case used.Id:
+			usedIndex = i
+			assert.Equal(t, int64(2), u.ReactionCount)
+			assert.NotZero(t, u.LastUsedAt)
+		}
+	}
+	require.NotEqual(t, -1, unusedIndex)
+	require.NotEqual(t, -1, usedIndex)
+	assert.True(t, unusedIndex < usedIndex, "least used emoji should come first")
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEphemeralPostStore(t *testing.T, ss store.Store) {
+	t.Run("SaveUpdateGet", func(t *testing.T) { testEphemeralPostStoreSaveUpdateGet(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testEphemeralPostStoreDelete(t, ss) })
+	t.Run("DeleteExpired", func(t *testing.T) { testEphemeralPostStoreDeleteExpired(t, ss) })
+}
+
+func newTestEphemeralPost(userId, message string) *model.EphemeralPost {
+	return model.NewEphemeralPost(userId, &model.Post{
+		Id:        model.NewId(),
+		ChannelId: model.NewId(),
+		Message:   message,
+		Type:      model.POST_EPHEMERAL,
+	})
+}
+
+func testEphemeralPostStoreSaveUpdateGet(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	ephemeralPost1, err := ss.EphemeralPost().Save(newTestEphemeralPost(userId, "first"))
+	require.Nil(t, err)
+	assert.NotZero(t, ephemeralPost1.ExpireAt)
+
+	ephemeralPost2, err := ss.EphemeralPost().Save(newTestEphemeralPost(userId, "second"))
+	require.Nil(t, err)
+
+	_, err = ss.EphemeralPost().Save(newTestEphemeralPost(model.NewId(), "other user"))
+	require.Nil(t, err)
+
+	ephemeralPosts, err := ss.EphemeralPost().GetForUser(userId, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, ephemeralPosts, 2)
+	assert.Equal(t, "first", ephemeralPosts[0].ToPost().Message)
+	assert.Equal(t, "second", ephemeralPosts[1].ToPost().Message)
+
+	ephemeralPosts, err = ss.EphemeralPost().GetForUser(userId, ephemeralPost2.UpdateAt, 10)
+	require.Nil(t, err)
+	assert.Empty(t, ephemeralPosts)
+
+	post := ephemeralPost1.ToPost()
+	post.Message = "updated"
+	updated, err := ss.EphemeralPost().Update(model.NewEphemeralPost(userId, post))
+	require.Nil(t, err)
+	assert.True(t, updated.UpdateAt >= ephemeralPost1.UpdateAt)
+
+	ephemeralPosts, err = ss.EphemeralPost().GetForUser(userId, 0, 1)
+	require.Nil(t, err)
+	require.Len(t, ephemeralPosts, 1)
+	assert.Equal(t, "updated", ephemeralPosts[0].ToPost().Message)
+
+	// Another user's ephemeral post can't be updated
+	_, err = ss.EphemeralPost().Update(model.NewEphemeralPost(model.NewId(), post))
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testEphemeralPostStoreDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	ephemeralPost, err := ss.EphemeralPost().Save(newTestEphemeralPost(userId, "message"))
+	require.Nil(t, err)
+
+	require.Nil(t, ss.EphemeralPost().Delete(model.NewId(), ephemeralPost.Id))
+
+	ephemeralPosts, err := ss.EphemeralPost().GetForUser(userId, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, ephemeralPosts, 1)
+
+	require.Nil(t, ss.EphemeralPost().Delete(userId, ephemeralPost.Id))
+
+	ephemeralPosts, err = ss.EphemeralPost().GetForUser(userId, 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, ephemeralPosts)
+
+	_, err = ss.EphemeralPost().Save(newTestEphemeralPost(userId, "message"))
+	require.Nil(t, err)
+	require.Nil(t, ss.EphemeralPost().PermanentDeleteByUser(userId))
+
+	ephemeralPosts, err = ss.EphemeralPost().GetForUser(userId, 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, ephemeralPosts)
+}
+
+func testEphemeralPostStoreDeleteExpired(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	ephemeralPost, err := ss.EphemeralPost().Save(newTestEphemeralPost(userId, "message"))
+	require.Nil(t, err)
+
+	_, err = ss.EphemeralPost().DeleteExpired(ephemeralPost.ExpireAt-1, 100)
+	require.Nil(t, err)
+
+	ephemeralPosts, err := ss.EphemeralPost().GetForUser(userId, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, ephemeralPosts, 1)
+
+	count, err := ss.EphemeralPost().DeleteExpired(ephemeralPost.ExpireAt, 100)
+	require.Nil(t, err)
+	assert.True(t, count >= 1)
+
+	ephemeralPosts, err = ss.EphemeralPost().GetForUser(userId, 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, ephemeralPosts)
+}
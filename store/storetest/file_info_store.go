@@ -5,6 +5,7 @@ package storetest
 
 import (
 	"fmt"
+	"net/http"
 	"sort"
 	"testing"
 
@@ -19,8 +20,10 @@ func TestFileInfoStore(t *testing.T, ss store.Store) {
 	t.Run("FileInfoSaveGet", func(t *testing.T) { testFileInfoSaveGet(t, ss) })
 	t.Run("FileInfoSaveGetByPath", func(t *testing.T) { testFileInfoSaveGetByPath(t, ss) })
 	t.Run("FileInfoGetForPost", func(t *testing.T) { testFileInfoGetForPost(t, ss) })
+	t.Run("FileInfoGetForPosts", func(t *testing.T) { testFileInfoGetForPosts(t, ss) })
 	t.Run("FileInfoGetForUser", func(t *testing.T) { testFileInfoGetForUser(t, ss) })
 	t.Run("FileInfoAttachToPost", func(t *testing.T) { testFileInfoAttachToPost(t, ss) })
+	t.Run("FileInfoSetVerdict", func(t *testing.T) { testFileInfoSetVerdict(t, ss) })
 	t.Run("FileInfoDeleteForPost", func(t *testing.T) { testFileInfoDeleteForPost(t, ss) })
 	t.Run("FileInfoPermanentDelete", func(t *testing.T) { testFileInfoPermanentDelete(t, ss) })
 	t.Run("FileInfoPermanentDeleteBatch", func(t *testing.T) { testFileInfoPermanentDeleteBatch(t, ss) })
@@ -210,6 +213,58 @@ func testFileInfoGetForPost(t *testing.T, ss store.Store) {
 	}
 }
 
+func testFileInfoGetForPosts(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	postId1 := model.NewId()
+	postId2 := model.NewId()
+	postIdWithoutFiles := model.NewId()
+
+	infos := []*model.FileInfo{
+		{
+			PostId:    postId1,
+			CreatorId: userId,
+			Path:      "file.txt",
+		},
+		{
+			PostId:    postId1,
+			CreatorId: userId,
+			Path:      "file.txt",
+			DeleteAt:  123,
+		},
+		{
+			PostId:    postId2,
+			CreatorId: userId,
+			Path:      "file.txt",
+		},
+		{
+			PostId:    postId2,
+			CreatorId: userId,
+			Path:      "file.txt",
+		},
+	}
+
+	for i, info := range infos {
+		newInfo, err := ss.FileInfo().Save(info)
+		require.Nil(t, err)
+		infos[i] = newInfo
+		defer func(id string) {
+			ss.FileInfo().PermanentDelete(id)
+		}(newInfo.Id)
+	}
+
+	// Caches the file infos of the first post so that the others are read from the database
+	_, err := ss.FileInfo().GetForPost(postId1, true, false, true)
+	require.Nil(t, err)
+
+	infosByPost, err := ss.FileInfo().GetForPosts([]string{postId1, postId2, postIdWithoutFiles}, true)
+	require.Nil(t, err)
+	assert.Len(t, infosByPost, 2)
+	require.Len(t, infosByPost[postId1], 1)
+	assert.Equal(t, infos[0].Id, infosByPost[postId1][0].Id)
+	assert.Len(t, infosByPost[postId2], 2)
+	assert.Empty(t, infosByPost[postIdWithoutFiles])
+}
+
 func testFileInfoGetForUser(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 	userId2 := model.NewId()
@@ -353,6 +408,33 @@ func testFileInfoAttachToPost(t *testing.T, ss store.Store) {
 	})
 }
 
+func testFileInfoSetVerdict(t *testing.T, ss store.Store) {
+	info, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      "file.txt",
+	})
+	require.Nil(t, err)
+
+	defer func() {
+		ss.FileInfo().PermanentDelete(info.Id)
+	}()
+
+	verdictAt := info.UpdateAt + 1000
+	err = ss.FileInfo().SetVerdict(info.Id, model.FILE_VERDICT_BLOCKED, "malware", verdictAt)
+	require.Nil(t, err)
+
+	rinfo, err := ss.FileInfo().Get(info.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.FILE_VERDICT_BLOCKED, rinfo.Verdict)
+	assert.Equal(t, "malware", rinfo.VerdictReason)
+	assert.Equal(t, verdictAt, rinfo.VerdictAt)
+	assert.Equal(t, verdictAt, rinfo.UpdateAt)
+
+	err = ss.FileInfo().SetVerdict(model.NewId(), model.FILE_VERDICT_CLEAN, "", verdictAt)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
 func testFileInfoDeleteForPost(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 	postId := model.NewId()
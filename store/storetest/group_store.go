@@ -23,6 +23,7 @@ func TestGroupStore(t *testing.T, ss store.Store) {
 	t.Run("GetByIDs", func(t *testing.T) { testGroupStoreGetByIDs(t, ss) })
 	t.Run("GetByRemoteID", func(t *testing.T) { testGroupStoreGetByRemoteID(t, ss) })
 	t.Run("GetAllBySource", func(t *testing.T) { testGroupStoreGetAllByType(t, ss) })
+	t.Run("GetByUser", func(t *testing.T) { testGroupStoreGetByUser(t, ss) })
 	t.Run("Update", func(t *testing.T) { testGroupStoreUpdate(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testGroupStoreDelete(t, ss) })
 
@@ -280,6 +281,48 @@ func testGroupStoreGetAllByType(t *testing.T, ss store.Store) {
 	}
 }
 
+func testGroupStoreGetByUser(t *testing.T, ss store.Store) {
+	u1, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: model.NewId(),
+	})
+	require.Nil(t, err)
+
+	var groups []*model.Group
+	for i := 0; i < 3; i++ {
+		g, err := ss.Group().Create(&model.Group{
+			Name:        model.NewId(),
+			DisplayName: model.NewId(),
+			Source:      model.GroupSourceSaml,
+			RemoteId:    model.NewId(),
+		})
+		require.Nil(t, err)
+		groups = append(groups, g)
+	}
+
+	_, err = ss.Group().UpsertMember(groups[0].Id, u1.Id)
+	require.Nil(t, err)
+	_, err = ss.Group().UpsertMember(groups[1].Id, u1.Id)
+	require.Nil(t, err)
+	_, err = ss.Group().UpsertMember(groups[2].Id, u1.Id)
+	require.Nil(t, err)
+
+	// Deleted memberships and groups are left out
+	_, err = ss.Group().DeleteMember(groups[1].Id, u1.Id)
+	require.Nil(t, err)
+	_, err = ss.Group().Delete(groups[2].Id)
+	require.Nil(t, err)
+
+	userGroups, err := ss.Group().GetByUser(u1.Id)
+	require.Nil(t, err)
+	require.Len(t, userGroups, 1)
+	require.Equal(t, groups[0].Id, userGroups[0].Id)
+
+	userGroups, err = ss.Group().GetByUser(model.NewId())
+	require.Nil(t, err)
+	require.Empty(t, userGroups)
+}
+
 func testGroupStoreUpdate(t *testing.T, ss store.Store) {
 	// Save a new group
 	g1 := &model.Group{
@@ -0,0 +1,137 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashtagStore(t *testing.T, ss store.Store) {
+	t.Run("SaveForPost", func(t *testing.T) { testHashtagStoreSaveForPost(t, ss) })
+	t.Run("GetTrending", func(t *testing.T) { testHashtagStoreGetTrending(t, ss) })
+	t.Run("GetPostsForUser", func(t *testing.T) { testHashtagStoreGetPostsForUser(t, ss) })
+}
+
+func saveHashtagTestChannel(t *testing.T, ss store.Store, teamId, channelType string) *model.Channel {
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      teamId,
+		DisplayName: "Hashtags",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        channelType,
+	}, -1)
+	require.Nil(t, err)
+	return channel
+}
+
+func saveHashtagTestPost(t *testing.T, ss store.Store, channel *model.Channel, message string, createAt int64) *model.Post {
+	hashtags, _ := model.ParseHashtags(message)
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: channel.Id,
+		UserId:    model.NewId(),
+		Message:   message,
+		Hashtags:  hashtags,
+		CreateAt:  createAt,
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.Hashtag().SaveForPost(post.Id, model.PostHashtagsForPost(post, channel.TeamId)))
+	return post
+}
+
+func testHashtagStoreSaveForPost(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	channel := saveHashtagTestChannel(t, ss, teamId, model.CHANNEL_OPEN)
+	userId := model.NewId()
+	_, err := ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: userId, NotifyProps: model.GetDefaultChannelNotifyProps()})
+	require.Nil(t, err)
+
+	post := saveHashtagTestPost(t, ss, channel, "#one #Two", model.GetMillis())
+
+	list, err := ss.Hashtag().GetPostsForUser(userId, teamId, "#two", 0, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []string{post.Id}, list.Order)
+
+	// Saving again replaces the previous hashtags of the post
+	post.Hashtags = "#three"
+	require.Nil(t, ss.Hashtag().SaveForPost(post.Id, model.PostHashtagsForPost(post, teamId)))
+
+	list, err = ss.Hashtag().GetPostsForUser(userId, teamId, "#two", 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, list.Order)
+
+	list, err = ss.Hashtag().GetPostsForUser(userId, teamId, "#three", 0, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []string{post.Id}, list.Order)
+
+	require.Nil(t, ss.Hashtag().DeleteForPost(post.Id))
+
+	list, err = ss.Hashtag().GetPostsForUser(userId, teamId, "#three", 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, list.Order)
+
+	err = ss.Hashtag().SaveForPost(post.Id, []*model.PostHashtag{{PostId: post.Id, ChannelId: channel.Id, Hashtag: ""}})
+	assert.NotNil(t, err)
+}
+
+func testHashtagStoreGetTrending(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	public := saveHashtagTestChannel(t, ss, teamId, model.CHANNEL_OPEN)
+	private := saveHashtagTestChannel(t, ss, teamId, model.CHANNEL_PRIVATE)
+	other := saveHashtagTestChannel(t, ss, model.NewId(), model.CHANNEL_OPEN)
+
+	now := model.GetMillis()
+	saveHashtagTestPost(t, ss, public, "#incident #deploy", now)
+	saveHashtagTestPost(t, ss, public, "#incident", now)
+	saveHashtagTestPost(t, ss, public, "#Incident #release", now)
+	saveHashtagTestPost(t, ss, public, "#deploy", now)
+	saveHashtagTestPost(t, ss, public, "#old #old", now-10000)
+	saveHashtagTestPost(t, ss, private, "#secret #secret2", now)
+	saveHashtagTestPost(t, ss, other, "#elsewhere", now)
+
+	trending, err := ss.Hashtag().GetTrending(teamId, now-1000, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.HashtagCount{
+		{Hashtag: "#incident", Count: 3},
+		{Hashtag: "#deploy", Count: 2},
+		{Hashtag: "#release", Count: 1},
+	}, trending)
+
+	trending, err = ss.Hashtag().GetTrending(teamId, now-100000, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.HashtagCount{{Hashtag: "#incident", Count: 3}}, trending)
+}
+
+func testHashtagStoreGetPostsForUser(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	member := saveHashtagTestChannel(t, ss, teamId, model.CHANNEL_OPEN)
+	notMember := saveHashtagTestChannel(t, ss, teamId, model.CHANNEL_PRIVATE)
+	userId := model.NewId()
+	_, err := ss.Channel().SaveMember(&model.ChannelMember{ChannelId: member.Id, UserId: userId, NotifyProps: model.GetDefaultChannelNotifyProps()})
+	require.Nil(t, err)
+
+	now := model.GetMillis()
+	older := saveHashtagTestPost(t, ss, member, "first #feed", now-2000)
+	newer := saveHashtagTestPost(t, ss, member, "second #feed", now-1000)
+	saveHashtagTestPost(t, ss, notMember, "hidden #feed", now)
+	deleted := saveHashtagTestPost(t, ss, member, "deleted #feed", now)
+	require.Nil(t, ss.Post().Delete(deleted.Id, model.GetMillis(), userId))
+
+	list, err := ss.Hashtag().GetPostsForUser(userId, teamId, "#feed", 0, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []string{newer.Id, older.Id}, list.Order)
+	assert.Equal(t, "first #feed", list.Posts[older.Id].Message)
+
+	list, err = ss.Hashtag().GetPostsForUser(userId, teamId, "#feed", 1, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []string{older.Id}, list.Order)
+
+	list, err = ss.Hashtag().GetPostsForUser(userId, model.NewId(), "#feed", 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, list.Order)
+}
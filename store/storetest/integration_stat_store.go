@@ -0,0 +1,134 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationStatStore(t *testing.T, ss store.Store) {
+	t.Run("Increment", func(t *testing.T) { testIntegrationStatStoreIncrement(t, ss) })
+	t.Run("GetTotals", func(t *testing.T) { testIntegrationStatStoreGetTotals(t, ss) })
+	t.Run("PermanentDeleteBefore", func(t *testing.T) { testIntegrationStatStorePermanentDeleteBefore(t, ss) })
+}
+
+func testIntegrationStatStoreIncrement(t *testing.T, ss store.Store) {
+	hookId := model.NewId()
+
+	require.Nil(t, ss.IntegrationStat().Increment(&model.IntegrationStat{
+		Date:            "2001-03-04",
+		IntegrationType: model.INTEGRATION_TYPE_INCOMING_WEBHOOK,
+		IntegrationId:   hookId,
+		Requests:        2,
+		Posts:           1,
+		Errors:          1,
+		TotalLatencyMs:  30,
+		MaxLatencyMs:    20,
+	}))
+	require.Nil(t, ss.IntegrationStat().Increment(&model.IntegrationStat{
+		Date:            "2001-03-04",
+		IntegrationType: model.INTEGRATION_TYPE_INCOMING_WEBHOOK,
+		IntegrationId:   hookId,
+		Requests:        1,
+		Posts:           1,
+		TotalLatencyMs:  5,
+		MaxLatencyMs:    5,
+	}))
+	require.Nil(t, ss.IntegrationStat().Increment(&model.IntegrationStat{
+		Date:            "2001-03-05",
+		IntegrationType: model.INTEGRATION_TYPE_INCOMING_WEBHOOK,
+		IntegrationId:   hookId,
+		Requests:        1,
+		TotalLatencyMs:  50,
+		MaxLatencyMs:    50,
+	}))
+
+	err := ss.IntegrationStat().Increment(&model.IntegrationStat{Date: "2001-03-05", IntegrationType: "unknown", IntegrationId: hookId})
+	require.NotNil(t, err)
+
+	stats, err := ss.IntegrationStat().GetDaily(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, hookId, "2001-03-01", "2001-03-31")
+	require.Nil(t, err)
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, "2001-03-04", stats[0].Date)
+	assert.Equal(t, int64(3), stats[0].Requests)
+	assert.Equal(t, int64(2), stats[0].Posts)
+	assert.Equal(t, int64(1), stats[0].Errors)
+	assert.Equal(t, int64(35), stats[0].TotalLatencyMs)
+	assert.Equal(t, int64(20), stats[0].MaxLatencyMs)
+
+	assert.Equal(t, "2001-03-05", stats[1].Date)
+	assert.Equal(t, int64(50), stats[1].MaxLatencyMs)
+
+	stats, err = ss.IntegrationStat().GetDaily(model.INTEGRATION_TYPE_INCOMING_WEBHOOK, hookId, "2001-03-05", "2001-03-05")
+	require.Nil(t, err)
+	assert.Len(t, stats, 1)
+}
+
+func testIntegrationStatStoreGetTotals(t *testing.T, ss store.Store) {
+	commandId, botId := model.NewId(), model.NewId()
+
+	for _, stat := range []*model.IntegrationStat{
+		{Date: "2002-05-01", IntegrationType: model.INTEGRATION_TYPE_COMMAND, IntegrationId: commandId, Requests: 1, Errors: 1, TotalLatencyMs: 100, MaxLatencyMs: 100},
+		{Date: "2002-05-02", IntegrationType: model.INTEGRATION_TYPE_COMMAND, IntegrationId: commandId, Requests: 2, Posts: 2, TotalLatencyMs: 20, MaxLatencyMs: 15},
+		{Date: "2002-05-02", IntegrationType: model.INTEGRATION_TYPE_BOT, IntegrationId: botId, Requests: 10, Posts: 4, TotalLatencyMs: 40, MaxLatencyMs: 10},
+		{Date: "2002-06-01", IntegrationType: model.INTEGRATION_TYPE_BOT, IntegrationId: botId, Requests: 100},
+	} {
+		require.Nil(t, ss.IntegrationStat().Increment(stat))
+	}
+
+	// Stats saved by other tests may fall in the same dates
+	getTotals := func(integrationType string) []*model.IntegrationStat {
+		totals, err := ss.IntegrationStat().GetTotals(integrationType, "2002-05-01", "2002-05-31")
+		require.Nil(t, err)
+
+		var ours []*model.IntegrationStat
+		for _, total := range totals {
+			if total.IntegrationId == commandId || total.IntegrationId == botId {
+				ours = append(ours, total)
+			}
+		}
+		return ours
+	}
+
+	totals := getTotals("")
+	require.Len(t, totals, 2)
+
+	assert.Equal(t, model.INTEGRATION_TYPE_BOT, totals[0].IntegrationType)
+	assert.Equal(t, botId, totals[0].IntegrationId)
+	assert.Equal(t, int64(10), totals[0].Requests)
+
+	assert.Equal(t, commandId, totals[1].IntegrationId)
+	assert.Equal(t, int64(3), totals[1].Requests)
+	assert.Equal(t, int64(2), totals[1].Posts)
+	assert.Equal(t, int64(1), totals[1].Errors)
+	assert.Equal(t, int64(120), totals[1].TotalLatencyMs)
+	assert.Equal(t, int64(100), totals[1].MaxLatencyMs)
+
+	totals = getTotals(model.INTEGRATION_TYPE_COMMAND)
+	require.Len(t, totals, 1)
+	assert.Equal(t, commandId, totals[0].IntegrationId)
+}
+
+func testIntegrationStatStorePermanentDeleteBefore(t *testing.T, ss store.Store) {
+	hookId := model.NewId()
+
+	for _, date := range []string{"1990-01-01", "1990-01-02"} {
+		require.Nil(t, ss.IntegrationStat().Increment(&model.IntegrationStat{Date: date, IntegrationType: model.INTEGRATION_TYPE_OUTGOING_WEBHOOK, IntegrationId: hookId, Requests: 1}))
+	}
+
+	deleted, err := ss.IntegrationStat().PermanentDeleteBefore("1990-01-02")
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	stats, err := ss.IntegrationStat().GetDaily(model.INTEGRATION_TYPE_OUTGOING_WEBHOOK, hookId, "1990-01-01", "1990-12-31")
+	require.Nil(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "1990-01-02", stats[0].Date)
+}
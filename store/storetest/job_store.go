@@ -24,6 +24,7 @@ func TestJobStore(t *testing.T, ss store.Store) {
 	t.Run("GetCountByStatusAndType", func(t *testing.T) { testJobStoreGetCountByStatusAndType(t, ss) })
 	t.Run("JobUpdateOptimistically", func(t *testing.T) { testJobUpdateOptimistically(t, ss) })
 	t.Run("JobUpdateStatusUpdateStatusOptimistically", func(t *testing.T) { testJobUpdateStatusUpdateStatusOptimistically(t, ss) })
+	t.Run("JobUpdateLastActivityAt", func(t *testing.T) { testJobUpdateLastActivityAt(t, ss) })
 	t.Run("JobDelete", func(t *testing.T) { testJobDelete(t, ss) })
 }
 
@@ -380,6 +381,42 @@ func testJobUpdateOptimistically(t *testing.T, ss store.Store) {
 	}
 }
 
+func testJobUpdateLastActivityAt(t *testing.T, ss store.Store) {
+	jobs := []*model.Job{
+		{
+			Id:             model.NewId(),
+			Type:           model.JOB_TYPE_DATA_RETENTION,
+			CreateAt:       model.GetMillis(),
+			LastActivityAt: 1000,
+			Status:         model.JOB_STATUS_IN_PROGRESS,
+		},
+		{
+			Id:             model.NewId(),
+			Type:           model.JOB_TYPE_DATA_RETENTION,
+			CreateAt:       model.GetMillis(),
+			LastActivityAt: 1000,
+			Status:         model.JOB_STATUS_SUCCESS,
+		},
+	}
+
+	for _, job := range jobs {
+		_, err := ss.Job().Save(job)
+		require.Nil(t, err)
+		defer ss.Job().Delete(job.Id)
+	}
+
+	require.Nil(t, ss.Job().UpdateLastActivityAt([]string{jobs[0].Id, jobs[1].Id}))
+	require.Nil(t, ss.Job().UpdateLastActivityAt([]string{}))
+
+	received, err := ss.Job().Get(jobs[0].Id)
+	require.Nil(t, err)
+	assert.True(t, received.LastActivityAt > 1000)
+
+	received, err = ss.Job().Get(jobs[1].Id)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1000), received.LastActivityAt)
+}
+
 func testJobUpdateStatusUpdateStatusOptimistically(t *testing.T, ss store.Store) {
 	job := &model.Job{
 		Id:       model.NewId(),
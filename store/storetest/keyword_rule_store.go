@@ -0,0 +1,98 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeywordRuleStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testKeywordRuleStoreSaveGetUpdateDelete(t, ss) })
+	t.Run("GetForTeam", func(t *testing.T) { testKeywordRuleStoreGetForTeam(t, ss) })
+}
+
+func testKeywordRuleStoreSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	rule := &model.KeywordRule{
+		CreatorId:    model.NewId(),
+		TeamId:       model.NewId(),
+		Name:         "outage",
+		ChannelIds:   model.StringArray{model.NewId()},
+		Keywords:     model.StringArray{"down", "outage"},
+		ResponderId:  model.NewId(),
+		ReplyMessage: "Check the status page.",
+	}
+
+	saved, err := ss.KeywordRule().Save(rule)
+	require.Nil(t, err)
+	assert.Len(t, saved.Id, 26)
+	assert.NotZero(t, saved.CreateAt)
+
+	_, err = ss.KeywordRule().Save(saved)
+	require.NotNil(t, err)
+
+	_, err = ss.KeywordRule().Save(&model.KeywordRule{Name: "no actions", Keywords: model.StringArray{"down"}, ResponderId: model.NewId()})
+	require.NotNil(t, err)
+	assert.Equal(t, "model.keyword_rule.is_valid.no_actions.app_error", err.Id)
+
+	got, err := ss.KeywordRule().Get(saved.Id)
+	require.Nil(t, err)
+	assert.Equal(t, saved.Keywords, got.Keywords)
+	assert.Equal(t, saved.ChannelIds, got.ChannelIds)
+	assert.Equal(t, model.StringArray{}, got.Patterns)
+
+	got.ReactionEmojiName = "eyes"
+	got.CopyToChannelId = model.NewId()
+	updated, err := ss.KeywordRule().Update(got)
+	require.Nil(t, err)
+
+	got, err = ss.KeywordRule().Get(saved.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "eyes", got.ReactionEmojiName)
+	assert.Equal(t, updated.CopyToChannelId, got.CopyToChannelId)
+
+	require.Nil(t, ss.KeywordRule().Delete(saved.Id, model.GetMillis()))
+
+	_, err = ss.KeywordRule().Get(saved.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	err = ss.KeywordRule().Delete(saved.Id, model.GetMillis())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testKeywordRuleStoreGetForTeam(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	newRule := func(teamId, name string) *model.KeywordRule {
+		rule, err := ss.KeywordRule().Save(&model.KeywordRule{TeamId: teamId, Name: name, Keywords: model.StringArray{name}, ResponderId: model.NewId(), ReactionEmojiName: "eyes"})
+		require.Nil(t, err)
+		return rule
+	}
+
+	team := newRule(teamId, "team")
+	global := newRule("", "global")
+	other := newRule(model.NewId(), "other")
+	deleted := newRule(teamId, "deleted")
+	require.Nil(t, ss.KeywordRule().Delete(deleted.Id, model.GetMillis()))
+
+	rules, err := ss.KeywordRule().GetForTeam(teamId)
+	require.Nil(t, err)
+
+	ids := make(map[string]bool)
+	for _, rule := range rules {
+		ids[rule.Id] = true
+	}
+	assert.True(t, ids[team.Id])
+	assert.True(t, ids[global.Id])
+	assert.False(t, ids[other.Id])
+	assert.False(t, ids[deleted.Id])
+
+	require.Nil(t, ss.KeywordRule().Delete(global.Id, model.GetMillis()))
+}
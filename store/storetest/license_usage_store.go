@@ -0,0 +1,55 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseUsageStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testLicenseUsageStoreSaveGet(t, ss) })
+	t.Run("GetRange", func(t *testing.T) { testLicenseUsageStoreGetRange(t, ss) })
+}
+
+func testLicenseUsageStoreSaveGet(t *testing.T, ss store.Store) {
+	usage, err := ss.LicenseUsage().Save(&model.LicenseUsage{Date: "2001-01-01", RegisteredUsers: 10, ActiveUsers: 5, Seats: 20})
+	require.Nil(t, err)
+	assert.NotZero(t, usage.UpdateAt)
+
+	_, err = ss.LicenseUsage().Save(&model.LicenseUsage{Date: "junk"})
+	require.NotNil(t, err)
+
+	// Saving the usage of a date again replaces it.
+	_, err = ss.LicenseUsage().Save(&model.LicenseUsage{Date: "2001-01-01", RegisteredUsers: 12, ActiveUsers: 7, Seats: 20})
+	require.Nil(t, err)
+
+	usage, err = ss.LicenseUsage().Get("2001-01-01")
+	require.Nil(t, err)
+	assert.Equal(t, int64(12), usage.RegisteredUsers)
+	assert.Equal(t, int64(7), usage.ActiveUsers)
+
+	_, err = ss.LicenseUsage().Get("2001-01-02")
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testLicenseUsageStoreGetRange(t *testing.T, ss store.Store) {
+	for _, date := range []string{"2002-01-03", "2002-01-01", "2002-01-02", "2002-01-05"} {
+		_, err := ss.LicenseUsage().Save(&model.LicenseUsage{Date: date, RegisteredUsers: 1})
+		require.Nil(t, err)
+	}
+
+	usages, err := ss.LicenseUsage().GetRange("2002-01-02", "2002-01-05")
+	require.Nil(t, err)
+	require.Len(t, usages, 3)
+	assert.Equal(t, "2002-01-02", usages[0].Date)
+	assert.Equal(t, "2002-01-03", usages[1].Date)
+	assert.Equal(t, "2002-01-05", usages[2].Date)
+}
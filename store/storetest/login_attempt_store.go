@@ -0,0 +1,103 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAttemptStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testLoginAttemptStoreSaveGet(t, ss) })
+	t.Run("GetLatestFailure", func(t *testing.T) { testLoginAttemptStoreGetLatestFailure(t, ss) })
+	t.Run("PermanentDelete", func(t *testing.T) { testLoginAttemptStorePermanentDelete(t, ss) })
+}
+
+func testLoginAttemptStoreSaveGet(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	failed, err := ss.LoginAttempt().Save(&model.LoginAttempt{UserId: userId, CreateAt: 1000, IpAddress: "10.0.0.1"})
+	require.Nil(t, err)
+	require.NotEmpty(t, failed.Id)
+
+	succeeded, err := ss.LoginAttempt().Save(&model.LoginAttempt{UserId: userId, CreateAt: 2000, Success: true, IpAddress: "10.0.0.1", Country: "FR", Device: "Linux/Chrome"})
+	require.Nil(t, err)
+
+	_, err = ss.LoginAttempt().Save(&model.LoginAttempt{UserId: model.NewId(), CreateAt: 3000, Success: true})
+	require.Nil(t, err)
+
+	_, err = ss.LoginAttempt().Save(&model.LoginAttempt{UserId: "junk"})
+	require.NotNil(t, err)
+
+	attempts, err := ss.LoginAttempt().GetForUser(userId, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, succeeded, attempts[0])
+	assert.Equal(t, failed, attempts[1])
+
+	attempts, err = ss.LoginAttempt().GetForUser(userId, 1, 10)
+	require.Nil(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, failed.Id, attempts[0].Id)
+
+	attempts, err = ss.LoginAttempt().GetSuccessfulForUser(userId, 10)
+	require.Nil(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, succeeded, attempts[0])
+}
+
+func testLoginAttemptStoreGetLatestFailure(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	_, err := ss.LoginAttempt().GetLatestFailure(userId)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	_, err = ss.LoginAttempt().Save(&model.LoginAttempt{UserId: userId, CreateAt: 1000})
+	require.Nil(t, err)
+
+	latest, err := ss.LoginAttempt().Save(&model.LoginAttempt{UserId: userId, CreateAt: 2000})
+	require.Nil(t, err)
+
+	_, err = ss.LoginAttempt().Save(&model.LoginAttempt{UserId: userId, CreateAt: 3000, Success: true})
+	require.Nil(t, err)
+
+	failure, err := ss.LoginAttempt().GetLatestFailure(userId)
+	require.Nil(t, err)
+	assert.Equal(t, latest.Id, failure.Id)
+}
+
+func testLoginAttemptStorePermanentDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	_, err := ss.LoginAttempt().Save(&model.LoginAttempt{UserId: userId, CreateAt: 1000})
+	require.Nil(t, err)
+
+	_, err = ss.LoginAttempt().Save(&model.LoginAttempt{UserId: otherUserId, CreateAt: 1000})
+	require.Nil(t, err)
+
+	recent, err := ss.LoginAttempt().Save(&model.LoginAttempt{UserId: otherUserId, CreateAt: model.GetMillis()})
+	require.Nil(t, err)
+
+	err = ss.LoginAttempt().PermanentDeleteByUser(userId)
+	require.Nil(t, err)
+
+	attempts, err := ss.LoginAttempt().GetForUser(userId, 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, attempts)
+
+	_, err = ss.LoginAttempt().PermanentDeleteBatch(2000, 1000)
+	require.Nil(t, err)
+
+	attempts, err = ss.LoginAttempt().GetForUser(otherUserId, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, recent.Id, attempts[0].Id)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMentionAliasStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testMentionAliasStoreSaveGetUpdateDelete(t, ss) })
+	t.Run("GetByName", func(t *testing.T) { testMentionAliasStoreGetByName(t, ss) })
+	t.Run("GetForTeam", func(t *testing.T) { testMentionAliasStoreGetForTeam(t, ss) })
+}
+
+func testMentionAliasStoreSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	alias := &model.MentionAlias{
+		CreatorId: model.NewId(),
+		TeamId:    model.NewId(),
+		Name:      "@Frontend-" + model.NewId(),
+		Type:      model.MENTION_ALIAS_TYPE_USERS,
+		TargetIds: model.StringArray{model.NewId(), model.NewId()},
+	}
+
+	saved, err := ss.MentionAlias().Save(alias)
+	require.Nil(t, err)
+	assert.Len(t, saved.Id, 26)
+	assert.NotZero(t, saved.CreateAt)
+	assert.Equal(t, "frontend-", saved.Name[:9])
+
+	_, err = ss.MentionAlias().Save(saved)
+	require.NotNil(t, err)
+
+	duplicate := &model.MentionAlias{TeamId: alias.TeamId, Name: saved.Name, Type: model.MENTION_ALIAS_TYPE_GROUP, TargetIds: model.StringArray{model.NewId()}}
+	_, err = ss.MentionAlias().Save(duplicate)
+	require.NotNil(t, err)
+	assert.Equal(t, "store.sql_mention_alias.save.name_exists.app_error", err.Id)
+
+	got, err := ss.MentionAlias().Get(saved.Id)
+	require.Nil(t, err)
+	assert.Equal(t, saved.TargetIds, got.TargetIds)
+
+	got.Type = model.MENTION_ALIAS_TYPE_CHANNEL
+	got.TargetIds = model.StringArray{model.NewId()}
+	updated, err := ss.MentionAlias().Update(got)
+	require.Nil(t, err)
+
+	got, err = ss.MentionAlias().Get(saved.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.MENTION_ALIAS_TYPE_CHANNEL, got.Type)
+	assert.Equal(t, updated.TargetIds, got.TargetIds)
+
+	require.Nil(t, ss.MentionAlias().Delete(saved.Id, model.GetMillis()))
+
+	_, err = ss.MentionAlias().Get(saved.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	err = ss.MentionAlias().Delete(saved.Id, model.GetMillis())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testMentionAliasStoreGetByName(t *testing.T, ss store.Store) {
+	name := "alias-" + model.NewId()
+	team, err := ss.MentionAlias().Save(&model.MentionAlias{TeamId: model.NewId(), Name: name, Type: model.MENTION_ALIAS_TYPE_GROUP, TargetIds: model.StringArray{model.NewId()}})
+	require.Nil(t, err)
+	global, err := ss.MentionAlias().Save(&model.MentionAlias{Name: name, Type: model.MENTION_ALIAS_TYPE_GROUP, TargetIds: model.StringArray{model.NewId()}})
+	require.Nil(t, err)
+	deleted, err := ss.MentionAlias().Save(&model.MentionAlias{TeamId: model.NewId(), Name: name, Type: model.MENTION_ALIAS_TYPE_GROUP, TargetIds: model.StringArray{model.NewId()}})
+	require.Nil(t, err)
+	require.Nil(t, ss.MentionAlias().Delete(deleted.Id, model.GetMillis()))
+
+	aliases, err := ss.MentionAlias().GetByName(name)
+	require.Nil(t, err)
+
+	ids := []string{}
+	for _, alias := range aliases {
+		ids = append(ids, alias.Id)
+	}
+	assert.ElementsMatch(t, []string{team.Id, global.Id}, ids)
+}
+
+func testMentionAliasStoreGetForTeam(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	team, err := ss.MentionAlias().Save(&model.MentionAlias{TeamId: teamId, Name: "team-" + model.NewId(), Type: model.MENTION_ALIAS_TYPE_GROUP, TargetIds: model.StringArray{model.NewId()}})
+	require.Nil(t, err)
+	global, err := ss.MentionAlias().Save(&model.MentionAlias{Name: "global-" + model.NewId(), Type: model.MENTION_ALIAS_TYPE_GROUP, TargetIds: model.StringArray{model.NewId()}})
+	require.Nil(t, err)
+	defer ss.MentionAlias().Delete(global.Id, model.GetMillis())
+	other, err := ss.MentionAlias().Save(&model.MentionAlias{TeamId: model.NewId(), Name: "other-" + model.NewId(), Type: model.MENTION_ALIAS_TYPE_GROUP, TargetIds: model.StringArray{model.NewId()}})
+	require.Nil(t, err)
+
+	aliases, err := ss.MentionAlias().GetForTeam(teamId)
+	require.Nil(t, err)
+
+	ids := []string{}
+	for _, alias := range aliases {
+		ids = append(ids, alias.Id)
+	}
+	assert.Contains(t, ids, team.Id)
+	assert.Contains(t, ids, global.Id)
+	assert.NotContains(t, ids, other.Id)
+
+	all, err := ss.MentionAlias().GetAll(0, 1000)
+	require.Nil(t, err)
+	assert.True(t, len(all) >= 3)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageExportConsumerStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testMessageExportConsumerStoreSaveGetUpdateDelete(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testMessageExportConsumerStoreGetAll(t, ss) })
+}
+
+func testMessageExportConsumerStoreSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	consumer, err := ss.MessageExportConsumer().Save(&model.MessageExportConsumer{CreatorId: model.NewId(), Name: "Archive"})
+	require.Nil(t, err)
+	assert.Len(t, consumer.Id, 26)
+
+	_, err = ss.MessageExportConsumer().Save(consumer)
+	require.NotNil(t, err)
+
+	cursor := model.MessageExportCursor{UpdateAt: model.GetMillis(), PostId: model.NewId()}
+	consumer.SetCursor(cursor)
+	_, err = ss.MessageExportConsumer().Update(consumer)
+	require.Nil(t, err)
+
+	received, err := ss.MessageExportConsumer().Get(consumer.Id)
+	require.Nil(t, err)
+	assert.Equal(t, cursor, received.GetCursor())
+
+	err = ss.MessageExportConsumer().Delete(consumer.Id)
+	require.Nil(t, err)
+
+	_, err = ss.MessageExportConsumer().Get(consumer.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testMessageExportConsumerStoreGetAll(t *testing.T, ss store.Store) {
+	consumer1, err := ss.MessageExportConsumer().Save(&model.MessageExportConsumer{CreatorId: model.NewId(), Name: "zz" + model.NewId()})
+	require.Nil(t, err)
+	defer ss.MessageExportConsumer().Delete(consumer1.Id)
+
+	consumer2, err := ss.MessageExportConsumer().Save(&model.MessageExportConsumer{CreatorId: model.NewId(), Name: "aa" + model.NewId()})
+	require.Nil(t, err)
+	defer ss.MessageExportConsumer().Delete(consumer2.Id)
+
+	consumers, err := ss.MessageExportConsumer().GetAll()
+	require.Nil(t, err)
+
+	ids := []string{}
+	for _, consumer := range consumers {
+		if consumer.Id == consumer1.Id || consumer.Id == consumer2.Id {
+			ids = append(ids, consumer.Id)
+		}
+	}
+	assert.Equal(t, []string{consumer2.Id, consumer1.Id}, ids)
+}
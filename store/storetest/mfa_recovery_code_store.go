@@ -0,0 +1,82 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMfaRecoveryCodeStore(t *testing.T, ss store.Store) {
+	t.Run("SaveForUser", func(t *testing.T) { testMfaRecoveryCodeStoreSaveForUser(t, ss) })
+	t.Run("Use", func(t *testing.T) { testMfaRecoveryCodeStoreUse(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testMfaRecoveryCodeStorePermanentDeleteByUser(t, ss) })
+}
+
+func testMfaRecoveryCodeStoreSaveForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	oldCodes, recoveryCodes := model.NewMfaRecoveryCodes(userId)
+	require.Nil(t, ss.MfaRecoveryCode().SaveForUser(userId, recoveryCodes))
+
+	count, err := ss.MfaRecoveryCode().CountUnused(userId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(model.MFA_RECOVERY_CODES_COUNT), count)
+
+	// New codes replace the old ones
+	_, recoveryCodes = model.NewMfaRecoveryCodes(userId)
+	require.Nil(t, ss.MfaRecoveryCode().SaveForUser(userId, recoveryCodes[:2]))
+
+	count, err = ss.MfaRecoveryCode().CountUnused(userId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+
+	used, err := ss.MfaRecoveryCode().Use(userId, model.HashMfaRecoveryCode(oldCodes[0]))
+	require.Nil(t, err)
+	assert.False(t, used)
+
+	require.NotNil(t, ss.MfaRecoveryCode().SaveForUser(userId, []*model.MfaRecoveryCode{{CodeHash: "invalid"}}))
+}
+
+func testMfaRecoveryCodeStoreUse(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	codes, recoveryCodes := model.NewMfaRecoveryCodes(userId)
+	require.Nil(t, ss.MfaRecoveryCode().SaveForUser(userId, recoveryCodes))
+
+	used, err := ss.MfaRecoveryCode().Use(userId, model.HashMfaRecoveryCode(codes[0]))
+	require.Nil(t, err)
+	assert.True(t, used)
+
+	// A code can only be used once
+	used, err = ss.MfaRecoveryCode().Use(userId, model.HashMfaRecoveryCode(codes[0]))
+	require.Nil(t, err)
+	assert.False(t, used)
+
+	// The codes of a user are of no use to another
+	used, err = ss.MfaRecoveryCode().Use(model.NewId(), model.HashMfaRecoveryCode(codes[1]))
+	require.Nil(t, err)
+	assert.False(t, used)
+
+	count, err := ss.MfaRecoveryCode().CountUnused(userId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(model.MFA_RECOVERY_CODES_COUNT-1), count)
+}
+
+func testMfaRecoveryCodeStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	_, recoveryCodes := model.NewMfaRecoveryCodes(userId)
+	require.Nil(t, ss.MfaRecoveryCode().SaveForUser(userId, recoveryCodes))
+
+	require.Nil(t, ss.MfaRecoveryCode().PermanentDeleteByUser(userId))
+
+	count, err := ss.MfaRecoveryCode().CountUnused(userId)
+	require.Nil(t, err)
+	assert.Zero(t, count)
+}
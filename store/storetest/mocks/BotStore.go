@@ -64,6 +64,31 @@ func (_m *BotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, *model.A
 	return r0, r1
 }
 
+// GetAllForChannel provides a mock function with given fields: channelId
+func (_m *BotStore) GetAllForChannel(channelId string) ([]*model.Bot, *model.AppError) {
+	ret := _m.Called(channelId)
+
+	var r0 []*model.Bot
+	if rf, ok := ret.Get(0).(func(string) []*model.Bot); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bot)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(channelId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // PermanentDelete provides a mock function with given fields: userId
 func (_m *BotStore) PermanentDelete(userId string) *model.AppError {
 	ret := _m.Called(userId)
@@ -696,6 +696,31 @@ func (_m *ChannelStore) GetDeletedByName(team_id string, name string) (*model.Ch
 	return r0, r1
 }
 
+// GetDirectMessagePartners provides a mock function with given fields: userId, limit
+func (_m *ChannelStore) GetDirectMessagePartners(userId string, limit int) (map[string]int64, *model.AppError) {
+	ret := _m.Called(userId, limit)
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func(string, int) map[string]int64); ok {
+		r0 = rf(userId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, int) *model.AppError); ok {
+		r1 = rf(userId, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetForPost provides a mock function with given fields: postId
 func (_m *ChannelStore) GetForPost(postId string) (*model.Channel, *model.AppError) {
 	ret := _m.Called(postId)
@@ -845,6 +870,31 @@ func (_m *ChannelStore) GetMemberCountFromCache(channelId string) int64 {
 	return r0
 }
 
+// GetMemberCountsByRole provides a mock function with given fields: channelId
+func (_m *ChannelStore) GetMemberCountsByRole(channelId string) (*model.ChannelMemberCounts, *model.AppError) {
+	ret := _m.Called(channelId)
+
+	var r0 *model.ChannelMemberCounts
+	if rf, ok := ret.Get(0).(func(string) *model.ChannelMemberCounts); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelMemberCounts)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(channelId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetMemberForPost provides a mock function with given fields: postId, userId
 func (_m *ChannelStore) GetMemberForPost(postId string, userId string) (*model.ChannelMember, *model.AppError) {
 	ret := _m.Called(postId, userId)
@@ -970,6 +1020,31 @@ func (_m *ChannelStore) GetMembersForUserWithPagination(teamId string, userId st
 	return r0, r1
 }
 
+// GetMembersWithOptions provides a mock function with given fields: channelId, offset, limit, options
+func (_m *ChannelStore) GetMembersWithOptions(channelId string, offset int, limit int, options *model.ChannelMembersGetOptions) (*model.ChannelMembers, *model.AppError) {
+	ret := _m.Called(channelId, offset, limit, options)
+
+	var r0 *model.ChannelMembers
+	if rf, ok := ret.Get(0).(func(string, int, int, *model.ChannelMembersGetOptions) *model.ChannelMembers); ok {
+		r0 = rf(channelId, offset, limit, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelMembers)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, int, int, *model.ChannelMembersGetOptions) *model.AppError); ok {
+		r1 = rf(channelId, offset, limit, options)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetMoreChannels provides a mock function with given fields: teamId, userId, offset, limit
 func (_m *ChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
 	ret := _m.Called(teamId, userId, offset, limit)
@@ -1107,6 +1182,31 @@ func (_m *ChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limi
 	return r0, r1
 }
 
+// GetSharedChannelCounts provides a mock function with given fields: userId, limit
+func (_m *ChannelStore) GetSharedChannelCounts(userId string, limit int) (map[string]int64, *model.AppError) {
+	ret := _m.Called(userId, limit)
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func(string, int) map[string]int64); ok {
+		r0 = rf(userId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, int) *model.AppError); ok {
+		r1 = rf(userId, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetTeamChannels provides a mock function with given fields: teamId
 func (_m *ChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, *model.AppError) {
 	ret := _m.Called(teamId)
@@ -1148,6 +1248,22 @@ func (_m *ChannelStore) IncrementMentionCount(channelId string, userId string) *
 	return r0
 }
 
+// IncrementMsgCount provides a mock function with given fields: channelId, userId
+func (_m *ChannelStore) IncrementMsgCount(channelId string, userId string) *model.AppError {
+	ret := _m.Called(channelId, userId)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, string) *model.AppError); ok {
+		r0 = rf(channelId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // InvalidateAllChannelMembersForUser provides a mock function with given fields: userId
 func (_m *ChannelStore) InvalidateAllChannelMembersForUser(userId string) {
 	_m.Called(userId)
@@ -114,6 +114,31 @@ func (_m *ComplianceStore) MessageExport(after int64, limit int) ([]*model.Messa
 	return r0, r1
 }
 
+// MessageExportChanges provides a mock function with given fields: cursor, limit
+func (_m *ComplianceStore) MessageExportChanges(cursor model.MessageExportCursor, limit int) ([]*model.MessageExportChange, *model.AppError) {
+	ret := _m.Called(cursor, limit)
+
+	var r0 []*model.MessageExportChange
+	if rf, ok := ret.Get(0).(func(model.MessageExportCursor, int) []*model.MessageExportChange); ok {
+		r0 = rf(cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.MessageExportChange)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(model.MessageExportCursor, int) *model.AppError); ok {
+		r1 = rf(cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: compliance
 func (_m *ComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
 	ret := _m.Called(compliance)
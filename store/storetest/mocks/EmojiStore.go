@@ -14,6 +14,29 @@ type EmojiStore struct {
 	mock.Mock
 }
 
+// CountByCreator provides a mock function with given fields: creatorId
+func (_m *EmojiStore) CountByCreator(creatorId string) (int64, *model.AppError) {
+	ret := _m.Called(creatorId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(creatorId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(creatorId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: emoji, time
 func (_m *EmojiStore) Delete(emoji *model.Emoji, time int64) *model.AppError {
 	ret := _m.Called(emoji, time)
@@ -130,6 +153,31 @@ func (_m *EmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, *model.
 	return r0, r1
 }
 
+// GetUsage provides a mock function with given fields: offset, limit
+func (_m *EmojiStore) GetUsage(offset int, limit int) ([]*model.EmojiUsage, *model.AppError) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []*model.EmojiUsage
+	if rf, ok := ret.Get(0).(func(int, int) []*model.EmojiUsage); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.EmojiUsage)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(int, int) *model.AppError); ok {
+		r1 = rf(offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: emoji
 func (_m *EmojiStore) Save(emoji *model.Emoji) (*model.Emoji, *model.AppError) {
 	ret := _m.Called(emoji)
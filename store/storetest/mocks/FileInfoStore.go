@@ -133,6 +133,31 @@ func (_m *FileInfoStore) GetForPost(postId string, readFromMaster bool, includeD
 	return r0, r1
 }
 
+// GetForPosts provides a mock function with given fields: postIds, readFromMaster
+func (_m *FileInfoStore) GetForPosts(postIds []string, readFromMaster bool) (map[string][]*model.FileInfo, *model.AppError) {
+	ret := _m.Called(postIds, readFromMaster)
+
+	var r0 map[string][]*model.FileInfo
+	if rf, ok := ret.Get(0).(func([]string, bool) map[string][]*model.FileInfo); ok {
+		r0 = rf(postIds, readFromMaster)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]*model.FileInfo)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func([]string, bool) *model.AppError); ok {
+		r1 = rf(postIds, readFromMaster)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetForUser provides a mock function with given fields: userId
 func (_m *FileInfoStore) GetForUser(userId string) ([]*model.FileInfo, *model.AppError) {
 	ret := _m.Called(userId)
@@ -249,3 +274,19 @@ func (_m *FileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, *model.App
 
 	return r0, r1
 }
+
+// SetVerdict provides a mock function with given fields: fileId, verdict, reason, verdictAt
+func (_m *FileInfoStore) SetVerdict(fileId string, verdict string, reason string, verdictAt int64) *model.AppError {
+	ret := _m.Called(fileId, verdict, reason, verdictAt)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, string, string, int64) *model.AppError); ok {
+		r0 = rf(fileId, verdict, reason, verdictAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
@@ -235,6 +235,22 @@ func (_m *JobStore) Save(job *model.Job) (*model.Job, *model.AppError) {
 	return r0, r1
 }
 
+// UpdateLastActivityAt provides a mock function with given fields: ids
+func (_m *JobStore) UpdateLastActivityAt(ids []string) *model.AppError {
+	ret := _m.Called(ids)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func([]string) *model.AppError); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UpdateOptimistically provides a mock function with given fields: job, currentStatus
 func (_m *JobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, *model.AppError) {
 	ret := _m.Called(job, currentStatus)
@@ -18,6 +18,22 @@ type LayeredStoreDatabaseLayer struct {
 	mock.Mock
 }
 
+// Announcement provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) Announcement() store.AnnouncementStore {
+	ret := _m.Called()
+
+	var r0 store.AnnouncementStore
+	if rf, ok := ret.Get(0).(func() store.AnnouncementStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AnnouncementStore)
+		}
+	}
+
+	return r0
+}
+
 // Audit provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Audit() store.AuditStore {
 	ret := _m.Called()
@@ -50,6 +66,22 @@ func (_m *LayeredStoreDatabaseLayer) Bot() store.BotStore {
 	return r0
 }
 
+// Call provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) Call() store.CallStore {
+	ret := _m.Called()
+
+	var r0 store.CallStore
+	if rf, ok := ret.Get(0).(func() store.CallStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.CallStore)
+		}
+	}
+
+	return r0
+}
+
 // Channel provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Channel() store.ChannelStore {
 	ret := _m.Called()
@@ -66,6 +98,22 @@ func (_m *LayeredStoreDatabaseLayer) Channel() store.ChannelStore {
 	return r0
 }
 
+// ChannelMemberExpiry provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) ChannelMemberExpiry() store.ChannelMemberExpiryStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelMemberExpiryStore
+	if rf, ok := ret.Get(0).(func() store.ChannelMemberExpiryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelMemberExpiryStore)
+		}
+	}
+
+	return r0
+}
+
 // ChannelMemberHistory provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	ret := _m.Called()
@@ -82,6 +130,54 @@ func (_m *LayeredStoreDatabaseLayer) ChannelMemberHistory() store.ChannelMemberH
 	return r0
 }
 
+// ChannelMembershipRule provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) ChannelMembershipRule() store.ChannelMembershipRuleStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelMembershipRuleStore
+	if rf, ok := ret.Get(0).(func() store.ChannelMembershipRuleStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelMembershipRuleStore)
+		}
+	}
+
+	return r0
+}
+
+// ChannelReadStat provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) ChannelReadStat() store.ChannelReadStatStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelReadStatStore
+	if rf, ok := ret.Get(0).(func() store.ChannelReadStatStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelReadStatStore)
+		}
+	}
+
+	return r0
+}
+
+// ChannelTeamBinding provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) ChannelTeamBinding() store.ChannelTeamBindingStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelTeamBindingStore
+	if rf, ok := ret.Get(0).(func() store.ChannelTeamBindingStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelTeamBindingStore)
+		}
+	}
+
+	return r0
+}
+
 // CheckIntegrity provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) CheckIntegrity() <-chan store.IntegrityCheckResult {
 	ret := _m.Called()
@@ -167,6 +263,54 @@ func (_m *LayeredStoreDatabaseLayer) Compliance() store.ComplianceStore {
 	return r0
 }
 
+// ConfigAudit provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) ConfigAudit() store.ConfigAuditStore {
+	ret := _m.Called()
+
+	var r0 store.ConfigAuditStore
+	if rf, ok := ret.Get(0).(func() store.ConfigAuditStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ConfigAuditStore)
+		}
+	}
+
+	return r0
+}
+
+// ContentFilter provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) ContentFilter() store.ContentFilterStore {
+	ret := _m.Called()
+
+	var r0 store.ContentFilterStore
+	if rf, ok := ret.Get(0).(func() store.ContentFilterStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ContentFilterStore)
+		}
+	}
+
+	return r0
+}
+
+// DailyStat provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) DailyStat() store.DailyStatStore {
+	ret := _m.Called()
+
+	var r0 store.DailyStatStore
+	if rf, ok := ret.Get(0).(func() store.DailyStatStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.DailyStatStore)
+		}
+	}
+
+	return r0
+}
+
 // DropAllTables provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) DropAllTables() {
 	_m.Called()
@@ -188,6 +332,38 @@ func (_m *LayeredStoreDatabaseLayer) Emoji() store.EmojiStore {
 	return r0
 }
 
+// EmojiAlias provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) EmojiAlias() store.EmojiAliasStore {
+	ret := _m.Called()
+
+	var r0 store.EmojiAliasStore
+	if rf, ok := ret.Get(0).(func() store.EmojiAliasStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.EmojiAliasStore)
+		}
+	}
+
+	return r0
+}
+
+// EphemeralPost provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) EphemeralPost() store.EphemeralPostStore {
+	ret := _m.Called()
+
+	var r0 store.EphemeralPostStore
+	if rf, ok := ret.Get(0).(func() store.EphemeralPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.EphemeralPostStore)
+		}
+	}
+
+	return r0
+}
+
 // FileInfo provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) FileInfo() store.FileInfoStore {
 	ret := _m.Called()
@@ -234,6 +410,38 @@ func (_m *LayeredStoreDatabaseLayer) Group() store.GroupStore {
 	return r0
 }
 
+// Hashtag provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) Hashtag() store.HashtagStore {
+	ret := _m.Called()
+
+	var r0 store.HashtagStore
+	if rf, ok := ret.Get(0).(func() store.HashtagStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.HashtagStore)
+		}
+	}
+
+	return r0
+}
+
+// IntegrationStat provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) IntegrationStat() store.IntegrationStatStore {
+	ret := _m.Called()
+
+	var r0 store.IntegrationStatStore
+	if rf, ok := ret.Get(0).(func() store.IntegrationStatStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.IntegrationStatStore)
+		}
+	}
+
+	return r0
+}
+
 // Job provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Job() store.JobStore {
 	ret := _m.Called()
@@ -250,6 +458,22 @@ func (_m *LayeredStoreDatabaseLayer) Job() store.JobStore {
 	return r0
 }
 
+// KeywordRule provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) KeywordRule() store.KeywordRuleStore {
+	ret := _m.Called()
+
+	var r0 store.KeywordRuleStore
+	if rf, ok := ret.Get(0).(func() store.KeywordRuleStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.KeywordRuleStore)
+		}
+	}
+
+	return r0
+}
+
 // License provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) License() store.LicenseStore {
 	ret := _m.Called()
@@ -266,6 +490,22 @@ func (_m *LayeredStoreDatabaseLayer) License() store.LicenseStore {
 	return r0
 }
 
+// LicenseUsage provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) LicenseUsage() store.LicenseUsageStore {
+	ret := _m.Called()
+
+	var r0 store.LicenseUsageStore
+	if rf, ok := ret.Get(0).(func() store.LicenseUsageStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.LicenseUsageStore)
+		}
+	}
+
+	return r0
+}
+
 // LinkMetadata provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) LinkMetadata() store.LinkMetadataStore {
 	ret := _m.Called()
@@ -287,11 +527,75 @@ func (_m *LayeredStoreDatabaseLayer) LockToMaster() {
 	_m.Called()
 }
 
+// LoginAttempt provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) LoginAttempt() store.LoginAttemptStore {
+	ret := _m.Called()
+
+	var r0 store.LoginAttemptStore
+	if rf, ok := ret.Get(0).(func() store.LoginAttemptStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.LoginAttemptStore)
+		}
+	}
+
+	return r0
+}
+
 // MarkSystemRanUnitTests provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// MentionAlias provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) MentionAlias() store.MentionAliasStore {
+	ret := _m.Called()
+
+	var r0 store.MentionAliasStore
+	if rf, ok := ret.Get(0).(func() store.MentionAliasStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MentionAliasStore)
+		}
+	}
+
+	return r0
+}
+
+// MessageExportConsumer provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) MessageExportConsumer() store.MessageExportConsumerStore {
+	ret := _m.Called()
+
+	var r0 store.MessageExportConsumerStore
+	if rf, ok := ret.Get(0).(func() store.MessageExportConsumerStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MessageExportConsumerStore)
+		}
+	}
+
+	return r0
+}
+
+// MfaRecoveryCode provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	ret := _m.Called()
+
+	var r0 store.MfaRecoveryCodeStore
+	if rf, ok := ret.Get(0).(func() store.MfaRecoveryCodeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MfaRecoveryCodeStore)
+		}
+	}
+
+	return r0
+}
+
 // Next provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Next() store.LayeredStoreSupplier {
 	ret := _m.Called()
@@ -324,6 +628,54 @@ func (_m *LayeredStoreDatabaseLayer) OAuth() store.OAuthStore {
 	return r0
 }
 
+// Onboarding provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) Onboarding() store.OnboardingStore {
+	ret := _m.Called()
+
+	var r0 store.OnboardingStore
+	if rf, ok := ret.Get(0).(func() store.OnboardingStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.OnboardingStore)
+		}
+	}
+
+	return r0
+}
+
+// PendingEmoji provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PendingEmoji() store.PendingEmojiStore {
+	ret := _m.Called()
+
+	var r0 store.PendingEmojiStore
+	if rf, ok := ret.Get(0).(func() store.PendingEmojiStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PendingEmojiStore)
+		}
+	}
+
+	return r0
+}
+
+// PendingPost provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PendingPost() store.PendingPostStore {
+	ret := _m.Called()
+
+	var r0 store.PendingPostStore
+	if rf, ok := ret.Get(0).(func() store.PendingPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PendingPostStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -340,6 +692,22 @@ func (_m *LayeredStoreDatabaseLayer) Plugin() store.PluginStore {
 	return r0
 }
 
+// Poll provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) Poll() store.PollStore {
+	ret := _m.Called()
+
+	var r0 store.PollStore
+	if rf, ok := ret.Get(0).(func() store.PollStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PollStore)
+		}
+	}
+
+	return r0
+}
+
 // Post provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Post() store.PostStore {
 	ret := _m.Called()
@@ -356,6 +724,70 @@ func (_m *LayeredStoreDatabaseLayer) Post() store.PostStore {
 	return r0
 }
 
+// PostPinEvent provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PostPinEvent() store.PostPinEventStore {
+	ret := _m.Called()
+
+	var r0 store.PostPinEventStore
+	if rf, ok := ret.Get(0).(func() store.PostPinEventStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostPinEventStore)
+		}
+	}
+
+	return r0
+}
+
+// PostPurge provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PostPurge() store.PostPurgeStore {
+	ret := _m.Called()
+
+	var r0 store.PostPurgeStore
+	if rf, ok := ret.Get(0).(func() store.PostPurgeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostPurgeStore)
+		}
+	}
+
+	return r0
+}
+
+// PostReport provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PostReport() store.PostReportStore {
+	ret := _m.Called()
+
+	var r0 store.PostReportStore
+	if rf, ok := ret.Get(0).(func() store.PostReportStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostReportStore)
+		}
+	}
+
+	return r0
+}
+
+// PostStar provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PostStar() store.PostStarStore {
+	ret := _m.Called()
+
+	var r0 store.PostStarStore
+	if rf, ok := ret.Get(0).(func() store.PostStarStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostStarStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -372,6 +804,22 @@ func (_m *LayeredStoreDatabaseLayer) Preference() store.PreferenceStore {
 	return r0
 }
 
+// PublicPostLink provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PublicPostLink() store.PublicPostLinkStore {
+	ret := _m.Called()
+
+	var r0 store.PublicPostLinkStore
+	if rf, ok := ret.Get(0).(func() store.PublicPostLinkStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PublicPostLinkStore)
+		}
+	}
+
+	return r0
+}
+
 // Reaction provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Reaction() store.ReactionStore {
 	ret := _m.Called()
@@ -388,6 +836,54 @@ func (_m *LayeredStoreDatabaseLayer) Reaction() store.ReactionStore {
 	return r0
 }
 
+// RecentSlowQueries provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) RecentSlowQueries() []*model.SlowQuery {
+	ret := _m.Called()
+
+	var r0 []*model.SlowQuery
+	if rf, ok := ret.Get(0).(func() []*model.SlowQuery); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SlowQuery)
+		}
+	}
+
+	return r0
+}
+
+// RecurringPost provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) RecurringPost() store.RecurringPostStore {
+	ret := _m.Called()
+
+	var r0 store.RecurringPostStore
+	if rf, ok := ret.Get(0).(func() store.RecurringPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.RecurringPostStore)
+		}
+	}
+
+	return r0
+}
+
+// Reminder provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) Reminder() store.ReminderStore {
+	ret := _m.Called()
+
+	var r0 store.ReminderStore
+	if rf, ok := ret.Get(0).(func() store.ReminderStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ReminderStore)
+		}
+	}
+
+	return r0
+}
+
 // Role provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Role() store.RoleStore {
 	ret := _m.Called()
@@ -619,6 +1115,22 @@ func (_m *LayeredStoreDatabaseLayer) RoleSave(ctx context.Context, role *model.R
 	return r0, r1
 }
 
+// SchemaMigration provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) SchemaMigration() store.SchemaMigrationStore {
+	ret := _m.Called()
+
+	var r0 store.SchemaMigrationStore
+	if rf, ok := ret.Get(0).(func() store.SchemaMigrationStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SchemaMigrationStore)
+		}
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Scheme() store.SchemeStore {
 	ret := _m.Called()
@@ -903,6 +1415,22 @@ func (_m *LayeredStoreDatabaseLayer) TermsOfService() store.TermsOfServiceStore
 	return r0
 }
 
+// Todo provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) Todo() store.TodoStore {
+	ret := _m.Called()
+
+	var r0 store.TodoStore
+	if rf, ok := ret.Get(0).(func() store.TodoStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.TodoStore)
+		}
+	}
+
+	return r0
+}
+
 // Token provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Token() store.TokenStore {
 	ret := _m.Called()
@@ -998,6 +1526,54 @@ func (_m *LayeredStoreDatabaseLayer) UserAccessToken() store.UserAccessTokenStor
 	return r0
 }
 
+// UserAttribute provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) UserAttribute() store.UserAttributeStore {
+	ret := _m.Called()
+
+	var r0 store.UserAttributeStore
+	if rf, ok := ret.Get(0).(func() store.UserAttributeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.UserAttributeStore)
+		}
+	}
+
+	return r0
+}
+
+// UserAvailability provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) UserAvailability() store.UserAvailabilityStore {
+	ret := _m.Called()
+
+	var r0 store.UserAvailabilityStore
+	if rf, ok := ret.Get(0).(func() store.UserAvailabilityStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.UserAvailabilityStore)
+		}
+	}
+
+	return r0
+}
+
+// UserDeactivationSchedule provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) UserDeactivationSchedule() store.UserDeactivationScheduleStore {
+	ret := _m.Called()
+
+	var r0 store.UserDeactivationScheduleStore
+	if rf, ok := ret.Get(0).(func() store.UserDeactivationScheduleStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.UserDeactivationScheduleStore)
+		}
+	}
+
+	return r0
+}
+
 // UserTermsOfService provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) UserTermsOfService() store.UserTermsOfServiceStore {
 	ret := _m.Called()
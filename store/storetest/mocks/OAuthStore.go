@@ -55,6 +55,31 @@ func (_m *OAuthStore) GetAccessData(token string) (*model.AccessData, *model.App
 	return r0, r1
 }
 
+// GetAccessDataByPreviousRefreshToken provides a mock function with given fields: token
+func (_m *OAuthStore) GetAccessDataByPreviousRefreshToken(token string) (*model.AccessData, *model.AppError) {
+	ret := _m.Called(token)
+
+	var r0 *model.AccessData
+	if rf, ok := ret.Get(0).(func(string) *model.AccessData); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AccessData)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetAccessDataByRefreshToken provides a mock function with given fields: token
 func (_m *OAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, *model.AppError) {
 	ret := _m.Called(token)
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	model "github.com/mattermost/mattermost-server/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -87,6 +88,29 @@ func (_m *PostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.Ana
 	return r0, r1
 }
 
+// ArchiveBatch provides a mock function with given fields: before, limit
+func (_m *PostStore) ArchiveBatch(before int64, limit int) (int64, *model.AppError) {
+	ret := _m.Called(before, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int) int64); ok {
+		r0 = rf(before, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(int64, int) *model.AppError); ok {
+		r1 = rf(before, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ClearCaches provides a mock function with given fields:
 func (_m *PostStore) ClearCaches() {
 	_m.Called()
@@ -133,6 +157,31 @@ func (_m *PostStore) Get(id string) (*model.PostList, *model.AppError) {
 	return r0, r1
 }
 
+// GetByUserAfter provides a mock function with given fields: userId, afterId, limit
+func (_m *PostStore) GetByUserAfter(userId string, afterId string, limit int) ([]*model.Post, *model.AppError) {
+	ret := _m.Called(userId, afterId, limit)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(string, string, int) []*model.Post); ok {
+		r0 = rf(userId, afterId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string, int) *model.AppError); ok {
+		r1 = rf(userId, afterId, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterId
 func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError) {
 	ret := _m.Called(limit, afterId)
@@ -247,6 +296,56 @@ func (_m *PostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset
 	return r0, r1
 }
 
+// GetForChannelAfter provides a mock function with given fields: channelId, afterCreateAt, afterId, endTime, limit
+func (_m *PostStore) GetForChannelAfter(channelId string, afterCreateAt int64, afterId string, endTime int64, limit int) ([]*model.Post, *model.AppError) {
+	ret := _m.Called(channelId, afterCreateAt, afterId, endTime, limit)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(string, int64, string, int64, int) []*model.Post); ok {
+		r0 = rf(channelId, afterCreateAt, afterId, endTime, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, int64, string, int64, int) *model.AppError); ok {
+		r1 = rf(channelId, afterCreateAt, afterId, endTime, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetForPurge provides a mock function with given fields: scope, targetId, limit
+func (_m *PostStore) GetForPurge(scope string, targetId string, limit int) ([]*model.Post, *model.AppError) {
+	ret := _m.Called(scope, targetId, limit)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(string, string, int) []*model.Post); ok {
+		r0 = rf(scope, targetId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string, int) *model.AppError); ok {
+		r1 = rf(scope, targetId, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetMaxPostSize provides a mock function with given fields:
 func (_m *PostStore) GetMaxPostSize() int {
 	ret := _m.Called()
@@ -607,6 +706,56 @@ func (_m *PostStore) GetSingle(id string) (*model.Post, *model.AppError) {
 	return r0, r1
 }
 
+// GetSingleFromMaster provides a mock function with given fields: id
+func (_m *PostStore) GetSingleFromMaster(id string) (*model.Post, *model.AppError) {
+	ret := _m.Called(id)
+
+	var r0 *model.Post
+	if rf, ok := ret.Get(0).(func(string) *model.Post); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetThreadParticipantCounts provides a mock function with given fields: userId, since, limit
+func (_m *PostStore) GetThreadParticipantCounts(userId string, since int64, limit int) (map[string]int64, *model.AppError) {
+	ret := _m.Called(userId, since, limit)
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func(string, int64, int) map[string]int64); ok {
+		r0 = rf(userId, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, int64, int) *model.AppError); ok {
+		r1 = rf(userId, since, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // InvalidateLastPostTimeCache provides a mock function with given fields: channelId
 func (_m *PostStore) InvalidateLastPostTimeCache(channelId string) {
 	_m.Called(channelId)
@@ -637,6 +786,22 @@ func (_m *PostStore) Overwrite(post *model.Post) (*model.Post, *model.AppError)
 	return r0, r1
 }
 
+// OverwriteProps provides a mock function with given fields: post
+func (_m *PostStore) OverwriteProps(post *model.Post) *model.AppError {
+	ret := _m.Called(post)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(*model.Post) *model.AppError); ok {
+		r0 = rf(post)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // PermanentDeleteBatch provides a mock function with given fields: endTime, limit
 func (_m *PostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
 	ret := _m.Called(endTime, limit)
@@ -692,6 +857,22 @@ func (_m *PostStore) PermanentDeleteByUser(userId string) *model.AppError {
 	return r0
 }
 
+// PermanentDeleteForPurge provides a mock function with given fields: postIds
+func (_m *PostStore) PermanentDeleteForPurge(postIds []string) *model.AppError {
+	ret := _m.Called(postIds)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func([]string) *model.AppError); ok {
+		r0 = rf(postIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: post
 func (_m *PostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
 	ret := _m.Called(post)
@@ -717,13 +898,13 @@ func (_m *PostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
 	return r0, r1
 }
 
-// Search provides a mock function with given fields: teamId, userId, params
-func (_m *PostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
-	ret := _m.Called(teamId, userId, params)
+// Search provides a mock function with given fields: ctx, teamId, userId, params
+func (_m *PostStore) Search(ctx context.Context, teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+	ret := _m.Called(ctx, teamId, userId, params)
 
 	var r0 *model.PostList
-	if rf, ok := ret.Get(0).(func(string, string, *model.SearchParams) *model.PostList); ok {
-		r0 = rf(teamId, userId, params)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.SearchParams) *model.PostList); ok {
+		r0 = rf(ctx, teamId, userId, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.PostList)
@@ -731,8 +912,8 @@ func (_m *PostStore) Search(teamId string, userId string, params *model.SearchPa
 	}
 
 	var r1 *model.AppError
-	if rf, ok := ret.Get(1).(func(string, string, *model.SearchParams) *model.AppError); ok {
-		r1 = rf(teamId, userId, params)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *model.SearchParams) *model.AppError); ok {
+		r1 = rf(ctx, teamId, userId, params)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*model.AppError)
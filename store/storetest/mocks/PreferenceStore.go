@@ -160,6 +160,106 @@ func (_m *PreferenceStore) GetCategory(userId string, category string) (model.Pr
 	return r0, r1
 }
 
+// GetCategoryAndName provides a mock function with given fields: category, name
+func (_m *PreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, *model.AppError) {
+	ret := _m.Called(category, name)
+
+	var r0 model.Preferences
+	if rf, ok := ret.Get(0).(func(string, string) model.Preferences); ok {
+		r0 = rf(category, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Preferences)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string) *model.AppError); ok {
+		r1 = rf(category, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetNamespaces provides a mock function with given fields: userId, categories
+func (_m *PreferenceStore) GetNamespaces(userId string, categories []string) (map[string]*model.PreferenceNamespace, *model.AppError) {
+	ret := _m.Called(userId, categories)
+
+	var r0 map[string]*model.PreferenceNamespace
+	if rf, ok := ret.Get(0).(func(string, []string) map[string]*model.PreferenceNamespace); ok {
+		r0 = rf(userId, categories)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*model.PreferenceNamespace)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, []string) *model.AppError); ok {
+		r1 = rf(userId, categories)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetVersions provides a mock function with given fields: userId
+func (_m *PreferenceStore) GetVersions(userId string) (map[string]int64, *model.AppError) {
+	ret := _m.Called(userId)
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func(string) map[string]int64); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(userId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Patch provides a mock function with given fields: userId, patch
+func (_m *PreferenceStore) Patch(userId string, patch model.PreferencesPatch) ([]*model.PreferenceNamespaceDiff, *model.AppError) {
+	ret := _m.Called(userId, patch)
+
+	var r0 []*model.PreferenceNamespaceDiff
+	if rf, ok := ret.Get(0).(func(string, model.PreferencesPatch) []*model.PreferenceNamespaceDiff); ok {
+		r0 = rf(userId, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PreferenceNamespaceDiff)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, model.PreferencesPatch) *model.AppError); ok {
+		r1 = rf(userId, patch)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteByUser provides a mock function with given fields: userId
 func (_m *PreferenceStore) PermanentDeleteByUser(userId string) *model.AppError {
 	ret := _m.Called(userId)
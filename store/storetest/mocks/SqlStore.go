@@ -8,6 +8,8 @@ import (
 	gorp "github.com/mattermost/gorp"
 	mock "github.com/stretchr/testify/mock"
 
+	model "github.com/mattermost/mattermost-server/model"
+
 	squirrel "github.com/Masterminds/squirrel"
 
 	store "github.com/mattermost/mattermost-server/store"
@@ -46,6 +48,22 @@ func (_m *SqlStore) AlterColumnTypeIfExists(tableName string, columnName string,
 	return r0
 }
 
+// Announcement provides a mock function with given fields:
+func (_m *SqlStore) Announcement() store.AnnouncementStore {
+	ret := _m.Called()
+
+	var r0 store.AnnouncementStore
+	if rf, ok := ret.Get(0).(func() store.AnnouncementStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AnnouncementStore)
+		}
+	}
+
+	return r0
+}
+
 // Audit provides a mock function with given fields:
 func (_m *SqlStore) Audit() store.AuditStore {
 	ret := _m.Called()
@@ -78,6 +96,22 @@ func (_m *SqlStore) Bot() store.BotStore {
 	return r0
 }
 
+// Call provides a mock function with given fields:
+func (_m *SqlStore) Call() store.CallStore {
+	ret := _m.Called()
+
+	var r0 store.CallStore
+	if rf, ok := ret.Get(0).(func() store.CallStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.CallStore)
+		}
+	}
+
+	return r0
+}
+
 // Channel provides a mock function with given fields:
 func (_m *SqlStore) Channel() store.ChannelStore {
 	ret := _m.Called()
@@ -94,6 +128,70 @@ func (_m *SqlStore) Channel() store.ChannelStore {
 	return r0
 }
 
+// ChannelMemberExpiry provides a mock function with given fields:
+func (_m *SqlStore) ChannelMemberExpiry() store.ChannelMemberExpiryStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelMemberExpiryStore
+	if rf, ok := ret.Get(0).(func() store.ChannelMemberExpiryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelMemberExpiryStore)
+		}
+	}
+
+	return r0
+}
+
+// ChannelMembershipRule provides a mock function with given fields:
+func (_m *SqlStore) ChannelMembershipRule() store.ChannelMembershipRuleStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelMembershipRuleStore
+	if rf, ok := ret.Get(0).(func() store.ChannelMembershipRuleStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelMembershipRuleStore)
+		}
+	}
+
+	return r0
+}
+
+// ChannelReadStat provides a mock function with given fields:
+func (_m *SqlStore) ChannelReadStat() store.ChannelReadStatStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelReadStatStore
+	if rf, ok := ret.Get(0).(func() store.ChannelReadStatStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelReadStatStore)
+		}
+	}
+
+	return r0
+}
+
+// ChannelTeamBinding provides a mock function with given fields:
+func (_m *SqlStore) ChannelTeamBinding() store.ChannelTeamBindingStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelTeamBindingStore
+	if rf, ok := ret.Get(0).(func() store.ChannelTeamBindingStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelTeamBindingStore)
+		}
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *SqlStore) Close() {
 	_m.Called()
@@ -163,6 +261,38 @@ func (_m *SqlStore) Compliance() store.ComplianceStore {
 	return r0
 }
 
+// ConfigAudit provides a mock function with given fields:
+func (_m *SqlStore) ConfigAudit() store.ConfigAuditStore {
+	ret := _m.Called()
+
+	var r0 store.ConfigAuditStore
+	if rf, ok := ret.Get(0).(func() store.ConfigAuditStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ConfigAuditStore)
+		}
+	}
+
+	return r0
+}
+
+// ContentFilter provides a mock function with given fields:
+func (_m *SqlStore) ContentFilter() store.ContentFilterStore {
+	ret := _m.Called()
+
+	var r0 store.ContentFilterStore
+	if rf, ok := ret.Get(0).(func() store.ContentFilterStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ContentFilterStore)
+		}
+	}
+
+	return r0
+}
+
 // CreateColumnIfNotExists provides a mock function with given fields: tableName, columnName, mySqlColType, postgresColType, defaultValue
 func (_m *SqlStore) CreateColumnIfNotExists(tableName string, columnName string, mySqlColType string, postgresColType string, defaultValue string) bool {
 	ret := _m.Called(tableName, columnName, mySqlColType, postgresColType, defaultValue)
@@ -247,6 +377,22 @@ func (_m *SqlStore) CreateUniqueIndexIfNotExists(indexName string, tableName str
 	return r0
 }
 
+// DailyStat provides a mock function with given fields:
+func (_m *SqlStore) DailyStat() store.DailyStatStore {
+	ret := _m.Called()
+
+	var r0 store.DailyStatStore
+	if rf, ok := ret.Get(0).(func() store.DailyStatStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.DailyStatStore)
+		}
+	}
+
+	return r0
+}
+
 // DoesColumnExist provides a mock function with given fields: tableName, columName
 func (_m *SqlStore) DoesColumnExist(tableName string, columName string) bool {
 	ret := _m.Called(tableName, columName)
@@ -319,6 +465,38 @@ func (_m *SqlStore) Emoji() store.EmojiStore {
 	return r0
 }
 
+// EmojiAlias provides a mock function with given fields:
+func (_m *SqlStore) EmojiAlias() store.EmojiAliasStore {
+	ret := _m.Called()
+
+	var r0 store.EmojiAliasStore
+	if rf, ok := ret.Get(0).(func() store.EmojiAliasStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.EmojiAliasStore)
+		}
+	}
+
+	return r0
+}
+
+// EphemeralPost provides a mock function with given fields:
+func (_m *SqlStore) EphemeralPost() store.EphemeralPostStore {
+	ret := _m.Called()
+
+	var r0 store.EphemeralPostStore
+	if rf, ok := ret.Get(0).(func() store.EphemeralPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.EphemeralPostStore)
+		}
+	}
+
+	return r0
+}
+
 // FileInfo provides a mock function with given fields:
 func (_m *SqlStore) FileInfo() store.FileInfoStore {
 	ret := _m.Called()
@@ -427,6 +605,38 @@ func (_m *SqlStore) GetSearchReplica() *gorp.DbMap {
 	return r0
 }
 
+// Hashtag provides a mock function with given fields:
+func (_m *SqlStore) Hashtag() store.HashtagStore {
+	ret := _m.Called()
+
+	var r0 store.HashtagStore
+	if rf, ok := ret.Get(0).(func() store.HashtagStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.HashtagStore)
+		}
+	}
+
+	return r0
+}
+
+// IntegrationStat provides a mock function with given fields:
+func (_m *SqlStore) IntegrationStat() store.IntegrationStatStore {
+	ret := _m.Called()
+
+	var r0 store.IntegrationStatStore
+	if rf, ok := ret.Get(0).(func() store.IntegrationStatStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.IntegrationStatStore)
+		}
+	}
+
+	return r0
+}
+
 // Job provides a mock function with given fields:
 func (_m *SqlStore) Job() store.JobStore {
 	ret := _m.Called()
@@ -443,6 +653,22 @@ func (_m *SqlStore) Job() store.JobStore {
 	return r0
 }
 
+// KeywordRule provides a mock function with given fields:
+func (_m *SqlStore) KeywordRule() store.KeywordRuleStore {
+	ret := _m.Called()
+
+	var r0 store.KeywordRuleStore
+	if rf, ok := ret.Get(0).(func() store.KeywordRuleStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.KeywordRuleStore)
+		}
+	}
+
+	return r0
+}
+
 // License provides a mock function with given fields:
 func (_m *SqlStore) License() store.LicenseStore {
 	ret := _m.Called()
@@ -459,6 +685,22 @@ func (_m *SqlStore) License() store.LicenseStore {
 	return r0
 }
 
+// LicenseUsage provides a mock function with given fields:
+func (_m *SqlStore) LicenseUsage() store.LicenseUsageStore {
+	ret := _m.Called()
+
+	var r0 store.LicenseUsageStore
+	if rf, ok := ret.Get(0).(func() store.LicenseUsageStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.LicenseUsageStore)
+		}
+	}
+
+	return r0
+}
+
 // LinkMetadata provides a mock function with given fields:
 func (_m *SqlStore) LinkMetadata() store.LinkMetadataStore {
 	ret := _m.Called()
@@ -480,11 +722,75 @@ func (_m *SqlStore) LockToMaster() {
 	_m.Called()
 }
 
+// LoginAttempt provides a mock function with given fields:
+func (_m *SqlStore) LoginAttempt() store.LoginAttemptStore {
+	ret := _m.Called()
+
+	var r0 store.LoginAttemptStore
+	if rf, ok := ret.Get(0).(func() store.LoginAttemptStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.LoginAttemptStore)
+		}
+	}
+
+	return r0
+}
+
 // MarkSystemRanUnitTests provides a mock function with given fields:
 func (_m *SqlStore) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// MentionAlias provides a mock function with given fields:
+func (_m *SqlStore) MentionAlias() store.MentionAliasStore {
+	ret := _m.Called()
+
+	var r0 store.MentionAliasStore
+	if rf, ok := ret.Get(0).(func() store.MentionAliasStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MentionAliasStore)
+		}
+	}
+
+	return r0
+}
+
+// MessageExportConsumer provides a mock function with given fields:
+func (_m *SqlStore) MessageExportConsumer() store.MessageExportConsumerStore {
+	ret := _m.Called()
+
+	var r0 store.MessageExportConsumerStore
+	if rf, ok := ret.Get(0).(func() store.MessageExportConsumerStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MessageExportConsumerStore)
+		}
+	}
+
+	return r0
+}
+
+// MfaRecoveryCode provides a mock function with given fields:
+func (_m *SqlStore) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	ret := _m.Called()
+
+	var r0 store.MfaRecoveryCodeStore
+	if rf, ok := ret.Get(0).(func() store.MfaRecoveryCodeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MfaRecoveryCodeStore)
+		}
+	}
+
+	return r0
+}
+
 // OAuth provides a mock function with given fields:
 func (_m *SqlStore) OAuth() store.OAuthStore {
 	ret := _m.Called()
@@ -501,6 +807,54 @@ func (_m *SqlStore) OAuth() store.OAuthStore {
 	return r0
 }
 
+// Onboarding provides a mock function with given fields:
+func (_m *SqlStore) Onboarding() store.OnboardingStore {
+	ret := _m.Called()
+
+	var r0 store.OnboardingStore
+	if rf, ok := ret.Get(0).(func() store.OnboardingStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.OnboardingStore)
+		}
+	}
+
+	return r0
+}
+
+// PendingEmoji provides a mock function with given fields:
+func (_m *SqlStore) PendingEmoji() store.PendingEmojiStore {
+	ret := _m.Called()
+
+	var r0 store.PendingEmojiStore
+	if rf, ok := ret.Get(0).(func() store.PendingEmojiStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PendingEmojiStore)
+		}
+	}
+
+	return r0
+}
+
+// PendingPost provides a mock function with given fields:
+func (_m *SqlStore) PendingPost() store.PendingPostStore {
+	ret := _m.Called()
+
+	var r0 store.PendingPostStore
+	if rf, ok := ret.Get(0).(func() store.PendingPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PendingPostStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *SqlStore) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -517,6 +871,22 @@ func (_m *SqlStore) Plugin() store.PluginStore {
 	return r0
 }
 
+// Poll provides a mock function with given fields:
+func (_m *SqlStore) Poll() store.PollStore {
+	ret := _m.Called()
+
+	var r0 store.PollStore
+	if rf, ok := ret.Get(0).(func() store.PollStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PollStore)
+		}
+	}
+
+	return r0
+}
+
 // Post provides a mock function with given fields:
 func (_m *SqlStore) Post() store.PostStore {
 	ret := _m.Called()
@@ -533,6 +903,70 @@ func (_m *SqlStore) Post() store.PostStore {
 	return r0
 }
 
+// PostPinEvent provides a mock function with given fields:
+func (_m *SqlStore) PostPinEvent() store.PostPinEventStore {
+	ret := _m.Called()
+
+	var r0 store.PostPinEventStore
+	if rf, ok := ret.Get(0).(func() store.PostPinEventStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostPinEventStore)
+		}
+	}
+
+	return r0
+}
+
+// PostPurge provides a mock function with given fields:
+func (_m *SqlStore) PostPurge() store.PostPurgeStore {
+	ret := _m.Called()
+
+	var r0 store.PostPurgeStore
+	if rf, ok := ret.Get(0).(func() store.PostPurgeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostPurgeStore)
+		}
+	}
+
+	return r0
+}
+
+// PostReport provides a mock function with given fields:
+func (_m *SqlStore) PostReport() store.PostReportStore {
+	ret := _m.Called()
+
+	var r0 store.PostReportStore
+	if rf, ok := ret.Get(0).(func() store.PostReportStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostReportStore)
+		}
+	}
+
+	return r0
+}
+
+// PostStar provides a mock function with given fields:
+func (_m *SqlStore) PostStar() store.PostStarStore {
+	ret := _m.Called()
+
+	var r0 store.PostStarStore
+	if rf, ok := ret.Get(0).(func() store.PostStarStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostStarStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with given fields:
 func (_m *SqlStore) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -549,6 +983,22 @@ func (_m *SqlStore) Preference() store.PreferenceStore {
 	return r0
 }
 
+// PublicPostLink provides a mock function with given fields:
+func (_m *SqlStore) PublicPostLink() store.PublicPostLinkStore {
+	ret := _m.Called()
+
+	var r0 store.PublicPostLinkStore
+	if rf, ok := ret.Get(0).(func() store.PublicPostLinkStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PublicPostLinkStore)
+		}
+	}
+
+	return r0
+}
+
 // Reaction provides a mock function with given fields:
 func (_m *SqlStore) Reaction() store.ReactionStore {
 	ret := _m.Called()
@@ -565,6 +1015,54 @@ func (_m *SqlStore) Reaction() store.ReactionStore {
 	return r0
 }
 
+// RecentSlowQueries provides a mock function with given fields:
+func (_m *SqlStore) RecentSlowQueries() []*model.SlowQuery {
+	ret := _m.Called()
+
+	var r0 []*model.SlowQuery
+	if rf, ok := ret.Get(0).(func() []*model.SlowQuery); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SlowQuery)
+		}
+	}
+
+	return r0
+}
+
+// RecurringPost provides a mock function with given fields:
+func (_m *SqlStore) RecurringPost() store.RecurringPostStore {
+	ret := _m.Called()
+
+	var r0 store.RecurringPostStore
+	if rf, ok := ret.Get(0).(func() store.RecurringPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.RecurringPostStore)
+		}
+	}
+
+	return r0
+}
+
+// Reminder provides a mock function with given fields:
+func (_m *SqlStore) Reminder() store.ReminderStore {
+	ret := _m.Called()
+
+	var r0 store.ReminderStore
+	if rf, ok := ret.Get(0).(func() store.ReminderStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ReminderStore)
+		}
+	}
+
+	return r0
+}
+
 // RemoveColumnIfExists provides a mock function with given fields: tableName, columnName
 func (_m *SqlStore) RemoveColumnIfExists(tableName string, columnName string) bool {
 	ret := _m.Called(tableName, columnName)
@@ -637,6 +1135,22 @@ func (_m *SqlStore) Role() store.RoleStore {
 	return r0
 }
 
+// SchemaMigration provides a mock function with given fields:
+func (_m *SqlStore) SchemaMigration() store.SchemaMigrationStore {
+	ret := _m.Called()
+
+	var r0 store.SchemaMigrationStore
+	if rf, ok := ret.Get(0).(func() store.SchemaMigrationStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SchemaMigrationStore)
+		}
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *SqlStore) Scheme() store.SchemeStore {
 	ret := _m.Called()
@@ -733,6 +1247,22 @@ func (_m *SqlStore) TermsOfService() store.TermsOfServiceStore {
 	return r0
 }
 
+// Todo provides a mock function with given fields:
+func (_m *SqlStore) Todo() store.TodoStore {
+	ret := _m.Called()
+
+	var r0 store.TodoStore
+	if rf, ok := ret.Get(0).(func() store.TodoStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.TodoStore)
+		}
+	}
+
+	return r0
+}
+
 // Token provides a mock function with given fields:
 func (_m *SqlStore) Token() store.TokenStore {
 	ret := _m.Called()
@@ -828,6 +1358,54 @@ func (_m *SqlStore) UserAccessToken() store.UserAccessTokenStore {
 	return r0
 }
 
+// UserAttribute provides a mock function with given fields:
+func (_m *SqlStore) UserAttribute() store.UserAttributeStore {
+	ret := _m.Called()
+
+	var r0 store.UserAttributeStore
+	if rf, ok := ret.Get(0).(func() store.UserAttributeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.UserAttributeStore)
+		}
+	}
+
+	return r0
+}
+
+// UserAvailability provides a mock function with given fields:
+func (_m *SqlStore) UserAvailability() store.UserAvailabilityStore {
+	ret := _m.Called()
+
+	var r0 store.UserAvailabilityStore
+	if rf, ok := ret.Get(0).(func() store.UserAvailabilityStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.UserAvailabilityStore)
+		}
+	}
+
+	return r0
+}
+
+// UserDeactivationSchedule provides a mock function with given fields:
+func (_m *SqlStore) UserDeactivationSchedule() store.UserDeactivationScheduleStore {
+	ret := _m.Called()
+
+	var r0 store.UserDeactivationScheduleStore
+	if rf, ok := ret.Get(0).(func() store.UserDeactivationScheduleStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.UserDeactivationScheduleStore)
+		}
+	}
+
+	return r0
+}
+
 // UserTermsOfService provides a mock function with given fields:
 func (_m *SqlStore) UserTermsOfService() store.UserTermsOfServiceStore {
 	ret := _m.Called()
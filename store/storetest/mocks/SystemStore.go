@@ -14,6 +14,31 @@ type SystemStore struct {
 	mock.Mock
 }
 
+// AcquireLease provides a mock function with given fields: lease
+func (_m *SystemStore) AcquireLease(lease *model.LeaderLease) (*model.LeaderLease, *model.AppError) {
+	ret := _m.Called(lease)
+
+	var r0 *model.LeaderLease
+	if rf, ok := ret.Get(0).(func(*model.LeaderLease) *model.LeaderLease); ok {
+		r0 = rf(lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LeaderLease)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*model.LeaderLease) *model.AppError); ok {
+		r1 = rf(lease)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields:
 func (_m *SystemStore) Get() (model.StringMap, *model.AppError) {
 	ret := _m.Called()
@@ -89,6 +114,29 @@ func (_m *SystemStore) PermanentDeleteByName(name string) (*model.System, *model
 	return r0, r1
 }
 
+// ReleaseLease provides a mock function with given fields: name, holderId
+func (_m *SystemStore) ReleaseLease(name string, holderId string) (bool, *model.AppError) {
+	ret := _m.Called(name, holderId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(name, holderId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string) *model.AppError); ok {
+		r1 = rf(name, holderId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: system
 func (_m *SystemStore) Save(system *model.System) *model.AppError {
 	ret := _m.Called(system)
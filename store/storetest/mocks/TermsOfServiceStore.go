@@ -39,6 +39,31 @@ func (_m *TermsOfServiceStore) Get(id string, allowFromCache bool) (*model.Terms
 	return r0, r1
 }
 
+// GetAll provides a mock function with given fields: 
+func (_m *TermsOfServiceStore) GetAll() ([]*model.TermsOfService, *model.AppError) {
+	ret := _m.Called()
+
+	var r0 []*model.TermsOfService
+	if rf, ok := ret.Get(0).(func() []*model.TermsOfService); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TermsOfService)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func() *model.AppError); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetLatest provides a mock function with given fields: allowFromCache
 func (_m *TermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, *model.AppError) {
 	ret := _m.Called(allowFromCache)
@@ -88,3 +113,19 @@ func (_m *TermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*mode
 
 	return r0, r1
 }
+
+// UpdateReacceptAt provides a mock function with given fields: id, reacceptAt
+func (_m *TermsOfServiceStore) UpdateReacceptAt(id string, reacceptAt int64) *model.AppError {
+	ret := _m.Called(id, reacceptAt)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, int64) *model.AppError); ok {
+		r0 = rf(id, reacceptAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
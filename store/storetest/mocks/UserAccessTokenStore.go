@@ -146,6 +146,31 @@ func (_m *UserAccessTokenStore) GetByUser(userId string, page int, perPage int)
 	return r0, r1
 }
 
+// GetExpiring provides a mock function with given fields: from, to, offset, limit
+func (_m *UserAccessTokenStore) GetExpiring(from int64, to int64, offset int, limit int) ([]*model.UserAccessToken, *model.AppError) {
+	ret := _m.Called(from, to, offset, limit)
+
+	var r0 []*model.UserAccessToken
+	if rf, ok := ret.Get(0).(func(int64, int64, int, int) []*model.UserAccessToken); ok {
+		r0 = rf(from, to, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserAccessToken)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(int64, int64, int, int) *model.AppError); ok {
+		r1 = rf(from, to, offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: token
 func (_m *UserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
 	ret := _m.Called(token)
@@ -196,6 +221,22 @@ func (_m *UserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, *
 	return r0, r1
 }
 
+// UpdateLastUsedAt provides a mock function with given fields: tokenId, lastUsedAt
+func (_m *UserAccessTokenStore) UpdateLastUsedAt(tokenId string, lastUsedAt int64) *model.AppError {
+	ret := _m.Called(tokenId, lastUsedAt)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, int64) *model.AppError); ok {
+		r0 = rf(tokenId, lastUsedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UpdateTokenDisable provides a mock function with given fields: tokenId
 func (_m *UserAccessTokenStore) UpdateTokenDisable(tokenId string) *model.AppError {
 	ret := _m.Called(tokenId)
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	model "github.com/mattermost/mattermost-server/model"
 	store "github.com/mattermost/mattermost-server/store"
 	mock "github.com/stretchr/testify/mock"
@@ -61,6 +62,31 @@ func (_m *UserStore) AnalyticsGetInactiveUsersCount() (int64, *model.AppError) {
 	return r0, r1
 }
 
+// AnalyticsGetMfaAdoption provides a mock function with given fields:
+func (_m *UserStore) AnalyticsGetMfaAdoption() (*model.MfaAdoption, *model.AppError) {
+	ret := _m.Called()
+
+	var r0 *model.MfaAdoption
+	if rf, ok := ret.Get(0).(func() *model.MfaAdoption); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MfaAdoption)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func() *model.AppError); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // AnalyticsGetSystemAdminCount provides a mock function with given fields:
 func (_m *UserStore) AnalyticsGetSystemAdminCount() (int64, *model.AppError) {
 	ret := _m.Called()
@@ -989,13 +1015,13 @@ func (_m *UserStore) Save(user *model.User) (*model.User, *model.AppError) {
 	return r0, r1
 }
 
-// Search provides a mock function with given fields: teamId, term, options
-func (_m *UserStore) Search(teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
-	ret := _m.Called(teamId, term, options)
+// Search provides a mock function with given fields: ctx, teamId, term, options
+func (_m *UserStore) Search(ctx context.Context, teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	ret := _m.Called(ctx, teamId, term, options)
 
 	var r0 []*model.User
-	if rf, ok := ret.Get(0).(func(string, string, *model.UserSearchOptions) []*model.User); ok {
-		r0 = rf(teamId, term, options)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.UserSearchOptions) []*model.User); ok {
+		r0 = rf(ctx, teamId, term, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.User)
@@ -1003,8 +1029,8 @@ func (_m *UserStore) Search(teamId string, term string, options *model.UserSearc
 	}
 
 	var r1 *model.AppError
-	if rf, ok := ret.Get(1).(func(string, string, *model.UserSearchOptions) *model.AppError); ok {
-		r1 = rf(teamId, term, options)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *model.UserSearchOptions) *model.AppError); ok {
+		r1 = rf(ctx, teamId, term, options)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*model.AppError)
@@ -1014,13 +1040,13 @@ func (_m *UserStore) Search(teamId string, term string, options *model.UserSearc
 	return r0, r1
 }
 
-// SearchInChannel provides a mock function with given fields: channelId, term, options
-func (_m *UserStore) SearchInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
-	ret := _m.Called(channelId, term, options)
+// SearchInChannel provides a mock function with given fields: ctx, channelId, term, options
+func (_m *UserStore) SearchInChannel(ctx context.Context, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	ret := _m.Called(ctx, channelId, term, options)
 
 	var r0 []*model.User
-	if rf, ok := ret.Get(0).(func(string, string, *model.UserSearchOptions) []*model.User); ok {
-		r0 = rf(channelId, term, options)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.UserSearchOptions) []*model.User); ok {
+		r0 = rf(ctx, channelId, term, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.User)
@@ -1028,8 +1054,8 @@ func (_m *UserStore) SearchInChannel(channelId string, term string, options *mod
 	}
 
 	var r1 *model.AppError
-	if rf, ok := ret.Get(1).(func(string, string, *model.UserSearchOptions) *model.AppError); ok {
-		r1 = rf(channelId, term, options)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *model.UserSearchOptions) *model.AppError); ok {
+		r1 = rf(ctx, channelId, term, options)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*model.AppError)
@@ -1039,13 +1065,13 @@ func (_m *UserStore) SearchInChannel(channelId string, term string, options *mod
 	return r0, r1
 }
 
-// SearchNotInChannel provides a mock function with given fields: teamId, channelId, term, options
-func (_m *UserStore) SearchNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
-	ret := _m.Called(teamId, channelId, term, options)
+// SearchNotInChannel provides a mock function with given fields: ctx, teamId, channelId, term, options
+func (_m *UserStore) SearchNotInChannel(ctx context.Context, teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	ret := _m.Called(ctx, teamId, channelId, term, options)
 
 	var r0 []*model.User
-	if rf, ok := ret.Get(0).(func(string, string, string, *model.UserSearchOptions) []*model.User); ok {
-		r0 = rf(teamId, channelId, term, options)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *model.UserSearchOptions) []*model.User); ok {
+		r0 = rf(ctx, teamId, channelId, term, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.User)
@@ -1053,8 +1079,8 @@ func (_m *UserStore) SearchNotInChannel(teamId string, channelId string, term st
 	}
 
 	var r1 *model.AppError
-	if rf, ok := ret.Get(1).(func(string, string, string, *model.UserSearchOptions) *model.AppError); ok {
-		r1 = rf(teamId, channelId, term, options)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *model.UserSearchOptions) *model.AppError); ok {
+		r1 = rf(ctx, teamId, channelId, term, options)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*model.AppError)
@@ -1064,13 +1090,13 @@ func (_m *UserStore) SearchNotInChannel(teamId string, channelId string, term st
 	return r0, r1
 }
 
-// SearchNotInTeam provides a mock function with given fields: notInTeamId, term, options
-func (_m *UserStore) SearchNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
-	ret := _m.Called(notInTeamId, term, options)
+// SearchNotInTeam provides a mock function with given fields: ctx, notInTeamId, term, options
+func (_m *UserStore) SearchNotInTeam(ctx context.Context, notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	ret := _m.Called(ctx, notInTeamId, term, options)
 
 	var r0 []*model.User
-	if rf, ok := ret.Get(0).(func(string, string, *model.UserSearchOptions) []*model.User); ok {
-		r0 = rf(notInTeamId, term, options)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.UserSearchOptions) []*model.User); ok {
+		r0 = rf(ctx, notInTeamId, term, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.User)
@@ -1078,8 +1104,8 @@ func (_m *UserStore) SearchNotInTeam(notInTeamId string, term string, options *m
 	}
 
 	var r1 *model.AppError
-	if rf, ok := ret.Get(1).(func(string, string, *model.UserSearchOptions) *model.AppError); ok {
-		r1 = rf(notInTeamId, term, options)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *model.UserSearchOptions) *model.AppError); ok {
+		r1 = rf(ctx, notInTeamId, term, options)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*model.AppError)
@@ -1089,13 +1115,13 @@ func (_m *UserStore) SearchNotInTeam(notInTeamId string, term string, options *m
 	return r0, r1
 }
 
-// SearchWithoutTeam provides a mock function with given fields: term, options
-func (_m *UserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
-	ret := _m.Called(term, options)
+// SearchWithoutTeam provides a mock function with given fields: ctx, term, options
+func (_m *UserStore) SearchWithoutTeam(ctx context.Context, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	ret := _m.Called(ctx, term, options)
 
 	var r0 []*model.User
-	if rf, ok := ret.Get(0).(func(string, *model.UserSearchOptions) []*model.User); ok {
-		r0 = rf(term, options)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.UserSearchOptions) []*model.User); ok {
+		r0 = rf(ctx, term, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.User)
@@ -1103,8 +1129,8 @@ func (_m *UserStore) SearchWithoutTeam(term string, options *model.UserSearchOpt
 	}
 
 	var r1 *model.AppError
-	if rf, ok := ret.Get(1).(func(string, *model.UserSearchOptions) *model.AppError); ok {
-		r1 = rf(term, options)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *model.UserSearchOptions) *model.AppError); ok {
+		r1 = rf(ctx, term, options)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*model.AppError)
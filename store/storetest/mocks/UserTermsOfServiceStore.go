@@ -30,6 +30,31 @@ func (_m *UserTermsOfServiceStore) Delete(userId string, termsOfServiceId string
 	return r0
 }
 
+// GetAcceptances provides a mock function with given fields: termsOfServiceId, since, offset, limit
+func (_m *UserTermsOfServiceStore) GetAcceptances(termsOfServiceId string, since int64, offset int, limit int) ([]*model.TermsOfServiceAcceptance, *model.AppError) {
+	ret := _m.Called(termsOfServiceId, since, offset, limit)
+
+	var r0 []*model.TermsOfServiceAcceptance
+	if rf, ok := ret.Get(0).(func(string, int64, int, int) []*model.TermsOfServiceAcceptance); ok {
+		r0 = rf(termsOfServiceId, since, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TermsOfServiceAcceptance)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, int64, int, int) *model.AppError); ok {
+		r1 = rf(termsOfServiceId, since, offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetByUser provides a mock function with given fields: userId
 func (_m *UserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOfService, *model.AppError) {
 	ret := _m.Called(userId)
@@ -79,3 +104,28 @@ func (_m *UserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfSer
 
 	return r0, r1
 }
+
+// SaveAcceptance provides a mock function with given fields: acceptance
+func (_m *UserTermsOfServiceStore) SaveAcceptance(acceptance *model.TermsOfServiceAcceptance) (*model.TermsOfServiceAcceptance, *model.AppError) {
+	ret := _m.Called(acceptance)
+
+	var r0 *model.TermsOfServiceAcceptance
+	if rf, ok := ret.Get(0).(func(*model.TermsOfServiceAcceptance) *model.TermsOfServiceAcceptance); ok {
+		r0 = rf(acceptance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TermsOfServiceAcceptance)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*model.TermsOfServiceAcceptance) *model.AppError); ok {
+		r1 = rf(acceptance)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
@@ -372,6 +372,31 @@ func (_m *WebhookStore) GetOutgoingByTeamByUser(teamId string, userId string, of
 	return r0, r1
 }
 
+// GetOutgoingForChannel provides a mock function with given fields: teamId, channelId
+func (_m *WebhookStore) GetOutgoingForChannel(teamId string, channelId string) ([]*model.OutgoingWebhook, *model.AppError) {
+	ret := _m.Called(teamId, channelId)
+
+	var r0 []*model.OutgoingWebhook
+	if rf, ok := ret.Get(0).(func(string, string) []*model.OutgoingWebhook); ok {
+		r0 = rf(teamId, channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.OutgoingWebhook)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string) *model.AppError); ok {
+		r1 = rf(teamId, channelId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetOutgoingList provides a mock function with given fields: offset, limit
 func (_m *WebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
 	ret := _m.Called(offset, limit)
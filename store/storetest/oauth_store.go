@@ -19,6 +19,7 @@ func TestOAuthStore(t *testing.T, ss store.Store) {
 	t.Run("SaveAccessData", func(t *testing.T) { testOAuthStoreSaveAccessData(t, ss) })
 	t.Run("OAuthUpdateAccessData", func(t *testing.T) { testOAuthUpdateAccessData(t, ss) })
 	t.Run("GetAccessData", func(t *testing.T) { testOAuthStoreGetAccessData(t, ss) })
+	t.Run("GetAccessDataByPreviousRefreshToken", func(t *testing.T) { testOAuthStoreGetAccessDataByPreviousRefreshToken(t, ss) })
 	t.Run("RemoveAccessData", func(t *testing.T) { testOAuthStoreRemoveAccessData(t, ss) })
 	t.Run("SaveAuthData", func(t *testing.T) { testOAuthStoreSaveAuthData(t, ss) })
 	t.Run("GetAuthData", func(t *testing.T) { testOAuthStoreGetAuthData(t, ss) })
@@ -162,6 +163,38 @@ func testOAuthUpdateAccessData(t *testing.T, ss store.Store) {
 	require.NotEqual(t, ra1.RefreshToken, refreshToken, "refresh tokens didn't match")
 }
 
+func testOAuthStoreGetAccessDataByPreviousRefreshToken(t *testing.T, ss store.Store) {
+	a1 := model.AccessData{}
+	a1.ClientId = model.NewId()
+	a1.UserId = model.NewId()
+	a1.Token = model.NewId()
+	a1.RefreshToken = model.NewId()
+	a1.ExpiresAt = model.GetMillis()
+	a1.RedirectUri = "http://example.com"
+	a1.Scope = model.OAUTH_SCOPE_READ_POSTS
+	_, err := ss.OAuth().SaveAccessData(&a1)
+	require.Nil(t, err)
+
+	previous := a1.RefreshToken
+	a1.PreviousRefreshToken = previous
+	a1.RefreshToken = model.NewId()
+	a1.Scope = model.OAUTH_SCOPE_READ_POSTS + " " + model.OAUTH_SCOPE_READ_USERS
+	_, err = ss.OAuth().UpdateAccessData(&a1)
+	require.Nil(t, err)
+
+	_, err = ss.OAuth().GetAccessDataByRefreshToken(previous)
+	require.NotNil(t, err, "the rotated refresh token should no longer be usable")
+
+	ra1, err := ss.OAuth().GetAccessDataByPreviousRefreshToken(previous)
+	require.Nil(t, err)
+	assert.Equal(t, a1.Token, ra1.Token)
+	assert.Equal(t, a1.RefreshToken, ra1.RefreshToken)
+	assert.Equal(t, a1.Scope, ra1.Scope)
+
+	_, err = ss.OAuth().GetAccessDataByPreviousRefreshToken(model.NewId())
+	require.NotNil(t, err)
+}
+
 func testOAuthStoreGetAccessData(t *testing.T, ss store.Store) {
 	a1 := model.AccessData{}
 	a1.ClientId = model.NewId()
@@ -0,0 +1,102 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnboardingStore(t *testing.T, ss store.Store) {
+	t.Run("Steps", func(t *testing.T) { testOnboardingStoreSteps(t, ss) })
+	t.Run("Completions", func(t *testing.T) { testOnboardingStoreCompletions(t, ss) })
+}
+
+func testOnboardingStoreSteps(t *testing.T, ss store.Store) {
+	second, err := ss.Onboarding().SaveStep(&model.OnboardingStep{Type: model.ONBOARDING_STEP_TYPE_MANUAL, DisplayName: "Second", SortOrder: 1001})
+	require.Nil(t, err)
+
+	first, err := ss.Onboarding().SaveStep(&model.OnboardingStep{Type: model.ONBOARDING_STEP_TYPE_JOIN_CHANNEL, DisplayName: "First", ChannelId: model.NewId(), SortOrder: 1000})
+	require.Nil(t, err)
+
+	_, err = ss.Onboarding().SaveStep(first)
+	require.NotNil(t, err, "shouldn't be able to save a step twice")
+
+	_, err = ss.Onboarding().SaveStep(&model.OnboardingStep{Type: model.ONBOARDING_STEP_TYPE_JOIN_CHANNEL, DisplayName: "No channel"})
+	require.NotNil(t, err, "shouldn't be able to save an invalid step")
+
+	got, err := ss.Onboarding().GetStep(first.Id)
+	require.Nil(t, err)
+	assert.Equal(t, first, got)
+
+	steps, err := ss.Onboarding().GetSteps()
+	require.Nil(t, err)
+	var ids []string
+	for _, step := range steps {
+		if step.Id == first.Id || step.Id == second.Id {
+			ids = append(ids, step.Id)
+		}
+	}
+	assert.Equal(t, []string{first.Id, second.Id}, ids, "steps should be in their sort order")
+
+	second.DisplayName = "Updated"
+	second.SortOrder = 999
+	_, err = ss.Onboarding().UpdateStep(second)
+	require.Nil(t, err)
+
+	got, err = ss.Onboarding().GetStep(second.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "Updated", got.DisplayName)
+	assert.Equal(t, 999, got.SortOrder)
+
+	require.Nil(t, ss.Onboarding().DeleteStep(second.Id, model.GetMillis()))
+
+	_, err = ss.Onboarding().GetStep(second.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	steps, err = ss.Onboarding().GetSteps()
+	require.Nil(t, err)
+	for _, step := range steps {
+		assert.NotEqual(t, second.Id, step.Id, "deleted steps shouldn't be listed")
+	}
+
+	require.Nil(t, ss.Onboarding().DeleteStep(first.Id, model.GetMillis()))
+}
+
+func testOnboardingStoreCompletions(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	stepId1 := model.NewId()
+	stepId2 := model.NewId()
+
+	completion, err := ss.Onboarding().SaveCompletion(&model.OnboardingStepCompletion{UserId: userId, StepId: stepId1})
+	require.Nil(t, err)
+	assert.NotZero(t, completion.CompleteAt)
+
+	_, err = ss.Onboarding().SaveCompletion(&model.OnboardingStepCompletion{UserId: userId, StepId: stepId1})
+	require.NotNil(t, err, "shouldn't be able to complete a step twice")
+	assert.Equal(t, "store.sql_onboarding.save_completion.exists.app_error", err.Id)
+
+	_, err = ss.Onboarding().SaveCompletion(&model.OnboardingStepCompletion{UserId: userId, StepId: stepId2, CompleteAt: 100})
+	require.Nil(t, err)
+
+	_, err = ss.Onboarding().SaveCompletion(&model.OnboardingStepCompletion{UserId: model.NewId(), StepId: stepId1})
+	require.Nil(t, err)
+
+	completions, err := ss.Onboarding().GetCompletionsForUser(userId)
+	require.Nil(t, err)
+	require.Len(t, completions, 2)
+	assert.ElementsMatch(t, []string{stepId1, stepId2}, []string{completions[0].StepId, completions[1].StepId})
+
+	require.Nil(t, ss.Onboarding().PermanentDeleteCompletionsByUser(userId))
+
+	completions, err = ss.Onboarding().GetCompletionsForUser(userId)
+	require.Nil(t, err)
+	assert.Empty(t, completions)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingEmojiStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testPendingEmojiSaveGetDelete(t, ss) })
+	t.Run("GetList", func(t *testing.T) { testPendingEmojiGetList(t, ss) })
+	t.Run("CountByCreator", func(t *testing.T) { testPendingEmojiCountByCreator(t, ss) })
+}
+
+func testPendingEmojiSaveGetDelete(t *testing.T, ss store.Store) {
+	id := model.NewId()
+	emoji, err := ss.PendingEmoji().Save(&model.PendingEmoji{
+		Id:        id,
+		CreatorId: model.NewId(),
+		Name:      model.NewId(),
+	})
+	require.Nil(t, err)
+	assert.Equal(t, id, emoji.Id, "should keep the id of the uploaded image")
+	assert.NotZero(t, emoji.CreateAt)
+
+	_, err = ss.PendingEmoji().Save(&model.PendingEmoji{CreatorId: model.NewId(), Name: emoji.Name})
+	require.NotNil(t, err, "shouldn't save two pending emoji with the same name")
+
+	received, err := ss.PendingEmoji().Get(emoji.Id)
+	require.Nil(t, err)
+	assert.Equal(t, emoji, received)
+
+	received, err = ss.PendingEmoji().GetByName(emoji.Name)
+	require.Nil(t, err)
+	assert.Equal(t, emoji, received)
+
+	require.Nil(t, ss.PendingEmoji().Delete(emoji.Id))
+
+	_, err = ss.PendingEmoji().Get(emoji.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testPendingEmojiGetList(t *testing.T, ss store.Store) {
+	emoji1, err := ss.PendingEmoji().Save(&model.PendingEmoji{CreatorId: model.NewId(), Name: model.NewId()})
+	require.Nil(t, err)
+	defer ss.PendingEmoji().Delete(emoji1.Id)
+	emoji2, err := ss.PendingEmoji().Save(&model.PendingEmoji{CreatorId: model.NewId(), Name: model.NewId()})
+	require.Nil(t, err)
+	defer ss.PendingEmoji().Delete(emoji2.Id)
+
+	list, err := ss.PendingEmoji().GetList(0, 10000)
+	require.Nil(t, err)
+
+	ids := make([]string, 0, len(list))
+	for _, emoji := range list {
+		ids = append(ids, emoji.Id)
+	}
+	assert.Contains(t, ids, emoji1.Id)
+	assert.Contains(t, ids, emoji2.Id)
+}
+
+func testPendingEmojiCountByCreator(t *testing.T, ss store.Store) {
+	creatorId := model.NewId()
+
+	count, err := ss.PendingEmoji().CountByCreator(creatorId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+
+	for i := 0; i < 2; i++ {
+		emoji, err := ss.PendingEmoji().Save(&model.PendingEmoji{CreatorId: creatorId, Name: model.NewId()})
+		require.Nil(t, err)
+		defer ss.PendingEmoji().Delete(emoji.Id)
+	}
+
+	count, err = ss.PendingEmoji().CountByCreator(creatorId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingPostStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testPendingPostStoreSaveGetDelete(t, ss) })
+	t.Run("GetForChannel", func(t *testing.T) { testPendingPostStoreGetForChannel(t, ss) })
+	t.Run("PermanentDelete", func(t *testing.T) { testPendingPostStorePermanentDelete(t, ss) })
+}
+
+func testPendingPostStoreSaveGetDelete(t *testing.T, ss store.Store) {
+	pendingPost := &model.PendingPost{
+		UserId:    model.NewId(),
+		ChannelId: model.NewId(),
+		Message:   "awaiting approval",
+		Props:     model.StringInterface{"attachments": "none"},
+		FileIds:   model.StringArray{model.NewId()},
+	}
+
+	saved, err := ss.PendingPost().Save(pendingPost)
+	require.Nil(t, err)
+	assert.Len(t, saved.Id, 26)
+	assert.NotZero(t, saved.CreateAt)
+
+	got, err := ss.PendingPost().Get(saved.Id)
+	require.Nil(t, err)
+	assert.Equal(t, saved.Message, got.Message)
+	assert.Equal(t, saved.Props, got.Props)
+	assert.Equal(t, saved.FileIds, got.FileIds)
+
+	_, err = ss.PendingPost().Save(&model.PendingPost{ChannelId: model.NewId()})
+	require.NotNil(t, err)
+
+	deleted, err := ss.PendingPost().Delete(saved.Id)
+	require.Nil(t, err)
+	assert.True(t, deleted)
+
+	_, err = ss.PendingPost().Get(saved.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	// A pending post is reviewed only once.
+	deleted, err = ss.PendingPost().Delete(saved.Id)
+	require.Nil(t, err)
+	assert.False(t, deleted)
+}
+
+func testPendingPostStoreGetForChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	pendingPosts := []*model.PendingPost{}
+	for i := 0; i < 3; i++ {
+		pendingPost, err := ss.PendingPost().Save(&model.PendingPost{
+			UserId:    model.NewId(),
+			ChannelId: channelId,
+			Message:   "message",
+			CreateAt:  model.GetMillis() + int64(i),
+		})
+		require.Nil(t, err)
+		pendingPosts = append(pendingPosts, pendingPost)
+	}
+
+	_, err := ss.PendingPost().Save(&model.PendingPost{UserId: model.NewId(), ChannelId: model.NewId(), Message: "elsewhere"})
+	require.Nil(t, err)
+
+	got, err := ss.PendingPost().GetForChannel(channelId, 0, 2)
+	require.Nil(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, pendingPosts[0].Id, got[0].Id)
+	assert.Equal(t, pendingPosts[1].Id, got[1].Id)
+
+	got, err = ss.PendingPost().GetForChannel(channelId, 2, 2)
+	require.Nil(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, pendingPosts[2].Id, got[0].Id)
+
+	count, err := ss.PendingPost().GetCountForChannel(channelId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func testPendingPostStorePermanentDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	channelId := model.NewId()
+
+	byUser, err := ss.PendingPost().Save(&model.PendingPost{UserId: userId, ChannelId: model.NewId(), Message: "message"})
+	require.Nil(t, err)
+	inChannel, err := ss.PendingPost().Save(&model.PendingPost{UserId: model.NewId(), ChannelId: channelId, Message: "message"})
+	require.Nil(t, err)
+	other, err := ss.PendingPost().Save(&model.PendingPost{UserId: model.NewId(), ChannelId: model.NewId(), Message: "message"})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.PendingPost().PermanentDeleteByUser(userId))
+	require.Nil(t, ss.PendingPost().PermanentDeleteByChannel(channelId))
+
+	_, err = ss.PendingPost().Get(byUser.Id)
+	require.NotNil(t, err)
+	_, err = ss.PendingPost().Get(inChannel.Id)
+	require.NotNil(t, err)
+	_, err = ss.PendingPost().Get(other.Id)
+	require.Nil(t, err)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdate", func(t *testing.T) { testPollStoreSaveGetUpdate(t, ss) })
+	t.Run("Votes", func(t *testing.T) { testPollStoreVotes(t, ss) })
+	t.Run("PermanentDeleteByChannel", func(t *testing.T) { testPollStorePermanentDeleteByChannel(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPollStorePermanentDeleteByUser(t, ss) })
+}
+
+func newTestPoll(channelId, creatorId string) *model.Poll {
+	return &model.Poll{
+		CreatorId: creatorId,
+		ChannelId: channelId,
+		Question:  "Lunch?",
+		Options:   model.StringArray{"Pizza", "Sushi"},
+	}
+}
+
+func testPollStoreSaveGetUpdate(t *testing.T, ss store.Store) {
+	poll, err := ss.Poll().Save(newTestPoll(model.NewId(), model.NewId()))
+	require.Nil(t, err)
+	assert.Len(t, poll.Id, 26)
+
+	_, err = ss.Poll().Save(poll)
+	require.NotNil(t, err)
+
+	_, err = ss.Poll().Save(&model.Poll{CreatorId: model.NewId(), ChannelId: model.NewId(), Question: "Lunch?"})
+	require.NotNil(t, err)
+
+	got, err := ss.Poll().Get(poll.Id)
+	require.Nil(t, err)
+	assert.Equal(t, poll.Question, got.Question)
+	assert.Equal(t, poll.Options, got.Options)
+	assert.False(t, got.IsClosed())
+
+	got.PostId = model.NewId()
+	got.CloseAt = model.GetMillis()
+	_, err = ss.Poll().Update(got)
+	require.Nil(t, err)
+
+	got, err = ss.Poll().Get(poll.Id)
+	require.Nil(t, err)
+	assert.True(t, got.IsClosed())
+
+	_, err = ss.Poll().Get(model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testPollStoreVotes(t *testing.T, ss store.Store) {
+	poll, err := ss.Poll().Save(newTestPoll(model.NewId(), model.NewId()))
+	require.Nil(t, err)
+
+	userId := model.NewId()
+	_, err = ss.Poll().SaveVote(&model.PollVote{PollId: poll.Id, UserId: userId, Option: 0})
+	require.Nil(t, err)
+	_, err = ss.Poll().SaveVote(&model.PollVote{PollId: poll.Id, UserId: model.NewId(), Option: 1})
+	require.Nil(t, err)
+
+	// Voting again replaces the previous vote
+	_, err = ss.Poll().SaveVote(&model.PollVote{PollId: poll.Id, UserId: userId, Option: 1})
+	require.Nil(t, err)
+
+	_, err = ss.Poll().SaveVote(&model.PollVote{PollId: poll.Id, UserId: userId, Option: -1})
+	require.NotNil(t, err)
+
+	votes, err := ss.Poll().GetVotes(poll.Id)
+	require.Nil(t, err)
+	require.Len(t, votes, 2)
+	assert.Equal(t, []int{0, 2}, model.CountPollVotes(poll, votes))
+
+	votes, err = ss.Poll().GetVotes(model.NewId())
+	require.Nil(t, err)
+	assert.Empty(t, votes)
+}
+
+func testPollStorePermanentDeleteByChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	poll, err := ss.Poll().Save(newTestPoll(channelId, model.NewId()))
+	require.Nil(t, err)
+	_, err = ss.Poll().SaveVote(&model.PollVote{PollId: poll.Id, UserId: model.NewId(), Option: 0})
+	require.Nil(t, err)
+	other, err := ss.Poll().Save(newTestPoll(model.NewId(), model.NewId()))
+	require.Nil(t, err)
+
+	require.Nil(t, ss.Poll().PermanentDeleteByChannel(channelId))
+
+	_, err = ss.Poll().Get(poll.Id)
+	require.NotNil(t, err)
+
+	votes, err := ss.Poll().GetVotes(poll.Id)
+	require.Nil(t, err)
+	assert.Empty(t, votes)
+
+	_, err = ss.Poll().Get(other.Id)
+	require.Nil(t, err)
+}
+
+func testPollStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	created, err := ss.Poll().Save(newTestPoll(model.NewId(), userId))
+	require.Nil(t, err)
+	_, err = ss.Poll().SaveVote(&model.PollVote{PollId: created.Id, UserId: model.NewId(), Option: 0})
+	require.Nil(t, err)
+
+	voted, err := ss.Poll().Save(newTestPoll(model.NewId(), model.NewId()))
+	require.Nil(t, err)
+	_, err = ss.Poll().SaveVote(&model.PollVote{PollId: voted.Id, UserId: userId, Option: 0})
+	require.Nil(t, err)
+	_, err = ss.Poll().SaveVote(&model.PollVote{PollId: voted.Id, UserId: model.NewId(), Option: 1})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.Poll().PermanentDeleteByUser(userId))
+
+	_, err = ss.Poll().Get(created.Id)
+	require.NotNil(t, err)
+
+	votes, err := ss.Poll().GetVotes(created.Id)
+	require.Nil(t, err)
+	assert.Empty(t, votes)
+
+	votes, err = ss.Poll().GetVotes(voted.Id)
+	require.Nil(t, err)
+	require.Len(t, votes, 1)
+	assert.NotEqual(t, userId, votes[0].UserId)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPinEventStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetForChannel", func(t *testing.T) { testPostPinEventStoreSaveGetForChannel(t, ss) })
+	t.Run("PermanentDeleteByChannel", func(t *testing.T) { testPostPinEventStorePermanentDeleteByChannel(t, ss) })
+}
+
+func testPostPinEventStoreSaveGetForChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	postId := model.NewId()
+	now := model.GetMillis()
+
+	unpinned, err := ss.PostPinEvent().Save(&model.PostPinEvent{PostId: postId, ChannelId: channelId, UserId: model.NewId(), CreateAt: now + 1000})
+	require.Nil(t, err)
+	pinned, err := ss.PostPinEvent().Save(&model.PostPinEvent{PostId: postId, ChannelId: channelId, UserId: model.NewId(), Pinned: true, CreateAt: now})
+	require.Nil(t, err)
+	_, err = ss.PostPinEvent().Save(&model.PostPinEvent{PostId: postId, ChannelId: channelId, UserId: model.NewId(), Pinned: true, CreateAt: now + 5000})
+	require.Nil(t, err)
+	_, err = ss.PostPinEvent().Save(&model.PostPinEvent{PostId: model.NewId(), ChannelId: model.NewId(), UserId: model.NewId(), Pinned: true, CreateAt: now})
+	require.Nil(t, err)
+
+	_, err = ss.PostPinEvent().Save(&model.PostPinEvent{PostId: postId, ChannelId: channelId})
+	require.NotNil(t, err)
+
+	events, err := ss.PostPinEvent().GetForChannel(channelId, now, now+1000)
+	require.Nil(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, pinned.Id, events[0].Id)
+	assert.True(t, events[0].Pinned)
+	assert.Equal(t, unpinned.Id, events[1].Id)
+	assert.False(t, events[1].Pinned)
+}
+
+func testPostPinEventStorePermanentDeleteByChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	otherChannelId := model.NewId()
+	now := model.GetMillis()
+
+	_, err := ss.PostPinEvent().Save(&model.PostPinEvent{PostId: model.NewId(), ChannelId: channelId, UserId: model.NewId(), Pinned: true, CreateAt: now})
+	require.Nil(t, err)
+	_, err = ss.PostPinEvent().Save(&model.PostPinEvent{PostId: model.NewId(), ChannelId: otherChannelId, UserId: model.NewId(), Pinned: true, CreateAt: now})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.PostPinEvent().PermanentDeleteByChannel(channelId))
+
+	events, err := ss.PostPinEvent().GetForChannel(channelId, now, now)
+	require.Nil(t, err)
+	assert.Empty(t, events)
+
+	events, err = ss.PostPinEvent().GetForChannel(otherChannelId, now, now)
+	require.Nil(t, err)
+	assert.Len(t, events, 1)
+}
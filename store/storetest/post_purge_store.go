@@ -0,0 +1,146 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPurgeStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdate", func(t *testing.T) { testPostPurgeStoreSaveGetUpdate(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testPostPurgeStoreGetAll(t, ss) })
+	t.Run("GetDueAndClaim", func(t *testing.T) { testPostPurgeStoreGetDueAndClaim(t, ss) })
+	t.Run("ResetStale", func(t *testing.T) { testPostPurgeStoreResetStale(t, ss) })
+}
+
+func newTestPostPurge(purgeAt int64) *model.PostPurge {
+	return &model.PostPurge{
+		CreatorId: model.NewId(),
+		Scope:     model.POST_PURGE_SCOPE_POST,
+		TargetId:  model.NewId(),
+		ChannelId: model.NewId(),
+		PurgeAt:   purgeAt,
+	}
+}
+
+func testPostPurgeStoreSaveGetUpdate(t *testing.T, ss store.Store) {
+	purge, err := ss.PostPurge().Save(newTestPostPurge(0))
+	require.Nil(t, err)
+	assert.Len(t, purge.Id, 26)
+	assert.Equal(t, model.POST_PURGE_STATUS_PENDING, purge.Status)
+	assert.Equal(t, purge.CreateAt, purge.PurgeAt)
+
+	_, err = ss.PostPurge().Save(purge)
+	require.NotNil(t, err)
+
+	purge.Status = model.POST_PURGE_STATUS_CANCELED
+	purge.FinishAt = model.GetMillis()
+	_, err = ss.PostPurge().Update(purge)
+	require.Nil(t, err)
+
+	received, err := ss.PostPurge().Get(purge.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.POST_PURGE_STATUS_CANCELED, received.Status)
+	assert.Equal(t, purge.FinishAt, received.FinishAt)
+
+	_, err = ss.PostPurge().Get(model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testPostPurgeStoreGetAll(t *testing.T, ss store.Store) {
+	purge1, err := ss.PostPurge().Save(newTestPostPurge(0))
+	require.Nil(t, err)
+
+	purge2, err := ss.PostPurge().Save(newTestPostPurge(0))
+	require.Nil(t, err)
+
+	ids := map[string]bool{}
+	for page := 0; ; page++ {
+		purges, err := ss.PostPurge().GetAll(page*10, 10)
+		require.Nil(t, err)
+
+		for _, purge := range purges {
+			ids[purge.Id] = true
+		}
+
+		if len(purges) < 10 {
+			break
+		}
+	}
+	assert.True(t, ids[purge1.Id])
+	assert.True(t, ids[purge2.Id])
+}
+
+func testPostPurgeStoreGetDueAndClaim(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	due, err := ss.PostPurge().Save(newTestPostPurge(0))
+	require.Nil(t, err)
+
+	later, err := ss.PostPurge().Save(newTestPostPurge(now + 60*60*1000))
+	require.Nil(t, err)
+
+	isDue := func(id string) bool {
+		purges, err := ss.PostPurge().GetDue(now+1000, 1000)
+		require.Nil(t, err)
+
+		for _, purge := range purges {
+			if purge.Id == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.True(t, isDue(due.Id))
+	assert.False(t, isDue(later.Id))
+
+	claimed, err := ss.PostPurge().Claim(due.Id)
+	require.Nil(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = ss.PostPurge().Claim(due.Id)
+	require.Nil(t, err)
+	assert.False(t, claimed)
+
+	assert.False(t, isDue(due.Id))
+}
+
+func testPostPurgeStoreResetStale(t *testing.T, ss store.Store) {
+	purge, err := ss.PostPurge().Save(newTestPostPurge(0))
+	require.Nil(t, err)
+
+	claimed, err := ss.PostPurge().Claim(purge.Id)
+	require.Nil(t, err)
+	require.True(t, claimed)
+
+	purge, err = ss.PostPurge().Get(purge.Id)
+	require.Nil(t, err)
+
+	_, err = ss.PostPurge().ResetStale(purge.UpdateAt)
+	require.Nil(t, err)
+
+	purge, err = ss.PostPurge().Get(purge.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.POST_PURGE_STATUS_RUNNING, purge.Status)
+
+	count, err := ss.PostPurge().ResetStale(purge.UpdateAt + 1)
+	require.Nil(t, err)
+	assert.True(t, count >= 1)
+
+	purge, err = ss.PostPurge().Get(purge.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.POST_PURGE_STATUS_PENDING, purge.Status)
+
+	claimed, err = ss.PostPurge().Claim(purge.Id)
+	require.Nil(t, err)
+	assert.True(t, claimed)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostReportStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdate", func(t *testing.T) { testPostReportStoreSaveGetUpdate(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testPostReportStoreGetAll(t, ss) })
+	t.Run("Events", func(t *testing.T) { testPostReportStoreEvents(t, ss) })
+}
+
+func newTestPostReport(reason string) *model.PostReport {
+	post := &model.Post{
+		Id:        model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		CreateAt:  model.GetMillis(),
+		Message:   "reported message",
+	}
+	return model.NewPostReport(post, model.NewId(), reason, "comment")
+}
+
+func testPostReportStoreSaveGetUpdate(t *testing.T, ss store.Store) {
+	report, err := ss.PostReport().Save(newTestPostReport(model.POST_REPORT_REASON_SPAM))
+	require.Nil(t, err)
+	assert.Len(t, report.Id, 26)
+	assert.Equal(t, model.POST_REPORT_STATE_OPEN, report.State)
+
+	_, err = ss.PostReport().Save(report)
+	require.NotNil(t, err)
+
+	_, err = ss.PostReport().Save(newTestPostReport("junk"))
+	require.NotNil(t, err)
+
+	fetched, err := ss.PostReport().Get(report.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "reported message", fetched.PostMessage)
+
+	fetched, err = ss.PostReport().GetOpenForPostAndReporter(report.PostId, report.ReporterId)
+	require.Nil(t, err)
+	assert.Equal(t, report.Id, fetched.Id)
+
+	_, err = ss.PostReport().GetOpenForPostAndReporter(report.PostId, model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	report.State = model.POST_REPORT_STATE_DISMISSED
+	report.ResolverId = model.NewId()
+	_, err = ss.PostReport().Update(report, model.POST_REPORT_STATE_OPEN)
+	require.Nil(t, err)
+
+	fetched, err = ss.PostReport().Get(report.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.POST_REPORT_STATE_DISMISSED, fetched.State)
+	assert.Equal(t, report.ResolverId, fetched.ResolverId)
+
+	_, err = ss.PostReport().GetOpenForPostAndReporter(report.PostId, report.ReporterId)
+	require.NotNil(t, err)
+
+	// The report was already resolved, so it's no longer open
+	report.State = model.POST_REPORT_STATE_WARNED
+	_, err = ss.PostReport().Update(report, model.POST_REPORT_STATE_OPEN)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+
+	_, err = ss.PostReport().Get(model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testPostReportStoreGetAll(t *testing.T, ss store.Store) {
+	open, err := ss.PostReport().Save(newTestPostReport(model.POST_REPORT_REASON_HARASSMENT))
+	require.Nil(t, err)
+
+	resolved, err := ss.PostReport().Save(newTestPostReport(model.POST_REPORT_REASON_OTHER))
+	require.Nil(t, err)
+	resolved.State = model.POST_REPORT_STATE_DELETED
+	_, err = ss.PostReport().Update(resolved, model.POST_REPORT_STATE_OPEN)
+	require.Nil(t, err)
+
+	reports, err := ss.PostReport().GetAll(model.POST_REPORT_STATE_OPEN, 0, 1000)
+	require.Nil(t, err)
+	ids := postReportIds(reports)
+	assert.Contains(t, ids, open.Id)
+	assert.NotContains(t, ids, resolved.Id)
+
+	reports, err = ss.PostReport().GetAll(model.POST_REPORT_STATE_DELETED, 0, 1000)
+	require.Nil(t, err)
+	ids = postReportIds(reports)
+	assert.Contains(t, ids, resolved.Id)
+	assert.NotContains(t, ids, open.Id)
+
+	reports, err = ss.PostReport().GetAll("", 0, 1000)
+	require.Nil(t, err)
+	ids = postReportIds(reports)
+	assert.Contains(t, ids, open.Id)
+	assert.Contains(t, ids, resolved.Id)
+}
+
+func testPostReportStoreEvents(t *testing.T, ss store.Store) {
+	reportId := model.NewId()
+	actorId := model.NewId()
+
+	_, err := ss.PostReport().SaveEvent(&model.PostReportEvent{CreateAt: 1000, ReportId: reportId, ActorId: actorId, ToState: model.POST_REPORT_STATE_OPEN})
+	require.Nil(t, err)
+
+	_, err = ss.PostReport().SaveEvent(&model.PostReportEvent{ReportId: reportId, ActorId: actorId, FromState: model.POST_REPORT_STATE_OPEN, ToState: "junk"})
+	require.NotNil(t, err)
+
+	_, err = ss.PostReport().SaveEvent(&model.PostReportEvent{CreateAt: 2000, ReportId: reportId, ActorId: actorId, FromState: model.POST_REPORT_STATE_OPEN, ToState: model.POST_REPORT_STATE_WARNED, Comment: "warned"})
+	require.Nil(t, err)
+
+	events, err := ss.PostReport().GetEvents(reportId)
+	require.Nil(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, model.POST_REPORT_STATE_OPEN, events[0].ToState)
+	assert.Equal(t, model.POST_REPORT_STATE_WARNED, events[1].ToState)
+	assert.Equal(t, "warned", events[1].Comment)
+
+	events, err = ss.PostReport().GetEvents(model.NewId())
+	require.Nil(t, err)
+	assert.Empty(t, events)
+}
+
+func postReportIds(reports []*model.PostReport) []string {
+	ids := make([]string, 0, len(reports))
+	for _, report := range reports {
+		ids = append(ids, report.Id)
+	}
+	return ids
+}
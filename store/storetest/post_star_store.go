@@ -0,0 +1,106 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostStarStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testPostStarStoreSaveGetDelete(t, ss) })
+	t.Run("GetCounts", func(t *testing.T) { testPostStarStoreGetCounts(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPostStarStorePermanentDeleteByUser(t, ss) })
+}
+
+func testPostStarStoreSaveGetDelete(t *testing.T, ss store.Store) {
+	star := &model.PostStar{
+		PostId: model.NewId(),
+		UserId: model.NewId(),
+	}
+
+	saved, err := ss.PostStar().Save(star)
+	require.Nil(t, err)
+	assert.NotZero(t, saved.CreateAt)
+
+	got, err := ss.PostStar().Get(star.PostId, star.UserId)
+	require.Nil(t, err)
+	assert.Equal(t, saved.CreateAt, got.CreateAt)
+
+	// Starring a post twice keeps the original star.
+	again, err := ss.PostStar().Save(&model.PostStar{PostId: star.PostId, UserId: star.UserId, CreateAt: saved.CreateAt + 1000})
+	require.Nil(t, err)
+	assert.Equal(t, saved.CreateAt, again.CreateAt)
+
+	_, err = ss.PostStar().Save(&model.PostStar{PostId: star.PostId})
+	require.NotNil(t, err)
+
+	require.Nil(t, ss.PostStar().Delete(star.PostId, star.UserId))
+
+	_, err = ss.PostStar().Get(star.PostId, star.UserId)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	// Unstarring a post that isn't starred isn't an error.
+	require.Nil(t, ss.PostStar().Delete(star.PostId, star.UserId))
+}
+
+func testPostStarStoreGetCounts(t *testing.T, ss store.Store) {
+	postId1 := model.NewId()
+	postId2 := model.NewId()
+	postId3 := model.NewId()
+
+	for i := 0; i < 3; i++ {
+		_, err := ss.PostStar().Save(&model.PostStar{PostId: postId1, UserId: model.NewId()})
+		require.Nil(t, err)
+	}
+	_, err := ss.PostStar().Save(&model.PostStar{PostId: postId2, UserId: model.NewId()})
+	require.Nil(t, err)
+
+	count, err := ss.PostStar().GetCountForPost(postId1)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = ss.PostStar().GetCountForPost(postId3)
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+
+	counts, err := ss.PostStar().GetCountsForPosts([]string{postId1, postId2, postId3})
+	require.Nil(t, err)
+	require.Len(t, counts, 2)
+
+	byPost := map[string]int64{}
+	for _, c := range counts {
+		byPost[c.PostId] = c.Count
+	}
+	assert.Equal(t, map[string]int64{postId1: 3, postId2: 1}, byPost)
+
+	counts, err = ss.PostStar().GetCountsForPosts([]string{})
+	require.Nil(t, err)
+	assert.Empty(t, counts)
+}
+
+func testPostStarStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	postId := model.NewId()
+
+	_, err := ss.PostStar().Save(&model.PostStar{PostId: postId, UserId: userId})
+	require.Nil(t, err)
+	_, err = ss.PostStar().Save(&model.PostStar{PostId: postId, UserId: model.NewId()})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.PostStar().PermanentDeleteByUser(userId))
+
+	_, err = ss.PostStar().Get(postId, userId)
+	require.NotNil(t, err)
+
+	count, err := ss.PostStar().GetCountForPost(postId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+}
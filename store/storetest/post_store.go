@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -22,6 +23,7 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("SaveAndUpdateChannelMsgCounts", func(t *testing.T) { testPostStoreSaveChannelMsgCounts(t, ss) })
 	t.Run("Get", func(t *testing.T) { testPostStoreGet(t, ss) })
 	t.Run("GetSingle", func(t *testing.T) { testPostStoreGetSingle(t, ss) })
+	t.Run("GetSingleFromMaster", func(t *testing.T) { testPostStoreGetSingleFromMaster(t, ss) })
 	t.Run("GetEtagCache", func(t *testing.T) { testGetEtagCache(t, ss) })
 	t.Run("Update", func(t *testing.T) { testPostStoreUpdate(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testPostStoreDelete(t, ss) })
@@ -52,6 +54,11 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetDirectPostParentsForExportAfter", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfter(t, ss, s) })
 	t.Run("GetDirectPostParentsForExportAfterDeleted", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfterDeleted(t, ss, s) })
 	t.Run("GetDirectPostParentsForExportAfterBatched", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfterBatched(t, ss, s) })
+	t.Run("GetForPurgeAndPermanentDeleteForPurge", func(t *testing.T) { testPostStoreGetForPurgeAndPermanentDeleteForPurge(t, ss) })
+	t.Run("GetByUserAfter", func(t *testing.T) { testPostStoreGetByUserAfter(t, ss) })
+	t.Run("OverwriteProps", func(t *testing.T) { testPostStoreOverwriteProps(t, ss) })
+	t.Run("GetForChannelAfter", func(t *testing.T) { testPostStoreGetForChannelAfter(t, ss) })
+	t.Run("GetThreadParticipantCounts", func(t *testing.T) { testPostStoreGetThreadParticipantCounts(t, ss) })
 }
 
 func testPostStoreSave(t *testing.T, ss store.Store) {
@@ -173,6 +180,18 @@ func testPostStoreGetSingle(t *testing.T, ss store.Store) {
 	}
 }
 
+func testPostStoreGetSingleFromMaster(t *testing.T, ss store.Store) {
+	o1, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: model.NewId(), Message: "zz" + model.NewId() + "b"})
+	require.Nil(t, err)
+
+	post, err := ss.Post().GetSingleFromMaster(o1.Id)
+	require.Nil(t, err)
+	assert.Equal(t, o1.CreateAt, post.CreateAt)
+
+	_, err = ss.Post().GetSingleFromMaster("123")
+	assert.NotNil(t, err)
+}
+
 func testGetEtagCache(t *testing.T, ss store.Store) {
 	o1 := &model.Post{}
 	o1.ChannelId = model.NewId()
@@ -1497,7 +1516,7 @@ func testPostStoreSearch(t *testing.T, ss store.Store) {
 	}
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			result, err := ss.Post().Search(teamId, userId, tc.searchParams)
+			result, err := ss.Post().Search(context.Background(), teamId, userId, tc.searchParams)
 			require.Nil(t, err)
 			require.Len(t, result.Order, tc.expectedResultsCount)
 			for _, expectedMessageResultId := range tc.expectedMessageResultIds {
@@ -2414,6 +2433,10 @@ func testPostStoreGetPostsBatchForIndexing(t *testing.T, ss store.Store) {
 			}
 		}
 	}
+
+	r, err := ss.Post().GetPostsBatchForIndexing(o1.CreateAt, model.GetMillis()+100000, 1)
+	require.Nil(t, err)
+	require.Len(t, r, 1)
 }
 
 func testPostStorePermanentDeleteBatch(t *testing.T, ss store.Store) {
@@ -2807,3 +2830,209 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, ss sto
 	// Manually truncate Channels table until testlib can handle cleanups
 	s.GetMaster().Exec("TRUNCATE Channels")
 }
+
+func testPostStoreGetForPurgeAndPermanentDeleteForPurge(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	root, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: model.NewId(), Message: "root"})
+	require.Nil(t, err)
+
+	reply, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: model.NewId(), RootId: root.Id, ParentId: root.Id, Message: "reply"})
+	require.Nil(t, err)
+
+	other, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: model.NewId(), Message: "other"})
+	require.Nil(t, err)
+
+	getIds := func(scope, targetId string) []string {
+		posts, err := ss.Post().GetForPurge(scope, targetId, 100)
+		require.Nil(t, err)
+
+		ids := []string{}
+		for _, post := range posts {
+			ids = append(ids, post.Id)
+		}
+		return ids
+	}
+
+	assert.ElementsMatch(t, []string{reply.Id}, getIds(model.POST_PURGE_SCOPE_POST, reply.Id))
+	assert.ElementsMatch(t, []string{root.Id, reply.Id}, getIds(model.POST_PURGE_SCOPE_THREAD, root.Id))
+	assert.ElementsMatch(t, []string{root.Id, reply.Id, other.Id}, getIds(model.POST_PURGE_SCOPE_CHANNEL, channelId))
+
+	posts, err := ss.Post().GetForPurge(model.POST_PURGE_SCOPE_CHANNEL, channelId, 1)
+	require.Nil(t, err)
+	assert.Len(t, posts, 1)
+
+	flaggerId := model.NewId()
+	require.Nil(t, ss.Preference().Save(&model.Preferences{
+		{UserId: flaggerId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: root.Id, Value: "true"},
+		{UserId: flaggerId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: other.Id, Value: "true"},
+	}))
+
+	report, err := ss.PostReport().Save(model.NewPostReport(root, model.NewId(), model.POST_REPORT_REASON_SPAM, ""))
+	require.Nil(t, err)
+
+	pendingReply, err := ss.PendingPost().Save(&model.PendingPost{UserId: model.NewId(), ChannelId: channelId, RootId: root.Id, ParentId: root.Id, Message: "pending reply"})
+	require.Nil(t, err)
+
+	err = ss.Post().PermanentDeleteForPurge([]string{root.Id, reply.Id})
+	require.Nil(t, err)
+
+	assert.ElementsMatch(t, []string{other.Id}, getIds(model.POST_PURGE_SCOPE_CHANNEL, channelId))
+
+	_, err = ss.Preference().Get(flaggerId, model.PREFERENCE_CATEGORY_FLAGGED_POST, root.Id)
+	assert.NotNil(t, err)
+	_, err = ss.Preference().Get(flaggerId, model.PREFERENCE_CATEGORY_FLAGGED_POST, other.Id)
+	assert.Nil(t, err)
+
+	_, err = ss.PostReport().Get(report.Id)
+	assert.NotNil(t, err)
+
+	_, err = ss.PendingPost().Get(pendingReply.Id)
+	assert.NotNil(t, err)
+
+	err = ss.Post().PermanentDeleteForPurge(nil)
+	require.Nil(t, err)
+}
+
+func testPostStoreGetByUserAfter(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	userId := model.NewId()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		post, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: userId, Message: "message"})
+		require.Nil(t, err)
+		ids = append(ids, post.Id)
+	}
+	sort.Strings(ids)
+
+	_, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: model.NewId(), Message: "other"})
+	require.Nil(t, err)
+
+	posts, err := ss.Post().GetByUserAfter(userId, "", 2)
+	require.Nil(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, ids[0], posts[0].Id)
+	assert.Equal(t, ids[1], posts[1].Id)
+
+	posts, err = ss.Post().GetByUserAfter(userId, posts[1].Id, 2)
+	require.Nil(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, ids[2], posts[0].Id)
+
+	// Old enough to be the only post archived
+	archived, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: userId, Message: "archived", CreateAt: 1000})
+	require.Nil(t, err)
+	_, err = ss.Post().ArchiveBatch(2000, 10)
+	require.Nil(t, err)
+
+	posts, err = ss.Post().GetByUserAfter(userId, "", 10)
+	require.Nil(t, err)
+	require.Len(t, posts, 4)
+
+	found := false
+	for _, post := range posts {
+		if post.Id == archived.Id {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func testPostStoreOverwriteProps(t *testing.T, ss store.Store) {
+	post, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: model.NewId(), Message: "message", Props: model.StringInterface{"override_username": "someone"}})
+	require.Nil(t, err)
+
+	archived, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: model.NewId(), Message: "archived", Props: model.StringInterface{"override_username": "someone"}, CreateAt: 1000})
+	require.Nil(t, err)
+	_, err = ss.Post().ArchiveBatch(2000, 10)
+	require.Nil(t, err)
+
+	for _, p := range []*model.Post{post, archived} {
+		p.Props = model.StringInterface{"username": "anonymous"}
+		require.Nil(t, ss.Post().OverwriteProps(p))
+	}
+
+	got, err := ss.Post().GetSingle(post.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.StringInterface{"username": "anonymous"}, got.Props)
+
+	posts, err := ss.Post().GetByUserAfter(archived.UserId, "", 10)
+	require.Nil(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, model.StringInterface{"username": "anonymous"}, posts[0].Props)
+}
+
+func testPostStoreGetForChannelAfter(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	createAt := model.GetMillis() - 10000
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		post, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: model.NewId(), Message: "message", CreateAt: createAt})
+		require.Nil(t, err)
+		ids = append(ids, post.Id)
+	}
+	sort.Strings(ids)
+
+	later, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: model.NewId(), Message: "later", CreateAt: createAt + 1000})
+	require.Nil(t, err)
+
+	_, err = ss.Post().Save(&model.Post{ChannelId: channelId, UserId: model.NewId(), Message: "too late", CreateAt: createAt + 2000})
+	require.Nil(t, err)
+
+	posts, err := ss.Post().GetForChannelAfter(channelId, createAt, "", createAt+1000, 2)
+	require.Nil(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, ids[0], posts[0].Id)
+	assert.Equal(t, ids[1], posts[1].Id)
+
+	posts, err = ss.Post().GetForChannelAfter(channelId, posts[1].CreateAt, posts[1].Id, createAt+1000, 2)
+	require.Nil(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, ids[2], posts[0].Id)
+	assert.Equal(t, later.Id, posts[1].Id)
+
+	posts, err = ss.Post().GetForChannelAfter(channelId, posts[1].CreateAt, posts[1].Id, createAt+1000, 2)
+	require.Nil(t, err)
+	assert.Empty(t, posts)
+}
+
+func testPostStoreGetThreadParticipantCounts(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	userId := model.NewId()
+	otherUserId1 := model.NewId()
+	otherUserId2 := model.NewId()
+
+	save := func(userId, rootId string, createAt int64) *model.Post {
+		post, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: userId, RootId: rootId, ParentId: rootId, Message: "message", CreateAt: createAt})
+		require.Nil(t, err)
+		return post
+	}
+
+	// The user replied to a thread started by another user, who replied to it too
+	root1 := save(otherUserId1, "", 1000)
+	save(userId, root1.Id, 2000)
+	save(otherUserId1, root1.Id, 3000)
+
+	// Another user replied to a thread the user started
+	root2 := save(userId, "", 4000)
+	save(otherUserId1, root2.Id, 5000)
+	save(otherUserId2, root2.Id, 6000)
+
+	// The user posted in this thread before the time the threads are looked for from
+	root3 := save(otherUserId2, "", 10)
+	save(userId, root3.Id, 20)
+
+	counts, err := ss.Post().GetThreadParticipantCounts(userId, 100, 10)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]int64{otherUserId1: 2, otherUserId2: 1}, counts)
+
+	counts, err = ss.Post().GetThreadParticipantCounts(userId, 100, 1)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]int64{otherUserId1: 2}, counts)
+
+	counts, err = ss.Post().GetThreadParticipantCounts(otherUserId2, 0, 10)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]int64{userId: 2, otherUserId1: 1}, counts)
+}
@@ -17,12 +17,16 @@ func TestPreferenceStore(t *testing.T, ss store.Store) {
 	t.Run("PreferenceSave", func(t *testing.T) { testPreferenceSave(t, ss) })
 	t.Run("PreferenceGet", func(t *testing.T) { testPreferenceGet(t, ss) })
 	t.Run("PreferenceGetCategory", func(t *testing.T) { testPreferenceGetCategory(t, ss) })
+	t.Run("PreferenceGetCategoryAndName", func(t *testing.T) { testPreferenceGetCategoryAndName(t, ss) })
 	t.Run("PreferenceGetAll", func(t *testing.T) { testPreferenceGetAll(t, ss) })
 	t.Run("PreferenceDeleteByUser", func(t *testing.T) { testPreferenceDeleteByUser(t, ss) })
 	t.Run("PreferenceDelete", func(t *testing.T) { testPreferenceDelete(t, ss) })
 	t.Run("PreferenceDeleteCategory", func(t *testing.T) { testPreferenceDeleteCategory(t, ss) })
 	t.Run("PreferenceDeleteCategoryAndName", func(t *testing.T) { testPreferenceDeleteCategoryAndName(t, ss) })
 	t.Run("PreferenceCleanupFlagsBatch", func(t *testing.T) { testPreferenceCleanupFlagsBatch(t, ss) })
+	t.Run("PreferenceVersions", func(t *testing.T) { testPreferenceVersions(t, ss) })
+	t.Run("PreferenceGetNamespaces", func(t *testing.T) { testPreferenceGetNamespaces(t, ss) })
+	t.Run("PreferencePatch", func(t *testing.T) { testPreferencePatch(t, ss) })
 }
 
 func testPreferenceSave(t *testing.T, ss store.Store) {
@@ -158,6 +162,54 @@ func testPreferenceGetCategory(t *testing.T, ss store.Store) {
 	}
 }
 
+func testPreferenceGetCategoryAndName(t *testing.T, ss store.Store) {
+	category := model.NewId()
+	name := model.NewId()
+	userId := model.NewId()
+	userId2 := model.NewId()
+
+	preferences := model.Preferences{
+		{
+			UserId:   userId,
+			Category: category,
+			Name:     name,
+			Value:    "value1a",
+		},
+		{
+			UserId:   userId2,
+			Category: category,
+			Name:     name,
+			Value:    "value1b",
+		},
+		// same category, different name
+		{
+			UserId:   userId,
+			Category: category,
+			Name:     model.NewId(),
+			Value:    "value2",
+		},
+		// different category, same name
+		{
+			UserId:   userId2,
+			Category: model.NewId(),
+			Name:     name,
+			Value:    "value3",
+		},
+	}
+
+	err := ss.Preference().Save(&preferences)
+	require.Nil(t, err)
+
+	result, err := ss.Preference().GetCategoryAndName(category, name)
+	require.Nil(t, err)
+	assert.Len(t, result, 2, "should've returned 2 preferences")
+
+	for _, preference := range result {
+		assert.Equal(t, category, preference.Category)
+		assert.Equal(t, name, preference.Name)
+	}
+}
+
 func testPreferenceGetAll(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 	category := model.PREFERENCE_CATEGORY_DIRECT_CHANNEL_SHOW
@@ -384,3 +436,129 @@ func testPreferenceCleanupFlagsBatch(t *testing.T, ss store.Store) {
 	_, err = ss.Preference().Get(userId, category, preference2.Name)
 	assert.NotNil(t, err)
 }
+
+func testPreferenceVersions(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	category := model.NewId()
+	name := model.NewId()
+
+	versions, err := ss.Preference().GetVersions(userId)
+	require.Nil(t, err)
+	assert.Empty(t, versions)
+
+	err = ss.Preference().Save(&model.Preferences{
+		{UserId: userId, Category: category, Name: name, Value: "a"},
+		{UserId: userId, Category: category, Name: model.NewId(), Value: "b"},
+	})
+	require.Nil(t, err)
+
+	versions, err = ss.Preference().GetVersions(userId)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]int64{category: 1}, versions, "a save should bump the version once per namespace")
+
+	require.Nil(t, ss.Preference().Delete(userId, category, name))
+	require.Nil(t, ss.Preference().Delete(userId, category, name))
+
+	versions, err = ss.Preference().GetVersions(userId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), versions[category], "deleting a missing preference should not bump the version")
+
+	require.Nil(t, ss.Preference().DeleteCategory(userId, category))
+
+	versions, err = ss.Preference().GetVersions(userId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), versions[category])
+
+	require.Nil(t, ss.Preference().PermanentDeleteByUser(userId))
+
+	versions, err = ss.Preference().GetVersions(userId)
+	require.Nil(t, err)
+	assert.Empty(t, versions)
+}
+
+func testPreferenceGetNamespaces(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	category1 := model.NewId()
+	category2 := model.NewId()
+	missing := model.NewId()
+
+	err := ss.Preference().Save(&model.Preferences{
+		{UserId: userId, Category: category1, Name: "a", Value: "1"},
+		{UserId: userId, Category: category2, Name: "b", Value: "2"},
+	})
+	require.Nil(t, err)
+
+	namespaces, err := ss.Preference().GetNamespaces(userId, nil)
+	require.Nil(t, err)
+	require.Len(t, namespaces, 2)
+	assert.Equal(t, int64(1), namespaces[category1].Version)
+	assert.Equal(t, map[string]string{"a": "1"}, namespaces[category1].Preferences)
+
+	namespaces, err = ss.Preference().GetNamespaces(userId, []string{category2, missing})
+	require.Nil(t, err)
+	require.Len(t, namespaces, 2)
+	assert.Equal(t, map[string]string{"b": "2"}, namespaces[category2].Preferences)
+	assert.Equal(t, int64(0), namespaces[missing].Version)
+	assert.Empty(t, namespaces[missing].Preferences)
+}
+
+func testPreferencePatch(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	category := model.NewId()
+
+	err := ss.Preference().Save(&model.Preferences{
+		{UserId: userId, Category: category, Name: "same", Value: "1"},
+		{UserId: userId, Category: category, Name: "changed", Value: "1"},
+		{UserId: userId, Category: category, Name: "deleted", Value: "1"},
+	})
+	require.Nil(t, err)
+
+	diffs, err := ss.Preference().Patch(userId, model.PreferencesPatch{
+		category: {
+			Set:    map[string]string{"same": "1", "changed": "2", "added": "3"},
+			Delete: []string{"deleted", "missing"},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, category, diffs[0].Namespace)
+	assert.Equal(t, int64(2), diffs[0].Version)
+	assert.Equal(t, map[string]string{"changed": "2", "added": "3"}, diffs[0].Set)
+	assert.Equal(t, []string{"deleted"}, diffs[0].Deleted)
+
+	namespaces, err := ss.Preference().GetNamespaces(userId, []string{category})
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{"same": "1", "changed": "2", "added": "3"}, namespaces[category].Preferences)
+
+	t.Run("no-op patch keeps the version", func(t *testing.T) {
+		diffs, err := ss.Preference().Patch(userId, model.PreferencesPatch{
+			category: {Set: map[string]string{"same": "1"}},
+		})
+		require.Nil(t, err)
+		require.Len(t, diffs, 1)
+		assert.True(t, diffs[0].IsEmpty())
+		assert.Equal(t, int64(2), diffs[0].Version)
+	})
+
+	t.Run("stale base version", func(t *testing.T) {
+		baseVersion := int64(1)
+		_, err := ss.Preference().Patch(userId, model.PreferencesPatch{
+			category: {Set: map[string]string{"same": "2"}, BaseVersion: &baseVersion},
+		})
+		require.NotNil(t, err)
+		assert.Equal(t, "store.sql_preference.patch.conflict.app_error", err.Id)
+
+		preference, err := ss.Preference().Get(userId, category, "same")
+		require.Nil(t, err)
+		assert.Equal(t, "1", preference.Value)
+	})
+
+	t.Run("current base version", func(t *testing.T) {
+		baseVersion := int64(2)
+		diffs, err := ss.Preference().Patch(userId, model.PreferencesPatch{
+			category: {Set: map[string]string{"same": "2"}, BaseVersion: &baseVersion},
+		})
+		require.Nil(t, err)
+		assert.Equal(t, int64(3), diffs[0].Version)
+	})
+}
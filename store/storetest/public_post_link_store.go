@@ -0,0 +1,66 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicPostLinkStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testPublicPostLinkStoreSaveGet(t, ss) })
+	t.Run("GetForPostAndRevoke", func(t *testing.T) { testPublicPostLinkStoreGetForPostAndRevoke(t, ss) })
+}
+
+func testPublicPostLinkStoreSaveGet(t *testing.T, ss store.Store) {
+	link, err := ss.PublicPostLink().Save(&model.PublicPostLink{CreatorId: model.NewId(), PostId: model.NewId(), ChannelId: model.NewId(), IncludeThread: true})
+	require.Nil(t, err)
+	assert.Len(t, link.Id, 26)
+
+	_, err = ss.PublicPostLink().Save(link)
+	require.NotNil(t, err)
+
+	received, err := ss.PublicPostLink().Get(link.Id)
+	require.Nil(t, err)
+	assert.Equal(t, link, received)
+
+	_, err = ss.PublicPostLink().Get(model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testPublicPostLinkStoreGetForPostAndRevoke(t *testing.T, ss store.Store) {
+	postId := model.NewId()
+
+	link1, err := ss.PublicPostLink().Save(&model.PublicPostLink{CreatorId: model.NewId(), PostId: postId, ChannelId: model.NewId()})
+	require.Nil(t, err)
+
+	link2, err := ss.PublicPostLink().Save(&model.PublicPostLink{CreatorId: model.NewId(), PostId: postId, ChannelId: model.NewId()})
+	require.Nil(t, err)
+
+	_, err = ss.PublicPostLink().Save(&model.PublicPostLink{CreatorId: model.NewId(), PostId: model.NewId(), ChannelId: model.NewId()})
+	require.Nil(t, err)
+
+	links, err := ss.PublicPostLink().GetForPost(postId)
+	require.Nil(t, err)
+	require.Len(t, links, 2)
+
+	err = ss.PublicPostLink().Revoke(link1.Id, model.GetMillis())
+	require.Nil(t, err)
+
+	links, err = ss.PublicPostLink().GetForPost(postId)
+	require.Nil(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, link2.Id, links[0].Id)
+
+	// Revoked links can still be looked up, so that they can be told apart from links that never existed
+	revoked, err := ss.PublicPostLink().Get(link1.Id)
+	require.Nil(t, err)
+	assert.True(t, revoked.IsRevoked())
+}
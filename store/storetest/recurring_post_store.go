@@ -0,0 +1,106 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurringPostStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testRecurringPostStoreSaveGetUpdateDelete(t, ss) })
+	t.Run("GetDue", func(t *testing.T) { testRecurringPostStoreGetDue(t, ss) })
+}
+
+func newTestRecurringPost(channelId string) *model.RecurringPost {
+	return &model.RecurringPost{
+		ChannelId: channelId,
+		CreatorId: model.NewId(),
+		BotUserId: model.NewId(),
+		Message:   "standup",
+		Schedule:  "0 9 * * 1-5",
+	}
+}
+
+func testRecurringPostStoreSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	recurringPost, err := ss.RecurringPost().Save(newTestRecurringPost(channelId))
+	require.Nil(t, err)
+	assert.Len(t, recurringPost.Id, 26)
+
+	_, err = ss.RecurringPost().Save(recurringPost)
+	require.NotNil(t, err)
+
+	_, err = ss.RecurringPost().Save(&model.RecurringPost{ChannelId: channelId})
+	require.NotNil(t, err)
+
+	recurringPost.Message = "weekly announcement"
+	recurringPost.Paused = true
+	_, err = ss.RecurringPost().Update(recurringPost)
+	require.Nil(t, err)
+
+	got, err := ss.RecurringPost().Get(recurringPost.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "weekly announcement", got.Message)
+	assert.True(t, got.Paused)
+
+	_, err = ss.RecurringPost().Save(newTestRecurringPost(channelId))
+	require.Nil(t, err)
+
+	recurringPosts, err := ss.RecurringPost().GetForChannel(channelId)
+	require.Nil(t, err)
+	assert.Len(t, recurringPosts, 2)
+
+	require.Nil(t, ss.RecurringPost().Delete(recurringPost.Id))
+
+	_, err = ss.RecurringPost().Get(recurringPost.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	require.Nil(t, ss.RecurringPost().PermanentDeleteByChannel(channelId))
+
+	recurringPosts, err = ss.RecurringPost().GetForChannel(channelId)
+	require.Nil(t, err)
+	assert.Empty(t, recurringPosts)
+}
+
+func testRecurringPostStoreGetDue(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	now := model.GetMillis()
+
+	due := newTestRecurringPost(channelId)
+	due.NextRunAt = now - 1000
+	due, err := ss.RecurringPost().Save(due)
+	require.Nil(t, err)
+
+	later := newTestRecurringPost(channelId)
+	later.NextRunAt = now + 60000
+	_, err = ss.RecurringPost().Save(later)
+	require.Nil(t, err)
+
+	paused := newTestRecurringPost(channelId)
+	paused.NextRunAt = now - 1000
+	paused.Paused = true
+	_, err = ss.RecurringPost().Save(paused)
+	require.Nil(t, err)
+
+	recurringPosts, err := ss.RecurringPost().GetDue(now, 1000)
+	require.Nil(t, err)
+
+	var ids []string
+	for _, recurringPost := range recurringPosts {
+		if recurringPost.ChannelId == channelId {
+			ids = append(ids, recurringPost.Id)
+		}
+	}
+	assert.Equal(t, []string{due.Id}, ids)
+
+	require.Nil(t, ss.RecurringPost().PermanentDeleteByChannel(channelId))
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testReminderStoreSaveGetDelete(t, ss) })
+	t.Run("GetPendingForUser", func(t *testing.T) { testReminderStoreGetPendingForUser(t, ss) })
+	t.Run("GetDueMarkSent", func(t *testing.T) { testReminderStoreGetDueMarkSent(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testReminderStorePermanentDeleteByUser(t, ss) })
+}
+
+func testReminderStoreSaveGetDelete(t *testing.T, ss store.Store) {
+	reminder, err := ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), Message: "Submit the report", RemindAt: model.GetMillis()})
+	require.Nil(t, err)
+	assert.Len(t, reminder.Id, 26)
+
+	_, err = ss.Reminder().Save(reminder)
+	require.NotNil(t, err)
+
+	_, err = ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), Message: "Submit the report"})
+	require.NotNil(t, err)
+
+	got, err := ss.Reminder().Get(reminder.Id)
+	require.Nil(t, err)
+	assert.Equal(t, reminder.Message, got.Message)
+
+	require.Nil(t, ss.Reminder().Delete(reminder.Id))
+
+	_, err = ss.Reminder().Get(reminder.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testReminderStoreGetPendingForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	now := model.GetMillis()
+
+	later, err := ss.Reminder().Save(&model.Reminder{UserId: userId, Message: "later", RemindAt: now + 2000})
+	require.Nil(t, err)
+	sooner, err := ss.Reminder().Save(&model.Reminder{UserId: userId, Message: "sooner", RemindAt: now + 1000})
+	require.Nil(t, err)
+	_, err = ss.Reminder().Save(&model.Reminder{UserId: userId, Message: "sent", RemindAt: now, SentAt: now})
+	require.Nil(t, err)
+	_, err = ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), Message: "someone else's", RemindAt: now})
+	require.Nil(t, err)
+
+	reminders, err := ss.Reminder().GetPendingForUser(userId)
+	require.Nil(t, err)
+	require.Len(t, reminders, 2)
+	assert.Equal(t, sooner.Id, reminders[0].Id)
+	assert.Equal(t, later.Id, reminders[1].Id)
+}
+
+func testReminderStoreGetDueMarkSent(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	due, err := ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), Message: "due", RemindAt: now - 1000})
+	require.Nil(t, err)
+	notDue, err := ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), Message: "not due", RemindAt: now + 60*60*1000})
+	require.Nil(t, err)
+
+	reminders, err := ss.Reminder().GetDue(now, 1000)
+	require.Nil(t, err)
+	ids := []string{}
+	for _, reminder := range reminders {
+		ids = append(ids, reminder.Id)
+	}
+	assert.Contains(t, ids, due.Id)
+	assert.NotContains(t, ids, notDue.Id)
+
+	sent, err := ss.Reminder().MarkSent(due.Id, now)
+	require.Nil(t, err)
+	assert.True(t, sent)
+
+	sent, err = ss.Reminder().MarkSent(due.Id, now)
+	require.Nil(t, err)
+	assert.False(t, sent)
+
+	reminders, err = ss.Reminder().GetDue(now, 1000)
+	require.Nil(t, err)
+	for _, reminder := range reminders {
+		assert.NotEqual(t, due.Id, reminder.Id)
+	}
+}
+
+func testReminderStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	_, err := ss.Reminder().Save(&model.Reminder{UserId: userId, Message: "first", RemindAt: model.GetMillis()})
+	require.Nil(t, err)
+	_, err = ss.Reminder().Save(&model.Reminder{UserId: otherUserId, Message: "second", RemindAt: model.GetMillis()})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.Reminder().PermanentDeleteByUser(userId))
+
+	reminders, err := ss.Reminder().GetPendingForUser(userId)
+	require.Nil(t, err)
+	assert.Empty(t, reminders)
+
+	reminders, err = ss.Reminder().GetPendingForUser(otherUserId)
+	require.Nil(t, err)
+	assert.Len(t, reminders, 1)
+}
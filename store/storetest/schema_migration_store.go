@@ -0,0 +1,38 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaMigrationStore(t *testing.T, ss store.Store) {
+	t.Run("GetAll", func(t *testing.T) { testSchemaMigrationStoreGetAll(t, ss) })
+	t.Run("RunBackfillBatchUnknown", func(t *testing.T) { testSchemaMigrationStoreRunBackfillBatchUnknown(t, ss) })
+}
+
+func testSchemaMigrationStoreGetAll(t *testing.T, ss store.Store) {
+	migrations, err := ss.SchemaMigration().GetAll()
+	require.Nil(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		if i > 0 {
+			assert.True(t, migration.Version > migrations[i-1].Version, "migrations should be sorted by version")
+		}
+		assert.NotEmpty(t, migration.Name)
+		assert.Contains(t, []string{model.SCHEMA_MIGRATION_STATUS_PENDING, model.SCHEMA_MIGRATION_STATUS_BACKFILLING, model.SCHEMA_MIGRATION_STATUS_COMPLETED}, migration.Status)
+	}
+}
+
+func testSchemaMigrationStoreRunBackfillBatchUnknown(t *testing.T, ss store.Store) {
+	_, err := ss.SchemaMigration().RunBackfillBatch(-1, 100)
+	require.NotNil(t, err)
+	assert.Equal(t, "store.sql_schema_migration.run_backfill_batch.unknown.app_error", err.Id)
+}
@@ -26,6 +26,10 @@ const (
 	defaultMysqlRootPWD  = "mostest"
 )
 
+// CockroachDBDSNEnv names the variable holding the DSN of the CockroachDB unittesting database. CockroachDB is only
+// tested when it's set.
+const CockroachDBDSNEnv = "TEST_DATABASE_COCKROACHDB_DSN"
+
 func getEnv(name, defaultValue string) string {
 	if value := os.Getenv(name); value != "" {
 		return value
@@ -77,6 +81,21 @@ func PostgreSQLSettings() *model.SqlSettings {
 	return databaseSettings("postgres", dsnUrl.String())
 }
 
+// CockroachDBSettings returns the database settings to connect to the CockroachDB unittesting database.
+// The database name is generated randomly and must be created before use.
+func CockroachDBSettings() *model.SqlSettings {
+	dsn := os.Getenv(CockroachDBDSNEnv)
+	dsnUrl, err := url.Parse(dsn)
+	if err != nil {
+		panic("failed to parse dsn " + dsn + ": " + err.Error())
+	}
+
+	// Generate a random database name
+	dsnUrl.Path = "db" + model.NewId()
+
+	return databaseSettings("cockroach", dsnUrl.String())
+}
+
 func mySQLRootDSN(dsn string) string {
 	rootPwd := getEnv("TEST_DATABASE_MYSQL_ROOT_PASSWD", defaultMysqlRootPWD)
 	cfg, err := mysql.ParseDSN(dsn)
@@ -109,6 +128,19 @@ func postgreSQLRootDSN(dsn string) string {
 	return dsnUrl.String()
 }
 
+// cockroachDBRootDSN returns the DSN of the database CockroachDB always has. Its unittesting user is expected to be
+// allowed to create databases.
+func cockroachDBRootDSN(dsn string) string {
+	dsnUrl, err := url.Parse(dsn)
+	if err != nil {
+		panic("failed to parse dsn " + dsn + ": " + err.Error())
+	}
+
+	dsnUrl.Path = "defaultdb"
+
+	return dsnUrl.String()
+}
+
 func mySQLDSNDatabase(dsn string) string {
 	cfg, err := mysql.ParseDSN(dsn)
 	if err != nil {
@@ -158,6 +190,9 @@ func execAsRoot(settings *model.SqlSettings, sqlCommand string) error {
 		dsn = mySQLRootDSN(*settings.DataSource)
 	case model.DATABASE_DRIVER_POSTGRES:
 		dsn = postgreSQLRootDSN(*settings.DataSource)
+	case model.DATABASE_DRIVER_COCKROACH:
+		dsn = cockroachDBRootDSN(*settings.DataSource)
+		driver = model.DATABASE_DRIVER_POSTGRES
 	default:
 		return fmt.Errorf("unsupported driver %s", driver)
 	}
@@ -186,6 +221,9 @@ func MakeSqlSettings(driver string) *model.SqlSettings {
 	case model.DATABASE_DRIVER_POSTGRES:
 		settings = PostgreSQLSettings()
 		dbName = postgreSQLDSNDatabase(*settings.DataSource)
+	case model.DATABASE_DRIVER_COCKROACH:
+		settings = CockroachDBSettings()
+		dbName = postgreSQLDSNDatabase(*settings.DataSource)
 	default:
 		panic("unsupported driver " + driver)
 	}
@@ -203,6 +241,7 @@ func MakeSqlSettings(driver string) *model.SqlSettings {
 		if err := execAsRoot(settings, "GRANT ALL PRIVILEGES ON DATABASE \""+dbName+"\" TO mmuser"); err != nil {
 			panic("failed to grant mmuser permission to " + dbName + ":" + err.Error())
 		}
+	case model.DATABASE_DRIVER_COCKROACH:
 	default:
 		panic("unsupported driver " + driver)
 	}
@@ -219,7 +258,7 @@ func CleanupSqlSettings(settings *model.SqlSettings) {
 	switch driver {
 	case model.DATABASE_DRIVER_MYSQL:
 		dbName = mySQLDSNDatabase(*settings.DataSource)
-	case model.DATABASE_DRIVER_POSTGRES:
+	case model.DATABASE_DRIVER_POSTGRES, model.DATABASE_DRIVER_COCKROACH:
 		dbName = postgreSQLDSNDatabase(*settings.DataSource)
 	default:
 		panic("unsupported driver " + driver)
@@ -6,43 +6,79 @@ package storetest
 import (
 	"github.com/stretchr/testify/mock"
 
+	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
 	"github.com/mattermost/mattermost-server/store/storetest/mocks"
 )
 
 // Store can be used to provide mock stores for testing.
 type Store struct {
-	TeamStore                 mocks.TeamStore
-	ChannelStore              mocks.ChannelStore
-	PostStore                 mocks.PostStore
-	UserStore                 mocks.UserStore
-	BotStore                  mocks.BotStore
-	AuditStore                mocks.AuditStore
-	ClusterDiscoveryStore     mocks.ClusterDiscoveryStore
-	ComplianceStore           mocks.ComplianceStore
-	SessionStore              mocks.SessionStore
-	OAuthStore                mocks.OAuthStore
-	SystemStore               mocks.SystemStore
-	WebhookStore              mocks.WebhookStore
-	CommandStore              mocks.CommandStore
-	CommandWebhookStore       mocks.CommandWebhookStore
-	PreferenceStore           mocks.PreferenceStore
-	LicenseStore              mocks.LicenseStore
-	TokenStore                mocks.TokenStore
-	EmojiStore                mocks.EmojiStore
-	StatusStore               mocks.StatusStore
-	FileInfoStore             mocks.FileInfoStore
-	ReactionStore             mocks.ReactionStore
-	JobStore                  mocks.JobStore
-	UserAccessTokenStore      mocks.UserAccessTokenStore
-	PluginStore               mocks.PluginStore
-	ChannelMemberHistoryStore mocks.ChannelMemberHistoryStore
-	RoleStore                 mocks.RoleStore
-	SchemeStore               mocks.SchemeStore
-	TermsOfServiceStore       mocks.TermsOfServiceStore
-	GroupStore                mocks.GroupStore
-	UserTermsOfServiceStore   mocks.UserTermsOfServiceStore
-	LinkMetadataStore         mocks.LinkMetadataStore
+	TeamStore                     mocks.TeamStore
+	ChannelStore                  mocks.ChannelStore
+	PostStore                     mocks.PostStore
+	UserStore                     mocks.UserStore
+	BotStore                      mocks.BotStore
+	AuditStore                    mocks.AuditStore
+	ClusterDiscoveryStore         mocks.ClusterDiscoveryStore
+	ComplianceStore               mocks.ComplianceStore
+	SessionStore                  mocks.SessionStore
+	OAuthStore                    mocks.OAuthStore
+	SystemStore                   mocks.SystemStore
+	WebhookStore                  mocks.WebhookStore
+	CommandStore                  mocks.CommandStore
+	CommandWebhookStore           mocks.CommandWebhookStore
+	PreferenceStore               mocks.PreferenceStore
+	LicenseStore                  mocks.LicenseStore
+	TokenStore                    mocks.TokenStore
+	EmojiStore                    mocks.EmojiStore
+	StatusStore                   mocks.StatusStore
+	FileInfoStore                 mocks.FileInfoStore
+	ReactionStore                 mocks.ReactionStore
+	JobStore                      mocks.JobStore
+	UserAccessTokenStore          mocks.UserAccessTokenStore
+	PluginStore                   mocks.PluginStore
+	ChannelMemberHistoryStore     mocks.ChannelMemberHistoryStore
+	RoleStore                     mocks.RoleStore
+	SchemeStore                   mocks.SchemeStore
+	TermsOfServiceStore           mocks.TermsOfServiceStore
+	GroupStore                    mocks.GroupStore
+	UserTermsOfServiceStore       mocks.UserTermsOfServiceStore
+	LinkMetadataStore             mocks.LinkMetadataStore
+	HashtagStore                  mocks.HashtagStore
+	MentionAliasStore             mocks.MentionAliasStore
+	ChannelMemberExpiryStore      mocks.ChannelMemberExpiryStore
+	PostStarStore                 mocks.PostStarStore
+	ChannelReadStatStore          mocks.ChannelReadStatStore
+	UserDeactivationScheduleStore mocks.UserDeactivationScheduleStore
+	UserAttributeStore            mocks.UserAttributeStore
+	DailyStatStore                mocks.DailyStatStore
+	SchemaMigrationStore          mocks.SchemaMigrationStore
+	ConfigAuditStore              mocks.ConfigAuditStore
+	LicenseUsageStore             mocks.LicenseUsageStore
+	PendingPostStore              mocks.PendingPostStore
+	ContentFilterStore            mocks.ContentFilterStore
+	KeywordRuleStore              mocks.KeywordRuleStore
+	PostReportStore               mocks.PostReportStore
+	RecurringPostStore            mocks.RecurringPostStore
+	UserAvailabilityStore         mocks.UserAvailabilityStore
+	CallStore                     mocks.CallStore
+	MessageExportConsumerStore    mocks.MessageExportConsumerStore
+	PostPurgeStore                mocks.PostPurgeStore
+	PublicPostLinkStore           mocks.PublicPostLinkStore
+	PendingEmojiStore             mocks.PendingEmojiStore
+	EmojiAliasStore               mocks.EmojiAliasStore
+	EphemeralPostStore            mocks.EphemeralPostStore
+	LoginAttemptStore             mocks.LoginAttemptStore
+	MfaRecoveryCodeStore          mocks.MfaRecoveryCodeStore
+	IntegrationStatStore          mocks.IntegrationStatStore
+	TodoStore                     mocks.TodoStore
+	PollStore                     mocks.PollStore
+	ReminderStore                 mocks.ReminderStore
+	ChannelMembershipRuleStore    mocks.ChannelMembershipRuleStore
+	ChannelTeamBindingStore       mocks.ChannelTeamBindingStore
+	AnnouncementStore             mocks.AnnouncementStore
+	OnboardingStore               mocks.OnboardingStore
+	PostPinEventStore             mocks.PostPinEventStore
 }
 
 func (s *Store) Team() store.TeamStore                             { return &s.TeamStore }
@@ -76,8 +112,109 @@ func (s *Store) UserTermsOfService() store.UserTermsOfServiceStore { return &s.U
 func (s *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return &s.ChannelMemberHistoryStore
 }
+func (s *Store) ChannelMemberExpiry() store.ChannelMemberExpiryStore {
+	return &s.ChannelMemberExpiryStore
+}
+func (s *Store) DailyStat() store.DailyStatStore {
+	return &s.DailyStatStore
+}
+func (s *Store) SchemaMigration() store.SchemaMigrationStore {
+	return &s.SchemaMigrationStore
+}
+func (s *Store) ConfigAudit() store.ConfigAuditStore {
+	return &s.ConfigAuditStore
+}
+func (s *Store) LicenseUsage() store.LicenseUsageStore {
+	return &s.LicenseUsageStore
+}
+func (s *Store) PendingPost() store.PendingPostStore {
+	return &s.PendingPostStore
+}
+func (s *Store) ContentFilter() store.ContentFilterStore {
+	return &s.ContentFilterStore
+}
+func (s *Store) KeywordRule() store.KeywordRuleStore {
+	return &s.KeywordRuleStore
+}
+func (s *Store) PostReport() store.PostReportStore {
+	return &s.PostReportStore
+}
+func (s *Store) RecurringPost() store.RecurringPostStore {
+	return &s.RecurringPostStore
+}
+func (s *Store) UserAvailability() store.UserAvailabilityStore {
+	return &s.UserAvailabilityStore
+}
+func (s *Store) Call() store.CallStore {
+	return &s.CallStore
+}
+func (s *Store) MessageExportConsumer() store.MessageExportConsumerStore {
+	return &s.MessageExportConsumerStore
+}
+func (s *Store) PostPurge() store.PostPurgeStore {
+	return &s.PostPurgeStore
+}
+func (s *Store) PublicPostLink() store.PublicPostLinkStore {
+	return &s.PublicPostLinkStore
+}
+func (s *Store) PendingEmoji() store.PendingEmojiStore {
+	return &s.PendingEmojiStore
+}
+func (s *Store) EmojiAlias() store.EmojiAliasStore {
+	return &s.EmojiAliasStore
+}
+func (s *Store) EphemeralPost() store.EphemeralPostStore {
+	return &s.EphemeralPostStore
+}
+func (s *Store) LoginAttempt() store.LoginAttemptStore {
+	return &s.LoginAttemptStore
+}
+func (s *Store) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return &s.MfaRecoveryCodeStore
+}
+func (s *Store) IntegrationStat() store.IntegrationStatStore {
+	return &s.IntegrationStatStore
+}
+func (s *Store) Todo() store.TodoStore {
+	return &s.TodoStore
+}
+func (s *Store) Poll() store.PollStore {
+	return &s.PollStore
+}
+func (s *Store) Reminder() store.ReminderStore {
+	return &s.ReminderStore
+}
+func (s *Store) ChannelMembershipRule() store.ChannelMembershipRuleStore {
+	return &s.ChannelMembershipRuleStore
+}
+func (s *Store) ChannelTeamBinding() store.ChannelTeamBindingStore {
+	return &s.ChannelTeamBindingStore
+}
+func (s *Store) Announcement() store.AnnouncementStore {
+	return &s.AnnouncementStore
+}
+func (s *Store) Onboarding() store.OnboardingStore {
+	return &s.OnboardingStore
+}
+func (s *Store) PostPinEvent() store.PostPinEventStore {
+	return &s.PostPinEventStore
+}
+func (s *Store) UserAttribute() store.UserAttributeStore {
+	return &s.UserAttributeStore
+}
+func (s *Store) UserDeactivationSchedule() store.UserDeactivationScheduleStore {
+	return &s.UserDeactivationScheduleStore
+}
+func (s *Store) ChannelReadStat() store.ChannelReadStatStore {
+	return &s.ChannelReadStatStore
+}
+func (s *Store) PostStar() store.PostStarStore {
+	return &s.PostStarStore
+}
 func (s *Store) Group() store.GroupStore               { return &s.GroupStore }
 func (s *Store) LinkMetadata() store.LinkMetadataStore { return &s.LinkMetadataStore }
+func (s *Store) Hashtag() store.HashtagStore           { return &s.HashtagStore }
+func (s *Store) MentionAlias() store.MentionAliasStore { return &s.MentionAliasStore }
 func (s *Store) MarkSystemRanUnitTests()               { /* do nothing */ }
 func (s *Store) Close()                                { /* do nothing */ }
 func (s *Store) LockToMaster()                         { /* do nothing */ }
@@ -86,6 +223,7 @@ func (s *Store) DropAllTables()                        { /* do nothing */ }
 func (s *Store) TotalMasterDbConnections() int         { return 1 }
 func (s *Store) TotalReadDbConnections() int           { return 1 }
 func (s *Store) TotalSearchDbConnections() int         { return 1 }
+func (s *Store) RecentSlowQueries() []*model.SlowQuery { return []*model.SlowQuery{} }
 func (s *Store) GetCurrentSchemaVersion() string       { return "" }
 func (s *Store) CheckIntegrity() <-chan store.IntegrityCheckResult {
 	return make(chan store.IntegrityCheckResult)
@@ -17,6 +17,8 @@ func TestSystemStore(t *testing.T, ss store.Store) {
 	t.Run("", func(t *testing.T) { testSystemStore(t, ss) })
 	t.Run("SaveOrUpdate", func(t *testing.T) { testSystemStoreSaveOrUpdate(t, ss) })
 	t.Run("PermanentDeleteByName", func(t *testing.T) { testSystemStorePermanentDeleteByName(t, ss) })
+	t.Run("AcquireLease", func(t *testing.T) { testSystemStoreAcquireLease(t, ss) })
+	t.Run("ReleaseLease", func(t *testing.T) { testSystemStoreReleaseLease(t, ss) })
 }
 
 func testSystemStore(t *testing.T, ss store.Store) {
@@ -86,3 +88,62 @@ func testSystemStorePermanentDeleteByName(t *testing.T, ss store.Store) {
 	_, err = ss.System().GetByName(s2.Name)
 	assert.NotNil(t, err)
 }
+
+func testSystemStoreAcquireLease(t *testing.T, ss store.Store) {
+	name := model.NewId()
+	lease1 := &model.LeaderLease{Name: name, HolderId: model.NewId(), Hostname: "host1", ExpireAt: model.GetMillis() + 60000}
+	lease2 := &model.LeaderLease{Name: name, HolderId: model.NewId(), Hostname: "host2", ExpireAt: model.GetMillis() + 60000}
+
+	t.Run("acquire a new lease", func(t *testing.T) {
+		current, err := ss.System().AcquireLease(lease1)
+		require.Nil(t, err)
+		assert.Equal(t, lease1.HolderId, current.HolderId)
+	})
+
+	t.Run("fail to acquire a lease held by another server", func(t *testing.T) {
+		current, err := ss.System().AcquireLease(lease2)
+		require.Nil(t, err)
+		assert.Equal(t, lease1.HolderId, current.HolderId)
+		assert.Equal(t, "host1", current.Hostname)
+	})
+
+	t.Run("renew a held lease", func(t *testing.T) {
+		lease1.ExpireAt = model.GetMillis() + 120000
+		current, err := ss.System().AcquireLease(lease1)
+		require.Nil(t, err)
+		assert.Equal(t, lease1.ExpireAt, current.ExpireAt)
+	})
+
+	t.Run("take over an expired lease", func(t *testing.T) {
+		lease1.ExpireAt = model.GetMillis() - 1000
+		_, err := ss.System().AcquireLease(lease1)
+		require.Nil(t, err)
+
+		current, err := ss.System().AcquireLease(lease2)
+		require.Nil(t, err)
+		assert.Equal(t, lease2.HolderId, current.HolderId)
+	})
+}
+
+func testSystemStoreReleaseLease(t *testing.T, ss store.Store) {
+	name := model.NewId()
+	lease := &model.LeaderLease{Name: name, HolderId: model.NewId(), Hostname: "host", ExpireAt: model.GetMillis() + 60000}
+
+	_, err := ss.System().AcquireLease(lease)
+	require.Nil(t, err)
+
+	released, err := ss.System().ReleaseLease(name, model.NewId())
+	require.Nil(t, err)
+	assert.False(t, released)
+
+	released, err = ss.System().ReleaseLease(name, lease.HolderId)
+	require.Nil(t, err)
+	assert.True(t, released)
+
+	_, err = ss.System().GetByName(name)
+	assert.NotNil(t, err)
+
+	released, err = ss.System().ReleaseLease(name, lease.HolderId)
+	require.Nil(t, err)
+	assert.False(t, released)
+}
@@ -4,7 +4,9 @@
 package storetest
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
@@ -16,6 +18,8 @@ func TestTermsOfServiceStore(t *testing.T, ss store.Store) {
 	t.Run("TestSaveTermsOfService", func(t *testing.T) { testSaveTermsOfService(t, ss) })
 	t.Run("TestGetLatestTermsOfService", func(t *testing.T) { testGetLatestTermsOfService(t, ss) })
 	t.Run("TestGetTermsOfService", func(t *testing.T) { testGetTermsOfService(t, ss) })
+	t.Run("TestTermsOfServiceVersions", func(t *testing.T) { testTermsOfServiceVersions(t, ss) })
+	t.Run("TestUpdateTermsOfServiceReacceptAt", func(t *testing.T) { testUpdateTermsOfServiceReacceptAt(t, ss) })
 }
 
 func testSaveTermsOfService(t *testing.T, ss store.Store) {
@@ -77,3 +81,40 @@ func testGetTermsOfService(t *testing.T, ss store.Store) {
 	assert.Nil(t, err)
 	assert.Equal(t, "terms of service", receivedTermsOfService.Text)
 }
+
+func testTermsOfServiceVersions(t *testing.T, ss store.Store) {
+	termsOfService1, err := ss.TermsOfService().Save(&model.TermsOfService{Text: "terms of service 1", UserId: model.NewId()})
+	require.Nil(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+
+	termsOfService2, err := ss.TermsOfService().Save(&model.TermsOfService{Text: "terms of service 2", UserId: model.NewId()})
+	require.Nil(t, err)
+	assert.Equal(t, termsOfService1.Version+1, termsOfService2.Version)
+
+	versions, err := ss.TermsOfService().GetAll()
+	require.Nil(t, err)
+	require.True(t, len(versions) >= 2)
+	assert.Equal(t, termsOfService2.Id, versions[0].Id)
+	assert.Equal(t, termsOfService1.Id, versions[1].Id)
+}
+
+func testUpdateTermsOfServiceReacceptAt(t *testing.T, ss store.Store) {
+	termsOfService, err := ss.TermsOfService().Save(&model.TermsOfService{Text: "terms of service", UserId: model.NewId()})
+	require.Nil(t, err)
+
+	// The cached terms of service are updated too
+	_, err = ss.TermsOfService().Get(termsOfService.Id, true)
+	require.Nil(t, err)
+
+	reacceptAt := model.GetMillis() + 1000
+	require.Nil(t, ss.TermsOfService().UpdateReacceptAt(termsOfService.Id, reacceptAt))
+
+	fetched, err := ss.TermsOfService().Get(termsOfService.Id, true)
+	require.Nil(t, err)
+	assert.Equal(t, reacceptAt, fetched.ReacceptAt)
+
+	err = ss.TermsOfService().UpdateReacceptAt(model.NewId(), reacceptAt)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
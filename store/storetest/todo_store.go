@@ -0,0 +1,103 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testTodoStoreSaveGetUpdateDelete(t, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testTodoStoreGetForUser(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testTodoStorePermanentDeleteByUser(t, ss) })
+}
+
+func testTodoStoreSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	todo, err := ss.Todo().Save(&model.Todo{UserId: userId, Message: "Review the release notes"})
+	require.Nil(t, err)
+	assert.Len(t, todo.Id, 26)
+
+	_, err = ss.Todo().Save(todo)
+	require.NotNil(t, err)
+
+	_, err = ss.Todo().Save(&model.Todo{UserId: userId})
+	require.NotNil(t, err)
+
+	got, err := ss.Todo().Get(todo.Id)
+	require.Nil(t, err)
+	assert.Equal(t, todo.Message, got.Message)
+	assert.False(t, got.IsComplete())
+
+	got.CompleteAt = model.GetMillis()
+	_, err = ss.Todo().Update(got)
+	require.Nil(t, err)
+
+	got, err = ss.Todo().Get(todo.Id)
+	require.Nil(t, err)
+	assert.True(t, got.IsComplete())
+
+	require.Nil(t, ss.Todo().Delete(todo.Id))
+
+	_, err = ss.Todo().Get(todo.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	_, err = ss.Todo().Update(got)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testTodoStoreGetForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	first, err := ss.Todo().Save(&model.Todo{UserId: userId, Message: "first"})
+	require.Nil(t, err)
+	second, err := ss.Todo().Save(&model.Todo{UserId: userId, Message: "second", CompleteAt: model.GetMillis()})
+	require.Nil(t, err)
+	_, err = ss.Todo().Save(&model.Todo{UserId: model.NewId(), Message: "someone else's"})
+	require.Nil(t, err)
+
+	todos, err := ss.Todo().GetForUser(userId, false)
+	require.Nil(t, err)
+	require.Len(t, todos, 1)
+	assert.Equal(t, first.Id, todos[0].Id)
+
+	todos, err = ss.Todo().GetForUser(userId, true)
+	require.Nil(t, err)
+	require.Len(t, todos, 2)
+	assert.Equal(t, first.Id, todos[0].Id)
+	assert.Equal(t, second.Id, todos[1].Id)
+
+	todos, err = ss.Todo().GetForUser(model.NewId(), true)
+	require.Nil(t, err)
+	assert.Empty(t, todos)
+}
+
+func testTodoStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	_, err := ss.Todo().Save(&model.Todo{UserId: userId, Message: "first"})
+	require.Nil(t, err)
+	_, err = ss.Todo().Save(&model.Todo{UserId: otherUserId, Message: "second"})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.Todo().PermanentDeleteByUser(userId))
+
+	todos, err := ss.Todo().GetForUser(userId, true)
+	require.Nil(t, err)
+	assert.Empty(t, todos)
+
+	todos, err = ss.Todo().GetForUser(otherUserId, true)
+	require.Nil(t, err)
+	assert.Len(t, todos, 1)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,6 +16,8 @@ func TestUserAccessTokenStore(t *testing.T, ss store.Store) {
 	t.Run("UserAccessTokenSaveGetDelete", func(t *testing.T) { testUserAccessTokenSaveGetDelete(t, ss) })
 	t.Run("UserAccessTokenDisableEnable", func(t *testing.T) { testUserAccessTokenDisableEnable(t, ss) })
 	t.Run("UserAccessTokenSearch", func(t *testing.T) { testUserAccessTokenSearch(t, ss) })
+	t.Run("UserAccessTokenUpdateLastUsedAt", func(t *testing.T) { testUserAccessTokenUpdateLastUsedAt(t, ss) })
+	t.Run("UserAccessTokenGetExpiring", func(t *testing.T) { testUserAccessTokenGetExpiring(t, ss) })
 }
 
 func testUserAccessTokenSaveGetDelete(t *testing.T, ss store.Store) {
@@ -180,3 +183,65 @@ func testUserAccessTokenSearch(t *testing.T, ss store.Store) {
 		t.Fatal("received incorrect number of tokens after search")
 	}
 }
+
+func testUserAccessTokenUpdateLastUsedAt(t *testing.T, ss store.Store) {
+	uat := &model.UserAccessToken{
+		Token:       model.NewId(),
+		UserId:      model.NewId(),
+		Description: "testtoken",
+		AllowedIPs:  " 10.0.0.0/8   192.168.1.1 ",
+		Scope:       "write:posts read:posts",
+	}
+
+	uat, err := ss.UserAccessToken().Save(uat)
+	require.Nil(t, err)
+	defer ss.UserAccessToken().Delete(uat.Id)
+
+	require.Nil(t, ss.UserAccessToken().UpdateLastUsedAt(uat.Id, 1234))
+
+	result, err := ss.UserAccessToken().Get(uat.Id)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1234), result.LastUsedAt)
+	assert.Equal(t, "10.0.0.0/8 192.168.1.1", result.AllowedIPs)
+	assert.Equal(t, "read:posts write:posts", result.Scope)
+}
+
+func testUserAccessTokenGetExpiring(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+	save := func(expiresAt int64) *model.UserAccessToken {
+		uat, err := ss.UserAccessToken().Save(&model.UserAccessToken{
+			Token:       model.NewId(),
+			UserId:      model.NewId(),
+			Description: "testtoken",
+			ExpiresAt:   expiresAt,
+		})
+		require.Nil(t, err)
+		return uat
+	}
+
+	later := save(now + 2000)
+	sooner := save(now + 1000)
+	expired := save(now - 1000)
+	farAway := save(now + 100000)
+	noExpiry := save(0)
+	disabled := save(now + 1000)
+	require.Nil(t, ss.UserAccessToken().UpdateTokenDisable(disabled.Id))
+
+	for _, uat := range []*model.UserAccessToken{later, sooner, expired, farAway, noExpiry, disabled} {
+		defer ss.UserAccessToken().Delete(uat.Id)
+	}
+
+	tokens, err := ss.UserAccessToken().GetExpiring(now, now+10000, 0, 100)
+	require.Nil(t, err)
+
+	ids := []string{}
+	for _, uat := range tokens {
+		ids = append(ids, uat.Id)
+	}
+	assert.Equal(t, []string{sooner.Id, later.Id}, ids)
+
+	tokens, err = ss.UserAccessToken().GetExpiring(now, now+10000, 1, 100)
+	require.Nil(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, later.Id, tokens[0].Id)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAttributeStore(t *testing.T, ss store.Store) {
+	t.Run("Fields", func(t *testing.T) { testUserAttributeStoreFields(t, ss) })
+	t.Run("Values", func(t *testing.T) { testUserAttributeStoreValues(t, ss) })
+	t.Run("Search", func(t *testing.T) { testUserAttributeStoreSearch(t, ss) })
+}
+
+func testUserAttributeStoreFields(t *testing.T, ss store.Store) {
+	field := &model.UserAttributeField{
+		Name:          "Department" + model.NewId()[:8],
+		DisplayName:   "Department",
+		LdapAttribute: "departmentNumber",
+	}
+
+	saved, err := ss.UserAttribute().SaveField(field)
+	require.Nil(t, err)
+	assert.Len(t, saved.Id, 26)
+	assert.NotZero(t, saved.CreateAt)
+	assert.Equal(t, "department", saved.Name[:10])
+
+	_, err = ss.UserAttribute().SaveField(saved)
+	require.NotNil(t, err)
+
+	duplicate := &model.UserAttributeField{Name: saved.Name, DisplayName: "Other"}
+	_, err = ss.UserAttribute().SaveField(duplicate)
+	require.NotNil(t, err)
+	assert.Equal(t, "store.sql_user_attribute.save_field.name_exists.app_error", err.Id)
+
+	got, err := ss.UserAttribute().GetField(saved.Id)
+	require.Nil(t, err)
+	assert.Equal(t, saved.LdapAttribute, got.LdapAttribute)
+
+	got.DisplayName = "Team"
+	got.Searchable = true
+	_, err = ss.UserAttribute().UpdateField(got)
+	require.Nil(t, err)
+
+	got, err = ss.UserAttribute().GetField(saved.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "Team", got.DisplayName)
+	assert.True(t, got.Searchable)
+
+	fields, err := ss.UserAttribute().GetFields()
+	require.Nil(t, err)
+	found := false
+	for _, f := range fields {
+		if f.Id == saved.Id {
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	require.Nil(t, ss.UserAttribute().DeleteField(saved.Id, model.GetMillis()))
+
+	_, err = ss.UserAttribute().GetField(saved.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	err = ss.UserAttribute().DeleteField(saved.Id, model.GetMillis())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	// The name of a deleted field can be reused.
+	_, err = ss.UserAttribute().SaveField(&model.UserAttributeField{Name: saved.Name, DisplayName: "Department"})
+	require.Nil(t, err)
+}
+
+func testUserAttributeStoreValues(t *testing.T, ss store.Store) {
+	department, err := ss.UserAttribute().SaveField(&model.UserAttributeField{Name: "department" + model.NewId()[:8], DisplayName: "Department"})
+	require.Nil(t, err)
+	location, err := ss.UserAttribute().SaveField(&model.UserAttributeField{Name: "location" + model.NewId()[:8], DisplayName: "Location"})
+	require.Nil(t, err)
+
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+
+	_, err = ss.UserAttribute().SaveValue(&model.UserAttributeValue{UserId: userId1, FieldId: department.Id, Value: "Sales"})
+	require.Nil(t, err)
+	_, err = ss.UserAttribute().SaveValue(&model.UserAttributeValue{UserId: userId1, FieldId: department.Id, Value: "Engineering", Source: model.USER_ATTRIBUTE_SOURCE_LDAP})
+	require.Nil(t, err)
+	_, err = ss.UserAttribute().SaveValue(&model.UserAttributeValue{UserId: userId1, FieldId: location.Id, Value: "Toronto"})
+	require.Nil(t, err)
+	_, err = ss.UserAttribute().SaveValue(&model.UserAttributeValue{UserId: userId2, FieldId: location.Id, Value: "Lisbon"})
+	require.Nil(t, err)
+
+	_, err = ss.UserAttribute().SaveValue(&model.UserAttributeValue{UserId: userId2, FieldId: department.Id, Value: ""})
+	require.NotNil(t, err)
+
+	values, err := ss.UserAttribute().GetValuesForUsers([]string{userId1})
+	require.Nil(t, err)
+	require.Len(t, values, 2)
+	for _, value := range values {
+		if value.FieldId == department.Id {
+			assert.Equal(t, "Engineering", value.Value)
+			assert.True(t, value.IsSynced())
+		} else {
+			assert.Equal(t, "Toronto", value.Value)
+		}
+	}
+
+	values, err = ss.UserAttribute().GetValuesForUsers([]string{userId1, userId2})
+	require.Nil(t, err)
+	assert.Len(t, values, 3)
+
+	values, err = ss.UserAttribute().GetValuesForUsers([]string{})
+	require.Nil(t, err)
+	assert.Len(t, values, 0)
+
+	require.Nil(t, ss.UserAttribute().DeleteValue(userId1, location.Id))
+
+	values, err = ss.UserAttribute().GetValuesForUsers([]string{userId1})
+	require.Nil(t, err)
+	assert.Len(t, values, 1)
+
+	require.Nil(t, ss.UserAttribute().DeleteField(department.Id, model.GetMillis()))
+
+	values, err = ss.UserAttribute().GetValuesForUsers([]string{userId1})
+	require.Nil(t, err)
+	assert.Len(t, values, 0)
+
+	require.Nil(t, ss.UserAttribute().PermanentDeleteValuesByUser(userId2))
+
+	values, err = ss.UserAttribute().GetValuesForUsers([]string{userId2})
+	require.Nil(t, err)
+	assert.Len(t, values, 0)
+}
+
+func testUserAttributeStoreSearch(t *testing.T, ss store.Store) {
+	searchable, err := ss.UserAttribute().SaveField(&model.UserAttributeField{Name: "team" + model.NewId()[:8], DisplayName: "Team", Searchable: true})
+	require.Nil(t, err)
+	hidden, err := ss.UserAttribute().SaveField(&model.UserAttributeField{Name: "badge" + model.NewId()[:8], DisplayName: "Badge"})
+	require.Nil(t, err)
+
+	u1, err := ss.User().Save(&model.User{Username: "attribute" + model.NewId(), Email: MakeEmail()})
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u1.Id)) }()
+
+	u2, err := ss.User().Save(&model.User{Username: "attribute" + model.NewId(), Email: MakeEmail()})
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u2.Id)) }()
+
+	term := "Platypus" + model.NewId()[:8]
+
+	_, err = ss.UserAttribute().SaveValue(&model.UserAttributeValue{UserId: u1.Id, FieldId: searchable.Id, Value: term + " Squad"})
+	require.Nil(t, err)
+	_, err = ss.UserAttribute().SaveValue(&model.UserAttributeValue{UserId: u2.Id, FieldId: hidden.Id, Value: term})
+	require.Nil(t, err)
+
+	options := &model.UserSearchOptions{AllowFullNames: true, Limit: model.USER_SEARCH_DEFAULT_LIMIT}
+
+	users, err := ss.User().Search(context.Background(), "", term, options)
+	require.Nil(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, u1.Id, users[0].Id)
+
+	users, err = ss.User().Search(context.Background(), "", term+" Squad", options)
+	require.Nil(t, err)
+	require.Len(t, users, 1)
+
+	require.Nil(t, ss.UserAttribute().DeleteField(searchable.Id, model.GetMillis()))
+
+	users, err = ss.User().Search(context.Background(), "", term, options)
+	require.Nil(t, err)
+	assert.Len(t, users, 0)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAvailabilityStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testUserAvailabilityStoreSaveGetUpdateDelete(t, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testUserAvailabilityStoreGetForUser(t, ss) })
+	t.Run("GetForUsersAt", func(t *testing.T) { testUserAvailabilityStoreGetForUsersAt(t, ss) })
+}
+
+func newTestUserAvailability(userId string, startAt, endAt int64) *model.UserAvailability {
+	return &model.UserAvailability{
+		UserId:    userId,
+		CreatorId: userId,
+		Source:    "com.example.calendar",
+		Status:    model.USER_AVAILABILITY_BUSY,
+		StartAt:   startAt,
+		EndAt:     endAt,
+	}
+}
+
+func testUserAvailabilityStoreSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	block, err := ss.UserAvailability().Save(newTestUserAvailability(userId, 1000, 2000))
+	require.Nil(t, err)
+	assert.Len(t, block.Id, 26)
+
+	_, err = ss.UserAvailability().Save(block)
+	require.NotNil(t, err)
+
+	_, err = ss.UserAvailability().Save(newTestUserAvailability(userId, 2000, 1000))
+	require.NotNil(t, err)
+
+	got, err := ss.UserAvailability().Get(block.Id)
+	require.Nil(t, err)
+	assert.Equal(t, block.Source, got.Source)
+
+	got.Status = model.USER_AVAILABILITY_FREE
+	_, err = ss.UserAvailability().Update(got)
+	require.Nil(t, err)
+
+	got, err = ss.UserAvailability().Get(block.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.USER_AVAILABILITY_FREE, got.Status)
+
+	require.Nil(t, ss.UserAvailability().Delete(block.Id))
+
+	_, err = ss.UserAvailability().Get(block.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testUserAvailabilityStoreGetForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	b1, err := ss.UserAvailability().Save(newTestUserAvailability(userId, 1000, 2000))
+	require.Nil(t, err)
+	b2, err := ss.UserAvailability().Save(newTestUserAvailability(userId, 3000, 4000))
+	require.Nil(t, err)
+	_, err = ss.UserAvailability().Save(newTestUserAvailability(model.NewId(), 1000, 4000))
+	require.Nil(t, err)
+
+	blocks, err := ss.UserAvailability().GetForUser(userId, 0, 5000)
+	require.Nil(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, b1.Id, blocks[0].Id)
+	assert.Equal(t, b2.Id, blocks[1].Id)
+
+	blocks, err = ss.UserAvailability().GetForUser(userId, 1999, 3000)
+	require.Nil(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, b1.Id, blocks[0].Id)
+
+	require.Nil(t, ss.UserAvailability().PermanentDeleteByUser(userId))
+
+	blocks, err = ss.UserAvailability().GetForUser(userId, 0, 5000)
+	require.Nil(t, err)
+	assert.Empty(t, blocks)
+}
+
+func testUserAvailabilityStoreGetForUsersAt(t *testing.T, ss store.Store) {
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+
+	b1, err := ss.UserAvailability().Save(newTestUserAvailability(userId1, 1000, 2000))
+	require.Nil(t, err)
+	_, err = ss.UserAvailability().Save(newTestUserAvailability(userId2, 2000, 3000))
+	require.Nil(t, err)
+
+	blocks, err := ss.UserAvailability().GetForUsersAt([]string{userId1, userId2}, 1500)
+	require.Nil(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, b1.Id, blocks[0].Id)
+
+	blocks, err = ss.UserAvailability().GetForUsersAt([]string{userId1, userId2}, 2000)
+	require.Nil(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, userId2, blocks[0].UserId)
+
+	blocks, err = ss.UserAvailability().GetForUsersAt(nil, 2000)
+	require.Nil(t, err)
+	assert.Empty(t, blocks)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserDeactivationScheduleStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testUserDeactivationScheduleStoreSaveGetDelete(t, ss) })
+	t.Run("GetAllAndDue", func(t *testing.T) { testUserDeactivationScheduleStoreGetAllAndDue(t, ss) })
+}
+
+func testUserDeactivationScheduleStoreSaveGetDelete(t *testing.T, ss store.Store) {
+	schedule := &model.UserDeactivationSchedule{
+		UserId:       model.NewId(),
+		CreatorId:    model.NewId(),
+		DeactivateAt: model.GetMillis() + 60000,
+	}
+
+	saved, err := ss.UserDeactivationSchedule().Save(schedule)
+	require.Nil(t, err)
+	assert.NotZero(t, saved.CreateAt)
+
+	got, err := ss.UserDeactivationSchedule().Get(schedule.UserId)
+	require.Nil(t, err)
+	assert.Equal(t, schedule.DeactivateAt, got.DeactivateAt)
+	assert.Equal(t, schedule.CreatorId, got.CreatorId)
+
+	// Saving again replaces the existing schedule.
+	postponed := &model.UserDeactivationSchedule{
+		UserId:       schedule.UserId,
+		CreatorId:    model.NewId(),
+		CreateAt:     got.CreateAt,
+		DeactivateAt: schedule.DeactivateAt + 60000,
+	}
+	_, err = ss.UserDeactivationSchedule().Save(postponed)
+	require.Nil(t, err)
+
+	got, err = ss.UserDeactivationSchedule().Get(schedule.UserId)
+	require.Nil(t, err)
+	assert.Equal(t, postponed.DeactivateAt, got.DeactivateAt)
+	assert.Equal(t, postponed.CreatorId, got.CreatorId)
+
+	_, err = ss.UserDeactivationSchedule().Save(&model.UserDeactivationSchedule{UserId: schedule.UserId})
+	require.NotNil(t, err)
+
+	require.Nil(t, ss.UserDeactivationSchedule().Delete(schedule.UserId))
+
+	_, err = ss.UserDeactivationSchedule().Get(schedule.UserId)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	// Deleting a schedule that doesn't exist isn't an error.
+	require.Nil(t, ss.UserDeactivationSchedule().Delete(schedule.UserId))
+}
+
+func testUserDeactivationScheduleStoreGetAllAndDue(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	due, err := ss.UserDeactivationSchedule().Save(&model.UserDeactivationSchedule{UserId: model.NewId(), CreatorId: model.NewId(), DeactivateAt: now - 60000})
+	require.Nil(t, err)
+	defer ss.UserDeactivationSchedule().Delete(due.UserId)
+
+	later, err := ss.UserDeactivationSchedule().Save(&model.UserDeactivationSchedule{UserId: model.NewId(), CreatorId: model.NewId(), DeactivateAt: now + 60000})
+	require.Nil(t, err)
+	defer ss.UserDeactivationSchedule().Delete(later.UserId)
+
+	schedules, err := ss.UserDeactivationSchedule().GetDue(now, 1000)
+	require.Nil(t, err)
+
+	found := map[string]bool{}
+	for _, schedule := range schedules {
+		found[schedule.UserId] = true
+	}
+	assert.True(t, found[due.UserId])
+	assert.False(t, found[later.UserId])
+
+	schedules, err = ss.UserDeactivationSchedule().GetAll(0, 1000)
+	require.Nil(t, err)
+
+	dueIndex, laterIndex := -1, -1
+	for i, schedule := range schedules {
+		if schedule.UserId == due.UserId {
+			dueIndex = i
+		} else if schedule.UserId == later.UserId {
+			laterIndex = i
+		}
+	}
+	require.NotEqual(t, -1, dueIndex)
+	require.NotEqual(t, -1, laterIndex)
+	assert.True(t, dueIndex < laterIndex)
+}
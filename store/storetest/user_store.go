@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -37,6 +38,7 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("Count", func(t *testing.T) { testCount(t, ss) })
 	t.Run("AnalyticsActiveCount", func(t *testing.T) { testUserStoreAnalyticsActiveCount(t, ss, s) })
 	t.Run("AnalyticsGetInactiveUsersCount", func(t *testing.T) { testUserStoreAnalyticsGetInactiveUsersCount(t, ss) })
+	t.Run("AnalyticsGetMfaAdoption", func(t *testing.T) { testUserStoreAnalyticsGetMfaAdoption(t, ss) })
 	t.Run("AnalyticsGetSystemAdminCount", func(t *testing.T) { testUserStoreAnalyticsGetSystemAdminCount(t, ss) })
 	t.Run("Save", func(t *testing.T) { testUserStoreSave(t, ss) })
 	t.Run("Update", func(t *testing.T) { testUserStoreUpdate(t, ss) })
@@ -72,6 +74,7 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("SearchInChannel", func(t *testing.T) { testUserStoreSearchInChannel(t, ss) })
 	t.Run("SearchNotInTeam", func(t *testing.T) { testUserStoreSearchNotInTeam(t, ss) })
 	t.Run("SearchWithoutTeam", func(t *testing.T) { testUserStoreSearchWithoutTeam(t, ss) })
+	t.Run("SearchFuzzy", func(t *testing.T) { testUserStoreSearchFuzzy(t, ss) })
 	t.Run("GetProfilesNotInTeam", func(t *testing.T) { testUserStoreGetProfilesNotInTeam(t, ss) })
 	t.Run("ClearAllCustomRoleAssignments", func(t *testing.T) { testUserStoreClearAllCustomRoleAssignments(t, ss) })
 	t.Run("GetAllAfter", func(t *testing.T) { testUserStoreGetAllAfter(t, ss) })
@@ -2602,7 +2605,7 @@ func testUserStoreSearch(t *testing.T, ss store.Store) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.Description, func(t *testing.T) {
-			users, err := ss.User().Search(testCase.TeamId, testCase.Term, testCase.Options)
+			users, err := ss.User().Search(context.Background(), testCase.TeamId, testCase.Term, testCase.Options)
 			require.Nil(t, err)
 			assertUsersMatchInAnyOrder(t, testCase.Expected, users)
 		})
@@ -2614,7 +2617,7 @@ func testUserStoreSearch(t *testing.T, ss store.Store) {
 			Limit:          model.USER_SEARCH_DEFAULT_LIMIT,
 		}
 
-		users, err := ss.User().Search(tid, "", searchOptions)
+		users, err := ss.User().Search(context.Background(), tid, "", searchOptions)
 		require.Nil(t, err)
 		assert.Len(t, users, 4)
 		// Don't assert contents, since Postgres' default collation order is left up to
@@ -2628,13 +2631,26 @@ func testUserStoreSearch(t *testing.T, ss store.Store) {
 			Limit:          2,
 		}
 
-		users, err := ss.User().Search(tid, "", searchOptions)
+		users, err := ss.User().Search(context.Background(), tid, "", searchOptions)
 		require.Nil(t, err)
 		assert.Len(t, users, 2)
 		// Don't assert contents, since Postgres' default collation order is left up to
 		// the operating system, and jimbo1 might sort before or after jim-bo.
 		// assertUsers(t, []*model.User{u2, u1, u6, u5}, r1.Data.([]*model.User))
 	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		searchOptions := &model.UserSearchOptions{
+			AllowFullNames: true,
+			Limit:          model.USER_SEARCH_DEFAULT_LIMIT,
+		}
+
+		_, err := ss.User().Search(ctx, tid, "jimbo", searchOptions)
+		require.NotNil(t, err)
+	})
 }
 
 func testUserStoreSearchNotInChannel(t *testing.T, ss store.Store) {
@@ -2852,6 +2868,7 @@ func testUserStoreSearchNotInChannel(t *testing.T, ss store.Store) {
 	for _, testCase := range testCases {
 		t.Run(testCase.Description, func(t *testing.T) {
 			users, err := ss.User().SearchNotInChannel(
+				context.Background(),
 				testCase.TeamId,
 				testCase.ChannelId,
 				testCase.Term,
@@ -3018,6 +3035,7 @@ func testUserStoreSearchInChannel(t *testing.T, ss store.Store) {
 	for _, testCase := range testCases {
 		t.Run(testCase.Description, func(t *testing.T) {
 			users, err := ss.User().SearchInChannel(
+				context.Background(),
 				testCase.ChannelId,
 				testCase.Term,
 				testCase.Options,
@@ -3210,6 +3228,7 @@ func testUserStoreSearchNotInTeam(t *testing.T, ss store.Store) {
 	for _, testCase := range testCases {
 		t.Run(testCase.Description, func(t *testing.T) {
 			users, err := ss.User().SearchNotInTeam(
+				context.Background(),
 				testCase.TeamId,
 				testCase.Term,
 				testCase.Options,
@@ -3316,6 +3335,7 @@ func testUserStoreSearchWithoutTeam(t *testing.T, ss store.Store) {
 	for _, testCase := range testCases {
 		t.Run(testCase.Description, func(t *testing.T) {
 			users, err := ss.User().SearchWithoutTeam(
+				context.Background(),
 				testCase.Term,
 				testCase.Options,
 			)
@@ -3519,6 +3539,34 @@ func testUserStoreAnalyticsGetInactiveUsersCount(t *testing.T, ss store.Store) {
 	}
 }
 
+func testUserStoreAnalyticsGetMfaAdoption(t *testing.T, ss store.Store) {
+	before, err := ss.User().AnalyticsGetMfaAdoption()
+	require.Nil(t, err)
+
+	u1, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u1.Id)) }()
+	require.Nil(t, ss.User().UpdateMfaActive(u1.Id, true))
+
+	u2, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u2.Id)) }()
+
+	// Users who can't enable MFA aren't counted
+	u3, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId(), AuthService: model.USER_AUTH_SERVICE_GITLAB, AuthData: model.NewString(model.NewId())})
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u3.Id)) }()
+
+	u4, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId(), DeleteAt: model.GetMillis()})
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u4.Id)) }()
+
+	after, err := ss.User().AnalyticsGetMfaAdoption()
+	require.Nil(t, err)
+	assert.Equal(t, before.TotalUsers+2, after.TotalUsers)
+	assert.Equal(t, before.MfaActiveUsers+1, after.MfaActiveUsers)
+}
+
 func testUserStoreAnalyticsGetSystemAdminCount(t *testing.T, ss store.Store) {
 	var countBefore int64
 	if result, err := ss.User().AnalyticsGetSystemAdminCount(); err != nil {
@@ -4886,3 +4934,58 @@ func testUserStoreResetLastPictureUpdate(t *testing.T, ss store.Store) {
 	assert.True(t, user2.UpdateAt > user.UpdateAt)
 	assert.Zero(t, user2.LastPictureUpdate)
 }
+
+func testUserStoreSearchFuzzy(t *testing.T, ss store.Store) {
+	id := model.NewId()[:8]
+
+	prefix := &model.User{Username: "marg" + id, Email: MakeEmail()}
+	fuzzyNickname := &model.User{Username: model.NewId(), Nickname: "m_a_r_g" + id, Email: MakeEmail()}
+	fuzzyFullName := &model.User{Username: model.NewId(), FirstName: "Mxaxrxg" + id, Email: MakeEmail()}
+
+	teamId := model.NewId()
+	for _, u := range []*model.User{prefix, fuzzyNickname, fuzzyFullName} {
+		_, err := ss.User().Save(u)
+		require.Nil(t, err)
+		defer func(userId string) { require.Nil(t, ss.User().PermanentDelete(userId)) }(u.Id)
+
+		_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: u.Id}, -1)
+		require.Nil(t, err)
+	}
+
+	term := "marg" + id
+
+	t.Run("prefix only without fuzzy matching", func(t *testing.T) {
+		users, err := ss.User().Search(context.Background(), teamId, term, &model.UserSearchOptions{AllowFullNames: true, Limit: 10})
+		require.Nil(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, prefix.Id, users[0].Id)
+	})
+
+	t.Run("prefix matches come first", func(t *testing.T) {
+		users, err := ss.User().Search(context.Background(), teamId, term, &model.UserSearchOptions{AllowFullNames: true, Limit: 10, Fuzzy: true})
+		require.Nil(t, err)
+		require.Len(t, users, 3)
+		assert.Equal(t, prefix.Id, users[0].Id)
+	})
+
+	t.Run("full names only when allowed", func(t *testing.T) {
+		users, err := ss.User().Search(context.Background(), teamId, term, &model.UserSearchOptions{Limit: 10, Fuzzy: true})
+		require.Nil(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, prefix.Id, users[0].Id)
+		assert.Equal(t, fuzzyNickname.Id, users[1].Id)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		users, err := ss.User().Search(context.Background(), teamId, term, &model.UserSearchOptions{AllowFullNames: true, Limit: 2, Fuzzy: true})
+		require.Nil(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, prefix.Id, users[0].Id)
+	})
+
+	t.Run("short terms", func(t *testing.T) {
+		users, err := ss.User().Search(context.Background(), teamId, "mv", &model.UserSearchOptions{AllowFullNames: true, Limit: 10, Fuzzy: true})
+		require.Nil(t, err)
+		assert.Empty(t, users)
+	})
+}
@@ -16,6 +16,7 @@ func TestUserTermsOfServiceStore(t *testing.T, ss store.Store) {
 	t.Run("TestSaveUserTermsOfService", func(t *testing.T) { testSaveUserTermsOfService(t, ss) })
 	t.Run("TestGetByUserTermsOfService", func(t *testing.T) { testGetByUserTermsOfService(t, ss) })
 	t.Run("TestDeleteUserTermsOfService", func(t *testing.T) { testDeleteUserTermsOfService(t, ss) })
+	t.Run("TestTermsOfServiceAcceptances", func(t *testing.T) { testTermsOfServiceAcceptances(t, ss) })
 }
 
 func testSaveUserTermsOfService(t *testing.T, ss store.Store) {
@@ -65,3 +66,37 @@ func testDeleteUserTermsOfService(t *testing.T, ss store.Store) {
 	_, err = ss.UserTermsOfService().GetByUser(userTermsOfService.UserId)
 	assert.Equal(t, "store.sql_user_terms_of_service.get_by_user.no_rows.app_error", err.Id)
 }
+
+func testTermsOfServiceAcceptances(t *testing.T, ss store.Store) {
+	termsOfServiceId := model.NewId()
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+
+	acceptance1, err := ss.UserTermsOfService().SaveAcceptance(&model.TermsOfServiceAcceptance{UserId: userId1, TermsOfServiceId: termsOfServiceId, Version: 3, AcceptAt: 1000})
+	require.Nil(t, err)
+	_, err = ss.UserTermsOfService().SaveAcceptance(&model.TermsOfServiceAcceptance{UserId: userId2, TermsOfServiceId: termsOfServiceId, Version: 3, AcceptAt: 2000})
+	require.Nil(t, err)
+	_, err = ss.UserTermsOfService().SaveAcceptance(&model.TermsOfServiceAcceptance{UserId: userId1, TermsOfServiceId: termsOfServiceId, Version: 3, AcceptAt: 3000})
+	require.Nil(t, err)
+	_, err = ss.UserTermsOfService().SaveAcceptance(&model.TermsOfServiceAcceptance{UserId: userId1, TermsOfServiceId: model.NewId(), Version: 4, AcceptAt: 4000})
+	require.Nil(t, err)
+
+	acceptances, err := ss.UserTermsOfService().GetAcceptances(termsOfServiceId, 0, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, acceptances, 3)
+	assert.Equal(t, acceptance1, acceptances[0])
+	assert.Equal(t, userId2, acceptances[1].UserId)
+	assert.Equal(t, int64(3000), acceptances[2].AcceptAt)
+
+	acceptances, err = ss.UserTermsOfService().GetAcceptances(termsOfServiceId, 1000, 0, 10)
+	require.Nil(t, err)
+	assert.Len(t, acceptances, 2)
+
+	acceptances, err = ss.UserTermsOfService().GetAcceptances(termsOfServiceId, 0, 1, 1)
+	require.Nil(t, err)
+	require.Len(t, acceptances, 1)
+	assert.Equal(t, userId2, acceptances[0].UserId)
+
+	_, err = ss.UserTermsOfService().SaveAcceptance(&model.TermsOfServiceAcceptance{UserId: userId1})
+	require.NotNil(t, err)
+}
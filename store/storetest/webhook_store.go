@@ -30,6 +30,7 @@ func TestWebhookStore(t *testing.T, ss store.Store) {
 	t.Run("GetOutgoingListByUser", func(t *testing.T) { testWebhookStoreGetOutgoingListByUser(t, ss) })
 	t.Run("GetOutgoingByChannel", func(t *testing.T) { testWebhookStoreGetOutgoingByChannel(t, ss) })
 	t.Run("GetOutgoingByChannelByUser", func(t *testing.T) { testWebhookStoreGetOutgoingByChannelByUser(t, ss) })
+	t.Run("GetOutgoingForChannel", func(t *testing.T) { testWebhookStoreGetOutgoingForChannel(t, ss) })
 	t.Run("GetOutgoingByTeam", func(t *testing.T) { testWebhookStoreGetOutgoingByTeam(t, ss) })
 	t.Run("GetOutgoingByTeamByUser", func(t *testing.T) { testWebhookStoreGetOutgoingByTeamByUser(t, ss) })
 	t.Run("DeleteOutgoing", func(t *testing.T) { testWebhookStoreDeleteOutgoing(t, ss) })
@@ -511,6 +512,64 @@ func testWebhookStoreGetOutgoingByChannelByUser(t *testing.T, ss store.Store) {
 	})
 }
 
+func testWebhookStoreGetOutgoingForChannel(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	channelId := model.NewId()
+
+	o1 := &model.OutgoingWebhook{}
+	o1.ChannelId = channelId
+	o1.CreatorId = model.NewId()
+	o1.TeamId = teamId
+	o1.CallbackURLs = []string{"http://nowhere.com/"}
+
+	o1, appErr := ss.Webhook().SaveOutgoing(o1)
+	require.Nil(t, appErr)
+
+	o2 := &model.OutgoingWebhook{}
+	o2.CreatorId = model.NewId()
+	o2.TeamId = teamId
+	o2.TriggerWords = []string{"trigger"}
+	o2.CallbackURLs = []string{"http://nowhere.com/"}
+
+	o2, appErr = ss.Webhook().SaveOutgoing(o2)
+	require.Nil(t, appErr)
+
+	o3 := &model.OutgoingWebhook{}
+	o3.ChannelId = model.NewId()
+	o3.CreatorId = model.NewId()
+	o3.TeamId = teamId
+	o3.CallbackURLs = []string{"http://nowhere.com/"}
+
+	_, appErr = ss.Webhook().SaveOutgoing(o3)
+	require.Nil(t, appErr)
+
+	o4 := &model.OutgoingWebhook{}
+	o4.ChannelId = channelId
+	o4.CreatorId = model.NewId()
+	o4.TeamId = teamId
+	o4.CallbackURLs = []string{"http://nowhere.com/"}
+
+	o4, appErr = ss.Webhook().SaveOutgoing(o4)
+	require.Nil(t, appErr)
+	require.Nil(t, ss.Webhook().DeleteOutgoing(o4.Id, model.GetMillis()))
+
+	t.Run("channel and team-wide hooks", func(t *testing.T) {
+		hooks, appErr := ss.Webhook().GetOutgoingForChannel(teamId, channelId)
+		require.Nil(t, appErr)
+		require.Len(t, hooks, 2)
+
+		ids := []string{hooks[0].Id, hooks[1].Id}
+		require.Contains(t, ids, o1.Id)
+		require.Contains(t, ids, o2.Id)
+	})
+
+	t.Run("other team", func(t *testing.T) {
+		hooks, appErr := ss.Webhook().GetOutgoingForChannel(model.NewId(), channelId)
+		require.Nil(t, appErr)
+		require.Empty(t, hooks)
+	})
+}
+
 func testWebhookStoreGetOutgoingByTeam(t *testing.T, ss store.Store) {
 	o1 := &model.OutgoingWebhook{}
 	o1.ChannelId = model.NewId()
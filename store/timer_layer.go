@@ -7,6 +7,7 @@
 package store
 
 import (
+	"context"
 	timemodule "time"
 
 	"github.com/mattermost/mattermost-server/einterfaces"
@@ -15,38 +16,77 @@ import (
 
 type TimerLayer struct {
 	Store
-	Metrics                   einterfaces.MetricsInterface
-	AuditStore                AuditStore
-	BotStore                  BotStore
-	ChannelStore              ChannelStore
-	ChannelMemberHistoryStore ChannelMemberHistoryStore
-	ClusterDiscoveryStore     ClusterDiscoveryStore
-	CommandStore              CommandStore
-	CommandWebhookStore       CommandWebhookStore
-	ComplianceStore           ComplianceStore
-	EmojiStore                EmojiStore
-	FileInfoStore             FileInfoStore
-	GroupStore                GroupStore
-	JobStore                  JobStore
-	LicenseStore              LicenseStore
-	LinkMetadataStore         LinkMetadataStore
-	OAuthStore                OAuthStore
-	PluginStore               PluginStore
-	PostStore                 PostStore
-	PreferenceStore           PreferenceStore
-	ReactionStore             ReactionStore
-	RoleStore                 RoleStore
-	SchemeStore               SchemeStore
-	SessionStore              SessionStore
-	StatusStore               StatusStore
-	SystemStore               SystemStore
-	TeamStore                 TeamStore
-	TermsOfServiceStore       TermsOfServiceStore
-	TokenStore                TokenStore
-	UserStore                 UserStore
-	UserAccessTokenStore      UserAccessTokenStore
-	UserTermsOfServiceStore   UserTermsOfServiceStore
-	WebhookStore              WebhookStore
+	Metrics                       einterfaces.MetricsInterface
+	AnnouncementStore             AnnouncementStore
+	AuditStore                    AuditStore
+	BotStore                      BotStore
+	CallStore                     CallStore
+	ChannelStore                  ChannelStore
+	ChannelMemberExpiryStore      ChannelMemberExpiryStore
+	ChannelMemberHistoryStore     ChannelMemberHistoryStore
+	ChannelMembershipRuleStore    ChannelMembershipRuleStore
+	ChannelReadStatStore          ChannelReadStatStore
+	ChannelTeamBindingStore       ChannelTeamBindingStore
+	ClusterDiscoveryStore         ClusterDiscoveryStore
+	CommandStore                  CommandStore
+	CommandWebhookStore           CommandWebhookStore
+	ComplianceStore               ComplianceStore
+	ConfigAuditStore              ConfigAuditStore
+	ContentFilterStore            ContentFilterStore
+	DailyStatStore                DailyStatStore
+	EmojiStore                    EmojiStore
+	EmojiAliasStore               EmojiAliasStore
+	EphemeralPostStore            EphemeralPostStore
+	FileInfoStore                 FileInfoStore
+	GroupStore                    GroupStore
+	HashtagStore                  HashtagStore
+	IntegrationStatStore          IntegrationStatStore
+	JobStore                      JobStore
+	KeywordRuleStore              KeywordRuleStore
+	LicenseStore                  LicenseStore
+	LicenseUsageStore             LicenseUsageStore
+	LinkMetadataStore             LinkMetadataStore
+	LoginAttemptStore             LoginAttemptStore
+	MentionAliasStore             MentionAliasStore
+	MessageExportConsumerStore    MessageExportConsumerStore
+	MfaRecoveryCodeStore          MfaRecoveryCodeStore
+	OAuthStore                    OAuthStore
+	OnboardingStore               OnboardingStore
+	PendingEmojiStore             PendingEmojiStore
+	PendingPostStore              PendingPostStore
+	PluginStore                   PluginStore
+	PollStore                     PollStore
+	PostStore                     PostStore
+	PostPinEventStore             PostPinEventStore
+	PostPurgeStore                PostPurgeStore
+	PostReportStore               PostReportStore
+	PostStarStore                 PostStarStore
+	PreferenceStore               PreferenceStore
+	PublicPostLinkStore           PublicPostLinkStore
+	ReactionStore                 ReactionStore
+	RecurringPostStore            RecurringPostStore
+	ReminderStore                 ReminderStore
+	RoleStore                     RoleStore
+	SchemaMigrationStore          SchemaMigrationStore
+	SchemeStore                   SchemeStore
+	SessionStore                  SessionStore
+	StatusStore                   StatusStore
+	SystemStore                   SystemStore
+	TeamStore                     TeamStore
+	TermsOfServiceStore           TermsOfServiceStore
+	TodoStore                     TodoStore
+	TokenStore                    TokenStore
+	UserStore                     UserStore
+	UserAccessTokenStore          UserAccessTokenStore
+	UserAttributeStore            UserAttributeStore
+	UserAvailabilityStore         UserAvailabilityStore
+	UserDeactivationScheduleStore UserDeactivationScheduleStore
+	UserTermsOfServiceStore       UserTermsOfServiceStore
+	WebhookStore                  WebhookStore
+}
+
+func (s *TimerLayer) Announcement() AnnouncementStore {
+	return s.AnnouncementStore
 }
 
 func (s *TimerLayer) Audit() AuditStore {
@@ -57,14 +97,34 @@ func (s *TimerLayer) Bot() BotStore {
 	return s.BotStore
 }
 
+func (s *TimerLayer) Call() CallStore {
+	return s.CallStore
+}
+
 func (s *TimerLayer) Channel() ChannelStore {
 	return s.ChannelStore
 }
 
+func (s *TimerLayer) ChannelMemberExpiry() ChannelMemberExpiryStore {
+	return s.ChannelMemberExpiryStore
+}
+
 func (s *TimerLayer) ChannelMemberHistory() ChannelMemberHistoryStore {
 	return s.ChannelMemberHistoryStore
 }
 
+func (s *TimerLayer) ChannelMembershipRule() ChannelMembershipRuleStore {
+	return s.ChannelMembershipRuleStore
+}
+
+func (s *TimerLayer) ChannelReadStat() ChannelReadStatStore {
+	return s.ChannelReadStatStore
+}
+
+func (s *TimerLayer) ChannelTeamBinding() ChannelTeamBindingStore {
+	return s.ChannelTeamBindingStore
+}
+
 func (s *TimerLayer) ClusterDiscovery() ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
@@ -81,10 +141,30 @@ func (s *TimerLayer) Compliance() ComplianceStore {
 	return s.ComplianceStore
 }
 
+func (s *TimerLayer) ConfigAudit() ConfigAuditStore {
+	return s.ConfigAuditStore
+}
+
+func (s *TimerLayer) ContentFilter() ContentFilterStore {
+	return s.ContentFilterStore
+}
+
+func (s *TimerLayer) DailyStat() DailyStatStore {
+	return s.DailyStatStore
+}
+
 func (s *TimerLayer) Emoji() EmojiStore {
 	return s.EmojiStore
 }
 
+func (s *TimerLayer) EmojiAlias() EmojiAliasStore {
+	return s.EmojiAliasStore
+}
+
+func (s *TimerLayer) EphemeralPost() EphemeralPostStore {
+	return s.EphemeralPostStore
+}
+
 func (s *TimerLayer) FileInfo() FileInfoStore {
 	return s.FileInfoStore
 }
@@ -93,42 +173,122 @@ func (s *TimerLayer) Group() GroupStore {
 	return s.GroupStore
 }
 
+func (s *TimerLayer) Hashtag() HashtagStore {
+	return s.HashtagStore
+}
+
+func (s *TimerLayer) IntegrationStat() IntegrationStatStore {
+	return s.IntegrationStatStore
+}
+
 func (s *TimerLayer) Job() JobStore {
 	return s.JobStore
 }
 
+func (s *TimerLayer) KeywordRule() KeywordRuleStore {
+	return s.KeywordRuleStore
+}
+
 func (s *TimerLayer) License() LicenseStore {
 	return s.LicenseStore
 }
 
+func (s *TimerLayer) LicenseUsage() LicenseUsageStore {
+	return s.LicenseUsageStore
+}
+
 func (s *TimerLayer) LinkMetadata() LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *TimerLayer) LoginAttempt() LoginAttemptStore {
+	return s.LoginAttemptStore
+}
+
+func (s *TimerLayer) MentionAlias() MentionAliasStore {
+	return s.MentionAliasStore
+}
+
+func (s *TimerLayer) MessageExportConsumer() MessageExportConsumerStore {
+	return s.MessageExportConsumerStore
+}
+
+func (s *TimerLayer) MfaRecoveryCode() MfaRecoveryCodeStore {
+	return s.MfaRecoveryCodeStore
+}
+
 func (s *TimerLayer) OAuth() OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *TimerLayer) Onboarding() OnboardingStore {
+	return s.OnboardingStore
+}
+
+func (s *TimerLayer) PendingEmoji() PendingEmojiStore {
+	return s.PendingEmojiStore
+}
+
+func (s *TimerLayer) PendingPost() PendingPostStore {
+	return s.PendingPostStore
+}
+
 func (s *TimerLayer) Plugin() PluginStore {
 	return s.PluginStore
 }
 
+func (s *TimerLayer) Poll() PollStore {
+	return s.PollStore
+}
+
 func (s *TimerLayer) Post() PostStore {
 	return s.PostStore
 }
 
+func (s *TimerLayer) PostPinEvent() PostPinEventStore {
+	return s.PostPinEventStore
+}
+
+func (s *TimerLayer) PostPurge() PostPurgeStore {
+	return s.PostPurgeStore
+}
+
+func (s *TimerLayer) PostReport() PostReportStore {
+	return s.PostReportStore
+}
+
+func (s *TimerLayer) PostStar() PostStarStore {
+	return s.PostStarStore
+}
+
 func (s *TimerLayer) Preference() PreferenceStore {
 	return s.PreferenceStore
 }
 
+func (s *TimerLayer) PublicPostLink() PublicPostLinkStore {
+	return s.PublicPostLinkStore
+}
+
 func (s *TimerLayer) Reaction() ReactionStore {
 	return s.ReactionStore
 }
 
+func (s *TimerLayer) RecurringPost() RecurringPostStore {
+	return s.RecurringPostStore
+}
+
+func (s *TimerLayer) Reminder() ReminderStore {
+	return s.ReminderStore
+}
+
 func (s *TimerLayer) Role() RoleStore {
 	return s.RoleStore
 }
 
+func (s *TimerLayer) SchemaMigration() SchemaMigrationStore {
+	return s.SchemaMigrationStore
+}
+
 func (s *TimerLayer) Scheme() SchemeStore {
 	return s.SchemeStore
 }
@@ -153,6 +313,10 @@ func (s *TimerLayer) TermsOfService() TermsOfServiceStore {
 	return s.TermsOfServiceStore
 }
 
+func (s *TimerLayer) Todo() TodoStore {
+	return s.TodoStore
+}
+
 func (s *TimerLayer) Token() TokenStore {
 	return s.TokenStore
 }
@@ -165,6 +329,18 @@ func (s *TimerLayer) UserAccessToken() UserAccessTokenStore {
 	return s.UserAccessTokenStore
 }
 
+func (s *TimerLayer) UserAttribute() UserAttributeStore {
+	return s.UserAttributeStore
+}
+
+func (s *TimerLayer) UserAvailability() UserAvailabilityStore {
+	return s.UserAvailabilityStore
+}
+
+func (s *TimerLayer) UserDeactivationSchedule() UserDeactivationScheduleStore {
+	return s.UserDeactivationScheduleStore
+}
+
 func (s *TimerLayer) UserTermsOfService() UserTermsOfServiceStore {
 	return s.UserTermsOfServiceStore
 }
@@ -173,6 +349,11 @@ func (s *TimerLayer) Webhook() WebhookStore {
 	return s.WebhookStore
 }
 
+type TimerLayerAnnouncementStore struct {
+	AnnouncementStore
+	Root *TimerLayer
+}
+
 type TimerLayerAuditStore struct {
 	AuditStore
 	Root *TimerLayer
@@ -183,16 +364,41 @@ type TimerLayerBotStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerCallStore struct {
+	CallStore
+	Root *TimerLayer
+}
+
 type TimerLayerChannelStore struct {
 	ChannelStore
 	Root *TimerLayer
 }
 
+type TimerLayerChannelMemberExpiryStore struct {
+	ChannelMemberExpiryStore
+	Root *TimerLayer
+}
+
 type TimerLayerChannelMemberHistoryStore struct {
 	ChannelMemberHistoryStore
 	Root *TimerLayer
 }
 
+type TimerLayerChannelMembershipRuleStore struct {
+	ChannelMembershipRuleStore
+	Root *TimerLayer
+}
+
+type TimerLayerChannelReadStatStore struct {
+	ChannelReadStatStore
+	Root *TimerLayer
+}
+
+type TimerLayerChannelTeamBindingStore struct {
+	ChannelTeamBindingStore
+	Root *TimerLayer
+}
+
 type TimerLayerClusterDiscoveryStore struct {
 	ClusterDiscoveryStore
 	Root *TimerLayer
@@ -213,11 +419,36 @@ type TimerLayerComplianceStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerConfigAuditStore struct {
+	ConfigAuditStore
+	Root *TimerLayer
+}
+
+type TimerLayerContentFilterStore struct {
+	ContentFilterStore
+	Root *TimerLayer
+}
+
+type TimerLayerDailyStatStore struct {
+	DailyStatStore
+	Root *TimerLayer
+}
+
 type TimerLayerEmojiStore struct {
 	EmojiStore
 	Root *TimerLayer
 }
 
+type TimerLayerEmojiAliasStore struct {
+	EmojiAliasStore
+	Root *TimerLayer
+}
+
+type TimerLayerEphemeralPostStore struct {
+	EphemeralPostStore
+	Root *TimerLayer
+}
+
 type TimerLayerFileInfoStore struct {
 	FileInfoStore
 	Root *TimerLayer
@@ -228,51 +459,151 @@ type TimerLayerGroupStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerHashtagStore struct {
+	HashtagStore
+	Root *TimerLayer
+}
+
+type TimerLayerIntegrationStatStore struct {
+	IntegrationStatStore
+	Root *TimerLayer
+}
+
 type TimerLayerJobStore struct {
 	JobStore
 	Root *TimerLayer
 }
 
+type TimerLayerKeywordRuleStore struct {
+	KeywordRuleStore
+	Root *TimerLayer
+}
+
 type TimerLayerLicenseStore struct {
 	LicenseStore
 	Root *TimerLayer
 }
 
+type TimerLayerLicenseUsageStore struct {
+	LicenseUsageStore
+	Root *TimerLayer
+}
+
 type TimerLayerLinkMetadataStore struct {
 	LinkMetadataStore
 	Root *TimerLayer
 }
 
+type TimerLayerLoginAttemptStore struct {
+	LoginAttemptStore
+	Root *TimerLayer
+}
+
+type TimerLayerMentionAliasStore struct {
+	MentionAliasStore
+	Root *TimerLayer
+}
+
+type TimerLayerMessageExportConsumerStore struct {
+	MessageExportConsumerStore
+	Root *TimerLayer
+}
+
+type TimerLayerMfaRecoveryCodeStore struct {
+	MfaRecoveryCodeStore
+	Root *TimerLayer
+}
+
 type TimerLayerOAuthStore struct {
 	OAuthStore
 	Root *TimerLayer
 }
 
+type TimerLayerOnboardingStore struct {
+	OnboardingStore
+	Root *TimerLayer
+}
+
+type TimerLayerPendingEmojiStore struct {
+	PendingEmojiStore
+	Root *TimerLayer
+}
+
+type TimerLayerPendingPostStore struct {
+	PendingPostStore
+	Root *TimerLayer
+}
+
 type TimerLayerPluginStore struct {
 	PluginStore
 	Root *TimerLayer
 }
 
+type TimerLayerPollStore struct {
+	PollStore
+	Root *TimerLayer
+}
+
 type TimerLayerPostStore struct {
 	PostStore
 	Root *TimerLayer
 }
 
+type TimerLayerPostPinEventStore struct {
+	PostPinEventStore
+	Root *TimerLayer
+}
+
+type TimerLayerPostPurgeStore struct {
+	PostPurgeStore
+	Root *TimerLayer
+}
+
+type TimerLayerPostReportStore struct {
+	PostReportStore
+	Root *TimerLayer
+}
+
+type TimerLayerPostStarStore struct {
+	PostStarStore
+	Root *TimerLayer
+}
+
 type TimerLayerPreferenceStore struct {
 	PreferenceStore
 	Root *TimerLayer
 }
 
+type TimerLayerPublicPostLinkStore struct {
+	PublicPostLinkStore
+	Root *TimerLayer
+}
+
 type TimerLayerReactionStore struct {
 	ReactionStore
 	Root *TimerLayer
 }
 
+type TimerLayerRecurringPostStore struct {
+	RecurringPostStore
+	Root *TimerLayer
+}
+
+type TimerLayerReminderStore struct {
+	ReminderStore
+	Root *TimerLayer
+}
+
 type TimerLayerRoleStore struct {
 	RoleStore
 	Root *TimerLayer
 }
 
+type TimerLayerSchemaMigrationStore struct {
+	SchemaMigrationStore
+	Root *TimerLayer
+}
+
 type TimerLayerSchemeStore struct {
 	SchemeStore
 	Root *TimerLayer
@@ -303,6 +634,11 @@ type TimerLayerTermsOfServiceStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerTodoStore struct {
+	TodoStore
+	Root *TimerLayer
+}
+
 type TimerLayerTokenStore struct {
 	TokenStore
 	Root *TimerLayer
@@ -318,6 +654,21 @@ type TimerLayerUserAccessTokenStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerUserAttributeStore struct {
+	UserAttributeStore
+	Root *TimerLayer
+}
+
+type TimerLayerUserAvailabilityStore struct {
+	UserAvailabilityStore
+	Root *TimerLayer
+}
+
+type TimerLayerUserDeactivationScheduleStore struct {
+	UserDeactivationScheduleStore
+	Root *TimerLayer
+}
+
 type TimerLayerUserTermsOfServiceStore struct {
 	UserTermsOfServiceStore
 	Root *TimerLayer
@@ -328,1002 +679,1124 @@ type TimerLayerWebhookStore struct {
 	Root *TimerLayer
 }
 
-func (s *TimerLayerAuditStore) Get(user_id string, offset int, limit int) (model.Audits, *model.AppError) {
+func (s *TimerLayerAnnouncementStore) Acknowledge(announcementId string, userId string, acknowledgeAt int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.AuditStore.Get(user_id, offset, limit)
+	resultVar0 := s.AnnouncementStore.Acknowledge(announcementId, userId, acknowledgeAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.Acknowledge")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.Acknowledge", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerAuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+func (s *TimerLayerAnnouncementStore) Delete(id string, deleteAt int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.AuditStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0 := s.AnnouncementStore.Delete(id, deleteAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.Delete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerAuditStore) PermanentDeleteByUser(userId string) *model.AppError {
+func (s *TimerLayerAnnouncementStore) Get(id string) (*model.Announcement, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.AuditStore.PermanentDeleteByUser(userId)
+	resultVar0, resultVar1 := s.AnnouncementStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.Get", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerAuditStore) Save(audit *model.Audit) *model.AppError {
+func (s *TimerLayerAnnouncementStore) GetAll(offset int, limit int) ([]*model.Announcement, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.AuditStore.Save(audit)
+	resultVar0, resultVar1 := s.AnnouncementStore.GetAll(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.GetAll", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot, *model.AppError) {
+func (s *TimerLayerAnnouncementStore) GetPendingForUser(userId string, now int64) ([]*model.Announcement, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.BotStore.Get(userId, includeDeleted)
+	resultVar0, resultVar1 := s.AnnouncementStore.GetPendingForUser(userId, now)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.GetPendingForUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.GetPendingForUser", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, *model.AppError) {
+func (s *TimerLayerAnnouncementStore) GetRecipient(announcementId string, userId string) (*model.AnnouncementRecipient, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.BotStore.GetAll(options)
+	resultVar0, resultVar1 := s.AnnouncementStore.GetRecipient(announcementId, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.GetRecipient")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.GetRecipient", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerBotStore) PermanentDelete(userId string) *model.AppError {
+func (s *TimerLayerAnnouncementStore) GetRecipients(announcementId string, offset int, limit int) ([]*model.AnnouncementRecipient, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.BotStore.PermanentDelete(userId)
+	resultVar0, resultVar1 := s.AnnouncementStore.GetRecipients(announcementId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.GetRecipients")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.PermanentDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.GetRecipients", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerBotStore) Save(bot *model.Bot) (*model.Bot, *model.AppError) {
+func (s *TimerLayerAnnouncementStore) GetReport(announcementId string) (*model.AnnouncementReport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.BotStore.Save(bot)
+	resultVar0, resultVar1 := s.AnnouncementStore.GetReport(announcementId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.GetReport")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.GetReport", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerBotStore) Update(bot *model.Bot) (*model.Bot, *model.AppError) {
+func (s *TimerLayerAnnouncementStore) PermanentDeleteRecipientsByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.BotStore.Update(bot)
+	resultVar0 := s.AnnouncementStore.PermanentDeleteRecipientsByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.PermanentDeleteRecipientsByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.PermanentDeleteRecipientsByUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) AnalyticsDeletedTypeCount(teamId string, channelType string) (int64, *model.AppError) {
+func (s *TimerLayerAnnouncementStore) Save(announcement *model.Announcement) (*model.Announcement, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.AnalyticsDeletedTypeCount(teamId, channelType)
+	resultVar0, resultVar1 := s.AnnouncementStore.Save(announcement)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AnalyticsDeletedTypeCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) AnalyticsTypeCount(teamId string, channelType string) (int64, *model.AppError) {
+func (s *TimerLayerAnnouncementStore) SaveRecipients(recipients []*model.AnnouncementRecipient) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.AnalyticsTypeCount(teamId, channelType)
+	resultVar0 := s.AnnouncementStore.SaveRecipients(recipients)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.SaveRecipients")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AnalyticsTypeCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.SaveRecipients", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) AutocompleteInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerAnnouncementStore) UpdateRecipientDelivery(announcementId string, userId string, status string, postId string, deliverAt int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.AutocompleteInTeam(teamId, term, includeDeleted)
+	resultVar0 := s.AnnouncementStore.UpdateRecipientDelivery(announcementId, userId, status, postId, deliverAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AnnouncementStore.UpdateRecipientDelivery")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AutocompleteInTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AnnouncementStore.UpdateRecipientDelivery", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) AutocompleteInTeamForSearch(teamId string, userId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerAuditStore) Get(user_id string, offset int, limit int) (model.Audits, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.AutocompleteInTeamForSearch(teamId, userId, term, includeDeleted)
+	resultVar0, resultVar1 := s.AuditStore.Get(user_id, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AuditStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AutocompleteInTeamForSearch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) ClearAllCustomRoleAssignments() *model.AppError {
+func (s *TimerLayerAuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.ClearAllCustomRoleAssignments()
+	resultVar0, resultVar1 := s.AuditStore.PermanentDeleteBatch(endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AuditStore.PermanentDeleteBatch")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ClearAllCustomRoleAssignments", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.PermanentDeleteBatch", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) ClearCaches() {
+func (s *TimerLayerAuditStore) PermanentDeleteByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	s.ChannelStore.ClearCaches()
+	resultVar0 := s.AuditStore.PermanentDeleteByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AuditStore.PermanentDeleteByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, *model.AppError) {
+func (s *TimerLayerAuditStore) Save(audit *model.Audit) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.CreateDirectChannel(userId, otherUserId)
+	resultVar0 := s.AuditStore.Save(audit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("AuditStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.CreateDirectChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.Save", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) Delete(channelId string, time int64) *model.AppError {
+func (s *TimerLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.Delete(channelId, time)
+	resultVar0, resultVar1 := s.BotStore.Get(userId, includeDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("BotStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Get", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) Get(id string, allowFromCache bool) (*model.Channel, *model.AppError) {
+func (s *TimerLayerBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.Get(id, allowFromCache)
+	resultVar0, resultVar1 := s.BotStore.GetAll(options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("BotStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetAll(teamId string) ([]*model.Channel, *model.AppError) {
+func (s *TimerLayerBotStore) GetAllForChannel(channelId string) ([]*model.Bot, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAll(teamId)
+	resultVar0, resultVar1 := s.BotStore.GetAllForChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("BotStore.GetAllForChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.GetAllForChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetAllChannelMembersForUser(userId string, allowFromCache bool, includeDeleted bool) (map[string]string, *model.AppError) {
+func (s *TimerLayerBotStore) PermanentDelete(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelMembersForUser(userId, allowFromCache, includeDeleted)
+	resultVar0 := s.BotStore.PermanentDelete(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("BotStore.PermanentDelete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelMembersForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.PermanentDelete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel(channelId string, allowFromCache bool) (map[string]model.StringMap, *model.AppError) {
+func (s *TimerLayerBotStore) Save(bot *model.Bot) (*model.Bot, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelMembersNotifyPropsForChannel(channelId, allowFromCache)
+	resultVar0, resultVar1 := s.BotStore.Save(bot)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("BotStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelMembersNotifyPropsForChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetAllChannels(page int, perPage int, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, *model.AppError) {
+func (s *TimerLayerBotStore) Update(bot *model.Bot) (*model.Bot, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllChannels(page, perPage, opts)
+	resultVar0, resultVar1 := s.BotStore.Update(bot)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("BotStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetAllChannelsCount(opts ChannelSearchOpts) (int64, *model.AppError) {
+func (s *TimerLayerCallStore) Get(id string) (*model.Call, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelsCount(opts)
+	resultVar0, resultVar1 := s.CallStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CallStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelsCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CallStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetAllChannelsForExportAfter(limit int, afterId string) ([]*model.ChannelForExport, *model.AppError) {
+func (s *TimerLayerCallStore) GetActiveForChannel(channelId string) ([]*model.Call, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelsForExportAfter(limit, afterId)
+	resultVar0, resultVar1 := s.CallStore.GetActiveForChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CallStore.GetActiveForChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelsForExportAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CallStore.GetActiveForChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterId string) ([]*model.DirectChannelForExport, *model.AppError) {
+func (s *TimerLayerCallStore) GetParticipants(callId string) ([]*model.CallParticipant, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllDirectChannelsForExportAfter(limit, afterId)
+	resultVar0, resultVar1 := s.CallStore.GetParticipants(callId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CallStore.GetParticipants")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllDirectChannelsForExportAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CallStore.GetParticipants", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetByName(team_id string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
+func (s *TimerLayerCallStore) PermanentDeleteByChannel(channelId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetByName(team_id, name, allowFromCache)
+	resultVar0 := s.CallStore.PermanentDeleteByChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CallStore.PermanentDeleteByChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CallStore.PermanentDeleteByChannel", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) GetByNameIncludeDeleted(team_id string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
+func (s *TimerLayerCallStore) Save(call *model.Call) (*model.Call, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetByNameIncludeDeleted(team_id, name, allowFromCache)
+	resultVar0, resultVar1 := s.CallStore.Save(call)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CallStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByNameIncludeDeleted", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CallStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetByNames(team_id string, names []string, allowFromCache bool) ([]*model.Channel, *model.AppError) {
+func (s *TimerLayerCallStore) SaveParticipant(participant *model.CallParticipant) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetByNames(team_id, names, allowFromCache)
+	resultVar0 := s.CallStore.SaveParticipant(participant)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CallStore.SaveParticipant")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByNames", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CallStore.SaveParticipant", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, *model.AppError) {
+func (s *TimerLayerCallStore) Update(call *model.Call) (*model.Call, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelCounts(teamId, userId)
+	resultVar0, resultVar1 := s.CallStore.Update(call)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CallStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelCounts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CallStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetChannelMembersForExport(userId string, teamId string) ([]*model.ChannelMemberForExport, *model.AppError) {
+func (s *TimerLayerChannelStore) AnalyticsDeletedTypeCount(teamId string, channelType string) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelMembersForExport(userId, teamId)
+	resultVar0, resultVar1 := s.ChannelStore.AnalyticsDeletedTypeCount(teamId, channelType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.AnalyticsDeletedTypeCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelMembersForExport", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AnalyticsDeletedTypeCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, *model.AppError) {
+func (s *TimerLayerChannelStore) AnalyticsTypeCount(teamId string, channelType string) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelMembersTimezones(channelId)
+	resultVar0, resultVar1 := s.ChannelStore.AnalyticsTypeCount(teamId, channelType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.AnalyticsTypeCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelMembersTimezones", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AnalyticsTypeCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetChannelUnread(channelId string, userId string) (*model.ChannelUnread, *model.AppError) {
+func (s *TimerLayerChannelStore) AutocompleteInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelUnread(channelId, userId)
+	resultVar0, resultVar1 := s.ChannelStore.AutocompleteInTeam(teamId, term, includeDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.AutocompleteInTeam")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelUnread", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AutocompleteInTeam", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetChannels(teamId string, userId string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerChannelStore) AutocompleteInTeamForSearch(teamId string, userId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannels(teamId, userId, includeDeleted)
+	resultVar0, resultVar1 := s.ChannelStore.AutocompleteInTeamForSearch(teamId, userId, term, includeDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.AutocompleteInTeamForSearch")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AutocompleteInTeamForSearch", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetChannelsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.Channel, *model.AppError) {
+func (s *TimerLayerChannelStore) ClearAllCustomRoleAssignments() *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelsBatchForIndexing(startTime, endTime, limit)
+	resultVar0 := s.ChannelStore.ClearAllCustomRoleAssignments()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.ClearAllCustomRoleAssignments")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsBatchForIndexing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ClearAllCustomRoleAssignments", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) GetChannelsByIds(channelIds []string) ([]*model.Channel, *model.AppError) {
+func (s *TimerLayerChannelStore) ClearCaches() {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelsByIds(channelIds)
+	s.ChannelStore.ClearCaches()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ClearCaches", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return
 }
 
-func (s *TimerLayerChannelStore) GetChannelsByScheme(schemeId string, offset int, limit int) (model.ChannelList, *model.AppError) {
+func (s *TimerLayerChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelsByScheme(schemeId, offset, limit)
+	resultVar0, resultVar1 := s.ChannelStore.CreateDirectChannel(userId, otherUserId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.CreateDirectChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsByScheme", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.CreateDirectChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetDeleted(team_id string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerChannelStore) Delete(channelId string, time int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetDeleted(team_id, offset, limit)
+	resultVar0 := s.ChannelStore.Delete(channelId, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDeleted", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Delete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) GetDeletedByName(team_id string, name string) (*model.Channel, *model.AppError) {
+func (s *TimerLayerChannelStore) Get(id string, allowFromCache bool) (*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetDeletedByName(team_id, name)
+	resultVar0, resultVar1 := s.ChannelStore.Get(id, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDeletedByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetForPost(postId string) (*model.Channel, *model.AppError) {
+func (s *TimerLayerChannelStore) GetAll(teamId string) ([]*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetForPost(postId)
+	resultVar0, resultVar1 := s.ChannelStore.GetAll(teamId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetFromMaster(id string) (*model.Channel, *model.AppError) {
+func (s *TimerLayerChannelStore) GetAllChannelMembersForUser(userId string, allowFromCache bool, includeDeleted bool) (map[string]string, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetFromMaster(id)
+	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelMembersForUser(userId, allowFromCache, includeDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetAllChannelMembersForUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetFromMaster", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelMembersForUser", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetGuestCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+func (s *TimerLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel(channelId string, allowFromCache bool) (map[string]model.StringMap, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetGuestCount(channelId, allowFromCache)
+	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelMembersNotifyPropsForChannel(channelId, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetAllChannelMembersNotifyPropsForChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetGuestCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelMembersNotifyPropsForChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetGuestCountFromCache(channelId string) int64 {
+func (s *TimerLayerChannelStore) GetAllChannels(page int, perPage int, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.GetGuestCountFromCache(channelId)
+	resultVar0, resultVar1 := s.ChannelStore.GetAllChannels(page, perPage, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetAllChannels")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetGuestCountFromCache", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannels", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, *model.AppError) {
+func (s *TimerLayerChannelStore) GetAllChannelsCount(opts ChannelSearchOpts) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMember(channelId, userId)
+	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelsCount(opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetAllChannelsCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelsCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+func (s *TimerLayerChannelStore) GetAllChannelsForExportAfter(limit int, afterId string) ([]*model.ChannelForExport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMemberCount(channelId, allowFromCache)
+	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelsForExportAfter(limit, afterId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetAllChannelsForExportAfter")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelsForExportAfter", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetMemberCountFromCache(channelId string) int64 {
+func (s *TimerLayerChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterId string) ([]*model.DirectChannelForExport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.GetMemberCountFromCache(channelId)
+	resultVar0, resultVar1 := s.ChannelStore.GetAllDirectChannelsForExportAfter(limit, afterId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetAllDirectChannelsForExportAfter")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCountFromCache", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllDirectChannelsForExportAfter", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetMemberForPost(postId string, userId string) (*model.ChannelMember, *model.AppError) {
+func (s *TimerLayerChannelStore) GetByName(team_id string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMemberForPost(postId, userId)
+	resultVar0, resultVar1 := s.ChannelStore.GetByName(team_id, name, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetByName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByName", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetMembers(channelId string, offset int, limit int) (*model.ChannelMembers, *model.AppError) {
+func (s *TimerLayerChannelStore) GetByNameIncludeDeleted(team_id string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMembers(channelId, offset, limit)
+	resultVar0, resultVar1 := s.ChannelStore.GetByNameIncludeDeleted(team_id, name, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetByNameIncludeDeleted")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByNameIncludeDeleted", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetMembersByIds(channelId string, userIds []string) (*model.ChannelMembers, *model.AppError) {
+func (s *TimerLayerChannelStore) GetByNames(team_id string, names []string, allowFromCache bool) ([]*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMembersByIds(channelId, userIds)
+	resultVar0, resultVar1 := s.ChannelStore.GetByNames(team_id, names, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetByNames")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByNames", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetMembersForUser(teamId string, userId string) (*model.ChannelMembers, *model.AppError) {
+func (s *TimerLayerChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMembersForUser(teamId, userId)
+	resultVar0, resultVar1 := s.ChannelStore.GetChannelCounts(teamId, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetChannelCounts")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelCounts", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetMembersForUserWithPagination(teamId string, userId string, page int, perPage int) (*model.ChannelMembers, *model.AppError) {
+func (s *TimerLayerChannelStore) GetChannelMembersForExport(userId string, teamId string) ([]*model.ChannelMemberForExport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMembersForUserWithPagination(teamId, userId, page, perPage)
+	resultVar0, resultVar1 := s.ChannelStore.GetChannelMembersForExport(userId, teamId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetChannelMembersForExport")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersForUserWithPagination", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelMembersForExport", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
+	resultVar0, resultVar1 := s.ChannelStore.GetChannelMembersTimezones(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetChannelMembersTimezones")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMoreChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelMembersTimezones", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+func (s *TimerLayerChannelStore) GetChannelUnread(channelId string, userId string) (*model.ChannelUnread, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
+	resultVar0, resultVar1 := s.ChannelStore.GetChannelUnread(channelId, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetChannelUnread")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPostCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelUnread", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetPinnedPostCountFromCache(channelId string) int64 {
+func (s *TimerLayerChannelStore) GetChannels(teamId string, userId string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.GetPinnedPostCountFromCache(channelId)
+	resultVar0, resultVar1 := s.ChannelStore.GetChannels(teamId, userId, includeDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetChannels")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPostCountFromCache", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannels", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetPinnedPosts(channelId string) (*model.PostList, *model.AppError) {
+func (s *TimerLayerChannelStore) GetChannelsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetPinnedPosts(channelId)
+	resultVar0, resultVar1 := s.ChannelStore.GetChannelsBatchForIndexing(startTime, endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetChannelsBatchForIndexing")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsBatchForIndexing", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerChannelStore) GetChannelsByIds(channelIds []string) ([]*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetPublicChannelsByIdsForTeam(teamId, channelIds)
+	resultVar0, resultVar1 := s.ChannelStore.GetChannelsByIds(channelIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetChannelsByIds")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPublicChannelsByIdsForTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsByIds", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerChannelStore) GetChannelsByScheme(schemeId string, offset int, limit int) (model.ChannelList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetPublicChannelsForTeam(teamId, offset, limit)
+	resultVar0, resultVar1 := s.ChannelStore.GetChannelsByScheme(schemeId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetChannelsByScheme")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPublicChannelsForTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsByScheme", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerChannelStore) GetDeleted(team_id string, offset int, limit int) (*model.ChannelList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetTeamChannels(teamId)
+	resultVar0, resultVar1 := s.ChannelStore.GetDeleted(team_id, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetDeleted")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetTeamChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDeleted", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) IncrementMentionCount(channelId string, userId string) *model.AppError {
+func (s *TimerLayerChannelStore) GetDeletedByName(team_id string, name string) (*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.IncrementMentionCount(channelId, userId)
+	resultVar0, resultVar1 := s.ChannelStore.GetDeletedByName(team_id, name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetDeletedByName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.IncrementMentionCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDeletedByName", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) InvalidateAllChannelMembersForUser(userId string) {
+func (s *TimerLayerChannelStore) GetDirectMessagePartners(userId string, limit int) (map[string]int64, *model.AppError) {
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateAllChannelMembersForUser(userId)
+	resultVar0, resultVar1 := s.ChannelStore.GetDirectMessagePartners(userId, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetDirectMessagePartners")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateAllChannelMembersForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDirectMessagePartners", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) InvalidateCacheForChannelMembersNotifyProps(channelId string) {
+func (s *TimerLayerChannelStore) GetForPost(postId string) (*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateCacheForChannelMembersNotifyProps(channelId)
+	resultVar0, resultVar1 := s.ChannelStore.GetForPost(postId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetForPost")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateCacheForChannelMembersNotifyProps", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetForPost", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) InvalidateChannel(id string) {
+func (s *TimerLayerChannelStore) GetFromMaster(id string) (*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateChannel(id)
+	resultVar0, resultVar1 := s.ChannelStore.GetFromMaster(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetFromMaster")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetFromMaster", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) InvalidateChannelByName(teamId string, name string) {
+func (s *TimerLayerChannelStore) GetGuestCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateChannelByName(teamId, name)
+	resultVar0, resultVar1 := s.ChannelStore.GetGuestCount(channelId, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetGuestCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateChannelByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetGuestCount", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) InvalidateGuestCount(channelId string) {
+func (s *TimerLayerChannelStore) GetGuestCountFromCache(channelId string) int64 {
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateGuestCount(channelId)
+	resultVar0 := s.ChannelStore.GetGuestCountFromCache(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -1331,47 +1804,51 @@ func (s *TimerLayerChannelStore) InvalidateGuestCount(channelId string) {
 		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateGuestCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetGuestCountFromCache", success, elapsed)
 	}
-	return
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) InvalidateMemberCount(channelId string) {
+func (s *TimerLayerChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, *model.AppError) {
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateMemberCount(channelId)
+	resultVar0, resultVar1 := s.ChannelStore.GetMember(channelId, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetMember")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateMemberCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMember", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) InvalidatePinnedPostCount(channelId string) {
+func (s *TimerLayerChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidatePinnedPostCount(channelId)
+	resultVar0, resultVar1 := s.ChannelStore.GetMemberCount(channelId, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetMemberCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidatePinnedPostCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCount", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) IsUserInChannelUseCache(userId string, channelId string) bool {
+func (s *TimerLayerChannelStore) GetMemberCountFromCache(channelId string) int64 {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.IsUserInChannelUseCache(userId, channelId)
+	resultVar0 := s.ChannelStore.GetMemberCountFromCache(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -1379,31 +1856,177 @@ func (s *TimerLayerChannelStore) IsUserInChannelUseCache(userId string, channelI
 		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.IsUserInChannelUseCache", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCountFromCache", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, *model.AppError) {
+func (s *TimerLayerChannelStore) GetMemberCountsByRole(channelId string) (*model.ChannelMemberCounts, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+	resultVar0, resultVar1 := s.ChannelStore.GetMemberCountsByRole(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetMemberCountsByRole")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.MigrateChannelMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCountsByRole", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) MigratePublicChannels() error {
+func (s *TimerLayerChannelStore) GetMemberForPost(postId string, userId string) (*model.ChannelMember, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.MigratePublicChannels()
+	resultVar0, resultVar1 := s.ChannelStore.GetMemberForPost(postId, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetMemberForPost")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberForPost", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetMembers(channelId string, offset int, limit int) (*model.ChannelMembers, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetMembers(channelId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetMembers")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembers", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetMembersByIds(channelId string, userIds []string) (*model.ChannelMembers, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetMembersByIds(channelId, userIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetMembersByIds")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersByIds", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetMembersForUser(teamId string, userId string) (*model.ChannelMembers, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetMembersForUser(teamId, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetMembersForUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetMembersForUserWithPagination(teamId string, userId string, page int, perPage int) (*model.ChannelMembers, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetMembersForUserWithPagination(teamId, userId, page, perPage)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetMembersForUserWithPagination")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersForUserWithPagination", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetMembersWithOptions(channelId string, offset int, limit int, options *model.ChannelMembersGetOptions) (*model.ChannelMembers, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetMembersWithOptions(channelId, offset, limit, options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetMembersWithOptions")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersWithOptions", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetMoreChannels")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMoreChannels", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetPinnedPostCount")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPostCount", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetPinnedPostCountFromCache(channelId string) int64 {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.GetPinnedPostCountFromCache(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -1411,943 +2034,5317 @@ func (s *TimerLayerChannelStore) MigratePublicChannels() error {
 		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.MigratePublicChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPostCountFromCache", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) PermanentDelete(channelId string) *model.AppError {
+func (s *TimerLayerChannelStore) GetPinnedPosts(channelId string) (*model.PostList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.PermanentDelete(channelId)
+	resultVar0, resultVar1 := s.ChannelStore.GetPinnedPosts(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetPinnedPosts")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPosts", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetPublicChannelsByIdsForTeam(teamId, channelIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetPublicChannelsByIdsForTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPublicChannelsByIdsForTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetPublicChannelsForTeam(teamId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetPublicChannelsForTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPublicChannelsForTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetSharedChannelCounts(userId string, limit int) (map[string]int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetSharedChannelCounts(userId, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetSharedChannelCounts")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetSharedChannelCounts", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetTeamChannels(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.GetTeamChannels")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetTeamChannels", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) IncrementMentionCount(channelId string, userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.IncrementMentionCount(channelId, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.IncrementMentionCount")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.IncrementMentionCount", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) IncrementMsgCount(channelId string, userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.IncrementMsgCount(channelId, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.IncrementMsgCount")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.IncrementMsgCount", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) InvalidateAllChannelMembersForUser(userId string) {
+	start := timemodule.Now()
+
+	s.ChannelStore.InvalidateAllChannelMembersForUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateAllChannelMembersForUser", success, elapsed)
+	}
+	return
+}
+
+func (s *TimerLayerChannelStore) InvalidateCacheForChannelMembersNotifyProps(channelId string) {
+	start := timemodule.Now()
+
+	s.ChannelStore.InvalidateCacheForChannelMembersNotifyProps(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateCacheForChannelMembersNotifyProps", success, elapsed)
+	}
+	return
+}
+
+func (s *TimerLayerChannelStore) InvalidateChannel(id string) {
+	start := timemodule.Now()
+
+	s.ChannelStore.InvalidateChannel(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateChannel", success, elapsed)
+	}
+	return
+}
+
+func (s *TimerLayerChannelStore) InvalidateChannelByName(teamId string, name string) {
+	start := timemodule.Now()
+
+	s.ChannelStore.InvalidateChannelByName(teamId, name)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateChannelByName", success, elapsed)
+	}
+	return
+}
+
+func (s *TimerLayerChannelStore) InvalidateGuestCount(channelId string) {
+	start := timemodule.Now()
+
+	s.ChannelStore.InvalidateGuestCount(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateGuestCount", success, elapsed)
+	}
+	return
+}
+
+func (s *TimerLayerChannelStore) InvalidateMemberCount(channelId string) {
+	start := timemodule.Now()
+
+	s.ChannelStore.InvalidateMemberCount(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateMemberCount", success, elapsed)
+	}
+	return
+}
+
+func (s *TimerLayerChannelStore) InvalidatePinnedPostCount(channelId string) {
+	start := timemodule.Now()
+
+	s.ChannelStore.InvalidatePinnedPostCount(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidatePinnedPostCount", success, elapsed)
+	}
+	return
+}
+
+func (s *TimerLayerChannelStore) IsUserInChannelUseCache(userId string, channelId string) bool {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.IsUserInChannelUseCache(userId, channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.IsUserInChannelUseCache", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.MigrateChannelMembers")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.MigrateChannelMembers", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) MigratePublicChannels() error {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.MigratePublicChannels()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.MigratePublicChannels", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) PermanentDelete(channelId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.PermanentDelete(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.PermanentDelete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDelete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.PermanentDeleteByTeam(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.PermanentDeleteByTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteByTeam", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) PermanentDeleteMembersByChannel(channelId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.PermanentDeleteMembersByChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteMembersByChannel", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) PermanentDeleteMembersByUser(userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.PermanentDeleteMembersByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.PermanentDeleteMembersByUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteMembersByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.RemoveAllDeactivatedMembers")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.RemoveAllDeactivatedMembers", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) RemoveMember(channelId string, userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.RemoveMember(channelId, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.RemoveMember")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.RemoveMember", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) ResetAllChannelSchemes() *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.ResetAllChannelSchemes()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.ResetAllChannelSchemes")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ResetAllChannelSchemes", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) Restore(channelId string, time int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.Restore(channelId, time)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.Restore")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Restore", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.Save(channel, maxChannelsPerTeam)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.SaveDirectChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveDirectChannel", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.SaveMember(member)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.SaveMember")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveMember", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) SearchAllChannels(term string, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.SearchAllChannels(term, opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.SearchAllChannels")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchAllChannels", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) SearchForUserInTeam(userId string, teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.SearchForUserInTeam(userId, teamId, term, includeDeleted)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.SearchForUserInTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchForUserInTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) SearchGroupChannels(userId string, term string) (*model.ChannelList, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.SearchGroupChannels(userId, term)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.SearchGroupChannels")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchGroupChannels", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) SearchInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.SearchInTeam(teamId, term, includeDeleted)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.SearchInTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchInTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) SearchMore(userId string, teamId string, term string) (*model.ChannelList, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.SearchMore(userId, teamId, term)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.SearchMore")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchMore", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.SetDeleteAt")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SetDeleteAt", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelStore) Update(channel *model.Channel) (*model.Channel, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.Update(channel)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.Update")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId string) (map[string]int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.UpdateLastViewedAt(channelIds, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.UpdateLastViewedAt")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateLastViewedAt", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.UpdateMember(member)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.UpdateMember")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateMember", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) UserBelongsToChannels(userId string, channelIds []string) (bool, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.UserBelongsToChannels(userId, channelIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelStore.UserBelongsToChannels")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UserBelongsToChannels", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMemberExpiryStore) Delete(channelId string, userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelMemberExpiryStore.Delete(channelId, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMemberExpiryStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberExpiryStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelMemberExpiryStore) Get(channelId string, userId string) (*model.ChannelMemberExpiry, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMemberExpiryStore.Get(channelId, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMemberExpiryStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberExpiryStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMemberExpiryStore) GetExpired(before int64, limit int) ([]*model.ChannelMemberExpiry, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMemberExpiryStore.GetExpired(before, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMemberExpiryStore.GetExpired")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberExpiryStore.GetExpired", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMemberExpiryStore) GetForChannel(channelId string) ([]*model.ChannelMemberExpiry, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMemberExpiryStore.GetForChannel(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMemberExpiryStore.GetForChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberExpiryStore.GetForChannel", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMemberExpiryStore) PermanentDeleteByUser(userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelMemberExpiryStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMemberExpiryStore.PermanentDeleteByUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberExpiryStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelMemberExpiryStore) Save(expiry *model.ChannelMemberExpiry) (*model.ChannelMemberExpiry, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMemberExpiryStore.Save(expiry)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMemberExpiryStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberExpiryStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMemberHistoryStore.GetUsersInChannelDuring")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetUsersInChannelDuring", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMemberHistoryStore.LogJoinEvent")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogJoinEvent", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMemberHistoryStore.LogLeaveEvent")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogLeaveEvent", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMemberHistoryStore.PermanentDeleteBatch")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) Delete(id string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelMembershipRuleStore.Delete(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) DeleteOverride(channelId string, userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelMembershipRuleStore.DeleteOverride(channelId, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.DeleteOverride")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.DeleteOverride", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) Get(id string) (*model.ChannelMembershipRule, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMembershipRuleStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) GetChannelIds() ([]string, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMembershipRuleStore.GetChannelIds()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.GetChannelIds")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.GetChannelIds", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) GetForChannel(channelId string) ([]*model.ChannelMembershipRule, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMembershipRuleStore.GetForChannel(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.GetForChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.GetForChannel", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) GetOverride(channelId string, userId string) (*model.ChannelMembershipOverride, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMembershipRuleStore.GetOverride(channelId, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.GetOverride")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.GetOverride", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) GetOverridesForChannel(channelId string) ([]*model.ChannelMembershipOverride, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMembershipRuleStore.GetOverridesForChannel(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.GetOverridesForChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.GetOverridesForChannel", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelMembershipRuleStore.PermanentDeleteByChannel(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.PermanentDeleteByChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.PermanentDeleteByChannel", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) PermanentDeleteOverridesByUser(userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelMembershipRuleStore.PermanentDeleteOverridesByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.PermanentDeleteOverridesByUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.PermanentDeleteOverridesByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) Save(rule *model.ChannelMembershipRule) (*model.ChannelMembershipRule, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMembershipRuleStore.Save(rule)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelMembershipRuleStore) SaveOverride(override *model.ChannelMembershipOverride) (*model.ChannelMembershipOverride, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMembershipRuleStore.SaveOverride(override)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelMembershipRuleStore.SaveOverride")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMembershipRuleStore.SaveOverride", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelReadStatStore) Compute(since int64, until int64, minimumMembers int) ([]*model.ChannelReadStat, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelReadStatStore.Compute(since, until, minimumMembers)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelReadStatStore.Compute")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelReadStatStore.Compute", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelReadStatStore) Get(teamId string, channelId string, since int64, until int64, offset int, limit int) ([]*model.ChannelReadStat, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelReadStatStore.Get(teamId, channelId, since, until, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelReadStatStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelReadStatStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelReadStatStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelReadStatStore.PermanentDeleteByChannel(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelReadStatStore.PermanentDeleteByChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelReadStatStore.PermanentDeleteByChannel", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelReadStatStore) Save(stat *model.ChannelReadStat) (*model.ChannelReadStat, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelReadStatStore.Save(stat)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelReadStatStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelReadStatStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelTeamBindingStore) Delete(channelId string, teamId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelTeamBindingStore.Delete(channelId, teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelTeamBindingStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTeamBindingStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelTeamBindingStore) Get(channelId string, teamId string) (*model.ChannelTeamBinding, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelTeamBindingStore.Get(channelId, teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelTeamBindingStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTeamBindingStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelTeamBindingStore) GetChannelByName(teamId string, name string, includeDeleted bool) (*model.Channel, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelTeamBindingStore.GetChannelByName(teamId, name, includeDeleted)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelTeamBindingStore.GetChannelByName")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTeamBindingStore.GetChannelByName", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelTeamBindingStore) GetForChannel(channelId string) ([]*model.ChannelTeamBinding, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelTeamBindingStore.GetForChannel(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelTeamBindingStore.GetForChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTeamBindingStore.GetForChannel", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelTeamBindingStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelTeamBindingStore.PermanentDeleteByChannel(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelTeamBindingStore.PermanentDeleteByChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTeamBindingStore.PermanentDeleteByChannel", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelTeamBindingStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelTeamBindingStore.PermanentDeleteByTeam(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelTeamBindingStore.PermanentDeleteByTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTeamBindingStore.PermanentDeleteByTeam", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelTeamBindingStore) Save(binding *model.ChannelTeamBinding) (*model.ChannelTeamBinding, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelTeamBindingStore.Save(binding)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ChannelTeamBindingStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTeamBindingStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Cleanup() *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ClusterDiscoveryStore.Cleanup()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ClusterDiscoveryStore.Cleanup")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Cleanup", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ClusterDiscoveryStore.Delete(discovery)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ClusterDiscoveryStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Delete", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ClusterDiscoveryStore.Exists(discovery)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ClusterDiscoveryStore.Exists")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Exists", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ClusterDiscoveryStore.GetAll")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.GetAll", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ClusterDiscoveryStore.Save(discovery)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ClusterDiscoveryStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Save", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ClusterDiscoveryStore.SetLastPingAt")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.SetLastPingAt", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamId string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.CommandStore.AnalyticsCommandCount(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandStore.AnalyticsCommandCount")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.AnalyticsCommandCount", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerCommandStore) Delete(commandId string, time int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.CommandStore.Delete(commandId, time)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerCommandStore) Get(id string) (*model.Command, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.CommandStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerCommandStore) GetByTeam(teamId string) ([]*model.Command, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.CommandStore.GetByTeam(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandStore.GetByTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerCommandStore) GetByTrigger(teamId string, trigger string) (*model.Command, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.CommandStore.GetByTrigger(teamId, trigger)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandStore.GetByTrigger")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTrigger", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerCommandStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.CommandStore.PermanentDeleteByTeam(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandStore.PermanentDeleteByTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByTeam", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerCommandStore) PermanentDeleteByUser(userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.CommandStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandStore.PermanentDeleteByUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerCommandStore) Save(webhook *model.Command) (*model.Command, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.CommandStore.Save(webhook)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerCommandStore) Update(hook *model.Command) (*model.Command, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.CommandStore.Update(hook)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandStore.Update")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerCommandWebhookStore) Cleanup() {
+	start := timemodule.Now()
+
+	s.CommandWebhookStore.Cleanup()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Cleanup", success, elapsed)
+	}
+	return
+}
+
+func (s *TimerLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.CommandWebhookStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandWebhookStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.CommandWebhookStore.Save(webhook)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandWebhookStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerCommandWebhookStore) TryUse(id string, limit int) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.CommandWebhookStore.TryUse(id, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("CommandWebhookStore.TryUse")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.TryUse", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerComplianceStore) ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ComplianceStore.ComplianceExport(compliance)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ComplianceStore.ComplianceExport")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.ComplianceExport", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerComplianceStore) Get(id string) (*model.Compliance, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ComplianceStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ComplianceStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ComplianceStore.GetAll(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ComplianceStore.GetAll")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.GetAll", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ComplianceStore.MessageExport(after, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ComplianceStore.MessageExport")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.MessageExport", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerComplianceStore) MessageExportChanges(cursor model.MessageExportCursor, limit int) ([]*model.MessageExportChange, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ComplianceStore.MessageExportChanges(cursor, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ComplianceStore.MessageExportChanges")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.MessageExportChanges", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ComplianceStore.Save(compliance)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ComplianceStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ComplianceStore.Update(compliance)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ComplianceStore.Update")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerConfigAuditStore) Get(id string) (*model.ConfigAudit, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ConfigAuditStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ConfigAuditStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ConfigAuditStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerConfigAuditStore) GetAll(offset int, limit int) ([]*model.ConfigAudit, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ConfigAuditStore.GetAll(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ConfigAuditStore.GetAll")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ConfigAuditStore.GetAll", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerConfigAuditStore) Save(audit *model.ConfigAudit) (*model.ConfigAudit, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ConfigAuditStore.Save(audit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ConfigAuditStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ConfigAuditStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerContentFilterStore) Delete(id string, time int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ContentFilterStore.Delete(id, time)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ContentFilterStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ContentFilterStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerContentFilterStore) Get(id string) (*model.ContentFilter, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ContentFilterStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ContentFilterStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ContentFilterStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerContentFilterStore) GetAll(offset int, limit int) ([]*model.ContentFilter, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ContentFilterStore.GetAll(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ContentFilterStore.GetAll")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ContentFilterStore.GetAll", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerContentFilterStore) GetForTeam(teamId string) ([]*model.ContentFilter, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ContentFilterStore.GetForTeam(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ContentFilterStore.GetForTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ContentFilterStore.GetForTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerContentFilterStore) Save(filter *model.ContentFilter) (*model.ContentFilter, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ContentFilterStore.Save(filter)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ContentFilterStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ContentFilterStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerContentFilterStore) Update(filter *model.ContentFilter) (*model.ContentFilter, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ContentFilterStore.Update(filter)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ContentFilterStore.Update")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ContentFilterStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerDailyStatStore) Compute(date string, since int64, until int64) ([]*model.DailyStat, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.DailyStatStore.Compute(date, since, until)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("DailyStatStore.Compute")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("DailyStatStore.Compute", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerDailyStatStore) Get(teamId string, channelId string, since string, until string) ([]*model.DailyStat, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.DailyStatStore.Get(teamId, channelId, since, until)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("DailyStatStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("DailyStatStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerDailyStatStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.DailyStatStore.PermanentDeleteByChannel(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("DailyStatStore.PermanentDeleteByChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("DailyStatStore.PermanentDeleteByChannel", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerDailyStatStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.DailyStatStore.PermanentDeleteByTeam(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("DailyStatStore.PermanentDeleteByTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("DailyStatStore.PermanentDeleteByTeam", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerDailyStatStore) Save(stat *model.DailyStat) (*model.DailyStat, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.DailyStatStore.Save(stat)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("DailyStatStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("DailyStatStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiStore) CountByCreator(creatorId string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiStore.CountByCreator(creatorId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiStore.CountByCreator")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.CountByCreator", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.EmojiStore.Delete(emoji, time)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiStore.Get(id, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiStore.GetByName(name, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiStore.GetByName")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetByName", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiStore.GetList(offset, limit, sort)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiStore.GetList")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetList", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiStore.GetMultipleByName(names)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiStore.GetMultipleByName")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetMultipleByName", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiStore) GetUsage(offset int, limit int) ([]*model.EmojiUsage, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiStore.GetUsage(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiStore.GetUsage")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetUsage", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiStore.Save(emoji)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiStore.Search(name, prefixOnly, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiStore.Search")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Search", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiAliasStore) Delete(alias string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.EmojiAliasStore.Delete(alias)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiAliasStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiAliasStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerEmojiAliasStore) DeleteForEmoji(emojiName string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.EmojiAliasStore.DeleteForEmoji(emojiName)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiAliasStore.DeleteForEmoji")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiAliasStore.DeleteForEmoji", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerEmojiAliasStore) Get(alias string) (*model.EmojiAlias, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiAliasStore.Get(alias)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiAliasStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiAliasStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiAliasStore) GetAll() ([]*model.EmojiAlias, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiAliasStore.GetAll()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiAliasStore.GetAll")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiAliasStore.GetAll", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEmojiAliasStore) Save(alias *model.EmojiAlias) (*model.EmojiAlias, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiAliasStore.Save(alias)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EmojiAliasStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiAliasStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEphemeralPostStore) Delete(userId string, id string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.EphemeralPostStore.Delete(userId, id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EphemeralPostStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EphemeralPostStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerEphemeralPostStore) DeleteExpired(now int64, limit int) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EphemeralPostStore.DeleteExpired(now, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EphemeralPostStore.DeleteExpired")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EphemeralPostStore.DeleteExpired", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEphemeralPostStore) GetForUser(userId string, since int64, limit int) ([]*model.EphemeralPost, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EphemeralPostStore.GetForUser(userId, since, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EphemeralPostStore.GetForUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EphemeralPostStore.GetForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEphemeralPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.EphemeralPostStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EphemeralPostStore.PermanentDeleteByUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EphemeralPostStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerEphemeralPostStore) Save(ephemeralPost *model.EphemeralPost) (*model.EphemeralPost, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EphemeralPostStore.Save(ephemeralPost)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EphemeralPostStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EphemeralPostStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerEphemeralPostStore) Update(ephemeralPost *model.EphemeralPost) (*model.EphemeralPost, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EphemeralPostStore.Update(ephemeralPost)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("EphemeralPostStore.Update")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EphemeralPostStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.AttachToPost")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.AttachToPost", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerFileInfoStore) ClearCaches() {
+	start := timemodule.Now()
+
+	s.FileInfoStore.ClearCaches()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.ClearCaches", success, elapsed)
+	}
+	return
+}
+
+func (s *TimerLayerFileInfoStore) DeleteForPost(postId string) (string, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.FileInfoStore.DeleteForPost(postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.DeleteForPost")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.DeleteForPost", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.FileInfoStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.FileInfoStore.GetByPath(path)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.GetByPath")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByPath", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.GetForPost")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForPost", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerFileInfoStore) GetForPosts(postIds []string, readFromMaster bool) (map[string][]*model.FileInfo, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.FileInfoStore.GetForPosts(postIds, readFromMaster)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.GetForPosts")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForPosts", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.FileInfoStore.GetForUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.GetForUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string) {
+	start := timemodule.Now()
+
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.InvalidateFileInfosForPostCache", success, elapsed)
+	}
+	return
+}
+
+func (s *TimerLayerFileInfoStore) PermanentDelete(fileId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.FileInfoStore.PermanentDelete(fileId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.PermanentDelete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDelete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.PermanentDeleteBatch")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.FileInfoStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.PermanentDeleteByUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.FileInfoStore.Save(info)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerFileInfoStore) SetVerdict(fileId string, verdict string, reason string, verdictAt int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.FileInfoStore.SetVerdict(fileId, verdict, reason, verdictAt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("FileInfoStore.SetVerdict")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetVerdict", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.ChannelMembersMinusGroupMembers")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersMinusGroupMembers", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64) ([]*model.UserChannelIDPair, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.ChannelMembersToAdd(since)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.ChannelMembersToAdd")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToAdd", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) ChannelMembersToRemove() ([]*model.ChannelMember, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.ChannelMembersToRemove()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.ChannelMembersToRemove")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToRemove", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.CountChannelMembersMinusGroupMembers")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountChannelMembersMinusGroupMembers", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) CountGroupsByChannel(channelId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.CountGroupsByChannel(channelId, opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.CountGroupsByChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByChannel", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) CountGroupsByTeam(teamId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.CountGroupsByTeam(teamId, opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.CountGroupsByTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.CountTeamMembersMinusGroupMembers")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountTeamMembersMinusGroupMembers", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) Create(group *model.Group) (*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.Create(group)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.Create")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Create", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.CreateGroupSyncable(groupSyncable)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.CreateGroupSyncable")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CreateGroupSyncable", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) Delete(groupID string) (*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.Delete(groupID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Delete", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.DeleteGroupSyncable")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteGroupSyncable", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.DeleteMember(groupID, userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.DeleteMember")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteMember", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) Get(groupID string) (*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.Get(groupID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetAllBySource(groupSource)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetAllBySource")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllBySource", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetAllGroupSyncablesByGroupId")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllGroupSyncablesByGroupId", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetByIDs(groupIDs)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetByIDs")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByIDs", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetByRemoteID(remoteID, groupSource)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetByRemoteID")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByRemoteID", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetByUser(userId string) ([]*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetByUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetGroupSyncable")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupSyncable", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetGroups(page, perPage, opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetGroups")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroups", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetGroupsByChannel(channelId string, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetGroupsByChannel(channelId, opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetGroupsByChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByChannel", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetGroupsByTeam(teamId, opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetGroupsByTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetMemberCount(groupID string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetMemberCount(groupID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetMemberCount")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberCount", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetMemberUsers(groupID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetMemberUsers")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsers", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.GetMemberUsersPage")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersPage", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.TeamMembersMinusGroupMembers")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersMinusGroupMembers", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) TeamMembersToAdd(since int64) ([]*model.UserTeamIDPair, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.TeamMembersToAdd(since)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.TeamMembersToAdd")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToAdd", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) TeamMembersToRemove() ([]*model.TeamMember, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.TeamMembersToRemove()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.TeamMembersToRemove")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToRemove", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) Update(group *model.Group) (*model.Group, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.Update(group)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.Update")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.UpdateGroupSyncable(groupSyncable)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.UpdateGroupSyncable")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpdateGroupSyncable", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.GroupStore.UpsertMember(groupID, userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("GroupStore.UpsertMember")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpsertMember", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerHashtagStore) DeleteForPost(postId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.HashtagStore.DeleteForPost(postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("HashtagStore.DeleteForPost")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("HashtagStore.DeleteForPost", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerHashtagStore) GetPostsForUser(userId string, teamId string, hashtag string, offset int, limit int) (*model.PostList, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.HashtagStore.GetPostsForUser(userId, teamId, hashtag, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("HashtagStore.GetPostsForUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("HashtagStore.GetPostsForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerHashtagStore) GetTrending(teamId string, since int64, limit int) ([]*model.HashtagCount, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.HashtagStore.GetTrending(teamId, since, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("HashtagStore.GetTrending")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("HashtagStore.GetTrending", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerHashtagStore) SaveForPost(postId string, hashtags []*model.PostHashtag) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.HashtagStore.SaveForPost(postId, hashtags)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("HashtagStore.SaveForPost")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("HashtagStore.SaveForPost", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerIntegrationStatStore) GetDaily(integrationType string, integrationId string, since string, until string) ([]*model.IntegrationStat, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.IntegrationStatStore.GetDaily(integrationType, integrationId, since, until)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("IntegrationStatStore.GetDaily")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("IntegrationStatStore.GetDaily", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerIntegrationStatStore) GetTotals(integrationType string, since string, until string) ([]*model.IntegrationStat, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.IntegrationStatStore.GetTotals(integrationType, since, until)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("IntegrationStatStore.GetTotals")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("IntegrationStatStore.GetTotals", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerIntegrationStatStore) Increment(stat *model.IntegrationStat) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.IntegrationStatStore.Increment(stat)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("IntegrationStatStore.Increment")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("IntegrationStatStore.Increment", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerIntegrationStatStore) PermanentDeleteBefore(date string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.IntegrationStatStore.PermanentDeleteBefore(date)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("IntegrationStatStore.PermanentDeleteBefore")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("IntegrationStatStore.PermanentDeleteBefore", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) Delete(id string) (string, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.Delete(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Delete", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) Get(id string) (*model.Job, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) GetAllByStatus(status string) ([]*model.Job, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.GetAllByStatus(status)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.GetAllByStatus")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByStatus", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.GetAllByType(jobType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.GetAllByType")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByType", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.GetAllByTypePage")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypePage", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.GetAllPage(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.GetAllPage")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllPage", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.GetCountByStatusAndType(status, jobType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.GetCountByStatusAndType")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetCountByStatusAndType", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.GetNewestJobByStatusAndType")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusAndType", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.Save(job)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) UpdateLastActivityAt(ids []string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.JobStore.UpdateLastActivityAt(ids)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.UpdateLastActivityAt")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateLastActivityAt", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.UpdateOptimistically(job, currentStatus)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.UpdateOptimistically")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateOptimistically", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.UpdateStatus(id, status)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.UpdateStatus")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatus", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("JobStore.UpdateStatusOptimistically")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatusOptimistically", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerKeywordRuleStore) Delete(id string, time int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.KeywordRuleStore.Delete(id, time)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("KeywordRuleStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordRuleStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerKeywordRuleStore) Get(id string) (*model.KeywordRule, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.KeywordRuleStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("KeywordRuleStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordRuleStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerKeywordRuleStore) GetAll(offset int, limit int) ([]*model.KeywordRule, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.KeywordRuleStore.GetAll(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("KeywordRuleStore.GetAll")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordRuleStore.GetAll", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerKeywordRuleStore) GetForTeam(teamId string) ([]*model.KeywordRule, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.KeywordRuleStore.GetForTeam(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("KeywordRuleStore.GetForTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordRuleStore.GetForTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerKeywordRuleStore) Save(rule *model.KeywordRule) (*model.KeywordRule, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.KeywordRuleStore.Save(rule)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("KeywordRuleStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordRuleStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerKeywordRuleStore) Update(rule *model.KeywordRule) (*model.KeywordRule, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.KeywordRuleStore.Update(rule)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("KeywordRuleStore.Update")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("KeywordRuleStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLicenseStore) Get(id string) (*model.LicenseRecord, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LicenseStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LicenseStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LicenseStore.Save(license)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LicenseStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLicenseUsageStore) Get(date string) (*model.LicenseUsage, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LicenseUsageStore.Get(date)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LicenseUsageStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LicenseUsageStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLicenseUsageStore) GetRange(since string, until string) ([]*model.LicenseUsage, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LicenseUsageStore.GetRange(since, until)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LicenseUsageStore.GetRange")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LicenseUsageStore.GetRange", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLicenseUsageStore) Save(usage *model.LicenseUsage) (*model.LicenseUsage, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LicenseUsageStore.Save(usage)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LicenseUsageStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LicenseUsageStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LinkMetadataStore.Get(url, timestamp)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LinkMetadataStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LinkMetadataStore.Save(linkMetadata)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LinkMetadataStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLoginAttemptStore) GetForUser(userId string, offset int, limit int) ([]*model.LoginAttempt, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LoginAttemptStore.GetForUser(userId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LoginAttemptStore.GetForUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LoginAttemptStore.GetForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLoginAttemptStore) GetLatestFailure(userId string) (*model.LoginAttempt, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LoginAttemptStore.GetLatestFailure(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LoginAttemptStore.GetLatestFailure")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LoginAttemptStore.GetLatestFailure", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLoginAttemptStore) GetSuccessfulForUser(userId string, limit int) ([]*model.LoginAttempt, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LoginAttemptStore.GetSuccessfulForUser(userId, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LoginAttemptStore.GetSuccessfulForUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LoginAttemptStore.GetSuccessfulForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLoginAttemptStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LoginAttemptStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LoginAttemptStore.PermanentDeleteBatch")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LoginAttemptStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerLoginAttemptStore) PermanentDeleteByUser(userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.LoginAttemptStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LoginAttemptStore.PermanentDeleteByUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LoginAttemptStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerLoginAttemptStore) Save(attempt *model.LoginAttempt) (*model.LoginAttempt, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.LoginAttemptStore.Save(attempt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("LoginAttemptStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LoginAttemptStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMentionAliasStore) Delete(id string, time int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.MentionAliasStore.Delete(id, time)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MentionAliasStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MentionAliasStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerMentionAliasStore) Get(id string) (*model.MentionAlias, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MentionAliasStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MentionAliasStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MentionAliasStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMentionAliasStore) GetAll(offset int, limit int) ([]*model.MentionAlias, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MentionAliasStore.GetAll(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MentionAliasStore.GetAll")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MentionAliasStore.GetAll", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMentionAliasStore) GetByName(name string) ([]*model.MentionAlias, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MentionAliasStore.GetByName(name)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MentionAliasStore.GetByName")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MentionAliasStore.GetByName", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMentionAliasStore) GetForTeam(teamId string) ([]*model.MentionAlias, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MentionAliasStore.GetForTeam(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MentionAliasStore.GetForTeam")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MentionAliasStore.GetForTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMentionAliasStore) Save(alias *model.MentionAlias) (*model.MentionAlias, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MentionAliasStore.Save(alias)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MentionAliasStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MentionAliasStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMentionAliasStore) Update(alias *model.MentionAlias) (*model.MentionAlias, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MentionAliasStore.Update(alias)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MentionAliasStore.Update")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MentionAliasStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMessageExportConsumerStore) Delete(id string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.MessageExportConsumerStore.Delete(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MessageExportConsumerStore.Delete")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MessageExportConsumerStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerMessageExportConsumerStore) Get(id string) (*model.MessageExportConsumer, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MessageExportConsumerStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MessageExportConsumerStore.Get")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MessageExportConsumerStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMessageExportConsumerStore) GetAll() ([]*model.MessageExportConsumer, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MessageExportConsumerStore.GetAll()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MessageExportConsumerStore.GetAll")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MessageExportConsumerStore.GetAll", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMessageExportConsumerStore) Save(consumer *model.MessageExportConsumer) (*model.MessageExportConsumer, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MessageExportConsumerStore.Save(consumer)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MessageExportConsumerStore.Save")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MessageExportConsumerStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMessageExportConsumerStore) Update(consumer *model.MessageExportConsumer) (*model.MessageExportConsumer, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MessageExportConsumerStore.Update(consumer)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MessageExportConsumerStore.Update")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MessageExportConsumerStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMfaRecoveryCodeStore) CountUnused(userId string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MfaRecoveryCodeStore.CountUnused(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MfaRecoveryCodeStore.CountUnused")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.CountUnused", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMfaRecoveryCodeStore) PermanentDeleteByUser(userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.MfaRecoveryCodeStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MfaRecoveryCodeStore.PermanentDeleteByUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerMfaRecoveryCodeStore) SaveForUser(userId string, codes []*model.MfaRecoveryCode) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.MfaRecoveryCodeStore.SaveForUser(userId, codes)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MfaRecoveryCodeStore.SaveForUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.SaveForUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerMfaRecoveryCodeStore) Use(userId string, codeHash string) (bool, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MfaRecoveryCodeStore.Use(userId, codeHash)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("MfaRecoveryCodeStore.Use")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.Use", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerOAuthStore) DeleteApp(id string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.OAuthStore.DeleteApp(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.DeleteApp")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.DeleteApp", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.OAuthStore.GetAccessData(token)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.GetAccessData")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessData", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerOAuthStore) GetAccessDataByPreviousRefreshToken(token string) (*model.AccessData, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.OAuthStore.GetAccessDataByPreviousRefreshToken(token)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.GetAccessDataByPreviousRefreshToken")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByPreviousRefreshToken", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.OAuthStore.GetAccessDataByRefreshToken(token)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.GetAccessDataByRefreshToken")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByRefreshToken", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId string) ([]*model.AccessData, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.OAuthStore.GetAccessDataByUserForApp(userId, clientId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.GetAccessDataByUserForApp")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByUserForApp", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerOAuthStore) GetApp(id string) (*model.OAuthApp, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.OAuthStore.GetApp(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.GetApp")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApp", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerOAuthStore) GetAppByUser(userId string, offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.OAuthStore.GetAppByUser(userId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.GetAppByUser")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAppByUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.OAuthStore.GetApps(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.GetApps")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApps", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+func (s *TimerLayerOAuthStore) GetAuthData(code string) (*model.AuthData, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.PermanentDeleteByTeam(teamId)
+	resultVar0, resultVar1 := s.OAuthStore.GetAuthData(code)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.GetAuthData")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthData", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) PermanentDeleteMembersByChannel(channelId string) *model.AppError {
+func (s *TimerLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+	resultVar0, resultVar1 := s.OAuthStore.GetAuthorizedApps(userId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.GetAuthorizedApps")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteMembersByChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthorizedApps", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) PermanentDeleteMembersByUser(userId string) *model.AppError {
+func (s *TimerLayerOAuthStore) GetPreviousAccessData(userId string, clientId string) (*model.AccessData, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.PermanentDeleteMembersByUser(userId)
+	resultVar0, resultVar1 := s.OAuthStore.GetPreviousAccessData(userId, clientId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.GetPreviousAccessData")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteMembersByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetPreviousAccessData", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) *model.AppError {
+func (s *TimerLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+	resultVar0 := s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.PermanentDeleteAuthDataByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.RemoveAllDeactivatedMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.PermanentDeleteAuthDataByUser", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) RemoveMember(channelId string, userId string) *model.AppError {
+func (s *TimerLayerOAuthStore) RemoveAccessData(token string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.RemoveMember(channelId, userId)
+	resultVar0 := s.OAuthStore.RemoveAccessData(token)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.RemoveAccessData")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.RemoveMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAccessData", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) ResetAllChannelSchemes() *model.AppError {
+func (s *TimerLayerOAuthStore) RemoveAllAccessData() *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.ResetAllChannelSchemes()
+	resultVar0 := s.OAuthStore.RemoveAllAccessData()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.RemoveAllAccessData")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ResetAllChannelSchemes", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAllAccessData", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) Restore(channelId string, time int64) *model.AppError {
+func (s *TimerLayerOAuthStore) RemoveAuthData(code string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.Restore(channelId, time)
+	resultVar0 := s.OAuthStore.RemoveAuthData(code)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.RemoveAuthData")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Restore", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAuthData", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, *model.AppError) {
+func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.Save(channel, maxChannelsPerTeam)
+	resultVar0, resultVar1 := s.OAuthStore.SaveAccessData(accessData)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.SaveAccessData")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAccessData", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, *model.AppError) {
+func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+	resultVar0, resultVar1 := s.OAuthStore.SaveApp(app)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.SaveApp")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveDirectChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveApp", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SaveMember(member)
+	resultVar0, resultVar1 := s.OAuthStore.SaveAuthData(authData)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.SaveAuthData")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAuthData", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) SearchAllChannels(term string, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, *model.AppError) {
+func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SearchAllChannels(term, opts)
+	resultVar0, resultVar1 := s.OAuthStore.UpdateAccessData(accessData)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.UpdateAccessData")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchAllChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateAccessData", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) SearchForUserInTeam(userId string, teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SearchForUserInTeam(userId, teamId, term, includeDeleted)
+	resultVar0, resultVar1 := s.OAuthStore.UpdateApp(app)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OAuthStore.UpdateApp")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchForUserInTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateApp", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) SearchGroupChannels(userId string, term string) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerOnboardingStore) DeleteStep(id string, deleteAt int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SearchGroupChannels(userId, term)
+	resultVar0 := s.OnboardingStore.DeleteStep(id, deleteAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OnboardingStore.DeleteStep")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchGroupChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingStore.DeleteStep", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) SearchInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerOnboardingStore) GetCompletionsForUser(userId string) ([]*model.OnboardingStepCompletion, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SearchInTeam(teamId, term, includeDeleted)
+	resultVar0, resultVar1 := s.OnboardingStore.GetCompletionsForUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OnboardingStore.GetCompletionsForUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchInTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingStore.GetCompletionsForUser", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) SearchMore(userId string, teamId string, term string) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerOnboardingStore) GetStep(id string) (*model.OnboardingStep, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SearchMore(userId, teamId, term)
+	resultVar0, resultVar1 := s.OnboardingStore.GetStep(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OnboardingStore.GetStep")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchMore", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingStore.GetStep", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) *model.AppError {
+func (s *TimerLayerOnboardingStore) GetSteps() ([]*model.OnboardingStep, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+	resultVar0, resultVar1 := s.OnboardingStore.GetSteps()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OnboardingStore.GetSteps")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SetDeleteAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingStore.GetSteps", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) Update(channel *model.Channel) (*model.Channel, *model.AppError) {
+func (s *TimerLayerOnboardingStore) PermanentDeleteCompletionsByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.Update(channel)
+	resultVar0 := s.OnboardingStore.PermanentDeleteCompletionsByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OnboardingStore.PermanentDeleteCompletionsByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingStore.PermanentDeleteCompletionsByUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId string) (map[string]int64, *model.AppError) {
+func (s *TimerLayerOnboardingStore) SaveCompletion(completion *model.OnboardingStepCompletion) (*model.OnboardingStepCompletion, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.UpdateLastViewedAt(channelIds, userId)
+	resultVar0, resultVar1 := s.OnboardingStore.SaveCompletion(completion)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OnboardingStore.SaveCompletion")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateLastViewedAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingStore.SaveCompletion", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+func (s *TimerLayerOnboardingStore) SaveStep(step *model.OnboardingStep) (*model.OnboardingStep, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.UpdateMember(member)
+	resultVar0, resultVar1 := s.OnboardingStore.SaveStep(step)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OnboardingStore.SaveStep")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingStore.SaveStep", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) UserBelongsToChannels(userId string, channelIds []string) (bool, *model.AppError) {
+func (s *TimerLayerOnboardingStore) UpdateStep(step *model.OnboardingStep) (*model.OnboardingStep, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.UserBelongsToChannels(userId, channelIds)
+	resultVar0, resultVar1 := s.OnboardingStore.UpdateStep(step)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("OnboardingStore.UpdateStep")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UserBelongsToChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingStore.UpdateStep", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, *model.AppError) {
+func (s *TimerLayerPendingEmojiStore) CountByCreator(creatorId string) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelId)
+	resultVar0, resultVar1 := s.PendingEmojiStore.CountByCreator(creatorId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingEmojiStore.CountByCreator")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetUsersInChannelDuring", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmojiStore.CountByCreator", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) *model.AppError {
+func (s *TimerLayerPendingEmojiStore) Delete(id string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
+	resultVar0 := s.PendingEmojiStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingEmojiStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogJoinEvent", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmojiStore.Delete", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) *model.AppError {
+func (s *TimerLayerPendingEmojiStore) Get(id string) (*model.PendingEmoji, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+	resultVar0, resultVar1 := s.PendingEmojiStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingEmojiStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogLeaveEvent", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmojiStore.Get", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+func (s *TimerLayerPendingEmojiStore) GetByName(name string) (*model.PendingEmoji, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0, resultVar1 := s.PendingEmojiStore.GetByName(name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingEmojiStore.GetByName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmojiStore.GetByName", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Cleanup() *model.AppError {
+func (s *TimerLayerPendingEmojiStore) GetList(offset int, limit int) ([]*model.PendingEmoji, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ClusterDiscoveryStore.Cleanup()
+	resultVar0, resultVar1 := s.PendingEmojiStore.GetList(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingEmojiStore.GetList")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Cleanup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmojiStore.GetList", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, *model.AppError) {
+func (s *TimerLayerPendingEmojiStore) Save(emoji *model.PendingEmoji) (*model.PendingEmoji, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ClusterDiscoveryStore.Delete(discovery)
+	resultVar0, resultVar1 := s.PendingEmojiStore.Save(emoji)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingEmojiStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmojiStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, *model.AppError) {
+func (s *TimerLayerPendingPostStore) Delete(id string) (bool, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ClusterDiscoveryStore.Exists(discovery)
+	resultVar0, resultVar1 := s.PendingPostStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingPostStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Exists", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingPostStore.Delete", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, *model.AppError) {
+func (s *TimerLayerPendingPostStore) Get(id string) (*model.PendingPost, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+	resultVar0, resultVar1 := s.PendingPostStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingPostStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingPostStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) *model.AppError {
+func (s *TimerLayerPendingPostStore) GetCountForChannel(channelId string) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ClusterDiscoveryStore.Save(discovery)
+	resultVar0, resultVar1 := s.PendingPostStore.GetCountForChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingPostStore.GetCountForChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingPostStore.GetCountForChannel", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) *model.AppError {
+func (s *TimerLayerPendingPostStore) GetForChannel(channelId string, offset int, limit int) ([]*model.PendingPost, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+	resultVar0, resultVar1 := s.PendingPostStore.GetForChannel(channelId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingPostStore.GetForChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.SetLastPingAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingPostStore.GetForChannel", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamId string) (int64, *model.AppError) {
+func (s *TimerLayerPendingPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.AnalyticsCommandCount(teamId)
+	resultVar0 := s.PendingPostStore.PermanentDeleteByChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingPostStore.PermanentDeleteByChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.AnalyticsCommandCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingPostStore.PermanentDeleteByChannel", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerCommandStore) Delete(commandId string, time int64) *model.AppError {
+func (s *TimerLayerPendingPostStore) PermanentDeleteByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.CommandStore.Delete(commandId, time)
+	resultVar0 := s.PendingPostStore.PermanentDeleteByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingPostStore.PermanentDeleteByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingPostStore.PermanentDeleteByUser", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerCommandStore) Get(id string) (*model.Command, *model.AppError) {
+func (s *TimerLayerPendingPostStore) Save(pendingPost *model.PendingPost) (*model.PendingPost, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.Get(id)
+	resultVar0, resultVar1 := s.PendingPostStore.Save(pendingPost)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PendingPostStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingPostStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerCommandStore) GetByTeam(teamId string) ([]*model.Command, *model.AppError) {
+func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.GetByTeam(teamId)
+	resultVar0, resultVar1 := s.PluginStore.CompareAndDelete(keyVal, oldValue)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PluginStore.CompareAndDelete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerCommandStore) GetByTrigger(teamId string, trigger string) (*model.Command, *model.AppError) {
+func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.GetByTrigger(teamId, trigger)
+	resultVar0, resultVar1 := s.PluginStore.CompareAndSet(keyVal, oldValue)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PluginStore.CompareAndSet")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTrigger", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerCommandStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+func (s *TimerLayerPluginStore) Delete(pluginId string, key string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.CommandStore.PermanentDeleteByTeam(teamId)
+	resultVar0 := s.PluginStore.Delete(pluginId, key)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PluginStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Delete", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerCommandStore) PermanentDeleteByUser(userId string) *model.AppError {
+func (s *TimerLayerPluginStore) DeleteAllExpired() *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.CommandStore.PermanentDeleteByUser(userId)
+	resultVar0 := s.PluginStore.DeleteAllExpired()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PluginStore.DeleteAllExpired")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllExpired", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerCommandStore) Save(webhook *model.Command) (*model.Command, *model.AppError) {
+func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.Save(webhook)
+	resultVar0 := s.PluginStore.DeleteAllForPlugin(PluginId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PluginStore.DeleteAllForPlugin")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllForPlugin", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerCommandStore) Update(hook *model.Command) (*model.Command, *model.AppError) {
+func (s *TimerLayerPluginStore) Get(pluginId string, key string) (*model.PluginKeyValue, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.Update(hook)
+	resultVar0, resultVar1 := s.PluginStore.Get(pluginId, key)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PluginStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerCommandWebhookStore) Cleanup() {
+func (s *TimerLayerPluginStore) List(pluginId string, page int, perPage int) ([]string, *model.AppError) {
 	start := timemodule.Now()
 
-	s.CommandWebhookStore.Cleanup()
+	resultVar0, resultVar1 := s.PluginStore.List(pluginId, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PluginStore.List")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Cleanup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, *model.AppError) {
+func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandWebhookStore.Get(id)
+	resultVar0, resultVar1 := s.PluginStore.SaveOrUpdate(keyVal)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PluginStore.SaveOrUpdate")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SaveOrUpdate", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, *model.AppError) {
+func (s *TimerLayerPollStore) Get(id string) (*model.Poll, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandWebhookStore.Save(webhook)
+	resultVar0, resultVar1 := s.PollStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PollStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerCommandWebhookStore) TryUse(id string, limit int) *model.AppError {
+func (s *TimerLayerPollStore) GetVotes(pollId string) ([]*model.PollVote, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.CommandWebhookStore.TryUse(id, limit)
+	resultVar0, resultVar1 := s.PollStore.GetVotes(pollId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PollStore.GetVotes")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.TryUse", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.GetVotes", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerComplianceStore) ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, *model.AppError) {
+func (s *TimerLayerPollStore) PermanentDeleteByChannel(channelId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.ComplianceExport(compliance)
+	resultVar0 := s.PollStore.PermanentDeleteByChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PollStore.PermanentDeleteByChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.ComplianceExport", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.PermanentDeleteByChannel", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerComplianceStore) Get(id string) (*model.Compliance, *model.AppError) {
+func (s *TimerLayerPollStore) PermanentDeleteByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.Get(id)
+	resultVar0 := s.PollStore.PermanentDeleteByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PollStore.PermanentDeleteByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, *model.AppError) {
+func (s *TimerLayerPollStore) Save(poll *model.Poll) (*model.Poll, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.GetAll(offset, limit)
+	resultVar0, resultVar1 := s.PollStore.Save(poll)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PollStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, *model.AppError) {
+func (s *TimerLayerPollStore) SaveVote(vote *model.PollVote) (*model.PollVote, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.MessageExport(after, limit)
+	resultVar0, resultVar1 := s.PollStore.SaveVote(vote)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PollStore.SaveVote")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.MessageExport", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.SaveVote", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
+func (s *TimerLayerPollStore) Update(poll *model.Poll) (*model.Poll, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.Save(compliance)
+	resultVar0, resultVar1 := s.PollStore.Update(poll)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PollStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PollStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
+func (s *TimerLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.Update(compliance)
+	resultVar0, resultVar1 := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.AnalyticsPostCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) *model.AppError {
+func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.EmojiStore.Delete(emoji, time)
+	resultVar0, resultVar1 := s.PostStore.AnalyticsPostCountsByDay(options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.AnalyticsPostCountsByDay")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountsByDay", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.Get(id, allowFromCache)
+	resultVar0, resultVar1 := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.AnalyticsUserCountsWithPostsByDay")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsUserCountsWithPostsByDay", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+func (s *TimerLayerPostStore) ArchiveBatch(before int64, limit int) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.GetByName(name, allowFromCache)
+	resultVar0, resultVar1 := s.PostStore.ArchiveBatch(before, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.ArchiveBatch")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ArchiveBatch", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, *model.AppError) {
+func (s *TimerLayerPostStore) ClearCaches() {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.GetList(offset, limit, sort)
+	s.PostStore.ClearCaches()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetList", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ClearCaches", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return
 }
 
-func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, *model.AppError) {
+func (s *TimerLayerPostStore) Delete(postId string, time int64, deleteByID string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.GetMultipleByName(names)
+	resultVar0 := s.PostStore.Delete(postId, time, deleteByID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetMultipleByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, *model.AppError) {
+func (s *TimerLayerPostStore) Get(id string) (*model.PostList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.Save(emoji)
+	resultVar0, resultVar1 := s.PostStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, *model.AppError) {
+func (s *TimerLayerPostStore) GetByUserAfter(userId string, afterId string, limit int) ([]*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.Search(name, prefixOnly, limit)
+	resultVar0, resultVar1 := s.PostStore.GetByUserAfter(userId, afterId, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetByUserAfter")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Search", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetByUserAfter", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) *model.AppError {
+func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+	resultVar0, resultVar1 := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetDirectPostParentsForExportAfter")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.AttachToPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDirectPostParentsForExportAfter", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerFileInfoStore) ClearCaches() {
+func (s *TimerLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
 	start := timemodule.Now()
 
-	s.FileInfoStore.ClearCaches()
+	resultVar0 := s.PostStore.GetEtag(channelId, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2355,95 +7352,105 @@ func (s *TimerLayerFileInfoStore) ClearCaches() {
 		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEtag", success, elapsed)
 	}
-	return
+	return resultVar0
 }
 
-func (s *TimerLayerFileInfoStore) DeleteForPost(postId string) (string, *model.AppError) {
+func (s *TimerLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.DeleteForPost(postId)
+	resultVar0, resultVar1 := s.PostStore.GetFlaggedPosts(userId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetFlaggedPosts")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.DeleteForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPosts", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, *model.AppError) {
+func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.Get(id)
+	resultVar0, resultVar1 := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetFlaggedPostsForChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, *model.AppError) {
+func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.GetByPath(path)
+	resultVar0, resultVar1 := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetFlaggedPostsForTeam")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByPath", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForTeam", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, *model.AppError) {
+func (s *TimerLayerPostStore) GetForChannelAfter(channelId string, afterCreateAt int64, afterId string, endTime int64, limit int) ([]*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+	resultVar0, resultVar1 := s.PostStore.GetForChannelAfter(channelId, afterCreateAt, afterId, endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetForChannelAfter")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetForChannelAfter", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, *model.AppError) {
+func (s *TimerLayerPostStore) GetForPurge(scope string, targetId string, limit int) ([]*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.GetForUser(userId)
+	resultVar0, resultVar1 := s.PostStore.GetForPurge(scope, targetId, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetForPurge")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetForPurge", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string) {
+func (s *TimerLayerPostStore) GetMaxPostSize() int {
 	start := timemodule.Now()
 
-	s.FileInfoStore.InvalidateFileInfosForPostCache(postId)
+	resultVar0 := s.PostStore.GetMaxPostSize()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2451,1663 +7458,1849 @@ func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string)
 		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.InvalidateFileInfosForPostCache", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetMaxPostSize", success, elapsed)
 	}
-	return
+	return resultVar0
 }
 
-func (s *TimerLayerFileInfoStore) PermanentDelete(fileId string) *model.AppError {
+func (s *TimerLayerPostStore) GetOldest() (*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.FileInfoStore.PermanentDelete(fileId)
+	resultVar0, resultVar1 := s.PostStore.GetOldest()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetOldest")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldest", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0, resultVar1 := s.PostStore.GetParentsForExportAfter(limit, afterId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetParentsForExportAfter")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetParentsForExportAfter", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, *model.AppError) {
+func (s *TimerLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.PermanentDeleteByUser(userId)
+	resultVar0, resultVar1 := s.PostStore.GetPostAfterTime(channelId, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetPostAfterTime")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostAfterTime", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, *model.AppError) {
+func (s *TimerLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.Save(info)
+	resultVar0, resultVar1 := s.PostStore.GetPostIdAfterTime(channelId, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetPostIdAfterTime")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdAfterTime", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+	resultVar0, resultVar1 := s.PostStore.GetPostIdBeforeTime(channelId, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetPostIdBeforeTime")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersMinusGroupMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdBeforeTime", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64) ([]*model.UserChannelIDPair, *model.AppError) {
+func (s *TimerLayerPostStore) GetPosts(channelId string, offset int, limit int, allowFromCache bool) (*model.PostList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.ChannelMembersToAdd(since)
+	resultVar0, resultVar1 := s.PostStore.GetPosts(channelId, offset, limit, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetPosts")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToAdd", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPosts", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) ChannelMembersToRemove() ([]*model.ChannelMember, *model.AppError) {
+func (s *TimerLayerPostStore) GetPostsAfter(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.ChannelMembersToRemove()
+	resultVar0, resultVar1 := s.PostStore.GetPostsAfter(channelId, postId, numPosts, offset)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetPostsAfter")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToRemove", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsAfter", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, *model.AppError) {
+func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+	resultVar0, resultVar1 := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetPostsBatchForIndexing")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountChannelMembersMinusGroupMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBatchForIndexing", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) CountGroupsByChannel(channelId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+func (s *TimerLayerPostStore) GetPostsBefore(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.CountGroupsByChannel(channelId, opts)
+	resultVar0, resultVar1 := s.PostStore.GetPostsBefore(channelId, postId, numPosts, offset)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetPostsBefore")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBefore", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) CountGroupsByTeam(teamId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.CountGroupsByTeam(teamId, opts)
+	resultVar0, resultVar1 := s.PostStore.GetPostsByIds(postIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetPostsByIds")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsByIds", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, *model.AppError) {
+func (s *TimerLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+	resultVar0, resultVar1 := s.PostStore.GetPostsCreatedAt(channelId, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetPostsCreatedAt")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountTeamMembersMinusGroupMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsCreatedAt", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) Create(group *model.Group) (*model.Group, *model.AppError) {
+func (s *TimerLayerPostStore) GetPostsSince(channelId string, time int64, allowFromCache bool) (*model.PostList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.Create(group)
+	resultVar0, resultVar1 := s.PostStore.GetPostsSince(channelId, time, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetPostsSince")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Create", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsSince", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+func (s *TimerLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.CreateGroupSyncable(groupSyncable)
+	resultVar0, resultVar1 := s.PostStore.GetRepliesForExport(parentId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetRepliesForExport")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CreateGroupSyncable", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRepliesForExport", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) Delete(groupID string) (*model.Group, *model.AppError) {
+func (s *TimerLayerPostStore) GetSingle(id string) (*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.Delete(groupID)
+	resultVar0, resultVar1 := s.PostStore.GetSingle(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetSingle")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetSingle", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+func (s *TimerLayerPostStore) GetSingleFromMaster(id string) (*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+	resultVar0, resultVar1 := s.PostStore.GetSingleFromMaster(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetSingleFromMaster")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteGroupSyncable", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetSingleFromMaster", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+func (s *TimerLayerPostStore) GetThreadParticipantCounts(userId string, since int64, limit int) (map[string]int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.DeleteMember(groupID, userID)
+	resultVar0, resultVar1 := s.PostStore.GetThreadParticipantCounts(userId, since, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.GetThreadParticipantCounts")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetThreadParticipantCounts", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) Get(groupID string) (*model.Group, *model.AppError) {
+func (s *TimerLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.Get(groupID)
+	s.PostStore.InvalidateLastPostTimeCache(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.InvalidateLastPostTimeCache", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return
 }
 
-func (s *TimerLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError) {
+func (s *TimerLayerPostStore) Overwrite(post *model.Post) (*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetAllBySource(groupSource)
+	resultVar0, resultVar1 := s.PostStore.Overwrite(post)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.Overwrite")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllBySource", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Overwrite", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError) {
+func (s *TimerLayerPostStore) OverwriteProps(post *model.Post) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
+	resultVar0 := s.PostStore.OverwriteProps(post)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.OverwriteProps")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllGroupSyncablesByGroupId", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.OverwriteProps", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, *model.AppError) {
+func (s *TimerLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetByIDs(groupIDs)
+	resultVar0, resultVar1 := s.PostStore.PermanentDeleteBatch(endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.PermanentDeleteBatch")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByIDs", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteBatch", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError) {
+func (s *TimerLayerPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetByRemoteID(remoteID, groupSource)
+	resultVar0 := s.PostStore.PermanentDeleteByChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.PermanentDeleteByChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByRemoteID", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByChannel", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+func (s *TimerLayerPostStore) PermanentDeleteByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
+	resultVar0 := s.PostStore.PermanentDeleteByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.PermanentDeleteByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupSyncable", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+func (s *TimerLayerPostStore) PermanentDeleteForPurge(postIds []string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetGroups(page, perPage, opts)
+	resultVar0 := s.PostStore.PermanentDeleteForPurge(postIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.PermanentDeleteForPurge")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroups", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteForPurge", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerGroupStore) GetGroupsByChannel(channelId string, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetGroupsByChannel(channelId, opts)
+	resultVar0, resultVar1 := s.PostStore.Save(post)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+func (s *TimerLayerPostStore) Search(ctx context.Context, teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetGroupsByTeam(teamId, opts)
+	resultVar0, resultVar1 := s.PostStore.Search(ctx, teamId, userId, params)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.Search")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Search", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) GetMemberCount(groupID string) (int64, *model.AppError) {
+func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetMemberCount(groupID)
+	resultVar0, resultVar1 := s.PostStore.Update(newPost, oldPost)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, *model.AppError) {
+func (s *TimerLayerPostPinEventStore) GetForChannel(channelId string, startTime int64, endTime int64) ([]*model.PostPinEvent, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetMemberUsers(groupID)
+	resultVar0, resultVar1 := s.PostPinEventStore.GetForChannel(channelId, startTime, endTime)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostPinEventStore.GetForChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPinEventStore.GetForChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, *model.AppError) {
+func (s *TimerLayerPostPinEventStore) PermanentDeleteByChannel(channelId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
+	resultVar0 := s.PostPinEventStore.PermanentDeleteByChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostPinEventStore.PermanentDeleteByChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPinEventStore.PermanentDeleteByChannel", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+func (s *TimerLayerPostPinEventStore) Save(event *model.PostPinEvent) (*model.PostPinEvent, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+	resultVar0, resultVar1 := s.PostPinEventStore.Save(event)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostPinEventStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersMinusGroupMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPinEventStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) TeamMembersToAdd(since int64) ([]*model.UserTeamIDPair, *model.AppError) {
+func (s *TimerLayerPostPurgeStore) Claim(id string) (bool, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.TeamMembersToAdd(since)
+	resultVar0, resultVar1 := s.PostPurgeStore.Claim(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostPurgeStore.Claim")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToAdd", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPurgeStore.Claim", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) TeamMembersToRemove() ([]*model.TeamMember, *model.AppError) {
+func (s *TimerLayerPostPurgeStore) Get(id string) (*model.PostPurge, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.TeamMembersToRemove()
+	resultVar0, resultVar1 := s.PostPurgeStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostPurgeStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToRemove", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPurgeStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) Update(group *model.Group) (*model.Group, *model.AppError) {
+func (s *TimerLayerPostPurgeStore) GetAll(offset int, limit int) ([]*model.PostPurge, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.Update(group)
+	resultVar0, resultVar1 := s.PostPurgeStore.GetAll(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostPurgeStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPurgeStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+func (s *TimerLayerPostPurgeStore) GetDue(before int64, limit int) ([]*model.PostPurge, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.UpdateGroupSyncable(groupSyncable)
+	resultVar0, resultVar1 := s.PostPurgeStore.GetDue(before, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostPurgeStore.GetDue")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpdateGroupSyncable", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPurgeStore.GetDue", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+func (s *TimerLayerPostPurgeStore) ResetStale(staleBefore int64) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.UpsertMember(groupID, userID)
+	resultVar0, resultVar1 := s.PostPurgeStore.ResetStale(staleBefore)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostPurgeStore.ResetStale")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpsertMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPurgeStore.ResetStale", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) Delete(id string) (string, *model.AppError) {
+func (s *TimerLayerPostPurgeStore) Save(purge *model.PostPurge) (*model.PostPurge, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.Delete(id)
+	resultVar0, resultVar1 := s.PostPurgeStore.Save(purge)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostPurgeStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPurgeStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) Get(id string) (*model.Job, *model.AppError) {
+func (s *TimerLayerPostPurgeStore) Update(purge *model.PostPurge) (*model.PostPurge, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.Get(id)
+	resultVar0, resultVar1 := s.PostPurgeStore.Update(purge)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostPurgeStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPurgeStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) GetAllByStatus(status string) ([]*model.Job, *model.AppError) {
+func (s *TimerLayerPostReportStore) Get(id string) (*model.PostReport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetAllByStatus(status)
+	resultVar0, resultVar1 := s.PostReportStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostReportStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByStatus", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, *model.AppError) {
+func (s *TimerLayerPostReportStore) GetAll(state string, offset int, limit int) ([]*model.PostReport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetAllByType(jobType)
+	resultVar0, resultVar1 := s.PostReportStore.GetAll(state, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostReportStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, *model.AppError) {
+func (s *TimerLayerPostReportStore) GetEvents(reportId string) ([]*model.PostReportEvent, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+	resultVar0, resultVar1 := s.PostReportStore.GetEvents(reportId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostReportStore.GetEvents")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypePage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.GetEvents", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, *model.AppError) {
+func (s *TimerLayerPostReportStore) GetOpenForPostAndReporter(postId string, reporterId string) (*model.PostReport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetAllPage(offset, limit)
+	resultVar0, resultVar1 := s.PostReportStore.GetOpenForPostAndReporter(postId, reporterId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostReportStore.GetOpenForPostAndReporter")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.GetOpenForPostAndReporter", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, *model.AppError) {
+func (s *TimerLayerPostReportStore) Save(report *model.PostReport) (*model.PostReport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetCountByStatusAndType(status, jobType)
+	resultVar0, resultVar1 := s.PostReportStore.Save(report)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostReportStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetCountByStatusAndType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, *model.AppError) {
+func (s *TimerLayerPostReportStore) SaveEvent(event *model.PostReportEvent) (*model.PostReportEvent, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+	resultVar0, resultVar1 := s.PostReportStore.SaveEvent(event)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostReportStore.SaveEvent")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusAndType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.SaveEvent", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, *model.AppError) {
+func (s *TimerLayerPostReportStore) Update(report *model.PostReport, previousState string) (*model.PostReport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.Save(job)
+	resultVar0, resultVar1 := s.PostReportStore.Update(report, previousState)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostReportStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, *model.AppError) {
+func (s *TimerLayerPostStarStore) Delete(postId string, userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.UpdateOptimistically(job, currentStatus)
+	resultVar0 := s.PostStarStore.Delete(postId, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStarStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateOptimistically", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStarStore.Delete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job, *model.AppError) {
+func (s *TimerLayerPostStarStore) Get(postId string, userId string) (*model.PostStar, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.UpdateStatus(id, status)
+	resultVar0, resultVar1 := s.PostStarStore.Get(postId, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStarStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatus", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStarStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, *model.AppError) {
+func (s *TimerLayerPostStarStore) GetCountForPost(postId string) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+	resultVar0, resultVar1 := s.PostStarStore.GetCountForPost(postId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStarStore.GetCountForPost")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatusOptimistically", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStarStore.GetCountForPost", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerLicenseStore) Get(id string) (*model.LicenseRecord, *model.AppError) {
+func (s *TimerLayerPostStarStore) GetCountsForPosts(postIds []string) ([]*model.PostStarCount, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.LicenseStore.Get(id)
+	resultVar0, resultVar1 := s.PostStarStore.GetCountsForPosts(postIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStarStore.GetCountsForPosts")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStarStore.GetCountsForPosts", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, *model.AppError) {
+func (s *TimerLayerPostStarStore) PermanentDeleteByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.LicenseStore.Save(license)
+	resultVar0 := s.PostStarStore.PermanentDeleteByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStarStore.PermanentDeleteByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStarStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, *model.AppError) {
+func (s *TimerLayerPostStarStore) Save(star *model.PostStar) (*model.PostStar, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.LinkMetadataStore.Get(url, timestamp)
+	resultVar0, resultVar1 := s.PostStarStore.Save(star)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PostStarStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStarStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, *model.AppError) {
+func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.LinkMetadataStore.Save(linkMetadata)
+	resultVar0, resultVar1 := s.PreferenceStore.CleanupFlagsBatch(limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.CleanupFlagsBatch")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.CleanupFlagsBatch", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) DeleteApp(id string) *model.AppError {
+func (s *TimerLayerPreferenceStore) Delete(userId string, category string, name string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.OAuthStore.DeleteApp(id)
+	resultVar0 := s.PreferenceStore.Delete(userId, category, name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.DeleteApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Delete", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, *model.AppError) {
+func (s *TimerLayerPreferenceStore) DeleteCategory(userId string, category string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAccessData(token)
+	resultVar0 := s.PreferenceStore.DeleteCategory(userId, category)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.DeleteCategory")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, *model.AppError) {
+func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAccessDataByRefreshToken(token)
+	resultVar0 := s.PreferenceStore.DeleteCategoryAndName(category, name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.DeleteCategoryAndName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByRefreshToken", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategoryAndName", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId string) ([]*model.AccessData, *model.AppError) {
+func (s *TimerLayerPreferenceStore) Get(userId string, category string, name string) (*model.Preference, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAccessDataByUserForApp(userId, clientId)
+	resultVar0, resultVar1 := s.PreferenceStore.Get(userId, category, name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByUserForApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) GetApp(id string) (*model.OAuthApp, *model.AppError) {
+func (s *TimerLayerPreferenceStore) GetAll(userId string) (model.Preferences, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetApp(id)
+	resultVar0, resultVar1 := s.PreferenceStore.GetAll(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) GetAppByUser(userId string, offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+func (s *TimerLayerPreferenceStore) GetCategory(userId string, category string) (model.Preferences, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAppByUser(userId, offset, limit)
+	resultVar0, resultVar1 := s.PreferenceStore.GetCategory(userId, category)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.GetCategory")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAppByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+func (s *TimerLayerPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetApps(offset, limit)
+	resultVar0, resultVar1 := s.PreferenceStore.GetCategoryAndName(category, name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.GetCategoryAndName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApps", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategoryAndName", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) GetAuthData(code string) (*model.AuthData, *model.AppError) {
+func (s *TimerLayerPreferenceStore) GetNamespaces(userId string, categories []string) (map[string]*model.PreferenceNamespace, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAuthData(code)
+	resultVar0, resultVar1 := s.PreferenceStore.GetNamespaces(userId, categories)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.GetNamespaces")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetNamespaces", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+func (s *TimerLayerPreferenceStore) GetVersions(userId string) (map[string]int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAuthorizedApps(userId, offset, limit)
+	resultVar0, resultVar1 := s.PreferenceStore.GetVersions(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.GetVersions")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthorizedApps", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetVersions", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) GetPreviousAccessData(userId string, clientId string) (*model.AccessData, *model.AppError) {
+func (s *TimerLayerPreferenceStore) Patch(userId string, patch model.PreferencesPatch) ([]*model.PreferenceNamespaceDiff, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetPreviousAccessData(userId, clientId)
+	resultVar0, resultVar1 := s.PreferenceStore.Patch(userId, patch)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.Patch")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetPreviousAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Patch", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) *model.AppError {
-	start := timemodule.Now()
-
-	resultVar0 := s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
-
-	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
-	if s.Root.Metrics != nil {
-		success := "false"
-		if resultVar0 == nil {
-			success = "true"
-		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.PermanentDeleteAuthDataByUser", success, elapsed)
-	}
-	return resultVar0
-}
-
-func (s *TimerLayerOAuthStore) RemoveAccessData(token string) *model.AppError {
+func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.OAuthStore.RemoveAccessData(token)
+	resultVar0 := s.PreferenceStore.PermanentDeleteByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.PermanentDeleteByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.PermanentDeleteByUser", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerOAuthStore) RemoveAllAccessData() *model.AppError {
+func (s *TimerLayerPreferenceStore) Save(preferences *model.Preferences) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.OAuthStore.RemoveAllAccessData()
+	resultVar0 := s.PreferenceStore.Save(preferences)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PreferenceStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAllAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Save", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerOAuthStore) RemoveAuthData(code string) *model.AppError {
+func (s *TimerLayerPublicPostLinkStore) Get(id string) (*model.PublicPostLink, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.OAuthStore.RemoveAuthData(code)
+	resultVar0, resultVar1 := s.PublicPostLinkStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PublicPostLinkStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAuthData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PublicPostLinkStore.Get", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, *model.AppError) {
+func (s *TimerLayerPublicPostLinkStore) GetForPost(postId string) ([]*model.PublicPostLink, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.SaveAccessData(accessData)
+	resultVar0, resultVar1 := s.PublicPostLinkStore.GetForPost(postId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PublicPostLinkStore.GetForPost")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PublicPostLinkStore.GetForPost", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+func (s *TimerLayerPublicPostLinkStore) Revoke(id string, time int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.SaveApp(app)
+	resultVar0 := s.PublicPostLinkStore.Revoke(id, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PublicPostLinkStore.Revoke")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PublicPostLinkStore.Revoke", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, *model.AppError) {
+func (s *TimerLayerPublicPostLinkStore) Save(link *model.PublicPostLink) (*model.PublicPostLink, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.SaveAuthData(authData)
+	resultVar0, resultVar1 := s.PublicPostLinkStore.Save(link)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("PublicPostLinkStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAuthData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PublicPostLinkStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, *model.AppError) {
+func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.UpdateAccessData(accessData)
+	resultVar0, resultVar1 := s.ReactionStore.BulkGetForPosts(postIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReactionStore.BulkGetForPosts")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.BulkGetForPosts", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.UpdateApp(app)
+	resultVar0, resultVar1 := s.ReactionStore.Delete(reaction)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReactionStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Delete", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	resultVar0 := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReactionStore.DeleteAllWithEmojiName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteAllWithEmojiName", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+func (s *TimerLayerReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	resultVar0, resultVar1 := s.ReactionStore.GetForPost(postId, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReactionStore.GetForPost")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPost", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPluginStore) Delete(pluginId string, key string) *model.AppError {
+func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.PluginStore.Delete(pluginId, key)
+	resultVar0, resultVar1 := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReactionStore.PermanentDeleteBatch")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.PermanentDeleteBatch", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPluginStore) DeleteAllExpired() *model.AppError {
+func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.PluginStore.DeleteAllExpired()
+	resultVar0, resultVar1 := s.ReactionStore.Save(reaction)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReactionStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllExpired", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Save", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginId string) *model.AppError {
+func (s *TimerLayerRecurringPostStore) Delete(id string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.PluginStore.DeleteAllForPlugin(PluginId)
+	resultVar0 := s.RecurringPostStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RecurringPostStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllForPlugin", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RecurringPostStore.Delete", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerPluginStore) Get(pluginId string, key string) (*model.PluginKeyValue, *model.AppError) {
+func (s *TimerLayerRecurringPostStore) Get(id string) (*model.RecurringPost, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.Get(pluginId, key)
+	resultVar0, resultVar1 := s.RecurringPostStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RecurringPostStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RecurringPostStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPluginStore) List(pluginId string, page int, perPage int) ([]string, *model.AppError) {
+func (s *TimerLayerRecurringPostStore) GetDue(now int64, limit int) ([]*model.RecurringPost, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.List(pluginId, page, perPage)
+	resultVar0, resultVar1 := s.RecurringPostStore.GetDue(now, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RecurringPostStore.GetDue")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RecurringPostStore.GetDue", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, *model.AppError) {
+func (s *TimerLayerRecurringPostStore) GetForChannel(channelId string) ([]*model.RecurringPost, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.SaveOrUpdate(keyVal)
+	resultVar0, resultVar1 := s.RecurringPostStore.GetForChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RecurringPostStore.GetForChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SaveOrUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RecurringPostStore.GetForChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, *model.AppError) {
+func (s *TimerLayerRecurringPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
+	resultVar0 := s.RecurringPostStore.PermanentDeleteByChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RecurringPostStore.PermanentDeleteByChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RecurringPostStore.PermanentDeleteByChannel", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, *model.AppError) {
+func (s *TimerLayerRecurringPostStore) Save(recurringPost *model.RecurringPost) (*model.RecurringPost, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.AnalyticsPostCountsByDay(options)
+	resultVar0, resultVar1 := s.RecurringPostStore.Save(recurringPost)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RecurringPostStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountsByDay", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RecurringPostStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError) {
+func (s *TimerLayerRecurringPostStore) Update(recurringPost *model.RecurringPost) (*model.RecurringPost, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
+	resultVar0, resultVar1 := s.RecurringPostStore.Update(recurringPost)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RecurringPostStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsUserCountsWithPostsByDay", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RecurringPostStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) ClearCaches() {
+func (s *TimerLayerReminderStore) Delete(id string) *model.AppError {
 	start := timemodule.Now()
 
-	s.PostStore.ClearCaches()
+	resultVar0 := s.ReminderStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReminderStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.Delete", success, elapsed)
 	}
-	return
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) Delete(postId string, time int64, deleteByID string) *model.AppError {
+func (s *TimerLayerReminderStore) Get(id string) (*model.Reminder, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.PostStore.Delete(postId, time, deleteByID)
+	resultVar0, resultVar1 := s.ReminderStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReminderStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.Get", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) Get(id string) (*model.PostList, *model.AppError) {
+func (s *TimerLayerReminderStore) GetDue(now int64, limit int) ([]*model.Reminder, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.Get(id)
+	resultVar0, resultVar1 := s.ReminderStore.GetDue(now, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReminderStore.GetDue")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.GetDue", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError) {
+func (s *TimerLayerReminderStore) GetPendingForUser(userId string) ([]*model.Reminder, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
+	resultVar0, resultVar1 := s.ReminderStore.GetPendingForUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReminderStore.GetPendingForUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDirectPostParentsForExportAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.GetPendingForUser", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
+func (s *TimerLayerReminderStore) MarkSent(id string, sentAt int64) (bool, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.PostStore.GetEtag(channelId, allowFromCache)
+	resultVar0, resultVar1 := s.ReminderStore.MarkSent(id, sentAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReminderStore.MarkSent")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEtag", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.MarkSent", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, *model.AppError) {
+func (s *TimerLayerReminderStore) PermanentDeleteByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetFlaggedPosts(userId, offset, limit)
+	resultVar0 := s.ReminderStore.PermanentDeleteByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReminderStore.PermanentDeleteByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, *model.AppError) {
+func (s *TimerLayerReminderStore) Save(reminder *model.Reminder) (*model.Reminder, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+	resultVar0, resultVar1 := s.ReminderStore.Save(reminder)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("ReminderStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, *model.AppError) {
+func (s *TimerLayerRoleStore) Delete(roldId string) (*model.Role, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+	resultVar0, resultVar1 := s.RoleStore.Delete(roldId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RoleStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Delete", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetMaxPostSize() int {
+func (s *TimerLayerRoleStore) Get(roleId string) (*model.Role, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.PostStore.GetMaxPostSize()
+	resultVar0, resultVar1 := s.RoleStore.Get(roleId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RoleStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetMaxPostSize", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Get", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetOldest() (*model.Post, *model.AppError) {
+func (s *TimerLayerRoleStore) GetAll() ([]*model.Role, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetOldest()
+	resultVar0, resultVar1 := s.RoleStore.GetAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RoleStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldest", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, *model.AppError) {
+func (s *TimerLayerRoleStore) GetByName(name string) (*model.Role, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetParentsForExportAfter(limit, afterId)
+	resultVar0, resultVar1 := s.RoleStore.GetByName(name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RoleStore.GetByName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetParentsForExportAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByName", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, *model.AppError) {
+func (s *TimerLayerRoleStore) GetByNames(names []string) ([]*model.Role, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostAfterTime(channelId, time)
+	resultVar0, resultVar1 := s.RoleStore.GetByNames(names)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RoleStore.GetByNames")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostAfterTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByNames", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, *model.AppError) {
+func (s *TimerLayerRoleStore) PermanentDeleteAll() *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostIdAfterTime(channelId, time)
+	resultVar0 := s.RoleStore.PermanentDeleteAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RoleStore.PermanentDeleteAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdAfterTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.PermanentDeleteAll", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError) {
+func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostIdBeforeTime(channelId, time)
+	resultVar0, resultVar1 := s.RoleStore.Save(role)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("RoleStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdBeforeTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPosts(channelId string, offset int, limit int, allowFromCache bool) (*model.PostList, *model.AppError) {
+func (s *TimerLayerSchemaMigrationStore) GetAll() ([]*model.SchemaMigration, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPosts(channelId, offset, limit, allowFromCache)
+	resultVar0, resultVar1 := s.SchemaMigrationStore.GetAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SchemaMigrationStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemaMigrationStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostsAfter(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
+func (s *TimerLayerSchemaMigrationStore) RunBackfillBatch(version int, batchSize int) (bool, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsAfter(channelId, postId, numPosts, offset)
+	resultVar0, resultVar1 := s.SchemaMigrationStore.RunBackfillBatch(version, batchSize)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SchemaMigrationStore.RunBackfillBatch")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemaMigrationStore.RunBackfillBatch", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, *model.AppError) {
+func (s *TimerLayerSchemeStore) Delete(schemeId string) (*model.Scheme, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+	resultVar0, resultVar1 := s.SchemeStore.Delete(schemeId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SchemeStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBatchForIndexing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Delete", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostsBefore(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
+func (s *TimerLayerSchemeStore) Get(schemeId string) (*model.Scheme, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsBefore(channelId, postId, numPosts, offset)
+	resultVar0, resultVar1 := s.SchemeStore.Get(schemeId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SchemeStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBefore", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, *model.AppError) {
+func (s *TimerLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsByIds(postIds)
+	resultVar0, resultVar1 := s.SchemeStore.GetAllPage(scope, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SchemeStore.GetAllPage")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetAllPage", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, *model.AppError) {
+func (s *TimerLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsCreatedAt(channelId, time)
+	resultVar0, resultVar1 := s.SchemeStore.GetByName(schemeName)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SchemeStore.GetByName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsCreatedAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetByName", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostsSince(channelId string, time int64, allowFromCache bool) (*model.PostList, *model.AppError) {
+func (s *TimerLayerSchemeStore) PermanentDeleteAll() *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsSince(channelId, time, allowFromCache)
+	resultVar0 := s.SchemeStore.PermanentDeleteAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SchemeStore.PermanentDeleteAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsSince", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.PermanentDeleteAll", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, *model.AppError) {
+func (s *TimerLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetRepliesForExport(parentId)
+	resultVar0, resultVar1 := s.SchemeStore.Save(scheme)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SchemeStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRepliesForExport", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetSingle(id string) (*model.Post, *model.AppError) {
+func (s *TimerLayerSessionStore) AnalyticsSessionCount() (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetSingle(id)
+	resultVar0, resultVar1 := s.SessionStore.AnalyticsSessionCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.AnalyticsSessionCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetSingle", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.AnalyticsSessionCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
+func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
 	start := timemodule.Now()
 
-	s.PostStore.InvalidateLastPostTimeCache(channelId)
+	s.SessionStore.Cleanup(expiryTime, batchSize)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4115,1759 +9308,1947 @@ func (s *TimerLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
 		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.InvalidateLastPostTimeCache", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Cleanup", success, elapsed)
 	}
 	return
 }
 
-func (s *TimerLayerPostStore) Overwrite(post *model.Post) (*model.Post, *model.AppError) {
+func (s *TimerLayerSessionStore) Get(sessionIdOrToken string) (*model.Session, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.Overwrite(post)
+	resultVar0, resultVar1 := s.SessionStore.Get(sessionIdOrToken)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Overwrite", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+func (s *TimerLayerSessionStore) GetSessions(userId string) ([]*model.Session, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0, resultVar1 := s.SessionStore.GetSessions(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.GetSessions")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessions", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*model.Session, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.PostStore.PermanentDeleteByChannel(channelId)
+	resultVar0, resultVar1 := s.SessionStore.GetSessionsWithActiveDeviceIds(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.GetSessionsWithActiveDeviceIds")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessionsWithActiveDeviceIds", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.PostStore.PermanentDeleteByUser(userId)
+	resultVar0 := s.SessionStore.PermanentDeleteSessionsByUser(teamId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.PermanentDeleteSessionsByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.PermanentDeleteSessionsByUser", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
-	start := timemodule.Now()
-
-	resultVar0, resultVar1 := s.PostStore.Save(post)
-
-	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
-	if s.Root.Metrics != nil {
-		success := "false"
-		if resultVar1 == nil {
-			success = "true"
-		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Save", success, elapsed)
-	}
-	return resultVar0, resultVar1
-}
-
-func (s *TimerLayerPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+func (s *TimerLayerSessionStore) Remove(sessionIdOrToken string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.Search(teamId, userId, params)
+	resultVar0 := s.SessionStore.Remove(sessionIdOrToken)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.Remove")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Search", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Remove", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
+func (s *TimerLayerSessionStore) RemoveAllSessions() *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.Update(newPost, oldPost)
+	resultVar0 := s.SessionStore.RemoveAllSessions()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.RemoveAllSessions")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.RemoveAllSessions", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, *model.AppError) {
+func (s *TimerLayerSessionStore) Save(session *model.Session) (*model.Session, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PreferenceStore.CleanupFlagsBatch(limit)
+	resultVar0, resultVar1 := s.SessionStore.Save(session)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.CleanupFlagsBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPreferenceStore) Delete(userId string, category string, name string) *model.AppError {
+func (s *TimerLayerSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.Delete(userId, category, name)
+	resultVar0, resultVar1 := s.SessionStore.UpdateDeviceId(id, deviceId, expiresAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.UpdateDeviceId")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateDeviceId", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPreferenceStore) DeleteCategory(userId string, category string) *model.AppError {
+func (s *TimerLayerSessionStore) UpdateLastActivityAt(sessionId string, time int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.DeleteCategory(userId, category)
+	resultVar0 := s.SessionStore.UpdateLastActivityAt(sessionId, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.UpdateLastActivityAt")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateLastActivityAt", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) *model.AppError {
+func (s *TimerLayerSessionStore) UpdateProps(session *model.Session) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.DeleteCategoryAndName(category, name)
+	resultVar0 := s.SessionStore.UpdateProps(session)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.UpdateProps")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategoryAndName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateProps", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerPreferenceStore) Get(userId string, category string, name string) (*model.Preference, *model.AppError) {
+func (s *TimerLayerSessionStore) UpdateRoles(userId string, roles string) (string, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PreferenceStore.Get(userId, category, name)
+	resultVar0, resultVar1 := s.SessionStore.UpdateRoles(userId, roles)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SessionStore.UpdateRoles")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateRoles", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPreferenceStore) GetAll(userId string) (model.Preferences, *model.AppError) {
+func (s *TimerLayerStatusStore) Get(userId string) (*model.Status, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PreferenceStore.GetAll(userId)
+	resultVar0, resultVar1 := s.StatusStore.Get(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("StatusStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPreferenceStore) GetCategory(userId string, category string) (model.Preferences, *model.AppError) {
+func (s *TimerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PreferenceStore.GetCategory(userId, category)
+	resultVar0, resultVar1 := s.StatusStore.GetByIds(userIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("StatusStore.GetByIds")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetByIds", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userId string) *model.AppError {
+func (s *TimerLayerStatusStore) GetTotalActiveUsersCount() (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.PermanentDeleteByUser(userId)
+	resultVar0, resultVar1 := s.StatusStore.GetTotalActiveUsersCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("StatusStore.GetTotalActiveUsersCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetTotalActiveUsersCount", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPreferenceStore) Save(preferences *model.Preferences) *model.AppError {
+func (s *TimerLayerStatusStore) ResetAll() *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.Save(preferences)
+	resultVar0 := s.StatusStore.ResetAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("StatusStore.ResetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.ResetAll", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, *model.AppError) {
+func (s *TimerLayerStatusStore) SaveOrUpdate(status *model.Status) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ReactionStore.BulkGetForPosts(postIds)
+	resultVar0 := s.StatusStore.SaveOrUpdate(status)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("StatusStore.SaveOrUpdate")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.BulkGetForPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.SaveOrUpdate", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+func (s *TimerLayerStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ReactionStore.Delete(reaction)
+	resultVar0 := s.StatusStore.UpdateLastActivityAt(userId, lastActivityAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("StatusStore.UpdateLastActivityAt")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.UpdateLastActivityAt", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) *model.AppError {
+func (s *TimerLayerSystemStore) AcquireLease(lease *model.LeaderLease) (*model.LeaderLease, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+	resultVar0, resultVar1 := s.SystemStore.AcquireLease(lease)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SystemStore.AcquireLease")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteAllWithEmojiName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.AcquireLease", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, *model.AppError) {
+func (s *TimerLayerSystemStore) Get() (model.StringMap, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ReactionStore.GetForPost(postId, allowFromCache)
+	resultVar0, resultVar1 := s.SystemStore.Get()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SystemStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+func (s *TimerLayerSystemStore) GetByName(name string) (*model.System, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0, resultVar1 := s.SystemStore.GetByName(name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SystemStore.GetByName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.GetByName", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+func (s *TimerLayerSystemStore) PermanentDeleteByName(name string) (*model.System, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ReactionStore.Save(reaction)
+	resultVar0, resultVar1 := s.SystemStore.PermanentDeleteByName(name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SystemStore.PermanentDeleteByName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.PermanentDeleteByName", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerRoleStore) Delete(roldId string) (*model.Role, *model.AppError) {
+func (s *TimerLayerSystemStore) ReleaseLease(name string, holderId string) (bool, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.Delete(roldId)
+	resultVar0, resultVar1 := s.SystemStore.ReleaseLease(name, holderId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SystemStore.ReleaseLease")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.ReleaseLease", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerRoleStore) Get(roleId string) (*model.Role, *model.AppError) {
+func (s *TimerLayerSystemStore) Save(system *model.System) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.Get(roleId)
+	resultVar0 := s.SystemStore.Save(system)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SystemStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Save", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerRoleStore) GetAll() ([]*model.Role, *model.AppError) {
+func (s *TimerLayerSystemStore) SaveOrUpdate(system *model.System) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.GetAll()
+	resultVar0 := s.SystemStore.SaveOrUpdate(system)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SystemStore.SaveOrUpdate")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.SaveOrUpdate", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerRoleStore) GetByName(name string) (*model.Role, *model.AppError) {
+func (s *TimerLayerSystemStore) Update(system *model.System) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.GetByName(name)
+	resultVar0 := s.SystemStore.Update(system)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("SystemStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Update", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerRoleStore) GetByNames(names []string) ([]*model.Role, *model.AppError) {
+func (s *TimerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.GetByNames(names)
+	resultVar0, resultVar1 := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.AnalyticsGetTeamCountForScheme")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByNames", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsGetTeamCountForScheme", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerRoleStore) PermanentDeleteAll() *model.AppError {
+func (s *TimerLayerTeamStore) AnalyticsPrivateTeamCount() (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.RoleStore.PermanentDeleteAll()
+	resultVar0, resultVar1 := s.TeamStore.AnalyticsPrivateTeamCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.AnalyticsPrivateTeamCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.PermanentDeleteAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsPrivateTeamCount", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, *model.AppError) {
+func (s *TimerLayerTeamStore) AnalyticsPublicTeamCount() (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.Save(role)
+	resultVar0, resultVar1 := s.TeamStore.AnalyticsPublicTeamCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.AnalyticsPublicTeamCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsPublicTeamCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSchemeStore) Delete(schemeId string) (*model.Scheme, *model.AppError) {
+func (s *TimerLayerTeamStore) AnalyticsTeamCount() (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SchemeStore.Delete(schemeId)
+	resultVar0, resultVar1 := s.TeamStore.AnalyticsTeamCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.AnalyticsTeamCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsTeamCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSchemeStore) Get(schemeId string) (*model.Scheme, *model.AppError) {
+func (s *TimerLayerTeamStore) ClearAllCustomRoleAssignments() *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SchemeStore.Get(schemeId)
+	resultVar0 := s.TeamStore.ClearAllCustomRoleAssignments()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.ClearAllCustomRoleAssignments")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearAllCustomRoleAssignments", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError) {
+func (s *TimerLayerTeamStore) ClearCaches() {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SchemeStore.GetAllPage(scope, offset, limit)
+	s.TeamStore.ClearCaches()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetAllPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearCaches", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return
 }
 
-func (s *TimerLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, *model.AppError) {
+func (s *TimerLayerTeamStore) Get(id string) (*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SchemeStore.GetByName(schemeName)
+	resultVar0, resultVar1 := s.TeamStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSchemeStore) PermanentDeleteAll() *model.AppError {
+func (s *TimerLayerTeamStore) GetActiveMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.SchemeStore.PermanentDeleteAll()
+	resultVar0, resultVar1 := s.TeamStore.GetActiveMemberCount(teamId, restrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetActiveMemberCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.PermanentDeleteAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetActiveMemberCount", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *model.AppError) {
+func (s *TimerLayerTeamStore) GetAll() ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SchemeStore.Save(scheme)
+	resultVar0, resultVar1 := s.TeamStore.GetAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) AnalyticsSessionCount() (int64, *model.AppError) {
+func (s *TimerLayerTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.TeamForExport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.AnalyticsSessionCount()
+	resultVar0, resultVar1 := s.TeamStore.GetAllForExportAfter(limit, afterId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetAllForExportAfter")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.AnalyticsSessionCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllForExportAfter", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
+func (s *TimerLayerTeamStore) GetAllPage(offset int, limit int) ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	s.SessionStore.Cleanup(expiryTime, batchSize)
+	resultVar0, resultVar1 := s.TeamStore.GetAllPage(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetAllPage")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Cleanup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPage", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) Get(sessionIdOrToken string) (*model.Session, *model.AppError) {
+func (s *TimerLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.Get(sessionIdOrToken)
+	resultVar0, resultVar1 := s.TeamStore.GetAllPrivateTeamListing()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetAllPrivateTeamListing")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPrivateTeamListing", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) GetSessions(userId string) ([]*model.Session, *model.AppError) {
+func (s *TimerLayerTeamStore) GetAllPrivateTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.GetSessions(userId)
+	resultVar0, resultVar1 := s.TeamStore.GetAllPrivateTeamPageListing(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetAllPrivateTeamPageListing")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessions", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPrivateTeamPageListing", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*model.Session, *model.AppError) {
+func (s *TimerLayerTeamStore) GetAllPublicTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.GetSessionsWithActiveDeviceIds(userId)
+	resultVar0, resultVar1 := s.TeamStore.GetAllPublicTeamPageListing(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetAllPublicTeamPageListing")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessionsWithActiveDeviceIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPublicTeamPageListing", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) *model.AppError {
+func (s *TimerLayerTeamStore) GetAllTeamListing() ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.SessionStore.PermanentDeleteSessionsByUser(teamId)
+	resultVar0, resultVar1 := s.TeamStore.GetAllTeamListing()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetAllTeamListing")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.PermanentDeleteSessionsByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllTeamListing", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) Remove(sessionIdOrToken string) *model.AppError {
+func (s *TimerLayerTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.SessionStore.Remove(sessionIdOrToken)
+	resultVar0, resultVar1 := s.TeamStore.GetAllTeamPageListing(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetAllTeamPageListing")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Remove", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllTeamPageListing", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) RemoveAllSessions() *model.AppError {
+func (s *TimerLayerTeamStore) GetByInviteId(inviteId string) (*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.SessionStore.RemoveAllSessions()
+	resultVar0, resultVar1 := s.TeamStore.GetByInviteId(inviteId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetByInviteId")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.RemoveAllSessions", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByInviteId", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) Save(session *model.Session) (*model.Session, *model.AppError) {
+func (s *TimerLayerTeamStore) GetByName(name string) (*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.Save(session)
+	resultVar0, resultVar1 := s.TeamStore.GetByName(name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetByName")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByName", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, *model.AppError) {
+func (s *TimerLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId string, userId string) ([]*model.ChannelUnread, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.UpdateDeviceId(id, deviceId, expiresAt)
+	resultVar0, resultVar1 := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamId, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetChannelUnreadsForAllTeams")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateDeviceId", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForAllTeams", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) UpdateLastActivityAt(sessionId string, time int64) *model.AppError {
+func (s *TimerLayerTeamStore) GetChannelUnreadsForTeam(teamId string, userId string) ([]*model.ChannelUnread, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.SessionStore.UpdateLastActivityAt(sessionId, time)
+	resultVar0, resultVar1 := s.TeamStore.GetChannelUnreadsForTeam(teamId, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetChannelUnreadsForTeam")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateLastActivityAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForTeam", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) UpdateProps(session *model.Session) *model.AppError {
+func (s *TimerLayerTeamStore) GetMember(teamId string, userId string) (*model.TeamMember, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.SessionStore.UpdateProps(session)
+	resultVar0, resultVar1 := s.TeamStore.GetMember(teamId, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetMember")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateProps", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMember", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSessionStore) UpdateRoles(userId string, roles string) (string, *model.AppError) {
+func (s *TimerLayerTeamStore) GetMembers(teamId string, offset int, limit int, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.UpdateRoles(userId, roles)
+	resultVar0, resultVar1 := s.TeamStore.GetMembers(teamId, offset, limit, restrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetMembers")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateRoles", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembers", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerStatusStore) Get(userId string) (*model.Status, *model.AppError) {
+func (s *TimerLayerTeamStore) GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.StatusStore.Get(userId)
+	resultVar0, resultVar1 := s.TeamStore.GetMembersByIds(teamId, userIds, restrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetMembersByIds")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembersByIds", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, *model.AppError) {
+func (s *TimerLayerTeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMemberForExport, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.StatusStore.GetByIds(userIds)
+	resultVar0, resultVar1 := s.TeamStore.GetTeamMembersForExport(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetTeamMembersForExport")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamMembersForExport", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerStatusStore) GetTotalActiveUsersCount() (int64, *model.AppError) {
+func (s *TimerLayerTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.StatusStore.GetTotalActiveUsersCount()
+	resultVar0, resultVar1 := s.TeamStore.GetTeamsByScheme(schemeId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetTeamsByScheme")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetTotalActiveUsersCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByScheme", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerStatusStore) ResetAll() *model.AppError {
+func (s *TimerLayerTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.StatusStore.ResetAll()
+	resultVar0, resultVar1 := s.TeamStore.GetTeamsByUserId(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetTeamsByUserId")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.ResetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByUserId", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerStatusStore) SaveOrUpdate(status *model.Status) *model.AppError {
+func (s *TimerLayerTeamStore) GetTeamsForUser(userId string) ([]*model.TeamMember, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.StatusStore.SaveOrUpdate(status)
+	resultVar0, resultVar1 := s.TeamStore.GetTeamsForUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetTeamsForUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.SaveOrUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUser", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) *model.AppError {
+func (s *TimerLayerTeamStore) GetTeamsForUserWithPagination(userId string, page int, perPage int) ([]*model.TeamMember, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.StatusStore.UpdateLastActivityAt(userId, lastActivityAt)
+	resultVar0, resultVar1 := s.TeamStore.GetTeamsForUserWithPagination(userId, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetTeamsForUserWithPagination")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.UpdateLastActivityAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUserWithPagination", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSystemStore) Get() (model.StringMap, *model.AppError) {
+func (s *TimerLayerTeamStore) GetTotalMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SystemStore.Get()
+	resultVar0, resultVar1 := s.TeamStore.GetTotalMemberCount(teamId, restrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetTotalMemberCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTotalMemberCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSystemStore) GetByName(name string) (*model.System, *model.AppError) {
+func (s *TimerLayerTeamStore) GetUserTeamIds(userId string, allowFromCache bool) ([]string, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SystemStore.GetByName(name)
+	resultVar0, resultVar1 := s.TeamStore.GetUserTeamIds(userId, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.GetUserTeamIds")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetUserTeamIds", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSystemStore) PermanentDeleteByName(name string) (*model.System, *model.AppError) {
+func (s *TimerLayerTeamStore) InvalidateAllTeamIdsForUser(userId string) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SystemStore.PermanentDeleteByName(name)
+	s.TeamStore.InvalidateAllTeamIdsForUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.PermanentDeleteByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.InvalidateAllTeamIdsForUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return
 }
 
-func (s *TimerLayerSystemStore) Save(system *model.System) *model.AppError {
+func (s *TimerLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.SystemStore.Save(system)
+	resultVar0, resultVar1 := s.TeamStore.MigrateTeamMembers(fromTeamId, fromUserId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.MigrateTeamMembers")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.MigrateTeamMembers", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSystemStore) SaveOrUpdate(system *model.System) *model.AppError {
+func (s *TimerLayerTeamStore) PermanentDelete(teamId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.SystemStore.SaveOrUpdate(system)
+	resultVar0 := s.TeamStore.PermanentDelete(teamId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.PermanentDelete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.SaveOrUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.PermanentDelete", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerSystemStore) Update(system *model.System) *model.AppError {
+func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.SystemStore.Update(system)
+	resultVar0 := s.TeamStore.RemoveAllMembersByTeam(teamId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.RemoveAllMembersByTeam")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByTeam", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, *model.AppError) {
+func (s *TimerLayerTeamStore) RemoveAllMembersByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeId)
+	resultVar0 := s.TeamStore.RemoveAllMembersByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.RemoveAllMembersByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsGetTeamCountForScheme", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) AnalyticsPrivateTeamCount() (int64, *model.AppError) {
+func (s *TimerLayerTeamStore) RemoveMember(teamId string, userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.AnalyticsPrivateTeamCount()
+	resultVar0 := s.TeamStore.RemoveMember(teamId, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.RemoveMember")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsPrivateTeamCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveMember", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) AnalyticsPublicTeamCount() (int64, *model.AppError) {
+func (s *TimerLayerTeamStore) ResetAllTeamSchemes() *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.AnalyticsPublicTeamCount()
+	resultVar0 := s.TeamStore.ResetAllTeamSchemes()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.ResetAllTeamSchemes")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsPublicTeamCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ResetAllTeamSchemes", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) AnalyticsTeamCount() (int64, *model.AppError) {
+func (s *TimerLayerTeamStore) Save(team *model.Team) (*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.AnalyticsTeamCount()
+	resultVar0, resultVar1 := s.TeamStore.Save(team)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsTeamCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) ClearAllCustomRoleAssignments() *model.AppError {
+func (s *TimerLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.ClearAllCustomRoleAssignments()
+	resultVar0, resultVar1 := s.TeamStore.SaveMember(member, maxUsersPerTeam)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.SaveMember")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearAllCustomRoleAssignments", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveMember", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) ClearCaches() {
+func (s *TimerLayerTeamStore) SearchAll(term string) ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	s.TeamStore.ClearCaches()
+	resultVar0, resultVar1 := s.TeamStore.SearchAll(term)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.SearchAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchAll", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) Get(id string) (*model.Team, *model.AppError) {
+func (s *TimerLayerTeamStore) SearchOpen(term string) ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.Get(id)
+	resultVar0, resultVar1 := s.TeamStore.SearchOpen(term)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.SearchOpen")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchOpen", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetActiveMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, *model.AppError) {
+func (s *TimerLayerTeamStore) SearchPrivate(term string) ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetActiveMemberCount(teamId, restrictions)
+	resultVar0, resultVar1 := s.TeamStore.SearchPrivate(term)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.SearchPrivate")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetActiveMemberCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchPrivate", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetAll() ([]*model.Team, *model.AppError) {
+func (s *TimerLayerTeamStore) Update(team *model.Team) (*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAll()
+	resultVar0, resultVar1 := s.TeamStore.Update(team)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.TeamForExport, *model.AppError) {
+func (s *TimerLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllForExportAfter(limit, afterId)
+	resultVar0 := s.TeamStore.UpdateLastTeamIconUpdate(teamId, curTime)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.UpdateLastTeamIconUpdate")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllForExportAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateLastTeamIconUpdate", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) GetAllPage(offset int, limit int) ([]*model.Team, *model.AppError) {
+func (s *TimerLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllPage(offset, limit)
+	resultVar0, resultVar1 := s.TeamStore.UpdateMember(member)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.UpdateMember")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMember", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, *model.AppError) {
+func (s *TimerLayerTeamStore) UserBelongsToTeams(userId string, teamIds []string) (bool, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllPrivateTeamListing()
+	resultVar0, resultVar1 := s.TeamStore.UserBelongsToTeams(userId, teamIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TeamStore.UserBelongsToTeams")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPrivateTeamListing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UserBelongsToTeams", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetAllPrivateTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
+func (s *TimerLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllPrivateTeamPageListing(offset, limit)
+	resultVar0, resultVar1 := s.TermsOfServiceStore.Get(id, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TermsOfServiceStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPrivateTeamPageListing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetAllPublicTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
+func (s *TimerLayerTermsOfServiceStore) GetAll() ([]*model.TermsOfService, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllPublicTeamPageListing(offset, limit)
+	resultVar0, resultVar1 := s.TermsOfServiceStore.GetAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TermsOfServiceStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPublicTeamPageListing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetAllTeamListing() ([]*model.Team, *model.AppError) {
+func (s *TimerLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllTeamListing()
+	resultVar0, resultVar1 := s.TermsOfServiceStore.GetLatest(allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TermsOfServiceStore.GetLatest")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllTeamListing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.GetLatest", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
+func (s *TimerLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllTeamPageListing(offset, limit)
+	resultVar0, resultVar1 := s.TermsOfServiceStore.Save(termsOfService)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TermsOfServiceStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllTeamPageListing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetByInviteId(inviteId string) (*model.Team, *model.AppError) {
+func (s *TimerLayerTermsOfServiceStore) UpdateReacceptAt(id string, reacceptAt int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetByInviteId(inviteId)
+	resultVar0 := s.TermsOfServiceStore.UpdateReacceptAt(id, reacceptAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TermsOfServiceStore.UpdateReacceptAt")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByInviteId", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.UpdateReacceptAt", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) GetByName(name string) (*model.Team, *model.AppError) {
+func (s *TimerLayerTodoStore) Delete(id string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetByName(name)
+	resultVar0 := s.TodoStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TodoStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TodoStore.Delete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId string, userId string) ([]*model.ChannelUnread, *model.AppError) {
+func (s *TimerLayerTodoStore) Get(id string) (*model.Todo, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamId, userId)
+	resultVar0, resultVar1 := s.TodoStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TodoStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForAllTeams", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TodoStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetChannelUnreadsForTeam(teamId string, userId string) ([]*model.ChannelUnread, *model.AppError) {
+func (s *TimerLayerTodoStore) GetForUser(userId string, includeCompleted bool) ([]*model.Todo, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetChannelUnreadsForTeam(teamId, userId)
+	resultVar0, resultVar1 := s.TodoStore.GetForUser(userId, includeCompleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TodoStore.GetForUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TodoStore.GetForUser", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetMember(teamId string, userId string) (*model.TeamMember, *model.AppError) {
+func (s *TimerLayerTodoStore) PermanentDeleteByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetMember(teamId, userId)
+	resultVar0 := s.TodoStore.PermanentDeleteByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TodoStore.PermanentDeleteByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TodoStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) GetMembers(teamId string, offset int, limit int, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
+func (s *TimerLayerTodoStore) Save(todo *model.Todo) (*model.Todo, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetMembers(teamId, offset, limit, restrictions)
+	resultVar0, resultVar1 := s.TodoStore.Save(todo)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TodoStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TodoStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
+func (s *TimerLayerTodoStore) Update(todo *model.Todo) (*model.Todo, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetMembersByIds(teamId, userIds, restrictions)
+	resultVar0, resultVar1 := s.TodoStore.Update(todo)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TodoStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembersByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TodoStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMemberForExport, *model.AppError) {
+func (s *TimerLayerTokenStore) Cleanup() {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTeamMembersForExport(userId)
+	s.TokenStore.Cleanup()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamMembersForExport", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Cleanup", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return
 }
 
-func (s *TimerLayerTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, *model.AppError) {
+func (s *TimerLayerTokenStore) Delete(token string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTeamsByScheme(schemeId, offset, limit)
+	resultVar0 := s.TokenStore.Delete(token)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TokenStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByScheme", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Delete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, *model.AppError) {
+func (s *TimerLayerTokenStore) GetByToken(token string) (*model.Token, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTeamsByUserId(userId)
+	resultVar0, resultVar1 := s.TokenStore.GetByToken(token)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TokenStore.GetByToken")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByUserId", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.GetByToken", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetTeamsForUser(userId string) ([]*model.TeamMember, *model.AppError) {
+func (s *TimerLayerTokenStore) RemoveAllTokensByType(tokenType string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTeamsForUser(userId)
+	resultVar0 := s.TokenStore.RemoveAllTokensByType(tokenType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TokenStore.RemoveAllTokensByType")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.RemoveAllTokensByType", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) GetTeamsForUserWithPagination(userId string, page int, perPage int) ([]*model.TeamMember, *model.AppError) {
+func (s *TimerLayerTokenStore) Save(recovery *model.Token) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTeamsForUserWithPagination(userId, page, perPage)
+	resultVar0 := s.TokenStore.Save(recovery)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("TokenStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUserWithPagination", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Save", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) GetTotalMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, *model.AppError) {
+func (s *TimerLayerUserStore) AnalyticsActiveCount(time int64, options model.UserCountOptions) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTotalMemberCount(teamId, restrictions)
+	resultVar0, resultVar1 := s.UserStore.AnalyticsActiveCount(time, options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.AnalyticsActiveCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTotalMemberCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsActiveCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) GetUserTeamIds(userId string, allowFromCache bool) ([]string, *model.AppError) {
+func (s *TimerLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetUserTeamIds(userId, allowFromCache)
+	resultVar0, resultVar1 := s.UserStore.AnalyticsGetInactiveUsersCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.AnalyticsGetInactiveUsersCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetUserTeamIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetInactiveUsersCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) InvalidateAllTeamIdsForUser(userId string) {
+func (s *TimerLayerUserStore) AnalyticsGetMfaAdoption() (*model.MfaAdoption, *model.AppError) {
 	start := timemodule.Now()
 
-	s.TeamStore.InvalidateAllTeamIdsForUser(userId)
+	resultVar0, resultVar1 := s.UserStore.AnalyticsGetMfaAdoption()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.AnalyticsGetMfaAdoption")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.InvalidateAllTeamIdsForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetMfaAdoption", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, *model.AppError) {
+func (s *TimerLayerUserStore) AnalyticsGetSystemAdminCount() (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.MigrateTeamMembers(fromTeamId, fromUserId)
+	resultVar0, resultVar1 := s.UserStore.AnalyticsGetSystemAdminCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.AnalyticsGetSystemAdminCount")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.MigrateTeamMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetSystemAdminCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) PermanentDelete(teamId string) *model.AppError {
+func (s *TimerLayerUserStore) ClearAllCustomRoleAssignments() *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.PermanentDelete(teamId)
+	resultVar0 := s.UserStore.ClearAllCustomRoleAssignments()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.ClearAllCustomRoleAssignments")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.PermanentDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ClearAllCustomRoleAssignments", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamId string) *model.AppError {
+func (s *TimerLayerUserStore) ClearCaches() {
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.RemoveAllMembersByTeam(teamId)
+	s.UserStore.ClearCaches()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ClearCaches", success, elapsed)
 	}
-	return resultVar0
+	return
 }
 
-func (s *TimerLayerTeamStore) RemoveAllMembersByUser(userId string) *model.AppError {
+func (s *TimerLayerUserStore) Count(options model.UserCountOptions) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.RemoveAllMembersByUser(userId)
+	resultVar0, resultVar1 := s.UserStore.Count(options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.Count")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Count", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) RemoveMember(teamId string, userId string) *model.AppError {
+func (s *TimerLayerUserStore) DemoteUserToGuest(userID string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.RemoveMember(teamId, userId)
+	resultVar0 := s.UserStore.DemoteUserToGuest(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.DemoteUserToGuest")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.DemoteUserToGuest", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerTeamStore) ResetAllTeamSchemes() *model.AppError {
+func (s *TimerLayerUserStore) Get(id string) (*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.ResetAllTeamSchemes()
+	resultVar0, resultVar1 := s.UserStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ResetAllTeamSchemes", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Get", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) Save(team *model.Team) (*model.Team, *model.AppError) {
+func (s *TimerLayerUserStore) GetAll() ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.Save(team)
+	resultVar0, resultVar1 := s.UserStore.GetAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, *model.AppError) {
+func (s *TimerLayerUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.SaveMember(member, maxUsersPerTeam)
+	resultVar0, resultVar1 := s.UserStore.GetAllAfter(limit, afterId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetAllAfter")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllAfter", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) SearchAll(term string) ([]*model.Team, *model.AppError) {
+func (s *TimerLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.SearchAll(term)
+	resultVar0, resultVar1 := s.UserStore.GetAllProfiles(options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetAllProfiles")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllProfiles", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) SearchOpen(term string) ([]*model.Team, *model.AppError) {
+func (s *TimerLayerUserStore) GetAllProfilesInChannel(channelId string, allowFromCache bool) (map[string]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.SearchOpen(term)
+	resultVar0, resultVar1 := s.UserStore.GetAllProfilesInChannel(channelId, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetAllProfilesInChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchOpen", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllProfilesInChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) SearchPrivate(term string) ([]*model.Team, *model.AppError) {
+func (s *TimerLayerUserStore) GetAllUsingAuthService(authService string) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.SearchPrivate(term)
+	resultVar0, resultVar1 := s.UserStore.GetAllUsingAuthService(authService)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetAllUsingAuthService")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchPrivate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllUsingAuthService", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) Update(team *model.Team) (*model.Team, *model.AppError) {
+func (s *TimerLayerUserStore) GetAnyUnreadPostCountForChannel(userId string, channelId string) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.Update(team)
+	resultVar0, resultVar1 := s.UserStore.GetAnyUnreadPostCountForChannel(userId, channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetAnyUnreadPostCountForChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAnyUnreadPostCountForChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) *model.AppError {
+func (s *TimerLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.UpdateLastTeamIconUpdate(teamId, curTime)
+	resultVar0, resultVar1 := s.UserStore.GetByAuth(authData, authService)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetByAuth")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateLastTeamIconUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByAuth", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, *model.AppError) {
+func (s *TimerLayerUserStore) GetByEmail(email string) (*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.UpdateMember(member)
+	resultVar0, resultVar1 := s.UserStore.GetByEmail(email)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetByEmail")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByEmail", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) UserBelongsToTeams(userId string, teamIds []string) (bool, *model.AppError) {
+func (s *TimerLayerUserStore) GetByUsername(username string) (*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.UserBelongsToTeams(userId, teamIds)
+	resultVar0, resultVar1 := s.UserStore.GetByUsername(username)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetByUsername")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UserBelongsToTeams", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByUsername", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, *model.AppError) {
+func (s *TimerLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TermsOfServiceStore.Get(id, allowFromCache)
+	resultVar0, resultVar1 := s.UserStore.GetChannelGroupUsers(channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetChannelGroupUsers")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetChannelGroupUsers", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, *model.AppError) {
+func (s *TimerLayerUserStore) GetEtagForAllProfiles() string {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TermsOfServiceStore.GetLatest(allowFromCache)
+	resultVar0 := s.UserStore.GetEtagForAllProfiles()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.GetLatest", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForAllProfiles", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, *model.AppError) {
+func (s *TimerLayerUserStore) GetEtagForProfiles(teamId string) string {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TermsOfServiceStore.Save(termsOfService)
+	resultVar0 := s.UserStore.GetEtagForProfiles(teamId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForProfiles", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerTokenStore) Cleanup() {
+func (s *TimerLayerUserStore) GetEtagForProfilesNotInTeam(teamId string) string {
 	start := timemodule.Now()
 
-	s.TokenStore.Cleanup()
+	resultVar0 := s.UserStore.GetEtagForProfilesNotInTeam(teamId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5875,1239 +11256,1385 @@ func (s *TimerLayerTokenStore) Cleanup() {
 		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Cleanup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForProfilesNotInTeam", success, elapsed)
 	}
-	return
+	return resultVar0
 }
 
-func (s *TimerLayerTokenStore) Delete(token string) *model.AppError {
+func (s *TimerLayerUserStore) GetForLogin(loginId string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.TokenStore.Delete(token)
+	resultVar0, resultVar1 := s.UserStore.GetForLogin(loginId, allowSignInWithUsername, allowSignInWithEmail)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetForLogin")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetForLogin", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTokenStore) GetByToken(token string) (*model.Token, *model.AppError) {
+func (s *TimerLayerUserStore) GetNewUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TokenStore.GetByToken(token)
+	resultVar0, resultVar1 := s.UserStore.GetNewUsersForTeam(teamId, offset, limit, viewRestrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetNewUsersForTeam")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.GetByToken", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetNewUsersForTeam", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTokenStore) RemoveAllTokensByType(tokenType string) *model.AppError {
+func (s *TimerLayerUserStore) GetProfileByGroupChannelIdsForUser(userId string, channelIds []string) (map[string][]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.TokenStore.RemoveAllTokensByType(tokenType)
+	resultVar0, resultVar1 := s.UserStore.GetProfileByGroupChannelIdsForUser(userId, channelIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetProfileByGroupChannelIdsForUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.RemoveAllTokensByType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfileByGroupChannelIdsForUser", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTokenStore) Save(recovery *model.Token) *model.AppError {
+func (s *TimerLayerUserStore) GetProfileByIds(userIds []string, options *UserGetByIdsOpts, allowFromCache bool) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.TokenStore.Save(recovery)
+	resultVar0, resultVar1 := s.UserStore.GetProfileByIds(userIds, options, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetProfileByIds")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfileByIds", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) AnalyticsActiveCount(time int64, options model.UserCountOptions) (int64, *model.AppError) {
+func (s *TimerLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.AnalyticsActiveCount(time, options)
+	resultVar0, resultVar1 := s.UserStore.GetProfiles(options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetProfiles")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsActiveCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfiles", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, *model.AppError) {
+func (s *TimerLayerUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.AnalyticsGetInactiveUsersCount()
+	resultVar0, resultVar1 := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetProfilesByUsernames")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetInactiveUsersCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesByUsernames", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) AnalyticsGetSystemAdminCount() (int64, *model.AppError) {
+func (s *TimerLayerUserStore) GetProfilesInChannel(channelId string, offset int, limit int) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.AnalyticsGetSystemAdminCount()
+	resultVar0, resultVar1 := s.UserStore.GetProfilesInChannel(channelId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetProfilesInChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetSystemAdminCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesInChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) ClearAllCustomRoleAssignments() *model.AppError {
+func (s *TimerLayerUserStore) GetProfilesInChannelByStatus(channelId string, offset int, limit int) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.ClearAllCustomRoleAssignments()
+	resultVar0, resultVar1 := s.UserStore.GetProfilesInChannelByStatus(channelId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetProfilesInChannelByStatus")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ClearAllCustomRoleAssignments", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesInChannelByStatus", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) ClearCaches() {
+func (s *TimerLayerUserStore) GetProfilesNotInChannel(teamId string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	s.UserStore.ClearCaches()
+	resultVar0, resultVar1 := s.UserStore.GetProfilesNotInChannel(teamId, channelId, groupConstrained, offset, limit, viewRestrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetProfilesNotInChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesNotInChannel", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) Count(options model.UserCountOptions) (int64, *model.AppError) {
+func (s *TimerLayerUserStore) GetProfilesNotInTeam(teamId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.Count(options)
+	resultVar0, resultVar1 := s.UserStore.GetProfilesNotInTeam(teamId, groupConstrained, offset, limit, viewRestrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetProfilesNotInTeam")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Count", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesNotInTeam", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) DemoteUserToGuest(userID string) *model.AppError {
+func (s *TimerLayerUserStore) GetProfilesWithoutTeam(offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.DemoteUserToGuest(userID)
+	resultVar0, resultVar1 := s.UserStore.GetProfilesWithoutTeam(offset, limit, viewRestrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetProfilesWithoutTeam")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.DemoteUserToGuest", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesWithoutTeam", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) Get(id string) (*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) GetRecentlyActiveUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.Get(id)
+	resultVar0, resultVar1 := s.UserStore.GetRecentlyActiveUsersForTeam(teamId, offset, limit, viewRestrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetRecentlyActiveUsersForTeam")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetRecentlyActiveUsersForTeam", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetAll() ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAll()
+	resultVar0, resultVar1 := s.UserStore.GetSystemAdminProfiles()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetSystemAdminProfiles")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetSystemAdminProfiles", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAllAfter(limit, afterId)
+	resultVar0, resultVar1 := s.UserStore.GetTeamGroupUsers(teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetTeamGroupUsers")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetTeamGroupUsers", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) GetUnreadCount(userId string) (int64, error) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAllProfiles(options)
+	resultVar0, resultVar1 := s.UserStore.GetUnreadCount(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllProfiles", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUnreadCount", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetAllProfilesInChannel(channelId string, allowFromCache bool) (map[string]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) GetUnreadCountForChannel(userId string, channelId string) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAllProfilesInChannel(channelId, allowFromCache)
+	resultVar0, resultVar1 := s.UserStore.GetUnreadCountForChannel(userId, channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetUnreadCountForChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllProfilesInChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUnreadCountForChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetAllUsingAuthService(authService string) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAllUsingAuthService(authService)
+	resultVar0, resultVar1 := s.UserStore.GetUsersBatchForIndexing(startTime, endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.GetUsersBatchForIndexing")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllUsingAuthService", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUsersBatchForIndexing", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetAnyUnreadPostCountForChannel(userId string, channelId string) (int64, *model.AppError) {
+func (s *TimerLayerUserStore) InferSystemInstallDate() (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAnyUnreadPostCountForChannel(userId, channelId)
+	resultVar0, resultVar1 := s.UserStore.InferSystemInstallDate()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.InferSystemInstallDate")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAnyUnreadPostCountForChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InferSystemInstallDate", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) InvalidatProfileCacheForUser(userId string) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetByAuth(authData, authService)
+	s.UserStore.InvalidatProfileCacheForUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByAuth", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidatProfileCacheForUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return
 }
 
-func (s *TimerLayerUserStore) GetByEmail(email string) (*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) InvalidateProfilesInChannelCache(channelId string) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetByEmail(email)
+	s.UserStore.InvalidateProfilesInChannelCache(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByEmail", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidateProfilesInChannelCache", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return
 }
 
-func (s *TimerLayerUserStore) GetByUsername(username string) (*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) InvalidateProfilesInChannelCacheByUser(userId string) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetByUsername(username)
+	s.UserStore.InvalidateProfilesInChannelCacheByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByUsername", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidateProfilesInChannelCacheByUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return
 }
 
-func (s *TimerLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) PermanentDelete(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetChannelGroupUsers(channelID)
+	resultVar0 := s.UserStore.PermanentDelete(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.PermanentDelete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetChannelGroupUsers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.PermanentDelete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) GetEtagForAllProfiles() string {
+func (s *TimerLayerUserStore) PromoteGuestToUser(userID string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.GetEtagForAllProfiles()
+	resultVar0 := s.UserStore.PromoteGuestToUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.PromoteGuestToUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForAllProfiles", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.PromoteGuestToUser", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerUserStore) GetEtagForProfiles(teamId string) string {
+func (s *TimerLayerUserStore) ResetLastPictureUpdate(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.GetEtagForProfiles(teamId)
+	resultVar0 := s.UserStore.ResetLastPictureUpdate(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.ResetLastPictureUpdate")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForProfiles", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ResetLastPictureUpdate", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerUserStore) GetEtagForProfilesNotInTeam(teamId string) string {
+func (s *TimerLayerUserStore) Save(user *model.User) (*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.GetEtagForProfilesNotInTeam(teamId)
+	resultVar0, resultVar1 := s.UserStore.Save(user)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForProfilesNotInTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Save", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetForLogin(loginId string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) Search(ctx context.Context, teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetForLogin(loginId, allowSignInWithUsername, allowSignInWithEmail)
+	resultVar0, resultVar1 := s.UserStore.Search(ctx, teamId, term, options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.Search")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetForLogin", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Search", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetNewUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) SearchInChannel(ctx context.Context, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetNewUsersForTeam(teamId, offset, limit, viewRestrictions)
+	resultVar0, resultVar1 := s.UserStore.SearchInChannel(ctx, channelId, term, options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.SearchInChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetNewUsersForTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchInChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetProfileByGroupChannelIdsForUser(userId string, channelIds []string) (map[string][]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) SearchNotInChannel(ctx context.Context, teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfileByGroupChannelIdsForUser(userId, channelIds)
+	resultVar0, resultVar1 := s.UserStore.SearchNotInChannel(ctx, teamId, channelId, term, options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.SearchNotInChannel")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfileByGroupChannelIdsForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchNotInChannel", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetProfileByIds(userIds []string, options *UserGetByIdsOpts, allowFromCache bool) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) SearchNotInTeam(ctx context.Context, notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfileByIds(userIds, options, allowFromCache)
+	resultVar0, resultVar1 := s.UserStore.SearchNotInTeam(ctx, notInTeamId, term, options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.SearchNotInTeam")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfileByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchNotInTeam", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) SearchWithoutTeam(ctx context.Context, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfiles(options)
+	resultVar0, resultVar1 := s.UserStore.SearchWithoutTeam(ctx, term, options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.SearchWithoutTeam")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfiles", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchWithoutTeam", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
+	resultVar0, resultVar1 := s.UserStore.Update(user, allowRoleUpdate)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesByUsernames", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetProfilesInChannel(channelId string, offset int, limit int) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesInChannel(channelId, offset, limit)
+	resultVar0, resultVar1 := s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.UpdateAuthData")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesInChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateAuthData", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetProfilesInChannelByStatus(channelId string, offset int, limit int) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesInChannelByStatus(channelId, offset, limit)
+	resultVar0 := s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.UpdateFailedPasswordAttempts")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesInChannelByStatus", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateFailedPasswordAttempts", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) GetProfilesNotInChannel(teamId string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) UpdateLastPictureUpdate(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesNotInChannel(teamId, channelId, groupConstrained, offset, limit, viewRestrictions)
+	resultVar0 := s.UserStore.UpdateLastPictureUpdate(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.UpdateLastPictureUpdate")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesNotInChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateLastPictureUpdate", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) GetProfilesNotInTeam(teamId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) UpdateMfaActive(userId string, active bool) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesNotInTeam(teamId, groupConstrained, offset, limit, viewRestrictions)
+	resultVar0 := s.UserStore.UpdateMfaActive(userId, active)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.UpdateMfaActive")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesNotInTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateMfaActive", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) GetProfilesWithoutTeam(offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) UpdateMfaSecret(userId string, secret string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesWithoutTeam(offset, limit, viewRestrictions)
+	resultVar0 := s.UserStore.UpdateMfaSecret(userId, secret)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.UpdateMfaSecret")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesWithoutTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateMfaSecret", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) GetRecentlyActiveUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) UpdatePassword(userId string, newPassword string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetRecentlyActiveUsersForTeam(teamId, offset, limit, viewRestrictions)
+	resultVar0 := s.UserStore.UpdatePassword(userId, newPassword)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.UpdatePassword")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetRecentlyActiveUsersForTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdatePassword", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) UpdateUpdateAt(userId string) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetSystemAdminProfiles()
+	resultVar0, resultVar1 := s.UserStore.UpdateUpdateAt(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.UpdateUpdateAt")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetSystemAdminProfiles", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateUpdateAt", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserStore) VerifyEmail(userId string, email string) (string, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetTeamGroupUsers(teamID)
+	resultVar0, resultVar1 := s.UserStore.VerifyEmail(userId, email)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserStore.VerifyEmail")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetTeamGroupUsers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.VerifyEmail", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetUnreadCount(userId string) (int64, error) {
+func (s *TimerLayerUserAccessTokenStore) Delete(tokenId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetUnreadCount(userId)
+	resultVar0 := s.UserAccessTokenStore.Delete(tokenId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUnreadCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Delete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) GetUnreadCountForChannel(userId string, channelId string) (int64, *model.AppError) {
+func (s *TimerLayerUserAccessTokenStore) DeleteAllForUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetUnreadCountForChannel(userId, channelId)
+	resultVar0 := s.UserAccessTokenStore.DeleteAllForUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.DeleteAllForUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUnreadCountForChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.DeleteAllForUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, *model.AppError) {
+func (s *TimerLayerUserAccessTokenStore) Get(tokenId string) (*model.UserAccessToken, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetUsersBatchForIndexing(startTime, endTime, limit)
+	resultVar0, resultVar1 := s.UserAccessTokenStore.Get(tokenId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUsersBatchForIndexing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) InferSystemInstallDate() (int64, *model.AppError) {
+func (s *TimerLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.InferSystemInstallDate()
+	resultVar0, resultVar1 := s.UserAccessTokenStore.GetAll(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InferSystemInstallDate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) InvalidatProfileCacheForUser(userId string) {
+func (s *TimerLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, *model.AppError) {
 	start := timemodule.Now()
 
-	s.UserStore.InvalidatProfileCacheForUser(userId)
+	resultVar0, resultVar1 := s.UserAccessTokenStore.GetByToken(tokenString)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.GetByToken")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidatProfileCacheForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetByToken", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) InvalidateProfilesInChannelCache(channelId string) {
+func (s *TimerLayerUserAccessTokenStore) GetByUser(userId string, page int, perPage int) ([]*model.UserAccessToken, *model.AppError) {
 	start := timemodule.Now()
 
-	s.UserStore.InvalidateProfilesInChannelCache(channelId)
+	resultVar0, resultVar1 := s.UserAccessTokenStore.GetByUser(userId, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.GetByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidateProfilesInChannelCache", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetByUser", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) InvalidateProfilesInChannelCacheByUser(userId string) {
+func (s *TimerLayerUserAccessTokenStore) GetExpiring(from int64, to int64, offset int, limit int) ([]*model.UserAccessToken, *model.AppError) {
 	start := timemodule.Now()
 
-	s.UserStore.InvalidateProfilesInChannelCacheByUser(userId)
+	resultVar0, resultVar1 := s.UserAccessTokenStore.GetExpiring(from, to, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.GetExpiring")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidateProfilesInChannelCacheByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetExpiring", success, elapsed)
 	}
-	return
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) PermanentDelete(userId string) *model.AppError {
+func (s *TimerLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.PermanentDelete(userId)
+	resultVar0, resultVar1 := s.UserAccessTokenStore.Save(token)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.PermanentDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Save", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) PromoteGuestToUser(userID string) *model.AppError {
+func (s *TimerLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.PromoteGuestToUser(userID)
+	resultVar0, resultVar1 := s.UserAccessTokenStore.Search(term)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.Search")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.PromoteGuestToUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Search", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) ResetLastPictureUpdate(userId string) *model.AppError {
+func (s *TimerLayerUserAccessTokenStore) UpdateLastUsedAt(tokenId string, lastUsedAt int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.ResetLastPictureUpdate(userId)
+	resultVar0 := s.UserAccessTokenStore.UpdateLastUsedAt(tokenId, lastUsedAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.UpdateLastUsedAt")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ResetLastPictureUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateLastUsedAt", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerUserStore) Save(user *model.User) (*model.User, *model.AppError) {
+func (s *TimerLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.Save(user)
+	resultVar0 := s.UserAccessTokenStore.UpdateTokenDisable(tokenId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.UpdateTokenDisable")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateTokenDisable", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) Search(teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.Search(teamId, term, options)
+	resultVar0 := s.UserAccessTokenStore.UpdateTokenEnable(tokenId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAccessTokenStore.UpdateTokenEnable")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Search", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateTokenEnable", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) SearchInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserAttributeStore) DeleteField(id string, time int64) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.SearchInChannel(channelId, term, options)
+	resultVar0 := s.UserAttributeStore.DeleteField(id, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAttributeStore.DeleteField")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchInChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAttributeStore.DeleteField", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) SearchNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserAttributeStore) DeleteValue(userId string, fieldId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.SearchNotInChannel(teamId, channelId, term, options)
+	resultVar0 := s.UserAttributeStore.DeleteValue(userId, fieldId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAttributeStore.DeleteValue")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchNotInChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAttributeStore.DeleteValue", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) SearchNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserAttributeStore) GetField(id string) (*model.UserAttributeField, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.SearchNotInTeam(notInTeamId, term, options)
+	resultVar0, resultVar1 := s.UserAttributeStore.GetField(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAttributeStore.GetField")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchNotInTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAttributeStore.GetField", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+func (s *TimerLayerUserAttributeStore) GetFields() ([]*model.UserAttributeField, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.SearchWithoutTeam(term, options)
+	resultVar0, resultVar1 := s.UserAttributeStore.GetFields()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAttributeStore.GetFields")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchWithoutTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAttributeStore.GetFields", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, *model.AppError) {
+func (s *TimerLayerUserAttributeStore) GetValuesForUsers(userIds []string) ([]*model.UserAttributeValue, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.Update(user, allowRoleUpdate)
+	resultVar0, resultVar1 := s.UserAttributeStore.GetValuesForUsers(userIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAttributeStore.GetValuesForUsers")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAttributeStore.GetValuesForUsers", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, *model.AppError) {
+func (s *TimerLayerUserAttributeStore) PermanentDeleteValuesByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
+	resultVar0 := s.UserAttributeStore.PermanentDeleteValuesByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAttributeStore.PermanentDeleteValuesByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateAuthData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAttributeStore.PermanentDeleteValuesByUser", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) *model.AppError {
+func (s *TimerLayerUserAttributeStore) SaveField(field *model.UserAttributeField) (*model.UserAttributeField, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
+	resultVar0, resultVar1 := s.UserAttributeStore.SaveField(field)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAttributeStore.SaveField")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateFailedPasswordAttempts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAttributeStore.SaveField", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) UpdateLastPictureUpdate(userId string) *model.AppError {
+func (s *TimerLayerUserAttributeStore) SaveValue(value *model.UserAttributeValue) (*model.UserAttributeValue, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.UpdateLastPictureUpdate(userId)
+	resultVar0, resultVar1 := s.UserAttributeStore.SaveValue(value)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAttributeStore.SaveValue")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateLastPictureUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAttributeStore.SaveValue", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) UpdateMfaActive(userId string, active bool) *model.AppError {
+func (s *TimerLayerUserAttributeStore) UpdateField(field *model.UserAttributeField) (*model.UserAttributeField, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.UpdateMfaActive(userId, active)
+	resultVar0, resultVar1 := s.UserAttributeStore.UpdateField(field)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAttributeStore.UpdateField")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateMfaActive", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAttributeStore.UpdateField", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) UpdateMfaSecret(userId string, secret string) *model.AppError {
+func (s *TimerLayerUserAvailabilityStore) Delete(id string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.UpdateMfaSecret(userId, secret)
+	resultVar0 := s.UserAvailabilityStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAvailabilityStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateMfaSecret", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAvailabilityStore.Delete", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerUserStore) UpdatePassword(userId string, newPassword string) *model.AppError {
+func (s *TimerLayerUserAvailabilityStore) Get(id string) (*model.UserAvailability, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.UpdatePassword(userId, newPassword)
+	resultVar0, resultVar1 := s.UserAvailabilityStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAvailabilityStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdatePassword", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAvailabilityStore.Get", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) UpdateUpdateAt(userId string) (int64, *model.AppError) {
+func (s *TimerLayerUserAvailabilityStore) GetForUser(userId string, startAt int64, endAt int64) ([]*model.UserAvailability, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.UpdateUpdateAt(userId)
+	resultVar0, resultVar1 := s.UserAvailabilityStore.GetForUser(userId, startAt, endAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAvailabilityStore.GetForUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateUpdateAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAvailabilityStore.GetForUser", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) VerifyEmail(userId string, email string) (string, *model.AppError) {
+func (s *TimerLayerUserAvailabilityStore) GetForUsersAt(userIds []string, at int64) ([]*model.UserAvailability, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.VerifyEmail(userId, email)
+	resultVar0, resultVar1 := s.UserAvailabilityStore.GetForUsersAt(userIds, at)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAvailabilityStore.GetForUsersAt")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.VerifyEmail", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAvailabilityStore.GetForUsersAt", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserAccessTokenStore) Delete(tokenId string) *model.AppError {
+func (s *TimerLayerUserAvailabilityStore) PermanentDeleteByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserAccessTokenStore.Delete(tokenId)
+	resultVar0 := s.UserAvailabilityStore.PermanentDeleteByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAvailabilityStore.PermanentDeleteByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAvailabilityStore.PermanentDeleteByUser", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerUserAccessTokenStore) DeleteAllForUser(userId string) *model.AppError {
+func (s *TimerLayerUserAvailabilityStore) Save(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserAccessTokenStore.DeleteAllForUser(userId)
+	resultVar0, resultVar1 := s.UserAvailabilityStore.Save(availability)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAvailabilityStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.DeleteAllForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAvailabilityStore.Save", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserAccessTokenStore) Get(tokenId string) (*model.UserAccessToken, *model.AppError) {
+func (s *TimerLayerUserAvailabilityStore) Update(availability *model.UserAvailability) (*model.UserAvailability, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.Get(tokenId)
+	resultVar0, resultVar1 := s.UserAvailabilityStore.Update(availability)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserAvailabilityStore.Update")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAvailabilityStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, *model.AppError) {
+func (s *TimerLayerUserDeactivationScheduleStore) Delete(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.GetAll(offset, limit)
+	resultVar0 := s.UserDeactivationScheduleStore.Delete(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserDeactivationScheduleStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserDeactivationScheduleStore.Delete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, *model.AppError) {
+func (s *TimerLayerUserDeactivationScheduleStore) Get(userId string) (*model.UserDeactivationSchedule, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.GetByToken(tokenString)
+	resultVar0, resultVar1 := s.UserDeactivationScheduleStore.Get(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserDeactivationScheduleStore.Get")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetByToken", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserDeactivationScheduleStore.Get", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserAccessTokenStore) GetByUser(userId string, page int, perPage int) ([]*model.UserAccessToken, *model.AppError) {
+func (s *TimerLayerUserDeactivationScheduleStore) GetAll(offset int, limit int) ([]*model.UserDeactivationSchedule, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.GetByUser(userId, page, perPage)
+	resultVar0, resultVar1 := s.UserDeactivationScheduleStore.GetAll(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserDeactivationScheduleStore.GetAll")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserDeactivationScheduleStore.GetAll", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
+func (s *TimerLayerUserDeactivationScheduleStore) GetDue(before int64, limit int) ([]*model.UserDeactivationSchedule, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.Save(token)
+	resultVar0, resultVar1 := s.UserDeactivationScheduleStore.GetDue(before, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserDeactivationScheduleStore.GetDue")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserDeactivationScheduleStore.GetDue", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, *model.AppError) {
+func (s *TimerLayerUserDeactivationScheduleStore) Save(schedule *model.UserDeactivationSchedule) (*model.UserDeactivationSchedule, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.Search(term)
+	resultVar0, resultVar1 := s.UserDeactivationScheduleStore.Save(schedule)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserDeactivationScheduleStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Search", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserDeactivationScheduleStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) *model.AppError {
+func (s *TimerLayerUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserAccessTokenStore.UpdateTokenDisable(tokenId)
+	resultVar0 := s.UserTermsOfServiceStore.Delete(userId, termsOfServiceId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserTermsOfServiceStore.Delete")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateTokenDisable", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.Delete", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) *model.AppError {
+func (s *TimerLayerUserTermsOfServiceStore) GetAcceptances(termsOfServiceId string, since int64, offset int, limit int) ([]*model.TermsOfServiceAcceptance, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserAccessTokenStore.UpdateTokenEnable(tokenId)
+	resultVar0, resultVar1 := s.UserTermsOfServiceStore.GetAcceptances(termsOfServiceId, since, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserTermsOfServiceStore.GetAcceptances")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateTokenEnable", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.GetAcceptances", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) *model.AppError {
+func (s *TimerLayerUserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOfService, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0 := s.UserTermsOfServiceStore.Delete(userId, termsOfServiceId)
+	resultVar0, resultVar1 := s.UserTermsOfServiceStore.GetByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserTermsOfServiceStore.GetByUser")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.GetByUser", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOfService, *model.AppError) {
+func (s *TimerLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserTermsOfServiceStore.GetByUser(userId)
+	resultVar0, resultVar1 := s.UserTermsOfServiceStore.Save(userTermsOfService)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserTermsOfServiceStore.Save")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.GetByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, *model.AppError) {
+func (s *TimerLayerUserTermsOfServiceStore) SaveAcceptance(acceptance *model.TermsOfServiceAcceptance) (*model.TermsOfServiceAcceptance, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserTermsOfServiceStore.Save(userTermsOfService)
+	resultVar0, resultVar1 := s.UserTermsOfServiceStore.SaveAcceptance(acceptance)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("UserTermsOfServiceStore.SaveAcceptance")
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.SaveAcceptance", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
@@ -7122,6 +12649,8 @@ func (s *TimerLayerWebhookStore) AnalyticsIncomingCount(teamId string) (int64, *
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.AnalyticsIncomingCount")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.AnalyticsIncomingCount", success, elapsed)
 	}
@@ -7138,6 +12667,8 @@ func (s *TimerLayerWebhookStore) AnalyticsOutgoingCount(teamId string) (int64, *
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.AnalyticsOutgoingCount")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.AnalyticsOutgoingCount", success, elapsed)
 	}
@@ -7170,6 +12701,8 @@ func (s *TimerLayerWebhookStore) DeleteIncoming(webhookId string, time int64) *m
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.DeleteIncoming")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.DeleteIncoming", success, elapsed)
 	}
@@ -7186,6 +12719,8 @@ func (s *TimerLayerWebhookStore) DeleteOutgoing(webhookId string, time int64) *m
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.DeleteOutgoing")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.DeleteOutgoing", success, elapsed)
 	}
@@ -7202,6 +12737,8 @@ func (s *TimerLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*m
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetIncoming")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncoming", success, elapsed)
 	}
@@ -7218,6 +12755,8 @@ func (s *TimerLayerWebhookStore) GetIncomingByChannel(channelId string) ([]*mode
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetIncomingByChannel")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingByChannel", success, elapsed)
 	}
@@ -7234,6 +12773,8 @@ func (s *TimerLayerWebhookStore) GetIncomingByTeam(teamId string, offset int, li
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetIncomingByTeam")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingByTeam", success, elapsed)
 	}
@@ -7250,6 +12791,8 @@ func (s *TimerLayerWebhookStore) GetIncomingByTeamByUser(teamId string, userId s
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetIncomingByTeamByUser")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingByTeamByUser", success, elapsed)
 	}
@@ -7266,6 +12809,8 @@ func (s *TimerLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*mode
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetIncomingList")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingList", success, elapsed)
 	}
@@ -7282,6 +12827,8 @@ func (s *TimerLayerWebhookStore) GetIncomingListByUser(userId string, offset int
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetIncomingListByUser")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingListByUser", success, elapsed)
 	}
@@ -7298,6 +12845,8 @@ func (s *TimerLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook,
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetOutgoing")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoing", success, elapsed)
 	}
@@ -7314,6 +12863,8 @@ func (s *TimerLayerWebhookStore) GetOutgoingByChannel(channelId string, offset i
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetOutgoingByChannel")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByChannel", success, elapsed)
 	}
@@ -7330,6 +12881,8 @@ func (s *TimerLayerWebhookStore) GetOutgoingByChannelByUser(channelId string, us
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetOutgoingByChannelByUser")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByChannelByUser", success, elapsed)
 	}
@@ -7346,6 +12899,8 @@ func (s *TimerLayerWebhookStore) GetOutgoingByTeam(teamId string, offset int, li
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetOutgoingByTeam")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByTeam", success, elapsed)
 	}
@@ -7362,12 +12917,32 @@ func (s *TimerLayerWebhookStore) GetOutgoingByTeamByUser(teamId string, userId s
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetOutgoingByTeamByUser")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByTeamByUser", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerWebhookStore) GetOutgoingForChannel(teamId string, channelId string) ([]*model.OutgoingWebhook, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.WebhookStore.GetOutgoingForChannel(teamId, channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetOutgoingForChannel")
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingForChannel", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
 	start := timemodule.Now()
 
@@ -7378,6 +12953,8 @@ func (s *TimerLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*mode
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetOutgoingList")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingList", success, elapsed)
 	}
@@ -7394,6 +12971,8 @@ func (s *TimerLayerWebhookStore) GetOutgoingListByUser(userId string, offset int
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.GetOutgoingListByUser")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingListByUser", success, elapsed)
 	}
@@ -7426,6 +13005,8 @@ func (s *TimerLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId stri
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.PermanentDeleteIncomingByChannel")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteIncomingByChannel", success, elapsed)
 	}
@@ -7442,6 +13023,8 @@ func (s *TimerLayerWebhookStore) PermanentDeleteIncomingByUser(userId string) *m
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.PermanentDeleteIncomingByUser")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteIncomingByUser", success, elapsed)
 	}
@@ -7458,6 +13041,8 @@ func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelId stri
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.PermanentDeleteOutgoingByChannel")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteOutgoingByChannel", success, elapsed)
 	}
@@ -7474,6 +13059,8 @@ func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByUser(userId string) *m
 		success := "false"
 		if resultVar0 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.PermanentDeleteOutgoingByUser")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteOutgoingByUser", success, elapsed)
 	}
@@ -7490,6 +13077,8 @@ func (s *TimerLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.SaveIncoming")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.SaveIncoming", success, elapsed)
 	}
@@ -7506,6 +13095,8 @@ func (s *TimerLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.SaveOutgoing")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.SaveOutgoing", success, elapsed)
 	}
@@ -7522,6 +13113,8 @@ func (s *TimerLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook)
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.UpdateIncoming")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.UpdateIncoming", success, elapsed)
 	}
@@ -7538,6 +13131,8 @@ func (s *TimerLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 		success := "false"
 		if resultVar1 == nil {
 			success = "true"
+		} else {
+			s.Root.Metrics.IncrementStoreMethodError("WebhookStore.UpdateOutgoing")
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.UpdateOutgoing", success, elapsed)
 	}
@@ -7586,35 +13181,70 @@ func NewTimerLayer(childStore Store, metrics einterfaces.MetricsInterface) *Time
 		Metrics: metrics,
 	}
 
+	newStore.AnnouncementStore = &TimerLayerAnnouncementStore{AnnouncementStore: childStore.Announcement(), Root: &newStore}
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.CallStore = &TimerLayerCallStore{CallStore: childStore.Call(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelMemberExpiryStore = &TimerLayerChannelMemberExpiryStore{ChannelMemberExpiryStore: childStore.ChannelMemberExpiry(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &TimerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ChannelMembershipRuleStore = &TimerLayerChannelMembershipRuleStore{ChannelMembershipRuleStore: childStore.ChannelMembershipRule(), Root: &newStore}
+	newStore.ChannelReadStatStore = &TimerLayerChannelReadStatStore{ChannelReadStatStore: childStore.ChannelReadStat(), Root: &newStore}
+	newStore.ChannelTeamBindingStore = &TimerLayerChannelTeamBindingStore{ChannelTeamBindingStore: childStore.ChannelTeamBinding(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &TimerLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CommandStore = &TimerLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
 	newStore.CommandWebhookStore = &TimerLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
 	newStore.ComplianceStore = &TimerLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.ConfigAuditStore = &TimerLayerConfigAuditStore{ConfigAuditStore: childStore.ConfigAudit(), Root: &newStore}
+	newStore.ContentFilterStore = &TimerLayerContentFilterStore{ContentFilterStore: childStore.ContentFilter(), Root: &newStore}
+	newStore.DailyStatStore = &TimerLayerDailyStatStore{DailyStatStore: childStore.DailyStat(), Root: &newStore}
 	newStore.EmojiStore = &TimerLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.EmojiAliasStore = &TimerLayerEmojiAliasStore{EmojiAliasStore: childStore.EmojiAlias(), Root: &newStore}
+	newStore.EphemeralPostStore = &TimerLayerEphemeralPostStore{EphemeralPostStore: childStore.EphemeralPost(), Root: &newStore}
 	newStore.FileInfoStore = &TimerLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &TimerLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.HashtagStore = &TimerLayerHashtagStore{HashtagStore: childStore.Hashtag(), Root: &newStore}
+	newStore.IntegrationStatStore = &TimerLayerIntegrationStatStore{IntegrationStatStore: childStore.IntegrationStat(), Root: &newStore}
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.KeywordRuleStore = &TimerLayerKeywordRuleStore{KeywordRuleStore: childStore.KeywordRule(), Root: &newStore}
 	newStore.LicenseStore = &TimerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
+	newStore.LicenseUsageStore = &TimerLayerLicenseUsageStore{LicenseUsageStore: childStore.LicenseUsage(), Root: &newStore}
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.LoginAttemptStore = &TimerLayerLoginAttemptStore{LoginAttemptStore: childStore.LoginAttempt(), Root: &newStore}
+	newStore.MentionAliasStore = &TimerLayerMentionAliasStore{MentionAliasStore: childStore.MentionAlias(), Root: &newStore}
+	newStore.MessageExportConsumerStore = &TimerLayerMessageExportConsumerStore{MessageExportConsumerStore: childStore.MessageExportConsumer(), Root: &newStore}
+	newStore.MfaRecoveryCodeStore = &TimerLayerMfaRecoveryCodeStore{MfaRecoveryCodeStore: childStore.MfaRecoveryCode(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OnboardingStore = &TimerLayerOnboardingStore{OnboardingStore: childStore.Onboarding(), Root: &newStore}
+	newStore.PendingEmojiStore = &TimerLayerPendingEmojiStore{PendingEmojiStore: childStore.PendingEmoji(), Root: &newStore}
+	newStore.PendingPostStore = &TimerLayerPendingPostStore{PendingPostStore: childStore.PendingPost(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PollStore = &TimerLayerPollStore{PollStore: childStore.Poll(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostPinEventStore = &TimerLayerPostPinEventStore{PostPinEventStore: childStore.PostPinEvent(), Root: &newStore}
+	newStore.PostPurgeStore = &TimerLayerPostPurgeStore{PostPurgeStore: childStore.PostPurge(), Root: &newStore}
+	newStore.PostReportStore = &TimerLayerPostReportStore{PostReportStore: childStore.PostReport(), Root: &newStore}
+	newStore.PostStarStore = &TimerLayerPostStarStore{PostStarStore: childStore.PostStar(), Root: &newStore}
 	newStore.PreferenceStore = &TimerLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.PublicPostLinkStore = &TimerLayerPublicPostLinkStore{PublicPostLinkStore: childStore.PublicPostLink(), Root: &newStore}
 	newStore.ReactionStore = &TimerLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.RecurringPostStore = &TimerLayerRecurringPostStore{RecurringPostStore: childStore.RecurringPost(), Root: &newStore}
+	newStore.ReminderStore = &TimerLayerReminderStore{ReminderStore: childStore.Reminder(), Root: &newStore}
 	newStore.RoleStore = &TimerLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SchemaMigrationStore = &TimerLayerSchemaMigrationStore{SchemaMigrationStore: childStore.SchemaMigration(), Root: &newStore}
 	newStore.SchemeStore = &TimerLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &TimerLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.StatusStore = &TimerLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
 	newStore.SystemStore = &TimerLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
 	newStore.TeamStore = &TimerLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
 	newStore.TermsOfServiceStore = &TimerLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
+	newStore.TodoStore = &TimerLayerTodoStore{TodoStore: childStore.Todo(), Root: &newStore}
 	newStore.TokenStore = &TimerLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
 	newStore.UserStore = &TimerLayerUserStore{UserStore: childStore.User(), Root: &newStore}
 	newStore.UserAccessTokenStore = &TimerLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserAttributeStore = &TimerLayerUserAttributeStore{UserAttributeStore: childStore.UserAttribute(), Root: &newStore}
+	newStore.UserAvailabilityStore = &TimerLayerUserAvailabilityStore{UserAvailabilityStore: childStore.UserAvailability(), Root: &newStore}
+	newStore.UserDeactivationScheduleStore = &TimerLayerUserDeactivationScheduleStore{UserDeactivationScheduleStore: childStore.UserDeactivationSchedule(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &TimerLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
 	newStore.WebhookStore = &TimerLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
 	return &newStore
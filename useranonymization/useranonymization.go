@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package useranonymization
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type UserAnonymizationJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsUserAnonymizationJobInterface(func(a *app.App) tjobs.UserAnonymizationJobInterface {
+		return &UserAnonymizationJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package userdeactivation
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type UserDeactivationJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsUserDeactivationJobInterface(func(a *app.App) tjobs.UserDeactivationJobInterface {
+		return &UserDeactivationJobInterfaceImpl{a}
+	})
+}
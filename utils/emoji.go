@@ -5,6 +5,8 @@ package utils
 
 import (
 	"bytes"
+	"encoding/binary"
+	"hash/crc32"
 	"image"
 	"image/color"
 	"image/gif"
@@ -60,3 +62,32 @@ func CreateTestPng(t *testing.T, width int, height int) []byte {
 
 	return buffer.Bytes()
 }
+
+// CreateTestAnimatedPng returns a PNG with an animation control chunk claiming the given number of frames, which is
+// what marks a PNG as animated. Only the default image is actually stored.
+func CreateTestAnimatedPng(t *testing.T, width int, height int, frames int) []byte {
+	data := CreateTestPng(t, width, height)
+
+	// The animation control chunk has to come before the image data, so it goes right after the 8 byte signature and
+	// the 25 byte header chunk
+	const headerEnd = 8 + 25
+
+	chunkData := make([]byte, 8)
+	binary.BigEndian.PutUint32(chunkData[0:4], uint32(frames))
+	binary.BigEndian.PutUint32(chunkData[4:8], 0)
+
+	chunk := make([]byte, 0, 12+len(chunkData))
+	chunk = append(chunk, 0, 0, 0, byte(len(chunkData)))
+	chunk = append(chunk, "acTL"...)
+	chunk = append(chunk, chunkData...)
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(chunk[4:]))
+	chunk = append(chunk, crc...)
+
+	animated := make([]byte, 0, len(data)+len(chunk))
+	animated = append(animated, data[:headerEnd]...)
+	animated = append(animated, chunk...)
+	animated = append(animated, data[headerEnd:]...)
+
+	return animated
+}
@@ -21,6 +21,11 @@ const (
 type fileLocationFunc func(string) string
 
 func MloggerConfigFromLoggerConfig(s *model.LogSettings, getFileFunc fileLocationFunc) *mlog.LoggerConfiguration {
+	subsystemLevels := make(map[string]string, len(s.SubsystemLevels))
+	for subsystem, level := range s.SubsystemLevels {
+		subsystemLevels[subsystem] = strings.ToLower(level)
+	}
+
 	return &mlog.LoggerConfiguration{
 		EnableConsole: *s.EnableConsole,
 		ConsoleJson:   *s.ConsoleJson,
@@ -29,6 +34,23 @@ func MloggerConfigFromLoggerConfig(s *model.LogSettings, getFileFunc fileLocatio
 		FileJson:      *s.FileJson,
 		FileLevel:     strings.ToLower(*s.FileLevel),
 		FileLocation:  getFileFunc(*s.FileLocation),
+
+		SubsystemLevels:    subsystemLevels,
+		EnableSampling:     *s.EnableSampling,
+		SamplingInitial:    *s.SamplingInitial,
+		SamplingThereafter: *s.SamplingThereafter,
+
+		EnableSyslog:  *s.EnableSyslog,
+		SyslogLevel:   strings.ToLower(*s.SyslogLevel),
+		SyslogNetwork: *s.SyslogNetwork,
+		SyslogAddress: *s.SyslogAddress,
+		SyslogTag:     *s.SyslogTag,
+
+		EnableTcp:                    *s.EnableTcp,
+		TcpLevel:                     strings.ToLower(*s.TcpLevel),
+		TcpAddress:                   *s.TcpAddress,
+		TcpUseTLS:                    *s.TcpUseTLS,
+		TcpSkipCertificateValidation: *s.TcpSkipCertificateValidation,
 	}
 }
 
@@ -49,7 +71,7 @@ func GetNotificationsLogFileLocation(fileLocation string) string {
 }
 
 func GetLogSettingsFromNotificationsLogSettings(notificationLogSettings *model.NotificationLogSettings) *model.LogSettings {
-	return &model.LogSettings{
+	settings := &model.LogSettings{
 		ConsoleJson:   notificationLogSettings.ConsoleJson,
 		ConsoleLevel:  notificationLogSettings.ConsoleLevel,
 		EnableConsole: notificationLogSettings.EnableConsole,
@@ -58,6 +80,9 @@ func GetLogSettingsFromNotificationsLogSettings(notificationLogSettings *model.N
 		FileLevel:     notificationLogSettings.FileLevel,
 		FileLocation:  notificationLogSettings.FileLocation,
 	}
+	settings.SetDefaults()
+
+	return settings
 }
 
 // DON'T USE THIS Modify the level on the app logger
@@ -21,13 +21,14 @@ type Context struct {
 	Params        *Params
 	Err           *model.AppError
 	siteURLHeader string
+
+	auditMethod string
+	auditTarget model.StringMap
+	auditResult string
 }
 
 func (c *Context) LogAudit(extraInfo string) {
-	audit := &model.Audit{UserId: c.App.Session.UserId, IpAddress: c.App.IpAddress, Action: c.App.Path, ExtraInfo: extraInfo, SessionId: c.App.Session.Id}
-	if err := c.App.Srv.Store.Audit().Save(audit); err != nil {
-		c.LogError(err)
-	}
+	c.logAuditEvent(c.App.Session.UserId, extraInfo)
 }
 
 func (c *Context) LogAuditWithUserId(userId, extraInfo string) {
@@ -36,9 +37,45 @@ func (c *Context) LogAuditWithUserId(userId, extraInfo string) {
 		extraInfo = strings.TrimSpace(extraInfo + " session_user=" + c.App.Session.UserId)
 	}
 
-	audit := &model.Audit{UserId: userId, IpAddress: c.App.IpAddress, Action: c.App.Path, ExtraInfo: extraInfo, SessionId: c.App.Session.Id}
-	if err := c.App.Srv.Store.Audit().Save(audit); err != nil {
-		c.LogError(err)
+	c.logAuditEvent(userId, extraInfo)
+}
+
+func (c *Context) logAuditEvent(actorId, extraInfo string) {
+	if c.App.Session.IsImpersonated() {
+		extraInfo = strings.TrimSpace(extraInfo + " impersonator=" + c.App.Session.GetImpersonatorId())
+	}
+
+	event := &model.AuditEvent{
+		ActorId:   actorId,
+		SessionId: c.App.Session.Id,
+		IpAddress: c.App.IpAddress,
+		Action:    c.App.Path,
+		Method:    c.auditMethod,
+		Target:    c.auditTarget,
+		Result:    model.AuditResultFromExtraInfo(extraInfo),
+		Details:   extraInfo,
+	}
+
+	c.auditResult = event.Result
+	c.App.LogAuditEvent(event)
+}
+
+// logAuditResult records the failure of a request whose attempt was audited, since handlers only audit their
+// successes. When enabled in the AuditSettings, it also records the outcome of every API request changing data
+// that wasn't audited by its handler. Every request made with an impersonated session is recorded.
+func (c *Context) logAuditResult() {
+	logAll := *c.App.Config().AuditSettings.LogAllApiMutations && c.auditMethod != http.MethodGet && c.auditMethod != http.MethodHead && c.auditMethod != http.MethodOptions
+	logAll = logAll || c.App.Session.IsImpersonated()
+
+	if c.Err != nil {
+		if c.auditResult == model.AUDIT_RESULT_ATTEMPT || (c.auditResult == "" && logAll) {
+			c.logAuditEvent(c.App.Session.UserId, "fail - error="+c.Err.Id)
+		}
+		return
+	}
+
+	if c.auditResult == "" && logAll {
+		c.logAuditEvent(c.App.Session.UserId, "success")
 	}
 }
 
@@ -100,6 +137,22 @@ func (c *Context) SessionRequired() {
 	}
 }
 
+// OAuthScopeRequired denies OAuth apps that were only granted granular scopes access to handlers outside
+// of them.
+func (c *Context) OAuthScopeRequired(scope string) {
+	if !c.App.Session.HasOAuthScope(scope) {
+		c.Err = model.NewAppError("", "api.context.oauth_scope.app_error", nil, "scope="+scope, http.StatusForbidden)
+	}
+}
+
+// ImpersonationForbidden denies impersonated sessions access to handlers minting credentials or changing how
+// their user authenticates, since those would outlive the time-boxed impersonation.
+func (c *Context) ImpersonationForbidden() {
+	if c.App.Session.IsImpersonated() {
+		c.Err = model.NewAppError("", "api.context.impersonated_session.app_error", nil, "impersonator_id="+c.App.Session.GetImpersonatorId(), http.StatusForbidden)
+	}
+}
+
 func (c *Context) MfaRequired() {
 	// Must be licensed for MFA and have it configured for enforcement
 	if license := c.App.License(); license == nil || !*license.Features.MFA || !*c.App.Config().ServiceSettings.EnableMultifactorAuthentication || !*c.App.Config().ServiceSettings.EnforceMultifactorAuthentication {
@@ -137,6 +190,11 @@ func (c *Context) MfaRequired() {
 		}
 
 		if !user.MfaActive {
+			// Users are given some time to set up MFA once it is enforced
+			if c.App.IsInMfaGracePeriod(user) {
+				return
+			}
+
 			c.Err = model.NewAppError("", "api.context.mfa_required.app_error", nil, "MfaRequired", http.StatusForbidden)
 			return
 		}
@@ -357,6 +415,193 @@ func (c *Context) RequireEmojiId() *Context {
 	return c
 }
 
+func (c *Context) RequireMentionAliasId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.MentionAliasId) != 26 {
+		c.SetInvalidUrlParam("alias_id")
+	}
+	return c
+}
+
+func (c *Context) RequireUserAttributeFieldId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.UserAttributeFieldId) != 26 {
+		c.SetInvalidUrlParam("field_id")
+	}
+	return c
+}
+
+func (c *Context) RequirePendingPostId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.PendingPostId) != 26 {
+		c.SetInvalidUrlParam("pending_post_id")
+	}
+	return c
+}
+
+func (c *Context) RequireContentFilterId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.ContentFilterId) != 26 {
+		c.SetInvalidUrlParam("content_filter_id")
+	}
+	return c
+}
+
+func (c *Context) RequireKeywordRuleId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.KeywordRuleId) != 26 {
+		c.SetInvalidUrlParam("keyword_rule_id")
+	}
+	return c
+}
+
+func (c *Context) RequireMembershipRuleId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.MembershipRuleId) != 26 {
+		c.SetInvalidUrlParam("membership_rule_id")
+	}
+	return c
+}
+
+func (c *Context) RequireAnnouncementId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.AnnouncementId) != 26 {
+		c.SetInvalidUrlParam("announcement_id")
+	}
+	return c
+}
+
+func (c *Context) RequireOnboardingStepId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.OnboardingStepId) != 26 {
+		c.SetInvalidUrlParam("onboarding_step_id")
+	}
+	return c
+}
+
+func (c *Context) RequireRecurringPostId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.RecurringPostId) != 26 {
+		c.SetInvalidUrlParam("recurring_post_id")
+	}
+	return c
+}
+
+func (c *Context) RequireAvailabilityId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.AvailabilityId) != 26 {
+		c.SetInvalidUrlParam("availability_id")
+	}
+	return c
+}
+
+func (c *Context) RequireCallId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.CallId) != 26 {
+		c.SetInvalidUrlParam("call_id")
+	}
+	return c
+}
+
+func (c *Context) RequireExportConsumerId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.ExportConsumerId) != 26 {
+		c.SetInvalidUrlParam("consumer_id")
+	}
+	return c
+}
+
+func (c *Context) RequirePurgeId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.PurgeId) != 26 {
+		c.SetInvalidUrlParam("purge_id")
+	}
+	return c
+}
+
+func (c *Context) RequirePublicPostLinkId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.PublicPostLinkId) != 26 {
+		c.SetInvalidUrlParam("link_id")
+	}
+	return c
+}
+
+func (c *Context) RequirePendingEmojiId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.PendingEmojiId) != 26 {
+		c.SetInvalidUrlParam("pending_emoji_id")
+	}
+	return c
+}
+
+func (c *Context) RequireBroadcastId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.BroadcastId) != 26 {
+		c.SetInvalidUrlParam("broadcast_id")
+	}
+	return c
+}
+
+func (c *Context) RequireTermsOfServiceId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.TermsOfServiceId) != 26 {
+		c.SetInvalidUrlParam("terms_of_service_id")
+	}
+	return c
+}
+
 func (c *Context) RequireTeamName() *Context {
 	if c.Err != nil {
 		return c
@@ -417,6 +662,18 @@ func (c *Context) RequireService() *Context {
 	return c
 }
 
+func (c *Context) RequireHashtag() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidHashtag(c.Params.Hashtag) {
+		c.SetInvalidUrlParam("hashtag")
+	}
+
+	return c
+}
+
 func (c *Context) RequirePreferenceName() *Context {
 	if c.Err != nil {
 		return c
@@ -443,6 +700,18 @@ func (c *Context) RequireEmojiName() *Context {
 	return c
 }
 
+func (c *Context) RequireEmojiAlias() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if model.IsValidEmojiName(c.Params.EmojiAlias) != nil {
+		c.SetInvalidUrlParam("emoji_alias")
+	}
+
+	return c
+}
+
 func (c *Context) RequireHookId() *Context {
 	if c.Err != nil {
 		return c
@@ -455,6 +724,18 @@ func (c *Context) RequireHookId() *Context {
 	return c
 }
 
+func (c *Context) RequireDeliveryId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.DeliveryId) != 26 {
+		c.SetInvalidUrlParam("delivery_id")
+	}
+
+	return c
+}
+
 func (c *Context) RequireCommandId() *Context {
 	if c.Err != nil {
 		return c
@@ -477,6 +758,17 @@ func (c *Context) RequireJobId() *Context {
 	return c
 }
 
+func (c *Context) RequireConfigAuditId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.ConfigAuditId) != 26 {
+		c.SetInvalidUrlParam("audit_id")
+	}
+	return c
+}
+
 func (c *Context) RequireJobType() *Context {
 	if c.Err != nil {
 		return c
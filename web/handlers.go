@@ -6,9 +6,14 @@ package web
 import (
 	"fmt"
 	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NYTimes/gziphandler"
+	"github.com/gorilla/mux"
 
 	"github.com/mattermost/mattermost-server/app"
 	"github.com/mattermost/mattermost-server/mlog"
@@ -20,6 +25,7 @@ func (w *Web) NewHandler(h func(*Context, http.ResponseWriter, *http.Request)) h
 	return &Handler{
 		GetGlobalAppOptions: w.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         GetHandlerName(h),
 		RequireSession:      false,
 		TrustRequester:      false,
 		RequireMfa:          false,
@@ -35,6 +41,7 @@ func (w *Web) NewStaticHandler(h func(*Context, http.ResponseWriter, *http.Reque
 	return &Handler{
 		GetGlobalAppOptions: w.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         GetHandlerName(h),
 		RequireSession:      false,
 		TrustRequester:      false,
 		RequireMfa:          false,
@@ -44,13 +51,26 @@ func (w *Web) NewStaticHandler(h func(*Context, http.ResponseWriter, *http.Reque
 	}
 }
 
+// GetHandlerName returns the name of the function handling a route, which labels the metrics of the route.
+func GetHandlerName(h func(*Context, http.ResponseWriter, *http.Request)) string {
+	handlerName := runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+	if pos := strings.LastIndex(handlerName, "."); pos != -1 {
+		handlerName = handlerName[pos+1:]
+	}
+	return handlerName
+}
+
 type Handler struct {
 	GetGlobalAppOptions app.AppOptionCreator
 	HandleFunc          func(*Context, http.ResponseWriter, *http.Request)
+	HandlerName         string
 	RequireSession      bool
 	TrustRequester      bool
 	RequireMfa          bool
 	IsStatic            bool
+	// OAuthScope is the scope an OAuth app needs to be granted to use the handler when it was not given
+	// full access to the user's account.
+	OAuthScope string
 
 	cspShaDirective string
 }
@@ -59,6 +79,14 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	now := time.Now()
 	mlog.Debug("request:", mlog.String("method", r.Method), mlog.String("url", r.URL.Path))
 
+	// The response of requests in the API access log is recorded to log the status code it was sent with. The websocket
+	// isn't logged, since the connection is hijacked from the response.
+	var recorder *statusRecorder
+	if !h.IsStatic && r.URL.Path != model.API_URL_SUFFIX+"/websocket" {
+		recorder = &statusRecorder{ResponseWriter: w}
+		w = recorder
+	}
+
 	c := &Context{}
 	c.App = app.New(
 		h.GetGlobalAppOptions()...,
@@ -68,9 +96,12 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	c.App.IpAddress = utils.GetIpAddress(r, c.App.Config().ServiceSettings.TrustedProxyIPHeader)
 	c.App.UserAgent = r.UserAgent()
 	c.App.AcceptLanguage = r.Header.Get("Accept-Language")
+	c.App.SetContext(r.Context())
 	c.Params = ParamsFromRequest(r)
 	c.App.Path = r.URL.Path
 	c.Log = c.App.Log
+	c.auditMethod = r.Method
+	c.auditTarget = model.StringMap(mux.Vars(r))
 
 	subpath, _ := utils.GetSubpathFromConfig(c.App.Config())
 	siteURLHeader := app.GetProtocol(r) + "://" + r.Host + subpath
@@ -114,8 +145,22 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			}
 		} else if !session.IsOAuth && tokenLocation == app.TokenLocationQueryString {
 			c.Err = model.NewAppError("ServeHTTP", "api.context.token_provided.app_error", nil, "token="+token, http.StatusUnauthorized)
+		} else if !session.IsAllowedIpAddress(c.App.IpAddress) {
+			c.Err = model.NewAppError("ServeHTTP", "api.context.ip_address_not_allowed.app_error", nil, "ip_addr="+c.App.IpAddress, http.StatusUnauthorized)
+		} else if err := c.App.CheckNetworkAccess(session.UserId, session.GetUserRoles(), c.App.IpAddress, c.App.GetRequestCountry(r)); err != nil {
+			c.Err = err
 		} else {
 			c.App.Session = *session
+
+			if session.IsImpersonated() {
+				w.Header().Set(model.HEADER_IMPERSONATOR_ID, session.GetImpersonatorId())
+			}
+
+			// Requests made with user access tokens don't go through the handlers tracking activity, but the
+			// last time each token was used is reported to admins.
+			if session.Props[model.SESSION_PROP_TYPE] == model.SESSION_TYPE_USER_ACCESS_TOKEN {
+				c.App.UpdateLastActivityAtIfNeeded(*session)
+			}
 		}
 
 		// Rate limit by UserID
@@ -134,6 +179,10 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		mlog.String("method", r.Method),
 	)
 
+	if c.App.Session.IsImpersonated() {
+		c.Log = c.Log.With(mlog.String("impersonator_id", c.App.Session.GetImpersonatorId()))
+	}
+
 	if c.Err == nil && h.RequireSession {
 		c.SessionRequired()
 	}
@@ -142,10 +191,16 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		c.MfaRequired()
 	}
 
+	if c.Err == nil && h.RequireSession {
+		c.OAuthScopeRequired(h.OAuthScope)
+	}
+
 	if c.Err == nil {
 		h.HandleFunc(c, w, r)
 	}
 
+	c.logAuditResult()
+
 	// Handle errors that have occurred
 	if c.Err != nil {
 		c.Err.Translate(c.App.T)
@@ -186,16 +241,88 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if recorder != nil {
+		h.logApiAccess(c, r, now, recorder.StatusCode())
+
+		if c.App.Session.Props[model.SESSION_PROP_IS_BOT] == model.SESSION_PROP_IS_BOT_VALUE {
+			c.App.RecordBotRequest(c.App.Session.UserId, time.Since(now), c.Err != nil)
+		}
+	}
+
 	if c.App.Metrics != nil {
 		c.App.Metrics.IncrementHttpRequest()
 
 		if r.URL.Path != model.API_URL_SUFFIX+"/websocket" {
 			elapsed := float64(time.Since(now)) / float64(time.Second)
 			c.App.Metrics.ObserveHttpRequestDuration(elapsed)
+
+			statusCode := http.StatusOK
+			if c.Err != nil {
+				statusCode = c.Err.StatusCode
+			}
+			c.App.Metrics.ObserveApiEndpointDuration(h.HandlerName, r.Method, strconv.Itoa(statusCode), elapsed)
 		}
 	}
 }
 
+// logApiAccess records the request in the API access log, along with the user access token or webhook it was
+// made with so that the load on the server can be traced back to integrations.
+func (h *Handler) logApiAccess(c *Context, r *http.Request, start time.Time, statusCode int) {
+	entry := &model.ApiAccessLogEntry{
+		Timestamp:  model.GetMillis(),
+		RequestId:  c.App.RequestId,
+		Method:     r.Method,
+		Route:      h.HandlerName,
+		Path:       r.URL.Path,
+		StatusCode: statusCode,
+		DurationMs: float64(time.Since(start)) / float64(time.Millisecond),
+		UserId:     c.App.Session.UserId,
+		TokenId:    c.App.Session.Props[model.SESSION_PROP_USER_ACCESS_TOKEN_ID],
+		IpAddress:  c.App.IpAddress,
+		UserAgent:  r.UserAgent(),
+	}
+
+	if IsWebhookCall(c.App, r) {
+		entry.WebhookId = mux.Vars(r)["id"]
+	}
+
+	c.App.LogApiAccess(entry)
+}
+
+// statusRecorder records the status code a response was sent with.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (s *statusRecorder) WriteHeader(statusCode int) {
+	if s.statusCode == 0 {
+		s.statusCode = statusCode
+	}
+	s.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.statusCode == 0 {
+		s.statusCode = http.StatusOK
+	}
+	return s.ResponseWriter.Write(b)
+}
+
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// StatusCode returns the status code the response was sent with, which is 200 when the handler didn't write anything.
+func (s *statusRecorder) StatusCode() int {
+	if s.statusCode == 0 {
+		return http.StatusOK
+	}
+	return s.statusCode
+}
+
 // checkCSRFToken performs a CSRF check on the provided request with the given CSRF token. Returns whether or not
 // a CSRF check occurred and whether or not it succeeded.
 func (h *Handler) checkCSRFToken(c *Context, r *http.Request, token string, tokenLocation app.TokenLocation, session *model.Session) (checked bool, passed bool) {
@@ -249,6 +376,7 @@ func (w *Web) ApiHandler(h func(*Context, http.ResponseWriter, *http.Request)) h
 	handler := &Handler{
 		GetGlobalAppOptions: w.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         GetHandlerName(h),
 		RequireSession:      false,
 		TrustRequester:      false,
 		RequireMfa:          false,
@@ -267,6 +395,7 @@ func (w *Web) ApiHandlerTrustRequester(h func(*Context, http.ResponseWriter, *ht
 	handler := &Handler{
 		GetGlobalAppOptions: w.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         GetHandlerName(h),
 		RequireSession:      false,
 		TrustRequester:      true,
 		RequireMfa:          false,
@@ -284,6 +413,7 @@ func (w *Web) ApiSessionRequired(h func(*Context, http.ResponseWriter, *http.Req
 	handler := &Handler{
 		GetGlobalAppOptions: w.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         GetHandlerName(h),
 		RequireSession:      true,
 		TrustRequester:      false,
 		RequireMfa:          true,
@@ -302,6 +432,7 @@ func (w *Web) apiHandlerTrustRequester(h func(*Context, http.ResponseWriter, *ht
 	handler := &Handler{
 		GetGlobalAppOptions: w.GetGlobalAppOptions,
 		HandleFunc:          h,
+		HandlerName:         GetHandlerName(h),
 		RequireSession:      false,
 		TrustRequester:      true,
 		RequireMfa:          false,
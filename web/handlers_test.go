@@ -55,6 +55,16 @@ func TestHandlerServeHTTPErrors(t *testing.T) {
 	}
 }
 
+func TestGetHandlerName(t *testing.T) {
+	assert.Equal(t, "handlerForHTTPErrors", GetHandlerName(handlerForHTTPErrors))
+
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	web := New(th.Server, th.Server.AppOptions, th.Server.Router)
+	assert.Equal(t, "handlerForHTTPErrors", web.NewHandler(handlerForHTTPErrors).(*Handler).HandlerName)
+}
+
 func handlerForHTTPSecureTransport(c *Context, w http.ResponseWriter, r *http.Request) {
 }
 
@@ -513,3 +523,31 @@ func TestCheckCSRFToken(t *testing.T) {
 		assert.Nil(t, c.Err)
 	})
 }
+
+func TestStatusRecorder(t *testing.T) {
+	t.Run("status code written", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		recorder.WriteHeader(http.StatusCreated)
+		recorder.WriteHeader(http.StatusInternalServerError)
+		recorder.Write([]byte("{}"))
+
+		assert.Equal(t, http.StatusCreated, recorder.StatusCode())
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("body written without a status code", func(t *testing.T) {
+		recorder := &statusRecorder{ResponseWriter: httptest.NewRecorder()}
+		recorder.Write([]byte("{}"))
+		recorder.WriteHeader(http.StatusNotFound)
+
+		assert.Equal(t, http.StatusOK, recorder.StatusCode())
+	})
+
+	t.Run("nothing written", func(t *testing.T) {
+		recorder := &statusRecorder{ResponseWriter: httptest.NewRecorder()}
+
+		assert.Equal(t, http.StatusOK, recorder.StatusCode())
+	})
+}
@@ -57,6 +57,11 @@ func authorizeOAuthApp(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	c.ImpersonationForbidden()
+	if c.Err != nil {
+		return
+	}
+
 	c.LogAudit("attempt")
 
 	redirectUrl, err := c.App.AllowOAuthAppAccessToUser(c.App.Session.UserId, authRequest)
@@ -128,6 +133,12 @@ func authorizeOAuthPage(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if c.App.Session.IsImpersonated() {
+		err := model.NewAppError("authorizeOAuthPage", "api.context.impersonated_session.app_error", nil, "impersonator_id="+c.App.Session.GetImpersonatorId(), http.StatusForbidden)
+		utils.RenderWebAppError(c.App.Config(), w, r, err, c.App.AsymmetricSigningKey())
+		return
+	}
+
 	if !oauthApp.IsValidRedirectURL(authRequest.RedirectUri) {
 		err := model.NewAppError("authorizeOAuthPage", "api.oauth.allow_oauth.redirect_callback.app_error", nil, "", http.StatusBadRequest)
 		utils.RenderWebAppError(c.App.Config(), w, r, err, c.App.AsymmetricSigningKey())
@@ -136,9 +147,9 @@ func authorizeOAuthPage(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	isAuthorized := false
 
-	if _, err := c.App.GetPreferenceByCategoryAndNameForUser(c.App.Session.UserId, model.PREFERENCE_CATEGORY_AUTHORIZED_OAUTH_APP, authRequest.ClientId); err == nil {
-		// when we support scopes we should check if the scopes match
-		isAuthorized = true
+	if pref, err := c.App.GetPreferenceByCategoryAndNameForUser(c.App.Session.UserId, model.PREFERENCE_CATEGORY_AUTHORIZED_OAUTH_APP, authRequest.ClientId); err == nil {
+		// The user has to consent again when the app asks for different scopes than were authorized
+		isAuthorized = model.NormalizeOAuthScope(pref.Value) == model.NormalizeOAuthScope(authRequest.Scope)
 	}
 
 	// Automatically allow if the app is trusted
@@ -34,8 +34,26 @@ type Params struct {
 	PluginId               string
 	CommandId              string
 	HookId                 string
+	DeliveryId             string
 	ReportId               string
 	EmojiId                string
+	MentionAliasId         string
+	UserAttributeFieldId   string
+	PendingPostId          string
+	ContentFilterId        string
+	KeywordRuleId          string
+	RecurringPostId        string
+	AvailabilityId         string
+	CallId                 string
+	ExportConsumerId       string
+	MembershipRuleId       string
+	AnnouncementId         string
+	OnboardingStepId       string
+	PurgeId                string
+	PublicPostLinkId       string
+	PendingEmojiId         string
+	BroadcastId            string
+	TermsOfServiceId       string
 	AppId                  string
 	Email                  string
 	Username               string
@@ -43,10 +61,13 @@ type Params struct {
 	ChannelName            string
 	PreferenceName         string
 	EmojiName              string
+	EmojiAlias             string
+	Hashtag                string
 	Category               string
 	Service                string
 	JobId                  string
 	JobType                string
+	ConfigAuditId          string
 	ActionId               string
 	RoleId                 string
 	RoleName               string
@@ -126,6 +147,10 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.HookId = val
 	}
 
+	if val, ok := props["delivery_id"]; ok {
+		params.DeliveryId = val
+	}
+
 	if val, ok := props["report_id"]; ok {
 		params.ReportId = val
 	}
@@ -134,6 +159,74 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.EmojiId = val
 	}
 
+	if val, ok := props["alias_id"]; ok {
+		params.MentionAliasId = val
+	}
+
+	if val, ok := props["field_id"]; ok {
+		params.UserAttributeFieldId = val
+	}
+
+	if val, ok := props["pending_post_id"]; ok {
+		params.PendingPostId = val
+	}
+
+	if val, ok := props["content_filter_id"]; ok {
+		params.ContentFilterId = val
+	}
+
+	if val, ok := props["keyword_rule_id"]; ok {
+		params.KeywordRuleId = val
+	}
+
+	if val, ok := props["membership_rule_id"]; ok {
+		params.MembershipRuleId = val
+	}
+
+	if val, ok := props["announcement_id"]; ok {
+		params.AnnouncementId = val
+	}
+
+	if val, ok := props["onboarding_step_id"]; ok {
+		params.OnboardingStepId = val
+	}
+
+	if val, ok := props["recurring_post_id"]; ok {
+		params.RecurringPostId = val
+	}
+
+	if val, ok := props["availability_id"]; ok {
+		params.AvailabilityId = val
+	}
+
+	if val, ok := props["call_id"]; ok {
+		params.CallId = val
+	}
+
+	if val, ok := props["consumer_id"]; ok {
+		params.ExportConsumerId = val
+	}
+
+	if val, ok := props["purge_id"]; ok {
+		params.PurgeId = val
+	}
+
+	if val, ok := props["link_id"]; ok {
+		params.PublicPostLinkId = val
+	}
+
+	if val, ok := props["pending_emoji_id"]; ok {
+		params.PendingEmojiId = val
+	}
+
+	if val, ok := props["broadcast_id"]; ok {
+		params.BroadcastId = val
+	}
+
+	if val, ok := props["terms_of_service_id"]; ok {
+		params.TermsOfServiceId = val
+	}
+
 	if val, ok := props["app_id"]; ok {
 		params.AppId = val
 	}
@@ -170,6 +263,14 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.EmojiName = val
 	}
 
+	if val, ok := props["emoji_alias"]; ok {
+		params.EmojiAlias = val
+	}
+
+	if val, ok := props["hashtag"]; ok {
+		params.Hashtag = model.NormalizeHashtag(val)
+	}
+
 	if val, ok := props["job_id"]; ok {
 		params.JobId = val
 	}
@@ -178,6 +279,10 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.JobType = val
 	}
 
+	if val, ok := props["audit_id"]; ok {
+		params.ConfigAuditId = val
+	}
+
 	if val, ok := props["action_id"]; ok {
 		params.ActionId = val
 	}
@@ -85,7 +85,7 @@ func completeSaml(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	action := relayProps["action"]
-	if user, err := samlInterface.DoLogin(encodedXML, relayProps); err != nil {
+	if user, groups, err := samlInterface.DoLogin(encodedXML, relayProps); err != nil {
 		if action == model.OAUTH_ACTION_MOBILE {
 			err.Translate(c.App.T)
 			w.Write([]byte(err.ToJson()))
@@ -101,6 +101,10 @@ func completeSaml(c *Context, w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := c.App.SyncSamlGroups(user, groups); err != nil {
+			mlog.Error("Failed to sync the SAML groups of a user on login", mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+
 		switch action {
 		case model.OAUTH_ACTION_SIGNUP:
 			teamId := relayProps["team_id"]
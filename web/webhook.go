@@ -4,7 +4,9 @@
 package web
 
 import (
+	"bytes"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"strings"
 
@@ -15,6 +17,9 @@ import (
 	"github.com/mattermost/mattermost-server/model"
 )
 
+// The largest payload an incoming webhook accepts, which is the limit net/http puts on form-encoded payloads.
+const MAX_INCOMING_WEBHOOK_PAYLOAD_SIZE = 10 * 1024 * 1024
+
 func (w *Web) InitWebhooks() {
 	w.MainRouter.Handle("/hooks/commands/{id:[A-Za-z0-9]+}", w.NewHandler(commandWebhook)).Methods("POST")
 	w.MainRouter.Handle("/hooks/{id:[A-Za-z0-9]+}", w.NewHandler(incomingWebhook)).Methods("POST")
@@ -57,7 +62,20 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	} else {
-		incomingWebhookPayload, err = decodePayload(r.Body)
+		payload, readErr := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MAX_INCOMING_WEBHOOK_PAYLOAD_SIZE))
+		if readErr != nil {
+			c.Err = model.NewAppError("incomingWebhook", "api.webhook.incoming.error", nil, readErr.Error(), http.StatusBadRequest)
+			if len(payload) == MAX_INCOMING_WEBHOOK_PAYLOAD_SIZE {
+				c.Err.StatusCode = http.StatusRequestEntityTooLarge
+			}
+			return
+		}
+
+		if hook, hookErr := c.App.GetIncomingWebhook(id); hookErr == nil && len(hook.PayloadTemplate) > 0 {
+			incomingWebhookPayload, err = c.App.RenderIncomingWebhookPayload(hook, payload)
+		} else {
+			incomingWebhookPayload, err = decodePayload(bytes.NewReader(payload))
+		}
 		if err != nil {
 			c.Err = err
 			return
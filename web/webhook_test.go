@@ -223,6 +223,28 @@ func TestIncomingWebhook(t *testing.T) {
 		assert.True(t, resp.StatusCode == http.StatusForbidden)
 	})
 
+	t.Run("PayloadTemplateWebhook", func(t *testing.T) {
+		hook, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{
+			ChannelId:       th.BasicChannel.Id,
+			PayloadTemplate: "Build {{ .build.number }} {{ .build.status }}",
+		})
+		require.Nil(t, err)
+
+		resp, err2 := http.Post(ApiClient.Url+"/hooks/"+hook.Id, "application/json", strings.NewReader(`{"build": {"number": 42, "status": "passed"}}`))
+		require.Nil(t, err2)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		posts, err := th.App.GetPostsPage(th.BasicChannel.Id, 0, 1)
+		require.Nil(t, err)
+		require.Len(t, posts.Order, 1)
+		assert.Equal(t, "Build 42 passed", posts.Posts[posts.Order[0]].Message)
+
+		tooLarge := `{"build": {"number": 43, "status": "` + strings.Repeat("x", MAX_INCOMING_WEBHOOK_PAYLOAD_SIZE) + `"}}`
+		resp, err2 = http.Post(ApiClient.Url+"/hooks/"+hook.Id, "application/json", strings.NewReader(tooLarge))
+		require.Nil(t, err2)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
 	t.Run("DisableWebhooks", func(t *testing.T) {
 		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = false })
 		resp, err := http.Post(url, "application/json", strings.NewReader("{\"text\":\"this is a test\"}"))
@@ -21,6 +21,8 @@ func Init(a *app.App, router *app.WebSocketRouter) {
 	api.InitUser()
 	api.InitSystem()
 	api.InitStatus()
+	api.InitCommand()
+	api.InitWebrtc()
 
 	a.HubStart()
 }
@@ -0,0 +1,67 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package wsapi
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitCommand() {
+	api.Router.Handle("execute_command", api.ApiWebSocketHandler(api.executeCommand))
+}
+
+// executeCommand runs a slash command without holding up the connection, since commands may take a while and stream
+// their progress to the user in the meantime. Its outcome is sent to the user as a command_response event carrying
+// the seq of the request.
+func (api *API) executeCommand(req *model.WebSocketRequest) (map[string]interface{}, *model.AppError) {
+	var ok bool
+	var command string
+	if command, ok = req.Data["command"].(string); !ok {
+		return nil, NewInvalidWebSocketParamError(req.Action, "command")
+	}
+
+	var channelId string
+	if channelId, ok = req.Data["channel_id"].(string); !ok {
+		return nil, NewInvalidWebSocketParamError(req.Action, "channel_id")
+	}
+
+	teamId, _ := req.Data["team_id"].(string)
+	rootId, _ := req.Data["root_id"].(string)
+	parentId, _ := req.Data["parent_id"].(string)
+
+	args := &model.CommandArgs{
+		Command:   command,
+		ChannelId: channelId,
+		TeamId:    teamId,
+		RootId:    rootId,
+		ParentId:  parentId,
+		UserId:    req.Session.UserId,
+		T:         req.T,
+		Session:   req.Session,
+		SiteURL:   api.App.GetSiteURL(),
+	}
+
+	if err := api.App.CheckCommandArgs(req.Session, args); err != nil {
+		return nil, err
+	}
+
+	seq := req.Seq
+	api.App.Srv.Go(func() {
+		event := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_COMMAND_RESPONSE, "", "", args.UserId, nil)
+		event.Add("seq_reply", seq)
+
+		response, err := api.App.ExecuteCommand(args)
+		if err != nil {
+			err.Translate(args.T)
+			err.DetailedError = ""
+			event.Add("error", err.ToJson())
+		} else {
+			event.Add("response", response.ToJson())
+		}
+
+		api.App.Publish(event)
+	})
+
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package wsapi
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitWebrtc() {
+	api.Router.Handle("webrtc_join", api.ApiWebSocketHandler(api.webrtcJoin))
+	api.Router.Handle("webrtc_leave", api.ApiWebSocketHandler(api.webrtcLeave))
+	api.Router.Handle("webrtc_signal", api.ApiWebSocketHandler(api.webrtcSignal))
+}
+
+func (api *API) webrtcJoin(req *model.WebSocketRequest) (map[string]interface{}, *model.AppError) {
+	channelId, ok := req.Data["channel_id"].(string)
+	if !ok || !model.IsValidId(channelId) {
+		return nil, NewInvalidWebSocketParamError(req.Action, "channel_id")
+	}
+
+	if !api.App.SessionHasPermissionToChannel(req.Session, channelId, model.PERMISSION_READ_CHANNEL) {
+		return nil, api.App.MakePermissionError(model.PERMISSION_READ_CHANNEL)
+	}
+
+	userIds, err := api.App.JoinWebrtcRoom(channelId, req.Session.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"user_ids": userIds}, nil
+}
+
+func (api *API) webrtcLeave(req *model.WebSocketRequest) (map[string]interface{}, *model.AppError) {
+	channelId, ok := req.Data["channel_id"].(string)
+	if !ok || !model.IsValidId(channelId) {
+		return nil, NewInvalidWebSocketParamError(req.Action, "channel_id")
+	}
+
+	api.App.LeaveWebrtcRoom(channelId, req.Session.UserId)
+
+	return nil, nil
+}
+
+func (api *API) webrtcSignal(req *model.WebSocketRequest) (map[string]interface{}, *model.AppError) {
+	signal := &model.WebrtcSignal{UserId: req.Session.UserId}
+
+	var ok bool
+	if signal.ChannelId, ok = req.Data["channel_id"].(string); !ok {
+		return nil, NewInvalidWebSocketParamError(req.Action, "channel_id")
+	}
+
+	if signal.Type, ok = req.Data["type"].(string); !ok {
+		return nil, NewInvalidWebSocketParamError(req.Action, "type")
+	}
+
+	if signal.Data, ok = req.Data["data"].(string); !ok {
+		return nil, NewInvalidWebSocketParamError(req.Action, "data")
+	}
+
+	signal.ToUserId, _ = req.Data["to_user_id"].(string)
+	signal.ToPluginId, _ = req.Data["to_plugin_id"].(string)
+
+	// Only users who have joined the room may signal in it, which relaying checks
+	if err := api.App.RelayWebrtcSignal(signal); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}